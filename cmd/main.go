@@ -6,6 +6,9 @@ import (
 	"os"
 
 	"beads-lite/internal/cmd"
+
+	// Registers the "postgres" database/sql driver for storage.backend=postgres.
+	_ "github.com/lib/pq"
 )
 
 var (
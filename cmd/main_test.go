@@ -364,3 +364,30 @@ func TestWorkflow(t *testing.T) {
 		t.Error("show should fail for deleted issue")
 	}
 }
+
+func TestPostgresBackend(t *testing.T) {
+	dir := t.TempDir()
+	runBd(t, dir, "init")
+	if _, stderr, exitCode := runBd(t, dir, "config", "set", "storage.backend", "postgres"); exitCode != 0 {
+		t.Fatalf("config set failed: %s", stderr)
+	}
+
+	// Against a real database (the postgres CI job), bd works end to end.
+	if dsn := os.Getenv("BD_TEST_POSTGRES_DSN"); dsn != "" {
+		t.Setenv("BD_POSTGRES_DSN", dsn)
+		if stdout, stderr, exitCode := runBd(t, dir, "create", "Stored in postgres"); exitCode != 0 {
+			t.Fatalf("create failed (exit %d): stdout=%s stderr=%s", exitCode, stdout, stderr)
+		}
+		if stdout, _, _ := runBd(t, dir, "list"); !strings.Contains(stdout, "Stored in postgres") {
+			t.Errorf("list = %s", stdout)
+		}
+		return
+	}
+
+	// Otherwise the binary must at least have a driver to try connecting.
+	t.Setenv("BD_POSTGRES_DSN", "postgres://bd@127.0.0.1:1/bd?sslmode=disable&connect_timeout=2")
+	_, stderr, exitCode := runBd(t, dir, "list")
+	if exitCode == 0 || strings.Contains(stderr, "driver") || !strings.Contains(stderr, "connect") {
+		t.Errorf("list against an unreachable database: exit %d, stderr %s", exitCode, stderr)
+	}
+}
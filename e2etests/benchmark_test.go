@@ -351,64 +351,6 @@ func runMultiRepoPhases(t *testing.T, r *Runner, label string) []phaseResult {
 	return results
 }
 
-// RunInDir executes a bd command in a specific directory without setting BEADS_DIR.
-// This lets bd discover the .beads directory by walking up from cwd.
-func (r *Runner) RunInDir(dir string, args ...string) RunResult {
-	allArgs := append(r.ExtraArgs, args...)
-	cmd := exec.Command(r.BdCmd, allArgs...)
-	cmd.Dir = dir
-	cmd.Env = os.Environ()
-	cmd.Env = append(cmd.Env, r.ExtraEnv...)
-
-	var stdout, stderr strings.Builder
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	err := cmd.Run()
-	exitCode := 0
-	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			exitCode = exitErr.ExitCode()
-		} else {
-			exitCode = -1
-		}
-	}
-
-	return RunResult{
-		Stdout:   stdout.String(),
-		Stderr:   stderr.String(),
-		ExitCode: exitCode,
-	}
-}
-
-// RunWithBeadsDir executes a bd command with BEADS_DIR set to the specified path.
-func (r *Runner) RunWithBeadsDir(beadsDir string, args ...string) RunResult {
-	allArgs := append(r.ExtraArgs, args...)
-	cmd := exec.Command(r.BdCmd, allArgs...)
-	cmd.Env = append(os.Environ(), "BEADS_DIR="+beadsDir)
-	cmd.Env = append(cmd.Env, r.ExtraEnv...)
-
-	var stdout, stderr strings.Builder
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	err := cmd.Run()
-	exitCode := 0
-	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			exitCode = exitErr.ExitCode()
-		} else {
-			exitCode = -1
-		}
-	}
-
-	return RunResult{
-		Stdout:   stdout.String(),
-		Stderr:   stderr.String(),
-		ExitCode: exitCode,
-	}
-}
-
 func printSingleResults(t *testing.T, results []phaseResult) {
 	t.Helper()
 
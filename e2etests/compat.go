@@ -0,0 +1,18 @@
+// Package e2etests holds end-to-end tests that drive the bd binary. The
+// harness itself lives in the public testkit package; these aliases keep
+// the existing tests compiling without import changes.
+package e2etests
+
+import "beads-lite/testkit"
+
+// Runner executes bd commands against a sandbox directory.
+type Runner = testkit.Runner
+
+// RunResult holds the output of a command execution.
+type RunResult = testkit.RunResult
+
+// ExtractID forwards to the testkit package.
+func ExtractID(j []byte) string { return testkit.ExtractID(j) }
+
+// ExtractCommentID forwards to the testkit package.
+func ExtractCommentID(j []byte) string { return testkit.ExtractCommentID(j) }
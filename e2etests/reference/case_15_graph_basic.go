@@ -62,7 +62,7 @@ func caseGraphBasic(r *Runner, n *Normalizer, sandbox string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	section(&out, "graph parent text", n.normalizeText(result.Stdout))
+	section(&out, "graph parent text", n.NormalizeText(result.Stdout))
 
 	// Graph with parent ID — JSON output.
 	result, err = mustRun(r, sandbox, "graph", parentID, "--json")
@@ -76,7 +76,7 @@ func caseGraphBasic(r *Runner, n *Normalizer, sandbox string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	section(&out, "graph global text", n.normalizeText(result.Stdout))
+	section(&out, "graph global text", n.NormalizeText(result.Stdout))
 
 	return out.String(), nil
 }
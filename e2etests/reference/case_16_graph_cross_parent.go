@@ -82,7 +82,7 @@ func caseGraphCrossParent(r *Runner, n *Normalizer, sandbox string) (string, err
 	if err != nil {
 		return "", err
 	}
-	section(&out, "graph cross-parent text", n.normalizeText(result.Stdout))
+	section(&out, "graph cross-parent text", n.NormalizeText(result.Stdout))
 
 	// Graph JSON with waves — full structured output including wave grouping.
 	// Waves are tested via JSON (not text) to avoid non-deterministic ID ordering
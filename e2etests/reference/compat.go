@@ -1,14 +1,18 @@
 package reference
 
-import "beads-lite/e2etests"
+import "beads-lite/testkit"
 
 // Type aliases so existing case files and helpers continue to compile
 // without import changes.
-type Runner = e2etests.Runner
-type RunResult = e2etests.RunResult
+type Runner = testkit.Runner
+type RunResult = testkit.RunResult
+type Normalizer = testkit.Normalizer
 
-// ExtractID forwards to the parent e2etests package.
-func ExtractID(j []byte) string { return e2etests.ExtractID(j) }
+// NewNormalizer forwards to the testkit package.
+func NewNormalizer() *Normalizer { return testkit.NewNormalizer() }
 
-// ExtractCommentID forwards to the parent e2etests package.
-func ExtractCommentID(j []byte) string { return e2etests.ExtractCommentID(j) }
+// ExtractID forwards to the testkit package.
+func ExtractID(j []byte) string { return testkit.ExtractID(j) }
+
+// ExtractCommentID forwards to the testkit package.
+func ExtractCommentID(j []byte) string { return testkit.ExtractCommentID(j) }
@@ -3,6 +3,8 @@ package reference
 import (
 	"fmt"
 	"strings"
+
+	"beads-lite/testkit"
 )
 
 // TestCase defines a named e2e test scenario.
@@ -45,20 +47,12 @@ var testCases = []TestCase{
 
 // section writes a section header and normalized JSON content to the builder.
 func section(out *strings.Builder, label string, content string) {
-	out.WriteString("=== ")
-	out.WriteString(label)
-	out.WriteString(" ===\n")
-	out.WriteString(content)
-	out.WriteString("\n\n")
+	testkit.WriteSection(out, label, content)
 }
 
 // sectionExitCode writes a section with just an exit code.
 func sectionExitCode(out *strings.Builder, label string, exitCode int) {
-	out.WriteString("=== ")
-	out.WriteString(label)
-	out.WriteString(" ===\n")
-	out.WriteString(fmt.Sprintf("EXIT_CODE: %d", exitCode))
-	out.WriteString("\n\n")
+	testkit.WriteExitCodeSection(out, label, exitCode)
 }
 
 // mustRun runs a command and returns the result, failing the test case on error.
@@ -73,12 +73,12 @@ func GetAgent(ctx context.Context, store kvstorage.KVStore, agentID string) (Age
 // SetState upserts the agent's state. If the agent does not exist it is created
 // with the given state and LastActivity set to now. If it does exist, State and
 // LastActivity are updated and the record is overwritten.
-func SetState(ctx context.Context, store kvstorage.KVStore, agentID, state string) error {
+func SetState(ctx context.Context, store kvstorage.KVStore, agentID, state string, now time.Time) error {
 	if err := ValidateState(state); err != nil {
 		return err
 	}
 
-	now := time.Now().UTC()
+	now = now.UTC()
 
 	a, err := GetAgent(ctx, store, agentID)
 	if err != nil {
@@ -104,7 +104,7 @@ func SetState(ctx context.Context, store kvstorage.KVStore, agentID, state strin
 
 // Heartbeat updates the agent's LastActivity to now. The agent must already
 // exist; returns an error if not found.
-func Heartbeat(ctx context.Context, store kvstorage.KVStore, agentID string) error {
+func Heartbeat(ctx context.Context, store kvstorage.KVStore, agentID string, now time.Time) error {
 	a, err := GetAgent(ctx, store, agentID)
 	if err != nil {
 		if errors.Is(err, kvstorage.ErrKeyNotFound) {
@@ -113,7 +113,7 @@ func Heartbeat(ctx context.Context, store kvstorage.KVStore, agentID string) err
 		return err
 	}
 
-	a.LastActivity = time.Now().UTC()
+	a.LastActivity = now.UTC()
 
 	data, err := json.Marshal(a)
 	if err != nil {
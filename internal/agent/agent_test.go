@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"beads-lite/internal/kvstorage"
 	kvfs "beads-lite/internal/kvstorage/filesystem"
@@ -37,7 +38,7 @@ func TestSetStateCreatesAgent(t *testing.T) {
 	store := newTestStore(t)
 	ctx := context.Background()
 
-	if err := SetState(ctx, store, "agent-1", StateRunning); err != nil {
+	if err := SetState(ctx, store, "agent-1", StateRunning, time.Now()); err != nil {
 		t.Fatalf("SetState failed: %v", err)
 	}
 
@@ -57,11 +58,11 @@ func TestSetStateUpdatesExisting(t *testing.T) {
 	store := newTestStore(t)
 	ctx := context.Background()
 
-	if err := SetState(ctx, store, "agent-1", StateRunning); err != nil {
+	if err := SetState(ctx, store, "agent-1", StateRunning, time.Now()); err != nil {
 		t.Fatalf("first SetState failed: %v", err)
 	}
 
-	if err := SetState(ctx, store, "agent-1", StateWorking); err != nil {
+	if err := SetState(ctx, store, "agent-1", StateWorking, time.Now()); err != nil {
 		t.Fatalf("second SetState failed: %v", err)
 	}
 
@@ -78,7 +79,7 @@ func TestSetStateInvalidState(t *testing.T) {
 	store := newTestStore(t)
 	ctx := context.Background()
 
-	err := SetState(ctx, store, "agent-1", "bogus")
+	err := SetState(ctx, store, "agent-1", "bogus", time.Now())
 	if err == nil {
 		t.Fatal("expected error for invalid state")
 	}
@@ -100,14 +101,13 @@ func TestHeartbeat(t *testing.T) {
 	ctx := context.Background()
 
 	// Create agent first
-	if err := SetState(ctx, store, "agent-1", StateRunning); err != nil {
+	start := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+	if err := SetState(ctx, store, "agent-1", StateRunning, start); err != nil {
 		t.Fatalf("SetState failed: %v", err)
 	}
 
-	a1, _ := GetAgent(ctx, store, "agent-1")
-	firstActivity := a1.LastActivity
-
-	if err := Heartbeat(ctx, store, "agent-1"); err != nil {
+	beat := start.Add(5 * time.Minute)
+	if err := Heartbeat(ctx, store, "agent-1", beat); err != nil {
 		t.Fatalf("Heartbeat failed: %v", err)
 	}
 
@@ -118,8 +118,8 @@ func TestHeartbeat(t *testing.T) {
 	if a2.State != StateRunning {
 		t.Errorf("heartbeat should not change state, got %q", a2.State)
 	}
-	if a2.LastActivity.Before(firstActivity) {
-		t.Error("expected LastActivity to be updated")
+	if !a2.LastActivity.Equal(beat) {
+		t.Errorf("LastActivity = %v, want %v", a2.LastActivity, beat)
 	}
 }
 
@@ -127,7 +127,7 @@ func TestHeartbeatNotFound(t *testing.T) {
 	store := newTestStore(t)
 	ctx := context.Background()
 
-	err := Heartbeat(ctx, store, "agent-1")
+	err := Heartbeat(ctx, store, "agent-1", time.Now())
 	if err == nil {
 		t.Fatal("expected error for non-existent agent")
 	}
@@ -147,7 +147,7 @@ func TestSetStatePreservesFields(t *testing.T) {
 	}
 
 	// SetState should preserve RoleType and Rig (read-modify-write)
-	if err := SetState(ctx, store, "agent-1", StateDone); err != nil {
+	if err := SetState(ctx, store, "agent-1", StateDone, time.Now()); err != nil {
 		t.Fatalf("SetState failed: %v", err)
 	}
 
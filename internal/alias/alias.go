@@ -0,0 +1,210 @@
+// Package alias provides helpers for managing vanity issue IDs in a KV table ("aliases").
+package alias
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"beads-lite/internal/idgen"
+	"beads-lite/internal/kvstorage"
+)
+
+// Alias is a stable, human-chosen name for an issue, such as
+// "bd-login-rewrite", accepted wherever the issue's ID is.
+type Alias struct {
+	Name      string    `json:"name"`
+	IssueID   string    `json:"issue_id"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by,omitempty"`
+}
+
+// slugPattern matches the part of an alias after the issue prefix, such as
+// "login-rewrite". Aliases are used as KV keys and must not look like
+// hierarchical IDs, so they cannot contain separators or dots.
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// generatedPattern matches IDs bd generates: a prefix, the addition used by
+// bd mol pour or bd mol wisp if any, a base36 suffix of any adaptive length
+// and child numbers. An alias of this shape could be taken by a later
+// issue once the adaptive length grows or shrinks to match it.
+var generatedPattern = regexp.MustCompile(fmt.Sprintf(`^[^-]+-((mol|wisp)-)?[0-9a-z]{%d,%d}(\.[0-9]+)*$`, idgen.MinLength, idgen.MaxLength))
+
+// LooksGenerated reports whether id has the shape of a generated issue ID,
+// and so can never be an alias.
+func LooksGenerated(id string) bool {
+	return generatedPattern.MatchString(id)
+}
+
+// Name returns the alias for slug on an issue with the given prefix (e.g.
+// "bd-"), accepting a slug that already starts with the prefix.
+func Name(prefix, slug string) string {
+	if strings.HasPrefix(slug, prefix) {
+		return slug
+	}
+	return prefix + slug
+}
+
+// ValidateName returns an error if name, with the given issue prefix,
+// cannot be used as an alias.
+func ValidateName(prefix, name string) error {
+	slug := name[min(len(prefix), len(name)):]
+	if !slugPattern.MatchString(slug) {
+		return fmt.Errorf("invalid alias %q: use lowercase letters, digits and '-' after %q", name, prefix)
+	}
+	if LooksGenerated(name) {
+		return fmt.Errorf("invalid alias %q: it has the shape of a generated ID and could be taken by a new issue; use a hyphenated name such as %s", name, Name(prefix, "my-"+slug))
+	}
+	return nil
+}
+
+// Get retrieves the alias with the given name.
+// Returns kvstorage.ErrKeyNotFound if the alias does not exist.
+func Get(ctx context.Context, store kvstorage.KVStore, name string) (Alias, error) {
+	data, err := store.Get(ctx, name)
+	if err != nil {
+		if errors.Is(err, kvstorage.ErrKeyNotFound) {
+			return Alias{}, kvstorage.ErrKeyNotFound
+		}
+		return Alias{}, fmt.Errorf("getting alias %s: %w", name, err)
+	}
+	var a Alias
+	if err := json.Unmarshal(data, &a); err != nil {
+		return Alias{}, fmt.Errorf("decoding alias %s: %w", name, err)
+	}
+	return a, nil
+}
+
+// List returns every alias, ordered by name.
+func List(ctx context.Context, store kvstorage.KVStore) ([]Alias, error) {
+	names, err := store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing aliases: %w", err)
+	}
+	aliases := make([]Alias, 0, len(names))
+	for _, name := range names {
+		a, err := Get(ctx, store, name)
+		if err != nil {
+			return nil, err
+		}
+		aliases = append(aliases, a)
+	}
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].Name < aliases[j].Name })
+	return aliases, nil
+}
+
+// ForIssue returns the alias of the issue with the given ID, or
+// kvstorage.ErrKeyNotFound if it has none.
+func ForIssue(ctx context.Context, store kvstorage.KVStore, issueID string) (Alias, error) {
+	aliases, err := List(ctx, store)
+	if err != nil {
+		return Alias{}, err
+	}
+	for _, a := range aliases {
+		if a.IssueID == issueID {
+			return a, nil
+		}
+	}
+	return Alias{}, kvstorage.ErrKeyNotFound
+}
+
+// Set stores a as the alias of its issue, replacing the issue's previous
+// alias if it had one, which is returned. It fails if the name is taken
+// by another issue's alias.
+func Set(ctx context.Context, store kvstorage.KVStore, a Alias) (previous *Alias, err error) {
+	existing, err := Get(ctx, store, a.Name)
+	switch {
+	case err == nil && existing.IssueID != a.IssueID:
+		return nil, fmt.Errorf("alias %s already points to %s", a.Name, existing.IssueID)
+	case err == nil:
+		return nil, nil
+	case !errors.Is(err, kvstorage.ErrKeyNotFound):
+		return nil, err
+	}
+	old, err := ForIssue(ctx, store, a.IssueID)
+	if err != nil && !errors.Is(err, kvstorage.ErrKeyNotFound) {
+		return nil, err
+	}
+	a.CreatedAt = a.CreatedAt.UTC()
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, fmt.Errorf("encoding alias %s: %w", a.Name, err)
+	}
+	if err := store.Set(ctx, a.Name, data, kvstorage.SetOptions{FailIfExists: true}); err != nil {
+		if errors.Is(err, kvstorage.ErrAlreadyExists) {
+			return nil, fmt.Errorf("alias %s already exists", a.Name)
+		}
+		return nil, fmt.Errorf("storing alias %s: %w", a.Name, err)
+	}
+	if old.Name == "" {
+		return nil, nil
+	}
+	if err := store.Delete(ctx, old.Name); err != nil && !errors.Is(err, kvstorage.ErrKeyNotFound) {
+		return nil, fmt.Errorf("removing alias %s: %w", old.Name, err)
+	}
+	return &old, nil
+}
+
+// Remove deletes the alias with the given name and returns it.
+func Remove(ctx context.Context, store kvstorage.KVStore, name string) (Alias, error) {
+	a, err := Get(ctx, store, name)
+	if err != nil {
+		if errors.Is(err, kvstorage.ErrKeyNotFound) {
+			return Alias{}, fmt.Errorf("alias %s not found", name)
+		}
+		return Alias{}, err
+	}
+	if err := store.Delete(ctx, name); err != nil {
+		return Alias{}, fmt.Errorf("removing alias %s: %w", name, err)
+	}
+	return a, nil
+}
+
+// Lookup finds aliases in a store for issueservice.IssueStore.SetAliases.
+type Lookup struct {
+	store kvstorage.KVStore
+}
+
+// NewLookup returns a Lookup over the aliases in store.
+func NewLookup(store kvstorage.KVStore) *Lookup {
+	return &Lookup{store: store}
+}
+
+// Resolve returns the issue ID the alias name stands for. IDs of the
+// generated shape are never aliases, so they are answered without reading
+// the table.
+func (l *Lookup) Resolve(ctx context.Context, name string) (string, bool) {
+	if LooksGenerated(name) {
+		return "", false
+	}
+	a, err := Get(ctx, l.store, name)
+	if err != nil {
+		return "", false
+	}
+	return a.IssueID, true
+}
+
+// Match returns the aliases whose slug, the part after the issue prefix,
+// is slug, such as bd-login-rewrite for "login-rewrite", mapped to the
+// issue IDs they stand for.
+func (l *Lookup) Match(ctx context.Context, slug string) map[string]string {
+	if !slugPattern.MatchString(slug) {
+		return nil
+	}
+	aliases, err := List(ctx, l.store)
+	if err != nil {
+		return nil
+	}
+	matches := make(map[string]string)
+	for _, a := range aliases {
+		if prefix, rest, ok := strings.Cut(a.Name, "-"); ok && prefix != "" && rest == slug {
+			matches[a.Name] = a.IssueID
+		}
+	}
+	return matches
+}
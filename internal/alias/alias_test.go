@@ -0,0 +1,112 @@
+package alias
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"beads-lite/internal/kvstorage"
+	kvfs "beads-lite/internal/kvstorage/filesystem"
+)
+
+func newTestStore(t *testing.T) *kvfs.Store {
+	t.Helper()
+	store, err := kvfs.New(t.TempDir(), "aliases")
+	if err != nil {
+		t.Fatalf("failed to create kv store: %v", err)
+	}
+	if err := store.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init kv store: %v", err)
+	}
+	return store
+}
+
+func TestValidateName(t *testing.T) {
+	for _, slug := range []string{"login-rewrite", "q4-launch", "mol-login-2", "ab", "authentication"} {
+		if err := ValidateName("bd-", Name("bd-", slug)); err != nil {
+			t.Errorf("ValidateName(%q) = %v", slug, err)
+		}
+	}
+	// Slugs a generated ID could take at any adaptive length, with or
+	// without a molecule prefix, are rejected along with malformed ones.
+	for _, slug := range []string{"abc", "a1b2", "login", "mol-a1b2", "wisp-xyz9", "", "Login", "login_rewrite", "login.1", "-login", "login-"} {
+		if err := ValidateName("bd-", Name("bd-", slug)); err == nil {
+			t.Errorf("ValidateName(%q) should fail", slug)
+		}
+	}
+}
+
+func TestName(t *testing.T) {
+	if got := Name("bd-", "login-rewrite"); got != "bd-login-rewrite" {
+		t.Errorf("Name = %q", got)
+	}
+	if got := Name("bd-", "bd-login-rewrite"); got != "bd-login-rewrite" {
+		t.Errorf("Name with prefix = %q", got)
+	}
+}
+
+func TestSetRenameRemove(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	previous, err := Set(ctx, store, Alias{Name: "bd-login-rewrite", IssueID: "bd-a1b2", CreatedAt: now})
+	if err != nil || previous != nil {
+		t.Fatalf("Set = %v, %v", previous, err)
+	}
+	if _, err := Set(ctx, store, Alias{Name: "bd-login-rewrite", IssueID: "bd-c3d4"}); err == nil {
+		t.Error("taking another issue's alias should fail")
+	}
+	if previous, err := Set(ctx, store, Alias{Name: "bd-login-rewrite", IssueID: "bd-a1b2"}); err != nil || previous != nil {
+		t.Errorf("setting the same alias again = %v, %v", previous, err)
+	}
+
+	previous, err = Set(ctx, store, Alias{Name: "bd-auth-rewrite", IssueID: "bd-a1b2", CreatedAt: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if previous == nil || previous.Name != "bd-login-rewrite" {
+		t.Fatalf("renaming should return the old alias, got %+v", previous)
+	}
+	if _, err := Get(ctx, store, "bd-login-rewrite"); !errors.Is(err, kvstorage.ErrKeyNotFound) {
+		t.Errorf("old alias should be gone, got %v", err)
+	}
+	a, err := ForIssue(ctx, store, "bd-a1b2")
+	if err != nil || a.Name != "bd-auth-rewrite" {
+		t.Errorf("ForIssue = %+v, %v", a, err)
+	}
+
+	lookup := NewLookup(store)
+	if id, ok := lookup.Resolve(ctx, "bd-auth-rewrite"); !ok || id != "bd-a1b2" {
+		t.Errorf("resolve alias = %q, %v", id, ok)
+	}
+	if _, ok := lookup.Resolve(ctx, "bd-a1b2"); ok {
+		t.Error("a generated ID should not resolve as an alias")
+	}
+	if _, err := Set(ctx, store, Alias{Name: "ops-auth-rewrite", IssueID: "ops-e5f6"}); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"bd-auth-rewrite": "bd-a1b2", "ops-auth-rewrite": "ops-e5f6"}
+	if got := lookup.Match(ctx, "auth-rewrite"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Match = %v, want %v", got, want)
+	}
+	if got := lookup.Match(ctx, "rewrite"); len(got) != 0 {
+		t.Errorf("Match should compare whole slugs, got %v", got)
+	}
+	if _, err := Remove(ctx, store, "ops-auth-rewrite"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Remove(ctx, store, "bd-auth-rewrite"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Remove(ctx, store, "bd-auth-rewrite"); err == nil {
+		t.Error("removing a missing alias should fail")
+	}
+	list, err := List(ctx, store)
+	if err != nil || len(list) != 0 {
+		t.Errorf("List = %v, %v", list, err)
+	}
+}
@@ -0,0 +1,170 @@
+// Package apitoken provides helpers for managing bd serve API tokens in a KV table ("tokens").
+package apitoken
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"beads-lite/internal/kvstorage"
+)
+
+// Scope is what a token may do.
+type Scope string
+
+// Scopes: read tokens may only read, write tokens may also modify issues.
+const (
+	ScopeRead  Scope = "read"
+	ScopeWrite Scope = "write"
+)
+
+// ParseScope parses a scope name.
+func ParseScope(s string) (Scope, error) {
+	switch Scope(s) {
+	case ScopeRead, ScopeWrite:
+		return Scope(s), nil
+	}
+	return "", fmt.Errorf("invalid scope %q (want read or write)", s)
+}
+
+// AllowsWrite reports whether the scope permits modifying requests.
+func (s Scope) AllowsWrite() bool {
+	return s == ScopeWrite
+}
+
+// ErrInvalid is returned by Authenticate for a secret that matches no token.
+var ErrInvalid = errors.New("invalid API token")
+
+// secretPrefix starts every secret, so leaked ones are easy to recognize.
+const secretPrefix = "bdt_"
+
+// Token is an API token. Only a hash of its secret is stored; the secret
+// itself is shown once, when the token is created.
+type Token struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name,omitempty"`
+	Scope     Scope     `json:"scope"`
+	Hash      string    `json:"hash"` // hex SHA-256 of the secret
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by,omitempty"`
+}
+
+// New returns a new token and its secret, of the form bdt_<id>_<random>.
+func New(name string, scope Scope, createdBy string, now time.Time) (Token, string) {
+	id := randomHex(4)
+	secret := secretPrefix + id + "_" + randomHex(16)
+	return Token{
+		ID:        id,
+		Name:      name,
+		Scope:     scope,
+		Hash:      hash(secret),
+		CreatedAt: now.UTC(),
+		CreatedBy: createdBy,
+	}, secret
+}
+
+// Authenticate returns the token whose secret is secret, or ErrInvalid.
+func Authenticate(ctx context.Context, store kvstorage.KVStore, secret string) (Token, error) {
+	rest, ok := strings.CutPrefix(secret, secretPrefix)
+	if !ok {
+		return Token{}, ErrInvalid
+	}
+	id, _, ok := strings.Cut(rest, "_")
+	if !ok || id == "" {
+		return Token{}, ErrInvalid
+	}
+	t, err := Get(ctx, store, id)
+	if errors.Is(err, kvstorage.ErrKeyNotFound) {
+		return Token{}, ErrInvalid
+	}
+	if err != nil {
+		return Token{}, err
+	}
+	if subtle.ConstantTimeCompare([]byte(t.Hash), []byte(hash(secret))) != 1 {
+		return Token{}, ErrInvalid
+	}
+	return t, nil
+}
+
+// Get retrieves the token with the given ID.
+// Returns kvstorage.ErrKeyNotFound if there is no such token.
+func Get(ctx context.Context, store kvstorage.KVStore, id string) (Token, error) {
+	data, err := store.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, kvstorage.ErrKeyNotFound) {
+			return Token{}, kvstorage.ErrKeyNotFound
+		}
+		return Token{}, fmt.Errorf("getting token %s: %w", id, err)
+	}
+	var t Token
+	if err := json.Unmarshal(data, &t); err != nil {
+		return Token{}, fmt.Errorf("decoding token %s: %w", id, err)
+	}
+	return t, nil
+}
+
+// List returns every token, oldest first.
+func List(ctx context.Context, store kvstorage.KVStore) ([]Token, error) {
+	ids, err := store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing tokens: %w", err)
+	}
+	tokens := make([]Token, 0, len(ids))
+	for _, id := range ids {
+		t, err := Get(ctx, store, id)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		if !tokens[i].CreatedAt.Equal(tokens[j].CreatedAt) {
+			return tokens[i].CreatedAt.Before(tokens[j].CreatedAt)
+		}
+		return tokens[i].ID < tokens[j].ID
+	})
+	return tokens, nil
+}
+
+// Set stores t, failing if a token with the same ID exists.
+func Set(ctx context.Context, store kvstorage.KVStore, t Token) error {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("encoding token %s: %w", t.ID, err)
+	}
+	if err := store.Set(ctx, t.ID, data, kvstorage.SetOptions{FailIfExists: true}); err != nil {
+		return fmt.Errorf("storing token %s: %w", t.ID, err)
+	}
+	return nil
+}
+
+// Remove deletes the token with the given ID, revoking it.
+// Returns kvstorage.ErrKeyNotFound if there is no such token.
+func Remove(ctx context.Context, store kvstorage.KVStore, id string) error {
+	if err := store.Delete(ctx, id); err != nil {
+		if errors.Is(err, kvstorage.ErrKeyNotFound) {
+			return kvstorage.ErrKeyNotFound
+		}
+		return fmt.Errorf("removing token %s: %w", id, err)
+	}
+	return nil
+}
+
+func hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b) // never fails
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,101 @@
+package apitoken
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/kvstorage"
+	kvfs "beads-lite/internal/kvstorage/filesystem"
+)
+
+func newTestStore(t *testing.T) *kvfs.Store {
+	t.Helper()
+	store, err := kvfs.New(t.TempDir(), "tokens")
+	if err != nil {
+		t.Fatalf("failed to create kv store: %v", err)
+	}
+	if err := store.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init kv store: %v", err)
+	}
+	return store
+}
+
+func TestParseScope(t *testing.T) {
+	for _, s := range []string{"read", "write"} {
+		if _, err := ParseScope(s); err != nil {
+			t.Errorf("ParseScope(%q) = %v", s, err)
+		}
+	}
+	if _, err := ParseScope("admin"); err == nil {
+		t.Error("ParseScope(admin) should fail")
+	}
+	if ScopeRead.AllowsWrite() || !ScopeWrite.AllowsWrite() {
+		t.Error("only write tokens should allow writes")
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	tok, secret := New("ci", ScopeWrite, "alice", now)
+	if !strings.HasPrefix(secret, "bdt_"+tok.ID+"_") {
+		t.Errorf("secret %q does not carry the token ID %s", secret, tok.ID)
+	}
+	if strings.Contains(tok.Hash, secret) || tok.Hash == "" {
+		t.Errorf("hash = %q", tok.Hash)
+	}
+	if err := Set(ctx, store, tok); err != nil {
+		t.Fatal(err)
+	}
+	if err := Set(ctx, store, tok); err == nil {
+		t.Error("storing the same token twice should fail")
+	}
+
+	got, err := Authenticate(ctx, store, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != tok.ID || got.Scope != ScopeWrite || got.Name != "ci" {
+		t.Errorf("Authenticate = %+v", got)
+	}
+	for _, bad := range []string{"", "bdt_", secret + "x", "bdt_" + tok.ID + "_0000", "bdt_ffffffff_" + strings.Repeat("0", 32), strings.TrimPrefix(secret, "bdt_")} {
+		if _, err := Authenticate(ctx, store, bad); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Authenticate(%q) = %v, want ErrInvalid", bad, err)
+		}
+	}
+
+	if err := Remove(ctx, store, tok.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Authenticate(ctx, store, secret); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Authenticate after revoke = %v, want ErrInvalid", err)
+	}
+	if err := Remove(ctx, store, tok.ID); !errors.Is(err, kvstorage.ErrKeyNotFound) {
+		t.Errorf("revoking twice = %v", err)
+	}
+}
+
+func TestList(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	late, _ := New("late", ScopeRead, "", now.Add(time.Hour))
+	early, _ := New("early", ScopeWrite, "", now)
+	for _, tok := range []Token{late, early} {
+		if err := Set(ctx, store, tok); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tokens, err := List(ctx, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 2 || tokens[0].Name != "early" || tokens[1].Name != "late" {
+		t.Errorf("List = %+v", tokens)
+	}
+}
@@ -0,0 +1,207 @@
+// Package attachment stores issue attachment content as content-addressed
+// blobs under .beads/attachments/. Each blob is named by the hex SHA-256 of
+// its content, so identical files are stored once; per-issue metadata lives
+// on the issue itself (issuestorage.Attachment).
+package attachment
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DirName is the blob directory under the config directory.
+const DirName = "attachments"
+
+// Store reads and writes attachment blobs.
+type Store struct {
+	dir string
+}
+
+// New returns a Store for blobs under configDir/attachments/.
+func New(configDir string) *Store {
+	return &Store{dir: filepath.Join(configDir, DirName)}
+}
+
+// Key returns the content hash data is stored under.
+func Key(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// keyPattern matches the keys Key returns. Keys are read back from issue
+// files, which may come from elsewhere, so only these are used as paths.
+var keyPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// ValidKey reports whether key is a hex SHA-256, as returned by Key.
+func ValidKey(key string) bool {
+	return keyPattern.MatchString(key)
+}
+
+// ShortKey returns the abbreviated form of key shown to users.
+func ShortKey(key string) (string, error) {
+	if !ValidKey(key) {
+		return "", fmt.Errorf("invalid attachment hash %q", key)
+	}
+	return key[:12], nil
+}
+
+// Has reports whether a blob is stored under key.
+func (s *Store) Has(key string) bool {
+	path, err := s.Path(key)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// Put stores data and returns its hex SHA-256. Storing content that is
+// already present is a no-op.
+func (s *Store) Put(data []byte) (string, error) {
+	key := Key(data)
+	path, err := s.Path(key)
+	if err != nil {
+		return "", err
+	}
+	if s.Has(key) {
+		return key, nil
+	}
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return "", fmt.Errorf("creating attachments directory: %w", err)
+	}
+
+	f, err := os.CreateTemp(s.dir, key+".tmp.*")
+	if err != nil {
+		return "", err
+	}
+	tmp := f.Name()
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	return key, nil
+}
+
+// Path returns the blob path for a content hash, or an error if key is
+// not one.
+func (s *Store) Path(key string) (string, error) {
+	if !ValidKey(key) {
+		return "", fmt.Errorf("invalid attachment hash %q", key)
+	}
+	return filepath.Join(s.dir, key), nil
+}
+
+// Get returns the content stored under key.
+func (s *Store) Get(key string) ([]byte, error) {
+	path, err := s.Path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(path)
+}
+
+// Remove deletes the blob stored under key. Removing a missing blob is a
+// no-op.
+func (s *Store) Remove(key string) error {
+	path, err := s.Path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Blob is a file in the blob directory.
+type Blob struct {
+	Key  string
+	Size int64
+}
+
+// List returns the blobs in the blob directory, sorted by key. Other
+// files, such as the temporary file of a Put in progress, are left out.
+func (s *Store) List() ([]Blob, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var blobs []Blob
+	for _, e := range entries {
+		if e.IsDir() || !ValidKey(e.Name()) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return nil, err
+		}
+		blobs = append(blobs, Blob{Key: e.Name(), Size: info.Size()})
+	}
+	sort.Slice(blobs, func(i, j int) bool { return blobs[i].Key < blobs[j].Key })
+	return blobs, nil
+}
+
+// Usage returns the total size of the stored blobs.
+func (s *Store) Usage() (int64, error) {
+	blobs, err := s.List()
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, b := range blobs {
+		total += b.Size
+	}
+	return total, nil
+}
+
+// sizeUnits are the suffixes ParseSize accepts, longest first.
+var sizeUnits = []struct {
+	suffix string
+	factor int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"G", 1 << 30},
+	{"M", 1 << 20},
+	{"K", 1 << 10},
+	{"B", 1},
+}
+
+// ParseSize parses a byte count such as "512", "200KB" or "10MB". Units are
+// binary (1KB = 1024 bytes) and case-insensitive.
+func ParseSize(s string) (int64, error) {
+	v := strings.ToUpper(strings.TrimSpace(s))
+	factor := int64(1)
+	for _, u := range sizeUnits {
+		if rest, ok := strings.CutSuffix(v, u.suffix); ok {
+			v, factor = strings.TrimSpace(rest), u.factor
+			break
+		}
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n < 0 || n > (1<<62)/factor {
+		return 0, fmt.Errorf("invalid size %q (want a byte count like 512KB or 10MB)", s)
+	}
+	return n * factor, nil
+}
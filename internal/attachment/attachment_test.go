@@ -0,0 +1,124 @@
+package attachment
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPutIsContentAddressed(t *testing.T) {
+	s := New(t.TempDir())
+
+	key, err := s.Put([]byte("hello"))
+	if err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if key != "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824" {
+		t.Errorf("unexpected key %s", key)
+	}
+	data, err := s.Get(key)
+	if err != nil || string(data) != "hello" {
+		t.Fatalf("blob not stored: %q %v", data, err)
+	}
+
+	again, err := s.Put([]byte("hello"))
+	if err != nil || again != key {
+		t.Errorf("expected duplicate Put to return %s, got %s %v", key, again, err)
+	}
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected 1 blob, found %d entries", len(entries))
+	}
+}
+
+func TestListAndRemove(t *testing.T) {
+	s := New(t.TempDir())
+	if blobs, err := s.List(); err != nil || len(blobs) != 0 {
+		t.Fatalf("List before any Put = %v, %v", blobs, err)
+	}
+	a, _ := s.Put([]byte("hello"))
+	b, _ := s.Put([]byte("hi"))
+	if !s.Has(a) || a != Key([]byte("hello")) {
+		t.Errorf("Has(%s) = false", a)
+	}
+
+	blobs, err := s.List()
+	if err != nil || len(blobs) != 2 {
+		t.Fatalf("List = %v, %v", blobs, err)
+	}
+	if usage, err := s.Usage(); err != nil || usage != 7 {
+		t.Errorf("Usage = %d, %v, want 7", usage, err)
+	}
+
+	if err := s.Remove(a); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Remove(a); err != nil {
+		t.Errorf("removing a missing blob = %v", err)
+	}
+	if data, err := s.Get(b); err != nil || string(data) != "hi" {
+		t.Errorf("Get = %q, %v", data, err)
+	}
+	if blobs, _ := s.List(); len(blobs) != 1 || blobs[0].Key != b {
+		t.Errorf("List after Remove = %v", blobs)
+	}
+}
+
+func TestInvalidKeys(t *testing.T) {
+	dir := t.TempDir()
+	s := New(dir)
+	key, err := s.Put([]byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	outside := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(outside, []byte("keep"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, bad := range []string{"../config.yaml", "", "abc", strings.ToUpper(key), key + "/x"} {
+		if ValidKey(bad) {
+			t.Errorf("ValidKey(%q) = true", bad)
+		}
+		if _, err := s.Path(bad); err == nil {
+			t.Errorf("Path(%q) should fail", bad)
+		}
+		if _, err := s.Get(bad); err == nil {
+			t.Errorf("Get(%q) should fail", bad)
+		}
+		if err := s.Remove(bad); err == nil {
+			t.Errorf("Remove(%q) should fail", bad)
+		}
+		if _, err := ShortKey(bad); err == nil {
+			t.Errorf("ShortKey(%q) should fail", bad)
+		}
+	}
+	if _, err := os.Stat(outside); err != nil {
+		t.Errorf("file outside the blob directory was removed: %v", err)
+	}
+
+	// A Put in progress is not a blob.
+	if err := os.WriteFile(filepath.Join(s.dir, key+".tmp.123"), []byte("hel"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if blobs, err := s.List(); err != nil || len(blobs) != 1 || blobs[0].Key != key {
+		t.Errorf("List = %v, %v, want only %s", blobs, err, key)
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	for in, want := range map[string]int64{"0": 0, "512": 512, "512B": 512, "200KB": 200 << 10, "10mb": 10 << 20, "1G": 1 << 30, " 2 MB ": 2 << 20} {
+		if got, err := ParseSize(in); err != nil || got != want {
+			t.Errorf("ParseSize(%q) = %d, %v, want %d", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "MB", "-1", "1.5MB", "10TB"} {
+		if _, err := ParseSize(in); err == nil {
+			t.Errorf("ParseSize(%q) should fail", in)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	f := NewFake(start)
+	if got := f.Now(); !got.Equal(start) {
+		t.Errorf("Now = %v, want %v", got, start)
+	}
+	if got := f.Now(); !got.Equal(start) {
+		t.Errorf("Now moved without Advance: %v", got)
+	}
+	f.Advance(90 * time.Minute)
+	if got, want := f.Now(), start.Add(90*time.Minute); !got.Equal(want) {
+		t.Errorf("after Advance, Now = %v, want %v", got, want)
+	}
+	f.Set(start)
+	if got := f.Now(); !got.Equal(start) {
+		t.Errorf("after Set, Now = %v, want %v", got, start)
+	}
+}
+
+func TestOrReal(t *testing.T) {
+	if OrReal(nil) != Real {
+		t.Error("OrReal(nil) should be Real")
+	}
+	f := NewFake(time.Time{})
+	if OrReal(f) != Clock(f) {
+		t.Error("OrReal should keep a non-nil clock")
+	}
+	if d := time.Since(Real.Now()); d < 0 || d > time.Minute {
+		t.Errorf("Real.Now is %v away from time.Now", d)
+	}
+}
@@ -1,40 +1,143 @@
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"os/signal"
+	"sort"
 	"syscall"
+	"time"
+
+	"beads-lite/internal/issuestorage"
 
 	"github.com/spf13/cobra"
 )
 
+// activityEvent is a history entry and the issue it belongs to.
+type activityEvent struct {
+	issueID string
+	issuestorage.HistoryEntry
+}
+
 func newActivityCmd(provider *AppProvider) *cobra.Command {
+	var (
+		since  string
+		actor  string
+		follow bool
+	)
+
 	cmd := &cobra.Command{
 		Use:   "activity",
-		Short: "Real-time mutation feed (no-op in beads-lite)",
-		Long: `In the reference implementation, activity streams real-time issue
-mutations. beads-lite does not implement a daemon or event system,
-so this command is accepted for compatibility but produces no output.
+		Short: "Show recent changes across all issues",
+		Long: `Show the history of every issue (see bd history) merged into one feed,
+oldest first: issues created, status changes, edits, labels, comments
+and dependencies, with who made them.
+
+--since takes a duration back from now (1d, 2w, 6m) or a date, and
+defaults to the last 7 days. With --follow, changes made afterwards are
+printed as they happen until interrupted; with --json they are then
+printed one per line, like bd watch.
 
-With --follow, the process stays open until interrupted (Ctrl-C).
-Without --follow, it exits immediately.`,
+Examples:
+  bd activity
+  bd activity --since 1d --actor alice
+  bd activity --since 2026-10-01 --json
+  bd activity --follow`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			follow, _ := cmd.Flags().GetBool("follow")
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			var from time.Time
+			if d, err := parseDuration(since); err == nil {
+				from = app.Now().Add(-d)
+			} else if from, err = parseListCreatedTime(since, false); err != nil {
+				return fmt.Errorf("invalid --since value %q: use a duration such as 7d or a date", since)
+			}
+
+			issues, err := listAllIssuesForStats(ctx, app.Storage, &issuestorage.ListFilter{})
+			if err != nil {
+				return err
+			}
+			// seen is when each issue's latest event happened, so --follow
+			// prints only what comes after it.
+			seen := make(map[string]time.Time, len(issues))
+			var events []activityEvent
+			for _, issue := range issues {
+				for _, h := range issueEvents(issue) {
+					seen[issue.ID] = h.At
+					if !h.At.Before(from) && (actor == "" || h.Actor == actor) {
+						events = append(events, activityEvent{issue.ID, h})
+					}
+				}
+			}
+			sort.SliceStable(events, func(i, j int) bool {
+				if !events[i].At.Equal(events[j].At) {
+					return events[i].At.Before(events[j].At)
+				}
+				return events[i].issueID < events[j].issueID
+			})
+
+			enc := json.NewEncoder(app.Out)
+			if app.JSON && !follow {
+				out := make([]HistoryEventJSON, len(events))
+				for i, e := range events {
+					out[i] = toHistoryEventJSON(e.issueID, e.HistoryEntry)
+				}
+				return enc.Encode(out)
+			}
+			emit := func(e activityEvent) error {
+				if app.JSON {
+					return enc.Encode(toHistoryEventJSON(e.issueID, e.HistoryEntry))
+				}
+				writeEventLine(app.Out, e.issueID, e.HistoryEntry)
+				return nil
+			}
+			for _, e := range events {
+				if err := emit(e); err != nil {
+					return err
+				}
+			}
 			if !follow {
+				if len(events) == 0 {
+					fmt.Fprintf(app.Out, "No activity since %s\n", from.Format("2006-01-02 15:04"))
+				}
 				return nil
 			}
 
-			// Block until interrupted
-			sig := make(chan os.Signal, 1)
-			signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
-			<-sig
+			changes, err := app.Storage.Watch(ctx, nil)
+			if err != nil {
+				return err
+			}
+			for c := range changes {
+				if c.Issue == nil {
+					continue
+				}
+				for _, h := range issueEvents(c.Issue) {
+					if !h.At.After(seen[c.ID]) {
+						continue
+					}
+					seen[c.ID] = h.At
+					if actor == "" || h.Actor == actor {
+						if err := emit(activityEvent{c.ID, h}); err != nil {
+							return err
+						}
+					}
+				}
+			}
 			return nil
 		},
 	}
 
-	cmd.Flags().Bool("follow", false, "Follow mode: keep process open for new events (no-op)")
+	cmd.Flags().StringVar(&since, "since", "7d", "Show changes since this long ago (e.g. 1d, 2w) or this date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&actor, "actor", "", "Only changes made by this actor")
+	cmd.Flags().BoolVar(&follow, "follow", false, "Keep printing changes as they happen until interrupted")
 	cmd.Flags().Bool("town", false, "Filter to town events (no-op)")
-	cmd.Flags().Bool("json", false, "Output in JSON format (no-op)")
 
 	return cmd
 }
@@ -0,0 +1,468 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"beads-lite/internal/alias"
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/kvstorage"
+
+	"github.com/spf13/cobra"
+)
+
+// adminAuditFile is the log of bd admin operations in .beads/, one JSON
+// record per line. It is committed with the issues so everyone sees who
+// purged or renumbered what.
+const adminAuditFile = "admin-audit.jsonl"
+
+// AdminAuditJSON is a record of the admin audit log, and the JSON output
+// of bd admin log.
+type AdminAuditJSON struct {
+	At      string `json:"at"`
+	Actor   string `json:"actor,omitempty"`
+	Command string `json:"command"`
+	Error   string `json:"error,omitempty"` // set if the operation failed
+}
+
+// AdminPurgeJSON is the JSON output of bd admin purge.
+type AdminPurgeJSON struct {
+	Purged []string `json:"purged"`
+	DryRun bool     `json:"dry_run,omitempty"`
+}
+
+// AdminRenumberJSON is the JSON output of bd admin renumber.
+type AdminRenumberJSON struct {
+	From    string   `json:"from"`
+	To      string   `json:"to"`
+	Updated []string `json:"updated"` // other issues whose references were rewritten
+	DryRun  bool     `json:"dry_run,omitempty"`
+}
+
+// newAdminCmd creates the admin command group.
+func newAdminCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "admin",
+		Short: "Maintenance operations that rewrite or remove data",
+		Long: `Maintenance operations that permanently rewrite or remove data, kept
+apart from day-to-day commands.
+
+Each operation asks you to type its name before it runs, or takes it as
+--confirm <name> in scripts; --dry-run previews without asking. Every
+operation that runs is recorded, with who ran it and whether it failed,
+in .beads/` + adminAuditFile + `, which bd admin log prints.
+
+bd compact and bd migrate remain available at the top level for
+compatibility with beads.
+
+Subcommands:
+  purge          Permanently remove deleted (tombstoned) issues
+  renumber       Change an issue's ID, rewriting references to it
+  compact        Remove old closed issues
+  migrate        Run storage migrations (no-op in beads-lite)
+  rebuild-index  Rebuild the search index
+  log            Show the admin audit log`,
+	}
+
+	// The admin confirmation replaces compact's own prompt.
+	compact := newCompactCmd(provider)
+	compact.Flags().Set("force", "true")
+	compact.Flags().MarkHidden("force")
+	rebuildIndex := newReindexCmd(provider)
+	rebuildIndex.Use = "rebuild-index"
+
+	cmd.AddCommand(adminOperation(provider, newAdminPurgeCmd(provider)))
+	cmd.AddCommand(adminOperation(provider, newAdminRenumberCmd(provider)))
+	cmd.AddCommand(adminOperation(provider, compact))
+	cmd.AddCommand(adminOperation(provider, newMigrateCmd(provider)))
+	cmd.AddCommand(adminOperation(provider, rebuildIndex))
+	cmd.AddCommand(newAdminLogCmd(provider))
+
+	return cmd
+}
+
+// adminOperation makes cmd ask for its name to be typed, or given with
+// --confirm, before it runs, and record the run in the admin audit log.
+// A --dry-run is neither confirmed nor recorded.
+func adminOperation(provider *AppProvider, cmd *cobra.Command) *cobra.Command {
+	var confirm string
+	run := cmd.RunE
+	cmd.RunE = func(c *cobra.Command, args []string) error {
+		app, err := provider.Get()
+		if err != nil {
+			return err
+		}
+		if f := c.Flags().Lookup("dry-run"); f != nil && f.Value.String() == "true" {
+			return run(c, args)
+		}
+
+		name := c.Name()
+		if confirm == "" {
+			fmt.Fprintf(app.Out, "%s Admin operation: bd admin %s cannot be undone.\n", app.WarnColor("⚠"), name)
+			fmt.Fprintf(app.Out, "Type %q to continue: ", name)
+			response, err := bufio.NewReader(c.InOrStdin()).ReadString('\n')
+			if err != nil {
+				return fmt.Errorf("reading confirmation: %w", err)
+			}
+			if strings.TrimSpace(response) != name {
+				fmt.Fprintln(app.Out, "Cancelled")
+				return nil
+			}
+		} else if confirm != name {
+			return fmt.Errorf("--confirm %q does not match the operation name %q; nothing was changed", confirm, name)
+		}
+
+		err = run(c, args)
+		if aerr := appendAdminAudit(app, commandLine(c, args), err); aerr != nil {
+			fmt.Fprintf(app.Err, "warning: recording admin audit log: %v\n", aerr)
+		}
+		return err
+	}
+	cmd.Flags().StringVar(&confirm, "confirm", "", "The operation's name, to run it without the prompt")
+	return cmd
+}
+
+// appendAdminAudit records an admin operation and its outcome.
+func appendAdminAudit(app *App, command string, runErr error) error {
+	actor, _ := resolveActor(app)
+	record := AdminAuditJSON{At: formatTime(app.Now()), Actor: actor, Command: command}
+	if runErr != nil {
+		record.Error = runErr.Error()
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(app.ConfigDir, adminAuditFile), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// readAdminAudit returns the admin audit log, oldest first.
+func readAdminAudit(app *App) ([]AdminAuditJSON, error) {
+	data, err := os.ReadFile(filepath.Join(app.ConfigDir, adminAuditFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var records []AdminAuditJSON
+	for i, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		var r AdminAuditJSON
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", adminAuditFile, i+1, err)
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// newAdminPurgeCmd creates the "admin purge" subcommand.
+func newAdminPurgeCmd(provider *AppProvider) *cobra.Command {
+	var (
+		olderThan string
+		dryRun    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "purge",
+		Short: "Permanently remove deleted (tombstoned) issues",
+		Long: `Permanently remove the files of issues deleted with bd delete, which
+are kept as tombstones until purged. --older-than only purges issues
+deleted more than that long ago (e.g. 30d, 1w, 6m).
+
+Examples:
+  bd admin purge --dry-run
+  bd admin purge --older-than 90d --confirm purge`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			var cutoff time.Time
+			if olderThan != "" {
+				dur, err := parseDuration(olderThan)
+				if err != nil {
+					return fmt.Errorf("invalid --older-than duration %q: %w", olderThan, err)
+				}
+				cutoff = app.Now().Add(-dur)
+			}
+			tombstones, err := app.Storage.List(ctx, &issuestorage.ListFilter{Statuses: []issuestorage.Status{issuestorage.StatusTombstone}})
+			if err != nil {
+				return fmt.Errorf("listing deleted issues: %w", err)
+			}
+			purge := []string{}
+			for _, issue := range tombstones {
+				if !cutoff.IsZero() && (issue.DeletedAt == nil || !issue.DeletedAt.Before(cutoff)) {
+					continue
+				}
+				purge = append(purge, issue.ID)
+			}
+
+			if !dryRun && len(purge) > 0 {
+				release, err := acquireMaintenance(ctx, app, "purge")
+				if err != nil {
+					return err
+				}
+				defer release()
+				for i, id := range purge {
+					if err := app.Storage.Delete(ctx, id); err != nil {
+						return fmt.Errorf("purging %s (purged %d before it): %w", id, i, err)
+					}
+				}
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(AdminPurgeJSON{Purged: purge, DryRun: dryRun})
+			}
+			switch {
+			case len(purge) == 0:
+				fmt.Fprintln(app.Out, "No deleted issues to purge.")
+			case dryRun:
+				fmt.Fprintf(app.Out, "Would purge %d deleted issue(s): %s\n", len(purge), strings.Join(purge, ", "))
+			default:
+				fmt.Fprintf(app.Out, "%s Purged %d deleted issue(s)\n", app.SuccessColor("✓"), len(purge))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&olderThan, "older-than", "", "Only purge issues deleted more than this long ago (e.g. 30d, 1w, 6m)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List what would be purged without removing it")
+
+	return cmd
+}
+
+// newAdminRenumberCmd creates the "admin renumber" subcommand.
+func newAdminRenumberCmd(provider *AppProvider) *cobra.Command {
+	var (
+		force  bool
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "renumber <issue-id> <new-id>",
+		Short: "Change an issue's ID, rewriting references to it",
+		Long: `Change an issue's ID. Dependencies, parents, duplicate-of links and
+mentions in the descriptions of other issues are rewritten to the new
+ID, and the issue's vanity alias follows it. Scripts, commits and other
+places outside bd that name the old ID are not.
+
+The new ID must use the configured prefix or one of allowed_prefixes
+unless --force is given. Issues with dotted child IDs (bd-a1b2.1) cannot
+be renumbered, since their children's IDs are derived from theirs.
+
+Examples:
+  bd admin renumber bd-a1b2 bd-login --dry-run
+  bd admin renumber bd-a1b2 bd-login --confirm renumber`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			issue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+			from, to := issue.ID, args[1]
+			if err := validateCustomID(to, app, force); err != nil {
+				return err
+			}
+			if _, err := app.Storage.Get(ctx, to); err == nil {
+				return fmt.Errorf("%s already exists", to)
+			} else if !errors.Is(err, issuestorage.ErrNotFound) {
+				return err
+			}
+			all, err := app.Storage.List(ctx, nil)
+			if err != nil {
+				return err
+			}
+			closed, err := app.Storage.List(ctx, &issuestorage.ListFilter{Statuses: []issuestorage.Status{issuestorage.StatusClosed}})
+			if err != nil {
+				return err
+			}
+			all = append(all, closed...)
+			var referencing []string
+			mention := issueMentionPattern(from)
+			for _, other := range all {
+				if strings.HasPrefix(other.ID, from+".") {
+					return fmt.Errorf("%s has child issue %s, whose ID is derived from it", from, other.ID)
+				}
+				if other.ID != from && referencesIssue(other, from, mention) {
+					referencing = append(referencing, other.ID)
+				}
+			}
+
+			if !dryRun {
+				release, err := acquireMaintenance(ctx, app, "renumber")
+				if err != nil {
+					return err
+				}
+				defer release()
+				if err := renumberIssue(ctx, app, issue, to, referencing); err != nil {
+					return err
+				}
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(AdminRenumberJSON{From: from, To: to, Updated: append([]string{}, referencing...), DryRun: dryRun})
+			}
+			verb, done := "Renumbered", app.SuccessColor("✓")+" "
+			if dryRun {
+				verb, done = "Would renumber", ""
+			}
+			fmt.Fprintf(app.Out, "%s%s %s to %s\n", done, verb, from, to)
+			if len(referencing) > 0 {
+				fmt.Fprintf(app.Out, "  References in: %s\n", strings.Join(referencing, ", "))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Allow a new ID with any prefix")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would change without changing it")
+
+	return cmd
+}
+
+// issueMentionPattern matches id in free text, but not as part of a
+// longer ID.
+func issueMentionPattern(id string) *regexp.Regexp {
+	return regexp.MustCompile(`(^|[^A-Za-z0-9_-])` + regexp.QuoteMeta(id) + `($|[^A-Za-z0-9_-])`)
+}
+
+// referencesIssue reports whether issue links to or mentions id.
+func referencesIssue(issue *issuestorage.Issue, id string, mention *regexp.Regexp) bool {
+	if issue.Parent == id || issue.DuplicateOf == id || mention.MatchString(issue.Description) {
+		return true
+	}
+	for _, dep := range append(append([]issuestorage.Dependency{}, issue.Dependencies...), issue.Dependents...) {
+		if dep.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// renumberIssue stores issue under the ID to, rewrites the references to
+// it in the referencing issues, moves its alias and removes the old file.
+func renumberIssue(ctx context.Context, app *App, issue *issuestorage.Issue, to string, referencing []string) error {
+	from := issue.ID
+	renamed := *issue
+	renamed.ID = to
+	if _, err := app.Storage.Create(ctx, &renamed, issuestorage.CreateOpts{KeepTimestamps: true, PrioritySet: true}); err != nil {
+		return fmt.Errorf("creating %s: %w", to, err)
+	}
+
+	mention := issueMentionPattern(from)
+	rewrite := func(deps []issuestorage.Dependency) {
+		for i := range deps {
+			if deps[i].ID == from {
+				deps[i].ID = to
+			}
+		}
+	}
+	for _, id := range referencing {
+		if err := app.Storage.Modify(ctx, id, func(i *issuestorage.Issue) error {
+			rewrite(i.Dependencies)
+			rewrite(i.Dependents)
+			if i.Parent == from {
+				i.Parent = to
+			}
+			if i.DuplicateOf == from {
+				i.DuplicateOf = to
+			}
+			i.Description = mention.ReplaceAllString(i.Description, "${1}"+to+"${2}")
+			return nil
+		}); err != nil {
+			return fmt.Errorf("rewriting references in %s (%s and %s both exist now): %w", id, from, to, err)
+		}
+	}
+
+	if app.AliasStore != nil {
+		a, err := alias.ForIssue(ctx, app.AliasStore, from)
+		switch {
+		case err == nil:
+			if _, err := alias.Remove(ctx, app.AliasStore, a.Name); err != nil {
+				return err
+			}
+			a.IssueID = to
+			if _, err := alias.Set(ctx, app.AliasStore, a); err != nil {
+				return err
+			}
+		case !errors.Is(err, kvstorage.ErrKeyNotFound):
+			return err
+		}
+	}
+
+	if err := app.Storage.Delete(ctx, from); err != nil {
+		return fmt.Errorf("removing %s (references now point to %s): %w", from, to, err)
+	}
+	return nil
+}
+
+// newAdminLogCmd creates the "admin log" subcommand.
+func newAdminLogCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "log",
+		Short: "Show the admin audit log",
+		Long:  `Show the admin operations that ran in this repository, oldest first.`,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+
+			records, err := readAdminAudit(app)
+			if err != nil {
+				return err
+			}
+
+			if app.JSON {
+				if records == nil {
+					records = []AdminAuditJSON{}
+				}
+				return json.NewEncoder(app.Out).Encode(records)
+			}
+			if len(records) == 0 {
+				fmt.Fprintln(app.Out, "No admin operations recorded.")
+				return nil
+			}
+			for _, r := range records {
+				line := fmt.Sprintf("%s  %s  %s", r.At, r.Actor, r.Command)
+				if r.Error != "" {
+					line += "  (failed: " + r.Error + ")"
+				}
+				fmt.Fprintln(app.Out, line)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
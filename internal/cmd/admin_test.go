@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/alias"
+	"beads-lite/internal/clock"
+	"beads-lite/internal/issuestorage"
+	kvfs "beads-lite/internal/kvstorage/filesystem"
+)
+
+func TestAdminCommands(t *testing.T) {
+	t.Setenv("BD_ACTOR", "alice")
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC))
+	store.SetClock(fake)
+	app.ConfigDir = t.TempDir()
+	aliasStore, err := kvfs.New(app.ConfigDir, "aliases")
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.AliasStore = aliasStore
+	store.SetAliases(alias.NewLookup(aliasStore))
+
+	exec := func(stdin string, args ...string) (string, error) {
+		t.Helper()
+		out := &bytes.Buffer{}
+		app.Out = out
+		cmd := newAdminCmd(NewTestProvider(app))
+		cmd.SetIn(strings.NewReader(stdin))
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+	run := func(args ...string) string {
+		t.Helper()
+		out, err := exec("", args...)
+		if err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+		return out
+	}
+
+	old, _ := store.Create(ctx, &issuestorage.Issue{Title: "Deleted long ago"})
+	softDelete(ctx, store, old, "alice", "obsolete")
+	fake.Advance(60 * 24 * time.Hour)
+	recent, _ := store.Create(ctx, &issuestorage.Issue{Title: "Deleted today"})
+	softDelete(ctx, store, recent, "alice", "obsolete")
+
+	// Nothing runs without the operation's name.
+	if out, err := exec("yes\n", "purge"); err != nil || !strings.Contains(out, "Cancelled") {
+		t.Errorf("purge with a wrong answer = %q, %v", out, err)
+	}
+	if _, err := exec("", "purge", "--confirm", "renumber"); err == nil {
+		t.Error("--confirm with another operation's name should fail")
+	}
+	if out := run("purge", "--older-than", "30d", "--dry-run"); !strings.Contains(out, "Would purge 1 deleted issue(s): "+old) {
+		t.Errorf("purge --dry-run = %q", out)
+	}
+	if _, err := store.Get(ctx, old); err != nil {
+		t.Fatalf("a cancelled or dry-run purge removed %s: %v", old, err)
+	}
+
+	if out, err := exec("purge\n", "purge", "--older-than", "30d"); err != nil || !strings.Contains(out, "Purged 1 deleted issue(s)") {
+		t.Errorf("purge = %q, %v", out, err)
+	}
+	if _, err := store.Get(ctx, old); !errors.Is(err, issuestorage.ErrNotFound) {
+		t.Errorf("%s after purge: %v", old, err)
+	}
+	if issue, err := store.Get(ctx, recent); err != nil || issue.Status != issuestorage.StatusTombstone {
+		t.Errorf("%s was deleted too recently to purge: %+v, %v", recent, issue, err)
+	}
+
+	epic, _ := store.Create(ctx, &issuestorage.Issue{Title: "Epic", Type: issuestorage.TypeEpic})
+	target, _ := store.Create(ctx, &issuestorage.Issue{Title: "Login rewrite"})
+	blocked, _ := store.Create(ctx, &issuestorage.Issue{Title: "Blocked", Description: "Waits for " + target + "."})
+	if err := store.AddDependency(ctx, target, epic, issuestorage.DepTypeParentChild); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddDependency(ctx, blocked, target, issuestorage.DepTypeBlocks); err != nil {
+		t.Fatal(err)
+	}
+	if err := aliasStore.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := alias.Set(ctx, aliasStore, alias.Alias{Name: "bd-login-rewrite", IssueID: target}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := exec("", "renumber", target, "xx-login", "--confirm", "renumber"); err == nil {
+		t.Error("renumbering to another prefix should need --force")
+	}
+	if _, err := exec("", "renumber", target, epic, "--confirm", "renumber"); err == nil {
+		t.Error("renumbering to an existing ID should fail")
+	}
+	app.JSON = true
+	var renumbered AdminRenumberJSON
+	if err := json.Unmarshal([]byte(run("renumber", target, "bd-login", "--confirm", "renumber")), &renumbered); err != nil {
+		t.Fatal(err)
+	}
+	app.JSON = false
+	sort.Strings(renumbered.Updated)
+	want := []string{epic, blocked}
+	sort.Strings(want)
+	if renumbered.To != "bd-login" || !reflect.DeepEqual(renumbered.Updated, want) {
+		t.Errorf("renumber = %+v, want updates to %v", renumbered, want)
+	}
+	if _, err := store.Get(ctx, target); !errors.Is(err, issuestorage.ErrNotFound) {
+		t.Errorf("old ID %s still exists: %v", target, err)
+	}
+	moved, err := store.Get(ctx, "bd-login")
+	if err != nil || moved.Title != "Login rewrite" || moved.Parent != epic {
+		t.Fatalf("renumbered issue = %+v, %v", moved, err)
+	}
+	if issue, _ := store.Get(ctx, epic); len(issue.Dependents) != 1 || issue.Dependents[0].ID != "bd-login" {
+		t.Errorf("epic dependents = %+v", issue.Dependents)
+	}
+	if issue, _ := store.Get(ctx, blocked); len(issue.Dependencies) != 1 || issue.Dependencies[0].ID != "bd-login" || issue.Description != "Waits for bd-login." {
+		t.Errorf("blocked issue = %+v", issue)
+	}
+	if a, err := alias.Get(ctx, aliasStore, "bd-login-rewrite"); err != nil || a.IssueID != "bd-login" {
+		t.Errorf("alias after renumber = %+v, %v", a, err)
+	}
+
+	child, _ := store.Create(ctx, &issuestorage.Issue{ID: epic + ".1", Title: "Child"})
+	if err := store.AddDependency(ctx, child, epic, issuestorage.DepTypeParentChild); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := exec("", "renumber", epic, "bd-epic", "--confirm", "renumber"); err == nil {
+		t.Error("renumbering an issue with dotted children should fail")
+	}
+
+	app.JSON = true
+	var log []AdminAuditJSON
+	if err := json.Unmarshal([]byte(run("log")), &log); err != nil {
+		t.Fatal(err)
+	}
+	var commands []string
+	for _, r := range log {
+		if r.Actor != "alice" {
+			t.Errorf("audit actor = %q", r.Actor)
+		}
+		commands = append(commands, strings.Fields(r.Command)[1]) // "admin <operation> ..."
+	}
+	// The refused --confirm, the cancelled prompt and the dry run are not
+	// recorded; the failed renumbers are, with their errors.
+	if want := []string{"purge", "renumber", "renumber", "renumber", "renumber"}; !reflect.DeepEqual(commands, want) {
+		t.Errorf("audit log commands = %v, want %v", commands, want)
+	}
+	if len(log) == 5 && (log[1].Error == "" || log[3].Error != "" || log[4].Error == "") {
+		t.Errorf("audit log errors = %+v", log)
+	}
+}
+
+// bd admin compact confirms once, in place of bd compact's y/N prompt.
+func TestAdminCompactSkipsOwnPrompt(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	app.ConfigDir = t.TempDir()
+	id, _ := store.Create(ctx, &issuestorage.Issue{Title: "Done"})
+	if err := store.Modify(ctx, id, func(i *issuestorage.Issue) error {
+		i.Status = issuestorage.StatusClosed
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	out := &bytes.Buffer{}
+	app.Out = out
+	cmd := newAdminCmd(NewTestProvider(app))
+	cmd.SetIn(strings.NewReader("compact\n"))
+	cmd.SetArgs([]string{"compact"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "Deleted 1 issue(s)") || strings.Contains(out.String(), "[y/N]") {
+		t.Errorf("admin compact output = %q", out.String())
+	}
+}
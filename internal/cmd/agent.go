@@ -54,7 +54,7 @@ func newAgentStateCmd(provider *AppProvider) *cobra.Command {
 			agentID := args[0]
 			state := args[1]
 
-			if err := agent.SetState(ctx, app.AgentStore, agentID, state); err != nil {
+			if err := agent.SetState(ctx, app.AgentStore, agentID, state, app.Now()); err != nil {
 				return err
 			}
 
@@ -95,7 +95,7 @@ func newAgentHeartbeatCmd(provider *AppProvider) *cobra.Command {
 			ctx := cmd.Context()
 			agentID := args[0]
 
-			if err := agent.Heartbeat(ctx, app.AgentStore, agentID); err != nil {
+			if err := agent.Heartbeat(ctx, app.AgentStore, agentID, app.Now()); err != nil {
 				return err
 			}
 
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"beads-lite/internal/agent"
 	"beads-lite/internal/issueservice"
@@ -291,7 +292,7 @@ func TestAgentShowJSON(t *testing.T) {
 	app, _ := setupAgentTestApp(t)
 
 	// Create agent
-	if err := agent.SetState(context.Background(), app.AgentStore, "agent-1", "working"); err != nil {
+	if err := agent.SetState(context.Background(), app.AgentStore, "agent-1", "working", time.Now()); err != nil {
 		t.Fatalf("SetState failed: %v", err)
 	}
 
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// newAnswerCmd creates the answer command.
+func newAnswerCmd(provider *AppProvider) *cobra.Command {
+	var commentID int
+
+	cmd := &cobra.Command{
+		Use:   "answer <question-id> --comment <n>",
+		Short: "Mark a comment as the accepted answer to a question",
+		Long: `Mark a comment as the accepted answer to a question and close it.
+
+The accepted answer is highlighted by bd show and included in bd search
+results.
+
+Examples:
+  bd answer bd-a1b2 --comment 3`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			if !cmd.Flags().Changed("comment") {
+				return fmt.Errorf("--comment is required")
+			}
+			issue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+			if err := app.Storage.AcceptAnswer(ctx, issue.ID, commentID); err != nil {
+				return err
+			}
+
+			if app.JSON {
+				updated, err := app.Storage.Get(ctx, issue.ID)
+				if err != nil {
+					return fmt.Errorf("fetching updated issue: %w", err)
+				}
+				return json.NewEncoder(app.Out).Encode(ToIssueJSON(ctx, app.Storage, updated, false, false))
+			}
+			fmt.Fprintf(app.Out, "%s Accepted comment %d as the answer to %s\n", app.SuccessColor("✓"), commentID, issue.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&commentID, "comment", 0, "ID of the comment to accept (required)")
+
+	return cmd
+}
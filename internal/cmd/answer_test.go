@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestAnswerCmd(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+
+	qID, err := store.Create(ctx, &issuestorage.Issue{Title: "Where do config files live?", Type: issuestorage.TypeQuestion})
+	if err != nil {
+		t.Fatalf("failed to create question: %v", err)
+	}
+	for _, text := range []string{"Not sure", "Under .beads/config.yaml"} {
+		if err := addComment(ctx, store, qID, &issuestorage.Comment{Author: "alice", Text: text}); err != nil {
+			t.Fatalf("failed to add comment: %v", err)
+		}
+	}
+
+	cmd := newAnswerCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{qID, "--comment", "2"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("answer failed: %v", err)
+	}
+	q, err := store.Get(ctx, qID)
+	if err != nil {
+		t.Fatalf("failed to get question: %v", err)
+	}
+	if q.AcceptedAnswer != 2 || q.Status != issuestorage.StatusClosed {
+		t.Errorf("expected accepted answer 2 and closed, got %d/%s", q.AcceptedAnswer, q.Status)
+	}
+
+	// Search matches on the answer text and surfaces it.
+	app.Out = &bytes.Buffer{}
+	cmd = newSearchCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"config.yaml"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	out := app.Out.(*bytes.Buffer).String()
+	if !strings.Contains(out, qID) || !strings.Contains(out, "Answer: Under .beads/config.yaml") {
+		t.Errorf("expected question with answer in search output, got:\n%s", out)
+	}
+
+	app.Out = &bytes.Buffer{}
+	app.JSON = true
+	cmd = newSearchCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"config files"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	var results []IssueListJSON
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &results); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	if len(results) != 1 || results[0].Answer != "Under .beads/config.yaml" {
+		t.Errorf("expected answer in JSON search result, got %+v", results)
+	}
+
+	cmd = newAnswerCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{qID})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error without --comment")
+	}
+}
@@ -2,13 +2,23 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"time"
 
 	"beads-lite/internal/config"
+	"beads-lite/internal/deterministic"
 	"beads-lite/internal/issueservice"
+	"beads-lite/internal/issuestorage/indexed"
+	"beads-lite/internal/issuestorage/maintenance"
+	"beads-lite/internal/issuestorage/metrics"
+	"beads-lite/internal/issuestorage/replicated"
 	"beads-lite/internal/kvstorage"
 	"beads-lite/internal/meow"
+	"beads-lite/internal/notify"
 
 	"golang.org/x/term"
 )
@@ -19,12 +29,60 @@ type App struct {
 	SlotStore      kvstorage.KVStore
 	AgentStore     kvstorage.KVStore
 	MergeSlotStore kvstorage.KVStore
+	MilestoneStore kvstorage.KVStore
+	SprintStore    kvstorage.KVStore
+	AliasStore     kvstorage.KVStore
+	TemplateStore  kvstorage.KVStore
+	PeopleStore    kvstorage.KVStore
+	EstimateStore  kvstorage.KVStore
+	TokenStore     kvstorage.KVStore
+	UndoStore      kvstorage.KVStore
 	ConfigStore    config.Store
 	ConfigDir      string // path to .beads directory
 	FormulaPath    meow.FormulaSearchPath
 	Out            io.Writer
 	Err            io.Writer
 	JSON           bool // output in JSON format
+	Compat         int  // major version pinned with --compat, 0 for current
+
+	// SearchIndex is the full-text index used by bd search, nil for
+	// backends other than the filesystem.
+	SearchIndex *indexed.Store
+	// Replicas is the mirroring store when storage.mirrors is configured.
+	Replicas *replicated.Store
+	// Metrics records storage operation timings for slow-operation hints.
+	Metrics *metrics.Store
+	// Maintenance is the store-wide lock held by compaction and other
+	// maintenance, which the store's writes wait for.
+	Maintenance *maintenance.Lock
+	// Deterministic is the seeded clock and ID source in --deterministic
+	// mode, nil otherwise.
+	Deterministic *deterministic.Source
+	// Notifier, when set, replaces the notifier configured by notify.*
+	// keys. Tests use it to observe notifications.
+	Notifier *notify.Notifier
+}
+
+// Close releases resources held by the app, waiting for pending mirror
+// writes to finish, and saves the deterministic sequence position so the
+// next invocation continues it.
+func (a *App) Close() error {
+	var errs []error
+	if a.Replicas != nil {
+		errs = append(errs, a.Replicas.Close())
+	}
+	if a.Deterministic != nil {
+		if err := a.Deterministic.Save(filepath.Join(a.ConfigDir, deterministic.StateFile)); err != nil {
+			errs = append(errs, fmt.Errorf("saving deterministic state: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Now returns the current time by the storage clock, which is fake in
+// --deterministic mode.
+func (a *App) Now() time.Time {
+	return a.Storage.Now()
 }
 
 // IsColor returns true if colored output should be used.
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"beads-lite/internal/config"
+	"beads-lite/internal/issuestorage"
+)
+
+// Config keys for auto-assignment rules. Each rule is declared as
+// assign.<name>.team, optionally scoped to a label with assign.<name>.label
+// and choosing a strategy with assign.<name>.strategy.
+const (
+	assignKeyPrefix      = "assign."
+	assignTeamSuffix     = ".team"
+	assignLabelSuffix    = ".label"
+	assignStrategySuffix = ".strategy"
+)
+
+// assignStrategies lists the values accepted for assign.<name>.strategy:
+//   - round-robin: the next available team member after the one the rule
+//     last picked (the default)
+//   - least-loaded: the available team member with the fewest open and
+//     in-progress issues
+var assignStrategies = []string{"round-robin", "least-loaded"}
+
+// assignRule is an auto-assignment policy declared in config.
+type assignRule struct {
+	Name     string
+	Label    string // "" matches any issue
+	Strategy string
+	Team     []string
+}
+
+// source names the rule in history entries and messages.
+func (r assignRule) source() string {
+	return assignKeyPrefix + r.Name
+}
+
+// assignRules returns the configured rules in the order they are tried:
+// label rules before catch-all ones, then by name.
+func assignRules(app *App) []assignRule {
+	if app.ConfigStore == nil {
+		return nil
+	}
+	all := app.ConfigStore.All()
+	var rules []assignRule
+	for key, team := range all {
+		rest, ok := strings.CutPrefix(key, assignKeyPrefix)
+		if !ok || !strings.HasSuffix(rest, assignTeamSuffix) {
+			continue
+		}
+		name := strings.TrimSuffix(rest, assignTeamSuffix)
+		rule := assignRule{
+			Name:     name,
+			Label:    all[assignKeyPrefix+name+assignLabelSuffix],
+			Strategy: all[assignKeyPrefix+name+assignStrategySuffix],
+			Team:     config.SplitCustomValues(team),
+		}
+		if rule.Strategy == "" {
+			rule.Strategy = "round-robin"
+		}
+		if name != "" && len(rule.Team) > 0 && contains(assignStrategies, rule.Strategy) {
+			rules = append(rules, rule)
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if (rules[i].Label == "") != (rules[j].Label == "") {
+			return rules[i].Label != ""
+		}
+		return rules[i].Name < rules[j].Name
+	})
+	return rules
+}
+
+// validateAssignKey is the config validator for assign.<name>.* keys.
+func validateAssignKey(key, v string) string {
+	switch {
+	case strings.HasSuffix(key, assignTeamSuffix):
+		if len(config.SplitCustomValues(v)) == 0 {
+			return fmt.Sprintf("%s: team must name at least one person", key)
+		}
+	case strings.HasSuffix(key, assignStrategySuffix):
+		if !contains(assignStrategies, v) {
+			return fmt.Sprintf("%s: invalid value %q (valid: %s)", key, v, strings.Join(assignStrategies, ", "))
+		}
+	}
+	return ""
+}
+
+// autoAssign picks an assignee for a new issue with labels using the first
+// matching rule. It returns "" if no rule matches or no one on the team is
+// available, warning on stderr in the latter case.
+func autoAssign(ctx context.Context, app *App, labels []string, now time.Time) (string, *assignRule, error) {
+	var rule *assignRule
+	for _, r := range assignRules(app) {
+		if r.Label == "" || contains(labels, r.Label) {
+			rule = &r
+			break
+		}
+	}
+	if rule == nil {
+		return "", nil, nil
+	}
+
+	away := awayPeople(app, now)
+	available := func(person string) bool {
+		_, ok := away[person]
+		return !ok
+	}
+	if !slices.ContainsFunc(rule.Team, available) {
+		fmt.Fprintf(app.Err, "warning: everyone in %s is out of office; leaving the issue unassigned\n", rule.source())
+		return "", nil, nil
+	}
+
+	switch rule.Strategy {
+	case "least-loaded":
+		issues, err := workloadIssues(ctx, app, nil)
+		if err != nil {
+			return "", nil, err
+		}
+		load := make(map[string]int)
+		for _, issue := range issues {
+			if isActiveWork(issue) {
+				load[issue.Assignee]++
+			}
+		}
+		best := ""
+		for _, person := range rule.Team {
+			if available(person) && (best == "" || load[person] < load[best]) {
+				best = person
+			}
+		}
+		return best, rule, nil
+	default:
+		last, err := lastAutoAssignee(ctx, app, rule.source())
+		if err != nil {
+			return "", nil, err
+		}
+		start := 0
+		for i, person := range rule.Team {
+			if person == last {
+				start = i + 1
+				break
+			}
+		}
+		for i := range rule.Team {
+			if person := rule.Team[(start+i)%len(rule.Team)]; available(person) {
+				return person, rule, nil
+			}
+		}
+		return "", nil, nil
+	}
+}
+
+// lastAutoAssignee returns who source most recently auto-assigned an issue
+// to, found from issue history so the rotation needs no state of its own.
+func lastAutoAssignee(ctx context.Context, app *App, source string) (string, error) {
+	issues, err := listExportIssues(ctx, app, nil)
+	if err != nil {
+		return "", err
+	}
+	var last issuestorage.HistoryEntry
+	for _, issue := range issues {
+		for _, h := range issue.History {
+			if h.Event == issuestorage.EventAutoAssigned && h.Note == source && h.At.After(last.At) {
+				last = h
+			}
+		}
+	}
+	return last.New, nil
+}
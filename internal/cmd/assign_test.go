@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// createWith runs bd create with args and returns the new issue.
+func createWith(t *testing.T, app *App, args ...string) *issuestorage.Issue {
+	t.Helper()
+	out := app.Out.(*bytes.Buffer)
+	out.Reset()
+	cmd := newCreateCmd(NewTestProvider(app))
+	cmd.SetArgs(append(args, "--description", "d"))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("create %v: %v", args, err)
+	}
+	issue, err := app.Storage.Get(context.Background(), extractCreatedID(out.String()))
+	if err != nil {
+		t.Fatalf("get created issue: %v", err)
+	}
+	return issue
+}
+
+func TestCreate_AutoAssignRoundRobin(t *testing.T) {
+	app, _ := setupTestApp(t)
+	app.ConfigStore = &mapConfigStore{data: map[string]string{
+		"assign.backend.label": "backend",
+		"assign.backend.team":  "alice,bob,carol",
+		"ooo.bob":              time.Now().Format(oooDateLayout),
+		"assign.triage.team":   "dave",
+	}}
+
+	var got []string
+	for range 3 {
+		got = append(got, createWith(t, app, "API bug", "--labels", "backend").Assignee)
+	}
+	// bob is away, so the rotation skips him.
+	if want := "alice,carol,alice"; strings.Join(got, ",") != want {
+		t.Errorf("assignees = %s, want %s", strings.Join(got, ","), want)
+	}
+
+	// Issues without the label fall through to the catch-all rule.
+	issue := createWith(t, app, "Docs typo")
+	if issue.Assignee != "dave" {
+		t.Errorf("catch-all assignee = %q, want dave", issue.Assignee)
+	}
+	if n := len(issue.History); n != 1 {
+		t.Fatalf("history = %+v, want one entry", issue.History)
+	}
+	if h := issue.History[0]; h.Event != issuestorage.EventAutoAssigned || h.New != "dave" || h.Note != "assign.triage" {
+		t.Errorf("history entry = %+v, want auto_assigned dave by assign.triage", h)
+	}
+	if out := app.Out.(*bytes.Buffer).String(); !strings.Contains(out, "Assignee: dave (auto-assigned by assign.triage, round-robin)") {
+		t.Errorf("output = %q, want auto-assignment note", out)
+	}
+
+	// An explicit assignee, even an empty one, is left alone.
+	if issue := createWith(t, app, "Mine", "--assignee", ""); issue.Assignee != "" {
+		t.Errorf("explicit empty assignee became %q", issue.Assignee)
+	}
+}
+
+func TestCreate_AutoAssignLeastLoaded(t *testing.T) {
+	app, store := setupTestApp(t)
+	app.ConfigStore = &mapConfigStore{data: map[string]string{
+		"assign.ops.team":     "alice,bob",
+		"assign.ops.strategy": "least-loaded",
+	}}
+	createAssigned(t, store, "a1", "alice", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	createAssigned(t, store, "a2", "alice", issuestorage.StatusInProgress, issuestorage.PriorityMedium)
+	createAssigned(t, store, "b1", "bob", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+
+	if issue := createWith(t, app, "Next"); issue.Assignee != "bob" {
+		t.Errorf("assignee = %q, want bob (least loaded)", issue.Assignee)
+	}
+	// Now tied at two each: team order breaks the tie.
+	if issue := createWith(t, app, "After"); issue.Assignee != "alice" {
+		t.Errorf("assignee = %q, want alice (tie goes to team order)", issue.Assignee)
+	}
+}
+
+func TestCreate_AutoAssignEveryoneAway(t *testing.T) {
+	app, _ := setupTestApp(t)
+	app.ConfigStore = &mapConfigStore{data: map[string]string{
+		"assign.solo.team": "alice",
+		"ooo.alice":        time.Now().Format(oooDateLayout),
+	}}
+	if issue := createWith(t, app, "Task"); issue.Assignee != "" {
+		t.Errorf("assignee = %q, want unassigned", issue.Assignee)
+	}
+	if got := app.Err.(*bytes.Buffer).String(); !strings.Contains(got, "everyone in assign.solo is out of office") {
+		t.Errorf("stderr = %q", got)
+	}
+}
+
+func TestValidateAssignKey(t *testing.T) {
+	if msg := validateAssignKey("assign.x.strategy", "random"); msg == "" {
+		t.Error("invalid strategy accepted")
+	}
+	if msg := validateAssignKey("assign.x.team", " , "); msg == "" {
+		t.Error("empty team accepted")
+	}
+	if msg := validateAssignKey("assign.x.strategy", "least-loaded"); msg != "" {
+		t.Errorf("valid strategy rejected: %s", msg)
+	}
+}
@@ -0,0 +1,552 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"beads-lite/internal/attachment"
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// clipboardSource is one platform helper that can print clipboard content
+// of a given media type to stdout.
+type clipboardSource struct {
+	mediaType string
+	name      string
+	args      []string
+}
+
+// windowsClipboardImage writes the clipboard image (if any) to stdout as PNG.
+const windowsClipboardImage = `Add-Type -AssemblyName System.Windows.Forms,System.Drawing;` +
+	`$img = [System.Windows.Forms.Clipboard]::GetImage();` +
+	`if ($img) { $ms = New-Object System.IO.MemoryStream;` +
+	`$img.Save($ms, [System.Drawing.Imaging.ImageFormat]::Png);` +
+	`$out = [Console]::OpenStandardOutput(); $out.Write($ms.ToArray(), 0, $ms.Length) }`
+
+// clipboardSources returns the helpers to try for goos, images first so a
+// copied screenshot wins over any text also on the clipboard.
+func clipboardSources(goos string) []clipboardSource {
+	switch goos {
+	case "darwin":
+		return []clipboardSource{
+			{"image/png", "pngpaste", []string{"-"}},
+			{"text/plain", "pbpaste", nil},
+		}
+	case "windows":
+		return []clipboardSource{
+			{"image/png", "powershell", []string{"-NoProfile", "-NonInteractive", "-Command", windowsClipboardImage}},
+			{"text/plain", "powershell", []string{"-NoProfile", "-NonInteractive", "-Command", "Get-Clipboard -Raw"}},
+		}
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return []clipboardSource{
+			{"image/png", "wl-paste", []string{"--no-newline", "--type", "image/png"}},
+			{"image/png", "xclip", []string{"-selection", "clipboard", "-t", "image/png", "-o"}},
+			{"text/plain", "wl-paste", []string{"--no-newline"}},
+			{"text/plain", "xclip", []string{"-selection", "clipboard", "-o"}},
+			{"text/plain", "xsel", []string{"--clipboard", "--output"}},
+		}
+	}
+	return nil
+}
+
+// readClipboard returns the clipboard content and its media type using the
+// first helper for goos that is installed and produces output.
+func readClipboard(goos string, executor commandExecutor) ([]byte, string, error) {
+	sources := clipboardSources(goos)
+	if len(sources) == 0 {
+		return nil, "", fmt.Errorf("reading the clipboard is not supported on %s", goos)
+	}
+	var tried []string
+	for _, src := range sources {
+		if !contains(tried, src.name) {
+			tried = append(tried, src.name)
+		}
+		out, err := executor(src.name, src.args...)
+		if err != nil || len(out) == 0 {
+			continue
+		}
+		return out, src.mediaType, nil
+	}
+	return nil, "", fmt.Errorf("clipboard is empty or no clipboard helper is installed (tried %s)", strings.Join(tried, ", "))
+}
+
+// attachmentExt returns the file extension used for a media type.
+func attachmentExt(mediaType string) string {
+	switch mediaType {
+	case "image/png":
+		return ".png"
+	case "text/plain":
+		return ".txt"
+	}
+	return ""
+}
+
+// Config keys limiting attachment sizes. Both take a byte count such as
+// "512KB" or "10MB"; "0" means no limit.
+const (
+	attachMaxSizeKey  = "attach.max_size"  // per attachment, default defaultAttachMaxSize
+	attachMaxTotalKey = "attach.max_total" // all blobs together, default unlimited
+)
+
+const defaultAttachMaxSize = 10 << 20
+
+// validateAttachSize is the config validator for the attach.* size keys.
+func validateAttachSize(key string) func(string) string {
+	return func(v string) string {
+		if _, err := attachment.ParseSize(v); err != nil {
+			return fmt.Sprintf("%s: %v", key, err)
+		}
+		return ""
+	}
+}
+
+// attachLimit returns the configured size limit for key, or def if unset.
+func attachLimit(app *App, key string, def int64) (int64, error) {
+	if app.ConfigStore == nil {
+		return def, nil
+	}
+	v, ok := app.ConfigStore.Get(key)
+	if !ok || v == "" {
+		return def, nil
+	}
+	n, err := attachment.ParseSize(v)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", key, err)
+	}
+	return n, nil
+}
+
+// checkAttachLimits returns an error if storing data would exceed the
+// configured per-attachment or total size limit. Content that is already
+// stored takes no extra space.
+func checkAttachLimits(app *App, blobs *attachment.Store, data []byte) error {
+	maxSize, err := attachLimit(app, attachMaxSizeKey, defaultAttachMaxSize)
+	if err != nil {
+		return err
+	}
+	if maxSize > 0 && int64(len(data)) > maxSize {
+		return fmt.Errorf("attachment is %d bytes, over the %s limit (raise %s)", len(data), formatSize(maxSize), attachMaxSizeKey)
+	}
+	maxTotal, err := attachLimit(app, attachMaxTotalKey, 0)
+	if err != nil {
+		return err
+	}
+	if maxTotal == 0 || blobs.Has(attachment.Key(data)) {
+		return nil
+	}
+	used, err := blobs.Usage()
+	if err != nil {
+		return err
+	}
+	if used+int64(len(data)) > maxTotal {
+		return fmt.Errorf("attachments would use %s, over the %s limit (raise %s, or run bd doctor --fix to remove orphaned blobs)", formatSize(used+int64(len(data))), formatSize(maxTotal), attachMaxTotalKey)
+	}
+	return nil
+}
+
+// fileMediaType guesses a file's media type from its extension, falling
+// back to sniffing its content.
+func fileMediaType(path string, data []byte) string {
+	mediaType := mime.TypeByExtension(filepath.Ext(path))
+	if mediaType == "" {
+		mediaType = http.DetectContentType(data)
+	}
+	if base, _, err := mime.ParseMediaType(mediaType); err == nil {
+		return base
+	}
+	return mediaType
+}
+
+// findAttachment returns the index of the attachment on issue named ref, or
+// whose content hash starts with ref.
+func findAttachment(issue *issuestorage.Issue, ref string) (int, error) {
+	var byName, byHash []int
+	for i, a := range issue.Attachments {
+		if a.Name == ref {
+			byName = append(byName, i)
+		} else if len(ref) >= 4 && strings.HasPrefix(a.SHA256, strings.ToLower(ref)) {
+			byHash = append(byHash, i)
+		}
+	}
+	matches := byName
+	if len(matches) == 0 {
+		matches = byHash
+	}
+	switch len(matches) {
+	case 0:
+		return 0, fmt.Errorf("no attachment %q on %s (see bd attach list %s)", ref, issue.ID, issue.ID)
+	case 1:
+		return matches[0], nil
+	}
+	var hashes []string
+	for _, i := range matches {
+		hash, err := attachment.ShortKey(issue.Attachments[i].SHA256)
+		if err != nil {
+			return 0, fmt.Errorf("attachment %q on %s: %w", issue.Attachments[i].Name, issue.ID, err)
+		}
+		hashes = append(hashes, hash)
+	}
+	return 0, fmt.Errorf("%q matches %d attachments on %s; use a content hash (%s)", ref, len(matches), issue.ID, strings.Join(hashes, ", "))
+}
+
+// attachmentRefs returns the content hashes referenced by any issue,
+// including closed and deleted ones.
+func attachmentRefs(ctx context.Context, app *App) (map[string]bool, error) {
+	issues, err := app.Storage.List(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	rest, err := app.Storage.List(ctx, &issuestorage.ListFilter{Statuses: []issuestorage.Status{issuestorage.StatusClosed, issuestorage.StatusTombstone}})
+	if err != nil {
+		return nil, err
+	}
+	refs := map[string]bool{}
+	for _, issue := range append(issues, rest...) {
+		for _, a := range issue.Attachments {
+			refs[a.SHA256] = true
+		}
+	}
+	return refs, nil
+}
+
+// attachmentProblems reports blobs no issue references, removing them if
+// fix is set.
+func attachmentProblems(ctx context.Context, app *App, fix bool) ([]string, error) {
+	blobs := attachment.New(app.ConfigDir)
+	stored, err := blobs.List()
+	if err != nil || len(stored) == 0 {
+		return nil, err
+	}
+	refs, err := attachmentRefs(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	var problems []string
+	for _, b := range stored {
+		if refs[b.Key] {
+			continue
+		}
+		if fix {
+			if err := blobs.Remove(b.Key); err != nil {
+				return problems, err
+			}
+		}
+		problems = append(problems, fmt.Sprintf("orphaned attachment blob %s (%s)", b.Key, formatSize(b.Size)))
+	}
+	return problems, nil
+}
+
+func newAttachCmd(provider *AppProvider) *cobra.Command {
+	return attachCmd(provider, defaultCommandExecutor, runtime.GOOS)
+}
+
+// attachCmd builds the attach command. Separated from newAttachCmd so tests
+// can inject a mock executor and platform.
+func attachCmd(provider *AppProvider, executor commandExecutor, goos string) *cobra.Command {
+	var (
+		fromClipboard bool
+		name          string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "attach <id> [path]",
+		Short: "Attach a file or clipboard content to an issue",
+		Long: `Attach a file, or the current clipboard content (an image or text), to
+an issue.
+
+Content is stored once under .beads/attachments/, keyed by its SHA-256, and
+recorded on the issue. Attachments larger than ` + attachMaxSizeKey + ` (default
+10MB) are rejected, as is any that would take the attachments directory over
+` + attachMaxTotalKey + ` (default unlimited).
+
+From the clipboard, images are preferred over text. Clipboard helpers:
+  macOS    pngpaste (images), pbpaste (text)
+  Linux    wl-paste (Wayland) or xclip/xsel (X11)
+  Windows  powershell
+
+Examples:
+  bd attach bd-a1b2 ./crash.log
+  bd attach bd-a1b2 ./screenshot.png --name login-error.png
+  bd attach bd-a1b2 --from-clipboard
+  bd attach list bd-a1b2
+  bd attach get bd-a1b2 crash.log -o -`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			if fromClipboard == (len(args) == 2) {
+				return fmt.Errorf("pass either a file path or --from-clipboard")
+			}
+			issue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+
+			now := app.Now()
+			var (
+				data      []byte
+				mediaType string
+			)
+			if fromClipboard {
+				data, mediaType, err = readClipboard(goos, executor)
+				if err != nil {
+					return err
+				}
+				if name == "" {
+					name = "clipboard-" + now.Format("20060102-150405") + attachmentExt(mediaType)
+				}
+			} else {
+				data, err = os.ReadFile(args[1])
+				if err != nil {
+					return err
+				}
+				mediaType = fileMediaType(args[1], data)
+				if name == "" {
+					name = filepath.Base(args[1])
+				}
+			}
+
+			blobs := attachment.New(app.ConfigDir)
+			if err := checkAttachLimits(app, blobs, data); err != nil {
+				return err
+			}
+			key, err := blobs.Put(data)
+			if err != nil {
+				return fmt.Errorf("storing attachment: %w", err)
+			}
+
+			actor, _ := resolveActor(app)
+			att := issuestorage.Attachment{
+				Name:      name,
+				SHA256:    key,
+				Size:      int64(len(data)),
+				MediaType: mediaType,
+				AddedBy:   actor,
+				AddedAt:   now,
+			}
+			if err := app.Storage.Modify(ctx, issue.ID, func(i *issuestorage.Issue) error {
+				i.Attachments = append(i.Attachments, att)
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(ToAttachmentJSON(issue.ID, att))
+			}
+			fmt.Fprintf(app.Out, "%s Attached %s to %s (%s, %s)\n", app.SuccessColor("✓"), att.Name, issue.ID, att.MediaType, formatSize(att.Size))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&fromClipboard, "from-clipboard", false, "Attach the current clipboard content")
+	cmd.Flags().StringVar(&name, "name", "", "Attachment name (default: the file name, or clipboard-<timestamp>.<ext>)")
+
+	cmd.AddCommand(newAttachListCmd(provider))
+	cmd.AddCommand(newAttachGetCmd(provider))
+	cmd.AddCommand(newAttachRemoveCmd(provider))
+
+	return cmd
+}
+
+// newAttachListCmd creates the "attach list" subcommand.
+func newAttachListCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list <id>",
+		Short: "List an issue's attachments",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			issue, err := resolveIssue(app.Storage, cmd.Context(), args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+
+			if app.JSON {
+				out := make([]AttachmentJSON, 0, len(issue.Attachments))
+				for _, a := range issue.Attachments {
+					out = append(out, ToAttachmentJSON(issue.ID, a))
+				}
+				return json.NewEncoder(app.Out).Encode(out)
+			}
+			if len(issue.Attachments) == 0 {
+				fmt.Fprintf(app.Out, "No attachments on %s.\n", issue.ID)
+				return nil
+			}
+			for _, a := range issue.Attachments {
+				hash, err := attachment.ShortKey(a.SHA256)
+				if err != nil {
+					return fmt.Errorf("attachment %q on %s: %w", a.Name, issue.ID, err)
+				}
+				line := fmt.Sprintf("%s  %-8s  %s  %s", hash, formatSize(a.Size), a.AddedAt.Format("2006-01-02"), a.Name)
+				if a.AddedBy != "" {
+					line += "  by " + a.AddedBy
+				}
+				fmt.Fprintln(app.Out, line)
+			}
+			return nil
+		},
+	}
+}
+
+// newAttachGetCmd creates the "attach get" subcommand.
+func newAttachGetCmd(provider *AppProvider) *cobra.Command {
+	var (
+		output string
+		force  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "get <id> <name|hash>",
+		Short: "Save an attachment to a file",
+		Long: `Save an attachment's content, by default to a file of the same name in the
+current directory. Use -o - to write it to stdout.
+
+The attachment is named by its name or a prefix (at least 4 characters) of
+its content hash, as shown by bd attach list.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			issue, err := resolveIssue(app.Storage, cmd.Context(), args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+			i, err := findAttachment(issue, args[1])
+			if err != nil {
+				return err
+			}
+			att := issue.Attachments[i]
+			data, err := attachment.New(app.ConfigDir).Get(att.SHA256)
+			if errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("the content of %s is missing from .beads/%s/", att.Name, attachment.DirName)
+			}
+			if err != nil {
+				return err
+			}
+
+			if output == "-" {
+				_, err := app.Out.Write(data)
+				return err
+			}
+			if output == "" {
+				output = filepath.Base(att.Name)
+			}
+			flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+			if !force {
+				flags |= os.O_EXCL
+			}
+			f, err := os.OpenFile(output, flags, 0644)
+			if errors.Is(err, os.ErrExist) {
+				return fmt.Errorf("%s already exists (pass --force to overwrite)", output)
+			}
+			if err != nil {
+				return err
+			}
+			if _, err := f.Write(data); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(map[string]string{"path": output})
+			}
+			fmt.Fprintf(app.Out, "%s Saved %s to %s (%s)\n", app.SuccessColor("✓"), att.Name, output, formatSize(att.Size))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "File to write (default: the attachment name; - for stdout)")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite an existing file")
+
+	return cmd
+}
+
+// newAttachRemoveCmd creates the "attach remove" subcommand.
+func newAttachRemoveCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <id> <name|hash>",
+		Short: "Remove an attachment from an issue",
+		Long: `Remove an attachment from an issue. Its content is deleted from
+.beads/attachments/ unless another issue still references it.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			issue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+			i, err := findAttachment(issue, args[1])
+			if err != nil {
+				return err
+			}
+			att := issue.Attachments[i]
+			if !attachment.ValidKey(att.SHA256) {
+				return fmt.Errorf("attachment %q on %s has an invalid content hash %q; edit the issue to remove it", att.Name, issue.ID, att.SHA256)
+			}
+			if err := app.Storage.Modify(ctx, issue.ID, func(is *issuestorage.Issue) error {
+				is.Attachments = slices.DeleteFunc(is.Attachments, func(a issuestorage.Attachment) bool {
+					return a.SHA256 == att.SHA256 && a.Name == att.Name && a.AddedAt.Equal(att.AddedAt)
+				})
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			refs, err := attachmentRefs(ctx, app)
+			if err != nil {
+				return err
+			}
+			if !refs[att.SHA256] {
+				if err := attachment.New(app.ConfigDir).Remove(att.SHA256); err != nil {
+					return err
+				}
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(ToAttachmentJSON(issue.ID, att))
+			}
+			fmt.Fprintf(app.Out, "%s Removed %s from %s\n", app.SuccessColor("✓"), att.Name, issue.ID)
+			return nil
+		},
+	}
+}
+
+// formatSize formats a byte count for display.
+func formatSize(n int64) string {
+	switch {
+	case n < 1024:
+		return fmt.Sprintf("%d B", n)
+	case n < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
+	}
+}
@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/attachment"
+	"beads-lite/internal/issuestorage"
+)
+
+// fakeClipboard returns an executor that answers only for the given helper
+// and media-type argument, failing like a missing binary otherwise.
+func fakeClipboard(helper, wantArg string, data []byte) commandExecutor {
+	return func(name string, args ...string) ([]byte, error) {
+		if name != helper {
+			return nil, errors.New("executable file not found")
+		}
+		joined := strings.Join(args, " ")
+		if wantArg != "" && !strings.Contains(joined, wantArg) {
+			return nil, errors.New("target not available")
+		}
+		if wantArg == "" && strings.Contains(joined, "image/png") {
+			return nil, errors.New("target not available")
+		}
+		return data, nil
+	}
+}
+
+func TestAttachFromClipboardImage(t *testing.T) {
+	app, store := setupTestApp(t)
+	app.ConfigDir = t.TempDir()
+	ctx := context.Background()
+
+	id, err := store.Create(ctx, &issuestorage.Issue{Title: "Login page broken"})
+	if err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	png := []byte("\x89PNG\r\n\x1a\nfake")
+	cmd := attachCmd(NewTestProvider(app), fakeClipboard("xclip", "image/png", png), "linux")
+	cmd.SetArgs([]string{id, "--from-clipboard"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("attach failed: %v", err)
+	}
+
+	issue, err := store.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("failed to get issue: %v", err)
+	}
+	if len(issue.Attachments) != 1 {
+		t.Fatalf("expected 1 attachment, got %+v", issue.Attachments)
+	}
+	a := issue.Attachments[0]
+	if a.MediaType != "image/png" || !strings.HasSuffix(a.Name, ".png") || a.Size != int64(len(png)) {
+		t.Errorf("unexpected attachment metadata: %+v", a)
+	}
+	data, err := attachment.New(app.ConfigDir).Get(a.SHA256)
+	if err != nil || !bytes.Equal(data, png) {
+		t.Errorf("blob not stored under %s: %v", filepath.Join(app.ConfigDir, attachment.DirName), err)
+	}
+
+	app.Out = &bytes.Buffer{}
+	if err := outputIssue(app, ctx, issue); err != nil {
+		t.Fatalf("show failed: %v", err)
+	}
+	if out := app.Out.(*bytes.Buffer).String(); !strings.Contains(out, "Attachments (1)") || !strings.Contains(out, a.Name) {
+		t.Errorf("expected attachment in show output, got:\n%s", out)
+	}
+}
+
+func TestAttachFromClipboardText(t *testing.T) {
+	app, store := setupTestApp(t)
+	app.ConfigDir = t.TempDir()
+	ctx := context.Background()
+
+	id, err := store.Create(ctx, &issuestorage.Issue{Title: "Stack trace"})
+	if err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	cmd := attachCmd(NewTestProvider(app), fakeClipboard("pbpaste", "", []byte("panic: nil map")), "darwin")
+	cmd.SetArgs([]string{id, "--from-clipboard", "--name", "trace.txt"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("attach failed: %v", err)
+	}
+	issue, err := store.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("failed to get issue: %v", err)
+	}
+	if len(issue.Attachments) != 1 || issue.Attachments[0].Name != "trace.txt" || issue.Attachments[0].MediaType != "text/plain" {
+		t.Errorf("unexpected attachments: %+v", issue.Attachments)
+	}
+}
+
+func TestReadClipboardErrors(t *testing.T) {
+	none := func(name string, args ...string) ([]byte, error) {
+		return nil, errors.New("executable file not found")
+	}
+	if _, _, err := readClipboard("linux", none); err == nil || !strings.Contains(err.Error(), "wl-paste, xclip, xsel") {
+		t.Errorf("expected error listing tried helpers, got %v", err)
+	}
+	if _, _, err := readClipboard("plan9", none); err == nil {
+		t.Error("expected error for unsupported platform")
+	}
+}
+
+func TestAttachFile(t *testing.T) {
+	app, store := setupTestApp(t)
+	app.ConfigDir = t.TempDir()
+	ctx := context.Background()
+	id, err := store.Create(ctx, &issuestorage.Issue{Title: "Crash on start"})
+	if err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+	other, err := store.Create(ctx, &issuestorage.Issue{Title: "Same crash"})
+	if err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+	src := filepath.Join(t.TempDir(), "crash.txt")
+	if err := os.WriteFile(src, []byte("panic: nil map\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run := func(args ...string) (string, error) {
+		out := &bytes.Buffer{}
+		app.Out = out
+		cmd := newAttachCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	if _, err := run(id, src); err != nil {
+		t.Fatalf("attach failed: %v", err)
+	}
+	if _, err := run(other, src, "--name", "copy.txt"); err != nil {
+		t.Fatalf("attach failed: %v", err)
+	}
+	issue, _ := store.Get(ctx, id)
+	if len(issue.Attachments) != 1 || issue.Attachments[0].Name != "crash.txt" || issue.Attachments[0].MediaType != "text/plain" {
+		t.Fatalf("unexpected attachments: %+v", issue.Attachments)
+	}
+	key := issue.Attachments[0].SHA256
+	if _, err := run(id); err == nil {
+		t.Error("attach without a path or --from-clipboard should fail")
+	}
+	if _, err := run(id, src, "--from-clipboard"); err == nil {
+		t.Error("attach with both a path and --from-clipboard should fail")
+	}
+
+	if out, err := run("list", id); err != nil || !strings.Contains(out, key[:12]) || !strings.Contains(out, "crash.txt") {
+		t.Errorf("list = %q, %v", out, err)
+	}
+	if out, err := run("get", id, key[:8], "-o", "-"); err != nil || out != "panic: nil map\n" {
+		t.Errorf("get by hash = %q, %v", out, err)
+	}
+	dest := filepath.Join(t.TempDir(), "saved.txt")
+	if _, err := run("get", id, "crash.txt", "-o", dest); err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if _, err := run("get", id, "crash.txt", "-o", dest); err == nil {
+		t.Error("get should not overwrite an existing file without --force")
+	}
+	if _, err := run("get", id, "missing.txt"); err == nil || !strings.Contains(err.Error(), "bd attach list") {
+		t.Errorf("get of an unknown attachment = %v", err)
+	}
+
+	// The blob is shared, so it survives until its last reference goes.
+	blobs := attachment.New(app.ConfigDir)
+	if _, err := run("remove", id, "crash.txt"); err != nil {
+		t.Fatalf("remove failed: %v", err)
+	}
+	if issue, _ := store.Get(ctx, id); len(issue.Attachments) != 0 {
+		t.Errorf("attachments after remove: %+v", issue.Attachments)
+	}
+	if !blobs.Has(key) {
+		t.Error("a blob another issue references should be kept")
+	}
+	if _, err := run("remove", other, "copy.txt"); err != nil {
+		t.Fatalf("remove failed: %v", err)
+	}
+	if blobs.Has(key) {
+		t.Error("an unreferenced blob should be deleted")
+	}
+}
+
+func TestAttachSizeLimits(t *testing.T) {
+	app, store := setupTestApp(t)
+	app.ConfigDir = t.TempDir()
+	app.ConfigStore = &mapConfigStore{data: map[string]string{attachMaxSizeKey: "1KB", attachMaxTotalKey: "1500"}}
+	ctx := context.Background()
+	id, err := store.Create(ctx, &issuestorage.Issue{Title: "Big file"})
+	if err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+	dir := t.TempDir()
+	write := func(name string, size int) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, bytes.Repeat([]byte(name[:1]), size), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	attach := func(path string) error {
+		cmd := newAttachCmd(NewTestProvider(app))
+		cmd.SetArgs([]string{id, path})
+		return cmd.Execute()
+	}
+
+	if err := attach(write("a.bin", 1025)); err == nil || !strings.Contains(err.Error(), attachMaxSizeKey) {
+		t.Errorf("over the per-file limit = %v", err)
+	}
+	if err := attach(write("b.bin", 1000)); err != nil {
+		t.Fatalf("attach failed: %v", err)
+	}
+	if err := attach(write("c.bin", 1000)); err == nil || !strings.Contains(err.Error(), attachMaxTotalKey) {
+		t.Errorf("over the total limit = %v", err)
+	}
+	if err := attach(filepath.Join(dir, "b.bin")); err != nil {
+		t.Errorf("re-attaching stored content should not count against the total: %v", err)
+	}
+}
+
+func TestDoctorOrphanedAttachments(t *testing.T) {
+	app, store := setupTestApp(t)
+	app.ConfigDir = t.TempDir()
+	ctx := context.Background()
+	id, err := store.Create(ctx, &issuestorage.Issue{Title: "Has a screenshot"})
+	if err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+	blobs := attachment.New(app.ConfigDir)
+	kept, _ := blobs.Put([]byte("screenshot"))
+	orphan, _ := blobs.Put([]byte("left behind"))
+	if err := store.Modify(ctx, id, func(i *issuestorage.Issue) error {
+		i.Attachments = append(i.Attachments, issuestorage.Attachment{Name: "shot.png", SHA256: kept})
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := attachmentProblems(ctx, app, false)
+	if err != nil || len(problems) != 1 || !strings.Contains(problems[0], orphan) {
+		t.Fatalf("problems = %v, %v", problems, err)
+	}
+	if !blobs.Has(orphan) {
+		t.Error("checking should not delete anything")
+	}
+	if _, err := attachmentProblems(ctx, app, true); err != nil {
+		t.Fatal(err)
+	}
+	if blobs.Has(orphan) || !blobs.Has(kept) {
+		t.Error("--fix should delete only the orphaned blob")
+	}
+}
+
+// TestAttachInvalidHash checks that a content hash read from an issue file
+// is never used as a path or sliced unchecked.
+func TestAttachInvalidHash(t *testing.T) {
+	app, store := setupTestApp(t)
+	app.ConfigDir = t.TempDir()
+	ctx := context.Background()
+	victim := filepath.Join(app.ConfigDir, "victim")
+	if err := os.WriteFile(victim, []byte("keep"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	id, err := store.Create(ctx, &issuestorage.Issue{Title: "Synced", Attachments: []issuestorage.Attachment{
+		{Name: "evil.txt", SHA256: "../victim"},
+		{Name: "short.txt", SHA256: "abc"},
+	}})
+	if err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+	run := func(args ...string) error {
+		app.Out = &bytes.Buffer{}
+		cmd := newAttachCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		return cmd.Execute()
+	}
+
+	if err := run("list", id); err == nil || !strings.Contains(err.Error(), "invalid") {
+		t.Errorf("list = %v, want an invalid hash error", err)
+	}
+	if err := run("remove", id, "evil.txt"); err == nil {
+		t.Error("remove of an attachment with an invalid hash should fail")
+	}
+	if _, err := os.Stat(victim); err != nil {
+		t.Errorf("file outside the attachments directory was removed: %v", err)
+	}
+	if err := run("get", id, "short.txt", "-o", "-"); err == nil {
+		t.Error("get of an attachment with an invalid hash should fail")
+	}
+}
@@ -4,9 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 
-	"beads-lite/internal/graph"
-	"beads-lite/internal/issuestorage"
-
 	"github.com/spf13/cobra"
 )
 
@@ -43,54 +40,25 @@ An issue is blocked if:
 
 			ctx := cmd.Context()
 
-			// List all open issues
-			filter := &issuestorage.ListFilter{
-				Statuses: []issuestorage.Status{issuestorage.StatusOpen},
-			}
-
-			issues, err := app.Storage.List(ctx, filter)
-			if err != nil {
-				return fmt.Errorf("listing issues: %w", err)
-			}
-
-			// Get closed issues to check dependency status
-			closedFilter := &issuestorage.ListFilter{
-				Statuses: []issuestorage.Status{issuestorage.StatusClosed},
-			}
-			closedIssues, err := app.Storage.List(ctx, closedFilter)
+			issues, err := app.Storage.ListBlocked(ctx, nil, cascadeEnabled(app))
 			if err != nil {
-				return fmt.Errorf("listing closed issues: %w", err)
-			}
-			closedSet := make(map[string]bool)
-			for _, issue := range closedIssues {
-				closedSet[issue.ID] = true
+				return err
 			}
 
-			// Read cascade config flag
-			cascade := cascadeEnabled(app)
-
 			// Find blocked issues and what they're waiting on
 			blocked := []BlockedIssueJSON{} // Initialize as empty slice (marshals to [] not null)
 			for _, issue := range issues {
-				result, err := graph.EffectiveBlockers(ctx, app.Storage, issue, closedSet, cascade)
-				if err != nil {
-					return fmt.Errorf("checking blockers for %s: %w", issue.ID, err)
-				}
-				if !result.HasBlockers() {
-					continue
-				}
-
 				var inheritedJSON []InheritedBlockerShowJSON
-				for _, ib := range result.Inherited {
+				for _, ib := range issue.Blockers.Inherited {
 					inheritedJSON = append(inheritedJSON, InheritedBlockerShowJSON{
 						AncestorID: ib.AncestorID,
 						BlockerID:  ib.BlockerID,
 					})
 				}
 
-				allIDs := result.AllBlockerIDs()
+				allIDs := issue.Blockers.AllBlockerIDs()
 				blocked = append(blocked, BlockedIssueJSON{
-					BlockedBy:         result.Direct,
+					BlockedBy:         issue.Blockers.Direct,
 					BlockedByCount:    len(allIDs),
 					InheritedBlockers: inheritedJSON,
 					CreatedAt:         formatTime(issue.CreatedAt),
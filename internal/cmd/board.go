@@ -0,0 +1,430 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// Config keys selecting the fields that lay out bd board.
+const (
+	boardRowsKey    = "board.rows"
+	boardColumnsKey = "board.columns"
+)
+
+// boardFields lists the fields a board can be laid out by. Issues with
+// several labels appear in each of their label's lanes or columns.
+var boardFields = []string{"none", "status", "priority", "type", "assignee", "epic", "label"}
+
+// boardDefaultStatuses are the status columns shown even when empty.
+var boardDefaultStatuses = []issuestorage.Status{
+	issuestorage.StatusOpen, issuestorage.StatusInProgress,
+	issuestorage.StatusBlocked, issuestorage.StatusDeferred,
+}
+
+// boardColumnWidth is the width of a column in text output.
+const boardColumnWidth = 32
+
+// BoardColumnJSON is one cell of a board: a column within a lane.
+// Value is "" for the bucket of issues with the field unset.
+type BoardColumnJSON struct {
+	Value  string          `json:"value"`
+	Issues []IssueListJSON `json:"issues"`
+}
+
+// BoardLaneJSON is one swimlane (row) of a board. Title is set for epic
+// lanes. A board without swimlanes has a single lane with an empty Value.
+type BoardLaneJSON struct {
+	Value   string            `json:"value"`
+	Title   string            `json:"title,omitempty"`
+	Columns []BoardColumnJSON `json:"columns"`
+	Total   int               `json:"total"`
+}
+
+// BoardJSON is the JSON output of bd board. Every lane lists the same
+// columns, in the order given by Values.
+type BoardJSON struct {
+	Rows    string          `json:"rows"`
+	Columns string          `json:"columns"`
+	Values  []string        `json:"column_values"`
+	Lanes   []BoardLaneJSON `json:"lanes"`
+	Total   int             `json:"total"`
+}
+
+// newBoardCmd creates the board command.
+func newBoardCmd(provider *AppProvider) *cobra.Command {
+	var (
+		all         bool
+		rows        string
+		columns     string
+		noContext   bool
+		contextName string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "board",
+		Short: "Show issues as a board of columns and swimlanes",
+		Long: `Show issues laid out in columns, optionally split into swimlanes.
+
+The layout comes from config and can be overridden per invocation:
+  board.columns  field for columns (default: status)
+  board.rows     field for swimlanes (default: none)
+
+Fields: ` + strings.Join(boardFields, ", ") + `. --group-by is another
+name for --rows. Issues are grouped by epic through their nearest epic
+ancestor. Issues with the field unset are grouped under "(none)". Open
+issues waiting on unresolved blockers (see bd blocked) are grouped under
+blocked. Within a cell, issues are ordered by priority.
+
+By default only open (non-closed) issues are included, scoped by the
+active filter context (see bd context).
+
+Examples:
+  bd board
+  bd board --rows epic
+  bd board --rows assignee --columns priority
+  bd board --group-by label --all
+  bd config set board.rows epic
+  bd board --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			if rows == "" {
+				rows = boardConfigField(app, boardRowsKey, "none")
+			}
+			if columns == "" {
+				columns = boardConfigField(app, boardColumnsKey, "status")
+			}
+			for _, field := range []string{rows, columns} {
+				if !contains(boardFields, field) {
+					return fmt.Errorf("invalid board field %q (valid: %s)", field, strings.Join(boardFields, ", "))
+				}
+			}
+
+			scope, err := activeContextFilter(app, noContext, contextName)
+			if err != nil {
+				return err
+			}
+
+			issues, err := app.Storage.List(ctx, nil)
+			if err != nil {
+				return fmt.Errorf("listing issues: %w", err)
+			}
+			if all {
+				closed, err := app.Storage.List(ctx, &issuestorage.ListFilter{Statuses: []issuestorage.Status{issuestorage.StatusClosed}})
+				if err != nil {
+					return fmt.Errorf("listing closed issues: %w", err)
+				}
+				issues = append(issues, closed...)
+			}
+			issues = scopeIssues(issues, scope)
+			if err := sortIssues(issues, "priority", false); err != nil {
+				return err
+			}
+
+			board, err := buildBoard(ctx, app, issues, rows, columns, all)
+			if err != nil {
+				return err
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(board)
+			}
+			fmt.Fprint(app.Out, formatBoard(board))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "Include closed issues")
+	cmd.Flags().StringVar(&rows, "rows", "", "Field for swimlanes (default: board.rows config, or none)")
+	cmd.Flags().StringVar(&rows, "group-by", "", "Same as --rows")
+	cmd.Flags().StringVar(&columns, "columns", "", "Field for columns (default: board.columns config, or status)")
+	addContextFlags(cmd, &noContext, &contextName)
+
+	return cmd
+}
+
+// boardConfigField returns the board field configured under key, or def.
+func boardConfigField(app *App, key, def string) string {
+	if app.ConfigStore == nil {
+		return def
+	}
+	if v, ok := app.ConfigStore.Get(key); ok && v != "" {
+		return v
+	}
+	return def
+}
+
+// validateBoardField is the config validator for the board layout keys.
+func validateBoardField(key string) func(string) string {
+	return func(v string) string {
+		if !contains(boardFields, v) {
+			return fmt.Sprintf("%s: invalid value %q (valid: %s)", key, v, strings.Join(boardFields, ", "))
+		}
+		return ""
+	}
+}
+
+// buildBoard groups issues into lanes by rows and columns by columns.
+// Lanes with no issues are omitted; columns are shared by every lane so the
+// board stays a grid.
+func buildBoard(ctx context.Context, app *App, issues []*issuestorage.Issue, rows, columns string, includeClosed bool) (BoardJSON, error) {
+	board := BoardJSON{Rows: rows, Columns: columns, Lanes: []BoardLaneJSON{}, Total: len(issues)}
+	epics := &boardEpics{ctx: ctx, app: app, cache: make(map[string]*issuestorage.Issue)}
+	if rows == "status" || columns == "status" {
+		blocked, err := app.Storage.ListBlocked(ctx, nil, cascadeEnabled(app))
+		if err != nil {
+			return board, fmt.Errorf("finding blocked issues: %w", err)
+		}
+		epics.blocked = make(map[string]bool, len(blocked))
+		for _, b := range blocked {
+			epics.blocked[b.ID] = true
+		}
+	}
+
+	var colValues []string
+	seenCols := make(map[string]bool)
+	if columns == "status" {
+		for _, s := range boardDefaultStatuses {
+			colValues = append(colValues, string(s))
+			seenCols[string(s)] = true
+		}
+		if includeClosed {
+			colValues = append(colValues, string(issuestorage.StatusClosed))
+			seenCols[string(issuestorage.StatusClosed)] = true
+		}
+	}
+	if columns == "priority" {
+		for p := issuestorage.PriorityCritical; p <= issuestorage.PriorityBacklog; p++ {
+			colValues = append(colValues, p.Display())
+			seenCols[p.Display()] = true
+		}
+	}
+
+	type cellKey struct{ row, col string }
+	cells := make(map[cellKey][]*issuestorage.Issue)
+	var rowValues []string
+	seenRows := make(map[string]bool)
+	for _, issue := range issues {
+		rowKeys := epics.values(issue, rows)
+		colKeys := epics.values(issue, columns)
+		for _, r := range rowKeys {
+			if !seenRows[r] {
+				seenRows[r] = true
+				rowValues = append(rowValues, r)
+			}
+			for _, c := range colKeys {
+				cells[cellKey{r, c}] = append(cells[cellKey{r, c}], issue)
+			}
+		}
+		for _, c := range colKeys {
+			if !seenCols[c] {
+				seenCols[c] = true
+				colValues = append(colValues, c)
+			}
+		}
+	}
+	sortBoardValues(rowValues, rows)
+	sortBoardValues(colValues, columns)
+	board.Values = colValues
+	if board.Values == nil {
+		board.Values = []string{}
+	}
+
+	for _, r := range rowValues {
+		lane := BoardLaneJSON{Value: r}
+		if rows == "epic" && r != "" {
+			if epic := epics.cache[r]; epic != nil {
+				lane.Title = epic.Title
+			}
+		}
+		counted := make(map[string]bool)
+		for _, c := range colValues {
+			col := BoardColumnJSON{Value: c, Issues: []IssueListJSON{}}
+			for _, issue := range cells[cellKey{r, c}] {
+				col.Issues = append(col.Issues, ToIssueListJSON(issue))
+				counted[issue.ID] = true
+			}
+			lane.Columns = append(lane.Columns, col)
+		}
+		lane.Total = len(counted)
+		board.Lanes = append(board.Lanes, lane)
+	}
+	return board, nil
+}
+
+// sortBoardValues orders a field's values: statuses in workflow order,
+// priorities P0 first, and anything else alphabetically, with the unset
+// bucket last.
+func sortBoardValues(values []string, field string) {
+	order := make(map[string]int)
+	switch field {
+	case "status":
+		for i, s := range issuestorage.BuiltinStatuses {
+			order[string(s)] = i
+		}
+	case "priority":
+		for p := issuestorage.PriorityCritical; p <= issuestorage.PriorityBacklog; p++ {
+			order[p.Display()] = int(p)
+		}
+	}
+	sort.SliceStable(values, func(i, j int) bool {
+		a, b := values[i], values[j]
+		if a == "" || b == "" {
+			return a != "" && b == ""
+		}
+		oa, okA := order[a]
+		ob, okB := order[b]
+		if okA != okB {
+			return okA
+		}
+		if okA && oa != ob {
+			return oa < ob
+		}
+		return a < b
+	})
+}
+
+// boardEpics finds and caches the nearest epic ancestor of issues.
+// blocked holds the open issues with unresolved blockers.
+type boardEpics struct {
+	ctx     context.Context
+	app     *App
+	cache   map[string]*issuestorage.Issue
+	blocked map[string]bool
+}
+
+// values returns the board values of issue for field: one value for most
+// fields, one per label for label, and "" when the field is unset. The
+// none field puts every issue under "".
+func (e *boardEpics) values(issue *issuestorage.Issue, field string) []string {
+	switch field {
+	case "status":
+		if issue.Status == issuestorage.StatusOpen && e.blocked[issue.ID] {
+			return []string{string(issuestorage.StatusBlocked)}
+		}
+		return []string{string(issue.Status)}
+	case "priority":
+		return []string{issue.Priority.Display()}
+	case "type":
+		return []string{string(issue.Type)}
+	case "assignee":
+		return []string{issue.Assignee}
+	case "epic":
+		if epic := e.epicOf(issue); epic != nil {
+			return []string{epic.ID}
+		}
+		return []string{""}
+	case "label":
+		if len(issue.Labels) > 0 {
+			return issue.Labels
+		}
+		return []string{""}
+	}
+	return []string{""}
+}
+
+// epicOf returns the nearest epic ancestor of issue (or issue itself if it
+// is an epic), or nil if it has none.
+func (e *boardEpics) epicOf(issue *issuestorage.Issue) *issuestorage.Issue {
+	seen := make(map[string]bool)
+	for issue != nil && !seen[issue.ID] {
+		seen[issue.ID] = true
+		if issue.Type == issuestorage.TypeEpic {
+			e.cache[issue.ID] = issue
+			return issue
+		}
+		if issue.Parent == "" {
+			return nil
+		}
+		parent, ok := e.cache[issue.Parent]
+		if !ok {
+			parent, _ = e.app.Storage.Get(e.ctx, issue.Parent)
+			e.cache[issue.Parent] = parent
+		}
+		issue = parent
+	}
+	return nil
+}
+
+// formatBoard renders the board as side-by-side text columns, one block
+// per swimlane.
+func formatBoard(b BoardJSON) string {
+	var sb strings.Builder
+	if b.Total == 0 {
+		return "No issues found.\n"
+	}
+
+	for i, lane := range b.Lanes {
+		if b.Rows != "none" {
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			name := boardValueLabel(lane.Value)
+			if lane.Title != "" {
+				name += " · " + lane.Title
+			}
+			fmt.Fprintf(&sb, "== %s: %s (%d) ==\n", b.Rows, name, lane.Total)
+		}
+
+		var header []string
+		height := 0
+		for _, col := range lane.Columns {
+			header = append(header, fmt.Sprintf("%s (%d)", boardValueLabel(col.Value), len(col.Issues)))
+			if len(col.Issues) > height {
+				height = len(col.Issues)
+			}
+		}
+		writeBoardLine(&sb, header)
+		rule := make([]string, len(lane.Columns))
+		for j := range rule {
+			rule[j] = strings.Repeat("─", boardColumnWidth-2)
+		}
+		writeBoardLine(&sb, rule)
+		for row := 0; row < height; row++ {
+			line := make([]string, len(lane.Columns))
+			for j, col := range lane.Columns {
+				if row < len(col.Issues) {
+					issue := col.Issues[row]
+					line[j] = fmt.Sprintf("%s P%d %s", issue.ID, issue.Priority, issue.Title)
+				}
+			}
+			writeBoardLine(&sb, line)
+		}
+	}
+
+	fmt.Fprintf(&sb, "\n%d issue(s)\n", b.Total)
+	return sb.String()
+}
+
+// writeBoardLine writes cells padded or truncated to the column width.
+func writeBoardLine(sb *strings.Builder, cells []string) {
+	var line strings.Builder
+	for _, cell := range cells {
+		cell = truncateDesc(cell, boardColumnWidth-2)
+		line.WriteString(cell)
+		line.WriteString(strings.Repeat(" ", boardColumnWidth-utf8.RuneCountInString(cell)))
+	}
+	sb.WriteString(strings.TrimRight(line.String(), " "))
+	sb.WriteString("\n")
+}
+
+// boardValueLabel returns the text label of a board value.
+func boardValueLabel(v string) string {
+	if v == "" {
+		return "(none)"
+	}
+	return v
+}
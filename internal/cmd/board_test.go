@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// runBoard runs bd board with args and decodes its JSON output.
+func runBoard(t *testing.T, app *App, args ...string) BoardJSON {
+	t.Helper()
+	app.JSON = true
+	app.Out.(*bytes.Buffer).Reset()
+	cmd := newBoardCmd(NewTestProvider(app))
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("board %v failed: %v", args, err)
+	}
+	var got BoardJSON
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &got); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	return got
+}
+
+// boardCell returns the IDs in a lane's column.
+func boardCell(lane BoardLaneJSON, value string) []string {
+	var ids []string
+	for _, col := range lane.Columns {
+		if col.Value == value {
+			for _, issue := range col.Issues {
+				ids = append(ids, issue.ID)
+			}
+		}
+	}
+	return ids
+}
+
+func TestBoardDefaultLayout(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+
+	open, _ := store.Create(ctx, &issuestorage.Issue{Title: "Open", Priority: issuestorage.PriorityLow})
+	urgent, _ := store.Create(ctx, &issuestorage.Issue{Title: "Urgent", Priority: issuestorage.PriorityCritical})
+	wip, _ := store.Create(ctx, &issuestorage.Issue{Title: "WIP", Status: issuestorage.StatusInProgress})
+
+	got := runBoard(t, app)
+	if got.Rows != "none" || got.Columns != "status" {
+		t.Errorf("layout = %s/%s, want none/status", got.Rows, got.Columns)
+	}
+	if want := "open,in_progress,blocked,deferred"; strings.Join(got.Values, ",") != want {
+		t.Errorf("columns = %v, want %s", got.Values, want)
+	}
+	if len(got.Lanes) != 1 || got.Total != 3 {
+		t.Fatalf("expected one lane of 3 issues, got %+v", got)
+	}
+	if ids := boardCell(got.Lanes[0], "open"); strings.Join(ids, ",") != urgent+","+open {
+		t.Errorf("open column = %v, want [%s %s]", ids, urgent, open)
+	}
+	if ids := boardCell(got.Lanes[0], "in_progress"); len(ids) != 1 || ids[0] != wip {
+		t.Errorf("in_progress column = %v, want [%s]", ids, wip)
+	}
+}
+
+func TestBoardGroupByWithDependencyBlocked(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+
+	blocker, _ := store.Create(ctx, &issuestorage.Issue{Title: "Blocker", Assignee: "alice"})
+	waiting, _ := store.Create(ctx, &issuestorage.Issue{Title: "Waiting", Assignee: "alice"})
+	if err := store.AddDependency(ctx, waiting, blocker, issuestorage.DepTypeBlocks); err != nil {
+		t.Fatal(err)
+	}
+
+	got := runBoard(t, app, "--group-by", "assignee")
+	if got.Rows != "assignee" || len(got.Lanes) != 1 || got.Lanes[0].Value != "alice" {
+		t.Fatalf("expected one alice lane, got %+v", got)
+	}
+	if ids := boardCell(got.Lanes[0], "open"); len(ids) != 1 || ids[0] != blocker {
+		t.Errorf("open column = %v, want [%s]", ids, blocker)
+	}
+	if ids := boardCell(got.Lanes[0], "blocked"); len(ids) != 1 || ids[0] != waiting {
+		t.Errorf("blocked column = %v, want [%s]", ids, waiting)
+	}
+}
+
+func TestBoardSwimlanesFromConfig(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	app.ConfigStore = &mapConfigStore{data: map[string]string{
+		boardRowsKey:    "epic",
+		boardColumnsKey: "priority",
+	}}
+
+	epic, _ := store.Create(ctx, &issuestorage.Issue{Title: "Auth rewrite", Type: issuestorage.TypeEpic})
+	task, _ := store.Create(ctx, &issuestorage.Issue{Title: "Login form", Priority: issuestorage.PriorityHigh})
+	sub, _ := store.Create(ctx, &issuestorage.Issue{Title: "Validation", Priority: issuestorage.PriorityHigh})
+	for child, parent := range map[string]string{task: epic, sub: task} {
+		if err := store.AddDependency(ctx, child, parent, issuestorage.DepTypeParentChild); err != nil {
+			t.Fatal(err)
+		}
+	}
+	loose, _ := store.Create(ctx, &issuestorage.Issue{Title: "Loose", Priority: issuestorage.PriorityMedium})
+
+	got := runBoard(t, app)
+	if got.Rows != "epic" || got.Columns != "priority" {
+		t.Fatalf("layout = %s/%s, want epic/priority", got.Rows, got.Columns)
+	}
+	if len(got.Values) != 5 || got.Values[0] != "P0" {
+		t.Errorf("columns = %v, want P0..P4", got.Values)
+	}
+	if len(got.Lanes) != 2 {
+		t.Fatalf("expected 2 lanes, got %+v", got.Lanes)
+	}
+	lane := got.Lanes[0]
+	if lane.Value != epic || lane.Title != "Auth rewrite" || lane.Total != 3 {
+		t.Errorf("epic lane = %s %q total %d", lane.Value, lane.Title, lane.Total)
+	}
+	if ids := boardCell(lane, "P1"); strings.Join(ids, ",") != task+","+sub {
+		t.Errorf("epic/P1 = %v, want [%s %s]", ids, task, sub)
+	}
+	if got.Lanes[1].Value != "" || strings.Join(boardCell(got.Lanes[1], "P2"), ",") != loose {
+		t.Errorf("unset lane = %+v, want %s under P2", got.Lanes[1], loose)
+	}
+
+	// Flags override config.
+	got = runBoard(t, app, "--rows", "none", "--columns", "status")
+	if got.Rows != "none" || len(got.Lanes) != 1 {
+		t.Errorf("override layout = %s with %d lanes", got.Rows, len(got.Lanes))
+	}
+}
+
+func TestBoardLabelLanes(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	both, _ := store.Create(ctx, &issuestorage.Issue{Title: "Both", Labels: []string{"ui", "api"}})
+	none, _ := store.Create(ctx, &issuestorage.Issue{Title: "None"})
+
+	got := runBoard(t, app, "--rows", "label")
+	var values []string
+	for _, lane := range got.Lanes {
+		values = append(values, lane.Value)
+	}
+	if strings.Join(values, ",") != "api,ui," {
+		t.Fatalf("lanes = %q, want api, ui, unset", values)
+	}
+	if ids := boardCell(got.Lanes[1], "open"); len(ids) != 1 || ids[0] != both {
+		t.Errorf("ui lane = %v, want [%s]", ids, both)
+	}
+	if ids := boardCell(got.Lanes[2], "open"); len(ids) != 1 || ids[0] != none {
+		t.Errorf("unset lane = %v, want [%s]", ids, none)
+	}
+	if got.Total != 2 {
+		t.Errorf("total = %d, want 2", got.Total)
+	}
+}
+
+func TestBoardText(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	id, _ := store.Create(ctx, &issuestorage.Issue{Title: "Fix login", Assignee: "alice", Priority: issuestorage.PriorityMedium})
+
+	cmd := newBoardCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--rows", "assignee"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("board failed: %v", err)
+	}
+	out := app.Out.(*bytes.Buffer).String()
+	for _, want := range []string{"== assignee: alice (1) ==", "open (1)", "blocked (0)", id + " P2 Fix login", "1 issue(s)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestBoardInvalidField(t *testing.T) {
+	app, _ := setupTestApp(t)
+	cmd := newBoardCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--rows", "color"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error for invalid field")
+	}
+	if msg := configValidators[boardRowsKey]("color"); msg == "" {
+		t.Error("expected config validator to reject invalid field")
+	}
+}
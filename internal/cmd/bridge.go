@@ -0,0 +1,568 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// githubRefKey is the ExternalRefs key under which an issue records its
+// GitHub counterpart, as "owner/repo#12".
+const githubRefKey = "github"
+
+// githubRepoKey is the config key naming the repository bd bridge github
+// works against when --repo is not given.
+const githubRepoKey = "github.repo"
+
+// githubListLimit caps how many GitHub issues one bridge run reads.
+const githubListLimit = 1000
+
+// GitHubBridgeJSON is the JSON output format for bd bridge github.
+type GitHubBridgeJSON struct {
+	Repo   string             `json:"repo"`
+	DryRun bool               `json:"dry_run,omitempty"`
+	Pushed []GitHubChangeJSON `json:"pushed,omitempty"` // GitHub issues created or updated
+	Pulled []GitHubChangeJSON `json:"pulled,omitempty"` // local issues created or updated
+}
+
+// GitHubChangeJSON is one issue the bridge created or updated.
+type GitHubChangeJSON struct {
+	ID     string `json:"id"`
+	Number int    `json:"number"`
+	Action string `json:"action"` // "created" or "updated"
+}
+
+// newBridgeCmd creates the bridge command.
+func newBridgeCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bridge",
+		Short: "Sync issues with other issue trackers",
+		Long: `Keep beads issues and their counterparts in another tracker in step.
+
+Each linked issue records its counterpart in external_refs, keyed by
+tracker, so links survive export, import and merges.
+
+Subcommands:
+  github  Sync with GitHub Issues through the gh CLI`,
+	}
+
+	cmd.AddCommand(newBridgeGitHubCmd(provider))
+
+	return cmd
+}
+
+func newBridgeGitHubCmd(provider *AppProvider) *cobra.Command {
+	return bridgeGitHubCmd(provider, defaultCommandExecutor)
+}
+
+// bridgeGitHubCmd builds the bridge github command. Separated from
+// newBridgeGitHubCmd so tests can inject a fake gh.
+func bridgeGitHubCmd(provider *AppProvider, executor commandExecutor) *cobra.Command {
+	var (
+		repo   string
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "github",
+		Short: "Sync issues with GitHub Issues",
+		Long: `Map beads issues to GitHub issues and keep their title, description,
+open/closed state, labels and assignee in step.
+
+  push  Create a GitHub issue for each open issue not yet linked, and
+        update linked GitHub issues to match the local ones
+  pull  Create a local issue for each open GitHub issue not yet linked,
+        and update linked local issues to match GitHub
+  sync  Both: each linked pair that differs takes the side updated
+        later, then unlinked open issues are created on the other side
+
+The GitHub issue number is stored on the beads issue as external_refs
+github ("owner/repo#12"). A closed issue maps to a closed GitHub issue
+and every other status to open. Deleted issues are left alone on both
+sides. Labels must already exist in the repository.
+
+People are matched through github.user.<login> entries in config, each
+naming the local identity of a GitHub login: pulled assignees and authors
+become local identities, and pushed assignees become logins. Unmapped
+names are used as they are.
+
+All GitHub access goes through the gh CLI, which must be installed and
+authenticated (gh auth login, or a token in GH_TOKEN). The repository is
+--repo, else github.repo in config, else the one gh finds for the
+current directory.
+
+Examples:
+  bd bridge github push
+  bd bridge github push bd-a1b2 --repo acme/web
+  bd bridge github pull --dry-run
+  bd config set github.repo acme/web && bd bridge github sync
+  bd config set github.user.octocat alice@example.com`,
+	}
+
+	cmd.PersistentFlags().StringVar(&repo, "repo", "", "GitHub repository as owner/name (default: github.repo config, or gh's current repository)")
+	cmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Report what would change without writing either side")
+
+	run := func(mode string) func(*cobra.Command, []string) error {
+		return func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			b := &githubBridge{app: app, executor: executor, repo: repo, dryRun: dryRun}
+			result, err := b.run(cmd.Context(), mode, args)
+			if err != nil {
+				return err
+			}
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(result)
+			}
+			printGitHubBridge(app, result)
+			return nil
+		}
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "push [issue-id...]",
+		Short: "Create and update GitHub issues from local ones",
+		Long: `Create a GitHub issue for each open issue not yet linked, and update
+every linked GitHub issue to match its local issue. Given issue IDs,
+only those are pushed, and a closed one is created closed.`,
+		RunE: run("push"),
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "pull",
+		Short: "Create and update local issues from GitHub",
+		Args:  cobra.NoArgs,
+		RunE:  run("pull"),
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "sync",
+		Short: "Push and pull, keeping the side updated later",
+		Args:  cobra.NoArgs,
+		RunE:  run("sync"),
+	})
+
+	return cmd
+}
+
+// githubIssue is a GitHub issue as gh issue list --json reports it.
+type githubIssue struct {
+	Number    int           `json:"number"`
+	Title     string        `json:"title"`
+	Body      string        `json:"body"`
+	State     string        `json:"state"` // "OPEN" or "CLOSED"
+	Author    githubUser    `json:"author"`
+	Labels    []githubLabel `json:"labels"`
+	Assignees []githubUser  `json:"assignees"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+}
+
+type githubUser struct {
+	Login string `json:"login"`
+}
+
+type githubLabel struct {
+	Name string `json:"name"`
+}
+
+// githubFields are the fields the bridge keeps in step.
+type githubFields struct {
+	Title    string
+	Body     string
+	Closed   bool
+	Labels   []string // sorted
+	Assignee string
+}
+
+// localGitHubFields returns the fields of a local issue. The assignee is
+// named by the identity users maps its login to, so that "alice" and
+// "alice@example.com" compare equal when both are the same login.
+func localGitHubFields(issue *issuestorage.Issue, users userMap) githubFields {
+	labels := slices.Clone(issue.Labels)
+	slices.Sort(labels)
+	return githubFields{
+		Title:    issue.Title,
+		Body:     issue.Description,
+		Closed:   issue.Status == issuestorage.StatusClosed,
+		Labels:   labels,
+		Assignee: users.toLocal(users.toRemote(issue.Assignee)),
+	}
+}
+
+// remoteGitHubFields returns the fields of a GitHub issue, with the
+// assignee's login translated to a local identity through users.
+func remoteGitHubFields(gi *githubIssue, users userMap) githubFields {
+	f := githubFields{Title: gi.Title, Body: gi.Body, Closed: gi.State == "CLOSED"}
+	for _, l := range gi.Labels {
+		f.Labels = append(f.Labels, l.Name)
+	}
+	slices.Sort(f.Labels)
+	if len(gi.Assignees) > 0 {
+		f.Assignee = users.toLocal(gi.Assignees[0].Login)
+	}
+	return f
+}
+
+func (f githubFields) equal(o githubFields) bool {
+	return f.Title == o.Title && f.Body == o.Body && f.Closed == o.Closed &&
+		slices.Equal(f.Labels, o.Labels) && f.Assignee == o.Assignee
+}
+
+// githubBridge runs one push, pull or sync against a repository.
+type githubBridge struct {
+	app      *App
+	executor commandExecutor
+	repo     string
+	dryRun   bool
+	users    userMap
+	result   GitHubBridgeJSON
+}
+
+func (b *githubBridge) run(ctx context.Context, mode string, ids []string) (*GitHubBridgeJSON, error) {
+	if err := b.resolveRepo(); err != nil {
+		return nil, err
+	}
+	b.result = GitHubBridgeJSON{Repo: b.repo, DryRun: b.dryRun}
+	b.users = loadUserMap(b.app, githubRefKey)
+
+	remote, err := b.listRemote()
+	if err != nil {
+		return nil, err
+	}
+	locals, err := b.listLocal(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// Linked pairs first, then whatever is unlinked on either side.
+	linked := make(map[int]bool)
+	for _, issue := range locals {
+		n, ok := b.linkedNumber(issue)
+		if !ok {
+			continue
+		}
+		linked[n] = true
+		gi, ok := remote[n]
+		if !ok || issue.Status == issuestorage.StatusTombstone || localGitHubFields(issue, b.users).equal(remoteGitHubFields(gi, b.users)) {
+			continue
+		}
+		push := mode == "push" || (mode == "sync" && !gi.UpdatedAt.After(issue.UpdatedAt))
+		if push {
+			err = b.updateRemote(issue, gi)
+		} else {
+			err = b.updateLocal(ctx, issue, gi)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if mode != "pull" {
+		for _, issue := range locals {
+			if _, ok := b.linkedNumber(issue); ok || issue.Status == issuestorage.StatusTombstone {
+				continue
+			}
+			if issue.Status == issuestorage.StatusClosed && len(ids) == 0 {
+				continue
+			}
+			if err := b.createRemote(ctx, issue); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if mode != "push" {
+		for _, n := range slices.Sorted(maps.Keys(remote)) {
+			gi := remote[n]
+			if linked[n] || gi.State == "CLOSED" {
+				continue
+			}
+			if err := b.createLocal(ctx, gi); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &b.result, nil
+}
+
+// gh runs the gh CLI, explaining the likely causes when it fails.
+func (b *githubBridge) gh(args ...string) ([]byte, error) {
+	out, err := b.executor("gh", args...)
+	if err == nil {
+		return out, nil
+	}
+	if errors.Is(err, exec.ErrNotFound) {
+		return nil, errors.New("bd bridge github needs the gh CLI (https://cli.github.com), authenticated with gh auth login or GH_TOKEN")
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		err = errors.New(strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return nil, fmt.Errorf("gh %s %s: %w", args[0], args[1], err)
+}
+
+// resolveRepo settles the repository from --repo, config or gh.
+func (b *githubBridge) resolveRepo() error {
+	if b.repo == "" && b.app.ConfigStore != nil {
+		b.repo, _ = b.app.ConfigStore.Get(githubRepoKey)
+	}
+	if b.repo == "" {
+		out, err := b.gh("repo", "view", "--json", "nameWithOwner")
+		if err != nil {
+			return fmt.Errorf("finding the GitHub repository (set --repo or %s): %w", githubRepoKey, err)
+		}
+		var view struct {
+			NameWithOwner string `json:"nameWithOwner"`
+		}
+		if err := json.Unmarshal(out, &view); err != nil {
+			return fmt.Errorf("parsing gh repo view output: %w", err)
+		}
+		b.repo = view.NameWithOwner
+	}
+	return checkGitHubRepo(b.repo)
+}
+
+// checkGitHubRepo validates a repository given as owner/name.
+func checkGitHubRepo(repo string) error {
+	if owner, name, ok := strings.Cut(repo, "/"); !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return fmt.Errorf("invalid GitHub repository %q: expected owner/name", repo)
+	}
+	return nil
+}
+
+// listRemote returns the repository's issues by number.
+func (b *githubBridge) listRemote() (map[int]*githubIssue, error) {
+	out, err := b.gh("issue", "list", "--repo", b.repo, "--state", "all",
+		"--limit", strconv.Itoa(githubListLimit),
+		"--json", "number,title,body,state,author,labels,assignees,updatedAt")
+	if err != nil {
+		return nil, err
+	}
+	var list []*githubIssue
+	if err := json.Unmarshal(out, &list); err != nil {
+		return nil, fmt.Errorf("parsing gh issue list output: %w", err)
+	}
+	remote := make(map[int]*githubIssue, len(list))
+	for _, gi := range list {
+		remote[gi.Number] = gi
+	}
+	return remote, nil
+}
+
+// listLocal returns the issues named by ids, or every issue that is not
+// ephemeral. Deleted issues are included so their links are known.
+func (b *githubBridge) listLocal(ctx context.Context, ids []string) ([]*issuestorage.Issue, error) {
+	if len(ids) > 0 {
+		var issues []*issuestorage.Issue
+		for _, id := range ids {
+			issue, err := b.app.Storage.Get(ctx, id)
+			if err != nil {
+				return nil, fmt.Errorf("getting %s: %w", id, err)
+			}
+			issues = append(issues, issue)
+		}
+		return issues, nil
+	}
+	var issues []*issuestorage.Issue
+	for _, filter := range []*issuestorage.ListFilter{
+		nil,
+		{Statuses: []issuestorage.Status{issuestorage.StatusClosed}},
+		{Statuses: []issuestorage.Status{issuestorage.StatusTombstone}},
+	} {
+		found, err := b.app.Storage.List(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("listing issues: %w", err)
+		}
+		for _, issue := range found {
+			if !issue.Ephemeral {
+				issues = append(issues, issue)
+			}
+		}
+	}
+	return issues, nil
+}
+
+// linkedNumber returns the number of issue's counterpart in this
+// repository, if it has one.
+func (b *githubBridge) linkedNumber(issue *issuestorage.Issue) (int, bool) {
+	repo, number, ok := strings.Cut(issue.ExternalRefs[githubRefKey], "#")
+	if !ok || repo != b.repo {
+		return 0, false
+	}
+	n, err := strconv.Atoi(number)
+	return n, err == nil
+}
+
+func (b *githubBridge) createRemote(ctx context.Context, issue *issuestorage.Issue) error {
+	change := GitHubChangeJSON{ID: issue.ID, Action: "created"}
+	if b.dryRun {
+		b.result.Pushed = append(b.result.Pushed, change)
+		return nil
+	}
+	f := localGitHubFields(issue, b.users)
+	args := []string{"issue", "create", "--repo", b.repo, "--title", f.Title, "--body", f.Body}
+	for _, l := range f.Labels {
+		args = append(args, "--label", l)
+	}
+	if f.Assignee != "" {
+		args = append(args, "--assignee", b.users.toRemote(f.Assignee))
+	}
+	out, err := b.gh(args...)
+	if err != nil {
+		return fmt.Errorf("creating GitHub issue for %s: %w", issue.ID, err)
+	}
+	// gh prints the new issue's URL, which ends in its number.
+	url := strings.TrimSpace(string(out))
+	n, err := strconv.Atoi(url[strings.LastIndex(url, "/")+1:])
+	if err != nil {
+		return fmt.Errorf("creating GitHub issue for %s: unexpected gh output %q", issue.ID, url)
+	}
+	change.Number = n
+	if f.Closed {
+		if _, err := b.gh("issue", "close", strconv.Itoa(n), "--repo", b.repo); err != nil {
+			return fmt.Errorf("closing GitHub issue #%d: %w", n, err)
+		}
+	}
+	if err := b.app.Storage.Modify(ctx, issue.ID, func(i *issuestorage.Issue) error {
+		if i.ExternalRefs == nil {
+			i.ExternalRefs = make(map[string]string)
+		}
+		i.ExternalRefs[githubRefKey] = fmt.Sprintf("%s#%d", b.repo, n)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("linking %s to #%d: %w", issue.ID, n, err)
+	}
+	b.result.Pushed = append(b.result.Pushed, change)
+	return nil
+}
+
+func (b *githubBridge) updateRemote(issue *issuestorage.Issue, gi *githubIssue) error {
+	b.result.Pushed = append(b.result.Pushed, GitHubChangeJSON{ID: issue.ID, Number: gi.Number, Action: "updated"})
+	if b.dryRun {
+		return nil
+	}
+	want, have := localGitHubFields(issue, b.users), remoteGitHubFields(gi, b.users)
+	number := strconv.Itoa(gi.Number)
+
+	args := []string{"issue", "edit", number, "--repo", b.repo}
+	if want.Title != have.Title {
+		args = append(args, "--title", want.Title)
+	}
+	if want.Body != have.Body {
+		args = append(args, "--body", want.Body)
+	}
+	for _, l := range want.Labels {
+		if !slices.Contains(have.Labels, l) {
+			args = append(args, "--add-label", l)
+		}
+	}
+	for _, l := range have.Labels {
+		if !slices.Contains(want.Labels, l) {
+			args = append(args, "--remove-label", l)
+		}
+	}
+	if want.Assignee != have.Assignee {
+		for _, a := range gi.Assignees {
+			args = append(args, "--remove-assignee", a.Login)
+		}
+		if want.Assignee != "" {
+			args = append(args, "--add-assignee", b.users.toRemote(want.Assignee))
+		}
+	}
+	if len(args) > 5 {
+		if _, err := b.gh(args...); err != nil {
+			return fmt.Errorf("updating GitHub issue #%d from %s: %w", gi.Number, issue.ID, err)
+		}
+	}
+	if want.Closed != have.Closed {
+		verb := "reopen"
+		if want.Closed {
+			verb = "close"
+		}
+		if _, err := b.gh("issue", verb, number, "--repo", b.repo); err != nil {
+			return fmt.Errorf("updating GitHub issue #%d from %s: %w", gi.Number, issue.ID, err)
+		}
+	}
+	return nil
+}
+
+func (b *githubBridge) updateLocal(ctx context.Context, issue *issuestorage.Issue, gi *githubIssue) error {
+	b.result.Pulled = append(b.result.Pulled, GitHubChangeJSON{ID: issue.ID, Number: gi.Number, Action: "updated"})
+	if b.dryRun {
+		return nil
+	}
+	f := remoteGitHubFields(gi, b.users)
+	return b.app.Storage.Modify(ctx, issue.ID, func(i *issuestorage.Issue) error {
+		i.Title = f.Title
+		i.Description = f.Body
+		i.Labels = f.Labels
+		i.Assignee = f.Assignee
+		switch {
+		case f.Closed && i.Status != issuestorage.StatusClosed:
+			i.Status = issuestorage.StatusClosed
+			i.CloseReason = "Closed on GitHub"
+		case !f.Closed && i.Status == issuestorage.StatusClosed:
+			i.Status = issuestorage.StatusOpen
+		}
+		return nil
+	})
+}
+
+func (b *githubBridge) createLocal(ctx context.Context, gi *githubIssue) error {
+	f := remoteGitHubFields(gi, b.users)
+	author := b.users.toLocal(gi.Author.Login)
+	issue := &issuestorage.Issue{
+		Title:        f.Title,
+		Description:  f.Body,
+		Type:         issuestorage.TypeTask,
+		Priority:     issuestorage.PriorityMedium,
+		CreatedBy:    author,
+		Reporter:     author,
+		Labels:       f.Labels,
+		Assignee:     f.Assignee,
+		ExternalRefs: map[string]string{githubRefKey: fmt.Sprintf("%s#%d", b.repo, gi.Number)},
+	}
+	if !b.dryRun {
+		id, err := b.app.Storage.Create(ctx, issue)
+		if err != nil {
+			return fmt.Errorf("creating issue for GitHub #%d: %w", gi.Number, err)
+		}
+		issue.ID = id
+	}
+	b.result.Pulled = append(b.result.Pulled, GitHubChangeJSON{ID: issue.ID, Number: gi.Number, Action: "created"})
+	return nil
+}
+
+func printGitHubBridge(app *App, r *GitHubBridgeJSON) {
+	if len(r.Pushed) == 0 && len(r.Pulled) == 0 {
+		fmt.Fprintf(app.Out, "%s In step with %s\n", app.SuccessColor("✓"), r.Repo)
+		return
+	}
+	verb := "Synced with"
+	if r.DryRun {
+		verb = "Would sync with"
+	}
+	fmt.Fprintf(app.Out, "%s %s %s: %d pushed, %d pulled\n", app.SuccessColor("✓"), verb, r.Repo, len(r.Pushed), len(r.Pulled))
+	for _, c := range r.Pushed {
+		target := "new issue"
+		if c.Number > 0 {
+			target = "#" + strconv.Itoa(c.Number)
+		}
+		fmt.Fprintf(app.Out, "  %s → %s %s\n", c.ID, target, c.Action)
+	}
+	for _, c := range r.Pulled {
+		id := c.ID
+		if id == "" {
+			id = "new issue"
+		}
+		fmt.Fprintf(app.Out, "  %s ← #%d %s\n", id, c.Number, c.Action)
+	}
+}
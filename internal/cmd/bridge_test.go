@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"beads-lite/internal/clock"
+	"beads-lite/internal/issuestorage"
+)
+
+// fakeGitHub answers the gh commands the bridge runs from an in-memory
+// repository, acme/web.
+type fakeGitHub struct {
+	clock  *clock.Fake
+	issues map[int]*githubIssue
+	writes int
+}
+
+func (f *fakeGitHub) add(title, state string) {
+	f.issues[len(f.issues)+1] = &githubIssue{
+		Number:    len(f.issues) + 1,
+		Title:     title,
+		State:     state,
+		Author:    githubUser{Login: "octocat"},
+		UpdatedAt: f.clock.Now(),
+	}
+}
+
+func (f *fakeGitHub) exec(name string, args ...string) ([]byte, error) {
+	if name != "gh" || len(args) < 2 {
+		return nil, fmt.Errorf("unexpected command: %s %v", name, args)
+	}
+	flags := make(map[string][]string)
+	var positional []string
+	for i := 2; i < len(args); i++ {
+		if len(args[i]) > 2 && args[i][:2] == "--" && i+1 < len(args) {
+			flags[args[i]] = append(flags[args[i]], args[i+1])
+			i++
+		} else {
+			positional = append(positional, args[i])
+		}
+	}
+	if args[0] == "repo" {
+		return []byte(`{"nameWithOwner":"acme/web"}`), nil
+	}
+	if flags["--repo"][0] != "acme/web" {
+		return nil, fmt.Errorf("unexpected repo %v", flags["--repo"])
+	}
+	if args[1] == "list" {
+		return json.Marshal(slices.Collect(maps.Values(f.issues)))
+	}
+
+	f.writes++
+	var gi *githubIssue
+	if args[1] == "create" {
+		f.add(flags["--title"][0], "OPEN")
+		gi = f.issues[len(f.issues)]
+	} else {
+		n, _ := strconv.Atoi(positional[0])
+		if gi = f.issues[n]; gi == nil {
+			return nil, fmt.Errorf("no issue #%d", n)
+		}
+	}
+	gi.UpdatedAt = f.clock.Now()
+	if v := flags["--title"]; len(v) > 0 {
+		gi.Title = v[0]
+	}
+	if v := flags["--body"]; len(v) > 0 {
+		gi.Body = v[0]
+	}
+	for _, l := range append(flags["--label"], flags["--add-label"]...) {
+		gi.Labels = append(gi.Labels, githubLabel{Name: l})
+	}
+	gi.Labels = slices.DeleteFunc(gi.Labels, func(l githubLabel) bool {
+		return slices.Contains(flags["--remove-label"], l.Name)
+	})
+	gi.Assignees = slices.DeleteFunc(gi.Assignees, func(a githubUser) bool {
+		return slices.Contains(flags["--remove-assignee"], a.Login)
+	})
+	for _, a := range append(flags["--assignee"], flags["--add-assignee"]...) {
+		gi.Assignees = append(gi.Assignees, githubUser{Login: a})
+	}
+	switch args[1] {
+	case "create":
+		return []byte(fmt.Sprintf("https://github.com/acme/web/issues/%d\n", gi.Number)), nil
+	case "close":
+		gi.State = "CLOSED"
+	case "reopen":
+		gi.State = "OPEN"
+	}
+	return nil, nil
+}
+
+func TestBridgeGitHub(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC))
+	store.SetClock(fake)
+	gh := &fakeGitHub{clock: fake, issues: map[int]*githubIssue{}}
+	gh.add("Docs typo", "OPEN")
+	gh.add("Old report", "CLOSED")
+
+	login, _ := store.Create(ctx, &issuestorage.Issue{Title: "Fix login", Labels: []string{"auth"}})
+	search, _ := store.Create(ctx, &issuestorage.Issue{Title: "Search is slow", Assignee: "bob"})
+	done, _ := store.Create(ctx, &issuestorage.Issue{Title: "Shipped", Status: issuestorage.StatusClosed})
+
+	app.JSON = true
+	run := func(args ...string) GitHubBridgeJSON {
+		t.Helper()
+		fake.Advance(time.Minute)
+		app.Out.(*bytes.Buffer).Reset()
+		cmd := bridgeGitHubCmd(NewTestProvider(app), gh.exec)
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("bridge github %v: %v", args, err)
+		}
+		var result GitHubBridgeJSON
+		if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &result); err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+	ref := func(id string) string {
+		t.Helper()
+		issue, err := store.Get(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return issue.ExternalRefs[githubRefKey]
+	}
+
+	// Push creates GitHub issues for the open local issues only.
+	got := run("push")
+	if got.Repo != "acme/web" || len(got.Pulled) != 0 || len(got.Pushed) != 2 {
+		t.Fatalf("push = %+v", got)
+	}
+	var loginNumber, searchNumber int
+	fmt.Sscanf(ref(login), "acme/web#%d", &loginNumber)
+	fmt.Sscanf(ref(search), "acme/web#%d", &searchNumber)
+	if loginNumber+searchNumber != 3+4 || ref(done) != "" {
+		t.Errorf("refs after push: %q, %q, %q", ref(login), ref(search), ref(done))
+	}
+	if l := gh.issues[loginNumber].Labels; len(l) != 1 || l[0].Name != "auth" {
+		t.Errorf("pushed labels = %+v", l)
+	}
+
+	// Pull creates a local issue for the open GitHub issue only.
+	got = run("pull")
+	if len(got.Pulled) != 1 || got.Pulled[0].Number != 1 || got.Pulled[0].Action != "created" {
+		t.Fatalf("pull = %+v", got)
+	}
+	docs := got.Pulled[0].ID
+	if issue, _ := store.Get(ctx, docs); issue.Title != "Docs typo" || issue.CreatedBy != "octocat" || ref(docs) != "acme/web#1" {
+		t.Errorf("pulled issue = %+v", issue)
+	}
+
+	// Sync: a GitHub close pulls, a later local edit pushes.
+	fake.Advance(time.Minute)
+	gh.issues[loginNumber].State = "CLOSED"
+	gh.issues[loginNumber].UpdatedAt = fake.Now()
+	fake.Advance(time.Minute)
+	if err := store.Modify(ctx, search, func(i *issuestorage.Issue) error { i.Title = "Search is slow on mobile"; return nil }); err != nil {
+		t.Fatal(err)
+	}
+	got = run("sync")
+	if len(got.Pulled) != 1 || got.Pulled[0].ID != login || len(got.Pushed) != 1 || got.Pushed[0].ID != search {
+		t.Fatalf("sync = %+v", got)
+	}
+	if issue, _ := store.Get(ctx, login); issue.Status != issuestorage.StatusClosed || issue.CloseReason != "Closed on GitHub" {
+		t.Errorf("login after sync: %s %q", issue.Status, issue.CloseReason)
+	}
+	if gi := gh.issues[searchNumber]; gi.Title != "Search is slow on mobile" || gi.Assignees[0].Login != "bob" {
+		t.Errorf("search on GitHub after sync: %+v", gi)
+	}
+
+	if got := run("sync"); len(got.Pushed)+len(got.Pulled) != 0 {
+		t.Errorf("second sync changed %+v", got)
+	}
+
+	// A named closed issue is pushed, closed; a dry run writes nothing.
+	writes := gh.writes
+	if got := run("push", done, "--dry-run"); !got.DryRun || len(got.Pushed) != 1 || gh.writes != writes || ref(done) != "" {
+		t.Errorf("dry-run push = %+v, %d writes", got, gh.writes-writes)
+	}
+	run("push", done)
+	var doneNumber int
+	fmt.Sscanf(ref(done), "acme/web#%d", &doneNumber)
+	if gi := gh.issues[doneNumber]; gi == nil || gi.State != "CLOSED" {
+		t.Errorf("pushed closed issue = %+v", gi)
+	}
+}
+
+func TestBridgeGitHubUserMap(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	app.ConfigStore = &mapConfigStore{data: map[string]string{
+		"github.user.octocat": "alice@example.com",
+		"github.user.bobby":   "bob",
+	}}
+	fake := clock.NewFake(time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC))
+	store.SetClock(fake)
+	gh := &fakeGitHub{clock: fake, issues: map[int]*githubIssue{}}
+	gh.add("Docs typo", "OPEN")
+	gh.issues[1].Assignees = []githubUser{{Login: "bobby"}}
+
+	login, _ := store.Create(ctx, &issuestorage.Issue{Title: "Fix login", Assignee: "alice"})
+
+	app.JSON = true
+	run := func(args ...string) GitHubBridgeJSON {
+		t.Helper()
+		fake.Advance(time.Minute)
+		app.Out.(*bytes.Buffer).Reset()
+		cmd := bridgeGitHubCmd(NewTestProvider(app), gh.exec)
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("bridge github %v: %v", args, err)
+		}
+		var result GitHubBridgeJSON
+		if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &result); err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	got := run("sync")
+	if len(got.Pushed) != 1 || len(got.Pulled) != 1 {
+		t.Fatalf("sync = %+v", got)
+	}
+	if gi := gh.issues[2]; len(gi.Assignees) != 1 || gi.Assignees[0].Login != "octocat" {
+		t.Errorf("pushed %s assignees = %+v, want octocat", login, gi.Assignees)
+	}
+	docs, _ := store.Get(ctx, got.Pulled[0].ID)
+	if docs.Assignee != "bob" || docs.CreatedBy != "alice@example.com" || docs.Reporter != "alice@example.com" {
+		t.Errorf("pulled issue assignee %q, created by %q, reporter %q", docs.Assignee, docs.CreatedBy, docs.Reporter)
+	}
+
+	// Mapped names compare equal, so nothing is left to sync.
+	if got := run("sync"); len(got.Pushed)+len(got.Pulled) != 0 {
+		t.Errorf("second sync changed %+v", got)
+	}
+}
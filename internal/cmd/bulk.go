@@ -0,0 +1,309 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// BulkJSON is the JSON output of the bd bulk subcommands.
+type BulkJSON struct {
+	Action  string           `json:"action"`
+	DryRun  bool             `json:"dry_run"`
+	Changed int              `json:"changed"` // issues changed, or that would be with --dry-run
+	Failed  int              `json:"failed"`
+	Results []BulkResultJSON `json:"results"`
+}
+
+// BulkResultJSON is the outcome for one issue of a bulk change.
+type BulkResultJSON struct {
+	ID    string `json:"id"`
+	Title string `json:"title,omitempty"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// bulkUpdateFlags are the bd update flags bd bulk update passes on. Each
+// applies in update's single Modify, so every issue changes atomically.
+var bulkUpdateFlags = []string{"status", "priority", "severity", "type", "assignee", "reporter", "due", "add-label", "remove-label", "force"}
+
+// bulkOptions are the flags shared by the bulk subcommands.
+type bulkOptions struct {
+	filter string
+	dryRun bool
+}
+
+// newBulkCmd creates the bulk command with subcommands.
+func newBulkCmd(provider *AppProvider) *cobra.Command {
+	opts := &bulkOptions{}
+	cmd := &cobra.Command{
+		Use:   "bulk",
+		Short: "Close, update, label or assign many issues at once",
+		Long: `Apply one change to many issues. Issues are given as IDs, as - to read
+IDs from stdin (the first word of each line, so bd list output works),
+or with --filter, which takes the terms of bd context create (e.g.
+"label:release type:bug") and selects matching open issues.
+
+Each issue is changed atomically by the same code as bd close and
+bd update, so rules and hooks apply as usual. An issue that cannot be
+changed is reported and the rest go ahead; the command fails if any did.
+--dry-run lists the issues that would change without touching them.
+
+Examples:
+  bd bulk close --filter "label:release-1.4" --reason "fixed: shipped in 1.4"
+  bd bulk update bd-a1 bd-b2 --priority 1
+  bd bulk label --add needs-triage --filter "type:bug"
+  bd ready --json | jq -r '.[].id' | bd bulk assign alice -`,
+	}
+	cmd.PersistentFlags().StringVar(&opts.filter, "filter", "", "Select open issues matching field:value terms")
+	cmd.PersistentFlags().BoolVar(&opts.dryRun, "dry-run", false, "List the issues that would change without changing them")
+
+	cmd.AddCommand(newBulkCloseCmd(provider, opts))
+	cmd.AddCommand(newBulkUpdateCmd(provider, opts))
+	cmd.AddCommand(newBulkLabelCmd(provider, opts))
+	cmd.AddCommand(newBulkAssignCmd(provider, opts))
+	return cmd
+}
+
+// newBulkCloseCmd creates the "bulk close" subcommand.
+func newBulkCloseCmd(provider *AppProvider, opts *bulkOptions) *cobra.Command {
+	var reason string
+	cmd := &cobra.Command{
+		Use:   "close [issue-id...]",
+		Short: "Close many issues",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			closeArgs := []string{}
+			if cmd.Flags().Changed("reason") {
+				closeArgs = append(closeArgs, "--reason="+reason)
+			}
+			return runBulk(cmd, provider, opts, args, "close", "Closed", newCloseCmd, closeArgs)
+		},
+	}
+	cmd.Flags().StringVar(&reason, "reason", "", "Close reason, as for bd close --reason")
+	return cmd
+}
+
+// newBulkUpdateCmd creates the "bulk update" subcommand.
+func newBulkUpdateCmd(provider *AppProvider, opts *bulkOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update [issue-id...]",
+		Short: "Update fields of many issues",
+		Long: `Update fields of many issues. The flags are those of bd update;
+--parent, --title and --description are left out as they rarely apply to
+many issues at once.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var updateArgs []string
+			cmd.Flags().Visit(func(f *pflag.Flag) {
+				if !contains(bulkUpdateFlags, f.Name) {
+					return
+				}
+				if s, ok := f.Value.(pflag.SliceValue); ok {
+					for _, v := range s.GetSlice() {
+						updateArgs = append(updateArgs, "--"+f.Name+"="+v)
+					}
+					return
+				}
+				updateArgs = append(updateArgs, "--"+f.Name+"="+f.Value.String())
+			})
+			if len(updateArgs) == 0 {
+				return fmt.Errorf("no changes specified (use --%s)", strings.Join(bulkUpdateFlags, ", --"))
+			}
+			return runBulk(cmd, provider, opts, args, "update", "Updated", newUpdateCmd, updateArgs)
+		},
+	}
+	// Defaults are unused: only flags given are passed on to bd update.
+	cmd.Flags().StringP("status", "s", "", "New status ("+statusNames(nil)+")")
+	cmd.Flags().StringP("priority", "p", "", "New priority (0-4 or P0-P4)")
+	cmd.Flags().String("severity", "", "New severity (empty string to clear)")
+	cmd.Flags().StringP("type", "t", "", "New type")
+	cmd.Flags().StringP("assignee", "a", "", "Assign to user (empty string to unassign)")
+	cmd.Flags().String("reporter", "", "Who raised the issues")
+	cmd.Flags().String("due", "", "New deadline (YYYY-MM-DD or @<event>; empty string to clear)")
+	cmd.Flags().StringSlice("add-label", nil, "Add label (can repeat)")
+	cmd.Flags().StringSlice("remove-label", nil, "Remove label (can repeat)")
+	cmd.Flags().Bool("force", false, "Edit closed issues even if closed.edit is force or reopen")
+	return cmd
+}
+
+// newBulkLabelCmd creates the "bulk label" subcommand.
+func newBulkLabelCmd(provider *AppProvider, opts *bulkOptions) *cobra.Command {
+	var add, remove []string
+	cmd := &cobra.Command{
+		Use:   "label [issue-id...]",
+		Short: "Add or remove labels on many issues",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(add) == 0 && len(remove) == 0 {
+				return fmt.Errorf("no labels given (use --add or --remove)")
+			}
+			var updateArgs []string
+			for _, l := range add {
+				updateArgs = append(updateArgs, "--add-label="+l)
+			}
+			for _, l := range remove {
+				updateArgs = append(updateArgs, "--remove-label="+l)
+			}
+			return runBulk(cmd, provider, opts, args, "label", "Labeled", newUpdateCmd, updateArgs)
+		},
+	}
+	cmd.Flags().StringSliceVar(&add, "add", nil, "Label to add (can repeat)")
+	cmd.Flags().StringSliceVar(&remove, "remove", nil, "Label to remove (can repeat)")
+	return cmd
+}
+
+// newBulkAssignCmd creates the "bulk assign" subcommand.
+func newBulkAssignCmd(provider *AppProvider, opts *bulkOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "assign <assignee> [issue-id...]",
+		Short: `Assign many issues ("" unassigns)`,
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			done := "Assigned to " + args[0] + ":"
+			if args[0] == "" {
+				done = "Unassigned"
+			}
+			return runBulk(cmd, provider, opts, args[1:], "assign", done, newUpdateCmd, []string{"--assignee=" + args[0]})
+		},
+	}
+	return cmd
+}
+
+// runBulk runs newCmd with extra args on each selected issue and reports
+// the outcome, done being the past-tense verb for a changed issue.
+func runBulk(cmd *cobra.Command, provider *AppProvider, opts *bulkOptions, args []string, action, done string, newCmd func(*AppProvider) *cobra.Command, extra []string) error {
+	app, err := provider.Get()
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+
+	issues, results, err := bulkTargets(ctx, app, cmd.InOrStdin(), args, opts.filter)
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 && len(results) == 0 {
+		return fmt.Errorf("no issues given (pass IDs, - for stdin, or --filter)")
+	}
+
+	for _, issue := range issues {
+		result := BulkResultJSON{ID: issue.ID, Title: issue.Title, OK: true}
+		if !opts.dryRun {
+			if _, err := runJSONCommand(ctx, app, newCmd, append([]string{issue.ID}, extra...)); err != nil {
+				result.OK, result.Error = false, err.Error()
+			}
+		}
+		results = append(results, result)
+	}
+
+	out := BulkJSON{Action: action, DryRun: opts.dryRun, Results: results}
+	for _, r := range results {
+		if r.OK {
+			out.Changed++
+		} else {
+			out.Failed++
+		}
+	}
+
+	if app.JSON {
+		if err := json.NewEncoder(app.Out).Encode(out); err != nil {
+			return err
+		}
+	} else if opts.dryRun {
+		fmt.Fprintf(app.Out, "Would %s %d issues:\n", action, out.Changed)
+		for _, issue := range issues {
+			fmt.Fprintf(app.Out, "  %s\n", formatIssueLine(app, issue))
+		}
+		for _, r := range results {
+			if !r.OK {
+				fmt.Fprintf(app.Out, "  %s %s: %s\n", app.Colorize("✗", "31"), r.ID, r.Error)
+			}
+		}
+	} else {
+		for _, r := range results {
+			if r.OK {
+				fmt.Fprintf(app.Out, "%s %s %s - %s\n", app.SuccessColor("✓"), done, r.ID, r.Title)
+			} else {
+				fmt.Fprintf(app.Out, "%s %s: %s\n", app.Colorize("✗", "31"), r.ID, r.Error)
+			}
+		}
+		fmt.Fprintf(app.Out, "\n%d of %d issues changed.\n", out.Changed, len(results))
+	}
+
+	if out.Failed > 0 {
+		return fmt.Errorf("%d of %d issues could not be changed", out.Failed, len(results))
+	}
+	return nil
+}
+
+// bulkTargets resolves the issues a bulk command applies to, in the order
+// given and without repeats: args (- reads IDs from in), then the open
+// issues matching filter. IDs that do not resolve come back as failed
+// results.
+func bulkTargets(ctx context.Context, app *App, in io.Reader, args []string, filter string) ([]*issuestorage.Issue, []BulkResultJSON, error) {
+	var ids []string
+	for _, arg := range args {
+		if arg != "-" {
+			ids = append(ids, arg)
+			continue
+		}
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			fields := strings.Fields(line)
+			// Lines of bd list start with a status icon.
+			if len(fields) > 1 && len([]rune(fields[0])) == 1 {
+				fields = fields[1:]
+			}
+			ids = append(ids, fields[0])
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, nil, fmt.Errorf("reading issue IDs from stdin: %w", err)
+		}
+	}
+
+	seen := make(map[string]bool)
+	var issues []*issuestorage.Issue
+	var failed []BulkResultJSON
+	for _, id := range ids {
+		issue, err := resolveIssue(app.Storage, ctx, id)
+		if err != nil {
+			failed = append(failed, BulkResultJSON{ID: id, Error: err.Error()})
+			continue
+		}
+		if !seen[issue.ID] {
+			seen[issue.ID] = true
+			issues = append(issues, issue)
+		}
+	}
+
+	if filter != "" {
+		f, err := parseContextFilter(filter, getCustomValues(app, "types.custom"))
+		if err != nil {
+			return nil, nil, fmt.Errorf("--filter: %w", err)
+		}
+		matched, err := app.Storage.List(ctx, f)
+		if err != nil {
+			return nil, nil, fmt.Errorf("listing issues: %w", err)
+		}
+		if err := sortIssues(matched, "id", false); err != nil {
+			return nil, nil, err
+		}
+		for _, issue := range matched {
+			if !seen[issue.ID] {
+				seen[issue.ID] = true
+				issues = append(issues, issue)
+			}
+		}
+	}
+	return issues, failed, nil
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestBulkCommand(t *testing.T) {
+	app, rs := setupTestApp(t)
+	ctx := context.Background()
+	create := func(title string, labels ...string) string {
+		t.Helper()
+		id, err := rs.Create(ctx, &issuestorage.Issue{Title: title, Labels: labels})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+	a := create("A", "release")
+	b := create("B", "release")
+	c := create("C")
+
+	out := app.Out.(*bytes.Buffer)
+	run := func(stdin string, args ...string) (BulkJSON, error) {
+		t.Helper()
+		out.Reset()
+		app.JSON = true
+		cmd := newBulkCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		cmd.SetIn(strings.NewReader(stdin))
+		cmd.SilenceUsage, cmd.SilenceErrors = true, true
+		err := cmd.Execute()
+		var result BulkJSON
+		if jsonErr := json.Unmarshal(out.Bytes(), &result); jsonErr != nil {
+			t.Fatalf("parsing %q: %v (command error %v)", out.String(), jsonErr, err)
+		}
+		return result, err
+	}
+	status := func(id string) issuestorage.Status {
+		t.Helper()
+		issue, err := rs.Get(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return issue.Status
+	}
+
+	result, err := run("", "close", "--filter", "label:release", "--dry-run")
+	if err != nil || result.Changed != 2 || !result.DryRun || status(a) != issuestorage.StatusOpen {
+		t.Fatalf("dry run = %+v, %v; status of %s %s", result, err, a, status(a))
+	}
+
+	// Stdin takes the first word of each line, after a status icon.
+	result, err = run("○ "+c+" [● P2] [task] - C\n"+b+"\n", "label", "--add", "triaged", "-", "bd-missing")
+	if err == nil || result.Changed != 2 || result.Failed != 1 || result.Results[0].ID != "bd-missing" {
+		t.Fatalf("label = %+v, %v; want 2 changed and bd-missing failed", result, err)
+	}
+	if issue, _ := rs.Get(ctx, c); !contains(issue.Labels, "triaged") {
+		t.Errorf("labels of %s = %v", c, issue.Labels)
+	}
+
+	if _, err := run("", "update", a, "--priority", "1", "--add-label", "x", "--add-label", "y"); err != nil {
+		t.Fatal(err)
+	}
+	if issue, _ := rs.Get(ctx, a); issue.Priority != issuestorage.PriorityHigh || !contains(issue.Labels, "x") || !contains(issue.Labels, "y") {
+		t.Errorf("after update: priority %v, labels %v", issue.Priority, issue.Labels)
+	}
+
+	if _, err := run("", "assign", "alice", a, b); err != nil {
+		t.Fatal(err)
+	}
+	if issue, _ := rs.Get(ctx, b); issue.Assignee != "alice" {
+		t.Errorf("assignee of %s = %q", b, issue.Assignee)
+	}
+
+	result, err = run("", "close", "--filter", "label:release", "--reason", "fixed: shipped", a)
+	if err != nil || result.Changed != 2 || status(a) != issuestorage.StatusClosed || status(b) != issuestorage.StatusClosed {
+		t.Errorf("close = %+v, %v", result, err)
+	}
+	if issue, _ := rs.Get(ctx, b); issue.Resolution != issuestorage.ResolutionFixed {
+		t.Errorf("resolution of %s = %q", b, issue.Resolution)
+	}
+}
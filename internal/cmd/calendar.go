@@ -0,0 +1,301 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// calendarKeyPrefix prefixes the config keys of the calendar: each named
+// date is stored as calendar.<name>, e.g. calendar.code-freeze: 2025-08-01.
+const calendarKeyPrefix = "calendar."
+
+// calendarNamePattern matches a calendar event name: lower-case letters,
+// digits and dashes, starting with a letter.
+var calendarNamePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// CalendarEventJSON is the JSON output format for a calendar event.
+type CalendarEventJSON struct {
+	Name   string   `json:"name"`
+	Date   string   `json:"date"`
+	Issues []string `json:"issues,omitempty"` // open issues due on the event
+}
+
+// newCalendarCmd creates the calendar command.
+func newCalendarCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "calendar",
+		Short: "Manage named dates that deadlines and gates follow",
+		Long: `Manage the calendar: named dates such as code-freeze or release-2.0
+that issues and gates refer to by name, so moving a date moves every
+deadline tied to it.
+
+Give an issue a calendar deadline with --due @<name> on bd create or
+bd update. Its due date follows the event: bd calendar set rewrites the
+due date of every open issue due on the event. A gate with await_type
+calendar and await_id <name> resolves once the event's date arrives.
+
+Subcommands:
+  set     Add an event, or move it to a new date
+  list    List events and the issues due on them
+  remove  Remove an event no issue is due on`,
+	}
+
+	cmd.AddCommand(newCalendarSetCmd(provider))
+	cmd.AddCommand(newCalendarListCmd(provider))
+	cmd.AddCommand(newCalendarRemoveCmd(provider))
+
+	return cmd
+}
+
+// newCalendarSetCmd creates the "calendar set" subcommand.
+func newCalendarSetCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <name> <YYYY-MM-DD>",
+		Short: "Add an event, or move it to a new date",
+		Long: `Set the date of a calendar event, creating it if needed. Moving an
+event updates the due date of every open issue due on it.
+
+Examples:
+  bd calendar set code-freeze 2025-08-01
+  bd calendar set release-2-0 2025-09-15`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			name, date := args[0], args[1]
+			if err := checkCalendarName(name); err != nil {
+				return err
+			}
+			due, err := parseCalendarDate(date)
+			if err != nil {
+				return err
+			}
+			store, err := configStore(provider)
+			if err != nil {
+				return err
+			}
+			if err := store.Set(calendarKeyPrefix+name, date); err != nil {
+				return fmt.Errorf("saving calendar event: %w", err)
+			}
+
+			ctx := cmd.Context()
+			ids, err := calendarIssues(ctx, app, name)
+			if err != nil {
+				return err
+			}
+			for _, id := range ids {
+				if err := app.Storage.Modify(ctx, id, func(i *issuestorage.Issue) error {
+					i.DueAt = &due
+					return nil
+				}); err != nil {
+					return fmt.Errorf("moving due date of %s: %w", id, err)
+				}
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(CalendarEventJSON{Name: name, Date: date, Issues: ids})
+			}
+			fmt.Fprintf(app.Out, "%s %s is on %s\n", app.SuccessColor("✓"), name, date)
+			if len(ids) > 0 {
+				fmt.Fprintf(app.Out, "  Moved the due date of %d %s: %s\n", len(ids), plural(len(ids), "issue", "issues"), strings.Join(ids, ", "))
+			}
+			return nil
+		},
+	}
+}
+
+// newCalendarListCmd creates the "calendar list" subcommand.
+func newCalendarListCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List events and the issues due on them",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			calendar := calendarEvents(app)
+			due, err := issuesByDueEvent(cmd.Context(), app)
+			if err != nil {
+				return err
+			}
+			names := sortedKeys(calendar)
+			sort.SliceStable(names, func(i, j int) bool { return calendar[names[i]] < calendar[names[j]] })
+			events := []CalendarEventJSON{}
+			for _, name := range names {
+				events = append(events, CalendarEventJSON{Name: name, Date: calendar[name], Issues: due[name]})
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(events)
+			}
+			if len(events) == 0 {
+				fmt.Fprintln(app.Out, "No calendar events.")
+				return nil
+			}
+			width := 0
+			for _, e := range events {
+				width = max(width, len(e.Name))
+			}
+			for _, e := range events {
+				line := fmt.Sprintf("%s  %-*s", e.Date, width, e.Name)
+				if len(e.Issues) > 0 {
+					line += "  " + strings.Join(e.Issues, ", ")
+				}
+				fmt.Fprintln(app.Out, strings.TrimRight(line, " "))
+			}
+			return nil
+		},
+	}
+}
+
+// newCalendarRemoveCmd creates the "calendar remove" subcommand.
+func newCalendarRemoveCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove an event no issue is due on",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			name := args[0]
+			store, err := configStore(provider)
+			if err != nil {
+				return err
+			}
+			if _, ok := store.Get(calendarKeyPrefix + name); !ok {
+				return fmt.Errorf("no calendar event named %s", name)
+			}
+			ids, err := calendarIssues(cmd.Context(), app, name)
+			if err != nil {
+				return err
+			}
+			if len(ids) > 0 {
+				return fmt.Errorf("%s is the due date of %s; give them another --due first", name, strings.Join(ids, ", "))
+			}
+			if err := store.Unset(calendarKeyPrefix + name); err != nil {
+				return fmt.Errorf("removing calendar event: %w", err)
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(map[string]string{"removed": name})
+			}
+			fmt.Fprintf(app.Out, "%s Removed %s\n", app.SuccessColor("✓"), name)
+			return nil
+		},
+	}
+}
+
+// checkCalendarName validates a calendar event name.
+func checkCalendarName(name string) error {
+	if !calendarNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid calendar event name %q: must be lower-case letters, digits and -, starting with a letter", name)
+	}
+	return nil
+}
+
+// parseCalendarDate parses a calendar date, which is a plain day.
+func parseCalendarDate(s string) (time.Time, error) {
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: expected YYYY-MM-DD", s)
+	}
+	return t, nil
+}
+
+// validateCalendarKey is the config validator for calendar.<name> keys.
+func validateCalendarKey(key, v string) string {
+	if err := checkCalendarName(strings.TrimPrefix(key, calendarKeyPrefix)); err != nil {
+		return fmt.Sprintf("%s: %v", key, err)
+	}
+	if _, err := parseCalendarDate(v); err != nil {
+		return fmt.Sprintf("%s: %v", key, err)
+	}
+	return ""
+}
+
+// calendarEvents returns the dates of the calendar events by name.
+func calendarEvents(app *App) map[string]string {
+	calendar := make(map[string]string)
+	if app.ConfigStore == nil {
+		return calendar
+	}
+	for key, value := range app.ConfigStore.All() {
+		if name, ok := strings.CutPrefix(key, calendarKeyPrefix); ok && name != "" && value != "" {
+			calendar[name] = value
+		}
+	}
+	return calendar
+}
+
+// calendarDate returns the date of the named event.
+func calendarDate(app *App, name string) (time.Time, error) {
+	date, ok := calendarEvents(app)[name]
+	if !ok {
+		return time.Time{}, fmt.Errorf("no calendar event named %s (add it with bd calendar set %s <YYYY-MM-DD>)", name, name)
+	}
+	return parseCalendarDate(date)
+}
+
+// parseDueFlag parses a --due value: a date, @<event> for a calendar
+// event, or "" to clear the deadline. It returns the due date and the
+// event it follows, if any.
+func parseDueFlag(app *App, value string) (*time.Time, string, error) {
+	if value == "" {
+		return nil, "", nil
+	}
+	if name, ok := strings.CutPrefix(value, "@"); ok {
+		due, err := calendarDate(app, name)
+		if err != nil {
+			return nil, "", err
+		}
+		return &due, name, nil
+	}
+	due, err := parseListCreatedTime(value, false)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid --due value %q: %w (or @<event> for a calendar event)", value, err)
+	}
+	return &due, "", nil
+}
+
+// issuesByDueEvent returns the IDs of the open issues due on each
+// calendar event.
+func issuesByDueEvent(ctx context.Context, app *App) (map[string][]string, error) {
+	issues, err := app.Storage.List(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("listing issues: %w", err)
+	}
+	due := make(map[string][]string)
+	for _, issue := range issues {
+		if issue.DueEvent != "" {
+			due[issue.DueEvent] = append(due[issue.DueEvent], issue.ID)
+		}
+	}
+	for _, ids := range due {
+		sort.Strings(ids)
+	}
+	return due, nil
+}
+
+// calendarIssues returns the IDs of the open issues due on the named event.
+func calendarIssues(ctx context.Context, app *App, name string) ([]string, error) {
+	due, err := issuesByDueEvent(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	return due[name], nil
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/clock"
+	"beads-lite/internal/config/yamlstore"
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+func TestCalendarCmd(t *testing.T) {
+	app, store := setupTestApp(t)
+	app.ConfigDir = t.TempDir()
+	reload := func() {
+		cs, err := yamlstore.New(filepath.Join(app.ConfigDir, "config.yaml"))
+		if err != nil {
+			t.Fatalf("opening config: %v", err)
+		}
+		app.ConfigStore = cs
+	}
+	reload()
+	run := func(newCmd func(*AppProvider) *cobra.Command, args ...string) error {
+		app.Out.(*bytes.Buffer).Reset()
+		cmd := newCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		reload()
+		return err
+	}
+	ctx := context.Background()
+	dueDate := func(id string) string {
+		t.Helper()
+		issue, err := store.Get(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if issue.DueAt == nil {
+			return ""
+		}
+		return issue.DueAt.Format("2006-01-02") + " " + issue.DueEvent
+	}
+
+	if err := run(newCalendarCmd, "set", "code-freeze", "2025-08-01"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	for _, bad := range [][]string{{"Code-Freeze", "2025-08-01"}, {"code-freeze", "August 1st"}} {
+		if err := run(newCalendarCmd, append([]string{"set"}, bad...)...); err == nil {
+			t.Errorf("set %q: want error", bad)
+		}
+	}
+
+	if err := run(newCreateCmd, "Cut release branch", "--due", "@code-freeze"); err != nil {
+		t.Fatalf("create --due @code-freeze: %v", err)
+	}
+	if err := run(newCreateCmd, "Typo", "--due", "@no-such-event"); err == nil || !strings.Contains(err.Error(), "no calendar event named no-such-event") {
+		t.Errorf("create with unknown event: err = %v", err)
+	}
+	issues, _ := store.List(ctx, nil)
+	if len(issues) != 1 {
+		t.Fatalf("got %d issues, want 1", len(issues))
+	}
+	branch := issues[0].ID
+	fixed, err := store.Create(ctx, &issuestorage.Issue{Title: "Fixed date"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := run(newUpdateCmd, fixed, "--due", "2025-07-01"); err != nil {
+		t.Fatalf("update --due: %v", err)
+	}
+
+	// Moving the event moves only the deadlines that follow it.
+	if err := run(newCalendarCmd, "set", "code-freeze", "2025-08-15"); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	if got := dueDate(branch); got != "2025-08-15 code-freeze" {
+		t.Errorf("following issue due %q, want 2025-08-15 code-freeze", got)
+	}
+	if got := dueDate(fixed); got != "2025-07-01 " {
+		t.Errorf("fixed issue due %q, want 2025-07-01", got)
+	}
+
+	app.JSON = true
+	if err := run(newCalendarCmd, "list"); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	var events []CalendarEventJSON
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &events); err != nil {
+		t.Fatal(err)
+	}
+	if want := []CalendarEventJSON{{Name: "code-freeze", Date: "2025-08-15", Issues: []string{branch}}}; !reflect.DeepEqual(events, want) {
+		t.Errorf("list = %+v, want %+v", events, want)
+	}
+	app.JSON = false
+
+	if err := run(newCalendarCmd, "remove", "code-freeze"); err == nil || !strings.Contains(err.Error(), branch) {
+		t.Errorf("remove while in use: err = %v", err)
+	}
+	if err := run(newUpdateCmd, branch, "--due", ""); err != nil {
+		t.Fatalf("update --due '': %v", err)
+	}
+	if got := dueDate(branch); got != "" {
+		t.Errorf("cleared deadline = %q", got)
+	}
+	if err := run(newCalendarCmd, "remove", "code-freeze"); err != nil {
+		t.Errorf("remove: %v", err)
+	}
+	if _, ok := app.ConfigStore.Get("calendar.code-freeze"); ok {
+		t.Error("calendar.code-freeze still set after remove")
+	}
+}
+
+func TestGateCheckCalendar(t *testing.T) {
+	app, store := setupCheckTestApp(t)
+	app.ConfigStore = &mapConfigStore{data: map[string]string{"calendar.release": "2026-04-02"}}
+	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2026, 4, 1, 9, 0, 0, 0, time.Local))
+	store.SetClock(fake)
+
+	id, err := store.Create(ctx, &issuestorage.Issue{
+		Title:     "Wait for release day",
+		Type:      issuestorage.TypeGate,
+		AwaitType: "calendar",
+		AwaitID:   "release",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	check := func() string {
+		t.Helper()
+		out := app.Out.(*bytes.Buffer)
+		out.Reset()
+		cmd := gateCheckCmd(NewTestProvider(app), nil, false)
+		cmd.SetArgs([]string{})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("gate check: %v", err)
+		}
+		return out.String()
+	}
+
+	if out := check(); !strings.Contains(out, "waits for release on 2026-04-02") {
+		t.Errorf("before the date: %s", out)
+	}
+	fake.Advance(24 * time.Hour)
+	if out := check(); !strings.Contains(out, "release arrived") {
+		t.Errorf("on the date: %s", out)
+	}
+	if gate, _ := store.Get(ctx, id); gate.Status != issuestorage.StatusClosed {
+		t.Errorf("gate status = %s, want closed", gate.Status)
+	}
+}
+
+func TestValidateCalendarKey(t *testing.T) {
+	if msg := validateCalendarKey("calendar.code-freeze", "2025-08-01"); msg != "" {
+		t.Errorf("valid event: %s", msg)
+	}
+	if msg := validateCalendarKey("calendar.code-freeze", "next week"); msg == "" {
+		t.Error("bad date: want error")
+	}
+	if msg := validateCalendarKey("calendar.Code_Freeze", "2025-08-01"); msg == "" {
+		t.Error("bad name: want error")
+	}
+}
@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"sort"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// CloneJSON is the JSON output of bd clone. Cloned maps each original
+// issue ID to the ID of its copy.
+type CloneJSON struct {
+	ID     string            `json:"id"`
+	Cloned map[string]string `json:"cloned"`
+}
+
+// cloneOptions controls how bd clone copies issues.
+type cloneOptions struct {
+	title       string   // title of the root copy; "" keeps the original's
+	labels      []string // labels added to every copy
+	resetStatus bool
+	actor       string
+	owner       string
+}
+
+// newCloneCmd creates the clone command.
+func newCloneCmd(provider *AppProvider) *cobra.Command {
+	var (
+		includeChildren bool
+		opts            cloneOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "clone <issue-id>",
+		Short: "Copy an issue, optionally with its subtree",
+		Long: `Copy an issue into a new one, for example to start this month's release
+checklist from last month's.
+
+The copy keeps the original's title, description, type, priority,
+labels, vars, assignee, estimate, milestone, acceptance criteria and
+type-specific fields, and sits under the same parent. Comments, history, logged time,
+attachments, reviews and external references are not copied. --label
+adds labels to every copy; --title renames the root copy.
+
+With --include-children the whole subtree is copied, each copy a child of
+its parent's copy. Dependencies between cloned issues are relinked to the
+copies; dependencies on issues outside the subtree are kept as they are.
+Issues that depend on the originals do not depend on the copies.
+
+With --reset-status every copy starts open, with acceptance criteria
+unchecked; otherwise the copies keep the originals' statuses.
+
+Examples:
+  bd clone bd-a1b2
+  bd clone bd-a1b2 --include-children --reset-status --title "Release 1.8"
+  bd clone bd-a1b2 --include-children --label release-1.8`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			root, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+			originals := []*issuestorage.Issue{root}
+			if includeChildren {
+				if originals, err = collectSubtree(ctx, app, root); err != nil {
+					return err
+				}
+			}
+
+			opts.actor, _ = resolveActor(app)
+			opts.owner = resolveOwner()
+			cloned, err := cloneIssues(ctx, app, originals, opts)
+			if err != nil {
+				return err
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(CloneJSON{ID: cloned[root.ID], Cloned: cloned})
+			}
+			fmt.Fprintf(app.Out, "%s Cloned %s as %s\n", app.SuccessColor("✓"), root.ID, cloned[root.ID])
+			if len(cloned) > 1 {
+				fmt.Fprintf(app.Out, "  Issues: %d (with children)\n", len(cloned))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&includeChildren, "include-children", false, "Also copy all descendants")
+	cmd.Flags().BoolVar(&opts.resetStatus, "reset-status", false, "Start every copy open with unchecked criteria")
+	cmd.Flags().StringVar(&opts.title, "title", "", "Title of the copy (default: the original's)")
+	cmd.Flags().StringSliceVarP(&opts.labels, "label", "l", nil, "Label to add to every copy (repeatable)")
+
+	return cmd
+}
+
+// collectSubtree returns root and all its descendants, each parent before
+// its children.
+func collectSubtree(ctx context.Context, app *App, root *issuestorage.Issue) ([]*issuestorage.Issue, error) {
+	issues := []*issuestorage.Issue{root}
+	seen := map[string]bool{root.ID: true}
+	for i := 0; i < len(issues); i++ {
+		children := issues[i].Children()
+		sort.Strings(children)
+		for _, id := range children {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			child, err := app.Storage.Get(ctx, id)
+			if err != nil {
+				return nil, fmt.Errorf("reading child %s: %w", id, err)
+			}
+			if child.Status == issuestorage.StatusTombstone {
+				continue
+			}
+			issues = append(issues, child)
+		}
+	}
+	return issues, nil
+}
+
+// cloneIssues creates a copy of each of originals, parents first, then
+// adds their dependencies, pointing those between originals at the copies.
+// It returns the copies' IDs by original ID.
+func cloneIssues(ctx context.Context, app *App, originals []*issuestorage.Issue, opts cloneOptions) (map[string]string, error) {
+	cloned := make(map[string]string, len(originals))
+	for i, orig := range originals {
+		issue := cloneIssue(orig, opts)
+		if i == 0 && opts.title != "" {
+			issue.Title = opts.title
+		}
+		parent := orig.Parent
+		if id, ok := cloned[parent]; ok {
+			parent = id
+		}
+		if parent != "" {
+			childID, err := app.Storage.GetNextChildID(ctx, parent)
+			if err != nil {
+				return cloned, fmt.Errorf("generating child ID for parent %s: %w", parent, err)
+			}
+			issue.ID = childID
+		}
+		id, err := app.Storage.Create(ctx, issue, issuestorage.CreateOpts{PrioritySet: true})
+		if err != nil {
+			return cloned, fmt.Errorf("cloning %s: %w", orig.ID, err)
+		}
+		cloned[orig.ID] = id
+		if parent != "" {
+			if err := app.Storage.AddDependency(ctx, id, parent, issuestorage.DepTypeParentChild); err != nil {
+				return cloned, fmt.Errorf("setting parent of %s: %w", id, err)
+			}
+		}
+	}
+
+	for _, orig := range originals {
+		for _, dep := range orig.Dependencies {
+			if dep.Type == issuestorage.DepTypeParentChild {
+				continue
+			}
+			target := dep.ID
+			if id, ok := cloned[target]; ok {
+				target = id
+			}
+			if err := app.Storage.AddDependency(ctx, cloned[orig.ID], target, dep.Type); err != nil {
+				return cloned, fmt.Errorf("linking %s to %s: %w", cloned[orig.ID], target, err)
+			}
+		}
+	}
+	return cloned, nil
+}
+
+// cloneIssue returns a new issue with orig's content, without its ID,
+// relationships, activity or timestamps.
+func cloneIssue(orig *issuestorage.Issue, opts cloneOptions) *issuestorage.Issue {
+	issue := &issuestorage.Issue{
+		Title:         orig.Title,
+		Description:   orig.Description,
+		Status:        orig.Status,
+		Priority:      orig.Priority,
+		Severity:      orig.Severity,
+		Type:          orig.Type,
+		MolType:       orig.MolType,
+		CreatedBy:     opts.actor,
+		Owner:         opts.owner,
+		Assignee:      orig.Assignee,
+		Reviewer:      orig.Reviewer,
+		Ephemeral:     orig.Ephemeral,
+		CloseReason:   orig.CloseReason,
+		Resolution:    orig.Resolution,
+		DuplicateOf:   orig.DuplicateOf,
+		DueAt:         orig.DueAt,
+		DeferUntil:    orig.DeferUntil,
+		DueEvent:      orig.DueEvent,
+		Estimate:      orig.Estimate,
+		Milestone:     orig.Milestone,
+		AwaitType:     orig.AwaitType,
+		AwaitID:       orig.AwaitID,
+		TimeoutNS:     orig.TimeoutNS,
+		Waiters:       append([]string(nil), orig.Waiters...),
+		Likelihood:    orig.Likelihood,
+		Impact:        orig.Impact,
+		ReviewBy:      orig.ReviewBy,
+		DecisionState: orig.DecisionState,
+	}
+	issue.Labels = append([]string(nil), orig.Labels...)
+	if len(orig.Vars) > 0 {
+		issue.Vars = maps.Clone(orig.Vars)
+	}
+	for _, l := range opts.labels {
+		if !contains(issue.Labels, l) {
+			issue.Labels = append(issue.Labels, l)
+		}
+	}
+	for _, c := range orig.AcceptanceCriteria {
+		if opts.resetStatus {
+			c = issuestorage.Criterion{Text: c.Text}
+		}
+		issue.AcceptanceCriteria = append(issue.AcceptanceCriteria, c)
+	}
+	if opts.resetStatus {
+		issue.Status = issuestorage.StatusOpen
+		issue.CloseReason, issue.Resolution, issue.DuplicateOf = "", "", ""
+		if issue.Type == issuestorage.TypeDecision {
+			issue.DecisionState = issuestorage.DecisionProposed
+		}
+	}
+	return issue
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestCloneWithChildren(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+
+	external, _ := store.Create(ctx, &issuestorage.Issue{Title: "Infra"})
+	epic, _ := store.Create(ctx, &issuestorage.Issue{Title: "Release 1.7", Type: issuestorage.TypeEpic, Labels: []string{"release"}})
+	var steps []string
+	for _, title := range []string{"Freeze", "Tag"} {
+		id, err := store.GetNextChildID(ctx, epic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		store.Create(ctx, &issuestorage.Issue{
+			ID:                 id,
+			Title:              title,
+			AcceptanceCriteria: []issuestorage.Criterion{{Text: "done", Done: true}},
+		})
+		if err := store.AddDependency(ctx, id, epic, issuestorage.DepTypeParentChild); err != nil {
+			t.Fatal(err)
+		}
+		steps = append(steps, id)
+	}
+	store.AddDependency(ctx, steps[1], steps[0], issuestorage.DepTypeBlocks)
+	store.AddDependency(ctx, steps[0], external, issuestorage.DepTypeBlocks)
+	store.Modify(ctx, steps[0], func(i *issuestorage.Issue) error {
+		i.Status = issuestorage.StatusClosed
+		return nil
+	})
+
+	app.JSON = true
+	cmd := newCloneCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{epic, "--include-children", "--reset-status", "--title", "Release 1.8", "--label", "r1.8"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("clone failed: %v", err)
+	}
+	var result CloneJSON
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Cloned) != 3 || result.Cloned[epic] != result.ID {
+		t.Fatalf("cloned = %+v", result)
+	}
+
+	root, _ := store.Get(ctx, result.ID)
+	if root.Title != "Release 1.8" || root.Parent != "" || !reflect.DeepEqual(root.Labels, []string{"release", "r1.8"}) {
+		t.Errorf("root copy = %q parent %q labels %v", root.Title, root.Parent, root.Labels)
+	}
+	freeze, _ := store.Get(ctx, result.Cloned[steps[0]])
+	tag, _ := store.Get(ctx, result.Cloned[steps[1]])
+	if freeze.ID != result.ID+".1" || freeze.Parent != result.ID || tag.Parent != result.ID {
+		t.Errorf("children = %s (parent %s), %s (parent %s)", freeze.ID, freeze.Parent, tag.ID, tag.Parent)
+	}
+	if freeze.Status != issuestorage.StatusOpen || freeze.AcceptanceCriteria[0].Done {
+		t.Errorf("freeze copy not reset: %s %+v", freeze.Status, freeze.AcceptanceCriteria)
+	}
+	// Relinked within the subtree, kept outside it.
+	if !tag.HasDependency(freeze.ID) || tag.HasDependency(steps[0]) {
+		t.Errorf("tag copy dependencies = %+v, want %s", tag.Dependencies, freeze.ID)
+	}
+	if !freeze.HasDependency(external) {
+		t.Errorf("freeze copy dependencies = %+v, want %s", freeze.Dependencies, external)
+	}
+	if orig, _ := store.Get(ctx, steps[0]); orig.HasDependent(tag.ID) {
+		t.Errorf("original %s gained dependent %s", steps[0], tag.ID)
+	}
+}
+
+func TestCloneKeepsParentAndStatus(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+
+	epic, _ := store.Create(ctx, &issuestorage.Issue{Title: "Epic", Type: issuestorage.TypeEpic})
+	id, _ := store.GetNextChildID(ctx, epic)
+	store.Create(ctx, &issuestorage.Issue{ID: id, Title: "Step", Status: issuestorage.StatusInProgress})
+	store.AddDependency(ctx, id, epic, issuestorage.DepTypeParentChild)
+
+	cmd := newCloneCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{id})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("clone failed: %v", err)
+	}
+	out := app.Out.(*bytes.Buffer).String()
+	if want := "Cloned " + id + " as " + epic + ".2"; !strings.Contains(out, want) {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+	copied, err := store.Get(ctx, epic+".2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if copied.Parent != epic || copied.Status != issuestorage.StatusInProgress || copied.Title != "Step" {
+		t.Errorf("copy = parent %q status %s title %q", copied.Parent, copied.Status, copied.Title)
+	}
+}
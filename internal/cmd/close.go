@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"beads-lite/internal/graph"
 	"beads-lite/internal/issuestorage"
@@ -18,6 +19,7 @@ func newCloseCmd(provider *AppProvider) *cobra.Command {
 		noAuto       bool
 		suggestNext  bool
 		reason       string
+		duplicateOf  string
 	)
 
 	cmd := &cobra.Command{
@@ -27,10 +29,18 @@ func newCloseCmd(provider *AppProvider) *cobra.Command {
 
 Sets status to closed and records the closed_at timestamp.
 
+A --reason that is, or starts with, one of fixed, wontfix, duplicate,
+invalid or obsolete (e.g. "wontfix: out of scope") also records that as
+the issue's resolution, which bd stats counts. --duplicate-of records the
+original issue and implies duplicate. Set close.require_reason to true to
+refuse closing without a resolution.
+
 Examples:
   bd close bd-a1b2
   bd close bd-a1b2 bd-c3d4 bd-e5f6
-  bd close --reason "Won't fix" bd-a1b2`,
+  bd close --reason "Won't fix" bd-a1b2
+  bd close --reason "fixed: retry on timeout" bd-a1b2
+  bd close --duplicate-of bd-c3d4 bd-a1b2`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			app, err := provider.Get()
@@ -42,12 +52,39 @@ Examples:
 			var closed []string
 			var errors []error
 
+			resolution, _ := parseCloseResolution(reason)
+			if duplicateOf != "" {
+				if resolution != "" && resolution != issuestorage.ResolutionDuplicate {
+					return fmt.Errorf("--duplicate-of conflicts with --reason %q", reason)
+				}
+				original, err := resolveIssue(app.Storage, ctx, duplicateOf)
+				if err != nil {
+					return fmt.Errorf("resolving issue %s: %w", duplicateOf, err)
+				}
+				duplicateOf = original.ID
+				resolution = issuestorage.ResolutionDuplicate
+				if reason == "" {
+					reason = "Duplicate of " + duplicateOf
+				}
+			}
+			if resolution == "" && closeReasonRequired(app) {
+				return fmt.Errorf("%s is set: pass --reason starting with one of %s, or --duplicate-of", closeRequireReasonKey, resolutionNames())
+			}
+
 			for _, issueID := range args {
+				if duplicateOf != "" && issueID == duplicateOf {
+					errors = append(errors, fmt.Errorf("closing %s: an issue cannot duplicate itself", issueID))
+					continue
+				}
 				if err := app.Storage.Modify(ctx, issueID, func(i *issuestorage.Issue) error {
 					i.Status = issuestorage.StatusClosed
 					if reason != "" {
 						i.CloseReason = reason
 					}
+					if resolution != "" {
+						i.Resolution = resolution
+						i.DuplicateOf = duplicateOf
+					}
 					return nil
 				}); err != nil {
 					errors = append(errors, fmt.Errorf("closing %s: %w", issueID, err))
@@ -198,11 +235,48 @@ Examples:
 	cmd.Flags().BoolVar(&continueFlag, "continue", false, "Auto-advance to next molecule step")
 	cmd.Flags().BoolVar(&noAuto, "no-auto", false, "With --continue: show next step without claiming it")
 	cmd.Flags().BoolVar(&suggestNext, "suggest-next", false, "Show newly unblocked issues after close")
-	cmd.Flags().StringVar(&reason, "reason", "", "Set the close reason (default: \"Closed\")")
+	cmd.Flags().StringVar(&reason, "reason", "", "Set the close reason (default: \"Closed\"); a leading fixed, wontfix, duplicate, invalid or obsolete sets the resolution")
+	cmd.Flags().StringVar(&duplicateOf, "duplicate-of", "", "Close as a duplicate of this issue")
 
 	return cmd
 }
 
+// closeRequireReasonKey makes bd close require a resolution.
+const closeRequireReasonKey = "close.require_reason"
+
+// closeReasonRequired reports whether close.require_reason is set.
+func closeReasonRequired(app *App) bool {
+	if app.ConfigStore == nil {
+		return false
+	}
+	v, _ := app.ConfigStore.Get(closeRequireReasonKey)
+	return v == "true"
+}
+
+// parseCloseResolution returns the resolution named by a close reason,
+// either the whole reason ("won't fix") or the part before a colon
+// ("wontfix: out of scope"). ok is false for free-text reasons.
+func parseCloseResolution(reason string) (issuestorage.Resolution, bool) {
+	if r, err := issuestorage.ParseResolution(reason); err == nil {
+		return r, true
+	}
+	if head, _, found := strings.Cut(reason, ":"); found {
+		if r, err := issuestorage.ParseResolution(head); err == nil {
+			return r, true
+		}
+	}
+	return "", false
+}
+
+// resolutionNames returns the valid resolutions as a comma-separated list.
+func resolutionNames() string {
+	names := make([]string, len(issuestorage.Resolutions))
+	for i, r := range issuestorage.Resolutions {
+		names[i] = string(r)
+	}
+	return strings.Join(names, ", ")
+}
+
 // findNextMoleculeStep finds the next ready step in a molecule after the given issue.
 // Returns nil if the issue is not part of a molecule or there are no more steps.
 func findNextMoleculeStep(ctx context.Context, store issuestorage.IssueStore, issueID string) *issuestorage.Issue {
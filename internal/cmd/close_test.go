@@ -827,3 +827,82 @@ func TestCloseContinueAdvancesNextStepForEphemeralMolecule(t *testing.T) {
 		t.Errorf("expected step B assignee %q, got %q", "test-agent", got.Assignee)
 	}
 }
+
+func TestCloseResolution(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	tests := []struct {
+		args       []string
+		resolution issuestorage.Resolution
+		reason     string
+	}{
+		{[]string{"--reason", "Won't fix"}, issuestorage.ResolutionWontFix, "Won't fix"},
+		{[]string{"--reason", "fixed: retry on timeout"}, issuestorage.ResolutionFixed, "fixed: retry on timeout"},
+		{[]string{"--reason", "Shipped in v2"}, "", "Shipped in v2"},
+		{nil, "", "Closed"},
+	}
+	for _, tt := range tests {
+		id, _ := store.Create(ctx, &issuestorage.Issue{Title: "Issue"})
+		cmd := newCloseCmd(NewTestProvider(app))
+		cmd.SetArgs(append([]string{id}, tt.args...))
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("close %v: %v", tt.args, err)
+		}
+		got, _ := store.Get(ctx, id)
+		if got.Resolution != tt.resolution || got.CloseReason != tt.reason {
+			t.Errorf("close %v: resolution %q reason %q, want %q %q", tt.args, got.Resolution, got.CloseReason, tt.resolution, tt.reason)
+		}
+	}
+}
+
+func TestCloseDuplicateOf(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	original, _ := store.Create(ctx, &issuestorage.Issue{Title: "Original"})
+	dup, _ := store.Create(ctx, &issuestorage.Issue{Title: "Dup"})
+
+	cmd := newCloseCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{dup, "--duplicate-of", original})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	got, _ := store.Get(ctx, dup)
+	if got.Resolution != issuestorage.ResolutionDuplicate || got.DuplicateOf != original || got.CloseReason != "Duplicate of "+original {
+		t.Errorf("got resolution %q duplicate_of %q reason %q", got.Resolution, got.DuplicateOf, got.CloseReason)
+	}
+
+	// Reopening clears the resolution.
+	if err := store.Modify(ctx, dup, func(i *issuestorage.Issue) error { i.Status = issuestorage.StatusOpen; return nil }); err != nil {
+		t.Fatal(err)
+	}
+	got, _ = store.Get(ctx, dup)
+	if got.Resolution != "" || got.DuplicateOf != "" {
+		t.Errorf("reopen kept resolution %q duplicate_of %q", got.Resolution, got.DuplicateOf)
+	}
+
+	cmd = newCloseCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{dup, "--duplicate-of", original, "--reason", "invalid"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected conflicting --reason to be rejected")
+	}
+}
+
+func TestCloseRequireReason(t *testing.T) {
+	app, store := setupTestApp(t)
+	app.ConfigStore = &mapConfigStore{data: map[string]string{closeRequireReasonKey: "true"}}
+	ctx := context.Background()
+	id, _ := store.Create(ctx, &issuestorage.Issue{Title: "Issue"})
+
+	for _, args := range [][]string{{id}, {id, "--reason", "Shipped"}} {
+		cmd := newCloseCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), closeRequireReasonKey) {
+			t.Errorf("close %v: expected %s error, got %v", args, closeRequireReasonKey, err)
+		}
+	}
+	cmd := newCloseCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{id, "--reason", "obsolete"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("close with resolution: %v", err)
+	}
+}
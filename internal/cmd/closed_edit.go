@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// closedEditKey selects what happens when update or comment targets a
+// closed issue.
+const closedEditKey = "closed.edit"
+
+// closedEditModes lists the values accepted for closed.edit:
+//   - allow: edit silently (the default)
+//   - warn: edit, printing a warning to stderr
+//   - force: refuse unless --force is given
+//   - reopen: reopen the issue as part of the edit
+var closedEditModes = []string{"allow", "warn", "force", "reopen"}
+
+// closedEditMode returns the configured closed.edit mode.
+func closedEditMode(app *App) string {
+	if app.ConfigStore == nil {
+		return "allow"
+	}
+	if v, ok := app.ConfigStore.Get(closedEditKey); ok && v != "" {
+		return v
+	}
+	return "allow"
+}
+
+// validateClosedEdit is the config validator for closed.edit.
+func validateClosedEdit(v string) string {
+	if !contains(closedEditModes, v) {
+		return fmt.Sprintf("%s: invalid value %q (valid: %s)", closedEditKey, v, strings.Join(closedEditModes, ", "))
+	}
+	return ""
+}
+
+// checkClosedEdit applies the closed.edit policy before editing issueID.
+// It returns true if the edit should also reopen the issue. --force skips
+// the policy and edits the closed issue as-is. A missing issue is left for
+// the edit itself to report.
+func checkClosedEdit(ctx context.Context, app *App, issueID string, force bool) (bool, error) {
+	mode := closedEditMode(app)
+	if force || mode == "allow" {
+		return false, nil
+	}
+	issue, err := app.Storage.Get(ctx, issueID)
+	if err != nil || issue.Status != issuestorage.StatusClosed {
+		return false, nil
+	}
+	switch mode {
+	case "warn":
+		fmt.Fprintf(app.Err, "warning: %s is closed; editing it anyway (reopen it first to resume work)\n", issueID)
+	case "force":
+		return false, fmt.Errorf("%s is closed; reopen it first or pass --force to edit it anyway", issueID)
+	case "reopen":
+		return true, nil
+	}
+	return false, nil
+}
+
+// guardClosedComment applies the closed.edit policy before commenting on
+// issueID, reopening the issue first under the reopen policy.
+func guardClosedComment(ctx context.Context, app *App, issueID string, force bool) error {
+	reopen, err := checkClosedEdit(ctx, app, issueID, force)
+	if err != nil || !reopen {
+		return err
+	}
+	if err := app.Storage.Modify(ctx, issueID, func(i *issuestorage.Issue) error {
+		i.Status = issuestorage.StatusOpen
+		return nil
+	}); err != nil {
+		return fmt.Errorf("reopening issue %s: %w", issueID, err)
+	}
+	fmt.Fprintf(app.Err, "Reopened %s (closed.edit is reopen)\n", issueID)
+	return nil
+}
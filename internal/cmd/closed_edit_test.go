@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// setupClosedEditTest returns a test app with closed.edit set to mode and
+// a closed issue.
+func setupClosedEditTest(t *testing.T, mode string) (*App, string) {
+	t.Helper()
+	app, store := setupTestApp(t)
+	app.ConfigStore = &mapConfigStore{data: map[string]string{closedEditKey: mode}}
+	ctx := context.Background()
+	id, err := store.Create(ctx, &issuestorage.Issue{Title: "Done"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Modify(ctx, id, func(i *issuestorage.Issue) error { i.Status = issuestorage.StatusClosed; return nil }); err != nil {
+		t.Fatal(err)
+	}
+	return app, id
+}
+
+func runUpdate(app *App, args ...string) error {
+	cmd := newUpdateCmd(NewTestProvider(app))
+	cmd.SetArgs(args)
+	return cmd.Execute()
+}
+
+func TestClosedEdit_Allow(t *testing.T) {
+	app, id := setupClosedEditTest(t, "allow")
+	if err := runUpdate(app, id, "--title", "Renamed"); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	issue, _ := app.Storage.Get(context.Background(), id)
+	if issue.Title != "Renamed" || issue.Status != issuestorage.StatusClosed {
+		t.Errorf("got %q %s, want Renamed closed", issue.Title, issue.Status)
+	}
+	if errOut := app.Err.(*bytes.Buffer).String(); errOut != "" {
+		t.Errorf("unexpected stderr: %q", errOut)
+	}
+}
+
+func TestClosedEdit_Warn(t *testing.T) {
+	app, id := setupClosedEditTest(t, "warn")
+	if err := runUpdate(app, id, "--title", "Renamed"); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if errOut := app.Err.(*bytes.Buffer).String(); !strings.Contains(errOut, id+" is closed") {
+		t.Errorf("expected warning, got %q", errOut)
+	}
+	issue, _ := app.Storage.Get(context.Background(), id)
+	if issue.Title != "Renamed" {
+		t.Errorf("title = %q, want Renamed", issue.Title)
+	}
+}
+
+func TestClosedEdit_Force(t *testing.T) {
+	app, id := setupClosedEditTest(t, "force")
+	if err := runUpdate(app, id, "--title", "Renamed"); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("expected --force error, got %v", err)
+	}
+	issue, _ := app.Storage.Get(context.Background(), id)
+	if issue.Title != "Done" {
+		t.Errorf("title changed to %q without --force", issue.Title)
+	}
+	if err := runUpdate(app, id, "--title", "Renamed", "--force"); err != nil {
+		t.Fatalf("update --force: %v", err)
+	}
+	// Reopening via --status is always allowed.
+	if err := runUpdate(app, id, "--status", "open"); err != nil {
+		t.Fatalf("update --status: %v", err)
+	}
+
+	app2, id2 := setupClosedEditTest(t, "force")
+	cmd := newCommentsCmd(NewTestProvider(app2))
+	cmd.SetArgs([]string{id2, "late note"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected comment on closed issue to be refused")
+	}
+	add := newCommentsAddCmd(NewTestProvider(app2))
+	add.SetArgs([]string{id2, "late note", "--force"})
+	if err := add.Execute(); err != nil {
+		t.Fatalf("comments add --force: %v", err)
+	}
+}
+
+func TestClosedEdit_Reopen(t *testing.T) {
+	app, id := setupClosedEditTest(t, "reopen")
+	if err := runUpdate(app, id, "--priority", "1"); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	issue, _ := app.Storage.Get(context.Background(), id)
+	if issue.Status != issuestorage.StatusOpen || issue.ClosedAt != nil || issue.Priority != issuestorage.PriorityHigh {
+		t.Errorf("got status %s closed_at %v priority %d, want reopened P1", issue.Status, issue.ClosedAt, issue.Priority)
+	}
+
+	app2, id2 := setupClosedEditTest(t, "reopen")
+	cmd := newCommentsAddCmd(NewTestProvider(app2))
+	cmd.SetArgs([]string{id2, "found a regression"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("comments add: %v", err)
+	}
+	issue, _ = app2.Storage.Get(context.Background(), id2)
+	if issue.Status != issuestorage.StatusOpen || len(issue.Comments) != 1 {
+		t.Errorf("got status %s with %d comments, want reopened with 1", issue.Status, len(issue.Comments))
+	}
+	if errOut := app2.Err.(*bytes.Buffer).String(); !strings.Contains(errOut, "Reopened "+id2) {
+		t.Errorf("expected reopen note, got %q", errOut)
+	}
+}
+
+func TestClosedEdit_ConfigValidator(t *testing.T) {
+	if msg := configValidators[closedEditKey]("reopen"); msg != "" {
+		t.Errorf("reopen rejected: %s", msg)
+	}
+	if msg := configValidators[closedEditKey]("sometimes"); msg == "" {
+		t.Error("expected invalid mode to be rejected")
+	}
+}
@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 )
 
@@ -40,5 +38,5 @@ Use the 'add' subcommand to add a new comment.`
 }
 
 func printCommentDeprecationWarning(provider *AppProvider) {
-	fmt.Fprintln(provider.Err, `Warning: "comment" is deprecated, use "comments" instead`)
+	warnDeprecated(provider.Err, provider.Compat, `"comment"`, `use "comments" instead`)
 }
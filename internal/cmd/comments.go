@@ -8,16 +8,18 @@ import (
 	"io"
 	"os"
 	"strings"
-	"time"
 
+	"beads-lite/internal/issueservice"
 	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/notify"
 
 	"github.com/spf13/cobra"
 )
 
 // addComment adds a comment to an issue via Modify, auto-assigning the next
-// sequential comment ID if comment.ID is zero.
-func addComment(ctx context.Context, store issuestorage.IssueStore, issueID string, comment *issuestorage.Comment) error {
+// sequential comment ID if comment.ID is zero and a UUID if it has none.
+// Identities @mentioned in the comment are subscribed to the issue.
+func addComment(ctx context.Context, store *issueservice.IssueStore, issueID string, comment *issuestorage.Comment) error {
 	return store.Modify(ctx, issueID, func(issue *issuestorage.Issue) error {
 		if comment.ID == 0 {
 			maxID := 0
@@ -28,18 +30,61 @@ func addComment(ctx context.Context, store issuestorage.IssueStore, issueID stri
 			}
 			comment.ID = maxID + 1
 		}
+		if comment.UUID == "" {
+			comment.UUID = store.NewUUID()
+		}
 		if comment.CreatedAt.IsZero() {
-			comment.CreatedAt = time.Now()
+			comment.CreatedAt = store.Now()
 		}
 		issue.Comments = append(issue.Comments, *comment)
+		mentions := comment.Mentions
+		if mentions == nil {
+			mentions = issuestorage.ParseMentions(comment.Text)
+		}
+		for _, name := range mentions {
+			issue.Subscribe(name)
+		}
 		return nil
 	})
 }
 
+// notifyMentions sends a "mentioned" notification when comment @mentions
+// the local actor, unless the actor wrote it. Mentions of anyone else reach
+// them when they sync the comment.
+func notifyMentions(ctx context.Context, app *App, issueID string, comment *issuestorage.Comment) {
+	notifier := newNotifier(app)
+	if notifier == nil {
+		return
+	}
+	actor, err := resolveActor(app)
+	if err != nil || issuestorage.MentionMatches(actor, comment.Author) {
+		return
+	}
+	for _, name := range issuestorage.ParseMentions(comment.Text) {
+		if !issuestorage.MentionMatches(name, actor) {
+			continue
+		}
+		var title string
+		if issue, err := app.Storage.Get(ctx, issueID); err == nil {
+			title = issue.Title
+		}
+		detail := "@" + name + " mentioned"
+		if comment.Author != "" {
+			detail += " by " + comment.Author
+		}
+		if err := notifier.Notify(notify.Event{Kind: notify.KindMentioned, IssueID: issueID, Title: title, Detail: detail}); err != nil {
+			fmt.Fprintf(app.Err, "warning: %v\n", err)
+		}
+		return
+	}
+}
+
 // newCommentsCmd creates the comments command.
 // `bd comments <issue-id>` lists comments (default behavior).
 // `bd comments add <issue-id> <message>` adds a comment.
 func newCommentsCmd(provider *AppProvider) *cobra.Command {
+	var force bool
+
 	cmd := &cobra.Command{
 		Use:   "comments <issue-id> [message]",
 		Short: "List or add comments on an issue",
@@ -77,15 +122,20 @@ Examples:
 				comment := &issuestorage.Comment{
 					Author:    author,
 					Text:      message,
-					CreatedAt: time.Now(),
+					CreatedAt: app.Now(),
 				}
 
+				if err := guardClosedComment(ctx, app, issueID, force); err != nil {
+					return err
+				}
+				indexMentions(ctx, app, comment)
 				if err := addComment(ctx, store, issueID, comment); err != nil {
 					if err == issuestorage.ErrNotFound {
 						return fmt.Errorf("issue %s not found", issueID)
 					}
 					return fmt.Errorf("adding comment: %w", err)
 				}
+				notifyMentions(ctx, app, issueID, comment)
 
 				if app.JSON {
 					result := CommentJSON{
@@ -142,6 +192,7 @@ Examples:
 		},
 	}
 
+	cmd.Flags().BoolVar(&force, "force", false, "Comment on a closed issue even if closed.edit is force or reopen")
 	cmd.AddCommand(newCommentsAddCmd(provider))
 
 	return cmd
@@ -151,6 +202,7 @@ Examples:
 func newCommentsAddCmd(provider *AppProvider) *cobra.Command {
 	var author string
 	var file string
+	var force bool
 
 	cmd := &cobra.Command{
 		Use:   "add <issue-id> [message]",
@@ -160,6 +212,10 @@ func newCommentsAddCmd(provider *AppProvider) *cobra.Command {
 The message can be provided as the second argument, read from a file with -f,
 or read from stdin using - as the message.
 
+Identities @mentioned in the message are subscribed to the issue and, when
+notify.enabled is set, sent a "mentioned" notification. bd mentions lists
+recent mentions of you.
+
 Examples:
   bd comments add bd-a1b2 "This is a comment"
   bd comments add bd-a1b2 -f notes.txt
@@ -212,17 +268,22 @@ Examples:
 			comment := &issuestorage.Comment{
 				Author:    author,
 				Text:      message,
-				CreatedAt: time.Now(),
+				CreatedAt: app.Now(),
 			}
 
 			commentStore := app.Storage
 
+			if err := guardClosedComment(ctx, app, issueID, force); err != nil {
+				return err
+			}
+			indexMentions(ctx, app, comment)
 			if err := addComment(ctx, commentStore, issueID, comment); err != nil {
 				if err == issuestorage.ErrNotFound {
 					return fmt.Errorf("issue %s not found", issueID)
 				}
 				return fmt.Errorf("adding comment: %w", err)
 			}
+			notifyMentions(ctx, app, issueID, comment)
 
 			if app.JSON {
 				result := CommentJSON{
@@ -242,6 +303,7 @@ Examples:
 
 	cmd.Flags().StringVarP(&author, "author", "a", "", "Comment author")
 	cmd.Flags().StringVarP(&file, "file", "f", "", "Read comment from file")
+	cmd.Flags().BoolVar(&force, "force", false, "Comment on a closed issue even if closed.edit is force or reopen")
 
 	return cmd
 }
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -82,6 +83,33 @@ func TestCommentsAddWithAuthor(t *testing.T) {
 	}
 }
 
+func TestCommentsAddNotifiesMentionedLocalActor(t *testing.T) {
+	t.Setenv("BD_ACTOR", "alice")
+	app, store := setupTestApp(t)
+	sent := captureNotifications(app)
+	id, err := store.Create(context.Background(), &issuestorage.Issue{Title: "Mentions"})
+	if err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	for _, args := range [][]string{
+		{"@bob please look", "--author", "carol"},    // someone else
+		{"note to self @alice", "--author", "alice"}, // the actor's own comment
+		{"@alice please look", "--author", "carol"},
+	} {
+		cmd := newCommentsAddCmd(NewTestProvider(app))
+		cmd.SetArgs(append([]string{id}, args...))
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("comments add %q: %v", args[0], err)
+		}
+	}
+
+	want := []string{"Mentioned in " + id + ": Mentions\n@alice mentioned by carol"}
+	if !reflect.DeepEqual(*sent, want) {
+		t.Errorf("notifications = %q, want %q", *sent, want)
+	}
+}
+
 func TestCommentsAddFromFile(t *testing.T) {
 	app, store := setupTestApp(t)
 
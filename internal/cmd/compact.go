@@ -36,6 +36,9 @@ Filter which closed issues to remove:
 
 If no filter is specified, all closed issues will be targeted.
 
+Deletion holds the maintenance lock (see bd maintenance), so writes from
+other processes wait for it or fail.
+
 Examples:
   bd compact --dry-run                  # Preview all closed issues that would be removed
   bd compact --older-than 30d           # Remove issues closed more than 30 days ago
@@ -66,7 +69,7 @@ Examples:
 				if err != nil {
 					return fmt.Errorf("invalid --older-than duration %q: %w", olderThan, err)
 				}
-				cutoff = time.Now().Add(-dur)
+				cutoff = app.Now().Add(-dur)
 			}
 
 			// Get all closed issues
@@ -141,7 +144,12 @@ Examples:
 				}
 			}
 
-			// Delete the issues
+			// Delete the issues, keeping other processes' writes out
+			release, err := acquireMaintenance(ctx, app, "compact")
+			if err != nil {
+				return err
+			}
+			defer release()
 			var deleted []string
 			var errors []error
 			for _, issue := range toDelete {
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/schema"
+
+	"github.com/spf13/cobra"
+)
+
+// compatVersion is the major version of bd's documented behavior: the
+// --json output contract (schema.Version) together with the commands,
+// flags and flag defaults described in the README. A change that could
+// break a script bumps it. --compat names the version a script expects:
+// newer versions are refused, and the legacy behaviors of that version
+// are accepted without a deprecation warning.
+const compatVersion = schema.Version
+
+// parseCompat parses a --compat or BD_COMPAT value. Versions newer than
+// this bd are refused, so a script written against a later bd fails
+// instead of silently getting older formats.
+func parseCompat(s string) (int, error) {
+	v, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(s), "v"))
+	if err != nil || v < 1 {
+		return 0, fmt.Errorf("invalid --compat %q: must be a major version number, e.g. 1", s)
+	}
+	if v > compatVersion {
+		return 0, fmt.Errorf("--compat %d is newer than this bd, which supports 1 through %d; upgrade bd", v, compatVersion)
+	}
+	return v, nil
+}
+
+// legacyUntil is the last major version in which the deprecated behaviors
+// below are part of the documented behavior.
+const legacyUntil = 1
+
+// warnDeprecated tells the user that a legacy behavior they asked for is
+// deprecated. A caller that pinned --compat to a version the behavior
+// belongs to asked for it knowingly, and is not warned.
+func warnDeprecated(w io.Writer, compat int, legacy, instead string) {
+	if compat != 0 && compat <= legacyUntil {
+		return
+	}
+	fmt.Fprintf(w, "Warning: %s is deprecated, %s\n", legacy, instead)
+}
+
+// legacyFlags are the global flags accepted for compatibility with the
+// reference implementation that have no effect in beads-lite.
+var legacyFlags = []string{"no-auto-flush", "no-auto-import", "no-db", "sandbox", "readonly", "allow-stale"}
+
+// warnLegacyFlags warns about each legacy flag set on cmd's command line.
+func warnLegacyFlags(w io.Writer, compat int, cmd *cobra.Command) {
+	for _, name := range legacyFlags {
+		if cmd.Flags().Changed(name) {
+			warnDeprecated(w, compat, "--"+name, "it has no effect and can be dropped")
+		}
+	}
+}
+
+// warnLegacyPriority warns when a priority is written as a word ("high")
+// rather than P0-P4.
+func warnLegacyPriority(w io.Writer, compat int, value string) {
+	if _, err := strconv.Atoi(value); err == nil || strings.HasPrefix(strings.ToUpper(value), "P") {
+		return
+	}
+	if p, err := issuestorage.ParsePriority(value); err == nil {
+		warnDeprecated(w, compat, fmt.Sprintf("priority %q", value), fmt.Sprintf("use P%d instead", p))
+	}
+}
@@ -3,13 +3,21 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"beads-lite/internal/config"
 	"beads-lite/internal/config/yamlstore"
+	"beads-lite/internal/issueservice"
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/issuestorage/cached"
+	"beads-lite/internal/issuestorage/filesystem"
+	"beads-lite/internal/issuestorage/metrics"
+	"beads-lite/internal/notify"
 
 	"github.com/spf13/cobra"
 )
@@ -142,6 +150,9 @@ Examples:
 			if err := store.Set(key, value); err != nil {
 				return fmt.Errorf("setting config: %w", err)
 			}
+			if key == "defaults.priority" {
+				warnLegacyPriority(app.Err, app.Compat, value)
+			}
 
 			if app.JSON {
 				result := map[string]string{
@@ -286,6 +297,9 @@ var validTypes = map[string]bool{
 	"chore":    true,
 	"gate":     true,
 	"molecule": true,
+	"risk":     true,
+	"decision": true,
+	"question": true,
 }
 
 // configValidators maps known keys to their validation functions.
@@ -298,6 +312,45 @@ var configValidators = map[string]func(string) string{
 		}
 		return ""
 	},
+	closeRequireReasonKey: func(v string) string {
+		if v != "true" && v != "false" {
+			return fmt.Sprintf("%s: must be \"true\" or \"false\", got %q", closeRequireReasonKey, v)
+		}
+		return ""
+	},
+	githubRepoKey: func(v string) string {
+		if err := checkGitHubRepo(v); err != nil {
+			return fmt.Sprintf("%s: %v", githubRepoKey, err)
+		}
+		return ""
+	},
+	ciCommentsKey: func(v string) string {
+		if v != "true" && v != "false" {
+			return fmt.Sprintf("%s: must be \"true\" or \"false\", got %q", ciCommentsKey, v)
+		}
+		return ""
+	},
+	jiraURLKey: func(v string) string {
+		if u, err := url.Parse(v); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Sprintf("%s: must be the site's http(s) URL, e.g. https://acme.atlassian.net, got %q", jiraURLKey, v)
+		}
+		return ""
+	},
+	boardRowsKey:  validateBoardField(boardRowsKey),
+	closedEditKey: validateClosedEdit,
+	reviewRequireApprovalKey: func(v string) string {
+		if v != "true" && v != "false" {
+			return fmt.Sprintf("%s: must be \"true\" or \"false\", got %q", reviewRequireApprovalKey, v)
+		}
+		return ""
+	},
+	boardColumnsKey: validateBoardField(boardColumnsKey),
+	depBacklinkCommentsKey: func(v string) string {
+		if v != "true" && v != "false" {
+			return fmt.Sprintf("%s: must be \"true\" or \"false\", got %q", depBacklinkCommentsKey, v)
+		}
+		return ""
+	},
 	"defaults.priority": func(v string) string {
 		if !validPriorities[v] {
 			keys := sortedKeys(validPriorities)
@@ -305,10 +358,94 @@ var configValidators = map[string]func(string) string{
 		}
 		return ""
 	},
+	cached.ConfigKey: func(v string) string {
+		if v != "true" && v != "false" {
+			return fmt.Sprintf("%s: must be \"true\" or \"false\", got %q", cached.ConfigKey, v)
+		}
+		return ""
+	},
+	filesystem.CompressionConfigKey: func(v string) string {
+		if _, err := filesystem.ParseCompression(v); err != nil {
+			return fmt.Sprintf("%s: %v", filesystem.CompressionConfigKey, err)
+		}
+		return ""
+	},
+	filesystem.ShardWidthConfigKey: func(v string) string {
+		if _, err := filesystem.ParseShardWidth(v); err != nil {
+			return fmt.Sprintf("%s: %v", filesystem.ShardWidthConfigKey, err)
+		}
+		return ""
+	},
+	issuestorage.BackendConfigKey: func(v string) string {
+		if _, err := issuestorage.ParseBackend(v); err != nil {
+			return fmt.Sprintf("%s: %v", issuestorage.BackendConfigKey, err)
+		}
+		return ""
+	},
+	metrics.SlowThresholdKey: func(v string) string {
+		if v == "0" {
+			return ""
+		}
+		if d, err := time.ParseDuration(v); err != nil || d < 0 {
+			return fmt.Sprintf("%s: must be a duration like \"2s\" or \"0\" to disable, got %q", metrics.SlowThresholdKey, v)
+		}
+		return ""
+	},
+	attachMaxSizeKey:  validateAttachSize(attachMaxSizeKey),
+	attachMaxTotalKey: validateAttachSize(attachMaxTotalKey),
+	serveBasePathKey: func(v string) string {
+		if _, err := parseBasePath(v); err != nil {
+			return fmt.Sprintf("%s: %v", serveBasePathKey, err)
+		}
+		return ""
+	},
+	serveTrustedProxiesKey: func(v string) string {
+		if _, err := parseTrustedProxies(v); err != nil {
+			return fmt.Sprintf("%s: %v", serveTrustedProxiesKey, err)
+		}
+		return ""
+	},
+	serveCORSOriginsKey: func(v string) string {
+		if _, err := parseCORSOrigins(v); err != nil {
+			return fmt.Sprintf("%s: %v", serveCORSOriginsKey, err)
+		}
+		return ""
+	},
+	serveTLSSelfSignedKey: func(v string) string {
+		if v != "true" && v != "false" {
+			return fmt.Sprintf("%s: must be \"true\" or \"false\", got %q", serveTLSSelfSignedKey, v)
+		}
+		return ""
+	},
+	notify.EnabledKey: func(v string) string {
+		if v != "true" && v != "false" {
+			return fmt.Sprintf("%s: must be \"true\" or \"false\", got %q", notify.EnabledKey, v)
+		}
+		return ""
+	},
+	notify.EventsKey: func(v string) string {
+		for _, s := range config.SplitCustomValues(v) {
+			if _, err := notify.ParseKind(s); err != nil {
+				return fmt.Sprintf("%s: %v", notify.EventsKey, err)
+			}
+		}
+		return ""
+	},
 }
 
 // configStoreValidators maps known keys to validators that need access to the full config.
 var configStoreValidators = map[string]func(string, config.Store) string{
+	contextActiveKey: func(v string, store config.Store) string {
+		filter, ok := store.Get(contextFilterKey(v))
+		if !ok {
+			return fmt.Sprintf("%s: no context named %q", contextActiveKey, v)
+		}
+		custom, _ := store.Get("types.custom")
+		if _, err := parseContextFilter(filter, config.SplitCustomValues(custom)); err != nil {
+			return fmt.Sprintf("%s: context %s: %v", contextActiveKey, v, err)
+		}
+		return ""
+	},
 	"defaults.type": func(v string, store config.Store) string {
 		if validTypes[v] {
 			return ""
@@ -367,6 +504,37 @@ Examples:
 						errors = append(errors, msg)
 					}
 				}
+				if strings.HasPrefix(key, assignKeyPrefix) {
+					if msg := validateAssignKey(key, value); msg != "" {
+						errors = append(errors, msg)
+					}
+				}
+				if strings.HasPrefix(key, oooKeyPrefix) {
+					if msg := validateOOORanges(key, value); msg != "" {
+						errors = append(errors, msg)
+					}
+				}
+				if strings.HasPrefix(key, linkKeyPrefix) {
+					if msg := validateLinkKey(key, value); msg != "" {
+						errors = append(errors, msg)
+					}
+				}
+				if strings.HasPrefix(key, calendarKeyPrefix) {
+					if msg := validateCalendarKey(key, value); msg != "" {
+						errors = append(errors, msg)
+					}
+				}
+				if strings.HasPrefix(key, expireKeyPrefix) {
+					if msg := validateExpireKey(key, value); msg != "" {
+						errors = append(errors, msg)
+					}
+				}
+			}
+			if _, err := issueservice.ParseTypeRules(all); err != nil {
+				errors = append(errors, strings.Split(err.Error(), "; ")...)
+			}
+			if _, err := issueservice.ParseWorkflow(all); err != nil {
+				errors = append(errors, strings.Split(err.Error(), "; ")...)
 			}
 			sort.Strings(errors)
 
@@ -156,6 +156,24 @@ func TestConfigSet_CoreKey(t *testing.T) {
 	}
 }
 
+func TestConfigSet_LegacyPriority(t *testing.T) {
+	app, _ := setupConfigTestApp(t)
+	errOut := &bytes.Buffer{}
+	app.Err = errOut
+
+	for value, warn := range map[string]string{"high": `priority "high" is deprecated, use P1 instead`, "P1": "", "3": ""} {
+		errOut.Reset()
+		cmd := newConfigSetCmd(NewTestProvider(app))
+		cmd.SetArgs([]string{"defaults.priority", value})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("config set %s failed: %v", value, err)
+		}
+		if got := strings.TrimSpace(errOut.String()); !strings.Contains(got, warn) || (warn == "" && got != "") {
+			t.Errorf("config set defaults.priority %s: stderr %q, want %q", value, got, warn)
+		}
+	}
+}
+
 func TestConfigSet_CustomKey(t *testing.T) {
 	app, out := setupConfigTestApp(t)
 
@@ -392,6 +410,38 @@ func TestConfigValidate_Errors(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_NotifyKeys(t *testing.T) {
+	app, _ := setupConfigTestApp(t)
+	seedConfigStore(t, app.ConfigDir, map[string]string{
+		"notify.enabled": "yes",
+		"notify.events":  "assigned,closed",
+	})
+
+	cmd := newConfigValidateCmd(NewTestProvider(app))
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("config validate should have failed")
+	}
+	if !strings.Contains(err.Error(), "2 error(s)") {
+		t.Errorf("expected 2 errors, got: %v", err)
+	}
+}
+
+func TestConfigValidate_StorageBackend(t *testing.T) {
+	app, out := setupConfigTestApp(t)
+	seedConfigStore(t, app.ConfigDir, map[string]string{
+		"storage.backend": "dynamodb",
+	})
+
+	cmd := newConfigValidateCmd(NewTestProvider(app))
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("config validate should have failed")
+	}
+	if !strings.Contains(out.String(), "storage.backend") {
+		t.Errorf("expected storage.backend error, got: %s", out.String())
+	}
+}
+
 func TestConfigValidate_JSON_Valid(t *testing.T) {
 	app, out := setupConfigTestApp(t)
 	app.JSON = true
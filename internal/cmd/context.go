@@ -0,0 +1,392 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"beads-lite/internal/config"
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// Config keys for filter contexts. Each context is stored as
+// context.<name>.filter; context.active names the one in use.
+const (
+	contextActiveKey    = "context.active"
+	contextKeyPrefix    = "context."
+	contextFilterSuffix = ".filter"
+)
+
+// contextFilterFields lists the fields a context filter can match on.
+var contextFilterFields = []string{"label", "assignee", "type", "priority", "severity", "parent"}
+
+// ContextJSON is the JSON output format for a filter context.
+type ContextJSON struct {
+	Name   string `json:"name"`
+	Filter string `json:"filter"`
+	Active bool   `json:"active"`
+}
+
+// newContextCmd creates the context command with subcommands.
+func newContextCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "context",
+		Short: "Manage filter contexts that scope list, ready and board",
+		Long: `Manage filter contexts: named filters that implicitly scope bd list,
+bd ready and bd board while in use, so you don't repeat the same flags
+when working in one area for a while.
+
+A filter is a space-separated list of field:value terms. Values are
+comma-separated; an issue matches if it matches any value of every
+field. Fields: ` + strings.Join(contextFilterFields, ", ") + `.
+
+Contexts are stored in config as context.<name>.filter and the active one
+as context.active. BD_CONTEXT selects a context for the current shell
+without changing config. Pass --no-context to a scoped command to ignore
+the active context once, or --context <name> to use another one once.
+
+Subcommands:
+  create  Create or replace a context
+  use     Make a context active
+  clear   Stop using the active context
+  list    List contexts
+  delete  Delete a context`,
+	}
+
+	cmd.AddCommand(newContextCreateCmd(provider))
+	cmd.AddCommand(newContextUseCmd(provider))
+	cmd.AddCommand(newContextClearCmd(provider))
+	cmd.AddCommand(newContextListCmd(provider))
+	cmd.AddCommand(newContextDeleteCmd(provider))
+
+	return cmd
+}
+
+// newContextCreateCmd creates the "context create" subcommand.
+func newContextCreateCmd(provider *AppProvider) *cobra.Command {
+	var filter string
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create or replace a context",
+		Long: `Create a named filter context, replacing any existing one of that name.
+
+Examples:
+  bd context create backend --filter 'label:backend'
+  bd context create mine --filter 'assignee:alice type:bug,task'
+  bd context create auth --filter 'parent:bd-a1b2 priority:1'`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			name := args[0]
+			if name == "" || strings.ContainsAny(name, ". \t") {
+				return fmt.Errorf("invalid context name %q: must not contain dots or spaces", name)
+			}
+			if _, err := parseContextFilter(filter, getCustomValues(app, "types.custom")); err != nil {
+				return err
+			}
+			store, err := configStore(provider)
+			if err != nil {
+				return err
+			}
+			if err := store.Set(contextFilterKey(name), filter); err != nil {
+				return fmt.Errorf("saving context: %w", err)
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(ContextJSON{Name: name, Filter: filter, Active: activeContextName(app) == name})
+			}
+			fmt.Fprintf(app.Out, "%s Created context %s (use it with: bd context use %s)\n", app.SuccessColor("✓"), name, name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&filter, "filter", "", "Filter terms, e.g. 'label:backend assignee:alice'")
+	_ = cmd.MarkFlagRequired("filter")
+
+	return cmd
+}
+
+// newContextUseCmd creates the "context use" subcommand.
+func newContextUseCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "use <name>",
+		Short: "Make a context active",
+		Long: `Make a context active, scoping bd list, bd ready and bd board to its
+filter until bd context clear.
+
+Examples:
+  bd context use backend`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			name := args[0]
+			store, err := configStore(provider)
+			if err != nil {
+				return err
+			}
+			filter, ok := store.Get(contextFilterKey(name))
+			if !ok {
+				return fmt.Errorf("no context named %q (create it with bd context create)", name)
+			}
+			if err := store.Set(contextActiveKey, name); err != nil {
+				return fmt.Errorf("saving active context: %w", err)
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(ContextJSON{Name: name, Filter: filter, Active: true})
+			}
+			fmt.Fprintf(app.Out, "%s Using context %s (%s)\n", app.SuccessColor("✓"), name, filter)
+			return nil
+		},
+	}
+}
+
+// newContextClearCmd creates the "context clear" subcommand.
+func newContextClearCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "clear",
+		Short: "Stop using the active context",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			store, err := configStore(provider)
+			if err != nil {
+				return err
+			}
+			if err := store.Unset(contextActiveKey); err != nil {
+				return fmt.Errorf("clearing active context: %w", err)
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(map[string]any{"active": nil})
+			}
+			fmt.Fprintf(app.Out, "%s No context in use\n", app.SuccessColor("✓"))
+			return nil
+		},
+	}
+}
+
+// newContextListCmd creates the "context list" subcommand.
+func newContextListCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List contexts",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			contexts := listContexts(app)
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(contexts)
+			}
+			if len(contexts) == 0 {
+				fmt.Fprintln(app.Out, "No contexts defined.")
+				return nil
+			}
+			for _, c := range contexts {
+				mark := " "
+				if c.Active {
+					mark = "*"
+				}
+				fmt.Fprintf(app.Out, "%s %s  %s\n", mark, c.Name, c.Filter)
+			}
+			return nil
+		},
+	}
+}
+
+// newContextDeleteCmd creates the "context delete" subcommand.
+func newContextDeleteCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a context",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			name := args[0]
+			store, err := configStore(provider)
+			if err != nil {
+				return err
+			}
+			if _, ok := store.Get(contextFilterKey(name)); !ok {
+				return fmt.Errorf("no context named %q", name)
+			}
+			if err := store.Unset(contextFilterKey(name)); err != nil {
+				return fmt.Errorf("deleting context: %w", err)
+			}
+			if active, _ := store.Get(contextActiveKey); active == name {
+				if err := store.Unset(contextActiveKey); err != nil {
+					return fmt.Errorf("clearing active context: %w", err)
+				}
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(map[string]string{"deleted": name})
+			}
+			fmt.Fprintf(app.Out, "%s Deleted context %s\n", app.SuccessColor("✓"), name)
+			return nil
+		},
+	}
+}
+
+// contextFilterKey returns the config key holding a context's filter.
+func contextFilterKey(name string) string {
+	return contextKeyPrefix + name + contextFilterSuffix
+}
+
+// activeContextName returns the name of the context in use, or "".
+func activeContextName(app *App) string {
+	if app.ConfigStore == nil {
+		return ""
+	}
+	name, _ := app.ConfigStore.Get(contextActiveKey)
+	return name
+}
+
+// listContexts returns the defined contexts sorted by name.
+func listContexts(app *App) []ContextJSON {
+	contexts := []ContextJSON{}
+	if app.ConfigStore == nil {
+		return contexts
+	}
+	active := activeContextName(app)
+	for key, filter := range app.ConfigStore.All() {
+		if !strings.HasPrefix(key, contextKeyPrefix) || !strings.HasSuffix(key, contextFilterSuffix) {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(key, contextKeyPrefix), contextFilterSuffix)
+		contexts = append(contexts, ContextJSON{Name: name, Filter: filter, Active: name == active})
+	}
+	sort.Slice(contexts, func(i, j int) bool { return contexts[i].Name < contexts[j].Name })
+	return contexts
+}
+
+// addContextFlags registers --no-context and --context on a command scoped
+// by the active context.
+func addContextFlags(cmd *cobra.Command, noContext *bool, name *string) {
+	cmd.Flags().BoolVar(noContext, "no-context", false, "Ignore the active filter context")
+	cmd.Flags().StringVar(name, "context", "", "Scope by this filter context instead of the active one")
+}
+
+// activeContextFilter returns the filter of the named context, or if name
+// is empty the active one; nil if none is in use or noContext is set. In
+// text mode it notes the context on stderr so scoped output is never
+// mistaken for the full list.
+func activeContextFilter(app *App, noContext bool, name string) (*issuestorage.ListFilter, error) {
+	explicit := name != ""
+	if !explicit {
+		name = activeContextName(app)
+	}
+	if noContext || name == "" {
+		return nil, nil
+	}
+	var raw string
+	ok := false
+	if app.ConfigStore != nil {
+		raw, ok = app.ConfigStore.Get(contextFilterKey(name))
+	}
+	if !ok {
+		if explicit {
+			return nil, fmt.Errorf("context %q is not defined (see bd context list)", name)
+		}
+		return nil, fmt.Errorf("active context %q is not defined (bd context clear to stop using it)", name)
+	}
+	filter, err := parseContextFilter(raw, getCustomValues(app, "types.custom"))
+	if err != nil {
+		return nil, fmt.Errorf("context %s: %w", name, err)
+	}
+	if !app.JSON {
+		fmt.Fprintf(app.Err, "(context: %s — %s; --no-context to ignore)\n", name, raw)
+	}
+	return filter, nil
+}
+
+// scopeIssues returns the issues matching filter, or all of them if filter
+// is nil.
+func scopeIssues(issues []*issuestorage.Issue, filter *issuestorage.ListFilter) []*issuestorage.Issue {
+	if filter == nil {
+		return issues
+	}
+	var scoped []*issuestorage.Issue
+	for _, issue := range issues {
+		if filter.Matches(issue) {
+			scoped = append(scoped, issue)
+		}
+	}
+	return scoped
+}
+
+// parseContextFilter parses space-separated field:value terms into a list
+// filter, accepting customTypes as well as the built-in types.
+func parseContextFilter(s string, customTypes []string) (*issuestorage.ListFilter, error) {
+	filter := &issuestorage.ListFilter{}
+	terms := strings.Fields(s)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("empty filter (expected terms like label:backend)")
+	}
+	for _, term := range terms {
+		field, value, ok := strings.Cut(term, ":")
+		values := config.SplitCustomValues(value)
+		if !ok || len(values) == 0 {
+			return nil, fmt.Errorf("invalid filter term %q (expected field:value)", term)
+		}
+		switch field {
+		case "label":
+			filter.Labels = append(filter.Labels, values...)
+		case "assignee":
+			filter.Assignees = append(filter.Assignees, values...)
+		case "type":
+			for _, v := range values {
+				t, err := parseType(v, customTypes)
+				if err != nil {
+					return nil, err
+				}
+				filter.Types = append(filter.Types, t)
+			}
+		case "severity":
+			for _, v := range values {
+				sev, err := issuestorage.ParseSeverity(v)
+				if err != nil {
+					return nil, err
+				}
+				filter.Severities = append(filter.Severities, sev)
+			}
+		case "priority":
+			if len(values) > 1 || filter.Priority != nil {
+				return nil, fmt.Errorf("filter term %q: only one priority can be given", term)
+			}
+			p, err := parsePriority(values[0])
+			if err != nil {
+				return nil, err
+			}
+			filter.Priority = &p
+		case "parent":
+			if len(values) > 1 || filter.Parent != nil {
+				return nil, fmt.Errorf("filter term %q: only one parent can be given", term)
+			}
+			filter.Parent = &values[0]
+		default:
+			return nil, fmt.Errorf("unknown filter field %q (valid: %s)", field, strings.Join(contextFilterFields, ", "))
+		}
+	}
+	return filter, nil
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/config/yamlstore"
+	"beads-lite/internal/issuestorage"
+)
+
+// setupContextTestApp returns a test app whose config lives in a YAML file,
+// as the context subcommands write config through configStore.
+func setupContextTestApp(t *testing.T) (*App, func(args ...string) error) {
+	t.Helper()
+	app, _ := setupTestApp(t)
+	app.ConfigDir = t.TempDir()
+	reload := func() {
+		store, err := yamlstore.New(filepath.Join(app.ConfigDir, "config.yaml"))
+		if err != nil {
+			t.Fatalf("opening config: %v", err)
+		}
+		app.ConfigStore = store
+	}
+	reload()
+	run := func(args ...string) error {
+		app.Out.(*bytes.Buffer).Reset()
+		cmd := newContextCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		reload()
+		return err
+	}
+	return app, run
+}
+
+// listTitles runs bd list --json with args and returns the titles listed.
+func listTitles(t *testing.T, app *App, args ...string) string {
+	t.Helper()
+	app.JSON = true
+	defer func() { app.JSON = false }()
+	app.Out.(*bytes.Buffer).Reset()
+	cmd := newListCmd(NewTestProvider(app))
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	var issues []IssueListJSON
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &issues); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	var titles []string
+	for _, issue := range issues {
+		titles = append(titles, issue.Title)
+	}
+	return strings.Join(titles, ",")
+}
+
+func TestContextScopesList(t *testing.T) {
+	app, run := setupContextTestApp(t)
+	ctx := context.Background()
+	app.Storage.Create(ctx, &issuestorage.Issue{Title: "api", Labels: []string{"backend"}, Priority: issuestorage.PriorityHigh})
+	app.Storage.Create(ctx, &issuestorage.Issue{Title: "css", Labels: []string{"frontend"}, Priority: issuestorage.PriorityMedium})
+
+	if err := run("create", "backend", "--filter", "label:backend"); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if got := listTitles(t, app); got != "api,css" {
+		t.Errorf("before use: %s, want api,css", got)
+	}
+	if got := listTitles(t, app, "--context", "backend"); got != "api" {
+		t.Errorf("--context backend: %s, want api", got)
+	}
+	if err := run("use", "backend"); err != nil {
+		t.Fatalf("use: %v", err)
+	}
+	if got := listTitles(t, app); got != "api" {
+		t.Errorf("in context: %s, want api", got)
+	}
+	if got := listTitles(t, app, "--no-context"); got != "api,css" {
+		t.Errorf("--no-context: %s, want api,css", got)
+	}
+	if err := run("create", "frontend", "--filter", "label:frontend"); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if got := listTitles(t, app, "--context", "frontend"); got != "css" {
+		t.Errorf("--context frontend in backend: %s, want css", got)
+	}
+	if err := run("clear"); err != nil {
+		t.Fatalf("clear: %v", err)
+	}
+	if got := listTitles(t, app); got != "api,css" {
+		t.Errorf("after clear: %s, want api,css", got)
+	}
+}
+
+func TestContextScopesReady(t *testing.T) {
+	app, run := setupContextTestApp(t)
+	ctx := context.Background()
+	app.Storage.Create(ctx, &issuestorage.Issue{Title: "mine", Assignee: "alice"})
+	app.Storage.Create(ctx, &issuestorage.Issue{Title: "theirs", Assignee: "bob"})
+	if err := run("create", "mine", "--filter", "assignee:alice"); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := run("use", "mine"); err != nil {
+		t.Fatalf("use: %v", err)
+	}
+
+	app.Out.(*bytes.Buffer).Reset()
+	cmd := newReadyCmd(NewTestProvider(app))
+	cmd.SetArgs(nil)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("ready: %v", err)
+	}
+	out := app.Out.(*bytes.Buffer).String()
+	if !strings.Contains(out, "mine") || strings.Contains(out, "theirs") {
+		t.Errorf("ready output not scoped:\n%s", out)
+	}
+	if errOut := app.Err.(*bytes.Buffer).String(); !strings.Contains(errOut, "context: mine") {
+		t.Errorf("expected context note on stderr, got %q", errOut)
+	}
+}
+
+func TestContextListAndDelete(t *testing.T) {
+	app, run := setupContextTestApp(t)
+	if err := run("create", "b", "--filter", "type:bug"); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := run("create", "a", "--filter", "label:x,y priority:1"); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := run("use", "b"); err != nil {
+		t.Fatalf("use: %v", err)
+	}
+
+	app.JSON = true
+	if err := run("list"); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	var contexts []ContextJSON
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &contexts); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	if len(contexts) != 2 || contexts[0].Name != "a" || contexts[0].Active || !contexts[1].Active {
+		t.Errorf("contexts = %+v", contexts)
+	}
+
+	if err := run("delete", "b"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if name := activeContextName(app); name != "" {
+		t.Errorf("active context = %q after deleting it", name)
+	}
+	if err := run("use", "b"); err == nil {
+		t.Error("expected error using a deleted context")
+	}
+}
+
+func TestParseContextFilter(t *testing.T) {
+	filter, err := parseContextFilter("label:backend,api assignee:alice type:bug priority:P1 parent:bd-a1", nil)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if strings.Join(filter.Labels, ",") != "backend,api" || filter.Assignees[0] != "alice" ||
+		filter.Types[0] != issuestorage.TypeBug || *filter.Priority != issuestorage.PriorityHigh || *filter.Parent != "bd-a1" {
+		t.Errorf("unexpected filter: %+v", filter)
+	}
+
+	for _, bad := range []string{"", "label", "color:red", "type:nope", "priority:1 priority:2"} {
+		if _, err := parseContextFilter(bad, nil); err == nil {
+			t.Errorf("parseContextFilter(%q): expected error", bad)
+		}
+	}
+	if _, err := parseContextFilter("type:spike", []string{"spike"}); err != nil {
+		t.Errorf("custom type rejected: %v", err)
+	}
+}
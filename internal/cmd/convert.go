@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"beads-lite/internal/issueservice"
+
+	"github.com/spf13/cobra"
+)
+
+// newConvertCmd creates the convert command.
+func newConvertCmd(provider *AppProvider) *cobra.Command {
+	var (
+		typeFlag string
+		force    bool
+		dryRun   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "convert <issue-id> --type <type>",
+		Short: "Convert an issue to a different type",
+		Long: `Convert an issue to a different type, migrating type-specific fields.
+
+Fields that only apply to the old type (for example a gate's await spec or
+a risk's likelihood and impact) are discarded. If any would be lost, the
+conversion is refused unless --force is given. Fields the new type needs
+are initialized (a new decision starts as proposed), and any per-type
+requirements for the new type must already be satisfied.
+
+Examples:
+  bd convert bd-a1b2 --type epic
+  bd convert bd-a1b2 --type task --dry-run
+  bd convert bd-a1b2 --type task --force   # drop gate await fields`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			if typeFlag == "" {
+				return fmt.Errorf("--type is required")
+			}
+			to, err := parseType(typeFlag, getCustomValues(app, "types.custom"))
+			if err != nil {
+				return err
+			}
+			issue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+
+			var dropped []string
+			if dryRun {
+				dropped = issueservice.LossyFields(issue, to)
+			} else {
+				dropped, err = app.Storage.ConvertType(ctx, issue.ID, to, force)
+				if err != nil {
+					return err
+				}
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(map[string]interface{}{
+					"id":      issue.ID,
+					"from":    string(issue.Type),
+					"to":      string(to),
+					"dropped": dropped,
+					"dry_run": dryRun,
+				})
+			}
+
+			if len(dropped) > 0 {
+				fmt.Fprintf(app.Out, "%s Discarded fields: %s\n", app.WarnColor("⚠"), strings.Join(dropped, ", "))
+			}
+			if dryRun {
+				fmt.Fprintf(app.Out, "Would convert %s from %s to %s\n", issue.ID, issue.Type, to)
+				return nil
+			}
+			fmt.Fprintf(app.Out, "%s Converted %s from %s to %s\n", app.SuccessColor("✓"), issue.ID, issue.Type, to)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&typeFlag, "type", "t", "", "Target type (required)")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Convert even if type-specific fields would be discarded")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would change without converting")
+
+	return cmd
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestConvertCmd(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+
+	id, err := store.Create(ctx, &issuestorage.Issue{Title: "Outage risk", Type: issuestorage.TypeRisk, Likelihood: 2, Impact: 5})
+	if err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	// update --type refuses to silently drop risk fields.
+	cmd := newUpdateCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{id, "--type", "task"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "bd convert") {
+		t.Errorf("expected update to point at bd convert, got %v", err)
+	}
+
+	cmd = newConvertCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{id, "--type", "task"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected lossy convert without --force to fail")
+	}
+
+	cmd = newConvertCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{id, "--type", "task", "--dry-run"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if out := app.Out.(*bytes.Buffer).String(); !strings.Contains(out, "likelihood, impact") || !strings.Contains(out, "Would convert") {
+		t.Errorf("unexpected dry-run output: %s", out)
+	}
+
+	cmd = newConvertCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{id, "--type", "task", "--force"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("forced convert failed: %v", err)
+	}
+	issue, err := store.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("failed to get issue: %v", err)
+	}
+	if issue.Type != issuestorage.TypeTask || issue.Likelihood != 0 || issue.Impact != 0 {
+		t.Errorf("unexpected converted issue: type=%s likelihood=%d impact=%d", issue.Type, issue.Likelihood, issue.Impact)
+	}
+}
@@ -9,8 +9,10 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/template"
 
 	"github.com/spf13/cobra"
 )
@@ -20,17 +22,28 @@ func newCreateCmd(provider *AppProvider) *cobra.Command {
 	var (
 		typeFlag    string
 		priority    string
+		severity    string
+		likelihood  string
+		impact      string
+		reviewBy    string
+		due         string
+		estimate    string
+		milestone   string
+		vars        []string
 		parent      string
 		deps        []string
 		labels      []string
 		assignee    string
 		description string
+		criteria    []string
 		titleFlag   string
 		molType     string
 		idFlag      string
 		forceFlag   bool
 		ephemeral   bool
 		actorFlag   string
+		reporter    string
+		tmplName    string
 	)
 
 	cmd := &cobra.Command{
@@ -42,8 +55,16 @@ Examples:
   bd create "Fix login bug"
   bd create --title "Fix login bug"
   bd create "Add OAuth support" --type feature --priority high
+  bd create "Add OAuth support" --type feature --criteria "Google login works" --criteria "Tokens refresh"
+  bd create "Data loss on sync" --type bug --severity critical
+  bd create "Vendor may sunset API" --type risk --likelihood 3 --impact 4 --review-by 2026-06-30
+  bd create "Cut release branch" --due @code-freeze
+  bd create "Add rate limiting" --estimate 1d
+  bd create "Update changelog" --milestone v1.2
+  bd create "Deploy to staging" --type gate --var DEPLOY_ENV=staging
   bd create "Implement caching" --parent bd-a1b2
   bd create "Write tests" --deps bd-e5f6
+  bd create "Login fails on Safari" --template bug-report
   bd create "Task" --description -   # read description from stdin`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			app, err := provider.Get()
@@ -60,6 +81,22 @@ Examples:
 			if len(args) == 1 {
 				title = args[0]
 			}
+			// A template fills in whatever the flags leave unset.
+			var tmpl *template.Template
+			if tmplName != "" {
+				t, err := getTemplate(ctx, app, tmplName)
+				if err != nil {
+					return err
+				}
+				if title, err = t.IssueTitle(title); err != nil {
+					return err
+				}
+				if typeFlag == "" {
+					typeFlag = string(t.Type)
+				}
+				labels = append(append([]string{}, t.Labels...), labels...)
+				tmpl = &t
+			}
 			if strings.TrimSpace(title) == "" {
 				return fmt.Errorf("title is required (provide as argument or --title)")
 			}
@@ -74,6 +111,10 @@ Examples:
 				}
 			}
 
+			if err := checkAssignee(ctx, app, assignee, forceFlag); err != nil {
+				return err
+			}
+
 			// Merge --label alias into --labels
 			labelAlias, _ := cmd.Flags().GetStringSlice("label")
 			if len(labelAlias) > 0 {
@@ -92,12 +133,49 @@ Examples:
 
 			// Parse and validate priority
 			issuePriority := issuestorage.PriorityMedium
+			prioritySet := false
 			if priority != "" {
 				p, err := parsePriorityInput(priority)
 				if err != nil {
 					return err
 				}
-				issuePriority = p
+				issuePriority, prioritySet = p, true
+			} else if tmpl != nil && tmpl.Priority != nil {
+				issuePriority, prioritySet = *tmpl.Priority, true
+			}
+
+			var issueSeverity issuestorage.Severity
+			if severity != "" {
+				s, err := issuestorage.ParseSeverity(severity)
+				if err != nil {
+					return err
+				}
+				issueSeverity = s
+			}
+
+			likelihoodScore, impactScore, reviewByTime, err := parseRiskFlags(cmd, likelihood, impact, reviewBy)
+			if err != nil {
+				return err
+			}
+			dueAt, dueEvent, err := parseDueFlag(app, due)
+			if err != nil {
+				return err
+			}
+			estimateMinutes, err := parseEstimateFlag(estimate)
+			if err != nil {
+				return err
+			}
+			parsedVars, err := parseVarAssignments(vars)
+			if err != nil {
+				return err
+			}
+			if len(parsedVars) == 0 {
+				parsedVars = nil
+			}
+			if milestone != "" {
+				if err := checkMilestoneOpen(ctx, app, milestone); err != nil {
+					return err
+				}
 			}
 
 			// Handle description from stdin if "-"
@@ -109,6 +187,9 @@ Examples:
 				}
 				desc = strings.TrimSpace(string(data))
 			}
+			if desc == "" && tmpl != nil {
+				desc = tmpl.Description
+			}
 
 			// Enforce required description if configured
 			if app.ConfigStore != nil {
@@ -136,6 +217,9 @@ Examples:
 				actor, _ = resolveActor(app)
 			}
 			owner := resolveOwner()
+			if reporter == "" {
+				reporter = actor
+			}
 
 			// Create the issue
 			issue := &issuestorage.Issue{
@@ -144,12 +228,47 @@ Examples:
 				Type:        issueType,
 				MolType:     issueMolType,
 				Priority:    issuePriority,
+				Severity:    issueSeverity,
+				Likelihood:  likelihoodScore,
+				Impact:      impactScore,
+				ReviewBy:    reviewByTime,
+				DueAt:       dueAt,
+				DueEvent:    dueEvent,
+				Estimate:    estimateMinutes,
+				Milestone:   milestone,
+				Vars:        parsedVars,
 				CreatedBy:   actor,
 				Owner:       owner,
+				Reporter:    reporter,
 				Labels:      labels,
 				Assignee:    assignee,
 				Ephemeral:   ephemeral,
 			}
+			// Apply auto-assignment rules when no assignee was given
+			var assignedBy *assignRule
+			if !cmd.Flags().Changed("assignee") && !ephemeral {
+				picked, rule, err := autoAssign(ctx, app, labels, app.Now())
+				if err != nil {
+					return fmt.Errorf("auto-assigning: %w", err)
+				}
+				if picked != "" {
+					assignedBy = rule
+					issue.Assignee = picked
+					issue.History = append(issue.History, issuestorage.HistoryEntry{
+						At:    app.Now(),
+						Actor: actor,
+						Event: issuestorage.EventAutoAssigned,
+						Field: "assignee",
+						New:   picked,
+						Note:  rule.source(),
+					})
+				}
+			}
+			for _, text := range criteria {
+				if text = strings.TrimSpace(text); text != "" {
+					issue.AcceptanceCriteria = append(issue.AcceptanceCriteria, issuestorage.Criterion{Text: text})
+				}
+			}
 
 			// When --id is specified, use the explicit ID
 			if idFlag != "" {
@@ -170,10 +289,12 @@ Examples:
 				issue.ID = childID
 			}
 
-			id, err := app.Storage.Create(ctx, issue)
+			id, err := app.Storage.Create(ctx, issue, issuestorage.CreateOpts{PrioritySet: prioritySet})
 			if err != nil {
 				return fmt.Errorf("creating issue: %w", err)
 			}
+			warnIfAway(app, assignee)
+			notifyAssigned(app, id, issue.Title, "", issue.Assignee)
 
 			// Set parent relationship if specified
 			if parent != "" {
@@ -197,6 +318,16 @@ Examples:
 				}
 			}
 
+			// Create the template's child issues
+			var children []string
+			if tmpl != nil {
+				children, err = createTemplateChildren(ctx, app, *tmpl, id, issuePriority, actor)
+				if err != nil {
+					app.Storage.Delete(context.Background(), id)
+					return err
+				}
+			}
+
 			// Output the result
 			if app.JSON {
 				// Fetch the created issue to get all fields including timestamps
@@ -219,13 +350,27 @@ Examples:
 			fmt.Fprintf(app.Out, "  Title: %s\n", title)
 			fmt.Fprintf(app.Out, "  Priority: %s\n", issuePriority.Display())
 			fmt.Fprintf(app.Out, "  Status: %s\n", issuestorage.StatusOpen)
+			if assignedBy != nil {
+				fmt.Fprintf(app.Out, "  Assignee: %s (auto-assigned by %s, %s)\n", issue.Assignee, assignedBy.source(), assignedBy.Strategy)
+			}
+			if len(children) > 0 {
+				fmt.Fprintf(app.Out, "  Children: %s\n", strings.Join(children, ", "))
+			}
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&titleFlag, "title", "", "Issue title (required if no positional title is provided)")
-	cmd.Flags().StringVarP(&typeFlag, "type", "t", "", "Issue type (task, bug, feature, epic, chore, gate)")
+	cmd.Flags().StringVarP(&typeFlag, "type", "t", "", "Issue type (task, bug, feature, epic, chore, gate, risk, decision, question)")
 	cmd.Flags().StringVarP(&priority, "priority", "p", "", "Priority (0-4 or P0-P4)")
+	cmd.Flags().StringVar(&severity, "severity", "", "Severity (critical, major, minor, trivial or S1-S4)")
+	cmd.Flags().StringVar(&likelihood, "likelihood", "", "Risk likelihood (1-5)")
+	cmd.Flags().StringVar(&impact, "impact", "", "Risk impact (1-5)")
+	cmd.Flags().StringVar(&reviewBy, "review-by", "", "Date the risk must next be reviewed (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&due, "due", "", "Deadline (YYYY-MM-DD, or @<event> to follow a calendar event)")
+	cmd.Flags().StringVar(&estimate, "estimate", "", "Expected effort (e.g. 45m, 3h, 1d 4h; d is 8h, w is 5d)")
+	cmd.Flags().StringVar(&milestone, "milestone", "", "Milestone to plan the issue for (see bd milestone)")
+	cmd.Flags().StringArrayVar(&vars, "var", nil, "Var for automation, KEY=VALUE (can repeat; see bd env)")
 	cmd.Flags().StringVar(&parent, "parent", "", "Parent issue ID")
 	cmd.Flags().StringSliceVarP(&deps, "deps", "d", nil, "Dependencies in format 'type:id' or 'id' (can repeat)")
 	cmd.Flags().StringSliceVarP(&labels, "labels", "l", nil, "Labels (comma-separated or repeat flag)")
@@ -233,11 +378,14 @@ Examples:
 	cmd.Flags().MarkHidden("label")
 	cmd.Flags().StringVarP(&assignee, "assignee", "a", "", "Assign to user")
 	cmd.Flags().StringVar(&description, "description", "", "Full description (use - for stdin)")
+	cmd.Flags().StringArrayVar(&criteria, "criteria", nil, "Acceptance criterion (can repeat)")
 	cmd.Flags().StringVar(&molType, "mol-type", "", "Molecule type (swarm, patrol, work)")
 	cmd.Flags().StringVar(&idFlag, "id", "", "Explicit issue ID (must match configured prefix)")
-	cmd.Flags().BoolVar(&forceFlag, "force", false, "Bypass prefix validation for --id")
+	cmd.Flags().BoolVar(&forceFlag, "force", false, "Bypass prefix validation for --id and the people registry check for --assignee")
 	cmd.Flags().BoolVar(&ephemeral, "ephemeral", false, "Mark issue as ephemeral (not exported to JSONL)")
 	cmd.Flags().StringVar(&actorFlag, "actor", "", "Override actor identity for created_by")
+	cmd.Flags().StringVar(&reporter, "reporter", "", "Who raised the issue, if not you (default: the actor)")
+	cmd.Flags().StringVar(&tmplName, "template", "", "Template to fill in the issue and its children from (see bd template)")
 
 	return cmd
 }
@@ -335,3 +483,32 @@ func validDependencyTypeList() string {
 	sort.Strings(types)
 	return strings.Join(types, ", ")
 }
+
+// parseRiskFlags parses the --likelihood, --impact and --review-by flags.
+// Flags that were not set (or set to "") yield zero values.
+func parseRiskFlags(cmd *cobra.Command, likelihood, impact, reviewBy string) (int, int, *time.Time, error) {
+	var l, i int
+	var rb *time.Time
+	if cmd.Flags().Changed("likelihood") && likelihood != "" {
+		n, err := issuestorage.ParseRiskScore(likelihood)
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("--likelihood: %w", err)
+		}
+		l = n
+	}
+	if cmd.Flags().Changed("impact") && impact != "" {
+		n, err := issuestorage.ParseRiskScore(impact)
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("--impact: %w", err)
+		}
+		i = n
+	}
+	if cmd.Flags().Changed("review-by") && reviewBy != "" {
+		t, err := parseListCreatedTime(reviewBy, false)
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("invalid --review-by value %q: %w", reviewBy, err)
+		}
+		rb = &t
+	}
+	return l, i, rb, nil
+}
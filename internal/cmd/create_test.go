@@ -1324,3 +1324,40 @@ func TestCreateWithParent_AutoReopensClosedAncestors(t *testing.T) {
 		t.Fatalf("expected grandparent to be reopened, got status %s", grandparent.Status)
 	}
 }
+
+func TestCreateTypeDefaultPriority(t *testing.T) {
+	app, store := setupTestApp(t)
+	rules, err := issueservice.ParseTypeRules(map[string]string{"types.bug.default_priority": "1"})
+	if err != nil {
+		t.Fatalf("ParseTypeRules: %v", err)
+	}
+	store.SetTypeRules(rules)
+
+	cmd := newCreateCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"Crash", "--type", "bug"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	issue, err := store.Get(context.Background(), extractCreatedID(app.Out.(*bytes.Buffer).String()))
+	if err != nil {
+		t.Fatalf("failed to get issue: %v", err)
+	}
+	if issue.Priority != issuestorage.PriorityHigh {
+		t.Errorf("priority = %s, want P1 from type default", issue.Priority.Display())
+	}
+
+	// An explicit --priority still wins.
+	app.Out = &bytes.Buffer{}
+	cmd = newCreateCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"Cosmetic", "--type", "bug", "--priority", "4"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	issue, err = store.Get(context.Background(), extractCreatedID(app.Out.(*bytes.Buffer).String()))
+	if err != nil {
+		t.Fatalf("failed to get issue: %v", err)
+	}
+	if issue.Priority != issuestorage.PriorityBacklog {
+		t.Errorf("priority = %s, want P4", issue.Priority.Display())
+	}
+}
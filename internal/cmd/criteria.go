@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// newCriteriaCmd creates the criteria command with subcommands.
+func newCriteriaCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "criteria",
+		Short: "Manage an issue's acceptance criteria",
+		Long: `Manage an issue's acceptance criteria: a numbered checklist of conditions
+that must hold for the issue to be done.
+
+Criteria are shown by bd show and included in its JSON output, so agents
+can check them off as they verify each one. bd lint reports features
+without criteria and closed issues with unchecked criteria.
+
+Subcommands:
+  add      Add a criterion
+  check    Mark criteria done
+  uncheck  Mark criteria not done
+  list     List criteria`,
+	}
+
+	cmd.AddCommand(newCriteriaAddCmd(provider))
+	cmd.AddCommand(newCriteriaCheckCmd(provider, true))
+	cmd.AddCommand(newCriteriaCheckCmd(provider, false))
+	cmd.AddCommand(newCriteriaListCmd(provider))
+
+	return cmd
+}
+
+// formatCriterion formats a numbered criterion as a checklist line.
+func formatCriterion(n int, c issuestorage.Criterion) string {
+	mark := "[ ]"
+	if c.Done {
+		mark = "[x]"
+	}
+	return fmt.Sprintf("%d. %s %s", n, mark, c.Text)
+}
+
+// newCriteriaAddCmd creates the "criteria add" subcommand.
+func newCriteriaAddCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "add <issue-id> <text>",
+		Short: "Add an acceptance criterion",
+		Long: `Add an acceptance criterion to the end of an issue's checklist.
+
+Examples:
+  bd criteria add bd-a1b2 "Login works with SSO"
+  bd criteria add bd-a1b2 p95 latency stays under 200ms`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			issue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+			text := strings.Join(args[1:], " ")
+			n, err := app.Storage.AddCriterion(ctx, issue.ID, text)
+			if err != nil {
+				return err
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(map[string]any{"id": issue.ID, "number": n, "text": strings.TrimSpace(text)})
+			}
+			fmt.Fprintf(app.Out, "%s Added criterion %d to %s\n", app.SuccessColor("✓"), n, issue.ID)
+			return nil
+		},
+	}
+}
+
+// newCriteriaCheckCmd creates the "criteria check" subcommand, or
+// "criteria uncheck" if done is false.
+func newCriteriaCheckCmd(provider *AppProvider, done bool) *cobra.Command {
+	use, short, verb := "check", "Mark acceptance criteria done", "Checked"
+	if !done {
+		use, short, verb = "uncheck", "Mark acceptance criteria not done", "Unchecked"
+	}
+
+	return &cobra.Command{
+		Use:   use + " <issue-id> <n>...",
+		Short: short,
+		Long: short + `, by number as shown by bd criteria list.
+
+Examples:
+  bd criteria ` + use + ` bd-a1b2 1
+  bd criteria ` + use + ` bd-a1b2 2 3`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			numbers := make([]int, 0, len(args)-1)
+			for _, arg := range args[1:] {
+				n, err := strconv.Atoi(arg)
+				if err != nil {
+					return fmt.Errorf("invalid criterion number %q", arg)
+				}
+				numbers = append(numbers, n)
+			}
+			issue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+			actor, _ := resolveActor(app)
+			for _, n := range numbers {
+				if err := app.Storage.CheckCriterion(ctx, issue.ID, n, done, actor); err != nil {
+					return err
+				}
+			}
+
+			updated, err := app.Storage.Get(ctx, issue.ID)
+			if err != nil {
+				return fmt.Errorf("fetching updated issue: %w", err)
+			}
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(map[string]any{"id": issue.ID, "acceptance_criteria": ToCriteriaJSON(updated.AcceptanceCriteria)})
+			}
+			total := len(updated.AcceptanceCriteria)
+			fmt.Fprintf(app.Out, "%s %s %d criteria on %s (%d/%d done)\n", app.SuccessColor("✓"), verb, len(numbers), issue.ID, total-updated.UncheckedCriteria(), total)
+			return nil
+		},
+	}
+}
+
+// newCriteriaListCmd creates the "criteria list" subcommand.
+func newCriteriaListCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list <issue-id>",
+		Short: "List acceptance criteria",
+		Long: `List an issue's acceptance criteria with their numbers and state.
+
+Examples:
+  bd criteria list bd-a1b2
+  bd criteria list bd-a1b2 --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+
+			issue, err := resolveIssue(app.Storage, cmd.Context(), args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(ToCriteriaJSON(issue.AcceptanceCriteria))
+			}
+			if len(issue.AcceptanceCriteria) == 0 {
+				fmt.Fprintf(app.Out, "%s has no acceptance criteria\n", issue.ID)
+				return nil
+			}
+			for i, c := range issue.AcceptanceCriteria {
+				fmt.Fprintln(app.Out, formatCriterion(i+1, c))
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestCriteriaAddCheckList(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	id, err := store.Create(ctx, &issuestorage.Issue{Title: "SSO", Type: issuestorage.TypeFeature})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := app.Out.(*bytes.Buffer)
+
+	for _, args := range [][]string{
+		{"add", id, "Google", "login", "works"},
+		{"add", id, "Tokens refresh"},
+		{"check", id, "1", "2"},
+		{"uncheck", id, "2"},
+	} {
+		cmd := newCriteriaCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("criteria %v failed: %v", args, err)
+		}
+	}
+	if !strings.Contains(out.String(), "Added criterion 2 to "+id) || !strings.Contains(out.String(), "Unchecked 1 criteria on "+id+" (1/2 done)") {
+		t.Errorf("unexpected output: %s", out)
+	}
+
+	out.Reset()
+	cmd := newCriteriaCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"list", id})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("criteria list failed: %v", err)
+	}
+	if want := "1. [x] Google login works\n2. [ ] Tokens refresh\n"; out.String() != want {
+		t.Errorf("list output = %q, want %q", out.String(), want)
+	}
+
+	out.Reset()
+	app.JSON = true
+	cmd = newCriteriaCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"list", id})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("criteria list --json failed: %v", err)
+	}
+	var got []CriterionJSON
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(got) != 2 || got[0].Number != 1 || !got[0].Done || got[0].CheckedAt == "" || got[1].Done {
+		t.Errorf("JSON = %+v", got)
+	}
+}
+
+func TestCriteriaCheck_InvalidNumber(t *testing.T) {
+	app, store := setupTestApp(t)
+	id, err := store.Create(context.Background(), &issuestorage.Issue{Title: "SSO"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for args, want := range map[string]string{
+		"check " + id + " one": `invalid criterion number "one"`,
+		"check " + id + " 1":   "has no criterion 1",
+	} {
+		cmd := newCriteriaCmd(NewTestProvider(app))
+		cmd.SetArgs(strings.Fields(args))
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("criteria %s: got %v, want error containing %q", args, err, want)
+		}
+	}
+}
+
+func TestLintCmd(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	bare, err := store.Create(ctx, &issuestorage.Issue{Title: "Bare feature", Type: issuestorage.TypeFeature})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Create(ctx, &issuestorage.Issue{Title: "Specified", Type: issuestorage.TypeFeature, AcceptanceCriteria: []issuestorage.Criterion{{Text: "Works"}}}); err != nil {
+		t.Fatal(err)
+	}
+	closed, err := store.Create(ctx, &issuestorage.Issue{Title: "Shipped", Type: issuestorage.TypeTask, AcceptanceCriteria: []issuestorage.Criterion{{Text: "Tested"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Modify(ctx, closed, func(i *issuestorage.Issue) error {
+		i.Status = issuestorage.StatusClosed
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := newLintCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{})
+	err = cmd.Execute()
+	if err == nil || err.Error() != "1 issue(s) failed lint" {
+		t.Errorf("expected lint failure for one issue, got %v", err)
+	}
+	out := app.Out.(*bytes.Buffer)
+	if !strings.Contains(out.String(), bare+" (feature) Bare feature\n  - no acceptance criteria") {
+		t.Errorf("unexpected output: %s", out)
+	}
+
+	out.Reset()
+	app.JSON = true
+	cmd = newLintCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--all"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected lint failure")
+	}
+	var results []LintResultJSON
+	if err := json.Unmarshal(out.Bytes(), &results); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(results) != 2 || results[1].ID != closed || !strings.Contains(results[1].Problems[0], "unchecked") {
+		t.Errorf("results = %+v", results)
+	}
+
+	out.Reset()
+	app.JSON = false
+	cmd = newLintCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--type", "task"})
+	if err := cmd.Execute(); err != nil {
+		t.Errorf("lint --type task: %v", err)
+	}
+	if !strings.Contains(out.String(), "0 issue(s) passed lint") {
+		t.Errorf("unexpected output: %s", out)
+	}
+}
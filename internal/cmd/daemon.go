@@ -0,0 +1,644 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"beads-lite/internal/config"
+	"beads-lite/internal/configservice"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// daemonDirName is the directory under .beads holding the daemon's socket
+// and log. It is ignored by git.
+const daemonDirName = "daemon"
+
+// daemonStartTimeout bounds how long bd daemon start and stop wait for
+// the daemon to come up or go away.
+const daemonStartTimeout = 5 * time.Second
+
+// daemonLocalCommands always run in the CLI process: they are long-running,
+// stream output, interactive, prompt on stdin or manage the daemon itself.
+var daemonLocalCommands = []string{"daemon", "serve", "mcp", "ui", "watch", "init", "edit", "import", "delete", "compact", "admin"}
+
+// daemonEnvKeys are the environment variables baked into an App when it is
+// built (see config.ApplyEnvOverrides). The daemon rebuilds its App when a
+// client's differ from those it was built with.
+var daemonEnvKeys = []string{config.EnvBeadsDir, config.EnvActor, config.EnvH2Actor, config.EnvProject, config.EnvContext, config.EnvPostgresDSN}
+
+// DaemonStatusJSON is the JSON output of bd daemon status, start and stop.
+type DaemonStatusJSON struct {
+	Running   bool   `json:"running"`
+	PID       int    `json:"pid,omitempty"`
+	Socket    string `json:"socket"`
+	Version   string `json:"version,omitempty"`
+	StartedAt string `json:"started_at,omitempty"`
+	Requests  int64  `json:"requests,omitempty"` // commands run since it started
+}
+
+// daemonRequest is what the CLI sends over the socket, one per connection.
+type daemonRequest struct {
+	Op        string   `json:"op"` // "run", "status" or "stop"
+	Version   string   `json:"version"`
+	ConfigDir string   `json:"config_dir"`
+	Args      []string `json:"args,omitempty"`
+	Dir       string   `json:"dir,omitempty"`
+	Env       []string `json:"env,omitempty"`
+	TTY       bool     `json:"tty,omitempty"` // the client's stdout is a terminal
+}
+
+// daemonResponse is the daemon's answer. A request it refuses, such as one
+// from a different bd version, is run by the CLI itself instead.
+type daemonResponse struct {
+	Stdout  []byte            `json:"stdout,omitempty"`
+	Stderr  []byte            `json:"stderr,omitempty"`
+	Error   string            `json:"error,omitempty"`
+	Refused string            `json:"refused,omitempty"`
+	Status  *DaemonStatusJSON `json:"status,omitempty"`
+}
+
+// newDaemonCmd creates the daemon command with subcommands.
+func newDaemonCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Run a background process that serves bd commands with warm caches",
+		Long: `Run an optional background daemon for this beads directory. While it
+runs, bd hands each command to it over a unix socket instead of loading
+config, storage and the search index itself, and the daemon answers from
+an in-memory issue cache that is revalidated against the files, so
+changes made without the daemon are still seen.
+
+Output, errors and exit status are those the command would have in
+process. The daemon runs commands with the caller's working directory
+and environment, one at a time. These always run in process: bd daemon,
+serve, mcp, ui, watch, init, edit, import, delete, compact and admin, any
+command reading stdin (an argument of -) and any command given --follow.
+So does every command when --no-daemon
+or BD_NO_DAEMON=1 is given, in --deterministic mode, or when the daemon
+was started by a different bd version.
+
+The socket and log live in .beads/daemon/.
+
+Subcommands:
+  start   Start the daemon in the background
+  stop    Stop it
+  status  Show whether it is running
+  run     Run it in the foreground`,
+	}
+	cmd.AddCommand(newDaemonStartCmd(provider))
+	cmd.AddCommand(newDaemonStopCmd(provider))
+	cmd.AddCommand(newDaemonStatusCmd(provider))
+	cmd.AddCommand(newDaemonRunCmd(provider))
+	return cmd
+}
+
+// newDaemonStartCmd creates the "daemon start" subcommand.
+func newDaemonStartCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "start",
+		Short: "Start the daemon in the background",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			socket, err := daemonSocketPath(app.ConfigDir)
+			if err != nil {
+				return err
+			}
+			if status := daemonStatus(app.ConfigDir, socket); status.Running {
+				return fmt.Errorf("a daemon is already running (pid %d)", status.PID)
+			}
+
+			exe, err := os.Executable()
+			if err != nil {
+				return fmt.Errorf("finding the bd executable: %w", err)
+			}
+			logPath := filepath.Join(app.ConfigDir, daemonDirName, "daemon.log")
+			logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+			if err != nil {
+				return fmt.Errorf("opening daemon log: %w", err)
+			}
+			defer logFile.Close()
+			run := exec.Command(exe, "daemon", "run")
+			run.Stdout, run.Stderr = logFile, logFile
+			run.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+			if err := run.Start(); err != nil {
+				return fmt.Errorf("starting daemon: %w", err)
+			}
+			exited := make(chan struct{})
+			go func() { run.Wait(); close(exited) }()
+
+			deadline := time.Now().Add(daemonStartTimeout)
+			for {
+				status := daemonStatus(app.ConfigDir, socket)
+				if status.Running {
+					return printDaemonStatus(app, status, "Daemon started")
+				}
+				select {
+				case <-exited:
+					return fmt.Errorf("daemon exited during startup; see %s", logPath)
+				case <-time.After(20 * time.Millisecond):
+				}
+				if time.Now().After(deadline) {
+					return fmt.Errorf("daemon did not start within %s; see %s", daemonStartTimeout, logPath)
+				}
+			}
+		},
+	}
+}
+
+// newDaemonStopCmd creates the "daemon stop" subcommand.
+func newDaemonStopCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "stop",
+		Short: "Stop the daemon",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			socket, err := daemonSocketPath(app.ConfigDir)
+			if err != nil {
+				return err
+			}
+			resp, _, err := callDaemon(socket, daemonRequest{Op: "stop", Version: Version, ConfigDir: app.ConfigDir}, time.Second)
+			if err != nil {
+				return printDaemonStatus(app, &DaemonStatusJSON{Socket: socket}, "")
+			}
+			for deadline := time.Now().Add(daemonStartTimeout); time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+				if _, err := os.Stat(socket); os.IsNotExist(err) {
+					resp.Status.Running = false
+					return printDaemonStatus(app, resp.Status, "Daemon stopped")
+				}
+			}
+			return fmt.Errorf("daemon (pid %d) did not stop within %s", resp.Status.PID, daemonStartTimeout)
+		},
+	}
+}
+
+// newDaemonStatusCmd creates the "daemon status" subcommand.
+func newDaemonStatusCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show whether the daemon is running",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			socket, err := daemonSocketPath(app.ConfigDir)
+			if err != nil {
+				return err
+			}
+			return printDaemonStatus(app, daemonStatus(app.ConfigDir, socket), "")
+		},
+	}
+}
+
+// newDaemonRunCmd creates the "daemon run" subcommand.
+func newDaemonRunCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "run",
+		Short: "Run the daemon in the foreground",
+		Long: `Run the daemon in the foreground until interrupted or stopped with
+bd daemon stop. bd daemon start runs this in the background.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			socket, err := daemonSocketPath(app.ConfigDir)
+			if err != nil {
+				return err
+			}
+			if status := daemonStatus(app.ConfigDir, socket); status.Running {
+				return fmt.Errorf("a daemon is already running (pid %d)", status.PID)
+			}
+			os.Remove(socket) // left behind by a daemon that did not exit cleanly
+
+			d := &daemonServer{configDir: app.ConfigDir, started: time.Now()}
+			if err := d.load(); err != nil {
+				return err
+			}
+			ln, err := net.Listen("unix", socket)
+			if err != nil {
+				return fmt.Errorf("listening on %s: %w", socket, err)
+			}
+			if err := os.Chmod(socket, 0600); err != nil {
+				ln.Close()
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			fmt.Fprintf(app.Out, "%s Daemon serving %s on %s (pid %d)\n", app.SuccessColor("✓"), app.ConfigDir, socket, os.Getpid())
+			err = d.serve(ctx, ln)
+			if cerr := d.app.Close(); cerr != nil {
+				fmt.Fprintf(app.Err, "warning: %v\n", cerr)
+			}
+			return err
+		},
+	}
+}
+
+// printDaemonStatus prints status, after done if it is not "".
+func printDaemonStatus(app *App, status *DaemonStatusJSON, done string) error {
+	if app.JSON {
+		return json.NewEncoder(app.Out).Encode(status)
+	}
+	if done != "" {
+		fmt.Fprintf(app.Out, "%s %s\n", app.SuccessColor("✓"), done)
+		if !status.Running {
+			return nil
+		}
+	}
+	if !status.Running {
+		fmt.Fprintln(app.Out, "No daemon running.")
+		return nil
+	}
+	fmt.Fprintf(app.Out, "Daemon running: pid %d, bd %s, since %s, %d commands served\n", status.PID, status.Version, status.StartedAt, status.Requests)
+	fmt.Fprintf(app.Out, "  socket: %s\n", status.Socket)
+	return nil
+}
+
+// daemonSocketPath returns where the daemon for configDir listens,
+// creating its directory. The socket goes under .beads/daemon/ unless
+// that path is too long for a unix socket, in which case it goes in a
+// private directory under the system temp directory.
+func daemonSocketPath(configDir string) (string, error) {
+	dir := filepath.Join(configDir, daemonDirName)
+	if err := ensurePrivateDir(dir); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*\n"), 0644); err != nil {
+		return "", err
+	}
+	socket := filepath.Join(dir, "bd.sock")
+	if len(socket) < 100 { // sun_path holds 104 bytes on macOS, 108 on Linux
+		return socket, nil
+	}
+	dir = filepath.Join(os.TempDir(), fmt.Sprintf("bd-daemon-%d", os.Getuid()))
+	if err := ensurePrivateDir(dir); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fmt.Sprintf("%x.sock", sha256.Sum256([]byte(configDir)))[:16]), nil
+}
+
+// ensurePrivateDir creates dir readable only by the current user, and
+// refuses one someone else owns.
+func ensurePrivateDir(dir string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if st, ok := info.Sys().(*syscall.Stat_t); ok && int(st.Uid) != os.Getuid() {
+		return fmt.Errorf("%s is owned by another user", dir)
+	}
+	return os.Chmod(dir, 0700)
+}
+
+// daemonStatus asks the daemon at socket for its status.
+func daemonStatus(configDir, socket string) *DaemonStatusJSON {
+	resp, _, err := callDaemon(socket, daemonRequest{Op: "status", Version: Version, ConfigDir: configDir}, time.Second)
+	if err != nil || resp.Status == nil {
+		return &DaemonStatusJSON{Socket: socket}
+	}
+	return resp.Status
+}
+
+// callDaemon sends req to the daemon at socket and returns its response.
+// sent reports whether the request reached the daemon, after which it
+// may have run even if err is set. A timeout of 0 waits as long as the
+// command takes.
+func callDaemon(socket string, req daemonRequest, timeout time.Duration) (resp *daemonResponse, sent bool, err error) {
+	conn, err := net.DialTimeout("unix", socket, time.Second)
+	if err != nil {
+		return nil, false, err
+	}
+	defer conn.Close()
+	if timeout > 0 {
+		conn.SetDeadline(time.Now().Add(timeout))
+	}
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, false, err
+	}
+	resp = &daemonResponse{}
+	if err := json.NewDecoder(conn).Decode(resp); err != nil {
+		return nil, true, fmt.Errorf("reading the daemon's response: %w", err)
+	}
+	return resp, true, nil
+}
+
+// delegateToDaemon runs the command line args in the daemon, if one is
+// running for this beads directory and the command may run there. handled
+// reports whether it did; if not, the CLI runs the command itself.
+func delegateToDaemon(args []string) (handled bool, err error) {
+	if v := strings.ToLower(os.Getenv(config.EnvNoDaemon)); v == "1" || v == "true" {
+		return false, nil
+	}
+	if os.Getenv(config.EnvSeed) != "" || !daemonDelegable(newRootCmd(&AppProvider{}), args) {
+		return false, nil
+	}
+	paths, err := configservice.ResolvePaths()
+	if err != nil {
+		return false, nil
+	}
+	socket := filepath.Join(paths.ConfigDir, daemonDirName, "bd.sock")
+	if len(socket) >= 100 {
+		if socket, err = daemonSocketPath(paths.ConfigDir); err != nil {
+			return false, nil
+		}
+	}
+	if _, err := os.Stat(socket); err != nil {
+		return false, nil
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return false, nil
+	}
+
+	resp, sent, err := callDaemon(socket, daemonRequest{
+		Op:        "run",
+		Version:   Version,
+		ConfigDir: paths.ConfigDir,
+		Args:      args,
+		Dir:       dir,
+		Env:       os.Environ(),
+		TTY:       term.IsTerminal(int(os.Stdout.Fd())),
+	}, 0)
+	if err != nil {
+		if sent {
+			return true, fmt.Errorf("daemon: %w (the command may have run; bd daemon status shows whether the daemon is up)", err)
+		}
+		return false, nil
+	}
+	if resp.Refused != "" {
+		return false, nil
+	}
+	os.Stdout.Write(resp.Stdout)
+	os.Stderr.Write(resp.Stderr)
+	if resp.Error != "" {
+		return true, errors.New(resp.Error)
+	}
+	return true, nil
+}
+
+// daemonDelegable reports whether the command line args may run in the
+// daemon. The command is resolved in root's tree as cobra would run it, so
+// the values of flags such as --compat are not taken for it.
+func daemonDelegable(root *cobra.Command, args []string) bool {
+	for _, arg := range args {
+		if arg == "-" || strings.HasSuffix(arg, "=-") {
+			return false // reads stdin
+		}
+		if strings.HasPrefix(arg, "--no-daemon") || strings.HasPrefix(arg, "--deterministic") {
+			return false
+		}
+		if arg == "--follow" || strings.HasPrefix(arg, "--follow=") {
+			return false // streams until interrupted
+		}
+	}
+	cmd, _, err := root.Find(args)
+	if err != nil {
+		return false // unknown commands are reported by the CLI
+	}
+	for cmd.HasParent() && cmd.Parent().HasParent() {
+		cmd = cmd.Parent()
+	}
+	return !contains(daemonLocalCommands, cmd.Name())
+}
+
+// daemonServer runs commands sent by the CLI against an App it keeps
+// between them.
+type daemonServer struct {
+	configDir string
+	started   time.Time
+	requests  atomic.Int64
+
+	// mu serializes commands: each runs with the caller's environment and
+	// working directory, which are process-wide.
+	mu    sync.Mutex
+	app   *App
+	stamp string // daemonStamp when app was built
+
+	// newRoot builds the command tree a request runs; nil is newRootCmd.
+	newRoot func(*AppProvider) *cobra.Command
+}
+
+// serve accepts connections on ln until ctx is done or a stop request.
+func (d *daemonServer) serve(ctx context.Context, ln net.Listener) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		ln.Close()
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.handle(ctx, conn, cancel)
+		}()
+	}
+}
+
+// handle answers one request; stop is called after answering a stop.
+func (d *daemonServer) handle(ctx context.Context, conn net.Conn, stop func()) {
+	defer conn.Close()
+	var req daemonRequest
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		return
+	}
+	var resp daemonResponse
+	switch {
+	case req.ConfigDir != d.configDir:
+		resp.Refused = "daemon serves " + d.configDir
+	case req.Op == "status":
+		resp.Status = d.status()
+	case req.Op == "stop":
+		resp.Status = d.status()
+		defer stop()
+	case req.Op == "run" && req.Version != Version:
+		resp.Refused = "daemon runs bd " + Version
+	case req.Op == "run" && !daemonDelegable(d.rootCmd(&AppProvider{}), req.Args):
+		resp.Refused = "command runs in the CLI"
+	case req.Op == "run":
+		// The client sends nothing after its request, so a read returns
+		// only once it hangs up; the command is cancelled then rather than
+		// holding the daemon for a caller that is gone.
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			conn.Read(make([]byte, 1))
+			cancel()
+		}()
+		resp = d.run(ctx, req)
+	default:
+		resp.Refused = fmt.Sprintf("unknown request %q", req.Op)
+	}
+	json.NewEncoder(conn).Encode(resp)
+}
+
+func (d *daemonServer) status() *DaemonStatusJSON {
+	socket, _ := daemonSocketPath(d.configDir)
+	return &DaemonStatusJSON{
+		Running:   true,
+		PID:       os.Getpid(),
+		Socket:    socket,
+		Version:   Version,
+		StartedAt: formatTime(d.started),
+		Requests:  d.requests.Load(),
+	}
+}
+
+// run executes a command line as the CLI would, in the caller's working
+// directory and environment.
+func (d *daemonServer) run(ctx context.Context, req daemonRequest) daemonResponse {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	restore, err := daemonEnter(req.Dir, req.Env)
+	if err != nil {
+		return daemonResponse{Refused: err.Error()}
+	}
+	defer restore()
+	if req.TTY && os.Getenv("NO_COLOR") == "" {
+		os.Setenv("CLICOLOR_FORCE", "1")
+	}
+	if paths, err := configservice.ResolvePaths(); err != nil || paths.ConfigDir != d.configDir {
+		return daemonResponse{Refused: "daemon serves " + d.configDir}
+	}
+	if err := d.load(); err != nil {
+		return daemonResponse{Refused: err.Error()}
+	}
+
+	var out, errOut bytes.Buffer
+	provider := &AppProvider{base: d.app, Out: &out, Err: &errOut}
+	root := d.rootCmd(provider)
+	root.SetArgs(req.Args)
+	root.SetIn(strings.NewReader(""))
+	root.SetOut(&out)
+	root.SetErr(&errOut)
+	err = func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		return root.ExecuteContext(ctx)
+	}()
+	d.requests.Add(1)
+
+	resp := daemonResponse{Stdout: out.Bytes(), Stderr: errOut.Bytes()}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	return resp
+}
+
+// rootCmd builds the command tree a request runs.
+func (d *daemonServer) rootCmd(provider *AppProvider) *cobra.Command {
+	if d.newRoot != nil {
+		return d.newRoot(provider)
+	}
+	return newRootCmd(provider)
+}
+
+// load builds the App commands run against, or rebuilds it when the config
+// files or the environment it was built from changed.
+func (d *daemonServer) load() error {
+	paths, err := configservice.ResolvePaths()
+	if err != nil {
+		return err
+	}
+	stamp := daemonStamp(paths)
+	if d.app != nil && stamp == d.stamp {
+		return nil
+	}
+	app, err := (&AppProvider{Cache: true, Out: os.Stdout, Err: os.Stderr}).Get()
+	if err != nil {
+		return err
+	}
+	if d.app != nil {
+		d.app.Close()
+	}
+	d.app, d.stamp = app, stamp
+	return nil
+}
+
+// daemonStamp identifies what an App is built from: the config files'
+// modification times and sizes, and daemonEnvKeys.
+func daemonStamp(paths config.Paths) string {
+	var b strings.Builder
+	for _, f := range []string{paths.ConfigFile, paths.OverlayConfigFile} {
+		if info, err := os.Stat(f); err == nil {
+			fmt.Fprintf(&b, "%s %d %d\n", f, info.ModTime().UnixNano(), info.Size())
+		}
+	}
+	for _, key := range daemonEnvKeys {
+		fmt.Fprintf(&b, "%s=%s\n", key, os.Getenv(key))
+	}
+	return b.String()
+}
+
+// daemonEnter switches the process to dir and env, returning a function
+// that switches back.
+func daemonEnter(dir string, env []string) (func(), error) {
+	prevDir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	prevEnv := os.Environ()
+	if err := os.Chdir(dir); err != nil {
+		return nil, err
+	}
+	setEnviron(env)
+	return func() {
+		os.Chdir(prevDir)
+		setEnviron(prevEnv)
+	}, nil
+}
+
+// setEnviron replaces the process environment with env.
+func setEnviron(env []string) {
+	os.Clearenv()
+	for _, kv := range env {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			os.Setenv(k, v)
+		}
+	}
+}
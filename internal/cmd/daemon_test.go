@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/configservice"
+
+	"github.com/spf13/cobra"
+)
+
+func TestDaemonDelegable(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"list"}, true},
+		{[]string{"--json", "show", "bd-a1"}, true},
+		{[]string{"--lock-timeout", "5s", "ready"}, true},
+		{[]string{"--lock-timeout", "init"}, true}, // init is the flag's value
+		{[]string{"create", "Title", "-d", "-"}, false},
+		{[]string{"comments", "add", "bd-a1", "--file=-"}, false},
+		{[]string{"--no-daemon", "list"}, false},
+		{[]string{"--deterministic", "list"}, false},
+		{[]string{"init"}, false},
+		{[]string{"daemon", "status"}, false},
+		{[]string{"edit", "bd-a1"}, false},
+		{[]string{"--json", "delete", "bd-a1"}, false},
+		{[]string{"watch", "--assignee", "alice"}, false},
+		{[]string{"activity", "--follow"}, false},
+		{[]string{"--compat", "1", "serve"}, false},
+		{[]string{"--compat=1", "ui"}, false},
+		{[]string{"--compat", "1", "list"}, true},
+		{[]string{"bogus"}, false},
+	}
+	for _, tt := range tests {
+		if got := daemonDelegable(newRootCmd(&AppProvider{}), tt.args); got != tt.want {
+			t.Errorf("daemonDelegable(%q) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestDaemonServer(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldWd)
+	t.Setenv("BD_ACTOR", "alice")
+
+	if err := newInitCmd(&AppProvider{}).Execute(); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	paths, err := configservice.ResolvePaths()
+	if err != nil {
+		t.Fatal(err)
+	}
+	configDir := paths.ConfigDir
+
+	d := &daemonServer{configDir: configDir, started: time.Now()}
+	blocking := make(chan struct{})
+	d.newRoot = func(provider *AppProvider) *cobra.Command {
+		root := newRootCmd(provider)
+		root.AddCommand(&cobra.Command{Use: "block", RunE: func(cmd *cobra.Command, args []string) error {
+			close(blocking)
+			<-cmd.Context().Done()
+			return cmd.Context().Err()
+		}})
+		return root
+	}
+	if err := d.load(); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	defer d.app.Close()
+	socket, err := daemonSocketPath(configDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(filepath.Join(configDir, daemonDirName, ".gitignore")); err != nil || string(data) != "*\n" {
+		t.Errorf("daemon directory .gitignore = %q, %v", data, err)
+	}
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error)
+	go func() { done <- d.serve(context.Background(), ln) }()
+
+	run := func(args ...string) *daemonResponse {
+		t.Helper()
+		resp, _, err := callDaemon(socket, daemonRequest{
+			Op: "run", Version: Version, ConfigDir: configDir,
+			Args: args, Dir: tmpDir, Env: os.Environ(),
+		}, 5*time.Second)
+		if err != nil {
+			t.Fatalf("bd %s: %v", strings.Join(args, " "), err)
+		}
+		return resp
+	}
+
+	resp := run("create", "Served by the daemon", "--json")
+	if resp.Error != "" || resp.Refused != "" {
+		t.Fatalf("create: error %q, refused %q", resp.Error, resp.Refused)
+	}
+	resp = run("list")
+	if !strings.Contains(string(resp.Stdout), "Served by the daemon") {
+		t.Errorf("list output = %q, want the created issue", resp.Stdout)
+	}
+	if resp = run("show", "bd-nope"); resp.Error == "" {
+		t.Error("show of a missing issue should return its error")
+	}
+
+	if resp = run("watch"); resp.Refused == "" {
+		t.Errorf("watch should be refused and run in the CLI, got %+v", resp)
+	}
+
+	// A command that runs until cancelled is cancelled when its client
+	// hangs up, and does not hold up the next one.
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.NewEncoder(conn).Encode(daemonRequest{
+		Op: "run", Version: Version, ConfigDir: configDir,
+		Args: []string{"block"}, Dir: tmpDir, Env: os.Environ(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-blocking:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the blocking command did not start")
+	}
+	conn.Close()
+	if resp = run("list"); !strings.Contains(string(resp.Stdout), "Served by the daemon") {
+		t.Errorf("list after a cancelled command = %q", resp.Stdout)
+	}
+
+	resp, _, err = callDaemon(socket, daemonRequest{Op: "run", Version: "0.0.0-other", ConfigDir: configDir, Args: []string{"list"}}, time.Second)
+	if err != nil || resp.Refused == "" {
+		t.Errorf("a different version should be refused, got %+v, %v", resp, err)
+	}
+	resp, _, err = callDaemon(socket, daemonRequest{Op: "run", Version: Version, ConfigDir: "/elsewhere/.beads", Args: []string{"list"}}, time.Second)
+	if err != nil || resp.Refused == "" {
+		t.Errorf("another beads directory should be refused, got %+v, %v", resp, err)
+	}
+
+	status := daemonStatus(configDir, socket)
+	if !status.Running || status.PID != os.Getpid() || status.Requests != 5 {
+		t.Errorf("status = %+v, want running in this process after 5 commands", status)
+	}
+	if cwd, _ := os.Getwd(); cwd != tmpDir {
+		t.Errorf("working directory after commands = %s, want %s restored", cwd, tmpDir)
+	}
+
+	if _, _, err := callDaemon(socket, daemonRequest{Op: "stop", Version: Version, ConfigDir: configDir}, time.Second); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("serve: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("daemon did not stop")
+	}
+	if status := daemonStatus(configDir, socket); status.Running {
+		t.Error("status after stop should report no daemon")
+	}
+}
@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"beads-lite/internal/issueservice"
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// DecisionJSON is the JSON output format for an entry in bd decisions.
+type DecisionJSON struct {
+	ID           string   `json:"id"`
+	Title        string   `json:"title"`
+	State        string   `json:"state"`
+	Status       string   `json:"status"`
+	CreatedAt    string   `json:"created_at"`
+	Supersedes   []string `json:"supersedes,omitempty"`
+	SupersededBy []string `json:"superseded_by,omitempty"`
+}
+
+// newDecisionCmd creates the decision command with subcommands.
+func newDecisionCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "decision",
+		Short: "Manage decision records (ADRs)",
+		Long: `Manage decision records (issues of type "decision").
+
+Decisions move through proposed → accepted → superseded. New decisions
+start as proposed.
+
+Subcommands:
+  accept     Mark a proposed decision as accepted
+  supersede  Record that one decision supersedes another`,
+	}
+
+	cmd.AddCommand(newDecisionAcceptCmd(provider))
+	cmd.AddCommand(newDecisionSupersedeCmd(provider))
+
+	return cmd
+}
+
+// newDecisionAcceptCmd creates the "decision accept" subcommand.
+func newDecisionAcceptCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "accept <decision-id>",
+		Short: "Mark a proposed decision as accepted",
+		Long: `Mark a proposed decision as accepted.
+
+Examples:
+  bd decision accept bd-a1b2`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			issue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+			if err := app.Storage.SetDecisionState(ctx, issue.ID, issuestorage.DecisionAccepted); err != nil {
+				return err
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(map[string]string{
+					"id":    issue.ID,
+					"state": string(issuestorage.DecisionAccepted),
+				})
+			}
+			fmt.Fprintf(app.Out, "%s Accepted decision %s\n", app.SuccessColor("✓"), issue.ID)
+			return nil
+		},
+	}
+}
+
+// newDecisionSupersedeCmd creates the "decision supersede" subcommand.
+func newDecisionSupersedeCmd(provider *AppProvider) *cobra.Command {
+	var by string
+
+	cmd := &cobra.Command{
+		Use:   "supersede <decision-id> --by <new-decision-id>",
+		Short: "Record that one decision supersedes another",
+		Long: `Record that a decision has been superseded by a newer one.
+
+Adds a supersedes dependency from the new decision to the old one, marks
+the old decision superseded and closes it.
+
+Examples:
+  bd decision supersede bd-a1b2 --by bd-c3d4`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			if by == "" {
+				return fmt.Errorf("--by is required")
+			}
+			oldIssue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+			newIssue, err := resolveIssue(app.Storage, ctx, by)
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", by, err)
+			}
+			if err := app.Storage.Supersede(ctx, oldIssue.ID, newIssue.ID); err != nil {
+				return err
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(map[string]string{
+					"id":            oldIssue.ID,
+					"state":         string(issuestorage.DecisionSuperseded),
+					"superseded_by": newIssue.ID,
+				})
+			}
+			fmt.Fprintf(app.Out, "%s %s superseded by %s\n", app.SuccessColor("✓"), oldIssue.ID, newIssue.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&by, "by", "", "ID of the superseding decision (required)")
+
+	return cmd
+}
+
+// newDecisionsCmd creates the decisions command.
+func newDecisionsCmd(provider *AppProvider) *cobra.Command {
+	var states []string
+
+	cmd := &cobra.Command{
+		Use:   "decisions",
+		Short: "List decision records",
+		Long: `List decision records (issues of type "decision") in creation order,
+including superseded ones, with their supersedes relations.
+
+Examples:
+  bd decisions
+  bd decisions --state accepted
+  bd decisions --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			wantStates := make(map[issuestorage.DecisionState]bool)
+			for _, s := range states {
+				st, err := issuestorage.ParseDecisionState(s)
+				if err != nil {
+					return err
+				}
+				wantStates[st] = true
+			}
+
+			filter := &issuestorage.ListFilter{Types: []issuestorage.IssueType{issuestorage.TypeDecision}}
+			decisions, err := listAllIssuesForStats(ctx, app.Storage, filter)
+			if err != nil {
+				return err
+			}
+
+			var selected []*issuestorage.Issue
+			for _, d := range decisions {
+				if d.Status == issuestorage.StatusTombstone {
+					continue
+				}
+				if len(wantStates) > 0 && !wantStates[d.DecisionState] {
+					continue
+				}
+				selected = append(selected, d)
+			}
+			sort.SliceStable(selected, func(i, j int) bool {
+				return selected[i].CreatedAt.Before(selected[j].CreatedAt)
+			})
+
+			supersedes := issuestorage.DepTypeSupersedes
+			if app.JSON {
+				out := make([]DecisionJSON, 0, len(selected))
+				for _, d := range selected {
+					out = append(out, DecisionJSON{
+						ID:           d.ID,
+						Title:        d.Title,
+						State:        string(d.DecisionState),
+						Status:       string(d.Status),
+						CreatedAt:    formatTime(d.CreatedAt),
+						Supersedes:   d.DependencyIDs(&supersedes),
+						SupersededBy: issueservice.SupersededBy(d),
+					})
+				}
+				return json.NewEncoder(app.Out).Encode(out)
+			}
+
+			if len(selected) == 0 {
+				fmt.Fprintln(app.Out, "No decisions found.")
+				return nil
+			}
+			for _, d := range selected {
+				line := fmt.Sprintf("%s [%s] %s", d.ID, d.DecisionState, d.Title)
+				if by := issueservice.SupersededBy(d); len(by) > 0 {
+					line += " (superseded by " + strings.Join(by, ", ") + ")"
+				}
+				if old := d.DependencyIDs(&supersedes); len(old) > 0 {
+					line += " (supersedes " + strings.Join(old, ", ") + ")"
+				}
+				fmt.Fprintln(app.Out, line)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&states, "state", nil, "Filter by decision state (proposed, accepted, superseded)")
+
+	return cmd
+}
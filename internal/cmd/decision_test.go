@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestDecisionCommands(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+
+	oldID, err := store.Create(ctx, &issuestorage.Issue{Title: "Store issues as JSON files", Type: issuestorage.TypeDecision})
+	if err != nil {
+		t.Fatalf("failed to create decision: %v", err)
+	}
+	newID, err := store.Create(ctx, &issuestorage.Issue{Title: "Store issues in SQLite", Type: issuestorage.TypeDecision})
+	if err != nil {
+		t.Fatalf("failed to create decision: %v", err)
+	}
+
+	cmd := newDecisionCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"accept", oldID})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("decision accept failed: %v", err)
+	}
+
+	cmd = newDecisionCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"supersede", oldID, "--by", newID})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("decision supersede failed: %v", err)
+	}
+
+	app.Out = &bytes.Buffer{}
+	app.JSON = true
+	cmd = newDecisionsCmd(NewTestProvider(app))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("decisions failed: %v", err)
+	}
+	var got []DecisionJSON
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &got); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 decisions (including superseded), got %+v", got)
+	}
+	if got[0].ID != oldID || got[0].State != "superseded" || len(got[0].SupersededBy) != 1 || got[0].SupersededBy[0] != newID {
+		t.Errorf("unexpected old decision entry: %+v", got[0])
+	}
+	if got[1].ID != newID || got[1].State != "proposed" || len(got[1].Supersedes) != 1 {
+		t.Errorf("unexpected new decision entry: %+v", got[1])
+	}
+
+	app.Out = &bytes.Buffer{}
+	cmd = newDecisionsCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--state", "proposed"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("decisions --state failed: %v", err)
+	}
+	got = nil
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &got); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != newID {
+		t.Errorf("expected only %s, got %+v", newID, got)
+	}
+}
@@ -8,8 +8,8 @@ import (
 	"os"
 	"regexp"
 	"strings"
-	"time"
 
+	"beads-lite/internal/issueservice"
 	"beads-lite/internal/issuestorage"
 
 	"github.com/spf13/cobra"
@@ -18,14 +18,14 @@ import (
 // softDelete converts an issue to a tombstone (soft-delete) via Modify.
 // Sets status to tombstone, records deletion metadata, and moves the issue
 // to deleted storage. Returns ErrAlreadyTombstoned if already tombstoned.
-func softDelete(ctx context.Context, store issuestorage.IssueStore, id string, actor string, reason string) error {
+func softDelete(ctx context.Context, store *issueservice.IssueStore, id string, actor string, reason string) error {
 	return store.Modify(ctx, id, func(issue *issuestorage.Issue) error {
 		if issue.Status == issuestorage.StatusTombstone {
 			return issuestorage.ErrAlreadyTombstoned
 		}
 		issue.OriginalType = issue.Type
 		issue.Status = issuestorage.StatusTombstone
-		now := time.Now()
+		now := store.Now()
 		issue.DeletedAt = &now
 		issue.DeletedBy = actor
 		issue.DeleteReason = reason
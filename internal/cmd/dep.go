@@ -11,6 +11,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// depBacklinkCommentsKey makes adding or removing a dependency comment on
+// both issues, e.g. "bd-a1b2 now blocks this issue (added by alice)".
+const depBacklinkCommentsKey = "dep.backlink_comments"
+
 // newDepCmd creates the dep command with subcommands.
 func newDepCmd(provider *AppProvider) *cobra.Command {
 	cmd := &cobra.Command{
@@ -21,15 +25,21 @@ func newDepCmd(provider *AppProvider) *cobra.Command {
 Dependencies represent "A needs B to be done first" relationships.
 When A depends on B, B must be completed before A can start.
 
+With dep.backlink_comments set to true, adding or removing a dependency
+also comments on both issues, so each one's comments show who changed
+its relationships and when.
+
 Subcommands:
   add     Create a dependency (A depends on B)
   remove  Remove a dependency
-  list    Show dependencies for an issue`,
+  list    Show dependencies for an issue
+  tree    Show an issue's transitive dependencies as a tree`,
 	}
 
-	cmd.AddCommand(newDepAddCmd(provider))
+	cmd.AddCommand(undoable(provider, newDepAddCmd(provider)))
 	cmd.AddCommand(newDepRemoveCmd(provider))
 	cmd.AddCommand(newDepListCmd(provider))
+	cmd.AddCommand(newDepTreeCmd(provider))
 
 	return cmd
 }
@@ -69,7 +79,7 @@ Examples:
 			// Validate dependency type
 			dt := issuestorage.DependencyType(depType)
 			if !issuestorage.ValidDependencyTypes[dt] {
-				return fmt.Errorf("invalid dependency type %q; valid types: blocks, tracks, related, parent-child, discovered-from, until, caused-by, validates, relates-to, supersedes", depType)
+				return fmt.Errorf("invalid dependency type %q; valid types: blocks, tracks, related, parent-child, discovered-from, until, caused-by, validates, relates-to, supersedes, duplicate-of", depType)
 			}
 
 			// Resolve IDs (support prefix matching)
@@ -101,11 +111,11 @@ Examples:
 
 			// Output the result
 			if app.JSON {
-				result := map[string]string{
-					"issue_id":      issue.ID,
-					"depends_on_id": dependency.ID,
-					"type":          depType,
-					"status":        "added",
+				result := DepChangeJSON{
+					DependsOnID: dependency.ID,
+					IssueID:     issue.ID,
+					Status:      "added",
+					Type:        depType,
 				}
 				return json.NewEncoder(app.Out).Encode(result)
 			}
@@ -161,10 +171,10 @@ Examples:
 
 			// Output the result
 			if app.JSON {
-				result := map[string]string{
-					"issue_id":      issue.ID,
-					"depends_on_id": dependency.ID,
-					"status":        "removed",
+				result := DepChangeJSON{
+					DependsOnID: dependency.ID,
+					IssueID:     issue.ID,
+					Status:      "removed",
 				}
 				return json.NewEncoder(app.Out).Encode(result)
 			}
@@ -383,7 +393,7 @@ func statusIndicator(status issuestorage.Status) string {
 	switch status {
 	case issuestorage.StatusClosed:
 		return "✓"
-	case issuestorage.StatusInProgress:
+	case issuestorage.StatusInProgress, issuestorage.StatusReview:
 		return "●"
 	case issuestorage.StatusBlocked:
 		return "✗"
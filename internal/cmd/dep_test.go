@@ -350,6 +350,84 @@ func TestDepListTree(t *testing.T) {
 	}
 }
 
+func TestDepTree(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	create := func(title string, status issuestorage.Status) string {
+		id, err := store.Create(ctx, &issuestorage.Issue{Title: title, Status: status})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+	// A needs B and C; both need D; D is done.
+	a, b, c, d := create("A", issuestorage.StatusOpen), create("B", issuestorage.StatusInProgress), create("C", issuestorage.StatusOpen), create("D", issuestorage.StatusClosed)
+	for _, dep := range [][2]string{{a, b}, {a, c}, {b, d}, {c, d}} {
+		if err := store.AddDependency(ctx, dep[0], dep[1], issuestorage.DepTypeBlocks); err != nil {
+			t.Fatal(err)
+		}
+	}
+	related := create("Related", issuestorage.StatusOpen)
+	if err := store.AddDependency(ctx, a, related, issuestorage.DepTypeRelatesTo); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) (string, string) {
+		out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+		app.Out, app.Err = out, errOut
+		cmd := newDepCmd(NewTestProvider(app))
+		cmd.SetArgs(append([]string{"tree"}, args...))
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("dep tree %v: %v", args, err)
+		}
+		return out.String(), errOut.String()
+	}
+
+	out, _ := run(a)
+	for _, want := range []string{
+		"○ " + a + ": A\n",
+		"├── ● " + b + ": B [blocks]\n",
+		"│   └── ✓ " + d + ": D [blocks]\n",
+		"└── ○ " + c + ": C [blocks]\n",
+		"    └── ↗ " + d + " [blocks] (see above)\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dep tree missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, related) {
+		t.Errorf("dep tree follows links:\n%s", out)
+	}
+
+	out, _ = run(d, "--direction", "up", "--depth", "1")
+	if !strings.Contains(out, b+": B [blocks] …") || strings.Contains(out, a) {
+		t.Errorf("dep tree --direction up --depth 1:\n%s", out)
+	}
+
+	// A cycle written around cycle detection is marked and warned about.
+	if err := store.Modify(ctx, d, func(i *issuestorage.Issue) error {
+		i.Dependencies = append(i.Dependencies, issuestorage.Dependency{ID: a, Type: issuestorage.DepTypeBlocks})
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	out, warnings := run(a)
+	if !strings.Contains(out, "↻ "+a+" [blocks] (cycle)") || !strings.Contains(warnings, "dependency cycle: "+a+" → "+b+" → "+d+" → "+a) {
+		t.Errorf("cycle not reported:\n%s%s", out, warnings)
+	}
+
+	app.JSON = true
+	out, _ = run(a, "--type", "blocks")
+	var tree DepTreeJSON
+	if err := json.Unmarshal([]byte(out), &tree); err != nil {
+		t.Fatal(err)
+	}
+	if tree.Direction != "down" || tree.Root.ID != a || len(tree.Root.Children) != 2 || len(tree.Cycles) != 1 ||
+		!tree.Root.Children[0].Children[0].Children[0].Cycle || !tree.Root.Children[1].Children[0].Seen {
+		t.Errorf("dep tree --json = %s", out)
+	}
+}
+
 func TestDepNonExistent(t *testing.T) {
 	app, store := setupTestApp(t)
 
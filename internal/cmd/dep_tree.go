@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// DepTreeJSON is the JSON output of bd dep tree.
+type DepTreeJSON struct {
+	Direction string           `json:"direction"` // "down" (dependencies) or "up" (dependents)
+	Root      *DepTreeNodeJSON `json:"root"`
+	// Cycles are the cycles found, each a path of IDs from an issue back
+	// to itself.
+	Cycles [][]string `json:"cycles"`
+}
+
+// DepTreeNodeJSON is an issue in bd dep tree, with the issues it depends
+// on (or, going up, that depend on it) as children.
+type DepTreeNodeJSON struct {
+	ID     string `json:"id"`
+	Title  string `json:"title,omitempty"`
+	Status string `json:"status,omitempty"`
+	// Type is the dependency between the issue and its parent in the
+	// tree; empty for the root.
+	Type     string             `json:"dependency_type,omitempty"`
+	Children []*DepTreeNodeJSON `json:"children,omitempty"`
+	// The issue is not expanded when it is its own ancestor (Cycle), was
+	// expanded earlier in the tree (Seen), has further dependencies beyond
+	// --depth (Truncated), or could not be read (Missing).
+	Cycle     bool `json:"cycle,omitempty"`
+	Seen      bool `json:"seen,omitempty"`
+	Truncated bool `json:"truncated,omitempty"`
+	Missing   bool `json:"missing,omitempty"`
+}
+
+// newDepTreeCmd creates the "dep tree" subcommand.
+func newDepTreeCmd(provider *AppProvider) *cobra.Command {
+	var direction string
+	var depth int
+	var filterType string
+
+	cmd := &cobra.Command{
+		Use:   "tree <issue-id>",
+		Short: "Show an issue's transitive dependencies as a tree",
+		Long: `Show everything an issue depends on, directly or through other issues, as
+an indented tree with each issue's status. With --direction up, show
+everything that depends on it instead.
+
+Links (relates-to, duplicate-of, supersedes) are not dependencies and are
+left out unless selected with --type. An issue reached a second time is
+shown once more, marked "see above", but not expanded again. A cycle is
+marked where it closes and reported as a warning.
+
+Examples:
+  bd dep tree bd-a1b2                   # what bd-a1b2 needs done first
+  bd dep tree bd-a1b2 --direction up    # everything waiting on bd-a1b2
+  bd dep tree bd-a1b2 --depth 2         # two levels only
+  bd dep tree bd-a1b2 --type blocks     # blocking dependencies only`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			if direction != "down" && direction != "up" {
+				return fmt.Errorf("invalid direction %q; must be 'down' or 'up'", direction)
+			}
+			if depth < 0 {
+				return fmt.Errorf("invalid --depth %d; must be 0 (no limit) or more", depth)
+			}
+			var typeFilter *issuestorage.DependencyType
+			if filterType != "" {
+				dt := issuestorage.DependencyType(filterType)
+				if !issuestorage.ValidDependencyTypes[dt] {
+					return fmt.Errorf("invalid dependency type %q", filterType)
+				}
+				typeFilter = &dt
+			}
+
+			issue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+
+			b := &depTreeBuilder{
+				ctx:        ctx,
+				getter:     app.Storage,
+				up:         direction == "up",
+				depth:      depth,
+				typeFilter: typeFilter,
+				seen:       make(map[string]bool),
+			}
+			result := DepTreeJSON{Direction: direction, Root: b.build(issue, "", 0), Cycles: b.cycles}
+			if result.Cycles == nil {
+				result.Cycles = [][]string{}
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(result)
+			}
+			root := result.Root
+			fmt.Fprintf(app.Out, "%s %s: %s\n", statusIndicator(issuestorage.Status(root.Status)), root.ID, root.Title)
+			printDepTree(app, root.Children, "")
+			if len(root.Children) == 0 {
+				if b.up {
+					fmt.Fprintln(app.Out, "(nothing depends on it)")
+				} else {
+					fmt.Fprintln(app.Out, "(no dependencies)")
+				}
+			}
+			for _, cycle := range result.Cycles {
+				fmt.Fprintf(app.Err, "Warning: dependency cycle: %s\n", strings.Join(cycle, " → "))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&direction, "direction", "down", "'down' (what it depends on) or 'up' (what depends on it)")
+	cmd.Flags().IntVar(&depth, "depth", 0, "Levels to show below the issue (0: no limit)")
+	cmd.Flags().StringVarP(&filterType, "type", "t", "", "Follow only this dependency type (default: all but links)")
+
+	return cmd
+}
+
+// depTreeBuilder walks an issue's dependencies, or dependents, into a
+// DepTreeNodeJSON tree, expanding each issue once.
+type depTreeBuilder struct {
+	ctx        context.Context
+	getter     issuestorage.IssueGetter
+	up         bool
+	depth      int // 0: no limit
+	typeFilter *issuestorage.DependencyType
+
+	seen   map[string]bool
+	path   []string // IDs from the root to the issue being built
+	cycles [][]string
+}
+
+// edges returns the dependencies the tree follows from issue.
+func (b *depTreeBuilder) edges(issue *issuestorage.Issue) []issuestorage.Dependency {
+	deps := issue.Dependencies
+	if b.up {
+		deps = issue.Dependents
+	}
+	if b.typeFilter != nil {
+		return filterDeps(deps, b.typeFilter)
+	}
+	var edges []issuestorage.Dependency
+	for _, d := range deps {
+		if !d.Type.IsLink() {
+			edges = append(edges, d)
+		}
+	}
+	return edges
+}
+
+// build returns the node for issue, level levels below the root, reached
+// through a dependency of type depType.
+func (b *depTreeBuilder) build(issue *issuestorage.Issue, depType issuestorage.DependencyType, level int) *DepTreeNodeJSON {
+	node := &DepTreeNodeJSON{ID: issue.ID, Title: issue.Title, Status: string(issue.Status), Type: string(depType)}
+	if i := slices.Index(b.path, issue.ID); i >= 0 {
+		node.Cycle = true
+		b.cycles = append(b.cycles, append(slices.Clone(b.path[i:]), issue.ID))
+		return node
+	}
+	if b.seen[issue.ID] {
+		node.Seen = true
+		return node
+	}
+	b.seen[issue.ID] = true
+
+	edges := b.edges(issue)
+	if len(edges) == 0 {
+		return node
+	}
+	if b.depth > 0 && level >= b.depth {
+		node.Truncated = true
+		return node
+	}
+	b.path = append(b.path, issue.ID)
+	for _, e := range edges {
+		other, err := b.getter.Get(b.ctx, e.ID)
+		if err != nil {
+			node.Children = append(node.Children, &DepTreeNodeJSON{ID: e.ID, Type: string(e.Type), Missing: true})
+			continue
+		}
+		node.Children = append(node.Children, b.build(other, e.Type, level+1))
+	}
+	b.path = b.path[:len(b.path)-1]
+	return node
+}
+
+// printDepTree prints dep tree nodes below prefix, one per line.
+func printDepTree(app *App, nodes []*DepTreeNodeJSON, prefix string) {
+	for i, node := range nodes {
+		connector, childPrefix := "├── ", prefix+"│   "
+		if i == len(nodes)-1 {
+			connector, childPrefix = "└── ", prefix+"    "
+		}
+		line := fmt.Sprintf("%s %s: %s [%s]", statusIndicator(issuestorage.Status(node.Status)), node.ID, node.Title, node.Type)
+		switch {
+		case node.Missing:
+			line = fmt.Sprintf("? %s [%s] (not found)", node.ID, node.Type)
+		case node.Cycle:
+			line = fmt.Sprintf("↻ %s [%s] (cycle)", node.ID, node.Type)
+		case node.Seen:
+			line = fmt.Sprintf("↗ %s [%s] (see above)", node.ID, node.Type)
+		case node.Truncated:
+			line += " …"
+		}
+		fmt.Fprintf(app.Out, "%s%s%s\n", prefix, connector, line)
+		printDepTree(app, node.Children, childPrefix)
+	}
+}
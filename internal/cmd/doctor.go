@@ -28,7 +28,11 @@ Checks for:
 - Broken parent/child references
 - Orphaned lock files
 - Malformed JSON files
-- Asymmetric relationships (A depends on B but B doesn't list A as dependent)`,
+- Asymmetric relationships (A depends on B but B doesn't list A as dependent)
+- Clock skew (created_at/updated_at in the future, or updated_at before created_at);
+  --fix clamps the timestamps and records the correction in the issue's history
+- Orphaned attachment blobs (content in .beads/attachments/ no issue references);
+  --fix deletes them`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			app, err := provider.Get()
 			if err != nil {
@@ -41,6 +45,13 @@ Checks for:
 			if err != nil {
 				return fmt.Errorf("doctor failed: %w", err)
 			}
+			if app.ConfigDir != "" {
+				blobProblems, err := attachmentProblems(ctx, app, fix)
+				if err != nil {
+					return fmt.Errorf("checking attachments: %w", err)
+				}
+				problems = append(problems, blobProblems...)
+			}
 
 			if app.JSON {
 				result := DoctorResult{
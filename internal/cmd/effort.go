@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"beads-lite/internal/graph"
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// Effort is tracked in minutes of work time: a day is a working day and a
+// week a working week, so "3d" means three days of someone's time rather
+// than 72 hours.
+const (
+	minutesPerHour = 60
+	minutesPerDay  = 8 * minutesPerHour
+	minutesPerWeek = 5 * minutesPerDay
+)
+
+// effortUnits are the units parseEffort accepts and formatEffort prints,
+// largest first.
+var effortUnits = []struct {
+	unit    byte
+	minutes int
+}{
+	{'w', minutesPerWeek},
+	{'d', minutesPerDay},
+	{'h', minutesPerHour},
+	{'m', 1},
+}
+
+// EffortJSON totals estimates and logged time, in minutes. Unestimated
+// counts unclosed issues without an estimate, which Remaining leaves out.
+type EffortJSON struct {
+	Issues      int `json:"issues"`
+	Estimate    int `json:"estimate_minutes"`
+	Spent       int `json:"time_spent_minutes"`
+	Remaining   int `json:"remaining_minutes"`
+	Unestimated int `json:"unestimated"`
+}
+
+func toEffortJSON(e graph.Effort) *EffortJSON {
+	return &EffortJSON{Issues: e.Issues, Estimate: e.Estimate, Spent: e.Spent, Remaining: e.Remaining, Unestimated: e.Unestimated}
+}
+
+// parseEffort parses an amount of work such as "45m", "3h", "1d 4h" or
+// "2w" into minutes. Units are w (5 days), d (8 hours), h and m.
+func parseEffort(s string) (int, error) {
+	rest := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), " ", ""))
+	if rest == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+	total := 0
+	for rest != "" {
+		i := 0
+		for i < len(rest) && rest[i] >= '0' && rest[i] <= '9' {
+			i++
+		}
+		if i == 0 || i == len(rest) {
+			return 0, fmt.Errorf("invalid duration %q (expected e.g. 45m, 3h, 1d 4h or 2w)", s)
+		}
+		n, err := strconv.Atoi(rest[:i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+		minutes := 0
+		for _, u := range effortUnits {
+			if rest[i] == u.unit {
+				minutes = u.minutes
+			}
+		}
+		if minutes == 0 {
+			return 0, fmt.Errorf("invalid duration %q: unknown unit %q (use w, d, h or m)", s, rest[i:i+1])
+		}
+		total += n * minutes
+		rest = rest[i+1:]
+	}
+	return total, nil
+}
+
+// parseEstimateFlag parses an --estimate value; "" clears the estimate.
+func parseEstimateFlag(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	minutes, err := parseEffort(s)
+	if err != nil {
+		return 0, fmt.Errorf("--estimate: %w", err)
+	}
+	return minutes, nil
+}
+
+// formatEffort formats minutes the way parseEffort reads them, e.g. "1d 4h".
+func formatEffort(minutes int) string {
+	if minutes <= 0 {
+		return "0m"
+	}
+	var parts []string
+	for _, u := range effortUnits {
+		if n := minutes / u.minutes; n > 0 {
+			parts = append(parts, strconv.Itoa(n)+string(u.unit))
+			minutes -= n * u.minutes
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatEffortTotals summarizes an effort roll-up on one line.
+func formatEffortTotals(e graph.Effort) string {
+	s := fmt.Sprintf("%s remaining of %s estimated, %s logged", formatEffort(e.Remaining), formatEffort(e.Estimate), formatEffort(e.Spent))
+	if e.Unestimated > 0 {
+		s += fmt.Sprintf(" (%d open without an estimate)", e.Unestimated)
+	}
+	return s
+}
+
+// newLogTimeCmd creates the log-time command.
+func newLogTimeCmd(provider *AppProvider) *cobra.Command {
+	var message string
+
+	cmd := &cobra.Command{
+		Use:   "log-time <issue-id> <duration>",
+		Short: "Log time spent on an issue",
+		Long: `Add time worked to an issue's time spent. Durations are in working
+time: w (5 days), d (8 hours), h and m, combined as in "1d 4h".
+
+The entry is recorded in the issue's history with the actor and the
+optional message. Remaining effort is the estimate (bd update --estimate)
+less the time spent; bd show, bd stats, bd workload and bd impact roll it
+up.
+
+Examples:
+  bd log-time bd-a1b2 45m
+  bd log-time bd-a1b2 "1h 30m" -m "pairing on the migration"`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			minutes, err := parseEffort(args[1])
+			if err != nil {
+				return err
+			}
+			if minutes <= 0 {
+				return fmt.Errorf("duration must be positive: %s", args[1])
+			}
+			issue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+			actor, _ := resolveActor(app)
+			var total int
+			if err := app.Storage.Modify(ctx, issue.ID, func(i *issuestorage.Issue) error {
+				i.TimeSpent += minutes
+				total = i.TimeSpent
+				i.History = append(i.History, issuestorage.HistoryEntry{
+					At:    app.Now(),
+					Actor: actor,
+					Event: issuestorage.EventTimeLogged,
+					Field: "time_spent_minutes",
+					New:   strconv.Itoa(minutes),
+					Note:  message,
+				})
+				return nil
+			}); err != nil {
+				return fmt.Errorf("logging time on %s: %w", issue.ID, err)
+			}
+
+			if app.JSON {
+				updated, err := app.Storage.Get(ctx, issue.ID)
+				if err != nil {
+					return fmt.Errorf("fetching updated issue: %w", err)
+				}
+				return json.NewEncoder(app.Out).Encode(ToIssueJSON(ctx, app.Storage, updated, false, false))
+			}
+			line := fmt.Sprintf("%s Logged %s on %s (%s in total", app.SuccessColor("✓"), formatEffort(minutes), issue.ID, formatEffort(total))
+			if issue.Estimate > 0 {
+				line += fmt.Sprintf(" of %s estimated", formatEffort(issue.Estimate))
+			}
+			fmt.Fprintln(app.Out, line+")")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&message, "message", "m", "", "What the time was spent on")
+
+	return cmd
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+func TestParseEffort(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"45m", 45},
+		{"3h", 180},
+		{"1h30m", 90},
+		{"1d 4h", 720},
+		{"2W", 4800},
+	}
+	for _, tt := range tests {
+		got, err := parseEffort(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("parseEffort(%q) = %d, %v; want %d", tt.in, got, err, tt.want)
+		}
+		if back, _ := parseEffort(formatEffort(got)); back != got {
+			t.Errorf("formatEffort(%d) = %q does not parse back", got, formatEffort(got))
+		}
+	}
+	for _, bad := range []string{"", "45", "h", "3x", "1.5h"} {
+		if _, err := parseEffort(bad); err == nil {
+			t.Errorf("parseEffort(%q) should fail", bad)
+		}
+	}
+	if got := formatEffort(2*minutesPerWeek + minutesPerDay + 75); got != "2w 1d 1h 15m" {
+		t.Errorf("formatEffort = %q", got)
+	}
+}
+
+func TestEffortCommands(t *testing.T) {
+	t.Setenv("BD_ACTOR", "alice")
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	run := func(newCmd func(*AppProvider) *cobra.Command, args ...string) []byte {
+		t.Helper()
+		out := &bytes.Buffer{}
+		app.Out = out
+		cmd := newCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+		return out.Bytes()
+	}
+
+	epic := createAssigned(t, store, "Epic", "", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	build := createAssigned(t, store, "Build", "alice", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	ship := createAssigned(t, store, "Ship", "bob", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	docs := createAssigned(t, store, "Docs", "bob", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	for _, child := range []string{build, ship} {
+		if err := store.AddDependency(ctx, child, epic, issuestorage.DepTypeParentChild); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.AddDependency(ctx, ship, docs, issuestorage.DepTypeBlocks); err != nil {
+		t.Fatal(err)
+	}
+
+	run(newUpdateCmd, build, "--estimate", "1d")
+	run(newUpdateCmd, ship, "--estimate", "3h")
+	run(newUpdateCmd, docs, "--estimate", "2h")
+	out := run(newLogTimeCmd, build, "90m", "-m", "schema work")
+	if !strings.Contains(string(out), "Logged 1h 30m on "+build+" (1h 30m in total of 1d estimated)") {
+		t.Errorf("log-time output = %q", out)
+	}
+	run(newLogTimeCmd, build, "30m")
+
+	issue, _ := store.Get(ctx, build)
+	if issue.Estimate != 480 || issue.TimeSpent != 120 || issue.RemainingEffort() != 360 {
+		t.Errorf("estimate %d, spent %d, remaining %d", issue.Estimate, issue.TimeSpent, issue.RemainingEffort())
+	}
+	last := issue.History[len(issue.History)-1]
+	if last.Event != issuestorage.EventTimeLogged || last.Actor != "alice" || last.New != "30" {
+		t.Errorf("history entry = %+v", last)
+	}
+	if issue.History[len(issue.History)-2].Note != "schema work" {
+		t.Errorf("message not recorded: %+v", issue.History)
+	}
+
+	app.JSON = true
+
+	// The epic rolls up its children and the issue one of them waits on.
+	var shown []IssueJSON
+	if err := json.Unmarshal(run(newShowCmd, epic), &shown); err != nil {
+		t.Fatal(err)
+	}
+	want := EffortJSON{Issues: 4, Estimate: 780, Spent: 120, Remaining: 660, Unestimated: 1}
+	if len(shown) != 1 || shown[0].Effort == nil || *shown[0].Effort != want {
+		t.Fatalf("show effort = %+v, want %+v", shown[0].Effort, want)
+	}
+
+	var stats StatsResult
+	if err := json.Unmarshal(run(newStatsCmd, "--ids", epic), &stats); err != nil {
+		t.Fatal(err)
+	}
+	if stats.Effort == nil || stats.Effort.Remaining != 540 || stats.Effort.Issues != 3 {
+		t.Errorf("stats --ids effort = %+v, want the epic's subtree", stats.Effort)
+	}
+
+	var rows []WorkloadJSON
+	if err := json.Unmarshal(run(newWorkloadCmd), &rows); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range rows {
+		switch r.Assignee {
+		case "alice":
+			if r.Estimate != 480 || r.Remaining != 360 {
+				t.Errorf("alice = %+v", r)
+			}
+		case "bob":
+			if r.Estimate != 300 || r.Remaining != 300 {
+				t.Errorf("bob = %+v", r)
+			}
+		}
+	}
+
+	var impact ImpactJSON
+	if err := json.Unmarshal(run(newImpactCmd, docs), &impact); err != nil {
+		t.Fatal(err)
+	}
+	if impact.Effort == nil || impact.Effort.Remaining != 180 {
+		t.Errorf("impact effort = %+v, want ship's 3h", impact.Effort)
+	}
+
+	run(newUpdateCmd, build, "--estimate", "")
+	if issue, _ := store.Get(ctx, build); issue.Estimate != 0 || issue.TimeSpent != 120 {
+		t.Errorf("clearing the estimate: estimate %d, spent %d", issue.Estimate, issue.TimeSpent)
+	}
+}
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/kvstorage"
+	"beads-lite/internal/poker"
+
+	"github.com/spf13/cobra"
+)
+
+// EstimateRoundJSON is the JSON output of bd estimate vote: who has voted
+// so far, but not what.
+type EstimateRoundJSON struct {
+	ID     string   `json:"id"`
+	Voter  string   `json:"voter"`
+	Voters []string `json:"voters"`
+}
+
+// EstimateVoteJSON is a revealed vote.
+type EstimateVoteJSON struct {
+	Voter    string `json:"voter"`
+	Estimate int    `json:"estimate_minutes"`
+}
+
+// EstimateRevealJSON is the JSON output of bd estimate reveal. Consensus
+// is the median vote; Estimate is what was recorded on the issue, which
+// differs when --value overrides it.
+type EstimateRevealJSON struct {
+	ID        string             `json:"id"`
+	Votes     []EstimateVoteJSON `json:"votes"`
+	Consensus int                `json:"consensus_minutes"`
+	Unanimous bool               `json:"unanimous"`
+	Estimate  int                `json:"estimate_minutes"`
+}
+
+// newEstimateCmd creates the estimate command with subcommands.
+func newEstimateCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "estimate",
+		Short: "Estimate issues together by blind vote",
+		Long: `Run planning poker on an issue, asynchronously: each person votes an
+estimate without seeing anyone else's, and revealing the votes records
+their median as the issue's estimate (bd update --estimate).
+
+Votes are amounts of work as for --estimate (45m, 3h, 1d 4h, 2w); a bare
+number is hours. Voting again replaces your vote. Votes are kept in
+.beads/estimates/, one file per voter so votes cast on different clones
+merge without conflicts, until revealed.
+
+Subcommands:
+  vote    Vote an estimate for an issue
+  reveal  Show the votes and record the consensus`,
+	}
+
+	cmd.AddCommand(newEstimateVoteCmd(provider))
+	cmd.AddCommand(newEstimateRevealCmd(provider))
+
+	return cmd
+}
+
+// parseEstimateVote parses a vote: an amount of work, or a bare number of
+// hours as on a planning poker card.
+func parseEstimateVote(s string) (int, error) {
+	if n, err := strconv.Atoi(strings.TrimSpace(s)); err == nil {
+		if n <= 0 {
+			return 0, fmt.Errorf("an estimate must be more than zero, got %s", s)
+		}
+		return n * minutesPerHour, nil
+	}
+	minutes, err := parseEffort(s)
+	if err != nil {
+		return 0, err
+	}
+	if minutes <= 0 {
+		return 0, fmt.Errorf("an estimate must be more than zero, got %s", s)
+	}
+	return minutes, nil
+}
+
+// newEstimateVoteCmd creates the "estimate vote" subcommand.
+func newEstimateVoteCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "vote <id> <estimate>",
+		Short: "Vote an estimate for an issue",
+		Long: `Vote how much work an issue is, as the current actor. Nobody sees the
+votes until someone runs bd estimate reveal.
+
+Examples:
+  bd estimate vote bd-a1b2 5       # five hours
+  bd estimate vote bd-a1b2 2d`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			if app.EstimateStore == nil {
+				return fmt.Errorf("estimate store not configured")
+			}
+
+			minutes, err := parseEstimateVote(args[1])
+			if err != nil {
+				return err
+			}
+			issue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+			if issue.Status == issuestorage.StatusClosed || issue.Status == issuestorage.StatusTombstone {
+				return fmt.Errorf("%s is %s; only open issues can be estimated", issue.ID, issue.Status)
+			}
+			actor, err := resolveActor(app)
+			if err != nil {
+				return err
+			}
+
+			if s, ok := app.EstimateStore.(interface{ Init(context.Context) error }); ok {
+				if err := s.Init(ctx); err != nil {
+					return fmt.Errorf("initializing estimate store: %w", err)
+				}
+			}
+			round, err := poker.Cast(ctx, app.EstimateStore, issue.ID, poker.Vote{Voter: actor, Minutes: minutes, At: app.Now()})
+			if err != nil {
+				return err
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(EstimateRoundJSON{ID: issue.ID, Voter: actor, Voters: round.Voters()})
+			}
+			fmt.Fprintf(app.Out, "%s Voted %s on %s as %s; %d %s so far (%s)\n", app.SuccessColor("✓"), formatEffort(minutes), issue.ID, actor,
+				len(round.Votes), plural(len(round.Votes), "vote", "votes"), strings.Join(round.Voters(), ", "))
+			return nil
+		},
+	}
+}
+
+// newEstimateRevealCmd creates the "estimate reveal" subcommand.
+func newEstimateRevealCmd(provider *AppProvider) *cobra.Command {
+	var value string
+
+	cmd := &cobra.Command{
+		Use:   "reveal <id>",
+		Short: "Show the votes and record the consensus",
+		Long: `Show everyone's votes on an issue and record the consensus, their median,
+as its estimate. With an even number of votes the higher middle vote is
+taken. Use --value to record a different estimate, e.g. one agreed on
+after discussing widely differing votes. Revealing ends the round; a new
+round starts with the next vote.
+
+Examples:
+  bd estimate reveal bd-a1b2
+  bd estimate reveal bd-a1b2 --value 3d`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			if app.EstimateStore == nil {
+				return fmt.Errorf("estimate store not configured")
+			}
+
+			override := 0
+			if value != "" {
+				if override, err = parseEstimateVote(value); err != nil {
+					return fmt.Errorf("--value: %w", err)
+				}
+			}
+			issue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+			round, err := poker.Get(ctx, app.EstimateStore, issue.ID)
+			if errors.Is(err, kvstorage.ErrKeyNotFound) || (err == nil && len(round.Votes) == 0) {
+				return fmt.Errorf("no votes on %s yet; vote with bd estimate vote %s <estimate>", issue.ID, issue.ID)
+			}
+			if err != nil {
+				return err
+			}
+
+			result := EstimateRevealJSON{ID: issue.ID, Votes: make([]EstimateVoteJSON, len(round.Votes))}
+			for i, v := range round.Votes {
+				result.Votes[i] = EstimateVoteJSON{Voter: v.Voter, Estimate: v.Minutes}
+			}
+			result.Consensus, result.Unanimous = poker.Consensus(round.Votes)
+			result.Estimate = result.Consensus
+			if override > 0 {
+				result.Estimate = override
+			}
+
+			if err := app.Storage.Modify(ctx, issue.ID, func(i *issuestorage.Issue) error {
+				i.Estimate = result.Estimate
+				return nil
+			}); err != nil {
+				return fmt.Errorf("recording estimate on %s: %w", issue.ID, err)
+			}
+			if err := poker.Clear(ctx, app.EstimateStore, issue.ID); err != nil {
+				return err
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(result)
+			}
+			fmt.Fprintf(app.Out, "Votes on %s: %s\n", issue.ID, issue.Title)
+			for _, v := range result.Votes {
+				fmt.Fprintf(app.Out, "  %-16s %s\n", v.Voter, formatEffort(v.Estimate))
+			}
+			if result.Unanimous {
+				fmt.Fprintf(app.Out, "Unanimous: %s\n", formatEffort(result.Consensus))
+			} else {
+				fmt.Fprintf(app.Out, "Consensus (median): %s\n", formatEffort(result.Consensus))
+			}
+			fmt.Fprintf(app.Out, "%s Recorded estimate %s on %s\n", app.SuccessColor("✓"), formatEffort(result.Estimate), issue.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&value, "value", "", "Record this estimate instead of the consensus")
+
+	return cmd
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+	kvfs "beads-lite/internal/kvstorage/filesystem"
+)
+
+func TestParseEstimateVote(t *testing.T) {
+	for in, want := range map[string]int{"5": 300, " 1 ": 60, "45m": 45, "1d 4h": 720} {
+		if got, err := parseEstimateVote(in); err != nil || got != want {
+			t.Errorf("parseEstimateVote(%q) = %d, %v, want %d", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"0", "-3", "0h", "five", ""} {
+		if _, err := parseEstimateVote(in); err == nil {
+			t.Errorf("parseEstimateVote(%q) should fail", in)
+		}
+	}
+}
+
+func TestEstimatePoker(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	estimates, err := kvfs.New(t.TempDir(), "estimates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.EstimateStore = estimates
+	id, err := store.Create(ctx, &issuestorage.Issue{Title: "Rewrite login"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	run := func(actor string, args ...string) (string, error) {
+		out := &bytes.Buffer{}
+		app.Out = out
+		app.ConfigStore = &mapConfigStore{data: map[string]string{"actor": actor}}
+		cmd := newEstimateCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	if _, err := run("alice", "reveal", id); err == nil || !strings.Contains(err.Error(), "no votes") {
+		t.Errorf("reveal with no votes = %v", err)
+	}
+	if _, err := run("alice", "vote", id, "8"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run("alice", "vote", id, "3"); err != nil { // changed her mind
+		t.Fatal(err)
+	}
+	out, err := run("bob", "vote", id, "1d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "2 votes so far (alice, bob)") || strings.Contains(out, "3h") {
+		t.Errorf("vote output should name voters but not their votes: %q", out)
+	}
+	app.JSON = true
+	out, err = run("carol", "vote", id, "5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var round EstimateRoundJSON
+	if err := json.Unmarshal([]byte(out), &round); err != nil {
+		t.Fatal(err)
+	}
+	if round.Voter != "carol" || strings.Join(round.Voters, ",") != "alice,bob,carol" {
+		t.Errorf("vote --json = %s", out)
+	}
+
+	out, err = run("alice", "reveal", id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reveal EstimateRevealJSON
+	if err := json.Unmarshal([]byte(out), &reveal); err != nil {
+		t.Fatal(err)
+	}
+	if len(reveal.Votes) != 3 || reveal.Votes[0] != (EstimateVoteJSON{"alice", 180}) ||
+		reveal.Consensus != 300 || reveal.Unanimous || reveal.Estimate != 300 {
+		t.Errorf("reveal --json = %s", out)
+	}
+	issue, _ := store.Get(ctx, id)
+	if issue.Estimate != 300 {
+		t.Errorf("estimate = %d, want the median vote, 300", issue.Estimate)
+	}
+	if _, err := run("alice", "reveal", id); err == nil {
+		t.Error("revealing should end the round")
+	}
+
+	// A new round, settled on a value other than the median.
+	app.JSON = false
+	for _, actor := range []string{"alice", "bob"} {
+		if _, err := run(actor, "vote", id, "2h"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	out, err = run("bob", "reveal", id, "--value", "3h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "Unanimous: 2h") || !strings.Contains(out, "Recorded estimate 3h") {
+		t.Errorf("reveal --value output:\n%s", out)
+	}
+	issue, _ = store.Get(ctx, id)
+	if issue.Estimate != 180 {
+		t.Errorf("estimate = %d, want the --value, 180", issue.Estimate)
+	}
+
+	if err := store.Modify(ctx, id, func(i *issuestorage.Issue) error { i.Status = issuestorage.StatusClosed; return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run("alice", "vote", id, "1"); err == nil {
+		t.Error("voting on a closed issue should fail")
+	}
+}
@@ -0,0 +1,521 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"beads-lite/internal/graph"
+	"beads-lite/internal/issueservice"
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// ExplainJSON is the JSON output of bd explain.
+type ExplainJSON struct {
+	ID          string                  `json:"id"`
+	Title       string                  `json:"title"`
+	Status      string                  `json:"status"`
+	Summary     string                  `json:"summary"`
+	Blockers    []ExplainBlockerJSON    `json:"blockers"`
+	Gates       []GateCheckResultJSON   `json:"gates"`
+	Transitions []ExplainTransitionJSON `json:"transitions"`
+	Rules       []string                `json:"rules"`
+}
+
+// ExplainBlockerJSON is an open issue the explained issue waits on.
+type ExplainBlockerJSON struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	Via    string `json:"via,omitempty"` // ancestor whose blocker this is, when inherited
+}
+
+// ExplainTransitionJSON is a change the issue can make next and the
+// command that makes it.
+type ExplainTransitionJSON struct {
+	Field   string `json:"field"` // "status", or "decision_state" for decisions
+	To      string `json:"to"`
+	Command string `json:"command"`
+	Allowed bool   `json:"allowed"`
+	Note    string `json:"note,omitempty"` // why it is refused, or what to expect
+}
+
+// newExplainCmd creates the explain command.
+func newExplainCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain <issue-id>",
+		Short: "Explain why an issue is in its current state",
+		Long: `Explain in plain words why an issue is in its current state: what
+blocks it (directly or through an ancestor), which gates it waits on and
+what each awaits, which transitions it can make next and with which
+command, and which configured rules apply (approvals, close reasons,
+required fields, acceptance criteria, closed-issue edits, parent
+cascades).
+
+Nothing is changed. Gates waiting on GitHub are described but not
+polled; bd gate check does that.
+
+Examples:
+  bd explain bd-a1b2
+  bd explain bd-a1b2 --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			issue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+			result, err := explainIssue(ctx, app, issue)
+			if err != nil {
+				return err
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(result)
+			}
+			printExplain(app, result)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// explainIssue gathers why issue is in its state.
+func explainIssue(ctx context.Context, app *App, issue *issuestorage.Issue) (*ExplainJSON, error) {
+	now := app.Now()
+	result := &ExplainJSON{
+		ID:          issue.ID,
+		Title:       issue.Title,
+		Status:      string(issue.Status),
+		Blockers:    []ExplainBlockerJSON{},
+		Gates:       []GateCheckResultJSON{},
+		Transitions: []ExplainTransitionJSON{},
+		Rules:       []string{},
+	}
+
+	if issue.Status != issuestorage.StatusClosed && issue.Status != issuestorage.StatusTombstone {
+		blockers, err := explainBlockers(ctx, app, issue)
+		if err != nil {
+			return nil, err
+		}
+		result.Blockers = blockers
+	}
+
+	checker := &gateChecker{app: app, now: now}
+	if issue.Type == issuestorage.TypeGate && issue.Status != issuestorage.StatusClosed && issue.Status != issuestorage.StatusTombstone {
+		result.Gates = append(result.Gates, explainGate(ctx, checker, issue))
+	}
+	for _, b := range result.Blockers {
+		blocker, err := app.Storage.Get(ctx, b.ID)
+		if err == nil && blocker.Type == issuestorage.TypeGate {
+			result.Gates = append(result.Gates, explainGate(ctx, checker, blocker))
+		}
+	}
+
+	openChildren := 0
+	for _, id := range issue.Children() {
+		if child, err := app.Storage.Get(ctx, id); err == nil && child.Status != issuestorage.StatusClosed && child.Status != issuestorage.StatusTombstone {
+			openChildren++
+		}
+	}
+
+	result.Summary = explainSummary(app, issue, result.Blockers, now)
+	result.Transitions = explainTransitions(app, issue, len(result.Blockers), openChildren)
+	result.Rules = explainRules(app, issue, openChildren, now)
+	return result, nil
+}
+
+// explainBlockers returns the open issues issue waits on, including those
+// inherited from its ancestors when graph.cascade_parent_blocking is on.
+func explainBlockers(ctx context.Context, app *App, issue *issuestorage.Issue) ([]ExplainBlockerJSON, error) {
+	closedIssues, err := app.Storage.List(ctx, &issuestorage.ListFilter{Statuses: []issuestorage.Status{issuestorage.StatusClosed}})
+	if err != nil {
+		return nil, fmt.Errorf("listing closed issues: %w", err)
+	}
+	closedSet := make(map[string]bool, len(closedIssues))
+	for _, c := range closedIssues {
+		closedSet[c.ID] = true
+	}
+	effective, err := graph.EffectiveBlockers(ctx, app.Storage, issue, closedSet, cascadeEnabled(app))
+	if err != nil {
+		return nil, fmt.Errorf("checking blockers for %s: %w", issue.ID, err)
+	}
+
+	blockers := []ExplainBlockerJSON{}
+	add := func(id, via string) {
+		b := ExplainBlockerJSON{ID: id, Via: via, Status: "missing"}
+		if blocker, err := app.Storage.Get(ctx, id); err == nil {
+			b.Title, b.Status = blocker.Title, string(blocker.Status)
+		}
+		blockers = append(blockers, b)
+	}
+	for _, id := range effective.Direct {
+		add(id, "")
+	}
+	for _, ib := range effective.Inherited {
+		add(ib.BlockerID, ib.AncestorID)
+	}
+	return blockers, nil
+}
+
+// explainGate describes what gate awaits. Gates bd can check locally
+// (timers, calendar events and beads) are evaluated; GitHub and ticket
+// gates are only described, since polling them is bd gate check's job.
+func explainGate(ctx context.Context, c *gateChecker, gate *issuestorage.Issue) GateCheckResultJSON {
+	awaitType, awaitID := gateAwaitType(gate)
+	switch awaitType {
+	case "jira", "linear":
+		return GateCheckResultJSON{GateID: gate.ID, AwaitType: gate.AwaitType, Result: "skipped",
+			Reason: fmt.Sprintf("waits for %s ticket %s to be done; bd gate check polls it", strings.ToUpper(awaitType[:1])+awaitType[1:], awaitID)}
+	case "gh:run":
+		return GateCheckResultJSON{GateID: gate.ID, AwaitType: gate.AwaitType, Result: "skipped",
+			Reason: fmt.Sprintf("waits for GitHub Actions run %s to succeed; bd gate check polls it", gate.AwaitID)}
+	case "gh:pr":
+		return GateCheckResultJSON{GateID: gate.ID, AwaitType: gate.AwaitType, Result: "skipped",
+			Reason: fmt.Sprintf("waits for pull request %s to merge; bd gate check polls it", gate.AwaitID)}
+	case "human":
+		return GateCheckResultJSON{GateID: gate.ID, AwaitType: gate.AwaitType, Result: "pending",
+			Reason: fmt.Sprintf("waits for a person to run bd gate resolve %s", gate.ID)}
+	}
+	r, _ := c.evaluate(ctx, gate)
+	if r.Result == "resolved" {
+		r.Reason += "; bd gate check will close it"
+	}
+	return r
+}
+
+// explainSummary says in one sentence why the issue is in its status.
+func explainSummary(app *App, issue *issuestorage.Issue, blockers []ExplainBlockerJSON, now time.Time) string {
+	waiting := ""
+	if n := len(blockers); n > 0 {
+		waiting = fmt.Sprintf("it waits on %d open %s", n, plural(n, "issue", "issues"))
+	}
+
+	var s string
+	switch issue.Status {
+	case issuestorage.StatusOpen:
+		switch {
+		case waiting != "":
+			s = "Open but blocked: " + waiting + ", so bd ready leaves it out."
+		case issue.Type == issuestorage.TypeGate:
+			s = "An open gate: it closes when what it awaits happens."
+		case issue.Ephemeral:
+			s = "Open and unblocked, but ephemeral, so bd ready never lists it."
+		case issue.DeferUntil != nil && issue.DeferUntil.After(now):
+			s = fmt.Sprintf("Open and unblocked, but not expected to start before %s.", issue.DeferUntil.Format("2006-01-02"))
+		case issue.Parent != "":
+			s = fmt.Sprintf("Open and unblocked: a step of %s, listed by bd ready --mol %s.", issue.Parent, issue.Parent)
+		default:
+			s = "Open and unblocked, so bd ready lists it."
+		}
+	case issuestorage.StatusInProgress:
+		if issue.Assignee == "" {
+			s = "In progress, but nobody is assigned."
+		} else {
+			s = fmt.Sprintf("In progress, assigned to %s.", issue.Assignee)
+		}
+	case issuestorage.StatusReview:
+		s = "In review"
+		if issue.Reviewer != "" {
+			s += " by " + issue.Reviewer
+		}
+		switch {
+		case len(issue.Reviews) == 0:
+			s += ", awaiting a verdict."
+		case issue.Approved():
+			s += fmt.Sprintf(": approved by %s and ready to close.", issue.Reviews[len(issue.Reviews)-1].Reviewer)
+		default:
+			s += fmt.Sprintf(": %s asked for changes; a new approval is needed.", issue.Reviews[len(issue.Reviews)-1].Reviewer)
+		}
+	case issuestorage.StatusBlocked:
+		if waiting != "" {
+			s = "Marked blocked: " + waiting + "."
+		} else {
+			s = "Marked blocked by hand; it has no open blockers, so nothing recorded holds it back."
+		}
+	case issuestorage.StatusDeferred:
+		if issue.DeferUntil != nil {
+			s = fmt.Sprintf("Deferred until %s.", issue.DeferUntil.Format("2006-01-02"))
+		} else {
+			s = "Deferred: set aside until someone reopens it."
+		}
+	case issuestorage.StatusHooked:
+		s = "Hooked onto an agent's work slot"
+		if issue.Assignee != "" {
+			s += " (" + issue.Assignee + ")"
+		}
+		s += "; it stays there until the agent closes or releases it."
+	case issuestorage.StatusPinned:
+		s = "Pinned: kept open as a standing reference rather than work to finish."
+	case issuestorage.StatusClosed:
+		s = "Closed"
+		if issue.Resolution != "" {
+			s += " as " + string(issue.Resolution)
+		}
+		if issue.DuplicateOf != "" {
+			s += " of " + issue.DuplicateOf
+		}
+		if issue.ClosedAt != nil {
+			s += " on " + issue.ClosedAt.Format("2006-01-02")
+		}
+		if issue.CloseReason != "" && issue.CloseReason != "Closed" {
+			s += ": " + issue.CloseReason
+		}
+		s += "."
+	case issuestorage.StatusTombstone:
+		s = "Deleted"
+		if issue.DeletedBy != "" {
+			s += " by " + issue.DeletedBy
+		}
+		if issue.DeleteReason != "" {
+			s += ": " + issue.DeleteReason
+		}
+		s += "; only kept so the ID is not reused."
+	default:
+		s = fmt.Sprintf("In custom status %q (configured by status.custom).", issue.Status)
+		if waiting != "" {
+			s += " It " + strings.TrimPrefix(waiting, "it ") + "."
+		}
+	}
+
+	if issue.Type == issuestorage.TypeDecision && issue.DecisionState != "" {
+		s += fmt.Sprintf(" As a decision it is %s.", issue.DecisionState)
+	}
+	return s
+}
+
+// explainTransitions lists the changes the issue can make next. A
+// transition is not allowed only when bd would refuse it; other rules are
+// noted.
+func explainTransitions(app *App, issue *issuestorage.Issue, blockers, openChildren int) []ExplainTransitionJSON {
+	status := func(to issuestorage.Status, command, note string) ExplainTransitionJSON {
+		return ExplainTransitionJSON{Field: "status", To: string(to), Command: fmt.Sprintf(command, issue.ID), Allowed: true, Note: note}
+	}
+
+	transitions := []ExplainTransitionJSON{}
+	switch issue.Status {
+	case issuestorage.StatusTombstone:
+		return transitions
+	case issuestorage.StatusClosed:
+		note := ""
+		if issue.Parent != "" {
+			note = "reopens closed ancestors too"
+		}
+		return append(transitions, status(issuestorage.StatusOpen, "bd reopen %s", note))
+	}
+
+	blockedNote := ""
+	if blockers > 0 {
+		blockedNote = fmt.Sprintf("still waits on %d open %s", blockers, plural(blockers, "issue", "issues"))
+	}
+
+	switch issue.Status {
+	case issuestorage.StatusReview:
+		transitions = append(transitions, status(issuestorage.StatusInProgress, "bd review request-changes %s -m <comment>", "records the reviewer's verdict"))
+	case issuestorage.StatusInProgress:
+		transitions = append(transitions, status(issuestorage.StatusReview, "bd review request %s", ""))
+	default:
+		transitions = append(transitions, status(issuestorage.StatusInProgress, "bd update %s --claim", blockedNote))
+		transitions = append(transitions, status(issuestorage.StatusReview, "bd review request %s", ""))
+	}
+	if issue.Status != issuestorage.StatusOpen {
+		transitions = append(transitions, status(issuestorage.StatusOpen, "bd update %s --status open", ""))
+	}
+	if issue.Status != issuestorage.StatusDeferred {
+		transitions = append(transitions, status(issuestorage.StatusDeferred, "bd update %s --status deferred", ""))
+	}
+
+	closing := status(issuestorage.StatusClosed, "bd close %s", "")
+	if issue.Type == issuestorage.TypeGate {
+		closing.Command = "bd gate resolve " + issue.ID
+	}
+	var notes []string
+	if reviewApprovalRequired(app) && !issue.Approved() {
+		closing.Allowed = false
+		notes = append(notes, fmt.Sprintf("needs an approved review first (bd review approve %s)", issue.ID))
+	}
+	if closeReasonRequired(app) && issue.Type != issuestorage.TypeGate {
+		closing.Command += " --reason <resolution>"
+		notes = append(notes, "needs a reason starting with one of "+resolutionNames())
+	}
+	if n := issue.UncheckedCriteria(); n > 0 {
+		notes = append(notes, fmt.Sprintf("%d acceptance %s unchecked (bd lint flags that once closed)", n, plural(n, "criterion", "criteria")))
+	}
+	if blockedNote != "" {
+		notes = append(notes, blockedNote)
+	}
+	if openChildren > 0 {
+		notes = append(notes, fmt.Sprintf("%d %s still open", openChildren, plural(openChildren, "child is", "children are")))
+	}
+	closing.Note = strings.Join(notes, "; ")
+	transitions = append(transitions, closing)
+
+	if issue.Type == issuestorage.TypeDecision {
+		decision := func(to issuestorage.DecisionState, command string) ExplainTransitionJSON {
+			return ExplainTransitionJSON{Field: "decision_state", To: string(to), Command: fmt.Sprintf(command, issue.ID), Allowed: true}
+		}
+		from := issue.DecisionState
+		if from == "" {
+			from = issuestorage.DecisionProposed
+		}
+		if issuestorage.ValidDecisionTransition(from, issuestorage.DecisionAccepted) {
+			transitions = append(transitions, decision(issuestorage.DecisionAccepted, "bd decision accept %s"))
+		}
+		if issuestorage.ValidDecisionTransition(from, issuestorage.DecisionSuperseded) {
+			transitions = append(transitions, decision(issuestorage.DecisionSuperseded, "bd decision supersede %s --by <new-decision-id>"))
+		}
+	}
+	return transitions
+}
+
+// explainRules lists the configured rules that bear on the issue.
+func explainRules(app *App, issue *issuestorage.Issue, openChildren int, now time.Time) []string {
+	rules := []string{}
+	open := issue.Status != issuestorage.StatusClosed && issue.Status != issuestorage.StatusTombstone
+
+	if reviewApprovalRequired(app) && open {
+		latest := "none yet"
+		if n := len(issue.Reviews); n > 0 {
+			r := issue.Reviews[n-1]
+			latest = fmt.Sprintf("%s by %s", strings.ReplaceAll(string(r.Outcome), "_", " "), r.Reviewer)
+		}
+		rules = append(rules, fmt.Sprintf("%s: closing needs the latest review to be an approval (latest: %s)", reviewRequireApprovalKey, latest))
+	}
+	if closeReasonRequired(app) && open {
+		rules = append(rules, fmt.Sprintf("%s: bd close needs --reason starting with one of %s, or --duplicate-of", closeRequireReasonKey, resolutionNames()))
+	}
+	if app.ConfigStore != nil {
+		typeRules, _ := issueservice.ParseTypeRules(app.ConfigStore.All())
+		if rule, ok := typeRules[issue.Type]; ok && len(rule.Required) > 0 {
+			line := fmt.Sprintf("types.%s.required: %s", issue.Type, strings.Join(rule.Required, ", "))
+			if problems := app.Storage.Lint(issue); len(problems) > 0 && strings.HasPrefix(problems[0], "missing required") {
+				line += "; " + problems[0]
+			}
+			rules = append(rules, line)
+		}
+	}
+	if next, ok := app.Storage.Workflow().Next[issue.Status]; ok {
+		names := make([]string, len(next))
+		for i, s := range next {
+			names[i] = string(s)
+		}
+		allowed := strings.Join(names, ", ")
+		if allowed == "" {
+			allowed = "no other status"
+		}
+		rules = append(rules, fmt.Sprintf("workflow.%s.next: can move to %s", issue.Status, allowed))
+	}
+	if n := len(issue.AcceptanceCriteria); n > 0 {
+		rules = append(rules, fmt.Sprintf("acceptance criteria: %d of %d checked (bd criteria list %s)", n-issue.UncheckedCriteria(), n, issue.ID))
+	}
+	if issue.Status == issuestorage.StatusClosed {
+		switch mode := closedEditMode(app); mode {
+		case "warn":
+			rules = append(rules, closedEditKey+"=warn: bd update and bd comments warn before editing it")
+		case "force":
+			rules = append(rules, closedEditKey+"=force: bd update and bd comments refuse to edit it without --force")
+		case "reopen":
+			rules = append(rules, closedEditKey+"=reopen: editing it with bd update or bd comments reopens it")
+		}
+	}
+	if issue.Parent != "" && open {
+		if cascadeEnabled(app) {
+			rules = append(rules, fmt.Sprintf("graph.cascade_parent_blocking: blockers of %s and its ancestors block this issue too", issue.Parent))
+		}
+		if autoCloseParentEnabled(app) {
+			rules = append(rules, fmt.Sprintf("graph.auto_close_parent: closing the last open child of %s closes it too", issue.Parent))
+		}
+	}
+	if openChildren > 0 && autoCloseParentEnabled(app) && open {
+		rules = append(rules, fmt.Sprintf("graph.auto_close_parent: closes automatically when its %d open %s close", openChildren, plural(openChildren, "child", "children")))
+	}
+	if until, ok := awayPeople(app, now)[issue.Assignee]; ok && issue.Assignee != "" && open {
+		rules = append(rules, fmt.Sprintf("ooo: %s is out of office until %s", issue.Assignee, until.Format(oooDateLayout)))
+	}
+	return rules
+}
+
+// reviewApprovalRequired reports whether review.require_approval is set.
+func reviewApprovalRequired(app *App) bool {
+	if app.ConfigStore == nil {
+		return false
+	}
+	v, _ := app.ConfigStore.Get(reviewRequireApprovalKey)
+	return v == "true"
+}
+
+// autoCloseParentEnabled reads graph.auto_close_parent, on by default.
+func autoCloseParentEnabled(app *App) bool {
+	if app.ConfigStore == nil {
+		return true
+	}
+	v, ok := app.ConfigStore.Get("graph.auto_close_parent")
+	return !ok || v != "false"
+}
+
+// printExplain writes the explanation as text.
+func printExplain(app *App, e *ExplainJSON) {
+	fmt.Fprintf(app.Out, "%s: %s\n", e.ID, e.Title)
+	fmt.Fprintf(app.Out, "Status: %s\n\n", e.Status)
+	fmt.Fprintf(app.Out, "%s\n", e.Summary)
+
+	if len(e.Blockers) > 0 {
+		fmt.Fprintf(app.Out, "\nWaiting on:\n")
+		for _, b := range e.Blockers {
+			via := ""
+			if b.Via != "" {
+				via = " (via " + b.Via + ")"
+			}
+			fmt.Fprintf(app.Out, "  %s  %s [%s]%s\n", b.ID, b.Title, b.Status, via)
+		}
+	}
+
+	if len(e.Gates) > 0 {
+		fmt.Fprintf(app.Out, "\nGates:\n")
+		for _, g := range e.Gates {
+			fmt.Fprintf(app.Out, "  %s %s  %s: %s\n", resultSymbol(g.Result), g.GateID, g.AwaitType, g.Reason)
+		}
+	}
+
+	if len(e.Transitions) > 0 {
+		fmt.Fprintf(app.Out, "\nNext:\n")
+		width := 0
+		for _, t := range e.Transitions {
+			width = max(width, len(t.To))
+		}
+		for _, t := range e.Transitions {
+			line := fmt.Sprintf("  %-*s  %s", width, t.To, t.Command)
+			if !t.Allowed {
+				line = fmt.Sprintf("  %-*s  not allowed: %s", width, t.To, t.Note)
+			} else if t.Note != "" {
+				line += "  (" + t.Note + ")"
+			}
+			fmt.Fprintln(app.Out, line)
+		}
+	}
+
+	if len(e.Rules) > 0 {
+		fmt.Fprintf(app.Out, "\nRules:\n")
+		for _, r := range e.Rules {
+			fmt.Fprintf(app.Out, "  - %s\n", r)
+		}
+	}
+}
+
+// plural picks one or many by n.
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func runExplain(t *testing.T, app *App, id string) ExplainJSON {
+	t.Helper()
+	out := &bytes.Buffer{}
+	app.Out = out
+	app.JSON = true
+	cmd := newExplainCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{id})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("explain %s: %v", id, err)
+	}
+	var result ExplainJSON
+	if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+		t.Fatalf("parsing %q: %v", out.String(), err)
+	}
+	return result
+}
+
+func findTransition(e ExplainJSON, to string) *ExplainTransitionJSON {
+	for i, tr := range e.Transitions {
+		if tr.Field == "status" && tr.To == to {
+			return &e.Transitions[i]
+		}
+	}
+	return nil
+}
+
+func TestExplain_BlockedByGate(t *testing.T) {
+	app, rs := setupTestApp(t)
+	ctx := context.Background()
+	id, err := rs.Create(ctx, &issuestorage.Issue{Title: "Deploy", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gate, err := rs.Create(ctx, &issuestorage.Issue{Title: "Wait for CI", Type: issuestorage.TypeGate, Status: issuestorage.StatusOpen, AwaitType: "gh:run", AwaitID: "12345"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rs.AddDependency(ctx, id, gate, issuestorage.DepTypeBlocks); err != nil {
+		t.Fatal(err)
+	}
+
+	e := runExplain(t, app, id)
+	if len(e.Blockers) != 1 || e.Blockers[0].ID != gate || e.Blockers[0].Status != "open" {
+		t.Errorf("blockers = %+v, want the gate", e.Blockers)
+	}
+	if len(e.Gates) != 1 || e.Gates[0].GateID != gate || !strings.Contains(e.Gates[0].Reason, "12345") {
+		t.Errorf("gates = %+v, want the gh:run gate", e.Gates)
+	}
+	if !strings.Contains(e.Summary, "blocked") {
+		t.Errorf("summary = %q, want it to say blocked", e.Summary)
+	}
+	closing := findTransition(e, "closed")
+	if closing == nil || !closing.Allowed || !strings.Contains(closing.Note, "1 open issue") {
+		t.Errorf("close transition = %+v", closing)
+	}
+}
+
+func TestExplain_InheritedBlockerAndTimerGate(t *testing.T) {
+	app, rs := setupTestApp(t)
+	ctx := context.Background()
+	epic, _ := rs.Create(ctx, &issuestorage.Issue{Title: "Epic", Type: issuestorage.TypeEpic, Status: issuestorage.StatusOpen})
+	child, _ := rs.Create(ctx, &issuestorage.Issue{Title: "Step", Status: issuestorage.StatusOpen})
+	if err := rs.AddDependency(ctx, child, epic, issuestorage.DepTypeParentChild); err != nil {
+		t.Fatal(err)
+	}
+	timer, err := rs.Create(ctx, &issuestorage.Issue{Title: "Cool-off", Type: issuestorage.TypeGate, Status: issuestorage.StatusOpen, AwaitType: "timer", TimeoutNS: int64(time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rs.AddDependency(ctx, epic, timer, issuestorage.DepTypeBlocks); err != nil {
+		t.Fatal(err)
+	}
+
+	e := runExplain(t, app, child)
+	if len(e.Blockers) != 1 || e.Blockers[0].ID != timer || e.Blockers[0].Via != epic {
+		t.Errorf("blockers = %+v, want the timer via %s", e.Blockers, epic)
+	}
+	if len(e.Gates) != 1 || e.Gates[0].Result != "pending" {
+		t.Errorf("gates = %+v, want a pending timer", e.Gates)
+	}
+	if !strings.Contains(strings.Join(e.Rules, "\n"), "graph.cascade_parent_blocking") {
+		t.Errorf("rules = %q, want the cascade rule", e.Rules)
+	}
+}
+
+func TestExplain_ApprovalRequired(t *testing.T) {
+	app, rs := setupTestApp(t)
+	app.ConfigStore = &mapConfigStore{data: map[string]string{reviewRequireApprovalKey: "true"}}
+	ctx := context.Background()
+	id, err := rs.Create(ctx, &issuestorage.Issue{Title: "Change", Status: issuestorage.StatusReview, Reviewer: "bob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := runExplain(t, app, id)
+	closing := findTransition(e, "closed")
+	if closing == nil || closing.Allowed || !strings.Contains(closing.Note, "bd review approve "+id) {
+		t.Errorf("close transition = %+v, want it refused pending approval", closing)
+	}
+	if back := findTransition(e, "in_progress"); back == nil || !strings.HasPrefix(back.Command, "bd review request-changes") {
+		t.Errorf("in_progress transition = %+v", back)
+	}
+	if !strings.Contains(strings.Join(e.Rules, "\n"), "latest: none yet") {
+		t.Errorf("rules = %q", e.Rules)
+	}
+
+	if err := rs.Modify(ctx, id, func(i *issuestorage.Issue) error {
+		i.Reviews = append(i.Reviews, issuestorage.Review{Reviewer: "bob", Outcome: issuestorage.ReviewApproved})
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	e = runExplain(t, app, id)
+	if closing := findTransition(e, "closed"); closing == nil || !closing.Allowed {
+		t.Errorf("close transition after approval = %+v", closing)
+	}
+	if !strings.Contains(e.Summary, "approved by bob") {
+		t.Errorf("summary = %q", e.Summary)
+	}
+}
+
+func TestExplain_Closed(t *testing.T) {
+	app, id := setupClosedEditTest(t, "force")
+	e := runExplain(t, app, id)
+	if len(e.Transitions) != 1 || e.Transitions[0].To != "open" || e.Transitions[0].Command != "bd reopen "+id {
+		t.Errorf("transitions = %+v, want only reopen", e.Transitions)
+	}
+	if len(e.Rules) != 1 || !strings.HasPrefix(e.Rules[0], "closed.edit=force") {
+		t.Errorf("rules = %q", e.Rules)
+	}
+}
+
+func TestExplain_Decision(t *testing.T) {
+	app, rs := setupTestApp(t)
+	id, err := rs.Create(context.Background(), &issuestorage.Issue{Title: "Use Postgres", Type: issuestorage.TypeDecision, Status: issuestorage.StatusOpen, DecisionState: issuestorage.DecisionAccepted})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := runExplain(t, app, id)
+	var decisions []string
+	for _, tr := range e.Transitions {
+		if tr.Field == "decision_state" {
+			decisions = append(decisions, tr.To)
+		}
+	}
+	if len(decisions) != 1 || decisions[0] != "superseded" {
+		t.Errorf("decision transitions = %v, want [superseded]", decisions)
+	}
+}
+
+func TestExplain_Text(t *testing.T) {
+	app, rs := setupTestApp(t)
+	id, err := rs.Create(context.Background(), &issuestorage.Issue{Title: "Plain", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := newExplainCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{id})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	out := app.Out.(*bytes.Buffer).String()
+	for _, want := range []string{id + ": Plain", "Status: open", "bd ready lists it", "Next:", "bd update " + id + " --claim"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
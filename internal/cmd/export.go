@@ -0,0 +1,423 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// csvExportColumns maps the columns bd export csv can write to their
+// values. Column names match the fields bd import csv reads, so an export
+// can be imported again.
+var csvExportColumns = map[string]func(*issuestorage.Issue) string{
+	"id":           func(i *issuestorage.Issue) string { return i.ID },
+	"parent":       func(i *issuestorage.Issue) string { return i.Parent },
+	"title":        func(i *issuestorage.Issue) string { return i.Title },
+	"description":  func(i *issuestorage.Issue) string { return i.Description },
+	"type":         func(i *issuestorage.Issue) string { return string(i.Type) },
+	"status":       func(i *issuestorage.Issue) string { return string(i.Status) },
+	"priority":     func(i *issuestorage.Issue) string { return i.Priority.Display() },
+	"assignee":     func(i *issuestorage.Issue) string { return i.Assignee },
+	"labels":       func(i *issuestorage.Issue) string { return strings.Join(i.Labels, ",") },
+	"owner":        func(i *issuestorage.Issue) string { return i.Owner },
+	"created_by":   func(i *issuestorage.Issue) string { return i.CreatedBy },
+	"created_at":   func(i *issuestorage.Issue) string { return formatExportTime(&i.CreatedAt) },
+	"updated_at":   func(i *issuestorage.Issue) string { return formatExportTime(&i.UpdatedAt) },
+	"closed_at":    func(i *issuestorage.Issue) string { return formatExportTime(i.ClosedAt) },
+	"close_reason": func(i *issuestorage.Issue) string { return i.CloseReason },
+	"resolution":   func(i *issuestorage.Issue) string { return string(i.Resolution) },
+	"duplicate_of": func(i *issuestorage.Issue) string { return i.DuplicateOf },
+}
+
+// defaultCSVExportColumns is used when --columns is not given.
+var defaultCSVExportColumns = []string{"id", "parent", "title", "type", "status", "priority", "assignee", "labels"}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// newExportCmd creates the export command with a subcommand per format.
+func newExportCmd(provider *AppProvider) *cobra.Command {
+	var (
+		format string
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export issues to other formats",
+		Long: `Export issues to formats used by other tools.
+
+With --format jsonl, write the whole store as JSON Lines: one issue per
+line, in the same format as the issue files, with its dependencies in both
+directions, comments, history and attachments. Closed and deleted
+(tombstoned) issues are included; ephemeral ones are not. Child issues
+keep their hierarchical IDs, so numbering of new children carries on from
+where it was. bd import --format jsonl reads the file back into this or
+any other backend.
+
+Subcommands:
+  csv       Spreadsheet rows with selectable columns
+  org       Org-mode TODO headlines
+  timeline  An epic's schedule as a Mermaid Gantt chart or CSV
+
+Examples:
+  bd export --format jsonl -o backup.jsonl
+  bd export --format jsonl | ssh other-host 'cd proj && bd import --format jsonl'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format == "" {
+				return cmd.Help()
+			}
+			if format != formatJSONL {
+				return fmt.Errorf("unknown export format %q (valid: %s; csv, org and timeline are subcommands)", format, formatJSONL)
+			}
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+
+			issues, err := listJSONLIssues(cmd.Context(), app)
+			if err != nil {
+				return err
+			}
+			return writeExport(app, output, len(issues), func(w io.Writer) error {
+				return writeJSONL(w, issues)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "", "Export the whole store in this format (jsonl)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write to this file instead of stdout")
+
+	cmd.AddCommand(newExportCSVCmd(provider))
+	cmd.AddCommand(newExportOrgCmd(provider))
+	cmd.AddCommand(newExportTimelineCmd(provider))
+
+	return cmd
+}
+
+// listExportIssues returns the issues with the given statuses, or every
+// issue except deleted ones if statuses is empty, ordered by creation.
+func listExportIssues(ctx context.Context, app *App, statuses []string) ([]*issuestorage.Issue, error) {
+	var filters []*issuestorage.ListFilter
+	if len(statuses) == 0 {
+		filters = []*issuestorage.ListFilter{nil, {Statuses: []issuestorage.Status{issuestorage.StatusClosed}}}
+	} else {
+		filter := &issuestorage.ListFilter{}
+		for _, status := range statuses {
+			s, err := parseStatus(status, getCustomValues(app, "status.custom"))
+			if err != nil {
+				return nil, err
+			}
+			filter.Statuses = append(filter.Statuses, s)
+		}
+		filters = []*issuestorage.ListFilter{filter}
+	}
+
+	var issues []*issuestorage.Issue
+	for _, filter := range filters {
+		found, err := app.Storage.List(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("listing issues: %w", err)
+		}
+		issues = append(issues, found...)
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		if !issues[i].CreatedAt.Equal(issues[j].CreatedAt) {
+			return issues[i].CreatedAt.Before(issues[j].CreatedAt)
+		}
+		return issues[i].ID < issues[j].ID
+	})
+	return issues, nil
+}
+
+// writeExport writes to path, or to app.Out if path is empty, and reports
+// where the export went when it was written to a file.
+func writeExport(app *App, path string, count int, write func(io.Writer) error) error {
+	if path == "" {
+		return write(app.Out)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if app.JSON {
+		return json.NewEncoder(app.Out).Encode(map[string]any{"path": path, "count": count})
+	}
+	fmt.Fprintf(app.Out, "%s Exported %d issue(s) to %s\n", app.SuccessColor("✓"), count, path)
+	return nil
+}
+
+// newExportCSVCmd creates the "export csv" subcommand.
+func newExportCSVCmd(provider *AppProvider) *cobra.Command {
+	var (
+		columns   []string
+		delimiter string
+		statuses  []string
+		output    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "csv",
+		Short: "Export issues as CSV",
+		Long: `Export issues as CSV with a header row, one issue per row.
+
+Columns: id, parent, title, description, type, status, priority, assignee,
+labels, owner, created_by, created_at, updated_at, closed_at, close_reason.
+The default is ` + strings.Join(defaultCSVExportColumns, ",") + `.
+
+All issues except deleted ones are exported unless --status is given. The
+output can be read back with bd import csv.
+
+Examples:
+  bd export csv > issues.csv
+  bd export csv --columns id,title,assignee,priority --status open -o open.csv
+  bd export csv --delimiter ';'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			comma, err := parseDelimiter(delimiter)
+			if err != nil {
+				return err
+			}
+			if len(columns) == 0 {
+				columns = defaultCSVExportColumns
+			}
+			for _, c := range columns {
+				if _, ok := csvExportColumns[c]; !ok {
+					return fmt.Errorf("unknown column %q (valid: %s)", c, strings.Join(sortedKeys(csvExportColumns), ", "))
+				}
+			}
+
+			issues, err := listExportIssues(ctx, app, statuses)
+			if err != nil {
+				return err
+			}
+
+			return writeExport(app, output, len(issues), func(w io.Writer) error {
+				cw := csv.NewWriter(w)
+				cw.Comma = comma
+				if err := cw.Write(columns); err != nil {
+					return err
+				}
+				for _, issue := range issues {
+					record := make([]string, len(columns))
+					for i, c := range columns {
+						record[i] = csvExportColumns[c](issue)
+					}
+					if err := cw.Write(record); err != nil {
+						return err
+					}
+				}
+				cw.Flush()
+				return cw.Error()
+			})
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&columns, "columns", nil, "Columns to export, in order (comma-separated)")
+	cmd.Flags().StringVar(&delimiter, "delimiter", ",", "Field delimiter (a single character, or \"tab\")")
+	cmd.Flags().StringSliceVarP(&statuses, "status", "s", nil, "Only export issues with these statuses (comma-separated)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write to this file instead of stdout")
+
+	return cmd
+}
+
+// orgTodoKeywords is the #+TODO: line written by bd export org, declaring
+// a keyword for each status it writes.
+const orgTodoKeywords = "#+TODO: TODO STARTED WAITING DEFERRED | DONE"
+
+// orgKeyword returns the TODO keyword written for status.
+func orgKeyword(status issuestorage.Status) string {
+	switch status {
+	case issuestorage.StatusClosed:
+		return "DONE"
+	case issuestorage.StatusInProgress, issuestorage.StatusReview:
+		return "STARTED"
+	case issuestorage.StatusBlocked:
+		return "WAITING"
+	case issuestorage.StatusDeferred:
+		return "DEFERRED"
+	}
+	return "TODO"
+}
+
+// orgPriorityCookie returns the priority cookie for p. Org has three
+// levels, so P0 shares [#A] with P1 and P4 shares [#C] with P3.
+func orgPriorityCookie(p issuestorage.Priority) string {
+	switch {
+	case p <= issuestorage.PriorityHigh:
+		return "[#A]"
+	case p == issuestorage.PriorityMedium:
+		return "[#B]"
+	}
+	return "[#C]"
+}
+
+// orgTag replaces characters org-mode doesn't allow in tags.
+func orgTag(label string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_@#%", r) {
+			return r
+		}
+		return '_'
+	}, label)
+}
+
+// orgTimestamp formats t as an org timestamp, with the time of day only if
+// it isn't midnight.
+func orgTimestamp(t time.Time, open, close string) string {
+	t = t.Local()
+	layout := "2006-01-02 Mon"
+	if t.Hour() != 0 || t.Minute() != 0 {
+		layout += " 15:04"
+	}
+	return open + t.Format(layout) + close
+}
+
+// writeOrg writes issues as nested org headlines. Issues whose parent is
+// not among issues are written at the top level.
+func writeOrg(w io.Writer, issues []*issuestorage.Issue) error {
+	included := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		included[issue.ID] = true
+	}
+	children := make(map[string][]*issuestorage.Issue)
+	var roots []*issuestorage.Issue
+	for _, issue := range issues {
+		if issue.Parent != "" && included[issue.Parent] {
+			children[issue.Parent] = append(children[issue.Parent], issue)
+		} else {
+			roots = append(roots, issue)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(orgTodoKeywords + "\n")
+	var write func(issues []*issuestorage.Issue, level int)
+	write = func(issues []*issuestorage.Issue, level int) {
+		indent := strings.Repeat(" ", level+1)
+		for _, issue := range issues {
+			fmt.Fprintf(&b, "%s %s %s %s", strings.Repeat("*", level), orgKeyword(issue.Status), orgPriorityCookie(issue.Priority), issue.Title)
+			if len(issue.Labels) > 0 {
+				tags := make([]string, len(issue.Labels))
+				for i, l := range issue.Labels {
+					tags[i] = orgTag(l)
+				}
+				b.WriteString(" :" + strings.Join(tags, ":") + ":")
+			}
+			b.WriteString("\n")
+
+			var planning []string
+			if issue.ClosedAt != nil {
+				planning = append(planning, "CLOSED: "+orgTimestamp(*issue.ClosedAt, "[", "]"))
+			}
+			if issue.DueAt != nil {
+				planning = append(planning, "DEADLINE: "+orgTimestamp(*issue.DueAt, "<", ">"))
+			}
+			if issue.DeferUntil != nil {
+				planning = append(planning, "SCHEDULED: "+orgTimestamp(*issue.DeferUntil, "<", ">"))
+			}
+			if len(planning) > 0 {
+				b.WriteString(indent + strings.Join(planning, " ") + "\n")
+			}
+
+			b.WriteString(indent + ":PROPERTIES:\n")
+			fmt.Fprintf(&b, "%s:ID: %s\n", indent, issue.ID)
+			fmt.Fprintf(&b, "%s:TYPE: %s\n", indent, issue.Type)
+			if issue.Assignee != "" {
+				fmt.Fprintf(&b, "%s:ASSIGNEE: %s\n", indent, issue.Assignee)
+			}
+			b.WriteString(indent + ":END:\n")
+
+			if issue.Description != "" {
+				for _, line := range strings.Split(issue.Description, "\n") {
+					if line == "" {
+						b.WriteString("\n")
+					} else {
+						b.WriteString(indent + line + "\n")
+					}
+				}
+			}
+			write(children[issue.ID], level+1)
+		}
+	}
+	write(roots, 1)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// newExportOrgCmd creates the "export org" subcommand.
+func newExportOrgCmd(provider *AppProvider) *cobra.Command {
+	var (
+		statuses []string
+		output   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "org",
+		Short: "Export issues as org-mode headlines",
+		Long: `Export issues as an org-mode file of TODO headlines.
+
+Child issues are nested under their parents. Each headline carries a TODO
+keyword for the status (TODO, STARTED, WAITING, DEFERRED or DONE), a
+priority cookie ([#A] for P0-P1, [#B] for P2, [#C] for P3-P4) and the
+labels as tags. Due and scheduled dates become DEADLINE and SCHEDULED
+timestamps, and the ID, type and assignee go in a property drawer.
+
+All issues except deleted ones are exported unless --status is given. The
+output can be read back with bd import org.
+
+Examples:
+  bd export org > issues.org
+  bd export org --status open,in_progress -o ~/org/beads.org`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+
+			issues, err := listExportIssues(cmd.Context(), app, statuses)
+			if err != nil {
+				return err
+			}
+			return writeExport(app, output, len(issues), func(w io.Writer) error {
+				return writeOrg(w, issues)
+			})
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&statuses, "status", "s", nil, "Only export issues with these statuses (comma-separated)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write to this file instead of stdout")
+
+	return cmd
+}
@@ -0,0 +1,315 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/clock"
+	"beads-lite/internal/issueservice"
+	"beads-lite/internal/issuestorage"
+)
+
+// seedExportIssues creates an epic with a closed child and a labelled bug.
+func seedExportIssues(t *testing.T, store *issueservice.IssueStore) (epic, child, bug string) {
+	t.Helper()
+	ctx := context.Background()
+	var err error
+	epic, err = store.Create(ctx, &issuestorage.Issue{Title: "Launch", Type: issuestorage.TypeEpic, Priority: issuestorage.PriorityHigh})
+	if err != nil {
+		t.Fatal(err)
+	}
+	child, err = store.GetNextChildID(ctx, epic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Create(ctx, &issuestorage.Issue{ID: child, Title: "Write copy, briefly", Type: issuestorage.TypeTask, Status: issuestorage.StatusClosed, Priority: issuestorage.PriorityLow}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddDependency(ctx, child, epic, issuestorage.DepTypeParentChild); err != nil {
+		t.Fatal(err)
+	}
+	bug, err = store.Create(ctx, &issuestorage.Issue{Title: "Broken link", Type: issuestorage.TypeBug, Priority: issuestorage.PriorityMedium, Assignee: "alice", Labels: []string{"web", "urgent"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return epic, child, bug
+}
+
+func TestExportCSV(t *testing.T) {
+	app, store := setupTestApp(t)
+	epic, child, bug := seedExportIssues(t, store)
+
+	cmd := newExportCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"csv"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("export csv failed: %v", err)
+	}
+
+	records, err := csv.NewReader(app.Out.(*bytes.Buffer)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	want := [][]string{
+		defaultCSVExportColumns,
+		{epic, "", "Launch", "epic", "open", "P1", "", ""},
+		{child, epic, "Write copy, briefly", "task", "closed", "P3", "", ""},
+		{bug, "", "Broken link", "bug", "open", "P2", "alice", "web,urgent"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("got %d records, want %d: %v", len(records), len(want), records)
+	}
+	for i := range want {
+		if strings.Join(records[i], "|") != strings.Join(want[i], "|") {
+			t.Errorf("record %d = %v, want %v", i, records[i], want[i])
+		}
+	}
+}
+
+func TestExportCSV_ColumnsStatusDelimiterOutput(t *testing.T) {
+	app, store := setupTestApp(t)
+	_, _, bug := seedExportIssues(t, store)
+	path := filepath.Join(t.TempDir(), "open.csv")
+
+	cmd := newExportCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"csv", "--columns", "id,assignee", "--status", "open", "--delimiter", ";", "-o", path})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("export csv failed: %v", err)
+	}
+	if !strings.Contains(app.Out.(*bytes.Buffer).String(), "Exported 2 issue(s)") {
+		t.Errorf("unexpected output: %s", app.Out)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "id;assignee\n") || !strings.Contains(string(data), bug+";alice\n") {
+		t.Errorf("unexpected file contents:\n%s", data)
+	}
+}
+
+func TestExportCSV_UnknownColumn(t *testing.T) {
+	app, _ := setupTestApp(t)
+	cmd := newExportCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"csv", "--columns", "id,nope"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), `unknown column "nope"`) {
+		t.Errorf("expected unknown column error, got %v", err)
+	}
+}
+
+// TestExportImportCSVRoundTrip exports a tracker and imports it into a
+// fresh one, keeping hierarchy, status and fields.
+func TestExportImportCSVRoundTrip(t *testing.T) {
+	src, srcStore := setupTestApp(t)
+	seedExportIssues(t, srcStore)
+	path := filepath.Join(t.TempDir(), "issues.csv")
+	export := newExportCmd(NewTestProvider(src))
+	export.SetArgs([]string{"csv", "-o", path})
+	if err := export.Execute(); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+
+	dst, dstStore := setupTestApp(t)
+	imp := newImportCmd(NewTestProvider(dst))
+	imp.SetArgs([]string{"csv", path})
+	if err := imp.Execute(); err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+
+	issues, err := dstStore.List(context.Background(), &issuestorage.ListFilter{Statuses: []issuestorage.Status{issuestorage.StatusOpen, issuestorage.StatusClosed}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	byTitle := make(map[string]*issuestorage.Issue)
+	for _, issue := range issues {
+		byTitle[issue.Title] = issue
+	}
+	launch, copyIssue, bug := byTitle["Launch"], byTitle["Write copy, briefly"], byTitle["Broken link"]
+	if launch == nil || copyIssue == nil || bug == nil {
+		t.Fatalf("missing imported issues: %v", byTitle)
+	}
+	if launch.Type != issuestorage.TypeEpic || launch.Priority != issuestorage.PriorityHigh {
+		t.Errorf("launch = %+v", launch)
+	}
+	if copyIssue.Parent != launch.ID || copyIssue.Status != issuestorage.StatusClosed {
+		t.Errorf("copy = %+v", copyIssue)
+	}
+	if bug.Type != issuestorage.TypeBug || bug.Assignee != "alice" || strings.Join(bug.Labels, ",") != "web,urgent" {
+		t.Errorf("bug = %+v", bug)
+	}
+}
+
+func TestParseDelimiter(t *testing.T) {
+	for in, want := range map[string]rune{",": ',', ";": ';', "tab": '\t', `\t`: '\t', "|": '|'} {
+		if got, err := parseDelimiter(in); err != nil || got != want {
+			t.Errorf("parseDelimiter(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", ";;", `"`} {
+		if _, err := parseDelimiter(bad); err == nil {
+			t.Errorf("parseDelimiter(%q): expected error", bad)
+		}
+	}
+}
+
+func TestExportOrg(t *testing.T) {
+	app, store := setupTestApp(t)
+	epic, child, bug := seedExportIssues(t, store)
+
+	cmd := newExportCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"org"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("export org failed: %v", err)
+	}
+	out := app.Out.(*bytes.Buffer).String()
+	for _, want := range []string{
+		orgTodoKeywords + "\n",
+		"* TODO [#A] Launch\n  :PROPERTIES:\n  :ID: " + epic + "\n  :TYPE: epic\n  :END:\n",
+		"** DONE [#C] Write copy, briefly\n",
+		"   :ID: " + child + "\n",
+		"* TODO [#B] Broken link :web:urgent:\n",
+		"  :ID: " + bug + "\n  :TYPE: bug\n  :ASSIGNEE: alice\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+// TestExportImportOrgRoundTrip exports a tracker as org and imports it into
+// a fresh one, keeping hierarchy, status, type, assignee and due dates.
+func TestExportImportOrgRoundTrip(t *testing.T) {
+	src, srcStore := setupTestApp(t)
+	_, _, bug := seedExportIssues(t, srcStore)
+	due := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+	if err := srcStore.Modify(context.Background(), bug, func(i *issuestorage.Issue) error {
+		i.DueAt = &due
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "issues.org")
+	export := newExportCmd(NewTestProvider(src))
+	export.SetArgs([]string{"org", "-o", path})
+	if err := export.Execute(); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+
+	dst, dstStore := setupTestApp(t)
+	imp := newImportCmd(NewTestProvider(dst))
+	imp.SetArgs([]string{"org", path})
+	if err := imp.Execute(); err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+
+	issues, err := dstStore.List(context.Background(), &issuestorage.ListFilter{Statuses: []issuestorage.Status{issuestorage.StatusOpen, issuestorage.StatusClosed}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	byTitle := make(map[string]*issuestorage.Issue)
+	for _, issue := range issues {
+		byTitle[issue.Title] = issue
+	}
+	launch, copyIssue, bugIssue := byTitle["Launch"], byTitle["Write copy, briefly"], byTitle["Broken link"]
+	if launch == nil || copyIssue == nil || bugIssue == nil {
+		t.Fatalf("missing imported issues: %v", byTitle)
+	}
+	if launch.Type != issuestorage.TypeEpic || copyIssue.Parent != launch.ID || copyIssue.Status != issuestorage.StatusClosed {
+		t.Errorf("launch = %+v, copy = %+v", launch, copyIssue)
+	}
+	if bugIssue.Type != issuestorage.TypeBug || bugIssue.Assignee != "alice" || bugIssue.DueAt == nil || !bugIssue.DueAt.Equal(due) {
+		t.Errorf("bug = %+v", bugIssue)
+	}
+}
+
+func TestExportTimeline(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2026, 10, 2, 15, 0, 0, 0, time.Local))
+	store.SetClock(fake)
+
+	create := func(title, parent string, edit func(*issuestorage.Issue)) string {
+		t.Helper()
+		id, err := store.Create(ctx, &issuestorage.Issue{Title: title, Type: issuestorage.TypeTask, Priority: issuestorage.PriorityMedium})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parent != "" {
+			if err := store.AddDependency(ctx, id, parent, issuestorage.DepTypeParentChild); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if edit != nil {
+			if err := store.Modify(ctx, id, func(i *issuestorage.Issue) error { edit(i); return nil }); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return id
+	}
+	epic := create("Launch", "", nil)
+	backend := create("Backend", epic, nil)
+	design := create("Design: API", backend, func(i *issuestorage.Issue) { i.Status = issuestorage.StatusClosed })
+	fake.Set(time.Date(2026, 10, 5, 10, 0, 0, 0, time.Local))
+	docs := create("Docs", epic, nil)
+	ship := create("Ship", epic, func(i *issuestorage.Issue) {
+		due := time.Date(2026, 10, 6, 17, 0, 0, 0, time.Local)
+		i.DueAt = &due
+	})
+	build := create("Build", backend, func(i *issuestorage.Issue) {
+		i.Status, i.Estimate, i.TimeSpent = issuestorage.StatusInProgress, 3*minutesPerDay, minutesPerDay/2
+	})
+	for _, dep := range [][2]string{{build, design}, {ship, build}} {
+		if err := store.AddDependency(ctx, dep[0], dep[1], issuestorage.DepTypeBlocks); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	export := func(args ...string) string {
+		t.Helper()
+		out := &bytes.Buffer{}
+		app.Out = out
+		cmd := newExportCmd(NewTestProvider(app))
+		cmd.SetArgs(append([]string{"timeline", epic}, args...))
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("export timeline %v: %v", args, err)
+		}
+		return out.String()
+	}
+
+	want := "gantt\n" +
+		"    title Launch\n" +
+		"    dateFormat YYYY-MM-DD\n" +
+		"    section Launch\n" +
+		"    Docs (" + docs + ") :2026-10-05, 1d\n" +
+		"    Ship (" + ship + ") :crit, 2026-10-08, 1d\n" +
+		"    section Backend\n" +
+		"    Design - API (" + design + ") :done, 2026-10-02, 1d\n" +
+		"    Build (" + build + ") :active, 2026-10-05, 3d\n"
+	if got := export(); got != want {
+		t.Errorf("mermaid-gantt =\n%s\nwant\n%s", got, want)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(export("--format", "csv", "--start", "2026-10-12"))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 5 || !reflect.DeepEqual(records[0], timelineCSVColumns) {
+		t.Fatalf("csv = %v", records)
+	}
+	wantShip := []string{ship, "Ship", "Launch", "open", "", "2026-10-15", "2026-10-15", "1", "2026-10-06", "true", build}
+	if !reflect.DeepEqual(records[4], wantShip) {
+		t.Errorf("ship row = %v, want %v", records[4], wantShip)
+	}
+
+	cmd := newExportCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"timeline", epic, "--format", "svg"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("unknown format should fail")
+	}
+}
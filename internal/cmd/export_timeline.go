@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"beads-lite/internal/graph"
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// Formats bd export timeline writes.
+const (
+	timelineFormatMermaid = "mermaid-gantt"
+	timelineFormatCSV     = "csv"
+)
+
+// timelineCSVColumns is the header bd export timeline --format csv writes.
+var timelineCSVColumns = []string{"id", "title", "section", "status", "assignee", "start", "end", "days", "due", "late", "blocked_by"}
+
+// mermaidTitleReplacer drops the characters that end a task or section
+// name in Mermaid's gantt syntax.
+var mermaidTitleReplacer = strings.NewReplacer(":", " -", ";", ",", "#", "", "\n", " ")
+
+// timelineDays is how many days an issue takes on the timeline: what is
+// left of its estimate for an open issue, its whole estimate for a closed
+// one, in working days of minutesPerDay, rounded up. Issues without an
+// estimate take a day.
+func timelineDays(issue *issuestorage.Issue) int {
+	minutes := issue.RemainingEffort()
+	if issue.Status == issuestorage.StatusClosed {
+		minutes = issue.Estimate
+	}
+	return max((minutes+minutesPerDay-1)/minutesPerDay, 1)
+}
+
+// newExportTimelineCmd creates the "export timeline" subcommand.
+func newExportTimelineCmd(provider *AppProvider) *cobra.Command {
+	var (
+		format string
+		start  string
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "timeline <epic-id>",
+		Short: "Export an epic's schedule as a Gantt chart or CSV",
+		Long: `Export a simple schedule of an epic's work, for a timeline view.
+
+The issues under the epic are laid out day by day in dependency order:
+each starts once the issues blocking it are done, no earlier than today
+(or --start) or its defer date, and takes one day per 8h of remaining
+estimate (a day if it has none). Closed issues end on the day they were
+closed. Issues due before they would end are marked late. Issues with
+children become sections rather than tasks.
+
+Formats:
+  mermaid-gantt  A Mermaid gantt block, for Markdown renderers that draw it
+  csv            One row per issue: ` + strings.Join(timelineCSVColumns, ", ") + `
+
+Examples:
+  bd export timeline bd-a1b2 > timeline.mmd
+  bd export timeline bd-a1b2 --format csv --start 2026-11-02 -o plan.csv`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			if format != timelineFormatMermaid && format != timelineFormatCSV {
+				return fmt.Errorf("unknown timeline format %q (valid: %s, %s)", format, timelineFormatMermaid, timelineFormatCSV)
+			}
+			from := app.Now()
+			if start != "" {
+				if from, err = parseListCreatedTime(start, false); err != nil {
+					return fmt.Errorf("invalid --start value %q: %w", start, err)
+				}
+			}
+
+			root, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+			descendants, err := graph.CollectMoleculeChildren(ctx, app.Storage, root.ID)
+			if err != nil {
+				return err
+			}
+			byID := map[string]*issuestorage.Issue{root.ID: root}
+			for _, issue := range descendants {
+				byID[issue.ID] = issue
+			}
+			var tasks []*issuestorage.Issue
+			for _, issue := range descendants {
+				if issue.Status != issuestorage.StatusTombstone && !hasChildIn(issue, byID) {
+					tasks = append(tasks, issue)
+				}
+			}
+			if len(descendants) == 0 {
+				tasks = []*issuestorage.Issue{root}
+			}
+			ordered, err := graph.TopologicalOrder(tasks)
+			if err != nil {
+				return fmt.Errorf("scheduling %s: %w", root.ID, err)
+			}
+			slots := graph.Timeline(ordered, from, timelineDays)
+
+			return writeExport(app, output, len(slots), func(w io.Writer) error {
+				if format == timelineFormatCSV {
+					return writeTimelineCSV(w, slots, byID)
+				}
+				return writeMermaidGantt(w, root, slots, byID)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", timelineFormatMermaid, "Output format (mermaid-gantt, csv)")
+	cmd.Flags().StringVar(&start, "start", "", "Schedule open work from this date instead of today (YYYY-MM-DD)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write to this file instead of stdout")
+
+	return cmd
+}
+
+// hasChildIn reports whether any of issue's children are in byID.
+func hasChildIn(issue *issuestorage.Issue, byID map[string]*issuestorage.Issue) bool {
+	for _, id := range issue.Children() {
+		if _, ok := byID[id]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// timelineSection names the section a task goes in: its parent's title.
+func timelineSection(issue *issuestorage.Issue, byID map[string]*issuestorage.Issue) string {
+	if parent, ok := byID[issue.Parent]; ok {
+		return parent.Title
+	}
+	return issue.Title
+}
+
+// writeMermaidGantt writes slots as a Mermaid gantt chart with a section
+// per parent, in the order their first tasks are scheduled.
+func writeMermaidGantt(w io.Writer, root *issuestorage.Issue, slots []graph.Slot, byID map[string]*issuestorage.Issue) error {
+	var sections []string
+	bySection := make(map[string][]graph.Slot)
+	for _, s := range slots {
+		name := timelineSection(s.Issue, byID)
+		if _, ok := bySection[name]; !ok {
+			sections = append(sections, name)
+		}
+		bySection[name] = append(bySection[name], s)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "gantt\n    title %s\n    dateFormat YYYY-MM-DD\n", mermaidTitleReplacer.Replace(root.Title))
+	for _, name := range sections {
+		fmt.Fprintf(&b, "    section %s\n", mermaidTitleReplacer.Replace(name))
+		for _, s := range bySection[name] {
+			var tags []string
+			switch s.Issue.Status {
+			case issuestorage.StatusClosed:
+				tags = append(tags, "done")
+			case issuestorage.StatusInProgress:
+				tags = append(tags, "active")
+			}
+			if s.Late {
+				tags = append(tags, "crit")
+			}
+			tags = append(tags, s.Start.Format("2006-01-02"), strconv.Itoa(s.Days())+"d")
+			fmt.Fprintf(&b, "    %s (%s) :%s\n", mermaidTitleReplacer.Replace(s.Issue.Title), s.Issue.ID, strings.Join(tags, ", "))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeTimelineCSV writes slots as CSV rows with timelineCSVColumns. End
+// is the last day of the slot.
+func writeTimelineCSV(w io.Writer, slots []graph.Slot, byID map[string]*issuestorage.Issue) error {
+	blocks := issuestorage.DepTypeBlocks
+	cw := csv.NewWriter(w)
+	if err := cw.Write(timelineCSVColumns); err != nil {
+		return err
+	}
+	for _, s := range slots {
+		due := ""
+		if s.Issue.DueAt != nil {
+			due = s.Issue.DueAt.In(s.Start.Location()).Format("2006-01-02")
+		}
+		late := ""
+		if s.Late {
+			late = "true"
+		}
+		if err := cw.Write([]string{
+			s.Issue.ID,
+			s.Issue.Title,
+			timelineSection(s.Issue, byID),
+			string(s.Issue.Status),
+			s.Issue.Assignee,
+			s.Start.Format("2006-01-02"),
+			s.End.AddDate(0, 0, -1).Format("2006-01-02"),
+			strconv.Itoa(s.Days()),
+			due,
+			late,
+			strings.Join(s.Issue.DependencyIDs(&blocks), ","),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
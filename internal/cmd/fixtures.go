@@ -0,0 +1,360 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// Default distributions for bd fixtures generate, as NAME=WEIGHT lists.
+const (
+	fixtureDefaultStatus   = "open=50,in_progress=15,blocked=5,deferred=5,closed=25"
+	fixtureDefaultPriority = "0=5,1=15,2=45,3=25,4=10"
+	fixtureDefaultType     = "task=55,bug=25,feature=15,chore=5"
+)
+
+// fixtureMaxBlockers caps the blockers given to one generated issue.
+const fixtureMaxBlockers = 3
+
+// FixturesJSON is the JSON output of bd fixtures generate.
+type FixturesJSON struct {
+	Seed         int64          `json:"seed"`
+	Issues       int            `json:"issues"`
+	Epics        int            `json:"epics"`
+	Dependencies int            `json:"dependencies"`
+	ByStatus     map[string]int `json:"by_status"`
+	Elapsed      string         `json:"elapsed"`
+}
+
+// fixtureSpec describes the store bd fixtures generate produces.
+type fixtureSpec struct {
+	issues     int
+	epics      int
+	deps       float64 // chance of each further blocker on an earlier issue
+	inEpic     float64 // share of issues filed under an epic
+	unassigned float64 // share of issues with no assignee
+	days       int     // created times are spread over this many days
+	statuses   weightedChoice[issuestorage.Status]
+	priorities weightedChoice[issuestorage.Priority]
+	types      weightedChoice[issuestorage.IssueType]
+	people     []string
+	labels     []string
+}
+
+// weightedChoice picks values in proportion to their weights.
+type weightedChoice[T any] struct {
+	values  []T
+	weights []int
+	total   int
+}
+
+// pick returns a random value.
+func (w weightedChoice[T]) pick(rng *rand.Rand) T {
+	n := rng.Intn(w.total)
+	for i, weight := range w.weights {
+		if n < weight {
+			return w.values[i]
+		}
+		n -= weight
+	}
+	return w.values[len(w.values)-1]
+}
+
+// parseWeights parses a NAME=WEIGHT,... distribution, converting names with
+// parse. A name without a weight counts once.
+func parseWeights[T any](flag, spec string, parse func(string) (T, error)) (weightedChoice[T], error) {
+	var w weightedChoice[T]
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, weightStr, hasWeight := strings.Cut(part, "=")
+		weight := 1
+		if hasWeight {
+			n, err := strconv.Atoi(strings.TrimSpace(weightStr))
+			if err != nil || n < 0 {
+				return w, fmt.Errorf("--%s: invalid weight %q for %s", flag, weightStr, name)
+			}
+			weight = n
+		}
+		v, err := parse(strings.TrimSpace(name))
+		if err != nil {
+			return w, fmt.Errorf("--%s: %w", flag, err)
+		}
+		w.values = append(w.values, v)
+		w.weights = append(w.weights, weight)
+		w.total += weight
+	}
+	if w.total == 0 {
+		return w, fmt.Errorf("--%s: needs at least one NAME=WEIGHT with a positive weight", flag)
+	}
+	return w, nil
+}
+
+// newFixturesCmd creates the fixtures command with subcommands.
+func newFixturesCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fixtures",
+		Short: "Generate synthetic issue data",
+		Long: `Generate synthetic stores for benchmarking, demos and reproducing
+scale-related bug reports.
+
+Subcommands:
+  generate  Fill the store with realistic random issues`,
+	}
+
+	cmd.AddCommand(newFixturesGenerateCmd(provider))
+
+	return cmd
+}
+
+// newFixturesGenerateCmd creates the "fixtures generate" subcommand.
+func newFixturesGenerateCmd(provider *AppProvider) *cobra.Command {
+	var (
+		spec                               fixtureSpec
+		statusFlag, priorityFlag, typeFlag string
+		seed                               int64
+		force                              bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Fill the store with realistic random issues",
+		Long: `Generate --issues issues plus --epics epics with realistic titles,
+statuses, priorities, types, labels and assignees, created over the last
+--days days. Closed issues get a close time after their creation.
+
+--in-epic of the issues are filed as children of a random epic. Each issue
+is blocked by an earlier one with probability --deps, and by each further
+one with the same probability (up to 3), so dependencies never form a cycle.
+
+Distributions are NAME=WEIGHT lists; weights are relative. The same --seed
+generates the same issues (titles, fields and graph shape), though IDs and
+times relative to now differ between runs.
+
+Generation refuses to mix fixtures into a store that already has issues
+unless --force is given; run it in a fresh bd init.
+
+Examples:
+  bd fixtures generate --issues 5000 --deps 0.3 --epics 50
+  bd fixtures generate --issues 200 --status open=3,closed=1 --seed 42`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			if spec.issues < 0 || spec.epics < 0 || spec.days < 1 {
+				return fmt.Errorf("--issues and --epics must not be negative, and --days must be at least 1")
+			}
+			for name, p := range map[string]float64{"deps": spec.deps, "in-epic": spec.inEpic, "unassigned": spec.unassigned} {
+				if p < 0 || p > 1 {
+					return fmt.Errorf("--%s must be between 0 and 1", name)
+				}
+			}
+			customStatuses := getCustomValues(app, "status.custom")
+			if spec.statuses, err = parseWeights("status", statusFlag, func(s string) (issuestorage.Status, error) {
+				return parseStatus(s, customStatuses)
+			}); err != nil {
+				return err
+			}
+			if spec.priorities, err = parseWeights("priority", priorityFlag, parsePriority); err != nil {
+				return err
+			}
+			customTypes := getCustomValues(app, "types.custom")
+			if spec.types, err = parseWeights("type", typeFlag, func(s string) (issuestorage.IssueType, error) {
+				return parseType(s, customTypes)
+			}); err != nil {
+				return err
+			}
+
+			if !force {
+				existing, err := listExportIssues(ctx, app, nil)
+				if err != nil {
+					return err
+				}
+				if len(existing) > 0 {
+					return fmt.Errorf("the store already has %d issue(s); pass --force to add fixtures anyway", len(existing))
+				}
+			}
+
+			start := time.Now()
+			result, err := generateFixtures(ctx, app, spec, rand.New(rand.NewSource(seed)), start)
+			if err != nil {
+				return err
+			}
+			result.Seed = seed
+			result.Elapsed = time.Since(start).Round(time.Millisecond).String()
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(result)
+			}
+			fmt.Fprintf(app.Out, "%s Generated %d issues (%d epics) with %d dependencies in %s (seed %d)\n",
+				app.SuccessColor("✓"), result.Issues+result.Epics, result.Epics, result.Dependencies, result.Elapsed, seed)
+			for _, status := range sortedKeys(result.ByStatus) {
+				fmt.Fprintf(app.Out, "  %s: %d\n", status, result.ByStatus[status])
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&spec.issues, "issues", 100, "Number of issues to generate, not counting epics")
+	cmd.Flags().IntVar(&spec.epics, "epics", 0, "Number of epics to generate")
+	cmd.Flags().Float64Var(&spec.deps, "deps", 0.3, "Chance (0-1) that an issue is blocked by an earlier one, and of each further blocker")
+	cmd.Flags().Float64Var(&spec.inEpic, "in-epic", 0.6, "Share (0-1) of issues filed under an epic, when there are epics")
+	cmd.Flags().Float64Var(&spec.unassigned, "unassigned", 0.3, "Share (0-1) of issues left unassigned")
+	cmd.Flags().IntVar(&spec.days, "days", 90, "Spread creation times over this many past days")
+	cmd.Flags().StringVar(&statusFlag, "status", fixtureDefaultStatus, "Status distribution as NAME=WEIGHT,...")
+	cmd.Flags().StringVar(&priorityFlag, "priority", fixtureDefaultPriority, "Priority distribution as NAME=WEIGHT,...")
+	cmd.Flags().StringVar(&typeFlag, "type", fixtureDefaultType, "Type distribution as NAME=WEIGHT,...")
+	cmd.Flags().StringSliceVar(&spec.people, "assignees", []string{"alice", "bob", "carol", "dave", "erin"}, "People to assign issues to")
+	cmd.Flags().StringSliceVar(&spec.labels, "labels", []string{"backend", "frontend", "api", "docs", "infra"}, "Labels to draw from (up to two per issue)")
+	cmd.Flags().Int64Var(&seed, "seed", 1, "Random seed")
+	cmd.Flags().BoolVar(&force, "force", false, "Add fixtures to a store that already has issues")
+
+	return cmd
+}
+
+// Words fixture titles are built from, by issue type.
+var (
+	fixtureVerbs = map[issuestorage.IssueType][]string{
+		issuestorage.TypeBug:     {"Fix", "Investigate", "Handle", "Stop"},
+		issuestorage.TypeFeature: {"Add", "Support", "Implement", "Allow"},
+		issuestorage.TypeChore:   {"Upgrade", "Clean up", "Remove", "Rename"},
+		issuestorage.TypeEpic:    {"Overhaul", "Launch", "Rebuild", "Migrate"},
+	}
+	fixtureDefaultVerbs = []string{"Update", "Refactor", "Document", "Test"}
+	fixtureSubjects     = []string{
+		"login flow", "search API", "export job", "settings page", "billing webhook",
+		"rate limiter", "audit log", "onboarding email", "dashboard charts", "CSV import",
+		"session cache", "retry queue", "permissions check", "mobile layout", "release pipeline",
+	}
+	fixtureQualifiers = []string{
+		"", "", "", " for large accounts", " on slow networks", " after timeout",
+		" in dark mode", " behind feature flag", " for admins", " under load",
+	}
+)
+
+// fixtureTitle returns a random title for an issue of type t.
+func fixtureTitle(rng *rand.Rand, t issuestorage.IssueType) string {
+	verbs, ok := fixtureVerbs[t]
+	if !ok {
+		verbs = fixtureDefaultVerbs
+	}
+	return verbs[rng.Intn(len(verbs))] + " " +
+		fixtureSubjects[rng.Intn(len(fixtureSubjects))] +
+		fixtureQualifiers[rng.Intn(len(fixtureQualifiers))]
+}
+
+// generateFixtures creates the epics and issues described by spec, with
+// times before now.
+func generateFixtures(ctx context.Context, app *App, spec fixtureSpec, rng *rand.Rand, now time.Time) (*FixturesJSON, error) {
+	result := &FixturesJSON{ByStatus: make(map[string]int)}
+	opts := issuestorage.CreateOpts{UseCachedCount: true, KeepTimestamps: true, PrioritySet: true}
+	span := time.Duration(spec.days) * 24 * time.Hour
+
+	// newIssue fills the fields shared by epics and issues.
+	newIssue := func(t issuestorage.IssueType, status issuestorage.Status) *issuestorage.Issue {
+		created := now.Add(-time.Duration(rng.Int63n(int64(span))))
+		issue := &issuestorage.Issue{
+			Title:     fixtureTitle(rng, t),
+			Type:      t,
+			Status:    status,
+			Priority:  spec.priorities.pick(rng),
+			CreatedAt: created,
+			UpdatedAt: created.Add(time.Duration(rng.Int63n(int64(now.Sub(created)) + 1))),
+		}
+		if len(spec.people) > 0 {
+			issue.CreatedBy = spec.people[rng.Intn(len(spec.people))]
+			if rng.Float64() >= spec.unassigned {
+				issue.Assignee = spec.people[rng.Intn(len(spec.people))]
+			}
+		}
+		for _, i := range rng.Perm(len(spec.labels))[:min(len(spec.labels), rng.Intn(3))] {
+			issue.Labels = append(issue.Labels, spec.labels[i])
+		}
+		sort.Strings(issue.Labels)
+		if status == issuestorage.StatusClosed {
+			closed := issue.UpdatedAt
+			issue.ClosedAt = &closed
+			issue.CloseReason = "Closed"
+		}
+		return issue
+	}
+
+	epics := make([]string, 0, spec.epics)
+	for i := 0; i < spec.epics; i++ {
+		status := issuestorage.StatusOpen
+		if rng.Intn(3) == 0 {
+			status = issuestorage.StatusInProgress
+		}
+		id, err := app.Storage.Create(ctx, newIssue(issuestorage.TypeEpic, status), opts)
+		if err != nil {
+			return nil, fmt.Errorf("creating epic: %w", err)
+		}
+		epics = append(epics, id)
+		result.Epics++
+		result.ByStatus[string(status)]++
+	}
+
+	// Each issue is created with its parent and blockers already set;
+	// blockers are always earlier issues, so the graph stays acyclic. The
+	// other side of each link is written afterwards with one Modify per
+	// target, which gives epics and blockers a recent update time.
+	ids := make([]string, 0, spec.issues)
+	dependents := make(map[string][]issuestorage.Dependency)
+	for i := 0; i < spec.issues; i++ {
+		status := spec.statuses.pick(rng)
+		issue := newIssue(spec.types.pick(rng), status)
+		if len(epics) > 0 && rng.Float64() < spec.inEpic {
+			parent := epics[rng.Intn(len(epics))]
+			childID, err := app.Storage.GetNextChildID(ctx, parent)
+			if err != nil {
+				return nil, fmt.Errorf("generating child ID for %s: %w", parent, err)
+			}
+			issue.ID = childID
+			issue.Parent = parent
+			issue.Dependencies = append(issue.Dependencies, issuestorage.Dependency{ID: parent, Type: issuestorage.DepTypeParentChild})
+		}
+		for blockers := 0; len(ids) > 0 && blockers < fixtureMaxBlockers && rng.Float64() < spec.deps; blockers++ {
+			blocker := ids[rng.Intn(len(ids))]
+			if issue.HasDependency(blocker) {
+				break
+			}
+			issue.Dependencies = append(issue.Dependencies, issuestorage.Dependency{ID: blocker, Type: issuestorage.DepTypeBlocks})
+			result.Dependencies++
+		}
+		id, err := app.Storage.Create(ctx, issue, opts)
+		if err != nil {
+			return nil, fmt.Errorf("creating issue: %w", err)
+		}
+		for _, dep := range issue.Dependencies {
+			dependents[dep.ID] = append(dependents[dep.ID], issuestorage.Dependency{ID: id, Type: dep.Type})
+		}
+		ids = append(ids, id)
+		result.Issues++
+		result.ByStatus[string(status)]++
+	}
+
+	for _, target := range sortedKeys(dependents) {
+		if err := app.Storage.Modify(ctx, target, func(issue *issuestorage.Issue) error {
+			issue.Dependents = append(issue.Dependents, dependents[target]...)
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("linking dependents of %s: %w", target, err)
+		}
+	}
+	return result, nil
+}
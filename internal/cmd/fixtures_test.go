@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// runFixtures runs bd fixtures generate with args and returns the result.
+func runFixtures(t *testing.T, app *App, args ...string) FixturesJSON {
+	t.Helper()
+	app.JSON = true
+	app.Out.(*bytes.Buffer).Reset()
+	cmd := newFixturesCmd(NewTestProvider(app))
+	cmd.SetArgs(append([]string{"generate"}, args...))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("fixtures generate: %v", err)
+	}
+	var out FixturesJSON
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	return out
+}
+
+func TestFixturesGenerate(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+
+	out := runFixtures(t, app, "--issues", "60", "--epics", "4", "--deps", "0.5", "--days", "30")
+	if out.Issues != 60 || out.Epics != 4 || out.Seed != 1 {
+		t.Fatalf("result = %+v, want 60 issues, 4 epics, seed 1", out)
+	}
+	if out.Dependencies == 0 {
+		t.Error("expected some dependencies with --deps 0.5")
+	}
+
+	all, err := listExportIssues(ctx, app, nil)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(all) != 64 {
+		t.Fatalf("stored %d issues, want 64", len(all))
+	}
+	byID := make(map[string]*issuestorage.Issue)
+	for _, issue := range all {
+		byID[issue.ID] = issue
+	}
+	epics, children, blocks := 0, 0, 0
+	cutoff := time.Now().AddDate(0, 0, -30)
+	for _, issue := range all {
+		if issue.Type == issuestorage.TypeEpic {
+			epics++
+		}
+		if issue.CreatedAt.Before(cutoff) || issue.CreatedAt.After(time.Now()) {
+			t.Errorf("%s created %v, outside the last 30 days", issue.ID, issue.CreatedAt)
+		}
+		if (issue.ClosedAt != nil) != (issue.Status == issuestorage.StatusClosed) {
+			t.Errorf("%s: status %s with closed_at %v", issue.ID, issue.Status, issue.ClosedAt)
+		}
+		for _, dep := range issue.Dependencies {
+			target, _ := store.Get(ctx, dep.ID)
+			if target == nil || !slices.Contains(target.Dependents, issuestorage.Dependency{ID: issue.ID, Type: dep.Type}) {
+				t.Errorf("%s depends on %s (%s) without the matching dependent", issue.ID, dep.ID, dep.Type)
+				continue
+			}
+			switch dep.Type {
+			case issuestorage.DepTypeParentChild:
+				children++
+				if issue.Parent != dep.ID || target.Type != issuestorage.TypeEpic {
+					t.Errorf("%s: parent %q, want epic %s", issue.ID, issue.Parent, dep.ID)
+				}
+			case issuestorage.DepTypeBlocks:
+				blocks++
+				if byID[dep.ID] == nil {
+					t.Errorf("%s blocked by unknown issue %s", issue.ID, dep.ID)
+				}
+			}
+		}
+	}
+	if epics != 4 || children == 0 {
+		t.Errorf("epics = %d, children = %d; want 4 epics with children", epics, children)
+	}
+	if blocks != out.Dependencies {
+		t.Errorf("blocks dependencies = %d, reported %d", blocks, out.Dependencies)
+	}
+}
+
+func TestFixturesGenerate_SameSeedSameIssues(t *testing.T) {
+	titles := func(seed string) []string {
+		app, _ := setupTestApp(t)
+		runFixtures(t, app, "--issues", "20", "--epics", "2", "--seed", seed)
+		all, err := listExportIssues(context.Background(), app, nil)
+		if err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		var out []string
+		for _, issue := range all {
+			out = append(out, string(issue.Status)+" "+issue.Title+" @"+issue.Assignee)
+		}
+		sort.Strings(out)
+		return out
+	}
+	if a, b := titles("7"), titles("7"); !slices.Equal(a, b) {
+		t.Errorf("seed 7 generated different issues:\n%v\n%v", a, b)
+	}
+	if a, b := titles("7"), titles("8"); slices.Equal(a, b) {
+		t.Error("seeds 7 and 8 generated the same issues")
+	}
+}
+
+func TestFixturesGenerate_Distribution(t *testing.T) {
+	app, _ := setupTestApp(t)
+	out := runFixtures(t, app, "--issues", "30", "--status", "closed", "--type", "bug=1,feature=0", "--deps", "0")
+	if out.ByStatus["closed"] != 30 || len(out.ByStatus) != 1 {
+		t.Errorf("by_status = %v, want all 30 closed", out.ByStatus)
+	}
+	if out.Dependencies != 0 {
+		t.Errorf("dependencies = %d, want 0 with --deps 0", out.Dependencies)
+	}
+	all, _ := listExportIssues(context.Background(), app, nil)
+	for _, issue := range all {
+		if issue.Type != issuestorage.TypeBug {
+			t.Errorf("%s has type %s, want bug", issue.ID, issue.Type)
+		}
+	}
+}
+
+func TestFixturesGenerate_Errors(t *testing.T) {
+	app, store := setupTestApp(t)
+	createAssigned(t, store, "existing", "", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--issues", "5"}, "--force"},
+		{[]string{"--force", "--status", "open=x"}, "invalid weight"},
+		{[]string{"--force", "--status", "nope"}, "--status"},
+		{[]string{"--force", "--priority", "2=0"}, "positive weight"},
+		{[]string{"--force", "--deps", "1.5"}, "--deps must be between 0 and 1"},
+	} {
+		cmd := newFixturesCmd(NewTestProvider(app))
+		cmd.SetArgs(append([]string{"generate"}, tc.args...))
+		err := cmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("generate %v: got %v, want error containing %q", tc.args, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// FlappyIssueJSON is the JSON output format for an issue in bd flappy.
+// Reopen counts are reported here and by bd show rather than in the issue
+// JSON, which keeps the shape of the original beads output.
+type FlappyIssueJSON struct {
+	ID             string         `json:"id"`
+	Title          string         `json:"title"`
+	Status         string         `json:"status"`
+	ReopenCount    int            `json:"reopen_count"`
+	ReopenedBy     map[string]int `json:"reopened_by,omitempty"`
+	LastReopenedAt string         `json:"last_reopened_at,omitempty"`
+	LastReopenedBy string         `json:"last_reopened_by,omitempty"`
+}
+
+// newFlappyCmd creates the flappy command.
+func newFlappyCmd(provider *AppProvider) *cobra.Command {
+	var (
+		limit    int
+		minCount int
+		since    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "flappy",
+		Short: "List the most reopened issues",
+		Long: `List issues by how often they have been reopened, most first, to spot
+chronic regressions and premature closures.
+
+Every transition out of closed counts as a reopen and is recorded in the
+issue's history with the actor. With --since, only reopens in that window
+are counted.
+
+Examples:
+  bd flappy
+  bd flappy --min 3
+  bd flappy --since 30d --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+
+			var cutoff time.Time
+			if since != "" {
+				window, err := parseDuration(since)
+				if err != nil {
+					return fmt.Errorf("invalid --since value %q: %w", since, err)
+				}
+				cutoff = app.Now().Add(-window)
+			}
+
+			issues, err := listExportIssues(cmd.Context(), app, nil)
+			if err != nil {
+				return err
+			}
+			var flappy []FlappyIssueJSON
+			lastAt := make(map[string]time.Time)
+			for _, issue := range issues {
+				entry, last := toFlappyJSON(issue, cutoff)
+				if entry.ReopenCount >= minCount && entry.ReopenCount > 0 {
+					flappy = append(flappy, entry)
+					lastAt[entry.ID] = last
+				}
+			}
+			sort.SliceStable(flappy, func(i, j int) bool {
+				if flappy[i].ReopenCount != flappy[j].ReopenCount {
+					return flappy[i].ReopenCount > flappy[j].ReopenCount
+				}
+				return lastAt[flappy[i].ID].After(lastAt[flappy[j].ID])
+			})
+			if limit > 0 && len(flappy) > limit {
+				flappy = flappy[:limit]
+			}
+
+			if app.JSON {
+				if flappy == nil {
+					flappy = []FlappyIssueJSON{}
+				}
+				return json.NewEncoder(app.Out).Encode(flappy)
+			}
+			if len(flappy) == 0 {
+				fmt.Fprintln(app.Out, "No reopened issues found.")
+				return nil
+			}
+			for _, f := range flappy {
+				fmt.Fprintf(app.Out, "%3d× %s [%s] %s\n", f.ReopenCount, f.ID, f.Status, f.Title)
+				if last := lastAt[f.ID]; !last.IsZero() {
+					fmt.Fprintf(app.Out, "     last reopened %s by %s\n", last.Format("2006-01-02"), actorOrUnknown(f.LastReopenedBy))
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 10, "Maximum number of issues to show (0 for all)")
+	cmd.Flags().IntVar(&minCount, "min", 1, "Only show issues reopened at least this many times")
+	cmd.Flags().StringVar(&since, "since", "", "Only count reopens newer than this (e.g. 30d, 12w)")
+
+	return cmd
+}
+
+// toFlappyJSON summarizes an issue's reopens after cutoff (all of them if
+// cutoff is zero), also returning when it was last reopened.
+func toFlappyJSON(issue *issuestorage.Issue, cutoff time.Time) (FlappyIssueJSON, time.Time) {
+	out := FlappyIssueJSON{ID: issue.ID, Title: issue.Title, Status: string(issue.Status)}
+	var last *issuestorage.HistoryEntry
+	for i, h := range issue.History {
+		if h.Event != issuestorage.EventReopened || h.At.Before(cutoff) {
+			continue
+		}
+		if out.ReopenedBy == nil {
+			out.ReopenedBy = make(map[string]int)
+		}
+		out.ReopenedBy[actorOrUnknown(h.Actor)]++
+		out.ReopenCount++
+		last = &issue.History[i]
+	}
+	// Reopens from before they were recorded in history still count
+	// towards the all-time total.
+	if cutoff.IsZero() && issue.ReopenCount > out.ReopenCount {
+		out.ReopenCount = issue.ReopenCount
+	}
+	if last == nil {
+		return out, time.Time{}
+	}
+	out.LastReopenedAt = formatTime(last.At)
+	out.LastReopenedBy = last.Actor
+	return out, last.At
+}
+
+// formatReopens describes an issue's reopen count for bd show.
+func formatReopens(issue *issuestorage.Issue) string {
+	f, last := toFlappyJSON(issue, time.Time{})
+	s := fmt.Sprintf("Reopened: %d time(s)", issue.ReopenCount)
+	if !last.IsZero() {
+		s += fmt.Sprintf(" · last by %s on %s", actorOrUnknown(f.LastReopenedBy), last.Format("2006-01-02"))
+	}
+	return s
+}
+
+// actorOrUnknown returns actor, or "unknown" if it is empty.
+func actorOrUnknown(actor string) string {
+	if actor == "" {
+		return "unknown"
+	}
+	return actor
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// reopenEntry returns a reopen history entry by actor at the given time.
+func reopenEntry(actor string, at time.Time) issuestorage.HistoryEntry {
+	return issuestorage.HistoryEntry{At: at, Actor: actor, Event: issuestorage.EventReopened, Field: "status", Old: "closed", New: "open"}
+}
+
+func TestFlappyCmd(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	create := func(title string, history ...issuestorage.HistoryEntry) string {
+		t.Helper()
+		id, err := store.Create(ctx, &issuestorage.Issue{Title: title, Priority: issuestorage.PriorityMedium, Type: issuestorage.TypeBug})
+		if err != nil {
+			t.Fatalf("create: %v", err)
+		}
+		if err := store.Modify(ctx, id, func(i *issuestorage.Issue) error {
+			i.History = append(i.History, history...)
+			i.ReopenCount = len(history)
+			return nil
+		}); err != nil {
+			t.Fatalf("modify: %v", err)
+		}
+		return id
+	}
+	old := create("Old flake", reopenEntry("bob", now.AddDate(0, 0, -60)), reopenEntry("bob", now.AddDate(0, 0, -50)))
+	recent := create("Recent flake", reopenEntry("alice", now.AddDate(0, 0, -3)), reopenEntry("bob", now.AddDate(0, 0, -1)))
+	worst := create("Worst flake", reopenEntry("alice", now.AddDate(0, 0, -40)), reopenEntry("alice", now.AddDate(0, 0, -20)), reopenEntry("carol", now.AddDate(0, 0, -2)))
+	create("Stable")
+
+	run := func(args ...string) []FlappyIssueJSON {
+		t.Helper()
+		app.JSON = true
+		out := app.Out.(*bytes.Buffer)
+		out.Reset()
+		cmd := newFlappyCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("flappy %v: %v", args, err)
+		}
+		var got []FlappyIssueJSON
+		if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+			t.Fatalf("unmarshal: %v\n%s", err, out.String())
+		}
+		return got
+	}
+	ids := func(got []FlappyIssueJSON) string {
+		var s []string
+		for _, f := range got {
+			s = append(s, f.ID)
+		}
+		return strings.Join(s, ",")
+	}
+
+	// Most reopens first; ties go to the most recently reopened.
+	got := run()
+	if want := strings.Join([]string{worst, recent, old}, ","); ids(got) != want {
+		t.Errorf("order = %s, want %s", ids(got), want)
+	}
+	if got[0].ReopenCount != 3 || got[0].ReopenedBy["alice"] != 2 || got[0].LastReopenedBy != "carol" {
+		t.Errorf("worst = %+v, want 3 reopens, 2 by alice, last by carol", got[0])
+	}
+
+	if got := run("--min", "3"); ids(got) != worst {
+		t.Errorf("--min 3 = %s, want %s", ids(got), worst)
+	}
+	if got := run("--limit", "1"); ids(got) != worst {
+		t.Errorf("--limit 1 = %s, want %s", ids(got), worst)
+	}
+
+	// Only reopens within the window count.
+	got = run("--since", "30d")
+	if want := strings.Join([]string{recent, worst}, ","); ids(got) != want {
+		t.Errorf("--since 30d = %s, want %s", ids(got), want)
+	}
+
+	app.JSON = false
+	out := app.Out.(*bytes.Buffer)
+	out.Reset()
+	cmd := newFlappyCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--min", "3"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("flappy: %v", err)
+	}
+	if !strings.Contains(out.String(), "3× "+worst) || !strings.Contains(out.String(), "by carol") {
+		t.Errorf("text output = %q", out.String())
+	}
+}
+
+func TestFlappyCmd_None(t *testing.T) {
+	app, _ := setupTestApp(t)
+	cmd := newFlappyCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("flappy: %v", err)
+	}
+	if got := app.Out.(*bytes.Buffer).String(); !strings.Contains(got, "No reopened issues") {
+		t.Errorf("output = %q", got)
+	}
+}
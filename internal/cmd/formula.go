@@ -24,18 +24,24 @@ func newFormulaCmd(provider *AppProvider) *cobra.Command {
 
 Formulas are file-based templates (YAML/JSON/TOML) searched in priority order:
   1. <configDir>/formulas/     (project-level)
-  2. ~/.beads/formulas/        (user-level)
-  3. $GT_ROOT/.beads/formulas/ (orchestrator-level)
+  2. directories in formula.paths, relative to the project root
+     (e.g. a git submodule of shared formulas)
+  3. ~/.beads/formulas/        (user-level)
+  4. $GT_ROOT/.beads/formulas/ (orchestrator-level)
 
 Subcommands:
   list      List available formulas from all search paths
   show      Show formula details, steps, and composition rules
-  convert   Convert formula between JSON and TOML formats`,
+  convert   Convert formula between JSON and TOML formats
+  install   Install a formula from a URL or file, pinned to its checksum
+  update    Fetch installed formulas again and check them against their pins`,
 	}
 
 	cmd.AddCommand(newFormulaListCmd(provider))
 	cmd.AddCommand(newFormulaShowCmd(provider))
 	cmd.AddCommand(newFormulaConvertCmd(provider))
+	cmd.AddCommand(newFormulaInstallCmd(provider))
+	cmd.AddCommand(newFormulaUpdateCmd(provider))
 
 	return cmd
 }
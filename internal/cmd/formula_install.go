@@ -0,0 +1,457 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"beads-lite/internal/config"
+	"beads-lite/internal/meow"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/cobra"
+)
+
+// formulaPathsKey lists extra formula directories, comma-separated and
+// relative to the project root, searched after .beads/formulas/: e.g. a
+// git submodule of templates shared between repositories.
+const formulaPathsKey = "formula.paths"
+
+// formulaSourcesFile records, in a formula directory, where each installed
+// formula came from and the checksum it is pinned to. It is committed with
+// the formulas so every clone updates from the same sources.
+const formulaSourcesFile = "sources.json"
+
+// formulaFetchTimeout and formulaMaxSize bound a formula download.
+const (
+	formulaFetchTimeout = 30 * time.Second
+	formulaMaxSize      = 1 << 20
+)
+
+// FormulaInstallJSON is the JSON output of bd formula install, and of each
+// formula bd formula update checks.
+type FormulaInstallJSON struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	SHA256 string `json:"sha256"` // the checksum the formula is pinned to
+	Path   string `json:"path"`
+	// Status is "installed", "updated", "unchanged", "restored" (the local
+	// file had been edited) or "changed" (upstream differs from the pin
+	// and the update was refused).
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// formulaSource is an entry of sources.json.
+type formulaSource struct {
+	Source string `json:"source"`
+	SHA256 string `json:"sha256"`
+	File   string `json:"file"`
+}
+
+// formulaSearchPath returns the formula search path: .beads/formulas/,
+// then the directories of formula.paths, then the user and orchestrator
+// levels.
+func formulaSearchPath(configDir string, store config.Store) meow.FormulaSearchPath {
+	path := meow.DefaultSearchPath(configDir)
+	v, _ := store.Get(formulaPathsKey)
+	var shared meow.FormulaSearchPath
+	for _, dir := range config.SplitCustomValues(v) {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(filepath.Dir(configDir), dir)
+		}
+		shared = append(shared, dir)
+	}
+	result := append(meow.FormulaSearchPath{path[0]}, shared...)
+	return append(result, path[1:]...)
+}
+
+// newFormulaInstallCmd creates the "formula install" subcommand.
+func newFormulaInstallCmd(provider *AppProvider) *cobra.Command {
+	var (
+		name   string
+		format string
+		pin    string
+		user   bool
+		force  bool
+	)
+	cmd := &cobra.Command{
+		Use:   "install <url-or-file>",
+		Short: "Install a formula from a URL or file, pinned to its checksum",
+		Long: `Install a formula from an https URL or a local file into
+.beads/formulas/ (or ~/.beads/formulas/ with --user), so an organization
+can publish standard templates once and share them across repositories.
+
+The formula is checked to parse before it is written. Its source and
+SHA-256 checksum are recorded in formulas/sources.json, which is meant to
+be committed: bd formula update fetches every source again and only takes
+content whose checksum matches the pin. Pass --sha256 to pin a checksum
+published alongside the formula instead of trusting the first download.
+
+The name and format come from the file name (deploy.formula.toml,
+deploy.toml or deploy.json) unless --name or --format is given. Formulas
+are JSON or TOML.
+
+Examples:
+  bd formula install https://example.com/templates/bug-triage.formula.toml
+  bd formula install https://example.com/release.json --sha256 9f86d08...
+  bd formula install ../shared/deploy.formula.json --user`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			source := args[0]
+			if !isURL(source) {
+				if source, err = filepath.Abs(source); err != nil {
+					return err
+				}
+			}
+			if name == "" {
+				name = formulaNameFromSource(source)
+			}
+			if format == "" {
+				format = formulaFormatFromSource(source)
+			}
+			if err := checkFormulaName(name); err != nil {
+				return err
+			}
+			if format != "json" && format != "toml" {
+				return fmt.Errorf("cannot tell the format of %s: formulas are JSON or TOML (use --format)", args[0])
+			}
+
+			dir, err := formulaInstallDir(app, user)
+			if err != nil {
+				return err
+			}
+			sources, err := readFormulaSources(dir)
+			if err != nil {
+				return err
+			}
+			if prev, ok := sources[name]; !force && (!ok || prev.Source != source) {
+				for _, ext := range []string{".formula.json", ".formula.toml"} {
+					if _, err := os.Stat(filepath.Join(dir, name+ext)); err == nil {
+						return fmt.Errorf("formula %s already exists in %s (use --force to replace it)", name, dir)
+					}
+				}
+			}
+
+			data, err := fetchFormula(cmd.Context(), source)
+			if err != nil {
+				return err
+			}
+			sum := sha256Hex(data)
+			if pin != "" {
+				if want := strings.ToLower(strings.TrimPrefix(pin, "sha256:")); sum != want {
+					return fmt.Errorf("checksum mismatch for %s: got %s, want %s", args[0], sum, want)
+				}
+			}
+			if err := parseFormulaData(data, format); err != nil {
+				return fmt.Errorf("%s is not a valid formula: %w", args[0], err)
+			}
+
+			file := name + ".formula." + format
+			if err := installFormulaFile(dir, file, data); err != nil {
+				return err
+			}
+			sources[name] = formulaSource{Source: source, SHA256: sum, File: file}
+			if err := writeFormulaSources(dir, sources); err != nil {
+				return err
+			}
+
+			result := FormulaInstallJSON{Name: name, Source: source, SHA256: sum, Path: filepath.Join(dir, file), Status: "installed"}
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(result)
+			}
+			fmt.Fprintf(app.Out, "%s Installed formula %s → %s\n", app.SuccessColor("✓"), name, result.Path)
+			fmt.Fprintf(app.Out, "  sha256: %s\n", sum)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&name, "name", "", "Formula name (default: from the file name)")
+	cmd.Flags().StringVar(&format, "format", "", "Formula format, json or toml (default: from the file name)")
+	cmd.Flags().StringVar(&pin, "sha256", "", "Refuse the download unless its SHA-256 checksum is this")
+	cmd.Flags().BoolVar(&user, "user", false, "Install into ~/.beads/formulas/ instead of the project")
+	cmd.Flags().BoolVar(&force, "force", false, "Replace a formula of the same name from another source")
+	return cmd
+}
+
+// newFormulaUpdateCmd creates the "formula update" subcommand.
+func newFormulaUpdateCmd(provider *AppProvider) *cobra.Command {
+	var user, accept bool
+	cmd := &cobra.Command{
+		Use:   "update [name...]",
+		Short: "Fetch installed formulas again and check them against their pins",
+		Long: `Fetch the source of each installed formula (all, or those named) and
+compare it with the checksum pinned in formulas/sources.json.
+
+A formula whose source still matches its pin is left alone, or restored
+if its local file was edited. One whose source changed is refused, since
+a pinned template should not change under its users, unless --accept is
+given: then the new content is installed and pinned.
+
+Examples:
+  bd formula update
+  bd formula update bug-triage --accept`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			dir, err := formulaInstallDir(app, user)
+			if err != nil {
+				return err
+			}
+			sources, err := readFormulaSources(dir)
+			if err != nil {
+				return err
+			}
+			names := args
+			if len(names) == 0 {
+				for name := range sources {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+			}
+
+			results := []FormulaInstallJSON{}
+			failed := 0
+			for _, name := range names {
+				r := updateFormula(cmd.Context(), dir, name, sources, accept)
+				if r.Error != "" {
+					failed++
+				}
+				results = append(results, r)
+			}
+			if err := writeFormulaSources(dir, sources); err != nil {
+				return err
+			}
+
+			if app.JSON {
+				if err := json.NewEncoder(app.Out).Encode(results); err != nil {
+					return err
+				}
+			} else if len(results) == 0 {
+				fmt.Fprintf(app.Out, "No formulas installed from a source in %s.\n", dir)
+			} else {
+				for _, r := range results {
+					switch {
+					case r.Error != "":
+						fmt.Fprintf(app.Out, "%s %s: %s\n", app.Colorize("✗", "31"), r.Name, r.Error)
+					case r.Status == "unchanged":
+						fmt.Fprintf(app.Out, "  %s is up to date\n", r.Name)
+					default:
+						fmt.Fprintf(app.Out, "%s %s %s (sha256 %s)\n", app.SuccessColor("✓"), r.Name, r.Status, r.SHA256)
+					}
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d formulas could not be updated", failed, len(results))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&user, "user", false, "Update ~/.beads/formulas/ instead of the project")
+	cmd.Flags().BoolVar(&accept, "accept", false, "Install and pin sources whose content changed")
+	return cmd
+}
+
+// updateFormula fetches one installed formula's source again, updating
+// sources when it installs new content.
+func updateFormula(ctx context.Context, dir, name string, sources map[string]formulaSource, accept bool) FormulaInstallJSON {
+	src, ok := sources[name]
+	r := FormulaInstallJSON{Name: name, Source: src.Source, SHA256: src.SHA256, Path: filepath.Join(dir, src.File)}
+	if !ok {
+		r.Error = "not installed from a source (see bd formula install)"
+		return r
+	}
+	data, err := fetchFormula(ctx, src.Source)
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	sum := sha256Hex(data)
+	if sum != src.SHA256 && !accept {
+		r.Status = "changed"
+		r.Error = fmt.Sprintf("source changed (sha256 %s, pinned %s); rerun with --accept to take it", sum, src.SHA256)
+		return r
+	}
+	if err := parseFormulaData(data, strings.TrimPrefix(filepath.Ext(src.File), ".")); err != nil {
+		r.Error = fmt.Sprintf("source is not a valid formula: %v", err)
+		return r
+	}
+
+	local, err := os.ReadFile(r.Path)
+	switch {
+	case sum != src.SHA256:
+		r.Status = "updated"
+	case err != nil || sha256Hex(local) != sum:
+		r.Status = "restored"
+	default:
+		r.Status = "unchanged"
+		return r
+	}
+	if err := installFormulaFile(dir, src.File, data); err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	src.SHA256 = sum
+	sources[name] = src
+	r.SHA256 = sum
+	return r
+}
+
+// formulaInstallDir returns the directory formulas are installed into.
+func formulaInstallDir(app *App, user bool) (string, error) {
+	if !user {
+		return filepath.Join(app.ConfigDir, "formulas"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".beads", "formulas"), nil
+}
+
+func isURL(source string) bool {
+	return strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "http://")
+}
+
+// fetchFormula reads a formula from a URL or a local file.
+func fetchFormula(ctx context.Context, source string) ([]byte, error) {
+	if !isURL(source) {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > formulaMaxSize {
+			return nil, fmt.Errorf("%s is larger than %d bytes", source, formulaMaxSize)
+		}
+		return data, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, formulaFetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", source, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: %s", source, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, formulaMaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", source, err)
+	}
+	if len(data) > formulaMaxSize {
+		return nil, fmt.Errorf("%s is larger than %d bytes", source, formulaMaxSize)
+	}
+	return data, nil
+}
+
+// sourceBase returns the last path element of a URL or file path.
+func sourceBase(source string) string {
+	if isURL(source) {
+		source = strings.SplitN(strings.SplitN(source, "?", 2)[0], "#", 2)[0]
+		return path.Base(source)
+	}
+	return filepath.Base(source)
+}
+
+func formulaNameFromSource(source string) string {
+	base := sourceBase(source)
+	for _, suffix := range []string{".formula.json", ".formula.toml", ".json", ".toml"} {
+		if name, ok := strings.CutSuffix(base, suffix); ok {
+			return name
+		}
+	}
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+func formulaFormatFromSource(source string) string {
+	switch path.Ext(sourceBase(source)) {
+	case ".json":
+		return "json"
+	case ".toml":
+		return "toml"
+	}
+	return ""
+}
+
+// checkFormulaName rejects names that are not a plain file name.
+func checkFormulaName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid formula name %q", name)
+	}
+	return nil
+}
+
+// parseFormulaData checks that data parses as a formula in format.
+func parseFormulaData(data []byte, format string) error {
+	f := &meow.Formula{}
+	if format == "json" {
+		return json.Unmarshal(data, f)
+	}
+	return toml.Unmarshal(data, f)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// installFormulaFile writes a formula into dir, removing a file of the same
+// name in the other format so it cannot shadow the new one.
+func installFormulaFile(dir, file string, data []byte) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	name, format, _ := strings.Cut(file, ".formula.")
+	other := map[string]string{"json": "toml", "toml": "json"}[format]
+	if err := os.Remove(filepath.Join(dir, name+".formula."+other)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, file), data, 0o644)
+}
+
+func readFormulaSources(dir string) (map[string]formulaSource, error) {
+	sources := make(map[string]formulaSource)
+	data, err := os.ReadFile(filepath.Join(dir, formulaSourcesFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return sources, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &sources); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", filepath.Join(dir, formulaSourcesFile), err)
+	}
+	return sources, nil
+}
+
+func writeFormulaSources(dir string, sources map[string]formulaSource) error {
+	if len(sources) == 0 {
+		return nil
+	}
+	data, err := json.MarshalIndent(sources, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, formulaSourcesFile), append(data, '\n'), 0o644)
+}
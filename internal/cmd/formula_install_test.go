@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/meow"
+
+	"github.com/spf13/cobra"
+)
+
+const triageFormula = `formula = "bug-triage"
+description = "Bug triage"
+version = 1
+type = "workflow"
+`
+
+func TestFormulaInstallAndUpdate(t *testing.T) {
+	content := triageFormula
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/templates/bug-triage.formula.toml":
+			w.Write([]byte(content))
+		case "/templates/broken.toml":
+			w.Write([]byte("formula = "))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	url := srv.URL + "/templates/bug-triage.formula.toml"
+
+	app, _ := setupTestApp(t)
+	app.ConfigDir = t.TempDir()
+	formulaDir := filepath.Join(app.ConfigDir, "formulas")
+	app.FormulaPath = meow.FormulaSearchPath{formulaDir}
+	app.JSON = true
+	run := func(newCmd func(*AppProvider) *cobra.Command, args ...string) ([]byte, error) {
+		t.Helper()
+		out := &bytes.Buffer{}
+		app.Out = out
+		cmd := newCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.Bytes(), err
+	}
+
+	if _, err := run(newFormulaInstallCmd, url, "--sha256", strings.Repeat("0", 64)); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("install with a wrong pin = %v, want checksum mismatch", err)
+	}
+	if _, err := run(newFormulaInstallCmd, srv.URL+"/templates/broken.toml"); err == nil || !strings.Contains(err.Error(), "not a valid formula") {
+		t.Errorf("install of a broken formula = %v", err)
+	}
+	if _, err := run(newFormulaInstallCmd, srv.URL+"/templates/bug.yaml"); err == nil || !strings.Contains(err.Error(), "JSON or TOML") {
+		t.Errorf("install of YAML = %v", err)
+	}
+
+	out, err := run(newFormulaInstallCmd, url, "--sha256", sha256Hex([]byte(content)))
+	if err != nil {
+		t.Fatalf("install: %v", err)
+	}
+	var installed FormulaInstallJSON
+	if err := json.Unmarshal(out, &installed); err != nil {
+		t.Fatal(err)
+	}
+	want := FormulaInstallJSON{Name: "bug-triage", Source: url, SHA256: sha256Hex([]byte(content)), Path: filepath.Join(formulaDir, "bug-triage.formula.toml"), Status: "installed"}
+	if installed != want {
+		t.Errorf("install = %+v, want %+v", installed, want)
+	}
+	if f, err := meow.LoadFormula("bug-triage", app.FormulaPath); err != nil || f.Description != "Bug triage" {
+		t.Errorf("installed formula = %+v, %v", f, err)
+	}
+	sources, err := readFormulaSources(formulaDir)
+	if err != nil || sources["bug-triage"].SHA256 != want.SHA256 {
+		t.Errorf("sources.json = %+v, %v", sources, err)
+	}
+
+	if _, err := run(newFormulaInstallCmd, filepath.Join(t.TempDir(), "bug-triage.toml")); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("installing over a formula from another source = %v", err)
+	}
+
+	update := func(args ...string) (map[string]FormulaInstallJSON, error) {
+		t.Helper()
+		out, err := run(newFormulaUpdateCmd, args...)
+		var results []FormulaInstallJSON
+		if jerr := json.Unmarshal(out, &results); jerr != nil {
+			t.Fatalf("update output %q: %v", out, jerr)
+		}
+		byName := make(map[string]FormulaInstallJSON)
+		for _, r := range results {
+			byName[r.Name] = r
+		}
+		return byName, err
+	}
+	if results, err := update(); err != nil || results["bug-triage"].Status != "unchanged" {
+		t.Errorf("update with nothing changed = %+v, %v", results, err)
+	}
+
+	os.WriteFile(want.Path, []byte(triageFormula+"# local edit\n"), 0o644)
+	if results, err := update(); err != nil || results["bug-triage"].Status != "restored" {
+		t.Errorf("update after a local edit = %+v, %v", results, err)
+	}
+	if data, _ := os.ReadFile(want.Path); string(data) != triageFormula {
+		t.Errorf("restored file = %q", data)
+	}
+
+	content = strings.Replace(triageFormula, "Bug triage", "Bug triage v2", 1)
+	results, err := update()
+	if err == nil || results["bug-triage"].Status != "changed" {
+		t.Errorf("update of a changed source = %+v, %v; want refused", results, err)
+	}
+	if data, _ := os.ReadFile(want.Path); string(data) != triageFormula {
+		t.Error("a refused update should leave the formula alone")
+	}
+	results, err = update("--accept")
+	if err != nil || results["bug-triage"].Status != "updated" || results["bug-triage"].SHA256 != sha256Hex([]byte(content)) {
+		t.Errorf("update --accept = %+v, %v", results, err)
+	}
+	if sources, _ := readFormulaSources(formulaDir); sources["bug-triage"].SHA256 != sha256Hex([]byte(content)) {
+		t.Errorf("update --accept should re-pin, sources.json = %+v", sources)
+	}
+
+	if _, err := update("nope"); err == nil {
+		t.Error("update of a formula not installed from a source should fail")
+	}
+}
+
+func TestFormulaSearchPathShared(t *testing.T) {
+	t.Setenv("GT_ROOT", "")
+	t.Setenv("HOME", "/home/someone")
+	root := t.TempDir()
+	configDir := filepath.Join(root, ".beads")
+	store := &mapConfigStore{data: map[string]string{formulaPathsKey: "vendor/templates, /opt/formulas"}}
+
+	got := formulaSearchPath(configDir, store)
+	want := meow.FormulaSearchPath{
+		filepath.Join(configDir, "formulas"),
+		filepath.Join(root, "vendor", "templates"),
+		"/opt/formulas",
+		filepath.Join("/home/someone", ".beads", "formulas"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("formulaSearchPath = %v, want %v", got, want)
+	}
+}
@@ -4,14 +4,21 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"os/exec"
+	"strings"
 	"time"
 
 	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/notify"
 
 	"github.com/spf13/cobra"
 )
 
+// ciCommentsKey enables posting a CI run summary as a comment on gh:run
+// gates, and their parents, when bd gate check resolves them.
+const ciCommentsKey = "gate.ci_comments"
+
 // GateCheckResultJSON is the JSON output format for a single gate check result.
 type GateCheckResultJSON struct {
 	GateID    string `json:"gate_id"`
@@ -47,12 +54,26 @@ func gateCheckCmd(provider *AppProvider, executor commandExecutor, ghAvailable b
 For each open gate, evaluates based on await_type:
   human    - Skipped (manual only, never auto-closed)
   timer    - Closes if created_at + timeout has passed
+  calendar - Closes once the date of the calendar event named by await_id arrives
   gh:run   - Closes if GitHub Actions run completed successfully
   gh:pr    - Closes if pull request was merged
   bead     - Closes if referenced bead is closed
+  jira     - Closes once the Jira ticket in await_id (e.g. PROJ-123) is done
+  linear   - Closes once the Linear ticket in await_id (e.g. ENG-42) is completed
+
+A ticket gate may also name its ticket in the await type, as jira:PROJ-123
+or linear:ENG-42. Jira needs jira.url and the JIRA_API_TOKEN environment
+variable (plus jira.email for Jira Cloud); Linear needs LINEAR_API_KEY.
+jira.done_statuses and linear.done_statuses list the statuses that count
+as done, in place of Jira's "done" category and Linear's completed states.
 
 Use --dry-run to see what would happen without making changes.
-Use --escalate to report failed conditions (e.g., CI failure, PR closed without merge).
+Use --escalate to report failed conditions (e.g., CI failure, PR closed without merge,
+Linear ticket canceled).
+
+With gate.ci_comments set to true, resolving a gh:run gate also posts a
+summary of the run (workflow, commit, jobs and artifacts) as a comment on
+the gate and its parent issue, as an audit trail of what CI validated.
 
 Examples:
   bd gate check                    # Check and close all satisfied gates
@@ -82,7 +103,7 @@ Examples:
 			if typeFilter != "" {
 				var filtered []*issuestorage.Issue
 				for _, g := range gates {
-					if g.AwaitType == typeFilter {
+					if awaitType, _ := gateAwaitType(g); awaitType == typeFilter || g.AwaitType == typeFilter {
 						filtered = append(filtered, g)
 					}
 				}
@@ -92,11 +113,12 @@ Examples:
 			checker := &gateChecker{
 				app:         app,
 				executor:    executor,
-				now:         time.Now(),
+				now:         app.Now(),
 				escalate:    escalate,
 				ghAvailable: ghAvailable,
 			}
 
+			notifier := newNotifier(app)
 			var results []GateCheckResultJSON
 			for _, gate := range gates {
 				r, shouldClose := checker.evaluate(ctx, gate)
@@ -109,6 +131,16 @@ Examples:
 						fmt.Fprintf(app.Err, "warning: failed to close gate %s: %v\n", gate.ID, closeErr)
 						r.Result = "pending"
 						r.Reason = fmt.Sprintf("close failed: %v", closeErr)
+					} else if err := notifier.Notify(notify.Event{
+						Kind:    notify.KindGateResolved,
+						IssueID: gate.ID,
+						Title:   gate.Title,
+						Detail:  r.Reason,
+					}); err != nil {
+						fmt.Fprintf(app.Err, "warning: %v\n", err)
+					}
+					if gate.AwaitType == "gh:run" && ciCommentsEnabled(app) {
+						checker.postCIRunComments(ctx, gate)
 					}
 				}
 
@@ -180,6 +212,11 @@ type gateChecker struct {
 	now         time.Time
 	escalate    bool
 	ghAvailable bool
+
+	// httpClient and linearURL reach Jira and Linear; tests point them at
+	// a local server. Zero values use the real services.
+	httpClient *http.Client
+	linearURL  string
 }
 
 // evaluate checks a single gate and returns the result and whether the gate should be closed.
@@ -189,7 +226,8 @@ func (c *gateChecker) evaluate(ctx context.Context, gate *issuestorage.Issue) (G
 		AwaitType: gate.AwaitType,
 	}
 
-	switch gate.AwaitType {
+	awaitType, awaitID := gateAwaitType(gate)
+	switch awaitType {
 	case "human":
 		r.Result = "skipped"
 		r.Reason = "manual gate (human-only)"
@@ -198,6 +236,9 @@ func (c *gateChecker) evaluate(ctx context.Context, gate *issuestorage.Issue) (G
 	case "timer":
 		return c.evaluateTimer(gate, r)
 
+	case "calendar":
+		return c.evaluateCalendar(gate, r)
+
 	case "bead":
 		return c.evaluateBead(ctx, gate, r)
 
@@ -207,6 +248,12 @@ func (c *gateChecker) evaluate(ctx context.Context, gate *issuestorage.Issue) (G
 	case "gh:pr":
 		return c.evaluateGHPR(gate, r)
 
+	case "jira":
+		return c.evaluateJira(ctx, awaitID, r)
+
+	case "linear":
+		return c.evaluateLinear(ctx, awaitID, r)
+
 	default:
 		r.Result = "skipped"
 		r.Reason = fmt.Sprintf("unknown await_type %q", gate.AwaitType)
@@ -239,6 +286,31 @@ func (c *gateChecker) evaluateTimer(gate *issuestorage.Issue, r GateCheckResultJ
 	return r, false
 }
 
+func (c *gateChecker) evaluateCalendar(gate *issuestorage.Issue, r GateCheckResultJSON) (GateCheckResultJSON, bool) {
+	if gate.AwaitID == "" {
+		r.Result = "pending"
+		r.Reason = "no await_id configured"
+		return r, false
+	}
+
+	date, err := calendarDate(c.app, gate.AwaitID)
+	if err != nil {
+		r.Result = "pending"
+		r.Reason = err.Error()
+		return r, false
+	}
+
+	if !c.now.Before(date) {
+		r.Result = "resolved"
+		r.Reason = fmt.Sprintf("%s arrived (%s)", gate.AwaitID, date.Format("2006-01-02"))
+		return r, true
+	}
+
+	r.Result = "pending"
+	r.Reason = fmt.Sprintf("waits for %s on %s", gate.AwaitID, date.Format("2006-01-02"))
+	return r, false
+}
+
 func (c *gateChecker) evaluateBead(ctx context.Context, gate *issuestorage.Issue, r GateCheckResultJSON) (GateCheckResultJSON, bool) {
 	if gate.AwaitID == "" {
 		r.Result = "pending"
@@ -364,3 +436,123 @@ func (c *gateChecker) evaluateGHPR(gate *issuestorage.Issue, r GateCheckResultJS
 	r.Reason = fmt.Sprintf("PR state: %s", ghResult.State)
 	return r, false
 }
+
+// ciCommentsEnabled reports whether gate.ci_comments is set to "true".
+func ciCommentsEnabled(app *App) bool {
+	if app.ConfigStore == nil {
+		return false
+	}
+	v, _ := app.ConfigStore.Get(ciCommentsKey)
+	return v == "true"
+}
+
+// postCIRunComments adds a summary of a resolved gh:run gate's run to the
+// gate and its parent issue. Failures are reported as warnings since the
+// gate has already been closed.
+func (c *gateChecker) postCIRunComments(ctx context.Context, gate *issuestorage.Issue) {
+	summary, err := c.ciRunSummary(gate.AwaitID)
+	if err != nil {
+		fmt.Fprintf(c.app.Err, "warning: CI summary for gate %s: %v\n", gate.ID, err)
+		return
+	}
+	author, _ := resolveActor(c.app)
+	targets := []string{gate.ID}
+	if gate.Parent != "" {
+		targets = append(targets, gate.Parent)
+	}
+	for _, id := range targets {
+		if err := addComment(ctx, c.app.Storage, id, &issuestorage.Comment{Author: author, Text: summary}); err != nil {
+			fmt.Fprintf(c.app.Err, "warning: failed to comment on %s: %v\n", id, err)
+		}
+	}
+}
+
+// ciRunSummary fetches a GitHub Actions run's jobs and artifacts and
+// formats them as a comment. Artifacts are optional: if they can't be
+// listed the summary says so rather than failing.
+func (c *gateChecker) ciRunSummary(runID string) (string, error) {
+	output, err := c.executor("gh", "run", "view", runID, "--json", "workflowName,displayTitle,conclusion,headBranch,headSha,url,jobs")
+	if err != nil {
+		return "", fmt.Errorf("gh run view failed: %w", err)
+	}
+	var run struct {
+		WorkflowName string `json:"workflowName"`
+		DisplayTitle string `json:"displayTitle"`
+		Conclusion   string `json:"conclusion"`
+		HeadBranch   string `json:"headBranch"`
+		HeadSha      string `json:"headSha"`
+		URL          string `json:"url"`
+		Jobs         []struct {
+			Name       string `json:"name"`
+			Conclusion string `json:"conclusion"`
+		} `json:"jobs"`
+	}
+	if err := json.Unmarshal(output, &run); err != nil {
+		return "", fmt.Errorf("failed to parse gh output: %w", err)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "CI run %s", runID)
+	if run.WorkflowName != "" {
+		fmt.Fprintf(&b, " (%s)", run.WorkflowName)
+	}
+	fmt.Fprintf(&b, " completed: %s\n", run.Conclusion)
+	if run.DisplayTitle != "" {
+		fmt.Fprintf(&b, "Title: %s\n", run.DisplayTitle)
+	}
+	if run.HeadBranch != "" || run.HeadSha != "" {
+		sha := run.HeadSha
+		if len(sha) > 12 {
+			sha = sha[:12]
+		}
+		fmt.Fprintf(&b, "Commit: %s @ %s\n", run.HeadBranch, sha)
+	}
+	if run.URL != "" {
+		fmt.Fprintf(&b, "URL: %s\n", run.URL)
+	}
+	if len(run.Jobs) > 0 {
+		b.WriteString("Jobs:\n")
+		for _, job := range run.Jobs {
+			fmt.Fprintf(&b, "  %s %s (%s)\n", ciConclusionSymbol(job.Conclusion), job.Name, job.Conclusion)
+		}
+	}
+
+	output, err = c.executor("gh", "api", "repos/{owner}/{repo}/actions/runs/"+runID+"/artifacts")
+	var artifacts struct {
+		Artifacts []struct {
+			Name        string `json:"name"`
+			SizeInBytes int64  `json:"size_in_bytes"`
+			Expired     bool   `json:"expired"`
+		} `json:"artifacts"`
+	}
+	if err == nil {
+		err = json.Unmarshal(output, &artifacts)
+	}
+	switch {
+	case err != nil:
+		b.WriteString("Artifacts: unavailable\n")
+	case len(artifacts.Artifacts) == 0:
+		b.WriteString("Artifacts: none\n")
+	default:
+		b.WriteString("Artifacts:\n")
+		for _, a := range artifacts.Artifacts {
+			fmt.Fprintf(&b, "  %s (%s)", a.Name, formatSize(a.SizeInBytes))
+			if a.Expired {
+				b.WriteString(" [expired]")
+			}
+			b.WriteString("\n")
+		}
+	}
+	return strings.TrimRight(b.String(), "\n"), nil
+}
+
+func ciConclusionSymbol(conclusion string) string {
+	switch conclusion {
+	case "success":
+		return "✓"
+	case "skipped", "neutral":
+		return "-"
+	default:
+		return "✗"
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"beads-lite/internal/clock"
 	"beads-lite/internal/issueservice"
 	"beads-lite/internal/issuestorage"
 	"beads-lite/internal/issuestorage/filesystem"
@@ -47,8 +48,9 @@ func setupCheckTestApp(t *testing.T) (*App, *issueservice.IssueStore) {
 func TestGateCheckTimerExpired(t *testing.T) {
 	app, store := setupCheckTestApp(t)
 	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC))
+	store.SetClock(fake)
 
-	// Create a timer gate that expired 1 hour ago
 	id, err := store.Create(ctx, &issuestorage.Issue{
 		Title:     "Timer gate",
 		Type:      issuestorage.TypeGate,
@@ -60,13 +62,8 @@ func TestGateCheckTimerExpired(t *testing.T) {
 		t.Fatalf("failed to create gate: %v", err)
 	}
 
-	// Backdate the gate's creation time so the timer is expired
-	if err := store.Modify(ctx, id, func(i *issuestorage.Issue) error {
-		i.CreatedAt = time.Now().Add(-2 * time.Hour)
-		return nil
-	}); err != nil {
-		t.Fatalf("failed to backdate gate: %v", err)
-	}
+	// Move past the deadline instead of backdating the gate
+	fake.Advance(2 * time.Hour)
 
 	out := app.Out.(*bytes.Buffer)
 	cmd := gateCheckCmd(NewTestProvider(app), nil, false)
@@ -83,11 +80,14 @@ func TestGateCheckTimerExpired(t *testing.T) {
 		t.Errorf("expected 'deadline passed' in output, got: %s", output)
 	}
 
-	// Verify gate was closed
+	// Verify gate was closed at the fake time
 	closed, _ := store.Get(ctx, id)
 	if closed.Status != issuestorage.StatusClosed {
 		t.Errorf("expected gate to be closed, got status %q", closed.Status)
 	}
+	if closed.ClosedAt == nil || !closed.ClosedAt.Equal(fake.Now()) {
+		t.Errorf("ClosedAt = %v, want %v", closed.ClosedAt, fake.Now())
+	}
 }
 
 func TestGateCheckTimerNotExpired(t *testing.T) {
@@ -1216,3 +1216,93 @@ func TestGateCheckGHPRClosedNoEscalate(t *testing.T) {
 		t.Errorf("expected no 'escalate' without --escalate flag, got: %s", output)
 	}
 }
+
+func TestGateCheckGHRunCIComments(t *testing.T) {
+	app, store := setupCheckTestApp(t)
+	app.ConfigStore = &mapConfigStore{data: map[string]string{ciCommentsKey: "true", "actor": "ci-bot"}}
+	ctx := context.Background()
+
+	epicID, err := store.Create(ctx, &issuestorage.Issue{Title: "Release", Type: issuestorage.TypeEpic})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gateID, err := store.GetNextChildID(ctx, epicID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Create(ctx, &issuestorage.Issue{ID: gateID, Title: "CI gate", Type: issuestorage.TypeGate, AwaitType: "gh:run", AwaitID: "12345"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddDependency(ctx, gateID, epicID, issuestorage.DepTypeParentChild); err != nil {
+		t.Fatal(err)
+	}
+
+	executor := mockExecutor(map[string]struct {
+		output []byte
+		err    error
+	}{
+		"gh run view 12345 --json status,conclusion": {
+			output: []byte(`{"status":"completed","conclusion":"success"}`),
+		},
+		"gh run view 12345 --json workflowName,displayTitle,conclusion,headBranch,headSha,url,jobs": {
+			output: []byte(`{"workflowName":"CI","displayTitle":"Add login","conclusion":"success","headBranch":"main","headSha":"0123456789abcdef","url":"https://github.com/o/r/actions/runs/12345","jobs":[{"name":"test","conclusion":"success"},{"name":"deploy","conclusion":"skipped"}]}`),
+		},
+		"gh api repos/{owner}/{repo}/actions/runs/12345/artifacts": {
+			output: []byte(`{"artifacts":[{"name":"coverage","size_in_bytes":2048,"expired":false}]}`),
+		},
+	})
+
+	cmd := gateCheckCmd(NewTestProvider(app), executor, true)
+	cmd.SetArgs([]string{})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("gate check failed: %v", err)
+	}
+
+	want := "CI run 12345 (CI) completed: success\n" +
+		"Title: Add login\n" +
+		"Commit: main @ 0123456789ab\n" +
+		"URL: https://github.com/o/r/actions/runs/12345\n" +
+		"Jobs:\n  ✓ test (success)\n  - deploy (skipped)\n" +
+		"Artifacts:\n  coverage (2.0 KB)"
+	for _, id := range []string{gateID, epicID} {
+		issue, err := store.Get(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(issue.Comments) != 1 || issue.Comments[0].Text != want || issue.Comments[0].Author != "ci-bot" {
+			t.Errorf("%s comments = %+v, want one comment:\n%s", id, issue.Comments, want)
+		}
+	}
+}
+
+func TestGateCheckGHRunCIComments_ArtifactsUnavailable(t *testing.T) {
+	app, store := setupCheckTestApp(t)
+	app.ConfigStore = &mapConfigStore{data: map[string]string{ciCommentsKey: "true"}}
+	ctx := context.Background()
+	gateID, err := store.Create(ctx, &issuestorage.Issue{Title: "CI gate", Type: issuestorage.TypeGate, AwaitType: "gh:run", AwaitID: "7"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	executor := mockExecutor(map[string]struct {
+		output []byte
+		err    error
+	}{
+		"gh run view 7 --json status,conclusion": {
+			output: []byte(`{"status":"completed","conclusion":"success"}`),
+		},
+		"gh run view 7 --json workflowName,displayTitle,conclusion,headBranch,headSha,url,jobs": {
+			output: []byte(`{"conclusion":"success"}`),
+		},
+	})
+
+	cmd := gateCheckCmd(NewTestProvider(app), executor, true)
+	cmd.SetArgs([]string{})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("gate check failed: %v", err)
+	}
+	gate, _ := store.Get(ctx, gateID)
+	if len(gate.Comments) != 1 || gate.Comments[0].Text != "CI run 7 completed: success\nArtifacts: unavailable" {
+		t.Errorf("comments = %+v", gate.Comments)
+	}
+}
@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"beads-lite/internal/config"
+	"beads-lite/internal/issuestorage"
+)
+
+// Config keys for gates on tickets in Jira and Linear.
+const (
+	// jiraURLKey is the base URL of the Jira site, e.g.
+	// https://acme.atlassian.net.
+	jiraURLKey = "jira.url"
+	// jiraEmailKey is the account the API token belongs to. Without it the
+	// token is sent as a bearer token, as Jira Data Center expects.
+	jiraEmailKey = "jira.email"
+	// jiraDoneKey and linearDoneKey list the ticket statuses that resolve
+	// a gate, comma-separated. By default any status Jira puts in its
+	// "done" category, or Linear of type "completed", does.
+	jiraDoneKey   = "jira.done_statuses"
+	linearDoneKey = "linear.done_statuses"
+)
+
+// Credentials are read from the environment so they stay out of the
+// committed config.
+const (
+	envJiraToken    = "JIRA_API_TOKEN"
+	envLinearAPIKey = "LINEAR_API_KEY"
+)
+
+// linearAPIURL is Linear's GraphQL endpoint.
+const linearAPIURL = "https://api.linear.app/graphql"
+
+// remoteGateTimeout bounds each request to a ticket tracker.
+const remoteGateTimeout = 15 * time.Second
+
+// gateAwaitType returns the await type of gate and the ticket it awaits.
+// Ticket gates may name the ticket in await_id or, written as
+// "jira:PROJ-123", in the await type itself.
+func gateAwaitType(gate *issuestorage.Issue) (awaitType, awaitID string) {
+	for _, prefix := range []string{"jira:", "linear:"} {
+		if key, ok := strings.CutPrefix(gate.AwaitType, prefix); ok && gate.AwaitID == "" {
+			return strings.TrimSuffix(prefix, ":"), key
+		}
+	}
+	return gate.AwaitType, gate.AwaitID
+}
+
+// doneStatuses returns the statuses listed under key, lowercased, or nil
+// if it is unset.
+func (c *gateChecker) doneStatuses(key string) []string {
+	if c.app.ConfigStore == nil {
+		return nil
+	}
+	v, _ := c.app.ConfigStore.Get(key)
+	var statuses []string
+	for _, s := range config.SplitCustomValues(v) {
+		statuses = append(statuses, strings.ToLower(s))
+	}
+	return statuses
+}
+
+func (c *gateChecker) configValue(key string) string {
+	if c.app.ConfigStore == nil {
+		return ""
+	}
+	v, _ := c.app.ConfigStore.Get(key)
+	return v
+}
+
+func (c *gateChecker) evaluateJira(ctx context.Context, key string, r GateCheckResultJSON) (GateCheckResultJSON, bool) {
+	base := strings.TrimRight(c.configValue(jiraURLKey), "/")
+	token := os.Getenv(envJiraToken)
+	switch {
+	case base == "":
+		r.Result = "skipped"
+		r.Reason = jiraURLKey + " not configured"
+		return r, false
+	case token == "":
+		r.Result = "skipped"
+		r.Reason = envJiraToken + " not set"
+		return r, false
+	case key == "":
+		r.Result = "pending"
+		r.Reason = "no await_id configured"
+		return r, false
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"/rest/api/2/issue/"+url.PathEscape(key)+"?fields=status", nil)
+	if err != nil {
+		r.Result = "pending"
+		r.Reason = fmt.Sprintf("bad %s: %v", jiraURLKey, err)
+		return r, false
+	}
+	if email := c.configValue(jiraEmailKey); email != "" {
+		req.SetBasicAuth(email, token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	var ticket struct {
+		Fields struct {
+			Status struct {
+				Name           string `json:"name"`
+				StatusCategory struct {
+					Key string `json:"key"`
+				} `json:"statusCategory"`
+			} `json:"status"`
+		} `json:"fields"`
+	}
+	if err := c.fetchJSON(req, &ticket); err != nil {
+		r.Result = "pending"
+		r.Reason = fmt.Sprintf("fetching Jira ticket %s: %v", key, err)
+		return r, false
+	}
+
+	status := ticket.Fields.Status
+	done := status.StatusCategory.Key == "done"
+	if statuses := c.doneStatuses(jiraDoneKey); statuses != nil {
+		done = contains(statuses, strings.ToLower(status.Name))
+	}
+	if done {
+		r.Result = "resolved"
+		r.Reason = fmt.Sprintf("Jira ticket %s is %s", key, status.Name)
+		return r, true
+	}
+	r.Result = "pending"
+	r.Reason = fmt.Sprintf("Jira ticket %s is %s", key, status.Name)
+	return r, false
+}
+
+func (c *gateChecker) evaluateLinear(ctx context.Context, key string, r GateCheckResultJSON) (GateCheckResultJSON, bool) {
+	apiKey := os.Getenv(envLinearAPIKey)
+	switch {
+	case apiKey == "":
+		r.Result = "skipped"
+		r.Reason = envLinearAPIKey + " not set"
+		return r, false
+	case key == "":
+		r.Result = "pending"
+		r.Reason = "no await_id configured"
+		return r, false
+	}
+
+	body, _ := json.Marshal(map[string]any{
+		"query":     `query($id: String!) { issue(id: $id) { identifier state { name type } } }`,
+		"variables": map[string]string{"id": key},
+	})
+	endpoint := c.linearURL
+	if endpoint == "" {
+		endpoint = linearAPIURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		r.Result = "pending"
+		r.Reason = err.Error()
+		return r, false
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", apiKey) // personal API keys go without a scheme
+	var resp struct {
+		Data struct {
+			Issue *struct {
+				State struct {
+					Name string `json:"name"`
+					Type string `json:"type"`
+				} `json:"state"`
+			} `json:"issue"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	err = c.fetchJSON(req, &resp)
+	if err == nil && len(resp.Errors) > 0 {
+		err = fmt.Errorf("%s", resp.Errors[0].Message)
+	} else if err == nil && resp.Data.Issue == nil {
+		err = fmt.Errorf("not found")
+	}
+	if err != nil {
+		r.Result = "pending"
+		r.Reason = fmt.Sprintf("fetching Linear ticket %s: %v", key, err)
+		return r, false
+	}
+
+	state := resp.Data.Issue.State
+	done := state.Type == "completed"
+	if statuses := c.doneStatuses(linearDoneKey); statuses != nil {
+		done = contains(statuses, strings.ToLower(state.Name))
+	}
+	switch {
+	case done:
+		r.Result = "resolved"
+		r.Reason = fmt.Sprintf("Linear ticket %s is %s", key, state.Name)
+		return r, true
+	case state.Type == "canceled":
+		if c.escalate {
+			r.Result = "escalate"
+		} else {
+			r.Result = "pending"
+		}
+		r.Reason = fmt.Sprintf("Linear ticket %s was canceled (%s)", key, state.Name)
+		return r, false
+	}
+	r.Result = "pending"
+	r.Reason = fmt.Sprintf("Linear ticket %s is %s", key, state.Name)
+	return r, false
+}
+
+// fetchJSON sends req and decodes a successful JSON response into v.
+func (c *gateChecker) fetchJSON(req *http.Request, v any) error {
+	client := c.httpClient
+	if client == nil {
+		client = &http.Client{Timeout: remoteGateTimeout}
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg := strings.TrimSpace(string(data))
+		if len(msg) > 200 {
+			msg = msg[:200] + "..."
+		}
+		return fmt.Errorf("%s: %s", resp.Status, msg)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parsing response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestGateCheckJira(t *testing.T) {
+	statuses := map[string]string{
+		"PROJ-1": `{"name": "Done", "statusCategory": {"key": "done"}}`,
+		"PROJ-2": `{"name": "In Review", "statusCategory": {"key": "indeterminate"}}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "me@example.com" || pass != "secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		key := strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/")
+		status, ok := statuses[key]
+		if !ok {
+			http.Error(w, `{"errorMessages":["Issue does not exist"]}`, http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"key": %q, "fields": {"status": %s}}`, key, status)
+	}))
+	defer srv.Close()
+	t.Setenv(envJiraToken, "secret")
+
+	app, store := setupCheckTestApp(t)
+	app.ConfigStore = &mapConfigStore{data: map[string]string{jiraURLKey: srv.URL + "/", jiraEmailKey: "me@example.com"}}
+	app.JSON = true
+	ctx := context.Background()
+	gate := func(awaitType, awaitID string) string {
+		t.Helper()
+		id, err := store.Create(ctx, &issuestorage.Issue{Title: "Wait for " + awaitType + awaitID, Type: issuestorage.TypeGate, Priority: issuestorage.PriorityMedium, AwaitType: awaitType, AwaitID: awaitID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+	done := gate("jira", "PROJ-1")
+	review := gate("jira:PROJ-2", "")
+	missing := gate("jira", "PROJ-9")
+
+	check := func(args ...string) map[string]GateCheckResultJSON {
+		t.Helper()
+		out := &bytes.Buffer{}
+		app.Out = out
+		cmd := gateCheckCmd(NewTestProvider(app), nil, false)
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("gate check: %v", err)
+		}
+		var results []GateCheckResultJSON
+		if err := json.Unmarshal(out.Bytes(), &results); err != nil {
+			t.Fatal(err)
+		}
+		byID := make(map[string]GateCheckResultJSON)
+		for _, r := range results {
+			byID[r.GateID] = r
+		}
+		return byID
+	}
+
+	results := check("--type", "jira", "--dry-run")
+	if len(results) != 3 {
+		t.Fatalf("--type jira matched %d gates, want 3 including jira:PROJ-2", len(results))
+	}
+	if r := results[done]; r.Result != "resolved" || r.Reason != "Jira ticket PROJ-1 is Done" {
+		t.Errorf("done ticket: %+v", r)
+	}
+	if r := results[review]; r.Result != "pending" || r.Reason != "Jira ticket PROJ-2 is In Review" {
+		t.Errorf("ticket in review: %+v", r)
+	}
+	if r := results[missing]; r.Result != "pending" || !strings.Contains(r.Reason, "404") {
+		t.Errorf("missing ticket: %+v", r)
+	}
+
+	// Listed statuses replace the done category.
+	app.ConfigStore.Set(jiraDoneKey, "in review, Released")
+	results = check()
+	if results[done].Result != "pending" || results[review].Result != "resolved" {
+		t.Errorf("with %s: done %+v, review %+v", jiraDoneKey, results[done], results[review])
+	}
+	if issue, err := store.Get(ctx, review); err != nil || issue.Status != issuestorage.StatusClosed {
+		t.Errorf("resolved gate should be closed, got %v, %v", issue, err)
+	}
+
+	t.Setenv(envJiraToken, "")
+	if r := check()[missing]; r.Result != "skipped" || r.Reason != envJiraToken+" not set" {
+		t.Errorf("without a token: %+v", r)
+	}
+}
+
+func TestGateCheckLinear(t *testing.T) {
+	states := map[string]string{
+		"ENG-1": `{"name": "Done", "type": "completed"}`,
+		"ENG-2": `{"name": "Won't do", "type": "canceled"}`,
+		"ENG-3": `{"name": "In Progress", "type": "started"}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "lin_api_key" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		var req struct {
+			Variables struct {
+				ID string `json:"id"`
+			} `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		state, ok := states[req.Variables.ID]
+		if !ok {
+			fmt.Fprint(w, `{"data": null, "errors": [{"message": "Entity not found: Issue"}]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"data": {"issue": {"identifier": %q, "state": %s}}}`, req.Variables.ID, state)
+	}))
+	defer srv.Close()
+	t.Setenv(envLinearAPIKey, "lin_api_key")
+
+	app, _ := setupCheckTestApp(t)
+	app.ConfigStore = &mapConfigStore{data: map[string]string{}}
+	checker := &gateChecker{app: app, linearURL: srv.URL, escalate: true}
+	evaluate := func(awaitType, awaitID string) (GateCheckResultJSON, bool) {
+		return checker.evaluate(context.Background(), &issuestorage.Issue{ID: "bd-g", AwaitType: awaitType, AwaitID: awaitID})
+	}
+
+	tests := []struct {
+		awaitType, awaitID string
+		result, reason     string
+		close              bool
+	}{
+		{"linear", "ENG-1", "resolved", "Linear ticket ENG-1 is Done", true},
+		{"linear:ENG-1", "", "resolved", "Linear ticket ENG-1 is Done", true},
+		{"linear", "ENG-2", "escalate", "Linear ticket ENG-2 was canceled (Won't do)", false},
+		{"linear", "ENG-3", "pending", "Linear ticket ENG-3 is In Progress", false},
+		{"linear", "ENG-9", "pending", "fetching Linear ticket ENG-9: Entity not found: Issue", false},
+		{"linear", "", "pending", "no await_id configured", false},
+	}
+	for _, tt := range tests {
+		r, close := evaluate(tt.awaitType, tt.awaitID)
+		if r.Result != tt.result || r.Reason != tt.reason || close != tt.close {
+			t.Errorf("%s %s = %s %q (close %v), want %s %q (close %v)", tt.awaitType, tt.awaitID, r.Result, r.Reason, close, tt.result, tt.reason, tt.close)
+		}
+	}
+
+	app.ConfigStore.Set(linearDoneKey, "In Progress")
+	if r, close := evaluate("linear", "ENG-3"); r.Result != "resolved" || !close {
+		t.Errorf("with %s, ENG-3 = %+v", linearDoneKey, r)
+	}
+
+	t.Setenv(envLinearAPIKey, "")
+	if r, _ := evaluate("linear", "ENG-1"); r.Result != "skipped" {
+		t.Errorf("without an API key = %+v, want skipped", r)
+	}
+}
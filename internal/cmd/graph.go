@@ -34,6 +34,14 @@ type GraphGroupJSON struct {
 	Tasks        []GraphTaskJSON `json:"tasks"`
 }
 
+// GraphTrackJSON represents a tracking issue and the issues it tracks
+// (DepTypeTracks edges, distinct from parent-child grouping).
+type GraphTrackJSON struct {
+	ID      string   `json:"id"`
+	Title   string   `json:"title"`
+	Tracked []string `json:"tracked"`
+}
+
 // GraphWaveJSON represents a wave in graph JSON output.
 type GraphWaveJSON struct {
 	Issues []string `json:"issues"`
@@ -45,6 +53,7 @@ type GraphOutputJSON struct {
 	CascadeParentBlocking bool             `json:"cascade_parent_blocking"`
 	Groups                []GraphGroupJSON `json:"groups"`
 	Standalone            []GraphTaskJSON  `json:"standalone"`
+	Tracks                []GraphTrackJSON `json:"tracks,omitempty"`
 	Waves                 []GraphWaveJSON  `json:"waves,omitempty"`
 }
 
@@ -55,18 +64,35 @@ type graphGroup struct {
 }
 
 func newGraphCmd(provider *AppProvider) *cobra.Command {
-	var waves bool
+	var (
+		waves  bool
+		format string
+		all    bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "graph [parent-id]",
-		Short: "Render dependency graph as grouped trees",
+		Short: "Render dependency graph as grouped trees, DOT or Mermaid",
 		Long: `Render dependency graph as grouped trees with back-references.
 
 Without an argument, renders all open tasks grouped by immediate parent.
 With a parent ID, renders that parent's descendant scope grouped by immediate parent.
 
 Use --waves to include cross-parent wave grouping.
-Use --json to emit structured output.`,
+Use --json to emit structured output.
+
+With --format dot or --format mermaid, emit the graph for Graphviz or
+Mermaid instead: every issue that is not closed (with --all, closed ones
+too), or with a parent ID that issue, its descendants and the issues they
+link to directly, drawn dashed. Nodes are colored by status. Blockers
+point at the issues they block in red (thick in Mermaid), parents link to
+their children with dashed lines, and other links are dotted and labelled
+with their type.
+
+Examples:
+  bd graph bd-a1b2
+  bd graph --format dot | dot -Tsvg -o graph.svg
+  bd graph bd-a1b2 --format mermaid --all`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			app, err := provider.Get()
@@ -89,6 +115,25 @@ Use --json to emit structured output.`,
 				rootID = resolved.ID
 			}
 
+			if !contains(graphFormats, format) {
+				return fmt.Errorf("invalid format %q (valid: %s)", format, strings.Join(graphFormats, ", "))
+			}
+			if format != graphFormatText {
+				if app.JSON {
+					return fmt.Errorf("--format %s cannot be combined with --json", format)
+				}
+				g, err := collectGraphExport(ctx, app.Storage, rootID, all)
+				if err != nil {
+					return err
+				}
+				if format == graphFormatDOT {
+					writeGraphDOT(app.Out, g)
+				} else {
+					writeGraphMermaid(app.Out, g)
+				}
+				return nil
+			}
+
 			allIssues, err := collectGraphIssues(ctx, app.Storage, rootID)
 			if err != nil {
 				return err
@@ -132,11 +177,14 @@ Use --json to emit structured output.`,
 				waveData = toGraphWaveJSON(ws)
 			}
 
+			tracks := collectGraphTracks(allIssues)
+
 			if app.JSON {
-				return outputGraphJSON(app, taskByID, groups, parentOrder, standalone, blockersByID, closedSet, cascade, waveData)
+				return outputGraphJSON(app, taskByID, groups, parentOrder, standalone, blockersByID, closedSet, cascade, waveData, tracks)
 			}
 
 			outputGraphText(app, taskByID, groups, parentOrder, standalone, blockersByID, closedSet)
+			printTracksText(ctx, app, tracks)
 			if waves {
 				printWavesText(app, waveData)
 			}
@@ -145,6 +193,8 @@ Use --json to emit structured output.`,
 	}
 
 	cmd.Flags().BoolVar(&waves, "waves", false, "Show cross-parent wave grouping")
+	cmd.Flags().StringVar(&format, "format", graphFormatText, "Output format ("+strings.Join(graphFormats, ", ")+")")
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "Include closed issues (dot and mermaid)")
 	return cmd
 }
 
@@ -346,11 +396,13 @@ func outputGraphJSON(
 	closedSet map[string]bool,
 	cascade bool,
 	waves []GraphWaveJSON,
+	tracks []GraphTrackJSON,
 ) error {
 	out := GraphOutputJSON{
 		CascadeParentBlocking: cascade,
 		Groups:                []GraphGroupJSON{},
 		Standalone:            []GraphTaskJSON{},
+		Tracks:                tracks,
 		Waves:                 waves,
 	}
 
@@ -639,3 +691,40 @@ func printWavesText(app *App, waves []GraphWaveJSON) {
 		fmt.Fprintf(app.Out, "  Wave %d: %s\n", wave.Wave, strings.Join(wave.Issues, ", "))
 	}
 }
+
+// collectGraphTracks returns the tracks edges of the in-scope issues,
+// grouped by tracking issue and sorted by ID.
+func collectGraphTracks(issues []*issuestorage.Issue) []GraphTrackJSON {
+	var tracks []GraphTrackJSON
+	for _, issue := range issues {
+		tracked := graph.TrackedIDs(issue)
+		if len(tracked) == 0 {
+			continue
+		}
+		sorted := append([]string{}, tracked...)
+		sort.Strings(sorted)
+		tracks = append(tracks, GraphTrackJSON{ID: issue.ID, Title: issue.Title, Tracked: sorted})
+	}
+	sort.Slice(tracks, func(i, j int) bool { return tracks[i].ID < tracks[j].ID })
+	return tracks
+}
+
+// printTracksText renders tracks edges separately from the parent-child
+// trees, since a tracked issue may belong to several tracking epics.
+func printTracksText(ctx context.Context, app *App, tracks []GraphTrackJSON) {
+	if len(tracks) == 0 {
+		return
+	}
+	fmt.Fprintln(app.Out)
+	fmt.Fprintln(app.Out, "Tracked (non-hierarchical)")
+	for _, t := range tracks {
+		fmt.Fprintf(app.Out, "%s - %s\n", t.ID, t.Title)
+		for _, id := range t.Tracked {
+			if issue, err := app.Storage.Get(ctx, id); err == nil {
+				fmt.Fprintf(app.Out, "  ⇢ %s %s  %s\n", parentStatusIcon(issue, false), issue.ID, issue.Title)
+			} else {
+				fmt.Fprintf(app.Out, "  ⇢ %s\n", id)
+			}
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"beads-lite/internal/graph"
+	"beads-lite/internal/issuestorage"
+)
+
+// Formats accepted by bd graph --format.
+const (
+	graphFormatText    = "text"
+	graphFormatDOT     = "dot"
+	graphFormatMermaid = "mermaid"
+)
+
+var graphFormats = []string{graphFormatText, graphFormatDOT, graphFormatMermaid}
+
+// graphExportTitleWidth is where node titles are truncated in exports.
+const graphExportTitleWidth = 40
+
+// graphStatusColors are node fill colors in DOT and Mermaid exports.
+var graphStatusColors = map[issuestorage.Status]string{
+	issuestorage.StatusOpen:       "#ffffff",
+	issuestorage.StatusInProgress: "#fff3b0",
+	issuestorage.StatusReview:     "#d6eaf8",
+	issuestorage.StatusBlocked:    "#f5b7b1",
+	issuestorage.StatusDeferred:   "#e5e7e9",
+	issuestorage.StatusHooked:     "#e8daef",
+	issuestorage.StatusPinned:     "#e8daef",
+	issuestorage.StatusClosed:     "#d5f5e3",
+}
+
+// graphEdge is a dependency drawn in an export. Blocks and parent-child
+// edges point from the blocker or parent; other types point from the
+// issue holding the dependency.
+type graphEdge struct {
+	From, To string
+	Type     issuestorage.DependencyType
+}
+
+// graphExport is what bd graph draws as DOT or Mermaid.
+type graphExport struct {
+	Nodes    []*issuestorage.Issue // ordered by ID
+	Edges    []graphEdge
+	External map[string]bool // linked issues outside the requested subtree
+}
+
+// collectGraphExport gathers rootID and its descendants, or every issue
+// if rootID is "", plus the issues they link to directly when scoped to a
+// subtree. Closed issues are left out unless includeClosed; ephemeral
+// issues always are.
+func collectGraphExport(ctx context.Context, store issuestorage.IssueStore, rootID string, includeClosed bool) (*graphExport, error) {
+	keep := func(issue *issuestorage.Issue) bool {
+		return !issue.Ephemeral && issue.Status != issuestorage.StatusTombstone &&
+			(includeClosed || issue.Status != issuestorage.StatusClosed)
+	}
+
+	var scope []*issuestorage.Issue
+	if rootID != "" {
+		root, err := store.Get(ctx, rootID)
+		if err != nil {
+			return nil, err
+		}
+		descendants, err := graph.CollectMoleculeChildren(ctx, store, rootID)
+		if err != nil {
+			return nil, fmt.Errorf("collect descendants of %s: %w", rootID, err)
+		}
+		scope = append([]*issuestorage.Issue{root}, descendants...)
+	} else {
+		issues, err := store.List(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("list issues: %w", err)
+		}
+		scope = issues
+		if includeClosed {
+			closed, err := store.List(ctx, &issuestorage.ListFilter{Statuses: []issuestorage.Status{issuestorage.StatusClosed}})
+			if err != nil {
+				return nil, fmt.Errorf("list closed issues: %w", err)
+			}
+			scope = append(scope, closed...)
+		}
+	}
+
+	g := &graphExport{External: make(map[string]bool)}
+	byID := make(map[string]*issuestorage.Issue)
+	for _, issue := range scope {
+		if keep(issue) {
+			byID[issue.ID] = issue
+		}
+	}
+	if rootID != "" {
+		for _, issue := range scope {
+			if byID[issue.ID] == nil {
+				continue
+			}
+			for _, dep := range append(append([]issuestorage.Dependency(nil), issue.Dependencies...), issue.Dependents...) {
+				if byID[dep.ID] != nil || g.External[dep.ID] {
+					continue
+				}
+				linked, err := store.Get(ctx, dep.ID)
+				if err != nil || !keep(linked) {
+					continue
+				}
+				byID[linked.ID] = linked
+				g.External[linked.ID] = true
+			}
+		}
+	}
+
+	for _, issue := range byID {
+		g.Nodes = append(g.Nodes, issue)
+		for _, dep := range issue.Dependencies {
+			if byID[dep.ID] == nil {
+				continue
+			}
+			edge := graphEdge{From: issue.ID, To: dep.ID, Type: dep.Type}
+			if dep.Type == issuestorage.DepTypeBlocks || dep.Type == issuestorage.DepTypeParentChild {
+				edge.From, edge.To = dep.ID, issue.ID
+			}
+			g.Edges = append(g.Edges, edge)
+		}
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool { return g.Nodes[i].ID < g.Nodes[j].ID })
+	sort.Slice(g.Edges, func(i, j int) bool {
+		a, b := g.Edges[i], g.Edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		return a.Type < b.Type
+	})
+	return g, nil
+}
+
+// graphStatusColor returns the fill color of a node with status s.
+func graphStatusColor(s issuestorage.Status) string {
+	if c, ok := graphStatusColors[s]; ok {
+		return c
+	}
+	return graphStatusColors[issuestorage.StatusOpen]
+}
+
+// writeGraphDOT writes g as a Graphviz digraph. Blockers point at what
+// they block in red, parents at their children dashed, and other links
+// are dotted and labelled with their type.
+func writeGraphDOT(w io.Writer, g *graphExport) {
+	fmt.Fprintln(w, "digraph beads {")
+	fmt.Fprintln(w, "\trankdir=LR;")
+	fmt.Fprintln(w, `	node [shape=box, style="rounded,filled", fontname="Helvetica"];`)
+	for _, n := range g.Nodes {
+		style := ""
+		if g.External[n.ID] {
+			style = `, style="rounded,filled,dashed"`
+		}
+		font := ""
+		if n.Status == issuestorage.StatusClosed {
+			font = `, fontcolor="#7f8c8d"`
+		}
+		label := dotEscape(n.ID) + `\n` + dotEscape(truncateDesc(n.Title, graphExportTitleWidth))
+		fmt.Fprintf(w, "\t\"%s\" [label=\"%s\", fillcolor=\"%s\"%s%s];\n", dotEscape(n.ID), label, graphStatusColor(n.Status), style, font)
+	}
+	for _, e := range g.Edges {
+		var attrs string
+		switch e.Type {
+		case issuestorage.DepTypeBlocks:
+			attrs = `color="#c0392b"`
+		case issuestorage.DepTypeParentChild:
+			attrs = `style=dashed, color="#7f8c8d", arrowhead=none`
+		default:
+			attrs = fmt.Sprintf(`style=dotted, label="%s"`, dotEscape(string(e.Type)))
+		}
+		fmt.Fprintf(w, "\t\"%s\" -> \"%s\" [%s];\n", dotEscape(e.From), dotEscape(e.To), attrs)
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// dotEscape escapes s for a double-quoted DOT string.
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", " ").Replace(s)
+}
+
+// writeGraphMermaid writes g as a Mermaid flowchart, with the same edge
+// styles as writeGraphDOT as far as Mermaid allows: thick arrows for
+// blocks, dotted lines for parent-child, labelled arrows for the rest.
+func writeGraphMermaid(w io.Writer, g *graphExport) {
+	fmt.Fprintln(w, "flowchart LR")
+	// Issue IDs may contain dots, which Mermaid does not allow in node IDs.
+	alias := make(map[string]string, len(g.Nodes))
+	classes := make(map[issuestorage.Status][]string)
+	var external []string
+	for i, n := range g.Nodes {
+		a := fmt.Sprintf("n%d", i)
+		alias[n.ID] = a
+		classes[n.Status] = append(classes[n.Status], a)
+		if g.External[n.ID] {
+			external = append(external, a)
+		}
+		fmt.Fprintf(w, "\t%s[\"%s: %s\"]\n", a, mermaidEscape(n.ID), mermaidEscape(truncateDesc(n.Title, graphExportTitleWidth)))
+	}
+	for _, e := range g.Edges {
+		switch e.Type {
+		case issuestorage.DepTypeBlocks:
+			fmt.Fprintf(w, "\t%s ==> %s\n", alias[e.From], alias[e.To])
+		case issuestorage.DepTypeParentChild:
+			fmt.Fprintf(w, "\t%s -.- %s\n", alias[e.From], alias[e.To])
+		default:
+			fmt.Fprintf(w, "\t%s -.->|%s| %s\n", alias[e.From], mermaidEscape(string(e.Type)), alias[e.To])
+		}
+	}
+	statuses := make([]string, 0, len(classes))
+	for s := range classes {
+		statuses = append(statuses, string(s))
+	}
+	sort.Strings(statuses)
+	for _, s := range statuses {
+		status := issuestorage.Status(s)
+		color := ""
+		if status == issuestorage.StatusClosed {
+			color = ",color:#7f8c8d"
+		}
+		fmt.Fprintf(w, "\tclassDef %s fill:%s%s\n", s, graphStatusColor(status), color)
+		fmt.Fprintf(w, "\tclass %s %s\n", strings.Join(classes[status], ","), s)
+	}
+	if len(external) > 0 {
+		fmt.Fprintln(w, "\tclassDef external stroke-dasharray:5 5")
+		fmt.Fprintf(w, "\tclass %s external\n", strings.Join(external, ","))
+	}
+}
+
+// mermaidEscape makes s safe inside a Mermaid label.
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "|", "#124;", "\n", " ").Replace(s)
+}
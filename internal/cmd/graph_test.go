@@ -194,3 +194,116 @@ func TestGraphWavesFlagText(t *testing.T) {
 		t.Fatalf("expected both task IDs in output, got:\n%s", got)
 	}
 }
+
+func TestGraphTracksEdges(t *testing.T) {
+	app, rs := setupTestApp(t)
+	ctx := context.Background()
+
+	epicA, _ := rs.Create(ctx, &issuestorage.Issue{Title: "Epic A", Type: issuestorage.TypeEpic})
+	epicB, _ := rs.Create(ctx, &issuestorage.Issue{Title: "Epic B", Type: issuestorage.TypeEpic})
+	shared, _ := rs.Create(ctx, &issuestorage.Issue{Title: "Shared work"})
+	for _, epic := range []string{epicA, epicB} {
+		if err := rs.AddDependency(ctx, epic, shared, issuestorage.DepTypeTracks); err != nil {
+			t.Fatalf("add tracks %s->%s: %v", epic, shared, err)
+		}
+	}
+
+	cmd := newGraphCmd(NewTestProvider(app))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("graph failed: %v", err)
+	}
+	out := app.Out.(*bytes.Buffer).String()
+	if !strings.Contains(out, "Tracked (non-hierarchical)") || strings.Count(out, "⇢ ○ "+shared) != 2 {
+		t.Errorf("expected shared issue under both tracking epics, got:\n%s", out)
+	}
+
+	app.Out = &bytes.Buffer{}
+	app.JSON = true
+	cmd = newGraphCmd(NewTestProvider(app))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("graph --json failed: %v", err)
+	}
+	var got GraphOutputJSON
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &got); err != nil {
+		t.Fatalf("parse JSON: %v", err)
+	}
+	if len(got.Tracks) != 2 || got.Tracks[0].Tracked[0] != shared || got.Tracks[1].Tracked[0] != shared {
+		t.Errorf("unexpected tracks: %+v", got.Tracks)
+	}
+	if len(got.Groups) != 0 {
+		t.Errorf("tracks edges must not create parent groups, got %+v", got.Groups)
+	}
+}
+
+func TestGraphExportFormats(t *testing.T) {
+	app, rs := setupTestApp(t)
+	ctx := context.Background()
+
+	epic, _ := rs.Create(ctx, &issuestorage.Issue{Title: "Epic", Type: issuestorage.TypeEpic})
+	first, _ := rs.Create(ctx, &issuestorage.Issue{Title: `Say "hi"`, Status: issuestorage.StatusInProgress})
+	second, _ := rs.Create(ctx, &issuestorage.Issue{Title: "Second"})
+	done, _ := rs.Create(ctx, &issuestorage.Issue{Title: "Done", Status: issuestorage.StatusClosed})
+	infra, _ := rs.Create(ctx, &issuestorage.Issue{Title: "Infra"})
+	unrelated, _ := rs.Create(ctx, &issuestorage.Issue{Title: "Unrelated"})
+	for _, child := range []string{first, second, done} {
+		if err := rs.AddDependency(ctx, child, epic, issuestorage.DepTypeParentChild); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rs.AddDependency(ctx, second, first, issuestorage.DepTypeBlocks)
+	rs.AddDependency(ctx, first, infra, issuestorage.DepTypeBlocks)
+	rs.AddDependency(ctx, second, done, issuestorage.DepTypeRelated)
+
+	run := func(args ...string) string {
+		t.Helper()
+		app.Out = &bytes.Buffer{}
+		cmd := newGraphCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("graph %v: %v", args, err)
+		}
+		return app.Out.(*bytes.Buffer).String()
+	}
+
+	dot := run(epic, "--format", "dot")
+	for _, want := range []string{
+		"digraph beads {",
+		`"` + first + `" [label="` + first + `\nSay \"hi\"", fillcolor="#fff3b0"];`,
+		`"` + infra + `" [label="` + infra + `\nInfra", fillcolor="#ffffff", style="rounded,filled,dashed"];`,
+		`"` + first + `" -> "` + second + `" [color="#c0392b"];`,
+		`"` + infra + `" -> "` + first + `" [color="#c0392b"];`,
+		`"` + epic + `" -> "` + second + `" [style=dashed, color="#7f8c8d", arrowhead=none];`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("dot output missing %s:\n%s", want, dot)
+		}
+	}
+	for _, absent := range []string{done, unrelated} {
+		if strings.Contains(dot, absent) {
+			t.Errorf("dot output includes %s:\n%s", absent, dot)
+		}
+	}
+
+	mermaid := run(epic, "--format", "mermaid", "--all")
+	for _, want := range []string{
+		"flowchart LR",
+		`["` + first + `: Say #quot;hi#quot;"]`,
+		"-.->|related|",
+		"classDef closed fill:#d5f5e3,color:#7f8c8d",
+		"classDef external stroke-dasharray:5 5",
+	} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("mermaid output missing %s:\n%s", want, mermaid)
+		}
+	}
+
+	if all := run("--format", "dot"); !strings.Contains(all, unrelated) || strings.Contains(all, done) {
+		t.Errorf("project-wide dot output should include %s but not closed %s:\n%s", unrelated, done, all)
+	}
+
+	cmd := newGraphCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--format", "svg"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
 	"strings"
+
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/notify"
 )
 
 // resolveActor determines the current actor identity (a name/identifier).
@@ -65,3 +69,81 @@ func resolveOwner() string {
 
 	return ""
 }
+
+// newNotifier returns the desktop notifier configured by notify.* keys, or
+// nil when notifications are disabled. Config errors are reported as a
+// warning rather than failing the command that would have notified.
+func newNotifier(app *App) *notify.Notifier {
+	if app.Notifier != nil {
+		return app.Notifier
+	}
+	if app.ConfigStore == nil {
+		return nil
+	}
+	n, err := notify.FromConfig(app.ConfigStore.Get)
+	if err != nil {
+		fmt.Fprintf(app.Err, "warning: notifications disabled: %v\n", err)
+		return nil
+	}
+	return n
+}
+
+// notifyAssigned sends an "assigned" notification when an issue's assignee
+// changed from previous to the local actor. The commands that change an
+// assignee (update, create, sync) call this, and bd watch --notify for
+// changes made elsewhere.
+func notifyAssigned(app *App, issueID, title, previous, assignee string) {
+	if assignee == "" || assignee == previous {
+		return
+	}
+	notifier := newNotifier(app)
+	if notifier == nil {
+		return
+	}
+	actor, err := resolveActor(app)
+	if err != nil || !issuestorage.MentionMatches(assignee, actor) {
+		return
+	}
+	ev := notify.Event{Kind: notify.KindAssigned, IssueID: issueID, Title: title, Detail: "assigned to " + assignee}
+	if err := notifier.Notify(ev); err != nil {
+		fmt.Fprintf(app.Err, "warning: %v\n", err)
+	}
+}
+
+// needsInfoLabel marks an issue that is waiting on its reporter for more
+// information.
+const needsInfoLabel = "needs-info"
+
+// notifyNeedsInfo sends a "needs_info" notification when issue, which the
+// local actor reported, gains the needs-info label, unless the actor added
+// it. prevLabels are the issue's labels before the change.
+func notifyNeedsInfo(app *App, issue *issuestorage.Issue, prevLabels []string) {
+	if !contains(issue.Labels, needsInfoLabel) || contains(prevLabels, needsInfoLabel) {
+		return
+	}
+	notifier := newNotifier(app)
+	if notifier == nil {
+		return
+	}
+	actor, err := resolveActor(app)
+	if err != nil || !issuestorage.MentionMatches(issue.ReportedBy(), actor) {
+		return
+	}
+	var labeledBy string
+	for _, h := range issue.History {
+		if h.Event == issuestorage.EventLabeled && h.New == needsInfoLabel {
+			labeledBy = h.Actor
+		}
+	}
+	if labeledBy != "" && issuestorage.MentionMatches(actor, labeledBy) {
+		return
+	}
+	detail := "waiting on you as reporter"
+	if labeledBy != "" {
+		detail = labeledBy + " is waiting on you as reporter"
+	}
+	ev := notify.Event{Kind: notify.KindNeedsInfo, IssueID: issue.ID, Title: issue.Title, Detail: detail}
+	if err := notifier.Notify(ev); err != nil {
+		fmt.Fprintf(app.Err, "warning: %v\n", err)
+	}
+}
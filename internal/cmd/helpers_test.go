@@ -4,6 +4,8 @@ import (
 	"os/exec"
 	"strings"
 	"testing"
+
+	"beads-lite/internal/notify"
 )
 
 func TestResolveActorFromConfigStore(t *testing.T) {
@@ -185,3 +187,14 @@ func TestResolveActorBD_ACTORViaCOnfigStore(t *testing.T) {
 		t.Errorf("expected %q, got %q", "bd-actor-val", got)
 	}
 }
+
+// captureNotifications makes app deliver every notification kind to the
+// returned slice, as "title: body", instead of the desktop.
+func captureNotifications(app *App) *[]string {
+	var sent []string
+	app.Notifier = notify.NewWithExecutor("linux", func(name string, args ...string) error {
+		sent = append(sent, args[len(args)-2]+": "+args[len(args)-1])
+		return nil
+	})
+	return &sent
+}
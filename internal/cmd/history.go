@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// eventCreated is the event bd history and bd activity show for an
+// issue's creation, which is taken from CreatedAt rather than recorded.
+const eventCreated = "created"
+
+// HistoryEventJSON is one event in bd history and bd activity output.
+type HistoryEventJSON struct {
+	IssueID string `json:"issue_id"`
+	At      string `json:"at"`
+	Actor   string `json:"actor,omitempty"`
+	Event   string `json:"event"`
+	Field   string `json:"field,omitempty"`
+	Old     string `json:"old,omitempty"`
+	New     string `json:"new,omitempty"`
+	Note    string `json:"note,omitempty"`
+}
+
+// issueEvents returns issue's history, oldest first, starting with its
+// creation.
+func issueEvents(issue *issuestorage.Issue) []issuestorage.HistoryEntry {
+	events := append([]issuestorage.HistoryEntry{{At: issue.CreatedAt, Actor: issue.CreatedBy, Event: eventCreated}}, issue.History...)
+	sort.SliceStable(events, func(i, j int) bool { return events[i].At.Before(events[j].At) })
+	return events
+}
+
+func toHistoryEventJSON(issueID string, h issuestorage.HistoryEntry) HistoryEventJSON {
+	return HistoryEventJSON{
+		IssueID: issueID,
+		At:      formatTime(h.At),
+		Actor:   h.Actor,
+		Event:   h.Event,
+		Field:   h.Field,
+		Old:     h.Old,
+		New:     h.New,
+		Note:    h.Note,
+	}
+}
+
+// describeEvent says what a history entry records, e.g. "status open →
+// closed" or "added blocks dependency on bd-a1b2".
+func describeEvent(h issuestorage.HistoryEntry) string {
+	withNote := func(s string) string {
+		if h.Note != "" {
+			return s + " (" + h.Note + ")"
+		}
+		return s
+	}
+	switch h.Event {
+	case eventCreated:
+		return "created"
+	case issuestorage.EventReopened:
+		return fmt.Sprintf("reopened (%s → %s)", h.Old, h.New)
+	case issuestorage.EventStatus:
+		return fmt.Sprintf("status %s → %s", h.Old, h.New)
+	case issuestorage.EventAssigned:
+		if h.New == "" {
+			return "unassigned " + h.Old
+		}
+		return "assigned to " + h.New
+	case issuestorage.EventAutoAssigned:
+		return withNote("auto-assigned to " + h.New)
+	case issuestorage.EventLabeled:
+		return "labeled " + h.New
+	case issuestorage.EventUnlabeled:
+		return "unlabeled " + h.Old
+	case issuestorage.EventLabelExpired:
+		return withNote("label " + h.Old + " expired")
+	case issuestorage.EventTimeLogged:
+		minutes, _ := strconv.Atoi(h.New)
+		return withNote("logged " + formatEffort(minutes))
+	case issuestorage.EventCommented:
+		return "commented #" + h.New
+	case issuestorage.EventDepAdded:
+		return fmt.Sprintf("added %s dependency on %s", h.Field, h.New)
+	case issuestorage.EventDepRemoved:
+		return fmt.Sprintf("removed %s dependency on %s", h.Field, h.Old)
+	case issuestorage.EventUpdated:
+		switch {
+		case h.Old == "" && h.New == "":
+			return "changed " + h.Field
+		case h.Old == "":
+			return fmt.Sprintf("set %s to %s", h.Field, h.New)
+		case h.New == "":
+			return fmt.Sprintf("cleared %s (was %s)", h.Field, h.Old)
+		}
+		return fmt.Sprintf("%s %s → %s", h.Field, h.Old, h.New)
+	case issuestorage.EventDoctorFix:
+		return fmt.Sprintf("doctor fixed %s: %s → %s", h.Field, h.Old, h.New)
+	}
+	return withNote(h.Event + " " + h.Field)
+}
+
+// writeEventLine prints an event as "time  [issue]  actor  description";
+// issueID is left out when empty.
+func writeEventLine(w io.Writer, issueID string, h issuestorage.HistoryEntry) {
+	actor := h.Actor
+	if actor == "" {
+		actor = "-"
+	}
+	if issueID != "" {
+		fmt.Fprintf(w, "%s  %-10s  %-12s  %s\n", h.At.Format("2006-01-02 15:04"), issueID, actor, describeEvent(h))
+		return
+	}
+	fmt.Fprintf(w, "%s  %-12s  %s\n", h.At.Format("2006-01-02 15:04"), actor, describeEvent(h))
+}
+
+// newHistoryCmd creates the history command.
+func newHistoryCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history <issue-id>",
+		Short: "Show what happened to an issue",
+		Long: `Show an issue's change history, oldest first: when it was created, and
+each status change, field edit, label, assignment, comment, dependency
+and time log since, with who made it.
+
+History is kept in the issue file and merged like comments. Changes made
+before history was recorded show only as the issue's creation.
+
+Examples:
+  bd history bd-a1b2
+  bd history bd-a1b2 --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			issue, err := resolveIssue(app.Storage, cmd.Context(), args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+			events := issueEvents(issue)
+
+			if app.JSON {
+				out := make([]HistoryEventJSON, len(events))
+				for i, h := range events {
+					out[i] = toHistoryEventJSON(issue.ID, h)
+				}
+				return json.NewEncoder(app.Out).Encode(out)
+			}
+			fmt.Fprintf(app.Out, "%s: %s\n\n", issue.ID, issue.Title)
+			for _, h := range events {
+				writeEventLine(app.Out, "", h)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
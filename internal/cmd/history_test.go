@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/clock"
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+func TestHistoryAndActivity(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC))
+	store.SetClock(fake)
+	store.SetActor(func() string { return "alice" })
+	out := app.Out.(*bytes.Buffer)
+
+	old, err := store.Create(ctx, &issuestorage.Issue{Title: "Old work", CreatedBy: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake.Advance(10 * 24 * time.Hour)
+	id, err := store.Create(ctx, &issuestorage.Issue{Title: "Search", CreatedBy: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake.Advance(time.Hour)
+	if err := store.AddDependency(ctx, id, old, issuestorage.DepTypeBlocks); err != nil {
+		t.Fatal(err)
+	}
+	store.SetActor(func() string { return "bob" })
+	if err := store.Modify(ctx, id, func(i *issuestorage.Issue) error {
+		i.Status = issuestorage.StatusClosed
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(newCmd func(*AppProvider) *cobra.Command, args ...string) string {
+		t.Helper()
+		out.Reset()
+		cmd := newCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+		return out.String()
+	}
+
+	text := run(newHistoryCmd, id)
+	for _, want := range []string{
+		"2026-10-11 09:00  alice         created",
+		"2026-10-11 10:00  alice         added blocks dependency on " + old,
+		"2026-10-11 10:00  bob           status open → closed",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("history missing %q:\n%s", want, text)
+		}
+	}
+
+	// The last 7 days leave out the old issue's creation.
+	text = run(newActivityCmd)
+	if strings.Contains(text, "Old work") || strings.Count(text, "\n") != 3 || !strings.Contains(text, "bob           status open → closed") {
+		t.Errorf("activity:\n%s", text)
+	}
+
+	app.JSON = true
+	var events []HistoryEventJSON
+	if err := json.Unmarshal([]byte(run(newActivityCmd, "--since", "2026-09-01", "--actor", "alice")), &events); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 3 || events[0].IssueID != old || events[0].Event != "created" || events[2].Event != issuestorage.EventDepAdded {
+		t.Errorf("activity --json = %+v", events)
+	}
+
+	cmd := newActivityCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--since", "soon"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected an error for --since soon")
+	}
+}
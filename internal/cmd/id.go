@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"beads-lite/internal/alias"
+	"beads-lite/internal/kvstorage"
+	"beads-lite/internal/routing"
+
+	"github.com/spf13/cobra"
+)
+
+// AliasJSON is the JSON output of bd id vanity and bd id aliases.
+type AliasJSON struct {
+	Alias     string `json:"alias"`
+	IssueID   string `json:"issue_id"`
+	Previous  string `json:"previous,omitempty"` // the alias it replaced
+	Removed   bool   `json:"removed,omitempty"`  // set by bd id vanity --clear
+	CreatedAt string `json:"created_at,omitempty"`
+	CreatedBy string `json:"created_by,omitempty"`
+}
+
+func toAliasJSON(a alias.Alias) AliasJSON {
+	out := AliasJSON{Alias: a.Name, IssueID: a.IssueID, CreatedBy: a.CreatedBy}
+	if !a.CreatedAt.IsZero() {
+		out.CreatedAt = formatTime(a.CreatedAt)
+	}
+	return out
+}
+
+// newIDCmd creates the id command group.
+func newIDCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "id",
+		Short: "Manage issue IDs and vanity aliases",
+		Long: `Manage vanity aliases: stable, readable names such as bd-login-rewrite
+that are accepted wherever an issue ID is. They are stored under
+.beads/aliases/, and dependencies made through an alias record the
+issue's real ID.`,
+	}
+
+	cmd.AddCommand(newIDVanityCmd(provider))
+	cmd.AddCommand(newIDAliasesCmd(provider))
+
+	return cmd
+}
+
+// newIDVanityCmd creates the "id vanity" subcommand.
+func newIDVanityCmd(provider *AppProvider) *cobra.Command {
+	var clearAlias bool
+
+	cmd := &cobra.Command{
+		Use:   "vanity <issue-id> [slug]",
+		Short: "Give an issue a vanity alias",
+		Long: `Give an issue a stable alias made of its prefix and slug, such as
+bd-login-rewrite for slug login-rewrite. An issue has at most one alias:
+giving it a new one renames the old, which stops resolving. --clear
+removes the issue's alias.
+
+Slugs use lowercase letters, digits and '-'. An alias cannot be an
+existing issue ID or another issue's alias, and cannot have the shape of
+a generated ID (such as bd-abc or bd-mol-a1b2), which a new issue could
+be given as the adaptive ID length changes.
+
+Examples:
+  bd id vanity bd-a1b2 login-rewrite
+  bd id vanity bd-login-rewrite auth-rewrite
+  bd id vanity bd-a1b2 --clear`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if clearAlias {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			return cobra.ExactArgs(2)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			issue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+
+			if clearAlias {
+				current, err := alias.ForIssue(ctx, app.AliasStore, issue.ID)
+				if errors.Is(err, kvstorage.ErrKeyNotFound) {
+					return fmt.Errorf("%s has no alias", issue.ID)
+				}
+				if err != nil {
+					return err
+				}
+				removed, err := alias.Remove(ctx, app.AliasStore, current.Name)
+				if err != nil {
+					return err
+				}
+				if app.JSON {
+					out := toAliasJSON(removed)
+					out.Removed = true
+					return json.NewEncoder(app.Out).Encode(out)
+				}
+				fmt.Fprintf(app.Out, "%s Removed alias %s from %s\n", app.SuccessColor("✓"), removed.Name, issue.ID)
+				return nil
+			}
+
+			prefix := routing.ExtractPrefix(issue.ID)
+			name := alias.Name(prefix, args[1])
+			if err := alias.ValidateName(prefix, name); err != nil {
+				return err
+			}
+			if other, err := app.Storage.Get(ctx, name); err == nil && other.ID == name {
+				return fmt.Errorf("alias %s is already an issue ID", name)
+			}
+			// Repositories initialized before aliases existed have no
+			// table directory yet.
+			if s, ok := app.AliasStore.(interface{ Init(context.Context) error }); ok {
+				if err := s.Init(ctx); err != nil {
+					return fmt.Errorf("initializing alias store: %w", err)
+				}
+			}
+			actor, _ := resolveActor(app)
+			previous, err := alias.Set(ctx, app.AliasStore, alias.Alias{
+				Name:      name,
+				IssueID:   issue.ID,
+				CreatedAt: app.Now(),
+				CreatedBy: actor,
+			})
+			if err != nil {
+				return err
+			}
+			a, err := alias.Get(ctx, app.AliasStore, name)
+			if err != nil {
+				return err
+			}
+
+			if app.JSON {
+				out := toAliasJSON(a)
+				if previous != nil {
+					out.Previous = previous.Name
+				}
+				return json.NewEncoder(app.Out).Encode(out)
+			}
+			if previous != nil {
+				fmt.Fprintf(app.Out, "%s Renamed alias %s → %s for %s\n", app.SuccessColor("✓"), previous.Name, name, issue.ID)
+				return nil
+			}
+			fmt.Fprintf(app.Out, "%s %s is now an alias for %s\n", app.SuccessColor("✓"), name, issue.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&clearAlias, "clear", false, "Remove the issue's alias")
+
+	return cmd
+}
+
+// newIDAliasesCmd creates the "id aliases" subcommand.
+func newIDAliasesCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "aliases",
+		Short: "List vanity aliases",
+		Long: `List every vanity alias with the issue it stands for. Aliases whose
+issue no longer exists are marked missing.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			aliases, err := alias.List(ctx, app.AliasStore)
+			if err != nil {
+				return err
+			}
+
+			if app.JSON {
+				out := make([]AliasJSON, len(aliases))
+				for i, a := range aliases {
+					out[i] = toAliasJSON(a)
+				}
+				return json.NewEncoder(app.Out).Encode(out)
+			}
+			if len(aliases) == 0 {
+				fmt.Fprintln(app.Out, "No aliases. Add one with: bd id vanity <issue-id> <slug>")
+				return nil
+			}
+			for _, a := range aliases {
+				issue, err := app.Storage.Get(ctx, a.IssueID)
+				if err != nil {
+					fmt.Fprintf(app.Out, "%s → %s (missing)\n", a.Name, a.IssueID)
+					continue
+				}
+				fmt.Fprintf(app.Out, "%s → %s %s\n", a.Name, issue.ID, issue.Title)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
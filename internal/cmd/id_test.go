@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/alias"
+	"beads-lite/internal/issuestorage"
+	kvfs "beads-lite/internal/kvstorage/filesystem"
+
+	"github.com/spf13/cobra"
+)
+
+func TestIDVanity(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	// Left uninitialized: bd id vanity makes the table directory.
+	aliasStore, err := kvfs.New(t.TempDir(), "aliases")
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.AliasStore = aliasStore
+	store.SetAliases(alias.NewLookup(aliasStore))
+
+	exec := func(newCmd func(*AppProvider) *cobra.Command, args ...string) (string, error) {
+		t.Helper()
+		out := &bytes.Buffer{}
+		app.Out = out
+		cmd := newCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+	run := func(newCmd func(*AppProvider) *cobra.Command, args ...string) string {
+		t.Helper()
+		out, err := exec(newCmd, args...)
+		if err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+		return out
+	}
+
+	login := createAssigned(t, store, "Rewrite login", "", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	other := createAssigned(t, store, "Session store", "", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+
+	out := run(newIDCmd, "vanity", login, "login-rewrite")
+	if !strings.Contains(out, "bd-login-rewrite is now an alias for "+login) {
+		t.Errorf("unexpected output: %s", out)
+	}
+
+	// The alias works wherever an ID does, and dependencies record the
+	// real ID.
+	if out := run(newShowCmd, "bd-login-rewrite"); !strings.Contains(out, "Rewrite login") || !strings.Contains(out, "Alias: bd-login-rewrite") {
+		t.Errorf("show through alias: %s", out)
+	}
+	run(newDepCmd, "add", other, "bd-login-rewrite")
+	got, err := store.Get(ctx, other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Dependencies) != 1 || got.Dependencies[0].ID != login {
+		t.Errorf("dependency should be on %s, got %+v", login, got.Dependencies)
+	}
+	run(newUpdateCmd, "bd-login-rewrite", "--priority", "1")
+	if got, _ := store.Get(ctx, login); got.Priority != issuestorage.PriorityHigh {
+		t.Errorf("update through alias: priority = %d", got.Priority)
+	}
+
+	// Collisions: another issue's alias, an issue ID, and an ID-shaped slug.
+	if _, err := exec(newIDCmd, "vanity", other, "login-rewrite"); err == nil || !strings.Contains(err.Error(), "already points to") {
+		t.Errorf("taking another issue's alias: %v", err)
+	}
+	if _, err := exec(newIDCmd, "vanity", other, login); err == nil {
+		t.Error("an issue ID should not be accepted as an alias")
+	}
+	if _, err := exec(newIDCmd, "vanity", other, "login"); err == nil || !strings.Contains(err.Error(), "generated ID") {
+		t.Errorf("an ID-shaped alias: %v", err)
+	}
+
+	// Renaming through the old alias.
+	out = run(newIDCmd, "vanity", "bd-login-rewrite", "auth-rewrite")
+	if !strings.Contains(out, "Renamed alias bd-login-rewrite → bd-auth-rewrite for "+login) {
+		t.Errorf("unexpected rename output: %s", out)
+	}
+	if _, err := exec(newShowCmd, "bd-login-rewrite"); err == nil {
+		t.Error("the old alias should no longer resolve")
+	}
+
+	app.JSON = true
+	var aliases []AliasJSON
+	if err := json.Unmarshal([]byte(run(newIDCmd, "aliases")), &aliases); err != nil {
+		t.Fatal(err)
+	}
+	if len(aliases) != 1 || aliases[0].Alias != "bd-auth-rewrite" || aliases[0].IssueID != login {
+		t.Errorf("aliases = %+v", aliases)
+	}
+	var removed AliasJSON
+	if err := json.Unmarshal([]byte(run(newIDCmd, "vanity", login, "--clear")), &removed); err != nil {
+		t.Fatal(err)
+	}
+	if !removed.Removed || removed.Alias != "bd-auth-rewrite" {
+		t.Errorf("clear = %+v", removed)
+	}
+	if _, err := exec(newIDCmd, "vanity", login, "--clear"); err == nil {
+		t.Error("clearing an issue without an alias should fail")
+	}
+}
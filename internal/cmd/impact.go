@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"beads-lite/internal/graph"
+
+	"github.com/spf13/cobra"
+)
+
+// ImpactJSON is the JSON output of bd impact.
+type ImpactJSON struct {
+	ID       string            `json:"id"`
+	Count    int               `json:"count"`            // open issues blocked, directly or transitively
+	Direct   int               `json:"direct"`           // of which blocked by the issue itself
+	MaxDepth int               `json:"max_depth"`        // longest chain of blocking links followed
+	Effort   *EffortJSON       `json:"effort,omitempty"` // totals over the blocked issues, when any have estimates
+	Issues   []ImpactIssueJSON `json:"issues"`
+}
+
+// ImpactIssueJSON is an issue blocked by the one bd impact was run on.
+type ImpactIssueJSON struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Status    string `json:"status"`
+	Priority  int    `json:"priority"`
+	IssueType string `json:"issue_type"`
+	Assignee  string `json:"assignee,omitempty"`
+	Depth     int    `json:"depth"` // 1 if blocked directly
+}
+
+// newImpactCmd creates the impact command.
+func newImpactCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "impact <issue-id>",
+		Short: "List everything an issue blocks, directly or transitively",
+		Long: `List the open issues that cannot become ready until an issue closes:
+those it blocks, those they block in turn, and so on. Each is shown with
+its depth, the number of blocking links between it and the issue.
+
+With graph.cascade_parent_blocking on (the default) the descendants of a
+blocked issue are blocked too, at its depth.
+
+When blocked issues have estimates (bd update --estimate), the effort
+remaining on them is totalled: the work held up until the issue closes.
+
+Examples:
+  bd impact bd-a1b2
+  bd impact bd-a1b2 --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			issue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+			closedSet, err := graph.BuildClosedSet(ctx, app.Storage)
+			if err != nil {
+				return err
+			}
+			impacted, err := graph.Impact(ctx, app.Storage, issue, closedSet, cascadeEnabled(app))
+			if err != nil {
+				return err
+			}
+
+			result := ImpactJSON{ID: issue.ID, Count: len(impacted), Issues: []ImpactIssueJSON{}}
+			var effort graph.Effort
+			for _, i := range impacted {
+				effort.Add(i.Issue)
+				if i.Depth == 1 {
+					result.Direct++
+				}
+				result.MaxDepth = max(result.MaxDepth, i.Depth)
+				result.Issues = append(result.Issues, ImpactIssueJSON{
+					ID:        i.Issue.ID,
+					Title:     i.Issue.Title,
+					Status:    string(i.Issue.Status),
+					Priority:  priorityToInt(i.Issue.Priority),
+					IssueType: string(i.Issue.Type),
+					Assignee:  i.Issue.Assignee,
+					Depth:     i.Depth,
+				})
+			}
+
+			if effort.Any() {
+				result.Effort = toEffortJSON(effort)
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(result)
+			}
+
+			if len(impacted) == 0 {
+				fmt.Fprintf(app.Out, "%s blocks no open issues.\n", issue.ID)
+				return nil
+			}
+			fmt.Fprintf(app.Out, "%s blocks %d open issues (%d directly, up to %d deep):\n", issue.ID, result.Count, result.Direct, result.MaxDepth)
+			if result.Effort != nil {
+				fmt.Fprintf(app.Out, "Effort held up: %s\n", formatEffortTotals(effort))
+			}
+			depth := 0
+			for _, i := range impacted {
+				if i.Depth != depth {
+					depth = i.Depth
+					fmt.Fprintf(app.Out, "\n  Depth %d:\n", depth)
+				}
+				fmt.Fprintf(app.Out, "    %s\n", formatIssueLine(app, i.Issue))
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
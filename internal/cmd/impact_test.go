@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestImpactCommand(t *testing.T) {
+	app, rs := setupTestApp(t)
+	ctx := context.Background()
+	create := func(title string, blockers ...string) string {
+		t.Helper()
+		id, err := rs.Create(ctx, &issuestorage.Issue{Title: title})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, b := range blockers {
+			if err := rs.AddDependency(ctx, id, b, issuestorage.DepTypeBlocks); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return id
+	}
+	design := create("Design")
+	build := create("Build", design)
+	test := create("Test", build)
+	release := create("Release", build, test)
+	done := create("Done", design)
+	if err := rs.Modify(ctx, done, func(i *issuestorage.Issue) error { i.Status = issuestorage.StatusClosed; return nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	out := app.Out.(*bytes.Buffer)
+	app.JSON = true
+	cmd := newImpactCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{design})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	var result ImpactJSON
+	if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	depths := make(map[string]int)
+	for _, i := range result.Issues {
+		depths[i.ID] = i.Depth
+	}
+	if result.Count != 3 || result.Direct != 1 || result.MaxDepth != 2 ||
+		depths[build] != 1 || depths[test] != 2 || depths[release] != 2 {
+		t.Errorf("impact = %+v, want build at 1, test and release at 2, closed issue left out", result)
+	}
+}
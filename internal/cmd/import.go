@@ -1,18 +1,31 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"beads-lite/internal/importer"
+	"beads-lite/internal/issuestorage"
 
 	"github.com/spf13/cobra"
 )
 
 // newImportCmd creates the import command.
 // In beads-lite the storage layer is filesystem-based with no separate import
-// step, so this command is a no-op accepted for compatibility with the
-// reference implementation which imports from JSONL exports.
+// step, so the bare command is a no-op accepted for compatibility with the
+// reference implementation which imports from JSONL exports. With --format
+// jsonl it restores a bd export --format jsonl file. Subcommands import
+// backlogs kept in other formats.
 func newImportCmd(provider *AppProvider) *cobra.Command {
 	var (
 		inputFile           string
+		format              string
+		from                string
+		dryRun              bool
 		renameOnImport      bool
 		noGitHistory        bool
 		protectLeftSnapshot bool
@@ -20,17 +33,49 @@ func newImportCmd(provider *AppProvider) *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:   "import",
-		Short: "Import beads data (no-op in beads-lite)",
+		Short: "Import beads data (no-op in beads-lite without --format)",
 		Long: `In the reference implementation, import reads issues from a JSONL file
 into the database. beads-lite uses direct filesystem storage and does
-not require a separate import step. This command is accepted for
-compatibility but performs no action.`,
+not require a separate import step. Without --format this command is
+accepted for compatibility but performs no action.
+
+With --format jsonl, restore the issues in a file written by bd export
+--format jsonl (or stdin if --input is not given or is "-"). Issues are
+written exactly as exported, keeping their IDs, timestamps, dependencies,
+comments and history. An issue this store lacks is created; one it
+already has is replaced only if the exported copy was updated later. When
+an ID appears on several lines the last one wins, so an export can be kept
+current by appending to it.
+
+Subcommands convert backlogs kept in other formats into issues:
+  markdown     Import Markdown checkbox lists (e.g. TODO.md)
+  csv          Import spreadsheet rows, mapping columns to fields
+  org          Import org-mode TODO headlines
+  taskwarrior  Import the output of "task export"
+  gitlab       Import GitLab issues (API JSON or a project export)
+  jira         Import Jira issues (REST API JSON or a CSV export)
+
+--from runs a subcommand on --input (or stdin), with --dry-run passed on:
+"bd import --from jira -i issues.csv" is "bd import jira issues.csv".
+
+Examples:
+  bd import --format jsonl -i backup.jsonl
+  bd import --format jsonl -i backup.jsonl --dry-run
+  bd import --from gitlab -i issues.json`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			app, err := provider.Get()
 			if err != nil {
 				return err
 			}
 
+			if format != "" {
+				return runJSONLImport(cmd, app, format, inputFile, dryRun)
+			}
+			if from != "" {
+				return runImportFrom(cmd, from, inputFile, dryRun)
+			}
+
 			if app.JSON {
 				fmt.Fprintln(app.Out, `{"status":"noop","message":"import is not needed in beads-lite"}`)
 				return nil
@@ -41,11 +86,734 @@ compatibility but performs no action.`,
 		},
 	}
 
+	cmd.Flags().StringVarP(&inputFile, "input", "i", "", "Input file (read with --format or --from; stdin if omitted)")
+	cmd.Flags().StringVar(&format, "format", "", "Import a whole-store export in this format (jsonl)")
+	cmd.Flags().StringVar(&from, "from", "", "Import --input with this subcommand (e.g. gitlab, jira)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "With --format or --from, report what would change without writing")
+	cmd.MarkFlagsMutuallyExclusive("format", "from")
+
 	// Compatibility flags — accepted but not used by beads-lite.
-	cmd.Flags().StringVarP(&inputFile, "input", "i", "", "Input JSONL file (accepted for compatibility, no-op)")
 	cmd.Flags().BoolVar(&renameOnImport, "rename-on-import", false, "Accepted for compatibility (no-op)")
 	cmd.Flags().BoolVar(&noGitHistory, "no-git-history", false, "Accepted for compatibility (no-op)")
 	cmd.Flags().BoolVar(&protectLeftSnapshot, "protect-left-snapshot", false, "Accepted for compatibility (no-op)")
 
+	cmd.AddCommand(newImportMarkdownCmd(provider))
+	cmd.AddCommand(newImportCSVCmd(provider))
+	cmd.AddCommand(newImportOrgCmd(provider))
+	cmd.AddCommand(newImportTaskwarriorCmd(provider))
+	cmd.AddCommand(newImportGitLabCmd(provider))
+	cmd.AddCommand(newImportJiraCmd(provider))
+
+	return cmd
+}
+
+// runImportFrom runs the import subcommand named by --from on input.
+func runImportFrom(cmd *cobra.Command, from, input string, dryRun bool) error {
+	var names []string
+	for _, sub := range cmd.Commands() {
+		names = append(names, sub.Name())
+		if sub.Name() != from {
+			continue
+		}
+		if input == "" {
+			input = "-"
+		}
+		if dryRun {
+			if err := sub.Flags().Set("dry-run", "true"); err != nil {
+				return err
+			}
+		}
+		sub.SetContext(cmd.Context())
+		return sub.RunE(sub, []string{input})
+	}
+	return fmt.Errorf("unknown import source %q (valid: %s)", from, strings.Join(names, ", "))
+}
+
+// runJSONLImport restores a bd export --format jsonl file and reports
+// what changed.
+func runJSONLImport(cmd *cobra.Command, app *App, format, input string, dryRun bool) error {
+	if format != formatJSONL {
+		return fmt.Errorf("unknown import format %q (valid: %s; other formats are subcommands)", format, formatJSONL)
+	}
+	source := input
+	if source == "" {
+		source = "-"
+	}
+	r, done, err := openImportSource(cmd, source)
+	if err != nil {
+		return err
+	}
+	defer done()
+	issues, err := readJSONL(r)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", source, err)
+	}
+
+	result, err := importJSONL(cmd.Context(), app, issues, dryRun)
+	if err != nil {
+		return err
+	}
+	if app.JSON {
+		return json.NewEncoder(app.Out).Encode(result)
+	}
+	verb, mark := "Imported", app.SuccessColor("✓")+" "
+	if dryRun {
+		verb, mark = "Would import", ""
+	}
+	fmt.Fprintf(app.Out, "%s%s %d issue(s) from %s: %d created, %d updated, %d unchanged\n",
+		mark, verb, result.Created+result.Updated, source, result.Created, result.Updated, result.Unchanged)
+	return nil
+}
+
+// importOptions controls how imported items become issues.
+type importOptions struct {
+	parent    string // existing issue to create top-level items under
+	issueType issuestorage.IssueType
+	dryRun    bool
+
+	// refKey is the external_refs key items' refs are kept under, such as
+	// "jira"; "" if the source has no IDs of its own.
+	refKey string
+}
+
+// addImportFlags registers the flags shared by the import subcommands.
+func addImportFlags(cmd *cobra.Command, parent, typeFlag *string, dryRun *bool) {
+	cmd.Flags().StringVar(parent, "parent", "", "Create top-level items as children of this issue")
+	cmd.Flags().StringVarP(typeFlag, "type", "t", "task", "Issue type for imported items")
+	cmd.Flags().BoolVar(dryRun, "dry-run", false, "Show what would be imported without creating issues")
+}
+
+// resolveImportOptions validates the shared import flags.
+func resolveImportOptions(ctx context.Context, app *App, parent, typeFlag string, dryRun bool) (importOptions, error) {
+	opts := importOptions{dryRun: dryRun}
+	t, err := parseType(typeFlag, getCustomValues(app, "types.custom"))
+	if err != nil {
+		return opts, err
+	}
+	opts.issueType = t
+	if parent != "" {
+		issue, err := resolveIssue(app.Storage, ctx, parent)
+		if err != nil {
+			return opts, fmt.Errorf("resolving parent %s: %w", parent, err)
+		}
+		opts.parent = issue.ID
+	}
+	return opts, nil
+}
+
+// runImport creates issues for items, or previews them with --dry-run, and
+// reports the result. source names the input in messages.
+func runImport(ctx context.Context, app *App, items []*importer.Item, source string, opts importOptions) error {
+	total := importer.Count(items)
+	if opts.dryRun {
+		if app.JSON {
+			return json.NewEncoder(app.Out).Encode(map[string]any{"dry_run": true, "count": total})
+		}
+		fmt.Fprintf(app.Out, "Would import %d issue(s) from %s:\n", total, source)
+		printImportTree(app, items, nil, 1)
+		return nil
+	}
+
+	actor, _ := resolveActor(app)
+	owner := resolveOwner()
+	ids := make(map[*importer.Item]string, total)
+	var created []string
+	var create func(items []*importer.Item, parent string) error
+	create = func(items []*importer.Item, parent string) error {
+		for _, item := range items {
+			id, err := createImportedIssue(ctx, app, item, parent, opts, actor, owner)
+			if err != nil {
+				return fmt.Errorf("%s line %d (%q): %w; %d issue(s) created before the error", source, item.Line, item.Title, err, len(created))
+			}
+			ids[item] = id
+			created = append(created, id)
+			if err := create(item.Children, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := create(items, opts.parent); err != nil {
+		return err
+	}
+	links, err := linkImportedIssues(ctx, app, ids)
+	if err != nil {
+		return fmt.Errorf("%s: %w; %d issue(s) created", source, err, len(created))
+	}
+
+	if app.JSON {
+		out := make([]IssueListJSON, 0, len(created))
+		for _, id := range created {
+			issue, err := app.Storage.Get(ctx, id)
+			if err != nil {
+				return fmt.Errorf("fetching imported issue %s: %w", id, err)
+			}
+			out = append(out, ToIssueListJSON(issue))
+		}
+		return json.NewEncoder(app.Out).Encode(out)
+	}
+	fmt.Fprintf(app.Out, "%s Imported %d issue(s) from %s", app.SuccessColor("✓"), total, source)
+	if links > 0 {
+		fmt.Fprintf(app.Out, " with %d link(s)", links)
+	}
+	fmt.Fprintln(app.Out)
+	printImportTree(app, items, ids, 1)
+	return nil
+}
+
+// createImportedIssue creates the issue for one item, under parent if set.
+// Done items are created already closed.
+func createImportedIssue(ctx context.Context, app *App, item *importer.Item, parent string, opts importOptions, actor, owner string) (string, error) {
+	issue := &issuestorage.Issue{
+		Title:       item.Title,
+		Description: item.Description,
+		Type:        opts.issueType,
+		Priority:    issuestorage.PriorityMedium,
+		CreatedBy:   actor,
+		Owner:       owner,
+		Labels:      item.Labels,
+		Assignee:    item.Assignee,
+		DueAt:       item.Due,
+		DeferUntil:  item.Scheduled,
+	}
+	if item.Type != "" {
+		t, err := parseType(item.Type, getCustomValues(app, "types.custom"))
+		if err != nil {
+			return "", err
+		}
+		issue.Type = t
+	}
+	createOpts := issuestorage.CreateOpts{PrioritySet: item.Priority != nil}
+	if item.Priority != nil {
+		issue.Priority = *item.Priority
+	}
+	if item.Done {
+		now := app.Now()
+		issue.Status = issuestorage.StatusClosed
+		issue.ClosedAt = &now
+		issue.CloseReason = "Imported as done"
+	}
+	if item.Ref != "" && opts.refKey != "" {
+		issue.ExternalRefs = map[string]string{opts.refKey: item.Ref}
+	}
+	for i, c := range item.Comments {
+		at := c.At
+		if at.IsZero() {
+			at = app.Now()
+		}
+		issue.Comments = append(issue.Comments, issuestorage.Comment{ID: i + 1, UUID: app.Storage.NewUUID(), Author: c.Author, Text: c.Text, CreatedAt: at})
+	}
+
+	if parent != "" {
+		childID, err := app.Storage.GetNextChildID(ctx, parent)
+		if err != nil {
+			return "", fmt.Errorf("generating child ID for parent %s: %w", parent, err)
+		}
+		issue.ID = childID
+	}
+	id, err := app.Storage.Create(ctx, issue, createOpts)
+	if err != nil {
+		return "", fmt.Errorf("creating issue: %w", err)
+	}
+	if parent != "" {
+		if err := app.Storage.AddDependency(ctx, id, parent, issuestorage.DepTypeParentChild); err != nil {
+			app.Storage.Delete(context.Background(), id)
+			return "", fmt.Errorf("setting parent %s: %w", parent, err)
+		}
+	}
+	return id, nil
+}
+
+// linkImportedIssues adds the dependencies of items' links, now that the
+// issues for both ends exist, and returns how many it added.
+func linkImportedIssues(ctx context.Context, app *App, ids map[*importer.Item]string) (int, error) {
+	byRef := make(map[string]string)
+	for item, id := range ids {
+		if item.Ref != "" {
+			byRef[item.Ref] = id
+		}
+	}
+	n := 0
+	for item, id := range ids {
+		for _, link := range item.Links {
+			if err := app.Storage.AddDependency(ctx, id, byRef[link.Ref], link.Type); err != nil {
+				return n, fmt.Errorf("linking %s to %s: %w", item.Ref, link.Ref, err)
+			}
+			n++
+		}
+	}
+	return n, nil
+}
+
+// printImportTree prints items indented by depth, with their new IDs if
+// ids is set.
+func printImportTree(app *App, items []*importer.Item, ids map[*importer.Item]string, depth int) {
+	for _, item := range items {
+		mark := "[ ]"
+		if item.Done {
+			mark = "[x]"
+		}
+		line := strings.Repeat("  ", depth) + mark + " "
+		if id, ok := ids[item]; ok {
+			line += id + "  "
+		}
+		line += item.Title
+		if item.Ref != "" {
+			line += " (" + item.Ref + ")"
+		}
+		if item.Assignee != "" {
+			line += " @" + item.Assignee
+		}
+		for _, l := range item.Labels {
+			line += " #" + l
+		}
+		if item.Priority != nil {
+			line += " " + item.Priority.Display()
+		}
+		if item.Scheduled != nil {
+			line += " scheduled:" + item.Scheduled.Format("2006-01-02")
+		}
+		if item.Due != nil {
+			line += " due:" + item.Due.Format("2006-01-02")
+		}
+		fmt.Fprintln(app.Out, line)
+		printImportTree(app, item.Children, ids, depth+1)
+	}
+}
+
+// newImportMarkdownCmd creates the "import markdown" subcommand.
+func newImportMarkdownCmd(provider *AppProvider) *cobra.Command {
+	var (
+		parent   string
+		typeFlag string
+		dryRun   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "markdown <file>",
+		Short: "Import Markdown checkbox lists as issues",
+		Long: `Import Markdown task lists, such as an existing TODO.md, as issues.
+
+Each checkbox item ("- [ ] ..." or "- [x] ...") becomes an issue; checked
+items are created closed. Items indented under another item become its
+children. Annotations in an item's text are removed from the title:
+
+  @name   assignee
+  #name   label (repeatable)
+  !p1     priority (!p0-!p4)
+
+Other lines indented under an item become its description. Headings, prose
+and plain bullets are ignored. Use "-" as the file to read standard input.
+
+Examples:
+  bd import markdown TODO.md
+  bd import markdown TODO.md --dry-run
+  bd import markdown TODO.md --parent bd-a1b2 --type feature`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			opts, err := resolveImportOptions(ctx, app, parent, typeFlag, dryRun)
+			if err != nil {
+				return err
+			}
+
+			source := args[0]
+			in, closeIn, err := openImportSource(cmd, source)
+			if err != nil {
+				return err
+			}
+			defer closeIn()
+			items, err := importer.ParseMarkdown(in)
+			if err != nil {
+				return fmt.Errorf("parsing %s: %w", source, err)
+			}
+			if len(items) == 0 {
+				return fmt.Errorf("no checkbox items found in %s", source)
+			}
+			return runImport(ctx, app, items, source, opts)
+		},
+	}
+
+	addImportFlags(cmd, &parent, &typeFlag, &dryRun)
+
+	return cmd
+}
+
+// openImportSource opens the file named by an import argument, or returns
+// stdin for "-". The returned close function is always safe to call.
+func openImportSource(cmd *cobra.Command, source string) (io.Reader, func(), error) {
+	if source == "-" {
+		return cmd.InOrStdin(), func() {}, nil
+	}
+	f, err := os.Open(source)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, func() { f.Close() }, nil
+}
+
+// parseDelimiter parses a --delimiter value: a single character, or "tab".
+func parseDelimiter(s string) (rune, error) {
+	if s == "tab" || s == `\t` {
+		return '\t', nil
+	}
+	r := []rune(s)
+	if len(r) != 1 || r[0] == '"' || r[0] == '\n' || r[0] == '\r' {
+		return 0, fmt.Errorf("invalid delimiter %q (expected a single character or \"tab\")", s)
+	}
+	return r[0], nil
+}
+
+// newImportCSVCmd creates the "import csv" subcommand.
+func newImportCSVCmd(provider *AppProvider) *cobra.Command {
+	var (
+		parent    string
+		typeFlag  string
+		dryRun    bool
+		mapping   string
+		delimiter string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "csv <file>",
+		Short: "Import spreadsheet rows as issues",
+		Long: `Import a CSV file with a header row, one issue per row.
+
+Columns are matched to fields by header name (case-insensitive). Use --map
+to read a field from a differently named column. Fields:
+
+  title        required
+  description
+  type         issue type; defaults to --type
+  status       closed, done, x, yes and similar import the issue closed
+  priority     0-4, P0-P4 or high/medium/low
+  assignee
+  labels       separated by commas or semicolons
+  id, parent   rows whose parent matches another row's id become its
+               children (as written by bd export csv)
+
+Use "-" as the file to read standard input.
+
+Examples:
+  bd import csv backlog.csv
+  bd import csv export.csv --map title=Summary,priority=Prio,assignee=Owner
+  bd import csv tasks.tsv --delimiter tab --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			opts, err := resolveImportOptions(ctx, app, parent, typeFlag, dryRun)
+			if err != nil {
+				return err
+			}
+			csvOpts := importer.CSVOptions{}
+			if csvOpts.Comma, err = parseDelimiter(delimiter); err != nil {
+				return err
+			}
+			if csvOpts.Map, err = importer.ParseMapping(mapping); err != nil {
+				return err
+			}
+
+			source := args[0]
+			in, closeIn, err := openImportSource(cmd, source)
+			if err != nil {
+				return err
+			}
+			defer closeIn()
+			items, err := importer.ParseCSV(in, csvOpts)
+			if err != nil {
+				return fmt.Errorf("parsing %s: %w", source, err)
+			}
+			if len(items) == 0 {
+				return fmt.Errorf("no rows found in %s", source)
+			}
+			return runImport(ctx, app, items, source, opts)
+		},
+	}
+
+	addImportFlags(cmd, &parent, &typeFlag, &dryRun)
+	cmd.Flags().StringVar(&mapping, "map", "", "Map fields to column headers (e.g. title=Summary,priority=Prio)")
+	cmd.Flags().StringVar(&delimiter, "delimiter", ",", "Field delimiter (a single character, or \"tab\")")
+
+	return cmd
+}
+
+// newImportOrgCmd creates the "import org" subcommand.
+func newImportOrgCmd(provider *AppProvider) *cobra.Command {
+	var (
+		parent   string
+		typeFlag string
+		dryRun   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "org <file>",
+		Short: "Import org-mode TODO headlines as issues",
+		Long: `Import an org-mode file, such as an agenda file, as issues.
+
+Each headline with a TODO keyword becomes an issue; headlines with a done
+keyword (DONE, CANCELLED) are created closed. Headlines without a keyword
+are treated as section headings: they are not imported, and tasks under
+them become children of the nearest enclosing task. Keywords declared with
+a #+TODO: line replace the defaults (TODO, NEXT, STARTED, WAITING, HOLD,
+DEFERRED | DONE, CANCELLED).
+
+  [#A] [#B] [#C]   priority P1, P2, P3
+  :tag1:tag2:      labels
+  SCHEDULED: <..>  scheduled date
+  DEADLINE: <..>   due date
+  :ASSIGNEE:       assignee (property drawer)
+  :TYPE:           issue type (property drawer); defaults to --type
+
+Other body text becomes the description. Use "-" as the file to read
+standard input. The output of bd export org can be imported again.
+
+Examples:
+  bd import org todo.org
+  bd import org ~/org/work.org --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			opts, err := resolveImportOptions(ctx, app, parent, typeFlag, dryRun)
+			if err != nil {
+				return err
+			}
+
+			source := args[0]
+			in, closeIn, err := openImportSource(cmd, source)
+			if err != nil {
+				return err
+			}
+			defer closeIn()
+			items, err := importer.ParseOrg(in)
+			if err != nil {
+				return fmt.Errorf("parsing %s: %w", source, err)
+			}
+			if len(items) == 0 {
+				return fmt.Errorf("no TODO headlines found in %s", source)
+			}
+			return runImport(ctx, app, items, source, opts)
+		},
+	}
+
+	addImportFlags(cmd, &parent, &typeFlag, &dryRun)
+
+	return cmd
+}
+
+// newImportTaskwarriorCmd creates the "import taskwarrior" subcommand.
+func newImportTaskwarriorCmd(provider *AppProvider) *cobra.Command {
+	var (
+		parent   string
+		typeFlag string
+		dryRun   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "taskwarrior <file>",
+		Short: "Import Taskwarrior tasks as issues",
+		Long: `Import tasks from the JSON written by "task export".
+
+Each task becomes an issue: the description is the title and annotations
+become the issue description. Completed tasks are created closed; deleted
+tasks and recurrence templates are skipped.
+
+  tags, project  labels
+  priority       H, M, L become P1, P2, P3
+  due            due date
+  scheduled      scheduled date (wait if no scheduled date is set)
+
+Use "-" as the file to read standard input.
+
+Examples:
+  task export > tasks.json && bd import taskwarrior tasks.json
+  task project:home export | bd import taskwarrior - --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			opts, err := resolveImportOptions(ctx, app, parent, typeFlag, dryRun)
+			if err != nil {
+				return err
+			}
+
+			source := args[0]
+			in, closeIn, err := openImportSource(cmd, source)
+			if err != nil {
+				return err
+			}
+			defer closeIn()
+			items, err := importer.ParseTaskwarrior(in)
+			if err != nil {
+				return fmt.Errorf("parsing %s: %w", source, err)
+			}
+			if len(items) == 0 {
+				return fmt.Errorf("no tasks found in %s", source)
+			}
+			return runImport(ctx, app, items, source, opts)
+		},
+	}
+
+	addImportFlags(cmd, &parent, &typeFlag, &dryRun)
+
+	return cmd
+}
+
+// newImportGitLabCmd creates the "import gitlab" subcommand.
+func newImportGitLabCmd(provider *AppProvider) *cobra.Command {
+	var (
+		parent   string
+		typeFlag string
+		dryRun   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "gitlab <file>",
+		Short: "Import GitLab issues",
+		Long: `Import GitLab issues from the JSON of the issues API (an array) or the
+tree/project/issues.ndjson of a project export (one issue per line).
+
+Closed issues are created closed. Each issue's full reference, such as
+group/project#12, is kept in its external_refs under "gitlab".
+
+  labels             kept, except those below
+  priority::X, PN    priority (0-4, P0-P4, high, medium, low)
+  type::X            type (task, bug, feature, epic, chore); incidents
+                     are bugs; otherwise --type
+  assignees          the first becomes the assignee
+  due_date           due date
+  notes              comments, skipping system notes
+  links              blocks and is_blocked_by links become blocks
+                     dependencies and others related ones, between
+                     imported issues
+
+The API omits notes and links; add each issue's notes and links endpoint
+results to it as "notes" and "links" to import them. Use "-" as the file
+to read standard input.
+
+Examples:
+  glab api --paginate "projects/:id/issues?scope=all" > issues.json
+  bd import gitlab issues.json
+  bd import gitlab tree/project/issues.ndjson --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			opts, err := resolveImportOptions(ctx, app, parent, typeFlag, dryRun)
+			if err != nil {
+				return err
+			}
+			opts.refKey = "gitlab"
+
+			source := args[0]
+			in, closeIn, err := openImportSource(cmd, source)
+			if err != nil {
+				return err
+			}
+			defer closeIn()
+			items, err := importer.ParseGitLab(in)
+			if err != nil {
+				return fmt.Errorf("parsing %s: %w", source, err)
+			}
+			if len(items) == 0 {
+				return fmt.Errorf("no issues found in %s", source)
+			}
+			return runImport(ctx, app, items, source, opts)
+		},
+	}
+
+	addImportFlags(cmd, &parent, &typeFlag, &dryRun)
+
+	return cmd
+}
+
+// newImportJiraCmd creates the "import jira" subcommand.
+func newImportJiraCmd(provider *AppProvider) *cobra.Command {
+	var (
+		parent   string
+		typeFlag string
+		dryRun   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "jira <file>",
+		Short: "Import Jira issues",
+		Long: `Import Jira issues from the JSON of a REST API search, or from the CSV
+of the issue navigator's "Export CSV (all fields)".
+
+Issues in a done status category, or with a done, closed or resolved
+status, are created closed. Each issue key, such as PROJ-12, is kept in
+its external_refs under "jira".
+
+  Priority       Highest..Lowest or Blocker..Trivial become P0..P4
+  Issue Type     Bug, Story/Feature/Improvement, Epic and Task/Sub-task
+                 become bug, feature, epic and task; others use --type
+  Labels, Assignee, Due Date, Comments
+  Issue links    Blocks links become blocks dependencies and others
+                 related ones, between imported issues
+  Parent         an issue whose parent or epic link is imported becomes
+                 its child
+
+Use "-" as the file to read standard input.
+
+Examples:
+  bd import jira "Jira Export.csv"
+  curl -u me@example.com:$TOKEN "$JIRA/rest/api/2/search?jql=project=PROJ&maxResults=1000" \
+    | bd import jira - --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			opts, err := resolveImportOptions(ctx, app, parent, typeFlag, dryRun)
+			if err != nil {
+				return err
+			}
+			opts.refKey = "jira"
+
+			source := args[0]
+			in, closeIn, err := openImportSource(cmd, source)
+			if err != nil {
+				return err
+			}
+			defer closeIn()
+			items, err := importer.ParseJira(in)
+			if err != nil {
+				return fmt.Errorf("parsing %s: %w", source, err)
+			}
+			if len(items) == 0 {
+				return fmt.Errorf("no issues found in %s", source)
+			}
+			return runImport(ctx, app, items, source, opts)
+		},
+	}
+
+	addImportFlags(cmd, &parent, &typeFlag, &dryRun)
+
 	return cmd
 }
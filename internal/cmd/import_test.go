@@ -2,8 +2,14 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"beads-lite/internal/issuestorage"
 )
 
 func TestImportCmd(t *testing.T) {
@@ -80,3 +86,249 @@ func TestImportCmd_WithAllFlags(t *testing.T) {
 		t.Errorf("expected JSON noop status, got: %s", got)
 	}
 }
+
+const importMarkdownInput = `# TODO
+- [ ] Set up CI @alice #infra !p1
+  - [x] Add lint step
+  - [ ] Add test step
+    Run with -race.
+- [ ] Write README
+`
+
+func writeImportFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestImportMarkdown(t *testing.T) {
+	app, store := setupTestApp(t)
+	path := writeImportFile(t, "TODO.md", importMarkdownInput)
+
+	cmd := newImportCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"markdown", path})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("import markdown failed: %v", err)
+	}
+	out := app.Out.(*bytes.Buffer).String()
+	if !strings.Contains(out, "Imported 4 issue(s)") {
+		t.Errorf("expected import summary, got: %s", out)
+	}
+
+	ctx := context.Background()
+	issues, err := store.List(ctx, &issuestorage.ListFilter{Statuses: []issuestorage.Status{issuestorage.StatusOpen, issuestorage.StatusClosed}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	byTitle := make(map[string]*issuestorage.Issue)
+	for _, issue := range issues {
+		byTitle[issue.Title] = issue
+	}
+	if len(byTitle) != 4 {
+		t.Fatalf("expected 4 issues, got %d", len(byTitle))
+	}
+
+	ci := byTitle["Set up CI"]
+	if ci.Assignee != "alice" || ci.Priority != issuestorage.PriorityHigh || !contains(ci.Labels, "infra") {
+		t.Errorf("CI issue = %+v", ci)
+	}
+	lint := byTitle["Add lint step"]
+	if lint.Parent != ci.ID || lint.ID != ci.ID+".1" || lint.Status != issuestorage.StatusClosed || lint.ClosedAt == nil {
+		t.Errorf("lint issue = %+v", lint)
+	}
+	test := byTitle["Add test step"]
+	if test.Parent != ci.ID || test.Description != "Run with -race." {
+		t.Errorf("test issue = %+v", test)
+	}
+	if readme := byTitle["Write README"]; readme.Parent != "" {
+		t.Errorf("README should be top-level, has parent %s", readme.Parent)
+	}
+}
+
+func TestImportMarkdown_DryRun(t *testing.T) {
+	app, store := setupTestApp(t)
+	path := writeImportFile(t, "TODO.md", importMarkdownInput)
+
+	cmd := newImportCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"markdown", path, "--dry-run"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("import markdown --dry-run failed: %v", err)
+	}
+	out := app.Out.(*bytes.Buffer).String()
+	if !strings.Contains(out, "Would import 4 issue(s)") || !strings.Contains(out, "    [x] Add lint step") {
+		t.Errorf("unexpected dry-run output: %s", out)
+	}
+	issues, err := store.List(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("dry run created %d issues", len(issues))
+	}
+}
+
+func TestImportMarkdown_ParentAndJSON(t *testing.T) {
+	app, store := setupTestApp(t)
+	app.JSON = true
+	ctx := context.Background()
+	parent, err := store.Create(ctx, &issuestorage.Issue{Title: "Imported backlog", Type: issuestorage.TypeEpic})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := writeImportFile(t, "TODO.md", "- [ ] One\n- [ ] Two\n")
+
+	cmd := newImportCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"markdown", path, "--parent", parent, "--type", "bug"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("import markdown --parent failed: %v", err)
+	}
+	var got []IssueListJSON
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != parent+".1" || got[1].ID != parent+".2" || got[0].IssueType != "bug" {
+		t.Errorf("unexpected JSON output: %+v", got)
+	}
+}
+
+func TestImportMarkdown_NoItems(t *testing.T) {
+	app, _ := setupTestApp(t)
+	path := writeImportFile(t, "notes.md", "# Notes\n\nNothing to do.\n")
+
+	cmd := newImportCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"markdown", path})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "no checkbox items") {
+		t.Errorf("expected no-items error, got %v", err)
+	}
+}
+
+func TestImportOrg(t *testing.T) {
+	app, store := setupTestApp(t)
+	path := writeImportFile(t, "todo.org", "* TODO [#A] Launch :web:\n  DEADLINE: <2024-01-20 Sat>\n** DONE Write copy\n")
+
+	cmd := newImportCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"org", path})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("import org failed: %v", err)
+	}
+	issues, err := store.List(context.Background(), &issuestorage.ListFilter{Statuses: []issuestorage.Status{issuestorage.StatusOpen, issuestorage.StatusClosed}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	byTitle := make(map[string]*issuestorage.Issue)
+	for _, issue := range issues {
+		byTitle[issue.Title] = issue
+	}
+	launch, copyIssue := byTitle["Launch"], byTitle["Write copy"]
+	if launch == nil || copyIssue == nil {
+		t.Fatalf("missing imported issues: %v", byTitle)
+	}
+	if launch.Priority != issuestorage.PriorityHigh || !contains(launch.Labels, "web") || launch.DueAt == nil || launch.DueAt.Format("2006-01-02") != "2024-01-20" {
+		t.Errorf("launch = %+v", launch)
+	}
+	if copyIssue.Parent != launch.ID || copyIssue.Status != issuestorage.StatusClosed {
+		t.Errorf("copy = %+v", copyIssue)
+	}
+}
+
+func TestImportTaskwarrior(t *testing.T) {
+	app, store := setupTestApp(t)
+	input := `[{"description":"Renew passport","status":"pending","project":"home","priority":"H","scheduled":"20240110T000000Z"},
+{"description":"Old chore","status":"deleted"}]`
+
+	cmd := newImportCmd(NewTestProvider(app))
+	cmd.SetIn(strings.NewReader(input))
+	cmd.SetArgs([]string{"taskwarrior", "-"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("import taskwarrior failed: %v", err)
+	}
+	if out := app.Out.(*bytes.Buffer).String(); !strings.Contains(out, "Imported 1 issue(s) from -") || !strings.Contains(out, "scheduled:2024-01") {
+		t.Errorf("unexpected output: %s", out)
+	}
+	issues, err := store.List(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d", len(issues))
+	}
+	if got := issues[0]; got.Title != "Renew passport" || got.Priority != issuestorage.PriorityHigh || !contains(got.Labels, "home") || got.DeferUntil == nil {
+		t.Errorf("issue = %+v", got)
+	}
+}
+
+func TestImportJira(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	input := `[
+{"key":"PROJ-1","fields":{"summary":"Checkout","issuetype":{"name":"Epic"},"status":{"name":"To Do"},"priority":{"name":"High"}}},
+{"key":"PROJ-2","fields":{"summary":"Card payments","issuetype":{"name":"Bug"},"status":{"name":"To Do"},"parent":{"key":"PROJ-1"},
+ "comment":{"comments":[{"author":{"name":"dev1"},"body":"Needs review","created":"2024-01-02T10:00:00.000+0000"}]}}},
+{"key":"PROJ-3","fields":{"summary":"Gateway account","status":{"name":"Done"},"issuelinks":[{"type":{"name":"Blocks"},"outwardIssue":{"key":"PROJ-2"}}]}}
+]`
+	path := writeImportFile(t, "jira.json", input)
+
+	// --from runs the subcommand on --input.
+	cmd := newImportCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--from", "jira", "-i", path})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("import --from jira failed: %v", err)
+	}
+	if out := app.Out.(*bytes.Buffer).String(); !strings.Contains(out, "Imported 3 issue(s) from "+path+" with 1 link(s)") || !strings.Contains(out, "Card payments (PROJ-2)") {
+		t.Errorf("unexpected output: %s", out)
+	}
+
+	issues, err := store.List(ctx, &issuestorage.ListFilter{Statuses: []issuestorage.Status{issuestorage.StatusOpen, issuestorage.StatusClosed}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	byRef := make(map[string]*issuestorage.Issue)
+	for _, issue := range issues {
+		byRef[issue.ExternalRefs["jira"]] = issue
+	}
+	epic, card, gateway := byRef["PROJ-1"], byRef["PROJ-2"], byRef["PROJ-3"]
+	if epic == nil || card == nil || gateway == nil {
+		t.Fatalf("imported refs: %v", byRef)
+	}
+	if epic.Type != issuestorage.TypeEpic || epic.Priority != issuestorage.PriorityHigh || gateway.Status != issuestorage.StatusClosed {
+		t.Errorf("epic = %+v, gateway = %+v", epic, gateway)
+	}
+	if card.Type != issuestorage.TypeBug || card.Parent != epic.ID || len(card.Comments) != 1 || card.Comments[0].Author != "dev1" || card.Comments[0].ID != 1 {
+		t.Errorf("card = %+v", card)
+	}
+	blocked := false
+	for _, dep := range card.Dependencies {
+		blocked = blocked || (dep.ID == gateway.ID && dep.Type == issuestorage.DepTypeBlocks)
+	}
+	if !blocked {
+		t.Errorf("card dependencies = %+v, want blocked by %s", card.Dependencies, gateway.ID)
+	}
+
+	cmd = newImportCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--from", "bugzilla", "-i", path})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "unknown import source") {
+		t.Errorf("unknown --from: err = %v", err)
+	}
+}
+
+func TestImportGitLab_DryRun(t *testing.T) {
+	app, store := setupTestApp(t)
+	input := `{"iid":7,"title":"Dark mode","state":"opened","references":{"full":"acme/web#7"}}` + "\n"
+
+	cmd := newImportCmd(NewTestProvider(app))
+	cmd.SetIn(strings.NewReader(input))
+	cmd.SetArgs([]string{"--from", "gitlab", "--dry-run"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("import --from gitlab failed: %v", err)
+	}
+	if out := app.Out.(*bytes.Buffer).String(); !strings.Contains(out, "Would import 1 issue(s) from -") || !strings.Contains(out, "Dark mode (acme/web#7)") {
+		t.Errorf("unexpected output: %s", out)
+	}
+	if issues, _ := store.List(context.Background(), nil); len(issues) != 0 {
+		t.Errorf("dry run created %d issue(s)", len(issues))
+	}
+}
@@ -59,10 +59,17 @@ func runInit(out io.Writer, force bool, prefix string) error {
 		basePath = cwd
 	}
 
+	_, err := initRepository(out, basePath, force, prefix)
+	return err
+}
+
+// initRepository creates a beads-lite repository in basePath/.beads (or in
+// basePath itself if it is a .beads directory) and returns its path.
+func initRepository(out io.Writer, basePath string, force bool, prefix string) (string, error) {
 	// Resolve to absolute path
 	absPath, err := filepath.Abs(basePath)
 	if err != nil {
-		return fmt.Errorf("resolving path: %w", err)
+		return "", fmt.Errorf("resolving path: %w", err)
 	}
 
 	beadsPath := absPath
@@ -75,31 +82,31 @@ func runInit(out io.Writer, force bool, prefix string) error {
 		if info.IsDir() {
 			empty, checkErr := isDirEmpty(beadsPath)
 			if checkErr != nil {
-				return fmt.Errorf("checking .beads directory: %w", checkErr)
+				return "", fmt.Errorf("checking .beads directory: %w", checkErr)
 			}
 			if !empty && !force {
-				return errors.New("beads-lite repository already exists (use --force to reinitialize)")
+				return "", errors.New("beads-lite repository already exists (use --force to reinitialize)")
 			}
 		} else if !force {
-			return errors.New("beads-lite repository already exists (use --force to reinitialize)")
+			return "", errors.New("beads-lite repository already exists (use --force to reinitialize)")
 		}
 	} else if !os.IsNotExist(err) {
-		return fmt.Errorf("checking .beads directory: %w", err)
+		return "", fmt.Errorf("checking .beads directory: %w", err)
 	}
 
 	if err := os.MkdirAll(beadsPath, 0755); err != nil {
-		return fmt.Errorf("creating .beads directory: %w", err)
+		return "", fmt.Errorf("creating .beads directory: %w", err)
 	}
 
 	formulasPath := filepath.Join(beadsPath, "formulas")
 	if err := os.MkdirAll(formulasPath, 0755); err != nil {
-		return fmt.Errorf("creating formulas directory: %w", err)
+		return "", fmt.Errorf("creating formulas directory: %w", err)
 	}
 
 	configPath := filepath.Join(beadsPath, "config.yaml")
 	store, err := yamlstore.New(configPath)
 	if err != nil {
-		return fmt.Errorf("creating config store: %w", err)
+		return "", fmt.Errorf("creating config store: %w", err)
 	}
 
 	// Save existing issue_prefix before writing defaults (for re-init case).
@@ -110,58 +117,112 @@ func runInit(out io.Writer, force bool, prefix string) error {
 			continue // Resolved separately below.
 		}
 		if err := store.Set(k, v); err != nil {
-			return fmt.Errorf("writing default config: %w", err)
+			return "", fmt.Errorf("writing default config: %w", err)
 		}
 	}
 
 	dataPath := filepath.Join(beadsPath, filesystem.DataDirName)
 	idPrefix := resolvePrefix(prefix, existingPrefix, hasExistingPrefix, dataPath, absPath)
 	if err := store.Set("issue_prefix", idPrefix); err != nil {
-		return fmt.Errorf("setting issue prefix: %w", err)
+		return "", fmt.Errorf("setting issue prefix: %w", err)
 	}
 
 	// Create the issue storage (takes beadsPath, creates issues/ subdir internally)
 	issueStore := filesystem.New(beadsPath, idPrefix)
 	if err := issueStore.Init(context.Background()); err != nil {
-		return fmt.Errorf("initializing storage: %w", err)
+		return "", fmt.Errorf("initializing storage: %w", err)
 	}
 
 	// Create the slot KV store
 	slotStore, err := kvfs.New(beadsPath, "slots")
 	if err != nil {
-		return fmt.Errorf("creating slot store: %w", err)
+		return "", fmt.Errorf("creating slot store: %w", err)
 	}
 	if err := slotStore.Init(context.Background()); err != nil {
-		return fmt.Errorf("initializing slot store: %w", err)
+		return "", fmt.Errorf("initializing slot store: %w", err)
 	}
 
 	// Create the agent KV store
 	agentStore, err := kvfs.New(beadsPath, "agents")
 	if err != nil {
-		return fmt.Errorf("creating agent store: %w", err)
+		return "", fmt.Errorf("creating agent store: %w", err)
 	}
 	if err := agentStore.Init(context.Background()); err != nil {
-		return fmt.Errorf("initializing agent store: %w", err)
+		return "", fmt.Errorf("initializing agent store: %w", err)
 	}
 
 	// Create the merge-slot KV store
 	mergeSlotStore, err := kvfs.New(beadsPath, "merge-slot")
 	if err != nil {
-		return fmt.Errorf("creating merge-slot store: %w", err)
+		return "", fmt.Errorf("creating merge-slot store: %w", err)
 	}
 	if err := mergeSlotStore.Init(context.Background()); err != nil {
-		return fmt.Errorf("initializing merge-slot store: %w", err)
+		return "", fmt.Errorf("initializing merge-slot store: %w", err)
+	}
+
+	// Create the milestone KV store
+	milestoneStore, err := kvfs.New(beadsPath, "milestones")
+	if err != nil {
+		return "", fmt.Errorf("creating milestone store: %w", err)
+	}
+	if err := milestoneStore.Init(context.Background()); err != nil {
+		return "", fmt.Errorf("initializing milestone store: %w", err)
+	}
+
+	// Create the sprint KV store
+	sprintStore, err := kvfs.New(beadsPath, "sprints")
+	if err != nil {
+		return "", fmt.Errorf("creating sprint store: %w", err)
+	}
+	if err := sprintStore.Init(context.Background()); err != nil {
+		return "", fmt.Errorf("initializing sprint store: %w", err)
+	}
+
+	// Create the alias KV store
+	aliasStore, err := kvfs.New(beadsPath, "aliases")
+	if err != nil {
+		return "", fmt.Errorf("creating alias store: %w", err)
+	}
+	if err := aliasStore.Init(context.Background()); err != nil {
+		return "", fmt.Errorf("initializing alias store: %w", err)
+	}
+
+	// Create the template KV store
+	templateStore, err := kvfs.New(beadsPath, "templates")
+	if err != nil {
+		return "", fmt.Errorf("creating template store: %w", err)
+	}
+	if err := templateStore.Init(context.Background()); err != nil {
+		return "", fmt.Errorf("initializing template store: %w", err)
+	}
+
+	// Create the people registry KV store
+	peopleStore, err := kvfs.New(beadsPath, "people")
+	if err != nil {
+		return "", fmt.Errorf("creating people store: %w", err)
+	}
+	if err := peopleStore.Init(context.Background()); err != nil {
+		return "", fmt.Errorf("initializing people store: %w", err)
+	}
+
+	// Create the estimation round KV store
+	estimateStore, err := kvfs.New(beadsPath, "estimates")
+	if err != nil {
+		return "", fmt.Errorf("creating estimate store: %w", err)
+	}
+	if err := estimateStore.Init(context.Background()); err != nil {
+		return "", fmt.Errorf("initializing estimate store: %w", err)
 	}
 
 	// Create .gitignore in .beads/ directory
 	gitignorePath := filepath.Join(beadsPath, ".gitignore")
-	gitignoreContent := "issues/ephemeral/\n*.lock\n"
+	gitignoreContent := "issues/ephemeral/\n*.lock\nmaintenance.json\n"
 	if err := os.WriteFile(gitignorePath, []byte(gitignoreContent), 0644); err != nil {
-		return fmt.Errorf("creating .gitignore: %w", err)
+		return "", fmt.Errorf("creating .gitignore: %w", err)
 	}
 
 	fmt.Fprintf(out, "Initialized beads-lite repository at %s\n", beadsPath)
-	return nil
+	return beadsPath, nil
 }
 
 // resolvePrefix determines the issue_prefix using a fallback chain:
@@ -183,23 +244,40 @@ func resolvePrefix(flagValue, existingConfig string, hasExistingConfig bool, dat
 }
 
 // extractPrefixFromExistingIssues scans the data directory for existing issue
-// JSON files and extracts the ID prefix from the first one found.
+// JSON files and extracts the ID prefix from the first one found. Shard
+// subdirectories (see storage.shard_width) are searched too.
 func extractPrefixFromExistingIssues(dataPath string) string {
 	for _, dir := range []string{filesystem.DirOpen, filesystem.DirClosed, filesystem.DirDeleted} {
-		dirPath := filepath.Join(dataPath, dir)
-		entries, err := os.ReadDir(dirPath)
-		if err != nil {
-			continue
+		if p := extractPrefixFromDir(filepath.Join(dataPath, dir), true); p != "" {
+			return p
 		}
-		for _, entry := range entries {
-			name := entry.Name()
-			if entry.IsDir() || filepath.Ext(name) != ".json" || strings.Contains(name, ".tmp.") {
-				continue
-			}
-			id := strings.TrimSuffix(name, ".json")
-			if p := routing.ExtractPrefix(id); p != "" {
-				return p
+	}
+	return ""
+}
+
+// extractPrefixFromDir returns the prefix of the first issue file in
+// dirPath, descending one level into subdirectories if shards is set.
+func extractPrefixFromDir(dirPath string, shards bool) string {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return ""
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			if shards {
+				if p := extractPrefixFromDir(filepath.Join(dirPath, name), false); p != "" {
+					return p
+				}
 			}
+			continue
+		}
+		if filepath.Ext(name) != ".json" || strings.Contains(name, ".tmp.") {
+			continue
+		}
+		id := strings.TrimSuffix(name, ".json")
+		if p := routing.ExtractPrefix(id); p != "" {
+			return p
 		}
 	}
 	return ""
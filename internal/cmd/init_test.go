@@ -439,8 +439,8 @@ func TestInit(t *testing.T) {
 			t.Fatalf(".gitignore not created: %v", err)
 		}
 		content := string(data)
-		if content != "issues/ephemeral/\n*.lock\n" {
-			t.Errorf(".gitignore content = %q, want %q", content, "issues/ephemeral/\n*.lock\n")
+		if content != "issues/ephemeral/\n*.lock\nmaintenance.json\n" {
+			t.Errorf(".gitignore content = %q, want %q", content, "issues/ephemeral/\n*.lock\nmaintenance.json\n")
 		}
 	})
 
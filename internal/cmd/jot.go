@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// InboxLabel marks issues captured with bd jot that have not been triaged.
+const InboxLabel = "inbox"
+
+// newJotCmd creates the jot command.
+func newJotCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "jot <text...>",
+		Short: "Quickly capture a thought into the inbox",
+		Long: `Quickly capture a thought as a minimal issue labelled "inbox".
+
+Jot never prompts or warns, and sizes the new ID from a cached issue count
+instead of scanning every issue, so it stays fast on large projects.
+Review captured items later with bd inbox.
+
+Examples:
+  bd jot "flaky login test on CI"
+  bd jot look into rate limiting for the export API`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			title := strings.TrimSpace(strings.Join(args, " "))
+			if title == "" {
+				return fmt.Errorf("nothing to jot")
+			}
+			actor, _ := resolveActor(app)
+			issue := &issuestorage.Issue{
+				Title:     title,
+				Type:      issuestorage.TypeTask,
+				Priority:  issuestorage.PriorityMedium,
+				CreatedBy: actor,
+				Owner:     resolveOwner(),
+				Labels:    []string{InboxLabel},
+			}
+
+			id, err := app.Storage.Create(ctx, issue, issuestorage.CreateOpts{UseCachedCount: true})
+			if err != nil {
+				return fmt.Errorf("creating issue: %w", err)
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(ToIssueJSON(ctx, app.Storage, issue, false, false))
+			}
+			fmt.Fprintf(app.Out, "%s Jotted %s\n", app.SuccessColor("✓"), id)
+			return nil
+		},
+	}
+}
+
+// newInboxCmd creates the inbox command with subcommands.
+func newInboxCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inbox",
+		Short: "Review and triage items captured with bd jot",
+		Long: `List open issues labelled "inbox", oldest first.
+
+Subcommands:
+  triage  Accept an item: remove it from the inbox, optionally setting fields
+  drop    Close an item that is not worth tracking
+
+Examples:
+  bd inbox
+  bd inbox triage bd-a1b2 --type bug --priority 1
+  bd inbox drop bd-c3d4`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			items, err := app.Storage.List(ctx, &issuestorage.ListFilter{Labels: []string{InboxLabel}})
+			if err != nil {
+				return fmt.Errorf("listing inbox: %w", err)
+			}
+			var open []*issuestorage.Issue
+			for _, item := range items {
+				if item.Status != issuestorage.StatusClosed {
+					open = append(open, item)
+				}
+			}
+			sort.SliceStable(open, func(i, j int) bool {
+				return open[i].CreatedAt.Before(open[j].CreatedAt)
+			})
+
+			if app.JSON {
+				out := make([]IssueListJSON, 0, len(open))
+				for _, item := range open {
+					out = append(out, ToIssueListJSON(item))
+				}
+				return json.NewEncoder(app.Out).Encode(out)
+			}
+
+			if len(open) == 0 {
+				fmt.Fprintln(app.Out, "Inbox is empty.")
+				return nil
+			}
+			now := app.Now()
+			for _, item := range open {
+				fmt.Fprintf(app.Out, "%s  %s  (%s)\n", item.ID, item.Title, inboxAge(now.Sub(item.CreatedAt)))
+			}
+			fmt.Fprintf(app.Out, "\n%d item(s). Triage with: bd inbox triage <id> or bd inbox drop <id>\n", len(open))
+			return nil
+		},
+	}
+
+	cmd.AddCommand(newInboxTriageCmd(provider))
+	cmd.AddCommand(newInboxDropCmd(provider))
+
+	return cmd
+}
+
+// newInboxTriageCmd creates the "inbox triage" subcommand.
+func newInboxTriageCmd(provider *AppProvider) *cobra.Command {
+	var (
+		typeFlag string
+		priority string
+		assignee string
+		labels   []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "triage <id>",
+		Short: "Remove an item from the inbox, optionally setting fields",
+		Long: `Remove an item from the inbox so it is tracked like any other issue.
+
+Examples:
+  bd inbox triage bd-a1b2
+  bd inbox triage bd-a1b2 --type bug --priority 1 --assignee alice`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			issue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+			if !contains(issue.Labels, InboxLabel) {
+				return fmt.Errorf("%s is not in the inbox", issue.ID)
+			}
+
+			var newPriority *issuestorage.Priority
+			if priority != "" {
+				p, err := parsePriorityInput(priority)
+				if err != nil {
+					return err
+				}
+				newPriority = &p
+			}
+			if typeFlag != "" {
+				t, err := parseType(typeFlag, getCustomValues(app, "types.custom"))
+				if err != nil {
+					return err
+				}
+				if t != issue.Type {
+					if _, err := app.Storage.ConvertType(ctx, issue.ID, t, false); err != nil {
+						return err
+					}
+				}
+			}
+
+			if err := app.Storage.Modify(ctx, issue.ID, func(i *issuestorage.Issue) error {
+				i.Labels = removeFromSlice(i.Labels, InboxLabel)
+				for _, l := range labels {
+					if !contains(i.Labels, l) {
+						i.Labels = append(i.Labels, l)
+					}
+				}
+				if newPriority != nil {
+					i.Priority = *newPriority
+				}
+				if cmd.Flags().Changed("assignee") {
+					i.Assignee = assignee
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			if app.JSON {
+				updated, err := app.Storage.Get(ctx, issue.ID)
+				if err != nil {
+					return fmt.Errorf("fetching updated issue: %w", err)
+				}
+				return json.NewEncoder(app.Out).Encode(ToIssueJSON(ctx, app.Storage, updated, false, false))
+			}
+			fmt.Fprintf(app.Out, "%s Triaged %s\n", app.SuccessColor("✓"), issue.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&typeFlag, "type", "t", "", "Set the issue type")
+	cmd.Flags().StringVarP(&priority, "priority", "p", "", "Set the priority (0-4 or P0-P4)")
+	cmd.Flags().StringVarP(&assignee, "assignee", "a", "", "Assign to user")
+	cmd.Flags().StringSliceVarP(&labels, "label", "l", nil, "Add labels (comma-separated or repeat flag)")
+
+	return cmd
+}
+
+// newInboxDropCmd creates the "inbox drop" subcommand.
+func newInboxDropCmd(provider *AppProvider) *cobra.Command {
+	var reason string
+
+	cmd := &cobra.Command{
+		Use:   "drop <id>",
+		Short: "Close an inbox item that is not worth tracking",
+		Long: `Close an inbox item that is not worth tracking.
+
+Examples:
+  bd inbox drop bd-a1b2
+  bd inbox drop bd-a1b2 --reason "duplicate of bd-c3d4"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			issue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+			if !contains(issue.Labels, InboxLabel) {
+				return fmt.Errorf("%s is not in the inbox", issue.ID)
+			}
+			if err := app.Storage.Modify(ctx, issue.ID, func(i *issuestorage.Issue) error {
+				i.Status = issuestorage.StatusClosed
+				i.CloseReason = reason
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(map[string]string{
+					"id":     issue.ID,
+					"status": string(issuestorage.StatusClosed),
+				})
+			}
+			fmt.Fprintf(app.Out, "%s Dropped %s\n", app.SuccessColor("✓"), issue.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&reason, "reason", "r", "Dropped from inbox", "Close reason")
+
+	return cmd
+}
+
+// inboxAge formats how long an item has been waiting in the inbox.
+func inboxAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
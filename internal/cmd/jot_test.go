@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestJotAndInbox(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+
+	cmd := newJotCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"check", "flaky", "login", "test"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("jot failed: %v", err)
+	}
+	out := app.Out.(*bytes.Buffer).String()
+	if strings.Contains(out, "without description") {
+		t.Errorf("jot should not warn, got:\n%s", out)
+	}
+	id := strings.TrimSpace(strings.TrimPrefix(out, "✓ Jotted "))
+	issue, err := store.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("failed to get jotted issue %q: %v", id, err)
+	}
+	if issue.Title != "check flaky login test" || !contains(issue.Labels, InboxLabel) {
+		t.Errorf("unexpected jotted issue: title=%q labels=%v", issue.Title, issue.Labels)
+	}
+	other, err := store.Create(ctx, &issuestorage.Issue{Title: "Regular issue"})
+	if err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	app.Out = &bytes.Buffer{}
+	app.JSON = true
+	cmd = newInboxCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("inbox failed: %v", err)
+	}
+	var items []IssueListJSON
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &items); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	if len(items) != 1 || items[0].ID != id {
+		t.Fatalf("expected inbox [%s], got %+v", id, items)
+	}
+
+	app.JSON = false
+	app.Out = &bytes.Buffer{}
+	cmd = newInboxCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"triage", id, "--type", "bug", "--priority", "1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("triage failed: %v", err)
+	}
+	issue, err = store.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("failed to get issue: %v", err)
+	}
+	if contains(issue.Labels, InboxLabel) || issue.Type != issuestorage.TypeBug || issue.Priority != issuestorage.PriorityHigh {
+		t.Errorf("expected triaged P1 bug out of the inbox, got labels=%v type=%s priority=%d", issue.Labels, issue.Type, issue.Priority)
+	}
+
+	cmd = newInboxCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"drop", other})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error dropping an issue that is not in the inbox")
+	}
+}
+
+func TestInboxDrop(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+
+	id, err := store.Create(ctx, &issuestorage.Issue{Title: "Maybe later", Labels: []string{InboxLabel}})
+	if err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	cmd := newInboxCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"drop", id})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("drop failed: %v", err)
+	}
+	issue, err := store.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("failed to get issue: %v", err)
+	}
+	if issue.Status != issuestorage.StatusClosed || issue.CloseReason != "Dropped from inbox" {
+		t.Errorf("expected closed with reason, got status=%s reason=%q", issue.Status, issue.CloseReason)
+	}
+
+	app.Out = &bytes.Buffer{}
+	cmd = newInboxCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("inbox failed: %v", err)
+	}
+	if out := app.Out.(*bytes.Buffer).String(); !strings.Contains(out, "Inbox is empty.") {
+		t.Errorf("expected empty inbox, got:\n%s", out)
+	}
+}
@@ -17,6 +17,7 @@ type InheritedBlockerShowJSON struct {
 // Used for create, show, and list commands.
 type IssueJSON struct {
 	Assignee          string                     `json:"assignee,omitempty"`
+	Attachments       []AttachmentJSON           `json:"attachments,omitempty"`
 	Comments          []CommentJSON              `json:"comments,omitempty"`
 	CreatedAt         string                     `json:"created_at"`
 	CreatedBy         string                     `json:"created_by,omitempty"`
@@ -29,18 +30,92 @@ type IssueJSON struct {
 	InheritedBlockers []InheritedBlockerShowJSON `json:"inherited_blockers,omitempty"`
 	IssueType         string                     `json:"issue_type"`
 	Labels            []string                   `json:"labels,omitempty"`
+	Subscribers       []string                   `json:"subscribers,omitempty"`
 	Owner             string                     `json:"owner,omitempty"`
 	Parent            string                     `json:"parent,omitempty"`
 	Priority          int                        `json:"priority"`
+	Rank              string                     `json:"rank,omitempty"`
+	Reporter          string                     `json:"reporter,omitempty"`
+	Severity          string                     `json:"severity,omitempty"`
 	Status            string                     `json:"status"`
 	Title             string                     `json:"title"`
 	UpdatedAt         string                     `json:"updated_at"`
 	CloseReason       string                     `json:"close_reason,omitempty"`
+	Resolution        string                     `json:"resolution,omitempty"`
+	DuplicateOf       string                     `json:"duplicate_of,omitempty"`
 	ClosedAt          string                     `json:"closed_at,omitempty"`
+	DueAt             string                     `json:"due_at,omitempty"`
+	DueEvent          string                     `json:"due_event,omitempty"`
+	ExternalRefs      map[string]string          `json:"external_refs,omitempty"`
+	Vars              map[string]string          `json:"vars,omitempty"`
+	DeferUntil        string                     `json:"defer_until,omitempty"`
+	Estimate          int                        `json:"estimate_minutes,omitempty"`
+	TimeSpent         int                        `json:"time_spent_minutes,omitempty"`
+	Milestone         string                     `json:"milestone,omitempty"`
+	Sprint            string                     `json:"sprint,omitempty"`
 	AwaitType         string                     `json:"await_type,omitempty"`
 	AwaitID           string                     `json:"await_id,omitempty"`
 	TimeoutNS         int64                      `json:"timeout_ns,omitempty"`
 	Waiters           []string                   `json:"waiters,omitempty"`
+	Likelihood        int                        `json:"likelihood,omitempty"`
+	Impact            int                        `json:"impact,omitempty"`
+	Exposure          int                        `json:"exposure,omitempty"`
+	ReviewBy          string                     `json:"review_by,omitempty"`
+	DecisionState     string                     `json:"decision_state,omitempty"`
+	AcceptedAnswer    int                        `json:"accepted_answer,omitempty"`
+	Criteria          []CriterionJSON            `json:"acceptance_criteria,omitempty"`
+	Reviewer          string                     `json:"reviewer,omitempty"`
+	Reviews           []ReviewEntryJSON          `json:"reviews,omitempty"`
+	Rollup            *RollupJSON                `json:"rollup,omitempty"`
+	Effort            *EffortJSON                `json:"effort,omitempty"`
+}
+
+// CriterionJSON is the JSON output format for an acceptance criterion.
+type CriterionJSON struct {
+	Number    int    `json:"number"`
+	Text      string `json:"text"`
+	Done      bool   `json:"done"`
+	CheckedBy string `json:"checked_by,omitempty"`
+	CheckedAt string `json:"checked_at,omitempty"`
+}
+
+// ToCriteriaJSON converts an issue's acceptance criteria to JSON output
+// format, numbered from 1.
+func ToCriteriaJSON(criteria []issuestorage.Criterion) []CriterionJSON {
+	out := make([]CriterionJSON, len(criteria))
+	for i, c := range criteria {
+		out[i] = CriterionJSON{Number: i + 1, Text: c.Text, Done: c.Done, CheckedBy: c.CheckedBy}
+		if c.CheckedAt != nil {
+			out[i].CheckedAt = formatTime(*c.CheckedAt)
+		}
+	}
+	return out
+}
+
+// ReviewEntryJSON is the JSON output format for a review verdict on an
+// issue.
+type ReviewEntryJSON struct {
+	Reviewer string `json:"reviewer"`
+	Outcome  string `json:"outcome"`
+	Comment  string `json:"comment,omitempty"`
+	At       string `json:"at"`
+}
+
+// ToReviewsJSON converts an issue's reviews to JSON output format.
+func ToReviewsJSON(reviews []issuestorage.Review) []ReviewEntryJSON {
+	out := make([]ReviewEntryJSON, len(reviews))
+	for i, r := range reviews {
+		out[i] = ReviewEntryJSON{Reviewer: r.Reviewer, Outcome: string(r.Outcome), Comment: r.Comment, At: formatTime(r.At)}
+	}
+	return out
+}
+
+// RollupJSON summarizes progress across an issue's children and tracked issues.
+type RollupJSON struct {
+	Children int `json:"children"`
+	Closed   int `json:"closed"`
+	Total    int `json:"total"`
+	Tracked  int `json:"tracked"`
 }
 
 // EnrichedDepJSON is a dependency with full issue details for JSON output.
@@ -68,6 +143,30 @@ type CommentJSON struct {
 	Text      string `json:"text"`
 }
 
+// AttachmentJSON is the JSON output format for attachments.
+type AttachmentJSON struct {
+	AddedAt   string `json:"added_at"`
+	AddedBy   string `json:"added_by,omitempty"`
+	IssueID   string `json:"issue_id"`
+	MediaType string `json:"media_type,omitempty"`
+	Name      string `json:"name"`
+	SHA256    string `json:"sha256"`
+	Size      int64  `json:"size"`
+}
+
+// ToAttachmentJSON converts an issuestorage.Attachment to AttachmentJSON.
+func ToAttachmentJSON(issueID string, a issuestorage.Attachment) AttachmentJSON {
+	return AttachmentJSON{
+		AddedAt:   formatTime(a.AddedAt),
+		AddedBy:   a.AddedBy,
+		IssueID:   issueID,
+		MediaType: a.MediaType,
+		Name:      a.Name,
+		SHA256:    a.SHA256,
+		Size:      a.Size,
+	}
+}
+
 // ListDepJSON is the dependency format used in list command output.
 // Different from EnrichedDepJSON - uses depends_on_id/issue_id instead of full issue data.
 type ListDepJSON struct {
@@ -78,29 +177,54 @@ type ListDepJSON struct {
 	Type        string `json:"type"`
 }
 
+// DepChangeJSON is the JSON output format for dep add and dep remove.
+type DepChangeJSON struct {
+	DependsOnID string `json:"depends_on_id"`
+	IssueID     string `json:"issue_id"`
+	Status      string `json:"status"` // "added" or "removed"
+	Type        string `json:"type,omitempty"`
+}
+
 // IssueListJSON is the JSON output format for list command.
 type IssueListJSON struct {
-	Assignee        string        `json:"assignee,omitempty"`
-	CloseReason     string        `json:"close_reason,omitempty"`
-	ClosedAt        string        `json:"closed_at,omitempty"`
-	CreatedAt       string        `json:"created_at"`
-	CreatedBy       string        `json:"created_by,omitempty"`
-	DeleteReason    string        `json:"delete_reason,omitempty"`
-	DeletedAt       string        `json:"deleted_at,omitempty"`
-	DeletedBy       string        `json:"deleted_by,omitempty"`
-	Dependencies    []ListDepJSON `json:"dependencies,omitempty"`
-	DependencyCount int           `json:"dependency_count"`
-	DependentCount  int           `json:"dependent_count"`
-	Description     string        `json:"description,omitempty"`
-	ID              string        `json:"id"`
-	IssueType       string        `json:"issue_type"`
-	Labels          []string      `json:"labels,omitempty"`
-	OriginalType    string        `json:"original_type,omitempty"`
-	Owner           string        `json:"owner,omitempty"`
-	Priority        int           `json:"priority"`
-	Status          string        `json:"status"`
-	Title           string        `json:"title"`
-	UpdatedAt       string        `json:"updated_at"`
+	Assignee        string           `json:"assignee,omitempty"`
+	Reviewer        string           `json:"reviewer,omitempty"`
+	CloseReason     string           `json:"close_reason,omitempty"`
+	Resolution      string           `json:"resolution,omitempty"`
+	DuplicateOf     string           `json:"duplicate_of,omitempty"`
+	ClosedAt        string           `json:"closed_at,omitempty"`
+	CreatedAt       string           `json:"created_at"`
+	CreatedBy       string           `json:"created_by,omitempty"`
+	DeleteReason    string           `json:"delete_reason,omitempty"`
+	DeletedAt       string           `json:"deleted_at,omitempty"`
+	DeletedBy       string           `json:"deleted_by,omitempty"`
+	Dependencies    []ListDepJSON    `json:"dependencies,omitempty"`
+	DependencyCount int              `json:"dependency_count"`
+	DependentCount  int              `json:"dependent_count"`
+	Description     string           `json:"description,omitempty"`
+	ID              string           `json:"id"`
+	IssueType       string           `json:"issue_type"`
+	Labels          []string         `json:"labels,omitempty"`
+	Milestone       string           `json:"milestone,omitempty"`
+	OriginalType    string           `json:"original_type,omitempty"`
+	Owner           string           `json:"owner,omitempty"`
+	Priority        int              `json:"priority"`
+	Rank            string           `json:"rank,omitempty"`
+	Severity        string           `json:"severity,omitempty"`
+	Status          string           `json:"status"`
+	Title           string           `json:"title"`
+	UpdatedAt       string           `json:"updated_at"`
+	Answer          string           `json:"answer,omitempty"` // accepted answer text (search results only)
+	Match           *SearchMatchJSON `json:"match,omitempty"`  // best-matching field (bd search --snippets only)
+}
+
+// SearchMatchJSON is the best-matching field of a search result: its name
+// (title, labels, description, answer or comment), an excerpt around the
+// first match, and the byte ranges of matching words in the excerpt.
+type SearchMatchJSON struct {
+	Field      string   `json:"field"`
+	Snippet    string   `json:"snippet"`
+	Highlights [][2]int `json:"highlights"`
 }
 
 // IssueSimpleJSON is a simpler JSON output format for ready/blocked commands (no counts).
@@ -153,9 +277,13 @@ func ToIssueJSON(ctx context.Context, store issuestorage.IssueGetter, issue *iss
 		ID:          issue.ID,
 		IssueType:   string(issue.Type),
 		Labels:      issue.Labels,
+		Subscribers: issue.Subscribers,
 		Owner:       issue.Owner,
 		Parent:      issue.Parent,
 		Priority:    priorityToInt(issue.Priority),
+		Rank:        issue.Rank,
+		Reporter:    issue.ReportedBy(),
+		Severity:    string(issue.Severity),
 		Status:      string(issue.Status),
 		Title:       issue.Title,
 		UpdatedAt:   formatTime(issue.UpdatedAt),
@@ -164,9 +292,24 @@ func ToIssueJSON(ctx context.Context, store issuestorage.IssueGetter, issue *iss
 	if issue.CloseReason != "" {
 		out.CloseReason = issue.CloseReason
 	}
+	out.Resolution = string(issue.Resolution)
+	out.DuplicateOf = issue.DuplicateOf
 	if issue.ClosedAt != nil {
 		out.ClosedAt = formatTime(*issue.ClosedAt)
 	}
+	if issue.DueAt != nil {
+		out.DueAt = formatTime(*issue.DueAt)
+	}
+	out.DueEvent = issue.DueEvent
+	out.ExternalRefs = issue.ExternalRefs
+	out.Vars = issue.Vars
+	if issue.DeferUntil != nil {
+		out.DeferUntil = formatTime(*issue.DeferUntil)
+	}
+	out.Estimate = issue.Estimate
+	out.TimeSpent = issue.TimeSpent
+	out.Milestone = issue.Milestone
+	out.Sprint = issue.Sprint
 
 	// Gate fields
 	out.AwaitType = issue.AwaitType
@@ -174,6 +317,23 @@ func ToIssueJSON(ctx context.Context, store issuestorage.IssueGetter, issue *iss
 	out.TimeoutNS = issue.TimeoutNS
 	out.Waiters = issue.Waiters
 
+	// Risk fields
+	out.Likelihood = issue.Likelihood
+	out.Impact = issue.Impact
+	out.Exposure = issue.Exposure()
+	if issue.ReviewBy != nil {
+		out.ReviewBy = formatTime(*issue.ReviewBy)
+	}
+	out.DecisionState = string(issue.DecisionState)
+	out.AcceptedAnswer = issue.AcceptedAnswer
+	if len(issue.AcceptanceCriteria) > 0 {
+		out.Criteria = ToCriteriaJSON(issue.AcceptanceCriteria)
+	}
+	out.Reviewer = issue.Reviewer
+	if len(issue.Reviews) > 0 {
+		out.Reviews = ToReviewsJSON(issue.Reviews)
+	}
+
 	// Comments
 	if len(issue.Comments) > 0 {
 		out.Comments = make([]CommentJSON, len(issue.Comments))
@@ -188,6 +348,10 @@ func ToIssueJSON(ctx context.Context, store issuestorage.IssueGetter, issue *iss
 		}
 	}
 
+	for _, a := range issue.Attachments {
+		out.Attachments = append(out.Attachments, ToAttachmentJSON(issue.ID, a))
+	}
+
 	if useCounts {
 		// Use dependency/dependent counts
 		depCount := len(issue.Dependencies)
@@ -226,6 +390,7 @@ func ToIssueListJSON(issue *issuestorage.Issue) IssueListJSON {
 
 	out := IssueListJSON{
 		Assignee:        issue.Assignee,
+		Reviewer:        issue.Reviewer,
 		CreatedAt:       formatTime(issue.CreatedAt),
 		CreatedBy:       issue.CreatedBy,
 		Dependencies:    deps,
@@ -235,8 +400,11 @@ func ToIssueListJSON(issue *issuestorage.Issue) IssueListJSON {
 		ID:              issue.ID,
 		IssueType:       string(issue.Type),
 		Labels:          issue.Labels,
+		Milestone:       issue.Milestone,
 		Owner:           issue.Owner,
 		Priority:        priorityToInt(issue.Priority),
+		Rank:            issue.Rank,
+		Severity:        string(issue.Severity),
 		Status:          string(issue.Status),
 		Title:           issue.Title,
 		UpdatedAt:       formatTime(issue.UpdatedAt),
@@ -245,6 +413,8 @@ func ToIssueListJSON(issue *issuestorage.Issue) IssueListJSON {
 	if issue.CloseReason != "" {
 		out.CloseReason = issue.CloseReason
 	}
+	out.Resolution = string(issue.Resolution)
+	out.DuplicateOf = issue.DuplicateOf
 	if issue.ClosedAt != nil {
 		out.ClosedAt = formatTime(*issue.ClosedAt)
 	}
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// formatJSONL is the --format value of bd export and bd import for a whole
+// store in JSON Lines: one issue per line, in the issue file format.
+const formatJSONL = "jsonl"
+
+// JSONLImportJSON is the JSON output of bd import --format jsonl.
+type JSONLImportJSON struct {
+	Created   int  `json:"created"`
+	Updated   int  `json:"updated"`
+	Unchanged int  `json:"unchanged"` // the store's copy is as new or newer
+	DryRun    bool `json:"dry_run,omitempty"`
+}
+
+// listJSONLIssues returns every issue in the store, tombstones included,
+// ordered by creation. Ephemeral issues are left out, along with the
+// dependencies other issues have on them.
+func listJSONLIssues(ctx context.Context, app *App) ([]*issuestorage.Issue, error) {
+	seen := make(map[string]bool)
+	ephemeral := make(map[string]bool)
+	var issues []*issuestorage.Issue
+	for _, filter := range []*issuestorage.ListFilter{
+		nil,
+		{Statuses: []issuestorage.Status{issuestorage.StatusClosed}},
+		{Statuses: []issuestorage.Status{issuestorage.StatusTombstone}},
+	} {
+		found, err := app.Storage.List(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("listing issues: %w", err)
+		}
+		for _, issue := range found {
+			switch {
+			case seen[issue.ID]:
+			case issue.Ephemeral:
+				ephemeral[issue.ID] = true
+			default:
+				issues = append(issues, issue)
+			}
+			seen[issue.ID] = true
+		}
+	}
+
+	if len(ephemeral) > 0 {
+		keep := func(deps []issuestorage.Dependency) []issuestorage.Dependency {
+			var kept []issuestorage.Dependency
+			for _, d := range deps {
+				if !ephemeral[d.ID] {
+					kept = append(kept, d)
+				}
+			}
+			return kept
+		}
+		for _, issue := range issues {
+			issue.Dependencies = keep(issue.Dependencies)
+			issue.Dependents = keep(issue.Dependents)
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if !issues[i].CreatedAt.Equal(issues[j].CreatedAt) {
+			return issues[i].CreatedAt.Before(issues[j].CreatedAt)
+		}
+		return issues[i].ID < issues[j].ID
+	})
+	return issues, nil
+}
+
+// writeJSONL writes one issue per line.
+func writeJSONL(w io.Writer, issues []*issuestorage.Issue) error {
+	enc := json.NewEncoder(w)
+	for _, issue := range issues {
+		if err := enc.Encode(issue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readJSONL reads issues written by writeJSONL, skipping blank lines. An
+// ID that appears more than once takes its last line, so an export can be
+// brought up to date by appending changed issues to it. Issues are
+// returned in the order their IDs first appear.
+func readJSONL(r io.Reader) ([]*issuestorage.Issue, error) {
+	var order []string
+	byID := make(map[string]*issuestorage.Issue)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		data := scanner.Bytes()
+		if len(bytes.TrimSpace(data)) == 0 {
+			continue
+		}
+		var issue issuestorage.Issue
+		if err := json.Unmarshal(data, &issue); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if issue.ID == "" {
+			return nil, fmt.Errorf("line %d: issue has no id", line)
+		}
+		if _, ok := byID[issue.ID]; !ok {
+			order = append(order, issue.ID)
+		}
+		byID[issue.ID] = &issue
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	issues := make([]*issuestorage.Issue, len(order))
+	for i, id := range order {
+		issues[i] = byID[id]
+	}
+	return issues, nil
+}
+
+// importJSONL restores issues into the store. An issue the store lacks is
+// created; one it has is replaced only when the imported copy was updated
+// later, so importing an older export never loses newer work.
+func importJSONL(ctx context.Context, app *App, issues []*issuestorage.Issue, dryRun bool) (*JSONLImportJSON, error) {
+	result := &JSONLImportJSON{DryRun: dryRun}
+	for _, issue := range issues {
+		existing, err := app.Storage.Get(ctx, issue.ID)
+		switch {
+		case err == nil && !issue.UpdatedAt.After(existing.UpdatedAt):
+			result.Unchanged++
+			continue
+		case err != nil && !errors.Is(err, issuestorage.ErrNotFound):
+			return nil, fmt.Errorf("checking %s: %w", issue.ID, err)
+		}
+		if dryRun {
+			if err == nil {
+				result.Updated++
+			} else {
+				result.Created++
+			}
+			continue
+		}
+		created, err := app.Storage.Restore(ctx, issue)
+		if err != nil {
+			return nil, fmt.Errorf("restoring %s: %w", issue.ID, err)
+		}
+		if created {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+	}
+	return result, nil
+}
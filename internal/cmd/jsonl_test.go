@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issueservice"
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// seedJSONLIssues adds to the export seed a comment, a blocking
+// dependency, a tombstoned child and an ephemeral issue.
+func seedJSONLIssues(t *testing.T, store *issueservice.IssueStore) (epic, bug, wisp string) {
+	t.Helper()
+	ctx := context.Background()
+	epic, _, bug = seedExportIssues(t, store)
+	if err := store.Modify(ctx, bug, func(i *issuestorage.Issue) error {
+		i.Comments = append(i.Comments, issuestorage.Comment{ID: 1, Author: "alice", Text: "Repro on staging"})
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddDependency(ctx, epic, bug, issuestorage.DepTypeBlocks); err != nil {
+		t.Fatal(err)
+	}
+	gone, err := store.GetNextChildID(ctx, epic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deleted := store.Now()
+	if _, err := store.Create(ctx, &issuestorage.Issue{
+		ID:           gone,
+		Title:        "Dropped",
+		Status:       issuestorage.StatusTombstone,
+		DeletedAt:    &deleted,
+		Parent:       epic,
+		Dependencies: []issuestorage.Dependency{{ID: epic, Type: issuestorage.DepTypeParentChild}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	wisp, err = store.Create(ctx, &issuestorage.Issue{Title: "Scratch", Ephemeral: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddDependency(ctx, wisp, bug, issuestorage.DepTypeRelated); err != nil {
+		t.Fatal(err)
+	}
+	return epic, bug, wisp
+}
+
+func runJSONLCmd(t *testing.T, app *App, newCmd func(*AppProvider) *cobra.Command, args ...string) string {
+	t.Helper()
+	out := &bytes.Buffer{}
+	app.Out = out
+	cmd := newCmd(NewTestProvider(app))
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("%v: %v", args, err)
+	}
+	return out.String()
+}
+
+func TestJSONLRoundTrip(t *testing.T) {
+	src, srcStore := setupTestApp(t)
+	epic, bug, wisp := seedJSONLIssues(t, srcStore)
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	runJSONLCmd(t, src, newExportCmd, "--format", "jsonl", "-o", path)
+
+	dst, dstStore := setupTestApp(t)
+	out := runJSONLCmd(t, dst, newImportCmd, "--format", "jsonl", "-i", path)
+	if !strings.Contains(out, "4 created, 0 updated, 0 unchanged") {
+		t.Errorf("import output = %q", out)
+	}
+
+	ctx := context.Background()
+	exported, err := listJSONLIssues(ctx, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range exported {
+		got, err := dstStore.Get(ctx, want.ID)
+		if err != nil {
+			t.Fatalf("imported %s: %v", want.ID, err)
+		}
+		gotJSON, _ := json.Marshal(got)
+		wantJSON, _ := json.Marshal(want)
+		if !bytes.Equal(gotJSON, wantJSON) {
+			t.Errorf("%s imported as\n%s\nwant\n%s", want.ID, gotJSON, wantJSON)
+		}
+	}
+
+	if _, err := dstStore.Get(ctx, wisp); err != issuestorage.ErrNotFound {
+		t.Errorf("ephemeral issue imported: %v", err)
+	}
+	if got, _ := dstStore.Get(ctx, bug); len(got.Dependents) != 1 || got.Dependents[0].ID != epic {
+		t.Errorf("bug dependents = %+v, want only %s", got.Dependents, epic)
+	}
+	if next, err := dstStore.GetNextChildID(ctx, epic); err != nil || next != epic+".3" {
+		t.Errorf("next child of %s = %q, %v; want %s.3", epic, next, err, epic)
+	}
+
+	out = runJSONLCmd(t, dst, newImportCmd, "--format", "jsonl", "-i", path)
+	if !strings.Contains(out, "0 created, 0 updated, 4 unchanged") {
+		t.Errorf("re-import output = %q", out)
+	}
+}
+
+func TestImportJSONL_NewestWins(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	id, err := store.Create(ctx, &issuestorage.Issue{Title: "Current"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, _ := store.Get(ctx, id)
+
+	line := func(title string, updated time.Time) string {
+		issue := *current
+		issue.Title, issue.UpdatedAt = title, updated
+		data, _ := json.Marshal(&issue)
+		return string(data) + "\n"
+	}
+	older := line("Stale", current.UpdatedAt.Add(-time.Hour))
+	newer := line("Edited elsewhere", current.UpdatedAt.Add(time.Hour))
+
+	path := writeImportFile(t, "old.jsonl", older)
+	app.JSON = true
+	var result JSONLImportJSON
+	json.Unmarshal([]byte(runJSONLCmd(t, app, newImportCmd, "--format", "jsonl", "-i", path)), &result)
+	if result != (JSONLImportJSON{Unchanged: 1}) {
+		t.Errorf("older copy: %+v", result)
+	}
+
+	// Appended lines replace earlier ones for the same ID.
+	path = writeImportFile(t, "appended.jsonl", older+"\n"+newer)
+	result = JSONLImportJSON{}
+	json.Unmarshal([]byte(runJSONLCmd(t, app, newImportCmd, "--format", "jsonl", "-i", path, "--dry-run")), &result)
+	if result != (JSONLImportJSON{Updated: 1, DryRun: true}) {
+		t.Errorf("dry run: %+v", result)
+	}
+	if got, _ := store.Get(ctx, id); got.Title != "Current" {
+		t.Errorf("dry run wrote %q", got.Title)
+	}
+	runJSONLCmd(t, app, newImportCmd, "--format", "jsonl", "-i", path)
+	if got, _ := store.Get(ctx, id); got.Title != "Edited elsewhere" {
+		t.Errorf("title = %q, want the newer copy", got.Title)
+	}
+}
+
+func TestImportJSONL_Errors(t *testing.T) {
+	app, _ := setupTestApp(t)
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--format", "yaml"}, `unknown import format "yaml"`},
+		{[]string{"--format", "jsonl", "-i", writeImportFile(t, "bad.jsonl", "{\"id\":\"bd-1\"}\nnot json\n")}, "line 2"},
+		{[]string{"--format", "jsonl", "-i", writeImportFile(t, "noid.jsonl", "{\"title\":\"x\"}\n")}, "line 1: issue has no id"},
+		{[]string{"--format", "jsonl", "-i", filepath.Join(t.TempDir(), "missing.jsonl")}, "no such file"},
+	}
+	for _, tt := range tests {
+		cmd := newImportCmd(NewTestProvider(app))
+		cmd.SetArgs(tt.args)
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v: err = %v, want %q", tt.args, err, tt.want)
+		}
+	}
+
+	cmd := newExportCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--format", "csv"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "csv, org and timeline are subcommands") {
+		t.Errorf("export --format csv: err = %v", err)
+	}
+}
+
+func TestExportJSONL_Stdout(t *testing.T) {
+	app, store := setupTestApp(t)
+	seedExportIssues(t, store)
+	out := runJSONLCmd(t, app, newExportCmd, "--format", "jsonl")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3:\n%s", len(lines), out)
+	}
+	for _, l := range lines {
+		var issue issuestorage.Issue
+		if err := json.Unmarshal([]byte(l), &issue); err != nil || issue.ID == "" {
+			t.Errorf("line %q: %v", l, err)
+		}
+	}
+}
@@ -20,12 +20,14 @@ func newLabelCmd(provider *AppProvider) *cobra.Command {
 Subcommands:
   add     Add a label to an issue
   remove  Remove a label from an issue
-  list    List labels on an issue`,
+  list    List labels on an issue
+  expire  Remove or escalate labels whose expiry has passed`,
 	}
 
 	cmd.AddCommand(newLabelAddCmd(provider))
 	cmd.AddCommand(newLabelRemoveCmd(provider))
 	cmd.AddCommand(newLabelListCmd(provider))
+	cmd.AddCommand(newLabelExpireCmd(provider))
 
 	return cmd
 }
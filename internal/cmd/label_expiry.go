@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// Config keys for label expiry policies. A label becomes temporary once
+// expire.<label>.after is set; expire.<label>.action chooses what happens
+// when it runs out.
+const (
+	expireKeyPrefix    = "expire."
+	expireAfterSuffix  = ".after"
+	expireActionSuffix = ".action"
+)
+
+// expireActions lists the values accepted for expire.<label>.action:
+//   - remove: drop the label (the default)
+//   - escalate: drop the label and raise the issue's priority one level
+var expireActions = []string{"remove", "escalate"}
+
+// expiryPolicy is a label expiry policy declared in config.
+type expiryPolicy struct {
+	Label  string
+	After  time.Duration
+	Window string // After as configured, e.g. "14d"
+	Action string
+}
+
+// source names the policy in history entries and messages.
+func (p expiryPolicy) source() string {
+	return expireKeyPrefix + p.Label
+}
+
+// LabelExpiryJSON is the JSON output format for one label bd label expire
+// removed, or would remove with --dry-run.
+type LabelExpiryJSON struct {
+	ID       string    `json:"id"`
+	Label    string    `json:"label"`
+	Action   string    `json:"action"`
+	Since    time.Time `json:"since"`    // when the label's clock last started
+	Priority int       `json:"priority"` // the issue's priority afterwards
+}
+
+// expiryPolicies returns the configured policies ordered by label,
+// skipping any that do not validate.
+func expiryPolicies(app *App) []expiryPolicy {
+	if app.ConfigStore == nil {
+		return nil
+	}
+	all := app.ConfigStore.All()
+	var policies []expiryPolicy
+	for key, window := range all {
+		rest, ok := strings.CutPrefix(key, expireKeyPrefix)
+		if !ok || !strings.HasSuffix(rest, expireAfterSuffix) {
+			continue
+		}
+		label := strings.TrimSuffix(rest, expireAfterSuffix)
+		after, err := parseDuration(window)
+		if label == "" || err != nil {
+			continue
+		}
+		action := all[expireKeyPrefix+label+expireActionSuffix]
+		if action == "" {
+			action = "remove"
+		}
+		if contains(expireActions, action) {
+			policies = append(policies, expiryPolicy{Label: label, After: after, Window: window, Action: action})
+		}
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Label < policies[j].Label })
+	return policies
+}
+
+// validateExpireKey is the config validator for expire.<label>.* keys.
+func validateExpireKey(key, v string) string {
+	switch {
+	case strings.HasSuffix(key, expireAfterSuffix):
+		if _, err := parseDuration(v); err != nil {
+			return fmt.Sprintf("%s: %v", key, err)
+		}
+	case strings.HasSuffix(key, expireActionSuffix):
+		if !contains(expireActions, v) {
+			return fmt.Sprintf("%s: invalid value %q (valid: %s)", key, v, strings.Join(expireActions, ", "))
+		}
+	}
+	return ""
+}
+
+// labelClockStart returns when label's expiry clock last started on issue:
+// when the label was last added, or the issue created if history does not
+// say, restarted by each later comment from the reporter.
+func labelClockStart(issue *issuestorage.Issue, label string) time.Time {
+	start := issue.CreatedAt
+	for _, h := range issue.History {
+		if h.Event == issuestorage.EventLabeled && h.New == label && h.At.After(start) {
+			start = h.At
+		}
+	}
+	if reporter := issue.ReportedBy(); reporter != "" {
+		for _, c := range issue.Comments {
+			if c.Author == reporter && c.CreatedAt.After(start) {
+				start = c.CreatedAt
+			}
+		}
+	}
+	return start
+}
+
+// expireLabels applies every expiry policy to the open issues, removing
+// (and with escalate, raising the priority for) each label whose clock ran
+// out before now. With dryRun it only reports what it would do.
+func expireLabels(ctx context.Context, app *App, now time.Time, dryRun bool) ([]LabelExpiryJSON, error) {
+	policies := expiryPolicies(app)
+	expired := []LabelExpiryJSON{}
+	if len(policies) == 0 {
+		return expired, nil
+	}
+	issues, err := app.Storage.List(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("listing issues: %w", err)
+	}
+	sort.Slice(issues, func(i, j int) bool { return issues[i].ID < issues[j].ID })
+
+	actor, _ := resolveActor(app)
+	for _, issue := range issues {
+		for _, p := range policies {
+			if !contains(issue.Labels, p.Label) {
+				continue
+			}
+			since := labelClockStart(issue, p.Label)
+			if now.Before(since.Add(p.After)) {
+				continue
+			}
+			priority := issue.Priority
+			note := fmt.Sprintf("%s: removed after %s", p.source(), p.Window)
+			if p.Action == "escalate" && priority > issuestorage.PriorityCritical {
+				priority--
+				note = fmt.Sprintf("%s: escalated %s → %s after %s", p.source(), issue.Priority.Display(), priority.Display(), p.Window)
+			}
+			if !dryRun {
+				if err := app.Storage.Modify(ctx, issue.ID, func(i *issuestorage.Issue) error {
+					i.Labels = removeFromSlice(i.Labels, p.Label)
+					i.Priority = priority
+					i.History = append(i.History, issuestorage.HistoryEntry{
+						At:    now,
+						Actor: actor,
+						Event: issuestorage.EventLabelExpired,
+						Field: "labels",
+						Old:   p.Label,
+						Note:  note,
+					})
+					return nil
+				}); err != nil {
+					return nil, fmt.Errorf("expiring %s on %s: %w", p.Label, issue.ID, err)
+				}
+			}
+			issue.Priority = priority
+			expired = append(expired, LabelExpiryJSON{
+				ID:       issue.ID,
+				Label:    p.Label,
+				Action:   p.Action,
+				Since:    since,
+				Priority: int(priority),
+			})
+		}
+	}
+	return expired, nil
+}
+
+// newLabelExpireCmd creates the "label expire" subcommand.
+func newLabelExpireCmd(provider *AppProvider) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "expire",
+		Short: "Remove or escalate labels whose expiry has passed",
+		Long: `Apply the label expiry policies in config to every open issue.
+
+A policy makes a label temporary: expire.<label>.after sets how long it may
+stay, in days, weeks, months or years (e.g. 14d, 2w). Its clock starts when
+the label is added and restarts whenever the issue's reporter comments, so
+a needs-info label lasts until the reporter has been silent that long.
+
+When the time is up the label is removed. With expire.<label>.action set to
+escalate, the issue's priority is also raised one level. Each expiry is
+recorded in the issue's history.
+
+bd label expire does nothing between runs; schedule it, e.g. from cron.
+
+Examples:
+  bd config set expire.needs-info.after 14d
+  bd config set expire.needs-info.action escalate
+  bd label expire --dry-run
+  bd label expire`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			expired, err := expireLabels(cmd.Context(), app, app.Now(), dryRun)
+			if err != nil {
+				return err
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(expired)
+			}
+			if len(expired) == 0 {
+				fmt.Fprintln(app.Out, "No labels expired.")
+				return nil
+			}
+			mark := app.SuccessColor("✓")
+			if dryRun {
+				mark = "(dry run)"
+			}
+			for _, e := range expired {
+				done := "removed"
+				if e.Action == "escalate" {
+					done = "removed, priority now " + issuestorage.Priority(e.Priority).Display()
+				}
+				fmt.Fprintf(app.Out, "%s %s: %q %s (clock started %s)\n", mark, e.ID, e.Label, done, e.Since.Format("2006-01-02"))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would expire without changing anything")
+
+	return cmd
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/clock"
+	"beads-lite/internal/issuestorage"
+)
+
+func TestLabelExpire(t *testing.T) {
+	app, store := setupTestApp(t)
+	app.ConfigStore = &mapConfigStore{data: map[string]string{
+		"expire.needs-info.after":  "14d",
+		"expire.needs-info.action": "escalate",
+		"expire.flaky.after":       "1w",
+	}}
+	fake := clock.NewFake(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC))
+	store.SetClock(fake)
+	ctx := context.Background()
+
+	create := func(title string) string {
+		id, err := store.Create(ctx, &issuestorage.Issue{Title: title, CreatedBy: "alice", Priority: issuestorage.PriorityMedium})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+	label := func(id, l string) {
+		if err := store.Modify(ctx, id, func(i *issuestorage.Issue) error {
+			i.Labels = append(i.Labels, l)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	comment := func(id, author string) {
+		if err := store.Modify(ctx, id, func(i *issuestorage.Issue) error {
+			i.Comments = append(i.Comments, issuestorage.Comment{ID: len(i.Comments) + 1, Author: author, Text: "…", CreatedAt: fake.Now()})
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	silent := create("Reporter went quiet")
+	replied := create("Reporter replied")
+	flaky := create("Flaky test")
+	fake.Advance(24 * time.Hour)
+	label(silent, "needs-info")
+	label(replied, "needs-info")
+	label(flaky, "flaky")
+
+	fake.Advance(10 * 24 * time.Hour)
+	comment(silent, "bob") // only the reporter restarts the clock
+	comment(replied, "alice")
+	fake.Advance(5 * 24 * time.Hour)
+
+	run := func(args ...string) []LabelExpiryJSON {
+		t.Helper()
+		app.JSON = true
+		app.Out = &bytes.Buffer{}
+		cmd := newLabelCmd(NewTestProvider(app))
+		cmd.SetArgs(append([]string{"expire"}, args...))
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("label expire: %v", err)
+		}
+		var got []LabelExpiryJSON
+		if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	dry := run("--dry-run")
+	if len(dry) != 2 {
+		t.Fatalf("dry run = %+v, want flaky and the silent needs-info", dry)
+	}
+	if got, _ := store.Get(ctx, silent); !contains(got.Labels, "needs-info") {
+		t.Error("dry run removed a label")
+	}
+
+	expired := run()
+	want := map[string]string{silent: "needs-info", flaky: "flaky"}
+	if len(expired) != 2 {
+		t.Fatalf("expired = %+v", expired)
+	}
+	for _, e := range expired {
+		if want[e.ID] != e.Label {
+			t.Errorf("unexpected expiry %+v", e)
+		}
+	}
+
+	got, _ := store.Get(ctx, silent)
+	if contains(got.Labels, "needs-info") || got.Priority != issuestorage.PriorityHigh {
+		t.Errorf("silent: labels %q, priority %s; want needs-info removed and P1", got.Labels, got.Priority.Display())
+	}
+	last := got.History[len(got.History)-1]
+	if last.Event != issuestorage.EventLabelExpired || last.Old != "needs-info" || !strings.Contains(last.Note, "escalated P2 → P1 after 14d") {
+		t.Errorf("history entry = %+v", last)
+	}
+	if got, _ := store.Get(ctx, flaky); contains(got.Labels, "flaky") || got.Priority != issuestorage.PriorityMedium {
+		t.Errorf("flaky: labels %q, priority %s; want removed at P2", got.Labels, got.Priority.Display())
+	}
+	if got, _ := store.Get(ctx, replied); !contains(got.Labels, "needs-info") {
+		t.Error("needs-info expired although the reporter commented")
+	}
+
+	if again := run(); len(again) != 0 {
+		t.Errorf("second run expired %+v", again)
+	}
+}
+
+func TestValidateExpireKey(t *testing.T) {
+	if msg := validateExpireKey("expire.needs-info.after", "14d"); msg != "" {
+		t.Errorf("valid window: %s", msg)
+	}
+	if msg := validateExpireKey("expire.needs-info.after", "14"); msg == "" {
+		t.Error("window without unit: want error")
+	}
+	if msg := validateExpireKey("expire.needs-info.action", "close"); msg == "" {
+		t.Error("unknown action: want error")
+	}
+}
@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// linkKeyPrefix prefixes the config keys of the link registry: each short
+// key's URL is stored as link.<KEY>, e.g. link.RFC-123.
+const linkKeyPrefix = "link."
+
+var (
+	// linkKeyPattern matches a registry key: a letter, then letters,
+	// digits, dashes and underscores.
+	linkKeyPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+	// linkRefPattern matches a reference to a registry key in text.
+	linkRefPattern = regexp.MustCompile(`\[\[([A-Za-z][A-Za-z0-9_-]*)\]\]`)
+)
+
+// LinkJSON is the JSON output format for a link registry entry.
+type LinkJSON struct {
+	Key string `json:"key"`
+	URL string `json:"url"`
+}
+
+// newLinkCmd creates the link command.
+func newLinkCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "link",
+		Short: "Link related issues, and reference external documents",
+		Long: `Link issues that are related without one blocking the other, and manage
+references from issues to documents kept elsewhere.
+
+Subcommands:
+  add       Link two issues
+  remove    Unlink two issues
+  list      List an issue's links
+  registry  Short keys for external URLs`,
+	}
+
+	cmd.AddCommand(newLinkAddCmd(provider))
+	cmd.AddCommand(newLinkRemoveCmd(provider))
+	cmd.AddCommand(newLinkListCmd(provider))
+	cmd.AddCommand(newLinkRegistryCmd(provider))
+
+	return cmd
+}
+
+// newLinkRegistryCmd creates the "link registry" subcommand.
+func newLinkRegistryCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "registry",
+		Short: "Manage short keys for external URLs",
+		Long: `Manage the link registry: short keys such as RFC-123 or DESIGN-4 that
+stand for the URLs of external documents, so issues can refer to them
+tersely and the same way everywhere.
+
+Write [[KEY]] in a description to refer to a registered document. bd show
+expands it to the key followed by its URL, and bd lint flags references
+to keys that are not registered. Keys are case-insensitive and stored in
+config as link.<KEY>, upper-cased.
+
+Subcommands:
+  add     Register a key, or point it at a new URL
+  list    List registered keys
+  remove  Unregister a key`,
+	}
+
+	cmd.AddCommand(newLinkRegistryAddCmd(provider))
+	cmd.AddCommand(newLinkRegistryListCmd(provider))
+	cmd.AddCommand(newLinkRegistryRemoveCmd(provider))
+
+	return cmd
+}
+
+// newLinkRegistryAddCmd creates the "link registry add" subcommand.
+func newLinkRegistryAddCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "add <key> <url>",
+		Short: "Register a key, or point it at a new URL",
+		Long: `Register a short key for a URL, replacing the URL of an existing key.
+
+Examples:
+  bd link registry add RFC-123 https://www.rfc-editor.org/rfc/rfc123
+  bd link registry add DESIGN-4 https://docs.example.com/design/4`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			key, err := parseLinkKey(args[0])
+			if err != nil {
+				return err
+			}
+			target := args[1]
+			if err := checkLinkURL(target); err != nil {
+				return err
+			}
+			store, err := configStore(provider)
+			if err != nil {
+				return err
+			}
+			if err := store.Set(linkKeyPrefix+key, target); err != nil {
+				return fmt.Errorf("saving link: %w", err)
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(LinkJSON{Key: key, URL: target})
+			}
+			fmt.Fprintf(app.Out, "%s [[%s]] now links to %s\n", app.SuccessColor("✓"), key, target)
+			return nil
+		},
+	}
+}
+
+// newLinkRegistryListCmd creates the "link registry list" subcommand.
+func newLinkRegistryListCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List registered keys",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			registry := linkRegistry(app)
+			links := []LinkJSON{}
+			for _, key := range sortedKeys(registry) {
+				links = append(links, LinkJSON{Key: key, URL: registry[key]})
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(links)
+			}
+			if len(links) == 0 {
+				fmt.Fprintln(app.Out, "No links registered.")
+				return nil
+			}
+			width := 0
+			for _, l := range links {
+				width = max(width, len(l.Key))
+			}
+			for _, l := range links {
+				fmt.Fprintf(app.Out, "%-*s  %s\n", width, l.Key, l.URL)
+			}
+			return nil
+		},
+	}
+}
+
+// newLinkRegistryRemoveCmd creates the "link registry remove" subcommand.
+func newLinkRegistryRemoveCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <key>",
+		Short: "Unregister a key",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			key, err := parseLinkKey(args[0])
+			if err != nil {
+				return err
+			}
+			store, err := configStore(provider)
+			if err != nil {
+				return err
+			}
+			if _, ok := store.Get(linkKeyPrefix + key); !ok {
+				return fmt.Errorf("no link registered for %s", key)
+			}
+			if err := store.Unset(linkKeyPrefix + key); err != nil {
+				return fmt.Errorf("removing link: %w", err)
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(map[string]string{"removed": key})
+			}
+			fmt.Fprintf(app.Out, "%s Removed [[%s]]\n", app.SuccessColor("✓"), key)
+			return nil
+		},
+	}
+}
+
+// parseLinkKey validates a registry key and returns it upper-cased.
+func parseLinkKey(s string) (string, error) {
+	if !linkKeyPattern.MatchString(s) {
+		return "", fmt.Errorf("invalid link key %q: must start with a letter and contain only letters, digits, - and _", s)
+	}
+	return strings.ToUpper(s), nil
+}
+
+// checkLinkURL checks that s is an absolute URL.
+func checkLinkURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" || (u.Host == "" && u.Opaque == "" && u.Path == "") {
+		return fmt.Errorf("invalid URL %q: must be absolute, e.g. https://example.com/doc", s)
+	}
+	return nil
+}
+
+// validateLinkKey is the config validator for link.<KEY> keys.
+func validateLinkKey(key, v string) string {
+	name := strings.TrimPrefix(key, linkKeyPrefix)
+	if !linkKeyPattern.MatchString(name) || name != strings.ToUpper(name) {
+		return fmt.Sprintf("%s: key must be upper-case letters, digits, - and _ (set it with bd link registry add)", key)
+	}
+	if err := checkLinkURL(v); err != nil {
+		return fmt.Sprintf("%s: %v", key, err)
+	}
+	return ""
+}
+
+// linkRegistry returns the registered URLs by upper-cased key.
+func linkRegistry(app *App) map[string]string {
+	registry := make(map[string]string)
+	if app.ConfigStore == nil {
+		return registry
+	}
+	for key, value := range app.ConfigStore.All() {
+		if name, ok := strings.CutPrefix(key, linkKeyPrefix); ok && name != "" && value != "" {
+			registry[strings.ToUpper(name)] = value
+		}
+	}
+	return registry
+}
+
+// expandLinks replaces each [[KEY]] reference in text to a registered key
+// with the key and its URL. Unknown references are left as written.
+func expandLinks(text string, registry map[string]string) string {
+	if len(registry) == 0 {
+		return text
+	}
+	return linkRefPattern.ReplaceAllStringFunc(text, func(ref string) string {
+		key := strings.ToUpper(ref[2 : len(ref)-2])
+		if target, ok := registry[key]; ok {
+			return key + " <" + target + ">"
+		}
+		return ref
+	})
+}
+
+// unknownLinkRefs returns the keys text refers to that are not
+// registered, upper-cased, in order of first use.
+func unknownLinkRefs(text string, registry map[string]string) []string {
+	var unknown []string
+	seen := make(map[string]bool)
+	for _, m := range linkRefPattern.FindAllStringSubmatch(text, -1) {
+		key := strings.ToUpper(m[1])
+		if _, ok := registry[key]; !ok && !seen[key] {
+			seen[key] = true
+			unknown = append(unknown, key)
+		}
+	}
+	return unknown
+}
@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// IssueLinkJSON is the JSON output of bd link add, remove and list: a link
+// of type Type from IssueID to Target ("outgoing") or from Target to
+// IssueID ("incoming").
+type IssueLinkJSON struct {
+	IssueID   string `json:"issue_id"`
+	Type      string `json:"type"`
+	Direction string `json:"direction"`
+	Target    string `json:"target"`
+	Title     string `json:"title,omitempty"`
+	Status    string `json:"status,omitempty"`
+}
+
+// linkTypeNames returns the link types, for flag help and errors.
+func linkTypeNames() string {
+	names := make([]string, len(issuestorage.LinkTypes))
+	for i, t := range issuestorage.LinkTypes {
+		names[i] = string(t)
+	}
+	return strings.Join(names, ", ")
+}
+
+// describeLink says how an issue relates to the other end of a link,
+// e.g. "duplicate of" or, for an incoming link, "duplicated by".
+func describeLink(t issuestorage.DependencyType, outgoing bool) string {
+	switch t {
+	case issuestorage.DepTypeRelatesTo:
+		return "relates to"
+	case issuestorage.DepTypeDuplicateOf:
+		if outgoing {
+			return "duplicate of"
+		}
+		return "duplicated by"
+	case issuestorage.DepTypeSupersedes:
+		if outgoing {
+			return "supersedes"
+		}
+		return "superseded by"
+	}
+	return string(t)
+}
+
+// issueLinks returns issue's links in both directions, outgoing first, in
+// the order they were added.
+func issueLinks(ctx context.Context, getter issuestorage.IssueGetter, issue *issuestorage.Issue) []IssueLinkJSON {
+	var links []IssueLinkJSON
+	add := func(dep issuestorage.Dependency, direction string) {
+		link := IssueLinkJSON{IssueID: issue.ID, Type: string(dep.Type), Direction: direction, Target: dep.ID}
+		if other, err := getter.Get(ctx, dep.ID); err == nil {
+			link.Title, link.Status = other.Title, string(other.Status)
+		}
+		links = append(links, link)
+	}
+	for _, dep := range issue.Dependencies {
+		if dep.Type.IsLink() {
+			add(dep, "outgoing")
+		}
+	}
+	for _, dep := range issue.Dependents {
+		if dep.Type.IsLink() {
+			add(dep, "incoming")
+		}
+	}
+	return links
+}
+
+// resolveLinkEnds resolves the two issues of a link command.
+func resolveLinkEnds(ctx context.Context, app *App, a, b string) (*issuestorage.Issue, *issuestorage.Issue, error) {
+	issue, err := resolveIssue(app.Storage, ctx, a)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolving issue %s: %w", a, err)
+	}
+	other, err := resolveIssue(app.Storage, ctx, b)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolving issue %s: %w", b, err)
+	}
+	return issue, other, nil
+}
+
+// newLinkAddCmd creates the "link add" subcommand.
+func newLinkAddCmd(provider *AppProvider) *cobra.Command {
+	var linkType string
+
+	cmd := &cobra.Command{
+		Use:   "add <id> <other-id>",
+		Short: "Link two issues",
+		Long: `Link an issue to another without either blocking the other: links are
+shown by bd show and bd link list, and ignored by bd ready and bd blocked.
+
+Link types:
+  relates-to    the issues are related; the same seen from either side
+  duplicate-of  the issue duplicates the other (see also bd close --duplicate-of)
+  supersedes    the issue replaces the other
+
+An issue cannot be a duplicate of, or supersede, an issue that already is
+(directly or through others) a duplicate of, or superseded by, it.
+
+Examples:
+  bd link add bd-a1b2 bd-c3d4
+  bd link add bd-a1b2 bd-c3d4 --type duplicate-of
+  bd link add bd-a1b2 bd-c3d4 --type supersedes`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			t := issuestorage.DependencyType(linkType)
+			if !t.IsLink() {
+				return fmt.Errorf("invalid link type %q; valid types: %s (use bd dep add for dependencies)", linkType, linkTypeNames())
+			}
+			issue, other, err := resolveLinkEnds(ctx, app, args[0], args[1])
+			if err != nil {
+				return err
+			}
+			if issue.Status == issuestorage.StatusTombstone || other.Status == issuestorage.StatusTombstone {
+				return fmt.Errorf("cannot link tombstoned issues")
+			}
+			for _, dep := range issue.Dependencies {
+				if dep.ID == other.ID {
+					return fmt.Errorf("%s already has a %s relation to %s", issue.ID, dep.Type, other.ID)
+				}
+			}
+			if t.Symmetric() {
+				for _, dep := range other.Dependencies {
+					if dep.ID == issue.ID && dep.Type == t {
+						return fmt.Errorf("%s already %s %s", other.ID, describeLink(t, true), issue.ID)
+					}
+				}
+			}
+
+			if err := app.Storage.AddDependency(ctx, issue.ID, other.ID, t); err != nil {
+				if err == issuestorage.ErrCycle {
+					return fmt.Errorf("cannot link: %s would be %s itself", issue.ID, describeLink(t, true))
+				}
+				return fmt.Errorf("adding link: %w", err)
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(IssueLinkJSON{
+					IssueID: issue.ID, Type: string(t), Direction: "outgoing",
+					Target: other.ID, Title: other.Title, Status: string(other.Status),
+				})
+			}
+			fmt.Fprintf(app.Out, "%s Linked: %s %s %s\n", app.SuccessColor("✓"), issue.ID, describeLink(t, true), other.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&linkType, "type", "t", string(issuestorage.DepTypeRelatesTo), "Link type ("+linkTypeNames()+")")
+
+	return cmd
+}
+
+// newLinkRemoveCmd creates the "link remove" subcommand.
+func newLinkRemoveCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <id> <other-id>",
+		Short: "Unlink two issues",
+		Long: `Remove the link between two issues, whichever of them it was added from.
+Dependencies are left alone; remove those with bd dep remove.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			issue, other, err := resolveLinkEnds(ctx, app, args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			from, to := issue, other
+			var link *issuestorage.Dependency
+			for _, end := range [][2]*issuestorage.Issue{{issue, other}, {other, issue}} {
+				for _, dep := range end[0].Dependencies {
+					if dep.ID == end[1].ID && dep.Type.IsLink() {
+						link = &dep
+						from, to = end[0], end[1]
+						break
+					}
+				}
+				if link != nil {
+					break
+				}
+			}
+			if link == nil {
+				return fmt.Errorf("%s and %s are not linked (see bd link list %s)", issue.ID, other.ID, issue.ID)
+			}
+			if err := app.Storage.RemoveDependency(ctx, from.ID, to.ID); err != nil {
+				return fmt.Errorf("removing link: %w", err)
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(IssueLinkJSON{
+					IssueID: from.ID, Type: string(link.Type), Direction: "outgoing",
+					Target: to.ID, Title: to.Title, Status: string(to.Status),
+				})
+			}
+			fmt.Fprintf(app.Out, "%s Unlinked: %s no longer %s %s\n", app.SuccessColor("✓"), from.ID, describeLink(link.Type, true), to.ID)
+			return nil
+		},
+	}
+}
+
+// newLinkListCmd creates the "link list" subcommand.
+func newLinkListCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list <id>",
+		Short: "List an issue's links",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			issue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+
+			links := issueLinks(ctx, app.Storage, issue)
+			if app.JSON {
+				if links == nil {
+					links = []IssueLinkJSON{}
+				}
+				return json.NewEncoder(app.Out).Encode(links)
+			}
+			if len(links) == 0 {
+				fmt.Fprintf(app.Out, "%s has no links.\n", issue.ID)
+				return nil
+			}
+			writeIssueLinks(ctx, app, app.Storage, links)
+			return nil
+		},
+	}
+}
+
+// writeIssueLinks prints links one per line, with the linked issue.
+func writeIssueLinks(ctx context.Context, app *App, getter issuestorage.IssueGetter, links []IssueLinkJSON) {
+	for _, link := range links {
+		what := describeLink(issuestorage.DependencyType(link.Type), link.Direction == "outgoing")
+		if other, err := getter.Get(ctx, link.Target); err == nil {
+			fmt.Fprintf(app.Out, "  %-13s %s\n", what, formatIssueLine(app, other))
+		} else {
+			fmt.Fprintf(app.Out, "  %-13s %s\n", what, link.Target)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/config/yamlstore"
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+func TestExpandLinks(t *testing.T) {
+	registry := map[string]string{"RFC-123": "https://example.com/rfc/123", "DESIGN-4": "https://docs.example.com/4"}
+	got := expandLinks("See [[RFC-123]] and [[design-4]], not [[RFC-9]] or [RFC-123].", registry)
+	want := "See RFC-123 <https://example.com/rfc/123> and DESIGN-4 <https://docs.example.com/4>, not [[RFC-9]] or [RFC-123]."
+	if got != want {
+		t.Errorf("expandLinks = %q, want %q", got, want)
+	}
+	if got := unknownLinkRefs("[[rfc-9]] [[RFC-123]] [[RFC-9]] [[X]]", registry); !reflect.DeepEqual(got, []string{"RFC-9", "X"}) {
+		t.Errorf("unknownLinkRefs = %q", got)
+	}
+}
+
+func TestLinkRegistryCmd(t *testing.T) {
+	app, store := setupTestApp(t)
+	app.ConfigDir = t.TempDir()
+	reload := func() {
+		cs, err := yamlstore.New(filepath.Join(app.ConfigDir, "config.yaml"))
+		if err != nil {
+			t.Fatalf("opening config: %v", err)
+		}
+		app.ConfigStore = cs
+	}
+	reload()
+	run := func(newCmd func(*AppProvider) *cobra.Command, args ...string) error {
+		app.Out.(*bytes.Buffer).Reset()
+		cmd := newCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		reload()
+		return err
+	}
+
+	if err := run(newLinkCmd, "registry", "add", "rfc-123", "https://example.com/rfc/123"); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if got, _ := app.ConfigStore.Get("link.RFC-123"); got != "https://example.com/rfc/123" {
+		t.Errorf("link.RFC-123 = %q", got)
+	}
+	for _, bad := range [][]string{{"1st", "https://example.com"}, {"RFC.1", "https://example.com"}, {"RFC-1", "docs/rfc1"}} {
+		if err := run(newLinkCmd, append([]string{"registry", "add"}, bad...)...); err == nil {
+			t.Errorf("add %q: want error", bad)
+		}
+	}
+
+	app.JSON = true
+	if err := run(newLinkCmd, "registry", "list"); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	var links []LinkJSON
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &links); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(links, []LinkJSON{{Key: "RFC-123", URL: "https://example.com/rfc/123"}}) {
+		t.Errorf("list = %+v", links)
+	}
+	app.JSON = false
+
+	// Descriptions expand registered keys in bd show and lint flags the rest.
+	id, err := store.Create(context.Background(), &issuestorage.Issue{Title: "Implement", Description: "Per [[RFC-123]] and [[DESIGN-4]]."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := run(newShowCmd, id); err != nil {
+		t.Fatalf("show: %v", err)
+	}
+	if out := app.Out.(*bytes.Buffer).String(); !strings.Contains(out, "Per RFC-123 <https://example.com/rfc/123> and [[DESIGN-4]].") {
+		t.Errorf("show did not expand links:\n%s", out)
+	}
+	if err := run(newLintCmd, id); err == nil || !strings.Contains(app.Out.(*bytes.Buffer).String(), "unknown link [[DESIGN-4]]") {
+		t.Errorf("lint = %v:\n%s", err, app.Out.(*bytes.Buffer).String())
+	}
+
+	if err := run(newLinkCmd, "registry", "remove", "RFC-123"); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if _, ok := app.ConfigStore.Get("link.RFC-123"); ok {
+		t.Error("link.RFC-123 still set after remove")
+	}
+	if err := run(newLinkCmd, "registry", "remove", "RFC-123"); err == nil {
+		t.Error("remove unregistered key: want error")
+	}
+}
+
+func TestValidateLinkKey(t *testing.T) {
+	if msg := validateLinkKey("link.RFC-1", "https://example.com/1"); msg != "" {
+		t.Errorf("valid entry: %s", msg)
+	}
+	if msg := validateLinkKey("link.rfc-1", "https://example.com/1"); msg == "" {
+		t.Error("lower-case key: want error")
+	}
+	if msg := validateLinkKey("link.RFC-1", "example.com"); msg == "" {
+		t.Error("relative URL: want error")
+	}
+}
+
+func TestIssueLinks(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	create := func(title string) string {
+		id, err := store.Create(ctx, &issuestorage.Issue{Title: title})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+	a, b, c := create("Login"), create("Sign in"), create("Auth v2")
+	run := func(args ...string) (string, error) {
+		out := &bytes.Buffer{}
+		app.Out = out
+		cmd := newLinkCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	if _, err := run("add", a, b); err != nil {
+		t.Fatalf("add relates-to: %v", err)
+	}
+	if _, err := run("add", b, a); err == nil {
+		t.Error("relates-to the other way: want already linked")
+	}
+	if _, err := run("add", b, a, "--type", "blocks"); err == nil {
+		t.Error("link add --type blocks: want error")
+	}
+	if _, err := run("add", c, a, "--type", "supersedes"); err != nil {
+		t.Fatalf("add supersedes: %v", err)
+	}
+	if _, err := run("add", a, c, "--type", "supersedes"); err == nil {
+		t.Error("supersedes cycle: want error")
+	}
+	// A link does not stand in the way of a dependency against it.
+	if err := store.AddDependency(ctx, b, a, issuestorage.DepTypeBlocks); err != nil {
+		t.Fatalf("blocks opposite a link: %v", err)
+	}
+
+	out, err := run("list", a)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	for _, want := range []string{"relates to", b, "superseded by", c} {
+		if !strings.Contains(out, want) {
+			t.Errorf("list missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "blocks") {
+		t.Errorf("list shows a dependency:\n%s", out)
+	}
+
+	// Links never block: c is ready though it supersedes an open issue.
+	ready := newReadyCmd(NewTestProvider(app))
+	app.Out = &bytes.Buffer{}
+	if err := ready.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if got := app.Out.(*bytes.Buffer).String(); !strings.Contains(got, c) || !strings.Contains(got, a) {
+		t.Errorf("ready should include linked issues:\n%s", got)
+	}
+
+	if _, err := run("remove", a, c); err != nil {
+		t.Fatalf("remove incoming link: %v", err)
+	}
+	if _, err := run("remove", a, b); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if _, err := run("remove", a, b); err == nil {
+		t.Error("remove with only a dependency left: want error")
+	}
+	issue, _ := store.Get(ctx, b)
+	if !issue.HasDependency(a) {
+		t.Error("link remove removed the blocking dependency")
+	}
+}
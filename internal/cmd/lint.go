@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// LintResultJSON is the JSON output format for an issue that failed lint.
+type LintResultJSON struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Type     string   `json:"issue_type"`
+	Problems []string `json:"problems"`
+}
+
+// newLintCmd creates the lint command.
+func newLintCmd(provider *AppProvider) *cobra.Command {
+	var (
+		all   bool
+		types []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "lint [issue-id...]",
+		Short: "Check issues for missing required information",
+		Long: `Check issues for missing information and exit non-zero if any fail.
+
+Rules:
+- Features must have at least one acceptance criterion (bd criteria add)
+- Fields required by types.<type>.required must be set (issues created
+  before a rule was configured are accepted on update but flagged here)
+- Closed issues must not have unchecked acceptance criteria
+- [[KEY]] references in descriptions must name keys in the link registry
+  (bd link registry)
+
+Open issues are checked unless issue IDs or --all are given.
+
+Examples:
+  bd lint
+  bd lint --type feature
+  bd lint --all --json
+  bd lint bd-a1b2`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			var issues []*issuestorage.Issue
+			if len(args) > 0 {
+				for _, arg := range args {
+					issue, err := resolveIssue(app.Storage, ctx, arg)
+					if err != nil {
+						return fmt.Errorf("resolving issue %s: %w", arg, err)
+					}
+					issues = append(issues, issue)
+				}
+			} else {
+				found, err := listExportIssues(ctx, app, nil)
+				if err != nil {
+					return err
+				}
+				for _, issue := range found {
+					if all || issue.Status != issuestorage.StatusClosed {
+						issues = append(issues, issue)
+					}
+				}
+			}
+
+			if len(types) > 0 {
+				filter := &issuestorage.ListFilter{}
+				for _, t := range types {
+					parsed, err := parseType(t, getCustomValues(app, "types.custom"))
+					if err != nil {
+						return err
+					}
+					filter.Types = append(filter.Types, parsed)
+				}
+				var matched []*issuestorage.Issue
+				for _, issue := range issues {
+					if filter.Matches(issue) {
+						matched = append(matched, issue)
+					}
+				}
+				issues = matched
+			}
+
+			results := lintIssues(app, issues)
+
+			if app.JSON {
+				if err := json.NewEncoder(app.Out).Encode(results); err != nil {
+					return err
+				}
+			} else if len(results) == 0 {
+				fmt.Fprintf(app.Out, "%s %d issue(s) passed lint\n", app.SuccessColor("✓"), len(issues))
+			} else {
+				for _, r := range results {
+					fmt.Fprintf(app.Out, "%s (%s) %s\n", r.ID, r.Type, r.Title)
+					for _, p := range r.Problems {
+						fmt.Fprintf(app.Out, "  - %s\n", p)
+					}
+				}
+			}
+
+			if len(results) > 0 {
+				return fmt.Errorf("%d issue(s) failed lint", len(results))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Check closed issues too")
+	cmd.Flags().StringSliceVarP(&types, "type", "t", nil, "Only check issues of these types (comma-separated)")
+
+	return cmd
+}
+
+// lintIssues returns the issues with lint problems, in the order given.
+func lintIssues(app *App, issues []*issuestorage.Issue) []LintResultJSON {
+	results := []LintResultJSON{}
+	links := linkRegistry(app)
+	for _, issue := range issues {
+		problems := app.Storage.Lint(issue)
+		for _, key := range unknownLinkRefs(issue.Description, links) {
+			problems = append(problems, fmt.Sprintf("unknown link [[%s]] (register it with bd link registry add %s <url>)", key, key))
+		}
+		if len(problems) > 0 {
+			results = append(results, LintResultJSON{
+				ID:       issue.ID,
+				Title:    issue.Title,
+				Type:     string(issue.Type),
+				Problems: problems,
+			})
+		}
+	}
+	return results
+}
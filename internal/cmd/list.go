@@ -3,7 +3,6 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"sort"
 	"strings"
 	"time"
 
@@ -17,12 +16,16 @@ func newListCmd(provider *AppProvider) *cobra.Command {
 	var (
 		statuses      []string
 		priority      string
+		severities    []string
 		issueTypes    []string
 		molType       string
 		labels        []string
 		labelsAll     []string
 		parent        string
 		assignees     []string
+		mine          bool
+		reporters     []string
+		milestone     string
 		all           bool
 		closed        bool
 		roots         bool
@@ -30,6 +33,10 @@ func newListCmd(provider *AppProvider) *cobra.Command {
 		limit         int
 		createdAfter  string
 		createdBefore string
+		sortKey       string
+		reverse       bool
+		noContext     bool
+		contextName   string
 	)
 
 	cmd := &cobra.Command{
@@ -38,7 +45,7 @@ func newListCmd(provider *AppProvider) *cobra.Command {
 		Long: `List issues with various filters.
 
 By default, lists open issues (up to 50). Use flags to filter by status,
-type, priority, labels, parent, or assignee. Use --limit to change the
+type, priority, labels, parent, assignee, reporter, or milestone. Use --limit to change the
 maximum number of results, or --limit 0 / --all to return all results.
 
 Examples:
@@ -49,10 +56,16 @@ Examples:
   bd list --status=in-progress # List in-progress issues
   bd list --type=bug           # List bugs
   bd list --priority=high      # List high priority issues
+  bd list --severity=critical  # List critical-severity issues
   bd list --label=urgent,v2    # List issues with both labels
   bd list --parent=be-abc      # List children of issue be-abc
   bd list --roots              # List root issues (no parent)
   bd list --assignee=alice     # List issues assigned to alice
+  bd list --mine               # List issues assigned to you
+  bd list --milestone=v1.2     # List issues planned for milestone v1.2
+  bd list --reporter=bob       # List issues bob raised
+  bd list --sort updated -r    # Most recently updated first
+  bd list --no-context         # Ignore the active filter context
   bd list --created-after 2026-03-01
   bd list --created-before 2026-03-31
   bd list --created-after 2026-03-01T09:00:00 --created-before 2026-03-01T17:00:00`,
@@ -105,6 +118,14 @@ Examples:
 				filter.Priority = &p
 			}
 
+			for _, sev := range severities {
+				s, err := issuestorage.ParseSeverity(sev)
+				if err != nil {
+					return err
+				}
+				filter.Severities = append(filter.Severities, s)
+			}
+
 			if len(issueTypes) > 0 {
 				for _, it := range issueTypes {
 					filter.Types = append(filter.Types, issuestorage.IssueType(it))
@@ -127,9 +148,23 @@ Examples:
 				filter.LabelsAll = labelsAll
 			}
 
+			if mine {
+				if len(assignees) > 0 {
+					return fmt.Errorf("--mine and --assignee cannot be combined")
+				}
+				if filter.Assignees, err = myIdentities(ctx, app); err != nil {
+					return err
+				}
+			}
 			if len(assignees) > 0 {
 				filter.Assignees = assignees
 			}
+			if len(reporters) > 0 {
+				filter.Reporters = reporters
+			}
+			if cmd.Flags().Changed("milestone") {
+				filter.Milestone = &milestone
+			}
 			if createdAfter != "" {
 				t, err := parseListCreatedTime(createdAfter, false)
 				if err != nil {
@@ -153,9 +188,18 @@ Examples:
 				empty := ""
 				filter.Parent = &empty
 			} else if parent != "" {
+				// Accept a vanity alias for the parent.
+				if p, err := app.Storage.Get(ctx, parent); err == nil {
+					parent = p.ID
+				}
 				filter.Parent = &parent
 			}
 
+			scope, err := activeContextFilter(app, noContext, contextName)
+			if err != nil {
+				return err
+			}
+
 			// Get open issues with filter
 			issues, err := app.Storage.List(ctx, filter)
 			if err != nil {
@@ -174,10 +218,12 @@ Examples:
 				issues = append(issues, closedIssues...)
 			}
 
-			// Sort by priority (P0 first)
-			sort.Slice(issues, func(i, j int) bool {
-				return issues[i].Priority < issues[j].Priority
-			})
+			issues = scopeIssues(issues, scope)
+
+			// Sort by priority (P0 first) unless --sort says otherwise
+			if err := sortIssues(issues, sortKey, reverse); err != nil {
+				return err
+			}
 
 			// Apply limit
 			limited := limit > 0 && len(issues) > limit
@@ -214,16 +260,22 @@ Examples:
 
 	cmd.Flags().StringSliceVarP(&statuses, "status", "s", nil, "Filter by status (comma-separated or repeated; "+statusNames(nil)+")")
 	cmd.Flags().StringVarP(&priority, "priority", "p", "", "Filter by priority (0-4 or P0-P4)")
+	cmd.Flags().StringSliceVar(&severities, "severity", nil, "Filter by severity (comma-separated or repeated; critical, major, minor, trivial)")
 	cmd.Flags().StringSliceVarP(&issueTypes, "type", "t", nil, "Filter by type (comma-separated or repeated; task, bug, feature, epic, chore)")
 	cmd.Flags().StringVar(&molType, "mol-type", "", "Filter by molecule type (swarm, patrol, work)")
 	cmd.Flags().StringSliceVarP(&labels, "label", "l", nil, "Filter by labels (comma-separated or repeated, OR semantics)")
 	cmd.Flags().StringSliceVar(&labelsAll, "label-all", nil, "Filter by labels (comma-separated or repeated, AND semantics — must have all)")
 	cmd.Flags().StringVar(&parent, "parent", "", "Filter by parent issue ID")
 	cmd.Flags().StringSliceVarP(&assignees, "assignee", "a", nil, "Filter by assignee (comma-separated or repeated)")
+	cmd.Flags().BoolVar(&mine, "mine", false, "List issues assigned to you: the configured actor, or git user.name, and their people registry identities")
+	cmd.Flags().StringSliceVar(&reporters, "reporter", nil, "Filter by reporter (comma-separated or repeated)")
+	cmd.Flags().StringVar(&milestone, "milestone", "", "Filter by milestone (empty for issues without one)")
 	cmd.Flags().BoolVar(&all, "all", false, "List all issues (open and closed)")
 	cmd.Flags().BoolVar(&closed, "closed", false, "List only closed issues")
 	cmd.Flags().BoolVar(&roots, "roots", false, "List only root issues (no parent)")
 	cmd.Flags().StringVarP(&format, "format", "f", "", "Output format (not implemented, accepts any value)")
+	addSortFlags(cmd, &sortKey, &reverse, "priority")
+	addContextFlags(cmd, &noContext, &contextName)
 	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of issues to return (0 for all)")
 	cmd.Flags().StringVar(&createdAfter, "created-after", "", "Filter by created_at >= this time (YYYY-MM-DD or RFC3339; timezone optional for local time)")
 	cmd.Flags().StringVar(&createdBefore, "created-before", "", "Filter by created_at <= this time (YYYY-MM-DD or RFC3339; timezone optional for local time)")
@@ -350,6 +402,8 @@ func statusIcon(s issuestorage.Status) (icon string, colorCode string) {
 		return "○", ""
 	case issuestorage.StatusInProgress:
 		return "◐", "38;5;214"
+	case issuestorage.StatusReview:
+		return "◑", "36"
 	case issuestorage.StatusClosed:
 		return "✓", "90"
 	case issuestorage.StatusBlocked:
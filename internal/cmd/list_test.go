@@ -472,6 +472,47 @@ func TestListCommand_AssigneeFilter(t *testing.T) {
 	}
 }
 
+func TestListCommand_ReporterFilter(t *testing.T) {
+	app, rs := setupTestApp(t)
+	ctx := context.Background()
+	t.Setenv("BD_ACTOR", "alice")
+
+	create := newCreateCmd(NewTestProvider(app))
+	create.SetArgs([]string{"Reported for bob", "--reporter", "bob"})
+	if err := create.Execute(); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	bobID := extractCreatedID(app.Out.(*bytes.Buffer).String())
+	// Issues from before reporters were recorded fall back to the creator.
+	legacyID, _ := rs.Create(ctx, &issuestorage.Issue{Title: "Legacy", CreatedBy: "bob"})
+	aliceID, _ := rs.Create(ctx, &issuestorage.Issue{Title: "Alice's", Reporter: "alice", CreatedBy: "bob"})
+
+	if issue, _ := rs.Get(ctx, bobID); issue.Reporter != "bob" || issue.CreatedBy != "alice" {
+		t.Errorf("reporter = %q, created by %q; want bob, alice", issue.Reporter, issue.CreatedBy)
+	}
+
+	app.Out = &bytes.Buffer{}
+	cmd := newListCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--reporter=bob"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("list command failed: %v", err)
+	}
+	output := app.Out.(*bytes.Buffer).String()
+	if !strings.Contains(output, bobID) || !strings.Contains(output, legacyID) || strings.Contains(output, aliceID) {
+		t.Errorf("expected %s and %s but not %s, got: %s", bobID, legacyID, aliceID, output)
+	}
+
+	app.Out = &bytes.Buffer{}
+	show := newShowCmd(NewTestProvider(app))
+	show.SetArgs([]string{legacyID})
+	if err := show.Execute(); err != nil {
+		t.Fatalf("show failed: %v", err)
+	}
+	if out := app.Out.(*bytes.Buffer).String(); !strings.Contains(out, "Reporter: bob") {
+		t.Errorf("show output missing reporter:\n%s", out)
+	}
+}
+
 func TestListCommand_ParentFilter(t *testing.T) {
 	dir := t.TempDir()
 	store := filesystem.New(dir, "bd-")
@@ -495,11 +536,13 @@ func TestListCommand_ParentFilter(t *testing.T) {
 	childID, err := rs.Create(ctx, &issuestorage.Issue{
 		Title:    "Child issue",
 		Priority: issuestorage.PriorityMedium,
-		Parent:   parentID,
 	})
 	if err != nil {
 		t.Fatalf("failed to create issue: %v", err)
 	}
+	if err := rs.AddDependency(ctx, childID, parentID, issuestorage.DepTypeParentChild); err != nil {
+		t.Fatalf("failed to add parent: %v", err)
+	}
 
 	// Create another root issue
 	rootID, err := rs.Create(ctx, &issuestorage.Issue{
@@ -555,11 +598,13 @@ func TestListCommand_RootsFlag(t *testing.T) {
 	childID, err := rs.Create(ctx, &issuestorage.Issue{
 		Title:    "Child issue",
 		Priority: issuestorage.PriorityMedium,
-		Parent:   parentID,
 	})
 	if err != nil {
 		t.Fatalf("failed to create issue: %v", err)
 	}
+	if err := rs.AddDependency(ctx, childID, parentID, issuestorage.DepTypeParentChild); err != nil {
+		t.Fatalf("failed to add parent: %v", err)
+	}
 
 	var out bytes.Buffer
 	app := &App{
@@ -746,7 +791,7 @@ func TestListCommand_StatusAll(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to create issue: %v", err)
 	}
-	softDelete(ctx, store, deletedID, "test", "test deletion")
+	softDelete(ctx, rs, deletedID, "test", "test deletion")
 
 	var out bytes.Buffer
 	app := &App{
@@ -1232,3 +1277,52 @@ func TestParseListCreatedTime_DateOnlyUsesLocalTimezoneAndEndOfDayForUpperBound(
 		t.Fatalf("expected upper bound to be end-of-day before %v, got %v", expectedNextDayStart, upper)
 	}
 }
+
+func TestListSortFlag(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+
+	var ids []string
+	for _, title := range []string{"banana", "Apple", "cherry"} {
+		id, err := store.Create(ctx, &issuestorage.Issue{Title: title, Priority: issuestorage.PriorityMedium})
+		if err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{nil, ids}, // equal priority: creation order
+		{[]string{"--sort", "title"}, []string{ids[1], ids[0], ids[2]}},
+		{[]string{"--sort", "created", "--reverse"}, []string{ids[2], ids[1], ids[0]}},
+	}
+	for _, tt := range tests {
+		app.Out = &bytes.Buffer{}
+		app.JSON = true
+		cmd := newListCmd(NewTestProvider(app))
+		cmd.SetArgs(tt.args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("list %v failed: %v", tt.args, err)
+		}
+		var got []IssueListJSON
+		if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &got); err != nil {
+			t.Fatalf("failed to parse JSON: %v", err)
+		}
+		var gotIDs []string
+		for _, g := range got {
+			gotIDs = append(gotIDs, g.ID)
+		}
+		if strings.Join(gotIDs, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("list %v: got %v, want %v", tt.args, gotIDs, tt.want)
+		}
+	}
+
+	cmd := newListCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--sort", "bogus"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error for invalid --sort value")
+	}
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+	"github.com/spf13/cobra"
+)
+
+// matrixUnsetRow labels the row holding issues with no severity set.
+const matrixUnsetRow = "unset"
+
+// MatrixRowJSON is one severity row of the severity×priority matrix.
+// Cells maps a priority label ("P0".."P4") to the issue IDs in that cell.
+type MatrixRowJSON struct {
+	Severity string              `json:"severity"`
+	Cells    map[string][]string `json:"cells"`
+	Total    int                 `json:"total"`
+}
+
+// MatrixJSON is the JSON output of bd matrix.
+type MatrixJSON struct {
+	Priorities []string        `json:"priorities"`
+	Rows       []MatrixRowJSON `json:"rows"`
+	Total      int             `json:"total"`
+}
+
+// newMatrixCmd creates the matrix command.
+func newMatrixCmd(provider *AppProvider) *cobra.Command {
+	var (
+		all        bool
+		issueTypes []string
+		labels     []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "matrix",
+		Short: "Show issues plotted by severity and priority",
+		Long: `Show a severity×priority matrix of issues.
+
+Severity (how bad) runs down the rows and priority (how soon) across the
+columns. Each cell holds the number of issues with that combination; issues
+without a severity are counted in the "unset" row.
+
+By default only open (non-closed) issues are included.
+
+Examples:
+  bd matrix
+  bd matrix --type bug
+  bd matrix --label backend --all
+  bd matrix --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			filter := &issuestorage.ListFilter{LabelsAll: labels}
+			for _, it := range issueTypes {
+				filter.Types = append(filter.Types, issuestorage.IssueType(it))
+			}
+
+			issues, err := app.Storage.List(ctx, filter)
+			if err != nil {
+				return fmt.Errorf("listing issues: %w", err)
+			}
+			if all {
+				closedFilter := *filter
+				closedFilter.Statuses = []issuestorage.Status{issuestorage.StatusClosed}
+				closed, err := app.Storage.List(ctx, &closedFilter)
+				if err != nil {
+					return fmt.Errorf("listing closed issues: %w", err)
+				}
+				issues = append(issues, closed...)
+			}
+
+			matrix := buildMatrix(issues)
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(matrix)
+			}
+
+			fmt.Fprint(app.Out, formatMatrix(matrix))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "Include closed issues")
+	cmd.Flags().StringSliceVarP(&issueTypes, "type", "t", nil, "Filter by type (comma-separated or repeated)")
+	cmd.Flags().StringSliceVarP(&labels, "label", "l", nil, "Filter by label (AND: must have ALL)")
+
+	return cmd
+}
+
+// buildMatrix buckets issues into severity rows and priority columns. Rows
+// are ordered most to least severe, followed by the unset row.
+func buildMatrix(issues []*issuestorage.Issue) MatrixJSON {
+	var m MatrixJSON
+	for p := issuestorage.PriorityCritical; p <= issuestorage.PriorityBacklog; p++ {
+		m.Priorities = append(m.Priorities, p.Display())
+	}
+
+	rowNames := make([]string, 0, len(issuestorage.Severities)+1)
+	for _, s := range issuestorage.Severities {
+		rowNames = append(rowNames, string(s))
+	}
+	rowNames = append(rowNames, matrixUnsetRow)
+
+	rowIndex := make(map[string]int, len(rowNames))
+	for i, name := range rowNames {
+		rowIndex[name] = i
+		m.Rows = append(m.Rows, MatrixRowJSON{Severity: name, Cells: make(map[string][]string)})
+	}
+
+	for _, issue := range issues {
+		name := string(issue.Severity)
+		if name == "" {
+			name = matrixUnsetRow
+		}
+		i, ok := rowIndex[name]
+		if !ok {
+			continue
+		}
+		col := issue.Priority.Display()
+		m.Rows[i].Cells[col] = append(m.Rows[i].Cells[col], issue.ID)
+		m.Rows[i].Total++
+		m.Total++
+	}
+
+	return m
+}
+
+// formatMatrix renders the matrix as an aligned text table of counts.
+func formatMatrix(m MatrixJSON) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%-10s", "")
+	for _, p := range m.Priorities {
+		fmt.Fprintf(&sb, "%5s", p)
+	}
+	fmt.Fprintf(&sb, "%7s\n", "Total")
+
+	for _, row := range m.Rows {
+		fmt.Fprintf(&sb, "%-10s", row.Severity)
+		for _, p := range m.Priorities {
+			n := len(row.Cells[p])
+			if n == 0 {
+				fmt.Fprintf(&sb, "%5s", ".")
+			} else {
+				fmt.Fprintf(&sb, "%5d", n)
+			}
+		}
+		fmt.Fprintf(&sb, "%7d\n", row.Total)
+	}
+
+	fmt.Fprintf(&sb, "\n%d issue(s)\n", m.Total)
+	return sb.String()
+}
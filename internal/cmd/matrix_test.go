@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestMatrixCounts(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+
+	critP0, err := store.Create(ctx, &issuestorage.Issue{Title: "Outage", Priority: issuestorage.PriorityCritical, Severity: issuestorage.SeverityCritical})
+	if err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+	critP3, err := store.Create(ctx, &issuestorage.Issue{Title: "Rare crash", Priority: issuestorage.PriorityLow, Severity: issuestorage.SeverityCritical})
+	if err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+	unset, err := store.Create(ctx, &issuestorage.Issue{Title: "Untriaged", Priority: issuestorage.PriorityMedium})
+	if err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+	closed, err := store.Create(ctx, &issuestorage.Issue{Title: "Fixed", Priority: issuestorage.PriorityCritical, Severity: issuestorage.SeverityMajor})
+	if err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+	if err := store.Modify(ctx, closed, func(i *issuestorage.Issue) error { i.Status = issuestorage.StatusClosed; return nil }); err != nil {
+		t.Fatalf("failed to close issue: %v", err)
+	}
+
+	app.JSON = true
+	cmd := newMatrixCmd(NewTestProvider(app))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("matrix failed: %v", err)
+	}
+
+	var got MatrixJSON
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &got); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	if got.Total != 3 {
+		t.Errorf("expected 3 open issues, got %d", got.Total)
+	}
+	if len(got.Rows) != 5 || got.Rows[0].Severity != "critical" || got.Rows[4].Severity != "unset" {
+		t.Fatalf("unexpected rows: %+v", got.Rows)
+	}
+	if ids := got.Rows[0].Cells["P0"]; len(ids) != 1 || ids[0] != critP0 {
+		t.Errorf("critical/P0 = %v, want [%s]", ids, critP0)
+	}
+	if ids := got.Rows[0].Cells["P3"]; len(ids) != 1 || ids[0] != critP3 {
+		t.Errorf("critical/P3 = %v, want [%s]", ids, critP3)
+	}
+	if ids := got.Rows[4].Cells["P2"]; len(ids) != 1 || ids[0] != unset {
+		t.Errorf("unset/P2 = %v, want [%s]", ids, unset)
+	}
+	if got.Rows[1].Total != 0 {
+		t.Errorf("closed major issue should be excluded by default, got %+v", got.Rows[1])
+	}
+
+	// --all includes closed issues.
+	app.Out = &bytes.Buffer{}
+	cmd = newMatrixCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--all"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("matrix --all failed: %v", err)
+	}
+	got = MatrixJSON{}
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &got); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	if got.Total != 4 || got.Rows[1].Total != 1 {
+		t.Errorf("expected closed major issue with --all, got %+v", got)
+	}
+}
+
+func TestMatrixText(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+
+	if _, err := store.Create(ctx, &issuestorage.Issue{Title: "Bad", Priority: issuestorage.PriorityHigh, Severity: issuestorage.SeverityMajor}); err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	cmd := newMatrixCmd(NewTestProvider(app))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("matrix failed: %v", err)
+	}
+
+	out := app.Out.(*bytes.Buffer).String()
+	for _, want := range []string{"P0", "P4", "Total", "critical", "major", "unset", "1 issue(s)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestSeverityFlags(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+
+	cmd := newCreateCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"Data loss", "--severity", "S1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	id := extractCreatedID(app.Out.(*bytes.Buffer).String())
+	issue, err := store.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("failed to get issue: %v", err)
+	}
+	if issue.Severity != issuestorage.SeverityCritical {
+		t.Errorf("expected severity critical, got %q", issue.Severity)
+	}
+
+	cmd = newCreateCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"Bogus", "--severity", "awful"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error for invalid severity")
+	}
+
+	// list --severity filters on the field.
+	if _, err := store.Create(ctx, &issuestorage.Issue{Title: "No severity"}); err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+	app.Out = &bytes.Buffer{}
+	app.JSON = true
+	cmd = newListCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--severity", "critical"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	var listed []IssueListJSON
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &listed); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	if len(listed) != 1 || listed[0].ID != id || listed[0].Severity != "critical" {
+		t.Errorf("expected only %s with severity critical, got %+v", id, listed)
+	}
+
+	// update --severity "" clears the field.
+	app.JSON = false
+	cmd = newUpdateCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{id, "--severity", ""})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	issue, err = store.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("failed to get issue: %v", err)
+	}
+	if issue.Severity != "" {
+		t.Errorf("expected severity cleared, got %q", issue.Severity)
+	}
+}
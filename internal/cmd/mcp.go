@@ -0,0 +1,338 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"beads-lite/internal/schema"
+
+	"github.com/spf13/cobra"
+)
+
+// mcpProtocolVersions are the Model Context Protocol revisions bd mcp
+// speaks, newest first.
+var mcpProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// mcpMaxMessage caps the size of one incoming message.
+const mcpMaxMessage = 16 << 20
+
+// JSON-RPC 2.0 error codes.
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+// mcpArg is a required tool argument, passed to the command positionally.
+type mcpArg struct {
+	name        string
+	description string
+}
+
+// mcpTool exposes a bd command as an MCP tool. Calling it runs the command
+// in-process with --json: the sub path, then the positional arguments in
+// order, then the optional arguments as flags.
+type mcpTool struct {
+	name        string
+	description string
+	newCmd      func(*AppProvider) *cobra.Command
+	sub         []string
+	positional  []mcpArg
+	flags       []string // optional arguments: flag names with underscores for dashes
+}
+
+var mcpTools = []mcpTool{
+	{
+		name:        "create_issue",
+		description: "Create an issue. Returns the created issue as JSON.",
+		newCmd:      newCreateCmd,
+		positional:  []mcpArg{{"title", "Issue title"}},
+		flags:       []string{"type", "priority", "description", "parent", "deps", "labels", "assignee", "reporter", "criteria", "severity", "mol_type"},
+	},
+	{
+		name:        "list_ready",
+		description: "List open issues with no open blockers, i.e. work that can start now. Returns a JSON array.",
+		newCmd:      newReadyCmd,
+		flags:       []string{"priority", "assignee", "limit", "mol", "mol_type"},
+	},
+	{
+		name:        "list_issues",
+		description: "List issues matching filters (open issues by default). Returns a JSON array.",
+		newCmd:      newListCmd,
+		flags:       []string{"status", "priority", "type", "label", "assignee", "reporter", "parent", "all", "limit"},
+	},
+	{
+		name:        "show_issue",
+		description: "Show an issue with its dependencies, dependents and comments.",
+		newCmd:      newShowCmd,
+		positional:  []mcpArg{{"id", "Issue ID or unique prefix"}},
+	},
+	{
+		name:        "update_issue",
+		description: "Update an issue's fields. Returns the updated issue.",
+		newCmd:      newUpdateCmd,
+		positional:  []mcpArg{{"id", "Issue ID or unique prefix"}},
+		flags:       []string{"title", "description", "status", "priority", "assignee", "add_label", "remove_label", "claim"},
+	},
+	{
+		name:        "close_issue",
+		description: "Close an issue. Returns the closed issue.",
+		newCmd:      newCloseCmd,
+		positional:  []mcpArg{{"id", "Issue ID or unique prefix"}},
+		flags:       []string{"reason", "duplicate_of"},
+	},
+	{
+		name:        "add_comment",
+		description: "Add a comment to an issue.",
+		newCmd:      newCommentsCmd,
+		sub:         []string{"add"},
+		positional:  []mcpArg{{"id", "Issue ID or unique prefix"}, {"text", "Comment text"}},
+		flags:       []string{"author"},
+	},
+	{
+		name:        "add_dependency",
+		description: "Record that an issue depends on another (by default, is blocked by it).",
+		newCmd:      newDepCmd,
+		sub:         []string{"add"},
+		positional:  []mcpArg{{"id", "ID of the dependent issue"}, {"depends_on", "ID of the issue it depends on"}},
+		flags:       []string{"type"},
+	},
+	{
+		name:        "gate_check",
+		description: "Evaluate open gates and close those whose condition is met. Returns one result per gate.",
+		newCmd:      newGateCmd,
+		sub:         []string{"check"},
+		flags:       []string{"type", "dry_run", "escalate"},
+	},
+}
+
+// inputSchema returns the JSON Schema for the tool's arguments. Optional
+// arguments take their type and description from the command's flags.
+func (t mcpTool) inputSchema() *schema.Schema {
+	s := &schema.Schema{Type: "object", Properties: map[string]*schema.Schema{}, AdditionalProperties: false}
+	for _, arg := range t.positional {
+		s.Properties[arg.name] = &schema.Schema{Type: "string", Description: arg.description}
+		s.Required = append(s.Required, arg.name)
+	}
+
+	cmd, _, err := t.newCmd(&AppProvider{}).Find(t.sub)
+	if err != nil {
+		panic(fmt.Sprintf("mcp tool %s: %v", t.name, err))
+	}
+	for _, name := range t.flags {
+		f := cmd.Flags().Lookup(strings.ReplaceAll(name, "_", "-"))
+		if f == nil {
+			panic(fmt.Sprintf("mcp tool %s: %s has no flag for %q", t.name, cmd.CommandPath(), name))
+		}
+		p := &schema.Schema{Description: f.Usage}
+		switch f.Value.Type() {
+		case "bool":
+			p.Type = "boolean"
+		case "int", "int64", "uint":
+			p.Type = "integer"
+		case "stringSlice", "stringArray":
+			p.Type = "array"
+			p.Items = &schema.Schema{Type: "string"}
+		default:
+			p.Type = "string"
+		}
+		s.Properties[name] = p
+	}
+	return s
+}
+
+// args converts tool arguments to the command line for the tool.
+func (t mcpTool) args(arguments map[string]any) ([]string, error) {
+	args := append([]string(nil), t.sub...)
+	for _, arg := range t.positional {
+		value, _ := arguments[arg.name].(string)
+		if strings.TrimSpace(value) == "" {
+			return nil, fmt.Errorf("argument %q is required", arg.name)
+		}
+		if value == "-" {
+			return nil, fmt.Errorf("argument %q cannot be read from stdin", arg.name)
+		}
+		delete(arguments, arg.name)
+		args = append(args, value)
+	}
+	flags, err := bodyFlags(arguments, t.flags)
+	if err != nil {
+		return nil, err
+	}
+	return append(args, flags...), nil
+}
+
+// newMCPCmd creates the mcp command.
+func newMCPCmd(provider *AppProvider) *cobra.Command {
+	var names []string
+	for _, t := range mcpTools {
+		names = append(names, "  "+t.name)
+	}
+
+	cmd := &cobra.Command{
+		Use:   "mcp",
+		Short: "Serve beads to LLM agents over the Model Context Protocol",
+		Long: `Speak the Model Context Protocol (MCP) over stdin and stdout, so LLM agents
+can use beads as a native tool instead of running bd and parsing its
+output. Register it with an MCP client as a stdio server:
+
+  {"mcpServers": {"beads": {"command": "bd", "args": ["mcp"]}}}
+
+Each tool call runs the matching bd command against the same store and
+config as the CLI, and returns what that command prints with --json.
+Arguments are the command's flag names with underscores for dashes.
+
+Tools:
+` + strings.Join(names, "\n"),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			return serveMCP(cmd.Context(), app, cmd.InOrStdin(), app.Out)
+		},
+	}
+
+	return cmd
+}
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// serveMCP reads newline-delimited JSON-RPC messages from in and writes
+// responses to out until in is exhausted. Messages are handled one at a
+// time, in order.
+func serveMCP(ctx context.Context, app *App, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), mcpMaxMessage)
+	enc := json.NewEncoder(out)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if resp := handleMCPMessage(ctx, app, line); resp != nil {
+			if err := enc.Encode(resp); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// handleMCPMessage returns the response to one message, or nil for a
+// notification.
+func handleMCPMessage(ctx context.Context, app *App, line []byte) *rpcResponse {
+	var req rpcRequest
+	if err := json.Unmarshal(line, &req); err != nil {
+		return rpcFailure(json.RawMessage("null"), rpcParseError, "parse error: "+err.Error())
+	}
+	if len(req.ID) == 0 {
+		return nil // notifications (initialized, cancelled) need no reply
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return rpcFailure(req.ID, rpcInvalidRequest, "invalid request")
+	}
+
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		json.Unmarshal(req.Params, &params)
+		version := mcpProtocolVersions[0]
+		if contains(mcpProtocolVersions, params.ProtocolVersion) {
+			version = params.ProtocolVersion
+		}
+		return rpcSuccess(req.ID, map[string]any{
+			"protocolVersion": version,
+			"capabilities":    map[string]any{"tools": map[string]any{"listChanged": false}},
+			"serverInfo":      map[string]string{"name": "beads-lite", "version": Version},
+			"instructions":    "Tools read and change the beads issue tracker in the current project. Issue IDs may be given as unique prefixes. Results are the JSON bd prints with --json.",
+		})
+	case "ping":
+		return rpcSuccess(req.ID, map[string]any{})
+	case "tools/list":
+		tools := make([]map[string]any, len(mcpTools))
+		for i, t := range mcpTools {
+			tools[i] = map[string]any{"name": t.name, "description": t.description, "inputSchema": t.inputSchema()}
+		}
+		return rpcSuccess(req.ID, map[string]any{"tools": tools})
+	case "tools/call":
+		return callMCPTool(ctx, app, req)
+	default:
+		return rpcFailure(req.ID, rpcMethodNotFound, fmt.Sprintf("method %q not found", req.Method))
+	}
+}
+
+// callMCPTool runs a tools/call request. Bad arguments are protocol
+// errors; a command that fails returns a result with isError set, so the
+// agent sees the message and can correct itself.
+func callMCPTool(ctx context.Context, app *App, req rpcRequest) *rpcResponse {
+	var params struct {
+		Name      string         `json:"name"`
+		Arguments map[string]any `json:"arguments"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(req.Params))
+	dec.UseNumber()
+	if err := dec.Decode(&params); err != nil {
+		return rpcFailure(req.ID, rpcInvalidParams, "invalid params: "+err.Error())
+	}
+	if params.Arguments == nil {
+		params.Arguments = map[string]any{}
+	}
+
+	for _, t := range mcpTools {
+		if t.name != params.Name {
+			continue
+		}
+		args, err := t.args(params.Arguments)
+		if err != nil {
+			return rpcFailure(req.ID, rpcInvalidParams, err.Error())
+		}
+		out, err := runJSONCommand(ctx, app, t.newCmd, args)
+		if err != nil {
+			return rpcSuccess(req.ID, mcpText(err.Error(), true))
+		}
+		return rpcSuccess(req.ID, mcpText(strings.TrimSpace(string(out)), false))
+	}
+	return rpcFailure(req.ID, rpcInvalidParams, fmt.Sprintf("unknown tool %q", params.Name))
+}
+
+func mcpText(text string, isError bool) map[string]any {
+	return map[string]any{
+		"content": []map[string]string{{"type": "text", "text": text}},
+		"isError": isError,
+	}
+}
+
+func rpcSuccess(id json.RawMessage, result any) *rpcResponse {
+	return &rpcResponse{JSONRPC: "2.0", ID: id, Result: result}
+}
+
+func rpcFailure(id json.RawMessage, code int, msg string) *rpcResponse {
+	return &rpcResponse{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: msg}}
+}
@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// mcpSession sends each message to an MCP server on a new line and
+// returns the responses by request ID.
+func mcpSession(t *testing.T, app *App, messages ...string) map[string]rpcResponseJSON {
+	t.Helper()
+	var out bytes.Buffer
+	if err := serveMCP(context.Background(), app, strings.NewReader(strings.Join(messages, "\n")+"\n"), &out); err != nil {
+		t.Fatal(err)
+	}
+	responses := map[string]rpcResponseJSON{}
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var resp rpcResponseJSON
+		if err := json.Unmarshal([]byte(line), &resp); err != nil {
+			t.Fatalf("decoding %q: %v", line, err)
+		}
+		responses[string(resp.ID)] = resp
+	}
+	return responses
+}
+
+// rpcResponseJSON is a decoded JSON-RPC response.
+type rpcResponseJSON struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result"`
+	Error   *rpcError       `json:"error"`
+}
+
+// toolText returns the text content of a tools/call result.
+func toolText(t *testing.T, resp rpcResponseJSON) (string, bool) {
+	t.Helper()
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %+v", resp.Error)
+	}
+	var result struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+		IsError bool `json:"isError"`
+	}
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Content) != 1 || result.Content[0].Type != "text" {
+		t.Fatalf("content = %+v", result.Content)
+	}
+	return result.Content[0].Text, result.IsError
+}
+
+func TestMCPInitializeAndListTools(t *testing.T) {
+	app, _ := setupTestApp(t)
+	responses := mcpSession(t, app,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","capabilities":{}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"initialize","params":{"protocolVersion":"1999-01-01"}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"ping"}`,
+	)
+	if len(responses) != 4 {
+		t.Fatalf("got %d responses, want 4 (notifications get none)", len(responses))
+	}
+
+	var init struct {
+		ProtocolVersion string `json:"protocolVersion"`
+		ServerInfo      struct {
+			Name string `json:"name"`
+		} `json:"serverInfo"`
+	}
+	json.Unmarshal(responses["1"].Result, &init)
+	if init.ProtocolVersion != "2024-11-05" || init.ServerInfo.Name != "beads-lite" {
+		t.Errorf("initialize = %+v", init)
+	}
+	json.Unmarshal(responses["3"].Result, &init)
+	if init.ProtocolVersion != mcpProtocolVersions[0] {
+		t.Errorf("unsupported version negotiated to %q, want %q", init.ProtocolVersion, mcpProtocolVersions[0])
+	}
+
+	var list struct {
+		Tools []struct {
+			Name        string `json:"name"`
+			InputSchema struct {
+				Type       string                     `json:"type"`
+				Properties map[string]json.RawMessage `json:"properties"`
+				Required   []string                   `json:"required"`
+			} `json:"inputSchema"`
+		} `json:"tools"`
+	}
+	if err := json.Unmarshal(responses["2"].Result, &list); err != nil {
+		t.Fatal(err)
+	}
+	tools := map[string]int{}
+	for i, tool := range list.Tools {
+		tools[tool.Name] = i
+	}
+	for _, name := range []string{"create_issue", "list_ready", "close_issue", "add_dependency", "gate_check"} {
+		if _, ok := tools[name]; !ok {
+			t.Errorf("tool %s missing", name)
+		}
+	}
+	create := list.Tools[tools["create_issue"]].InputSchema
+	if create.Type != "object" || strings.Join(create.Required, ",") != "title" {
+		t.Errorf("create_issue schema = %+v", create)
+	}
+	if got := string(create.Properties["labels"]); !strings.Contains(got, `"type":"array"`) {
+		t.Errorf("labels property = %s, want an array", got)
+	}
+}
+
+func TestMCPToolCalls(t *testing.T) {
+	app, rs := setupTestApp(t)
+	ctx := context.Background()
+	blocker, err := rs.Create(ctx, &issuestorage.Issue{Title: "Blocker", Status: issuestorage.StatusOpen, Priority: issuestorage.PriorityHigh, Type: issuestorage.TypeTask})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	responses := mcpSession(t, app,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"create_issue","arguments":{"title":"Write docs","priority":1,"labels":["docs"]}}}`,
+	)
+	text, isError := toolText(t, responses["1"])
+	if isError {
+		t.Fatalf("create_issue failed: %s", text)
+	}
+	var created IssueJSON
+	if err := json.Unmarshal([]byte(text), &created); err != nil {
+		t.Fatal(err)
+	}
+	if created.Title != "Write docs" || created.Priority != 1 || len(created.Labels) != 1 {
+		t.Errorf("created = %+v", created)
+	}
+
+	responses = mcpSession(t, app,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"add_dependency","arguments":{"id":"`+created.ID+`","depends_on":"`+blocker+`"}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"list_ready","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"close_issue","arguments":{"id":"`+blocker+`","reason":"done"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"list_ready"}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"gate_check","arguments":{"dry_run":true}}}`,
+	)
+	readyIDs := func(id string) []string {
+		text, _ := toolText(t, responses[id])
+		var ready []IssueSimpleJSON
+		if err := json.Unmarshal([]byte(text), &ready); err != nil {
+			t.Fatalf("%s: %v", text, err)
+		}
+		var ids []string
+		for _, issue := range ready {
+			ids = append(ids, issue.ID)
+		}
+		return ids
+	}
+	if _, isError := toolText(t, responses["1"]); isError {
+		t.Error("add_dependency failed")
+	}
+	if got := readyIDs("2"); len(got) != 1 || got[0] != blocker {
+		t.Errorf("ready before close = %v, want only %s", got, blocker)
+	}
+	if got := readyIDs("4"); len(got) != 1 || got[0] != created.ID {
+		t.Errorf("ready after close = %v, want only %s", got, created.ID)
+	}
+	if text, isError := toolText(t, responses["5"]); isError || text != "[]" {
+		t.Errorf("gate_check = %q (isError %v)", text, isError)
+	}
+}
+
+func TestMCPErrors(t *testing.T) {
+	app, _ := setupTestApp(t)
+	responses := mcpSession(t, app,
+		`not json`,
+		`{"jsonrpc":"2.0","id":1,"method":"resources/list"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"drop_tables"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"create_issue","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"create_issue","arguments":{"title":"x","file":"/etc/passwd"}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"create_issue","arguments":{"title":"x","description":"-"}}}`,
+		`{"jsonrpc":"2.0","id":6,"method":"tools/call","params":{"name":"show_issue","arguments":{"id":"bd-zzzz"}}}`,
+	)
+
+	wantCodes := map[string]int{
+		"null": rpcParseError,
+		"1":    rpcMethodNotFound,
+		"2":    rpcInvalidParams,
+		"3":    rpcInvalidParams,
+		"4":    rpcInvalidParams,
+		"5":    rpcInvalidParams,
+	}
+	for id, code := range wantCodes {
+		resp, ok := responses[id]
+		if !ok || resp.Error == nil || resp.Error.Code != code {
+			t.Errorf("id %s: response %+v, want error code %d", id, resp, code)
+		}
+	}
+
+	// A command that fails is a tool result the agent can read, not a
+	// protocol error.
+	text, isError := toolText(t, responses["6"])
+	if !isError || !strings.Contains(text, "bd-zzzz") {
+		t.Errorf("show_issue unknown ID = %q (isError %v)", text, isError)
+	}
+}
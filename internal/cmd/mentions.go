@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/people"
+
+	"github.com/spf13/cobra"
+)
+
+// MentionJSON is the JSON output format for a comment in bd mentions.
+type MentionJSON struct {
+	IssueID    string `json:"issue_id"`
+	IssueTitle string `json:"issue_title"`
+	CommentID  int    `json:"comment_id"`
+	Author     string `json:"author"`
+	CreatedAt  string `json:"created_at"`
+	Text       string `json:"text"`
+}
+
+// newMentionsCmd creates the mentions command.
+func newMentionsCmd(provider *AppProvider) *cobra.Command {
+	var (
+		user  string
+		since string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "mentions",
+		Short: "List recent comments that mention you",
+		Long: `List comments that @mention you, newest first, across open and closed
+issues.
+
+You are the configured actor unless --user is given. A mention matches the
+identity ignoring case, and a mention of an email identity's local part
+matches it (@alice matches alice@example.com). If the identity is in the
+people registry, mentions of their handle, email or name all match.
+
+Examples:
+  bd mentions
+  bd mentions --since 1d
+  bd mentions --user bob --since 4w --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+
+			if user == "" {
+				user, err = resolveActor(app)
+				if err != nil || user == "" {
+					return fmt.Errorf("cannot determine who you are; set an actor or use --user")
+				}
+			}
+			user = strings.TrimPrefix(user, "@")
+			window, err := parseDuration(since)
+			if err != nil {
+				return fmt.Errorf("invalid --since value %q: %w", since, err)
+			}
+			cutoff := app.Now().Add(-window)
+
+			// Someone in the people registry is mentioned by any of their
+			// identities.
+			identities := []string{user}
+			registry, err := listPeople(cmd.Context(), app)
+			if err != nil {
+				return err
+			}
+			if p, ok := people.Find(registry, user); ok {
+				identities = append(identities, p.Identities()...)
+			}
+
+			issues, err := listExportIssues(cmd.Context(), app, nil)
+			if err != nil {
+				return err
+			}
+			type mention struct {
+				issue   *issuestorage.Issue
+				comment issuestorage.Comment
+			}
+			var mentions []mention
+			for _, issue := range issues {
+				for _, c := range issue.Comments {
+					if c.CreatedAt.Before(cutoff) {
+						continue
+					}
+					names := c.Mentions
+					if names == nil {
+						names = issuestorage.ParseMentions(c.Text)
+					}
+					if mentionsAny(names, identities) {
+						mentions = append(mentions, mention{issue: issue, comment: c})
+					}
+				}
+			}
+			sort.SliceStable(mentions, func(i, j int) bool {
+				return mentions[i].comment.CreatedAt.After(mentions[j].comment.CreatedAt)
+			})
+
+			if app.JSON {
+				out := make([]MentionJSON, len(mentions))
+				for i, m := range mentions {
+					out[i] = MentionJSON{
+						IssueID:    m.issue.ID,
+						IssueTitle: m.issue.Title,
+						CommentID:  m.comment.ID,
+						Author:     m.comment.Author,
+						CreatedAt:  formatTime(m.comment.CreatedAt),
+						Text:       m.comment.Text,
+					}
+				}
+				return json.NewEncoder(app.Out).Encode(out)
+			}
+
+			if len(mentions) == 0 {
+				fmt.Fprintf(app.Out, "No mentions of @%s in the last %s\n", user, since)
+				return nil
+			}
+			for _, m := range mentions {
+				fmt.Fprintf(app.Out, "%s · %s\n", m.issue.ID, m.issue.Title)
+				fmt.Fprintf(app.Out, "  [%s] %s (comment %d):\n", m.comment.CreatedAt.Format("2006-01-02 15:04"), m.comment.Author, m.comment.ID)
+				for _, line := range strings.Split(m.comment.Text, "\n") {
+					fmt.Fprintf(app.Out, "    %s\n", line)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&user, "user", "u", "", "Identity to list mentions of (default: the configured actor)")
+	cmd.Flags().StringVar(&since, "since", "7d", "Only include comments newer than this (e.g. 1d, 2w, 3m)")
+
+	return cmd
+}
+
+// mentionsAny reports whether any of names mentions one of identities.
+func mentionsAny(names, identities []string) bool {
+	for _, name := range names {
+		for _, id := range identities {
+			if issuestorage.MentionMatches(name, id) {
+				return true
+			}
+		}
+	}
+	return false
+}
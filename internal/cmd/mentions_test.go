@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestCommentMentionsSubscribe(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	id, err := store.Create(ctx, &issuestorage.Issue{Title: "Flaky test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := newCommentsCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"add", id, "@alice @bob please take a look", "--author", "carol"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("comments add failed: %v", err)
+	}
+	if err := addComment(ctx, store, id, &issuestorage.Comment{Author: "dave", Text: "+1 @Alice"}); err != nil {
+		t.Fatal(err)
+	}
+
+	issue, err := store.Get(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(issue.Subscribers, ",") != "alice,bob" {
+		t.Errorf("subscribers = %v, want [alice bob]", issue.Subscribers)
+	}
+}
+
+func TestMentionsCmd(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	id, err := store.Create(ctx, &issuestorage.Issue{Title: "Flaky test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := store.Create(ctx, &issuestorage.Issue{Title: "Docs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	for _, c := range []struct {
+		issue string
+		c     issuestorage.Comment
+	}{
+		{id, issuestorage.Comment{Author: "bob", Text: "@alice old news", CreatedAt: now.Add(-30 * 24 * time.Hour)}},
+		{id, issuestorage.Comment{Author: "bob", Text: "@alice can you check?", CreatedAt: now.Add(-2 * time.Hour)}},
+		{other, issuestorage.Comment{Author: "carol", Text: "thanks @Alice!", CreatedAt: now.Add(-time.Hour)}},
+		{other, issuestorage.Comment{Author: "carol", Text: "@bob too", CreatedAt: now}},
+	} {
+		c := c
+		if err := addComment(ctx, store, c.issue, &c.c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := newMentionsCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--user", "alice@example.com"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("mentions failed: %v", err)
+	}
+	out := app.Out.(*bytes.Buffer).String()
+	if strings.Contains(out, "old news") || strings.Contains(out, "@bob too") {
+		t.Errorf("unexpected mention in output:\n%s", out)
+	}
+	if i, j := strings.Index(out, "thanks @Alice!"), strings.Index(out, "@alice can you check?"); i < 0 || j < 0 || i > j {
+		t.Errorf("expected both recent mentions, newest first:\n%s", out)
+	}
+
+	app.Out.(*bytes.Buffer).Reset()
+	app.JSON = true
+	cmd = newMentionsCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--user", "alice", "--since", "8w"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("mentions --json failed: %v", err)
+	}
+	var got []MentionJSON
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(got) != 3 || got[0].IssueID != other || got[2].Text != "@alice old news" {
+		t.Errorf("JSON = %+v", got)
+	}
+}
+
+func TestMentionsCmd_None(t *testing.T) {
+	app, _ := setupTestApp(t)
+	cmd := newMentionsCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--user", "@zed"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("mentions failed: %v", err)
+	}
+	if out := app.Out.(*bytes.Buffer).String(); out != "No mentions of @zed in the last 7d\n" {
+		t.Errorf("output = %q", out)
+	}
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/issuestorage/filesystem"
+)
+
+func writeMergeVersion(t *testing.T, dir, name string, issue *issuestorage.Issue, c filesystem.Compression) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	var data []byte
+	if issue != nil {
+		var err error
+		if data, err = filesystem.EncodeIssueFile(issue, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestMergeFileCmd(t *testing.T) {
+	dir := t.TempDir()
+	then := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	base := &issuestorage.Issue{ID: "bd-m1", Title: "Merge me", Labels: []string{"a"}, CreatedAt: then, UpdatedAt: then}
+	ours := *base
+	ours.Labels, ours.UpdatedAt = []string{"a", "ours"}, then.Add(time.Hour)
+	theirs := *base
+	theirs.Title, theirs.Labels, theirs.UpdatedAt = "Merged", []string{"a", "theirs"}, then.Add(2*time.Hour)
+
+	basePath := writeMergeVersion(t, dir, "base", base, filesystem.CompressionNone)
+	oursPath := writeMergeVersion(t, dir, "ours", &ours, filesystem.CompressionGzip)
+	theirsPath := writeMergeVersion(t, dir, "theirs", &theirs, filesystem.CompressionNone)
+
+	cmd := newMergeFileCmd(&AppProvider{})
+	cmd.SetArgs([]string{basePath, oursPath, theirsPath, ".beads/issues/open/bd-m1.json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("merge-file: %v", err)
+	}
+	data, err := os.ReadFile(oursPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	merged, compression, err := filesystem.DecodeIssueFile(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if compression != filesystem.CompressionGzip {
+		t.Errorf("result compression = %s, want ours' gzip", compression)
+	}
+	if merged.Title != "Merged" || !reflect.DeepEqual(merged.Labels, []string{"a", "ours", "theirs"}) {
+		t.Errorf("merged = %q %q", merged.Title, merged.Labels)
+	}
+
+	// Both sides adding the file gives an empty base.
+	emptyBase := writeMergeVersion(t, dir, "empty", nil, filesystem.CompressionNone)
+	cmd = newMergeFileCmd(&AppProvider{})
+	cmd.SetArgs([]string{emptyBase, oursPath, theirsPath})
+	if err := cmd.Execute(); err != nil {
+		t.Errorf("merge-file with empty base: %v", err)
+	}
+
+	bad := filepath.Join(dir, "bad")
+	os.WriteFile(bad, []byte("<<<<<<< HEAD\n"), 0644)
+	cmd = newMergeFileCmd(&AppProvider{})
+	cmd.SetArgs([]string{basePath, oursPath, bad, "bd-m1.json"})
+	cmd.SilenceUsage = true
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "bd-m1.json (theirs)") {
+		t.Errorf("unparseable theirs: err = %v", err)
+	}
+}
@@ -0,0 +1,442 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/kvstorage"
+	"beads-lite/internal/milestone"
+
+	"github.com/spf13/cobra"
+)
+
+// MilestoneJSON is the JSON output format for milestone commands and the
+// per-milestone summary of bd stats. Burndown is only set by bd stats.
+type MilestoneJSON struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Status      string            `json:"status"`
+	DueAt       string            `json:"due_at,omitempty"`
+	CreatedAt   string            `json:"created_at,omitempty"`
+	ClosedAt    string            `json:"closed_at,omitempty"`
+	Open        int               `json:"open"`
+	Closed      int               `json:"closed"`
+	Total       int               `json:"total"`
+	Burndown    []milestone.Point `json:"burndown,omitempty"`
+}
+
+// MilestoneAssignJSON is the JSON output of bd milestone assign.
+type MilestoneAssignJSON struct {
+	Milestone string   `json:"milestone"`
+	Assigned  []string `json:"assigned"`
+}
+
+// burndownTextDays is how many days of a burndown bd stats prints.
+const burndownTextDays = 14
+
+func toMilestoneJSON(m milestone.Milestone, p milestone.Progress) MilestoneJSON {
+	out := MilestoneJSON{
+		Name:        m.Name,
+		Description: m.Description,
+		Status:      m.Status,
+		Open:        p.Open,
+		Closed:      p.Closed,
+		Total:       p.Total(),
+	}
+	if m.DueAt != nil {
+		out.DueAt = formatTime(*m.DueAt)
+	}
+	if !m.CreatedAt.IsZero() {
+		out.CreatedAt = formatTime(m.CreatedAt)
+	}
+	if m.ClosedAt != nil {
+		out.ClosedAt = formatTime(*m.ClosedAt)
+	}
+	return out
+}
+
+// formatMilestoneProgress summarizes a milestone's issues on one line,
+// e.g. "3 open, 7 closed (70% done), due 2026-11-01".
+func formatMilestoneProgress(m milestone.Milestone, p milestone.Progress) string {
+	s := fmt.Sprintf("%d open, %d closed", p.Open, p.Closed)
+	if p.Total() > 0 {
+		s += fmt.Sprintf(" (%d%% done)", p.Closed*100/p.Total())
+	}
+	if m.DueAt != nil {
+		s += ", due " + m.DueAt.Local().Format("2006-01-02")
+	}
+	if m.Status == milestone.StatusClosed {
+		s += ", closed"
+	}
+	return s
+}
+
+// checkMilestoneOpen returns an error unless name is an open milestone,
+// so issues are only planned for milestones that exist and are still open.
+func checkMilestoneOpen(ctx context.Context, app *App, name string) error {
+	if app.MilestoneStore == nil {
+		return fmt.Errorf("milestones are not available in this repository")
+	}
+	m, err := milestone.Get(ctx, app.MilestoneStore, name)
+	if errors.Is(err, kvstorage.ErrKeyNotFound) {
+		return fmt.Errorf("milestone %s not found: create it with 'bd milestone create %s'", name, name)
+	}
+	if err != nil {
+		return err
+	}
+	if m.Status == milestone.StatusClosed {
+		return fmt.Errorf("milestone %s is closed", name)
+	}
+	return nil
+}
+
+// issuesByMilestone returns every issue planned for a milestone, open or
+// closed, keyed by milestone name.
+func issuesByMilestone(ctx context.Context, store issuestorage.IssueStore) (map[string][]*issuestorage.Issue, error) {
+	issues, err := listAllIssuesForStats(ctx, store, &issuestorage.ListFilter{})
+	if err != nil {
+		return nil, err
+	}
+	return groupByMilestone(issues), nil
+}
+
+// groupByMilestone keys issues by the milestone they are planned for,
+// leaving out those without one.
+func groupByMilestone(issues []*issuestorage.Issue) map[string][]*issuestorage.Issue {
+	byName := make(map[string][]*issuestorage.Issue)
+	for _, issue := range issues {
+		if issue.Milestone != "" {
+			byName[issue.Milestone] = append(byName[issue.Milestone], issue)
+		}
+	}
+	return byName
+}
+
+// milestoneSummary is a milestone with the progress of its issues.
+type milestoneSummary struct {
+	milestone milestone.Milestone
+	progress  milestone.Progress
+	burndown  []milestone.Point
+}
+
+func (s milestoneSummary) toJSON() MilestoneJSON {
+	out := toMilestoneJSON(s.milestone, s.progress)
+	out.Burndown = s.burndown
+	return out
+}
+
+// milestoneSummaries summarizes the milestones issues are planned for, in
+// bd milestone list order, with a burndown from when each milestone was
+// created (or its first issue, if it has no record) until it closed or now.
+func milestoneSummaries(ctx context.Context, app *App, issues []*issuestorage.Issue) ([]milestoneSummary, error) {
+	byName := groupByMilestone(issues)
+	if len(byName) == 0 {
+		return nil, nil
+	}
+
+	var known []milestone.Milestone
+	if app.MilestoneStore != nil {
+		var err error
+		if known, err = milestone.List(ctx, app.MilestoneStore); err != nil {
+			return nil, err
+		}
+	}
+	var ordered []milestone.Milestone
+	for _, m := range known {
+		if len(byName[m.Name]) > 0 {
+			ordered = append(ordered, m)
+		}
+	}
+	for _, name := range sortedKeys(byName) {
+		if !containsMilestone(known, name) {
+			ordered = append(ordered, milestone.Milestone{Name: name, Status: milestone.StatusOpen})
+		}
+	}
+
+	now := app.Now()
+	summaries := make([]milestoneSummary, 0, len(ordered))
+	for _, m := range ordered {
+		planned := byName[m.Name]
+		from := m.CreatedAt
+		if from.IsZero() {
+			from = now
+			for _, issue := range planned {
+				if issue.CreatedAt.Before(from) {
+					from = issue.CreatedAt
+				}
+			}
+		}
+		to := now
+		if m.ClosedAt != nil {
+			to = m.ClosedAt.In(now.Location())
+		}
+		summaries = append(summaries, milestoneSummary{
+			milestone: m,
+			progress:  milestone.Count(planned),
+			burndown:  milestone.Burndown(planned, from, to),
+		})
+	}
+	return summaries, nil
+}
+
+func containsMilestone(milestones []milestone.Milestone, name string) bool {
+	for _, m := range milestones {
+		if m.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// formatBurndown prints the last days of a burndown as open counts, oldest
+// first.
+func formatBurndown(points []milestone.Point) string {
+	if len(points) > burndownTextDays {
+		points = points[len(points)-burndownTextDays:]
+	}
+	counts := make([]string, len(points))
+	for i, p := range points {
+		counts[i] = strconv.Itoa(p.Open)
+	}
+	return fmt.Sprintf("open at end of day since %s: %s", points[0].Date, strings.Join(counts, " "))
+}
+
+// newMilestoneCmd creates the milestone command group.
+func newMilestoneCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "milestone",
+		Short: "Group issues into milestones and releases",
+		Long: `Milestones group issues planned for the same release or target date.
+They are stored under .beads/milestones/.
+
+Plan an issue for a milestone with bd milestone assign, or with
+--milestone on bd create and bd update. bd list --milestone lists a
+milestone's issues, and bd stats summarizes each milestone with a daily
+burndown of its open issues.`,
+	}
+
+	cmd.AddCommand(newMilestoneCreateCmd(provider))
+	cmd.AddCommand(newMilestoneListCmd(provider))
+	cmd.AddCommand(newMilestoneAssignCmd(provider))
+	cmd.AddCommand(newMilestoneCloseCmd(provider))
+
+	return cmd
+}
+
+// newMilestoneCreateCmd creates the "milestone create" subcommand.
+func newMilestoneCreateCmd(provider *AppProvider) *cobra.Command {
+	var (
+		due         string
+		description string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a milestone",
+		Long: `Create an open milestone. Names may contain letters, digits, '.', '_'
+and '-', such as v1.2 or 2026-Q4.
+
+Examples:
+  bd milestone create v1.2 --due 2026-11-01
+  bd milestone create 2026-Q4 -d "Fourth quarter goals"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			m := milestone.Milestone{Name: args[0], Description: description, CreatedAt: app.Now()}
+			if due != "" {
+				t, err := parseListCreatedTime(due, false)
+				if err != nil {
+					return fmt.Errorf("invalid --due value %q: %w", due, err)
+				}
+				m.DueAt = &t
+			}
+			// Repositories initialized before milestones existed have no
+			// table directory yet.
+			if s, ok := app.MilestoneStore.(interface{ Init(context.Context) error }); ok {
+				if err := s.Init(ctx); err != nil {
+					return fmt.Errorf("initializing milestone store: %w", err)
+				}
+			}
+			if err := milestone.Create(ctx, app.MilestoneStore, m); err != nil {
+				return err
+			}
+			m, err = milestone.Get(ctx, app.MilestoneStore, m.Name)
+			if err != nil {
+				return err
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(toMilestoneJSON(m, milestone.Progress{}))
+			}
+			fmt.Fprintf(app.Out, "%s Created milestone %s\n", app.SuccessColor("✓"), m.Name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&due, "due", "", "Target date (YYYY-MM-DD or RFC3339)")
+	cmd.Flags().StringVarP(&description, "description", "d", "", "What the milestone delivers")
+
+	return cmd
+}
+
+// newMilestoneListCmd creates the "milestone list" subcommand.
+func newMilestoneListCmd(provider *AppProvider) *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List milestones with their open and closed issues",
+		Long: `List open milestones, those due soonest first, with how many of their
+issues are open and closed. --all includes closed milestones.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			milestones, err := milestone.List(ctx, app.MilestoneStore)
+			if err != nil {
+				return err
+			}
+			byName, err := issuesByMilestone(ctx, app.Storage)
+			if err != nil {
+				return err
+			}
+
+			result := []MilestoneJSON{}
+			var shown []milestone.Milestone
+			for _, m := range milestones {
+				if m.Status == milestone.StatusClosed && !all {
+					continue
+				}
+				shown = append(shown, m)
+				result = append(result, toMilestoneJSON(m, milestone.Count(byName[m.Name])))
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(result)
+			}
+			if len(shown) == 0 {
+				fmt.Fprintln(app.Out, "No milestones found.")
+				return nil
+			}
+			width := 0
+			for _, m := range shown {
+				width = max(width, len(m.Name))
+			}
+			for _, m := range shown {
+				fmt.Fprintf(app.Out, "%-*s  %s\n", width, m.Name, formatMilestoneProgress(m, milestone.Count(byName[m.Name])))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Include closed milestones")
+
+	return cmd
+}
+
+// newMilestoneAssignCmd creates the "milestone assign" subcommand.
+func newMilestoneAssignCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "assign <name> <issue-id>...",
+		Short: "Plan issues for a milestone",
+		Long: `Plan issues for an open milestone, replacing any milestone they were
+planned for. Use bd update --milestone "" to take an issue off its
+milestone.
+
+Examples:
+  bd milestone assign v1.2 bd-a1b2 bd-c3d4`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			name := args[0]
+			if err := checkMilestoneOpen(ctx, app, name); err != nil {
+				return err
+			}
+			assigned := make([]string, 0, len(args)-1)
+			for _, id := range args[1:] {
+				issue, err := resolveIssue(app.Storage, ctx, id)
+				if err != nil {
+					return fmt.Errorf("resolving issue %s: %w", id, err)
+				}
+				if err := app.Storage.Modify(ctx, issue.ID, func(i *issuestorage.Issue) error {
+					i.Milestone = name
+					return nil
+				}); err != nil {
+					return fmt.Errorf("assigning %s to milestone %s: %w", issue.ID, name, err)
+				}
+				assigned = append(assigned, issue.ID)
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(MilestoneAssignJSON{Milestone: name, Assigned: assigned})
+			}
+			fmt.Fprintf(app.Out, "%s Planned %s for milestone %s\n", app.SuccessColor("✓"), strings.Join(assigned, ", "), name)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// newMilestoneCloseCmd creates the "milestone close" subcommand.
+func newMilestoneCloseCmd(provider *AppProvider) *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "close <name>",
+		Short: "Close a milestone",
+		Long: `Close a milestone once all of its issues are closed. --force closes it
+anyway; its open issues stay planned for it until moved elsewhere.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			name := args[0]
+			byName, err := issuesByMilestone(ctx, app.Storage)
+			if err != nil {
+				return err
+			}
+			progress := milestone.Count(byName[name])
+			if progress.Open > 0 && !force {
+				return fmt.Errorf("milestone %s has %d open issue(s): close or move them first, or use --force", name, progress.Open)
+			}
+			m, err := milestone.Close(ctx, app.MilestoneStore, name, app.Now())
+			if err != nil {
+				return err
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(toMilestoneJSON(m, progress))
+			}
+			fmt.Fprintf(app.Out, "%s Closed milestone %s (%s)\n", app.SuccessColor("✓"), name, formatMilestoneProgress(milestone.Milestone{Name: name}, progress))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Close even if issues are still open")
+
+	return cmd
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/clock"
+	"beads-lite/internal/issuestorage"
+	kvfs "beads-lite/internal/kvstorage/filesystem"
+	"beads-lite/internal/milestone"
+
+	"github.com/spf13/cobra"
+)
+
+func TestMilestoneCommands(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2026, 10, 1, 9, 0, 0, 0, time.Local))
+	store.SetClock(fake)
+	// Left uninitialized: bd milestone create makes the table directory.
+	milestoneStore, err := kvfs.New(t.TempDir(), "milestones")
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.MilestoneStore = milestoneStore
+
+	exec := func(newCmd func(*AppProvider) *cobra.Command, args ...string) ([]byte, error) {
+		t.Helper()
+		out := &bytes.Buffer{}
+		app.Out = out
+		cmd := newCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.Bytes(), err
+	}
+	run := func(newCmd func(*AppProvider) *cobra.Command, args ...string) []byte {
+		t.Helper()
+		out, err := exec(newCmd, args...)
+		if err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+		return out
+	}
+
+	run(newMilestoneCmd, "create", "v1.2", "--due", "2026-10-20", "-d", "Autumn release")
+	if _, err := exec(newMilestoneCmd, "create", "v1.2"); err == nil {
+		t.Error("creating v1.2 twice should fail")
+	}
+	if _, err := exec(newCreateCmd, "Orphan", "--milestone", "v9"); err == nil {
+		t.Error("--milestone should require an existing milestone")
+	}
+
+	a := extractCreatedID(string(run(newCreateCmd, "Changelog", "--milestone", "v1.2")))
+	b := createAssigned(t, store, "Release notes", "", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	c := createAssigned(t, store, "Tag release", "", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	other := createAssigned(t, store, "Unplanned", "", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	run(newMilestoneCmd, "assign", "v1.2", b, c)
+	if issue, _ := store.Get(ctx, b); issue.Milestone != "v1.2" {
+		t.Errorf("%s milestone = %q", b, issue.Milestone)
+	}
+
+	fake.Advance(24 * time.Hour)
+	run(newCloseCmd, a)
+	fake.Advance(24 * time.Hour)
+
+	app.JSON = true
+
+	var listed []IssueListJSON
+	if err := json.Unmarshal(run(newListCmd, "--milestone", "v1.2"), &listed); err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 2 || listed[0].Milestone != "v1.2" {
+		t.Errorf("list --milestone v1.2 = %+v, want the 2 open issues", listed)
+	}
+	if err := json.Unmarshal(run(newListCmd, "--milestone", ""), &listed); err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 1 || listed[0].ID != other {
+		t.Errorf("list --milestone '' = %+v, want only %s", listed, other)
+	}
+
+	var milestones []MilestoneJSON
+	if err := json.Unmarshal(run(newMilestoneCmd, "list"), &milestones); err != nil {
+		t.Fatal(err)
+	}
+	if len(milestones) != 1 || milestones[0].Open != 2 || milestones[0].Closed != 1 || milestones[0].Description != "Autumn release" {
+		t.Errorf("milestone list = %+v", milestones)
+	}
+
+	var stats StatsResult
+	if err := json.Unmarshal(run(newStatsCmd), &stats); err != nil {
+		t.Fatal(err)
+	}
+	wantBurndown := []milestone.Point{
+		{Date: "2026-10-01", Open: 3},
+		{Date: "2026-10-02", Open: 2},
+		{Date: "2026-10-03", Open: 2},
+	}
+	if len(stats.Milestones) != 1 || stats.Milestones[0].Total != 3 || !reflect.DeepEqual(stats.Milestones[0].Burndown, wantBurndown) {
+		t.Errorf("stats milestones = %+v", stats.Milestones)
+	}
+
+	app.JSON = false
+	out := string(run(newStatsCmd))
+	if !strings.Contains(out, "v1.2: 2 open, 1 closed (33% done), due 2026-10-20") || !strings.Contains(out, "since 2026-10-01: 3 2 2") {
+		t.Errorf("stats output = %q", out)
+	}
+
+	if _, err := exec(newMilestoneCmd, "close", "v1.2"); err == nil {
+		t.Error("closing a milestone with open issues should need --force")
+	}
+	run(newMilestoneCmd, "close", "v1.2", "--force")
+	if _, err := exec(newMilestoneCmd, "assign", "v1.2", other); err == nil {
+		t.Error("assigning to a closed milestone should fail")
+	}
+	if out := string(run(newMilestoneCmd, "list")); out != "No milestones found.\n" {
+		t.Errorf("milestone list after close = %q", out)
+	}
+	if out := string(run(newMilestoneCmd, "list", "--all")); !strings.Contains(out, "v1.2  2 open, 1 closed (33% done), due 2026-10-20, closed") {
+		t.Errorf("milestone list --all = %q", out)
+	}
+
+	run(newUpdateCmd, b, "--milestone", "")
+	if issue, _ := store.Get(ctx, b); issue.Milestone != "" {
+		t.Errorf("clearing the milestone left %q", issue.Milestone)
+	}
+}
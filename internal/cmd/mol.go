@@ -51,19 +51,25 @@ Subcommands:
 
 // newMolPourCmd creates the "mol pour" subcommand.
 func newMolPourCmd(provider *AppProvider) *cobra.Command {
-	var vars []string
+	var (
+		vars     []string
+		varsFrom string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "pour <formula-name>",
 		Short: "Pour a formula to create a molecule",
 		Long: `Instantiate a formula by name, creating a molecule (issue tree).
 
-Variables can be supplied with --var key=value (repeatable).
-Missing required variables cause an error.
+Variables can be supplied with --var key=value (repeatable), or taken
+from the vars of an issue with --vars-from (see bd env); --var wins.
+Missing required variables cause an error. The molecule keeps the
+variables as its vars, so its steps see them too.
 
 Examples:
   bd mol pour feature-workflow
-  bd mol pour bug-triage --var component=auth --var severity=high`,
+  bd mol pour bug-triage --var component=auth --var severity=high
+  bd mol pour deploy --vars-from bd-a1b2`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			app, err := provider.Get()
@@ -76,9 +82,14 @@ Examples:
 				return err
 			}
 
+			molVars, err := pourVars(cmd.Context(), app, varsFrom, vars)
+			if err != nil {
+				return err
+			}
+
 			opts := meow.PourOptions{
 				FormulaName:    args[0],
-				Vars:           parseVarFlags(vars),
+				Vars:           molVars,
 				PrefixAddition: "mol",
 				SearchPath:     meow.DefaultSearchPath(app.ConfigDir),
 				Actor:          actor,
@@ -100,13 +111,17 @@ Examples:
 	}
 
 	cmd.Flags().StringArrayVar(&vars, "var", nil, "Variable assignment (key=value, repeatable)")
+	cmd.Flags().StringVar(&varsFrom, "vars-from", "", "Take variables from this issue's vars")
 
 	return cmd
 }
 
 // newMolWispCmd creates the "mol wisp" subcommand (ephemeral pour).
 func newMolWispCmd(provider *AppProvider) *cobra.Command {
-	var vars []string
+	var (
+		vars     []string
+		varsFrom string
+	)
 
 	wispRunE := func(cmd *cobra.Command, args []string) error {
 		app, err := provider.Get()
@@ -119,9 +134,14 @@ func newMolWispCmd(provider *AppProvider) *cobra.Command {
 			return err
 		}
 
+		molVars, err := pourVars(cmd.Context(), app, varsFrom, vars)
+		if err != nil {
+			return err
+		}
+
 		opts := meow.PourOptions{
 			FormulaName:    args[0],
-			Vars:           parseVarFlags(vars),
+			Vars:           molVars,
 			Ephemeral:      true,
 			PrefixAddition: "wisp",
 			SearchPath:     meow.DefaultSearchPath(app.ConfigDir),
@@ -163,9 +183,11 @@ Examples:
 		RunE:  wispRunE,
 	}
 	createCmd.Flags().StringArrayVar(&vars, "var", nil, "Variable assignment (key=value, repeatable)")
+	createCmd.Flags().StringVar(&varsFrom, "vars-from", "", "Take variables from this issue's vars")
 	cmd.AddCommand(createCmd)
 
 	cmd.Flags().StringArrayVar(&vars, "var", nil, "Variable assignment (key=value, repeatable)")
+	cmd.Flags().StringVar(&varsFrom, "vars-from", "", "Take variables from this issue's vars")
 
 	return cmd
 }
@@ -397,7 +419,7 @@ Examples:
 				// Compute rate/ETA based on elapsed time.
 				var ratePerHour, etaHours float64
 				if rootErr == nil && result.Completed > 0 {
-					elapsed := time.Since(root.CreatedAt).Hours()
+					elapsed := app.Now().Sub(root.CreatedAt).Hours()
 					if elapsed > 0 {
 						ratePerHour = float64(result.Completed) / elapsed
 						remaining := result.Total - result.Completed
@@ -593,6 +615,7 @@ Examples:
 
 			opts := meow.GCOptions{
 				OlderThan: dur,
+				Clock:     app.Storage.Clock(),
 			}
 
 			result, err := meow.GC(cmd.Context(), app.Storage, opts)
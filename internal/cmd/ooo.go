@@ -0,0 +1,328 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// oooKeyPrefix prefixes the config keys of the availability registry: each
+// person's out-of-office ranges are stored as ooo.<person>, e.g.
+// "2026-10-20..2026-10-31,2026-12-24".
+const oooKeyPrefix = "ooo."
+
+// oooDateLayout is the date format of out-of-office ranges.
+const oooDateLayout = "2006-01-02"
+
+// OOORange is an inclusive range of whole days someone is away.
+type OOORange struct {
+	From time.Time
+	To   time.Time
+}
+
+// String formats the range as FROM..TO, or a single date for one day.
+func (r OOORange) String() string {
+	if r.From.Equal(r.To) {
+		return r.From.Format(oooDateLayout)
+	}
+	return r.From.Format(oooDateLayout) + ".." + r.To.Format(oooDateLayout)
+}
+
+// Contains reports whether t falls on one of the range's days.
+func (r OOORange) Contains(t time.Time) bool {
+	return !t.Before(r.From) && t.Before(r.To.AddDate(0, 0, 1))
+}
+
+// OOOJSON is the JSON output format for one person in bd ooo list.
+type OOOJSON struct {
+	Person    string   `json:"person"`
+	Ranges    []string `json:"ranges"`
+	AwayUntil string   `json:"away_until,omitempty"` // set if away now
+}
+
+// newOOOCmd creates the ooo command with subcommands.
+func newOOOCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ooo",
+		Short: "Manage the out-of-office availability registry",
+		Long: `Manage the availability registry: date ranges when people are out of
+office, so work stops being routed to them while they are away.
+
+Ranges are whole days, inclusive, stored in config as ooo.<person>.
+While someone is away, bd rebalance --suggest moves their unstarted work
+to others and never proposes them as a target, bd workload marks them,
+and assigning an issue to them prints a warning.
+
+Subcommands:
+  add    Record a range someone is away
+  list   List current and upcoming absences
+  clear  Remove someone's absences`,
+	}
+
+	cmd.AddCommand(newOOOAddCmd(provider))
+	cmd.AddCommand(newOOOListCmd(provider))
+	cmd.AddCommand(newOOOClearCmd(provider))
+
+	return cmd
+}
+
+// newOOOAddCmd creates the "ooo add" subcommand.
+func newOOOAddCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "add <person> <from> [to]",
+		Short: "Record a range someone is away",
+		Long: `Record that someone is away from one date to another, inclusive. With
+no end date they are away for the single day.
+
+Examples:
+  bd ooo add alice 2026-10-20 2026-10-31
+  bd ooo add bob 2026-12-24`,
+		Args: cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			person := args[0]
+			spec := args[1]
+			if len(args) == 3 {
+				spec += ".." + args[2]
+			}
+			r, err := parseOOORange(spec)
+			if err != nil {
+				return err
+			}
+			store, err := configStore(provider)
+			if err != nil {
+				return err
+			}
+			existing, _ := store.Get(oooKeyPrefix + person)
+			ranges, err := parseOOORanges(existing)
+			if err != nil {
+				return fmt.Errorf("%s%s: %w", oooKeyPrefix, person, err)
+			}
+			ranges = append(ranges, r)
+			sort.Slice(ranges, func(i, j int) bool { return ranges[i].From.Before(ranges[j].From) })
+			if err := store.Set(oooKeyPrefix+person, formatOOORanges(ranges)); err != nil {
+				return fmt.Errorf("saving availability: %w", err)
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(toOOOJSON(person, ranges, app.Now()))
+			}
+			fmt.Fprintf(app.Out, "%s %s is away %s\n", app.SuccessColor("✓"), person, r)
+			return nil
+		},
+	}
+}
+
+// newOOOListCmd creates the "ooo list" subcommand.
+func newOOOListCmd(provider *AppProvider) *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List current and upcoming absences",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			now := app.Now()
+			people := []OOOJSON{}
+			registry := oooRegistry(app)
+			for _, person := range sortedKeys(registry) {
+				var ranges []OOORange
+				for _, r := range registry[person] {
+					if all || !r.To.AddDate(0, 0, 1).Before(now) {
+						ranges = append(ranges, r)
+					}
+				}
+				if len(ranges) > 0 {
+					people = append(people, toOOOJSON(person, ranges, now))
+				}
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(people)
+			}
+			if len(people) == 0 {
+				fmt.Fprintln(app.Out, "No one is out of office.")
+				return nil
+			}
+			for _, p := range people {
+				line := fmt.Sprintf("%s  %s", p.Person, strings.Join(p.Ranges, ", "))
+				if p.AwayUntil != "" {
+					line += "  (away now, until " + p.AwayUntil + ")"
+				}
+				fmt.Fprintln(app.Out, line)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Include past absences")
+
+	return cmd
+}
+
+// newOOOClearCmd creates the "ooo clear" subcommand.
+func newOOOClearCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "clear <person>",
+		Short: "Remove someone's absences",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			person := args[0]
+			store, err := configStore(provider)
+			if err != nil {
+				return err
+			}
+			if _, ok := store.Get(oooKeyPrefix + person); !ok {
+				return fmt.Errorf("no absences recorded for %s", person)
+			}
+			if err := store.Unset(oooKeyPrefix + person); err != nil {
+				return fmt.Errorf("clearing availability: %w", err)
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(map[string]string{"cleared": person})
+			}
+			fmt.Fprintf(app.Out, "%s Cleared absences for %s\n", app.SuccessColor("✓"), person)
+			return nil
+		},
+	}
+}
+
+// toOOOJSON converts someone's ranges to JSON, noting if they are away at
+// now.
+func toOOOJSON(person string, ranges []OOORange, now time.Time) OOOJSON {
+	out := OOOJSON{Person: person, Ranges: []string{}}
+	for _, r := range ranges {
+		out.Ranges = append(out.Ranges, r.String())
+	}
+	if until, ok := awayUntil(ranges, now); ok {
+		out.AwayUntil = until.Format(oooDateLayout)
+	}
+	return out
+}
+
+// parseOOORange parses FROM..TO or a single date into a range.
+func parseOOORange(s string) (OOORange, error) {
+	fromStr, toStr, isRange := strings.Cut(strings.TrimSpace(s), "..")
+	if !isRange {
+		toStr = fromStr
+	}
+	from, err := time.ParseInLocation(oooDateLayout, fromStr, time.Local)
+	if err != nil {
+		return OOORange{}, fmt.Errorf("invalid date %q (expected YYYY-MM-DD)", fromStr)
+	}
+	to, err := time.ParseInLocation(oooDateLayout, toStr, time.Local)
+	if err != nil {
+		return OOORange{}, fmt.Errorf("invalid date %q (expected YYYY-MM-DD)", toStr)
+	}
+	if to.Before(from) {
+		return OOORange{}, fmt.Errorf("range %s ends before it starts", s)
+	}
+	return OOORange{From: from, To: to}, nil
+}
+
+// parseOOORanges parses a comma-separated list of ranges.
+func parseOOORanges(s string) ([]OOORange, error) {
+	var ranges []OOORange
+	for _, part := range strings.Split(s, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		r, err := parseOOORange(part)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
+
+// formatOOORanges formats ranges for storing in config.
+func formatOOORanges(ranges []OOORange) string {
+	parts := make([]string, len(ranges))
+	for i, r := range ranges {
+		parts[i] = r.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// validateOOORanges is the config validator for ooo.<person> keys.
+func validateOOORanges(key, v string) string {
+	if _, err := parseOOORanges(v); err != nil {
+		return fmt.Sprintf("%s: %v", key, err)
+	}
+	return ""
+}
+
+// oooRegistry returns everyone's out-of-office ranges by person. Invalid
+// entries are skipped; bd config validate reports them.
+func oooRegistry(app *App) map[string][]OOORange {
+	registry := make(map[string][]OOORange)
+	if app.ConfigStore == nil {
+		return registry
+	}
+	for key, value := range app.ConfigStore.All() {
+		person, ok := strings.CutPrefix(key, oooKeyPrefix)
+		if !ok || person == "" {
+			continue
+		}
+		if ranges, err := parseOOORanges(value); err == nil && len(ranges) > 0 {
+			registry[person] = ranges
+		}
+	}
+	return registry
+}
+
+// awayUntil reports whether ranges cover t and, if so, the last day of the
+// absence, following back-to-back ranges.
+func awayUntil(ranges []OOORange, t time.Time) (time.Time, bool) {
+	var until time.Time
+	away := false
+	for changed := true; changed; {
+		changed = false
+		for _, r := range ranges {
+			if r.Contains(t) && r.To.After(until) {
+				until, away, changed = r.To, true, true
+				t = until.AddDate(0, 0, 1)
+			}
+		}
+	}
+	return until, away
+}
+
+// awayPeople returns the people away at t with the last day of each
+// absence.
+func awayPeople(app *App, t time.Time) map[string]time.Time {
+	away := make(map[string]time.Time)
+	for person, ranges := range oooRegistry(app) {
+		if until, ok := awayUntil(ranges, t); ok {
+			away[person] = until
+		}
+	}
+	return away
+}
+
+// warnIfAway prints a warning if person is out of office today.
+func warnIfAway(app *App, person string) {
+	if person == "" {
+		return
+	}
+	if until, ok := awayPeople(app, app.Now())[person]; ok {
+		fmt.Fprintf(app.Err, "warning: %s is out of office until %s\n", person, until.Format(oooDateLayout))
+	}
+}
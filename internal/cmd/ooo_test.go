@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/config/yamlstore"
+	"beads-lite/internal/issuestorage"
+)
+
+// oooDay parses a YYYY-MM-DD date in local time.
+func oooDay(s string) time.Time {
+	t, err := time.ParseInLocation(oooDateLayout, s, time.Local)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestParseOOORanges(t *testing.T) {
+	ranges, err := parseOOORanges("2026-10-20..2026-10-31, 2026-12-24")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got := formatOOORanges(ranges); got != "2026-10-20..2026-10-31,2026-12-24" {
+		t.Errorf("round trip = %q", got)
+	}
+	for _, bad := range []string{"2026-13-01", "2026-10-31..2026-10-20", "tomorrow"} {
+		if _, err := parseOOORanges(bad); err == nil {
+			t.Errorf("parseOOORanges(%q) = nil error, want error", bad)
+		}
+	}
+}
+
+func TestAwayUntil(t *testing.T) {
+	ranges, _ := parseOOORanges("2026-10-20..2026-10-23,2026-10-24..2026-10-25,2026-11-02")
+	tests := []struct {
+		at    time.Time
+		until string
+	}{
+		{oooDay("2026-10-19").Add(23 * time.Hour), ""},
+		{oooDay("2026-10-20"), "2026-10-25"}, // follows the back-to-back range
+		{oooDay("2026-10-23").Add(20 * time.Hour), "2026-10-25"},
+		{oooDay("2026-10-26"), ""},
+		{oooDay("2026-11-02").Add(12 * time.Hour), "2026-11-02"},
+	}
+	for _, tt := range tests {
+		until, ok := awayUntil(ranges, tt.at)
+		got := ""
+		if ok {
+			got = until.Format(oooDateLayout)
+		}
+		if got != tt.until {
+			t.Errorf("awayUntil(%s) = %q, want %q", tt.at, got, tt.until)
+		}
+	}
+}
+
+func TestOOOCmd(t *testing.T) {
+	app, _ := setupTestApp(t)
+	app.ConfigDir = t.TempDir()
+	reload := func() {
+		store, err := yamlstore.New(filepath.Join(app.ConfigDir, "config.yaml"))
+		if err != nil {
+			t.Fatalf("opening config: %v", err)
+		}
+		app.ConfigStore = store
+	}
+	reload()
+	run := func(args ...string) error {
+		app.Out.(*bytes.Buffer).Reset()
+		cmd := newOOOCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		reload()
+		return err
+	}
+
+	today := time.Now().Format(oooDateLayout)
+	nextWeek := time.Now().AddDate(0, 0, 7).Format(oooDateLayout)
+	if err := run("add", "alice", nextWeek); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err := run("add", "alice", today, today); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err := run("add", "bob", "2020-01-01", "2020-01-05"); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err := run("add", "carol", "2026-10-31", "2026-10-01"); err == nil {
+		t.Error("add with end before start: want error")
+	}
+	if got, _ := app.ConfigStore.Get("ooo.alice"); got != today+","+nextWeek {
+		t.Errorf("ooo.alice = %q, want ranges sorted by start", got)
+	}
+
+	app.JSON = true
+	if err := run("list"); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	var people []OOOJSON
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &people); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	// bob's absence is over, so only alice is listed.
+	if len(people) != 1 || people[0].Person != "alice" || people[0].AwayUntil != today {
+		t.Errorf("list = %+v, want alice away until %s", people, today)
+	}
+	if err := run("list", "--all"); err != nil {
+		t.Fatalf("list --all: %v", err)
+	}
+	if !strings.Contains(app.Out.(*bytes.Buffer).String(), `"bob"`) {
+		t.Errorf("list --all = %s, want bob", app.Out.(*bytes.Buffer).String())
+	}
+
+	if err := run("clear", "alice"); err != nil {
+		t.Fatalf("clear: %v", err)
+	}
+	if _, ok := app.ConfigStore.Get("ooo.alice"); ok {
+		t.Error("ooo.alice still set after clear")
+	}
+	if err := run("clear", "alice"); err == nil {
+		t.Error("clear with nothing recorded: want error")
+	}
+}
+
+func TestRebalanceCmd_SkipsPeopleAway(t *testing.T) {
+	app, store := setupTestApp(t)
+	today := time.Now().Format(oooDateLayout)
+	app.ConfigStore = &mapConfigStore{data: map[string]string{"ooo.carol": today}}
+	createAssigned(t, store, "c-started", "carol", issuestorage.StatusInProgress, issuestorage.PriorityMedium)
+	awayID := createAssigned(t, store, "c-open", "carol", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	createAssigned(t, store, "a1", "alice", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	createAssigned(t, store, "a2", "alice", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+
+	app.JSON = true
+	cmd := newRebalanceCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--suggest", "--people", "bob"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("rebalance: %v", err)
+	}
+	var got RebalanceJSON
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	// carol's open issue goes to bob even though carol is less loaded than
+	// alice, then alice and bob are even; nothing ever goes to carol.
+	if len(got.Moves) != 1 || got.Moves[0].ID != awayID || got.Moves[0].To != "bob" {
+		t.Fatalf("moves = %+v, want %s carol → bob", got.Moves, awayID)
+	}
+	if got.Away["carol"] != today {
+		t.Errorf("away = %v, want carol until %s", got.Away, today)
+	}
+}
+
+func TestUpdateCmd_WarnsWhenAssigneeAway(t *testing.T) {
+	app, store := setupTestApp(t)
+	today := time.Now().Format(oooDateLayout)
+	app.ConfigStore = &mapConfigStore{data: map[string]string{"ooo.carol": today}}
+	id := createAssigned(t, store, "task", "", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+
+	cmd := newUpdateCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{id, "--assignee", "carol"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if got := app.Err.(*bytes.Buffer).String(); !strings.Contains(got, "carol is out of office until "+today) {
+		t.Errorf("stderr = %q, want out-of-office warning", got)
+	}
+}
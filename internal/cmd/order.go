@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"beads-lite/internal/graph"
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// OrderJSON is the JSON output of bd order. Total counts every issue
+// ordered, including those in or behind a cycle and those past --limit.
+type OrderJSON struct {
+	Total  int               `json:"total"`
+	Order  []PlanIssueJSON   `json:"order"`
+	Cycles [][]PlanIssueJSON `json:"cycles,omitempty"` // issues that block each other
+	Stuck  []PlanIssueJSON   `json:"stuck,omitempty"`  // issues waiting on a cycle
+}
+
+// newOrderCmd creates the order command.
+func newOrderCmd(provider *AppProvider) *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "order",
+		Short: "List all open work as one queue in dependency order",
+		Long: `List every issue that is not closed as a single queue: each issue comes
+after the issues that block it, or block its ancestors, and otherwise
+the most urgent comes first, then the oldest. Working down the list in order never starts
+something before its blockers.
+
+Ephemeral issues are left out, and so are issues with children that are
+not closed, in favor of those children. Issues that block each other are
+reported as cycles, together with the issues waiting on them, since no
+order can satisfy them; fix them with bd dep remove.
+
+Examples:
+  bd order
+  bd order -n 5 --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			if limit < 0 {
+				return fmt.Errorf("invalid --limit %d", limit)
+			}
+
+			issues, err := app.Storage.List(ctx, nil)
+			if err != nil {
+				return fmt.Errorf("listing issues: %w", err)
+			}
+			byID := make(map[string]*issuestorage.Issue, len(issues))
+			closed := make(map[string]bool)
+			for _, issue := range issues {
+				if issue.Status == issuestorage.StatusClosed {
+					closed[issue.ID] = true
+				}
+				if issue.Status != issuestorage.StatusClosed && issue.Status != issuestorage.StatusTombstone && !issue.Ephemeral {
+					byID[issue.ID] = issue
+				}
+			}
+			var work []*issuestorage.Issue
+			for _, issue := range issues {
+				if byID[issue.ID] != nil && !hasChildIn(issue, byID) {
+					work = append(work, issue)
+				}
+			}
+			queued := make(map[string]bool, len(work))
+			for _, issue := range work {
+				queued[issue.ID] = true
+			}
+			// A child waits on what blocks its ancestors, as in bd ready.
+			blockers, err := issuestorage.ResolveBlockers(ctx, app.Storage, work, closed, cascadeEnabled(app))
+			if err != nil {
+				return err
+			}
+			q := graph.QueueOrder(work, blockers)
+			toJSON := func(issue *issuestorage.Issue) PlanIssueJSON {
+				out := toPlanIssueJSON(issue, queued)
+				out.BlockedBy = nil
+				for _, id := range blockers[issue.ID].AllBlockerIDs() {
+					if queued[id] {
+						out.BlockedBy = append(out.BlockedBy, id)
+					}
+				}
+				return out
+			}
+
+			result := OrderJSON{Total: len(work), Order: []PlanIssueJSON{}}
+			for _, issue := range q.Order {
+				if limit > 0 && len(result.Order) == limit {
+					break
+				}
+				result.Order = append(result.Order, toJSON(issue))
+			}
+			for _, cycle := range q.Cycles {
+				var c []PlanIssueJSON
+				for _, issue := range cycle {
+					c = append(c, toJSON(issue))
+				}
+				result.Cycles = append(result.Cycles, c)
+			}
+			for _, issue := range q.Stuck {
+				result.Stuck = append(result.Stuck, toJSON(issue))
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(result)
+			}
+
+			if len(work) == 0 {
+				fmt.Fprintln(app.Out, "No open issues.")
+				return nil
+			}
+			for i, issue := range result.Order {
+				fmt.Fprintf(app.Out, "%3d. %s\n", i+1, formatPlanIssue(issue))
+			}
+			if more := len(q.Order) - len(result.Order); more > 0 {
+				fmt.Fprintf(app.Out, "     … %d more\n", more)
+			}
+			if len(result.Cycles) > 0 {
+				fmt.Fprintln(app.Out, "\nCycles (these block each other and cannot start):")
+				for _, cycle := range result.Cycles {
+					ids := make([]string, len(cycle))
+					for i, issue := range cycle {
+						ids[i] = issue.ID
+					}
+					fmt.Fprintf(app.Out, "  ✗ %s\n", strings.Join(ids, ", "))
+					for _, issue := range cycle {
+						fmt.Fprintf(app.Out, "      %s\n", formatPlanIssue(issue))
+					}
+				}
+				fmt.Fprintf(app.Err, "Warning: %d dependency %s in stored data; see bd dep tree and bd dep remove\n",
+					len(result.Cycles), plural(len(result.Cycles), "cycle", "cycles"))
+			}
+			if len(result.Stuck) > 0 {
+				fmt.Fprintln(app.Out, "\nWaiting on a cycle:")
+				for _, issue := range result.Stuck {
+					fmt.Fprintf(app.Out, "      %s\n", formatPlanIssue(issue))
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "n", 0, "Show only the first N issues of the queue (0: all)")
+
+	return cmd
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestOrderCommand(t *testing.T) {
+	app, rs := setupTestApp(t)
+	ctx := context.Background()
+	start := time.Now().Add(-time.Hour)
+	n := 0
+	create := func(title string, priority issuestorage.Priority, blockers ...string) string {
+		t.Helper()
+		n++
+		id, err := rs.Create(ctx, &issuestorage.Issue{Title: title, Priority: priority, CreatedAt: start.Add(time.Duration(n) * time.Minute)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, b := range blockers {
+			if err := rs.AddDependency(ctx, id, b, issuestorage.DepTypeBlocks); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return id
+	}
+	epic := create("Launch", issuestorage.PriorityCritical)
+	design := create("Design", issuestorage.PriorityMedium)
+	older := create("Old chore", issuestorage.PriorityLow)
+	newer := create("New chore", issuestorage.PriorityLow)
+	ship := create("Ship", issuestorage.PriorityCritical, design)
+	if err := rs.AddDependency(ctx, ship, epic, issuestorage.DepTypeParentChild); err != nil {
+		t.Fatal(err)
+	}
+	closed := create("Done", issuestorage.PriorityCritical)
+	if err := rs.Modify(ctx, closed, func(i *issuestorage.Issue) error { i.Status = issuestorage.StatusClosed; return nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) (string, string) {
+		out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+		app.Out, app.Err = out, errOut
+		cmd := newOrderCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("order %v: %v", args, err)
+		}
+		return out.String(), errOut.String()
+	}
+
+	// The epic gives way to its child, and the urgent child to its blocker.
+	app.JSON = true
+	out, _ := run()
+	var result OrderJSON
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, issue := range result.Order {
+		got = append(got, issue.ID)
+	}
+	if want := []string{design, ship, older, newer}; strings.Join(got, " ") != strings.Join(want, " ") || result.Total != 4 {
+		t.Errorf("order = %v (total %d), want %v", got, result.Total, want)
+	}
+	if result.Order[1].BlockedBy[0] != design || result.Cycles != nil {
+		t.Errorf("order --json = %s", out)
+	}
+
+	app.JSON = false
+	out, _ = run("-n", "2")
+	if !strings.Contains(out, "  1. "+design) || !strings.Contains(out, "  2. "+ship) || !strings.Contains(out, "… 2 more") || strings.Contains(out, older) {
+		t.Errorf("order -n 2:\n%s", out)
+	}
+
+	// A cycle written around cycle detection is reported, and what waits
+	// on it left out of the queue.
+	if err := rs.Modify(ctx, design, func(i *issuestorage.Issue) error {
+		i.Dependencies = append(i.Dependencies, issuestorage.Dependency{ID: ship, Type: issuestorage.DepTypeBlocks})
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	waiting := create("Announce", issuestorage.PriorityCritical, ship)
+	out, warnings := run()
+	if !strings.Contains(out, "✗ "+min(design, ship)+", "+max(design, ship)) || !strings.Contains(out, "Waiting on a cycle:\n      "+waiting) ||
+		!strings.Contains(warnings, "1 dependency cycle in stored data") {
+		t.Errorf("order with a cycle:\n%s%s", out, warnings)
+	}
+	if !strings.Contains(out, "  1. "+older) {
+		t.Errorf("the rest should still be ordered:\n%s", out)
+	}
+}
+
+func TestOrderCommand_InheritedBlockers(t *testing.T) {
+	app, rs := setupTestApp(t)
+	ctx := context.Background()
+	q, err := rs.Create(ctx, &issuestorage.Issue{Title: "Q", Priority: issuestorage.PriorityLow})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := rs.Create(ctx, &issuestorage.Issue{Title: "P", Type: issuestorage.TypeEpic})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rs.AddDependency(ctx, p, q, issuestorage.DepTypeBlocks); err != nil {
+		t.Fatal(err)
+	}
+	child, err := rs.Create(ctx, &issuestorage.Issue{Title: "P.1", Priority: issuestorage.PriorityCritical})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rs.AddDependency(ctx, child, p, issuestorage.DepTypeParentChild); err != nil {
+		t.Fatal(err)
+	}
+
+	out := &bytes.Buffer{}
+	app.Out, app.JSON = out, true
+	cmd := newOrderCmd(NewTestProvider(app))
+	cmd.SetArgs(nil)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	var result OrderJSON
+	if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	// The urgent child still waits on what blocks its parent, as bd ready
+	// and bd blocked report.
+	if len(result.Order) != 2 || result.Order[0].ID != q || result.Order[1].ID != child ||
+		len(result.Order[1].BlockedBy) != 1 || result.Order[1].BlockedBy[0] != q {
+		t.Errorf("order --json = %s", out)
+	}
+}
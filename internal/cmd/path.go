@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"beads-lite/internal/graph"
+
+	"github.com/spf13/cobra"
+)
+
+// PathJSON is the JSON output of bd path.
+type PathJSON struct {
+	ID     string            `json:"id"`
+	Length int               `json:"length"` // issues on the path, the issue included
+	Path   []IssueSimpleJSON `json:"path"`   // from the first blocker to the issue
+}
+
+// newPathCmd creates the path command.
+func newPathCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "path <issue-id>",
+		Short: "Show the longest chain of open blockers ending at an issue",
+		Long: `Show the critical path to an issue: the longest chain of open blockers
+that must close, one after another, before it can start. The chain
+begins with an issue that waits on nothing open.
+
+Blockers are those bd blocked reports, so with
+graph.cascade_parent_blocking on (the default) an ancestor's blockers
+count as the issue's own. Of equally long chains, the one through the
+lowest IDs is shown.
+
+Examples:
+  bd path bd-a1b2
+  bd path bd-a1b2 --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			issue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+			closedSet, err := graph.BuildClosedSet(ctx, app.Storage)
+			if err != nil {
+				return err
+			}
+			path, err := graph.CriticalPath(ctx, app.Storage, issue, closedSet, cascadeEnabled(app))
+			if err != nil {
+				return err
+			}
+
+			if app.JSON {
+				result := PathJSON{ID: issue.ID, Length: len(path), Path: make([]IssueSimpleJSON, len(path))}
+				for i, p := range path {
+					result.Path[i] = ToIssueSimpleJSON(p)
+				}
+				return json.NewEncoder(app.Out).Encode(result)
+			}
+
+			if len(path) == 1 {
+				fmt.Fprintf(app.Out, "%s waits on nothing open.\n", issue.ID)
+				return nil
+			}
+			fmt.Fprintf(app.Out, "Critical path to %s (%d blockers to close in turn):\n\n", issue.ID, len(path)-1)
+			for i, p := range path {
+				fmt.Fprintf(app.Out, "  %d. %s\n", i+1, formatIssueLine(app, p))
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
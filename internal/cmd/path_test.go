@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestPathCommand(t *testing.T) {
+	app, rs := setupTestApp(t)
+	ctx := context.Background()
+	create := func(title string, blockers ...string) string {
+		t.Helper()
+		id, err := rs.Create(ctx, &issuestorage.Issue{Title: title})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, b := range blockers {
+			if err := rs.AddDependency(ctx, id, b, issuestorage.DepTypeBlocks); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return id
+	}
+	design := create("Design")
+	build := create("Build", design)
+	docs := create("Docs")
+	release := create("Release", build, docs)
+
+	out := app.Out.(*bytes.Buffer)
+	cmd := newPathCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{release})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	text := out.String()
+	if !strings.Contains(text, "2 blockers to close in turn") || strings.Contains(text, docs) ||
+		strings.Index(text, design) > strings.Index(text, build) {
+		t.Errorf("path output:\n%s", text)
+	}
+
+	out.Reset()
+	app.JSON = true
+	cmd = newPathCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{design})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	var result PathJSON
+	if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Length != 1 || len(result.Path) != 1 || result.Path[0].ID != design {
+		t.Errorf("path to unblocked issue = %+v", result)
+	}
+}
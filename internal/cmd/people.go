@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/kvstorage"
+	"beads-lite/internal/people"
+
+	"github.com/spf13/cobra"
+)
+
+// PersonJSON is the JSON output of bd people add and bd people list.
+type PersonJSON struct {
+	Handle    string `json:"handle"`
+	Name      string `json:"name,omitempty"`
+	Email     string `json:"email,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+func toPersonJSON(p people.Person) PersonJSON {
+	out := PersonJSON{Handle: p.Handle, Name: p.Name, Email: p.Email}
+	if !p.CreatedAt.IsZero() {
+		out.CreatedAt = formatTime(p.CreatedAt)
+	}
+	return out
+}
+
+// listPeople returns the people registry, empty if there is none.
+func listPeople(ctx context.Context, app *App) ([]people.Person, error) {
+	if app.PeopleStore == nil {
+		return nil, nil
+	}
+	return people.List(ctx, app.PeopleStore)
+}
+
+// checkAssignee returns an error if assignee is not in the people
+// registry, unless force is set. An empty registry accepts anyone, so
+// repositories that don't keep one are unaffected.
+func checkAssignee(ctx context.Context, app *App, assignee string, force bool) error {
+	if assignee == "" || force {
+		return nil
+	}
+	registry, err := listPeople(ctx, app)
+	if err != nil {
+		return err
+	}
+	if len(registry) == 0 {
+		return nil
+	}
+	if _, ok := people.Find(registry, assignee); ok {
+		return nil
+	}
+	return fmt.Errorf("%s is not in the people registry: add them with 'bd people add %s', or pass --force", assignee, assignee)
+}
+
+// myIdentities returns the identities the current actor is known by: the
+// actor, plus their handle, email and name if the people registry knows
+// them.
+func myIdentities(ctx context.Context, app *App) ([]string, error) {
+	actor, err := resolveActor(app)
+	if err != nil || actor == "" || actor == "unknown" {
+		return nil, fmt.Errorf("cannot determine who you are; set actor in config or BD_ACTOR")
+	}
+	ids := []string{actor}
+	registry, err := listPeople(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	if p, ok := people.Find(registry, actor); ok {
+		for _, id := range p.Identities() {
+			if !contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// indexMentions records the identities comment @mentions in its Mentions
+// field, using the handle of anyone in the people registry, and warns on
+// app.Err about mentions the registry doesn't know. Without a registry
+// it does nothing.
+func indexMentions(ctx context.Context, app *App, comment *issuestorage.Comment) {
+	names := issuestorage.ParseMentions(comment.Text)
+	if len(names) == 0 {
+		return
+	}
+	registry, err := listPeople(ctx, app)
+	if err != nil {
+		fmt.Fprintf(app.Err, "warning: %v\n", err)
+		return
+	}
+	if len(registry) == 0 {
+		return
+	}
+	comment.Mentions = nil
+	for _, name := range names {
+		if p, ok := people.Find(registry, name); ok {
+			name = p.Handle
+		} else {
+			fmt.Fprintf(app.Err, "warning: @%s is not in the people registry\n", name)
+		}
+		if !contains(comment.Mentions, name) {
+			comment.Mentions = append(comment.Mentions, name)
+		}
+	}
+}
+
+// newPeopleCmd creates the people command with subcommands.
+func newPeopleCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "people",
+		Short: "Manage the people registry",
+		Long: `The people registry lists who works in this repository. It is stored
+under .beads/people/.
+
+Once anyone is registered, bd create and bd update refuse an --assignee
+the registry doesn't know (by handle, email, email local part or name)
+unless --force is given, and comments warn about unknown @mentions.
+Mentions are recorded on each comment by handle, for bd mentions.
+bd list --mine lists issues assigned to any of your identities.`,
+	}
+
+	cmd.AddCommand(newPeopleAddCmd(provider))
+	cmd.AddCommand(newPeopleListCmd(provider))
+	cmd.AddCommand(newPeopleRemoveCmd(provider))
+
+	return cmd
+}
+
+// newPeopleAddCmd creates the "people add" subcommand.
+func newPeopleAddCmd(provider *AppProvider) *cobra.Command {
+	var (
+		name  string
+		email string
+		force bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add <handle>",
+		Short: "Add someone to the people registry",
+		Long: `Add someone to the people registry. --force replaces an existing entry
+with the same handle.
+
+Examples:
+  bd people add alice --email alice@example.com --name "Alice Liddell"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			if app.PeopleStore == nil {
+				return fmt.Errorf("the people registry is not available in this repository")
+			}
+
+			p := people.Person{
+				Handle:    strings.TrimPrefix(args[0], "@"),
+				Name:      strings.TrimSpace(name),
+				Email:     strings.TrimSpace(email),
+				CreatedAt: app.Now(),
+			}
+			if p.Email != "" && !strings.Contains(p.Email, "@") {
+				return fmt.Errorf("invalid email %q", p.Email)
+			}
+			// Repositories initialized before the registry existed have
+			// no table directory yet.
+			if s, ok := app.PeopleStore.(interface{ Init(context.Context) error }); ok {
+				if err := s.Init(ctx); err != nil {
+					return fmt.Errorf("initializing people store: %w", err)
+				}
+			}
+			if !force {
+				if _, err := people.Get(ctx, app.PeopleStore, p.Handle); err == nil {
+					return fmt.Errorf("%s is already registered (use --force to replace the entry)", p.Handle)
+				}
+			}
+			if err := people.Set(ctx, app.PeopleStore, p, force); err != nil {
+				return err
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(toPersonJSON(p))
+			}
+			fmt.Fprintf(app.Out, "%s Added %s\n", app.SuccessColor("✓"), p.Handle)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Full name")
+	cmd.Flags().StringVar(&email, "email", "", "Email address")
+	cmd.Flags().BoolVar(&force, "force", false, "Replace an existing entry")
+
+	return cmd
+}
+
+// newPeopleListCmd creates the "people list" subcommand.
+func newPeopleListCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the people registry",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+
+			registry, err := listPeople(cmd.Context(), app)
+			if err != nil {
+				return err
+			}
+
+			if app.JSON {
+				result := make([]PersonJSON, 0, len(registry))
+				for _, p := range registry {
+					result = append(result, toPersonJSON(p))
+				}
+				return json.NewEncoder(app.Out).Encode(result)
+			}
+			if len(registry) == 0 {
+				fmt.Fprintln(app.Out, "No one is registered. Add people with 'bd people add <handle>'.")
+				return nil
+			}
+			width := 0
+			for _, p := range registry {
+				width = max(width, len(p.Handle))
+			}
+			for _, p := range registry {
+				var parts []string
+				if p.Name != "" {
+					parts = append(parts, p.Name)
+				}
+				if p.Email != "" {
+					parts = append(parts, "<"+p.Email+">")
+				}
+				line := fmt.Sprintf("%-*s  %s", width, p.Handle, strings.Join(parts, " "))
+				fmt.Fprintln(app.Out, strings.TrimRight(line, " "))
+			}
+			return nil
+		},
+	}
+}
+
+// newPeopleRemoveCmd creates the "people remove" subcommand.
+func newPeopleRemoveCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <handle>",
+		Short: "Remove someone from the people registry",
+		Long: `Remove someone from the people registry. Issues assigned to them keep
+their assignee.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			if app.PeopleStore == nil {
+				return fmt.Errorf("the people registry is not available in this repository")
+			}
+			handle := strings.TrimPrefix(args[0], "@")
+			if err := people.Remove(cmd.Context(), app.PeopleStore, handle); err != nil {
+				if errors.Is(err, kvstorage.ErrKeyNotFound) {
+					return fmt.Errorf("%s is not registered", handle)
+				}
+				return err
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(map[string]string{"removed": handle})
+			}
+			fmt.Fprintf(app.Out, "%s Removed %s\n", app.SuccessColor("✓"), handle)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	kvfs "beads-lite/internal/kvstorage/filesystem"
+
+	"github.com/spf13/cobra"
+)
+
+func TestPeopleCommands(t *testing.T) {
+	t.Setenv("BD_ACTOR", "Alice Liddell")
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	// Left uninitialized: bd people add makes the table directory.
+	peopleStore, err := kvfs.New(t.TempDir(), "people")
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.PeopleStore = peopleStore
+
+	errOut := &bytes.Buffer{}
+	app.Err = errOut
+	exec := func(newCmd func(*AppProvider) *cobra.Command, args ...string) ([]byte, error) {
+		t.Helper()
+		out := &bytes.Buffer{}
+		app.Out = out
+		cmd := newCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.Bytes(), err
+	}
+	run := func(newCmd func(*AppProvider) *cobra.Command, args ...string) []byte {
+		t.Helper()
+		out, err := exec(newCmd, args...)
+		if err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+		return out
+	}
+
+	// With no registry, anyone can be assigned.
+	freeID := extractCreatedID(string(run(newCreateCmd, "Anyone", "--assignee", "zed")))
+
+	run(newPeopleCmd, "add", "alice", "--name", "Alice Liddell", "--email", "al@example.com")
+	run(newPeopleCmd, "add", "@bob")
+	if _, err := exec(newPeopleCmd, "add", "bob"); err == nil {
+		t.Error("adding bob twice should need --force")
+	}
+	if _, err := exec(newPeopleCmd, "add", "carol", "--email", "carol"); err == nil {
+		t.Error("an email without @ should be rejected")
+	}
+	if out := string(run(newPeopleCmd, "list")); out != "alice  Alice Liddell <al@example.com>\nbob\n" {
+		t.Errorf("people list = %q", out)
+	}
+	app.JSON = true
+	var listed []PersonJSON
+	if err := json.Unmarshal(run(newPeopleCmd, "list"), &listed); err != nil {
+		t.Fatal(err)
+	}
+	app.JSON = false
+	if len(listed) != 2 || listed[0].Email != "al@example.com" || listed[1].Handle != "bob" {
+		t.Errorf("people list --json = %+v", listed)
+	}
+
+	// Assignees must now be registered, by any identity, unless forced.
+	if _, err := exec(newCreateCmd, "Unknown", "--assignee", "zed"); err == nil || !strings.Contains(err.Error(), "bd people add zed") {
+		t.Errorf("create --assignee zed = %v, want a registry error", err)
+	}
+	aliceID := extractCreatedID(string(run(newCreateCmd, "By email", "--assignee", "al@example.com")))
+	forcedID := extractCreatedID(string(run(newCreateCmd, "Forced", "--assignee", "zed", "--force")))
+	if _, err := exec(newUpdateCmd, freeID, "--assignee", "dave"); err == nil {
+		t.Error("update --assignee dave should fail")
+	}
+	run(newUpdateCmd, freeID, "--assignee", "alice")
+	run(newUpdateCmd, forcedID, "--assignee", "")
+
+	// bd list --mine finds alice's issues under every identity.
+	out := string(run(newListCmd, "--mine"))
+	if !strings.Contains(out, aliceID) || !strings.Contains(out, freeID) || strings.Contains(out, forcedID) {
+		t.Errorf("list --mine = %q", out)
+	}
+	if _, err := exec(newListCmd, "--mine", "--assignee", "bob"); err == nil {
+		t.Error("--mine and --assignee should not combine")
+	}
+
+	// Mentions are recorded by handle, with a warning for unknown ones.
+	run(newCommentsCmd, aliceID, "ping @al and @bob and @zed")
+	issue, err := store.Get(ctx, aliceID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := issue.Comments[0]
+	if want := []string{"alice", "bob", "zed"}; !slices.Equal(c.Mentions, want) {
+		t.Errorf("comment mentions = %v, want %v", c.Mentions, want)
+	}
+	if !strings.Contains(errOut.String(), "@zed is not in the people registry") || strings.Contains(errOut.String(), "@bob is not") {
+		t.Errorf("warnings = %q", errOut.String())
+	}
+	if !slices.Contains(issue.Subscribers, "alice") {
+		t.Errorf("subscribers = %v, want alice", issue.Subscribers)
+	}
+
+	// bd mentions matches alice by her email's local part.
+	if out := string(run(newMentionsCmd, "--user", "alice")); !strings.Contains(out, aliceID) {
+		t.Errorf("mentions --user alice = %q", out)
+	}
+
+	run(newPeopleCmd, "remove", "bob")
+	if _, err := exec(newPeopleCmd, "remove", "bob"); err == nil {
+		t.Error("removing bob twice should fail")
+	}
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"beads-lite/internal/graph"
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// PlanJSON is the JSON output of bd plan.
+type PlanJSON struct {
+	EpicID    string            `json:"epic_id"`
+	EpicTitle string            `json:"epic_title"`
+	Total     int               `json:"total"`
+	Steps     []PlanStepJSON    `json:"steps"`
+	Cycles    [][]PlanIssueJSON `json:"cycles,omitempty"` // issues that block each other
+	Stuck     []PlanIssueJSON   `json:"stuck,omitempty"`  // issues waiting on a cycle
+}
+
+// PlanStepJSON is a group of issues that can be worked in parallel once
+// the steps before it are done.
+type PlanStepJSON struct {
+	Step   int             `json:"step"`
+	Issues []PlanIssueJSON `json:"issues"`
+}
+
+// PlanIssueJSON is an issue in a plan. BlockedBy lists its blockers
+// within the plan.
+type PlanIssueJSON struct {
+	ID        string   `json:"id"`
+	Title     string   `json:"title"`
+	Status    string   `json:"status"`
+	Priority  int      `json:"priority"`
+	Assignee  string   `json:"assignee,omitempty"`
+	BlockedBy []string `json:"blocked_by,omitempty"`
+}
+
+// toPlanIssueJSON converts issue, keeping only the blockers in planned.
+func toPlanIssueJSON(issue *issuestorage.Issue, planned map[string]bool) PlanIssueJSON {
+	out := PlanIssueJSON{
+		ID:       issue.ID,
+		Title:    issue.Title,
+		Status:   string(issue.Status),
+		Priority: int(issue.Priority),
+		Assignee: issue.Assignee,
+	}
+	blocks := issuestorage.DepTypeBlocks
+	for _, id := range issue.DependencyIDs(&blocks) {
+		if planned[id] {
+			out.BlockedBy = append(out.BlockedBy, id)
+		}
+	}
+	return out
+}
+
+// newPlanCmd creates the plan command.
+func newPlanCmd(provider *AppProvider) *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "plan <epic-id>",
+		Short: "Print an epic's work in dependency order",
+		Long: `Print an execution plan for the work under an epic: its descendants in
+an order that honors their blocking dependencies, as numbered steps.
+The issues in a step don't block each other and can be worked in
+parallel once the earlier steps are done; within a step the most urgent
+come first.
+
+Issues with children are left out in favor of their children, and so
+are closed issues unless --all is given. Issues that block each other are
+flagged as cycles, together with the issues waiting on them, since no
+order can satisfy them; fix them with bd dep remove.
+
+Examples:
+  bd plan bd-a1b2
+  bd plan bd-a1b2 --all --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			epic, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+			descendants, err := graph.CollectMoleculeChildren(ctx, app.Storage, epic.ID)
+			if err != nil {
+				return err
+			}
+			byID := make(map[string]*issuestorage.Issue, len(descendants))
+			for _, issue := range descendants {
+				byID[issue.ID] = issue
+			}
+			var tasks []*issuestorage.Issue
+			planned := make(map[string]bool)
+			for _, issue := range descendants {
+				if issue.Status == issuestorage.StatusTombstone || hasChildIn(issue, byID) {
+					continue
+				}
+				if issue.Status == issuestorage.StatusClosed && !all {
+					continue
+				}
+				tasks = append(tasks, issue)
+				planned[issue.ID] = true
+			}
+			plan := graph.PlanOrder(tasks)
+
+			result := PlanJSON{EpicID: epic.ID, EpicTitle: epic.Title, Total: len(tasks), Steps: []PlanStepJSON{}}
+			for i, step := range plan.Steps {
+				s := PlanStepJSON{Step: i + 1}
+				for _, issue := range step {
+					s.Issues = append(s.Issues, toPlanIssueJSON(issue, planned))
+				}
+				result.Steps = append(result.Steps, s)
+			}
+			for _, cycle := range plan.Cycles {
+				var c []PlanIssueJSON
+				for _, issue := range cycle {
+					c = append(c, toPlanIssueJSON(issue, planned))
+				}
+				result.Cycles = append(result.Cycles, c)
+			}
+			for _, issue := range plan.Stuck {
+				result.Stuck = append(result.Stuck, toPlanIssueJSON(issue, planned))
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(result)
+			}
+
+			if len(tasks) == 0 {
+				fmt.Fprintf(app.Out, "Nothing to plan under %s: %s\n", epic.ID, epic.Title)
+				return nil
+			}
+			fmt.Fprintf(app.Out, "Plan for %s: %s (%d issue(s), %d step(s))\n", epic.ID, epic.Title, len(tasks), len(result.Steps))
+			n := 0
+			for _, step := range result.Steps {
+				fmt.Fprintf(app.Out, "\nStep %d", step.Step)
+				if len(step.Issues) > 1 {
+					fmt.Fprintf(app.Out, " (%d in parallel)", len(step.Issues))
+				}
+				fmt.Fprintln(app.Out, ":")
+				for _, issue := range step.Issues {
+					n++
+					fmt.Fprintf(app.Out, "  %2d. %s\n", n, formatPlanIssue(issue))
+				}
+			}
+			if len(result.Cycles) > 0 {
+				fmt.Fprintln(app.Out, "\nCycles (these block each other and cannot start):")
+				for _, cycle := range result.Cycles {
+					ids := make([]string, len(cycle))
+					for i, issue := range cycle {
+						ids[i] = issue.ID
+					}
+					fmt.Fprintf(app.Out, "  ✗ %s\n", strings.Join(ids, ", "))
+					for _, issue := range cycle {
+						fmt.Fprintf(app.Out, "      %s\n", formatPlanIssue(issue))
+					}
+				}
+			}
+			if len(result.Stuck) > 0 {
+				fmt.Fprintln(app.Out, "\nWaiting on a cycle:")
+				for _, issue := range result.Stuck {
+					fmt.Fprintf(app.Out, "      %s\n", formatPlanIssue(issue))
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Include closed issues")
+
+	return cmd
+}
+
+// formatPlanIssue formats a plan line: ID, priority, title, status,
+// assignee and the blockers it waits on.
+func formatPlanIssue(issue PlanIssueJSON) string {
+	line := fmt.Sprintf("%s [P%d] %s (%s", issue.ID, issue.Priority, issue.Title, issue.Status)
+	if issue.Assignee != "" {
+		line += ", @" + issue.Assignee
+	}
+	line += ")"
+	if len(issue.BlockedBy) > 0 {
+		line += " ← after " + strings.Join(issue.BlockedBy, ", ")
+	}
+	return line
+}
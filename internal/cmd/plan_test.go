@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestPlanCommand(t *testing.T) {
+	app, rs := setupTestApp(t)
+	ctx := context.Background()
+	epic, err := rs.Create(ctx, &issuestorage.Issue{Title: "Launch", Type: issuestorage.TypeEpic})
+	if err != nil {
+		t.Fatal(err)
+	}
+	create := func(title string, priority issuestorage.Priority, blockers ...string) string {
+		t.Helper()
+		id, err := rs.Create(ctx, &issuestorage.Issue{Title: title, Priority: priority})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := rs.AddDependency(ctx, id, epic, issuestorage.DepTypeParentChild); err != nil {
+			t.Fatal(err)
+		}
+		for _, b := range blockers {
+			if err := rs.AddDependency(ctx, id, b, issuestorage.DepTypeBlocks); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return id
+	}
+	design := create("Design", issuestorage.PriorityHigh)
+	docs := create("Docs", issuestorage.PriorityLow)
+	build := create("Build", issuestorage.PriorityMedium, design)
+	done := create("Spike", issuestorage.PriorityMedium)
+	if err := rs.Modify(ctx, done, func(i *issuestorage.Issue) error {
+		i.Status = issuestorage.StatusClosed
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	out := app.Out.(*bytes.Buffer)
+	cmd := newPlanCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{epic})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	text := out.String()
+	for _, want := range []string{
+		"(3 issue(s), 2 step(s))",
+		"Step 1 (2 in parallel):\n   1. " + design + " [P1] Design (open)\n   2. " + docs,
+		"Step 2:\n   3. " + build + " [P2] Build (open) ← after " + design,
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("plan missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, done) {
+		t.Errorf("closed %s should be left out without --all:\n%s", done, text)
+	}
+
+	// A cycle can't be added through bd dep, but can arrive by import or merge.
+	loopA := create("Loop A", issuestorage.PriorityMedium)
+	loopB := create("Loop B", issuestorage.PriorityMedium, loopA)
+	waiting := create("Waiting", issuestorage.PriorityMedium, loopB)
+	if err := rs.Modify(ctx, loopA, func(i *issuestorage.Issue) error {
+		i.Dependencies = append(i.Dependencies, issuestorage.Dependency{ID: loopB, Type: issuestorage.DepTypeBlocks})
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	out.Reset()
+	app.JSON = true
+	cmd = newPlanCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{epic, "--all"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	var result PlanJSON
+	if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Total != 7 || len(result.Steps) != 2 || len(result.Steps[0].Issues) != 3 {
+		t.Errorf("plan = %+v", result)
+	}
+	if len(result.Cycles) != 1 || len(result.Cycles[0]) != 2 {
+		t.Errorf("cycles = %+v", result.Cycles)
+	}
+	if len(result.Stuck) != 1 || result.Stuck[0].ID != waiting || result.Stuck[0].BlockedBy[0] != loopB {
+		t.Errorf("stuck = %+v", result.Stuck)
+	}
+}
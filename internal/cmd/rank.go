@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// newRankCmd creates the rank command.
+func newRankCmd(provider *AppProvider) *cobra.Command {
+	var (
+		before string
+		after  string
+		top    bool
+		bottom bool
+		clear  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rank <issue-id>",
+		Short: "Set an issue's position in the manually ordered backlog",
+		Long: `Set an issue's position in the manually ordered backlog.
+
+Ranks are ordering keys stored on each issue. Use --sort rank with bd list
+or bd ready to show ranked issues first, in rank order, followed by
+unranked issues in their usual order. Only open issues are considered
+when placing an issue; an unranked --before/--after target is first
+ranked at the bottom.
+
+Exactly one of --before, --after, --top, --bottom or --clear is required.
+
+Examples:
+  bd rank bd-a1b2 --top
+  bd rank bd-c3d4 --after bd-a1b2
+  bd rank bd-e5f6 --before bd-c3d4
+  bd rank bd-e5f6 --clear
+  bd list --sort rank`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			modes := 0
+			for _, set := range []bool{before != "", after != "", top, bottom, clear} {
+				if set {
+					modes++
+				}
+			}
+			if modes != 1 {
+				return fmt.Errorf("exactly one of --before, --after, --top, --bottom or --clear is required")
+			}
+
+			issue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+
+			var rank string
+			if !clear {
+				ranked, err := rankedIssues(ctx, app, issue.ID)
+				if err != nil {
+					return err
+				}
+				switch {
+				case top:
+					first := ""
+					if len(ranked) > 0 {
+						first = ranked[0].Rank
+					}
+					rank, err = issuestorage.RankBetween("", first)
+				case bottom:
+					rank, err = rankAfterLast(ranked)
+				default:
+					targetID := before
+					if targetID == "" {
+						targetID = after
+					}
+					rank, err = rankNextTo(ctx, app, ranked, issue.ID, targetID, before != "")
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := app.Storage.Modify(ctx, issue.ID, func(i *issuestorage.Issue) error {
+				i.Rank = rank
+				return nil
+			}); err != nil {
+				return fmt.Errorf("updating rank: %w", err)
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(map[string]string{"id": issue.ID, "rank": rank})
+			}
+			if clear {
+				fmt.Fprintf(app.Out, "%s Cleared rank of %s\n", app.SuccessColor("✓"), issue.ID)
+			} else {
+				fmt.Fprintf(app.Out, "%s Ranked %s\n", app.SuccessColor("✓"), issue.ID)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&before, "before", "", "Place the issue immediately before this issue")
+	cmd.Flags().StringVar(&after, "after", "", "Place the issue immediately after this issue")
+	cmd.Flags().BoolVar(&top, "top", false, "Place the issue first")
+	cmd.Flags().BoolVar(&bottom, "bottom", false, "Place the issue after all ranked issues")
+	cmd.Flags().BoolVar(&clear, "clear", false, "Remove the issue's rank")
+
+	return cmd
+}
+
+// rankedIssues returns the ranked open issues other than exclude, in rank
+// order.
+func rankedIssues(ctx context.Context, app *App, exclude string) ([]*issuestorage.Issue, error) {
+	issues, err := app.Storage.List(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("listing issues: %w", err)
+	}
+	var ranked []*issuestorage.Issue
+	for _, issue := range issues {
+		if issue.Rank != "" && issue.ID != exclude {
+			ranked = append(ranked, issue)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Rank != ranked[j].Rank {
+			return ranked[i].Rank < ranked[j].Rank
+		}
+		return ranked[i].ID < ranked[j].ID
+	})
+	return ranked, nil
+}
+
+// rankAfterLast returns a rank after every issue in ranked.
+func rankAfterLast(ranked []*issuestorage.Issue) (string, error) {
+	last := ""
+	if len(ranked) > 0 {
+		last = ranked[len(ranked)-1].Rank
+	}
+	return issuestorage.RankBetween(last, "")
+}
+
+// rankNextTo returns a rank immediately before (or after) targetID among
+// ranked. An unranked target is first ranked at the bottom.
+func rankNextTo(ctx context.Context, app *App, ranked []*issuestorage.Issue, id, targetID string, placeBefore bool) (string, error) {
+	target, err := resolveIssue(app.Storage, ctx, targetID)
+	if err != nil {
+		return "", fmt.Errorf("resolving issue %s: %w", targetID, err)
+	}
+	if target.ID == id {
+		return "", fmt.Errorf("cannot rank %s relative to itself", id)
+	}
+
+	pos := -1
+	for i, issue := range ranked {
+		if issue.ID == target.ID {
+			pos = i
+			break
+		}
+	}
+	if pos < 0 {
+		rank, err := rankAfterLast(ranked)
+		if err != nil {
+			return "", err
+		}
+		if err := app.Storage.Modify(ctx, target.ID, func(i *issuestorage.Issue) error {
+			i.Rank = rank
+			return nil
+		}); err != nil {
+			return "", fmt.Errorf("ranking %s: %w", target.ID, err)
+		}
+		target.Rank = rank
+		ranked = append(ranked, target)
+		pos = len(ranked) - 1
+	}
+
+	lo, hi := target.Rank, ""
+	if placeBefore {
+		lo, hi = "", target.Rank
+		if pos > 0 {
+			lo = ranked[pos-1].Rank
+		}
+	} else if pos+1 < len(ranked) {
+		hi = ranked[pos+1].Rank
+	}
+	rank, err := issuestorage.RankBetween(lo, hi)
+	if err != nil {
+		return "", fmt.Errorf("%w (issues share a rank; re-rank one of them with --top or --bottom first)", err)
+	}
+	return rank, nil
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// rankOrder returns the titles of open issues sorted by --sort rank.
+func rankOrder(t *testing.T, app *App) string {
+	t.Helper()
+	issues, err := app.Storage.List(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sortIssues(issues, "created", false)
+	if err := sortIssues(issues, "rank", false); err != nil {
+		t.Fatal(err)
+	}
+	titles := make([]string, len(issues))
+	for i, issue := range issues {
+		titles[i] = issue.Title
+	}
+	return strings.Join(titles, ",")
+}
+
+func TestRankCmd(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	ids := map[string]string{}
+	for _, title := range []string{"a", "b", "c", "d"} {
+		id, err := store.Create(ctx, &issuestorage.Issue{Title: title})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[title] = id
+	}
+	rank := func(args ...string) error {
+		cmd := newRankCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		return cmd.Execute()
+	}
+
+	steps := []struct {
+		args []string
+		want string
+	}{
+		{[]string{ids["c"], "--top"}, "c,a,b,d"},
+		{[]string{ids["a"], "--bottom"}, "c,a,b,d"},
+		{[]string{ids["d"], "--before", ids["a"]}, "c,d,a,b"},
+		{[]string{ids["b"], "--after", ids["c"]}, "c,b,d,a"},
+		{[]string{ids["a"], "--top"}, "a,c,b,d"},
+		{[]string{ids["b"], "--clear"}, "a,c,d,b"},
+	}
+	for _, step := range steps {
+		if err := rank(step.args...); err != nil {
+			t.Fatalf("rank %v: %v", step.args, err)
+		}
+		if got := rankOrder(t, app); got != step.want {
+			t.Errorf("after rank %v: order = %s, want %s", step.args, got, step.want)
+		}
+	}
+}
+
+func TestRankCmd_UnrankedTarget(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	a, _ := store.Create(ctx, &issuestorage.Issue{Title: "a"})
+	b, _ := store.Create(ctx, &issuestorage.Issue{Title: "b"})
+
+	cmd := newRankCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{b, "--before", a})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("rank: %v", err)
+	}
+	target, _ := store.Get(ctx, a)
+	if target.Rank == "" {
+		t.Error("unranked target should have been ranked")
+	}
+	if got := rankOrder(t, app); got != "b,a" {
+		t.Errorf("order = %s, want b,a", got)
+	}
+}
+
+func TestRankCmd_Errors(t *testing.T) {
+	app, store := setupTestApp(t)
+	a, _ := store.Create(context.Background(), &issuestorage.Issue{Title: "a"})
+	for _, args := range [][]string{
+		{a},
+		{a, "--top", "--bottom"},
+		{a, "--before", a},
+	} {
+		cmd := newRankCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err == nil {
+			t.Errorf("rank %v: expected error", args)
+		}
+	}
+}
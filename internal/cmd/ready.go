@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 
-	"beads-lite/internal/graph"
 	"beads-lite/internal/issuestorage"
 
 	"github.com/spf13/cobra"
@@ -13,11 +12,15 @@ import (
 // newReadyCmd creates the ready command.
 func newReadyCmd(provider *AppProvider) *cobra.Command {
 	var (
-		priority string
-		molID    string
-		molType  string
-		assignee string
-		limit    int
+		priority    string
+		molID       string
+		molType     string
+		assignee    string
+		limit       int
+		sortKey     string
+		reverse     bool
+		noContext   bool
+		contextName string
 	)
 
 	cmd := &cobra.Command{
@@ -41,11 +44,13 @@ are shown, along with parallel group info.`,
 
 			ctx := cmd.Context()
 
-			// List all open issues
-			filter := &issuestorage.ListFilter{
-				Statuses: []issuestorage.Status{issuestorage.StatusOpen},
+			scope, err := activeContextFilter(app, noContext, contextName)
+			if err != nil {
+				return err
 			}
 
+			filter := &issuestorage.ListFilter{}
+
 			// Apply priority filter if specified
 			if priority != "" {
 				p, err := parsePriority(priority)
@@ -69,52 +74,25 @@ are shown, along with parallel group info.`,
 				filter.MolType = &mt
 			}
 
-			// When --mol is specified, scope to children of that molecule
+			// When --mol is specified, scope to children of that molecule;
+			// otherwise exclude molecule steps (issues with a parent) to keep
+			// the output focused on top-level work.
+			root := ""
 			if molID != "" {
 				filter.Parent = &molID
+			} else {
+				filter.Parent = &root
 			}
 
-			issues, err := app.Storage.List(ctx, filter)
-			if err != nil {
-				return fmt.Errorf("listing issues: %w", err)
-			}
-
-			// Get closed issues to check dependency resolution
-			closedFilter := &issuestorage.ListFilter{
-				Statuses: []issuestorage.Status{issuestorage.StatusClosed},
-			}
-			closedIssues, err := app.Storage.List(ctx, closedFilter)
+			ready, err := app.Storage.ListReady(ctx, filter, cascadeEnabled(app))
 			if err != nil {
-				return fmt.Errorf("listing closed issues: %w", err)
-			}
-			closedSet := make(map[string]bool)
-			for _, issue := range closedIssues {
-				closedSet[issue.ID] = true
+				return err
 			}
 
-			// Read cascade config flag
-			cascade := cascadeEnabled(app)
+			ready = scopeIssues(ready, scope)
 
-			// Filter to only ready issues, excluding ephemeral and
-			// (when not in --mol mode) molecule steps.
-			var ready []*issuestorage.Issue
-			for _, issue := range issues {
-				// Ephemeral issues are never ready (hardcoded exclusion).
-				if issue.Ephemeral {
-					continue
-				}
-				// Without --mol, exclude molecule steps (issues with a parent)
-				// to keep the output focused on top-level work.
-				if molID == "" && issue.Parent != "" {
-					continue
-				}
-				blocked, err := graph.IsEffectivelyBlocked(ctx, app.Storage, issue, closedSet, cascade)
-				if err != nil {
-					return fmt.Errorf("checking blocked status for %s: %w", issue.ID, err)
-				}
-				if !blocked {
-					ready = append(ready, issue)
-				}
+			if err := sortIssues(ready, sortKey, reverse); err != nil {
+				return err
 			}
 
 			// Apply limit
@@ -161,6 +139,8 @@ are shown, along with parallel group info.`,
 	cmd.Flags().StringVar(&molType, "mol-type", "", "Filter by molecule type (swarm, patrol, work)")
 	cmd.Flags().StringVar(&assignee, "assignee", "", "Filter by assignee")
 	cmd.Flags().IntVar(&limit, "limit", 0, "Maximum number of issues to show")
+	addSortFlags(cmd, &sortKey, &reverse, "")
+	addContextFlags(cmd, &noContext, &contextName)
 
 	return cmd
 }
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"beads-lite/internal/config"
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/issuestorage/filesystem"
+	"beads-lite/internal/issuestorage/objectstore"
+	"beads-lite/internal/issuestorage/replicated"
+
+	"github.com/spf13/cobra"
+)
+
+// openMirror opens a storage.mirrors target: an s3://bucket/prefix URL,
+// which takes its endpoint, region and credentials from the storage.s3
+// settings, or the path of another .beads directory.
+func openMirror(target, prefix string, cfg config.Store) (issuestorage.IssueStore, error) {
+	if strings.HasPrefix(target, "s3://") {
+		u, err := url.Parse(target)
+		if err != nil {
+			return nil, err
+		}
+		keyPrefix := strings.TrimPrefix(u.Path, "/")
+		if keyPrefix != "" && !strings.HasSuffix(keyPrefix, "/") {
+			keyPrefix += "/"
+		}
+		s3cfg, err := objectstore.S3ConfigFromEnv(func(key string) (string, bool) {
+			switch key {
+			case objectstore.BucketConfigKey:
+				return u.Host, true
+			case objectstore.PrefixConfigKey:
+				return keyPrefix, true
+			}
+			return cfg.Get(key)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return objectstore.New(objectstore.NewS3Bucket(s3cfg), prefix), nil
+	}
+	store := filesystem.New(target, prefix)
+	if err := store.Init(context.Background()); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// ReconcileJSON is the JSON output format for one bd reconcile difference.
+type ReconcileJSON struct {
+	Mirror string `json:"mirror"`
+	ID     string `json:"id"`
+	Kind   string `json:"kind"`
+}
+
+// newReconcileCmd creates the reconcile command.
+func newReconcileCmd(provider *AppProvider) *cobra.Command {
+	var fix bool
+
+	cmd := &cobra.Command{
+		Use:   "reconcile",
+		Short: "Compare storage mirrors with the primary store",
+		Long: `Compare every mirror configured in storage.mirrors with the primary
+store and report issues that are missing, extra or different.
+
+Writes are mirrored in the background, so a mirror can fall behind if it
+was unreachable. With --fix, each difference is repaired by copying the
+primary's copy of the issue to the mirror.
+
+Examples:
+  bd config set storage.mirrors s3://team-tracker/bd
+  bd reconcile
+  bd reconcile --fix`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			if app.Replicas == nil {
+				return fmt.Errorf("no mirrors configured (set %s)", replicated.MirrorsConfigKey)
+			}
+
+			diffs, err := app.Replicas.Reconcile(cmd.Context(), fix)
+			if app.JSON {
+				out := make([]ReconcileJSON, 0, len(diffs))
+				for _, d := range diffs {
+					out = append(out, ReconcileJSON{Mirror: d.Mirror, ID: d.ID, Kind: d.Kind})
+				}
+				if encErr := json.NewEncoder(app.Out).Encode(out); encErr != nil {
+					return encErr
+				}
+				return err
+			}
+
+			for _, d := range diffs {
+				fmt.Fprintf(app.Out, "  %s\n", d)
+			}
+			if err != nil {
+				return err
+			}
+			switch {
+			case len(diffs) == 0:
+				fmt.Fprintf(app.Out, "%s Mirrors are in sync\n", app.SuccessColor("✓"))
+			case fix:
+				fmt.Fprintf(app.Out, "%s Repaired %d difference(s)\n", app.SuccessColor("✓"), len(diffs))
+			default:
+				fmt.Fprintf(app.Out, "%s %d difference(s). Run with --fix to repair.\n", app.WarnColor("⚠"), len(diffs))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&fix, "fix", false, "Copy the primary's issues over differing mirror copies")
+
+	return cmd
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issueservice"
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/issuestorage/filesystem"
+	"beads-lite/internal/issuestorage/replicated"
+)
+
+func setupMirroredTestApp(t *testing.T) (*App, issuestorage.IssueStore, issuestorage.IssueStore) {
+	t.Helper()
+	ctx := context.Background()
+	primary := filesystem.New(t.TempDir(), "bd-")
+	mirrorDir := t.TempDir()
+	mirror, err := openMirror(mirrorDir, "bd-", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replicas := replicated.New(primary, replicated.Secondary{Name: mirrorDir, Store: mirror})
+	if err := replicas.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { replicas.Close() })
+	return &App{
+		Storage:  issueservice.New(nil, replicas),
+		Replicas: replicas,
+		Out:      &bytes.Buffer{},
+		Err:      &bytes.Buffer{},
+	}, primary, mirror
+}
+
+func TestReconcile_NoMirrors(t *testing.T) {
+	app, _ := setupTestApp(t)
+	cmd := newReconcileCmd(NewTestProvider(app))
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "storage.mirrors") {
+		t.Errorf("expected no-mirrors error, got %v", err)
+	}
+}
+
+func TestReconcile_ReportsAndFixes(t *testing.T) {
+	app, primary, mirror := setupMirroredTestApp(t)
+	ctx := context.Background()
+
+	id, err := primary.Create(ctx, &issuestorage.Issue{Title: "Written while mirror was away", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app.JSON = true
+	cmd := newReconcileCmd(NewTestProvider(app))
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	var diffs []ReconcileJSON
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &diffs); err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != 1 || diffs[0].ID != id || diffs[0].Kind != "missing" {
+		t.Fatalf("diffs = %+v, want %s missing", diffs, id)
+	}
+
+	app.JSON = false
+	app.Out = &bytes.Buffer{}
+	cmd = newReconcileCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--fix"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(app.Out.(*bytes.Buffer).String(), "Repaired 1") {
+		t.Errorf("output = %q", app.Out.(*bytes.Buffer).String())
+	}
+	if _, err := mirror.Get(ctx, id); err != nil {
+		t.Errorf("issue not repaired on mirror: %v", err)
+	}
+}
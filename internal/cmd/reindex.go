@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// ReindexJSON is the JSON output of bd reindex.
+type ReindexJSON struct {
+	Indexed int `json:"indexed"`
+}
+
+// newReindexCmd creates the reindex command.
+func newReindexCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reindex",
+		Short: "Rebuild the search index",
+		Long: `Rebuild the full-text index bd search uses, reading every issue again.
+
+The index in .beads/index/ is kept up to date as issues change, including
+changes from git pulls and hand edits, so this is rarely needed. It is
+local and ignored by git; deleting it is safe.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			if app.SearchIndex == nil {
+				return errors.New("no search index to rebuild: bd search only keeps one for the filesystem backend")
+			}
+
+			release, err := acquireMaintenance(cmd.Context(), app, "reindex")
+			if err != nil {
+				return err
+			}
+			defer release()
+			n, err := app.SearchIndex.Rebuild(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("rebuilding search index: %w", err)
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(ReindexJSON{Indexed: n})
+			}
+			fmt.Fprintf(app.Out, "%s Indexed %d issues\n", app.SuccessColor("✓"), n)
+			return nil
+		},
+	}
+
+	return cmd
+}
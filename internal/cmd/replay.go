@@ -0,0 +1,432 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"beads-lite/internal/issueservice"
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/issuestorage/filesystem"
+	"beads-lite/internal/routing"
+
+	"github.com/spf13/cobra"
+)
+
+// replayUnknownTitle is the title of a replayed issue whose title the event
+// log never recorded.
+const replayUnknownTitle = "(title not in event log)"
+
+// ReplayJSON is the JSON output of bd replay.
+type ReplayJSON struct {
+	Into     string          `json:"into"`
+	Until    string          `json:"until,omitempty"`
+	Events   int             `json:"events"`  // events applied
+	Skipped  int             `json:"skipped"` // events after --until
+	Issues   int             `json:"issues"`
+	Complete bool            `json:"complete"` // no gaps
+	Gaps     []ReplayGapJSON `json:"gaps,omitempty"`
+}
+
+// ReplayGapJSON lists what the event log could not restore of one issue.
+type ReplayGapJSON struct {
+	IssueID string   `json:"issue_id"`
+	Missing []string `json:"missing"`
+}
+
+// readEventLog reads history events written by bd activity --json: one
+// JSON array, or one event per line as printed by --follow.
+func readEventLog(r io.Reader) ([]HistoryEventJSON, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		var events []HistoryEventJSON
+		if err := json.Unmarshal(trimmed, &events); err != nil {
+			return nil, err
+		}
+		return events, nil
+	}
+	var events []HistoryEventJSON
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var e HistoryEventJSON
+		if err := json.Unmarshal(text, &e); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if e.IssueID == "" || e.Event == "" {
+			return nil, fmt.Errorf("line %d: event has no issue_id or event", line)
+		}
+		events = append(events, e)
+	}
+	return events, scanner.Err()
+}
+
+// replayState rebuilds issues from their history events.
+type replayState struct {
+	issues map[string]*issuestorage.Issue
+	order  []string
+	gaps   map[string][]string
+}
+
+func newReplayState() *replayState {
+	return &replayState{issues: map[string]*issuestorage.Issue{}, gaps: map[string][]string{}}
+}
+
+// gap records that part of issue id could not be restored.
+func (s *replayState) gap(id, what string) {
+	if !slices.Contains(s.gaps[id], what) {
+		s.gaps[id] = append(s.gaps[id], what)
+	}
+}
+
+// issue returns the issue id, creating it the first time it is seen. An
+// issue first seen other than by its creation is missing its start.
+func (s *replayState) issue(id string, h issuestorage.HistoryEntry) *issuestorage.Issue {
+	if issue, ok := s.issues[id]; ok {
+		return issue
+	}
+	issue := &issuestorage.Issue{
+		ID:        id,
+		Title:     replayUnknownTitle,
+		Status:    issuestorage.StatusOpen,
+		Priority:  issuestorage.PriorityMedium,
+		Type:      issuestorage.TypeTask,
+		CreatedAt: h.At,
+		CreatedBy: h.Actor,
+	}
+	if h.Event != eventCreated {
+		s.gap(id, "creation")
+	}
+	s.issues[id] = issue
+	s.order = append(s.order, id)
+	return issue
+}
+
+// apply applies one event to the issue it belongs to.
+func (s *replayState) apply(id string, h issuestorage.HistoryEntry) error {
+	issue := s.issue(id, h)
+	issue.UpdatedAt = h.At
+	if h.Event == eventCreated {
+		return nil
+	}
+	issue.History = append(issue.History, h)
+
+	switch h.Event {
+	case issuestorage.EventStatus, issuestorage.EventReopened:
+		issue.Status = issuestorage.Status(h.New)
+		if issue.Status == issuestorage.StatusClosed {
+			at := h.At
+			issue.ClosedAt = &at
+		} else {
+			issue.ClosedAt = nil
+		}
+		if h.Event == issuestorage.EventReopened {
+			issue.ReopenCount++
+		}
+	case issuestorage.EventAssigned, issuestorage.EventAutoAssigned:
+		issue.Assignee = h.New
+	case issuestorage.EventLabeled:
+		if !slices.Contains(issue.Labels, h.New) {
+			issue.Labels = append(issue.Labels, h.New)
+		}
+	case issuestorage.EventUnlabeled, issuestorage.EventLabelExpired:
+		issue.Labels = slices.DeleteFunc(issue.Labels, func(l string) bool { return l == h.Old })
+	case issuestorage.EventTimeLogged:
+		n, err := strconv.Atoi(h.New)
+		if err != nil {
+			return fmt.Errorf("time_logged: invalid minutes %q", h.New)
+		}
+		issue.TimeSpent += n
+	case issuestorage.EventCommented:
+		s.gap(id, "comment text")
+	case issuestorage.EventDepAdded, issuestorage.EventDepRemoved:
+		s.applyDep(issue, h)
+	case issuestorage.EventUpdated, issuestorage.EventDoctorFix:
+		return s.applyField(issue, h)
+	default:
+		s.gap(id, "event "+h.Event)
+	}
+	return nil
+}
+
+// applyDep adds or removes a dependency on both of its ends.
+func (s *replayState) applyDep(issue *issuestorage.Issue, h issuestorage.HistoryEntry) {
+	depType := issuestorage.DependencyType(h.Field)
+	if h.Event == issuestorage.EventDepAdded {
+		if !issue.HasDependency(h.New) {
+			issue.Dependencies = append(issue.Dependencies, issuestorage.Dependency{ID: h.New, Type: depType})
+		}
+		// The other end may live in another repository.
+		if target, ok := s.issues[h.New]; ok && !target.HasDependent(issue.ID) {
+			target.Dependents = append(target.Dependents, issuestorage.Dependency{ID: issue.ID, Type: depType})
+		}
+		if depType == issuestorage.DepTypeParentChild {
+			issue.Parent = h.New
+		}
+		return
+	}
+	issue.Dependencies = slices.DeleteFunc(issue.Dependencies, func(d issuestorage.Dependency) bool { return d.ID == h.Old })
+	if target, ok := s.issues[h.Old]; ok {
+		target.Dependents = slices.DeleteFunc(target.Dependents, func(d issuestorage.Dependency) bool { return d.ID == issue.ID })
+	}
+	if depType == issuestorage.DepTypeParentChild && issue.Parent == h.Old {
+		issue.Parent = ""
+	}
+}
+
+// applyField sets the field an updated or doctor_fix event changed.
+func (s *replayState) applyField(issue *issuestorage.Issue, h issuestorage.HistoryEntry) error {
+	optionalTime := func() (*time.Time, error) {
+		if h.New == "" {
+			return nil, nil
+		}
+		t, err := time.Parse(time.RFC3339, h.New)
+		return &t, err
+	}
+	optionalInt := func() (int, error) {
+		if h.New == "" {
+			return 0, nil
+		}
+		return strconv.Atoi(h.New)
+	}
+
+	var err error
+	switch h.Field {
+	case "title":
+		issue.Title = h.New
+	case "description":
+		// Text fields are recorded without their values.
+		s.gap(issue.ID, "description")
+	case "priority":
+		var p int
+		p, err = strconv.Atoi(h.New)
+		issue.Priority = issuestorage.Priority(p)
+	case "severity":
+		issue.Severity = issuestorage.Severity(h.New)
+	case "type":
+		issue.Type = issuestorage.IssueType(h.New)
+	case "owner":
+		issue.Owner = h.New
+	case "reporter":
+		issue.Reporter = h.New
+	case "reviewer":
+		issue.Reviewer = h.New
+	case "due_at":
+		issue.DueAt, err = optionalTime()
+	case "defer_until":
+		issue.DeferUntil, err = optionalTime()
+	case "estimate_minutes":
+		issue.Estimate, err = optionalInt()
+	case "time_spent_minutes":
+		issue.TimeSpent, err = optionalInt()
+	case "milestone":
+		issue.Milestone = h.New
+	case "sprint":
+		issue.Sprint = h.New
+	case "decision_state":
+		issue.DecisionState = issuestorage.DecisionState(h.New)
+	case "created_at", "updated_at":
+		var t *time.Time
+		if t, err = optionalTime(); err == nil && t != nil {
+			if h.Field == "created_at" {
+				issue.CreatedAt = *t
+			} else {
+				issue.UpdatedAt = *t
+			}
+		}
+	default:
+		s.gap(issue.ID, "field "+h.Field)
+	}
+	if err != nil {
+		return fmt.Errorf("%s %s: invalid value %q", h.Event, h.Field, h.New)
+	}
+	return nil
+}
+
+// result returns the replayed issues in the order they were first seen,
+// noting those whose title never appeared in the log.
+func (s *replayState) result() ([]*issuestorage.Issue, []ReplayGapJSON) {
+	issues := make([]*issuestorage.Issue, 0, len(s.order))
+	var gaps []ReplayGapJSON
+	for _, id := range s.order {
+		issue := s.issues[id]
+		if issue.Title == replayUnknownTitle {
+			s.gaps[id] = append([]string{"title"}, s.gaps[id]...)
+		}
+		issues = append(issues, issue)
+		if len(s.gaps[id]) > 0 {
+			gaps = append(gaps, ReplayGapJSON{IssueID: id, Missing: s.gaps[id]})
+		}
+	}
+	return issues, gaps
+}
+
+// replayPrefix returns the ID prefix most of issues share, without its
+// trailing dash.
+func replayPrefix(issues []*issuestorage.Issue) string {
+	counts := map[string]int{}
+	best := ""
+	for _, issue := range issues {
+		p := strings.TrimRight(routing.ExtractPrefix(issue.ID), "-")
+		counts[p]++
+		if p != "" && counts[p] > counts[best] {
+			best = p
+		}
+	}
+	return best
+}
+
+// newReplayCmd creates the replay command.
+// Note: replay doesn't use the provider's app since it builds a new store.
+func newReplayCmd(provider *AppProvider) *cobra.Command {
+	var (
+		into  string
+		until string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "replay <events.ndjson>",
+		Short: "Rebuild a store from an event log",
+		Long: `Rebuild issues purely from their history events, into a new repository.
+
+The event log is the output of bd activity --json (a JSON array), or of
+bd activity --follow --json (one event per line), with "-" reading stdin.
+Events are applied oldest first to fresh issues, and the result is written
+to a new repository in <dir>/.beads.
+
+With --until only events up to that point are applied, recovering the
+state as of then. It takes a date or time, or a duration back from now
+(3d, 2w).
+
+Some of an issue is never in its events: its description and comment
+texts, and anything set when it was created other than by a later edit.
+Replay reports what each issue is missing, so it also checks how complete
+an event log is; issues whose title was never recorded are titled
+"` + replayUnknownTitle + `".
+
+Examples:
+  bd activity --since 2000-01-01 --json > events.json
+  bd replay events.json --into /tmp/restore
+  bd replay events.ndjson --into /tmp/tuesday --until 2026-10-06`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := provider.Out
+			if out == nil {
+				out = os.Stdout
+			}
+			if into == "" {
+				return fmt.Errorf("--into is required")
+			}
+			var cutoff time.Time
+			if until != "" {
+				if d, err := parseDuration(until); err == nil {
+					// Measured back from the repository's clock when run
+					// in one, so it follows --deterministic.
+					now := time.Now()
+					if app, err := provider.Get(); err == nil {
+						now = app.Now()
+					}
+					cutoff = now.Add(-d)
+				} else if cutoff, err = parseListCreatedTime(until, true); err != nil {
+					return fmt.Errorf("invalid --until value %q: use a date, a time or a duration such as 3d", until)
+				}
+			}
+
+			r, done, err := openImportSource(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			defer done()
+			events, err := readEventLog(r)
+			if err != nil {
+				return fmt.Errorf("parsing %s: %w", args[0], err)
+			}
+			entries := make([]issuestorage.HistoryEntry, len(events))
+			for i, e := range events {
+				at, err := time.Parse(time.RFC3339Nano, e.At)
+				if err != nil {
+					return fmt.Errorf("event %d: invalid time %q", i+1, e.At)
+				}
+				entries[i] = issuestorage.HistoryEntry{At: at, Actor: e.Actor, Event: e.Event, Field: e.Field, Old: e.Old, New: e.New, Note: e.Note}
+			}
+			idx := make([]int, len(events))
+			for i := range idx {
+				idx[i] = i
+			}
+			sort.SliceStable(idx, func(a, b int) bool { return entries[idx[a]].At.Before(entries[idx[b]].At) })
+
+			state := newReplayState()
+			result := ReplayJSON{}
+			for _, i := range idx {
+				if !cutoff.IsZero() && entries[i].At.After(cutoff) {
+					result.Skipped++
+					continue
+				}
+				if err := state.apply(events[i].IssueID, entries[i]); err != nil {
+					return fmt.Errorf("%s: %w", events[i].IssueID, err)
+				}
+				result.Events++
+			}
+			issues, gaps := state.result()
+			if len(issues) == 0 {
+				return fmt.Errorf("no events to replay in %s", args[0])
+			}
+
+			prefix := replayPrefix(issues)
+			beadsPath, err := initRepository(io.Discard, into, false, prefix)
+			if err != nil {
+				return err
+			}
+			store := issueservice.New(nil, filesystem.New(beadsPath, prefix))
+			for _, issue := range issues {
+				if _, err := store.Restore(cmd.Context(), issue); err != nil {
+					return fmt.Errorf("writing %s: %w", issue.ID, err)
+				}
+			}
+
+			result.Into = beadsPath
+			result.Issues = len(issues)
+			result.Complete = len(gaps) == 0
+			result.Gaps = gaps
+			if !cutoff.IsZero() {
+				result.Until = formatTime(cutoff)
+			}
+			if provider.JSONOutput {
+				return json.NewEncoder(out).Encode(result)
+			}
+			fmt.Fprintf(out, "Replayed %d events into %d issues at %s\n", result.Events, result.Issues, beadsPath)
+			if result.Skipped > 0 {
+				fmt.Fprintf(out, "Skipped %d events after %s\n", result.Skipped, cutoff.Format("2006-01-02 15:04"))
+			}
+			if result.Complete {
+				fmt.Fprintln(out, "The event log restored every issue completely.")
+				return nil
+			}
+			fmt.Fprintf(out, "\nNot in the event log (%d issues):\n", len(gaps))
+			for _, g := range gaps {
+				fmt.Fprintf(out, "  %s: %s\n", g.IssueID, strings.Join(g.Missing, ", "))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&into, "into", "", "Directory to create the new repository in (required)")
+	cmd.Flags().StringVar(&until, "until", "", "Only apply events up to this date, time or duration ago")
+
+	return cmd
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/clock"
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/issuestorage/filesystem"
+)
+
+func TestReplay(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	epic, err := store.Create(ctx, &issuestorage.Issue{Title: "Epic", Type: issuestorage.TypeEpic, CreatedAt: time.Now().Add(-time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := store.Create(ctx, &issuestorage.Issue{Title: "Task"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, change := range []func(*issuestorage.Issue){
+		func(i *issuestorage.Issue) { i.Title = "Login task"; i.Priority = issuestorage.PriorityHigh },
+		func(i *issuestorage.Issue) { i.Labels = append(i.Labels, "auth"); i.Assignee = "alice" },
+		func(i *issuestorage.Issue) { i.Description = "Secret details" },
+		func(i *issuestorage.Issue) { i.Status = issuestorage.StatusClosed },
+	} {
+		if err := store.Modify(ctx, task, func(i *issuestorage.Issue) error { change(i); return nil }); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.AddDependency(ctx, task, epic, issuestorage.DepTypeParentChild); err != nil {
+		t.Fatal(err)
+	}
+
+	var log bytes.Buffer
+	app.Out, app.JSON = &log, true
+	activity := newActivityCmd(NewTestProvider(app))
+	activity.SetArgs([]string{"--since", "2000-01-01"})
+	if err := activity.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	events := filepath.Join(t.TempDir(), "events.json")
+	if err := os.WriteFile(events, log.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	into := t.TempDir()
+	out := &bytes.Buffer{}
+	replay := newReplayCmd(&AppProvider{Out: out, JSONOutput: true})
+	replay.SetArgs([]string{events, "--into", into})
+	if err := replay.Execute(); err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+	var result ReplayJSON
+	if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Issues != 2 || result.Complete || result.Into != filepath.Join(into, ".beads") {
+		t.Errorf("result = %+v", result)
+	}
+	gaps := map[string][]string{}
+	for _, g := range result.Gaps {
+		gaps[g.IssueID] = g.Missing
+	}
+	if !slices.Equal(gaps[epic], []string{"title"}) || !slices.Equal(gaps[task], []string{"description"}) {
+		t.Errorf("gaps = %v", gaps)
+	}
+
+	replayed := filesystem.New(result.Into, "bd")
+	got, err := replayed.Get(ctx, task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "Login task" || got.Priority != issuestorage.PriorityHigh || got.Assignee != "alice" ||
+		!slices.Equal(got.Labels, []string{"auth"}) || got.Status != issuestorage.StatusClosed || got.Parent != epic || got.Description != "" {
+		t.Errorf("replayed task = %+v", got)
+	}
+	parent, err := replayed.Get(ctx, epic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parent.Title != replayUnknownTitle || !parent.HasDependent(task) {
+		t.Errorf("replayed epic = %+v", parent)
+	}
+
+	// Point in time: before the task was closed.
+	original, _ := store.Get(ctx, task)
+	var closedAt time.Time
+	for _, h := range original.History {
+		if h.Event == issuestorage.EventStatus {
+			closedAt = h.At
+		}
+	}
+	out.Reset()
+	earlier := t.TempDir()
+	asOf := newReplayCmd(&AppProvider{Out: out})
+	asOf.SetArgs([]string{events, "--into", earlier, "--until", closedAt.Add(-time.Nanosecond).Format(time.RFC3339Nano)})
+	if err := asOf.Execute(); err != nil {
+		t.Fatalf("replay --until failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Skipped") || !strings.Contains(out.String(), task+": description") {
+		t.Errorf("replay --until output:\n%s", out.String())
+	}
+	before, err := filesystem.New(filepath.Join(earlier, ".beads"), "bd").Get(ctx, task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if before.Status != issuestorage.StatusOpen || before.Title != "Login task" || before.Parent != "" {
+		t.Errorf("task as of before closing = %+v", before)
+	}
+
+	// Replaying into an existing repository is refused.
+	again := newReplayCmd(&AppProvider{Out: out})
+	again.SetArgs([]string{events, "--into", into})
+	if err := again.Execute(); err == nil {
+		t.Error("replaying into an existing repository should fail")
+	}
+}
+
+func TestReplayUntilDuration(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2020, 3, 1, 9, 0, 0, 0, time.UTC))
+	store.SetClock(fake)
+
+	id, err := store.Create(ctx, &issuestorage.Issue{Title: "Task"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake.Advance(10 * 24 * time.Hour)
+	if err := store.Modify(ctx, id, func(i *issuestorage.Issue) error { i.Title = "Renamed"; return nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	var log bytes.Buffer
+	app.Out, app.JSON = &log, true
+	activity := newActivityCmd(NewTestProvider(app))
+	activity.SetArgs([]string{"--since", "2000-01-01"})
+	if err := activity.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	events := filepath.Join(t.TempDir(), "events.json")
+	if err := os.WriteFile(events, log.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// 5d is measured from the store's clock, between the two events; by
+	// the system clock both would be long past.
+	out := &bytes.Buffer{}
+	app.Out = out
+	provider := NewTestProvider(app)
+	provider.JSONOutput = true
+	replay := newReplayCmd(provider)
+	replay.SetArgs([]string{events, "--into", t.TempDir(), "--until", "5d"})
+	if err := replay.Execute(); err != nil {
+		t.Fatalf("replay --until 5d failed: %v", err)
+	}
+	var result ReplayJSON
+	if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Skipped != 1 || result.Until != formatTime(fake.Now().Add(-5*24*time.Hour)) {
+		t.Errorf("result = %+v", result)
+	}
+}
@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// reviewRequireApprovalKey makes closing an issue require an approved
+// review.
+const reviewRequireApprovalKey = "review.require_approval"
+
+// ReviewJSON is the JSON output format for a review verdict.
+type ReviewJSON struct {
+	ID       string `json:"id"`
+	Reviewer string `json:"reviewer"`
+	Outcome  string `json:"outcome"`
+	Comment  string `json:"comment,omitempty"`
+	Status   string `json:"status"`
+}
+
+// ReviewQueueJSON is the JSON output format for an entry in bd review list.
+type ReviewQueueJSON struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Priority    int    `json:"priority"`
+	Assignee    string `json:"assignee,omitempty"`
+	Reviewer    string `json:"reviewer,omitempty"`
+	LastOutcome string `json:"last_outcome,omitempty"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// newReviewCmd creates the review command with subcommands.
+func newReviewCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "review",
+		Short: "Request, list and record reviews",
+		Long: `Manage the review workflow.
+
+Issues awaiting review have status "review" (set it with bd review request
+or bd update --status review). Reviewers record a verdict with approve or
+request-changes; requesting changes sends the issue back to in_progress.
+With review.require_approval set, an issue can only be closed once its
+latest review is an approval.
+
+Subcommands:
+  request          Put an issue in review
+  list             List issues awaiting review
+  approve          Approve an issue
+  request-changes  Ask for changes to an issue`,
+	}
+
+	cmd.AddCommand(newReviewRequestCmd(provider))
+	cmd.AddCommand(newReviewListCmd(provider))
+	cmd.AddCommand(newReviewVerdictCmd(provider, issuestorage.ReviewApproved))
+	cmd.AddCommand(newReviewVerdictCmd(provider, issuestorage.ReviewChangesRequested))
+
+	return cmd
+}
+
+// newReviewRequestCmd creates the "review request" subcommand.
+func newReviewRequestCmd(provider *AppProvider) *cobra.Command {
+	var reviewer string
+
+	cmd := &cobra.Command{
+		Use:   "request <id>",
+		Short: "Put an issue in review",
+		Long: `Set an issue's status to review, optionally naming who should review it.
+
+Examples:
+  bd review request bd-a1b2 --reviewer bob`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			issue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+			if err := app.Storage.RequestReview(ctx, issue.ID, reviewer); err != nil {
+				return err
+			}
+			warnIfAway(app, reviewer)
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(map[string]string{
+					"id":       issue.ID,
+					"status":   string(issuestorage.StatusReview),
+					"reviewer": reviewer,
+				})
+			}
+			msg := fmt.Sprintf("%s %s is awaiting review", app.SuccessColor("✓"), issue.ID)
+			if reviewer != "" {
+				msg += " by " + reviewer
+			}
+			fmt.Fprintln(app.Out, msg)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&reviewer, "reviewer", "", "Who should review the issue")
+
+	return cmd
+}
+
+// newReviewListCmd creates the "review list" subcommand.
+func newReviewListCmd(provider *AppProvider) *cobra.Command {
+	var reviewer string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List issues awaiting review",
+		Long: `List issues with status review, longest waiting first.
+
+Examples:
+  bd review list
+  bd review list --reviewer bob`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+
+			issues, err := app.Storage.List(cmd.Context(), &issuestorage.ListFilter{
+				Statuses: []issuestorage.Status{issuestorage.StatusReview},
+			})
+			if err != nil {
+				return fmt.Errorf("listing issues: %w", err)
+			}
+			var queue []*issuestorage.Issue
+			for _, issue := range issues {
+				if reviewer == "" || issue.Reviewer == reviewer {
+					queue = append(queue, issue)
+				}
+			}
+			sort.SliceStable(queue, func(i, j int) bool { return queue[i].UpdatedAt.Before(queue[j].UpdatedAt) })
+
+			if app.JSON {
+				out := []ReviewQueueJSON{}
+				for _, issue := range queue {
+					out = append(out, toReviewQueueJSON(issue))
+				}
+				return json.NewEncoder(app.Out).Encode(out)
+			}
+			if len(queue) == 0 {
+				fmt.Fprintln(app.Out, "No issues awaiting review.")
+				return nil
+			}
+			now := app.Now()
+			for _, issue := range queue {
+				reviewedBy := issue.Reviewer
+				if reviewedBy == "" {
+					reviewedBy = "anyone"
+				}
+				fmt.Fprintf(app.Out, "%s [%s] %s\n    reviewer: %s · updated %s", issue.ID, issue.Priority.Display(), issue.Title, reviewedBy, inboxAge(now.Sub(issue.UpdatedAt)))
+				if issue.Approved() {
+					fmt.Fprint(app.Out, " · approved")
+				}
+				fmt.Fprintln(app.Out)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&reviewer, "reviewer", "", "Only show issues awaiting this reviewer")
+
+	return cmd
+}
+
+// newReviewVerdictCmd creates the "review approve" or "review
+// request-changes" subcommand, recording outcome by the current actor.
+func newReviewVerdictCmd(provider *AppProvider, outcome issuestorage.ReviewOutcome) *cobra.Command {
+	var comment string
+
+	use, short, example := "approve", "Approve an issue", "bd review approve bd-a1b2 -m 'LGTM'"
+	if outcome == issuestorage.ReviewChangesRequested {
+		use, short, example = "request-changes", "Ask for changes to an issue", "bd review request-changes bd-a1b2 -m 'Needs tests'"
+	}
+
+	cmd := &cobra.Command{
+		Use:   use + " <id>",
+		Short: short,
+		Long: short + `, recording you as the reviewer.
+
+Examples:
+  ` + example,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			if outcome == issuestorage.ReviewChangesRequested && comment == "" {
+				return fmt.Errorf("--message is required when requesting changes")
+			}
+			issue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+			actor, err := resolveActor(app)
+			if err != nil {
+				return err
+			}
+			review := issuestorage.Review{Reviewer: actor, Outcome: outcome, Comment: comment}
+			if err := app.Storage.RecordReview(ctx, issue.ID, review); err != nil {
+				return err
+			}
+			updated, err := app.Storage.Get(ctx, issue.ID)
+			if err != nil {
+				return fmt.Errorf("fetching updated issue: %w", err)
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(ReviewJSON{
+					ID:       issue.ID,
+					Reviewer: actor,
+					Outcome:  string(outcome),
+					Comment:  comment,
+					Status:   string(updated.Status),
+				})
+			}
+			if outcome == issuestorage.ReviewApproved {
+				fmt.Fprintf(app.Out, "%s Approved %s\n", app.SuccessColor("✓"), issue.ID)
+			} else {
+				fmt.Fprintf(app.Out, "%s Requested changes to %s (now %s)\n", app.SuccessColor("✓"), issue.ID, updated.Status)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&comment, "message", "m", "", "Review comment")
+
+	return cmd
+}
+
+// toReviewQueueJSON converts an issue awaiting review to JSON output format.
+func toReviewQueueJSON(issue *issuestorage.Issue) ReviewQueueJSON {
+	out := ReviewQueueJSON{
+		ID:        issue.ID,
+		Title:     issue.Title,
+		Priority:  int(issue.Priority),
+		Assignee:  issue.Assignee,
+		Reviewer:  issue.Reviewer,
+		UpdatedAt: formatTime(issue.UpdatedAt),
+	}
+	if n := len(issue.Reviews); n > 0 {
+		out.LastOutcome = string(issue.Reviews[n-1].Outcome)
+	}
+	return out
+}
+
+// formatReview describes a review verdict for bd show.
+func formatReview(r issuestorage.Review) string {
+	verdict := "approved"
+	if r.Outcome == issuestorage.ReviewChangesRequested {
+		verdict = "requested changes"
+	}
+	s := fmt.Sprintf("%s %s on %s", r.Reviewer, verdict, r.At.Format("2006-01-02"))
+	if r.Comment != "" {
+		s += ": " + r.Comment
+	}
+	return s
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// runReview runs bd review with args and returns stdout.
+func runReview(t *testing.T, app *App, args ...string) (string, error) {
+	t.Helper()
+	out := app.Out.(*bytes.Buffer)
+	out.Reset()
+	cmd := newReviewCmd(NewTestProvider(app))
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestReviewCmd_Workflow(t *testing.T) {
+	app, store := setupTestApp(t)
+	app.ConfigStore = &mapConfigStore{data: map[string]string{"actor": "bob"}}
+	ctx := context.Background()
+	forBob := createAssigned(t, store, "For bob", "alice", issuestorage.StatusInProgress, issuestorage.PriorityMedium)
+	forCarol := createAssigned(t, store, "For carol", "alice", issuestorage.StatusInProgress, issuestorage.PriorityMedium)
+
+	if _, err := runReview(t, app, "request", forBob, "--reviewer", "bob"); err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	if _, err := runReview(t, app, "request", forCarol, "--reviewer", "carol"); err != nil {
+		t.Fatalf("request: %v", err)
+	}
+
+	app.JSON = true
+	out, err := runReview(t, app, "list", "--reviewer", "bob")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	var queue []ReviewQueueJSON
+	if err := json.Unmarshal([]byte(out), &queue); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(queue) != 1 || queue[0].ID != forBob || queue[0].Reviewer != "bob" {
+		t.Fatalf("queue = %+v, want only %s", queue, forBob)
+	}
+	app.JSON = false
+
+	if _, err := runReview(t, app, "request-changes", forBob); err == nil || !strings.Contains(err.Error(), "--message") {
+		t.Fatalf("request-changes without message: err = %v", err)
+	}
+	out, err = runReview(t, app, "request-changes", forBob, "-m", "Needs tests")
+	if err != nil {
+		t.Fatalf("request-changes: %v", err)
+	}
+	if !strings.Contains(out, "now in_progress") {
+		t.Errorf("output = %q", out)
+	}
+
+	out, err = runReview(t, app, "approve", forCarol, "-m", "LGTM")
+	if err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if !strings.Contains(out, "Approved "+forCarol) {
+		t.Errorf("output = %q", out)
+	}
+	issue, _ := store.Get(ctx, forCarol)
+	if !issue.Approved() || issue.Reviews[0].Reviewer != "bob" || issue.Reviews[0].Comment != "LGTM" {
+		t.Errorf("reviews = %+v, want approval by bob", issue.Reviews)
+	}
+
+	out, err = runReview(t, app, "list")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if strings.Contains(out, forBob) || !strings.Contains(out, forCarol) || !strings.Contains(out, "approved") {
+		t.Errorf("list = %q, want only the approved %s", out, forCarol)
+	}
+}
+
+func TestCloseCmd_RequiresApproval(t *testing.T) {
+	app, store := setupTestApp(t)
+	store.SetRequireApproval(true)
+	id := createAssigned(t, store, "Change", "", issuestorage.StatusReview, issuestorage.PriorityMedium)
+
+	cmd := newCloseCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{id})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "without an approved review") {
+		t.Fatalf("close: err = %v, want approval error", err)
+	}
+}
+
+func TestParseStatus_Review(t *testing.T) {
+	for _, s := range []string{"review", "in-review", "in_review"} {
+		if got, err := parseStatus(s, nil); err != nil || got != issuestorage.StatusReview {
+			t.Errorf("parseStatus(%q) = %q, %v", s, got, err)
+		}
+	}
+}
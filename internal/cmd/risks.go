@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+	"github.com/spf13/cobra"
+)
+
+// RiskJSON is the JSON output format for an entry in bd risks.
+type RiskJSON struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	Status     string `json:"status"`
+	Assignee   string `json:"assignee,omitempty"`
+	Likelihood int    `json:"likelihood"`
+	Impact     int    `json:"impact"`
+	Exposure   int    `json:"exposure"`
+	ReviewBy   string `json:"review_by,omitempty"`
+	Overdue    bool   `json:"overdue,omitempty"`
+}
+
+// newRisksCmd creates the risks command.
+func newRisksCmd(provider *AppProvider) *cobra.Command {
+	var (
+		all     bool
+		overdue bool
+		minExp  int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "risks",
+		Short: "List risks ranked by exposure",
+		Long: `List issues of type "risk" ranked by exposure (likelihood × impact).
+
+Risks with equal exposure are ordered by review-by date (earliest first),
+then by ID. A risk is overdue when its review-by date has passed.
+
+By default only open risks are listed.
+
+Examples:
+  bd risks
+  bd risks --overdue
+  bd risks --min-exposure 12
+  bd risks --all --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			filter := &issuestorage.ListFilter{Types: []issuestorage.IssueType{issuestorage.TypeRisk}}
+			risks, err := app.Storage.List(ctx, filter)
+			if err != nil {
+				return fmt.Errorf("listing risks: %w", err)
+			}
+			if all {
+				closedFilter := *filter
+				closedFilter.Statuses = []issuestorage.Status{issuestorage.StatusClosed}
+				closed, err := app.Storage.List(ctx, &closedFilter)
+				if err != nil {
+					return fmt.Errorf("listing closed risks: %w", err)
+				}
+				risks = append(risks, closed...)
+			}
+
+			now := app.Now()
+			var selected []*issuestorage.Issue
+			for _, r := range risks {
+				if r.Exposure() < minExp {
+					continue
+				}
+				if overdue && !riskOverdue(r, now) {
+					continue
+				}
+				selected = append(selected, r)
+			}
+			sortRisks(selected)
+
+			if app.JSON {
+				out := make([]RiskJSON, 0, len(selected))
+				for _, r := range selected {
+					rj := RiskJSON{
+						ID:         r.ID,
+						Title:      r.Title,
+						Status:     string(r.Status),
+						Assignee:   r.Assignee,
+						Likelihood: r.Likelihood,
+						Impact:     r.Impact,
+						Exposure:   r.Exposure(),
+						Overdue:    riskOverdue(r, now),
+					}
+					if r.ReviewBy != nil {
+						rj.ReviewBy = formatTime(*r.ReviewBy)
+					}
+					out = append(out, rj)
+				}
+				return json.NewEncoder(app.Out).Encode(out)
+			}
+
+			if len(selected) == 0 {
+				fmt.Fprintln(app.Out, "No risks found.")
+				return nil
+			}
+
+			idW := 0
+			for _, r := range selected {
+				if len(r.ID) > idW {
+					idW = len(r.ID)
+				}
+			}
+			fmt.Fprintf(app.Out, "%-*s  %3s  %-5s  %-10s  %s\n", idW, "ID", "EXP", "L×I", "REVIEW BY", "TITLE")
+			for _, r := range selected {
+				review := "-"
+				if r.ReviewBy != nil {
+					review = r.ReviewBy.Format("2006-01-02")
+					if riskOverdue(r, now) {
+						review = app.WarnColor(review)
+					}
+				}
+				fmt.Fprintf(app.Out, "%-*s  %3d  %d×%d    %-10s  %s\n", idW, r.ID, r.Exposure(), r.Likelihood, r.Impact, review, r.Title)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "Include closed risks")
+	cmd.Flags().BoolVar(&overdue, "overdue", false, "Only show risks whose review-by date has passed")
+	cmd.Flags().IntVar(&minExp, "min-exposure", 0, "Only show risks with at least this exposure")
+
+	return cmd
+}
+
+// riskOverdue reports whether an open risk's review-by date is before now.
+func riskOverdue(issue *issuestorage.Issue, now time.Time) bool {
+	return issue.ReviewBy != nil && issue.Status != issuestorage.StatusClosed && issue.ReviewBy.Before(now)
+}
+
+// sortRisks orders risks by exposure (highest first), then review-by date
+// (earliest first, unset last), then ID.
+func sortRisks(risks []*issuestorage.Issue) {
+	sort.SliceStable(risks, func(i, j int) bool {
+		a, b := risks[i], risks[j]
+		if a.Exposure() != b.Exposure() {
+			return a.Exposure() > b.Exposure()
+		}
+		switch {
+		case a.ReviewBy != nil && b.ReviewBy == nil:
+			return true
+		case a.ReviewBy == nil && b.ReviewBy != nil:
+			return false
+		case a.ReviewBy != nil && b.ReviewBy != nil && !a.ReviewBy.Equal(*b.ReviewBy):
+			return a.ReviewBy.Before(*b.ReviewBy)
+		}
+		return a.ID < b.ID
+	})
+}
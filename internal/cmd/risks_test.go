@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestRisksRankedByExposure(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+
+	past := time.Now().AddDate(0, 0, -7)
+	low, err := store.Create(ctx, &issuestorage.Issue{Title: "Minor vendor delay", Type: issuestorage.TypeRisk, Likelihood: 2, Impact: 2})
+	if err != nil {
+		t.Fatalf("failed to create risk: %v", err)
+	}
+	high, err := store.Create(ctx, &issuestorage.Issue{Title: "Key engineer leaves", Type: issuestorage.TypeRisk, Likelihood: 3, Impact: 5, ReviewBy: &past})
+	if err != nil {
+		t.Fatalf("failed to create risk: %v", err)
+	}
+	if _, err := store.Create(ctx, &issuestorage.Issue{Title: "Not a risk", Likelihood: 5, Impact: 5}); err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	app.JSON = true
+	cmd := newRisksCmd(NewTestProvider(app))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("risks failed: %v", err)
+	}
+	var got []RiskJSON
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &got); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 risks, got %+v", got)
+	}
+	if got[0].ID != high || got[0].Exposure != 15 || !got[0].Overdue {
+		t.Errorf("expected %s first with exposure 15 and overdue, got %+v", high, got[0])
+	}
+	if got[1].ID != low || got[1].Exposure != 4 || got[1].Overdue {
+		t.Errorf("expected %s second with exposure 4, got %+v", low, got[1])
+	}
+
+	// --overdue and --min-exposure narrow the list.
+	for _, args := range [][]string{{"--overdue"}, {"--min-exposure", "10"}} {
+		app.Out = &bytes.Buffer{}
+		cmd = newRisksCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("risks %v failed: %v", args, err)
+		}
+		got = nil
+		if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &got); err != nil {
+			t.Fatalf("failed to parse JSON: %v", err)
+		}
+		if len(got) != 1 || got[0].ID != high {
+			t.Errorf("risks %v: expected [%s], got %+v", args, high, got)
+		}
+	}
+}
+
+func TestRiskFlags(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+
+	cmd := newCreateCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"Supplier outage", "--type", "risk", "--likelihood", "4", "--impact", "3", "--review-by", "2026-06-30"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	id := extractCreatedID(app.Out.(*bytes.Buffer).String())
+	issue, err := store.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("failed to get issue: %v", err)
+	}
+	if issue.Type != issuestorage.TypeRisk || issue.Exposure() != 12 {
+		t.Errorf("expected risk with exposure 12, got type=%s exposure=%d", issue.Type, issue.Exposure())
+	}
+	if issue.ReviewBy == nil || issue.ReviewBy.Format("2006-01-02") != "2026-06-30" {
+		t.Errorf("expected review-by 2026-06-30, got %v", issue.ReviewBy)
+	}
+
+	cmd = newCreateCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"Bad", "--likelihood", "6"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error for likelihood out of range")
+	}
+
+	cmd = newUpdateCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{id, "--impact", "5", "--review-by", ""})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	issue, err = store.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("failed to get issue: %v", err)
+	}
+	if issue.Exposure() != 20 || issue.ReviewBy != nil {
+		t.Errorf("expected exposure 20 and cleared review-by, got %d %v", issue.Exposure(), issue.ReviewBy)
+	}
+
+	app.Out = &bytes.Buffer{}
+	if err := outputIssue(app, ctx, issue); err != nil {
+		t.Fatalf("show failed: %v", err)
+	}
+	if out := app.Out.(*bytes.Buffer).String(); !strings.Contains(out, "Exposure: 20") {
+		t.Errorf("expected exposure in show output, got:\n%s", out)
+	}
+}
@@ -2,20 +2,33 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
+	"beads-lite/internal/alias"
+	"beads-lite/internal/clock"
 	"beads-lite/internal/config"
 	"beads-lite/internal/config/yamlstore"
 	"beads-lite/internal/configservice"
+	"beads-lite/internal/deterministic"
 	"beads-lite/internal/issueservice"
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/issuestorage/cached"
 	"beads-lite/internal/issuestorage/filesystem"
+	"beads-lite/internal/issuestorage/indexed"
+	"beads-lite/internal/issuestorage/maintenance"
+	"beads-lite/internal/issuestorage/metrics"
+	"beads-lite/internal/issuestorage/objectstore"
+	"beads-lite/internal/issuestorage/postgres"
+	"beads-lite/internal/issuestorage/replicated"
 	kvfs "beads-lite/internal/kvstorage/filesystem"
-	"beads-lite/internal/meow"
 	"beads-lite/internal/routing"
 
 	"github.com/spf13/cobra"
@@ -28,16 +41,34 @@ type AppProvider struct {
 	err  error
 
 	// Config captured from flags before Execute()
-	JSONOutput bool
-	Quiet      bool
-	Out        io.Writer
-	Err        io.Writer
+	JSONOutput    bool
+	Quiet         bool
+	Deterministic bool
+	Seed          int64
+	LockTimeout   time.Duration
+	// Compat is the major version pinned with --compat, or 0 for the
+	// current behavior.
+	Compat int
+	Out    io.Writer
+	Err    io.Writer
+
+	// Cache turns on the in-memory issue cache whatever storage.cache
+	// says. The daemon sets it.
+	Cache bool
+	// base, when set, is an App already built (by the daemon) that Get
+	// copies with this provider's output settings instead of building one.
+	base *App
 }
 
 // Get returns the App, initializing it on first call.
 func (p *AppProvider) Get() (*App, error) {
 	p.once.Do(func() {
-		if p.app == nil {
+		if p.app == nil && p.base != nil {
+			app := *p.base
+			app.Out, app.Err, app.JSON, app.Compat = p.Out, p.Err, p.JSONOutput, p.Compat
+			app.Maintenance.SetTimeout(p.LockTimeout) // commands run one at a time
+			p.app = &app
+		} else if p.app == nil {
 			p.app, p.err = p.init()
 		}
 	})
@@ -70,10 +101,38 @@ func (p *AppProvider) init() (*App, error) {
 	}
 	config.ApplyEnvOverrides(configStore)
 
-	var fsOpts []filesystem.Option
+	// Everything that reads the current time shares one clock: the system
+	// clock, or in deterministic mode a fake one driven by the seed.
+	clk := clock.Real
+	var seeded *deterministic.Source
+	if p.Deterministic {
+		seeded, err = deterministic.Load(filepath.Join(paths.ConfigDir, deterministic.StateFile), p.Seed)
+		if err != nil {
+			return nil, err
+		}
+		clk = seeded
+	}
+
+	fsOpts := []filesystem.Option{filesystem.WithClock(clk)}
+	var osOpts []objectstore.Option
+	var pgOpts []postgres.Option
 	if v, ok := configStore.Get("hierarchy.max_depth"); ok {
 		if n, err := strconv.Atoi(v); err == nil && n >= 1 {
 			fsOpts = append(fsOpts, filesystem.WithMaxHierarchyDepth(n))
+			osOpts = append(osOpts, objectstore.WithMaxHierarchyDepth(n))
+			pgOpts = append(pgOpts, postgres.WithMaxHierarchyDepth(n))
+		}
+	}
+	// An invalid storage.compression or storage.shard_width is reported by
+	// "bd config validate".
+	if v, ok := configStore.Get(filesystem.CompressionConfigKey); ok {
+		if c, err := filesystem.ParseCompression(v); err == nil {
+			fsOpts = append(fsOpts, filesystem.WithCompression(c))
+		}
+	}
+	if v, ok := configStore.Get(filesystem.ShardWidthConfigKey); ok {
+		if n, err := filesystem.ParseShardWidth(v); err == nil {
+			fsOpts = append(fsOpts, filesystem.WithShardWidth(n))
 		}
 	}
 	prefix := "bd"
@@ -81,8 +140,73 @@ func (p *AppProvider) init() (*App, error) {
 		prefix = v
 	}
 
-	store := filesystem.New(paths.ConfigDir, prefix, fsOpts...)
-	store.CleanupStaleLocks()
+	// Unlike other settings, a bad backend is an error rather than ignored:
+	// silently falling back to local files would split the tracker.
+	var store issuestorage.IssueStore
+	var fileCounter metrics.FileCounter
+	var searchIndex *indexed.Store
+	var watcher issuestorage.Watcher
+	backend := issuestorage.BackendFilesystem
+	if v, ok := configStore.Get(issuestorage.BackendConfigKey); ok {
+		if backend, err = issuestorage.ParseBackend(v); err != nil {
+			return nil, err
+		}
+	}
+	switch backend {
+	case issuestorage.BackendS3:
+		s3cfg, err := objectstore.S3ConfigFromEnv(configStore.Get)
+		if err != nil {
+			return nil, err
+		}
+		store = objectstore.New(objectstore.NewS3Bucket(s3cfg), prefix, osOpts...)
+	case issuestorage.BackendPostgres:
+		dsn, _ := configStore.Get(postgres.DSNConfigKey)
+		if dsn == "" {
+			return nil, fmt.Errorf("%s (or %s) is required when %s is %s", postgres.DSNConfigKey, config.EnvPostgresDSN, issuestorage.BackendConfigKey, backend)
+		}
+		pgStore, err := postgres.Open(dsn, prefix, pgOpts...)
+		if err != nil {
+			return nil, err
+		}
+		// Apply pending migrations so an upgraded bd never runs against an
+		// older schema.
+		if err := pgStore.Init(context.Background()); err != nil {
+			return nil, err
+		}
+		store = pgStore
+	default:
+		fsStore := filesystem.New(paths.ConfigDir, prefix, fsOpts...)
+		fsStore.CleanupStaleLocks()
+		store = fsStore
+		fileCounter = fsStore
+		watcher = fsStore
+		if v, ok := configStore.Get(cached.ConfigKey); ok && v == "true" || p.Cache {
+			store = cached.New(fsStore)
+		}
+		searchIndex = indexed.New(store, fsStore, filepath.Join(paths.ConfigDir, indexed.DirName))
+		store = searchIndex
+	}
+
+	var replicas *replicated.Store
+	if v, ok := configStore.Get(replicated.MirrorsConfigKey); ok && v != "" {
+		var secondaries []replicated.Secondary
+		for _, target := range config.SplitCustomValues(v) {
+			mirror, err := openMirror(target, prefix, configStore)
+			if err != nil {
+				return nil, fmt.Errorf("opening mirror %s: %w", target, err)
+			}
+			secondaries = append(secondaries, replicated.Secondary{Name: target, Store: mirror})
+		}
+		replicas = replicated.New(store, secondaries...)
+		store = replicas
+	}
+
+	lock := maintenance.New(paths.ConfigDir)
+	lock.SetTimeout(p.LockTimeout)
+	store = maintenance.NewStore(store, lock)
+
+	storeMetrics := metrics.New(store, fileCounter)
+	store = storeMetrics
 
 	slotStore, err := kvfs.New(paths.ConfigDir, "slots")
 	if err != nil {
@@ -99,6 +223,46 @@ func (p *AppProvider) init() (*App, error) {
 		return nil, fmt.Errorf("creating merge-slot store: %w", err)
 	}
 
+	milestoneStore, err := kvfs.New(paths.ConfigDir, "milestones")
+	if err != nil {
+		return nil, fmt.Errorf("creating milestone store: %w", err)
+	}
+
+	sprintStore, err := kvfs.New(paths.ConfigDir, "sprints")
+	if err != nil {
+		return nil, fmt.Errorf("creating sprint store: %w", err)
+	}
+
+	aliasStore, err := kvfs.New(paths.ConfigDir, "aliases")
+	if err != nil {
+		return nil, fmt.Errorf("creating alias store: %w", err)
+	}
+
+	templateStore, err := kvfs.New(paths.ConfigDir, "templates")
+	if err != nil {
+		return nil, fmt.Errorf("creating template store: %w", err)
+	}
+
+	peopleStore, err := kvfs.New(paths.ConfigDir, "people")
+	if err != nil {
+		return nil, fmt.Errorf("creating people store: %w", err)
+	}
+
+	estimateStore, err := kvfs.New(paths.ConfigDir, "estimates")
+	if err != nil {
+		return nil, fmt.Errorf("creating estimate store: %w", err)
+	}
+
+	tokenStore, err := kvfs.New(paths.ConfigDir, tokenTable)
+	if err != nil {
+		return nil, fmt.Errorf("creating token store: %w", err)
+	}
+
+	undoStore, err := kvfs.New(paths.ConfigDir, undoTable)
+	if err != nil {
+		return nil, fmt.Errorf("creating undo store: %w", err)
+	}
+
 	router, err := routing.New(paths.ConfigDir)
 	if err != nil {
 		return nil, err
@@ -108,6 +272,23 @@ func (p *AppProvider) init() (*App, error) {
 	if v, ok := configStore.Get("graph.auto_close_parent"); ok && v == "false" {
 		routingStore.SetAutoCloseParent(false)
 	}
+	// Invalid per-type and workflow rules are reported by "bd config validate".
+	typeRules, _ := issueservice.ParseTypeRules(configStore.All())
+	routingStore.SetTypeRules(typeRules)
+	workflow, _ := issueservice.ParseWorkflow(configStore.All())
+	routingStore.SetWorkflow(workflow)
+	if v, ok := configStore.Get(reviewRequireApprovalKey); ok && v == "true" {
+		routingStore.SetRequireApproval(true)
+	}
+	if v, ok := configStore.Get(depBacklinkCommentsKey); ok && v == "true" {
+		routingStore.SetBacklinkComments(true)
+	}
+	routingStore.SetAliases(alias.NewLookup(aliasStore))
+	routingStore.SetClock(clk)
+	routingStore.SetWatcher(watcher)
+	if seeded != nil {
+		routingStore.SetIDSource(seeded)
+	}
 
 	out := p.Out
 	if out == nil {
@@ -117,19 +298,48 @@ func (p *AppProvider) init() (*App, error) {
 	if errOut == nil {
 		errOut = os.Stderr
 	}
+	// An invalid mode is reported by "bd config validate".
+	if v, ok := configStore.Get(issueservice.ParanoidConfigKey); ok {
+		if mode, err := issueservice.ParseParanoidMode(v); err == nil {
+			routingStore.SetParanoid(mode, errOut)
+		}
+	}
 
-	return &App{
+	app := &App{
 		Storage:        routingStore,
 		SlotStore:      slotStore,
 		AgentStore:     agentStore,
 		MergeSlotStore: mergeSlotStore,
+		MilestoneStore: milestoneStore,
+		SprintStore:    sprintStore,
+		AliasStore:     aliasStore,
+		TemplateStore:  templateStore,
+		PeopleStore:    peopleStore,
+		EstimateStore:  estimateStore,
+		TokenStore:     tokenStore,
+		UndoStore:      undoStore,
 		ConfigStore:    configStore,
 		ConfigDir:      paths.ConfigDir,
-		FormulaPath:    meow.DefaultSearchPath(paths.ConfigDir),
+		FormulaPath:    formulaSearchPath(paths.ConfigDir, configStore),
 		Out:            out,
 		Err:            errOut,
 		JSON:           p.JSONOutput,
-	}, nil
+		Compat:         p.Compat,
+		SearchIndex:    searchIndex,
+		Replicas:       replicas,
+		Metrics:        storeMetrics,
+		Maintenance:    lock,
+		Deterministic:  seeded,
+	}
+	routingStore.SetActor(func() string {
+		actor, _ := resolveActor(app)
+		return actor
+	})
+	lock.SetActor(func() string {
+		actor, _ := resolveActor(app)
+		return actor
+	})
+	return app, nil
 }
 
 // Execute runs the CLI.
@@ -139,12 +349,33 @@ func Execute() error {
 		Err: os.Stderr,
 	}
 
+	if handled, err := delegateToDaemon(os.Args[1:]); handled {
+		return err
+	}
+
 	rootCmd := newRootCmd(provider)
-	return rootCmd.Execute()
+	err := rootCmd.Execute()
+	if app := provider.app; app != nil {
+		// The command's own writes succeeded, so a mirroring failure is
+		// only a warning.
+		if cerr := app.Close(); cerr != nil {
+			fmt.Fprintf(provider.Err, "warning: %v\n", cerr)
+		}
+		if app.Metrics != nil {
+			if v := strings.ToLower(os.Getenv(config.EnvStorageMetrics)); v == "1" || v == "true" {
+				printStorageMetrics(provider.Err, app.Metrics)
+			} else if !provider.Quiet {
+				printStorageHints(provider.Err, app.Metrics, slowThreshold(app.ConfigStore.Get))
+			}
+		}
+	}
+	return err
 }
 
 // newRootCmd creates the root command with all subcommands.
 func newRootCmd(provider *AppProvider) *cobra.Command {
+	var lockTimeout string // parsed into provider.LockTimeout
+	var compat string      // parsed into provider.Compat
 	rootCmd := &cobra.Command{
 		Use:   "bd",
 		Short: "A lightweight issue tracker that lives in your repo",
@@ -175,6 +406,36 @@ making them easy to review, diff, and track alongside your code.`,
 			if provider.Quiet {
 				provider.Out = io.Discard
 			}
+			// BD_SEED both picks the seed and turns on deterministic mode.
+			if envSeed := os.Getenv(config.EnvSeed); envSeed != "" {
+				seed, err := strconv.ParseInt(envSeed, 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid %s %q: must be an integer", config.EnvSeed, envSeed)
+				}
+				provider.Seed = seed
+				provider.Deterministic = true
+			}
+			if lockTimeout == "" {
+				lockTimeout = os.Getenv(config.EnvLockTimeout)
+			}
+			if lockTimeout != "" {
+				d, err := time.ParseDuration(lockTimeout)
+				if err != nil {
+					return fmt.Errorf("invalid --lock-timeout %q: %w", lockTimeout, err)
+				}
+				provider.LockTimeout = d
+			}
+			if compat == "" {
+				compat = os.Getenv(config.EnvCompat)
+			}
+			if compat != "" {
+				v, err := parseCompat(compat)
+				if err != nil {
+					return err
+				}
+				provider.Compat = v
+			}
+			warnLegacyFlags(provider.Err, provider.Compat, cmd)
 			return nil
 		},
 	}
@@ -182,65 +443,125 @@ making them easy to review, diff, and track alongside your code.`,
 	// Global flags - these populate the provider config
 	rootCmd.PersistentFlags().BoolVar(&provider.JSONOutput, "json", false, "Output in JSON format (env: BD_JSON)")
 	rootCmd.PersistentFlags().BoolVarP(&provider.Quiet, "quiet", "q", false, "Suppress non-error output (env: BD_QUIET)")
+	rootCmd.PersistentFlags().BoolVar(&provider.Deterministic, "deterministic", false, "Derive IDs and timestamps from a seed and a fake clock (env: BD_SEED, default seed 0)")
+
+	rootCmd.PersistentFlags().StringVar(&compat, "compat", "", fmt.Sprintf("Declare the major version N, 1-%d, a script expects; refuses unknown versions and suppresses warnings for legacy behaviors of N (env: BD_COMPAT)", compatVersion))
+	rootCmd.PersistentFlags().StringVar(&lockTimeout, "lock-timeout", "", "How long writes wait for maintenance to end, e.g. 30s (env: BD_LOCK_TIMEOUT, default: fail at once)")
+
+	// --no-daemon is read by delegateToDaemon before the command line is
+	// parsed.
+	var noDaemon bool
+	rootCmd.PersistentFlags().BoolVar(&noDaemon, "no-daemon", false, "Run in this process even when a daemon is running (env: BD_NO_DAEMON)")
 
 	// Compatibility flags — accepted for compatibility with the reference
 	// implementation but not used by beads-lite.
 	var (
-		noDaemon     bool
 		noAutoFlush  bool
 		noAutoImport bool
 		noDB         bool
-		lockTimeout  string
 		sandbox      bool
 		readOnly     bool
 		allowStale   bool
 	)
-	rootCmd.PersistentFlags().BoolVar(&noDaemon, "no-daemon", false, "Accepted for compatibility (no-op)")
 	rootCmd.PersistentFlags().BoolVar(&noAutoFlush, "no-auto-flush", false, "Accepted for compatibility (no-op)")
 	rootCmd.PersistentFlags().BoolVar(&noAutoImport, "no-auto-import", false, "Accepted for compatibility (no-op)")
 	rootCmd.PersistentFlags().BoolVar(&noDB, "no-db", false, "Accepted for compatibility (no-op)")
-	rootCmd.PersistentFlags().StringVar(&lockTimeout, "lock-timeout", "", "Accepted for compatibility (no-op)")
 	rootCmd.PersistentFlags().BoolVar(&sandbox, "sandbox", false, "Accepted for compatibility (no-op)")
 	rootCmd.PersistentFlags().BoolVar(&readOnly, "readonly", false, "Accepted for compatibility (no-op)")
 	rootCmd.PersistentFlags().BoolVar(&allowStale, "allow-stale", false, "Accepted for compatibility (no-op)")
 
 	// Register all commands
 	rootCmd.AddCommand(newInitCmd(provider))
-	rootCmd.AddCommand(newCreateCmd(provider))
+	rootCmd.AddCommand(undoable(provider, newCreateCmd(provider)))
+	rootCmd.AddCommand(newJotCmd(provider))
+	rootCmd.AddCommand(newInboxCmd(provider))
 	rootCmd.AddCommand(newShowCmd(provider))
-	rootCmd.AddCommand(newUpdateCmd(provider))
-	rootCmd.AddCommand(newDeleteCmd(provider))
+	rootCmd.AddCommand(newIDCmd(provider))
+	rootCmd.AddCommand(newExplainCmd(provider))
+	rootCmd.AddCommand(newEnvCmd(provider))
+	rootCmd.AddCommand(undoable(provider, newUpdateCmd(provider)))
+	rootCmd.AddCommand(newLogTimeCmd(provider))
+	rootCmd.AddCommand(newEstimateCmd(provider))
+	rootCmd.AddCommand(newMilestoneCmd(provider))
+	rootCmd.AddCommand(newTemplateCmd(provider))
+	rootCmd.AddCommand(newPeopleCmd(provider))
+	rootCmd.AddCommand(newSprintCmd(provider))
+	rootCmd.AddCommand(undoable(provider, newDeleteCmd(provider)))
 	rootCmd.AddCommand(newDoctorCmd(provider))
+	rootCmd.AddCommand(newLintCmd(provider))
+	rootCmd.AddCommand(newReconcileCmd(provider))
 	rootCmd.AddCommand(newStatsCmd(provider))
+	rootCmd.AddCommand(newMatrixCmd(provider))
+	rootCmd.AddCommand(newBoardCmd(provider))
+	rootCmd.AddCommand(newContextCmd(provider))
+	rootCmd.AddCommand(newFlappyCmd(provider))
+	rootCmd.AddCommand(newWorkloadCmd(provider))
+	rootCmd.AddCommand(newRebalanceCmd(provider))
+	rootCmd.AddCommand(newOOOCmd(provider))
+	rootCmd.AddCommand(newLinkCmd(provider))
+	rootCmd.AddCommand(newCalendarCmd(provider))
+	rootCmd.AddCommand(newReviewCmd(provider))
+	rootCmd.AddCommand(newWorkflowCmd(provider))
+	rootCmd.AddCommand(newFixturesCmd(provider))
+	rootCmd.AddCommand(newServeCmd(provider))
+	rootCmd.AddCommand(newSchemaCmd(provider))
+	rootCmd.AddCommand(newMCPCmd(provider))
+	rootCmd.AddCommand(newRisksCmd(provider))
+	rootCmd.AddCommand(newDecisionCmd(provider))
+	rootCmd.AddCommand(newDecisionsCmd(provider))
+	rootCmd.AddCommand(newAnswerCmd(provider))
 	rootCmd.AddCommand(newSearchCmd(provider))
+	rootCmd.AddCommand(newReindexCmd(provider))
+	rootCmd.AddCommand(newReplayCmd(provider))
 	rootCmd.AddCommand(newReadyCmd(provider))
 	rootCmd.AddCommand(newBlockedCmd(provider))
+	rootCmd.AddCommand(newUICmd(provider))
 	rootCmd.AddCommand(newGraphCmd(provider))
-	rootCmd.AddCommand(newCloseCmd(provider))
+	rootCmd.AddCommand(newPathCmd(provider))
+	rootCmd.AddCommand(newImpactCmd(provider))
+	rootCmd.AddCommand(newPlanCmd(provider))
+	rootCmd.AddCommand(newOrderCmd(provider))
+	rootCmd.AddCommand(undoable(provider, newCloseCmd(provider)))
+	rootCmd.AddCommand(newUndoCmd(provider))
+	rootCmd.AddCommand(newBulkCmd(provider))
 	rootCmd.AddCommand(newListCmd(provider))
+	rootCmd.AddCommand(newRankCmd(provider))
 	rootCmd.AddCommand(newReopenCmd(provider))
 	rootCmd.AddCommand(newCommentsCmd(provider))
 	rootCmd.AddCommand(newCommentCmd(provider))
+	rootCmd.AddCommand(newMentionsCmd(provider))
+	rootCmd.AddCommand(newAttachCmd(provider))
+	rootCmd.AddCommand(newCriteriaCmd(provider))
 	rootCmd.AddCommand(newChildrenCmd(provider))
 	rootCmd.AddCommand(newDepCmd(provider))
 	rootCmd.AddCommand(newCompactCmd(provider))
+	rootCmd.AddCommand(newAdminCmd(provider))
+	rootCmd.AddCommand(newMaintenanceCmd(provider))
 	rootCmd.AddCommand(newConfigCmd(provider))
 	rootCmd.AddCommand(newMolCmd(provider))
 	rootCmd.AddCommand(newCookCmd(provider))
 	rootCmd.AddCommand(newFormulaCmd(provider))
 	rootCmd.AddCommand(newSyncCmd(provider))
+	rootCmd.AddCommand(newWatchCmd(provider))
+	rootCmd.AddCommand(newDaemonCmd(provider))
+	rootCmd.AddCommand(newBridgeCmd(provider))
 	rootCmd.AddCommand(newMigrateCmd(provider))
 	rootCmd.AddCommand(newVersionCmd(provider))
 	rootCmd.AddCommand(newPrimeCmd(provider))
 	rootCmd.AddCommand(newImportCmd(provider))
+	rootCmd.AddCommand(newExportCmd(provider))
 	rootCmd.AddCommand(newGateCmd(provider))
 	rootCmd.AddCommand(newSlotCmd(provider))
 	rootCmd.AddCommand(newAgentCmd(provider))
 	rootCmd.AddCommand(newLabelCmd(provider))
 	rootCmd.AddCommand(newEditCmd(provider))
+	rootCmd.AddCommand(newConvertCmd(provider))
+	rootCmd.AddCommand(newCloneCmd(provider))
 	rootCmd.AddCommand(newSwarmCmd(provider))
 	rootCmd.AddCommand(newMergeSlotCmd(provider))
+	rootCmd.AddCommand(newMergeFileCmd(provider))
 	rootCmd.AddCommand(newActivityCmd(provider))
+	rootCmd.AddCommand(newHistoryCmd(provider))
 
 	return rootCmd
 }
@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -310,3 +312,55 @@ func TestAppProvider_Get_BD_JSON_FlagOverridesEnv(t *testing.T) {
 		t.Error("provider.JSONOutput should be false when --json=false is explicitly passed")
 	}
 }
+
+func TestCompatFlag(t *testing.T) {
+	tmpDir := t.TempDir()
+	beadsDir := setupBeadsDir(t, tmpDir)
+	t.Setenv("BEADS_DIR", beadsDir)
+
+	run := func(args ...string) (*AppProvider, string, string, error) {
+		var out, errOut bytes.Buffer
+		provider := &AppProvider{Out: &out, Err: &errOut}
+		rootCmd := newRootCmd(provider)
+		rootCmd.SetArgs(args)
+		rootCmd.SetOut(&out)
+		rootCmd.SetErr(&errOut)
+		err := rootCmd.Execute()
+		return provider, out.String(), errOut.String(), err
+	}
+
+	provider, out, _, err := run("--compat", "1", "--json", "version")
+	if err != nil {
+		t.Fatalf("--compat 1: %v", err)
+	}
+	if provider.Compat != 1 || !strings.Contains(out, `"compat":"1"`) {
+		t.Errorf("compat = %d, version output %q", provider.Compat, out)
+	}
+	for _, bad := range []string{"0", "one", fmt.Sprint(compatVersion + 1)} {
+		if _, _, _, err := run("--compat", bad, "version"); err == nil {
+			t.Errorf("--compat %s: want error", bad)
+		}
+	}
+
+	// Legacy behaviors keep working, with a warning unless the version
+	// they belong to is pinned.
+	_, _, errOut, err := run("--no-db", "list")
+	if err != nil || !strings.Contains(errOut, "--no-db is deprecated") {
+		t.Errorf("--no-db: %v, stderr %q", err, errOut)
+	}
+	if _, _, errOut, _ := run("list"); strings.Contains(errOut, "deprecated") {
+		t.Errorf("no legacy flags, but stderr %q", errOut)
+	}
+	if _, _, errOut, err := run("--compat", "1", "--no-db", "comment", "bd-none"); strings.Contains(errOut, "deprecated") {
+		t.Errorf("--compat 1: legacy behaviors warned: %v, stderr %q", err, errOut)
+	}
+
+	t.Setenv("BD_COMPAT", "v1")
+	if provider, _, _, err := run("version"); err != nil || provider.Compat != 1 {
+		t.Errorf("BD_COMPAT=v1: compat = %d, %v", provider.Compat, err)
+	}
+	_, _, errOut, _ = run("--no-db", "list")
+	if strings.Contains(errOut, "deprecated") {
+		t.Errorf("BD_COMPAT=v1: --no-db warned, stderr %q", errOut)
+	}
+}
@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/schema"
+
+	"github.com/spf13/cobra"
+)
+
+// outputSchema ties a command to the Go value its --json output encodes.
+type outputSchema struct {
+	name        string // command path, e.g. "dep add"; "issue" is the file format
+	description string
+	value       any
+}
+
+// outputSchemas lists the published schemas. A command whose --json output
+// changes shape must change the type registered here, and a breaking change
+// must bump schema.Version. TestOutputSchemas runs each command against
+// its schema; TestPublishedSchemasUpToDate keeps schema/ in step.
+var outputSchemas = []outputSchema{
+	{"issue", "An issue as stored by the filesystem backend, one JSON file per issue.", issuestorage.Issue{}},
+
+	{"activity", "bd activity without --follow.", []HistoryEventJSON{}},
+	{"admin log", "bd admin log.", []AdminAuditJSON{}},
+	{"admin purge", "bd admin purge.", AdminPurgeJSON{}},
+	{"admin renumber", "bd admin renumber.", AdminRenumberJSON{}},
+	{"agent show", "bd agent show, state and heartbeat.", AgentJSON{}},
+	{"attach", "bd attach and attach remove.", AttachmentJSON{}},
+	{"attach list", "bd attach list.", []AttachmentJSON{}},
+	{"blocked", "bd blocked.", []BlockedIssueJSON{}},
+	{"board", "bd board.", BoardJSON{}},
+	{"bridge github", "bd bridge github push, pull and sync.", GitHubBridgeJSON{}},
+	{"bulk close", "bd bulk close, update, label and assign.", BulkJSON{}},
+	{"calendar list", "bd calendar list.", []CalendarEventJSON{}},
+	{"children", "bd children without --tree.", []IssueListJSON{}},
+	{"close", "bd close without --continue.", []IssueJSON{}},
+	{"comments", "bd comments <issue-id>.", []CommentJSON{}},
+	{"comments add", "bd comments add.", CommentJSON{}},
+	{"context list", "bd context list.", []ContextJSON{}},
+	{"create", "bd create.", IssueJSON{}},
+	{"criteria list", "bd criteria list.", []CriterionJSON{}},
+	{"daemon status", "bd daemon status, start and stop.", DaemonStatusJSON{}},
+	{"decisions", "bd decisions.", []DecisionJSON{}},
+	{"delete", "bd delete.", deleteResult{}},
+	{"dep add", "bd dep add.", DepChangeJSON{}},
+	{"dep list", "bd dep list.", []EnrichedDepJSON{}},
+	{"dep remove", "bd dep remove.", DepChangeJSON{}},
+	{"dep tree", "bd dep tree.", DepTreeJSON{}},
+	{"doctor", "bd doctor.", DoctorResult{}},
+	{"env", "bd env, the environment variables by name.", map[string]string{}},
+	{"estimate reveal", "bd estimate reveal.", EstimateRevealJSON{}},
+	{"estimate vote", "bd estimate vote.", EstimateRoundJSON{}},
+	{"explain", "bd explain.", ExplainJSON{}},
+	{"fixtures generate", "bd fixtures generate.", FixturesJSON{}},
+	{"flappy", "bd flappy.", []FlappyIssueJSON{}},
+	{"formula install", "bd formula install.", FormulaInstallJSON{}},
+	{"formula update", "bd formula update.", []FormulaInstallJSON{}},
+	{"gate check", "bd gate check.", []GateCheckResultJSON{}},
+	{"gate list", "bd gate list.", []GateListJSON{}},
+	{"graph", "bd graph.", GraphOutputJSON{}},
+	{"history", "bd history.", []HistoryEventJSON{}},
+	{"id aliases", "bd id aliases.", []AliasJSON{}},
+	{"id vanity", "bd id vanity.", AliasJSON{}},
+	{"impact", "bd impact.", ImpactJSON{}},
+	{"label expire", "bd label expire.", []LabelExpiryJSON{}},
+	{"label list", "bd label list, add and remove.", []IssueJSON{}},
+	{"link add", "bd link add and remove.", IssueLinkJSON{}},
+	{"link list", "bd link list.", []IssueLinkJSON{}},
+	{"link registry list", "bd link registry list.", []LinkJSON{}},
+	{"lint", "bd lint.", []LintResultJSON{}},
+	{"list", "bd list.", []IssueListJSON{}},
+	{"log-time", "bd log-time.", IssueJSON{}},
+	{"maintenance status", "bd maintenance status, begin and end.", MaintenanceJSON{}},
+	{"matrix", "bd matrix.", MatrixJSON{}},
+	{"mentions", "bd mentions.", []MentionJSON{}},
+	{"merge-slot check", "bd merge-slot create, check, acquire and release.", MergeSlotJSON{}},
+	{"milestone assign", "bd milestone assign.", MilestoneAssignJSON{}},
+	{"milestone create", "bd milestone create and close.", MilestoneJSON{}},
+	{"milestone list", "bd milestone list.", []MilestoneJSON{}},
+	{"mol current", "bd mol current.", []MolCurrentJSON{}},
+	{"mol progress", "bd mol progress.", MolProgressJSON{}},
+	{"mol show", "bd mol show.", MolShowJSON{}},
+	{"ooo list", "bd ooo list.", []OOOJSON{}},
+	{"order", "bd order.", OrderJSON{}},
+	{"path", "bd path.", PathJSON{}},
+	{"people add", "bd people add.", PersonJSON{}},
+	{"people list", "bd people list.", []PersonJSON{}},
+	{"plan", "bd plan.", PlanJSON{}},
+	{"ready", "bd ready.", []IssueSimpleJSON{}},
+	{"rebalance", "bd rebalance.", RebalanceJSON{}},
+	{"reindex", "bd reindex.", ReindexJSON{}},
+	{"replay", "bd replay.", ReplayJSON{}},
+	{"reopen", "bd reopen.", []IssueJSON{}},
+	{"review list", "bd review list.", []ReviewQueueJSON{}},
+	{"risks", "bd risks.", []RiskJSON{}},
+	{"search", "bd search.", []IssueListJSON{}},
+	{"serve log", "bd serve log.", []ServeAuditJSON{}},
+	{"serve token create", "bd serve token create.", TokenJSON{}},
+	{"serve token list", "bd serve token list.", []TokenJSON{}},
+	{"show", "bd show, once per issue.", []IssueJSON{}},
+	{"slot show", "bd slot show, set and clear.", SlotJSON{}},
+	{"sprint plan", "bd sprint plan.", SprintPlanJSON{}},
+	{"sprint report", "bd sprint report.", SprintReportJSON{}},
+	{"sprint start", "bd sprint start and close.", SprintJSON{}},
+	{"stats", "bd stats.", StatsResult{}},
+	{"swarm list", "bd swarm list.", SwarmListJSON{}},
+	{"swarm status", "bd swarm status.", SwarmStatusJSON{}},
+	{"swarm validate", "bd swarm validate.", SwarmValidateJSON{}},
+	{"sync", "bd sync.", SyncJSON{}},
+	{"template add", "bd template add.", TemplateJSON{}},
+	{"template apply", "bd template apply.", TemplateApplyJSON{}},
+	{"template list", "bd template list.", []TemplateJSON{}},
+	{"undo", "bd undo.", UndoJSON{}},
+	{"undo list", "bd undo --list.", []UndoJSON{}},
+	{"update", "bd update.", []IssueJSON{}},
+	{"workflow show", "bd workflow show.", []WorkflowStatusJSON{}},
+	{"workload", "bd workload.", []WorkloadJSON{}},
+}
+
+// findOutputSchema returns the schema document registered under name.
+func findOutputSchema(name string) (*schema.Schema, bool) {
+	for _, s := range outputSchemas {
+		if s.name == name {
+			return schema.Document(s.name, s.description, s.value), true
+		}
+	}
+	return nil, false
+}
+
+// newSchemaCmd creates the schema command with subcommands.
+func newSchemaCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Print JSON Schemas for --json output",
+		Long: fmt.Sprintf(`Print the JSON Schemas describing each command's --json output and the
+issue file format, so external tools can code against a stable contract.
+
+Schemas are strict: every property a command may print is declared,
+unknown properties are rejected, and a property is required unless it is
+omitted when empty. String values bd generates contain no emoji or status
+symbols. This is version %d of the contract; it is bumped, and the $id of
+every schema changes, when a property is removed, renamed or retyped.
+
+The same schemas are published in the repository under schema/v%d/.
+
+Subcommands:
+  list   List the commands that have a schema
+  print  Print the schema for a command, or "issue" for the file format`, schema.Version, schema.Version),
+	}
+
+	cmd.AddCommand(newSchemaListCmd(provider))
+	cmd.AddCommand(newSchemaPrintCmd(provider))
+
+	return cmd
+}
+
+// newSchemaListCmd creates the "schema list" subcommand.
+func newSchemaListCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the commands that have a schema",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if provider.JSONOutput {
+				out := make([]map[string]string, len(outputSchemas))
+				for i, s := range outputSchemas {
+					out[i] = map[string]string{"name": s.name, "id": schema.ID(s.name), "description": s.description}
+				}
+				return json.NewEncoder(provider.Out).Encode(out)
+			}
+			width := 0
+			for _, s := range outputSchemas {
+				width = max(width, len(s.name))
+			}
+			for _, s := range outputSchemas {
+				fmt.Fprintf(provider.Out, "%-*s  %s\n", width, s.name, s.description)
+			}
+			return nil
+		},
+	}
+}
+
+// newSchemaPrintCmd creates the "schema print" subcommand.
+func newSchemaPrintCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "print <command>",
+		Short: "Print the JSON Schema for a command's --json output",
+		Long: `Print the JSON Schema for a command's --json output. Subcommands may be
+given as separate arguments or quoted: "bd schema print dep add" and
+"bd schema print 'dep add'" are the same. "bd schema print issue" prints
+the issue file format.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.Join(args, " ")
+			s, ok := findOutputSchema(name)
+			if !ok {
+				return fmt.Errorf("no schema for %q (see bd schema list)", name)
+			}
+			data, err := schema.MarshalIndent(s)
+			if err != nil {
+				return err
+			}
+			_, err = provider.Out.Write(data)
+			return err
+		},
+	}
+}
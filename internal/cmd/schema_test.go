@@ -0,0 +1,404 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/alias"
+	"beads-lite/internal/clock"
+	"beads-lite/internal/config/yamlstore"
+	"beads-lite/internal/issueservice"
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/issuestorage/maintenance"
+	kvfs "beads-lite/internal/kvstorage/filesystem"
+	"beads-lite/internal/schema"
+
+	"github.com/spf13/cobra"
+)
+
+var updateSchema = flag.Bool("update-schema", false, "rewrite the published schemas under schema/")
+
+// publishedSchemaDir is where the schemas for the current version are
+// committed, relative to this package.
+var publishedSchemaDir = filepath.Join("..", "..", "schema", fmt.Sprintf("v%d", schema.Version))
+
+func TestPublishedSchemasUpToDate(t *testing.T) {
+	if *updateSchema {
+		if err := os.RemoveAll(publishedSchemaDir); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.MkdirAll(publishedSchemaDir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	published := map[string]bool{}
+	for _, s := range outputSchemas {
+		file := schema.FileName(s.name) + ".json"
+		published[file] = true
+		want, err := schema.MarshalIndent(schema.Document(s.name, s.description, s.value))
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(publishedSchemaDir, file)
+		if *updateSchema {
+			if err := os.WriteFile(path, want, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Errorf("%s: %v (run make update-schema)", s.name, err)
+			continue
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date (run make update-schema)", path)
+		}
+	}
+
+	entries, err := os.ReadDir(publishedSchemaDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if !published[e.Name()] {
+			t.Errorf("%s has no registered schema; remove it or add it to outputSchemas", e.Name())
+		}
+	}
+}
+
+// setupSchemaTestApp returns an app with every store a command may need
+// and a handful of issues covering the fields --json output can carry.
+func setupSchemaTestApp(t *testing.T) (*App, *issueservice.IssueStore) {
+	t.Helper()
+	app, rs := setupAgentTestApp(t)
+	dir := t.TempDir()
+	mergeSlotStore, err := kvfs.New(dir, "merge-slot")
+	if err != nil {
+		t.Fatalf("failed to create merge slot store: %v", err)
+	}
+	if err := mergeSlotStore.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init merge slot store: %v", err)
+	}
+	app.MergeSlotStore = mergeSlotStore
+	milestoneStore, err := kvfs.New(dir, "milestones")
+	if err != nil {
+		t.Fatalf("failed to create milestone store: %v", err)
+	}
+	app.MilestoneStore = milestoneStore
+	sprintStore, err := kvfs.New(dir, "sprints")
+	if err != nil {
+		t.Fatalf("failed to create sprint store: %v", err)
+	}
+	app.SprintStore = sprintStore
+	aliasStore, err := kvfs.New(dir, "aliases")
+	if err != nil {
+		t.Fatalf("failed to create alias store: %v", err)
+	}
+	app.AliasStore = aliasStore
+	rs.SetAliases(alias.NewLookup(aliasStore))
+	rs.SetWorkflow(issueservice.Workflow{
+		Next:     map[issuestorage.Status][]issuestorage.Status{issuestorage.StatusPinned: {issuestorage.StatusOpen}},
+		Required: map[issuestorage.Status]map[issuestorage.IssueType][]string{issuestorage.StatusPinned: {"": {"assignee"}}},
+	})
+	templateStore, err := kvfs.New(dir, "templates")
+	if err != nil {
+		t.Fatalf("failed to create template store: %v", err)
+	}
+	app.TemplateStore = templateStore
+	peopleStore, err := kvfs.New(dir, "people")
+	if err != nil {
+		t.Fatalf("failed to create people store: %v", err)
+	}
+	app.PeopleStore = peopleStore
+	estimateStore, err := kvfs.New(dir, "estimates")
+	if err != nil {
+		t.Fatalf("failed to create estimate store: %v", err)
+	}
+	app.EstimateStore = estimateStore
+	tokenStore, err := kvfs.New(dir, tokenTable)
+	if err != nil {
+		t.Fatalf("failed to create token store: %v", err)
+	}
+	app.TokenStore = tokenStore
+	undoStore, err := kvfs.New(dir, undoTable)
+	if err != nil {
+		t.Fatalf("failed to create undo store: %v", err)
+	}
+	app.UndoStore = undoStore
+	app.ConfigDir = dir
+	configStore, err := yamlstore.New(filepath.Join(dir, "config.yaml"))
+	if err != nil {
+		t.Fatalf("opening config: %v", err)
+	}
+	app.ConfigStore = configStore
+	app.Maintenance = maintenance.New(dir)
+	return app, rs
+}
+
+// runSchemaCmd runs a command with --json and returns what it printed.
+func runSchemaCmd(t *testing.T, app *App, newCmd func(*AppProvider) *cobra.Command, args ...string) []byte {
+	t.Helper()
+	out := &bytes.Buffer{}
+	app.Out = out
+	app.JSON = true
+	cmd := newCmd(&AppProvider{app: app, JSONOutput: true, Out: out, Err: app.Err})
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("%v: %v", args, err)
+	}
+	return out.Bytes()
+}
+
+func TestOutputSchemas(t *testing.T) {
+	t.Setenv("BD_ACTOR", "alice")
+	app, rs := setupSchemaTestApp(t)
+	ctx := context.Background()
+
+	created := func(args ...string) string {
+		t.Helper()
+		var issue IssueJSON
+		if err := json.Unmarshal(runSchemaCmd(t, app, newCreateCmd, args...), &issue); err != nil {
+			t.Fatal(err)
+		}
+		return issue.ID
+	}
+	epic := created("Epic", "--type", "epic", "--var", "DEPLOY_ENV=staging")
+	task := created("Task", "--parent", epic, "--labels", "api,auth", "--assignee", "alice", "--criteria", "Tests pass")
+	blocked := created("Blocked", "--parent", epic, "--deps", task, "--estimate", "1d")
+	flappy := created("Flappy", "--assignee", "bob")
+	risk := created("Risk", "--type", "risk", "--likelihood", "3", "--impact", "4")
+	created("Decision", "--type", "decision")
+	gate, err := rs.Create(ctx, &issuestorage.Issue{Title: "Gate", Type: issuestorage.TypeGate, Priority: issuestorage.PriorityMedium, AwaitType: "timer", TimeoutNS: int64(time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	molRoot, _, _, _ := setupMolecule(t, rs)
+	swarmEpic, _ := buildSwarmEpic(t, rs, []string{"One", "Two"}, map[string][]string{"Two": {"One"}})
+	sharedFormula := filepath.Join(t.TempDir(), "bug-triage.formula.toml")
+	if err := os.WriteFile(sharedFormula, []byte(triageFormula), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	notes := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(notes, []byte("Repro steps"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	runSchemaCmd(t, app, newCommentsCmd, "add", task, "Looping in @bob")
+	runSchemaCmd(t, app, newCloseCmd, flappy)
+	runSchemaCmd(t, app, newReopenCmd, flappy)
+	runSchemaCmd(t, app, newReviewCmd, "request", task, "--reviewer", "bob")
+	runSchemaCmd(t, app, newAgentCmd, "state", "agent-1", "working")
+	runSchemaCmd(t, app, newSlotCmd, "set", "agent-1", "hook", task)
+	runSchemaCmd(t, app, newOOOCmd, "add", "bob", "2099-01-01", "2099-01-05")
+	runSchemaCmd(t, app, newContextCmd, "create", "api", "--filter", "label:api")
+	runSchemaCmd(t, app, newLinkCmd, "registry", "add", "RFC-1", "https://example.com/rfc/1")
+	runSchemaCmd(t, app, newMilestoneCmd, "create", "v1", "--due", "2099-01-01")
+	runSchemaCmd(t, app, newMilestoneCmd, "assign", "v1", task)
+	eventLog := filepath.Join(t.TempDir(), "events.json")
+	if err := os.WriteFile(eventLog, runSchemaCmd(t, app, newActivityCmd, "--since", "2000-01-01"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		schema string
+		newCmd func(*AppProvider) *cobra.Command
+		args   []string
+	}{
+		{"activity", newActivityCmd, []string{"--since", "2000-01-01"}},
+		{"admin purge", newAdminCmd, []string{"purge", "--confirm", "purge"}},
+		{"admin renumber", newAdminCmd, []string{"renumber", blocked, "bd-schema-blocked", "--dry-run"}},
+		{"admin log", newAdminCmd, []string{"log"}},
+		{"agent show", newAgentCmd, []string{"show", "agent-1"}},
+		{"attach", newAttachCmd, []string{task, notes}},
+		{"attach list", newAttachCmd, []string{"list", task}},
+		{"attach", newAttachCmd, []string{"remove", task, "notes.txt"}},
+		{"blocked", newBlockedCmd, nil},
+		{"board", newBoardCmd, nil},
+		{"bulk close", newBulkCmd, []string{"label", "--add", "triaged", "--dry-run", task}},
+		{"calendar list", newCalendarCmd, []string{"list"}},
+		{"children", newChildrenCmd, []string{epic}},
+		{"comments", newCommentsCmd, []string{task}},
+		{"comments add", newCommentsCmd, []string{"add", task, "Another note"}},
+		{"context list", newContextCmd, []string{"list"}},
+		{"create", newCreateCmd, []string{"Created", "--description", "Body", "--priority", "1"}},
+		{"criteria list", newCriteriaCmd, []string{"list", task}},
+		{"daemon status", newDaemonCmd, []string{"status"}},
+		{"decisions", newDecisionsCmd, nil},
+		{"dep add", newDepCmd, []string{"add", flappy, task}},
+		{"dep list", newDepCmd, []string{"list", blocked}},
+		{"dep remove", newDepCmd, []string{"remove", flappy, task}},
+		{"dep tree", newDepCmd, []string{"tree", blocked, "--depth", "1"}},
+		{"dep tree", newDepCmd, []string{"tree", task, "--direction", "up"}},
+		{"doctor", newDoctorCmd, nil},
+		{"env", newEnvCmd, []string{task}},
+		{"estimate vote", newEstimateCmd, []string{"vote", task, "3"}},
+		{"estimate reveal", newEstimateCmd, []string{"reveal", task}},
+		{"explain", newExplainCmd, []string{blocked}},
+		{"explain", newExplainCmd, []string{gate}},
+		{"flappy", newFlappyCmd, nil},
+		{"formula install", newFormulaCmd, []string{"install", sharedFormula}},
+		{"formula update", newFormulaCmd, []string{"update"}},
+		{"gate check", newGateCmd, []string{"check", "--dry-run"}},
+		{"gate list", newGateCmd, []string{"list"}},
+		{"graph", newGraphCmd, []string{epic}},
+		{"history", newHistoryCmd, []string{blocked}},
+		{"id vanity", newIDCmd, []string{"vanity", task, "schema-task"}},
+		{"id vanity", newIDCmd, []string{"vanity", "bd-schema-task", "schema-work"}},
+		{"id aliases", newIDCmd, []string{"aliases"}},
+		{"id vanity", newIDCmd, []string{"vanity", task, "--clear"}},
+		{"impact", newImpactCmd, []string{task}},
+		{"label expire", newLabelCmd, []string{"expire", "--dry-run"}},
+		{"label list", newLabelCmd, []string{"list", task}},
+		{"label list", newLabelCmd, []string{"add", task, "backend"}},
+		{"link add", newLinkCmd, []string{"add", task, blocked, "--type", "supersedes"}},
+		{"link list", newLinkCmd, []string{"list", blocked}},
+		{"link add", newLinkCmd, []string{"remove", blocked, task}},
+		{"link registry list", newLinkCmd, []string{"registry", "list"}},
+		{"lint", newLintCmd, nil},
+		{"list", newListCmd, []string{"--all"}},
+		{"log-time", newLogTimeCmd, []string{blocked, "45m"}},
+		{"maintenance status", newMaintenanceCmd, []string{"begin", "--reason", "schema test"}},
+		{"maintenance status", newMaintenanceCmd, []string{"status"}},
+		{"maintenance status", newMaintenanceCmd, []string{"end"}},
+		{"matrix", newMatrixCmd, nil},
+		{"mentions", newMentionsCmd, []string{"--user", "bob"}},
+		{"merge-slot check", newMergeSlotCmd, []string{"create"}},
+		{"merge-slot check", newMergeSlotCmd, []string{"check"}},
+		{"milestone assign", newMilestoneCmd, []string{"assign", "v1", blocked}},
+		{"milestone create", newMilestoneCmd, []string{"create", "v2", "-d", "Next"}},
+		{"milestone create", newMilestoneCmd, []string{"close", "v2"}},
+		{"milestone list", newMilestoneCmd, []string{"list", "--all"}},
+		{"mol current", newMolCmd, []string{"current", molRoot}},
+		{"mol progress", newMolCmd, []string{"progress", molRoot}},
+		{"mol show", newMolCmd, []string{"show", molRoot}},
+		{"ooo list", newOOOCmd, []string{"list"}},
+		{"order", newOrderCmd, nil},
+		{"path", newPathCmd, []string{blocked}},
+		{"people add", newPeopleCmd, []string{"add", "bob", "--name", "Bob Smith", "--email", "bob@example.com"}},
+		{"people list", newPeopleCmd, []string{"list"}},
+		{"plan", newPlanCmd, []string{epic, "--all"}},
+		{"ready", newReadyCmd, nil},
+		{"rebalance", newRebalanceCmd, []string{"--suggest"}},
+		{"replay", newReplayCmd, []string{eventLog, "--into", t.TempDir()}},
+		{"review list", newReviewCmd, []string{"list"}},
+		{"risks", newRisksCmd, nil},
+		{"search", newSearchCmd, []string{"Task"}},
+		{"serve token create", newServeCmd, []string{"token", "create", "--scope", "write", "--name", "ci"}},
+		{"serve token list", newServeCmd, []string{"token", "list"}},
+		{"serve log", newServeCmd, []string{"log"}},
+		{"show", newShowCmd, []string{task}},
+		{"show", newShowCmd, []string{gate}},
+		{"show", newShowCmd, []string{epic}},
+		{"slot show", newSlotCmd, []string{"show", "agent-1"}},
+		{"sprint start", newSprintCmd, []string{"start", "s1", "--goal", "Schema"}},
+		{"sprint plan", newSprintCmd, []string{"plan", task}},
+		{"sprint report", newSprintCmd, []string{"report"}},
+		{"sprint start", newSprintCmd, []string{"close"}},
+		{"stats", newStatsCmd, nil},
+		{"stats", newStatsCmd, []string{"--burndown", "--throughput", "--by", "week"}},
+		{"swarm list", newSwarmCmd, []string{"list"}},
+		{"swarm status", newSwarmCmd, []string{"status", swarmEpic}},
+		{"swarm validate", newSwarmCmd, []string{"validate", swarmEpic}},
+		{"sync", newSyncCmd, nil},
+		{"template add", newTemplateCmd, []string{"add", "bug-report", "--title", "Bug: {{title}}", "--type", "bug", "-p", "1", "-l", "triage", "--description", "Steps", "--child", "Reproduce"}},
+		{"template apply", newTemplateCmd, []string{"apply", "bug-report", flappy}},
+		{"template list", newTemplateCmd, []string{"list"}},
+		{"update", func(p *AppProvider) *cobra.Command { return undoable(p, newUpdateCmd(p)) }, []string{risk, "--status", "in_progress"}},
+		{"undo list", newUndoCmd, []string{"--list"}},
+		{"undo", newUndoCmd, nil},
+		{"workflow show", newWorkflowCmd, []string{"show"}},
+		{"workload", newWorkloadCmd, nil},
+		{"close", newCloseCmd, []string{flappy, "--reason", "done"}},
+		{"reopen", newReopenCmd, []string{flappy}},
+		{"delete", newDeleteCmd, []string{risk, "--force"}},
+	}
+
+	covered := map[string]bool{"issue": true} // checked below
+	for _, tt := range tests {
+		covered[tt.schema] = true
+		t.Run(tt.schema+" "+strings.Join(tt.args, " "), func(t *testing.T) {
+			s, ok := findOutputSchema(tt.schema)
+			if !ok {
+				t.Fatalf("no schema registered for %q", tt.schema)
+			}
+			checkOutputSchema(t, s, runSchemaCmd(t, app, tt.newCmd, tt.args...))
+		})
+	}
+
+	t.Run("issue", func(t *testing.T) {
+		s, _ := findOutputSchema("issue")
+		issues, err := rs.List(ctx, &issuestorage.ListFilter{Statuses: []issuestorage.Status{issuestorage.StatusOpen, issuestorage.StatusInProgress, issuestorage.StatusClosed, issuestorage.StatusTombstone}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, issue := range issues {
+			data, err := json.Marshal(issue)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := schema.ValidateJSON(s, data); err != nil {
+				t.Errorf("%s: %v", issue.ID, err)
+			}
+		}
+	})
+
+	t.Run("fixtures generate", func(t *testing.T) {
+		app, _ := setupTestApp(t)
+		s, _ := findOutputSchema("fixtures generate")
+		checkOutputSchema(t, s, runSchemaCmd(t, app, newFixturesCmd, "generate", "--issues", "20", "--seed", "1"))
+	})
+	covered["fixtures generate"] = true
+
+	t.Run("bridge github", func(t *testing.T) {
+		app, store := setupTestApp(t)
+		if _, err := store.Create(ctx, &issuestorage.Issue{Title: "Fix login"}); err != nil {
+			t.Fatal(err)
+		}
+		gh := &fakeGitHub{clock: clock.NewFake(time.Now()), issues: map[int]*githubIssue{}}
+		gh.add("Docs typo", "OPEN")
+		s, _ := findOutputSchema("bridge github")
+		newCmd := func(p *AppProvider) *cobra.Command { return bridgeGitHubCmd(p, gh.exec) }
+		checkOutputSchema(t, s, runSchemaCmd(t, app, newCmd, "sync"))
+	})
+	covered["bridge github"] = true
+
+	t.Run("reindex", func(t *testing.T) {
+		app, _, _ := setupIndexedSearchApp(t)
+		s, _ := findOutputSchema("reindex")
+		checkOutputSchema(t, s, runSchemaCmd(t, app, newReindexCmd))
+	})
+	covered["reindex"] = true
+
+	for _, s := range outputSchemas {
+		if !covered[s.name] {
+			t.Errorf("schema %q is not exercised by TestOutputSchemas", s.name)
+		}
+	}
+}
+
+// checkOutputSchema validates a command's --json output against s and
+// checks it carries no emoji.
+func checkOutputSchema(t *testing.T, s *schema.Schema, out []byte) {
+	t.Helper()
+	if err := schema.ValidateJSON(s, out); err != nil {
+		t.Errorf("%v\noutput: %s", err, out)
+	}
+	var v any
+	if err := json.Unmarshal(out, &v); err != nil {
+		t.Fatal(err)
+	}
+	if err := schema.CheckEmojiFree(v); err != nil {
+		t.Error(err)
+	}
+}
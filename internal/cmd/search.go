@@ -1,19 +1,29 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
+	"time"
 
 	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/issuestorage/indexed"
+
 	"github.com/spf13/cobra"
 )
 
 // newSearchCmd creates the search command.
 func newSearchCmd(provider *AppProvider) *cobra.Command {
 	var (
-		titleOnly bool
-		status    string
+		titleOnly  bool
+		statusName string
+		sortKey    string
+		reverse    bool
+		snippets   bool
 	)
 
 	cmd := &cobra.Command{
@@ -22,8 +32,24 @@ func newSearchCmd(provider *AppProvider) *cobra.Command {
 		Long: `Search for issues matching the given query.
 
 By default, searches both open and closed issues in title and description.
+For questions, the accepted answer is searched too and shown with the match.
 Use --status to filter by a specific status.
-Use --title-only to search only in titles.`,
+Use --title-only to search only in titles.
+
+The query is split into words; an issue matches when it contains every
+word, either whole or as the start of a longer word, so "auth" finds
+"authentication". Labels and comments are searched too. Case and
+punctuation are ignored.
+
+Matches are listed best first unless --sort is given: title matches rank
+above label matches, then description, then comment matches, and rarer
+words count more. Issues not yet closed, and recently updated ones, are
+boosted. When the best-matching field is not the title, an excerpt of it
+is shown with the matching words highlighted; --snippets adds it to
+--json output as "match".
+
+With the filesystem backend, search uses an index kept in .beads/index/
+and updated as issues change; run "bd reindex" to rebuild it.`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			app, err := provider.Get()
@@ -31,46 +57,30 @@ Use --title-only to search only in titles.`,
 				return err
 			}
 
-			ctx := cmd.Context()
-			query := strings.ToLower(args[0])
-
-			var matches []*issuestorage.Issue
-			var issues []*issuestorage.Issue
-
-			if status != "" {
-				s, err := parseStatus(status, getCustomValues(app, "status.custom"))
-				if err != nil {
+			var status issuestorage.Status
+			if statusName != "" {
+				if status, err = parseStatus(statusName, getCustomValues(app, "status.custom")); err != nil {
 					return err
 				}
-				filter := &issuestorage.ListFilter{Statuses: []issuestorage.Status{s}}
-				issues, err = app.Storage.List(ctx, filter)
-				if err != nil {
-					return fmt.Errorf("listing issues: %w", err)
-				}
-			} else {
-				openIssues, err := app.Storage.List(ctx, nil)
-				if err != nil {
-					return fmt.Errorf("listing open issues: %w", err)
-				}
-				issues = append(issues, openIssues...)
-
-				closedIssues, err := app.Storage.List(ctx, &issuestorage.ListFilter{Statuses: []issuestorage.Status{issuestorage.StatusClosed}})
-				if err != nil {
-					return fmt.Errorf("listing closed issues: %w", err)
-				}
-				issues = append(issues, closedIssues...)
 			}
-
-			for _, issue := range issues {
-				if matchesQuery(issue, query, titleOnly) {
-					matches = append(matches, issue)
-				}
+			matches, err := searchIssues(cmd.Context(), app, args[0], titleOnly, status)
+			if err != nil {
+				return err
+			}
+			if err := sortIssues(matches, sortKey, reverse); err != nil {
+				return err
 			}
 
 			if app.JSON {
 				results := make([]IssueListJSON, len(matches))
 				for i, issue := range matches {
 					results[i] = ToIssueListJSON(issue)
+					if answer := issue.Answer(); answer != nil {
+						results[i].Answer = answer.Text
+					}
+					if snippets {
+						results[i].Match = searchMatch(issue, args[0], titleOnly)
+					}
 				}
 				return json.NewEncoder(app.Out).Encode(results)
 			}
@@ -80,31 +90,179 @@ Use --title-only to search only in titles.`,
 				return nil
 			}
 
+			// Highlights are bold in color; otherwise snippets mark them
+			// **like this** and titles go unmarked.
+			bold := func(s string) string { return "**" + s + "**" }
+			plainTitle := func(s string) string { return s }
+			if app.IsColor() {
+				bold = func(s string) string { return app.Colorize(s, "1") }
+				plainTitle = bold
+			}
+
 			fmt.Fprintf(app.Out, "Found %d matches:\n", len(matches))
 			for _, issue := range matches {
 				statusStr := ""
 				if issue.Status != issuestorage.StatusOpen {
 					statusStr = fmt.Sprintf(" [%s]", issue.Status)
 				}
-				fmt.Fprintf(app.Out, "  %s  %s%s\n", issue.ID, issue.Title, statusStr)
+				match := searchMatch(issue, args[0], titleOnly)
+				title := issue.Title
+				if match != nil && match.Field == "title" {
+					title = indexed.Highlight(match.Snippet, match.Highlights, plainTitle)
+				}
+				fmt.Fprintf(app.Out, "  %s  %s%s\n", issue.ID, title, statusStr)
+				if answer := issue.Answer(); answer != nil {
+					fmt.Fprintf(app.Out, "      ↳ Answer: %s\n", firstLine(answer.Text))
+				}
+				if match != nil && match.Field != "title" && match.Field != "answer" {
+					fmt.Fprintf(app.Out, "      ↳ %s: %s\n", match.Field, indexed.Highlight(match.Snippet, match.Highlights, bold))
+				}
 			}
 
 			return nil
 		},
 	}
 
-	cmd.Flags().StringVarP(&status, "status", "s", "", "Filter by status ("+statusNames(nil)+")")
+	cmd.Flags().StringVarP(&statusName, "status", "s", "", "Filter by status ("+statusNames(nil)+")")
 	cmd.Flags().BoolVar(&titleOnly, "title-only", false, "Only search titles")
+	cmd.Flags().BoolVar(&snippets, "snippets", false, "Include the best-matching excerpt in --json output")
+	addSortFlags(cmd, &sortKey, &reverse, "")
 
 	return cmd
 }
 
-func matchesQuery(issue *issuestorage.Issue, query string, titleOnly bool) bool {
-	if strings.Contains(strings.ToLower(issue.Title), query) {
-		return true
+// searchIssues returns the issues matching query, best match first (see
+// searchBoost), with ties in creation order. With a status only issues in
+// that status are searched; otherwise open and closed ones. Without a
+// search index (other backends) the issues are listed and indexed in
+// memory.
+func searchIssues(ctx context.Context, app *App, query string, titleOnly bool, status issuestorage.Status) ([]*issuestorage.Issue, error) {
+	var hits []indexed.Hit
+	issues := make(map[string]*issuestorage.Issue)
+	if app.SearchIndex != nil {
+		var err error
+		if hits, err = app.SearchIndex.Search(ctx, query, titleOnly); err != nil {
+			return nil, fmt.Errorf("searching: %w", err)
+		}
+		for _, hit := range hits {
+			issue, err := app.Storage.Get(ctx, hit.ID)
+			if errors.Is(err, issuestorage.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("getting %s: %w", hit.ID, err)
+			}
+			wanted := issue.Status != issuestorage.StatusTombstone
+			if status != "" {
+				wanted = issue.Status == status
+			}
+			if wanted {
+				issues[issue.ID] = issue
+			}
+		}
+	} else {
+		filter := &issuestorage.ListFilter{Statuses: []issuestorage.Status{issuestorage.StatusClosed}}
+		if status != "" {
+			filter.Statuses = []issuestorage.Status{status}
+		}
+		listed, err := app.Storage.List(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("listing issues: %w", err)
+		}
+		if status == "" {
+			open, err := app.Storage.List(ctx, nil)
+			if err != nil {
+				return nil, fmt.Errorf("listing open issues: %w", err)
+			}
+			listed = append(open, listed...)
+		}
+		ix := indexed.NewIndex()
+		for _, issue := range listed {
+			ix.Add(issue, "")
+			issues[issue.ID] = issue
+		}
+		hits = ix.Search(query, titleOnly)
+	}
+
+	var matches []*issuestorage.Issue
+	scores := make(map[string]float64, len(hits))
+	now := app.Now()
+	for _, hit := range hits {
+		if issue, ok := issues[hit.ID]; ok {
+			matches = append(matches, issue)
+			scores[hit.ID] = hit.Score * searchBoost(issue, now)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if scores[a.ID] != scores[b.ID] {
+			return scores[a.ID] > scores[b.ID]
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID < b.ID
+	})
+	return matches, nil
+}
+
+// Search boosts multiply an issue's text score. Issues not yet closed get
+// searchOpenBoost; recently updated ones up to 1+searchRecencyBoost, the
+// extra halving every searchRecencyHalfLife since the last update.
+const (
+	searchOpenBoost       = 1.25
+	searchRecencyBoost    = 0.25
+	searchRecencyHalfLife = 30 * 24 * time.Hour
+)
+
+// searchBoost returns the factor issue's text score is multiplied by.
+func searchBoost(issue *issuestorage.Issue, now time.Time) float64 {
+	boost := 1.0
+	if issue.Status != issuestorage.StatusClosed {
+		boost = searchOpenBoost
 	}
-	if !titleOnly && strings.Contains(strings.ToLower(issue.Description), query) {
-		return true
+	age := max(0, now.Sub(issue.UpdatedAt))
+	return boost * (1 + searchRecencyBoost*math.Pow(0.5, float64(age)/float64(searchRecencyHalfLife)))
+}
+
+// searchSnippetWidth is the length in bytes of a search excerpt.
+const searchSnippetWidth = 80
+
+// searchMatch returns the highest-ranked field of issue matching query,
+// with an excerpt of it: the title, labels, description, accepted answer
+// or another comment, in that order. With titleOnly only the title is
+// considered. It returns nil if no field matches, as for an empty query.
+func searchMatch(issue *issuestorage.Issue, query string, titleOnly bool) *SearchMatchJSON {
+	type field struct{ name, text string }
+	fields := []field{{"title", issue.Title}}
+	if !titleOnly {
+		fields = append(fields, field{"labels", strings.Join(issue.Labels, ", ")}, field{"description", issue.Description})
+		answer := issue.Answer()
+		if answer != nil {
+			fields = append(fields, field{"answer", answer.Text})
+		}
+		for _, c := range issue.Comments {
+			if answer == nil || c.ID != answer.ID {
+				fields = append(fields, field{"comment", c.Text})
+			}
+		}
+	}
+	for _, f := range fields {
+		width := searchSnippetWidth
+		if f.name == "title" {
+			width = len(f.text) // titles are shown whole
+		}
+		if snippet, highlights, ok := indexed.Snippet(f.text, query, width); ok {
+			return &SearchMatchJSON{Field: f.name, Snippet: snippet, Highlights: highlights}
+		}
+	}
+	return nil
+}
+
+// firstLine returns the first line of s.
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
 	}
-	return false
+	return s
 }
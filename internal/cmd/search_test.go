@@ -4,12 +4,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"beads-lite/internal/clock"
 	"beads-lite/internal/issueservice"
 	"beads-lite/internal/issuestorage"
 	"beads-lite/internal/issuestorage/filesystem"
+	"beads-lite/internal/issuestorage/indexed"
 )
 
 func TestSearchCmd_NoArgs(t *testing.T) {
@@ -302,3 +306,175 @@ func TestSearchCmd_JSON(t *testing.T) {
 		t.Errorf("expected 2 results, got %d", len(results))
 	}
 }
+
+// setupIndexedSearchApp returns an app whose store is wrapped in a search
+// index, as for the filesystem backend, and the unwrapped store.
+func setupIndexedSearchApp(t *testing.T) (*App, *issueservice.IssueStore, *filesystem.FilesystemStorage) {
+	t.Helper()
+	dir := t.TempDir()
+	s := filesystem.New(dir, "bd-")
+	if err := s.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init storage: %v", err)
+	}
+	index := indexed.New(s, s, filepath.Join(dir, indexed.DirName))
+	rs := issueservice.New(nil, index)
+	return &App{Storage: rs, SearchIndex: index, Out: &bytes.Buffer{}, Err: &bytes.Buffer{}, JSON: true}, rs, s
+}
+
+func searchIDs(t *testing.T, app *App, args ...string) []string {
+	t.Helper()
+	out := app.Out.(*bytes.Buffer)
+	out.Reset()
+	cmd := newSearchCmd(NewTestProvider(app))
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("search %v failed: %v", args, err)
+	}
+	var results []IssueListJSON
+	if err := json.Unmarshal(out.Bytes(), &results); err != nil {
+		t.Fatalf("failed to parse JSON output: %v", err)
+	}
+	var ids []string
+	for _, r := range results {
+		ids = append(ids, r.ID)
+	}
+	return ids
+}
+
+func TestSearchCmd_Indexed(t *testing.T) {
+	app, rs, s := setupIndexedSearchApp(t)
+	ctx := context.Background()
+
+	docs, _ := rs.Create(ctx, &issuestorage.Issue{Title: "Update docs", Description: "Explain the authentication flow"})
+	fix, _ := rs.Create(ctx, &issuestorage.Issue{Title: "Fix authentication timeout", Description: "Authentication times out"})
+	closed, _ := rs.Create(ctx, &issuestorage.Issue{Title: "Old authentication bug"})
+	if err := rs.Modify(ctx, closed, func(i *issuestorage.Issue) error {
+		i.Status = issuestorage.StatusClosed
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := strings.Join(searchIDs(t, app, "auth"), ","), strings.Join([]string{fix, closed, docs}, ","); got != want {
+		t.Errorf("ranked prefix search = %s, want %s", got, want)
+	}
+	if got := searchIDs(t, app, "auth", "--status", "closed"); len(got) != 1 || got[0] != closed {
+		t.Errorf("--status closed = %v, want [%s]", got, closed)
+	}
+	if got := searchIDs(t, app, "auth", "--sort", "id"); len(got) != 3 || got[0] > got[1] || got[1] > got[2] {
+		t.Errorf("--sort id = %v", got)
+	}
+	if got := searchIDs(t, app, "authentication flow"); len(got) != 1 || got[0] != docs {
+		t.Errorf("multi-word search = %v, want [%s]", got, docs)
+	}
+
+	// An issue written without going through the index is still found.
+	pulled, err := s.Create(ctx, &issuestorage.Issue{Title: "Pulled from a teammate", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := searchIDs(t, app, "teammate"); len(got) != 1 || got[0] != pulled {
+		t.Errorf("outside write: search = %v, want [%s]", got, pulled)
+	}
+}
+
+func TestReindexCmd(t *testing.T) {
+	app, rs, _ := setupIndexedSearchApp(t)
+	ctx := context.Background()
+	rs.Create(ctx, &issuestorage.Issue{Title: "One"})
+	rs.Create(ctx, &issuestorage.Issue{Title: "Two"})
+
+	out := app.Out.(*bytes.Buffer)
+	cmd := newReindexCmd(NewTestProvider(app))
+	cmd.SetArgs(nil)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("reindex failed: %v", err)
+	}
+	var result ReindexJSON
+	if err := json.Unmarshal(out.Bytes(), &result); err != nil || result.Indexed != 2 {
+		t.Errorf("reindex = %s (%v), want 2 indexed", out.String(), err)
+	}
+
+	app, _ = setupTestApp(t)
+	cmd = newReindexCmd(NewTestProvider(app))
+	cmd.SetArgs(nil)
+	if err := cmd.Execute(); err == nil {
+		t.Error("reindex without an index succeeded, want an error")
+	}
+}
+
+func TestSearchCmd_BoostsAndSnippets(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC))
+	store.SetClock(fake)
+
+	stale, _ := store.Create(ctx, &issuestorage.Issue{Title: "Sync drops edits"})
+	fake.Advance(90 * 24 * time.Hour)
+	fresh, _ := store.Create(ctx, &issuestorage.Issue{Title: "Sync drops edits"})
+	closed, _ := store.Create(ctx, &issuestorage.Issue{Title: "Sync drops edits"})
+	if err := store.Modify(ctx, closed, func(i *issuestorage.Issue) error {
+		i.Status = issuestorage.StatusClosed
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	labelled, _ := store.Create(ctx, &issuestorage.Issue{Title: "Offline mode", Labels: []string{"sync"}})
+	commented, _ := store.Create(ctx, &issuestorage.Issue{Title: "Flaky CI", Description: "Fails on the runner."})
+	if err := store.Modify(ctx, commented, func(i *issuestorage.Issue) error {
+		i.Comments = []issuestorage.Comment{{ID: 1, Text: "Looks like the nightly sync job overlaps with the test run."}}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	out := &bytes.Buffer{}
+	app.Out = out
+	run := func(args ...string) string {
+		t.Helper()
+		out.Reset()
+		cmd := newSearchCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("search %v: %v", args, err)
+		}
+		return out.String()
+	}
+
+	text := run("sync")
+	rank := make(map[string]int)
+	for _, line := range strings.Split(text, "\n") {
+		if fields := strings.Fields(line); len(fields) > 1 && strings.HasPrefix(fields[0], "bd-") {
+			rank[fields[0]] = len(rank)
+		}
+	}
+	for _, pair := range [][2]string{
+		{fresh, stale},        // recently updated first
+		{fresh, closed},       // open first
+		{fresh, labelled},     // title before labels
+		{labelled, commented}, // labels before comments
+	} {
+		if rank[pair[0]] > rank[pair[1]] {
+			t.Errorf("%s ranked below %s:\n%s", pair[0], pair[1], text)
+		}
+	}
+	if !strings.Contains(text, "↳ comment: Looks like the nightly **sync** job") || !strings.Contains(text, "↳ labels: **sync**") {
+		t.Errorf("snippets missing from:\n%s", text)
+	}
+
+	app.JSON = true
+	var results []IssueListJSON
+	if err := json.Unmarshal([]byte(run("sync", "--snippets")), &results); err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 5 || results[0].ID != fresh || results[0].Match.Field != "title" || results[4].Match.Field != "comment" {
+		t.Fatalf("results = %+v", results)
+	}
+	if m := results[4].Match; m.Snippet[m.Highlights[0][0]:m.Highlights[0][1]] != "sync" {
+		t.Errorf("highlight = %+v", m)
+	}
+	var plain []IssueListJSON
+	if err := json.Unmarshal([]byte(run("sync")), &plain); err != nil || plain[0].Match != nil {
+		t.Errorf("match without --snippets: %+v, %v", plain[0], err)
+	}
+}
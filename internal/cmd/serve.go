@@ -0,0 +1,816 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"beads-lite/internal/apitoken"
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultServeAddr is where bd serve listens unless --addr is given. It
+// binds to loopback only, as the API needs no token until one is created.
+const defaultServeAddr = "127.0.0.1:7373"
+
+// serveShutdownTimeout bounds how long bd serve waits for in-flight
+// requests after an interrupt.
+const serveShutdownTimeout = 5 * time.Second
+
+// newServeCmd creates the serve command.
+func newServeCmd(provider *AppProvider) *cobra.Command {
+	var (
+		addr       string
+		readOnly   bool
+		noAuth     bool
+		basePath   string
+		tlsCert    string
+		tlsKey     string
+		selfSigned bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the issue store over an HTTP/JSON API",
+		Long: `Start a long-running HTTP server exposing the issue store as a JSON API,
+so dashboards and agents can talk to beads without shelling out to bd.
+
+Each request runs the same command the CLI would, against the same
+storage backend and config, so validation and side effects match. A
+response body is exactly what that command prints with --json; errors
+are {"error": "..."} with status 400, 403 (read-only, or a rejected Host
+or Origin), 404 (unknown issue) or 415 (a write without a JSON body).
+
+Endpoints:
+  GET    /api/health
+  GET    /api/issues                  bd list; query parameters are list flags
+                                      (?status=open&label=api&assignee=alice)
+  POST   /api/issues                  bd create; body {"title": ..., "type": ...,
+                                      "priority": ..., "labels": [...], ...}
+  GET    /api/issues/{id}             bd show
+  PATCH  /api/issues/{id}             bd update; body {"status": ..., "add_label": [...], ...}
+  POST   /api/issues/{id}/close       bd close; body {"reason": ..., "duplicate_of": ...}
+  POST   /api/issues/{id}/reopen      bd reopen
+  GET    /api/issues/{id}/comments    bd comments
+  POST   /api/issues/{id}/comments    bd comments add; body {"text": ..., "author": ...}
+  GET    /api/issues/{id}/deps        bd dep list
+  POST   /api/issues/{id}/deps        bd dep add; body {"depends_on": ..., "type": ...}
+  DELETE /api/issues/{id}/deps/{dep}  bd dep remove
+
+Views, for bookmarking:
+  GET    /api/board                   bd board (?rows=epic&columns=priority)
+  GET    /api/contexts                bd context list
+  GET    /api/contexts/{name}/issues  bd list in a saved filter context
+  GET    /api/contexts/{name}/board   bd board in a saved filter context
+  GET    /api/reports/stats           bd stats (?burndown=true&by=week)
+  GET    /api/reports/workload        bd workload
+  GET    /api/reports/risks           bd risks
+  GET    /api/reports/sprint[/{name}] bd sprint report
+
+Body and query keys are the command's flag names with underscores for
+dashes. Any GET endpoint also takes ?format=text, which responds with
+what the command prints without --json, as plain text, for reading in a
+browser. --read-only rejects every request that would change an issue.
+
+Once an API token exists (see bd serve token), every request but
+/api/health needs one, as Authorization: Bearer <token>, and read tokens
+may only GET; a missing or unknown token gets status 401. Issues created
+with a token are created by the token's name (its ID if unnamed), and
+without tokens by the server's actor. Without tokens
+the server accepts anyone, so it refuses to listen beyond loopback
+unless --no-auth is given. Every request that modifies issues is
+recorded in .beads/` + serveAuditFile + `; see bd serve log.
+
+So that web pages cannot drive the API from a browser, every request
+other than GET must have Content-Type: application/json and no Origin
+other than a loopback one, and every request's Host must name the
+listen address: any loopback name when listening on loopback, and an IP
+address or this machine's hostname when listening on all interfaces.
+
+Deployment is configured with bd config set, or the matching flags:
+  serve.base_path        path prefix to serve the API under, e.g. /beads
+                         (--base-path)
+  serve.trusted_proxies  comma-separated IPs or CIDR ranges of reverse
+                         proxies: their requests may use any Host, a page
+                         on that host may write, and X-Forwarded-For names
+                         the client in the audit log
+  serve.cors_origins     comma-separated origins, or *, whose pages may
+                         call the API from a browser
+  serve.tls_cert         certificate file to serve HTTPS with (--tls-cert)
+  serve.tls_key          its key file (--tls-key)
+  serve.tls_self_signed  true to serve HTTPS with a certificate generated
+                         in .beads/` + serveTLSDir + `/ (--tls-self-signed)
+
+Examples:
+  bd serve
+  bd serve --addr 127.0.0.1:8080 --read-only
+  bd serve token create --scope write --name ci
+  bd serve --addr 0.0.0.0:7373
+  bd serve --addr 0.0.0.0:7443 --tls-self-signed
+  bd config set serve.base_path /beads
+  bd config set serve.trusted_proxies 127.0.0.1
+  curl -s localhost:7373/api/issues?status=open
+  open 'http://localhost:7373/api/contexts/p0/board?format=text'
+  curl -s -X POST localhost:7373/api/issues -H 'Content-Type: application/json' \
+    -d '{"title": "Fix login", "priority": 1}'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+
+			opts, err := loadServeOptions(app)
+			if err != nil {
+				return err
+			}
+			opts.readOnly = readOnly
+			if cmd.Flags().Changed("base-path") {
+				if opts.basePath, err = parseBasePath(basePath); err != nil {
+					return err
+				}
+			}
+			if cmd.Flags().Changed("tls-cert") || cmd.Flags().Changed("tls-key") {
+				opts.tlsCert, opts.tlsKey = tlsCert, tlsKey
+			}
+			if cmd.Flags().Changed("tls-self-signed") {
+				opts.selfSigned = selfSigned
+			}
+
+			if host, _, err := net.SplitHostPort(addr); err == nil && !isLoopbackHost(host) && !noAuth {
+				tokens, err := listTokens(cmd.Context(), app)
+				if err != nil {
+					return err
+				}
+				if len(tokens) == 0 {
+					return fmt.Errorf("refusing to serve %s without authentication: create a token with 'bd serve token create', or pass --no-auth", addr)
+				}
+			}
+
+			ln, err := net.Listen("tcp", addr)
+			if err != nil {
+				return fmt.Errorf("listening on %s: %w", addr, err)
+			}
+			var names []string
+			if host, _, err := net.SplitHostPort(addr); err == nil && host != "" && net.ParseIP(host) == nil {
+				names = append(names, host)
+			}
+			api := newAPIServer(app, opts, ln.Addr().String(), names...)
+			tlsConfig, err := opts.tlsConfig(app, api.certHosts())
+			if err != nil {
+				ln.Close()
+				return err
+			}
+			srv := &http.Server{
+				Handler:           api.handler(),
+				TLSConfig:         tlsConfig,
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			go func() {
+				<-ctx.Done()
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+				defer cancel()
+				srv.Shutdown(shutdownCtx)
+			}()
+
+			scheme := "http"
+			if tlsConfig != nil {
+				scheme = "https"
+			}
+			url := scheme + "://" + ln.Addr().String() + opts.basePath
+			if app.JSON {
+				json.NewEncoder(app.Out).Encode(map[string]any{"url": url, "read_only": readOnly})
+			} else {
+				mode := ""
+				if readOnly {
+					mode = " (read-only)"
+				}
+				fmt.Fprintf(app.Out, "%s Serving %s on %s%s\n", app.SuccessColor("✓"), app.ConfigDir, url, mode)
+				if tlsConfig != nil && opts.tlsCert == "" {
+					fmt.Fprintf(app.Out, "  Self-signed certificate SHA-256 fingerprint: %s\n", certFingerprint(tlsConfig.Certificates[0]))
+				}
+			}
+
+			if tlsConfig != nil {
+				err = srv.ServeTLS(ln, "", "")
+			} else {
+				err = srv.Serve(ln)
+			}
+			if !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&addr, "addr", defaultServeAddr, "Address to listen on (host:port)")
+	cmd.Flags().BoolVar(&readOnly, "read-only", false, "Reject requests that would modify issues")
+	cmd.Flags().BoolVar(&noAuth, "no-auth", false, "Listen beyond loopback even if no API token exists")
+	cmd.Flags().StringVar(&basePath, "base-path", "", "Path prefix to serve the API under (default: serve.base_path config)")
+	cmd.Flags().StringVar(&tlsCert, "tls-cert", "", "Certificate file to serve HTTPS with (default: serve.tls_cert config)")
+	cmd.Flags().StringVar(&tlsKey, "tls-key", "", "Key file for --tls-cert (default: serve.tls_key config)")
+	cmd.Flags().BoolVar(&selfSigned, "tls-self-signed", false, "Serve HTTPS with a generated certificate (default: serve.tls_self_signed config)")
+
+	cmd.AddCommand(newServeTokenCmd(provider))
+	cmd.AddCommand(newServeLogCmd(provider))
+
+	return cmd
+}
+
+// API field allowlists: the body or query keys each endpoint passes on to
+// its command as flags. Flags that read local files or stdin are left out.
+var (
+	apiListFields   = []string{"status", "priority", "severity", "type", "mol_type", "label", "label_all", "parent", "assignee", "reporter", "all", "closed", "roots", "limit", "created_after", "created_before", "sort", "reverse", "context", "no_context"}
+	apiCreateFields = []string{"title", "description", "type", "priority", "severity", "likelihood", "impact", "review_by", "parent", "deps", "labels", "assignee", "reporter", "criteria", "id", "ephemeral"}
+	apiUpdateFields = []string{"title", "description", "priority", "severity", "likelihood", "impact", "review_by", "type", "status", "assignee", "reporter", "parent", "add_label", "remove_label", "claim", "force"}
+	apiCloseFields  = []string{"reason", "duplicate_of"}
+	apiCommentField = []string{"author", "force"}
+	apiDepFields    = []string{"type"}
+
+	apiBoardFields    = []string{"all", "rows", "group_by", "columns", "context", "no_context"}
+	apiStatsFields    = []string{"created_after", "created_before", "ids", "burndown", "throughput", "by", "periods"}
+	apiWorkloadFields = []string{"label"}
+	apiRisksFields    = []string{"all", "overdue", "min_exposure"}
+)
+
+// apiFormatParam is the query parameter choosing between JSON and text
+// responses; it is the server's own, not passed on to the command.
+const apiFormatParam = "format"
+
+// apiServer maps HTTP requests onto bd commands run in-process.
+type apiServer struct {
+	app  *App
+	opts serveOptions
+
+	// listenIP and listenPort are the listener's address, and hostNames
+	// any other names a request's Host may use for it; see hostAllowed.
+	listenIP   net.IP
+	listenPort string
+	hostNames  []string
+
+	// mu runs one request at a time: commands share the app, and the
+	// issue service's routing cache is not safe for concurrent use.
+	mu sync.Mutex
+}
+
+// newAPIServer returns an apiServer for app listening on listenAddr (an IP
+// and port). names are further host names requests may address it by,
+// such as the one given to --addr.
+func newAPIServer(app *App, opts serveOptions, listenAddr string, names ...string) *apiServer {
+	s := &apiServer{app: app, opts: opts, hostNames: names}
+	host, port, _ := net.SplitHostPort(listenAddr)
+	s.listenIP, s.listenPort = net.ParseIP(host), port
+	if s.listenIP != nil && s.listenIP.IsUnspecified() {
+		if hostname, err := os.Hostname(); err == nil {
+			s.hostNames = append(s.hostNames, hostname)
+		}
+	}
+	return s
+}
+
+// newAPIHandler returns the bd serve HTTP handler; see newAPIServer.
+func newAPIHandler(app *App, opts serveOptions, listenAddr string, names ...string) http.Handler {
+	return newAPIServer(app, opts, listenAddr, names...).handler()
+}
+
+// certHosts returns the names a generated certificate should cover: the
+// loopback names, and the listen address and names.
+func (s *apiServer) certHosts() []string {
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+	if s.listenIP != nil && !s.listenIP.IsUnspecified() && !s.listenIP.IsLoopback() {
+		hosts = append(hosts, s.listenIP.String())
+	}
+	for _, name := range s.hostNames {
+		if !contains(hosts, name) {
+			hosts = append(hosts, name)
+		}
+	}
+	return hosts
+}
+
+// handler returns the bd serve HTTP handler.
+func (s *apiServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	handle := func(pattern string, h func(http.ResponseWriter, *http.Request)) {
+		mux.HandleFunc(pattern, s.serialized(h))
+	}
+	handle("GET /api/health", s.health)
+	handle("GET /api/issues", s.listIssues)
+	handle("POST /api/issues", s.createIssue)
+	handle("GET /api/issues/{id}", s.showIssue)
+	handle("PATCH /api/issues/{id}", s.updateIssue)
+	handle("POST /api/issues/{id}/close", s.closeIssue)
+	handle("POST /api/issues/{id}/reopen", s.reopenIssue)
+	handle("GET /api/issues/{id}/comments", s.listComments)
+	handle("POST /api/issues/{id}/comments", s.addComment)
+	handle("GET /api/issues/{id}/deps", s.listDeps)
+	handle("POST /api/issues/{id}/deps", s.addDep)
+	handle("DELETE /api/issues/{id}/deps/{dep}", s.removeDep)
+	handle("GET /api/board", s.view(newBoardCmd, nil, apiBoardFields))
+	handle("GET /api/contexts", s.view(newContextCmd, []string{"list"}, nil))
+	handle("GET /api/contexts/{name}/issues", s.contextView(newListCmd, apiListFields))
+	handle("GET /api/contexts/{name}/board", s.contextView(newBoardCmd, apiBoardFields))
+	handle("GET /api/reports/stats", s.view(newStatsCmd, nil, apiStatsFields))
+	handle("GET /api/reports/workload", s.view(newWorkloadCmd, nil, apiWorkloadFields))
+	handle("GET /api/reports/risks", s.view(newRisksCmd, nil, apiRisksFields))
+	handle("GET /api/reports/sprint", s.view(newSprintCmd, []string{"report"}, nil))
+	handle("GET /api/reports/sprint/{name}", s.sprintReport)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, http.StatusNotFound, fmt.Sprintf("no endpoint %s %s", r.Method, r.URL.Path))
+	})
+	return s.mount(s.guard(mux))
+}
+
+// guard rejects requests for another host, which is how a DNS rebinding
+// attack arrives, requests without a valid token once tokens exist, and
+// modifying requests when the server or token is read-only or when a web
+// page could have sent them: browsers send a page's form posts and simple
+// fetches cross-origin without asking, but never with a JSON Content-Type,
+// and always with the page's Origin. Modifying requests that get through
+// are recorded in the audit log.
+func (s *apiServer) guard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.trustedProxy(r) && !s.hostAllowed(r.Host) {
+			writeAPIError(w, http.StatusForbidden, fmt.Sprintf("host %q does not name this server", r.Host))
+			return
+		}
+		if s.cors(w, r) {
+			return
+		}
+		token, ok := s.authenticate(w, r)
+		if !ok {
+			return
+		}
+		if token != nil {
+			r = r.WithContext(context.WithValue(r.Context(), apiTokenKey{}, token))
+		}
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if s.opts.readOnly {
+			writeAPIError(w, http.StatusForbidden, "server is read-only")
+			return
+		}
+		if token != nil && !token.Scope.AllowsWrite() {
+			writeAPIError(w, http.StatusForbidden, fmt.Sprintf("token %s is read-only", token.ID))
+			return
+		}
+		if origin := r.Header.Get("Origin"); origin != "" && !s.originAllowed(r, origin) {
+			writeAPIError(w, http.StatusForbidden, fmt.Sprintf("cross-origin request from %s rejected", origin))
+			return
+		}
+		if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+			writeAPIError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+			return
+		}
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		record := ServeAuditJSON{At: formatTime(s.app.Now()), Remote: s.clientAddr(r), Method: r.Method, Path: r.URL.Path, Status: rec.status}
+		if token != nil {
+			record.TokenID, record.TokenName = token.ID, token.Name
+		}
+		if err := appendServeAudit(s.app, record); err != nil {
+			fmt.Fprintf(s.app.Err, "warning: recording API audit log: %v\n", err)
+		}
+	})
+}
+
+// apiTokenKey is the request context key of the token guard
+// authenticated the request with.
+type apiTokenKey struct{}
+
+// requestActor returns the actor a request acts as: the name of the token
+// it was authenticated with, or its ID if unnamed, and "" without one,
+// leaving the server's own actor.
+func requestActor(r *http.Request) string {
+	token, _ := r.Context().Value(apiTokenKey{}).(*apitoken.Token)
+	if token == nil {
+		return ""
+	}
+	if token.Name != "" {
+		return token.Name
+	}
+	return token.ID
+}
+
+// authenticate returns the token a request carries, nil if no tokens
+// exist or the request is a health check. It writes a 401 and returns
+// false if the token is missing or unknown.
+func (s *apiServer) authenticate(w http.ResponseWriter, r *http.Request) (*apitoken.Token, bool) {
+	if s.app.TokenStore == nil || r.URL.Path == "/api/health" {
+		return nil, true
+	}
+	ids, err := s.app.TokenStore.List(r.Context())
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, fmt.Sprintf("listing tokens: %v", err))
+		return nil, false
+	}
+	if len(ids) == 0 {
+		return nil, true
+	}
+	secret, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="bd"`)
+		writeAPIError(w, http.StatusUnauthorized, "API token required (Authorization: Bearer <token>)")
+		return nil, false
+	}
+	token, err := apitoken.Authenticate(r.Context(), s.app.TokenStore, strings.TrimSpace(secret))
+	if errors.Is(err, apitoken.ErrInvalid) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="bd", error="invalid_token"`)
+		writeAPIError(w, http.StatusUnauthorized, err.Error())
+		return nil, false
+	}
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	return &token, true
+}
+
+// statusRecorder remembers the status a handler responded with.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// hostAllowed reports whether a request's Host names the listener. When
+// listening on loopback any loopback name is accepted, as clients use
+// localhost and 127.0.0.1 interchangeably; on all interfaces, any IP
+// address or this machine's hostname is. A name such as evil.example that
+// merely resolves to the listener never matches.
+func (s *apiServer) hostAllowed(hostport string) bool {
+	host, port, err := net.SplitHostPort(hostport)
+	if err != nil {
+		host, port = hostport, "80"
+	}
+	if port != s.listenPort {
+		return false
+	}
+	for _, name := range s.hostNames {
+		if strings.EqualFold(host, name) {
+			return true
+		}
+	}
+	switch ip := net.ParseIP(host); {
+	case s.listenIP == nil:
+		return false
+	case s.listenIP.IsUnspecified():
+		return ip != nil || strings.EqualFold(host, "localhost")
+	case s.listenIP.IsLoopback():
+		return isLoopbackHost(host)
+	default:
+		return ip != nil && ip.Equal(s.listenIP)
+	}
+}
+
+// isLoopbackHost reports whether host is localhost or a loopback address.
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// serialized wraps h so requests run one at a time.
+func (s *apiServer) serialized(h func(http.ResponseWriter, *http.Request)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		h(w, r)
+	}
+}
+
+func (s *apiServer) health(w http.ResponseWriter, r *http.Request) {
+	writeAPIJSON(w, http.StatusOK, map[string]any{"status": "ok", "read_only": s.opts.readOnly})
+}
+
+func (s *apiServer) listIssues(w http.ResponseWriter, r *http.Request) {
+	args, err := queryFlags(r, apiListFields)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.run(w, r, http.StatusOK, newListCmd, args)
+}
+
+// view returns a handler running the command built by newCmd with args,
+// then the query parameters as flags.
+func (s *apiServer) view(newCmd func(*AppProvider) *cobra.Command, args []string, allowed []string) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		flags, err := queryFlags(r, allowed)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		s.run(w, r, http.StatusOK, newCmd, append(append([]string(nil), args...), flags...))
+	}
+}
+
+// contextView returns a handler running the command built by newCmd
+// scoped by the filter context named in the path, writing a 404 when there
+// is no such context.
+func (s *apiServer) contextView(newCmd func(*AppProvider) *cobra.Command, allowed []string) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("name")
+		if r.URL.Query().Has("context") {
+			writeAPIError(w, http.StatusBadRequest, `parameter "context" is given by the path`)
+			return
+		}
+		if s.app.ConfigStore == nil {
+			writeAPIError(w, http.StatusNotFound, fmt.Sprintf("no context named %q", name))
+			return
+		}
+		if _, ok := s.app.ConfigStore.Get(contextFilterKey(name)); !ok {
+			writeAPIError(w, http.StatusNotFound, fmt.Sprintf("no context named %q", name))
+			return
+		}
+		s.view(newCmd, []string{"--context=" + name}, allowed)(w, r)
+	}
+}
+
+func (s *apiServer) sprintReport(w http.ResponseWriter, r *http.Request) {
+	s.view(newSprintCmd, []string{"report", r.PathValue("name")}, nil)(w, r)
+}
+
+func (s *apiServer) createIssue(w http.ResponseWriter, r *http.Request) {
+	body, err := decodeAPIBody(r)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	args, err := bodyFlags(body, apiCreateFields)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if actor := requestActor(r); actor != "" {
+		args = append(args, "--actor="+actor)
+	}
+	s.run(w, r, http.StatusCreated, newCreateCmd, args)
+}
+
+func (s *apiServer) showIssue(w http.ResponseWriter, r *http.Request) {
+	if id, ok := s.resolveID(w, r, "id"); ok {
+		s.run(w, r, http.StatusOK, newShowCmd, []string{id})
+	}
+}
+
+func (s *apiServer) updateIssue(w http.ResponseWriter, r *http.Request) {
+	s.runWithBody(w, r, newUpdateCmd, nil, apiUpdateFields)
+}
+
+func (s *apiServer) closeIssue(w http.ResponseWriter, r *http.Request) {
+	s.runWithBody(w, r, newCloseCmd, nil, apiCloseFields)
+}
+
+func (s *apiServer) reopenIssue(w http.ResponseWriter, r *http.Request) {
+	s.runWithBody(w, r, newReopenCmd, nil, nil)
+}
+
+func (s *apiServer) listComments(w http.ResponseWriter, r *http.Request) {
+	if id, ok := s.resolveID(w, r, "id"); ok {
+		s.run(w, r, http.StatusOK, newCommentsCmd, []string{id})
+	}
+}
+
+func (s *apiServer) addComment(w http.ResponseWriter, r *http.Request) {
+	s.runWithBody(w, r, newCommentsCmd, []string{"add"}, apiCommentField, "text")
+}
+
+func (s *apiServer) listDeps(w http.ResponseWriter, r *http.Request) {
+	if id, ok := s.resolveID(w, r, "id"); ok {
+		s.run(w, r, http.StatusOK, newDepCmd, []string{"list", id})
+	}
+}
+
+func (s *apiServer) addDep(w http.ResponseWriter, r *http.Request) {
+	s.runWithBody(w, r, newDepCmd, []string{"add"}, apiDepFields, "depends_on")
+}
+
+func (s *apiServer) removeDep(w http.ResponseWriter, r *http.Request) {
+	id, ok := s.resolveID(w, r, "id")
+	if !ok {
+		return
+	}
+	dep, ok := s.resolveID(w, r, "dep")
+	if !ok {
+		return
+	}
+	s.run(w, r, http.StatusOK, newDepCmd, []string{"remove", id, dep})
+}
+
+// runWithBody runs an issue's subcommand with the request body as flags:
+// sub, then the allowed flags, then after "--" the issue ID and the
+// required positional fields in order, so a value starting with "-" is
+// taken literally rather than as a flag.
+func (s *apiServer) runWithBody(w http.ResponseWriter, r *http.Request, newCmd func(*AppProvider) *cobra.Command, sub []string, allowed []string, positional ...string) {
+	id, ok := s.resolveID(w, r, "id")
+	if !ok {
+		return
+	}
+	body, err := decodeAPIBody(r)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	args := []string{id}
+	for _, field := range positional {
+		value, _ := body[field].(string)
+		if strings.TrimSpace(value) == "" {
+			writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("%q is required", field))
+			return
+		}
+		if value == "-" {
+			writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("%q cannot be read from stdin", field))
+			return
+		}
+		delete(body, field)
+		args = append(args, value)
+	}
+	flags, err := bodyFlags(body, allowed)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	argv := append(append(append([]string(nil), sub...), flags...), "--")
+	s.run(w, r, http.StatusOK, newCmd, append(argv, args...))
+}
+
+// resolveID resolves the named path value to a full issue ID, writing a
+// 404 when no issue matches.
+func (s *apiServer) resolveID(w http.ResponseWriter, r *http.Request, name string) (string, bool) {
+	query := r.PathValue(name)
+	issue, err := resolveIssue(s.app.Storage, r.Context(), query)
+	if errors.Is(err, issuestorage.ErrNotFound) {
+		writeAPIError(w, http.StatusNotFound, fmt.Sprintf("no issue found matching %q", query))
+		return "", false
+	}
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return "", false
+	}
+	return issue.ID, true
+}
+
+// run executes the command built by newCmd with args, and writes what it
+// prints as the response: its JSON output, or for a GET with
+// ?format=text, its text output.
+func (s *apiServer) run(w http.ResponseWriter, r *http.Request, status int, newCmd func(*AppProvider) *cobra.Command, args []string) {
+	format := r.URL.Query().Get(apiFormatParam)
+	if r.Method != http.MethodGet {
+		format = ""
+	}
+	var (
+		out []byte
+		err error
+	)
+	switch format {
+	case "", "json":
+		out, err = runJSONCommand(r.Context(), s.app, newCmd, args)
+	case "text":
+		out, err = runCommand(r.Context(), s.app, newCmd, args, false)
+	default:
+		writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("unknown format %q (want json or text)", format))
+		return
+	}
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if format == "text" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	w.WriteHeader(status)
+	w.Write(out)
+}
+
+// runJSONCommand runs the command built by newCmd in-process, against a
+// copy of app with --json set, and returns what it printed to stdout.
+// Anything it prints to stderr is discarded.
+func runJSONCommand(ctx context.Context, app *App, newCmd func(*AppProvider) *cobra.Command, args []string) ([]byte, error) {
+	return runCommand(ctx, app, newCmd, args, true)
+}
+
+// runCommand runs the command built by newCmd in-process, against a copy
+// of app with --json set to asJSON, and returns what it printed to stdout.
+func runCommand(ctx context.Context, app *App, newCmd func(*AppProvider) *cobra.Command, args []string, asJSON bool) ([]byte, error) {
+	var out, errOut bytes.Buffer
+	cmdApp := *app
+	cmdApp.Out, cmdApp.Err, cmdApp.JSON = &out, &errOut, asJSON
+	cmd := newCmd(&AppProvider{app: &cmdApp, JSONOutput: asJSON, Out: &out, Err: &errOut})
+	cmd.SetArgs(args)
+	cmd.SetIn(strings.NewReader(""))
+	cmd.SetOut(&errOut)
+	cmd.SetErr(&errOut)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	if err := cmd.ExecuteContext(ctx); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// decodeAPIBody reads a JSON object request body. An empty body is an
+// empty object.
+func decodeAPIBody(r *http.Request) (map[string]any, error) {
+	body := map[string]any{}
+	dec := json.NewDecoder(r.Body)
+	dec.UseNumber()
+	if err := dec.Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid JSON body: %w", err)
+	}
+	return body, nil
+}
+
+// bodyFlags converts body fields to --flag=value arguments, in sorted
+// order. Arrays repeat the flag.
+func bodyFlags(body map[string]any, allowed []string) ([]string, error) {
+	var args []string
+	for _, key := range sortedKeys(body) {
+		if !contains(allowed, key) {
+			return nil, fmt.Errorf("unknown field %q (allowed: %s)", key, strings.Join(allowed, ", "))
+		}
+		values, ok := body[key].([]any)
+		if !ok {
+			values = []any{body[key]}
+		}
+		for _, v := range values {
+			var s string
+			switch v := v.(type) {
+			case string:
+				s = v
+			case json.Number:
+				s = v.String()
+			case bool:
+				s = strconv.FormatBool(v)
+			default:
+				return nil, fmt.Errorf("field %q: expected a string, number, boolean or array of them", key)
+			}
+			if s == "-" {
+				return nil, fmt.Errorf("field %q cannot be read from stdin", key)
+			}
+			args = append(args, "--"+strings.ReplaceAll(key, "_", "-")+"="+s)
+		}
+	}
+	return args, nil
+}
+
+// queryFlags converts URL query parameters, other than format, to
+// --flag=value arguments.
+func queryFlags(r *http.Request, allowed []string) ([]string, error) {
+	query := r.URL.Query()
+	query.Del(apiFormatParam)
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var args []string
+	for _, key := range keys {
+		if !contains(allowed, key) {
+			return nil, fmt.Errorf("unknown parameter %q (allowed: %s)", key, strings.Join(allowed, ", "))
+		}
+		for _, v := range query[key] {
+			args = append(args, "--"+strings.ReplaceAll(key, "_", "-")+"="+v)
+		}
+	}
+	return args, nil
+}
+
+func writeAPIJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeAPIError(w http.ResponseWriter, status int, msg string) {
+	writeAPIJSON(w, status, map[string]string{"error": msg})
+}
@@ -0,0 +1,370 @@
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"beads-lite/internal/config"
+)
+
+// Config keys for deploying bd serve behind a reverse proxy or on a
+// shared machine.
+const (
+	serveBasePathKey       = "serve.base_path"
+	serveTrustedProxiesKey = "serve.trusted_proxies"
+	serveCORSOriginsKey    = "serve.cors_origins"
+	serveTLSCertKey        = "serve.tls_cert"
+	serveTLSKeyKey         = "serve.tls_key"
+	serveTLSSelfSignedKey  = "serve.tls_self_signed"
+)
+
+// serveTLSDir holds the self-signed certificate bd serve generates. The
+// key is private to the machine, so it gets its own .gitignore.
+const serveTLSDir = "tls"
+
+// selfSignedValidity is how long a generated certificate lasts; bd serve
+// makes a new one when it has expired.
+const selfSignedValidity = 365 * 24 * time.Hour
+
+// serveOptions is how bd serve is deployed, from serve.* config and the
+// flags that override it.
+type serveOptions struct {
+	readOnly bool
+	// basePath is the path prefix the API is mounted under, such as
+	// "/beads", or "" for the root.
+	basePath string
+	// trustedProxies are the addresses of reverse proxies whose Host and
+	// X-Forwarded-For headers are believed.
+	trustedProxies []*net.IPNet
+	// corsOrigins are the web origins allowed to call the API from a
+	// browser; "*" allows any.
+	corsOrigins []string
+	// tlsCert and tlsKey are the certificate and key files to serve HTTPS
+	// with; selfSigned serves HTTPS with a generated certificate instead.
+	tlsCert, tlsKey string
+	selfSigned      bool
+}
+
+// parseBasePath normalizes a base path to start with "/" and not end
+// with one, "" for the root.
+func parseBasePath(v string) (string, error) {
+	v = strings.TrimSpace(v)
+	if v == "" || v == "/" {
+		return "", nil
+	}
+	if !strings.HasPrefix(v, "/") || strings.ContainsAny(v, "?#") || strings.Contains(v, "//") {
+		return "", fmt.Errorf("invalid base path %q: want a path such as /beads", v)
+	}
+	return strings.TrimSuffix(v, "/"), nil
+}
+
+// parseTrustedProxies parses comma-separated IP addresses and CIDR ranges.
+func parseTrustedProxies(v string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, s := range config.SplitCustomValues(v) {
+		if !strings.Contains(s, "/") {
+			ip := net.ParseIP(s)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid proxy address %q: want an IP address or CIDR range", s)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy range %q: want an IP address or CIDR range", s)
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+// parseCORSOrigins parses comma-separated origins such as
+// https://dash.example.com, or "*" for any.
+func parseCORSOrigins(v string) ([]string, error) {
+	var origins []string
+	for _, s := range config.SplitCustomValues(v) {
+		if s == "*" {
+			origins = append(origins, s)
+			continue
+		}
+		u, err := url.Parse(s)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			return nil, fmt.Errorf("invalid origin %q: want scheme://host[:port], or *", s)
+		}
+		origins = append(origins, strings.ToLower(u.Scheme+"://"+u.Host))
+	}
+	return origins, nil
+}
+
+// loadServeOptions reads bd serve's serve.* configuration.
+func loadServeOptions(app *App) (serveOptions, error) {
+	var opts serveOptions
+	if app.ConfigStore == nil {
+		return opts, nil
+	}
+	var err error
+	get := func(key string) string {
+		v, _ := app.ConfigStore.Get(key)
+		return v
+	}
+	if opts.basePath, err = parseBasePath(get(serveBasePathKey)); err != nil {
+		return opts, fmt.Errorf("%s: %w", serveBasePathKey, err)
+	}
+	if opts.trustedProxies, err = parseTrustedProxies(get(serveTrustedProxiesKey)); err != nil {
+		return opts, fmt.Errorf("%s: %w", serveTrustedProxiesKey, err)
+	}
+	if opts.corsOrigins, err = parseCORSOrigins(get(serveCORSOriginsKey)); err != nil {
+		return opts, fmt.Errorf("%s: %w", serveCORSOriginsKey, err)
+	}
+	opts.tlsCert, opts.tlsKey = get(serveTLSCertKey), get(serveTLSKeyKey)
+	if v := get(serveTLSSelfSignedKey); v != "" {
+		if opts.selfSigned, err = strconv.ParseBool(v); err != nil {
+			return opts, fmt.Errorf("%s: must be \"true\" or \"false\", got %q", serveTLSSelfSignedKey, v)
+		}
+	}
+	return opts, nil
+}
+
+// tlsConfig returns the TLS configuration opts asks for, nil for plain
+// HTTP. hosts are the names a generated certificate is valid for.
+func (opts serveOptions) tlsConfig(app *App, hosts []string) (*tls.Config, error) {
+	switch {
+	case (opts.tlsCert == "") != (opts.tlsKey == ""):
+		return nil, fmt.Errorf("%s and %s must be set together", serveTLSCertKey, serveTLSKeyKey)
+	case opts.tlsCert != "":
+		cert, err := tls.LoadX509KeyPair(opts.tlsCert, opts.tlsKey)
+		if err != nil {
+			return nil, fmt.Errorf("loading TLS certificate: %w", err)
+		}
+		return &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}, nil
+	case opts.selfSigned:
+		cert, err := selfSignedCert(filepath.Join(app.ConfigDir, serveTLSDir), hosts, app.Now())
+		if err != nil {
+			return nil, err
+		}
+		return &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}, nil
+	}
+	return nil, nil
+}
+
+// selfSignedCert returns the certificate in dir, generating one for hosts
+// if there is none, it has expired or it doesn't cover hosts. Keeping it
+// lets clients trust it once rather than on every restart.
+func selfSignedCert(dir string, hosts []string, now time.Time) (tls.Certificate, error) {
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if cert, err := tls.LoadX509KeyPair(certFile, keyFile); err == nil {
+		if leaf, err := x509.ParseCertificate(cert.Certificate[0]); err == nil && now.Before(leaf.NotAfter) && coversHosts(leaf, hosts) {
+			return cert, nil
+		}
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "bd serve"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(selfSignedValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+		} else {
+			tmpl.DNSNames = append(tmpl.DNSNames, h)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("generating certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return tls.Certificate{}, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*\n"), 0644); err != nil {
+		return tls.Certificate{}, err
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	if err := os.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		return tls.Certificate{}, err
+	}
+	if err := os.WriteFile(certFile, certPEM, 0644); err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.X509KeyPair(certPEM, keyPEM)
+}
+
+// coversHosts reports whether cert is valid for every one of hosts.
+func coversHosts(cert *x509.Certificate, hosts []string) bool {
+	for _, h := range hosts {
+		if cert.VerifyHostname(h) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// certFingerprint returns the SHA-256 fingerprint of a certificate, as
+// clients show it when asked to trust a self-signed one.
+func certFingerprint(cert tls.Certificate) string {
+	sum := sha256.Sum256(cert.Certificate[0])
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+// trustedProxy reports whether a request came straight from a trusted
+// reverse proxy.
+func (s *apiServer) trustedProxy(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, n := range s.opts.trustedProxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientAddr returns the address of the client a request came from: behind
+// trusted proxies, the last address in X-Forwarded-For that isn't one of
+// them.
+func (s *apiServer) clientAddr(r *http.Request) string {
+	if !s.trustedProxy(r) {
+		return r.RemoteAddr
+	}
+	hops := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		ip := net.ParseIP(hop)
+		if ip == nil {
+			break
+		}
+		trusted := false
+		for _, n := range s.opts.trustedProxies {
+			trusted = trusted || n.Contains(ip)
+		}
+		if !trusted {
+			return hop
+		}
+	}
+	return r.RemoteAddr
+}
+
+// originAllowed reports whether a browser page at origin may call the
+// API: a loopback page, one listed in serve.cors_origins, or behind a
+// trusted proxy, a page on the proxy's own host.
+func (s *apiServer) originAllowed(r *http.Request, origin string) bool {
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	if isLoopbackHost(u.Hostname()) {
+		return true
+	}
+	for _, o := range s.opts.corsOrigins {
+		if o == "*" || strings.EqualFold(o, u.Scheme+"://"+u.Host) {
+			return true
+		}
+	}
+	if s.trustedProxy(r) {
+		host := r.Header.Get("X-Forwarded-Host")
+		if host == "" {
+			host = r.Host
+		}
+		return strings.EqualFold(u.Host, host)
+	}
+	return false
+}
+
+// corsAllowed reports whether origin is one serve.cors_origins lets read
+// responses, which other origins' pages cannot.
+func (s *apiServer) corsAllowed(origin string) bool {
+	for _, o := range s.opts.corsOrigins {
+		if o == "*" || strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// cors adds CORS headers for allowed origins and answers preflight
+// requests, which carry no token.
+func (s *apiServer) cors(w http.ResponseWriter, r *http.Request) (handled bool) {
+	origin := r.Header.Get("Origin")
+	if origin == "" || !s.corsAllowed(origin) {
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			writeAPIError(w, http.StatusForbidden, fmt.Sprintf("origin %s is not allowed (see %s)", origin, serveCORSOriginsKey))
+			return true
+		}
+		return false
+	}
+	h := w.Header()
+	h.Set("Access-Control-Allow-Origin", origin)
+	h.Add("Vary", "Origin")
+	if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+		h.Set("Access-Control-Allow-Methods", "GET, POST, PATCH, DELETE")
+		h.Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+		h.Set("Access-Control-Max-Age", "600")
+		w.WriteHeader(http.StatusNoContent)
+		return true
+	}
+	return false
+}
+
+// mount serves next under opts.basePath, stripping it from request paths.
+func (s *apiServer) mount(next http.Handler) http.Handler {
+	if s.opts.basePath == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rest, ok := strings.CutPrefix(r.URL.Path, s.opts.basePath)
+		if !ok || (rest != "" && !strings.HasPrefix(rest, "/")) {
+			writeAPIError(w, http.StatusNotFound, fmt.Sprintf("no endpoint %s %s (the API is under %s)", r.Method, r.URL.Path, s.opts.basePath))
+			return
+		}
+		r2 := r.Clone(r.Context())
+		r2.URL.Path = rest
+		r2.URL.RawPath = ""
+		next.ServeHTTP(w, r2)
+	})
+}
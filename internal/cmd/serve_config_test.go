@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseServeConfig(t *testing.T) {
+	for in, want := range map[string]string{"": "", "/": "", "/beads": "/beads", "/beads/": "/beads", "/a/b": "/a/b"} {
+		if got, err := parseBasePath(in); err != nil || got != want {
+			t.Errorf("parseBasePath(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"beads", "/a//b", "/a?x", "/a#b"} {
+		if _, err := parseBasePath(in); err == nil {
+			t.Errorf("parseBasePath(%q) should fail", in)
+		}
+	}
+
+	nets, err := parseTrustedProxies("127.0.0.1, 10.0.0.0/8,::1")
+	if err != nil || len(nets) != 3 {
+		t.Fatalf("parseTrustedProxies = %v, %v", nets, err)
+	}
+	for _, in := range []string{"proxy.lan", "10.0.0.0/33"} {
+		if _, err := parseTrustedProxies(in); err == nil {
+			t.Errorf("parseTrustedProxies(%q) should fail", in)
+		}
+	}
+
+	origins, err := parseCORSOrigins("https://Dash.example.com, http://localhost:3000/, *")
+	if err != nil || strings.Join(origins, " ") != "https://dash.example.com http://localhost:3000 *" {
+		t.Errorf("parseCORSOrigins = %v, %v", origins, err)
+	}
+	for _, in := range []string{"dash.example.com", "ftp://x", "https://x/app"} {
+		if _, err := parseCORSOrigins(in); err == nil {
+			t.Errorf("parseCORSOrigins(%q) should fail", in)
+		}
+	}
+}
+
+// apiStatus sends a request and returns the response.
+func apiStatus(t *testing.T, srv *httptest.Server, method, path string, header map[string]string) *http.Response {
+	t.Helper()
+	var body *strings.Reader
+	if method == "POST" {
+		body = strings.NewReader(`{"title": "Via proxy"}`)
+	} else {
+		body = strings.NewReader("")
+	}
+	req, err := http.NewRequest(method, srv.URL+path, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range header {
+		if k == "Host" {
+			req.Host = v
+		} else {
+			req.Header.Set(k, v)
+		}
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp
+}
+
+func TestServeBehindProxy(t *testing.T) {
+	app, _ := setupTestApp(t)
+	app.ConfigDir = t.TempDir()
+	proxies, _ := parseTrustedProxies("127.0.0.1")
+	origins, _ := parseCORSOrigins("https://dash.example.com")
+	srv := httptest.NewUnstartedServer(nil)
+	srv.Config.Handler = newAPIHandler(app, serveOptions{basePath: "/beads", trustedProxies: proxies, corsOrigins: origins}, srv.Listener.Addr().String())
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	if got := apiStatus(t, srv, "GET", "/api/health", nil).StatusCode; got != http.StatusNotFound {
+		t.Errorf("outside the base path = %d, want 404", got)
+	}
+	if got := apiStatus(t, srv, "GET", "/beadsx/api/health", nil).StatusCode; got != http.StatusNotFound {
+		t.Errorf("base path prefix = %d, want 404", got)
+	}
+	if got := apiStatus(t, srv, "GET", "/beads/api/health", nil).StatusCode; got != http.StatusOK {
+		t.Errorf("under the base path = %d, want 200", got)
+	}
+
+	// The proxy may use its public name and let its own pages write.
+	public := map[string]string{"Host": "tracker.example.com", "Content-Type": "application/json", "Origin": "https://tracker.example.com", "X-Forwarded-For": "203.0.113.7, 127.0.0.1"}
+	if got := apiStatus(t, srv, "POST", "/beads/api/issues", public).StatusCode; got != http.StatusCreated {
+		t.Errorf("write through the proxy = %d, want 201", got)
+	}
+	records, err := readServeAudit(app)
+	if err != nil || len(records) != 1 || records[0].Remote != "203.0.113.7" {
+		t.Errorf("audit log = %+v, %v, want the forwarded client", records, err)
+	}
+	public["Origin"] = "https://evil.example"
+	if got := apiStatus(t, srv, "POST", "/beads/api/issues", public).StatusCode; got != http.StatusForbidden {
+		t.Errorf("write from another site = %d, want 403", got)
+	}
+
+	// CORS: an allowed origin gets headers and preflights; others don't.
+	preflight := map[string]string{"Origin": "https://dash.example.com", "Access-Control-Request-Method": "PATCH"}
+	resp := apiStatus(t, srv, "OPTIONS", "/beads/api/issues/bd-1", preflight)
+	if resp.StatusCode != http.StatusNoContent || !strings.Contains(resp.Header.Get("Access-Control-Allow-Headers"), "Authorization") {
+		t.Errorf("preflight = %d %v", resp.StatusCode, resp.Header)
+	}
+	resp = apiStatus(t, srv, "GET", "/beads/api/issues", map[string]string{"Origin": "https://dash.example.com"})
+	if resp.Header.Get("Access-Control-Allow-Origin") != "https://dash.example.com" {
+		t.Errorf("allowed origin headers = %v", resp.Header)
+	}
+	preflight["Origin"] = "https://evil.example"
+	if got := apiStatus(t, srv, "OPTIONS", "/beads/api/issues", preflight).StatusCode; got != http.StatusForbidden {
+		t.Errorf("preflight from another site = %d, want 403", got)
+	}
+	resp = apiStatus(t, srv, "GET", "/beads/api/issues", map[string]string{"Origin": "https://evil.example"})
+	if resp.Header.Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("disallowed origin got CORS headers: %v", resp.Header)
+	}
+}
+
+func TestSelfSignedCert(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), serveTLSDir)
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	hosts := []string{"localhost", "127.0.0.1", "tracker.lan"}
+
+	cert, err := selfSignedCert(dir, hosts, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !coversHosts(leaf, hosts) {
+		t.Errorf("certificate covers %v %v, want %v", leaf.DNSNames, leaf.IPAddresses, hosts)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".gitignore")); err != nil {
+		t.Errorf("tls directory should be ignored by git: %v", err)
+	}
+	if info, err := os.Stat(filepath.Join(dir, "key.pem")); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("key file = %v, %v, want mode 0600", info, err)
+	}
+
+	again, err := selfSignedCert(dir, hosts, now.Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if certFingerprint(again) != certFingerprint(cert) {
+		t.Error("a valid certificate should be reused")
+	}
+	renewed, err := selfSignedCert(dir, hosts, now.Add(selfSignedValidity+time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if certFingerprint(renewed) == certFingerprint(cert) {
+		t.Error("an expired certificate should be replaced")
+	}
+	wider, err := selfSignedCert(dir, append(hosts, "other.lan"), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if certFingerprint(wider) == certFingerprint(renewed) {
+		t.Error("a certificate missing a host should be replaced")
+	}
+}
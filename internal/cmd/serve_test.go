@@ -0,0 +1,487 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+	kvfs "beads-lite/internal/kvstorage/filesystem"
+)
+
+// newTestAPIServer starts the bd serve handler for app on a loopback port.
+func newTestAPIServer(t *testing.T, app *App, readOnly bool) *httptest.Server {
+	t.Helper()
+	if app.ConfigDir == "" {
+		app.ConfigDir = t.TempDir() // for the audit log
+	}
+	srv := httptest.NewUnstartedServer(nil)
+	srv.Config.Handler = newAPIHandler(app, serveOptions{readOnly: readOnly}, srv.Listener.Addr().String())
+	srv.Start()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// apiDo sends a request to the test server and decodes the JSON response
+// into out, failing unless the status is want.
+func apiDo(t *testing.T, srv *httptest.Server, method, path, body string, want int, out any) {
+	t.Helper()
+	var r io.Reader
+	if body != "" {
+		r = strings.NewReader(body)
+	}
+	req, err := http.NewRequest(method, srv.URL+path, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if method != "GET" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != want {
+		t.Fatalf("%s %s: status %d, want %d: %s", method, path, resp.StatusCode, want, data)
+	}
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			t.Fatalf("%s %s: decoding %q: %v", method, path, data, err)
+		}
+	}
+}
+
+func TestServeIssueLifecycle(t *testing.T) {
+	app, rs := setupTestApp(t)
+	srv := newTestAPIServer(t, app, false)
+
+	var created map[string]any
+	apiDo(t, srv, "POST", "/api/issues", `{"title": "Fix login", "priority": 1, "labels": ["api", "auth"]}`, http.StatusCreated, &created)
+	id, _ := created["id"].(string)
+	if id == "" {
+		t.Fatalf("create response has no id: %v", created)
+	}
+
+	var blocker map[string]any
+	apiDo(t, srv, "POST", "/api/issues", `{"title": "Add sessions"}`, http.StatusCreated, &blocker)
+	blockerID := blocker["id"].(string)
+
+	apiDo(t, srv, "PATCH", "/api/issues/"+id, `{"status": "in_progress", "assignee": "alice"}`, http.StatusOK, nil)
+	apiDo(t, srv, "POST", "/api/issues/"+id+"/comments", `{"text": "Looking into it", "author": "alice"}`, http.StatusOK, nil)
+	apiDo(t, srv, "POST", "/api/issues/"+id+"/deps", `{"depends_on": "`+blockerID+`"}`, http.StatusOK, nil)
+
+	var deps []map[string]any
+	apiDo(t, srv, "GET", "/api/issues/"+id+"/deps", "", http.StatusOK, &deps)
+	if len(deps) != 1 {
+		t.Errorf("deps = %v, want one dependency", deps)
+	}
+
+	var comments []map[string]any
+	apiDo(t, srv, "GET", "/api/issues/"+id+"/comments", "", http.StatusOK, &comments)
+	if len(comments) != 1 || comments[0]["text"] != "Looking into it" {
+		t.Errorf("comments = %v", comments)
+	}
+
+	got, err := rs.Get(t.Context(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != issuestorage.StatusInProgress || got.Assignee != "alice" || got.Priority != 1 {
+		t.Errorf("issue = status %s assignee %q priority %d", got.Status, got.Assignee, got.Priority)
+	}
+
+	apiDo(t, srv, "DELETE", "/api/issues/"+id+"/deps/"+blockerID, "", http.StatusOK, nil)
+	apiDo(t, srv, "POST", "/api/issues/"+blockerID+"/close", `{"reason": "done"}`, http.StatusOK, nil)
+
+	var open []map[string]any
+	apiDo(t, srv, "GET", "/api/issues?status=in_progress", "", http.StatusOK, &open)
+	if len(open) != 1 || open[0]["id"] != id {
+		t.Errorf("list in_progress = %v, want only %s", open, id)
+	}
+
+	closed, err := rs.Get(t.Context(), blockerID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if closed.Status != issuestorage.StatusClosed {
+		t.Errorf("blocker status = %s, want closed", closed.Status)
+	}
+}
+
+// TestServePositionalNotFlag checks that body values passed as positional
+// arguments are never parsed as flags, which would let a comment read a
+// file on the server.
+func TestServePositionalNotFlag(t *testing.T) {
+	app, rs := setupTestApp(t)
+	srv := newTestAPIServer(t, app, false)
+
+	secret := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(secret, []byte("server secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var created map[string]any
+	apiDo(t, srv, "POST", "/api/issues", `{"title": "Target"}`, http.StatusCreated, &created)
+	id := created["id"].(string)
+
+	text := "--file=" + secret
+	body, _ := json.Marshal(map[string]string{"text": text})
+	apiDo(t, srv, "POST", "/api/issues/"+id+"/comments", string(body), http.StatusOK, nil)
+	got, err := rs.Get(t.Context(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Comments) != 1 || got.Comments[0].Text != text {
+		t.Errorf("comments = %+v, want the text stored literally", got.Comments)
+	}
+
+	apiDo(t, srv, "POST", "/api/issues/"+id+"/deps", `{"depends_on": "--type=blocks"}`, http.StatusBadRequest, nil)
+}
+
+func TestServeReadOnly(t *testing.T) {
+	app, _ := setupTestApp(t)
+	srv := newTestAPIServer(t, app, true)
+
+	var errBody map[string]string
+	apiDo(t, srv, "POST", "/api/issues", `{"title": "Nope"}`, http.StatusForbidden, &errBody)
+	if errBody["error"] != "server is read-only" {
+		t.Errorf("error = %q", errBody["error"])
+	}
+	apiDo(t, srv, "GET", "/api/issues", "", http.StatusOK, nil)
+}
+
+func TestServeErrors(t *testing.T) {
+	app, _ := setupTestApp(t)
+	srv := newTestAPIServer(t, app, false)
+
+	var created map[string]any
+	apiDo(t, srv, "POST", "/api/issues", `{"title": "Real"}`, http.StatusCreated, &created)
+	id := created["id"].(string)
+
+	tests := []struct {
+		name, method, path, body string
+		want                     int
+	}{
+		{"unknown issue", "GET", "/api/issues/bd-zzzz", "", http.StatusNotFound},
+		{"unknown field", "POST", "/api/issues", `{"title": "x", "file": "/etc/passwd"}`, http.StatusBadRequest},
+		{"actor field", "POST", "/api/issues", `{"title": "x", "actor": "mallory"}`, http.StatusBadRequest},
+		{"unknown query", "GET", "/api/issues?file=x", "", http.StatusBadRequest},
+		{"stdin description", "PATCH", "/api/issues/" + id, `{"description": "-"}`, http.StatusBadRequest},
+		{"missing comment text", "POST", "/api/issues/" + id + "/comments", `{}`, http.StatusBadRequest},
+		{"invalid json", "POST", "/api/issues", `{`, http.StatusBadRequest},
+		{"command error", "PATCH", "/api/issues/" + id, `{"status": "bogus"}`, http.StatusBadRequest},
+		{"unknown endpoint", "GET", "/api/nope", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errBody map[string]string
+			apiDo(t, srv, tt.method, tt.path, tt.body, tt.want, &errBody)
+			if errBody["error"] == "" {
+				t.Errorf("response has no error message")
+			}
+		})
+	}
+}
+
+func TestServeRejectsCrossSiteRequests(t *testing.T) {
+	app, rs := setupTestApp(t)
+	srv := newTestAPIServer(t, app, false)
+	_, port, _ := net.SplitHostPort(srv.Listener.Addr().String())
+
+	send := func(method, host string, header map[string]string) int {
+		t.Helper()
+		req, err := http.NewRequest(method, srv.URL+"/api/issues", strings.NewReader(`{"title": "csrf"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Host = host
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	local := "localhost:" + port
+	tests := []struct {
+		name, method, host string
+		header             map[string]string
+		want               int
+	}{
+		{"form post", "POST", local, map[string]string{"Content-Type": "text/plain", "Origin": "http://evil.example"}, http.StatusForbidden},
+		{"simple post without origin", "POST", local, map[string]string{"Content-Type": "text/plain"}, http.StatusUnsupportedMediaType},
+		{"no content type", "POST", local, nil, http.StatusUnsupportedMediaType},
+		{"json from another site", "POST", local, map[string]string{"Content-Type": "application/json", "Origin": "https://evil.example"}, http.StatusForbidden},
+		{"null origin", "POST", local, map[string]string{"Content-Type": "application/json", "Origin": "null"}, http.StatusForbidden},
+		{"rebound host", "POST", "evil.example:" + port, map[string]string{"Content-Type": "application/json"}, http.StatusForbidden},
+		{"rebound host read", "GET", "evil.example:" + port, nil, http.StatusForbidden},
+		{"other port", "GET", "localhost:1", nil, http.StatusForbidden},
+		{"local page", "POST", "127.0.0.1:" + port, map[string]string{"Content-Type": "application/json; charset=utf-8", "Origin": "http://localhost:3000"}, http.StatusCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := send(tt.method, tt.host, tt.header); got != tt.want {
+				t.Errorf("status %d, want %d", got, tt.want)
+			}
+		})
+	}
+	if issues, _ := rs.List(t.Context(), nil); len(issues) != 1 {
+		t.Errorf("%d issues created, want only the local page's", len(issues))
+	}
+}
+
+func TestServeHostAllowed(t *testing.T) {
+	tests := []struct {
+		listen string
+		names  []string
+		host   string
+		want   bool
+	}{
+		{"127.0.0.1:7373", nil, "127.0.0.1:7373", true},
+		{"127.0.0.1:7373", nil, "LOCALHOST:7373", true},
+		{"127.0.0.1:7373", nil, "[::1]:7373", true},
+		{"127.0.0.1:7373", nil, "evil.example:7373", false},
+		{"127.0.0.1:7373", nil, "localhost", false},
+		{"[::]:7373", nil, "192.168.1.5:7373", true},
+		{"[::]:7373", nil, "evil.example:7373", false},
+		{"192.168.1.5:80", nil, "192.168.1.5", true},
+		{"192.168.1.5:80", nil, "192.168.1.6", false},
+		{"192.168.1.5:80", []string{"tracker.lan"}, "tracker.lan", true},
+	}
+	for _, tt := range tests {
+		if got := newAPIServer(nil, serveOptions{}, tt.listen, tt.names...).hostAllowed(tt.host); got != tt.want {
+			t.Errorf("listening on %s %v, hostAllowed(%q) = %v, want %v", tt.listen, tt.names, tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestServeViews(t *testing.T) {
+	app, runContext := setupContextTestApp(t)
+	sprintStore, err := kvfs.New(t.TempDir(), "sprints")
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.SprintStore = sprintStore
+	for _, issue := range []*issuestorage.Issue{
+		{Title: "Outage", Priority: issuestorage.PriorityCritical, Type: issuestorage.TypeBug},
+		{Title: "Polish", Priority: issuestorage.PriorityLow, Type: issuestorage.TypeTask},
+	} {
+		if _, err := app.Storage.Create(t.Context(), issue); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := runContext("create", "p0", "--filter", "priority:0"); err != nil {
+		t.Fatal(err)
+	}
+	srv := newTestAPIServer(t, app, false)
+
+	var issues []IssueListJSON
+	apiDo(t, srv, "GET", "/api/contexts/p0/issues", "", http.StatusOK, &issues)
+	if len(issues) != 1 || issues[0].Title != "Outage" {
+		t.Errorf("p0 issues = %+v, want only Outage", issues)
+	}
+	apiDo(t, srv, "GET", "/api/contexts/p0/issues?type=task", "", http.StatusOK, &issues)
+	if len(issues) != 0 {
+		t.Errorf("p0 tasks = %+v, want none", issues)
+	}
+	apiDo(t, srv, "GET", "/api/issues?context=p0", "", http.StatusOK, &issues)
+	if len(issues) != 1 {
+		t.Errorf("issues?context=p0 = %+v, want only Outage", issues)
+	}
+
+	var contexts []ContextJSON
+	apiDo(t, srv, "GET", "/api/contexts", "", http.StatusOK, &contexts)
+	if len(contexts) != 1 || contexts[0].Name != "p0" {
+		t.Errorf("contexts = %+v", contexts)
+	}
+
+	var board BoardJSON
+	apiDo(t, srv, "GET", "/api/contexts/p0/board?columns=type", "", http.StatusOK, &board)
+	apiDo(t, srv, "GET", "/api/board?rows=priority", "", http.StatusOK, &board)
+	for _, path := range []string{"/api/reports/stats?burndown=true&by=week", "/api/reports/workload", "/api/reports/risks?all=true"} {
+		apiDo(t, srv, "GET", path, "", http.StatusOK, nil)
+	}
+
+	resp, err := http.Get(srv.URL + "/api/contexts/p0/board?format=text")
+	if err != nil {
+		t.Fatal(err)
+	}
+	text, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("format=text Content-Type = %q", ct)
+	}
+	if !strings.Contains(string(text), "Outage") || strings.Contains(string(text), "Polish") {
+		t.Errorf("p0 board text = %q", text)
+	}
+
+	tests := []struct {
+		name, path string
+		want       int
+	}{
+		{"unknown context", "/api/contexts/nope/issues", http.StatusNotFound},
+		{"context in query", "/api/contexts/p0/issues?context=p0", http.StatusBadRequest},
+		{"unknown board field", "/api/board?rows=bogus", http.StatusBadRequest},
+		{"unknown format", "/api/board?format=xml", http.StatusBadRequest},
+		{"no sprint", "/api/reports/sprint", http.StatusBadRequest},
+		{"unknown sprint", "/api/reports/sprint/s9", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errBody map[string]string
+			apiDo(t, srv, "GET", tt.path, "", tt.want, &errBody)
+			if errBody["error"] == "" {
+				t.Errorf("response has no error message")
+			}
+		})
+	}
+}
+
+func TestServeTokens(t *testing.T) {
+	app, _ := setupTestApp(t)
+	app.ConfigDir = t.TempDir()
+	// Left uninitialized: bd serve token create makes the table directory.
+	tokenStore, err := kvfs.New(app.ConfigDir, tokenTable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.TokenStore = tokenStore
+	srv := newTestAPIServer(t, app, false)
+
+	createToken := func(scope, name string) TokenJSON {
+		t.Helper()
+		app.JSON = true
+		defer func() { app.JSON = false }()
+		out := &bytes.Buffer{}
+		app.Out = out
+		cmd := newServeCmd(NewTestProvider(app))
+		cmd.SetArgs([]string{"token", "create", "--scope", scope, "--name", name})
+		if err := cmd.Execute(); err != nil {
+			t.Fatal(err)
+		}
+		var tok TokenJSON
+		if err := json.Unmarshal(out.Bytes(), &tok); err != nil {
+			t.Fatal(err)
+		}
+		return tok
+	}
+	send := func(method, path, token string) int {
+		t.Helper()
+		var body io.Reader
+		if method != "GET" {
+			body = strings.NewReader(`{"title": "Via API"}`)
+		}
+		req, err := http.NewRequest(method, srv.URL+path, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// Without tokens, anyone may write, and writes are audited.
+	if got := send("POST", "/api/issues", ""); got != http.StatusCreated {
+		t.Fatalf("anonymous create = %d", got)
+	}
+
+	read := createToken("read", "dashboard")
+	write := createToken("write", "ci")
+	if read.Token == "" || write.Token == "" {
+		t.Fatalf("tokens have no secret: %+v %+v", read, write)
+	}
+	if _, err := os.Stat(filepath.Join(app.ConfigDir, tokenTable, ".gitignore")); err != nil {
+		t.Errorf("token table should be ignored by git: %v", err)
+	}
+
+	tests := []struct {
+		name, method, path, token string
+		want                      int
+	}{
+		{"health needs no token", "GET", "/api/health", "", http.StatusOK},
+		{"read without token", "GET", "/api/issues", "", http.StatusUnauthorized},
+		{"unknown token", "GET", "/api/issues", "bdt_00000000_" + strings.Repeat("0", 32), http.StatusUnauthorized},
+		{"read with read token", "GET", "/api/issues", read.Token, http.StatusOK},
+		{"write with read token", "POST", "/api/issues", read.Token, http.StatusForbidden},
+		{"write with write token", "POST", "/api/issues", write.Token, http.StatusCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := send(tt.method, tt.path, tt.token); got != tt.want {
+				t.Errorf("status %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	// Issues are created by the token that created them.
+	issues, err := app.Storage.List(t.Context(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var byToken int
+	for _, issue := range issues {
+		if issue.CreatedBy == "ci" {
+			byToken++
+		}
+	}
+	if byToken != 1 {
+		t.Errorf("issues created by the ci token = %d, want 1", byToken)
+	}
+
+	records, err := readServeAudit(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("audit log = %+v, want the two creates", records)
+	}
+	if records[0].TokenID != "" || records[1].TokenID != write.ID || records[1].TokenName != "ci" || records[1].Status != http.StatusCreated || records[1].Path != "/api/issues" {
+		t.Errorf("audit log = %+v", records)
+	}
+
+	// Revoking a token rejects it from the next request on.
+	cmd := newServeCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"token", "revoke", write.ID})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if got := send("POST", "/api/issues", write.Token); got != http.StatusUnauthorized {
+		t.Errorf("revoked token = %d, want 401", got)
+	}
+}
+
+func TestServeRefusesOpenNetworkWithoutTokens(t *testing.T) {
+	app, _ := setupTestApp(t)
+	tokenStore, err := kvfs.New(t.TempDir(), tokenTable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.TokenStore = tokenStore
+	cmd := newServeCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--addr", "0.0.0.0:0"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--no-auth") {
+		t.Errorf("serving 0.0.0.0 without tokens = %v, want a refusal", err)
+	}
+}
@@ -0,0 +1,310 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"beads-lite/internal/apitoken"
+	"beads-lite/internal/kvstorage"
+
+	"github.com/spf13/cobra"
+)
+
+// tokenTable is the KV table holding bd serve API tokens. Tokens belong to
+// the machine serving the API, not the repository, so it gets its own
+// .gitignore.
+const tokenTable = "tokens"
+
+// serveAuditFile is the log of API requests that modified issues, in
+// .beads/, one JSON record per line. Like the admin audit log it is
+// committed with the issues.
+const serveAuditFile = "serve-audit.jsonl"
+
+// TokenJSON is the JSON output of bd serve token create and list. Token,
+// the secret, is only ever output by create.
+type TokenJSON struct {
+	ID        string `json:"id"`
+	Name      string `json:"name,omitempty"`
+	Scope     string `json:"scope"`
+	CreatedAt string `json:"created_at"`
+	CreatedBy string `json:"created_by,omitempty"`
+	Token     string `json:"token,omitempty"`
+}
+
+// ServeAuditJSON is a record of the API audit log, and the JSON output of
+// bd serve log.
+type ServeAuditJSON struct {
+	At        string `json:"at"`
+	TokenID   string `json:"token_id,omitempty"` // empty when no token was needed
+	TokenName string `json:"token_name,omitempty"`
+	Remote    string `json:"remote,omitempty"`
+	Method    string `json:"method"`
+	Path      string `json:"path"`
+	Status    int    `json:"status"`
+}
+
+func toTokenJSON(t apitoken.Token) TokenJSON {
+	return TokenJSON{
+		ID:        t.ID,
+		Name:      t.Name,
+		Scope:     string(t.Scope),
+		CreatedAt: formatTime(t.CreatedAt),
+		CreatedBy: t.CreatedBy,
+	}
+}
+
+// listTokens returns the API tokens, none if there is no token store.
+func listTokens(ctx context.Context, app *App) ([]apitoken.Token, error) {
+	if app.TokenStore == nil {
+		return nil, nil
+	}
+	return apitoken.List(ctx, app.TokenStore)
+}
+
+// appendServeAudit records an API request that modified issues.
+func appendServeAudit(app *App, record ServeAuditJSON) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(app.ConfigDir, serveAuditFile), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// readServeAudit returns the API audit log, oldest first.
+func readServeAudit(app *App) ([]ServeAuditJSON, error) {
+	data, err := os.ReadFile(filepath.Join(app.ConfigDir, serveAuditFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var records []ServeAuditJSON
+	for i, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		var r ServeAuditJSON
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", serveAuditFile, i+1, err)
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// newServeTokenCmd creates the "serve token" command group.
+func newServeTokenCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Manage API tokens for bd serve",
+		Long: `Manage the API tokens bd serve accepts. Once any token exists, every
+request other than GET /api/health must carry one:
+
+  Authorization: Bearer bdt_...
+
+A read token may only make GET requests; a write token may also modify
+issues. Tokens are stored, hashed, under .beads/` + tokenTable + `/, which
+is not committed.`,
+	}
+
+	cmd.AddCommand(newServeTokenCreateCmd(provider))
+	cmd.AddCommand(newServeTokenListCmd(provider))
+	cmd.AddCommand(newServeTokenRevokeCmd(provider))
+
+	return cmd
+}
+
+// newServeTokenCreateCmd creates the "serve token create" subcommand.
+func newServeTokenCreateCmd(provider *AppProvider) *cobra.Command {
+	var (
+		scope string
+		name  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create an API token",
+		Long: `Create an API token and print its secret. The secret is not stored and
+cannot be shown again.
+
+Examples:
+  bd serve token create --scope read --name dashboard
+  bd serve token create --scope write --name ci`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			if app.TokenStore == nil {
+				return fmt.Errorf("API tokens are not available in this repository")
+			}
+			s, err := apitoken.ParseScope(scope)
+			if err != nil {
+				return err
+			}
+
+			if st, ok := app.TokenStore.(interface{ Init(context.Context) error }); ok {
+				if err := st.Init(ctx); err != nil {
+					return fmt.Errorf("initializing token store: %w", err)
+				}
+				if err := os.WriteFile(filepath.Join(app.ConfigDir, tokenTable, ".gitignore"), []byte("*\n"), 0644); err != nil {
+					return err
+				}
+			}
+			actor, _ := resolveActor(app)
+			t, secret := apitoken.New(name, s, actor, app.Now())
+			if err := apitoken.Set(ctx, app.TokenStore, t); err != nil {
+				return err
+			}
+
+			if app.JSON {
+				out := toTokenJSON(t)
+				out.Token = secret
+				return json.NewEncoder(app.Out).Encode(out)
+			}
+			fmt.Fprintf(app.Out, "%s Created %s token %s\n", app.SuccessColor("✓"), t.Scope, t.ID)
+			fmt.Fprintf(app.Out, "  %s\n", secret)
+			fmt.Fprintln(app.Out, "Copy it now: it cannot be shown again.")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&scope, "scope", string(apitoken.ScopeRead), "What the token may do (read, write)")
+	cmd.Flags().StringVar(&name, "name", "", "What the token is for")
+
+	return cmd
+}
+
+// newServeTokenListCmd creates the "serve token list" subcommand.
+func newServeTokenListCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List API tokens",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+
+			tokens, err := listTokens(cmd.Context(), app)
+			if err != nil {
+				return err
+			}
+
+			if app.JSON {
+				out := make([]TokenJSON, 0, len(tokens))
+				for _, t := range tokens {
+					out = append(out, toTokenJSON(t))
+				}
+				return json.NewEncoder(app.Out).Encode(out)
+			}
+			if len(tokens) == 0 {
+				fmt.Fprintln(app.Out, "No API tokens: bd serve accepts requests without one.")
+				return nil
+			}
+			for _, t := range tokens {
+				line := fmt.Sprintf("%s  %-5s  %s", t.ID, t.Scope, t.CreatedAt.Format("2006-01-02"))
+				if t.CreatedBy != "" {
+					line += "  by " + t.CreatedBy
+				}
+				if t.Name != "" {
+					line += "  " + t.Name
+				}
+				fmt.Fprintln(app.Out, line)
+			}
+			return nil
+		},
+	}
+}
+
+// newServeTokenRevokeCmd creates the "serve token revoke" subcommand.
+func newServeTokenRevokeCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke <id>",
+		Short: "Revoke an API token",
+		Long: `Revoke an API token, rejecting it from the next request on. Revoking
+the last token lets bd serve accept requests without one again.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			if app.TokenStore == nil {
+				return fmt.Errorf("API tokens are not available in this repository")
+			}
+			if err := apitoken.Remove(cmd.Context(), app.TokenStore, args[0]); err != nil {
+				if errors.Is(err, kvstorage.ErrKeyNotFound) {
+					return fmt.Errorf("no token %s (see bd serve token list)", args[0])
+				}
+				return err
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(map[string]string{"revoked": args[0]})
+			}
+			fmt.Fprintf(app.Out, "%s Revoked token %s\n", app.SuccessColor("✓"), args[0])
+			return nil
+		},
+	}
+}
+
+// newServeLogCmd creates the "serve log" subcommand.
+func newServeLogCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "log",
+		Short: "Show the API audit log",
+		Long: `Show the API requests that modified issues, oldest first, with the
+token that made each one and its response status.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+
+			records, err := readServeAudit(app)
+			if err != nil {
+				return err
+			}
+
+			if app.JSON {
+				if records == nil {
+					records = []ServeAuditJSON{}
+				}
+				return json.NewEncoder(app.Out).Encode(records)
+			}
+			if len(records) == 0 {
+				fmt.Fprintln(app.Out, "No API changes recorded.")
+				return nil
+			}
+			for _, r := range records {
+				who := r.TokenID
+				if who == "" {
+					who = "-"
+				} else if r.TokenName != "" {
+					who += " (" + r.TokenName + ")"
+				}
+				fmt.Fprintf(app.Out, "%s  %s  %d  %s %s\n", r.At, who, r.Status, r.Method, r.Path)
+			}
+			return nil
+		},
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	"beads-lite/internal/alias"
 	"beads-lite/internal/graph"
 	"beads-lite/internal/issuestorage"
 
@@ -98,17 +99,107 @@ func outputIssue(app *App, ctx context.Context, issue *issuestorage.Issue) error
 	// --- Metadata line ---
 	// Owner: X · Assignee: Y · Type: Z
 	var meta []string
+	if app.AliasStore != nil {
+		if a, err := alias.ForIssue(ctx, app.AliasStore, issue.ID); err == nil {
+			meta = append(meta, "Alias: "+a.Name)
+		}
+	}
 	if issue.Owner != "" {
 		meta = append(meta, "Owner: "+issue.Owner)
 	}
+	if reporter := issue.ReportedBy(); reporter != "" {
+		meta = append(meta, "Reporter: "+reporter)
+	}
 	if issue.Assignee != "" {
 		meta = append(meta, "Assignee: "+issue.Assignee)
 	}
+	if issue.Reviewer != "" {
+		meta = append(meta, "Reviewer: "+issue.Reviewer)
+	}
 	meta = append(meta, "Type: "+string(issue.Type))
+	if issue.Severity != "" {
+		meta = append(meta, "Severity: "+string(issue.Severity))
+	}
+	if issue.DecisionState != "" {
+		meta = append(meta, "Decision: "+string(issue.DecisionState))
+	}
 	fmt.Fprintln(w, strings.Join(meta, " · "))
 
+	// --- Risk line ---
+	if issue.Likelihood > 0 || issue.Impact > 0 || issue.ReviewBy != nil {
+		risk := []string{fmt.Sprintf("Likelihood: %d", issue.Likelihood), fmt.Sprintf("Impact: %d", issue.Impact), fmt.Sprintf("Exposure: %d", issue.Exposure())}
+		if issue.ReviewBy != nil {
+			risk = append(risk, "Review by: "+issue.ReviewBy.Format("2006-01-02"))
+		}
+		fmt.Fprintln(w, strings.Join(risk, " · "))
+	}
+
 	// --- Dates line ---
 	fmt.Fprintf(w, "Created: %s · Updated: %s\n", issue.CreatedAt.Format("2006-01-02"), issue.UpdatedAt.Format("2006-01-02"))
+	if issue.DueAt != nil || issue.DeferUntil != nil {
+		var sched []string
+		if issue.DeferUntil != nil {
+			sched = append(sched, "Scheduled: "+issue.DeferUntil.Format("2006-01-02"))
+		}
+		if issue.DueAt != nil {
+			due := "Due: " + issue.DueAt.Format("2006-01-02")
+			if issue.DueEvent != "" {
+				due += " (" + issue.DueEvent + ")"
+			}
+			sched = append(sched, due)
+		}
+		fmt.Fprintln(w, strings.Join(sched, " · "))
+	}
+
+	if issue.Milestone != "" || issue.Sprint != "" {
+		var planned []string
+		if issue.Milestone != "" {
+			planned = append(planned, "Milestone: "+issue.Milestone)
+		}
+		if issue.Sprint != "" {
+			planned = append(planned, "Sprint: "+issue.Sprint)
+		}
+		fmt.Fprintln(w, strings.Join(planned, " · "))
+	}
+
+	if issue.Estimate > 0 || issue.TimeSpent > 0 {
+		effort := []string{"Estimate: " + formatEffort(issue.Estimate), "Logged: " + formatEffort(issue.TimeSpent)}
+		if issue.Estimate > 0 && issue.Status != issuestorage.StatusClosed {
+			effort = append(effort, "Remaining: "+formatEffort(issue.RemainingEffort()))
+		}
+		fmt.Fprintln(w, strings.Join(effort, " · "))
+	}
+	if e, ok := effortRollup(ctx, app, issue); ok {
+		fmt.Fprintf(w, "Effort across %d issues: %s\n", e.Issues, formatEffortTotals(e))
+	}
+
+	if len(issue.ExternalRefs) > 0 {
+		var refs []string
+		for _, tracker := range sortedKeys(issue.ExternalRefs) {
+			refs = append(refs, tracker+" "+issue.ExternalRefs[tracker])
+		}
+		fmt.Fprintln(w, "External: "+strings.Join(refs, " · "))
+	}
+
+	if len(issue.Vars) > 0 {
+		var vars []string
+		for _, k := range sortedKeys(issue.Vars) {
+			vars = append(vars, k+"="+issue.Vars[k])
+		}
+		fmt.Fprintln(w, "Vars: "+strings.Join(vars, " · "))
+	}
+
+	if issue.Resolution != "" {
+		res := "Resolution: " + string(issue.Resolution)
+		if issue.DuplicateOf != "" {
+			res += " of " + issue.DuplicateOf
+		}
+		fmt.Fprintln(w, res)
+	}
+
+	if issue.ReopenCount > 0 {
+		fmt.Fprintln(w, formatReopens(issue))
+	}
 
 	// --- Tombstone metadata ---
 	if issue.DeletedAt != nil {
@@ -127,16 +218,37 @@ func outputIssue(app *App, ctx context.Context, issue *issuestorage.Issue) error
 	// --- Description ---
 	if issue.Description != "" {
 		fmt.Fprintf(w, "\nDescription\n\n")
-		for _, line := range strings.Split(issue.Description, "\n") {
+		for _, line := range strings.Split(expandLinks(issue.Description, linkRegistry(app)), "\n") {
 			fmt.Fprintf(w, "  %s\n", line)
 		}
 	}
 
+	// --- Acceptance Criteria ---
+	if len(issue.AcceptanceCriteria) > 0 {
+		fmt.Fprintf(w, "\nAcceptance Criteria (%d/%d)\n", len(issue.AcceptanceCriteria)-issue.UncheckedCriteria(), len(issue.AcceptanceCriteria))
+		for i, c := range issue.AcceptanceCriteria {
+			fmt.Fprintf(w, "  %s\n", formatCriterion(i+1, c))
+		}
+	}
+
+	// --- Reviews ---
+	if len(issue.Reviews) > 0 {
+		fmt.Fprintf(w, "\nReviews\n")
+		for _, r := range issue.Reviews {
+			fmt.Fprintf(w, "  %s\n", formatReview(r))
+		}
+	}
+
 	// --- Labels ---
 	if len(issue.Labels) > 0 {
 		fmt.Fprintf(w, "\nLabels: %s\n", strings.Join(issue.Labels, ", "))
 	}
 
+	// --- Subscribers ---
+	if len(issue.Subscribers) > 0 {
+		fmt.Fprintf(w, "\nSubscribers: %s\n", strings.Join(issue.Subscribers, ", "))
+	}
+
 	// Use routing-aware store for dependency lookups (may be cross-rig).
 	getter := app.Storage
 
@@ -165,10 +277,37 @@ func outputIssue(app *App, ctx context.Context, issue *issuestorage.Issue) error
 		}
 	}
 
-	// --- Depends On (non-parent-child dependencies) ---
+	// --- Tracks (many-to-many, non-hierarchical) ---
+	if tracked := graph.TrackedIDs(issue); len(tracked) > 0 {
+		if rollup, err := graph.ComputeRollup(ctx, getter, issue); err == nil {
+			fmt.Fprintf(w, "\nProgress: %d/%d closed (%d children, %d tracked)\n", rollup.Closed, rollup.Total, rollup.Children, rollup.Tracked)
+		}
+		fmt.Fprintf(w, "\nTracks\n")
+		for _, id := range tracked {
+			trackedIssue, err := getter.Get(ctx, id)
+			if err == nil {
+				fmt.Fprintf(w, "  ⇢ %s\n", formatIssueLine(app, trackedIssue))
+			} else {
+				fmt.Fprintf(w, "  ⇢ %s\n", id)
+			}
+		}
+	}
+	if trackers := graph.TrackedBy(issue); len(trackers) > 0 {
+		fmt.Fprintf(w, "\nTracked By\n")
+		for _, id := range trackers {
+			tracker, err := getter.Get(ctx, id)
+			if err == nil {
+				fmt.Fprintf(w, "  ⇠ %s\n", formatIssueLine(app, tracker))
+			} else {
+				fmt.Fprintf(w, "  ⇠ %s\n", id)
+			}
+		}
+	}
+
+	// --- Depends On (non-parent-child, non-tracks, non-link dependencies) ---
 	var deps []issuestorage.Dependency
 	for _, dep := range issue.Dependencies {
-		if dep.Type != issuestorage.DepTypeParentChild {
+		if dep.Type != issuestorage.DepTypeParentChild && dep.Type != issuestorage.DepTypeTracks && !dep.Type.IsLink() {
 			deps = append(deps, dep)
 		}
 	}
@@ -184,10 +323,10 @@ func outputIssue(app *App, ctx context.Context, issue *issuestorage.Issue) error
 		}
 	}
 
-	// --- Blocks (non-parent-child dependents) ---
+	// --- Blocks (non-parent-child, non-tracks, non-link dependents) ---
 	var blocks []issuestorage.Dependency
 	for _, dep := range issue.Dependents {
-		if dep.Type != issuestorage.DepTypeParentChild {
+		if dep.Type != issuestorage.DepTypeParentChild && dep.Type != issuestorage.DepTypeTracks && !dep.Type.IsLink() {
 			blocks = append(blocks, dep)
 		}
 	}
@@ -203,6 +342,12 @@ func outputIssue(app *App, ctx context.Context, issue *issuestorage.Issue) error
 		}
 	}
 
+	// --- Links (relates-to, duplicate-of, supersedes; never blocking) ---
+	if links := issueLinks(ctx, getter, issue); len(links) > 0 {
+		fmt.Fprintf(w, "\nLinks\n")
+		writeIssueLinks(ctx, app, getter, links)
+	}
+
 	// --- Inherited Blocks (from parent cascade) ---
 	if cascade := cascadeEnabled(app); cascade && issue.Parent != "" {
 		closedSet, err := graph.BuildClosedSet(ctx, app.Storage)
@@ -222,11 +367,23 @@ func outputIssue(app *App, ctx context.Context, issue *issuestorage.Issue) error
 		}
 	}
 
+	// --- Attachments ---
+	if len(issue.Attachments) > 0 {
+		fmt.Fprintf(w, "\nAttachments (%d)\n", len(issue.Attachments))
+		for _, a := range issue.Attachments {
+			fmt.Fprintf(w, "  📎 %s (%s, %s)\n", a.Name, a.MediaType, formatSize(a.Size))
+		}
+	}
+
 	// --- Comments ---
 	if len(issue.Comments) > 0 {
 		fmt.Fprintf(w, "\nComments (%d)\n", len(issue.Comments))
 		for _, comment := range issue.Comments {
-			fmt.Fprintf(w, "\n  [%d] %s (%s):\n", comment.ID, comment.Author, comment.CreatedAt.Format("2006-01-02 15:04"))
+			accepted := ""
+			if comment.ID == issue.AcceptedAnswer {
+				accepted = " " + app.SuccessColor("✓ accepted answer")
+			}
+			fmt.Fprintf(w, "\n  [%d] %s (%s):%s\n", comment.ID, comment.Author, comment.CreatedAt.Format("2006-01-02 15:04"), accepted)
 			for _, line := range strings.Split(comment.Text, "\n") {
 				fmt.Fprintf(w, "    %s\n", line)
 			}
@@ -241,6 +398,17 @@ func outputIssue(app *App, ctx context.Context, issue *issuestorage.Issue) error
 func outputIssueJSON(app *App, ctx context.Context, issue *issuestorage.Issue) error {
 	out := ToIssueJSON(ctx, app.Storage, issue, true, false)
 
+	// Add progress rollup for issues that track others
+	if len(graph.TrackedIDs(issue)) > 0 {
+		if r, err := graph.ComputeRollup(ctx, app.Storage, issue); err == nil {
+			out.Rollup = &RollupJSON{Children: r.Children, Closed: r.Closed, Total: r.Total, Tracked: r.Tracked}
+		}
+	}
+
+	if e, ok := effortRollup(ctx, app, issue); ok {
+		out.Effort = toEffortJSON(e)
+	}
+
 	// Add inherited blockers if cascade is enabled and issue has a parent
 	if cascade := cascadeEnabled(app); cascade && issue.Parent != "" {
 		closedSet, err := graph.BuildClosedSet(ctx, app.Storage)
@@ -261,3 +429,18 @@ func outputIssueJSON(app *App, ctx context.Context, issue *issuestorage.Issue) e
 	// Original beads returns an array for show
 	return json.NewEncoder(app.Out).Encode([]IssueJSON{out})
 }
+
+// effortRollup totals the effort of issue with its descendants and
+// dependencies. ok is false when there is nothing to roll up: the issue
+// stands alone, or no covered issue has an estimate or logged time.
+func effortRollup(ctx context.Context, app *App, issue *issuestorage.Issue) (graph.Effort, bool) {
+	blocks, tracks := issuestorage.DepTypeBlocks, issuestorage.DepTypeTracks
+	if len(issue.Children()) == 0 && len(issue.DependencyIDs(&blocks)) == 0 && len(issue.DependencyIDs(&tracks)) == 0 {
+		return graph.Effort{}, false
+	}
+	e, err := graph.ComputeEffort(ctx, app.Storage, issue)
+	if err != nil || !e.Any() {
+		return graph.Effort{}, false
+	}
+	return e, true
+}
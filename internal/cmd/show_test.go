@@ -528,7 +528,7 @@ func TestShowWithComments(t *testing.T) {
 	}
 
 	// Add a comment
-	if err := addComment(ctx, store, id, &issuestorage.Comment{
+	if err := addComment(ctx, rs, id, &issuestorage.Comment{
 		Author: "bob",
 		Text:   "This is a test comment",
 	}); err != nil {
@@ -561,3 +561,59 @@ func TestShowWithComments(t *testing.T) {
 		t.Errorf("expected comment body, got: %s", output)
 	}
 }
+
+func TestShowTracksRollup(t *testing.T) {
+	app, rs := setupTestApp(t)
+	ctx := context.Background()
+
+	epic, _ := rs.Create(ctx, &issuestorage.Issue{Title: "Epic", Type: issuestorage.TypeEpic})
+	other, _ := rs.Create(ctx, &issuestorage.Issue{Title: "Other epic", Type: issuestorage.TypeEpic})
+	child, _ := rs.Create(ctx, &issuestorage.Issue{Title: "Child"})
+	shared, _ := rs.Create(ctx, &issuestorage.Issue{Title: "Shared"})
+	if err := rs.AddDependency(ctx, child, epic, issuestorage.DepTypeParentChild); err != nil {
+		t.Fatalf("add parent-child: %v", err)
+	}
+	for _, e := range []string{epic, other} {
+		if err := rs.AddDependency(ctx, e, shared, issuestorage.DepTypeTracks); err != nil {
+			t.Fatalf("add tracks: %v", err)
+		}
+	}
+	if err := rs.Modify(ctx, shared, func(i *issuestorage.Issue) error { i.Status = issuestorage.StatusClosed; return nil }); err != nil {
+		t.Fatalf("close shared: %v", err)
+	}
+
+	issue, _ := rs.Get(ctx, epic)
+	if err := outputIssue(app, ctx, issue); err != nil {
+		t.Fatalf("show failed: %v", err)
+	}
+	out := app.Out.(*bytes.Buffer).String()
+	if !strings.Contains(out, "Progress: 1/2 closed (1 children, 1 tracked)") || !strings.Contains(out, "Tracks") {
+		t.Errorf("expected rollup and tracks section, got:\n%s", out)
+	}
+	if strings.Contains(out, "Depends On") {
+		t.Errorf("tracks edge should not be listed under Depends On:\n%s", out)
+	}
+
+	app.Out = &bytes.Buffer{}
+	sharedIssue, _ := rs.Get(ctx, shared)
+	if err := outputIssue(app, ctx, sharedIssue); err != nil {
+		t.Fatalf("show failed: %v", err)
+	}
+	out = app.Out.(*bytes.Buffer).String()
+	if !strings.Contains(out, "Tracked By") || strings.Contains(out, "Blocks") {
+		t.Errorf("expected Tracked By section and no Blocks, got:\n%s", out)
+	}
+
+	app.Out = &bytes.Buffer{}
+	app.JSON = true
+	if err := outputIssue(app, ctx, issue); err != nil {
+		t.Fatalf("show --json failed: %v", err)
+	}
+	var shown []IssueJSON
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &shown); err != nil {
+		t.Fatalf("parse JSON: %v", err)
+	}
+	if len(shown) != 1 || shown[0].Rollup == nil || shown[0].Rollup.Total != 2 || shown[0].Rollup.Closed != 1 {
+		t.Errorf("unexpected rollup JSON: %+v", shown)
+	}
+}
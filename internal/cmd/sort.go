@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// issueSortKeys lists the values accepted by --sort.
+var issueSortKeys = []string{"priority", "created", "updated", "closed", "status", "id", "title", "type", "assignee", "rank"}
+
+// addSortFlags registers --sort and --reverse on cmd.
+func addSortFlags(cmd *cobra.Command, key *string, reverse *bool, def string) {
+	cmd.Flags().StringVar(key, "sort", def, "Sort by field ("+strings.Join(issueSortKeys, ", ")+")")
+	cmd.Flags().BoolVarP(reverse, "reverse", "r", false, "Reverse the sort order")
+}
+
+// sortIssues orders issues by key, applied identically to text and JSON
+// output. The sort is stable, so issues that compare equal keep the order
+// they were listed in (creation time, then ID), and the result is always
+// deterministic. An empty key leaves the order unchanged.
+func sortIssues(issues []*issuestorage.Issue, key string, reverse bool) error {
+	var less func(a, b *issuestorage.Issue) bool
+	switch key {
+	case "":
+		if reverse {
+			for i, j := 0, len(issues)-1; i < j; i, j = i+1, j-1 {
+				issues[i], issues[j] = issues[j], issues[i]
+			}
+		}
+		return nil
+	case "priority":
+		less = func(a, b *issuestorage.Issue) bool { return a.Priority < b.Priority }
+	case "created":
+		less = func(a, b *issuestorage.Issue) bool { return a.CreatedAt.Before(b.CreatedAt) }
+	case "updated":
+		less = func(a, b *issuestorage.Issue) bool { return a.UpdatedAt.Before(b.UpdatedAt) }
+	case "closed":
+		less = func(a, b *issuestorage.Issue) bool { return closedTime(a).Before(closedTime(b)) }
+	case "status":
+		less = func(a, b *issuestorage.Issue) bool { return a.Status < b.Status }
+	case "id":
+		less = func(a, b *issuestorage.Issue) bool { return a.ID < b.ID }
+	case "title":
+		less = func(a, b *issuestorage.Issue) bool { return strings.ToLower(a.Title) < strings.ToLower(b.Title) }
+	case "type":
+		less = func(a, b *issuestorage.Issue) bool { return a.Type < b.Type }
+	case "assignee":
+		less = func(a, b *issuestorage.Issue) bool { return a.Assignee < b.Assignee }
+	case "rank":
+		// Ranked issues come first, in rank order; unranked issues follow.
+		less = func(a, b *issuestorage.Issue) bool {
+			if a.Rank == "" || b.Rank == "" {
+				return a.Rank != "" && b.Rank == ""
+			}
+			return a.Rank < b.Rank
+		}
+	default:
+		return fmt.Errorf("invalid --sort value %q (valid: %s)", key, strings.Join(issueSortKeys, ", "))
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if reverse {
+			return less(issues[j], issues[i])
+		}
+		return less(issues[i], issues[j])
+	})
+	return nil
+}
+
+// closedTime returns an issue's close time, or the zero time if open.
+func closedTime(issue *issuestorage.Issue) time.Time {
+	if issue.ClosedAt == nil {
+		return time.Time{}
+	}
+	return *issue.ClosedAt
+}
@@ -0,0 +1,482 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/kvstorage"
+	"beads-lite/internal/sprint"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultSprintDays is the length of a sprint started without --length or
+// --end.
+const defaultSprintDays = 14
+
+// SprintJSON is the JSON output format for a sprint.
+type SprintJSON struct {
+	Name        string   `json:"name"`
+	Goal        string   `json:"goal,omitempty"`
+	Status      string   `json:"status"`
+	StartAt     string   `json:"start_at"`
+	EndAt       string   `json:"end_at"`
+	ClosedAt    string   `json:"closed_at,omitempty"`
+	Completed   []string `json:"completed,omitempty"`
+	CarriedOver []string `json:"carried_over,omitempty"`
+}
+
+// SprintPlanJSON is the JSON output of bd sprint plan.
+type SprintPlanJSON struct {
+	Sprint  string   `json:"sprint"`
+	Planned []string `json:"planned,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// SprintReportJSON is the JSON output of bd sprint report. Remaining
+// lists the issues not closed: still open in an active sprint, carried
+// over from a closed one.
+type SprintReportJSON struct {
+	Sprint    SprintJSON        `json:"sprint"`
+	Completed []IssueSimpleJSON `json:"completed"`
+	Remaining []IssueSimpleJSON `json:"remaining"`
+}
+
+func toSprintJSON(s sprint.Sprint) SprintJSON {
+	out := SprintJSON{
+		Name:        s.Name,
+		Goal:        s.Goal,
+		Status:      s.Status,
+		StartAt:     formatTime(s.StartAt),
+		EndAt:       formatTime(s.EndAt),
+		Completed:   s.Completed,
+		CarriedOver: s.CarriedOver,
+	}
+	if s.ClosedAt != nil {
+		out.ClosedAt = formatTime(*s.ClosedAt)
+	}
+	return out
+}
+
+// parseSprintLength parses a sprint length such as "10d" or "2w" into
+// calendar days.
+func parseSprintLength(s string) (int, error) {
+	unit := 0
+	switch {
+	case strings.HasSuffix(s, "d"):
+		unit = 1
+	case strings.HasSuffix(s, "w"):
+		unit = 7
+	}
+	n, err := strconv.Atoi(s[:max(len(s)-1, 0)])
+	if unit == 0 || err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid --length %q (expected days or weeks, e.g. 10d or 2w)", s)
+	}
+	return n * unit, nil
+}
+
+// activeSprint returns the active sprint, with a hint to start one if
+// there is none.
+func activeSprint(ctx context.Context, app *App) (sprint.Sprint, error) {
+	s, err := sprint.Active(ctx, app.SprintStore)
+	if errors.Is(err, sprint.ErrNoActiveSprint) {
+		return sprint.Sprint{}, fmt.Errorf("no active sprint: start one with 'bd sprint start <name>'")
+	}
+	return s, err
+}
+
+// sprintIssues returns the issues planned into the named sprint, ordered
+// by ID.
+func sprintIssues(ctx context.Context, app *App, name string) ([]*issuestorage.Issue, error) {
+	all, err := listAllIssuesForStats(ctx, app.Storage, &issuestorage.ListFilter{})
+	if err != nil {
+		return nil, err
+	}
+	var issues []*issuestorage.Issue
+	for _, issue := range all {
+		if issue.Sprint == name && issue.Status != issuestorage.StatusTombstone {
+			issues = append(issues, issue)
+		}
+	}
+	sort.Slice(issues, func(i, j int) bool { return issues[i].ID < issues[j].ID })
+	return issues, nil
+}
+
+// planIntoSprint sets the sprint of each issue to name ("" to unplan) and
+// returns their full IDs.
+func planIntoSprint(ctx context.Context, app *App, name string, ids []string) ([]string, error) {
+	planned := make([]string, 0, len(ids))
+	for _, id := range ids {
+		issue, err := resolveIssue(app.Storage, ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("resolving issue %s: %w", id, err)
+		}
+		if err := app.Storage.Modify(ctx, issue.ID, func(i *issuestorage.Issue) error {
+			i.Sprint = name
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("planning %s: %w", issue.ID, err)
+		}
+		planned = append(planned, issue.ID)
+	}
+	return planned, nil
+}
+
+// newSprintCmd creates the sprint command group.
+func newSprintCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sprint",
+		Short: "Plan work in time-boxed sprints",
+		Long: `Sprints are time boxes that issues are planned into, for teams that work
+in iterations. One sprint is active at a time; sprints are stored under
+.beads/sprints/.
+
+Start a sprint with bd sprint start, plan issues into it with bd sprint
+plan, and close it with bd sprint close, which records the issues
+completed and carries the rest over. bd sprint report compares the two.
+
+Examples:
+  bd sprint start sprint-14 --length 2w --goal "Ship search"
+  bd sprint plan bd-a1b2 bd-c3d4
+  bd sprint report
+  bd sprint close
+  bd sprint start sprint-15 --carry-over`,
+	}
+
+	cmd.AddCommand(newSprintStartCmd(provider))
+	cmd.AddCommand(newSprintPlanCmd(provider))
+	cmd.AddCommand(newSprintCloseCmd(provider))
+	cmd.AddCommand(newSprintReportCmd(provider))
+
+	return cmd
+}
+
+// newSprintStartCmd creates the "sprint start" subcommand.
+func newSprintStartCmd(provider *AppProvider) *cobra.Command {
+	var (
+		length    string
+		end       string
+		goal      string
+		carryOver bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "start <name>",
+		Short: "Start a sprint today",
+		Long: `Start a sprint today and make it the active sprint. It lasts two weeks
+unless --length (e.g. 10d, 2w) or --end (its last day) says otherwise.
+
+--carry-over plans the issues carried over from the last sprint that are
+still open into the new one.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			now := app.Now()
+			s := sprint.Sprint{
+				Name:    args[0],
+				Goal:    goal,
+				StartAt: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()),
+			}
+			switch {
+			case end != "" && length != "":
+				return fmt.Errorf("use either --length or --end, not both")
+			case end != "":
+				if s.EndAt, err = parseListCreatedTime(end, false); err != nil {
+					return fmt.Errorf("invalid --end value %q: %w", end, err)
+				}
+			default:
+				days := defaultSprintDays
+				if length != "" {
+					if days, err = parseSprintLength(length); err != nil {
+						return err
+					}
+				}
+				s.EndAt = s.StartAt.AddDate(0, 0, days-1)
+			}
+
+			var carried []string
+			if carryOver {
+				previous, err := sprint.Latest(ctx, app.SprintStore)
+				if err != nil && !errors.Is(err, sprint.ErrNoActiveSprint) {
+					return err
+				}
+				for _, id := range previous.CarriedOver {
+					issue, err := app.Storage.Get(ctx, id)
+					if errors.Is(err, issuestorage.ErrNotFound) {
+						continue
+					}
+					if err != nil {
+						return fmt.Errorf("getting %s: %w", id, err)
+					}
+					if issue.Status != issuestorage.StatusClosed && issue.Status != issuestorage.StatusTombstone && issue.Sprint == "" {
+						carried = append(carried, id)
+					}
+				}
+			}
+
+			// Repositories initialized before sprints existed have no
+			// table directory yet.
+			if st, ok := app.SprintStore.(interface{ Init(context.Context) error }); ok {
+				if err := st.Init(ctx); err != nil {
+					return fmt.Errorf("initializing sprint store: %w", err)
+				}
+			}
+			if err := sprint.Start(ctx, app.SprintStore, s); err != nil {
+				return err
+			}
+			if _, err := planIntoSprint(ctx, app, s.Name, carried); err != nil {
+				return err
+			}
+			if s, err = sprint.Get(ctx, app.SprintStore, s.Name); err != nil {
+				return err
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(toSprintJSON(s))
+			}
+			fmt.Fprintf(app.Out, "%s Started sprint %s (%s to %s)\n", app.SuccessColor("✓"), s.Name, s.StartAt.Format("2006-01-02"), s.EndAt.Format("2006-01-02"))
+			if len(carried) > 0 {
+				fmt.Fprintf(app.Out, "  Carried over %d issue(s): %s\n", len(carried), strings.Join(carried, ", "))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&length, "length", "", "Sprint length in days or weeks (e.g. 10d, 2w; default 2w)")
+	cmd.Flags().StringVar(&end, "end", "", "Last day of the sprint (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&goal, "goal", "", "What the sprint sets out to achieve")
+	cmd.Flags().BoolVar(&carryOver, "carry-over", false, "Plan in the open issues carried over from the last sprint")
+
+	return cmd
+}
+
+// newSprintPlanCmd creates the "sprint plan" subcommand.
+func newSprintPlanCmd(provider *AppProvider) *cobra.Command {
+	var remove bool
+
+	cmd := &cobra.Command{
+		Use:   "plan <issue-id>...",
+		Short: "Plan issues into the active sprint",
+		Long: `Plan issues into the active sprint, moving them out of any other.
+--remove takes them out of the sprint instead.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			s, err := activeSprint(ctx, app)
+			if err != nil {
+				return err
+			}
+			result := SprintPlanJSON{Sprint: s.Name}
+			if remove {
+				for _, id := range args {
+					issue, err := resolveIssue(app.Storage, ctx, id)
+					if err != nil {
+						return fmt.Errorf("resolving issue %s: %w", id, err)
+					}
+					if issue.Sprint != s.Name {
+						return fmt.Errorf("%s is not planned into sprint %s", issue.ID, s.Name)
+					}
+				}
+				if result.Removed, err = planIntoSprint(ctx, app, "", args); err != nil {
+					return err
+				}
+			} else if result.Planned, err = planIntoSprint(ctx, app, s.Name, args); err != nil {
+				return err
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(result)
+			}
+			if remove {
+				fmt.Fprintf(app.Out, "%s Removed %s from sprint %s\n", app.SuccessColor("✓"), strings.Join(result.Removed, ", "), s.Name)
+			} else {
+				fmt.Fprintf(app.Out, "%s Planned %s into sprint %s\n", app.SuccessColor("✓"), strings.Join(result.Planned, ", "), s.Name)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&remove, "remove", false, "Take the issues out of the sprint")
+
+	return cmd
+}
+
+// newSprintCloseCmd creates the "sprint close" subcommand.
+func newSprintCloseCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "close",
+		Short: "Close the active sprint",
+		Long: `Close the active sprint. Its closed issues are recorded as completed;
+the rest are recorded as carried over and taken out of the sprint, ready
+for bd sprint start --carry-over or bd sprint plan.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			s, err := activeSprint(ctx, app)
+			if err != nil {
+				return err
+			}
+			issues, err := sprintIssues(ctx, app, s.Name)
+			if err != nil {
+				return err
+			}
+			var completed, carried []string
+			for _, issue := range issues {
+				if issue.Status == issuestorage.StatusClosed {
+					completed = append(completed, issue.ID)
+				} else {
+					carried = append(carried, issue.ID)
+				}
+			}
+			if s, err = sprint.Close(ctx, app.SprintStore, s, app.Now(), completed, carried); err != nil {
+				return err
+			}
+			if _, err := planIntoSprint(ctx, app, "", carried); err != nil {
+				return err
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(toSprintJSON(s))
+			}
+			fmt.Fprintf(app.Out, "%s Closed sprint %s: %d completed, %d carried over\n", app.SuccessColor("✓"), s.Name, len(completed), len(carried))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// newSprintReportCmd creates the "sprint report" subcommand.
+func newSprintReportCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report [name]",
+		Short: "Show a sprint's completed and carried-over work",
+		Long: `Show the issues completed in a sprint and those not completed: still
+open in the active sprint, or carried over from a closed one. Without a
+name, reports on the active sprint, or the last one if none is active.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			var s sprint.Sprint
+			if len(args) == 1 {
+				s, err = sprint.Get(ctx, app.SprintStore, args[0])
+				if errors.Is(err, kvstorage.ErrKeyNotFound) {
+					return fmt.Errorf("sprint %s not found", args[0])
+				}
+			} else {
+				s, err = sprint.Latest(ctx, app.SprintStore)
+				if errors.Is(err, sprint.ErrNoActiveSprint) {
+					return fmt.Errorf("no sprints yet: start one with 'bd sprint start <name>'")
+				}
+			}
+			if err != nil {
+				return err
+			}
+
+			var completed, remaining []*issuestorage.Issue
+			if s.Status == sprint.StatusActive {
+				issues, err := sprintIssues(ctx, app, s.Name)
+				if err != nil {
+					return err
+				}
+				for _, issue := range issues {
+					if issue.Status == issuestorage.StatusClosed {
+						completed = append(completed, issue)
+					} else {
+						remaining = append(remaining, issue)
+					}
+				}
+			} else {
+				if completed, err = getIssues(ctx, app, s.Completed); err != nil {
+					return err
+				}
+				if remaining, err = getIssues(ctx, app, s.CarriedOver); err != nil {
+					return err
+				}
+			}
+
+			if app.JSON {
+				report := SprintReportJSON{Sprint: toSprintJSON(s), Completed: []IssueSimpleJSON{}, Remaining: []IssueSimpleJSON{}}
+				for _, issue := range completed {
+					report.Completed = append(report.Completed, ToIssueSimpleJSON(issue))
+				}
+				for _, issue := range remaining {
+					report.Remaining = append(report.Remaining, ToIssueSimpleJSON(issue))
+				}
+				return json.NewEncoder(app.Out).Encode(report)
+			}
+
+			header := fmt.Sprintf("Sprint %s (%s) · %s to %s", s.Name, s.Status, s.StartAt.Format("2006-01-02"), s.EndAt.Format("2006-01-02"))
+			remainingLabel := "Carried over"
+			if s.Status == sprint.StatusActive {
+				remainingLabel = "Still open"
+				now := app.Now()
+				today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, s.EndAt.Location())
+				if left := int(s.EndAt.Sub(today).Hours()/24) + 1; left > 0 {
+					header += fmt.Sprintf(" · %d day(s) left", left)
+				} else {
+					header += " · past its end"
+				}
+			}
+			fmt.Fprintln(app.Out, header)
+			if s.Goal != "" {
+				fmt.Fprintln(app.Out, "Goal: "+s.Goal)
+			}
+			for _, group := range []struct {
+				label  string
+				issues []*issuestorage.Issue
+			}{{"Completed", completed}, {remainingLabel, remaining}} {
+				fmt.Fprintf(app.Out, "\n%s (%d):\n", group.label, len(group.issues))
+				for _, issue := range group.issues {
+					fmt.Fprintf(app.Out, "  %s  %s [%s]\n", issue.ID, issue.Title, issue.Status)
+				}
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// getIssues fetches issues by ID, skipping those that no longer exist.
+func getIssues(ctx context.Context, app *App, ids []string) ([]*issuestorage.Issue, error) {
+	issues := make([]*issuestorage.Issue, 0, len(ids))
+	for _, id := range ids {
+		issue, err := app.Storage.Get(ctx, id)
+		if errors.Is(err, issuestorage.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("getting %s: %w", id, err)
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
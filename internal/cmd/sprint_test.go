@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/clock"
+	"beads-lite/internal/issuestorage"
+	kvfs "beads-lite/internal/kvstorage/filesystem"
+
+	"github.com/spf13/cobra"
+)
+
+func TestParseSprintLength(t *testing.T) {
+	for in, want := range map[string]int{"10d": 10, "2w": 14, "1w": 7} {
+		if got, err := parseSprintLength(in); err != nil || got != want {
+			t.Errorf("parseSprintLength(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "2", "0w", "2wd", "1m", "-1d"} {
+		if _, err := parseSprintLength(bad); err == nil {
+			t.Errorf("parseSprintLength(%q) should fail", bad)
+		}
+	}
+}
+
+func TestSprintCommands(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2026, 10, 5, 9, 0, 0, 0, time.Local))
+	store.SetClock(fake)
+	// Left uninitialized: bd sprint start makes the table directory.
+	sprintStore, err := kvfs.New(t.TempDir(), "sprints")
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.SprintStore = sprintStore
+
+	exec := func(newCmd func(*AppProvider) *cobra.Command, args ...string) ([]byte, error) {
+		t.Helper()
+		out := &bytes.Buffer{}
+		app.Out = out
+		cmd := newCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.Bytes(), err
+	}
+	run := func(newCmd func(*AppProvider) *cobra.Command, args ...string) []byte {
+		t.Helper()
+		out, err := exec(newCmd, args...)
+		if err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+		return out
+	}
+
+	search := createAssigned(t, store, "Search index", "alice", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	ranking := createAssigned(t, store, "Ranking", "bob", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	typos := createAssigned(t, store, "Typo tolerance", "bob", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+
+	if _, err := exec(newSprintCmd, "plan", search); err == nil {
+		t.Error("planning without an active sprint should fail")
+	}
+	out := run(newSprintCmd, "start", "s14", "--length", "10d", "--goal", "Ship search")
+	if !strings.Contains(string(out), "Started sprint s14 (2026-10-05 to 2026-10-14)") {
+		t.Errorf("start output = %q", out)
+	}
+	if _, err := exec(newSprintCmd, "start", "s15"); err == nil {
+		t.Error("starting a sprint while s14 is active should fail")
+	}
+	run(newSprintCmd, "plan", search, ranking, typos)
+	run(newSprintCmd, "plan", "--remove", typos)
+	if issue, _ := store.Get(ctx, search); issue.Sprint != "s14" {
+		t.Errorf("%s sprint = %q", search, issue.Sprint)
+	}
+	if issue, _ := store.Get(ctx, typos); issue.Sprint != "" {
+		t.Errorf("%s still in sprint %q", typos, issue.Sprint)
+	}
+
+	fake.Advance(3 * 24 * time.Hour)
+	run(newCloseCmd, search)
+	out = run(newSprintCmd, "report")
+	for _, want := range []string{"Sprint s14 (active) · 2026-10-05 to 2026-10-14 · 7 day(s) left", "Goal: Ship search", "Completed (1):\n  " + search, "Still open (1):\n  " + ranking} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+
+	fake.Advance(7 * 24 * time.Hour)
+	out = run(newSprintCmd, "close")
+	if !strings.Contains(string(out), "Closed sprint s14: 1 completed, 1 carried over") {
+		t.Errorf("close output = %q", out)
+	}
+	if issue, _ := store.Get(ctx, ranking); issue.Sprint != "" {
+		t.Errorf("carried-over %s still in sprint %q", ranking, issue.Sprint)
+	}
+
+	app.JSON = true
+	var report SprintReportJSON
+	if err := json.Unmarshal(run(newSprintCmd, "report", "s14"), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Sprint.Status != "closed" || len(report.Completed) != 1 || report.Completed[0].ID != search || len(report.Remaining) != 1 || report.Remaining[0].ID != ranking {
+		t.Errorf("report = %+v", report)
+	}
+
+	var next SprintJSON
+	if err := json.Unmarshal(run(newSprintCmd, "start", "s15", "--end", "2026-10-30", "--carry-over"), &next); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(next.EndAt, "2026-10-30") || next.Status != "active" {
+		t.Errorf("s15 = %+v", next)
+	}
+	if issue, _ := store.Get(ctx, ranking); issue.Sprint != "s15" {
+		t.Errorf("--carry-over left %s in sprint %q", ranking, issue.Sprint)
+	}
+	var planned SprintPlanJSON
+	if err := json.Unmarshal(run(newSprintCmd, "plan", typos), &planned); err != nil {
+		t.Fatal(err)
+	}
+	if want := (SprintPlanJSON{Sprint: "s15", Planned: []string{typos}}); !reflect.DeepEqual(planned, want) {
+		t.Errorf("plan = %+v, want %+v", planned, want)
+	}
+}
@@ -22,22 +22,47 @@ type StatsSummary struct {
 	InProgressIssues        int     `json:"in_progress_issues"`
 	OpenIssues              int     `json:"open_issues"`
 	PinnedIssues            int     `json:"pinned_issues"`
+	ReviewIssues            int     `json:"review_issues,omitempty"`
 	ReadyIssues             int     `json:"ready_issues"`
 	TombstoneIssues         int     `json:"tombstone_issues"`
 	TotalIssues             int     `json:"total_issues"`
 }
 
-// StatsResult wraps the summary in a top-level object.
+// StatsResult wraps the summary in a top-level object. ClosedByReason
+// counts closed issues by resolution ("unspecified" for none) and is only
+// present when at least one closed issue has a resolution. Effort totals
+// estimates and logged time, and is only present when there are some.
+// Milestones summarizes the milestones the selected issues are planned for.
+// Series is only present with --burndown or --throughput.
 type StatsResult struct {
-	Summary StatsSummary `json:"summary"`
+	Summary        StatsSummary     `json:"summary"`
+	ClosedByReason map[string]int   `json:"closed_by_reason,omitempty"`
+	Reopens        *ReopenStatsJSON `json:"reopens,omitempty"`
+	Effort         *EffortJSON      `json:"effort,omitempty"`
+	Milestones     []MilestoneJSON  `json:"milestones,omitempty"`
+	Series         *StatsSeriesJSON `json:"series,omitempty"`
 }
 
+// ReopenStatsJSON counts reopens across the selected issues. It is only
+// present when at least one issue has been reopened; bd flappy lists them.
+type ReopenStatsJSON struct {
+	ReopenedIssues int `json:"reopened_issues"`
+	TotalReopens   int `json:"total_reopens"`
+}
+
+// unspecifiedResolution labels closed issues without a resolution in stats.
+const unspecifiedResolution = "unspecified"
+
 // newStatsCmd creates the stats command.
 func newStatsCmd(provider *AppProvider) *cobra.Command {
 	var (
 		createdAfter  string
 		createdBefore string
 		idsCSV        string
+		burndown      bool
+		throughput    bool
+		by            string
+		periods       int
 	)
 
 	cmd := &cobra.Command{
@@ -50,10 +75,25 @@ By default, stats are computed over all issues.
 Use --created-after/--created-before to scope by creation time.
 Use --ids to scope stats to specific issue IDs (comma-separated).
 
+When issues have estimates or logged time (bd update --estimate, bd
+log-time), the effort remaining across the selected issues is shown too.
+With --ids that includes the descendants of each issue, so an epic's ID
+gives its remaining effort.
+
+Issues planned for milestones (bd milestone) are summarized per
+milestone: how many are open and closed, and a burndown of the issues
+open at the end of each day since the milestone was created.
+
+--burndown charts how many of the selected issues were open at the end of
+each day (or --by week), and --throughput how many were opened and closed
+in each, from their created and closed times. They cover the last 30 days
+or 12 weeks, up to today; --periods changes how many.
+
 Examples:
   bd stats
   bd stats --created-after 2026-03-01 --created-before 2026-03-31
-  bd stats --ids bd-abc,bd-def,bd-ghi`,
+  bd stats --ids bd-abc,bd-def,bd-ghi
+  bd stats --burndown --throughput --by week`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			app, err := provider.Get()
 			if err != nil {
@@ -63,6 +103,8 @@ Examples:
 			ctx := cmd.Context()
 
 			var summary StatsSummary
+			byReason := make(map[string]int)
+			hasResolutions := false
 
 			selectedIssues, err := collectStatsIssues(ctx, app.Storage, idsCSV, createdAfter, createdBefore)
 			if err != nil {
@@ -80,7 +122,14 @@ Examples:
 				closedSet[issue.ID] = true
 			}
 
+			var reopens ReopenStatsJSON
+			var effort graph.Effort
 			for _, issue := range selectedIssues {
+				effort.Add(issue)
+				if issue.ReopenCount > 0 {
+					reopens.ReopenedIssues++
+					reopens.TotalReopens += issue.ReopenCount
+				}
 				switch issue.Status {
 				case issuestorage.StatusOpen:
 					summary.OpenIssues++
@@ -101,8 +150,16 @@ Examples:
 					summary.DeferredIssues++
 				case issuestorage.StatusPinned:
 					summary.PinnedIssues++
+				case issuestorage.StatusReview:
+					summary.ReviewIssues++
 				case issuestorage.StatusClosed:
 					summary.ClosedIssues++
+					if issue.Resolution != "" {
+						byReason[string(issue.Resolution)]++
+						hasResolutions = true
+					} else {
+						byReason[unspecifiedResolution]++
+					}
 				case issuestorage.StatusTombstone:
 					summary.TombstoneIssues++
 				}
@@ -117,16 +174,43 @@ Examples:
 				summary.AverageLeadTimeHours = 0.001 // Placeholder
 			}
 
+			result := StatsResult{Summary: summary}
+			if hasResolutions {
+				result.ClosedByReason = byReason
+			}
+			if reopens.ReopenedIssues > 0 {
+				result.Reopens = &reopens
+			}
+			if effort.Any() {
+				result.Effort = toEffortJSON(effort)
+			}
+			milestones, err := milestoneSummaries(ctx, app, selectedIssues)
+			if err != nil {
+				return err
+			}
+			for _, m := range milestones {
+				result.Milestones = append(result.Milestones, m.toJSON())
+			}
+			var flow []graph.Period
+			if burndown || throughput {
+				if flow, result.Series, err = statsFlow(app, selectedIssues, by, periods, burndown, throughput); err != nil {
+					return err
+				}
+			}
+
 			if app.JSON {
-				return json.NewEncoder(app.Out).Encode(StatsResult{Summary: summary})
+				return json.NewEncoder(app.Out).Encode(result)
 			}
 
 			// Human-readable output
-			openTotal := summary.OpenIssues + summary.InProgressIssues + summary.BlockedIssues + summary.DeferredIssues
+			openTotal := summary.OpenIssues + summary.InProgressIssues + summary.ReviewIssues + summary.BlockedIssues + summary.DeferredIssues
 			fmt.Fprintf(app.Out, "Open issues:     %d\n", openTotal)
 			if summary.InProgressIssues > 0 {
 				fmt.Fprintf(app.Out, "  In progress:   %d\n", summary.InProgressIssues)
 			}
+			if summary.ReviewIssues > 0 {
+				fmt.Fprintf(app.Out, "  In review:     %d\n", summary.ReviewIssues)
+			}
 			if summary.BlockedIssues > 0 {
 				fmt.Fprintf(app.Out, "  Blocked:       %d\n", summary.BlockedIssues)
 			}
@@ -134,7 +218,30 @@ Examples:
 				fmt.Fprintf(app.Out, "  Deferred:      %d\n", summary.DeferredIssues)
 			}
 			fmt.Fprintf(app.Out, "Closed issues:   %d\n", summary.ClosedIssues)
+			if result.ClosedByReason != nil {
+				for _, r := range append(issuestorage.Resolutions, unspecifiedResolution) {
+					if n := result.ClosedByReason[string(r)]; n > 0 {
+						fmt.Fprintf(app.Out, "  %-14s %d\n", string(r)+":", n)
+					}
+				}
+			}
+			if result.Reopens != nil {
+				fmt.Fprintf(app.Out, "Reopened:        %d issue(s), %d time(s) (see bd flappy)\n", reopens.ReopenedIssues, reopens.TotalReopens)
+			}
 			fmt.Fprintf(app.Out, "Total:           %d\n", summary.TotalIssues)
+			if result.Effort != nil {
+				fmt.Fprintf(app.Out, "Effort:          %s\n", formatEffortTotals(effort))
+			}
+			if len(milestones) > 0 {
+				fmt.Fprintln(app.Out, "Milestones:")
+				for _, m := range milestones {
+					fmt.Fprintf(app.Out, "  %s: %s\n", m.milestone.Name, formatMilestoneProgress(m.milestone, m.progress))
+					if len(m.burndown) > 0 {
+						fmt.Fprintf(app.Out, "    %s\n", formatBurndown(m.burndown))
+					}
+				}
+			}
+			writeStatsFlow(app.Out, flow, by, burndown, throughput)
 
 			return nil
 		},
@@ -143,6 +250,10 @@ Examples:
 	cmd.Flags().StringVar(&createdAfter, "created-after", "", "Filter stats by created_at >= this time (YYYY-MM-DD or RFC3339; timezone optional for local time)")
 	cmd.Flags().StringVar(&createdBefore, "created-before", "", "Filter stats by created_at <= this time (YYYY-MM-DD or RFC3339; timezone optional for local time)")
 	cmd.Flags().StringVar(&idsCSV, "ids", "", "Comma-separated issue IDs to include")
+	cmd.Flags().BoolVar(&burndown, "burndown", false, "Chart the issues open at the end of each period")
+	cmd.Flags().BoolVar(&throughput, "throughput", false, "Chart the issues opened and closed in each period")
+	cmd.Flags().StringVar(&by, "by", flowByDay, "Period for --burndown and --throughput (day, week)")
+	cmd.Flags().IntVar(&periods, "periods", 0, "Number of periods to chart (default 30 days or 12 weeks)")
 
 	return cmd
 }
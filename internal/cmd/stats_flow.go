@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"beads-lite/internal/graph"
+	"beads-lite/internal/issuestorage"
+)
+
+// Periods bd stats --by groups the flow series into.
+const (
+	flowByDay  = "day"
+	flowByWeek = "week"
+)
+
+// Default number of periods bd stats --burndown and --throughput cover.
+const (
+	defaultFlowDays  = 30
+	defaultFlowWeeks = 12
+)
+
+// sparkBlocks are the bars of a sparkline, lowest first.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// StatsSeriesJSON is the per-period series bd stats --burndown and
+// --throughput add. Period is "day" or "week"; Date is the period's first
+// day. Each series is only present when its flag is given.
+type StatsSeriesJSON struct {
+	Period     string                   `json:"period"`
+	Burndown   []StatsBurndownPointJSON `json:"burndown,omitempty"`
+	Throughput []StatsThroughputJSON    `json:"throughput,omitempty"`
+}
+
+// StatsBurndownPointJSON is how many issues were open at the end of a period.
+type StatsBurndownPointJSON struct {
+	Date string `json:"date"`
+	Open int    `json:"open"`
+}
+
+// StatsThroughputJSON is how many issues were opened and closed in a period.
+type StatsThroughputJSON struct {
+	Date   string `json:"date"`
+	Opened int    `json:"opened"`
+	Closed int    `json:"closed"`
+}
+
+// statsFlow buckets issues into periods of by ending with the one
+// containing now, and returns them with the series JSON for the flags.
+func statsFlow(app *App, issues []*issuestorage.Issue, by string, periods int, burndown, throughput bool) ([]graph.Period, *StatsSeriesJSON, error) {
+	days := 1
+	switch by {
+	case flowByDay:
+		if periods == 0 {
+			periods = defaultFlowDays
+		}
+	case flowByWeek:
+		days = 7
+		if periods == 0 {
+			periods = defaultFlowWeeks
+		}
+	default:
+		return nil, nil, fmt.Errorf("invalid --by value %q (valid: %s, %s)", by, flowByDay, flowByWeek)
+	}
+	if periods < 0 {
+		return nil, nil, fmt.Errorf("--periods must be positive")
+	}
+
+	now := app.Now()
+	flow := graph.Flow(issues, now.AddDate(0, 0, -days*(periods-1)), now, days)
+	series := &StatsSeriesJSON{Period: by}
+	for _, p := range flow {
+		date := p.Start.Format("2006-01-02")
+		if burndown {
+			series.Burndown = append(series.Burndown, StatsBurndownPointJSON{Date: date, Open: p.Open})
+		}
+		if throughput {
+			series.Throughput = append(series.Throughput, StatsThroughputJSON{Date: date, Opened: p.Opened, Closed: p.Closed})
+		}
+	}
+	return flow, series, nil
+}
+
+// sparkline draws values as a row of bars scaled to the largest.
+func sparkline(values []int) string {
+	top := 0
+	for _, v := range values {
+		top = max(top, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		i := 0
+		if top > 0 {
+			i = v * (len(sparkBlocks) - 1) / top
+		}
+		b.WriteRune(sparkBlocks[i])
+	}
+	return b.String()
+}
+
+// writeStatsFlow prints the burndown and throughput sparklines for flow.
+func writeStatsFlow(w io.Writer, flow []graph.Period, by string, burndown, throughput bool) {
+	if len(flow) == 0 {
+		return
+	}
+	span := fmt.Sprintf("%s to %s", flow[0].Start.Format("2006-01-02"), flow[len(flow)-1].Start.Format("2006-01-02"))
+	open := make([]int, len(flow))
+	opened := make([]int, len(flow))
+	closed := make([]int, len(flow))
+	var totalOpened, totalClosed int
+	for i, p := range flow {
+		open[i], opened[i], closed[i] = p.Open, p.Opened, p.Closed
+		totalOpened += p.Opened
+		totalClosed += p.Closed
+	}
+	if burndown {
+		fmt.Fprintf(w, "Burndown (open at end of each %s, %s):\n", by, span)
+		fmt.Fprintf(w, "  Open    %s  %d → %d\n", sparkline(open), open[0], open[len(open)-1])
+	}
+	if throughput {
+		fmt.Fprintf(w, "Throughput (per %s, %s):\n", by, span)
+		fmt.Fprintf(w, "  Opened  %s  %d total\n", sparkline(opened), totalOpened)
+		fmt.Fprintf(w, "  Closed  %s  %d total\n", sparkline(closed), totalClosed)
+	}
+}
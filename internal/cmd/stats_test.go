@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"beads-lite/internal/clock"
 	"beads-lite/internal/issueservice"
 	"beads-lite/internal/issuestorage"
 	"beads-lite/internal/issuestorage/filesystem"
@@ -160,6 +161,62 @@ func TestStatsCmd_JSON(t *testing.T) {
 	}
 }
 
+func TestStatsCmd_ClosedByReason(t *testing.T) {
+	app, rs := setupTestApp(t)
+	ctx := context.Background()
+	closeAs := func(r issuestorage.Resolution) {
+		id, _ := rs.Create(ctx, &issuestorage.Issue{Title: "Issue"})
+		rs.Modify(ctx, id, func(i *issuestorage.Issue) error {
+			i.Status = issuestorage.StatusClosed
+			i.Resolution = r
+			return nil
+		})
+	}
+
+	// Without resolutions the breakdown is omitted.
+	closeAs("")
+	app.JSON = true
+	cmd := newStatsCmd(NewTestProvider(app))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("stats command failed: %v", err)
+	}
+	if strings.Contains(app.Out.(*bytes.Buffer).String(), "closed_by_reason") {
+		t.Errorf("unexpected closed_by_reason: %s", app.Out.(*bytes.Buffer).String())
+	}
+
+	closeAs(issuestorage.ResolutionFixed)
+	closeAs(issuestorage.ResolutionFixed)
+	closeAs(issuestorage.ResolutionWontFix)
+	app.Out.(*bytes.Buffer).Reset()
+	cmd = newStatsCmd(NewTestProvider(app))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("stats command failed: %v", err)
+	}
+	var result StatsResult
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &result); err != nil {
+		t.Fatalf("failed to parse JSON output: %v", err)
+	}
+	want := map[string]int{"fixed": 2, "wontfix": 1, "unspecified": 1}
+	if len(result.ClosedByReason) != len(want) {
+		t.Fatalf("closed_by_reason = %v, want %v", result.ClosedByReason, want)
+	}
+	for k, v := range want {
+		if result.ClosedByReason[k] != v {
+			t.Errorf("closed_by_reason[%s] = %d, want %d", k, result.ClosedByReason[k], v)
+		}
+	}
+
+	app.JSON = false
+	app.Out.(*bytes.Buffer).Reset()
+	cmd = newStatsCmd(NewTestProvider(app))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("stats command failed: %v", err)
+	}
+	if out := app.Out.(*bytes.Buffer).String(); !strings.Contains(out, "fixed:") || !strings.Contains(out, "unspecified:") {
+		t.Errorf("text output missing reasons:\n%s", out)
+	}
+}
+
 func TestStatsCmd_IDsIncludesDescendantsRecursively(t *testing.T) {
 	dir := t.TempDir()
 	s := filesystem.New(dir, "bd-")
@@ -168,19 +225,26 @@ func TestStatsCmd_IDsIncludesDescendantsRecursively(t *testing.T) {
 		t.Fatalf("failed to init storage: %v", err)
 	}
 	rs := issueservice.New(nil, s)
+	rs.SetAutoCloseParent(false) // keep the ancestors open when the grandchild closes
 
 	parentID, err := rs.Create(ctx, &issuestorage.Issue{Title: "Parent", Type: issuestorage.TypeEpic})
 	if err != nil {
 		t.Fatalf("failed to create parent issue: %v", err)
 	}
-	childID, err := rs.Create(ctx, &issuestorage.Issue{Title: "Child", Parent: parentID})
+	childID, err := rs.Create(ctx, &issuestorage.Issue{Title: "Child"})
 	if err != nil {
 		t.Fatalf("failed to create child issue: %v", err)
 	}
-	grandChildID, err := rs.Create(ctx, &issuestorage.Issue{Title: "Grandchild", Parent: childID})
+	if err := rs.AddDependency(ctx, childID, parentID, issuestorage.DepTypeParentChild); err != nil {
+		t.Fatalf("failed to parent child issue: %v", err)
+	}
+	grandChildID, err := rs.Create(ctx, &issuestorage.Issue{Title: "Grandchild"})
 	if err != nil {
 		t.Fatalf("failed to create grandchild issue: %v", err)
 	}
+	if err := rs.AddDependency(ctx, grandChildID, childID, issuestorage.DepTypeParentChild); err != nil {
+		t.Fatalf("failed to parent grandchild issue: %v", err)
+	}
 	if err := rs.Modify(ctx, grandChildID, func(i *issuestorage.Issue) error {
 		i.Status = issuestorage.StatusClosed
 		return nil
@@ -227,14 +291,19 @@ func TestStatsCmd_IDsCombinedWithCreatedRangeFiltersExpandedSubtree(t *testing.T
 	if err != nil {
 		t.Fatalf("failed to create parent issue: %v", err)
 	}
-	childInRangeID, err := rs.Create(ctx, &issuestorage.Issue{Title: "Child in range", Parent: parentID})
+	childInRangeID, err := rs.Create(ctx, &issuestorage.Issue{Title: "Child in range"})
 	if err != nil {
 		t.Fatalf("failed to create in-range child issue: %v", err)
 	}
-	childOutOfRangeID, err := rs.Create(ctx, &issuestorage.Issue{Title: "Child out of range", Parent: parentID})
+	childOutOfRangeID, err := rs.Create(ctx, &issuestorage.Issue{Title: "Child out of range"})
 	if err != nil {
 		t.Fatalf("failed to create out-of-range child issue: %v", err)
 	}
+	for _, id := range []string{childInRangeID, childOutOfRangeID} {
+		if err := rs.AddDependency(ctx, id, parentID, issuestorage.DepTypeParentChild); err != nil {
+			t.Fatalf("failed to parent child issue: %v", err)
+		}
+	}
 
 	if err := rs.Modify(ctx, parentID, func(i *issuestorage.Issue) error {
 		i.CreatedAt = time.Date(2026, 2, 1, 10, 0, 0, 0, time.Local)
@@ -281,3 +350,78 @@ func TestStatsCmd_IDsCombinedWithCreatedRangeFiltersExpandedSubtree(t *testing.T
 		t.Fatalf("expected closed_issues=0, got %d", result.Summary.ClosedIssues)
 	}
 }
+
+func TestStatsCmd_BurndownAndThroughput(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2026, 10, 12, 10, 0, 0, 0, time.Local))
+	store.SetClock(fake)
+
+	// Two issues on the 12th, one more on the 13th; one closes on the 14th.
+	first := createAssigned(t, store, "First", "", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	createAssigned(t, store, "Second", "", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	fake.Advance(24 * time.Hour)
+	createAssigned(t, store, "Third", "", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	fake.Advance(24 * time.Hour)
+	if err := store.Modify(ctx, first, func(i *issuestorage.Issue) error {
+		i.Status = issuestorage.StatusClosed
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	app.Out = &out
+	app.JSON = true
+	cmd := newStatsCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--burndown", "--throughput", "--periods", "4"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("stats command failed: %v", err)
+	}
+	var result StatsResult
+	if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	if result.Series == nil || result.Series.Period != "day" {
+		t.Fatalf("series = %+v", result.Series)
+	}
+	wantOpen := []int{0, 2, 3, 2}
+	wantOpened := []int{0, 2, 1, 0}
+	wantClosed := []int{0, 0, 0, 1}
+	if len(result.Series.Burndown) != 4 || len(result.Series.Throughput) != 4 {
+		t.Fatalf("series = %+v", result.Series)
+	}
+	if result.Series.Burndown[0].Date != "2026-10-11" {
+		t.Errorf("first date = %s, want 2026-10-11", result.Series.Burndown[0].Date)
+	}
+	for i := range wantOpen {
+		b, tp := result.Series.Burndown[i], result.Series.Throughput[i]
+		if b.Open != wantOpen[i] || tp.Opened != wantOpened[i] || tp.Closed != wantClosed[i] {
+			t.Errorf("%s: open %d, opened %d, closed %d; want %d, %d, %d", b.Date, b.Open, tp.Opened, tp.Closed, wantOpen[i], wantOpened[i], wantClosed[i])
+		}
+	}
+
+	out.Reset()
+	app.JSON = false
+	cmd = newStatsCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--burndown", "--throughput", "--by", "week"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("stats command failed: %v", err)
+	}
+	for _, want := range []string{
+		"Burndown (open at end of each week, 2026-07-27 to 2026-10-12):",
+		"  Open    ▁▁▁▁▁▁▁▁▁▁▁█  0 → 2",
+		"  Opened  ▁▁▁▁▁▁▁▁▁▁▁█  3 total",
+		"  Closed  ▁▁▁▁▁▁▁▁▁▁▁█  1 total",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	cmd = newStatsCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--throughput", "--by", "month"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected an error for --by month")
+	}
+}
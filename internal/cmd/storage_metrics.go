@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"beads-lite/internal/issuestorage/metrics"
+)
+
+// slowThreshold returns the configured storage.slow_threshold, falling back
+// to the default when unset or invalid ("bd config validate" reports it).
+func slowThreshold(get func(string) (string, bool)) time.Duration {
+	if v, ok := get(metrics.SlowThresholdKey); ok {
+		if v == "0" {
+			return 0
+		}
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return metrics.DefaultSlowThreshold
+}
+
+// storageHint explains a slow storage operation and what to do about it.
+func storageHint(st metrics.OpStats) string {
+	if st.SlowestFiles > 0 {
+		hint := fmt.Sprintf("%s scanned %s files in %s", st.Op, formatCount(st.SlowestFiles), formatSeconds(st.Slowest))
+		if st.Op == metrics.OpList || st.Op == metrics.OpDoctor {
+			return hint + " — `bd compact --older-than 6m` removes old closed issues that every scan has to read"
+		}
+		return hint
+	}
+	hint := fmt.Sprintf("%s took %s", st.Op, formatSeconds(st.Slowest))
+	if st.Count > 1 {
+		hint += fmt.Sprintf(" (%d calls, %s total)", st.Count, formatSeconds(st.Total))
+	}
+	return hint + " — the issue store may be on a slow or network filesystem"
+}
+
+// printStorageHints writes a hint for each storage operation that took at
+// least threshold.
+func printStorageHints(w io.Writer, m *metrics.Store, threshold time.Duration) {
+	for _, st := range m.Slow(threshold) {
+		fmt.Fprintf(w, "hint: %s\n", storageHint(st))
+	}
+}
+
+// printStorageMetrics writes a table of every recorded storage operation.
+func printStorageMetrics(w io.Writer, m *metrics.Store) {
+	fmt.Fprintf(w, "%-14s %6s %10s %10s\n", "OPERATION", "CALLS", "TOTAL", "SLOWEST")
+	for _, st := range m.Snapshot() {
+		fmt.Fprintf(w, "%-14s %6d %10s %10s\n", st.Op, st.Count, st.Total.Round(time.Microsecond), st.Slowest.Round(time.Microsecond))
+	}
+}
+
+func formatSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
+
+// formatCount formats n with thousands separators, e.g. 42,113.
+func formatCount(n int64) string {
+	s := strconv.FormatInt(n, 10)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage/metrics"
+)
+
+func TestStorageHint(t *testing.T) {
+	got := storageHint(metrics.OpStats{Op: metrics.OpList, Count: 1, Slowest: 9300 * time.Millisecond, SlowestFiles: 42113})
+	if !strings.HasPrefix(got, "list scanned 42,113 files in 9.3s") || !strings.Contains(got, "bd compact") {
+		t.Errorf("list hint = %q", got)
+	}
+
+	got = storageHint(metrics.OpStats{Op: metrics.OpGet, Count: 4, Total: 10 * time.Second, Slowest: 3 * time.Second})
+	if !strings.HasPrefix(got, "get took 3.0s (4 calls, 10.0s total)") {
+		t.Errorf("get hint = %q", got)
+	}
+}
+
+func TestSlowThreshold(t *testing.T) {
+	get := func(v string) func(string) (string, bool) {
+		return func(string) (string, bool) { return v, v != "" }
+	}
+	if d := slowThreshold(get("")); d != metrics.DefaultSlowThreshold {
+		t.Errorf("unset = %v", d)
+	}
+	if d := slowThreshold(get("500ms")); d != 500*time.Millisecond {
+		t.Errorf("500ms = %v", d)
+	}
+	if d := slowThreshold(get("0")); d != 0 {
+		t.Errorf("0 = %v, want disabled", d)
+	}
+	if d := slowThreshold(get("soon")); d != metrics.DefaultSlowThreshold {
+		t.Errorf("invalid = %v, want default", d)
+	}
+}
+
+func TestFormatCount(t *testing.T) {
+	for n, want := range map[int64]string{0: "0", 999: "999", 1000: "1,000", 42113: "42,113", 1234567: "1,234,567"} {
+		if got := formatCount(n); got != want {
+			t.Errorf("formatCount(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -274,7 +274,7 @@ Examples:
 					Priority: target.Priority,
 					Owner:    target.Owner,
 				}
-				wrapID, err := store.Create(ctx, wrapEpic)
+				wrapID, err := store.Create(ctx, wrapEpic, issuestorage.CreateOpts{PrioritySet: true})
 				if err != nil {
 					return fmt.Errorf("create wrapper epic: %w", err)
 				}
@@ -312,7 +312,7 @@ Examples:
 				Priority: epic.Priority,
 				Assignee: coordinator,
 			}
-			molID, err := store.Create(ctx, mol)
+			molID, err := store.Create(ctx, mol, issuestorage.CreateOpts{PrioritySet: true})
 			if err != nil {
 				return fmt.Errorf("create molecule: %w", err)
 			}
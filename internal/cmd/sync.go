@@ -1,40 +1,457 @@
 package cmd
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"beads-lite/internal/issuemerge"
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/issuestorage/filesystem"
 
 	"github.com/spf13/cobra"
 )
 
+// syncRemoteKey is the config key naming the git remote bd sync fetches
+// from when --remote is not given.
+const syncRemoteKey = "sync.remote"
+
+// SyncJSON is the JSON output format for bd sync.
+type SyncJSON struct {
+	Status    string             `json:"status"` // "noop", "synced" or "dry_run"
+	Message   string             `json:"message,omitempty"`
+	Remote    string             `json:"remote,omitempty"`
+	Branch    string             `json:"branch,omitempty"`
+	Created   []string           `json:"created,omitempty"`  // new on the remote
+	Updated   []string           `json:"updated,omitempty"`  // changed only on the remote
+	Merged    []string           `json:"merged,omitempty"`   // changed on both sides
+	Repaired  []string           `json:"repaired,omitempty"` // given the missing half of a dependency
+	Conflicts []SyncConflictJSON `json:"conflicts,omitempty"`
+}
+
+// SyncConflictJSON describes fields both sides changed, which the merge
+// resolved by keeping the side updated later.
+type SyncConflictJSON struct {
+	ID     string   `json:"id"`
+	Fields []string `json:"fields"`
+	Kept   string   `json:"kept"` // "local" or "remote"
+}
+
 // newSyncCmd creates the sync command.
-// In beads-lite the storage layer is filesystem-based with no separate sync
-// step, so this command is a no-op accepted for compatibility with the
-// reference implementation.
 func newSyncCmd(provider *AppProvider) *cobra.Command {
+	var (
+		remote string
+		branch string
+		dryRun bool
+	)
+
 	cmd := &cobra.Command{
 		Use:   "sync",
-		Short: "Sync beads data (no-op in beads-lite)",
-		Long: `In the reference implementation, sync pushes and pulls bead changes
-via a git sync branch. beads-lite uses direct filesystem storage and
-does not require a separate sync step. This command is accepted for
-compatibility but performs no action.`,
+		Short: "Merge issue changes from a git remote",
+		Long: `Fetch a branch from a git remote and merge the issue changes made there
+into the local store, issue by issue.
+
+For each issue changed on the remote since the branches diverged, bd sync
+takes the remote copy if the issue is unchanged locally, and otherwise
+merges the two field by field, as bd merge-file does: labels and
+dependencies are combined, comments kept from both sides, and a field
+changed on both sides takes the value of the side updated later. Such
+fields are reported as conflicts so the lost edit can be checked. Local
+changes need not be committed. Finally any dependency left one-sided by
+the merge is given its missing half.
+
+bd sync only writes the local store; commit and push the result as usual.
+Without --remote or sync.remote in config there is nothing to sync from
+and bd sync does nothing, as issues are stored directly on disk.
+
+Examples:
+  bd sync --remote origin
+  bd sync --remote origin --branch main --dry-run
+  bd config set sync.remote origin && bd sync`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			app, err := provider.Get()
 			if err != nil {
 				return err
 			}
+			if remote == "" && app.ConfigStore != nil {
+				remote, _ = app.ConfigStore.Get(syncRemoteKey)
+			}
 
-			if app.JSON {
-				fmt.Fprintln(app.Out, `{"status":"noop","message":"sync is not needed in beads-lite"}`)
+			if remote == "" {
+				if app.JSON {
+					return json.NewEncoder(app.Out).Encode(SyncJSON{Status: "noop", Message: "sync is not needed in beads-lite"})
+				}
+				fmt.Fprintln(app.Out, "sync: no-op (beads-lite uses direct filesystem storage)")
 				return nil
 			}
 
-			fmt.Fprintln(app.Out, "sync: no-op (beads-lite uses direct filesystem storage)")
+			result, err := syncFromRemote(cmd.Context(), app, remote, branch, dryRun)
+			if err != nil {
+				return err
+			}
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(result)
+			}
+			printSync(app, result)
 			return nil
 		},
 	}
 
-	cmd.Flags().Bool("import-only", false, "Only import changes (no-op in beads-lite)")
+	cmd.Flags().StringVar(&remote, "remote", "", "Git remote to sync from (default: sync.remote config)")
+	cmd.Flags().StringVar(&branch, "branch", "", "Remote branch to sync from (default: the current branch's name)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report what would change without writing")
+	cmd.Flags().Bool("import-only", false, "Accepted for compatibility; bd sync only ever imports")
 
 	return cmd
 }
+
+// syncFromRemote fetches branch from remote and merges the issues changed
+// there into the local store.
+func syncFromRemote(ctx context.Context, app *App, remote, branch string, dryRun bool) (*SyncJSON, error) {
+	dataDir := filepath.Join(app.ConfigDir, filesystem.DataDirName)
+	repo, err := newGitRepo(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	if branch == "" {
+		if branch, err = repo.run("rev-parse", "--abbrev-ref", "HEAD"); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := repo.run("fetch", "--quiet", remote, branch); err != nil {
+		return nil, err
+	}
+	theirsRev, err := repo.run("rev-parse", "FETCH_HEAD")
+	if err != nil {
+		return nil, err
+	}
+	// Unrelated histories have no merge base; every issue is then new to one
+	// side or merged without a base.
+	baseRev, _ := repo.run("merge-base", "HEAD", theirsRev)
+
+	rel, err := repo.rel(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	theirsBlobs, err := repo.issueBlobs(theirsRev, rel)
+	if err != nil {
+		return nil, err
+	}
+	baseBlobs := map[string]string{}
+	if baseRev != "" {
+		if baseBlobs, err = repo.issueBlobs(baseRev, rel); err != nil {
+			return nil, err
+		}
+	}
+
+	var changed []string
+	for id, blob := range theirsBlobs {
+		if baseBlobs[id] != blob {
+			changed = append(changed, id)
+		}
+	}
+	sort.Strings(changed)
+	var wanted []string
+	for _, id := range changed {
+		wanted = append(wanted, theirsBlobs[id])
+		if b, ok := baseBlobs[id]; ok {
+			wanted = append(wanted, b)
+		}
+	}
+	blobs, err := repo.readBlobs(wanted)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SyncJSON{Status: "synced", Remote: remote, Branch: branch}
+	if dryRun {
+		result.Status = "dry_run"
+	}
+	var touched []string
+	for _, id := range changed {
+		theirs, _, err := filesystem.DecodeIssueFile(blobs[theirsBlobs[id]])
+		if err != nil {
+			return nil, fmt.Errorf("reading %s from %s/%s: %w", id, remote, branch, err)
+		}
+		var base *issuestorage.Issue
+		if b, ok := baseBlobs[id]; ok {
+			if base, _, err = filesystem.DecodeIssueFile(blobs[b]); err != nil {
+				return nil, fmt.Errorf("reading %s at the merge base: %w", id, err)
+			}
+		}
+
+		ours, err := app.Storage.Get(ctx, id)
+		var write *issuestorage.Issue
+		switch {
+		case errors.Is(err, issuestorage.ErrNotFound):
+			write = theirs
+			result.Created = append(result.Created, id)
+		case err != nil:
+			return nil, fmt.Errorf("reading local %s: %w", id, err)
+		case issueJSONEqual(ours, theirs):
+			continue
+		case base != nil && issueJSONEqual(ours, base):
+			write = theirs
+			result.Updated = append(result.Updated, id)
+		default:
+			write = issuemerge.Merge(base, ours, theirs)
+			if issueJSONEqual(write, ours) {
+				continue // the remote's changes are already here
+			}
+			result.Merged = append(result.Merged, id)
+			if fields := issuemerge.Conflicts(base, ours, theirs); len(fields) > 0 {
+				kept := "local"
+				if theirs.UpdatedAt.After(ours.UpdatedAt) {
+					kept = "remote"
+				}
+				result.Conflicts = append(result.Conflicts, SyncConflictJSON{ID: id, Fields: fields, Kept: kept})
+			}
+		}
+		touched = append(touched, id)
+		if !dryRun {
+			if _, err := app.Storage.Restore(ctx, write); err != nil {
+				return nil, fmt.Errorf("writing %s: %w", id, err)
+			}
+			var prevAssignee string
+			var prevLabels []string
+			if ours != nil {
+				prevAssignee, prevLabels = ours.Assignee, ours.Labels
+			}
+			notifyAssigned(app, id, write.Title, prevAssignee, write.Assignee)
+			notifyNeedsInfo(app, write, prevLabels)
+			for _, c := range newComments(ours, write) {
+				notifyMentions(ctx, app, id, &c)
+			}
+		}
+	}
+
+	if !dryRun {
+		if result.Repaired, err = repairDependencySymmetry(ctx, app, touched); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// newComments returns the comments of issue that prev, its local version
+// or nil if it is new here, does not have.
+func newComments(prev, issue *issuestorage.Issue) []issuestorage.Comment {
+	type key struct {
+		author, text string
+		at           time.Time
+	}
+	seen := make(map[key]bool)
+	if prev != nil {
+		for _, c := range prev.Comments {
+			seen[key{c.Author, c.Text, c.CreatedAt.UTC()}] = true
+		}
+	}
+	var added []issuestorage.Comment
+	for _, c := range issue.Comments {
+		if !seen[key{c.Author, c.Text, c.CreatedAt.UTC()}] {
+			added = append(added, c)
+		}
+	}
+	return added
+}
+
+// repairDependencySymmetry gives the counterpart of every dependency and
+// dependent of the issues ids the missing half of the relationship, as
+// bd doctor --fix would. It returns the IDs of the issues it changed.
+func repairDependencySymmetry(ctx context.Context, app *App, ids []string) ([]string, error) {
+	fixes := make(map[string]*issuestorage.Issue)
+	load := func(id string) *issuestorage.Issue {
+		if issue, ok := fixes[id]; ok {
+			return issue
+		}
+		issue, err := app.Storage.Get(ctx, id)
+		if err != nil {
+			return nil
+		}
+		return issue
+	}
+	for _, id := range ids {
+		issue := load(id)
+		if issue == nil {
+			continue
+		}
+		for _, dep := range issue.Dependencies {
+			if target := load(dep.ID); target != nil && !target.HasDependent(id) {
+				target.Dependents = append(target.Dependents, issuestorage.Dependency{ID: id, Type: dep.Type})
+				fixes[dep.ID] = target
+			}
+		}
+		for _, dep := range issue.Dependents {
+			if dependent := load(dep.ID); dependent != nil && !dependent.HasDependency(id) {
+				dependent.Dependencies = append(dependent.Dependencies, issuestorage.Dependency{ID: id, Type: dep.Type})
+				fixes[dep.ID] = dependent
+			}
+		}
+	}
+
+	repaired := sortedKeys(fixes)
+	for _, id := range repaired {
+		if _, err := app.Storage.Restore(ctx, fixes[id]); err != nil {
+			return nil, fmt.Errorf("repairing dependencies of %s: %w", id, err)
+		}
+	}
+	return repaired, nil
+}
+
+// issueJSONEqual reports whether two issues have the same file contents.
+func issueJSONEqual(a, b *issuestorage.Issue) bool {
+	da, _ := json.Marshal(a)
+	db, _ := json.Marshal(b)
+	return bytes.Equal(da, db)
+}
+
+func printSync(app *App, r *SyncJSON) {
+	prefix := ""
+	if r.Status == "dry_run" {
+		prefix = "(dry run) "
+	}
+	n := len(r.Created) + len(r.Updated) + len(r.Merged)
+	if n == 0 {
+		fmt.Fprintf(app.Out, "%sAlready up to date with %s/%s.\n", prefix, r.Remote, r.Branch)
+		return
+	}
+	fmt.Fprintf(app.Out, "%s%s Synced %d %s from %s/%s\n", prefix, app.SuccessColor("✓"), n, plural(n, "issue", "issues"), r.Remote, r.Branch)
+	for _, group := range []struct {
+		label string
+		ids   []string
+	}{
+		{"Created", r.Created},
+		{"Updated", r.Updated},
+		{"Merged", r.Merged},
+		{"Repaired dependencies", r.Repaired},
+	} {
+		if len(group.ids) > 0 {
+			fmt.Fprintf(app.Out, "  %s: %s\n", group.label, strings.Join(group.ids, ", "))
+		}
+	}
+	if len(r.Conflicts) > 0 {
+		fmt.Fprintf(app.Out, "\n%s changed on both sides (check the edits that were not kept):\n", plural(len(r.Conflicts), "Field", "Fields"))
+		for _, c := range r.Conflicts {
+			fmt.Fprintf(app.Out, "  %s %s: kept %s\n", c.ID, strings.Join(c.Fields, ", "), c.Kept)
+		}
+	}
+	if r.Status != "dry_run" {
+		fmt.Fprintln(app.Out, "\nCommit the changes to record the sync.")
+	}
+}
+
+// gitRepo runs git commands in the work tree containing a directory.
+type gitRepo struct {
+	top string
+}
+
+func newGitRepo(dir string) (*gitRepo, error) {
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s is not in a git work tree: %w", dir, err)
+	}
+	return &gitRepo{top: strings.TrimSpace(string(out))}, nil
+}
+
+func (g *gitRepo) run(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = g.top
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %v: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// rel returns dir relative to the work tree, with forward slashes.
+func (g *gitRepo) rel(dir string) (string, error) {
+	top, err := filepath.EvalSymlinks(g.top)
+	if err != nil {
+		return "", err
+	}
+	abs, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(top, abs)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// issueBlobs lists the issue files under dir at rev, returning each issue
+// ID's blob. Ephemeral issues are not synced. If an ID has files in two
+// status directories, as while a move is half committed, the last listed
+// wins.
+func (g *gitRepo) issueBlobs(rev, dir string) (map[string]string, error) {
+	out, err := g.run("ls-tree", "-r", rev, "--", dir)
+	if err != nil {
+		return nil, err
+	}
+	blobs := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		meta, file, ok := strings.Cut(line, "\t")
+		fields := strings.Fields(meta)
+		if !ok || len(fields) != 3 || fields[1] != "blob" || !strings.HasSuffix(file, ".json") {
+			continue
+		}
+		if strings.Contains("/"+file+"/", "/"+filesystem.DirEphemeral+"/") {
+			continue
+		}
+		blobs[strings.TrimSuffix(path.Base(file), ".json")] = fields[2]
+	}
+	return blobs, nil
+}
+
+// readBlobs returns the contents of the given blobs by ID.
+func (g *gitRepo) readBlobs(ids []string) (map[string][]byte, error) {
+	contents := make(map[string][]byte)
+	if len(ids) == 0 {
+		return contents, nil
+	}
+	cmd := exec.Command("git", "cat-file", "--batch")
+	cmd.Dir = g.top
+	cmd.Stdin = strings.NewReader(strings.Join(ids, "\n") + "\n")
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git cat-file: %w", err)
+	}
+	r := bufio.NewReader(bytes.NewReader(out))
+	for range ids {
+		header, err := r.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("git cat-file: %w", err)
+		}
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("git cat-file: unexpected output %q", strings.TrimSpace(header))
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("git cat-file: unexpected output %q", strings.TrimSpace(header))
+		}
+		data := make([]byte, size+1) // contents and a trailing newline
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, fmt.Errorf("git cat-file: %w", err)
+		}
+		contents[fields[0]] = data[:size]
+	}
+	return contents, nil
+}
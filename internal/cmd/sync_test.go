@@ -2,8 +2,21 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 	"testing"
+	"time"
+
+	"beads-lite/internal/clock"
+	"beads-lite/internal/issueservice"
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/issuestorage/filesystem"
 )
 
 func TestSyncCmd(t *testing.T) {
@@ -56,3 +69,149 @@ func TestSyncCmd_JSON(t *testing.T) {
 		t.Errorf("expected JSON noop status, got: %s", got)
 	}
 }
+
+// gitCmd runs git in dir, failing the test on error.
+func gitCmd(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+}
+
+// openSyncStore opens the issue store of the .beads directory in repo.
+func openSyncStore(t *testing.T, repo string, now time.Time) *issueservice.IssueStore {
+	t.Helper()
+	fs := filesystem.New(filepath.Join(repo, ".beads"), "bd-")
+	if err := fs.Init(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	store := issueservice.New(nil, fs)
+	store.SetClock(clock.NewFake(now))
+	return store
+}
+
+func TestSyncFromRemote(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	ctx := context.Background()
+	then := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	origin := filepath.Join(t.TempDir(), "origin")
+	os.MkdirAll(origin, 0755)
+	gitCmd(t, origin, "init", "-q", "-b", "main")
+	remote := openSyncStore(t, origin, then)
+	shared, _ := remote.Create(ctx, &issuestorage.Issue{Title: "Shared", Labels: []string{"web"}})
+	blocker, _ := remote.Create(ctx, &issuestorage.Issue{Title: "Blocker", Reporter: "carol"})
+	gitCmd(t, origin, "add", "-A")
+	gitCmd(t, origin, "commit", "-q", "-m", "base")
+
+	local := filepath.Join(t.TempDir(), "local")
+	gitCmd(t, filepath.Dir(local), "clone", "-q", origin, local)
+	store := openSyncStore(t, local, then.Add(time.Hour))
+	app := &App{Storage: store, ConfigDir: filepath.Join(local, ".beads"), Out: &bytes.Buffer{}, Err: &bytes.Buffer{}}
+	t.Setenv("BD_ACTOR", "carol")
+	sent := captureNotifications(app)
+
+	// Local, uncommitted: retitle and reprioritise the shared issue.
+	store.Modify(ctx, shared, func(i *issuestorage.Issue) error {
+		i.Title, i.Priority = "Shared (local)", issuestorage.PriorityHigh
+		return nil
+	})
+	// Remote, later: retitle and label it, and add an issue blocked by another.
+	remote.SetClock(clock.NewFake(then.Add(2 * time.Hour)))
+	remote.Modify(ctx, shared, func(i *issuestorage.Issue) error {
+		i.Title = "Shared (remote)"
+		i.Labels = append(i.Labels, "api")
+		i.Comments = append(i.Comments,
+			issuestorage.Comment{ID: 1, Author: "dave", Text: "@erin can you look?", CreatedAt: then.Add(2 * time.Hour)},
+			issuestorage.Comment{ID: 2, Author: "dave", Text: "@carol this is yours", CreatedAt: then.Add(2 * time.Hour)})
+		return nil
+	})
+	remote.Modify(ctx, blocker, func(i *issuestorage.Issue) error {
+		i.Labels = append(i.Labels, needsInfoLabel)
+		return nil
+	})
+	added, _ := remote.Create(ctx, &issuestorage.Issue{Title: "Added remotely", Assignee: "carol"})
+	if err := remote.AddDependency(ctx, added, blocker, issuestorage.DepTypeBlocks); err != nil {
+		t.Fatal(err)
+	}
+	gitCmd(t, origin, "add", "-A")
+	gitCmd(t, origin, "commit", "-q", "-m", "remote work")
+
+	app.JSON = true
+	cmd := newSyncCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--remote", "origin"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	var result SyncJSON
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result.Created, []string{added}) || !reflect.DeepEqual(result.Updated, []string{blocker}) || !reflect.DeepEqual(result.Merged, []string{shared}) {
+		t.Errorf("sync = %+v", result)
+	}
+	if want := []SyncConflictJSON{{ID: shared, Fields: []string{"title"}, Kept: "remote"}}; !reflect.DeepEqual(result.Conflicts, want) {
+		t.Errorf("conflicts = %+v, want %+v", result.Conflicts, want)
+	}
+
+	got, _ := store.Get(ctx, shared)
+	if got.Title != "Shared (remote)" || got.Priority != issuestorage.PriorityHigh || !reflect.DeepEqual(got.Labels, []string{"web", "api"}) {
+		t.Errorf("merged issue: %q %s %q", got.Title, got.Priority.Display(), got.Labels)
+	}
+	if got, _ := store.Get(ctx, blocker); !got.HasDependent(added) {
+		t.Errorf("blocker dependents = %+v, want %s", got.Dependents, added)
+	}
+	sort.Strings(*sent) // issues sync in ID order
+	if want := []string{
+		"Assigned: " + added + ": Added remotely\nassigned to carol",
+		"Mentioned in " + shared + ": Shared (remote)\n@carol mentioned by dave",
+		"Needs info: " + blocker + ": Blocker\nwaiting on you as reporter",
+	}; !reflect.DeepEqual(*sent, want) {
+		t.Errorf("notifications = %q, want %q", *sent, want)
+	}
+
+	// Syncing again finds nothing new.
+	app.Out = &bytes.Buffer{}
+	cmd = newSyncCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--remote", "origin"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("second sync: %v", err)
+	}
+	if result := app.Out.(*bytes.Buffer).String(); strings.Contains(result, "merged") || strings.Contains(result, "created") {
+		t.Errorf("second sync changed issues: %s", result)
+	}
+}
+
+func TestRepairDependencySymmetry(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	a, _ := store.Create(ctx, &issuestorage.Issue{Title: "A"})
+	b, _ := store.Create(ctx, &issuestorage.Issue{Title: "B"})
+	c, _ := store.Create(ctx, &issuestorage.Issue{Title: "C"})
+	issue, _ := store.Get(ctx, a)
+	issue.Dependencies = []issuestorage.Dependency{{ID: b, Type: issuestorage.DepTypeBlocks}}
+	issue.Dependents = []issuestorage.Dependency{{ID: c, Type: issuestorage.DepTypeRelated}}
+	if _, err := store.Restore(ctx, issue); err != nil {
+		t.Fatal(err)
+	}
+
+	repaired, err := repairDependencySymmetry(ctx, app, []string{a})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{b, c}
+	sort.Strings(want)
+	if !reflect.DeepEqual(repaired, want) {
+		t.Errorf("repaired = %v, want %v", repaired, want)
+	}
+	if got, _ := store.Get(ctx, b); !got.HasDependent(a) {
+		t.Errorf("%s dependents = %+v", b, got.Dependents)
+	}
+	if got, _ := store.Get(ctx, c); !got.HasDependency(a) {
+		t.Errorf("%s dependencies = %+v", c, got.Dependencies)
+	}
+}
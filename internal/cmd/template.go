@@ -0,0 +1,345 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/kvstorage"
+	"beads-lite/internal/template"
+
+	"github.com/spf13/cobra"
+)
+
+// TemplateJSON is the JSON output of bd template add and bd template list.
+type TemplateJSON struct {
+	Name        string   `json:"name"`
+	Title       string   `json:"title,omitempty"`
+	IssueType   string   `json:"issue_type,omitempty"`
+	Priority    *int     `json:"priority,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Children    []string `json:"children,omitempty"`
+	CreatedAt   string   `json:"created_at,omitempty"`
+}
+
+// TemplateApplyJSON is the JSON output of bd template apply.
+type TemplateApplyJSON struct {
+	Template string   `json:"template"`
+	IssueID  string   `json:"issue_id"`
+	Children []string `json:"children"`
+}
+
+func toTemplateJSON(t template.Template) TemplateJSON {
+	out := TemplateJSON{
+		Name:        t.Name,
+		Title:       t.Title,
+		IssueType:   string(t.Type),
+		Labels:      t.Labels,
+		Description: t.Description,
+		Children:    t.Children,
+	}
+	if t.Priority != nil {
+		p := int(*t.Priority)
+		out.Priority = &p
+	}
+	if !t.CreatedAt.IsZero() {
+		out.CreatedAt = formatTime(t.CreatedAt)
+	}
+	return out
+}
+
+// getTemplate returns the template with the given name, with a hint on
+// adding it if there is none.
+func getTemplate(ctx context.Context, app *App, name string) (template.Template, error) {
+	if app.TemplateStore == nil {
+		return template.Template{}, fmt.Errorf("templates are not available in this repository")
+	}
+	t, err := template.Get(ctx, app.TemplateStore, name)
+	if errors.Is(err, kvstorage.ErrKeyNotFound) {
+		return template.Template{}, fmt.Errorf("template %s not found: add it with 'bd template add %s'", name, name)
+	}
+	return t, err
+}
+
+// createTemplateChildren creates a child of parentID for each item of t's
+// checklist, with the parent's priority, and returns their IDs. Children
+// created before a failure are deleted.
+func createTemplateChildren(ctx context.Context, app *App, t template.Template, parentID string, priority issuestorage.Priority, actor string) ([]string, error) {
+	ids := []string{}
+	for _, title := range t.Children {
+		childID, err := app.Storage.GetNextChildID(ctx, parentID)
+		if err == nil {
+			_, err = app.Storage.Create(ctx, &issuestorage.Issue{
+				ID:        childID,
+				Title:     title,
+				Type:      issuestorage.TypeTask,
+				Priority:  priority,
+				CreatedBy: actor,
+				Owner:     resolveOwner(),
+				Reporter:  actor,
+			}, issuestorage.CreateOpts{PrioritySet: true})
+		}
+		if err == nil {
+			ids = append(ids, childID)
+			err = app.Storage.AddDependency(ctx, childID, parentID, issuestorage.DepTypeParentChild)
+		}
+		if err != nil {
+			for _, id := range ids {
+				app.Storage.Delete(context.Background(), id)
+			}
+			return nil, fmt.Errorf("creating child %q of %s: %w", title, parentID, err)
+		}
+	}
+	return ids, nil
+}
+
+// newTemplateCmd creates the template command group.
+func newTemplateCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Manage issue templates",
+		Long: `Templates fill in a new issue's type, priority, labels and description,
+and give it a child issue for each item of a checklist. They are stored
+under .beads/templates/.
+
+Create an issue from a template with bd create --template, or apply one
+to an existing issue with bd template apply.`,
+	}
+
+	cmd.AddCommand(newTemplateAddCmd(provider))
+	cmd.AddCommand(newTemplateListCmd(provider))
+	cmd.AddCommand(newTemplateApplyCmd(provider))
+
+	return cmd
+}
+
+// newTemplateAddCmd creates the "template add" subcommand.
+func newTemplateAddCmd(provider *AppProvider) *cobra.Command {
+	var (
+		title       string
+		typeFlag    string
+		priority    string
+		labels      []string
+		description string
+		children    []string
+		force       bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Add an issue template",
+		Long: `Add an issue template. --title is a pattern for the issue's title, in
+which {{title}} is replaced by the title given to bd create. Each --child
+is the title of a child issue created with it. --force replaces an
+existing template of the same name.
+
+Examples:
+  bd template add bug-report --title "Bug: {{title}}" --type bug -p 1 -l triage \
+    --description "Steps to reproduce:" --child "Reproduce" --child "Add a regression test"
+  bd template add release --title "Release {{title}}" --type epic --description -`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			t := template.Template{Name: args[0], Title: strings.TrimSpace(title), Labels: labels, CreatedAt: app.Now()}
+			if typeFlag != "" {
+				issueType, err := parseType(typeFlag, getCustomValues(app, "types.custom"))
+				if err != nil {
+					return err
+				}
+				t.Type = issueType
+			}
+			if priority != "" {
+				p, err := parsePriorityInput(priority)
+				if err != nil {
+					return err
+				}
+				t.Priority = &p
+			}
+			t.Description = description
+			if description == "-" {
+				data, err := io.ReadAll(bufio.NewReader(os.Stdin))
+				if err != nil {
+					return fmt.Errorf("reading description from stdin: %w", err)
+				}
+				t.Description = strings.TrimSpace(string(data))
+			}
+			for _, child := range children {
+				if child = strings.TrimSpace(child); child != "" {
+					t.Children = append(t.Children, child)
+				}
+			}
+
+			// Repositories initialized before templates existed have no
+			// table directory yet.
+			if s, ok := app.TemplateStore.(interface{ Init(context.Context) error }); ok {
+				if err := s.Init(ctx); err != nil {
+					return fmt.Errorf("initializing template store: %w", err)
+				}
+			}
+			if !force {
+				if _, err := template.Get(ctx, app.TemplateStore, t.Name); err == nil {
+					return fmt.Errorf("template %s already exists (use --force to replace it)", t.Name)
+				}
+			}
+			if err := template.Set(ctx, app.TemplateStore, t, force); err != nil {
+				return err
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(toTemplateJSON(t))
+			}
+			fmt.Fprintf(app.Out, "%s Added template %s\n", app.SuccessColor("✓"), t.Name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&title, "title", "", "Title pattern; {{title}} is replaced by the title given to bd create")
+	cmd.Flags().StringVarP(&typeFlag, "type", "t", "", "Issue type")
+	cmd.Flags().StringVarP(&priority, "priority", "p", "", "Priority (0-4 or P0-P4)")
+	cmd.Flags().StringSliceVarP(&labels, "labels", "l", nil, "Labels (comma-separated or repeat flag)")
+	cmd.Flags().StringVar(&description, "description", "", "Description scaffold (use - for stdin)")
+	cmd.Flags().StringArrayVar(&children, "child", nil, "Title of a child issue to create (can repeat)")
+	cmd.Flags().BoolVar(&force, "force", false, "Replace an existing template")
+
+	return cmd
+}
+
+// newTemplateListCmd creates the "template list" subcommand.
+func newTemplateListCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List issue templates",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+
+			templates, err := template.List(cmd.Context(), app.TemplateStore)
+			if err != nil {
+				return err
+			}
+
+			if app.JSON {
+				result := make([]TemplateJSON, 0, len(templates))
+				for _, t := range templates {
+					result = append(result, toTemplateJSON(t))
+				}
+				return json.NewEncoder(app.Out).Encode(result)
+			}
+			if len(templates) == 0 {
+				fmt.Fprintln(app.Out, "No templates found.")
+				return nil
+			}
+			width := 0
+			for _, t := range templates {
+				width = max(width, len(t.Name))
+			}
+			for _, t := range templates {
+				var parts []string
+				if t.Title != "" {
+					parts = append(parts, fmt.Sprintf("%q", t.Title))
+				}
+				if t.Type != "" {
+					parts = append(parts, string(t.Type))
+				}
+				if t.Priority != nil {
+					parts = append(parts, t.Priority.Display())
+				}
+				if len(t.Labels) > 0 {
+					parts = append(parts, "labels "+strings.Join(t.Labels, ","))
+				}
+				if len(t.Children) > 0 {
+					parts = append(parts, fmt.Sprintf("%d children", len(t.Children)))
+				}
+				fmt.Fprintf(app.Out, "%-*s  %s\n", width, t.Name, strings.Join(parts, ", "))
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// newTemplateApplyCmd creates the "template apply" subcommand.
+func newTemplateApplyCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply <name> <issue-id>",
+		Short: "Apply a template to an existing issue",
+		Long: `Apply a template to an existing issue: set its type and priority to the
+template's, add the template's labels, fill in the description scaffold
+if the issue has no description, and create the checklist's child
+issues. The title is left as it is.
+
+Examples:
+  bd template apply bug-report bd-a1b2`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			t, err := getTemplate(ctx, app, args[0])
+			if err != nil {
+				return err
+			}
+			issue, err := resolveIssue(app.Storage, ctx, args[1])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[1], err)
+			}
+
+			var priority issuestorage.Priority
+			if err := app.Storage.Modify(ctx, issue.ID, func(i *issuestorage.Issue) error {
+				if t.Type != "" {
+					i.Type = t.Type
+				}
+				if t.Priority != nil {
+					i.Priority = *t.Priority
+				}
+				for _, label := range t.Labels {
+					if !contains(i.Labels, label) {
+						i.Labels = append(i.Labels, label)
+					}
+				}
+				if strings.TrimSpace(i.Description) == "" {
+					i.Description = t.Description
+				}
+				priority = i.Priority
+				return nil
+			}); err != nil {
+				return fmt.Errorf("applying template %s to %s: %w", t.Name, issue.ID, err)
+			}
+			actor, _ := resolveActor(app)
+			children, err := createTemplateChildren(ctx, app, t, issue.ID, priority, actor)
+			if err != nil {
+				return err
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(TemplateApplyJSON{Template: t.Name, IssueID: issue.ID, Children: children})
+			}
+			fmt.Fprintf(app.Out, "%s Applied template %s to %s\n", app.SuccessColor("✓"), t.Name, issue.ID)
+			if len(children) > 0 {
+				fmt.Fprintf(app.Out, "  Children: %s\n", strings.Join(children, ", "))
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+	kvfs "beads-lite/internal/kvstorage/filesystem"
+
+	"github.com/spf13/cobra"
+)
+
+func TestTemplateCommands(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	// Left uninitialized: bd template add makes the table directory.
+	templateStore, err := kvfs.New(t.TempDir(), "templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.TemplateStore = templateStore
+
+	exec := func(newCmd func(*AppProvider) *cobra.Command, args ...string) ([]byte, error) {
+		t.Helper()
+		out := &bytes.Buffer{}
+		app.Out = out
+		cmd := newCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.Bytes(), err
+	}
+	run := func(newCmd func(*AppProvider) *cobra.Command, args ...string) []byte {
+		t.Helper()
+		out, err := exec(newCmd, args...)
+		if err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+		return out
+	}
+
+	if out := string(run(newTemplateCmd, "list")); out != "No templates found.\n" {
+		t.Errorf("empty template list = %q", out)
+	}
+	run(newTemplateCmd, "add", "bug-report", "--title", "Bug: {{title}}", "--type", "bug", "-p", "1",
+		"-l", "triage", "--description", "Steps to reproduce:", "--child", "Reproduce", "--child", "Add a regression test")
+	if _, err := exec(newTemplateCmd, "add", "bug-report"); err == nil {
+		t.Error("adding bug-report twice should need --force")
+	}
+	run(newTemplateCmd, "add", "chore", "--type", "chore")
+	if _, err := exec(newCreateCmd, "Anything", "--template", "missing"); err == nil {
+		t.Error("--template should require an existing template")
+	}
+	if _, err := exec(newCreateCmd, "--template", "bug-report"); err == nil {
+		t.Error("a {{title}} pattern should require a title")
+	}
+
+	out := string(run(newCreateCmd, "Login fails", "--template", "bug-report", "-l", "auth"))
+	id := extractCreatedID(out)
+	issue, err := store.Get(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issue.Title != "Bug: Login fails" || issue.Type != issuestorage.TypeBug || issue.Priority != issuestorage.PriorityHigh ||
+		issue.Description != "Steps to reproduce:" || !reflect.DeepEqual(issue.Labels, []string{"triage", "auth"}) {
+		t.Errorf("issue from template = %+v", issue)
+	}
+	children := []string{id + ".1", id + ".2"}
+	if !strings.Contains(out, "Children: "+strings.Join(children, ", ")) {
+		t.Errorf("create output = %q", out)
+	}
+	for i, title := range []string{"Reproduce", "Add a regression test"} {
+		child, err := store.Get(ctx, children[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if child.Title != title || child.Parent != id || child.Priority != issuestorage.PriorityHigh {
+			t.Errorf("child %d = %+v", i, child)
+		}
+	}
+
+	// Flags win over the template.
+	overridden := extractCreatedID(string(run(newCreateCmd, "Typo", "--template", "bug-report", "-p", "3", "--description", "Own")))
+	if issue, _ := store.Get(ctx, overridden); issue.Priority != issuestorage.PriorityLow || issue.Description != "Own" {
+		t.Errorf("flags did not override the template: %+v", issue)
+	}
+
+	existing := extractCreatedID(string(run(newCreateCmd, "Crash on start", "--description", "Seen on 1.4")))
+	app.JSON = true
+	var applied TemplateApplyJSON
+	if err := json.Unmarshal(run(newTemplateCmd, "apply", "bug-report", existing), &applied); err != nil {
+		t.Fatal(err)
+	}
+	if want := (TemplateApplyJSON{Template: "bug-report", IssueID: existing, Children: []string{existing + ".1", existing + ".2"}}); !reflect.DeepEqual(applied, want) {
+		t.Errorf("template apply = %+v, want %+v", applied, want)
+	}
+	if issue, _ := store.Get(ctx, existing); issue.Title != "Crash on start" || issue.Type != issuestorage.TypeBug || issue.Description != "Seen on 1.4" || !reflect.DeepEqual(issue.Labels, []string{"triage"}) {
+		t.Errorf("issue after template apply = %+v", issue)
+	}
+
+	var templates []TemplateJSON
+	if err := json.Unmarshal(run(newTemplateCmd, "list"), &templates); err != nil {
+		t.Fatal(err)
+	}
+	if len(templates) != 2 || templates[0].Name != "bug-report" || *templates[0].Priority != 1 || len(templates[0].Children) != 2 || templates[1].IssueType != "chore" {
+		t.Errorf("template list = %+v", templates)
+	}
+}
@@ -0,0 +1,343 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+// uiPane identifies one of the lists shown by bd ui.
+type uiPane int
+
+const (
+	paneReady uiPane = iota
+	paneInProgress
+	paneBlocked
+	paneCount
+)
+
+var uiPaneTitles = [paneCount]string{"Ready", "In progress", "Blocked"}
+
+const uiHelp = "tab/←→ pane · j/k move · enter details · c close · o reopen · a assign · r refresh · q quit"
+
+// uiModel is the bubbletea model behind bd ui. Mutations run the same
+// commands as the CLI, so history, notifications and hooks behave alike.
+type uiModel struct {
+	ctx context.Context
+	app *App
+
+	panes    [paneCount][]*issuestorage.Issue
+	blockers map[string][]string // blocked issue ID -> blocker IDs
+	pane     uiPane
+	cursor   [paneCount]int
+
+	detail       *issuestorage.Issue // shown instead of the panes when set
+	detailLines  []string
+	detailScroll int
+
+	assigning bool   // reading an assignee into input
+	input     string // assignee typed so far
+	status    string // result of the last action
+	height    int
+}
+
+// newUIModel returns a model with the panes loaded.
+func newUIModel(ctx context.Context, app *App) *uiModel {
+	m := &uiModel{ctx: ctx, app: app}
+	m.reload()
+	return m
+}
+
+// newUICmd creates the ui command.
+func newUICmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ui",
+		Short: "Browse and triage issues in a terminal UI",
+		Long: `Open an interactive terminal UI with panes for ready, in-progress and
+blocked issues.
+
+The Ready pane lists the same issues as bd ready; Blocked lists those of
+bd blocked, with what they wait on. Enter opens an issue's details and
+comments. Closing, reopening and assigning run bd close, bd reopen and
+bd update --assignee, and the panes reload afterwards.
+
+Keys:
+  tab, shift+tab, ←, →   switch pane
+  j, k, ↓, ↑             move the selection (scroll in details)
+  enter                  show details and comments
+  esc                    back to the panes
+  c                      close the selected issue
+  o                      reopen the selected issue
+  a                      assign the selected issue (empty input unassigns)
+  r                      reload
+  q, ctrl+c              quit`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			if app.JSON {
+				return fmt.Errorf("bd ui is interactive and has no JSON output")
+			}
+			m := newUIModel(cmd.Context(), app)
+			_, err = tea.NewProgram(m, tea.WithAltScreen(), tea.WithContext(cmd.Context())).Run()
+			return err
+		},
+	}
+	return cmd
+}
+
+func (m *uiModel) Init() tea.Cmd { return nil }
+
+func (m *uiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+	case tea.KeyMsg:
+		if m.assigning {
+			m.updateInput(msg)
+			return m, nil
+		}
+		return m, m.updateKey(msg.String())
+	}
+	return m, nil
+}
+
+// updateInput handles a key while an assignee is being typed.
+func (m *uiModel) updateInput(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.assigning = false
+		if issue := m.selected(); issue != nil {
+			m.run(fmt.Sprintf("Assigned %s to %s", issue.ID, m.input), newUpdateCmd, issue.ID, "--assignee", m.input)
+		}
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.assigning = false
+	case tea.KeyBackspace:
+		if r := []rune(m.input); len(r) > 0 {
+			m.input = string(r[:len(r)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.input += string(msg.Runes)
+	}
+}
+
+// updateKey handles a key outside of input, returning tea.Quit to exit.
+func (m *uiModel) updateKey(key string) tea.Cmd {
+	switch key {
+	case "q", "ctrl+c":
+		return tea.Quit
+	case "r":
+		m.status = "Reloaded"
+		m.reload()
+	case "c":
+		if issue := m.selected(); issue != nil {
+			m.run("Closed "+issue.ID, newCloseCmd, issue.ID)
+		}
+	case "o":
+		if issue := m.selected(); issue != nil {
+			m.run("Reopened "+issue.ID, newReopenCmd, issue.ID)
+		}
+	case "a":
+		if issue := m.selected(); issue != nil {
+			m.assigning, m.input = true, issue.Assignee
+		}
+	}
+
+	if m.detail != nil {
+		switch key {
+		case "esc", "backspace", "h", "left":
+			m.detail = nil
+		case "j", "down":
+			if m.detailScroll < len(m.detailLines)-1 {
+				m.detailScroll++
+			}
+		case "k", "up":
+			if m.detailScroll > 0 {
+				m.detailScroll--
+			}
+		}
+		return nil
+	}
+
+	switch key {
+	case "tab", "l", "right":
+		m.pane = (m.pane + 1) % paneCount
+	case "shift+tab", "h", "left":
+		m.pane = (m.pane + paneCount - 1) % paneCount
+	case "j", "down":
+		if m.cursor[m.pane] < len(m.panes[m.pane])-1 {
+			m.cursor[m.pane]++
+		}
+	case "k", "up":
+		if m.cursor[m.pane] > 0 {
+			m.cursor[m.pane]--
+		}
+	case "enter":
+		if issue := m.selected(); issue != nil {
+			m.showDetail(issue.ID)
+		}
+	}
+	return nil
+}
+
+// selected returns the issue shown in details, else the one under the
+// cursor, or nil if the pane is empty.
+func (m *uiModel) selected() *issuestorage.Issue {
+	if m.detail != nil {
+		return m.detail
+	}
+	issues := m.panes[m.pane]
+	if len(issues) == 0 {
+		return nil
+	}
+	return issues[m.cursor[m.pane]]
+}
+
+// run executes a bd command in-process, reports done or the error in the
+// status line, and reloads.
+func (m *uiModel) run(done string, newCmd func(*AppProvider) *cobra.Command, args ...string) {
+	if _, err := runJSONCommand(m.ctx, m.app, newCmd, args); err != nil {
+		m.status = "Error: " + err.Error()
+	} else {
+		m.status = done
+	}
+	m.reload()
+}
+
+// reload re-reads the panes, and the issue in details if one is open.
+func (m *uiModel) reload() {
+	ready, err := m.app.Storage.ListReady(m.ctx, &issuestorage.ListFilter{Parent: new(string)}, cascadeEnabled(m.app))
+	if err == nil {
+		err = sortIssues(ready, "priority", false)
+	}
+	if err != nil {
+		m.status = "Error: " + err.Error()
+		return
+	}
+	inProgress, err := m.app.Storage.List(m.ctx, &issuestorage.ListFilter{Statuses: []issuestorage.Status{issuestorage.StatusInProgress}})
+	if err == nil {
+		err = sortIssues(inProgress, "priority", false)
+	}
+	if err != nil {
+		m.status = "Error: " + err.Error()
+		return
+	}
+	blockedIssues, err := m.app.Storage.ListBlocked(m.ctx, nil, cascadeEnabled(m.app))
+	if err != nil {
+		m.status = "Error: " + err.Error()
+		return
+	}
+	blocked := make([]*issuestorage.Issue, len(blockedIssues))
+	m.blockers = make(map[string][]string, len(blockedIssues))
+	for i, b := range blockedIssues {
+		blocked[i] = b.Issue
+		m.blockers[b.ID] = b.Blockers.AllBlockerIDs()
+	}
+	if err := sortIssues(blocked, "priority", false); err != nil {
+		m.status = "Error: " + err.Error()
+		return
+	}
+
+	m.panes = [paneCount][]*issuestorage.Issue{ready, inProgress, blocked}
+	for p := range m.cursor {
+		m.cursor[p] = max(0, min(m.cursor[p], len(m.panes[p])-1))
+	}
+	if m.detail != nil {
+		m.showDetail(m.detail.ID)
+	}
+}
+
+// showDetail opens details for id, rendered as bd show renders them.
+func (m *uiModel) showDetail(id string) {
+	issue, err := m.app.Storage.Get(m.ctx, id)
+	if err != nil {
+		m.status = "Error: " + err.Error()
+		return
+	}
+	var buf bytes.Buffer
+	showApp := *m.app
+	showApp.Out, showApp.JSON = &buf, false
+	if err := outputIssue(&showApp, m.ctx, issue); err != nil {
+		m.status = "Error: " + err.Error()
+		return
+	}
+	if m.detail == nil || m.detail.ID != id {
+		m.detailScroll = 0
+	}
+	m.detail = issue
+	m.detailLines = strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	m.detailScroll = min(m.detailScroll, max(0, len(m.detailLines)-1))
+}
+
+func (m *uiModel) View() string {
+	var b strings.Builder
+	if m.detail != nil {
+		lines := m.detailLines[m.detailScroll:]
+		if rows := m.height - 2; rows > 0 && len(lines) > rows {
+			lines = lines[:rows]
+		}
+		for _, line := range lines {
+			b.WriteString(line + "\n")
+		}
+	} else {
+		m.viewPanes(&b)
+	}
+
+	switch {
+	case m.assigning:
+		fmt.Fprintf(&b, "\nAssign %s to: %s█\n", m.selected().ID, m.input)
+	case m.status != "":
+		fmt.Fprintf(&b, "\n%s\n", m.status)
+	default:
+		b.WriteString("\n")
+	}
+	b.WriteString(m.app.Colorize(uiHelp, "2"))
+	return b.String()
+}
+
+// viewPanes writes the pane tabs and the selected pane's issues,
+// scrolled to keep the cursor in view.
+func (m *uiModel) viewPanes(b *strings.Builder) {
+	var tabs []string
+	for p := uiPane(0); p < paneCount; p++ {
+		tab := fmt.Sprintf("%s (%d)", uiPaneTitles[p], len(m.panes[p]))
+		if p == m.pane {
+			tab = "[" + m.app.Colorize(tab, "1") + "]"
+		} else {
+			tab = " " + tab + " "
+		}
+		tabs = append(tabs, tab)
+	}
+	b.WriteString(strings.Join(tabs, "  ") + "\n\n")
+
+	issues := m.panes[m.pane]
+	if len(issues) == 0 {
+		fmt.Fprintf(b, "  No %s issues.\n", strings.ToLower(uiPaneTitles[m.pane]))
+		return
+	}
+	start, end := 0, len(issues)
+	if rows := m.height - 5; rows > 0 && len(issues) > rows {
+		start = max(0, m.cursor[m.pane]-rows+1)
+		end = start + rows
+	}
+	for i := start; i < end; i++ {
+		issue := issues[i]
+		marker := "  "
+		if i == m.cursor[m.pane] {
+			marker = "> "
+		}
+		line := marker + formatIssueLine(m.app, issue)
+		if ids := m.blockers[issue.ID]; m.pane == paneBlocked && len(ids) > 0 {
+			line += " ← " + strings.Join(ids, ", ")
+		}
+		b.WriteString(line + "\n")
+	}
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestUIModel(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+
+	blocker, _ := store.Create(ctx, &issuestorage.Issue{
+		Title:    "Blocker",
+		Comments: []issuestorage.Comment{{ID: 1, Author: "alice", Text: "needs a decision", CreatedAt: time.Now()}},
+	})
+	waiting, _ := store.Create(ctx, &issuestorage.Issue{Title: "Waiting"})
+	started, _ := store.Create(ctx, &issuestorage.Issue{Title: "Started", Status: issuestorage.StatusInProgress})
+	if err := store.AddDependency(ctx, waiting, blocker, issuestorage.DepTypeBlocks); err != nil {
+		t.Fatal(err)
+	}
+
+	m := newUIModel(ctx, app)
+	press := func(keys ...tea.KeyMsg) {
+		t.Helper()
+		for _, k := range keys {
+			if _, cmd := m.Update(k); cmd != nil {
+				t.Fatalf("key %s returned a command", k)
+			}
+		}
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	paneIDs := func(p uiPane) []string {
+		var ids []string
+		for _, issue := range m.panes[p] {
+			ids = append(ids, issue.ID)
+		}
+		return ids
+	}
+
+	if got := paneIDs(paneReady); len(got) != 1 || got[0] != blocker {
+		t.Fatalf("ready pane = %v, want [%s] (%s is in progress)", got, blocker, started)
+	}
+	press(tea.KeyMsg{Type: tea.KeyTab}, tea.KeyMsg{Type: tea.KeyTab})
+	if view := m.View(); !strings.Contains(view, "> ") || !strings.Contains(view, waiting) || !strings.Contains(view, "← "+blocker) {
+		t.Errorf("blocked pane view missing %s and its blocker:\n%s", waiting, view)
+	}
+	press(tea.KeyMsg{Type: tea.KeyShiftTab}, tea.KeyMsg{Type: tea.KeyShiftTab})
+
+	// Details show comments; actions apply to the issue shown.
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if view := m.View(); !strings.Contains(view, "needs a decision") {
+		t.Errorf("detail view missing comment:\n%s", view)
+	}
+	press(runes("a"), runes("bob"), tea.KeyMsg{Type: tea.KeyEnter})
+	if issue, _ := store.Get(ctx, blocker); issue.Assignee != "bob" {
+		t.Errorf("assignee = %q, want bob (status %q)", issue.Assignee, m.status)
+	}
+	press(runes("c"))
+	if issue, _ := store.Get(ctx, blocker); issue.Status != issuestorage.StatusClosed {
+		t.Fatalf("status = %s after close (%q)", issue.Status, m.status)
+	}
+	if got := paneIDs(paneReady); len(got) != 1 || got[0] != waiting {
+		t.Errorf("ready pane after close = %v, want [%s]", got, waiting)
+	}
+	press(runes("o"))
+	if issue, _ := store.Get(ctx, blocker); issue.Status != issuestorage.StatusOpen {
+		t.Errorf("status = %s after reopen (%q)", issue.Status, m.status)
+	}
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.detail != nil || len(m.panes[paneBlocked]) != 1 {
+		t.Errorf("after esc: detail %v, blocked %v", m.detail != nil, paneIDs(paneBlocked))
+	}
+
+	if _, cmd := m.Update(runes("q")); cmd == nil {
+		t.Error("q did not quit")
+	}
+}
@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"beads-lite/internal/issueservice"
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/undo"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// undoTable is the KV table holding the undo stack. It is local to the
+// clone, so it gets its own .gitignore.
+const undoTable = "undo"
+
+// UndoJSON is an entry of the undo stack in bd undo output.
+type UndoJSON struct {
+	Seq     int             `json:"seq"`
+	Command string          `json:"command"`
+	At      string          `json:"at"`
+	Actor   string          `json:"actor,omitempty"`
+	Issues  []UndoIssueJSON `json:"issues"`
+}
+
+// UndoIssueJSON is an issue bd undo restores, or deletes if the command
+// created it.
+type UndoIssueJSON struct {
+	ID     string `json:"id"`
+	Action string `json:"action"` // "restore" or "delete"
+}
+
+func toUndoJSON(e undo.Entry) UndoJSON {
+	out := UndoJSON{Seq: e.Seq, Command: e.Command, At: formatTime(e.At), Actor: e.Actor, Issues: []UndoIssueJSON{}}
+	for _, img := range e.Issues {
+		action := "restore"
+		if img.Before == nil {
+			action = "delete"
+		}
+		out.Issues = append(out.Issues, UndoIssueJSON{ID: img.ID, Action: action})
+	}
+	return out
+}
+
+// undoable makes cmd push the issue changes it makes onto the undo stack
+// as one entry, so bd undo can reverse them. Changes are recorded even if
+// the command fails partway; failing to record them is only a warning,
+// since the changes themselves were made.
+func undoable(provider *AppProvider, cmd *cobra.Command) *cobra.Command {
+	run := cmd.RunE
+	cmd.RunE = func(c *cobra.Command, args []string) error {
+		app, err := provider.Get()
+		if err != nil {
+			return err
+		}
+		if app.UndoStore == nil {
+			return run(c, args)
+		}
+		journal := issueservice.NewJournal()
+		app.Storage.SetJournal(journal)
+		err = run(c, args)
+		app.Storage.SetJournal(nil)
+		if changes := journal.Changes(); len(changes) > 0 {
+			if perr := pushUndo(c.Context(), app, commandLine(c, args), changes); perr != nil {
+				fmt.Fprintf(app.Err, "warning: recording undo: %v\n", perr)
+			}
+		}
+		return err
+	}
+	return cmd
+}
+
+// commandLine reconstructs how c was invoked, for the undo stack.
+func commandLine(c *cobra.Command, args []string) string {
+	parts := append([]string{c.CommandPath()}, args...)
+	c.Flags().Visit(func(f *pflag.Flag) {
+		if c.InheritedFlags().Lookup(f.Name) != nil {
+			return
+		}
+		if f.Value.Type() == "bool" {
+			parts = append(parts, "--"+f.Name)
+			return
+		}
+		parts = append(parts, fmt.Sprintf("--%s=%q", f.Name, f.Value.String()))
+	})
+	return strings.Join(parts, " ")
+}
+
+// pushUndo records a command's changes on the undo stack, with when each
+// issue was last updated so bd undo can tell if it changed since.
+func pushUndo(ctx context.Context, app *App, command string, changes []issueservice.BeforeImage) error {
+	if s, ok := app.UndoStore.(interface{ Init(context.Context) error }); ok {
+		if err := s.Init(ctx); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(app.ConfigDir, undoTable, ".gitignore"), []byte("*\n"), 0644); err != nil {
+			return err
+		}
+	}
+	actor, _ := resolveActor(app)
+	e := undo.Entry{Command: command, At: app.Now(), Actor: actor}
+	for _, change := range changes {
+		img := undo.Image{ID: change.ID, Before: change.Issue}
+		if issue, err := app.Storage.Get(ctx, change.ID); err == nil {
+			img.UpdatedAt = issue.UpdatedAt
+		}
+		e.Issues = append(e.Issues, img)
+	}
+	_, err := undo.Push(ctx, app.UndoStore, e)
+	return err
+}
+
+// newUndoCmd creates the undo command.
+func newUndoCmd(provider *AppProvider) *cobra.Command {
+	var (
+		list  bool
+		force bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "undo",
+		Short: "Undo the last create, close, update, dep add or delete",
+		Long: `Reverse the most recent bd create, close, update, dep add or delete,
+putting every issue it changed back as it was: issues it created are
+deleted, and the others are restored from copies taken before it ran,
+including any parent it closed or dependency it added on the other side.
+Repeat to undo earlier commands; the last 20 are kept.
+
+If an issue has changed since, by hand or by another command, bd undo
+refuses rather than lose that change; --force undoes anyway.
+--list shows the undo stack, most recent first.
+
+Examples:
+  bd undo
+  bd undo --list
+  bd undo --force`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			entries, err := undo.List(ctx, app.UndoStore)
+			if err != nil {
+				return err
+			}
+
+			if list {
+				if app.JSON {
+					out := make([]UndoJSON, len(entries))
+					for i, e := range entries {
+						out[i] = toUndoJSON(e)
+					}
+					return json.NewEncoder(app.Out).Encode(out)
+				}
+				if len(entries) == 0 {
+					fmt.Fprintln(app.Out, "Nothing to undo")
+					return nil
+				}
+				for _, e := range entries {
+					ids := make([]string, len(e.Issues))
+					for i, img := range e.Issues {
+						ids[i] = img.ID
+					}
+					actor := e.Actor
+					if actor == "" {
+						actor = "-"
+					}
+					fmt.Fprintf(app.Out, "%3d  %s  %-12s  %s  (%s)\n", e.Seq, e.At.Local().Format("2006-01-02 15:04"), actor, e.Command, strings.Join(ids, ", "))
+				}
+				return nil
+			}
+
+			if len(entries) == 0 {
+				return errors.New("nothing to undo")
+			}
+			e := entries[0]
+			if !force {
+				var changed []string
+				for _, img := range e.Issues {
+					issue, err := app.Storage.Get(ctx, img.ID)
+					switch {
+					case errors.Is(err, issuestorage.ErrNotFound):
+						if !img.UpdatedAt.IsZero() {
+							changed = append(changed, img.ID+" (deleted)")
+						}
+					case err != nil:
+						return err
+					case !issue.UpdatedAt.Equal(img.UpdatedAt):
+						changed = append(changed, img.ID)
+					}
+				}
+				if len(changed) > 0 {
+					return fmt.Errorf("cannot undo %q: %s changed since; use --force to undo anyway", e.Command, strings.Join(changed, ", "))
+				}
+			}
+			for i := len(e.Issues) - 1; i >= 0; i-- {
+				img := e.Issues[i]
+				if img.Before == nil {
+					if err := app.Storage.Delete(ctx, img.ID); err != nil && !errors.Is(err, issuestorage.ErrNotFound) {
+						return fmt.Errorf("deleting %s: %w", img.ID, err)
+					}
+					continue
+				}
+				if _, err := app.Storage.Restore(ctx, img.Before); err != nil {
+					return fmt.Errorf("restoring %s: %w", img.ID, err)
+				}
+			}
+			if err := undo.Remove(ctx, app.UndoStore, e.Seq); err != nil {
+				return err
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(toUndoJSON(e))
+			}
+			fmt.Fprintf(app.Out, "%s Undid %s\n", app.SuccessColor("✓"), e.Command)
+			for _, img := range e.Issues {
+				if img.Before == nil {
+					fmt.Fprintf(app.Out, "  deleted %s\n", img.ID)
+				} else {
+					fmt.Fprintf(app.Out, "  restored %s: %s\n", img.ID, img.Before.Title)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&list, "list", false, "Show the undo stack instead of undoing")
+	cmd.Flags().BoolVar(&force, "force", false, "Undo even if the issues changed since")
+
+	return cmd
+}
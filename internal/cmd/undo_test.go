@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/clock"
+	"beads-lite/internal/issuestorage"
+	kvfs "beads-lite/internal/kvstorage/filesystem"
+
+	"github.com/spf13/cobra"
+)
+
+func TestUndo(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC))
+	store.SetClock(fake)
+	app.ConfigDir = t.TempDir()
+	// Left uninitialized: the first undoable command makes the table.
+	undoStore, err := kvfs.New(app.ConfigDir, undoTable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.UndoStore = undoStore
+
+	exec := func(newCmd func(*AppProvider) *cobra.Command, args ...string) (string, error) {
+		t.Helper()
+		fake.Advance(time.Minute)
+		out := &bytes.Buffer{}
+		app.Out = out
+		provider := NewTestProvider(app)
+		cmd := newCmd(provider)
+		if cmd.Use != "undo" {
+			cmd = undoable(provider, cmd)
+		}
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+	run := func(newCmd func(*AppProvider) *cobra.Command, args ...string) string {
+		t.Helper()
+		out, err := exec(newCmd, args...)
+		if err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+		return out
+	}
+
+	// Undoing a create deletes the issue.
+	created := extractCreatedID(run(newCreateCmd, "Scratch"))
+	if _, err := os.Stat(filepath.Join(app.ConfigDir, undoTable, ".gitignore")); err != nil {
+		t.Errorf("undo table should be git-ignored: %v", err)
+	}
+	out := run(newUndoCmd)
+	if !strings.Contains(out, "Undid create Scratch") || !strings.Contains(out, "deleted "+created) {
+		t.Errorf("unexpected undo output: %s", out)
+	}
+	if _, err := store.Get(ctx, created); !errors.Is(err, issuestorage.ErrNotFound) {
+		t.Errorf("created issue should be gone, got %v", err)
+	}
+
+	// Undoing a dep add removes both sides.
+	a := createAssigned(t, store, "Schema", "", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	b := createAssigned(t, store, "Migration", "", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	run(newDepAddCmd, b, a)
+	run(newUndoCmd)
+	for _, id := range []string{a, b} {
+		issue, _ := store.Get(ctx, id)
+		if len(issue.Dependencies) != 0 || len(issue.Dependents) != 0 {
+			t.Errorf("%s should have no dependencies after undo: %+v %+v", id, issue.Dependencies, issue.Dependents)
+		}
+	}
+
+	// Undoing a close reopens the issue as it was, and so does undoing a
+	// delete.
+	run(newCloseCmd, a, "--reason", "done")
+	run(newDeleteCmd, b, "--force")
+	out = run(newUndoCmd, "--list")
+	if !strings.Contains(out, "delete "+b+" --force") || !strings.Contains(out, `close `+a+` --reason="done"`) {
+		t.Errorf("unexpected undo list: %s", out)
+	}
+	run(newUndoCmd)
+	if issue, _ := store.Get(ctx, b); issue.Status != issuestorage.StatusOpen || issue.DeletedAt != nil {
+		t.Errorf("undoing delete: status %s, deleted at %v", issue.Status, issue.DeletedAt)
+	}
+	run(newUndoCmd)
+	if issue, _ := store.Get(ctx, a); issue.Status != issuestorage.StatusOpen || issue.ClosedAt != nil || issue.CloseReason != "" {
+		t.Errorf("undoing close: %+v", issue)
+	}
+
+	// A change made since is not overwritten without --force.
+	run(newUpdateCmd, a, "--title", "Schema v2")
+	fake.Advance(time.Minute)
+	if err := store.Modify(ctx, a, func(issue *issuestorage.Issue) error {
+		issue.Description = "edited by hand"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := exec(newUndoCmd); err == nil || !strings.Contains(err.Error(), "changed since") {
+		t.Errorf("undo over a later change: %v", err)
+	}
+	app.JSON = true
+	var undone UndoJSON
+	if err := json.Unmarshal([]byte(run(newUndoCmd, "--force")), &undone); err != nil {
+		t.Fatal(err)
+	}
+	if len(undone.Issues) != 1 || undone.Issues[0].ID != a || undone.Issues[0].Action != "restore" {
+		t.Errorf("undo JSON = %+v", undone)
+	}
+	if issue, _ := store.Get(ctx, a); issue.Title != "Schema" || issue.Description != "" {
+		t.Errorf("forced undo: title %q, description %q", issue.Title, issue.Description)
+	}
+	if _, err := exec(newUndoCmd); err == nil {
+		t.Error("undo with an empty stack should fail")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"beads-lite/internal/config"
+	"beads-lite/internal/issueservice"
 	"beads-lite/internal/issuestorage"
 
 	"github.com/spf13/cobra"
@@ -20,13 +21,24 @@ func newUpdateCmd(provider *AppProvider) *cobra.Command {
 		title        string
 		description  string
 		priority     string
+		severity     string
+		likelihood   string
+		impact       string
+		reviewBy     string
+		due          string
+		estimate     string
+		milestone    string
+		setVars      []string
+		unsetVars    []string
 		typeFlag     string
 		status       string
 		assignee     string
+		reporter     string
 		parent       string
 		addLabels    []string
 		removeLabels []string
 		claim        bool
+		force        bool
 	)
 
 	cmd := &cobra.Command{
@@ -37,14 +49,26 @@ func newUpdateCmd(provider *AppProvider) *cobra.Command {
 Examples:
   bd update bd-a1b2 --title "New title"
   bd update bd-a1b2 --priority 0
+  bd update bd-a1b2 --severity major
+  bd update bd-a1b2 --likelihood 2 --review-by 2026-09-01
+  bd update bd-a1b2 --due @code-freeze # follow a calendar event
+  bd update bd-a1b2 --due ""          # clear the deadline
+  bd update bd-a1b2 --estimate 3h     # see bd log-time for time spent
+  bd update bd-a1b2 --milestone v1.2  # see bd milestone
+  bd update bd-a1b2 --var DEPLOY_ENV=staging --unset-var DRY_RUN
   bd update bd-a1b2 --status in-progress
   bd update bd-a1b2 --add-label urgent --remove-label backlog
   bd update bd-a1b2 --assignee alice
   bd update bd-a1b2 --assignee ""     # unassign
+  bd update bd-a1b2 --reporter bob    # bob raised it
   bd update bd-a1b2 --parent bd-c3d4 # set parent
   bd update bd-a1b2 --parent ""      # remove parent
   bd update bd-a1b2 --description -  # read from stdin
-  bd update bd-a1b2 --claim          # assign to self + set in-progress`,
+  bd update bd-a1b2 --claim          # assign to self + set in-progress
+
+Editing a closed issue is governed by the closed.edit config: allow
+(default), warn, force (require --force) or reopen (reopen as part of the
+edit). Changing --status is always allowed.`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			app, err := provider.Get()
@@ -67,6 +91,41 @@ Examples:
 				parsedPriority = p
 			}
 
+			var parsedSeverity issuestorage.Severity
+			if cmd.Flags().Changed("severity") && severity != "" {
+				s, err := issuestorage.ParseSeverity(severity)
+				if err != nil {
+					return err
+				}
+				parsedSeverity = s
+			}
+
+			parsedLikelihood, parsedImpact, parsedReviewBy, err := parseRiskFlags(cmd, likelihood, impact, reviewBy)
+			if err != nil {
+				return err
+			}
+
+			parsedDueAt, parsedDueEvent, err := parseDueFlag(app, due)
+			if err != nil {
+				return err
+			}
+
+			parsedEstimate, err := parseEstimateFlag(estimate)
+			if err != nil {
+				return err
+			}
+
+			parsedVars, err := parseVarAssignments(setVars)
+			if err != nil {
+				return err
+			}
+
+			if cmd.Flags().Changed("milestone") && milestone != "" {
+				if err := checkMilestoneOpen(ctx, app, milestone); err != nil {
+					return err
+				}
+			}
+
 			var parsedType issuestorage.IssueType
 			if cmd.Flags().Changed("type") {
 				t, err := parseType(typeFlag, getCustomValues(app, "types.custom"))
@@ -102,6 +161,12 @@ Examples:
 				}
 			}
 
+			if cmd.Flags().Changed("assignee") {
+				if err := checkAssignee(ctx, app, assignee, force); err != nil {
+					return err
+				}
+			}
+
 			var actor string
 			if cmd.Flags().Changed("claim") && claim {
 				a, err := resolveActor(app)
@@ -111,6 +176,14 @@ Examples:
 				actor = a
 			}
 
+			reopen := false
+			if !cmd.Flags().Changed("status") {
+				reopen, err = checkClosedEdit(ctx, app, issueID, force)
+				if err != nil {
+					return err
+				}
+			}
+
 			// Handle parent changes first — AddDependency/RemoveDependency
 			// have their own locking.
 			if cmd.Flags().Changed("parent") {
@@ -147,9 +220,18 @@ Examples:
 			hasFieldChanges := cmd.Flags().Changed("title") ||
 				cmd.Flags().Changed("description") ||
 				cmd.Flags().Changed("priority") ||
+				cmd.Flags().Changed("severity") ||
+				cmd.Flags().Changed("likelihood") ||
+				cmd.Flags().Changed("impact") ||
+				cmd.Flags().Changed("review-by") ||
+				cmd.Flags().Changed("due") ||
+				cmd.Flags().Changed("estimate") ||
+				cmd.Flags().Changed("milestone") ||
+				len(setVars) > 0 || len(unsetVars) > 0 ||
 				cmd.Flags().Changed("type") ||
 				cmd.Flags().Changed("status") ||
 				cmd.Flags().Changed("assignee") ||
+				cmd.Flags().Changed("reporter") ||
 				(cmd.Flags().Changed("claim") && claim) ||
 				len(addLabels) > 0 || len(removeLabels) > 0
 
@@ -158,8 +240,21 @@ Examples:
 			}
 
 			// Apply all non-parent field changes atomically.
-			if hasFieldChanges {
+			var prevAssignee, issueTitle string
+			if hasFieldChanges || reopen {
 				if err := store.Modify(ctx, issueID, func(issue *issuestorage.Issue) error {
+					prevAssignee = issue.Assignee
+					// The type goes first, so fields for the new type given
+					// alongside it are not discarded with the old type's.
+					if cmd.Flags().Changed("type") {
+						if dropped := issueservice.LossyFields(issue, parsedType); len(dropped) > 0 && issue.Type != parsedType {
+							return fmt.Errorf("changing type to %s would discard %s; use 'bd convert %s --type %s --force'", parsedType, strings.Join(dropped, ", "), issueID, parsedType)
+						}
+						issueservice.SetType(issue, parsedType)
+					}
+					if reopen {
+						issue.Status = issuestorage.StatusOpen
+					}
 					if cmd.Flags().Changed("claim") && claim {
 						if issue.Assignee != "" {
 							return fmt.Errorf("cannot claim %s: already assigned to %q", issueID, issue.Assignee)
@@ -176,8 +271,39 @@ Examples:
 					if cmd.Flags().Changed("priority") {
 						issue.Priority = parsedPriority
 					}
-					if cmd.Flags().Changed("type") {
-						issue.Type = parsedType
+					if cmd.Flags().Changed("severity") {
+						issue.Severity = parsedSeverity
+					}
+					if cmd.Flags().Changed("likelihood") {
+						issue.Likelihood = parsedLikelihood
+					}
+					if cmd.Flags().Changed("impact") {
+						issue.Impact = parsedImpact
+					}
+					if cmd.Flags().Changed("review-by") {
+						issue.ReviewBy = parsedReviewBy
+					}
+					if cmd.Flags().Changed("due") {
+						issue.DueAt = parsedDueAt
+						issue.DueEvent = parsedDueEvent
+					}
+					if cmd.Flags().Changed("estimate") {
+						issue.Estimate = parsedEstimate
+					}
+					if cmd.Flags().Changed("milestone") {
+						issue.Milestone = milestone
+					}
+					for _, k := range unsetVars {
+						delete(issue.Vars, k)
+					}
+					for k, v := range parsedVars {
+						if issue.Vars == nil {
+							issue.Vars = make(map[string]string)
+						}
+						issue.Vars[k] = v
+					}
+					if len(issue.Vars) == 0 {
+						issue.Vars = nil
 					}
 					if cmd.Flags().Changed("status") {
 						issue.Status = parsedStatus
@@ -185,6 +311,9 @@ Examples:
 					if cmd.Flags().Changed("assignee") {
 						issue.Assignee = assignee
 					}
+					if cmd.Flags().Changed("reporter") {
+						issue.Reporter = reporter
+					}
 					if len(addLabels) > 0 || len(removeLabels) > 0 {
 						labels := issue.Labels
 						if labels == nil {
@@ -200,12 +329,21 @@ Examples:
 						}
 						issue.Labels = labels
 					}
+					issueTitle = issue.Title
 					return nil
 				}); err != nil {
 					return fmt.Errorf("updating issue: %w", err)
 				}
 			}
 
+			if reopen {
+				fmt.Fprintf(app.Err, "Reopened %s (closed.edit is reopen)\n", issueID)
+			}
+			if cmd.Flags().Changed("assignee") {
+				warnIfAway(app, assignee)
+				notifyAssigned(app, issueID, issueTitle, prevAssignee, assignee)
+			}
+
 			// Output the result
 			if app.JSON {
 				// Fetch the updated issue to return full details
@@ -228,13 +366,24 @@ Examples:
 	cmd.Flags().StringVar(&title, "title", "", "New title")
 	cmd.Flags().StringVar(&description, "description", "", "New description (use - for stdin)")
 	cmd.Flags().StringVarP(&priority, "priority", "p", "", "New priority (0-4 or P0-P4)")
-	cmd.Flags().StringVarP(&typeFlag, "type", "t", "", "New type (task, bug, feature, epic, chore, gate)")
+	cmd.Flags().StringVar(&severity, "severity", "", "New severity (critical, major, minor, trivial; empty string to clear)")
+	cmd.Flags().StringVar(&likelihood, "likelihood", "", "New risk likelihood (1-5; empty string to clear)")
+	cmd.Flags().StringVar(&impact, "impact", "", "New risk impact (1-5; empty string to clear)")
+	cmd.Flags().StringVar(&reviewBy, "review-by", "", "New risk review date (YYYY-MM-DD; empty string to clear)")
+	cmd.Flags().StringVar(&due, "due", "", "New deadline (YYYY-MM-DD, or @<event> to follow a calendar event; empty string to clear)")
+	cmd.Flags().StringVar(&estimate, "estimate", "", "New expected effort (e.g. 45m, 3h, 1d 4h; empty string to clear)")
+	cmd.Flags().StringVar(&milestone, "milestone", "", "Milestone to plan the issue for (empty string to clear)")
+	cmd.Flags().StringVarP(&typeFlag, "type", "t", "", "New type (task, bug, feature, epic, chore, gate, risk, decision, question)")
 	cmd.Flags().StringVarP(&status, "status", "s", "", "New status ("+statusNames(nil)+")")
 	cmd.Flags().StringVarP(&assignee, "assignee", "a", "", "Assign to user (empty string to unassign)")
+	cmd.Flags().StringVar(&reporter, "reporter", "", "Who raised the issue (empty string to fall back to the creator)")
 	cmd.Flags().StringVar(&parent, "parent", "", "Set parent issue (empty string to remove parent)")
+	cmd.Flags().StringArrayVar(&setVars, "var", nil, "Set a var for automation, KEY=VALUE (can repeat; see bd env)")
+	cmd.Flags().StringSliceVar(&unsetVars, "unset-var", nil, "Remove a var (can repeat)")
 	cmd.Flags().StringSliceVar(&addLabels, "add-label", nil, "Add label (can repeat)")
 	cmd.Flags().StringSliceVar(&removeLabels, "remove-label", nil, "Remove label (can repeat)")
 	cmd.Flags().BoolVar(&claim, "claim", false, "Claim issue: assign to current actor and set status to in-progress")
+	cmd.Flags().BoolVar(&force, "force", false, "Edit a closed issue even if closed.edit is force or reopen, or assign someone not in the people registry")
 
 	return cmd
 }
@@ -272,6 +421,12 @@ func parseType(s string, customTypes []string) (issuestorage.IssueType, error) {
 		return issuestorage.TypeGate, nil
 	case "molecule":
 		return issuestorage.TypeMolecule, nil
+	case "risk":
+		return issuestorage.TypeRisk, nil
+	case "decision":
+		return issuestorage.TypeDecision, nil
+	case "question":
+		return issuestorage.TypeQuestion, nil
 	default:
 		lower := strings.ToLower(s)
 		for _, ct := range customTypes {
@@ -279,7 +434,7 @@ func parseType(s string, customTypes []string) (issuestorage.IssueType, error) {
 				return issuestorage.IssueType(s), nil
 			}
 		}
-		builtins := "task, bug, feature, epic, chore, gate, molecule"
+		builtins := "task, bug, feature, epic, chore, gate, molecule, risk, decision, question"
 		if len(customTypes) > 0 {
 			builtins += ", " + strings.Join(customTypes, ", ")
 		}
@@ -293,6 +448,8 @@ func parseStatus(s string, customStatuses []string) (issuestorage.Status, error)
 		return issuestorage.StatusOpen, nil
 	case "in-progress", "in_progress", "inprogress":
 		return issuestorage.StatusInProgress, nil
+	case "review", "in-review", "in_review":
+		return issuestorage.StatusReview, nil
 	case "blocked":
 		return issuestorage.StatusBlocked, nil
 	case "deferred":
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -159,6 +160,38 @@ func TestUpdateType(t *testing.T) {
 	}
 }
 
+func TestUpdateType_InitializesFields(t *testing.T) {
+	app, store := setupTestApp(t)
+	issueID := createTestIssue(t, store)
+
+	cmd := newUpdateCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{issueID, "--type", "decision"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	issue, _ := store.Get(context.Background(), issueID)
+	if issue.Type != issuestorage.TypeDecision || issue.DecisionState != issuestorage.DecisionProposed {
+		t.Errorf("type %q, decision state %q, want a proposed decision", issue.Type, issue.DecisionState)
+	}
+
+	// Fields of the new type given alongside it are kept.
+	cmd = newUpdateCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{issueID, "--type", "risk", "--likelihood", "3"})
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("converting away from a decision should need bd convert --force")
+	}
+	issueID = createTestIssue(t, store)
+	cmd = newUpdateCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{issueID, "--type", "risk", "--likelihood", "3"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	issue, _ = store.Get(context.Background(), issueID)
+	if issue.Type != issuestorage.TypeRisk || issue.Likelihood != 3 {
+		t.Errorf("type %q, likelihood %d, want a risk with likelihood 3", issue.Type, issue.Likelihood)
+	}
+}
+
 func TestUpdateStatus(t *testing.T) {
 	tests := []struct {
 		status   string
@@ -208,6 +241,28 @@ func TestUpdateAssignee(t *testing.T) {
 	}
 }
 
+func TestUpdateAssigneeNotifiesLocalActor(t *testing.T) {
+	t.Setenv("BD_ACTOR", "alice")
+	app, store := setupTestApp(t)
+	sent := captureNotifications(app)
+	issueID := createTestIssue(t, store)
+
+	for _, assignee := range []string{"bob", "alice", "alice"} {
+		cmd := newUpdateCmd(NewTestProvider(app))
+		cmd.SetArgs([]string{issueID, "--assignee", assignee})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("update --assignee %s: %v", assignee, err)
+		}
+	}
+
+	// Only the change to alice notifies; assigning bob, or alice again,
+	// does not.
+	want := []string{"Assigned: " + issueID + ": Original title\nassigned to alice"}
+	if !reflect.DeepEqual(*sent, want) {
+		t.Errorf("notifications = %q, want %q", *sent, want)
+	}
+}
+
 func TestUpdateUnassign(t *testing.T) {
 	app, store := setupTestApp(t)
 	issueID := createTestIssue(t, store)
@@ -840,7 +895,7 @@ func TestParseTypeNoCustomTypes(t *testing.T) {
 }
 
 func TestParseStatusWithCustomStatuses(t *testing.T) {
-	customStatuses := []string{"review", "qa"}
+	customStatuses := []string{"verify", "qa"}
 
 	tests := []struct {
 		input    string
@@ -853,8 +908,8 @@ func TestParseStatusWithCustomStatuses(t *testing.T) {
 		{"BLOCKED", issuestorage.StatusBlocked},
 		{"closed", issuestorage.StatusClosed},
 		// Custom statuses work
-		{"review", issuestorage.Status("review")},
-		{"Review", issuestorage.Status("Review")},
+		{"verify", issuestorage.Status("verify")},
+		{"Verify", issuestorage.Status("Verify")},
 		{"qa", issuestorage.Status("qa")},
 	}
 
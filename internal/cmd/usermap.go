@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// userMapPrefix returns the prefix of tracker's user mapping in config:
+// <tracker>.user.<login> names the local identity of a login on tracker,
+// e.g. github.user.octocat = alice@example.com.
+func userMapPrefix(tracker string) string {
+	return tracker + ".user."
+}
+
+// userMap translates between local identities and the logins of one
+// tracker, as configured under userMapPrefix. Names without a mapping pass
+// through unchanged.
+type userMap struct {
+	logins []string          // sorted
+	local  map[string]string // login -> local identity
+}
+
+// loadUserMap reads tracker's user mapping from config.
+func loadUserMap(app *App, tracker string) userMap {
+	m := userMap{local: make(map[string]string)}
+	if app.ConfigStore == nil {
+		return m
+	}
+	all := app.ConfigStore.All()
+	for _, key := range sortedKeys(all) {
+		login, ok := strings.CutPrefix(key, userMapPrefix(tracker))
+		if !ok || login == "" || all[key] == "" {
+			continue
+		}
+		m.logins = append(m.logins, login)
+		m.local[login] = all[key]
+	}
+	return m
+}
+
+// toLocal returns the local identity of login.
+func (m userMap) toLocal(login string) string {
+	for _, l := range m.logins {
+		if strings.EqualFold(l, login) {
+			return m.local[l]
+		}
+	}
+	return login
+}
+
+// toRemote returns the login of a local identity, matched as mentions are
+// ("alice" finds the login mapped to alice@example.com). If several logins
+// map to the identity, the first in sorted order wins.
+func (m userMap) toRemote(identity string) string {
+	if identity == "" {
+		return ""
+	}
+	for _, l := range m.logins {
+		if issuestorage.MentionMatches(identity, m.local[l]) {
+			return l
+		}
+	}
+	return identity
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// varNamePattern matches the names issue vars may have: environment
+// variable names, so bd env can export them as they are.
+var varNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// envIssueID is set by bd env to the ID of the issue.
+const envIssueID = "BD_ISSUE_ID"
+
+// parseVarAssignments parses --var KEY=VALUE flags.
+func parseVarAssignments(assignments []string) (map[string]string, error) {
+	vars := make(map[string]string, len(assignments))
+	for _, a := range assignments {
+		name, value, ok := strings.Cut(a, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --var %q: expected KEY=VALUE", a)
+		}
+		if !varNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid --var name %q: use letters, digits and underscores, not starting with a digit", name)
+		}
+		vars[name] = value
+	}
+	return vars, nil
+}
+
+// issueVars returns the vars in effect for issue: its own, then those of
+// its parent, grandparent and so on that it does not set itself. Steps of
+// a molecule thereby see the vars it was poured with.
+func issueVars(ctx context.Context, store issuestorage.IssueGetter, issue *issuestorage.Issue) (map[string]string, error) {
+	vars := make(map[string]string)
+	seen := make(map[string]bool)
+	for cur := issue; cur != nil && !seen[cur.ID]; {
+		seen[cur.ID] = true
+		for k, v := range cur.Vars {
+			if _, ok := vars[k]; !ok {
+				vars[k] = v
+			}
+		}
+		if cur.Parent == "" {
+			break
+		}
+		parent, err := store.Get(ctx, cur.Parent)
+		if errors.Is(err, issuestorage.ErrNotFound) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("getting parent %s: %w", cur.Parent, err)
+		}
+		cur = parent
+	}
+	return vars, nil
+}
+
+// pourVars returns the variables to pour a formula with: the vars in
+// effect for the issue named by --vars-from, if any, overridden by --var
+// assignments.
+func pourVars(ctx context.Context, app *App, varsFrom string, assignments []string) (map[string]string, error) {
+	vars := make(map[string]string)
+	if varsFrom != "" {
+		issue, err := resolveIssue(app.Storage, ctx, varsFrom)
+		if err != nil {
+			return nil, fmt.Errorf("resolving --vars-from %s: %w", varsFrom, err)
+		}
+		if vars, err = issueVars(ctx, app.Storage, issue); err != nil {
+			return nil, err
+		}
+	}
+	for k, v := range parseVarFlags(assignments) {
+		vars[k] = v
+	}
+	return vars, nil
+}
+
+// newEnvCmd creates the env command.
+func newEnvCmd(provider *AppProvider) *cobra.Command {
+	var export bool
+
+	cmd := &cobra.Command{
+		Use:   "env <issue-id>",
+		Short: "Print an issue's vars as environment variables",
+		Long: `Print the vars of an issue as environment variables, one KEY=VALUE per
+line, for scripts and automation acting on it. Vars set on an ancestor
+apply to its descendants unless they set their own, so the steps and
+gates of a molecule see the vars it was poured with. BD_ISSUE_ID is set
+to the issue's ID.
+
+Set vars with bd create --var or bd update --var KEY=VALUE; formulas can
+take them as variables with bd mol pour --vars-from.
+
+Examples:
+  bd update bd-a1b2 --var DEPLOY_ENV=staging
+  bd env bd-a1b2
+  eval "$(bd env bd-a1b2 --export)" && ./deploy.sh`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			issue, err := resolveIssue(app.Storage, ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("resolving issue %s: %w", args[0], err)
+			}
+			env, err := issueVars(ctx, app.Storage, issue)
+			if err != nil {
+				return err
+			}
+			env[envIssueID] = issue.ID
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(env)
+			}
+			for _, k := range sortedKeys(env) {
+				if export {
+					fmt.Fprintf(app.Out, "export %s=%s\n", k, shellQuote(env[k]))
+				} else {
+					fmt.Fprintf(app.Out, "%s=%s\n", k, env[k])
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&export, "export", false, "Print export statements for a POSIX shell to eval")
+
+	return cmd
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
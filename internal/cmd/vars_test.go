@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+const deployFormula = `formula = "deploy"
+description = "Deploy a release"
+version = 1
+type = "workflow"
+
+[vars.DEPLOY_ENV]
+required = true
+enum = ["staging", "production"]
+
+[[steps]]
+id = "ship"
+title = "Ship to {{DEPLOY_ENV}}"
+`
+
+func TestParseVarAssignments(t *testing.T) {
+	got, err := parseVarAssignments([]string{"DEPLOY_ENV=staging", "URL=https://x?a=b", "EMPTY="})
+	want := map[string]string{"DEPLOY_ENV": "staging", "URL": "https://x?a=b", "EMPTY": ""}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("parseVarAssignments = %v, %v", got, err)
+	}
+	for _, bad := range []string{"NOVALUE", "1ST=x", "DEPLOY-ENV=x", "=x"} {
+		if _, err := parseVarAssignments([]string{bad}); err == nil {
+			t.Errorf("parseVarAssignments(%q) should fail", bad)
+		}
+	}
+}
+
+func TestIssueVars(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	app.ConfigDir = t.TempDir()
+	run := func(newCmd func(*AppProvider) *cobra.Command, args ...string) string {
+		t.Helper()
+		out := &bytes.Buffer{}
+		app.Out = out
+		cmd := newCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+		return out.String()
+	}
+
+	epic := createAssigned(t, store, "Release", "", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	gate := createAssigned(t, store, "Approve deploy", "", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	if err := store.AddDependency(ctx, gate, epic, issuestorage.DepTypeParentChild); err != nil {
+		t.Fatal(err)
+	}
+	run(newUpdateCmd, epic, "--var", "DEPLOY_ENV=staging", "--var", "REGION=eu-west-1", "--var", "DRY_RUN=1")
+	run(newUpdateCmd, epic, "--unset-var", "DRY_RUN")
+	run(newUpdateCmd, gate, "--var", "REGION=us-east-1", "--var", "NOTE=it's live")
+
+	if issue, _ := store.Get(ctx, epic); !reflect.DeepEqual(issue.Vars, map[string]string{"DEPLOY_ENV": "staging", "REGION": "eu-west-1"}) {
+		t.Errorf("epic vars = %v", issue.Vars)
+	}
+
+	// The gate inherits the epic's vars and overrides its own.
+	want := "BD_ISSUE_ID=" + gate + "\nDEPLOY_ENV=staging\nNOTE=it's live\nREGION=us-east-1\n"
+	if got := run(newEnvCmd, gate); got != want {
+		t.Errorf("env = %q, want %q", got, want)
+	}
+	if got := run(newEnvCmd, gate, "--export"); !bytes.Contains([]byte(got), []byte(`export NOTE='it'\''s live'`)) {
+		t.Errorf("env --export = %q", got)
+	}
+
+	// Formulas take an issue's vars as variables and keep them on the molecule.
+	formulaDir := filepath.Join(app.ConfigDir, "formulas")
+	os.MkdirAll(formulaDir, 0o755)
+	if err := os.WriteFile(filepath.Join(formulaDir, "deploy.formula.toml"), []byte(deployFormula), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	app.JSON = true
+	var poured struct {
+		NewEpicID string `json:"new_epic_id"`
+	}
+	if err := json.Unmarshal([]byte(run(newMolPourCmd, "deploy", "--vars-from", gate)), &poured); err != nil {
+		t.Fatal(err)
+	}
+	root, err := store.Get(ctx, poured.NewEpicID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root.Vars["DEPLOY_ENV"] != "staging" || root.Vars["REGION"] != "us-east-1" {
+		t.Errorf("molecule vars = %v", root.Vars)
+	}
+	step, err := store.Get(ctx, root.Children()[0])
+	if err != nil || step.Title != "Ship to staging" {
+		t.Errorf("step = %+v, %v", step, err)
+	}
+	var env map[string]string
+	if err := json.Unmarshal([]byte(run(newEnvCmd, step.ID)), &env); err != nil || env["DEPLOY_ENV"] != "staging" {
+		t.Errorf("step env = %v, %v", env, err)
+	}
+
+	cmd := newMolPourCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"deploy", "--vars-from", gate, "--var", "DEPLOY_ENV=qa"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("--var should override --vars-from and fail the enum check")
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/spf13/cobra"
 )
@@ -17,8 +18,13 @@ func newVersionCmd(provider *AppProvider) *cobra.Command {
 		Short: "Print version information",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if provider.JSONOutput {
+				compat := provider.Compat
+				if compat == 0 {
+					compat = compatVersion
+				}
 				return json.NewEncoder(provider.Out).Encode(map[string]string{
 					"version": Version,
+					"compat":  strconv.Itoa(compat),
 				})
 			}
 			fmt.Fprintf(provider.Out, "bd version %s (beads-lite)\n", Version)
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/notify"
+
+	"github.com/spf13/cobra"
+)
+
+// WatchEventJSON is one line of bd watch output.
+type WatchEventJSON struct {
+	Event string          `json:"event"` // created, updated, closed or deleted
+	ID    string          `json:"id"`
+	Issue IssueSimpleJSON `json:"issue"`
+}
+
+// newWatchCmd creates the watch command.
+func newWatchCmd(provider *AppProvider) *cobra.Command {
+	var (
+		statuses  []string
+		types     []string
+		labels    []string
+		assignees []string
+		notifyOn  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Stream issue changes as JSON lines",
+		Long: `Print a JSON line for every issue that is created, updated, closed or
+deleted, until interrupted, so scripts and TUIs can react to changes
+without polling bd list.
+
+Each line has the event, the issue ID and the issue as written (for a
+deletion, as last seen). Changes made by any process are seen: on the
+filesystem backend through file notifications, on other backends by
+listing the store every couple of seconds. Filters select the issues
+watched; an issue is reported if it matches before or after the change.
+
+With --notify, changes made elsewhere also send the desktop notifications
+configured by notify.* (see bd config): an issue assigned to you, a
+comment that @mentions you, an issue you reported marked needs-info.
+Commands you run yourself and bd sync already notify, so this is for
+shared backends (postgres, object store), where other people's changes
+reach you without passing through either.
+
+Examples:
+  bd watch
+  bd watch --notify > /dev/null
+  bd watch --assignee alice
+  bd watch --label backend | jq -r 'select(.event == "closed") | .id'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			filter := &issuestorage.ListFilter{Labels: labels, Assignees: assignees}
+			for _, s := range statuses {
+				status, err := parseStatus(s, getCustomValues(app, "status.custom"))
+				if err != nil {
+					return err
+				}
+				filter.Statuses = append(filter.Statuses, status)
+			}
+			for _, t := range types {
+				filter.Types = append(filter.Types, issuestorage.IssueType(t))
+			}
+
+			var seen map[string]*issuestorage.Issue
+			if notifyOn {
+				if newNotifier(app) == nil {
+					return fmt.Errorf("--notify: notifications are off; enable them with bd config set %s true", notify.EnabledKey)
+				}
+				issues, err := app.Storage.List(ctx, nil)
+				if err != nil {
+					return fmt.Errorf("listing issues: %w", err)
+				}
+				seen = make(map[string]*issuestorage.Issue, len(issues))
+				for _, issue := range issues {
+					seen[issue.ID] = issue
+				}
+			}
+
+			changes, err := app.Storage.Watch(ctx, filter)
+			if err != nil {
+				return err
+			}
+			enc := json.NewEncoder(app.Out)
+			for c := range changes {
+				if notifyOn && c.Kind != issuestorage.ChangeDeleted {
+					notifyChange(ctx, app, seen[c.ID], c.Issue)
+					seen[c.ID] = c.Issue
+				}
+				if err := enc.Encode(WatchEventJSON{Event: string(c.Kind), ID: c.ID, Issue: ToIssueSimpleJSON(c.Issue)}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&statuses, "status", "s", nil, "Only issues with these statuses (repeatable)")
+	cmd.Flags().StringSliceVarP(&types, "type", "t", nil, "Only issues of these types (repeatable)")
+	cmd.Flags().StringSliceVarP(&labels, "label", "l", nil, "Only issues with any of these labels (repeatable)")
+	cmd.Flags().StringSliceVar(&assignees, "assignee", nil, "Only issues assigned to these people (repeatable)")
+	cmd.Flags().BoolVar(&notifyOn, "notify", false, "Send desktop notifications for changes made elsewhere")
+
+	return cmd
+}
+
+// notifyChange sends the notifications a change made elsewhere calls for,
+// as the command that made it would have locally. prev is the issue as
+// last seen, or nil.
+func notifyChange(ctx context.Context, app *App, prev, issue *issuestorage.Issue) {
+	var (
+		prevAssignee string
+		prevLabels   []string
+		prevComments = make(map[string]bool)
+	)
+	if prev != nil {
+		prevAssignee, prevLabels = prev.Assignee, prev.Labels
+		for _, c := range prev.Comments {
+			prevComments[c.Key()] = true
+		}
+	}
+	if !assignedByActor(app, issue) {
+		notifyAssigned(app, issue.ID, issue.Title, prevAssignee, issue.Assignee)
+	}
+	notifyNeedsInfo(app, issue, prevLabels)
+	for _, c := range issue.Comments {
+		if !prevComments[c.Key()] {
+			notifyMentions(ctx, app, issue.ID, &c)
+		}
+	}
+}
+
+// assignedByActor reports whether the local actor made issue's latest
+// assignment, which the command they ran already notified.
+func assignedByActor(app *App, issue *issuestorage.Issue) bool {
+	actor, err := resolveActor(app)
+	if err != nil {
+		return false
+	}
+	for i := len(issue.History) - 1; i >= 0; i-- {
+		if h := issue.History[i]; h.Event == issuestorage.EventAssigned {
+			return h.Actor != "" && issuestorage.MentionMatches(actor, h.Actor)
+		}
+	}
+	return false
+}
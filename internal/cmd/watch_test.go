@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestWatchStreamsChanges(t *testing.T) {
+	app, store := setupTestApp(t)
+	store.SetWatcher(&issuestorage.PollWatcher{Store: store, Interval: 5 * time.Millisecond})
+	web, _ := store.Create(context.Background(), &issuestorage.Issue{Title: "Web", Labels: []string{"web"}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		cmd := newWatchCmd(NewTestProvider(app))
+		cmd.SetArgs([]string{"--label", "web"})
+		done <- cmd.ExecuteContext(ctx)
+	}()
+	time.Sleep(20 * time.Millisecond) // let the watch take its first listing
+
+	store.Create(ctx, &issuestorage.Issue{Title: "Unlabelled"})
+	store.Modify(ctx, web, func(i *issuestorage.Issue) error {
+		i.Status = issuestorage.StatusClosed
+		return nil
+	})
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("watch failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(app.Out.(*bytes.Buffer).String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("got %d events, want 1:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	var ev WatchEventJSON
+	if err := json.Unmarshal([]byte(lines[0]), &ev); err != nil {
+		t.Fatal(err)
+	}
+	if ev.Event != "closed" || ev.ID != web || ev.Issue.Status != "closed" {
+		t.Errorf("event = %+v", ev)
+	}
+}
+
+func TestWatchNotify(t *testing.T) {
+	t.Setenv("BD_ACTOR", "alice")
+	app, store := setupTestApp(t)
+	sent := captureNotifications(app)
+	store.SetWatcher(&issuestorage.PollWatcher{Store: store, Interval: 5 * time.Millisecond})
+	store.SetActor(func() string { return "carol" }) // changes made elsewhere
+	bg := context.Background()
+	id, _ := store.Create(bg, &issuestorage.Issue{Title: "Rollout"})
+	mine, _ := store.Create(bg, &issuestorage.Issue{Title: "Mine"})
+
+	ctx, cancel := context.WithCancel(bg)
+	done := make(chan error)
+	go func() {
+		cmd := newWatchCmd(NewTestProvider(app))
+		cmd.SetArgs([]string{"--notify"})
+		done <- cmd.ExecuteContext(ctx)
+	}()
+	time.Sleep(20 * time.Millisecond) // let the watch take its first listing
+
+	store.Modify(ctx, id, func(i *issuestorage.Issue) error {
+		i.Assignee = "alice"
+		i.Comments = append(i.Comments, issuestorage.Comment{ID: 1, UUID: "c1", Author: "carol", Text: "@alice over to you"})
+		return nil
+	})
+	store.SetActor(func() string { return "alice" })
+	store.Modify(ctx, mine, func(i *issuestorage.Issue) error {
+		i.Assignee = "alice" // notified by the command that did it
+		return nil
+	})
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("watch failed: %v", err)
+	}
+
+	want := []string{
+		"Assigned: " + id + ": Rollout\nassigned to alice",
+		"Mentioned in " + id + ": Rollout\n@alice mentioned by carol",
+	}
+	if strings.Join(*sent, "|") != strings.Join(want, "|") {
+		t.Errorf("notifications = %q, want %q", *sent, want)
+	}
+}
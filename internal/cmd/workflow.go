@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"beads-lite/internal/issueservice"
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// WorkflowStatusJSON is one status of the workflow in bd workflow show
+// output. Next is null when the status may move to any other. Required
+// maps issue types to the fields needed to enter the status; "*" applies
+// to every type.
+type WorkflowStatusJSON struct {
+	Status   string              `json:"status"`
+	Next     []string            `json:"next"`
+	Required map[string][]string `json:"required,omitempty"`
+}
+
+// newWorkflowCmd creates the workflow command with subcommands.
+func newWorkflowCmd(provider *AppProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "workflow",
+		Short: "Show the configured status workflow",
+		Long: `The workflow is a state machine over issue statuses, configured with
+bd config set:
+
+  workflow.<status>.next             statuses an issue may move to from
+                                     <status> (unset: any)
+  workflow.<status>.required         fields an issue needs to enter <status>
+  workflow.<status>.<type>.required  the same, for one issue type
+
+Required fields are any of: ` + strings.Join(issueservice.TransitionFields, ", ") + `.
+The workflow is enforced whenever a status changes, by bd update, bd
+close, bd reopen and every other command alike.
+
+Examples:
+  bd config set workflow.open.next "in_progress,closed"
+  bd config set workflow.closed.bug.required close_reason
+  bd workflow show`,
+	}
+
+	cmd.AddCommand(newWorkflowShowCmd(provider))
+
+	return cmd
+}
+
+// newWorkflowShowCmd creates the "workflow show" subcommand.
+func newWorkflowShowCmd(provider *AppProvider) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show",
+		Short: "Show allowed status transitions and required fields",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			workflow := app.Storage.Workflow()
+			statuses := workflowStatuses(app, workflow)
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(statuses)
+			}
+
+			if workflow.IsZero() {
+				fmt.Fprintln(app.Out, "No workflow configured: any status may move to any other.")
+				fmt.Fprintln(app.Out, "See bd workflow --help to configure one.")
+				return nil
+			}
+			width := 0
+			for _, st := range statuses {
+				width = max(width, len(st.Status))
+			}
+			for _, st := range statuses {
+				next := "any"
+				if st.Next != nil {
+					next = strings.Join(st.Next, ", ")
+					if next == "" {
+						next = "none"
+					}
+				}
+				fmt.Fprintf(app.Out, "%-*s → %s\n", width, st.Status, next)
+				for _, t := range sortedKeys(st.Required) {
+					label := "requires"
+					if t != "*" {
+						label = t + " requires"
+					}
+					fmt.Fprintf(app.Out, "%-*s   %s: %s\n", width, "", label, strings.Join(st.Required[t], ", "))
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// workflowStatuses describes every built-in and custom status, then any
+// others the workflow names, in that order.
+func workflowStatuses(app *App, workflow issueservice.Workflow) []WorkflowStatusJSON {
+	var names []issuestorage.Status
+	names = append(names, issuestorage.BuiltinStatuses...)
+	for _, s := range getCustomValues(app, "status.custom") {
+		names = append(names, issuestorage.Status(s))
+	}
+	for _, s := range workflow.Statuses() {
+		if !slices.Contains(names, s) {
+			names = append(names, s)
+		}
+	}
+
+	out := make([]WorkflowStatusJSON, len(names))
+	for i, status := range names {
+		out[i].Status = string(status)
+		if next, ok := workflow.Next[status]; ok {
+			out[i].Next = []string{}
+			for _, to := range next {
+				out[i].Next = append(out[i].Next, string(to))
+			}
+		}
+		for t, fields := range workflow.Required[status] {
+			if len(fields) == 0 {
+				continue
+			}
+			key := string(t)
+			if key == "" {
+				key = "*"
+			}
+			if out[i].Required == nil {
+				out[i].Required = make(map[string][]string)
+			}
+			out[i].Required[key] = fields
+		}
+	}
+	return out
+}
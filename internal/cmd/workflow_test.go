@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issueservice"
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+func TestWorkflowCommands(t *testing.T) {
+	app, store := setupTestApp(t)
+	ctx := context.Background()
+	exec := func(newCmd func(*AppProvider) *cobra.Command, args ...string) (string, error) {
+		t.Helper()
+		out := &bytes.Buffer{}
+		app.Out = out
+		cmd := newCmd(NewTestProvider(app))
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	if out, err := exec(newWorkflowCmd, "show"); err != nil || !strings.Contains(out, "No workflow configured") {
+		t.Errorf("workflow show without config = %q, %v", out, err)
+	}
+
+	workflow, err := issueservice.ParseWorkflow(map[string]string{
+		"workflow.open.next":           "in_progress",
+		"workflow.in_progress.next":    "review,closed",
+		"workflow.closed.bug.required": "close_reason",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.SetWorkflow(workflow)
+
+	out, err := exec(newWorkflowCmd, "show")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"open        → in_progress\n",
+		"in_progress → review, closed\n",
+		"blocked     → any\n",
+		"closed      → any\n              bug requires: close_reason\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("workflow show missing %q:\n%s", want, out)
+		}
+	}
+
+	// bd close and bd update are both held to the workflow.
+	bug, _ := store.Create(ctx, &issuestorage.Issue{Title: "Crash", Type: issuestorage.TypeBug})
+	if _, err := exec(newCloseCmd, bug); err == nil || !strings.Contains(err.Error(), "cannot move from open to closed") {
+		t.Errorf("closing an open bug = %v", err)
+	}
+	if _, err := exec(newUpdateCmd, bug, "--status", "in_progress"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := exec(newCloseCmd, bug); err == nil || !strings.Contains(err.Error(), "needs close_reason") {
+		t.Errorf("closing a bug without a reason = %v", err)
+	}
+	if _, err := exec(newCloseCmd, bug, "--reason", "fixed"); err != nil {
+		t.Errorf("closing a bug with a reason = %v", err)
+	}
+
+	app.JSON = true
+	out, _ = exec(newWorkflowCmd, "show")
+	var statuses []WorkflowStatusJSON
+	if err := json.Unmarshal([]byte(out), &statuses); err != nil {
+		t.Fatal(err)
+	}
+	byStatus := make(map[string]WorkflowStatusJSON)
+	for _, st := range statuses {
+		byStatus[st.Status] = st
+	}
+	if open := byStatus["open"]; len(open.Next) != 1 || open.Next[0] != "in_progress" || byStatus["blocked"].Next != nil {
+		t.Errorf("statuses = %+v", statuses)
+	}
+	if got := byStatus["closed"].Required["bug"]; len(got) != 1 || got[0] != "close_reason" {
+		t.Errorf("closed requires %v", byStatus["closed"].Required)
+	}
+}
@@ -0,0 +1,369 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/spf13/cobra"
+)
+
+// workloadUnassigned labels the workload row for issues with no assignee.
+const workloadUnassigned = "(unassigned)"
+
+// WorkloadJSON is one assignee's row in bd workload. Active counts the open
+// and in-progress issues, the ones rebalancing evens out; Other counts
+// blocked, deferred and other non-closed statuses. Estimate and Remaining
+// sum the estimates and the effort left on all of them, in minutes.
+type WorkloadJSON struct {
+	Assignee   string `json:"assignee"`
+	Open       int    `json:"open"`
+	InProgress int    `json:"in_progress"`
+	Other      int    `json:"other"`
+	Active     int    `json:"active"`
+	Total      int    `json:"total"`
+	Estimate   int    `json:"estimate_minutes,omitempty"`
+	Remaining  int    `json:"remaining_minutes,omitempty"`
+	AwayUntil  string `json:"away_until,omitempty"` // set while out of office
+}
+
+// RebalanceMoveJSON is one suggested reassignment from bd rebalance.
+type RebalanceMoveJSON struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Priority int    `json:"priority"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+}
+
+// RebalanceJSON is the JSON output of bd rebalance --suggest. Before and
+// After map each person considered to their active issue count; Away maps
+// those out of office to the last day of their absence.
+type RebalanceJSON struct {
+	Moves  []RebalanceMoveJSON `json:"moves"`
+	Before map[string]int      `json:"before"`
+	After  map[string]int      `json:"after"`
+	Away   map[string]string   `json:"away,omitempty"`
+}
+
+// newWorkloadCmd creates the workload command.
+func newWorkloadCmd(provider *AppProvider) *cobra.Command {
+	var labels []string
+
+	cmd := &cobra.Command{
+		Use:   "workload",
+		Short: "Show open and in-progress issue counts per assignee",
+		Long: `Show how much unfinished work each assignee has: open, in-progress and
+other non-closed issues (blocked, deferred, ...). The busiest people are
+listed first, with unassigned issues last. People who are out of office
+(see bd ooo) are marked.
+
+When issues have estimates (bd update --estimate), each row also sums
+them and the effort remaining after the time logged (bd log-time).
+Issues without an estimate add nothing to those sums.
+
+Use bd rebalance --suggest for proposed moves that even the load out.
+
+Examples:
+  bd workload
+  bd workload --label backend --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			issues, err := workloadIssues(cmd.Context(), app, labels)
+			if err != nil {
+				return err
+			}
+			rows := buildWorkload(issues)
+			away := awayPeople(app, app.Now())
+			for i := range rows {
+				if until, ok := away[rows[i].Assignee]; ok {
+					rows[i].AwayUntil = until.Format(oooDateLayout)
+				}
+			}
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(rows)
+			}
+			if len(rows) == 0 {
+				fmt.Fprintln(app.Out, "No open issues.")
+				return nil
+			}
+			nameW := len("ASSIGNEE")
+			estimated := false
+			for _, r := range rows {
+				nameW = max(nameW, len(r.Assignee))
+				estimated = estimated || r.Estimate > 0
+			}
+			header := fmt.Sprintf("%-*s  %4s  %11s  %5s  %5s", nameW, "ASSIGNEE", "OPEN", "IN PROGRESS", "OTHER", "TOTAL")
+			if estimated {
+				header += fmt.Sprintf("  %9s", "REMAINING")
+			}
+			fmt.Fprintln(app.Out, header)
+			for _, r := range rows {
+				line := fmt.Sprintf("%-*s  %4d  %11d  %5d  %5d", nameW, r.Assignee, r.Open, r.InProgress, r.Other, r.Total)
+				if estimated {
+					remaining := "-"
+					if r.Estimate > 0 {
+						remaining = formatEffort(r.Remaining)
+					}
+					line += fmt.Sprintf("  %9s", remaining)
+				}
+				if r.AwayUntil != "" {
+					line += "  (away until " + r.AwayUntil + ")"
+				}
+				fmt.Fprintln(app.Out, line)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&labels, "label", "l", nil, "Only count issues with all of these labels")
+
+	return cmd
+}
+
+// newRebalanceCmd creates the rebalance command.
+func newRebalanceCmd(provider *AppProvider) *cobra.Command {
+	var (
+		suggest bool
+		labels  []string
+		people  []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rebalance",
+		Short: "Suggest reassignments that even out workload",
+		Long: `Suggest moving unstarted (open) issues from the most loaded assignees
+to the least loaded ones, one at a time, until another move would no
+longer narrow the gap. Load is the number of open plus in-progress issues;
+the lowest priority issues are moved first, and in-progress work is never
+moved.
+
+Only assignees with active work are considered; add people with nothing
+assigned yet with --people. People who are out of office (see bd ooo) are
+never proposed as targets, and all their unstarted work is moved to others.
+Nothing is changed: apply a move with bd update <id> --assignee <name>.
+
+Examples:
+  bd rebalance --suggest
+  bd rebalance --suggest --people carol,dave --label backend`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := provider.Get()
+			if err != nil {
+				return err
+			}
+			if !suggest {
+				return fmt.Errorf("bd rebalance only proposes moves: pass --suggest (apply them with bd update <id> --assignee <name>)")
+			}
+			issues, err := workloadIssues(cmd.Context(), app, labels)
+			if err != nil {
+				return err
+			}
+			result := suggestRebalance(issues, people, awayPeople(app, app.Now()))
+
+			if app.JSON {
+				return json.NewEncoder(app.Out).Encode(result)
+			}
+			if len(result.Before)-len(result.Away) < 2 && len(result.Moves) == 0 {
+				fmt.Fprintln(app.Out, "Need at least two available people to rebalance (add more with --people).")
+				return nil
+			}
+			if len(result.Moves) == 0 {
+				fmt.Fprintln(app.Out, "Workload is already balanced.")
+				return nil
+			}
+			for _, m := range result.Moves {
+				fmt.Fprintf(app.Out, "%s [P%d] %s\n    %s → %s\n", m.ID, m.Priority, m.Title, m.From, m.To)
+			}
+			fmt.Fprintln(app.Out, "\nActive issues before → after:")
+			for _, name := range sortedKeys(result.Before) {
+				line := fmt.Sprintf("  %s: %d → %d", name, result.Before[name], result.After[name])
+				if until, ok := result.Away[name]; ok {
+					line += " (away until " + until + ")"
+				}
+				fmt.Fprintln(app.Out, line)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&suggest, "suggest", false, "Print suggested moves without changing anything")
+	cmd.Flags().StringSliceVarP(&labels, "label", "l", nil, "Only consider issues with all of these labels")
+	cmd.Flags().StringSliceVar(&people, "people", nil, "Also consider these people, even with nothing assigned")
+
+	return cmd
+}
+
+// workloadIssues returns the non-closed, non-ephemeral issues carrying all
+// of labels.
+func workloadIssues(ctx context.Context, app *App, labels []string) ([]*issuestorage.Issue, error) {
+	found, err := app.Storage.List(ctx, &issuestorage.ListFilter{LabelsAll: labels})
+	if err != nil {
+		return nil, fmt.Errorf("listing issues: %w", err)
+	}
+	var issues []*issuestorage.Issue
+	for _, issue := range found {
+		if !issue.Ephemeral && issue.Status != issuestorage.StatusClosed {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+// isActiveWork reports whether an issue counts towards its assignee's load.
+func isActiveWork(issue *issuestorage.Issue) bool {
+	return issue.Status == issuestorage.StatusOpen || issue.Status == issuestorage.StatusInProgress
+}
+
+// buildWorkload tallies issues per assignee, busiest first and unassigned
+// last.
+func buildWorkload(issues []*issuestorage.Issue) []WorkloadJSON {
+	byName := make(map[string]*WorkloadJSON)
+	for _, issue := range issues {
+		name := issue.Assignee
+		if name == "" {
+			name = workloadUnassigned
+		}
+		row := byName[name]
+		if row == nil {
+			row = &WorkloadJSON{Assignee: name}
+			byName[name] = row
+		}
+		switch issue.Status {
+		case issuestorage.StatusOpen:
+			row.Open++
+		case issuestorage.StatusInProgress:
+			row.InProgress++
+		default:
+			row.Other++
+		}
+		if isActiveWork(issue) {
+			row.Active++
+		}
+		row.Total++
+		row.Estimate += issue.Estimate
+		row.Remaining += issue.RemainingEffort()
+	}
+
+	rows := []WorkloadJSON{}
+	for _, row := range byName {
+		rows = append(rows, *row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		ui, uj := rows[i].Assignee == workloadUnassigned, rows[j].Assignee == workloadUnassigned
+		if ui != uj {
+			return uj
+		}
+		if rows[i].Active != rows[j].Active {
+			return rows[i].Active > rows[j].Active
+		}
+		if rows[i].Total != rows[j].Total {
+			return rows[i].Total > rows[j].Total
+		}
+		return rows[i].Assignee < rows[j].Assignee
+	})
+	return rows
+}
+
+// suggestRebalance proposes moving open issues from the most to the least
+// loaded person, one at a time, until no move would narrow the gap. extra
+// names people to consider even if they have no active work. People in away
+// (mapped to the last day of their absence) are never targets and have all
+// their open issues moved first.
+func suggestRebalance(issues []*issuestorage.Issue, extra []string, away map[string]time.Time) RebalanceJSON {
+	load := make(map[string]int)
+	for _, name := range extra {
+		if name != "" {
+			load[name] = 0
+		}
+	}
+	movable := make(map[string][]*issuestorage.Issue)
+	for _, issue := range issues {
+		if issue.Assignee == "" || !isActiveWork(issue) {
+			continue
+		}
+		load[issue.Assignee]++
+		if issue.Status == issuestorage.StatusOpen {
+			movable[issue.Assignee] = append(movable[issue.Assignee], issue)
+		}
+	}
+	// Give away the least important, most recently created work first.
+	for _, list := range movable {
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].Priority != list[j].Priority {
+				return list[i].Priority > list[j].Priority
+			}
+			return list[i].CreatedAt.After(list[j].CreatedAt)
+		})
+	}
+
+	result := RebalanceJSON{Moves: []RebalanceMoveJSON{}, Before: make(map[string]int), After: load}
+	for name, n := range load {
+		result.Before[name] = n
+		if until, ok := away[name]; ok {
+			if result.Away == nil {
+				result.Away = make(map[string]string)
+			}
+			result.Away[name] = until.Format(oooDateLayout)
+		}
+	}
+	var available, absent []string
+	for _, name := range sortedKeys(load) {
+		if _, ok := away[name]; ok {
+			absent = append(absent, name)
+		} else {
+			available = append(available, name)
+		}
+	}
+	if len(available) == 0 {
+		return result
+	}
+	for {
+		// Least loaded available person, ties broken by name.
+		to := available[0]
+		for _, name := range available {
+			if load[name] < load[to] {
+				to = name
+			}
+		}
+		// Anyone away with work left to hand over, else the most loaded
+		// available person with something left to give.
+		from := ""
+		for _, name := range absent {
+			if len(movable[name]) > 0 {
+				from = name
+				break
+			}
+		}
+		if from == "" {
+			for _, name := range available {
+				if len(movable[name]) > 0 && (from == "" || load[name] > load[from]) {
+					from = name
+				}
+			}
+			if from == "" || load[from]-load[to] < 2 {
+				return result
+			}
+		}
+		issue := movable[from][0]
+		movable[from] = movable[from][1:]
+		load[from]--
+		load[to]++
+		result.Moves = append(result.Moves, RebalanceMoveJSON{
+			ID:       issue.ID,
+			Title:    issue.Title,
+			Priority: int(issue.Priority),
+			From:     from,
+			To:       to,
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// createAssigned creates an issue with the given assignee, status and
+// priority.
+func createAssigned(t *testing.T, store issuestorage.IssueStore, title, assignee string, status issuestorage.Status, priority issuestorage.Priority) string {
+	t.Helper()
+	id, err := store.Create(context.Background(), &issuestorage.Issue{
+		Title:    title,
+		Assignee: assignee,
+		Status:   status,
+		Priority: priority,
+		Type:     issuestorage.TypeTask,
+	})
+	if err != nil {
+		t.Fatalf("create %s: %v", title, err)
+	}
+	return id
+}
+
+func TestWorkloadCmd(t *testing.T) {
+	app, store := setupTestApp(t)
+	createAssigned(t, store, "a1", "alice", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	createAssigned(t, store, "a2", "alice", issuestorage.StatusInProgress, issuestorage.PriorityMedium)
+	createAssigned(t, store, "a3", "alice", issuestorage.StatusBlocked, issuestorage.PriorityMedium)
+	createAssigned(t, store, "b1", "bob", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	createAssigned(t, store, "u1", "", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	done := createAssigned(t, store, "b2", "bob", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+	if err := store.Modify(context.Background(), done, func(i *issuestorage.Issue) error {
+		i.Status = issuestorage.StatusClosed
+		return nil
+	}); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	app.JSON = true
+	cmd := newWorkloadCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("workload: %v", err)
+	}
+	var rows []WorkloadJSON
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &rows); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	want := []WorkloadJSON{
+		{Assignee: "alice", Open: 1, InProgress: 1, Other: 1, Active: 2, Total: 3},
+		{Assignee: "bob", Open: 1, Active: 1, Total: 1},
+		{Assignee: workloadUnassigned, Open: 1, Active: 1, Total: 1},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("rows = %+v, want %+v", rows, want)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestRebalanceCmd_Suggest(t *testing.T) {
+	app, store := setupTestApp(t)
+	createAssigned(t, store, "a-started", "alice", issuestorage.StatusInProgress, issuestorage.PriorityLow)
+	createAssigned(t, store, "a-urgent", "alice", issuestorage.StatusOpen, issuestorage.PriorityHigh)
+	lowID := createAssigned(t, store, "a-low", "alice", issuestorage.StatusOpen, issuestorage.PriorityLow)
+	backlogID := createAssigned(t, store, "a-backlog", "alice", issuestorage.StatusOpen, issuestorage.PriorityBacklog)
+	createAssigned(t, store, "b1", "bob", issuestorage.StatusOpen, issuestorage.PriorityMedium)
+
+	app.JSON = true
+	cmd := newRebalanceCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{"--suggest", "--people", "carol"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("rebalance: %v", err)
+	}
+	var got RebalanceJSON
+	if err := json.Unmarshal(app.Out.(*bytes.Buffer).Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	// alice has 4 active, bob 1, carol 0: the two least important unstarted
+	// issues go to carol, then bob.
+	if len(got.Moves) != 2 {
+		t.Fatalf("moves = %+v, want 2", got.Moves)
+	}
+	if m := got.Moves[0]; m.ID != backlogID || m.From != "alice" || m.To != "carol" {
+		t.Errorf("first move = %+v, want %s alice → carol", m, backlogID)
+	}
+	if m := got.Moves[1]; m.ID != lowID || m.To != "bob" {
+		t.Errorf("second move = %+v, want %s alice → bob", m, lowID)
+	}
+	if got.Before["alice"] != 4 || got.After["alice"] != 2 {
+		t.Errorf("alice load %d → %d, want 4 → 2", got.Before["alice"], got.After["alice"])
+	}
+
+	// Nothing changed in storage.
+	issue, _ := store.Get(context.Background(), backlogID)
+	if issue.Assignee != "alice" {
+		t.Errorf("assignee = %q, want alice (suggest must not apply)", issue.Assignee)
+	}
+}
+
+func TestRebalanceCmd_RequiresSuggest(t *testing.T) {
+	app, _ := setupTestApp(t)
+	cmd := newRebalanceCmd(NewTestProvider(app))
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--suggest") {
+		t.Fatalf("err = %v, want mention of --suggest", err)
+	}
+}
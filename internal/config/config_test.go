@@ -194,6 +194,24 @@ func TestValidate_GraphAutoCloseParent(t *testing.T) {
 	}
 }
 
+func TestValidate_GraphParanoid(t *testing.T) {
+	for _, val := range []string{"off", "warn", "fail"} {
+		s := &memStore{data: map[string]string{
+			"graph.paranoid": val,
+		}}
+		if err := Validate(s); err != nil {
+			t.Errorf("Validate should accept graph.paranoid=%q: %v", val, err)
+		}
+	}
+
+	s := &memStore{data: map[string]string{
+		"graph.paranoid": "true",
+	}}
+	if err := Validate(s); err == nil {
+		t.Error("Validate should reject graph.paranoid=true")
+	}
+}
+
 // memStore is a simple in-memory Store for testing.
 type memStore struct {
 	data map[string]string
@@ -4,15 +4,23 @@ import "os"
 
 // Environment variable names for beads-lite configuration.
 const (
-	EnvBeadsDir = "BEADS_DIR"  // Path to .beads directory
-	EnvActor    = "BD_ACTOR"   // Override actor name
-	EnvH2Actor  = "H2_ACTOR"   // Alternate actor name from h2 runtime
-	EnvProject  = "BD_PROJECT" // Override project name
-	EnvJSON     = "BD_JSON"    // Enable JSON output ("1" or "true")
-	EnvQuiet    = "BD_QUIET"   // Suppress non-error output ("1" or "true")
+	EnvBeadsDir    = "BEADS_DIR"       // Path to .beads directory
+	EnvActor       = "BD_ACTOR"        // Override actor name
+	EnvH2Actor     = "H2_ACTOR"        // Alternate actor name from h2 runtime
+	EnvProject     = "BD_PROJECT"      // Override project name
+	EnvContext     = "BD_CONTEXT"      // Override the active filter context
+	EnvJSON        = "BD_JSON"         // Enable JSON output ("1" or "true")
+	EnvQuiet       = "BD_QUIET"        // Suppress non-error output ("1" or "true")
+	EnvSeed        = "BD_SEED"         // Seed for deterministic IDs and timestamps (implies --deterministic)
+	EnvNoDaemon    = "BD_NO_DAEMON"    // Run commands in-process even when a daemon is running ("1" or "true")
+	EnvLockTimeout = "BD_LOCK_TIMEOUT" // How long writes wait for maintenance to end (e.g. "30s")
+	EnvCompat      = "BD_COMPAT"       // Major version a script expects, suppressing its legacy warnings (e.g. "1")
+
+	EnvPostgresDSN    = "BD_POSTGRES_DSN"    // Postgres connection string, kept out of committed config
+	EnvStorageMetrics = "BD_STORAGE_METRICS" // Print storage operation timings on exit ("1" or "true")
 )
 
-// ApplyEnvOverrides checks actor/project env vars
+// ApplyEnvOverrides checks actor/project/context/storage env vars
 // and overrides the corresponding config values in memory.
 // These overrides are not persisted to the config file.
 func ApplyEnvOverrides(s Store) {
@@ -24,4 +32,10 @@ func ApplyEnvOverrides(s Store) {
 	if project := os.Getenv(EnvProject); project != "" {
 		s.SetInMemory("project.name", project)
 	}
+	if name := os.Getenv(EnvContext); name != "" {
+		s.SetInMemory("context.active", name)
+	}
+	if dsn := os.Getenv(EnvPostgresDSN); dsn != "" {
+		s.SetInMemory("storage.postgres.dsn", dsn)
+	}
 }
@@ -50,6 +50,17 @@ func TestApplyEnvOverrides_Project(t *testing.T) {
 	}
 }
 
+func TestApplyEnvOverrides_PostgresDSN(t *testing.T) {
+	t.Setenv(EnvPostgresDSN, "postgres://bd@db/issues")
+
+	s := &memStore{data: map[string]string{}}
+	ApplyEnvOverrides(s)
+
+	if v, _ := s.Get("storage.postgres.dsn"); v != "postgres://bd@db/issues" {
+		t.Errorf("storage.postgres.dsn = %q, want %q", v, "postgres://bd@db/issues")
+	}
+}
+
 func TestApplyEnvOverrides_NoOverride(t *testing.T) {
 	t.Setenv(EnvActor, "")
 	t.Setenv(EnvProject, "")
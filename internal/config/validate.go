@@ -17,6 +17,7 @@ var validValues = map[string][]string{
 	"hierarchy.max_depth":           {},
 	"graph.cascade_parent_blocking": {"true", "false"},
 	"graph.auto_close_parent":       {"true", "false"},
+	"graph.paranoid":                {"off", "warn", "fail"},
 	"types.custom":                  {},
 	"status.custom":                 {},
 }
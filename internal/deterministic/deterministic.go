@@ -0,0 +1,107 @@
+// Package deterministic derives timestamps and ID randomness from a seed,
+// so the same sequence of bd commands against a fresh store produces
+// byte-identical issues and output. It backs bd --deterministic and
+// BD_SEED, used for demos and golden-file tests.
+package deterministic
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Epoch is the time the fake clock starts from.
+var Epoch = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Tick is how far the fake clock advances on each reading, so every
+// timestamp is distinct and creation order is preserved by sorting on it.
+const Tick = time.Second
+
+// StateFile is the file under the .beads directory that carries the
+// sequence position from one bd invocation to the next.
+const StateFile = "deterministic.json"
+
+// State is a Source's position in its sequence.
+type State struct {
+	Seed  int64 `json:"seed"`
+	Ticks int64 `json:"ticks"` // clock readings taken
+	Draws int64 `json:"draws"` // random blocks consumed
+}
+
+// Source is a fake clock and a seeded random reader. It is safe for
+// concurrent use.
+type Source struct {
+	mu    sync.Mutex
+	state State
+}
+
+// New returns a Source at the start of seed's sequence.
+func New(seed int64) *Source {
+	return &Source{state: State{Seed: seed}}
+}
+
+// Now returns the next fake time: Epoch plus one Tick per earlier reading.
+func (s *Source) Now() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := Epoch.Add(time.Duration(s.state.Ticks) * Tick)
+	s.state.Ticks++
+	return t
+}
+
+// Read fills p with bytes derived from the seed and the number of blocks
+// already consumed. It never fails.
+func (s *Source) Read(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var in [16]byte
+	binary.BigEndian.PutUint64(in[:8], uint64(s.state.Seed))
+	for n := 0; n < len(p); {
+		binary.BigEndian.PutUint64(in[8:], uint64(s.state.Draws))
+		s.state.Draws++
+		block := sha256.Sum256(in[:])
+		n += copy(p[n:], block[:])
+	}
+	return len(p), nil
+}
+
+// State returns the source's current position.
+func (s *Source) State() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+// Load resumes seed's sequence from the state saved at path. A missing
+// file, or one saved for a different seed, starts the sequence over.
+func Load(path string, seed int64) (*Source, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return New(seed), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if state.Seed != seed {
+		return New(seed), nil
+	}
+	return &Source{state: state}, nil
+}
+
+// Save writes the source's position to path for the next Load.
+func (s *Source) Save(path string) error {
+	data, err := json.MarshalIndent(s.State(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
@@ -0,0 +1,143 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// CriticalPath returns the longest chain of open blockers ending at issue:
+// the first element waits on nothing open, each next one is blocked by the
+// one before it, and the last is issue itself. Blockers are those of
+// EffectiveBlockers, so with cascade an ancestor's blockers count too.
+// Where several chains are equally long, the one through the lowest
+// blocker IDs is returned. Dependency cycles are not followed around.
+func CriticalPath(
+	ctx context.Context,
+	store issuestorage.IssueGetter,
+	issue *issuestorage.Issue,
+	closedSet map[string]bool,
+	cascade bool,
+) ([]*issuestorage.Issue, error) {
+	longest := make(map[string][]*issuestorage.Issue)
+	visiting := make(map[string]bool)
+
+	var walk func(issue *issuestorage.Issue) ([]*issuestorage.Issue, error)
+	walk = func(issue *issuestorage.Issue) ([]*issuestorage.Issue, error) {
+		if path, ok := longest[issue.ID]; ok {
+			return path, nil
+		}
+		visiting[issue.ID] = true
+		defer delete(visiting, issue.ID)
+
+		blockers, err := EffectiveBlockers(ctx, store, issue, closedSet, cascade)
+		if err != nil {
+			return nil, fmt.Errorf("resolve blockers of %s: %w", issue.ID, err)
+		}
+		ids := blockers.AllBlockerIDs()
+		sort.Strings(ids)
+
+		var best []*issuestorage.Issue
+		for _, id := range ids {
+			if visiting[id] {
+				continue
+			}
+			blocker, err := store.Get(ctx, id)
+			if err != nil {
+				continue
+			}
+			path, err := walk(blocker)
+			if err != nil {
+				return nil, err
+			}
+			if len(path) > len(best) {
+				best = path
+			}
+		}
+		path := append(append([]*issuestorage.Issue(nil), best...), issue)
+		longest[issue.ID] = path
+		return path, nil
+	}
+	return walk(issue)
+}
+
+// Impacted is an open issue blocked, directly or transitively, by the
+// issue passed to Impact.
+type Impacted struct {
+	Issue *issuestorage.Issue
+	Depth int // blocking links between it and the blocker; 1 if blocked directly
+}
+
+// Impact returns every open issue that cannot become ready before issue
+// closes: the open issues issue blocks, those they block in turn, and with
+// cascade the descendants of each, which inherit their blockers. Results
+// are ordered by depth, then ID.
+func Impact(
+	ctx context.Context,
+	store issuestorage.IssueGetter,
+	issue *issuestorage.Issue,
+	closedSet map[string]bool,
+	cascade bool,
+) ([]Impacted, error) {
+	depth := map[string]int{issue.ID: 0}
+	found := make(map[string]*issuestorage.Issue)
+
+	// Children sit at their parent's depth, so they go to the front of
+	// the queue and every issue is reached first by its shortest route.
+	queue := []Impacted{{Issue: issue}}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if depth[cur.Issue.ID] < cur.Depth {
+			continue // reached by a shorter route since it was queued
+		}
+
+		type link struct {
+			id    string
+			depth int
+		}
+		var links []link
+		blocksType := issuestorage.DepTypeBlocks
+		for _, id := range cur.Issue.DependentIDs(&blocksType) {
+			links = append(links, link{id, cur.Depth + 1})
+		}
+		if cascade && cur.Issue.ID != issue.ID {
+			for _, id := range cur.Issue.Children() {
+				links = append(links, link{id, cur.Depth})
+			}
+		}
+		for _, l := range links {
+			if d, seen := depth[l.id]; seen && d <= l.depth || closedSet[l.id] {
+				continue
+			}
+			blocked, err := store.Get(ctx, l.id)
+			if err != nil || blocked.Status == issuestorage.StatusClosed || blocked.Status == issuestorage.StatusTombstone {
+				continue
+			}
+			depth[blocked.ID] = l.depth
+			found[blocked.ID] = blocked
+			if l.depth == cur.Depth {
+				queue = append([]Impacted{{Issue: blocked, Depth: l.depth}}, queue...)
+			} else {
+				queue = append(queue, Impacted{Issue: blocked, Depth: l.depth})
+			}
+		}
+	}
+
+	var impacted []Impacted
+	for id, d := range depth {
+		if id != issue.ID {
+			impacted = append(impacted, Impacted{Issue: found[id], Depth: d})
+		}
+	}
+	sort.Slice(impacted, func(i, j int) bool {
+		a, b := impacted[i], impacted[j]
+		if a.Depth != b.Depth {
+			return a.Depth < b.Depth
+		}
+		return a.Issue.ID < b.Issue.ID
+	})
+	return impacted, nil
+}
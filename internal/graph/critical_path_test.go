@@ -0,0 +1,110 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestCriticalPathAndImpact(t *testing.T) {
+	ctx := context.Background()
+	s := newStore(t)
+
+	_, children := buildMolecule(t, ctx, s, "Root", []string{"A", "B", "C", "D", "E"},
+		map[string][]string{
+			"B": {"A"},
+			"C": {"B"},
+			"D": {"A"},
+			"E": {"C", "D"},
+		})
+	byTitle := make(map[string]*issuestorage.Issue)
+	titles := make(map[string]string)
+	for _, c := range children {
+		byTitle[c.Title] = c
+		titles[c.ID] = c.Title
+	}
+
+	// An epic blocked by D passes D on to its child when cascading.
+	later := createIssue(t, ctx, s, "Later", issuestorage.TypeEpic)
+	step := createIssue(t, ctx, s, "Step", issuestorage.TypeTask)
+	titles[later.ID], titles[step.ID] = "Later", "Step"
+	if err := s.AddDependency(ctx, step.ID, later.ID, issuestorage.DepTypeParentChild); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddDependency(ctx, later.ID, byTitle["D"].ID, issuestorage.DepTypeBlocks); err != nil {
+		t.Fatal(err)
+	}
+	get := func(id string) *issuestorage.Issue {
+		t.Helper()
+		issue, err := s.Get(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return issue
+	}
+
+	path := func(id string, closedSet map[string]bool, cascade bool) []string {
+		t.Helper()
+		issues, err := CriticalPath(ctx, s, get(id), closedSet, cascade)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, issue := range issues {
+			got = append(got, titles[issue.ID])
+		}
+		return got
+	}
+	if got, want := path(byTitle["E"].ID, nil, true), []string{"A", "B", "C", "E"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("path to E = %v, want %v", got, want)
+	}
+	if got, want := path(byTitle["E"].ID, map[string]bool{byTitle["B"].ID: true}, true), []string{"A", "D", "E"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("path to E with B closed = %v, want %v", got, want)
+	}
+	if got, want := path(step.ID, nil, true), []string{"A", "D", "Step"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("path to Step = %v, want %v", got, want)
+	}
+	if got, want := path(step.ID, nil, false), []string{"Step"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("path to Step without cascade = %v, want %v", got, want)
+	}
+
+	impact := func(cascade bool) []string {
+		t.Helper()
+		impacted, err := Impact(ctx, s, get(byTitle["A"].ID), nil, cascade)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, i := range impacted {
+			got = append(got, fmt.Sprintf("%s@%d", titles[i.Issue.ID], i.Depth))
+		}
+		return got
+	}
+	if got, want := impact(true), []string{"B@1", "D@1", "C@2", "E@2", "Later@2", "Step@2"}; len(got) != len(want) || !equalUnordered(got[:2], want[:2]) || !equalUnordered(got[2:], want[2:]) {
+		t.Errorf("impact of A = %v, want %v", got, want)
+	}
+	if got := impact(false); len(got) != 5 {
+		t.Errorf("impact of A without cascade = %v, want Step left out", got)
+	}
+}
+
+// equalUnordered reports whether a and b hold the same strings.
+func equalUnordered(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	count := make(map[string]int)
+	for _, s := range a {
+		count[s]++
+	}
+	for _, s := range b {
+		if count[s] == 0 {
+			return false
+		}
+		count[s]--
+	}
+	return true
+}
@@ -230,6 +230,21 @@ func TestEffectiveBlockers_GrandparentInheritance(t *testing.T) {
 	}
 }
 
+// TestEffectiveBlockers_MissingAncestor tests that an ancestor that cannot
+// be loaded fails the call rather than leaving the issue unblocked.
+func TestEffectiveBlockers_MissingAncestor(t *testing.T) {
+	ctx := context.Background()
+	s := newStore(t)
+
+	orphan := &issuestorage.Issue{ID: "bd-orphan", Parent: "bd-gone"}
+	if _, err := EffectiveBlockers(ctx, s, orphan, map[string]bool{}, true); err == nil {
+		t.Error("expected an error for a parent that does not exist")
+	}
+	if _, err := EffectiveBlockers(ctx, s, orphan, map[string]bool{}, false); err != nil {
+		t.Errorf("without cascade the parent is not read: %v", err)
+	}
+}
+
 // TestEffectiveBlockers_DirectAndInherited tests that both direct and inherited
 // blockers are returned together.
 func TestEffectiveBlockers_DirectAndInherited(t *testing.T) {
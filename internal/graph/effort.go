@@ -0,0 +1,74 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// Effort totals the time estimates and logged time of a set of issues, in
+// minutes. Closed issues add their estimate and logged time but nothing
+// to Remaining.
+type Effort struct {
+	Issues      int // issues counted
+	Estimate    int // sum of estimates
+	Spent       int // sum of logged time
+	Remaining   int // sum of RemainingEffort
+	Unestimated int // unclosed issues without an estimate
+}
+
+// Add counts issue. Tombstoned issues are skipped.
+func (e *Effort) Add(issue *issuestorage.Issue) {
+	if issue.Status == issuestorage.StatusTombstone {
+		return
+	}
+	e.Issues++
+	e.Estimate += issue.Estimate
+	e.Spent += issue.TimeSpent
+	e.Remaining += issue.RemainingEffort()
+	if issue.Estimate == 0 && issue.Status != issuestorage.StatusClosed {
+		e.Unestimated++
+	}
+}
+
+// Any reports whether any counted issue has an estimate or logged time.
+func (e Effort) Any() bool {
+	return e.Estimate > 0 || e.Spent > 0
+}
+
+// ComputeEffort totals the effort needed to finish issue: issue itself,
+// its descendants, and the issues any of them depend on through blocks or
+// tracks edges, transitively. Each issue is counted once; missing issues
+// are skipped.
+func ComputeEffort(ctx context.Context, store issuestorage.IssueGetter, issue *issuestorage.Issue) (Effort, error) {
+	var e Effort
+	seen := map[string]bool{issue.ID: true}
+	queue := []*issuestorage.Issue{issue}
+	blocks, tracks := issuestorage.DepTypeBlocks, issuestorage.DepTypeTracks
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		e.Add(cur)
+
+		next := cur.Children()
+		next = append(next, cur.DependencyIDs(&blocks)...)
+		next = append(next, cur.DependencyIDs(&tracks)...)
+		for _, id := range next {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			covered, err := store.Get(ctx, id)
+			if errors.Is(err, issuestorage.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return e, fmt.Errorf("get %s: %w", id, err)
+			}
+			queue = append(queue, covered)
+		}
+	}
+	return e, nil
+}
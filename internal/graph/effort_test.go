@@ -0,0 +1,57 @@
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestComputeEffort(t *testing.T) {
+	ctx := context.Background()
+	s := newStore(t)
+
+	epic, children := buildMolecule(t, ctx, s, "Epic", []string{"Design", "Build", "Polish"}, map[string][]string{"Build": {"Design"}})
+	design, build, polish := children[0], children[1], children[2]
+	outside := createIssue(t, ctx, s, "Vendor API", issuestorage.TypeTask)
+	grandchild := createIssue(t, ctx, s, "Build step", issuestorage.TypeTask)
+	if err := s.AddDependency(ctx, build.ID, outside.ID, issuestorage.DepTypeBlocks); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddDependency(ctx, grandchild.ID, build.ID, issuestorage.DepTypeParentChild); err != nil {
+		t.Fatal(err)
+	}
+
+	set := func(id string, estimate, spent int, status issuestorage.Status) {
+		t.Helper()
+		if err := s.Modify(ctx, id, func(i *issuestorage.Issue) error {
+			i.Estimate, i.TimeSpent, i.Status = estimate, spent, status
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	set(design.ID, 120, 150, issuestorage.StatusClosed)
+	set(build.ID, 480, 60, issuestorage.StatusInProgress)
+	set(grandchild.ID, 60, 90, issuestorage.StatusOpen) // over its estimate
+	set(outside.ID, 240, 0, issuestorage.StatusOpen)
+	// Polish has no estimate.
+
+	e, _ := s.Get(ctx, epic.ID)
+	got, err := ComputeEffort(ctx, s, e)
+	if err != nil {
+		t.Fatalf("ComputeEffort: %v", err)
+	}
+	want := Effort{Issues: 6, Estimate: 900, Spent: 300, Remaining: 420 + 240, Unestimated: 2}
+	if got != want {
+		t.Errorf("epic effort = %+v, want %+v", got, want)
+	}
+	if !got.Any() {
+		t.Error("Any() = false with estimates set")
+	}
+
+	p, _ := s.Get(ctx, polish.ID)
+	if got, _ := ComputeEffort(ctx, s, p); got.Any() || got.Unestimated != 1 {
+		t.Errorf("unestimated leaf effort = %+v", got)
+	}
+}
@@ -0,0 +1,51 @@
+package graph
+
+import (
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// Period is one bucket of a flow series: the issues opened and closed
+// during it, and how many were open at its end.
+type Period struct {
+	Start  time.Time // midnight of the first day
+	Opened int
+	Closed int
+	Open   int
+}
+
+// Flow buckets issues' CreatedAt and ClosedAt into periods of days
+// calendar days each, in to's location, covering from through to. With
+// days of 7 the periods are weeks starting on Monday. Tombstoned issues
+// are left out; reopened issues count as open from the day they were
+// created, since only the last close is recorded.
+func Flow(issues []*issuestorage.Issue, from, to time.Time, days int) []Period {
+	loc := to.Location()
+	start := startOfDay(from, loc)
+	if days == 7 {
+		start = start.AddDate(0, 0, -(int(start.Weekday())+6)%7)
+	}
+	var periods []Period
+	for ; !start.After(to); start = start.AddDate(0, 0, days) {
+		end := start.AddDate(0, 0, days)
+		p := Period{Start: start}
+		for _, issue := range issues {
+			if issue.Status == issuestorage.StatusTombstone || !issue.CreatedAt.Before(end) {
+				continue
+			}
+			if !issue.CreatedAt.Before(start) {
+				p.Opened++
+			}
+			if issue.Status == issuestorage.StatusClosed && issue.ClosedAt != nil && issue.ClosedAt.Before(end) {
+				if !issue.ClosedAt.Before(start) {
+					p.Closed++
+				}
+				continue
+			}
+			p.Open++
+		}
+		periods = append(periods, p)
+	}
+	return periods
+}
@@ -0,0 +1,48 @@
+package graph
+
+import (
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestFlow(t *testing.T) {
+	// 2026-10-05 is a Monday.
+	at := func(d, h int) time.Time { return time.Date(2026, 10, d, h, 0, 0, 0, time.UTC) }
+	closedAt := func(d, h int) *time.Time { t := at(d, h); return &t }
+	issues := []*issuestorage.Issue{
+		{ID: "old", Status: issuestorage.StatusOpen, CreatedAt: at(1, 9)},
+		{ID: "fixed", Status: issuestorage.StatusClosed, CreatedAt: at(5, 9), ClosedAt: closedAt(6, 17)},
+		{ID: "new", Status: issuestorage.StatusInProgress, CreatedAt: at(6, 10)},
+		{ID: "late", Status: issuestorage.StatusClosed, CreatedAt: at(2, 9), ClosedAt: closedAt(13, 9)},
+		{ID: "deleted", Status: issuestorage.StatusTombstone, CreatedAt: at(5, 9)},
+	}
+
+	daily := Flow(issues, at(5, 12), at(7, 8), 1)
+	want := []Period{
+		{Start: at(5, 0), Opened: 1, Closed: 0, Open: 3},
+		{Start: at(6, 0), Opened: 1, Closed: 1, Open: 3},
+		{Start: at(7, 0), Opened: 0, Closed: 0, Open: 3},
+	}
+	if len(daily) != len(want) {
+		t.Fatalf("got %d days, want %d", len(daily), len(want))
+	}
+	for i, w := range want {
+		if d := daily[i]; !d.Start.Equal(w.Start) || d.Opened != w.Opened || d.Closed != w.Closed || d.Open != w.Open {
+			t.Errorf("day %d = %+v, want %+v", i, d, w)
+		}
+	}
+
+	// Weeks start on Monday, so Wednesday 1st falls in the week of 28 Sep.
+	weekly := Flow(issues, at(1, 0), at(14, 0), 7)
+	if len(weekly) != 3 || !weekly[0].Start.Equal(time.Date(2026, 9, 28, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("weekly = %+v", weekly)
+	}
+	if w := weekly[1]; w.Opened != 2 || w.Closed != 1 || w.Open != 3 {
+		t.Errorf("week of 5 Oct = %+v", w)
+	}
+	if w := weekly[2]; w.Opened != 0 || w.Closed != 1 || w.Open != 2 {
+		t.Errorf("week of 12 Oct = %+v", w)
+	}
+}
@@ -0,0 +1,250 @@
+package graph
+
+import (
+	"container/heap"
+	"slices"
+	"sort"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// Plan is an execution order for a set of issues.
+type Plan struct {
+	// Steps are waves of issues that can be worked in parallel: each
+	// issue's blockers within the set are all in earlier steps. Issues in a
+	// step are ordered by priority, then ID.
+	Steps [][]*issuestorage.Issue
+	// Cycles are groups of issues that block each other, directly or
+	// through one another, so none of them can start.
+	Cycles [][]*issuestorage.Issue
+	// Stuck are the issues left out of Steps because something they wait
+	// on, directly or transitively, is in a cycle.
+	Stuck []*issuestorage.Issue
+}
+
+// PlanOrder lays issues out like TopologicalWaves, but instead of failing
+// on a dependency cycle it reports the cycles and what waits on them, and
+// orders the rest. Only blocks dependencies between the issues count.
+func PlanOrder(issues []*issuestorage.Issue) Plan {
+	byID, inDegree, outEdges := blockEdges(issues, nil)
+
+	var plan Plan
+	var step []*issuestorage.Issue
+	for _, issue := range issues {
+		if inDegree[issue.ID] == 0 {
+			step = append(step, issue)
+		}
+	}
+	placed := make(map[string]bool, len(issues))
+	for len(step) > 0 {
+		sortByPriority(step)
+		plan.Steps = append(plan.Steps, step)
+		var next []*issuestorage.Issue
+		for _, issue := range step {
+			placed[issue.ID] = true
+			for _, id := range outEdges[issue.ID] {
+				inDegree[id]--
+				if inDegree[id] == 0 {
+					next = append(next, byID[id])
+				}
+			}
+		}
+		step = next
+	}
+	plan.Cycles, plan.Stuck = unplaced(issues, placed, outEdges, byID)
+	return plan
+}
+
+// Queue is a single-file work order for a set of issues.
+type Queue struct {
+	// Order lists the issues so that each comes after its blockers within
+	// the set.
+	Order []*issuestorage.Issue
+	// Cycles and Stuck are the issues left out of Order, as in Plan.
+	Cycles [][]*issuestorage.Issue
+	Stuck  []*issuestorage.Issue
+}
+
+// QueueOrder orders issues one after another, each after its blockers in
+// the set. Of the issues whose blockers are already placed, the most
+// urgent goes next, then the oldest, then by ID. Like PlanOrder, it
+// reports cycles and what waits on them instead of failing.
+//
+// blockers, as from issuestorage.ResolveBlockers, give what each issue
+// waits on, so blockers inherited from ancestors count; when nil, only
+// each issue's own blocks dependencies do. Either way only blockers in
+// the set order it.
+func QueueOrder(issues []*issuestorage.Issue, blockers map[string]*issuestorage.Blockers) Queue {
+	byID, inDegree, outEdges := blockEdges(issues, blockers)
+
+	var q Queue
+	available := &issueHeap{}
+	for _, issue := range issues {
+		if inDegree[issue.ID] == 0 {
+			heap.Push(available, issue)
+		}
+	}
+	placed := make(map[string]bool, len(issues))
+	for available.Len() > 0 {
+		issue := heap.Pop(available).(*issuestorage.Issue)
+		q.Order = append(q.Order, issue)
+		placed[issue.ID] = true
+		for _, id := range outEdges[issue.ID] {
+			inDegree[id]--
+			if inDegree[id] == 0 {
+				heap.Push(available, byID[id])
+			}
+		}
+	}
+	q.Cycles, q.Stuck = unplaced(issues, placed, outEdges, byID)
+	return q
+}
+
+// issueHeap is a heap of issues, the most urgent, then oldest, first.
+type issueHeap []*issuestorage.Issue
+
+func (h issueHeap) Len() int { return len(h) }
+func (h issueHeap) Less(i, j int) bool {
+	a, b := h[i], h[j]
+	if a.Priority != b.Priority {
+		return a.Priority < b.Priority
+	}
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	return a.ID < b.ID
+}
+func (h issueHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *issueHeap) Push(x any)   { *h = append(*h, x.(*issuestorage.Issue)) }
+func (h *issueHeap) Pop() any {
+	old := *h
+	issue := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return issue
+}
+
+// blockEdges indexes the blockers between issues: how many blockers each
+// issue has in the set, and which issues each one blocks. Blockers are
+// taken from blockers, or each issue's own blocks dependencies when nil.
+func blockEdges(issues []*issuestorage.Issue, blockers map[string]*issuestorage.Blockers) (byID map[string]*issuestorage.Issue, inDegree map[string]int, outEdges map[string][]string) {
+	byID = make(map[string]*issuestorage.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	blocks := issuestorage.DepTypeBlocks
+	inDegree = make(map[string]int, len(issues))
+	outEdges = make(map[string][]string, len(issues))
+	for _, issue := range issues {
+		blockerIDs := issue.DependencyIDs(&blocks)
+		if blockers != nil {
+			blockerIDs = nil
+			if b := blockers[issue.ID]; b != nil {
+				blockerIDs = b.AllBlockerIDs()
+			}
+		}
+		for _, depID := range blockerIDs {
+			if _, ok := byID[depID]; ok {
+				outEdges[depID] = append(outEdges[depID], issue.ID)
+				inDegree[issue.ID]++
+			}
+		}
+	}
+	return byID, inDegree, outEdges
+}
+
+// unplaced sorts the issues an ordering could not place into cycles, each
+// ordered by ID, and the issues stuck behind them, ordered by ID.
+func unplaced(issues []*issuestorage.Issue, placed map[string]bool, outEdges map[string][]string, byID map[string]*issuestorage.Issue) (cycles [][]*issuestorage.Issue, stuck []*issuestorage.Issue) {
+	if len(placed) == len(issues) {
+		return nil, nil
+	}
+	var left []*issuestorage.Issue
+	for _, issue := range issues {
+		if !placed[issue.ID] {
+			left = append(left, issue)
+		}
+	}
+	inCycle := make(map[string]bool)
+	for _, scc := range stronglyConnected(left, outEdges, byID) {
+		if len(scc) == 1 && !slices.Contains(outEdges[scc[0].ID], scc[0].ID) {
+			continue
+		}
+		sort.Slice(scc, func(i, j int) bool { return scc[i].ID < scc[j].ID })
+		cycles = append(cycles, scc)
+		for _, issue := range scc {
+			inCycle[issue.ID] = true
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0].ID < cycles[j][0].ID })
+	for _, issue := range left {
+		if !inCycle[issue.ID] {
+			stuck = append(stuck, issue)
+		}
+	}
+	sort.Slice(stuck, func(i, j int) bool { return stuck[i].ID < stuck[j].ID })
+	return cycles, stuck
+}
+
+// sortByPriority orders issues by priority, most urgent first, then ID.
+func sortByPriority(issues []*issuestorage.Issue) {
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Priority != issues[j].Priority {
+			return issues[i].Priority < issues[j].Priority
+		}
+		return issues[i].ID < issues[j].ID
+	})
+}
+
+// stronglyConnected returns the strongly connected components of issues
+// along outEdges, using Tarjan's algorithm. Edges to issues outside the
+// set are ignored.
+func stronglyConnected(issues []*issuestorage.Issue, outEdges map[string][]string, byID map[string]*issuestorage.Issue) [][]*issuestorage.Issue {
+	inSet := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		inSet[issue.ID] = true
+	}
+	index := make(map[string]int, len(issues))
+	low := make(map[string]int, len(issues))
+	onStack := make(map[string]bool, len(issues))
+	var stack []string
+	var sccs [][]*issuestorage.Issue
+
+	var visit func(id string)
+	visit = func(id string) {
+		index[id] = len(index)
+		low[id] = index[id]
+		stack = append(stack, id)
+		onStack[id] = true
+		for _, next := range outEdges[id] {
+			if !inSet[next] {
+				continue
+			}
+			if _, seen := index[next]; !seen {
+				visit(next)
+				low[id] = min(low[id], low[next])
+			} else if onStack[next] {
+				low[id] = min(low[id], index[next])
+			}
+		}
+		if low[id] != index[id] {
+			return
+		}
+		var scc []*issuestorage.Issue
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			scc = append(scc, byID[top])
+			if top == id {
+				break
+			}
+		}
+		sccs = append(sccs, scc)
+	}
+	for _, issue := range issues {
+		if _, seen := index[issue.ID]; !seen {
+			visit(issue.ID)
+		}
+	}
+	return sccs
+}
@@ -0,0 +1,124 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestPlanOrder(t *testing.T) {
+	issue := func(id string, priority issuestorage.Priority, blockedBy ...string) *issuestorage.Issue {
+		i := &issuestorage.Issue{ID: id, Priority: priority}
+		for _, dep := range blockedBy {
+			i.Dependencies = append(i.Dependencies, issuestorage.Dependency{ID: dep, Type: issuestorage.DepTypeBlocks})
+		}
+		return i
+	}
+	ids := func(issues []*issuestorage.Issue) []string {
+		var out []string
+		for _, i := range issues {
+			out = append(out, i.ID)
+		}
+		return out
+	}
+
+	plan := PlanOrder([]*issuestorage.Issue{
+		issue("docs", 3),
+		issue("schema", 1),
+		issue("api", 2, "schema", "closed-elsewhere"),
+		issue("ui", 1, "schema"),
+		issue("ship", 0, "api", "ui", "docs"),
+	})
+	var steps [][]string
+	for _, s := range plan.Steps {
+		steps = append(steps, ids(s))
+	}
+	want := [][]string{{"schema", "docs"}, {"ui", "api"}, {"ship"}}
+	if !reflect.DeepEqual(steps, want) {
+		t.Errorf("steps = %v, want %v", steps, want)
+	}
+	if plan.Cycles != nil || plan.Stuck != nil {
+		t.Errorf("unexpected cycles %v or stuck %v", plan.Cycles, plan.Stuck)
+	}
+
+	// b and c block each other, d waits on c, e blocks itself.
+	plan = PlanOrder([]*issuestorage.Issue{
+		issue("a", 2),
+		issue("b", 2, "a", "c"),
+		issue("c", 2, "b"),
+		issue("d", 2, "c"),
+		issue("e", 2, "e"),
+	})
+	if len(plan.Steps) != 1 || !reflect.DeepEqual(ids(plan.Steps[0]), []string{"a"}) {
+		t.Errorf("steps = %v", plan.Steps)
+	}
+	var cycles [][]string
+	for _, c := range plan.Cycles {
+		cycles = append(cycles, ids(c))
+	}
+	if want := [][]string{{"b", "c"}, {"e"}}; !reflect.DeepEqual(cycles, want) {
+		t.Errorf("cycles = %v, want %v", cycles, want)
+	}
+	if got := ids(plan.Stuck); !reflect.DeepEqual(got, []string{"d"}) {
+		t.Errorf("stuck = %v, want [d]", got)
+	}
+}
+
+func TestQueueOrder(t *testing.T) {
+	start := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	n := 0
+	issue := func(id string, priority issuestorage.Priority, blockedBy ...string) *issuestorage.Issue {
+		n++
+		i := &issuestorage.Issue{ID: id, Priority: priority, CreatedAt: start.Add(time.Duration(n) * time.Hour)}
+		for _, dep := range blockedBy {
+			i.Dependencies = append(i.Dependencies, issuestorage.Dependency{ID: dep, Type: issuestorage.DepTypeBlocks})
+		}
+		return i
+	}
+	ids := func(issues []*issuestorage.Issue) []string {
+		var out []string
+		for _, i := range issues {
+			out = append(out, i.ID)
+		}
+		return out
+	}
+
+	// Urgent work waits on its blockers; ties go to the older issue.
+	q := QueueOrder([]*issuestorage.Issue{
+		issue("z-old", 2),
+		issue("ship", 0, "api"),
+		issue("api", 2, "closed-elsewhere"),
+		issue("a-new", 2),
+		issue("hotfix", 0),
+	}, nil)
+	if want := []string{"hotfix", "z-old", "api", "ship", "a-new"}; !reflect.DeepEqual(ids(q.Order), want) {
+		t.Errorf("order = %v, want %v", ids(q.Order), want)
+	}
+	if q.Cycles != nil || q.Stuck != nil {
+		t.Errorf("unexpected cycles %v or stuck %v", q.Cycles, q.Stuck)
+	}
+
+	q = QueueOrder([]*issuestorage.Issue{
+		issue("a", 2),
+		issue("b", 2, "a", "c"),
+		issue("c", 2, "b"),
+		issue("d", 2, "c"),
+	}, nil)
+	if !reflect.DeepEqual(ids(q.Order), []string{"a"}) || len(q.Cycles) != 1 ||
+		!reflect.DeepEqual(ids(q.Cycles[0]), []string{"b", "c"}) || !reflect.DeepEqual(ids(q.Stuck), []string{"d"}) {
+		t.Errorf("queue with a cycle = %v, cycles %v, stuck %v", ids(q.Order), q.Cycles, ids(q.Stuck))
+	}
+
+	// Given resolved blockers, a child waits on what blocks its parent.
+	q = QueueOrder([]*issuestorage.Issue{
+		issue("q", 3),
+		issue("p.1", 0),
+	}, map[string]*issuestorage.Blockers{
+		"p.1": {Inherited: []issuestorage.InheritedBlocker{{AncestorID: "p", BlockerID: "q"}}},
+	})
+	if want := []string{"q", "p.1"}; !reflect.DeepEqual(ids(q.Order), want) {
+		t.Errorf("order with an inherited blocker = %v, want %v", ids(q.Order), want)
+	}
+}
@@ -0,0 +1,79 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// Rollup summarizes the progress of the issues an epic covers: its direct
+// hierarchical children plus any issues it tracks via DepTypeTracks. An
+// issue that is both a child and tracked is counted once.
+type Rollup struct {
+	Total    int // distinct covered issues
+	Closed   int // covered issues that are closed
+	Children int // direct parent-child children
+	Tracked  int // issues covered only through a tracks edge
+}
+
+// TrackedIDs returns the IDs of issues that issue tracks (outgoing
+// DepTypeTracks dependencies).
+func TrackedIDs(issue *issuestorage.Issue) []string {
+	depType := issuestorage.DepTypeTracks
+	return issue.DependencyIDs(&depType)
+}
+
+// TrackedBy returns the IDs of issues that track issue (incoming
+// DepTypeTracks dependents).
+func TrackedBy(issue *issuestorage.Issue) []string {
+	depType := issuestorage.DepTypeTracks
+	return issue.DependentIDs(&depType)
+}
+
+// ComputeRollup counts the children and tracked issues of issue. Missing
+// and tombstoned issues are skipped.
+func ComputeRollup(ctx context.Context, store issuestorage.IssueGetter, issue *issuestorage.Issue) (Rollup, error) {
+	var r Rollup
+	seen := make(map[string]bool)
+
+	count := func(id string, tracked bool) error {
+		if seen[id] {
+			return nil
+		}
+		seen[id] = true
+		covered, err := store.Get(ctx, id)
+		if errors.Is(err, issuestorage.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("get %s: %w", id, err)
+		}
+		if covered.Status == issuestorage.StatusTombstone {
+			return nil
+		}
+		r.Total++
+		if tracked {
+			r.Tracked++
+		} else {
+			r.Children++
+		}
+		if covered.Status == issuestorage.StatusClosed {
+			r.Closed++
+		}
+		return nil
+	}
+
+	for _, id := range issue.Children() {
+		if err := count(id, false); err != nil {
+			return r, err
+		}
+	}
+	for _, id := range TrackedIDs(issue) {
+		if err := count(id, true); err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,54 @@
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestComputeRollup(t *testing.T) {
+	ctx := context.Background()
+	s := newStore(t)
+
+	epicA, children := buildMolecule(t, ctx, s, "Epic A", []string{"Child"}, nil)
+	epicB := createIssue(t, ctx, s, "Epic B", issuestorage.TypeEpic)
+	shared := createIssue(t, ctx, s, "Shared", issuestorage.TypeTask)
+
+	for _, edge := range [][2]string{{epicA.ID, shared.ID}, {epicB.ID, shared.ID}, {epicB.ID, children[0].ID}} {
+		if err := s.AddDependency(ctx, edge[0], edge[1], issuestorage.DepTypeTracks); err != nil {
+			t.Fatalf("AddDependency tracks %s->%s: %v", edge[0], edge[1], err)
+		}
+	}
+	if err := s.Modify(ctx, children[0].ID, func(i *issuestorage.Issue) error { i.Status = issuestorage.StatusClosed; return nil }); err != nil {
+		t.Fatalf("close child: %v", err)
+	}
+
+	a, _ := s.Get(ctx, epicA.ID)
+	got, err := ComputeRollup(ctx, s, a)
+	if err != nil {
+		t.Fatalf("ComputeRollup: %v", err)
+	}
+	if got != (Rollup{Total: 2, Closed: 1, Children: 1, Tracked: 1}) {
+		t.Errorf("epic A rollup = %+v", got)
+	}
+
+	b, _ := s.Get(ctx, epicB.ID)
+	got, err = ComputeRollup(ctx, s, b)
+	if err != nil {
+		t.Fatalf("ComputeRollup: %v", err)
+	}
+	// Epic B covers A's child through a tracks edge without becoming its parent.
+	if got != (Rollup{Total: 2, Closed: 1, Tracked: 2}) {
+		t.Errorf("epic B rollup = %+v", got)
+	}
+
+	sh, _ := s.Get(ctx, shared.ID)
+	if by := TrackedBy(sh); len(by) != 2 {
+		t.Errorf("TrackedBy(shared) = %v, want both epics", by)
+	}
+	child, _ := s.Get(ctx, children[0].ID)
+	if child.Parent != epicA.ID {
+		t.Errorf("tracked child parent = %q, want %s", child.Parent, epicA.ID)
+	}
+}
@@ -0,0 +1,72 @@
+package graph
+
+import (
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// Slot is an issue's place on a timeline, in whole days.
+type Slot struct {
+	Issue *issuestorage.Issue
+	Start time.Time // midnight of the first day
+	End   time.Time // midnight after the last day
+	Late  bool      // ends after the issue's due date
+}
+
+// Days returns how many days the slot spans.
+func (s Slot) Days() int {
+	return int(s.End.Sub(s.Start).Hours()/24 + 0.5)
+}
+
+// Timeline schedules issues, which must be in dependency order (see
+// TopologicalOrder), day by day from start. An open issue starts once the
+// issues blocking it within the set have ended, but no earlier than start
+// or its DeferUntil date, and lasts days(issue) days. A closed issue ends
+// on the day it was closed, however long before start that was. Days are
+// calendar days in start's location.
+func Timeline(ordered []*issuestorage.Issue, start time.Time, days func(*issuestorage.Issue) int) []Slot {
+	loc := start.Location()
+	first := startOfDay(start, loc)
+	ends := make(map[string]time.Time, len(ordered))
+	slots := make([]Slot, 0, len(ordered))
+	blocks := issuestorage.DepTypeBlocks
+	for _, issue := range ordered {
+		n := max(days(issue), 1)
+		var s Slot
+		if issue.Status == issuestorage.StatusClosed && issue.ClosedAt != nil {
+			s.End = startOfDay(*issue.ClosedAt, loc).AddDate(0, 0, 1)
+			s.Start = s.End.AddDate(0, 0, -n)
+		} else {
+			s.Start = first
+			if issue.DeferUntil != nil {
+				s.Start = later(s.Start, startOfDay(*issue.DeferUntil, loc))
+			}
+			for _, id := range issue.DependencyIDs(&blocks) {
+				if end, ok := ends[id]; ok {
+					s.Start = later(s.Start, end)
+				}
+			}
+			s.End = s.Start.AddDate(0, 0, n)
+		}
+		s.Issue = issue
+		if issue.DueAt != nil {
+			s.Late = s.End.After(startOfDay(*issue.DueAt, loc).AddDate(0, 0, 1))
+		}
+		ends[issue.ID] = s.End
+		slots = append(slots, s)
+	}
+	return slots
+}
+
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+func later(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
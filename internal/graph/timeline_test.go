@@ -0,0 +1,55 @@
+package graph
+
+import (
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestTimeline(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	at := func(d, h int) *time.Time { t := day(d).Add(time.Duration(h) * time.Hour); return &t }
+	blockedBy := func(ids ...string) []issuestorage.Dependency {
+		var deps []issuestorage.Dependency
+		for _, id := range ids {
+			deps = append(deps, issuestorage.Dependency{ID: id, Type: issuestorage.DepTypeBlocks})
+		}
+		return deps
+	}
+
+	ordered := []*issuestorage.Issue{
+		{ID: "design", Status: issuestorage.StatusClosed, ClosedAt: at(2, 15)},
+		{ID: "build", Status: issuestorage.StatusInProgress, Dependencies: blockedBy("design")},
+		{ID: "docs", Status: issuestorage.StatusOpen, DeferUntil: at(12, 0)},
+		{ID: "ship", Status: issuestorage.StatusOpen, Dependencies: blockedBy("build", "docs", "elsewhere"), DueAt: at(13, 17)},
+	}
+	length := map[string]int{"design": 2, "build": 3, "docs": 1, "ship": 0}
+	slots := Timeline(ordered, day(10).Add(9*time.Hour), func(i *issuestorage.Issue) int { return length[i.ID] })
+
+	want := []struct {
+		id         string
+		start, end time.Time
+		late       bool
+	}{
+		{"design", day(1), day(3), false},
+		{"build", day(10), day(13), false},
+		{"docs", day(12), day(13), false},
+		{"ship", day(13), day(14), false}, // at least a day
+	}
+	for i, w := range want {
+		s := slots[i]
+		if s.Issue.ID != w.id || !s.Start.Equal(w.start) || !s.End.Equal(w.end) || s.Late != w.late {
+			t.Errorf("slot %d = %s %s..%s late=%v, want %s %s..%s late=%v", i, s.Issue.ID, s.Start, s.End, s.Late, w.id, w.start, w.end, w.late)
+		}
+	}
+	if slots[1].Days() != 3 {
+		t.Errorf("build days = %d", slots[1].Days())
+	}
+
+	// Due a day earlier, ship overruns.
+	ordered[3].DueAt = at(12, 17)
+	if slots := Timeline(ordered, day(10), func(i *issuestorage.Issue) int { return length[i.ID] }); !slots[3].Late {
+		t.Error("ship should be late")
+	}
+}
@@ -7,6 +7,7 @@ package idgen
 import (
 	"crypto/rand"
 	"fmt"
+	"io"
 	"math"
 	"math/big"
 	"strconv"
@@ -28,15 +29,21 @@ const (
 // It uses crypto/rand to generate length random base36 characters.
 // Returns an error if length is outside [MinLength, MaxLength].
 func RandomID(prefix string, length int) (string, error) {
+	return RandomIDFrom(rand.Reader, prefix, length)
+}
+
+// RandomIDFrom is RandomID drawing its randomness from r, so a seeded
+// reader yields a reproducible sequence of IDs.
+func RandomIDFrom(r io.Reader, prefix string, length int) (string, error) {
 	if length < MinLength || length > MaxLength {
 		return "", fmt.Errorf("idgen: length %d out of range [%d, %d]", length, MinLength, MaxLength)
 	}
 
 	// Generate a random number in [0, 36^length).
 	mod := new(big.Int).Exp(big.NewInt(36), big.NewInt(int64(length)), nil)
-	n, err := rand.Int(rand.Reader, mod)
+	n, err := rand.Int(r, mod)
 	if err != nil {
-		return "", fmt.Errorf("idgen: crypto/rand: %w", err)
+		return "", fmt.Errorf("idgen: reading random source: %w", err)
 	}
 
 	// Encode as base36, left-pad with zeros to the target length.
@@ -0,0 +1,199 @@
+package importer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// CSV fields an import can map columns to.
+const (
+	FieldID          = "id"
+	FieldParent      = "parent"
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldType        = "type"
+	FieldStatus      = "status"
+	FieldPriority    = "priority"
+	FieldAssignee    = "assignee"
+	FieldLabels      = "labels"
+)
+
+// CSVFields lists the fields ParseCSV understands, in display order.
+var CSVFields = []string{
+	FieldID, FieldParent, FieldTitle, FieldDescription, FieldType,
+	FieldStatus, FieldPriority, FieldAssignee, FieldLabels,
+}
+
+// CSVOptions configures ParseCSV.
+type CSVOptions struct {
+	// Comma is the field delimiter; zero means ','.
+	Comma rune
+
+	// Map maps fields to column headers. Fields not in Map are read from
+	// the column whose header equals the field name, ignoring case.
+	Map map[string]string
+}
+
+// doneStatuses are status cell values that mark an item done.
+var doneStatuses = map[string]bool{
+	"closed": true, "done": true, "complete": true, "completed": true,
+	"resolved": true, "x": true, "yes": true, "true": true,
+}
+
+// ParseMapping parses a --map value such as "title=Summary,priority=Prio".
+func ParseMapping(s string) (map[string]string, error) {
+	m := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		field, column, ok := strings.Cut(pair, "=")
+		field = strings.ToLower(strings.TrimSpace(field))
+		column = strings.TrimSpace(column)
+		if !ok || column == "" {
+			return nil, fmt.Errorf("invalid mapping %q (expected field=Column)", pair)
+		}
+		if !containsString(CSVFields, field) {
+			return nil, fmt.Errorf("unknown field %q in mapping (valid: %s)", field, strings.Join(CSVFields, ", "))
+		}
+		m[field] = column
+	}
+	return m, nil
+}
+
+// ParseCSV reads one item per row of a CSV file with a header row. A title
+// column is required. Labels are split on commas and semicolons; a status
+// of closed, done, x, yes or similar marks the item done; priorities accept
+// 0-4, P0-P4 or words like high and low. If the file has id and parent
+// columns, rows whose parent matches another row's id become its children;
+// rows whose parent isn't in the file are imported at the top level.
+func ParseCSV(r io.Reader, opts CSVOptions) ([]*Item, error) {
+	reader := csv.NewReader(r)
+	if opts.Comma != 0 {
+		reader.Comma = opts.Comma
+	}
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int) // field → column index
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		for _, field := range CSVFields {
+			want, mapped := opts.Map[field]
+			if (mapped && name == want) || (!mapped && strings.EqualFold(name, field)) {
+				columns[field] = i
+			}
+		}
+	}
+	for _, field := range sortedMapKeys(opts.Map) {
+		if _, ok := columns[field]; !ok {
+			return nil, fmt.Errorf("column %q (mapped to %s) not found in header", opts.Map[field], field)
+		}
+	}
+	if _, ok := columns[FieldTitle]; !ok {
+		return nil, fmt.Errorf("no title column in header (use --map title=Column)")
+	}
+
+	type row struct {
+		item       *Item
+		id, parent string
+	}
+	var rows []row
+	byID := make(map[string]*Item)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		cell := func(field string) string {
+			if i, ok := columns[field]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if strings.Join(record, "") == "" {
+			continue
+		}
+
+		item := &Item{
+			Title:       cell(FieldTitle),
+			Description: cell(FieldDescription),
+			Assignee:    cell(FieldAssignee),
+			Type:        cell(FieldType),
+			Done:        doneStatuses[strings.ToLower(cell(FieldStatus))],
+			Line:        line,
+		}
+		if item.Title == "" {
+			return nil, fmt.Errorf("line %d: empty title", line)
+		}
+		if v := cell(FieldPriority); v != "" {
+			p, err := issuestorage.ParsePriority(v)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid priority %q", line, v)
+			}
+			item.Priority = &p
+		}
+		for _, label := range strings.FieldsFunc(cell(FieldLabels), func(r rune) bool { return r == ',' || r == ';' }) {
+			if label = strings.TrimSpace(label); label != "" && !containsString(item.Labels, label) {
+				item.Labels = append(item.Labels, label)
+			}
+		}
+
+		id := cell(FieldID)
+		if id != "" {
+			if _, dup := byID[id]; dup {
+				return nil, fmt.Errorf("line %d: duplicate id %q", line, id)
+			}
+			byID[id] = item
+		}
+		rows = append(rows, row{item: item, id: id, parent: cell(FieldParent)})
+	}
+
+	var roots []*Item
+	for _, r := range rows {
+		if r.parent == "" {
+			roots = append(roots, r.item)
+			continue
+		}
+		parent, ok := byID[r.parent]
+		if !ok {
+			// The parent wasn't exported with this row, e.g. a closed epic
+			// left out of an export of open issues.
+			roots = append(roots, r.item)
+			continue
+		}
+		if parent == r.item {
+			return nil, fmt.Errorf("line %d: row is its own parent", r.item.Line)
+		}
+		parent.Children = append(parent.Children, r.item)
+	}
+	if Count(roots) != len(rows) {
+		return nil, fmt.Errorf("parent column contains a cycle")
+	}
+	return roots, nil
+}
+
+func sortedMapKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,88 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestParseCSV(t *testing.T) {
+	input := "\ufeffID,Title,Status,Priority,Labels,Parent,Assignee,Notes\n" +
+		"1,Launch site,open,P1,\"web, launch\",,alice,\n" +
+		"2,Write copy,done,low,web;copy,1,bob,Keep it short\n" +
+		",,,,,,,\n" +
+		"3,Orphan,open,,,99,,\n"
+	items, err := ParseCSV(strings.NewReader(input), CSVOptions{Map: map[string]string{FieldDescription: "Notes"}})
+	if err != nil {
+		t.Fatalf("ParseCSV: %v", err)
+	}
+	if len(items) != 2 || Count(items) != 3 {
+		t.Fatalf("got %d roots / %d items, want 2 / 3", len(items), Count(items))
+	}
+
+	launch := items[0]
+	if launch.Title != "Launch site" || launch.Assignee != "alice" || launch.Done || launch.Line != 2 {
+		t.Errorf("launch = %+v", launch)
+	}
+	if launch.Priority == nil || *launch.Priority != issuestorage.PriorityHigh {
+		t.Errorf("launch priority = %v, want P1", launch.Priority)
+	}
+	if strings.Join(launch.Labels, "|") != "web|launch" {
+		t.Errorf("launch labels = %v", launch.Labels)
+	}
+	if len(launch.Children) != 1 {
+		t.Fatalf("launch children = %d, want 1", len(launch.Children))
+	}
+	copyItem := launch.Children[0]
+	if !copyItem.Done || copyItem.Description != "Keep it short" || *copyItem.Priority != issuestorage.PriorityLow {
+		t.Errorf("copy = %+v", copyItem)
+	}
+	if orphan := items[1]; orphan.Title != "Orphan" || orphan.Line != 5 {
+		t.Errorf("row with unknown parent should be top-level: %+v", orphan)
+	}
+}
+
+func TestParseCSVDelimiterAndMapping(t *testing.T) {
+	input := "Summary\tPrio\nFix login\t0\n"
+	m, err := ParseMapping("title=Summary, priority=Prio")
+	if err != nil {
+		t.Fatalf("ParseMapping: %v", err)
+	}
+	items, err := ParseCSV(strings.NewReader(input), CSVOptions{Comma: '\t', Map: m})
+	if err != nil {
+		t.Fatalf("ParseCSV: %v", err)
+	}
+	if len(items) != 1 || items[0].Title != "Fix login" || *items[0].Priority != issuestorage.PriorityCritical {
+		t.Errorf("items = %+v", items)
+	}
+}
+
+func TestParseCSVErrors(t *testing.T) {
+	tests := []struct {
+		name, input string
+		mapping     map[string]string
+		want        string
+	}{
+		{"no title column", "Name\nx\n", nil, "no title column"},
+		{"missing mapped column", "Title\nx\n", map[string]string{FieldAssignee: "Owner"}, `column "Owner"`},
+		{"empty title", "title,status\n,open\n", nil, "line 2: empty title"},
+		{"bad priority", "title,priority\nx,urgent\n", nil, `line 2: invalid priority "urgent"`},
+		{"duplicate id", "id,title\n1,a\n1,b\n", nil, `line 3: duplicate id "1"`},
+		{"cycle", "id,title,parent\n1,a,2\n2,b,1\n", nil, "cycle"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCSV(strings.NewReader(tt.input), CSVOptions{Map: tt.mapping})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got %v, want error containing %q", err, tt.want)
+			}
+		})
+	}
+
+	for _, bad := range []string{"title", "summary=Title", "title="} {
+		if _, err := ParseMapping(bad); err == nil {
+			t.Errorf("ParseMapping(%q): expected error", bad)
+		}
+	}
+}
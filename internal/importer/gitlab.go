@@ -0,0 +1,190 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// gitlabIssue is the subset of a GitLab issue that is imported. It reads
+// both the issues API's JSON and the issues.ndjson of a project export,
+// which lists labels as label_links instead of names.
+type gitlabIssue struct {
+	IID         int      `json:"iid"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	State       string   `json:"state"`
+	IssueType   string   `json:"issue_type"`
+	Labels      []string `json:"labels"`
+	LabelLinks  []struct {
+		Label struct {
+			Title string `json:"title"`
+		} `json:"label"`
+	} `json:"label_links"`
+	Assignees  []gitlabUser `json:"assignees"`
+	DueDate    string       `json:"due_date"`
+	References struct {
+		Full string `json:"full"`
+	} `json:"references"`
+	Notes []struct {
+		Note      string     `json:"note"`
+		System    bool       `json:"system"`
+		Author    gitlabUser `json:"author"`
+		CreatedAt time.Time  `json:"created_at"`
+	} `json:"notes"`
+	Links []struct {
+		IID        int    `json:"iid"`
+		LinkType   string `json:"link_type"`
+		References struct {
+			Full string `json:"full"`
+		} `json:"references"`
+	} `json:"links"`
+}
+
+type gitlabUser struct {
+	Username string `json:"username"`
+	Name     string `json:"name"`
+}
+
+func (u gitlabUser) String() string {
+	if u.Username != "" {
+		return u.Username
+	}
+	return u.Name
+}
+
+// ParseGitLab reads GitLab issues, either a JSON array as returned by the
+// issues API or one issue per line as in a project export's issues.ndjson.
+// Each issue's notes and links, from the issue notes and links endpoints,
+// may be embedded as "notes" and "links"; user notes become comments, and
+// links between imported issues become blocks or related dependencies.
+// Closed issues are done. Scoped priority::X labels and P0-P4 labels set
+// the priority; type::X labels naming a task, bug, feature, epic or chore,
+// and the incident issue type, set the type; other labels are kept. The
+// ref is the issue's full reference, such as group/project#12, or #12 if
+// the export has none.
+func ParseGitLab(r io.Reader) ([]*Item, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+	}
+
+	var items []*Item
+	var issues []*gitlabIssue
+	byRef := make(map[string]*Item)
+	for dec.More() {
+		line := lineAt(data, dec.InputOffset())
+		var gi gitlabIssue
+		if err := dec.Decode(&gi); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		item, err := gitlabItem(&gi, line)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := byRef[item.Ref]; dup {
+			return nil, fmt.Errorf("line %d: duplicate issue %s", line, item.Ref)
+		}
+		byRef[item.Ref] = item
+		items = append(items, item)
+		issues = append(issues, &gi)
+	}
+
+	for i, gi := range issues {
+		for _, l := range gi.Links {
+			other := gitlabRef(l.References.Full, l.IID)
+			switch l.LinkType {
+			case "blocks":
+				addLink(byRef, other, items[i].Ref, issuestorage.DepTypeBlocks)
+			case "is_blocked_by":
+				addLink(byRef, items[i].Ref, other, issuestorage.DepTypeBlocks)
+			default:
+				addLink(byRef, items[i].Ref, other, issuestorage.DepTypeRelated)
+			}
+		}
+	}
+	return items, nil
+}
+
+// gitlabItem converts one issue.
+func gitlabItem(gi *gitlabIssue, line int) (*Item, error) {
+	item := &Item{
+		Title:       strings.TrimSpace(gi.Title),
+		Description: gi.Description,
+		Done:        gi.State == "closed",
+		Ref:         gitlabRef(gi.References.Full, gi.IID),
+		Line:        line,
+	}
+	if item.Title == "" {
+		return nil, fmt.Errorf("line %d: issue %s has no title", line, item.Ref)
+	}
+	if len(gi.Assignees) > 0 {
+		item.Assignee = gi.Assignees[0].String()
+	}
+	if gi.IssueType == "incident" {
+		item.Type = string(issuestorage.TypeBug)
+	}
+
+	labels := gi.Labels
+	for _, l := range gi.LabelLinks {
+		labels = append(labels, l.Label.Title)
+	}
+	for _, label := range labels {
+		if scope, value, ok := strings.Cut(label, "::"); ok {
+			switch strings.ToLower(scope) {
+			case "priority":
+				if p, err := issuestorage.ParsePriority(value); err == nil {
+					item.Priority = &p
+					continue
+				}
+			case "type":
+				if t := strings.ToLower(value); workTypes[t] {
+					item.Type = t
+					continue
+				}
+			}
+		} else if len(label) == 2 && (label[0] == 'P' || label[0] == 'p') {
+			if p, err := issuestorage.ParsePriority(label); err == nil {
+				item.Priority = &p
+				continue
+			}
+		}
+		item.Labels = uniqueAppend(item.Labels, label)
+	}
+
+	for _, n := range gi.Notes {
+		if n.System || strings.TrimSpace(n.Note) == "" {
+			continue
+		}
+		item.Comments = append(item.Comments, Comment{Author: n.Author.String(), Text: n.Note, At: n.CreatedAt})
+	}
+
+	if gi.DueDate != "" {
+		due, err := time.Parse("2006-01-02", gi.DueDate)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid due date %q", line, gi.DueDate)
+		}
+		item.Due = &due
+	}
+	return item, nil
+}
+
+// gitlabRef returns an issue's full reference, or #iid without one.
+func gitlabRef(full string, iid int) string {
+	if full != "" {
+		return full
+	}
+	return "#" + strconv.Itoa(iid)
+}
@@ -0,0 +1,74 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestParseGitLab(t *testing.T) {
+	input := `[
+{"iid":1,"title":"Login fails","description":"500 on submit","state":"opened","issue_type":"incident",
+ "labels":["priority::1","backend","type::chore"],"assignees":[{"username":"alice"}],"due_date":"2024-03-01",
+ "references":{"full":"acme/web#1"},
+ "notes":[{"note":"changed the description","system":true},{"note":"Seeing it too","author":{"username":"bob"},"created_at":"2024-01-02T10:00:00.000Z"}],
+ "links":[{"iid":2,"link_type":"is_blocked_by","references":{"full":"acme/web#2"}},{"iid":9,"link_type":"relates_to","references":{"full":"acme/web#9"}}]},
+{"iid":2,"title":"Upgrade auth library","state":"closed","labels":["P3"],"references":{"full":"acme/web#2"},
+ "links":[{"iid":1,"link_type":"blocks","references":{"full":"acme/web#1"}}]}
+]`
+	items, err := ParseGitLab(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseGitLab: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("got %d items, want 2", len(items))
+	}
+
+	login := items[0]
+	if login.Title != "Login fails" || login.Ref != "acme/web#1" || login.Assignee != "alice" || login.Done || login.Line != 2 {
+		t.Errorf("login = %+v", login)
+	}
+	if login.Type != "chore" || strings.Join(login.Labels, ",") != "backend" {
+		t.Errorf("login type %q, labels %v", login.Type, login.Labels)
+	}
+	if login.Priority == nil || *login.Priority != issuestorage.PriorityHigh || login.Due == nil || login.Due.Format("2006-01-02") != "2024-03-01" {
+		t.Errorf("login priority %v, due %v", login.Priority, login.Due)
+	}
+	if len(login.Comments) != 1 || login.Comments[0].Author != "bob" || login.Comments[0].At.IsZero() {
+		t.Errorf("login comments = %+v", login.Comments)
+	}
+	// The link is listed on both issues but recorded once; #9 isn't imported.
+	if len(login.Links) != 1 || login.Links[0] != (Link{Ref: "acme/web#2", Type: issuestorage.DepTypeBlocks}) || len(items[1].Links) != 0 {
+		t.Errorf("links = %+v, %+v", login.Links, items[1].Links)
+	}
+
+	if upgrade := items[1]; !upgrade.Done || *upgrade.Priority != issuestorage.PriorityLow || len(upgrade.Labels) != 0 {
+		t.Errorf("upgrade = %+v", upgrade)
+	}
+}
+
+func TestParseGitLabExport(t *testing.T) {
+	input := `{"iid":4,"title":"Dark mode","state":"opened","label_links":[{"label":{"title":"ui"}}],"notes":[{"note":"+1","author":{"name":"Carol"}}]}
+{"iid":5,"title":"Old","state":"closed"}
+`
+	items, err := ParseGitLab(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseGitLab: %v", err)
+	}
+	if len(items) != 2 || items[0].Ref != "#4" || strings.Join(items[0].Labels, ",") != "ui" || items[0].Comments[0].Author != "Carol" || items[1].Line != 2 {
+		t.Errorf("items = %+v", items)
+	}
+}
+
+func TestParseGitLabErrors(t *testing.T) {
+	for input, want := range map[string]string{
+		`[{"iid":1,"title":" "}]`:                                     "line 1: issue #1 has no title",
+		"[{\"iid\":1,\"title\":\"a\"},\n{\"iid\":1,\"title\":\"b\"}]": "line 2: duplicate issue #1",
+		`[{"iid":1,"title":"a","due_date":"soon"}]`:                   `line 1: invalid due date "soon"`,
+	} {
+		if _, err := ParseGitLab(strings.NewReader(input)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseGitLab(%q) = %v, want error containing %q", input, err, want)
+		}
+	}
+}
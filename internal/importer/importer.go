@@ -0,0 +1,122 @@
+// Package importer converts backlogs kept in other formats into trees of
+// items that the CLI creates as issues. Parsers only read their input;
+// creating issues, assigning IDs and wiring parent-child dependencies is
+// left to the caller.
+package importer
+
+import (
+	"fmt"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// Item is one imported entry. Children become child issues of the issue
+// created for their parent.
+type Item struct {
+	Title       string
+	Description string
+	Type        string // issue type name; "" to use the import's default
+	Assignee    string
+	Labels      []string
+	Priority    *issuestorage.Priority // nil to use the type's default
+	Done        bool
+	Due         *time.Time // deadline
+	Scheduled   *time.Time // date work is planned to start
+
+	// Ref is the item's ID in the tracker it was exported from, such as
+	// PROJ-12, kept on the issue as provenance. Links name items by Ref.
+	Ref      string
+	Comments []Comment
+	Links    []Link
+
+	// Line is the 1-based line the item starts on, for error messages.
+	Line int
+
+	Children []*Item
+}
+
+// workTypes are the built-in issue types a tracker's own type can map to.
+var workTypes = map[string]bool{
+	string(issuestorage.TypeTask): true, string(issuestorage.TypeBug): true,
+	string(issuestorage.TypeFeature): true, string(issuestorage.TypeEpic): true,
+	string(issuestorage.TypeChore): true,
+}
+
+// Comment is a comment carried over from the source tracker.
+type Comment struct {
+	Author string
+	Text   string
+	At     time.Time // zero if the export has no timestamp
+}
+
+// Link is a dependency of an item on another item of the same import,
+// named by its Ref. Links to items outside the import are not kept.
+type Link struct {
+	Ref  string
+	Type issuestorage.DependencyType
+}
+
+// Count returns the number of items in items and all their descendants.
+func Count(items []*Item) int {
+	n := 0
+	for _, item := range items {
+		n += 1 + Count(item.Children)
+	}
+	return n
+}
+
+// addLink records that the item with Ref from depends on the one with Ref
+// to. It does nothing if either item is not in byRef or the two are already
+// linked either way round, as trackers list a link on both of its issues
+// and a pair needs only one dependency.
+func addLink(byRef map[string]*Item, from, to string, t issuestorage.DependencyType) {
+	item, other := byRef[from], byRef[to]
+	if item == nil || other == nil || item == other {
+		return
+	}
+	for _, l := range item.Links {
+		if l.Ref == to {
+			return
+		}
+	}
+	for _, l := range other.Links {
+		if l.Ref == from {
+			return
+		}
+	}
+	item.Links = append(item.Links, Link{Ref: to, Type: t})
+}
+
+// nestByParent arranges items into trees, making each item a child of the
+// item whose Ref is its entry in parents. Items without a parent, or whose
+// parent is not among items, stay at the top level.
+func nestByParent(items []*Item, parents map[*Item]string) ([]*Item, error) {
+	byRef := make(map[string]*Item, len(items))
+	for _, item := range items {
+		if item.Ref != "" {
+			byRef[item.Ref] = item
+		}
+	}
+	var roots []*Item
+	for _, item := range items {
+		parent, ok := byRef[parents[item]]
+		if !ok || parent == item {
+			roots = append(roots, item)
+			continue
+		}
+		parent.Children = append(parent.Children, item)
+	}
+	if Count(roots) != len(items) {
+		return nil, fmt.Errorf("parent links contain a cycle")
+	}
+	return roots, nil
+}
+
+// uniqueAppend appends s to list unless it is empty or already present.
+func uniqueAppend(list []string, s string) []string {
+	if s == "" || containsString(list, s) {
+		return list
+	}
+	return append(list, s)
+}
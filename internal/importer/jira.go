@@ -0,0 +1,463 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// Timestamp formats in Jira exports: the REST API's, and the CSV export's
+// default date formats.
+const (
+	jiraTimeFormat = "2006-01-02T15:04:05.000-0700"
+	jiraDateFormat = "2006-01-02"
+)
+
+var jiraCSVTimeFormats = []string{"02/Jan/06 3:04 PM", "02/Jan/06 15:04", "02/Jan/06", jiraDateFormat}
+
+// jiraIssue is the subset of a Jira REST API issue that is imported.
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary     string          `json:"summary"`
+		Description json.RawMessage `json:"description"`
+		IssueType   struct {
+			Name string `json:"name"`
+		} `json:"issuetype"`
+		Status struct {
+			Name           string `json:"name"`
+			StatusCategory struct {
+				Key string `json:"key"`
+			} `json:"statusCategory"`
+		} `json:"status"`
+		Priority *struct {
+			Name string `json:"name"`
+		} `json:"priority"`
+		Labels   []string  `json:"labels"`
+		Assignee *jiraUser `json:"assignee"`
+		DueDate  string    `json:"duedate"`
+		Parent   *jiraKey  `json:"parent"`
+		Comment  struct {
+			Comments []struct {
+				Author  jiraUser        `json:"author"`
+				Body    json.RawMessage `json:"body"`
+				Created string          `json:"created"`
+			} `json:"comments"`
+		} `json:"comment"`
+		IssueLinks []struct {
+			Type struct {
+				Name string `json:"name"`
+			} `json:"type"`
+			InwardIssue  *jiraKey `json:"inwardIssue"`
+			OutwardIssue *jiraKey `json:"outwardIssue"`
+		} `json:"issuelinks"`
+	} `json:"fields"`
+}
+
+type jiraKey struct {
+	Key string `json:"key"`
+}
+
+type jiraUser struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+func (u jiraUser) String() string {
+	if u.Name != "" {
+		return u.Name
+	}
+	return u.DisplayName
+}
+
+// adfNode is a node of an Atlassian Document Format document, in which
+// Jira Cloud's v3 API returns descriptions and comment bodies.
+type adfNode struct {
+	Type    string    `json:"type"`
+	Text    string    `json:"text"`
+	Content []adfNode `json:"content"`
+}
+
+// ParseJira reads Jira issues from a JSON or CSV export, telling them apart
+// by the first character. JSON is a REST API search result (or an array of
+// its issues) with descriptions and comments as text or as Atlassian
+// documents; CSV is the issue navigator's "Export CSV (all fields)", whose
+// repeated Labels, Comment and issue link columns are all read.
+//
+// The issue key is the ref. Issues in a done status category, or with a
+// done, closed or resolved status, are done. Priorities Highest to Lowest,
+// or Blocker to Trivial, map to P0-P4. Bugs, stories and features, epics,
+// and tasks and sub-tasks map to those types. Comments are kept, Blocks
+// links between imported issues become blocks dependencies and other links
+// related ones, and an issue whose parent (or epic link) is imported
+// becomes its child.
+func ParseJira(r io.Reader) ([]*Item, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return parseJiraJSON(data)
+	}
+	return parseJiraCSV(data)
+}
+
+// parseJiraJSON reads a search result's issues, or an array of issues.
+func parseJiraJSON(data []byte) ([]*Item, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if bytes.TrimSpace(data)[0] == '{' {
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		for {
+			if !dec.More() {
+				return nil, fmt.Errorf(`no "issues" array in JSON object`)
+			}
+			key, err := dec.Token()
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineAt(data, dec.InputOffset()), err)
+			}
+			if key == "issues" {
+				break
+			}
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineAt(data, dec.InputOffset()), err)
+			}
+		}
+	}
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return nil, fmt.Errorf("line %d: expected an array of issues", lineAt(data, dec.InputOffset()))
+	}
+
+	var items []*Item
+	var issues []*jiraIssue
+	parents := make(map[*Item]string)
+	byRef := make(map[string]*Item)
+	for dec.More() {
+		line := lineAt(data, dec.InputOffset())
+		var ji jiraIssue
+		if err := dec.Decode(&ji); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		f := &ji.Fields
+		item := &Item{
+			Title:       strings.TrimSpace(f.Summary),
+			Description: jiraText(f.Description),
+			Type:        JiraType(f.IssueType.Name),
+			Done:        f.Status.StatusCategory.Key == "done" || doneStatuses[strings.ToLower(f.Status.Name)],
+			Ref:         ji.Key,
+			Line:        line,
+		}
+		if err := checkJiraItem(item, byRef); err != nil {
+			return nil, err
+		}
+		if f.Priority != nil {
+			if p, ok := JiraPriority(f.Priority.Name); ok {
+				item.Priority = &p
+			}
+		}
+		for _, label := range f.Labels {
+			item.Labels = uniqueAppend(item.Labels, label)
+		}
+		if f.Assignee != nil {
+			item.Assignee = f.Assignee.String()
+		}
+		for _, c := range f.Comment.Comments {
+			at, _ := time.Parse(jiraTimeFormat, c.Created)
+			if text := jiraText(c.Body); text != "" {
+				item.Comments = append(item.Comments, Comment{Author: c.Author.String(), Text: text, At: at})
+			}
+		}
+		if f.DueDate != "" {
+			due, err := time.Parse(jiraDateFormat, f.DueDate)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid due date %q", line, f.DueDate)
+			}
+			item.Due = &due
+		}
+		if f.Parent != nil {
+			parents[item] = f.Parent.Key
+		}
+		byRef[item.Ref] = item
+		items = append(items, item)
+		issues = append(issues, &ji)
+	}
+
+	for i, ji := range issues {
+		for _, l := range ji.Fields.IssueLinks {
+			t := jiraLinkType(l.Type.Name)
+			if l.OutwardIssue != nil {
+				// This issue blocks the outward one.
+				addLink(byRef, l.OutwardIssue.Key, items[i].Ref, t)
+			}
+			if l.InwardIssue != nil {
+				addLink(byRef, items[i].Ref, l.InwardIssue.Key, t)
+			}
+		}
+	}
+	return nestByParent(items, parents)
+}
+
+// parseJiraCSV reads a CSV export with a header row.
+func parseJiraCSV(data []byte) ([]*Item, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string][]int) // header → column indexes, in order
+	var linkColumns []string          // issue link headers, in order
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		if _, _, ok := jiraLinkColumn(name); ok && len(columns[name]) == 0 {
+			linkColumns = append(linkColumns, name)
+		}
+		columns[name] = append(columns[name], i)
+	}
+	for _, required := range []string{"Summary", "Issue key"} {
+		if len(columns[required]) == 0 {
+			return nil, fmt.Errorf("no %q column in header; is this a Jira CSV export?", required)
+		}
+	}
+
+	type link struct {
+		from, to string
+		t        issuestorage.DependencyType
+	}
+	var items []*Item
+	var links []link
+	parents := make(map[*Item]string)
+	byRef := make(map[string]*Item)
+	refByID := make(map[string]string) // numeric issue id → key
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if strings.Join(record, "") == "" {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		cells := func(name string) []string {
+			var values []string
+			for _, i := range columns[name] {
+				if i < len(record) {
+					if v := strings.TrimSpace(record[i]); v != "" {
+						values = append(values, v)
+					}
+				}
+			}
+			return values
+		}
+		cell := func(name string) string {
+			if v := cells(name); len(v) > 0 {
+				return v[0]
+			}
+			return ""
+		}
+
+		status := strings.ToLower(cell("Status"))
+		item := &Item{
+			Title:       cell("Summary"),
+			Description: cell("Description"),
+			Type:        JiraType(cell("Issue Type")),
+			Assignee:    cell("Assignee"),
+			Done:        doneStatuses[status] || strings.EqualFold(cell("Status Category"), "done"),
+			Ref:         cell("Issue key"),
+			Line:        line,
+		}
+		if err := checkJiraItem(item, byRef); err != nil {
+			return nil, err
+		}
+		if p, ok := JiraPriority(cell("Priority")); ok {
+			item.Priority = &p
+		}
+		for _, label := range cells("Labels") {
+			item.Labels = uniqueAppend(item.Labels, label)
+		}
+		for _, c := range cells("Comment") {
+			item.Comments = append(item.Comments, jiraCSVComment(c))
+		}
+		if v := cell("Due Date"); v != "" {
+			due, ok := parseJiraCSVTime(v)
+			if !ok {
+				return nil, fmt.Errorf("line %d: invalid due date %q", line, v)
+			}
+			item.Due = &due
+		}
+
+		for _, name := range linkColumns {
+			t, outward, _ := jiraLinkColumn(name)
+			for _, other := range cells(name) {
+				if outward {
+					// This issue blocks the other one.
+					links = append(links, link{other, item.Ref, t})
+				} else {
+					links = append(links, link{item.Ref, other, t})
+				}
+			}
+		}
+
+		for _, name := range []string{"Parent", "Parent id", "Parent key", "Custom field (Epic Link)"} {
+			if v := cell(name); v != "" {
+				parents[item] = v
+				break
+			}
+		}
+		if id := cell("Issue id"); id != "" {
+			refByID[id] = item.Ref
+		}
+		byRef[item.Ref] = item
+		items = append(items, item)
+	}
+
+	for item, parent := range parents {
+		if ref, ok := refByID[parent]; ok {
+			parents[item] = ref
+		}
+	}
+	for _, l := range links {
+		addLink(byRef, l.from, l.to, l.t)
+	}
+	return nestByParent(items, parents)
+}
+
+// checkJiraItem rejects an issue without a summary or key, or whose key
+// was already read.
+func checkJiraItem(item *Item, byRef map[string]*Item) error {
+	if item.Ref == "" {
+		return fmt.Errorf("line %d: issue has no key", item.Line)
+	}
+	if item.Title == "" {
+		return fmt.Errorf("line %d: issue %s has no summary", item.Line, item.Ref)
+	}
+	if _, dup := byRef[item.Ref]; dup {
+		return fmt.Errorf("line %d: duplicate issue %s", item.Line, item.Ref)
+	}
+	return nil
+}
+
+// JiraPriority maps a Jira priority name, from either the Highest to Lowest
+// or the Blocker to Trivial scheme, to a priority.
+func JiraPriority(name string) (issuestorage.Priority, bool) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "highest", "blocker":
+		return issuestorage.PriorityCritical, true
+	case "high", "critical":
+		return issuestorage.PriorityHigh, true
+	case "medium", "major":
+		return issuestorage.PriorityMedium, true
+	case "low", "minor":
+		return issuestorage.PriorityLow, true
+	case "lowest", "trivial":
+		return issuestorage.PriorityBacklog, true
+	}
+	return 0, false
+}
+
+// JiraType maps a Jira issue type name to an issue type name, or "" for
+// types without a counterpart, which import as the default type.
+func JiraType(name string) string {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "bug":
+		return string(issuestorage.TypeBug)
+	case "story", "feature", "new feature", "improvement":
+		return string(issuestorage.TypeFeature)
+	case "epic":
+		return string(issuestorage.TypeEpic)
+	case "task", "sub-task", "subtask":
+		return string(issuestorage.TypeTask)
+	}
+	return ""
+}
+
+// jiraLinkType maps a Jira link type name to a dependency type.
+func jiraLinkType(name string) issuestorage.DependencyType {
+	if strings.EqualFold(name, "blocks") {
+		return issuestorage.DepTypeBlocks
+	}
+	return issuestorage.DepTypeRelated
+}
+
+// jiraLinkColumn parses an issue link column header such as "Outward issue
+// link (Blocks)", reporting the link's dependency type and direction.
+func jiraLinkColumn(header string) (t issuestorage.DependencyType, outward, ok bool) {
+	rest, outward := strings.CutPrefix(header, "Outward issue link (")
+	if !outward {
+		if rest, ok = strings.CutPrefix(header, "Inward issue link ("); !ok {
+			return "", false, false
+		}
+	}
+	name, ok := strings.CutSuffix(rest, ")")
+	if !ok {
+		return "", false, false
+	}
+	return jiraLinkType(name), outward, true
+}
+
+// jiraCSVComment parses a Comment cell, "date;author;text" in exports.
+func jiraCSVComment(cell string) Comment {
+	parts := strings.SplitN(cell, ";", 3)
+	if len(parts) == 3 {
+		if at, ok := parseJiraCSVTime(parts[0]); ok {
+			return Comment{Author: parts[1], Text: parts[2], At: at}
+		}
+	}
+	return Comment{Text: cell}
+}
+
+func parseJiraCSVTime(s string) (time.Time, bool) {
+	for _, layout := range jiraCSVTimeFormats {
+		if t, err := time.Parse(layout, strings.TrimSpace(s)); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// jiraText returns the text of a description or comment body, given either
+// as a string or as an Atlassian document.
+func jiraText(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var doc adfNode
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return ""
+	}
+	var b strings.Builder
+	doc.writeText(&b)
+	return strings.TrimSpace(b.String())
+}
+
+func (n *adfNode) writeText(b *strings.Builder) {
+	switch n.Type {
+	case "hardBreak":
+		b.WriteString("\n")
+	case "listItem":
+		b.WriteString("- ")
+	}
+	b.WriteString(n.Text)
+	for i := range n.Content {
+		n.Content[i].writeText(b)
+	}
+	switch n.Type {
+	case "paragraph", "heading", "codeBlock":
+		b.WriteString("\n")
+	}
+}
@@ -0,0 +1,90 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestParseJiraJSON(t *testing.T) {
+	input := `{"startAt":0,"total":3,"issues":[
+{"key":"PROJ-1","fields":{"summary":"Checkout","issuetype":{"name":"Epic"},"status":{"name":"In Progress","statusCategory":{"key":"indeterminate"}},"priority":{"name":"Highest"}}},
+{"key":"PROJ-2","fields":{"summary":"Card payments","issuetype":{"name":"Story"},"status":{"name":"Open"},
+ "description":{"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"Support Visa"},{"type":"hardBreak"},{"type":"text","text":"and Amex"}]}]},
+ "labels":["payments"],"assignee":{"displayName":"Ana Lima"},"duedate":"2024-05-01","parent":{"key":"PROJ-1"},
+ "comment":{"comments":[{"author":{"name":"dev1"},"body":"Needs PCI review","created":"2024-01-02T10:00:00.000+0000"}]},
+ "issuelinks":[{"type":{"name":"Blocks"},"inwardIssue":{"key":"PROJ-3"}},{"type":{"name":"Relates"},"outwardIssue":{"key":"OTHER-9"}}]}},
+{"key":"PROJ-3","fields":{"summary":"Payment gateway account","issuetype":{"name":"Task"},"status":{"name":"Closed","statusCategory":{"key":"done"}},"priority":{"name":"Minor"},
+ "issuelinks":[{"type":{"name":"Blocks"},"outwardIssue":{"key":"PROJ-2"}}]}}
+]}`
+	items, err := ParseJira(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseJira: %v", err)
+	}
+	if len(items) != 2 || Count(items) != 3 {
+		t.Fatalf("got %d roots, %d items, want 2 and 3", len(items), Count(items))
+	}
+
+	epic := items[0]
+	if epic.Ref != "PROJ-1" || epic.Type != "epic" || *epic.Priority != issuestorage.PriorityCritical || epic.Done || epic.Line != 2 {
+		t.Errorf("epic = %+v", epic)
+	}
+	if len(epic.Children) != 1 {
+		t.Fatalf("epic children = %+v", epic.Children)
+	}
+	story := epic.Children[0]
+	if story.Type != "feature" || story.Description != "Support Visa\nand Amex" || story.Assignee != "Ana Lima" || story.Due == nil {
+		t.Errorf("story = %+v", story)
+	}
+	if len(story.Comments) != 1 || story.Comments[0].Author != "dev1" || story.Comments[0].Text != "Needs PCI review" || story.Comments[0].At.IsZero() {
+		t.Errorf("story comments = %+v", story.Comments)
+	}
+	if len(story.Links) != 1 || story.Links[0] != (Link{Ref: "PROJ-3", Type: issuestorage.DepTypeBlocks}) {
+		t.Errorf("story links = %+v", story.Links)
+	}
+	if gateway := items[1]; !gateway.Done || *gateway.Priority != issuestorage.PriorityLow || len(gateway.Links) != 0 {
+		t.Errorf("gateway = %+v", gateway)
+	}
+}
+
+func TestParseJiraCSV(t *testing.T) {
+	input := "\ufeffSummary,Issue key,Issue id,Issue Type,Status,Priority,Labels,Labels,Comment,Comment,Outward issue link (Blocks),Inward issue link (Cloners),Parent id\n" +
+		"Checkout,PROJ-1,10001,Epic,To Do,High,web,payments,\"02/Jan/24 10:00 AM;dev1;First; with a semicolon\",,PROJ-2,,\n" +
+		"Card payments,PROJ-2,10002,Sub-task,Done,Trivial,,,,,,PROJ-1,10001\n"
+	items, err := ParseJira(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseJira: %v", err)
+	}
+	if len(items) != 1 || len(items[0].Children) != 1 {
+		t.Fatalf("items = %+v", items)
+	}
+	epic, card := items[0], items[0].Children[0]
+	if epic.Ref != "PROJ-1" || *epic.Priority != issuestorage.PriorityHigh || strings.Join(epic.Labels, ",") != "web,payments" {
+		t.Errorf("epic = %+v", epic)
+	}
+	if len(epic.Comments) != 1 || epic.Comments[0].Author != "dev1" || epic.Comments[0].Text != "First; with a semicolon" || epic.Comments[0].At.Hour() != 10 {
+		t.Errorf("epic comments = %+v", epic.Comments)
+	}
+	if card.Type != "task" || !card.Done || card.Line != 3 || *card.Priority != issuestorage.PriorityBacklog {
+		t.Errorf("card = %+v", card)
+	}
+	// The clone link is dropped: the pair is already linked by Blocks.
+	if len(card.Links) != 1 || card.Links[0] != (Link{Ref: "PROJ-1", Type: issuestorage.DepTypeBlocks}) || len(epic.Links) != 0 {
+		t.Errorf("links = %+v, %+v", card.Links, epic.Links)
+	}
+}
+
+func TestParseJiraErrors(t *testing.T) {
+	for input, want := range map[string]string{
+		"Title\nx\n":                              `no "Summary" column`,
+		"Summary,Issue key\nx,\n":                 "line 2: issue has no key",
+		"Summary,Issue key\nx,P-1\ny,P-1\n":       "line 3: duplicate issue P-1",
+		`{"total":0}`:                             `no "issues" array`,
+		`[{"key":"P-1","fields":{"summary":""}}]`: "line 1: issue P-1 has no summary",
+	} {
+		if _, err := ParseJira(strings.NewReader(input)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseJira(%q) = %v, want error containing %q", input, err, want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// checkboxRe matches a Markdown task list item: indentation, a bullet or
+// ordered-list marker, the checkbox and the item's text.
+var checkboxRe = regexp.MustCompile(`^(\s*)(?:[-*+]|\d+[.)])\s+\[([ xX])\](?:\s+(.*))?$`)
+
+// labelRe matches a #label annotation.
+var labelRe = regexp.MustCompile(`^#[\p{L}\p{N}_][\p{L}\p{N}_\-./:]*$`)
+
+// ParseMarkdown reads Markdown checkbox lists ("- [ ] task", "- [x] done")
+// into items. Items indented under another item become its children.
+// Within an item's text, "@name" sets the assignee, "#name" adds a label
+// and "!p0".."!p4" sets the priority; these annotations are removed from
+// the title. Other lines indented under an item are added to its
+// description, and everything else (headings, prose, plain bullets) is
+// ignored.
+func ParseMarkdown(r io.Reader) ([]*Item, error) {
+	type frame struct {
+		indent int
+		item   *Item
+	}
+	var (
+		roots []*Item
+		stack []frame
+		last  *frame // item that indented continuation lines describe
+	)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.ReplaceAll(scanner.Text(), "\t", "    ")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		m := checkboxRe.FindStringSubmatch(line)
+		if m == nil {
+			if last != nil && indent > last.indent {
+				desc := &last.item.Description
+				if *desc != "" {
+					*desc += "\n"
+				}
+				*desc += strings.TrimSpace(line)
+			} else {
+				last = nil
+			}
+			continue
+		}
+
+		item, err := parseMarkdownItem(m[3], lineNo)
+		if err != nil {
+			return nil, err
+		}
+		item.Done = m[2] != " "
+
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			roots = append(roots, item)
+		} else {
+			parent := stack[len(stack)-1].item
+			parent.Children = append(parent.Children, item)
+		}
+		stack = append(stack, frame{indent: indent, item: item})
+		last = &frame{indent: indent, item: item}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return roots, nil
+}
+
+// parseMarkdownItem splits a task's text into its title and annotations.
+func parseMarkdownItem(text string, line int) (*Item, error) {
+	item := &Item{Line: line}
+	var words []string
+	for _, word := range strings.Fields(text) {
+		switch {
+		case len(word) > 1 && word[0] == '@':
+			item.Assignee = word[1:]
+		case labelRe.MatchString(word):
+			if label := word[1:]; !containsString(item.Labels, label) {
+				item.Labels = append(item.Labels, label)
+			}
+		case len(word) == 3 && word[0] == '!' && (word[1] == 'p' || word[1] == 'P'):
+			p, err := issuestorage.ParsePriority(word[1:])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid priority %q (expected !p0-!p4)", line, word)
+			}
+			item.Priority = &p
+		default:
+			words = append(words, word)
+		}
+	}
+	item.Title = strings.Join(words, " ")
+	if item.Title == "" {
+		return nil, fmt.Errorf("line %d: task has no title", line)
+	}
+	return item, nil
+}
+
+func containsString(s []string, v string) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
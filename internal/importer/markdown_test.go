@@ -0,0 +1,89 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestParseMarkdown(t *testing.T) {
+	input := `# Backlog
+
+Some intro text.
+
+- [ ] Set up CI @alice #infra !p1
+  - [x] Add lint step
+  - [ ] Add test step #infra #ci
+    Run the race detector too.
+    - not a task, part of the description
+- [X] Write README
+* [ ] Release
+	1. [ ] Tag version @bob
+
+## Later
+- plain bullet, ignored
+- [ ] Refactor storage !P3
+`
+	items, err := ParseMarkdown(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseMarkdown: %v", err)
+	}
+	if len(items) != 4 {
+		t.Fatalf("got %d top-level items, want 4: %+v", len(items), items)
+	}
+	if n := Count(items); n != 7 {
+		t.Errorf("Count = %d, want 7", n)
+	}
+
+	ci := items[0]
+	if ci.Title != "Set up CI" || ci.Assignee != "alice" || ci.Line != 5 {
+		t.Errorf("first item = %+v", ci)
+	}
+	if len(ci.Labels) != 1 || ci.Labels[0] != "infra" {
+		t.Errorf("labels = %v, want [infra]", ci.Labels)
+	}
+	if ci.Priority == nil || *ci.Priority != issuestorage.PriorityHigh {
+		t.Errorf("priority = %v, want P1", ci.Priority)
+	}
+	if len(ci.Children) != 2 {
+		t.Fatalf("got %d children, want 2", len(ci.Children))
+	}
+	if lint := ci.Children[0]; !lint.Done || lint.Title != "Add lint step" {
+		t.Errorf("lint child = %+v", lint)
+	}
+	test := ci.Children[1]
+	if test.Done || len(test.Labels) != 2 || test.Priority != nil {
+		t.Errorf("test child = %+v", test)
+	}
+	if want := "Run the race detector too.\n- not a task, part of the description"; test.Description != want {
+		t.Errorf("description = %q, want %q", test.Description, want)
+	}
+
+	if readme := items[1]; !readme.Done || readme.Title != "Write README" || len(readme.Children) != 0 {
+		t.Errorf("readme = %+v", readme)
+	}
+	if release := items[2]; len(release.Children) != 1 || release.Children[0].Assignee != "bob" {
+		t.Errorf("release = %+v", release)
+	}
+	if refactor := items[3]; refactor.Priority == nil || *refactor.Priority != issuestorage.PriorityLow {
+		t.Errorf("refactor = %+v", refactor)
+	}
+}
+
+func TestParseMarkdownErrors(t *testing.T) {
+	tests := []struct {
+		name, input, want string
+	}{
+		{"bad priority", "- [ ] Task !p9\n", "line 1: invalid priority"},
+		{"empty title", "- [ ] Task\n- [ ] @alice #x\n", "line 2: task has no title"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseMarkdown(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got %v, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
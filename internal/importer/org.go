@@ -0,0 +1,262 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// Default org-mode TODO keywords, used unless the file declares its own
+// with a #+TODO: (or #+SEQ_TODO:/#+TYP_TODO:) line.
+var (
+	orgOpenKeywords = []string{"TODO", "NEXT", "STARTED", "WAITING", "HOLD", "DEFERRED"}
+	orgDoneKeywords = []string{"DONE", "CANCELLED", "CANCELED"}
+)
+
+var (
+	// orgHeadlineRe matches a headline: its stars and the rest of the line.
+	orgHeadlineRe = regexp.MustCompile(`^(\*+)\s+(.*?)\s*$`)
+
+	// orgTagsRe matches a headline's trailing tag list (":work:urgent:").
+	orgTagsRe = regexp.MustCompile(`\s+(:(?:[\p{L}\p{N}_@#%]+:)+)$`)
+
+	// orgPriorityRe matches a priority cookie ("[#A]").
+	orgPriorityRe = regexp.MustCompile(`^\[#([A-Za-z0-9])\]\s*`)
+
+	// orgPlanningRe matches a SCHEDULED:, DEADLINE: or CLOSED: timestamp.
+	orgPlanningRe = regexp.MustCompile(`(SCHEDULED|DEADLINE|CLOSED):\s*[<\[](\d{4}-\d{2}-\d{2})(?:\s+[^\s>\]\d][^\s>\]]*)?(?:\s+(\d{1,2}:\d{2}))?[^>\]]*[>\]]`)
+
+	// orgPropertyRe matches a line of a :PROPERTIES: drawer.
+	orgPropertyRe = regexp.MustCompile(`^:([^:\s]+):\s*(.*)$`)
+)
+
+// OrgPriority maps an org priority cookie letter to a priority: A is high,
+// B medium and C low.
+func OrgPriority(letter string) (issuestorage.Priority, bool) {
+	switch strings.ToUpper(letter) {
+	case "A":
+		return issuestorage.PriorityHigh, true
+	case "B":
+		return issuestorage.PriorityMedium, true
+	case "C":
+		return issuestorage.PriorityLow, true
+	}
+	return 0, false
+}
+
+// ParseOrg reads org-mode headlines that carry a TODO keyword into items.
+// Headlines nested under another task become its children; headlines
+// without a keyword are treated as section headings and skipped, with
+// their tasks attached to the nearest enclosing task. Done keywords (DONE,
+// CANCELLED) mark the item done. Priority cookies set the priority, tags
+// become labels, SCHEDULED and DEADLINE set the scheduled and due dates,
+// and the ASSIGNEE and TYPE properties set those fields. Other body text
+// becomes the description.
+func ParseOrg(r io.Reader) ([]*Item, error) {
+	openKeywords, doneKeywords := orgOpenKeywords, orgDoneKeywords
+	type frame struct {
+		level int
+		item  *Item // nil for a section heading
+	}
+	var (
+		roots      []*Item
+		stack      []frame
+		current    *Item // task whose body is being read
+		inDrawer   bool
+		properties bool
+	)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+
+		if m := orgHeadlineRe.FindStringSubmatch(line); m != nil {
+			level := len(m[1])
+			for len(stack) > 0 && stack[len(stack)-1].level >= level {
+				stack = stack[:len(stack)-1]
+			}
+			inDrawer, properties = false, false
+
+			item, err := parseOrgHeadline(m[2], lineNo, openKeywords, doneKeywords)
+			if err != nil {
+				return nil, err
+			}
+			current = item
+			stack = append(stack, frame{level: level, item: item})
+			if item == nil {
+				continue
+			}
+			var parent *Item
+			for i := len(stack) - 2; i >= 0; i-- {
+				if stack[i].item != nil {
+					parent = stack[i].item
+					break
+				}
+			}
+			if parent == nil {
+				roots = append(roots, item)
+			} else {
+				parent.Children = append(parent.Children, item)
+			}
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if current == nil {
+			// Only file-level settings matter outside a task.
+			if len(stack) == 0 {
+				if open, done, ok := parseOrgTodoSetting(trimmed); ok {
+					openKeywords, doneKeywords = open, done
+				}
+			}
+			continue
+		}
+
+		switch {
+		case inDrawer:
+			if strings.EqualFold(trimmed, ":END:") {
+				inDrawer, properties = false, false
+			} else if properties {
+				if m := orgPropertyRe.FindStringSubmatch(trimmed); m != nil {
+					switch strings.ToUpper(m[1]) {
+					case "ASSIGNEE":
+						current.Assignee = m[2]
+					case "TYPE":
+						current.Type = m[2]
+					}
+				}
+			}
+		case strings.EqualFold(trimmed, ":PROPERTIES:"):
+			inDrawer, properties = true, true
+		case strings.EqualFold(trimmed, ":LOGBOOK:"):
+			inDrawer = true
+		case isOrgPlanningLine(trimmed):
+			for _, m := range orgPlanningRe.FindAllStringSubmatch(trimmed, -1) {
+				t, err := parseOrgTime(m[2], m[3])
+				if err != nil {
+					return nil, fmt.Errorf("line %d: invalid %s date %q", lineNo, m[1], m[2])
+				}
+				switch m[1] {
+				case "SCHEDULED":
+					current.Scheduled = &t
+				case "DEADLINE":
+					current.Due = &t
+				}
+			}
+		default:
+			if trimmed == "" && current.Description == "" {
+				continue
+			}
+			if current.Description != "" {
+				current.Description += "\n"
+			}
+			current.Description += trimmed
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	trimDescriptions(roots)
+	return roots, nil
+}
+
+// parseOrgHeadline parses the text after a headline's stars. It returns
+// nil if the headline has no TODO keyword.
+func parseOrgHeadline(text string, line int, openKeywords, doneKeywords []string) (*Item, error) {
+	keyword, rest, _ := strings.Cut(text, " ")
+	item := &Item{Line: line}
+	switch {
+	case containsString(doneKeywords, keyword):
+		item.Done = true
+	case containsString(openKeywords, keyword):
+	default:
+		return nil, nil
+	}
+
+	rest = strings.TrimSpace(rest)
+	if m := orgPriorityRe.FindStringSubmatch(rest); m != nil {
+		p, ok := OrgPriority(m[1])
+		if !ok {
+			return nil, fmt.Errorf("line %d: unsupported priority [#%s] (expected A, B or C)", line, m[1])
+		}
+		item.Priority = &p
+		rest = rest[len(m[0]):]
+	}
+	if m := orgTagsRe.FindStringSubmatch(" " + rest); m != nil {
+		for _, tag := range strings.Split(strings.Trim(m[1], ":"), ":") {
+			if !containsString(item.Labels, tag) {
+				item.Labels = append(item.Labels, tag)
+			}
+		}
+		rest = strings.TrimSpace(strings.TrimSuffix(" "+rest, m[0]))
+	}
+	item.Title = rest
+	if item.Title == "" {
+		return nil, fmt.Errorf("line %d: headline has no title", line)
+	}
+	return item, nil
+}
+
+// parseOrgTodoSetting parses a "#+TODO: TODO NEXT | DONE" line into its
+// open and done keywords. Fast-access keys ("DONE(d)") are dropped. Without
+// a "|", the last keyword is the done state, as in org-mode.
+func parseOrgTodoSetting(line string) (open, done []string, ok bool) {
+	key, value, found := strings.Cut(line, ":")
+	switch strings.ToUpper(key) {
+	case "#+TODO", "#+SEQ_TODO", "#+TYP_TODO":
+	default:
+		return nil, nil, false
+	}
+	if !found {
+		return nil, nil, false
+	}
+	var words []string
+	split := -1
+	for _, word := range strings.Fields(value) {
+		if word == "|" {
+			split = len(words)
+			continue
+		}
+		if i := strings.IndexByte(word, '('); i > 0 {
+			word = word[:i]
+		}
+		words = append(words, word)
+	}
+	if len(words) == 0 {
+		return nil, nil, false
+	}
+	if split < 0 {
+		split = len(words) - 1
+	}
+	return words[:split], words[split:], true
+}
+
+// isOrgPlanningLine reports whether line holds only planning timestamps.
+func isOrgPlanningLine(line string) bool {
+	return orgPlanningRe.MatchString(line) && strings.TrimSpace(orgPlanningRe.ReplaceAllString(line, "")) == ""
+}
+
+// parseOrgTime parses an org timestamp's date and optional time of day in
+// the local time zone.
+func parseOrgTime(date, clock string) (time.Time, error) {
+	if clock == "" {
+		return time.ParseInLocation("2006-01-02", date, time.Local)
+	}
+	return time.ParseInLocation("2006-01-02 15:04", date+" "+clock, time.Local)
+}
+
+// trimDescriptions removes trailing blank lines left by body text.
+func trimDescriptions(items []*Item) {
+	for _, item := range items {
+		item.Description = strings.TrimRight(item.Description, "\n")
+		trimDescriptions(item.Children)
+	}
+}
@@ -0,0 +1,92 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestParseOrg(t *testing.T) {
+	input := `#+TITLE: Work
+* Projects
+** TODO [#A] Launch site :web:launch:
+   DEADLINE: <2024-01-20 Sat> SCHEDULED: <2024-01-15 Mon 09:30>
+   :PROPERTIES:
+   :ASSIGNEE: alice
+   :TYPE: epic
+   :END:
+   Ship before the conference.
+
+*** DONE Write copy
+    CLOSED: [2024-01-10 Wed 17:00]
+*** Notes
+**** NEXT Pick a font
+* CANCELLED Old idea
+* Just a heading
+`
+	items, err := ParseOrg(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseOrg: %v", err)
+	}
+	if len(items) != 2 || Count(items) != 4 {
+		t.Fatalf("got %d roots / %d items, want 2 / 4", len(items), Count(items))
+	}
+
+	launch := items[0]
+	if launch.Title != "Launch site" || launch.Assignee != "alice" || launch.Type != "epic" || launch.Done || launch.Line != 3 {
+		t.Errorf("launch = %+v", launch)
+	}
+	if launch.Priority == nil || *launch.Priority != issuestorage.PriorityHigh {
+		t.Errorf("launch priority = %v, want P1", launch.Priority)
+	}
+	if strings.Join(launch.Labels, "|") != "web|launch" {
+		t.Errorf("launch labels = %v", launch.Labels)
+	}
+	if launch.Description != "Ship before the conference." {
+		t.Errorf("launch description = %q", launch.Description)
+	}
+	if want := time.Date(2024, 1, 20, 0, 0, 0, 0, time.Local); launch.Due == nil || !launch.Due.Equal(want) {
+		t.Errorf("launch due = %v, want %v", launch.Due, want)
+	}
+	if want := time.Date(2024, 1, 15, 9, 30, 0, 0, time.Local); launch.Scheduled == nil || !launch.Scheduled.Equal(want) {
+		t.Errorf("launch scheduled = %v, want %v", launch.Scheduled, want)
+	}
+
+	if len(launch.Children) != 2 {
+		t.Fatalf("launch children = %d, want 2", len(launch.Children))
+	}
+	if c := launch.Children[0]; c.Title != "Write copy" || !c.Done || c.Description != "" {
+		t.Errorf("copy = %+v", c)
+	}
+	if c := launch.Children[1]; c.Title != "Pick a font" || c.Done {
+		t.Errorf("task under a section heading should attach to the enclosing task: %+v", c)
+	}
+	if old := items[1]; old.Title != "Old idea" || !old.Done {
+		t.Errorf("old = %+v", old)
+	}
+}
+
+func TestParseOrgCustomKeywords(t *testing.T) {
+	input := "#+TODO: TODO(t) REVIEW(r) | SHIPPED(s)\n* REVIEW Check docs\n* SHIPPED Release\n* DONE Not a keyword here\n"
+	items, err := ParseOrg(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseOrg: %v", err)
+	}
+	if len(items) != 2 || items[0].Title != "Check docs" || items[0].Done || !items[1].Done {
+		t.Errorf("items = %+v", items)
+	}
+}
+
+func TestParseOrgErrors(t *testing.T) {
+	for input, want := range map[string]string{
+		"* TODO [#D] Odd\n":                    "line 1: unsupported priority [#D]",
+		"* TODO\n":                             "line 1: headline has no title",
+		"* TODO x\n  DEADLINE: <2024-13-01>\n": "line 2: invalid DEADLINE date",
+	} {
+		if _, err := ParseOrg(strings.NewReader(input)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseOrg(%q) = %v, want error containing %q", input, err, want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// taskwarriorTimeFormat is the UTC timestamp format used by task export.
+const taskwarriorTimeFormat = "20060102T150405Z"
+
+// taskwarriorTask is the subset of a task export record that is imported.
+type taskwarriorTask struct {
+	UUID        string   `json:"uuid"`
+	Description string   `json:"description"`
+	Status      string   `json:"status"`
+	Project     string   `json:"project"`
+	Priority    string   `json:"priority"`
+	Tags        []string `json:"tags"`
+	Due         string   `json:"due"`
+	Scheduled   string   `json:"scheduled"`
+	Wait        string   `json:"wait"`
+	Annotations []struct {
+		Description string `json:"description"`
+	} `json:"annotations"`
+}
+
+// ParseTaskwarrior reads the output of "task export", either a JSON array
+// or one JSON object per line, into items. The description becomes the
+// title and annotations the item's description; completed tasks are done,
+// while deleted tasks and recurrence templates are skipped. Tags and the
+// project become labels, priority H/M/L maps to high/medium/low, and due,
+// scheduled (or wait) set the due and scheduled dates.
+func ParseTaskwarrior(r io.Reader) ([]*Item, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+	}
+
+	var items []*Item
+	for dec.More() {
+		line := lineAt(data, dec.InputOffset())
+		var task taskwarriorTask
+		if err := dec.Decode(&task); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		item, err := taskwarriorItem(&task, line)
+		if err != nil {
+			return nil, err
+		}
+		if item != nil {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// taskwarriorItem converts one task, returning nil for tasks that are not
+// imported.
+func taskwarriorItem(task *taskwarriorTask, line int) (*Item, error) {
+	switch task.Status {
+	case "deleted", "recurring":
+		return nil, nil
+	}
+	item := &Item{
+		Title: strings.TrimSpace(task.Description),
+		Done:  task.Status == "completed",
+		Line:  line,
+	}
+	if item.Title == "" {
+		return nil, fmt.Errorf("line %d: task %s has no description", line, task.UUID)
+	}
+
+	var notes []string
+	for _, a := range task.Annotations {
+		if a.Description != "" {
+			notes = append(notes, a.Description)
+		}
+	}
+	item.Description = strings.Join(notes, "\n")
+
+	for _, label := range append(task.Tags, task.Project) {
+		if label != "" && !containsString(item.Labels, label) {
+			item.Labels = append(item.Labels, label)
+		}
+	}
+
+	if task.Priority != "" {
+		p, ok := TaskwarriorPriority(task.Priority)
+		if !ok {
+			return nil, fmt.Errorf("line %d: unsupported priority %q (expected H, M or L)", line, task.Priority)
+		}
+		item.Priority = &p
+	}
+
+	var err error
+	if item.Due, err = parseTaskwarriorTime(task.Due); err != nil {
+		return nil, fmt.Errorf("line %d: invalid due date %q", line, task.Due)
+	}
+	scheduled := task.Scheduled
+	if scheduled == "" {
+		scheduled = task.Wait
+	}
+	if item.Scheduled, err = parseTaskwarriorTime(scheduled); err != nil {
+		return nil, fmt.Errorf("line %d: invalid scheduled date %q", line, scheduled)
+	}
+	return item, nil
+}
+
+// TaskwarriorPriority maps a Taskwarrior priority (H, M or L) to a priority.
+func TaskwarriorPriority(s string) (issuestorage.Priority, bool) {
+	switch strings.ToUpper(s) {
+	case "H":
+		return issuestorage.PriorityHigh, true
+	case "M":
+		return issuestorage.PriorityMedium, true
+	case "L":
+		return issuestorage.PriorityLow, true
+	}
+	return 0, false
+}
+
+// parseTaskwarriorTime parses an exported timestamp; "" yields nil.
+func parseTaskwarriorTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(taskwarriorTimeFormat, s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// lineAt returns the 1-based line of the first value at or after offset,
+// skipping the whitespace and commas that separate array elements.
+func lineAt(data []byte, offset int64) int {
+	for offset < int64(len(data)) && strings.IndexByte(" \t\r\n,", data[offset]) >= 0 {
+		offset++
+	}
+	return 1 + bytes.Count(data[:offset], []byte("\n"))
+}
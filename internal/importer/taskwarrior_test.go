@@ -0,0 +1,70 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestParseTaskwarrior(t *testing.T) {
+	input := `[
+{"uuid":"a","description":"Renew passport","status":"pending","project":"home","tags":["admin","home"],"priority":"H","due":"20240301T000000Z","annotations":[{"entry":"20240101T000000Z","description":"Photos first"}]},
+{"uuid":"b","description":"File taxes","status":"completed","priority":"L"},
+{"uuid":"c","description":"Old chore","status":"deleted"},
+{"uuid":"d","description":"Water plants","status":"waiting","wait":"20240110T120000Z"}
+]`
+	items, err := ParseTaskwarrior(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseTaskwarrior: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("got %d items, want 3: %+v", len(items), items)
+	}
+
+	passport := items[0]
+	if passport.Title != "Renew passport" || passport.Description != "Photos first" || passport.Done || passport.Line != 2 {
+		t.Errorf("passport = %+v", passport)
+	}
+	if strings.Join(passport.Labels, "|") != "admin|home" {
+		t.Errorf("passport labels = %v", passport.Labels)
+	}
+	if passport.Priority == nil || *passport.Priority != issuestorage.PriorityHigh {
+		t.Errorf("passport priority = %v, want P1", passport.Priority)
+	}
+	if want := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC); passport.Due == nil || !passport.Due.Equal(want) {
+		t.Errorf("passport due = %v, want %v", passport.Due, want)
+	}
+
+	if taxes := items[1]; !taxes.Done || *taxes.Priority != issuestorage.PriorityLow {
+		t.Errorf("taxes = %+v", taxes)
+	}
+	if want := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC); items[2].Scheduled == nil || !items[2].Scheduled.Equal(want) || items[2].Line != 5 {
+		t.Errorf("waiting task should be scheduled for its wait date: %+v", items[2])
+	}
+}
+
+func TestParseTaskwarriorLines(t *testing.T) {
+	input := "{\"description\":\"One\",\"status\":\"pending\"}\n{\"description\":\"Two\",\"status\":\"pending\",\"project\":\"x\"}\n"
+	items, err := ParseTaskwarrior(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseTaskwarrior: %v", err)
+	}
+	if len(items) != 2 || items[1].Title != "Two" || items[1].Line != 2 || strings.Join(items[1].Labels, ",") != "x" {
+		t.Errorf("items = %+v", items)
+	}
+}
+
+func TestParseTaskwarriorErrors(t *testing.T) {
+	for input, want := range map[string]string{
+		`[{"description":"x","status":"pending","priority":"X"}]`:            `line 1: unsupported priority "X"`,
+		`[{"description":"x","status":"pending","due":"tomorrow"}]`:          `line 1: invalid due date "tomorrow"`,
+		"[\n{\"uuid\":\"u1\",\"description\":\" \",\"status\":\"pending\"}]": "line 2: task u1 has no description",
+		`{"description":`: "line 1:",
+	} {
+		if _, err := ParseTaskwarrior(strings.NewReader(input)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseTaskwarrior(%q) = %v, want error containing %q", input, err, want)
+		}
+	}
+}
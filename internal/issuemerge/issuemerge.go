@@ -0,0 +1,347 @@
+// Package issuemerge merges concurrent edits to an issue three ways, the
+// way a git merge driver for issue files needs to: each side's changes
+// against the common ancestor are kept, and where both sides changed the
+// same thing the side updated later wins.
+package issuemerge
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// Merge combines ours and theirs, two edits of base, into one issue. base
+// may be nil when both sides created the file independently.
+//
+// Scalar fields take the side that changed them; when both sides did, the
+// side with the later UpdatedAt wins, ours on a tie. The status and the
+// fields recorded with it (closed_at, close reason, resolution, tombstone
+// fields) move as a group so a merge never pairs one side's status with
+// the other's close details. Labels, subscribers and waiters merge as
+// sets, dependencies and dependents by issue ID, acceptance criteria by
+// text, attachments by content, external references by tracker and vars by
+// name. Comments merge by UUID, so their IDs are only display numbers:
+// where both sides added a comment under the same ID, the later one is
+// renumbered after the highest ID. History and reviews keep every entry
+// from either side, ordered by time. Time logged on either side is added
+// up. UpdatedAt is the later of the two.
+func Merge(base, ours, theirs *issuestorage.Issue) *issuestorage.Issue {
+	if base == nil {
+		base = &issuestorage.Issue{}
+	}
+	m := &mergeState{theirsWins: theirs.UpdatedAt.After(ours.UpdatedAt)}
+
+	merged := *ours
+	merged.ID = pick(m, base.ID, ours.ID, theirs.ID)
+	merged.Title = pick(m, base.Title, ours.Title, theirs.Title)
+	merged.Description = pick(m, base.Description, ours.Description, theirs.Description)
+	merged.Priority = pick(m, base.Priority, ours.Priority, theirs.Priority)
+	merged.Severity = pick(m, base.Severity, ours.Severity, theirs.Severity)
+	merged.Type = pick(m, base.Type, ours.Type, theirs.Type)
+	merged.MolType = pick(m, base.MolType, ours.MolType, theirs.MolType)
+	merged.Rank = pick(m, base.Rank, ours.Rank, theirs.Rank)
+	merged.Parent = pick(m, base.Parent, ours.Parent, theirs.Parent)
+	merged.CreatedBy = pick(m, base.CreatedBy, ours.CreatedBy, theirs.CreatedBy)
+	merged.Owner = pick(m, base.Owner, ours.Owner, theirs.Owner)
+	merged.Reporter = pick(m, base.Reporter, ours.Reporter, theirs.Reporter)
+	merged.Assignee = pick(m, base.Assignee, ours.Assignee, theirs.Assignee)
+	merged.Reviewer = pick(m, base.Reviewer, ours.Reviewer, theirs.Reviewer)
+	merged.Ephemeral = pick(m, base.Ephemeral, ours.Ephemeral, theirs.Ephemeral)
+	merged.CreatedAt = pick(m, base.CreatedAt, ours.CreatedAt, theirs.CreatedAt)
+	merged.DueAt = pick(m, base.DueAt, ours.DueAt, theirs.DueAt)
+	merged.DueEvent = pick(m, base.DueEvent, ours.DueEvent, theirs.DueEvent)
+	merged.DeferUntil = pick(m, base.DeferUntil, ours.DeferUntil, theirs.DeferUntil)
+	merged.Estimate = pick(m, base.Estimate, ours.Estimate, theirs.Estimate)
+	merged.Milestone = pick(m, base.Milestone, ours.Milestone, theirs.Milestone)
+	merged.Sprint = pick(m, base.Sprint, ours.Sprint, theirs.Sprint)
+	merged.AwaitType = pick(m, base.AwaitType, ours.AwaitType, theirs.AwaitType)
+	merged.AwaitID = pick(m, base.AwaitID, ours.AwaitID, theirs.AwaitID)
+	merged.TimeoutNS = pick(m, base.TimeoutNS, ours.TimeoutNS, theirs.TimeoutNS)
+	merged.Likelihood = pick(m, base.Likelihood, ours.Likelihood, theirs.Likelihood)
+	merged.Impact = pick(m, base.Impact, ours.Impact, theirs.Impact)
+	merged.ReviewBy = pick(m, base.ReviewBy, ours.ReviewBy, theirs.ReviewBy)
+	merged.DecisionState = pick(m, base.DecisionState, ours.DecisionState, theirs.DecisionState)
+	merged.ReopenCount = max(ours.ReopenCount, theirs.ReopenCount)
+	merged.TimeSpent = max(ours.TimeSpent+theirs.TimeSpent-base.TimeSpent, 0)
+	merged.UpdatedAt = ours.UpdatedAt
+	if m.theirsWins {
+		merged.UpdatedAt = theirs.UpdatedAt
+	}
+	setStatusGroup(&merged, pick(m, statusGroupOf(base), statusGroupOf(ours), statusGroupOf(theirs)))
+
+	self := func(s string) string { return s }
+	merged.Labels = mergeList(m, base.Labels, ours.Labels, theirs.Labels, self)
+	merged.Subscribers = mergeList(m, base.Subscribers, ours.Subscribers, theirs.Subscribers, self)
+	merged.Waiters = mergeList(m, base.Waiters, ours.Waiters, theirs.Waiters, self)
+
+	depID := func(d issuestorage.Dependency) string { return d.ID }
+	merged.Dependencies = mergeList(m, base.Dependencies, ours.Dependencies, theirs.Dependencies, depID)
+	merged.Dependents = mergeList(m, base.Dependents, ours.Dependents, theirs.Dependents, depID)
+
+	merged.AcceptanceCriteria = mergeList(m, base.AcceptanceCriteria, ours.AcceptanceCriteria, theirs.AcceptanceCriteria,
+		func(c issuestorage.Criterion) string { return c.Text })
+	merged.Attachments = mergeList(m, base.Attachments, ours.Attachments, theirs.Attachments,
+		func(a issuestorage.Attachment) string { return a.SHA256 + "\x00" + a.Name })
+
+	merged.Comments, merged.AcceptedAnswer = mergeComments(m, base, ours, theirs)
+
+	merged.History = mergeList(m, base.History, ours.History, theirs.History, jsonKey[issuestorage.HistoryEntry])
+	sortByTime(merged.History, func(h issuestorage.HistoryEntry) time.Time { return h.At })
+	merged.Reviews = mergeList(m, base.Reviews, ours.Reviews, theirs.Reviews, jsonKey[issuestorage.Review])
+	sortByTime(merged.Reviews, func(r issuestorage.Review) time.Time { return r.At })
+	merged.ExternalRefs = mergeMap(m, base.ExternalRefs, ours.ExternalRefs, theirs.ExternalRefs)
+	merged.Vars = mergeMap(m, base.Vars, ours.Vars, theirs.Vars)
+
+	return &merged
+}
+
+// mergedFields are the fields Merge combines item by item rather than
+// choosing one side's value, or derives from both sides.
+var mergedFields = map[string]bool{
+	"updated_at": true, "reopen_count": true, "time_spent_minutes": true,
+	"labels": true, "subscribers": true, "waiters": true,
+	"dependencies": true, "dependents": true,
+	"acceptance_criteria": true, "attachments": true,
+	"comments": true, "accepted_answer": true, "history": true, "reviews": true,
+	"external_refs": true, "vars": true,
+}
+
+// Conflicts returns the fields, by JSON name and in order, that ours and
+// theirs both changed from base to different values. Merge keeps the value
+// of the side updated later for each, so the other side's edit is lost.
+func Conflicts(base, ours, theirs *issuestorage.Issue) []string {
+	if base == nil {
+		base = &issuestorage.Issue{}
+	}
+	b, o, t := fieldsOf(base), fieldsOf(ours), fieldsOf(theirs)
+	keys := make(map[string]bool)
+	for _, fields := range []map[string]json.RawMessage{b, o, t} {
+		for k := range fields {
+			if !mergedFields[k] {
+				keys[k] = true
+			}
+		}
+	}
+	var conflicts []string
+	for k := range keys {
+		if !bytes.Equal(o[k], b[k]) && !bytes.Equal(t[k], b[k]) && !bytes.Equal(o[k], t[k]) {
+			conflicts = append(conflicts, k)
+		}
+	}
+	sort.Strings(conflicts)
+	return conflicts
+}
+
+func fieldsOf(issue *issuestorage.Issue) map[string]json.RawMessage {
+	data, _ := json.Marshal(issue)
+	var fields map[string]json.RawMessage
+	json.Unmarshal(data, &fields)
+	return fields
+}
+
+// mergeState carries what every field merge needs to know: which side
+// wins when both changed the same thing.
+type mergeState struct {
+	theirsWins bool
+}
+
+// pick merges one value three ways.
+func pick[T any](m *mergeState, base, ours, theirs T) T {
+	switch {
+	case equal(ours, theirs), equal(theirs, base):
+		return ours
+	case equal(ours, base):
+		return theirs
+	case m.theirsWins:
+		return theirs
+	default:
+		return ours
+	}
+}
+
+// mergeList merges a list whose items are identified by key. The result
+// keeps ours' order, followed by the items only theirs added. An item one
+// side removed stays removed unless the other side changed it.
+func mergeList[T any, K comparable](m *mergeState, base, ours, theirs []T, key func(T) K) []T {
+	baseByKey := indexBy(base, key)
+	oursByKey := indexBy(ours, key)
+	theirsByKey := indexBy(theirs, key)
+
+	var merged []T
+	for _, o := range ours {
+		k := key(o)
+		b, inBase := baseByKey[k]
+		if t, ok := theirsByKey[k]; ok {
+			if inBase {
+				merged = append(merged, pick(m, b, o, t))
+			} else if m.theirsWins {
+				merged = append(merged, t)
+			} else {
+				merged = append(merged, o)
+			}
+			continue
+		}
+		if !inBase || !equal(o, b) {
+			merged = append(merged, o)
+		}
+	}
+	for _, t := range theirs {
+		k := key(t)
+		if _, ok := oursByKey[k]; ok {
+			continue
+		}
+		if b, inBase := baseByKey[k]; !inBase || !equal(t, b) {
+			merged = append(merged, t)
+		}
+	}
+	return merged
+}
+
+// mergeMap merges a map key by key, a missing key counting as "".
+func mergeMap(m *mergeState, base, ours, theirs map[string]string) map[string]string {
+	var merged map[string]string
+	for _, side := range []map[string]string{base, ours, theirs} {
+		for k := range side {
+			if v := pick(m, base[k], ours[k], theirs[k]); v != "" {
+				if merged == nil {
+					merged = make(map[string]string)
+				}
+				merged[k] = v
+			}
+		}
+	}
+	return merged
+}
+
+// mergeComments merges the comments of base, ours and theirs by
+// Comment.Key, and returns them ordered by ID with the ID of the merged
+// accepted answer. A comment's ID is merged like a scalar, so one side
+// renumbering it is kept. Where two comments end up with the same ID,
+// the earlier created keeps it and the other moves after the highest ID.
+func mergeComments(m *mergeState, base, ours, theirs *issuestorage.Issue) ([]issuestorage.Comment, int) {
+	key := issuestorage.Comment.Key
+	baseByKey := indexBy(base.Comments, key)
+	oursByKey := indexBy(ours.Comments, key)
+	theirsByKey := indexBy(theirs.Comments, key)
+
+	merged := mergeList(m, base.Comments, ours.Comments, theirs.Comments, key)
+	for i, c := range merged {
+		b, inBase := baseByKey[c.Key()]
+		o, inOurs := oursByKey[c.Key()]
+		t, inTheirs := theirsByKey[c.Key()]
+		if inOurs && inTheirs {
+			if !inBase {
+				b = o
+			}
+			merged[i].ID = pick(m, b.ID, o.ID, t.ID)
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		a, b := merged[i], merged[j]
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.Key() < b.Key()
+	})
+	next := 0
+	for _, c := range merged {
+		next = max(next, c.ID)
+	}
+	used := make(map[int]bool, len(merged))
+	for i := range merged {
+		if used[merged[i].ID] {
+			next++
+			merged[i].ID = next
+		}
+		used[merged[i].ID] = true
+	}
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].ID < merged[j].ID })
+
+	answerKey := func(issue *issuestorage.Issue) string {
+		if c := issue.Answer(); c != nil {
+			return c.Key()
+		}
+		return ""
+	}
+	answer := pick(m, answerKey(base), answerKey(ours), answerKey(theirs))
+	for _, c := range merged {
+		if answer != "" && c.Key() == answer {
+			return merged, c.ID
+		}
+	}
+	return merged, 0
+}
+
+// statusGroup is the status together with the fields set alongside it
+// when an issue is closed or deleted.
+type statusGroup struct {
+	Status       issuestorage.Status
+	ClosedAt     *time.Time
+	CloseReason  string
+	Resolution   issuestorage.Resolution
+	DuplicateOf  string
+	DeletedAt    *time.Time
+	DeletedBy    string
+	DeleteReason string
+	OriginalType issuestorage.IssueType
+}
+
+func statusGroupOf(i *issuestorage.Issue) statusGroup {
+	return statusGroup{
+		Status:       i.Status,
+		ClosedAt:     i.ClosedAt,
+		CloseReason:  i.CloseReason,
+		Resolution:   i.Resolution,
+		DuplicateOf:  i.DuplicateOf,
+		DeletedAt:    i.DeletedAt,
+		DeletedBy:    i.DeletedBy,
+		DeleteReason: i.DeleteReason,
+		OriginalType: i.OriginalType,
+	}
+}
+
+func setStatusGroup(i *issuestorage.Issue, g statusGroup) {
+	i.Status = g.Status
+	i.ClosedAt = g.ClosedAt
+	i.CloseReason = g.CloseReason
+	i.Resolution = g.Resolution
+	i.DuplicateOf = g.DuplicateOf
+	i.DeletedAt = g.DeletedAt
+	i.DeletedBy = g.DeletedBy
+	i.DeleteReason = g.DeleteReason
+	i.OriginalType = g.OriginalType
+}
+
+func indexBy[T any, K comparable](items []T, key func(T) K) map[K]T {
+	byKey := make(map[K]T, len(items))
+	for _, item := range items {
+		byKey[key(item)] = item
+	}
+	return byKey
+}
+
+func sortByTime[T any](items []T, at func(T) time.Time) {
+	sort.SliceStable(items, func(i, j int) bool { return at(items[i]).Before(at(items[j])) })
+}
+
+// jsonKey identifies an append-only entry by its whole content.
+func jsonKey[T any](v T) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+// equal compares values as they are written to an issue file, so times
+// parsed from different files compare equal when they name the same
+// instant in the same form.
+func equal(a, b any) bool {
+	da, errA := json.Marshal(a)
+	db, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(da, db)
+}
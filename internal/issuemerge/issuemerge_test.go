@@ -0,0 +1,234 @@
+package issuemerge
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+var t0 = time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+
+func baseIssue() *issuestorage.Issue {
+	return &issuestorage.Issue{
+		ID:        "bd-a1b2",
+		Title:     "Fix login",
+		Status:    issuestorage.StatusOpen,
+		Priority:  issuestorage.PriorityMedium,
+		Type:      issuestorage.TypeBug,
+		Labels:    []string{"auth", "web"},
+		Comments:  []issuestorage.Comment{{ID: 1, Author: "alice", Text: "Seen on staging", CreatedAt: t0}},
+		CreatedAt: t0,
+		UpdatedAt: t0,
+	}
+}
+
+// edit returns a copy of base updated at the given offset from t0.
+func edit(base *issuestorage.Issue, after time.Duration, change func(*issuestorage.Issue)) *issuestorage.Issue {
+	copied := *base
+	copied.Labels = append([]string(nil), base.Labels...)
+	copied.Comments = append([]issuestorage.Comment(nil), base.Comments...)
+	copied.Dependencies = append([]issuestorage.Dependency(nil), base.Dependencies...)
+	copied.Dependents = append([]issuestorage.Dependency(nil), base.Dependents...)
+	copied.UpdatedAt = t0.Add(after)
+	change(&copied)
+	return &copied
+}
+
+func TestMerge_Fields(t *testing.T) {
+	base := baseIssue()
+	ours := edit(base, time.Hour, func(i *issuestorage.Issue) {
+		i.Title = "Fix login redirect"
+		i.Priority = issuestorage.PriorityHigh
+	})
+	theirs := edit(base, 2*time.Hour, func(i *issuestorage.Issue) {
+		i.Description = "Redirect loops after SSO"
+		i.Priority = issuestorage.PriorityLow
+	})
+
+	got := Merge(base, ours, theirs)
+	if got.Title != "Fix login redirect" || got.Description != "Redirect loops after SSO" {
+		t.Errorf("one-sided changes lost: title %q, description %q", got.Title, got.Description)
+	}
+	if got.Priority != issuestorage.PriorityLow {
+		t.Errorf("priority = %v, want theirs (updated later)", got.Priority)
+	}
+	if !got.UpdatedAt.Equal(theirs.UpdatedAt) {
+		t.Errorf("updated_at = %v, want the later side", got.UpdatedAt)
+	}
+
+	if got := Conflicts(base, ours, theirs); !reflect.DeepEqual(got, []string{"priority"}) {
+		t.Errorf("conflicts = %q, want [priority]", got)
+	}
+
+	// With ours updated later, ours wins the same conflict.
+	ours.UpdatedAt = t0.Add(3 * time.Hour)
+	if got := Merge(base, ours, theirs); got.Priority != issuestorage.PriorityHigh {
+		t.Errorf("priority = %v, want ours (updated later)", got.Priority)
+	}
+}
+
+func TestMerge_StatusMovesAsGroup(t *testing.T) {
+	base := baseIssue()
+	closed := t0.Add(time.Hour)
+	ours := edit(base, time.Hour, func(i *issuestorage.Issue) {
+		i.Status = issuestorage.StatusClosed
+		i.ClosedAt = &closed
+		i.CloseReason = "fixed in #12"
+	})
+	theirs := edit(base, 2*time.Hour, func(i *issuestorage.Issue) {
+		i.Status = issuestorage.StatusInProgress
+	})
+	got := Merge(base, ours, theirs)
+	if got.Status != issuestorage.StatusInProgress || got.ClosedAt != nil || got.CloseReason != "" {
+		t.Errorf("status group = %s, %v, %q; want theirs whole", got.Status, got.ClosedAt, got.CloseReason)
+	}
+}
+
+func TestMerge_Labels(t *testing.T) {
+	base := baseIssue()
+	ours := edit(base, time.Hour, func(i *issuestorage.Issue) { i.Labels = []string{"auth", "web", "p1"} })
+	theirs := edit(base, 2*time.Hour, func(i *issuestorage.Issue) { i.Labels = []string{"auth", "sso"} })
+	if got := Merge(base, ours, theirs).Labels; !reflect.DeepEqual(got, []string{"auth", "p1", "sso"}) {
+		t.Errorf("labels = %q", got)
+	}
+}
+
+func TestMerge_Dependencies(t *testing.T) {
+	base := baseIssue()
+	base.Dependencies = []issuestorage.Dependency{{ID: "bd-old", Type: issuestorage.DepTypeBlocks}}
+	ours := edit(base, time.Hour, func(i *issuestorage.Issue) {
+		i.Dependencies = append(i.Dependencies, issuestorage.Dependency{ID: "bd-x", Type: issuestorage.DepTypeBlocks})
+		i.Dependents = []issuestorage.Dependency{{ID: "bd-epic", Type: issuestorage.DepTypeParentChild}}
+	})
+	theirs := edit(base, 2*time.Hour, func(i *issuestorage.Issue) {
+		i.Dependencies = []issuestorage.Dependency{{ID: "bd-y", Type: issuestorage.DepTypeRelated}}
+		i.Dependents = []issuestorage.Dependency{{ID: "bd-z", Type: issuestorage.DepTypeBlocks}}
+	})
+	got := Merge(base, ours, theirs)
+	wantDeps := []issuestorage.Dependency{{ID: "bd-x", Type: issuestorage.DepTypeBlocks}, {ID: "bd-y", Type: issuestorage.DepTypeRelated}}
+	if !reflect.DeepEqual(got.Dependencies, wantDeps) {
+		t.Errorf("dependencies = %+v, want %+v", got.Dependencies, wantDeps)
+	}
+	wantDependents := []issuestorage.Dependency{{ID: "bd-epic", Type: issuestorage.DepTypeParentChild}, {ID: "bd-z", Type: issuestorage.DepTypeBlocks}}
+	if !reflect.DeepEqual(got.Dependents, wantDependents) {
+		t.Errorf("dependents = %+v, want %+v", got.Dependents, wantDependents)
+	}
+
+	// Swapping sides yields the same set.
+	swapped := Merge(base, theirs, ours)
+	if len(swapped.Dependencies) != 2 || len(swapped.Dependents) != 2 {
+		t.Errorf("swapped merge = %+v / %+v", swapped.Dependencies, swapped.Dependents)
+	}
+}
+
+func TestMerge_Comments(t *testing.T) {
+	base := baseIssue()
+	ours := edit(base, time.Hour, func(i *issuestorage.Issue) {
+		i.Comments = append(i.Comments, issuestorage.Comment{ID: 2, Author: "bob", Text: "Working on it", CreatedAt: t0.Add(time.Hour)})
+	})
+	theirs := edit(base, 2*time.Hour, func(i *issuestorage.Issue) {
+		i.Comments = append(i.Comments, issuestorage.Comment{ID: 2, Author: "carol", Text: "Try clearing cookies", CreatedAt: t0.Add(2 * time.Hour)})
+		i.AcceptedAnswer = 2
+	})
+	got := Merge(base, ours, theirs)
+	var texts []string
+	for _, c := range got.Comments {
+		texts = append(texts, c.Text)
+	}
+	if !reflect.DeepEqual(texts, []string{"Seen on staging", "Working on it", "Try clearing cookies"}) {
+		t.Fatalf("comments = %q", texts)
+	}
+	if got.Comments[2].ID != 3 || got.AcceptedAnswer != 3 {
+		t.Errorf("theirs' comment renumbered to %d, accepted answer %d; want 3, 3", got.Comments[2].ID, got.AcceptedAnswer)
+	}
+}
+
+func TestMerge_CommentUUIDs(t *testing.T) {
+	base := baseIssue()
+	ours := edit(base, time.Hour, func(i *issuestorage.Issue) {
+		i.Comments = append(i.Comments, issuestorage.Comment{ID: 2, UUID: "uuid-bob", Author: "bob", Text: "Working on it", CreatedAt: t0.Add(time.Hour)})
+	})
+	theirs := edit(base, 2*time.Hour, func(i *issuestorage.Issue) {
+		i.Comments = append(i.Comments, issuestorage.Comment{ID: 2, UUID: "uuid-carol", Author: "carol", Text: "Try clearing cookies", CreatedAt: t0.Add(2 * time.Hour)})
+	})
+	merged := Merge(base, ours, theirs)
+
+	// Carol comments again before pulling the merge, which numbered her
+	// first comment 3, the number she just used.
+	more := edit(theirs, 3*time.Hour, func(i *issuestorage.Issue) {
+		i.Comments = append(i.Comments, issuestorage.Comment{ID: 3, UUID: "uuid-carol-2", Author: "carol", Text: "Or a private window", CreatedAt: t0.Add(3 * time.Hour)})
+		i.AcceptedAnswer = 3
+	})
+	got := Merge(theirs, more, merged)
+	var texts []string
+	for i, c := range got.Comments {
+		if c.ID != i+1 {
+			t.Errorf("comment %q has ID %d, want %d", c.Text, c.ID, i+1)
+		}
+		texts = append(texts, c.Text)
+	}
+	if want := []string{"Seen on staging", "Working on it", "Try clearing cookies", "Or a private window"}; !reflect.DeepEqual(texts, want) {
+		t.Fatalf("comments = %q, want %q", texts, want)
+	}
+	if got.AcceptedAnswer != 4 {
+		t.Errorf("accepted answer = %d, want 4", got.AcceptedAnswer)
+	}
+}
+
+func TestMerge_NoBase(t *testing.T) {
+	ours := &issuestorage.Issue{ID: "bd-n", Title: "Ours", Labels: []string{"a"}, UpdatedAt: t0}
+	theirs := &issuestorage.Issue{ID: "bd-n", Title: "Theirs", Labels: []string{"b"}, UpdatedAt: t0.Add(time.Minute)}
+	got := Merge(nil, ours, theirs)
+	if got.Title != "Theirs" || !reflect.DeepEqual(got.Labels, []string{"a", "b"}) {
+		t.Errorf("merge without base = %q %q", got.Title, got.Labels)
+	}
+}
+
+func TestMerge_HistoryUnion(t *testing.T) {
+	base := baseIssue()
+	ours := edit(base, time.Hour, func(i *issuestorage.Issue) {
+		i.History = []issuestorage.HistoryEntry{{At: t0.Add(time.Hour), Event: "updated", Field: "title"}}
+	})
+	theirs := edit(base, 2*time.Hour, func(i *issuestorage.Issue) {
+		i.History = []issuestorage.HistoryEntry{{At: t0.Add(30 * time.Minute), Event: "updated", Field: "priority"}}
+	})
+	got := Merge(base, ours, theirs).History
+	if len(got) != 2 || got[0].Field != "priority" || got[1].Field != "title" {
+		t.Errorf("history = %+v", got)
+	}
+}
+
+func TestMerge_ExternalRefs(t *testing.T) {
+	base := baseIssue()
+	ours := edit(base, time.Hour, func(i *issuestorage.Issue) { i.ExternalRefs = map[string]string{"github": "acme/web#12"} })
+	theirs := edit(base, 2*time.Hour, func(i *issuestorage.Issue) { i.ExternalRefs = map[string]string{"jira": "WEB-7"} })
+	want := map[string]string{"github": "acme/web#12", "jira": "WEB-7"}
+	if got := Merge(base, ours, theirs).ExternalRefs; !reflect.DeepEqual(got, want) {
+		t.Errorf("external refs = %v, want %v", got, want)
+	}
+}
+
+func TestMerge_TimeSpentAdds(t *testing.T) {
+	base := edit(baseIssue(), 0, func(i *issuestorage.Issue) { i.Estimate, i.TimeSpent = 240, 60 })
+	ours := edit(base, time.Hour, func(i *issuestorage.Issue) { i.TimeSpent += 45 })
+	theirs := edit(base, 2*time.Hour, func(i *issuestorage.Issue) { i.TimeSpent += 30; i.Estimate = 300 })
+	got := Merge(base, ours, theirs)
+	if got.TimeSpent != 135 || got.Estimate != 300 {
+		t.Errorf("time spent %d, estimate %d; want 135 logged on both sides, estimate 300", got.TimeSpent, got.Estimate)
+	}
+	if c := Conflicts(base, ours, theirs); len(c) != 0 {
+		t.Errorf("conflicts = %q, want none for logged time", c)
+	}
+}
+
+func TestMerge_Vars(t *testing.T) {
+	base := edit(baseIssue(), 0, func(i *issuestorage.Issue) { i.Vars = map[string]string{"DEPLOY_ENV": "staging", "DRY_RUN": "1"} })
+	ours := edit(base, time.Hour, func(i *issuestorage.Issue) { i.Vars = map[string]string{"DEPLOY_ENV": "production", "DRY_RUN": "1"} })
+	theirs := edit(base, 2*time.Hour, func(i *issuestorage.Issue) { i.Vars = map[string]string{"DEPLOY_ENV": "staging", "REGION": "eu"} })
+	want := map[string]string{"DEPLOY_ENV": "production", "REGION": "eu"}
+	if got := Merge(base, ours, theirs).Vars; !reflect.DeepEqual(got, want) {
+		t.Errorf("vars = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,156 @@
+package issueservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// Aliases looks up vanity aliases; see SetAliases.
+type Aliases interface {
+	// Resolve returns the issue ID the alias name stands for.
+	Resolve(ctx context.Context, name string) (string, bool)
+	// Match returns the aliases whose slug, the part after the issue
+	// prefix, is slug, mapped to the issue IDs they stand for.
+	Match(ctx context.Context, slug string) map[string]string
+}
+
+// refShorthands maps the prefixes accepted for synced remote issues, as
+// in GH-123, to the external_refs key their refs are kept under.
+var refShorthands = map[string]string{
+	"gh": "github",
+	"gl": "gitlab",
+}
+
+// AmbiguousIDError is returned when an ID that is not an issue's matches
+// several issues by alias slug or remote ID.
+type AmbiguousIDError struct {
+	ID         string
+	Candidates []string // each issue ID with what matched, e.g. "bd-a1b2 (github acme/app#12)"
+}
+
+func (e *AmbiguousIDError) Error() string {
+	return fmt.Sprintf("ambiguous ID %q matches multiple issues: %s", e.ID, strings.Join(e.Candidates, ", "))
+}
+
+// SetAliases sets where vanity aliases are looked up, so that Get, Modify,
+// Delete and the dependency operations accept aliases wherever they accept
+// IDs. Dependencies are always stored by real ID.
+func (s *IssueStore) SetAliases(aliases Aliases) {
+	s.aliases = aliases
+}
+
+// resolveAlias returns the issue ID id stands for if it is an alias, and
+// id itself otherwise.
+func (s *IssueStore) resolveAlias(ctx context.Context, id string) string {
+	if s.aliases == nil {
+		return id
+	}
+	if target, ok := s.aliases.Resolve(ctx, id); ok {
+		return target
+	}
+	return id
+}
+
+// resolveID returns the issue ID id stands for: id itself if it is an
+// issue's ID or alias, otherwise the one issue whose alias slug or synced
+// remote ID it is. Returns ErrNotFound if nothing matches, and an
+// AmbiguousIDError if several issues do.
+func (s *IssueStore) resolveID(ctx context.Context, id string) (string, error) {
+	id = s.resolveAlias(ctx, id)
+	_, err := s.storeFor(id).Get(ctx, id)
+	if !errors.Is(err, issuestorage.ErrNotFound) {
+		return id, err
+	}
+	return s.lookupID(ctx, id)
+}
+
+// lookupID finds the issue whose alias slug or synced remote ID is id, for
+// an id that is not an issue's ID or alias.
+func (s *IssueStore) lookupID(ctx context.Context, id string) (string, error) {
+	matches := make(map[string][]string) // issue ID -> what matched
+	if s.aliases != nil {
+		for name, target := range s.aliases.Match(ctx, id) {
+			matches[target] = append(matches[target], "alias "+name)
+		}
+	}
+	if looksLikeRemoteID(id) {
+		issues, err := s.listWithClosed(ctx)
+		if err != nil {
+			return "", err
+		}
+		for _, issue := range issues {
+			for _, key := range sortedRefKeys(issue.ExternalRefs) {
+				if ref := issue.ExternalRefs[key]; remoteIDMatches(id, key, ref) {
+					matches[issue.ID] = append(matches[issue.ID], key+" "+ref)
+				}
+			}
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", issuestorage.ErrNotFound
+	case 1:
+		for target := range matches {
+			return target, nil
+		}
+	}
+	ambiguous := &AmbiguousIDError{ID: id}
+	for target, via := range matches {
+		ambiguous.Candidates = append(ambiguous.Candidates, fmt.Sprintf("%s (%s)", target, strings.Join(via, ", ")))
+	}
+	sort.Strings(ambiguous.Candidates)
+	return "", ambiguous
+}
+
+// looksLikeRemoteID reports whether id could be a synced remote ID: a ref
+// such as acme/app#12, or a tracker key and number such as GH-12 or
+// PROJ-123.
+func looksLikeRemoteID(id string) bool {
+	if strings.Contains(id, "#") {
+		return true
+	}
+	project, number, ok := strings.Cut(id, "-")
+	return ok && project != "" && number != "" && strings.Trim(number, "0123456789") == ""
+}
+
+// remoteIDMatches reports whether id names the remote issue ref, kept
+// under the external_refs key key: the ref itself, ignoring case, or a
+// shorthand such as GH-12 for a github ref ending in #12.
+func remoteIDMatches(id, key, ref string) bool {
+	if strings.EqualFold(id, ref) {
+		return true
+	}
+	shorthand, number, ok := strings.Cut(id, "-")
+	if !ok || refShorthands[strings.ToLower(shorthand)] != key {
+		return false
+	}
+	return strings.HasSuffix(ref, "#"+number)
+}
+
+func sortedRefKeys(refs map[string]string) []string {
+	keys := make([]string, 0, len(refs))
+	for k := range refs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// listWithClosed lists the local issues, open and closed, leaving out
+// tombstones.
+func (s *IssueStore) listWithClosed(ctx context.Context) ([]*issuestorage.Issue, error) {
+	open, err := s.local.List(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	closed, err := s.local.List(ctx, &issuestorage.ListFilter{Statuses: []issuestorage.Status{issuestorage.StatusClosed}})
+	if err != nil {
+		return nil, err
+	}
+	return append(open, closed...), nil
+}
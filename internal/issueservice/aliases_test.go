@@ -0,0 +1,85 @@
+package issueservice
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// fakeAliases is an in-memory Aliases keyed by alias name.
+type fakeAliases map[string]string
+
+func (f fakeAliases) Resolve(ctx context.Context, name string) (string, bool) {
+	id, ok := f[name]
+	return id, ok
+}
+
+func (f fakeAliases) Match(ctx context.Context, slug string) map[string]string {
+	matches := make(map[string]string)
+	for name, id := range f {
+		if _, rest, ok := strings.Cut(name, "-"); ok && rest == slug {
+			matches[name] = id
+		}
+	}
+	return matches
+}
+
+func TestResolveAliasSlugAndRemoteID(t *testing.T) {
+	ctx := context.Background()
+	s := newTestIssueService(t)
+
+	a, _ := s.Create(ctx, &issuestorage.Issue{Title: "A", ExternalRefs: map[string]string{"github": "acme/app#123"}})
+	b, _ := s.Create(ctx, &issuestorage.Issue{Title: "B", ExternalRefs: map[string]string{"jira": "PROJ-7"}})
+	c, _ := s.Create(ctx, &issuestorage.Issue{Title: "C", ExternalRefs: map[string]string{"github": "acme/lib#9"}})
+	d, _ := s.Create(ctx, &issuestorage.Issue{Title: "D", ExternalRefs: map[string]string{"github": "acme/web#9"}})
+	s.SetAliases(fakeAliases{"bd-login-rewrite": a, "bd-dup": b, "ops-dup": c})
+
+	for _, id := range []string{"bd-login-rewrite", "login-rewrite", "GH-123", "acme/app#123"} {
+		issue, err := s.Get(ctx, id)
+		if err != nil || issue.ID != a {
+			t.Errorf("Get(%q) = %v, %v; want %s", id, issue, err, a)
+		}
+	}
+	if issue, err := s.Get(ctx, "proj-7"); err != nil || issue.ID != b {
+		t.Errorf("Get(proj-7) = %v, %v; want %s", issue, err, b)
+	}
+
+	if err := s.Modify(ctx, "login-rewrite", func(issue *issuestorage.Issue) error {
+		issue.Title = "A renamed"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if issue, _ := s.Get(ctx, a); issue.Title != "A renamed" {
+		t.Errorf("Modify by slug did not change %s: %q", a, issue.Title)
+	}
+	if err := s.AddDependency(ctx, "GH-123", "proj-7", issuestorage.DepTypeBlocks); err != nil {
+		t.Fatal(err)
+	}
+	if issue, _ := s.Get(ctx, a); len(issue.Dependencies) != 1 || issue.Dependencies[0].ID != b {
+		t.Errorf("dependency not stored by real ID: %+v", issue.Dependencies)
+	}
+
+	for id, want := range map[string][]string{
+		"dup":  {b + " (alias bd-dup)", c + " (alias ops-dup)"},
+		"GH-9": {c + " (github acme/lib#9)", d + " (github acme/web#9)"},
+	} {
+		_, err := s.Get(ctx, id)
+		var ambiguous *AmbiguousIDError
+		if !errors.As(err, &ambiguous) {
+			t.Fatalf("Get(%q) error = %v, want AmbiguousIDError", id, err)
+		}
+		sort.Strings(want)
+		if strings.Join(ambiguous.Candidates, "|") != strings.Join(want, "|") {
+			t.Errorf("Get(%q) candidates = %v, want %v", id, ambiguous.Candidates, want)
+		}
+	}
+
+	if _, err := s.Get(ctx, "GH-404"); !errors.Is(err, issuestorage.ErrNotFound) {
+		t.Errorf("Get(GH-404) error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,75 @@
+package issueservice
+
+import (
+	"fmt"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// SetBacklinkComments makes AddDependency and RemoveDependency comment on
+// both issues when a dependency changes, so each issue's comments show its
+// relationships changing.
+func (s *IssueStore) SetBacklinkComments(enabled bool) {
+	s.backlinkComments = enabled
+}
+
+// backlinkTexts returns the comments for a dependency of from on to being
+// added or removed: one for from and one for to.
+func backlinkTexts(depType issuestorage.DependencyType, from, to string, added bool) (onFrom, onTo string) {
+	now := "now"
+	if !added {
+		now = "no longer"
+	}
+	switch depType {
+	case issuestorage.DepTypeBlocks:
+		onFrom = fmt.Sprintf("%s %s blocks this issue", to, now)
+		onTo = fmt.Sprintf("This issue %s blocks %s", now, from)
+	case issuestorage.DepTypeParentChild:
+		onFrom = fmt.Sprintf("This issue is %s a child of %s", now, to)
+		onTo = fmt.Sprintf("%s is %s a child of this issue", from, now)
+	case issuestorage.DepTypeRelatesTo:
+		onFrom = fmt.Sprintf("This issue %s relates to %s", now, to)
+		onTo = fmt.Sprintf("%s %s relates to this issue", from, now)
+	case issuestorage.DepTypeDuplicateOf:
+		onFrom = fmt.Sprintf("This issue is %s a duplicate of %s", now, to)
+		onTo = fmt.Sprintf("%s is %s a duplicate of this issue", from, now)
+	case issuestorage.DepTypeSupersedes:
+		onFrom = fmt.Sprintf("This issue %s supersedes %s", now, to)
+		onTo = fmt.Sprintf("This issue is %s superseded by %s", now, from)
+	default:
+		onFrom = fmt.Sprintf("This issue %s has a %s dependency on %s", now, depType, to)
+		onTo = fmt.Sprintf("%s %s has a %s dependency on this issue", from, now, depType)
+	}
+	return onFrom, onTo
+}
+
+// addBacklink appends a comment with text to issue, by the current actor,
+// if backlink comments are on. added says whether a dependency was added
+// or removed, for the "(added by ...)" suffix.
+func (s *IssueStore) addBacklink(issue *issuestorage.Issue, text string, added bool) {
+	if !s.backlinkComments {
+		return
+	}
+	var actor string
+	if s.actor != nil {
+		actor = s.actor()
+	}
+	if actor != "" {
+		by := "added"
+		if !added {
+			by = "removed"
+		}
+		text += fmt.Sprintf(" (%s by %s)", by, actor)
+	}
+	maxID := 0
+	for _, c := range issue.Comments {
+		maxID = max(maxID, c.ID)
+	}
+	issue.Comments = append(issue.Comments, issuestorage.Comment{
+		ID:        maxID + 1,
+		UUID:      s.NewUUID(),
+		Author:    actor,
+		Text:      text,
+		CreatedAt: s.Now(),
+	})
+}
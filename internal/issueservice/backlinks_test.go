@@ -0,0 +1,71 @@
+package issueservice
+
+import (
+	"context"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestBacklinkComments(t *testing.T) {
+	ctx := context.Background()
+	s := newTestIssueService(t)
+	s.SetActor(func() string { return "alice" })
+
+	a, _ := s.Create(ctx, &issuestorage.Issue{Title: "A"})
+	b, _ := s.Create(ctx, &issuestorage.Issue{Title: "B"})
+	epic, _ := s.Create(ctx, &issuestorage.Issue{Title: "Epic", Type: issuestorage.TypeEpic})
+	other, _ := s.Create(ctx, &issuestorage.Issue{Title: "Other epic", Type: issuestorage.TypeEpic})
+
+	// Off by default.
+	if err := s.AddDependency(ctx, a, b, issuestorage.DepTypeBlocks); err != nil {
+		t.Fatal(err)
+	}
+	if issue, _ := s.Get(ctx, a); len(issue.Comments) != 0 {
+		t.Fatalf("comments with backlinks off: %+v", issue.Comments)
+	}
+
+	s.SetBacklinkComments(true)
+	if err := s.RemoveDependency(ctx, a, b); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddDependency(ctx, a, epic, issuestorage.DepTypeParentChild); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddDependency(ctx, a, other, issuestorage.DepTypeParentChild); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddDependency(ctx, a, b, issuestorage.DepTypeRelated); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][]string{
+		a: {
+			b + " no longer blocks this issue (removed by alice)",
+			"This issue is now a child of " + epic + " (added by alice)",
+			"This issue is now a child of " + other + " (added by alice)",
+			"This issue now has a related dependency on " + b + " (added by alice)",
+		},
+		b: {
+			"This issue no longer blocks " + a + " (removed by alice)",
+			a + " now has a related dependency on this issue (added by alice)",
+		},
+		epic: {
+			a + " is now a child of this issue (added by alice)",
+			a + " is no longer a child of this issue (removed by alice)",
+		},
+		other: {a + " is now a child of this issue (added by alice)"},
+	}
+	for id, texts := range want {
+		issue, _ := s.Get(ctx, id)
+		if len(issue.Comments) != len(texts) {
+			t.Errorf("%s comments = %+v, want %q", id, issue.Comments, texts)
+			continue
+		}
+		for i, c := range issue.Comments {
+			if c.Text != texts[i] || c.Author != "alice" || c.ID != i+1 {
+				t.Errorf("%s comment %d = %+v, want %q", id, i, c, texts[i])
+			}
+		}
+	}
+}
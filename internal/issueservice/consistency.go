@@ -0,0 +1,42 @@
+package issueservice
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// ConsistencyError is returned when a write would leave an issue's fields
+// contradicting one another, such as a closed issue without closed_at or a
+// parent with no matching parent-child dependency.
+type ConsistencyError struct {
+	ID       string
+	Problems []string
+}
+
+func (e *ConsistencyError) Error() string {
+	subject := "issue"
+	if e.ID != "" {
+		subject = e.ID
+	}
+	return fmt.Sprintf("%s would be inconsistent: %s", subject, strings.Join(e.Problems, "; "))
+}
+
+// checkConsistency returns a ConsistencyError listing the inconsistencies
+// of after that are not among before, the issue's inconsistencies before
+// the write (nil on create), so an issue damaged outside the service, which
+// bd doctor reports, remains editable.
+func checkConsistency(before []string, after *issuestorage.Issue) error {
+	var introduced []string
+	for _, problem := range after.Inconsistencies() {
+		if !slices.Contains(before, problem) {
+			introduced = append(introduced, problem)
+		}
+	}
+	if len(introduced) == 0 {
+		return nil
+	}
+	return &ConsistencyError{ID: after.ID, Problems: introduced}
+}
@@ -0,0 +1,80 @@
+package issueservice
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestCreateRejectsInconsistentFields(t *testing.T) {
+	ctx := context.Background()
+	s := newTestIssueService(t)
+
+	_, err := s.Create(ctx, &issuestorage.Issue{Title: "Wait", AwaitType: "timer"})
+	var consErr *ConsistencyError
+	if !errors.As(err, &consErr) || !strings.Contains(err.Error(), "only gates await") {
+		t.Errorf("await_type on a task: err = %v, want ConsistencyError", err)
+	}
+
+	_, err = s.Create(ctx, &issuestorage.Issue{Title: "Orphan", Parent: "bd-epic"})
+	if !errors.As(err, &consErr) || !strings.Contains(err.Error(), "no parent-child dependency") {
+		t.Errorf("parent without dependency: err = %v, want ConsistencyError", err)
+	}
+
+	// A closed issue is given its closed_at rather than rejected.
+	id, err := s.Create(ctx, &issuestorage.Issue{Title: "Done already", Status: issuestorage.StatusClosed})
+	if err != nil {
+		t.Fatalf("Create closed: %v", err)
+	}
+	if got, _ := s.Get(ctx, id); got.ClosedAt == nil {
+		t.Error("closed issue created without closed_at")
+	}
+}
+
+func TestModifyRejectsInconsistentFields(t *testing.T) {
+	ctx := context.Background()
+	s := newTestIssueService(t)
+	id, err := s.Create(ctx, &issuestorage.Issue{Title: "Task"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := s.Now()
+	err = s.Modify(ctx, id, func(i *issuestorage.Issue) error { i.DeletedAt = &now; return nil })
+	var consErr *ConsistencyError
+	if !errors.As(err, &consErr) || consErr.ID != id || !strings.Contains(err.Error(), "deleted_at is set but status is open") {
+		t.Errorf("deleted_at on an open issue: err = %v, want ConsistencyError", err)
+	}
+	err = s.Modify(ctx, id, func(i *issuestorage.Issue) error { i.Parent = "bd-epic"; return nil })
+	if !errors.As(err, &consErr) {
+		t.Errorf("parent without dependency: err = %v, want ConsistencyError", err)
+	}
+
+	// Status transitions keep closed_at in step on their own.
+	if err := s.Modify(ctx, id, func(i *issuestorage.Issue) error { i.Status = issuestorage.StatusClosed; return nil }); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if err := s.Modify(ctx, id, func(i *issuestorage.Issue) error { i.Status = issuestorage.StatusOpen; return nil }); err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+}
+
+func TestModifyAllowsExistingInconsistency(t *testing.T) {
+	ctx := context.Background()
+	s := newTestIssueService(t)
+
+	// Restore writes verbatim, like a hand edit; later edits must still work.
+	if _, err := s.Restore(ctx, &issuestorage.Issue{ID: "bd-odd", Title: "Odd", Status: issuestorage.StatusOpen, AwaitType: "timer"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Modify(ctx, "bd-odd", func(i *issuestorage.Issue) error { i.Title = "Still odd"; return nil }); err != nil {
+		t.Errorf("unrelated edit of an inconsistent issue rejected: %v", err)
+	}
+	now := s.Now()
+	if err := s.Modify(ctx, "bd-odd", func(i *issuestorage.Issue) error { i.DeletedAt = &now; return nil }); err == nil {
+		t.Error("expected a new inconsistency to be rejected")
+	}
+}
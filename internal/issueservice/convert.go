@@ -0,0 +1,97 @@
+package issueservice
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// ErrLossyConversion is returned by ConvertType when the conversion would
+// drop type-specific data and force was not set.
+type ErrLossyConversion struct {
+	ID      string
+	From    issuestorage.IssueType
+	To      issuestorage.IssueType
+	Dropped []string
+}
+
+func (e *ErrLossyConversion) Error() string {
+	return fmt.Sprintf("converting %s from %s to %s would discard: %s (use --force to convert anyway)",
+		e.ID, e.From, e.To, strings.Join(e.Dropped, ", "))
+}
+
+// typeFieldOwners maps each type-specific field to the type that owns it.
+// Fields owned by a type are discarded when an issue is converted away
+// from that type.
+var typeFieldOwners = []struct {
+	field string
+	owner issuestorage.IssueType
+	isSet func(*issuestorage.Issue) bool
+	clear func(*issuestorage.Issue)
+}{
+	{"await_type", issuestorage.TypeGate, func(i *issuestorage.Issue) bool { return i.AwaitType != "" }, func(i *issuestorage.Issue) { i.AwaitType = "" }},
+	{"await_id", issuestorage.TypeGate, func(i *issuestorage.Issue) bool { return i.AwaitID != "" }, func(i *issuestorage.Issue) { i.AwaitID = "" }},
+	{"timeout", issuestorage.TypeGate, func(i *issuestorage.Issue) bool { return i.TimeoutNS != 0 }, func(i *issuestorage.Issue) { i.TimeoutNS = 0 }},
+	{"waiters", issuestorage.TypeGate, func(i *issuestorage.Issue) bool { return len(i.Waiters) > 0 }, func(i *issuestorage.Issue) { i.Waiters = nil }},
+	{"likelihood", issuestorage.TypeRisk, func(i *issuestorage.Issue) bool { return i.Likelihood != 0 }, func(i *issuestorage.Issue) { i.Likelihood = 0 }},
+	{"impact", issuestorage.TypeRisk, func(i *issuestorage.Issue) bool { return i.Impact != 0 }, func(i *issuestorage.Issue) { i.Impact = 0 }},
+	{"review_by", issuestorage.TypeRisk, func(i *issuestorage.Issue) bool { return i.ReviewBy != nil }, func(i *issuestorage.Issue) { i.ReviewBy = nil }},
+	{"decision_state", issuestorage.TypeDecision, func(i *issuestorage.Issue) bool { return i.DecisionState != "" }, func(i *issuestorage.Issue) { i.DecisionState = "" }},
+	{"accepted_answer", issuestorage.TypeQuestion, func(i *issuestorage.Issue) bool { return i.AcceptedAnswer != 0 }, func(i *issuestorage.Issue) { i.AcceptedAnswer = 0 }},
+}
+
+// LossyFields returns the type-specific fields set on issue that would be
+// discarded by converting it to the target type.
+func LossyFields(issue *issuestorage.Issue, to issuestorage.IssueType) []string {
+	var dropped []string
+	for _, f := range typeFieldOwners {
+		if f.owner != to && f.isSet(issue) {
+			dropped = append(dropped, f.field)
+		}
+	}
+	return dropped
+}
+
+// SetType changes issue's type in place, discarding fields that belong to
+// other types and initializing fields the new type needs. Callers check
+// LossyFields first; setting the type an issue already has does nothing.
+func SetType(issue *issuestorage.Issue, to issuestorage.IssueType) {
+	if issue.Type == to {
+		return
+	}
+	for _, f := range typeFieldOwners {
+		if f.owner != to {
+			f.clear(issue)
+		}
+	}
+	if to == issuestorage.TypeDecision {
+		issue.DecisionState = issuestorage.DecisionProposed
+	}
+	issue.Type = to
+}
+
+// ConvertType changes an issue's type, discarding fields that belong to
+// other types and initializing fields the new type needs. If the
+// conversion would discard data and force is false, it returns an
+// *ErrLossyConversion without modifying the issue. The discarded field
+// names are returned on success.
+func (s *IssueStore) ConvertType(ctx context.Context, id string, to issuestorage.IssueType, force bool) ([]string, error) {
+	var dropped []string
+	err := s.Modify(ctx, id, func(issue *issuestorage.Issue) error {
+		if issue.Type == to {
+			return fmt.Errorf("%s is already a %s", id, to)
+		}
+		dropped = LossyFields(issue, to)
+		if len(dropped) > 0 && !force {
+			return &ErrLossyConversion{ID: id, From: issue.Type, To: to, Dropped: dropped}
+		}
+		SetType(issue, to)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dropped, nil
+}
@@ -0,0 +1,55 @@
+package issueservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestConvertType(t *testing.T) {
+	ctx := context.Background()
+	s := newTestIssueService(t)
+
+	gateID, _ := s.Create(ctx, &issuestorage.Issue{Title: "Wait for CI", Type: issuestorage.TypeGate, AwaitType: "gh:run", AwaitID: "123"})
+
+	_, err := s.ConvertType(ctx, gateID, issuestorage.TypeTask, false)
+	var lossy *ErrLossyConversion
+	if !errors.As(err, &lossy) {
+		t.Fatalf("expected ErrLossyConversion, got %v", err)
+	}
+	if len(lossy.Dropped) != 2 {
+		t.Errorf("dropped = %v, want [await_type await_id]", lossy.Dropped)
+	}
+	gate, _ := s.Get(ctx, gateID)
+	if gate.Type != issuestorage.TypeGate || gate.AwaitType == "" {
+		t.Error("refused conversion must not modify the issue")
+	}
+
+	dropped, err := s.ConvertType(ctx, gateID, issuestorage.TypeTask, true)
+	if err != nil {
+		t.Fatalf("forced convert: %v", err)
+	}
+	if len(dropped) != 2 {
+		t.Errorf("dropped = %v, want 2 fields", dropped)
+	}
+	task, _ := s.Get(ctx, gateID)
+	if task.Type != issuestorage.TypeTask || task.AwaitType != "" || task.AwaitID != "" {
+		t.Errorf("converted issue = %+v", task)
+	}
+
+	// Lossless conversion initializes new type fields.
+	dropped, err = s.ConvertType(ctx, gateID, issuestorage.TypeDecision, false)
+	if err != nil || len(dropped) != 0 {
+		t.Fatalf("convert to decision: dropped=%v err=%v", dropped, err)
+	}
+	dec, _ := s.Get(ctx, gateID)
+	if dec.DecisionState != issuestorage.DecisionProposed {
+		t.Errorf("decision state = %q, want proposed", dec.DecisionState)
+	}
+
+	if _, err := s.ConvertType(ctx, gateID, issuestorage.TypeDecision, false); err == nil {
+		t.Error("expected error converting to the same type")
+	}
+}
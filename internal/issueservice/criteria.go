@@ -0,0 +1,71 @@
+package issueservice
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// CriteriaRequiredTypes lists the issue types that bd lint expects to
+// carry acceptance criteria.
+var CriteriaRequiredTypes = []issuestorage.IssueType{issuestorage.TypeFeature}
+
+// AddCriterion appends an acceptance criterion to an issue and returns its
+// 1-based number.
+func (s *IssueStore) AddCriterion(ctx context.Context, id, text string) (int, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, fmt.Errorf("criterion text cannot be empty")
+	}
+	var n int
+	err := s.Modify(ctx, id, func(issue *issuestorage.Issue) error {
+		issue.AcceptanceCriteria = append(issue.AcceptanceCriteria, issuestorage.Criterion{Text: text})
+		n = len(issue.AcceptanceCriteria)
+		return nil
+	})
+	return n, err
+}
+
+// CheckCriterion marks the nth (1-based) acceptance criterion of an issue
+// done, recording who checked it, or clears it when done is false.
+func (s *IssueStore) CheckCriterion(ctx context.Context, id string, n int, done bool, actor string) error {
+	return s.Modify(ctx, id, func(issue *issuestorage.Issue) error {
+		if n < 1 || n > len(issue.AcceptanceCriteria) {
+			return fmt.Errorf("%s has no criterion %d (it has %d)", id, n, len(issue.AcceptanceCriteria))
+		}
+		c := &issue.AcceptanceCriteria[n-1]
+		c.Done = done
+		if done {
+			now := s.Now()
+			c.CheckedBy, c.CheckedAt = actor, &now
+		} else {
+			c.CheckedBy, c.CheckedAt = "", nil
+		}
+		return nil
+	})
+}
+
+// Lint returns the problems bd lint reports for an issue: fields its type
+// requires that are unset (issues created before a types.<type>.required
+// rule was configured are not rejected, so they are flagged here), missing
+// acceptance criteria on types in CriteriaRequiredTypes, and closed issues
+// with unchecked criteria.
+func (s *IssueStore) Lint(issue *issuestorage.Issue) []string {
+	var problems []string
+	if missing := s.missingRequired(issue); len(missing) > 0 {
+		problems = append(problems, fmt.Sprintf("missing required %s (types.%s.required)", strings.Join(missing, ", "), issue.Type))
+	}
+	for _, t := range CriteriaRequiredTypes {
+		if issue.Type == t && len(issue.AcceptanceCriteria) == 0 {
+			problems = append(problems, fmt.Sprintf("no acceptance criteria (%s issues need at least one)", issue.Type))
+		}
+	}
+	if issue.Status == issuestorage.StatusClosed {
+		if n := issue.UncheckedCriteria(); n > 0 {
+			problems = append(problems, fmt.Sprintf("closed with %d of %d acceptance criteria unchecked", n, len(issue.AcceptanceCriteria)))
+		}
+	}
+	return problems
+}
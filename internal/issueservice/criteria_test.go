@@ -0,0 +1,75 @@
+package issueservice
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestCriteria(t *testing.T) {
+	ctx := context.Background()
+	s := newTestIssueService(t)
+	id, _ := s.Create(ctx, &issuestorage.Issue{Title: "SSO", Type: issuestorage.TypeFeature})
+
+	if _, err := s.AddCriterion(ctx, id, "  "); err == nil {
+		t.Error("expected error for empty criterion")
+	}
+	for i, text := range []string{"Google login works", "Tokens refresh"} {
+		n, err := s.AddCriterion(ctx, id, text)
+		if err != nil || n != i+1 {
+			t.Fatalf("AddCriterion(%q) = %d, %v", text, n, err)
+		}
+	}
+
+	if err := s.CheckCriterion(ctx, id, 3, true, "alice"); err == nil {
+		t.Error("expected error for out-of-range criterion")
+	}
+	if err := s.CheckCriterion(ctx, id, 2, true, "alice"); err != nil {
+		t.Fatalf("CheckCriterion: %v", err)
+	}
+	issue, _ := s.Get(ctx, id)
+	c := issue.AcceptanceCriteria[1]
+	if !c.Done || c.CheckedBy != "alice" || c.CheckedAt == nil || issue.UncheckedCriteria() != 1 {
+		t.Errorf("criteria = %+v", issue.AcceptanceCriteria)
+	}
+
+	if err := s.CheckCriterion(ctx, id, 2, false, "bob"); err != nil {
+		t.Fatalf("uncheck: %v", err)
+	}
+	issue, _ = s.Get(ctx, id)
+	if c := issue.AcceptanceCriteria[1]; c.Done || c.CheckedBy != "" || c.CheckedAt != nil {
+		t.Errorf("unchecked criterion = %+v", c)
+	}
+}
+
+func TestLint(t *testing.T) {
+	s := newTestIssueService(t)
+	s.SetTypeRules(map[issuestorage.IssueType]TypeRule{issuestorage.TypeBug: {Required: []string{"severity"}}})
+
+	tests := []struct {
+		name  string
+		issue issuestorage.Issue
+		want  []string
+	}{
+		{"task", issuestorage.Issue{Type: issuestorage.TypeTask}, nil},
+		{"feature without criteria", issuestorage.Issue{Type: issuestorage.TypeFeature}, []string{"no acceptance criteria"}},
+		{"feature with criteria", issuestorage.Issue{Type: issuestorage.TypeFeature, AcceptanceCriteria: []issuestorage.Criterion{{Text: "x"}}}, nil},
+		{"bug missing severity", issuestorage.Issue{Type: issuestorage.TypeBug}, []string{"missing required severity (types.bug.required)"}},
+		{"closed unchecked", issuestorage.Issue{Type: issuestorage.TypeTask, Status: issuestorage.StatusClosed, AcceptanceCriteria: []issuestorage.Criterion{{Text: "x", Done: true}, {Text: "y"}}}, []string{"closed with 1 of 2 acceptance criteria unchecked"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.Lint(&tt.issue)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Lint = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !strings.Contains(got[i], tt.want[i]) {
+					t.Errorf("problem %d = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package issueservice
+
+import (
+	"context"
+	"fmt"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// SetDecisionState moves a decision record to the given state, enforcing
+// the proposed → accepted → superseded workflow. Use Supersede to mark a
+// decision superseded so the superseding relation is recorded too.
+func (s *IssueStore) SetDecisionState(ctx context.Context, id string, state issuestorage.DecisionState) error {
+	if state == issuestorage.DecisionSuperseded {
+		return fmt.Errorf("use supersede to mark %s superseded", id)
+	}
+	return s.Modify(ctx, id, func(issue *issuestorage.Issue) error {
+		return transitionDecision(issue, state)
+	})
+}
+
+// Supersede records that newID supersedes oldID: it adds a supersedes
+// dependency from newID to oldID, marks oldID superseded and closes it.
+func (s *IssueStore) Supersede(ctx context.Context, oldID, newID string) error {
+	if oldID == newID {
+		return fmt.Errorf("a decision cannot supersede itself")
+	}
+	newIssue, err := s.Get(ctx, newID)
+	if err != nil {
+		return fmt.Errorf("getting %s: %w", newID, err)
+	}
+	if newIssue.Type != issuestorage.TypeDecision {
+		return fmt.Errorf("%s is not a decision (type %s)", newID, newIssue.Type)
+	}
+
+	// Supersede the old decision first, so a decision that cannot be
+	// superseded is left without a dangling supersedes relation.
+	var prev issuestorage.Issue
+	if err := s.Modify(ctx, oldID, func(issue *issuestorage.Issue) error {
+		prev = *issue
+		if err := transitionDecision(issue, issuestorage.DecisionSuperseded); err != nil {
+			return fmt.Errorf("cannot supersede %s: %w", oldID, err)
+		}
+		issue.Status = issuestorage.StatusClosed
+		issue.CloseReason = "Superseded by " + newID
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := s.AddDependency(ctx, newID, oldID, issuestorage.DepTypeSupersedes); err != nil {
+		if restoreErr := s.Modify(ctx, oldID, func(issue *issuestorage.Issue) error {
+			issue.DecisionState, issue.Status, issue.CloseReason = prev.DecisionState, prev.Status, prev.CloseReason
+			return nil
+		}); restoreErr != nil {
+			return fmt.Errorf("adding supersedes relation: %w (restoring %s: %v)", err, oldID, restoreErr)
+		}
+		return fmt.Errorf("adding supersedes relation: %w", err)
+	}
+	return nil
+}
+
+// SupersededBy returns the IDs of decisions that supersede the given issue.
+func SupersededBy(issue *issuestorage.Issue) []string {
+	depType := issuestorage.DepTypeSupersedes
+	return issue.DependentIDs(&depType)
+}
+
+func transitionDecision(issue *issuestorage.Issue, state issuestorage.DecisionState) error {
+	if issue.Type != issuestorage.TypeDecision {
+		return fmt.Errorf("%s is not a decision (type %s)", issue.ID, issue.Type)
+	}
+	from := issue.DecisionState
+	if from == "" {
+		from = issuestorage.DecisionProposed
+	}
+	if !issuestorage.ValidDecisionTransition(from, state) {
+		return fmt.Errorf("cannot move decision %s from %s to %s", issue.ID, from, state)
+	}
+	issue.DecisionState = state
+	return nil
+}
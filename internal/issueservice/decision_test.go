@@ -0,0 +1,67 @@
+package issueservice
+
+import (
+	"context"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestDecisionWorkflow(t *testing.T) {
+	ctx := context.Background()
+	s := newTestIssueService(t)
+
+	oldID, _ := s.Create(ctx, &issuestorage.Issue{Title: "Use REST", Type: issuestorage.TypeDecision})
+	newID, _ := s.Create(ctx, &issuestorage.Issue{Title: "Use gRPC", Type: issuestorage.TypeDecision})
+	taskID, _ := s.Create(ctx, &issuestorage.Issue{Title: "Task", Type: issuestorage.TypeTask})
+
+	old, _ := s.Get(ctx, oldID)
+	if old.DecisionState != issuestorage.DecisionProposed {
+		t.Fatalf("new decision state = %q, want proposed", old.DecisionState)
+	}
+
+	if err := s.SetDecisionState(ctx, oldID, issuestorage.DecisionAccepted); err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+	if err := s.SetDecisionState(ctx, oldID, issuestorage.DecisionProposed); err == nil {
+		t.Error("expected error moving accepted decision back to proposed")
+	}
+	if err := s.SetDecisionState(ctx, taskID, issuestorage.DecisionAccepted); err == nil {
+		t.Error("expected error accepting a non-decision")
+	}
+	if err := s.Supersede(ctx, oldID, taskID); err == nil {
+		t.Error("expected error superseding with a non-decision")
+	}
+
+	if err := s.Supersede(ctx, oldID, newID); err != nil {
+		t.Fatalf("supersede: %v", err)
+	}
+	old, _ = s.Get(ctx, oldID)
+	if old.DecisionState != issuestorage.DecisionSuperseded || old.Status != issuestorage.StatusClosed {
+		t.Errorf("old decision = %s/%s, want superseded/closed", old.DecisionState, old.Status)
+	}
+	if by := SupersededBy(old); len(by) != 1 || by[0] != newID {
+		t.Errorf("SupersededBy = %v, want [%s]", by, newID)
+	}
+	if err := s.Supersede(ctx, oldID, newID); err == nil {
+		t.Error("expected error superseding an already superseded decision")
+	}
+	thirdID, _ := s.Create(ctx, &issuestorage.Issue{Title: "Use Thrift", Type: issuestorage.TypeDecision})
+	if err := s.Supersede(ctx, oldID, thirdID); err == nil {
+		t.Error("expected error superseding an already superseded decision")
+	}
+	if third, _ := s.Get(ctx, thirdID); len(third.Dependencies) != 0 {
+		t.Errorf("a failed supersede left dependencies %v", third.Dependencies)
+	}
+
+	// A decision with no state recorded is taken as proposed.
+	if err := s.Modify(ctx, newID, func(i *issuestorage.Issue) error {
+		i.DecisionState = ""
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Supersede(ctx, newID, thirdID); err != nil {
+		t.Errorf("superseding a decision with no state: %v", err)
+	}
+}
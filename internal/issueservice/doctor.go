@@ -0,0 +1,92 @@
+package issueservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// ClockSkewTolerance is how far in the future a timestamp may be before
+// Doctor reports it, allowing for small differences between machines.
+const ClockSkewTolerance = 5 * time.Minute
+
+// Doctor runs the local store's own checks, then checks every backend for
+// clock skew: timestamps in the future by the store's clock, or updated
+// before created. With fix, skewed timestamps are corrected as described
+// by checkTimestamps.
+func (s *IssueStore) Doctor(ctx context.Context, fix bool) ([]string, error) {
+	problems, err := s.local.Doctor(ctx, fix)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := s.listWithClosed(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := s.Now()
+	for _, issue := range issues {
+		found := checkTimestamps(issue, now, false)
+		problems = append(problems, found...)
+		if !fix || len(found) == 0 {
+			continue
+		}
+		if err := s.journaled(s.local).Modify(ctx, issue.ID, func(i *issuestorage.Issue) error {
+			checkTimestamps(i, now, true)
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("fixing timestamps of %s: %w", issue.ID, err)
+		}
+	}
+	return problems, nil
+}
+
+// checkTimestamps reports CreatedAt/UpdatedAt values in the future or an
+// UpdatedAt before CreatedAt. With fix, a future UpdatedAt is clamped to
+// now, a future CreatedAt to the (corrected) UpdatedAt, an UpdatedAt before
+// CreatedAt is raised to CreatedAt, and each correction is recorded in the
+// issue's history.
+func checkTimestamps(issue *issuestorage.Issue, now time.Time, fix bool) []string {
+	var problems []string
+	record := func(field string, old, new time.Time) {
+		issue.History = append(issue.History, issuestorage.HistoryEntry{
+			At:    now,
+			Actor: "doctor",
+			Event: issuestorage.EventDoctorFix,
+			Field: field,
+			Old:   old.Format(time.RFC3339),
+			New:   new.Format(time.RFC3339),
+		})
+	}
+
+	limit := now.Add(ClockSkewTolerance)
+	createdInFuture := issue.CreatedAt.After(limit)
+	if createdInFuture {
+		problems = append(problems, fmt.Sprintf("future timestamp: %s created_at %s is in the future", issue.ID, issue.CreatedAt.Format(time.RFC3339)))
+	}
+	if issue.UpdatedAt.After(limit) {
+		problems = append(problems, fmt.Sprintf("future timestamp: %s updated_at %s is in the future", issue.ID, issue.UpdatedAt.Format(time.RFC3339)))
+		if fix {
+			record("updated_at", issue.UpdatedAt, now)
+			issue.UpdatedAt = now
+		}
+	}
+	if createdInFuture && fix {
+		// The issue cannot have been created after it was last updated.
+		clamped := now
+		if !issue.UpdatedAt.IsZero() && issue.UpdatedAt.Before(now) {
+			clamped = issue.UpdatedAt
+		}
+		record("created_at", issue.CreatedAt, clamped)
+		issue.CreatedAt = clamped
+	}
+	if !issue.UpdatedAt.IsZero() && issue.UpdatedAt.Before(issue.CreatedAt) {
+		problems = append(problems, fmt.Sprintf("clock skew: %s updated_at %s is before created_at %s", issue.ID, issue.UpdatedAt.Format(time.RFC3339), issue.CreatedAt.Format(time.RFC3339)))
+		if fix {
+			record("updated_at", issue.UpdatedAt, issue.CreatedAt)
+			issue.UpdatedAt = issue.CreatedAt
+		}
+	}
+	return problems
+}
@@ -0,0 +1,81 @@
+package issueservice
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/clock"
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/issuestorage/memstore"
+)
+
+// TestDoctorClockSkew runs against memstore, whose own Doctor checks
+// nothing, as clock skew is checked for every backend.
+func TestDoctorClockSkew(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC))
+	local := memstore.New("bd-")
+	s := New(nil, local)
+	s.SetClock(fake)
+
+	now := fake.Now()
+	past := now.Add(-24 * time.Hour)
+	future := now.Add(30 * 24 * time.Hour)
+	closedAt := past
+	for _, issue := range []*issuestorage.Issue{
+		{ID: "bd-future", Title: "Future", Status: issuestorage.StatusOpen, CreatedAt: future, UpdatedAt: future},
+		{ID: "bd-skewed", Title: "Skewed", Status: issuestorage.StatusClosed, ClosedAt: &closedAt, CreatedAt: past, UpdatedAt: past.Add(-time.Hour)},
+		{ID: "bd-fine", Title: "Fine", Status: issuestorage.StatusOpen, CreatedAt: past, UpdatedAt: past.Add(time.Minute)},
+		{ID: "bd-close", Title: "Within tolerance", Status: issuestorage.StatusOpen, CreatedAt: now.Add(time.Minute), UpdatedAt: now.Add(time.Minute)},
+	} {
+		if _, err := local.Create(ctx, issue); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	problems, err := s.Doctor(ctx, false)
+	if err != nil {
+		t.Fatalf("Doctor: %v", err)
+	}
+	if len(problems) != 3 {
+		t.Fatalf("expected 3 problems, got %d: %v", len(problems), problems)
+	}
+	for _, p := range problems {
+		if strings.Contains(p, "bd-fine") || strings.Contains(p, "bd-close") {
+			t.Errorf("unexpected problem: %s", p)
+		}
+	}
+
+	if _, err := s.Doctor(ctx, true); err != nil {
+		t.Fatalf("Doctor fix: %v", err)
+	}
+	got, err := s.Get(ctx, "bd-future")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.CreatedAt.Equal(now) || !got.UpdatedAt.Equal(now) {
+		t.Errorf("expected timestamps clamped to now, got created=%v updated=%v", got.CreatedAt, got.UpdatedAt)
+	}
+	if len(got.History) != 2 || got.History[0].Event != issuestorage.EventDoctorFix || got.History[1].Field != "created_at" {
+		t.Errorf("expected two doctor_fix history entries, got %+v", got.History)
+	}
+	got, err = s.Get(ctx, "bd-skewed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.UpdatedAt.Equal(past) || len(got.History) != 1 || got.History[0].Field != "updated_at" {
+		t.Errorf("expected updated_at raised to created_at with history, got updated=%v history=%+v", got.UpdatedAt, got.History)
+	}
+
+	if problems, err := s.Doctor(ctx, false); err != nil || len(problems) != 0 {
+		t.Errorf("expected no problems after fix, got %v, %v", problems, err)
+	}
+
+	// The future is judged by the store's clock.
+	fake.Set(past)
+	if problems, _ := s.Doctor(ctx, false); len(problems) == 0 {
+		t.Error("expected future timestamps once the clock is behind them")
+	}
+}
@@ -0,0 +1,119 @@
+package issueservice
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// trackedField is an issue field whose changes Modify records as
+// EventUpdated. The values of text fields are too long to keep, so only
+// the change is recorded.
+type trackedField struct {
+	name  string
+	value func(*issuestorage.Issue) string
+	text  bool
+}
+
+var trackedFields = []trackedField{
+	{name: "title", value: func(i *issuestorage.Issue) string { return i.Title }},
+	{name: "description", value: func(i *issuestorage.Issue) string { return i.Description }, text: true},
+	{name: "priority", value: func(i *issuestorage.Issue) string { return strconv.Itoa(int(i.Priority)) }},
+	{name: "severity", value: func(i *issuestorage.Issue) string { return string(i.Severity) }},
+	{name: "type", value: func(i *issuestorage.Issue) string { return string(i.Type) }},
+	{name: "owner", value: func(i *issuestorage.Issue) string { return i.Owner }},
+	{name: "reporter", value: func(i *issuestorage.Issue) string { return i.Reporter }},
+	{name: "reviewer", value: func(i *issuestorage.Issue) string { return i.Reviewer }},
+	{name: "due_at", value: func(i *issuestorage.Issue) string { return historyTime(i.DueAt) }},
+	{name: "defer_until", value: func(i *issuestorage.Issue) string { return historyTime(i.DeferUntil) }},
+	{name: "estimate_minutes", value: func(i *issuestorage.Issue) string { return historyInt(i.Estimate) }},
+	{name: "time_spent_minutes", value: func(i *issuestorage.Issue) string { return historyInt(i.TimeSpent) }},
+	{name: "milestone", value: func(i *issuestorage.Issue) string { return i.Milestone }},
+	{name: "sprint", value: func(i *issuestorage.Issue) string { return i.Sprint }},
+	{name: "decision_state", value: func(i *issuestorage.Issue) string { return string(i.DecisionState) }},
+}
+
+func historyTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func historyInt(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+// snapshot copies the parts of issue recordChanges compares, so that
+// changes made in place by a Modify callback still show.
+func snapshot(issue *issuestorage.Issue) issuestorage.Issue {
+	before := *issue
+	before.Labels = slices.Clone(issue.Labels)
+	before.Dependencies = slices.Clone(issue.Dependencies)
+	before.Comments = slices.Clone(issue.Comments)
+	return before
+}
+
+// recordChanges appends to issue's history the status, field, label,
+// comment and dependency changes made since before. Reopens, assignments
+// and added labels are recorded by Modify itself. If the change already
+// recorded entries of its own, such as time logged or an expired label,
+// they describe it and nothing is added.
+func (s *IssueStore) recordChanges(before, issue *issuestorage.Issue, now time.Time) {
+	if len(issue.History) > len(before.History) {
+		return
+	}
+	if before.Status != issue.Status && before.Status != issuestorage.StatusClosed {
+		s.recordHistory(issue, now, issuestorage.EventStatus, "status", string(before.Status), string(issue.Status))
+	}
+	for _, f := range trackedFields {
+		old, new := f.value(before), f.value(issue)
+		if old == new {
+			continue
+		}
+		if f.text {
+			old, new = "", ""
+		}
+		s.recordHistory(issue, now, issuestorage.EventUpdated, f.name, old, new)
+	}
+	for _, label := range before.Labels {
+		if !slices.Contains(issue.Labels, label) {
+			s.recordHistory(issue, now, issuestorage.EventUnlabeled, "labels", label, "")
+		}
+	}
+	for _, c := range issue.Comments {
+		if !slices.ContainsFunc(before.Comments, func(b issuestorage.Comment) bool { return b.ID == c.ID }) {
+			s.recordHistory(issue, now, issuestorage.EventCommented, "comments", "", strconv.Itoa(c.ID))
+		}
+	}
+	for _, d := range issue.Dependencies {
+		if !slices.Contains(before.Dependencies, d) {
+			s.recordHistory(issue, now, issuestorage.EventDepAdded, string(d.Type), "", d.ID)
+		}
+	}
+	for _, d := range before.Dependencies {
+		if !slices.Contains(issue.Dependencies, d) {
+			s.recordHistory(issue, now, issuestorage.EventDepRemoved, string(d.Type), d.ID, "")
+		}
+	}
+}
+
+// modifyRecorded is store.Modify, recording the dependency changes fn
+// makes in the issue's history. The dependency operations use it to change
+// an issue without the status handling of Modify.
+func (s *IssueStore) modifyRecorded(ctx context.Context, store issuestorage.IssueStore, id string, fn func(*issuestorage.Issue) error) error {
+	return store.Modify(ctx, id, func(issue *issuestorage.Issue) error {
+		before := snapshot(issue)
+		if err := fn(issue); err != nil {
+			return err
+		}
+		s.recordChanges(&before, issue, s.Now())
+		return nil
+	})
+}
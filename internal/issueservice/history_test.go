@@ -0,0 +1,68 @@
+package issueservice
+
+import (
+	"context"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestModifyRecordsChanges(t *testing.T) {
+	ctx := context.Background()
+	s := newTestIssueService(t)
+	s.SetActor(func() string { return "alice" })
+
+	blocker, _ := s.Create(ctx, &issuestorage.Issue{Title: "Blocker"})
+	id, _ := s.Create(ctx, &issuestorage.Issue{Title: "Draft", Priority: issuestorage.PriorityMedium, Labels: []string{"triage"}})
+	if err := s.Modify(ctx, id, func(i *issuestorage.Issue) error {
+		i.Title = "Final"
+		i.Description = "A long description"
+		i.Priority = issuestorage.PriorityHigh
+		i.Status = issuestorage.StatusInProgress
+		i.Labels = nil
+		i.Comments = append(i.Comments, issuestorage.Comment{ID: 1, Author: "alice", Text: "on it"})
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddDependency(ctx, id, blocker, issuestorage.DepTypeBlocks); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RemoveDependency(ctx, id, blocker); err != nil {
+		t.Fatal(err)
+	}
+
+	issue, _ := s.Get(ctx, id)
+	want := []issuestorage.HistoryEntry{
+		{Event: issuestorage.EventStatus, Field: "status", Old: "open", New: "in_progress"},
+		{Event: issuestorage.EventUpdated, Field: "title", Old: "Draft", New: "Final"},
+		{Event: issuestorage.EventUpdated, Field: "description"},
+		{Event: issuestorage.EventUpdated, Field: "priority", Old: "2", New: "1"},
+		{Event: issuestorage.EventUnlabeled, Field: "labels", Old: "triage"},
+		{Event: issuestorage.EventCommented, Field: "comments", New: "1"},
+		{Event: issuestorage.EventDepAdded, Field: "blocks", New: blocker},
+		{Event: issuestorage.EventDepRemoved, Field: "blocks", Old: blocker},
+	}
+	if len(issue.History) != len(want) {
+		t.Fatalf("history = %+v", issue.History)
+	}
+	for i, w := range want {
+		h := issue.History[i]
+		if h.Event != w.Event || h.Field != w.Field || h.Old != w.Old || h.New != w.New || h.Actor != "alice" {
+			t.Errorf("history[%d] = %+v, want %+v by alice", i, h, w)
+		}
+	}
+
+	// A change that records its own entry is not recorded twice.
+	if err := s.Modify(ctx, id, func(i *issuestorage.Issue) error {
+		i.TimeSpent += 30
+		i.History = append(i.History, issuestorage.HistoryEntry{Event: issuestorage.EventTimeLogged, Field: "time_spent_minutes", New: "30"})
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	issue, _ = s.Get(ctx, id)
+	if n := len(issue.History); n != len(want)+1 || issue.History[n-1].Event != issuestorage.EventTimeLogged {
+		t.Errorf("history after logging time = %+v", issue.History[len(want):])
+	}
+}
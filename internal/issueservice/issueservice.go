@@ -2,9 +2,13 @@ package issueservice
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"slices"
 	"time"
 
+	"beads-lite/internal/clock"
 	"beads-lite/internal/issuestorage"
 	"beads-lite/internal/issuestorage/filesystem"
 	"beads-lite/internal/routing"
@@ -22,10 +26,22 @@ import (
 //
 // When router is nil, all operations delegate straight to the local store.
 type IssueStore struct {
-	router          *routing.Router
-	local           issuestorage.IssueStore
-	stores          map[string]issuestorage.IssueStore // cache opened stores by prefix
-	autoCloseParent bool
+	router           *routing.Router
+	local            issuestorage.IssueStore
+	stores           map[string]issuestorage.IssueStore // cache opened stores by prefix
+	autoCloseParent  bool
+	watcher          issuestorage.Watcher
+	typeRules        map[issuestorage.IssueType]TypeRule
+	workflow         Workflow
+	actor            func() string
+	requireApproval  bool
+	backlinkComments bool
+	aliases          Aliases
+	journal          *Journal
+	clock            clock.Clock
+	idSource         io.Reader
+	paranoid         ParanoidMode
+	paranoidWarn     io.Writer
 }
 
 // NewIssueStore creates a routing-aware IssueStore. When router is nil,
@@ -38,6 +54,7 @@ func New(router *routing.Router, local issuestorage.IssueStore) *IssueStore {
 		local:           local,
 		stores:          make(map[string]issuestorage.IssueStore),
 		autoCloseParent: true,
+		clock:           clock.Real,
 	}
 }
 
@@ -47,9 +64,15 @@ func (s *IssueStore) SetAutoCloseParent(enabled bool) {
 	s.autoCloseParent = enabled
 }
 
-// storeFor returns the underlying IssueStore for the given issue ID.
-// Caches opened remote stores by prefix for the lifetime of this IssueStore.
+// storeFor returns the underlying IssueStore for the given issue ID,
+// journaled if a journal is set.
 func (s *IssueStore) storeFor(id string) issuestorage.IssueStore {
+	return s.journaled(s.routedStore(id))
+}
+
+// routedStore returns the IssueStore the given issue ID routes to.
+// Caches opened remote stores by prefix for the lifetime of this IssueStore.
+func (s *IssueStore) routedStore(id string) issuestorage.IssueStore {
 	if s.router == nil {
 		return s.local
 	}
@@ -76,6 +99,45 @@ func (s *IssueStore) sameStore(id1, id2 string) bool {
 	return s.router.SameStore(id1, id2)
 }
 
+// SetActor sets the function naming who makes changes, recorded in issue
+// history when an issue is reopened or reassigned. It is called only when
+// needed.
+func (s *IssueStore) SetActor(actor func() string) {
+	s.actor = actor
+}
+
+// SetClock sets the clock used to stamp created, updated, closed and
+// history times. A nil clock restores the system clock.
+func (s *IssueStore) SetClock(c clock.Clock) {
+	s.clock = clock.OrReal(c)
+}
+
+// Clock returns the store's clock.
+func (s *IssueStore) Clock() clock.Clock {
+	return s.clock
+}
+
+// Now returns the current time by the store's clock.
+func (s *IssueStore) Now() time.Time {
+	return s.clock.Now()
+}
+
+// SetIDSource makes Create draw generated IDs from r instead of
+// crypto/rand, unless the caller supplies CreateOpts.Rand. A nil r
+// restores crypto/rand.
+func (s *IssueStore) SetIDSource(r io.Reader) {
+	s.idSource = r
+}
+
+// NewUUID returns a UUID for a comment, drawn from the ID source set by
+// SetIDSource so deterministic mode writes the same UUIDs each run.
+func (s *IssueStore) NewUUID() string {
+	if s.idSource != nil {
+		return issuestorage.NewUUIDFrom(s.idSource)
+	}
+	return issuestorage.NewUUID()
+}
+
 // Router returns the underlying router (may be nil).
 func (s *IssueStore) Router() *routing.Router {
 	return s.router
@@ -83,11 +145,44 @@ func (s *IssueStore) Router() *routing.Router {
 
 // --- issuestorage.IssueStore: single-ID routing ---
 
+// Get returns the issue with the given ID, alias, alias slug or synced
+// remote ID; see resolveID.
 func (s *IssueStore) Get(ctx context.Context, id string) (*issuestorage.Issue, error) {
-	return s.storeFor(id).Get(ctx, id)
+	resolved := s.resolveAlias(ctx, id)
+	issue, err := s.storeFor(resolved).Get(ctx, resolved)
+	if !errors.Is(err, issuestorage.ErrNotFound) {
+		return issue, err
+	}
+	target, lerr := s.lookupID(ctx, resolved)
+	if lerr != nil {
+		if errors.Is(lerr, issuestorage.ErrNotFound) {
+			return nil, err
+		}
+		return nil, lerr
+	}
+	return s.storeFor(target).Get(ctx, target)
 }
 
+// Modify changes the issue with the given ID, alias, alias slug or synced
+// remote ID, recording the change in its history and applying status
+// side effects.
 func (s *IssueStore) Modify(ctx context.Context, id string, fn func(*issuestorage.Issue) error) error {
+	resolved := s.resolveAlias(ctx, id)
+	err := s.modify(ctx, resolved, fn)
+	if !errors.Is(err, issuestorage.ErrNotFound) {
+		return err
+	}
+	target, lerr := s.lookupID(ctx, resolved)
+	if lerr != nil {
+		if errors.Is(lerr, issuestorage.ErrNotFound) {
+			return err
+		}
+		return lerr
+	}
+	return s.modify(ctx, target, fn)
+}
+
+func (s *IssueStore) modify(ctx context.Context, id string, fn func(*issuestorage.Issue) error) error {
 	store := s.storeFor(id)
 	var oldStatus issuestorage.Status
 	var newStatus issuestorage.Status
@@ -96,15 +191,43 @@ func (s *IssueStore) Modify(ctx context.Context, id string, fn func(*issuestorag
 	// Wrap fn to apply status defaults and update timestamp after user changes
 	wrappedFn := func(issue *issuestorage.Issue) error {
 		oldStatus = issue.Status
+		inconsistent := issue.Inconsistencies()
+		before := snapshot(issue)
 		if err := fn(issue); err != nil {
 			return err
 		}
+		if err := s.checkModifyRequirements(&before, issue); err != nil {
+			return err
+		}
+		if err := s.checkApproval(oldStatus, issue); err != nil {
+			return err
+		}
+		if err := s.checkTransition(oldStatus, issue); err != nil {
+			return err
+		}
+		now := s.Now()
 		// Apply status transition side effects (ClosedAt, CloseReason)
-		applyStatusDefaults(oldStatus, issue)
+		applyStatusDefaults(oldStatus, issue, now)
+		if err := checkConsistency(inconsistent, issue); err != nil {
+			return err
+		}
+		s.recordChanges(&before, issue, now)
+		if oldStatus == issuestorage.StatusClosed && issue.Status != issuestorage.StatusClosed {
+			issue.ReopenCount++
+			s.recordHistory(issue, now, issuestorage.EventReopened, "status", string(oldStatus), string(issue.Status))
+		}
+		if before.Assignee != issue.Assignee {
+			s.recordHistory(issue, now, issuestorage.EventAssigned, "assignee", before.Assignee, issue.Assignee)
+		}
+		for _, label := range issue.Labels {
+			if !slices.Contains(before.Labels, label) {
+				s.recordHistory(issue, now, issuestorage.EventLabeled, "labels", "", label)
+			}
+		}
 		newStatus = issue.Status
 		statusCaptured = true
 		// Update timestamp
-		issue.UpdatedAt = time.Now()
+		issue.UpdatedAt = now
 		return nil
 	}
 	if err := store.Modify(ctx, id, wrappedFn); err != nil {
@@ -123,12 +246,29 @@ func (s *IssueStore) Modify(ctx context.Context, id string, fn func(*issuestorag
 	return nil
 }
 
+// recordHistory appends an event by the current actor at now to issue's
+// history.
+func (s *IssueStore) recordHistory(issue *issuestorage.Issue, now time.Time, event, field, old, new string) {
+	var actor string
+	if s.actor != nil {
+		actor = s.actor()
+	}
+	issue.History = append(issue.History, issuestorage.HistoryEntry{
+		At:    now,
+		Actor: actor,
+		Event: event,
+		Field: field,
+		Old:   old,
+		New:   new,
+	})
+}
+
 // applyStatusDefaults sets side-effect fields for status transitions.
-// When status changes to Closed, sets ClosedAt and default CloseReason.
-// When status changes from Closed, clears ClosedAt and CloseReason.
-func applyStatusDefaults(oldStatus issuestorage.Status, issue *issuestorage.Issue) {
+// When status changes to Closed, sets ClosedAt to now and default CloseReason.
+// When status changes from Closed, clears ClosedAt, CloseReason and the
+// resolution.
+func applyStatusDefaults(oldStatus issuestorage.Status, issue *issuestorage.Issue, now time.Time) {
 	if issue.Status == issuestorage.StatusClosed && oldStatus != issuestorage.StatusClosed {
-		now := time.Now()
 		issue.ClosedAt = &now
 		if issue.CloseReason == "" {
 			issue.CloseReason = "Closed"
@@ -137,14 +277,21 @@ func applyStatusDefaults(oldStatus issuestorage.Status, issue *issuestorage.Issu
 	if oldStatus == issuestorage.StatusClosed && issue.Status != issuestorage.StatusClosed {
 		issue.ClosedAt = nil
 		issue.CloseReason = ""
+		issue.Resolution = ""
+		issue.DuplicateOf = ""
 	}
 }
 
 func (s *IssueStore) Delete(ctx context.Context, id string) error {
+	id, err := s.resolveID(ctx, id)
+	if err != nil {
+		return err
+	}
 	return s.storeFor(id).Delete(ctx, id)
 }
 
 func (s *IssueStore) GetNextChildID(ctx context.Context, parentID string) (string, error) {
+	parentID = s.resolveAlias(ctx, parentID)
 	return s.storeFor(parentID).GetNextChildID(ctx, parentID)
 }
 
@@ -152,13 +299,37 @@ func (s *IssueStore) GetNextChildID(ctx context.Context, parentID string) (strin
 
 func (s *IssueStore) Create(ctx context.Context, issue *issuestorage.Issue, opts ...issuestorage.CreateOpts) (string, error) {
 	// Set timestamps and default status before storage
-	now := time.Now()
-	issue.CreatedAt = now
-	issue.UpdatedAt = now
+	var createOpts issuestorage.CreateOpts
+	if len(opts) > 0 {
+		createOpts = opts[0]
+	}
+	if createOpts.Rand == nil && s.idSource != nil {
+		createOpts.Rand = s.idSource
+	}
+	now := s.Now()
+	if !createOpts.KeepTimestamps || issue.CreatedAt.IsZero() {
+		issue.CreatedAt = now
+	}
+	if !createOpts.KeepTimestamps || issue.UpdatedAt.IsZero() {
+		issue.UpdatedAt = now
+	}
 	if issue.Status == "" {
 		issue.Status = issuestorage.StatusOpen
 	}
-	return s.local.Create(ctx, issue, opts...)
+	if issue.ClosedAt == nil {
+		applyStatusDefaults(issuestorage.StatusOpen, issue, now)
+	}
+	if issue.Type == issuestorage.TypeDecision && issue.DecisionState == "" {
+		issue.DecisionState = issuestorage.DecisionProposed
+	}
+	s.applyTypeDefaults(issue, createOpts.PrioritySet)
+	if err := s.checkTypeRequirements(issue); err != nil {
+		return "", err
+	}
+	if err := checkConsistency(nil, issue); err != nil {
+		return "", err
+	}
+	return s.journaled(s.local).Create(ctx, issue, createOpts)
 }
 
 func (s *IssueStore) List(ctx context.Context, filter *issuestorage.ListFilter) ([]*issuestorage.Issue, error) {
@@ -169,20 +340,28 @@ func (s *IssueStore) Init(ctx context.Context) error {
 	return s.local.Init(ctx)
 }
 
-func (s *IssueStore) Doctor(ctx context.Context, fix bool) ([]string, error) {
-	return s.local.Doctor(ctx, fix)
-}
-
 // --- Dependency operations ---
 
 // AddDependency creates a typed dependency relationship (issueID depends on dependsOnID).
 // Handles cycle detection, parent-child constraints, and reparenting.
+// In paranoid mode the result is verified; see SetParanoid.
 func (s *IssueStore) AddDependency(ctx context.Context, issueID, dependsOnID string, depType issuestorage.DependencyType) error {
-	if depType == issuestorage.DepTypeParentChild {
-		return s.addParentChildDep(ctx, issueID, dependsOnID)
+	issueID, dependsOnID, err := s.resolveDepIDs(ctx, issueID, dependsOnID)
+	if err != nil {
+		return err
 	}
+	op := fmt.Sprintf("adding %s dependency of %s on %s", depType, issueID, dependsOnID)
+	return s.verified(ctx, op, []string{issueID, dependsOnID}, func() error {
+		if depType == issuestorage.DepTypeParentChild {
+			return s.addParentChildDep(ctx, issueID, dependsOnID)
+		}
+		return s.addDependency(ctx, issueID, dependsOnID, depType)
+	})
+}
 
-	hasCycle, err := s.hasCycle(ctx, issueID, dependsOnID)
+// addDependency handles AddDependency with any type but parent-child.
+func (s *IssueStore) addDependency(ctx context.Context, issueID, dependsOnID string, depType issuestorage.DependencyType) error {
+	hasCycle, err := s.hasCycle(ctx, issueID, dependsOnID, depType)
 	if err != nil {
 		return err
 	}
@@ -190,10 +369,13 @@ func (s *IssueStore) AddDependency(ctx context.Context, issueID, dependsOnID str
 		return issuestorage.ErrCycle
 	}
 
+	onSource, onTarget := backlinkTexts(depType, issueID, dependsOnID, true)
+
 	// Add dependency to the source issue
-	if err := s.storeFor(issueID).Modify(ctx, issueID, func(issue *issuestorage.Issue) error {
+	if err := s.modifyRecorded(ctx, s.storeFor(issueID), issueID, func(issue *issuestorage.Issue) error {
 		if !issue.HasDependency(dependsOnID) {
 			issue.Dependencies = append(issue.Dependencies, issuestorage.Dependency{ID: dependsOnID, Type: depType})
+			s.addBacklink(issue, onSource, true)
 		}
 		return nil
 	}); err != nil {
@@ -201,9 +383,10 @@ func (s *IssueStore) AddDependency(ctx context.Context, issueID, dependsOnID str
 	}
 
 	// Add inverse dependent to the target issue
-	return s.storeFor(dependsOnID).Modify(ctx, dependsOnID, func(dep *issuestorage.Issue) error {
+	return s.modifyRecorded(ctx, s.storeFor(dependsOnID), dependsOnID, func(dep *issuestorage.Issue) error {
 		if !dep.HasDependent(issueID) {
 			dep.Dependents = append(dep.Dependents, issuestorage.Dependency{ID: issueID, Type: depType})
+			s.addBacklink(dep, onTarget, true)
 		}
 		return nil
 	})
@@ -228,7 +411,7 @@ func (s *IssueStore) addParentChildDep(ctx context.Context, childID, parentID st
 
 	// Modify the child: set parent, remove old parent dep, add new parent dep
 	var oldParentID string
-	if err := store.Modify(ctx, childID, func(child *issuestorage.Issue) error {
+	if err := s.modifyRecorded(ctx, store, childID, func(child *issuestorage.Issue) error {
 		if child.Parent != "" && child.Parent != parentID {
 			oldParentID = child.Parent
 			child.Dependencies = removeDep(child.Dependencies, child.Parent)
@@ -236,6 +419,8 @@ func (s *IssueStore) addParentChildDep(ctx context.Context, childID, parentID st
 		child.Parent = parentID
 		if !child.HasDependency(parentID) {
 			child.Dependencies = append(child.Dependencies, issuestorage.Dependency{ID: parentID, Type: issuestorage.DepTypeParentChild})
+			onChild, _ := backlinkTexts(issuestorage.DepTypeParentChild, childID, parentID, true)
+			s.addBacklink(child, onChild, true)
 		}
 		return nil
 	}); err != nil {
@@ -244,16 +429,20 @@ func (s *IssueStore) addParentChildDep(ctx context.Context, childID, parentID st
 
 	// Remove child from old parent's dependents
 	if oldParentID != "" {
-		_ = store.Modify(ctx, oldParentID, func(oldParent *issuestorage.Issue) error {
+		_ = s.modifyRecorded(ctx, store, oldParentID, func(oldParent *issuestorage.Issue) error {
 			oldParent.Dependents = removeDep(oldParent.Dependents, childID)
+			_, onOldParent := backlinkTexts(issuestorage.DepTypeParentChild, childID, oldParentID, false)
+			s.addBacklink(oldParent, onOldParent, false)
 			return nil
 		})
 	}
 
 	// Add child to new parent's dependents
-	if err := store.Modify(ctx, parentID, func(parent *issuestorage.Issue) error {
+	if err := s.modifyRecorded(ctx, store, parentID, func(parent *issuestorage.Issue) error {
 		if !parent.HasDependent(childID) {
 			parent.Dependents = append(parent.Dependents, issuestorage.Dependency{ID: childID, Type: issuestorage.DepTypeParentChild})
+			_, onParent := backlinkTexts(issuestorage.DepTypeParentChild, childID, parentID, true)
+			s.addBacklink(parent, onParent, true)
 		}
 		return nil
 	}); err != nil {
@@ -272,12 +461,31 @@ func (s *IssueStore) addParentChildDep(ctx context.Context, childID, parentID st
 // RemoveDependency removes a dependency relationship by ID from both sides.
 // If the removed dep was parent-child, also clears issueID.Parent.
 func (s *IssueStore) RemoveDependency(ctx context.Context, issueID, dependsOnID string) error {
-	if err := s.storeFor(issueID).Modify(ctx, issueID, func(issue *issuestorage.Issue) error {
+	issueID, dependsOnID, err := s.resolveDepIDs(ctx, issueID, dependsOnID)
+	if err != nil {
+		return err
+	}
+	op := fmt.Sprintf("removing dependency of %s on %s", issueID, dependsOnID)
+	return s.verified(ctx, op, []string{issueID, dependsOnID}, func() error {
+		return s.removeDependency(ctx, issueID, dependsOnID)
+	})
+}
+
+// removeDependency handles RemoveDependency once both IDs are resolved.
+func (s *IssueStore) removeDependency(ctx context.Context, issueID, dependsOnID string) error {
+	var onTarget string
+	if err := s.modifyRecorded(ctx, s.storeFor(issueID), issueID, func(issue *issuestorage.Issue) error {
 		for _, dep := range issue.Dependencies {
-			if dep.ID == dependsOnID && dep.Type == issuestorage.DepTypeParentChild {
+			if dep.ID != dependsOnID {
+				continue
+			}
+			if dep.Type == issuestorage.DepTypeParentChild {
 				issue.Parent = ""
-				break
 			}
+			var onSource string
+			onSource, onTarget = backlinkTexts(dep.Type, issueID, dependsOnID, false)
+			s.addBacklink(issue, onSource, false)
+			break
 		}
 		issue.Dependencies = removeDep(issue.Dependencies, dependsOnID)
 		return nil
@@ -285,7 +493,10 @@ func (s *IssueStore) RemoveDependency(ctx context.Context, issueID, dependsOnID
 		return err
 	}
 
-	return s.storeFor(dependsOnID).Modify(ctx, dependsOnID, func(dep *issuestorage.Issue) error {
+	return s.modifyRecorded(ctx, s.storeFor(dependsOnID), dependsOnID, func(dep *issuestorage.Issue) error {
+		if onTarget != "" && dep.HasDependent(issueID) {
+			s.addBacklink(dep, onTarget, false)
+		}
 		dep.Dependents = removeDep(dep.Dependents, issueID)
 		return nil
 	})
@@ -293,13 +504,25 @@ func (s *IssueStore) RemoveDependency(ctx context.Context, issueID, dependsOnID
 
 // --- Cycle detection ---
 
-// hasCycle checks if adding issueID→dependsOnID would create a cycle.
-// BFS from dependsOnID following Dependencies; if issueID is reachable, it's a cycle.
-// Uses s.Get() so traversal is routing-aware (works across rigs).
-func (s *IssueStore) hasCycle(ctx context.Context, issueID, dependsOnID string) (bool, error) {
+// hasCycle checks if adding issueID→dependsOnID of type depType would
+// create a cycle. BFS from dependsOnID following Dependencies; if issueID is
+// reachable, it's a cycle. Links only form cycles with links of the same
+// type (A supersedes B supersedes A), and symmetric ones never do; other
+// dependencies ignore links. Uses s.Get() so traversal is routing-aware
+// (works across rigs).
+func (s *IssueStore) hasCycle(ctx context.Context, issueID, dependsOnID string, depType issuestorage.DependencyType) (bool, error) {
 	if issueID == dependsOnID {
 		return true, nil
 	}
+	if depType.Symmetric() {
+		return false, nil
+	}
+	follows := func(t issuestorage.DependencyType) bool {
+		if depType.IsLink() {
+			return t == depType
+		}
+		return !t.IsLink()
+	}
 
 	visited := make(map[string]bool)
 	queue := []string{dependsOnID}
@@ -322,6 +545,9 @@ func (s *IssueStore) hasCycle(ctx context.Context, issueID, dependsOnID string)
 		}
 
 		for _, dep := range issue.Dependencies {
+			if !follows(dep.Type) {
+				continue
+			}
 			if dep.ID == issueID {
 				return true, nil
 			}
@@ -367,6 +593,23 @@ func (s *IssueStore) hasHierarchyCycle(ctx context.Context, child, parent string
 	return false, nil
 }
 
+// resolveDepIDs resolves both sides of a dependency with resolveID. A side
+// that matches nothing is left for the operation to report.
+func (s *IssueStore) resolveDepIDs(ctx context.Context, issueID, dependsOnID string) (string, string, error) {
+	var ids [2]string
+	for i, id := range []string{issueID, dependsOnID} {
+		resolved, err := s.resolveID(ctx, id)
+		switch {
+		case errors.Is(err, issuestorage.ErrNotFound):
+			resolved = id
+		case err != nil:
+			return "", "", err
+		}
+		ids[i] = resolved
+	}
+	return ids[0], ids[1], nil
+}
+
 // removeDep removes a dependency entry by ID from a Dependency slice.
 func removeDep(deps []issuestorage.Dependency, id string) []issuestorage.Dependency {
 	result := make([]issuestorage.Dependency, 0, len(deps))
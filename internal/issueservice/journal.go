@@ -0,0 +1,121 @@
+package issueservice
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// Journal collects the state of each issue before a store first changed
+// it, while the journal is set on the store, so the changes can be undone.
+type Journal struct {
+	mu     sync.Mutex
+	before map[string]*issuestorage.Issue
+	order  []string
+}
+
+// BeforeImage is an issue as it was before a journaled change. Issue is
+// nil if the issue was created.
+type BeforeImage struct {
+	ID    string
+	Issue *issuestorage.Issue
+}
+
+// NewJournal returns an empty journal.
+func NewJournal() *Journal {
+	return &Journal{before: make(map[string]*issuestorage.Issue)}
+}
+
+// Changes returns the before-image of every issue changed, in the order
+// they were first changed.
+func (j *Journal) Changes() []BeforeImage {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	out := make([]BeforeImage, len(j.order))
+	for i, id := range j.order {
+		out[i] = BeforeImage{ID: id, Issue: j.before[id]}
+	}
+	return out
+}
+
+// note records issue as id's before-image unless id was already changed.
+func (j *Journal) note(id string, issue *issuestorage.Issue) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, seen := j.before[id]; seen {
+		return
+	}
+	j.before[id] = issue
+	j.order = append(j.order, id)
+}
+
+// SetJournal makes the store record in j the before-image of every issue
+// it creates, modifies or deletes. A nil j stops recording.
+func (s *IssueStore) SetJournal(j *Journal) {
+	s.journal = j
+}
+
+// journaled returns store, recording its changes in the journal if one is
+// set.
+func (s *IssueStore) journaled(store issuestorage.IssueStore) issuestorage.IssueStore {
+	if s.journal == nil {
+		return store
+	}
+	return &journaledStore{IssueStore: store, journal: s.journal}
+}
+
+// journaledStore records the before-images of the issues changed through
+// it in a Journal.
+type journaledStore struct {
+	issuestorage.IssueStore
+	journal *Journal
+}
+
+func (j *journaledStore) Create(ctx context.Context, issue *issuestorage.Issue, opts ...issuestorage.CreateOpts) (string, error) {
+	id, err := j.IssueStore.Create(ctx, issue, opts...)
+	if err == nil {
+		j.journal.note(id, nil)
+	}
+	return id, err
+}
+
+func (j *journaledStore) Modify(ctx context.Context, id string, fn func(*issuestorage.Issue) error) error {
+	return j.IssueStore.Modify(ctx, id, func(issue *issuestorage.Issue) error {
+		before, err := copyIssue(issue)
+		if err != nil {
+			return err
+		}
+		if err := fn(issue); err != nil {
+			return err
+		}
+		j.journal.note(id, before)
+		return nil
+	})
+}
+
+func (j *journaledStore) Delete(ctx context.Context, id string) error {
+	issue, err := j.IssueStore.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := j.IssueStore.Delete(ctx, id); err != nil {
+		return err
+	}
+	j.journal.note(id, issue)
+	return nil
+}
+
+// copyIssue returns a deep copy of issue.
+func copyIssue(issue *issuestorage.Issue) (*issuestorage.Issue, error) {
+	data, err := json.Marshal(issue)
+	if err != nil {
+		return nil, err
+	}
+	var out issuestorage.Issue
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -2,8 +2,11 @@ package issueservice
 
 import (
 	"context"
+	"slices"
 	"testing"
+	"time"
 
+	"beads-lite/internal/deterministic"
 	"beads-lite/internal/issuestorage"
 	"beads-lite/internal/issuestorage/filesystem"
 )
@@ -144,3 +147,182 @@ func TestAutoParentLifecycleDisabled(t *testing.T) {
 		t.Fatalf("parent should not auto-close when disabled")
 	}
 }
+
+func TestModifyReopenRecordsReopenCount(t *testing.T) {
+	ctx := context.Background()
+	s := newTestIssueService(t)
+	s.SetActor(func() string { return "alice" })
+
+	id, _ := s.Create(ctx, &issuestorage.Issue{Title: "Flappy", Type: issuestorage.TypeTask})
+	setStatus := func(status issuestorage.Status) {
+		t.Helper()
+		if err := s.Modify(ctx, id, func(i *issuestorage.Issue) error {
+			i.Status = status
+			return nil
+		}); err != nil {
+			t.Fatalf("set status %s: %v", status, err)
+		}
+	}
+	for range 2 {
+		setStatus(issuestorage.StatusClosed)
+		setStatus(issuestorage.StatusOpen)
+	}
+	// Changes that don't leave closed are not reopens.
+	setStatus(issuestorage.StatusInProgress)
+
+	got, _ := s.Get(ctx, id)
+	if got.ReopenCount != 2 {
+		t.Fatalf("ReopenCount = %d, want 2", got.ReopenCount)
+	}
+	var reopens int
+	for _, h := range got.History {
+		if h.Event != issuestorage.EventReopened {
+			continue
+		}
+		reopens++
+		if h.Actor != "alice" || h.Old != "closed" || h.New != "open" {
+			t.Errorf("reopen entry = %+v, want alice closed->open", h)
+		}
+	}
+	if reopens != 2 {
+		t.Fatalf("reopen history entries = %d, want 2", reopens)
+	}
+}
+
+func TestModifyAssigneeRecordsHistory(t *testing.T) {
+	ctx := context.Background()
+	s := newTestIssueService(t)
+	s.SetActor(func() string { return "lead" })
+
+	id, _ := s.Create(ctx, &issuestorage.Issue{Title: "Task", Type: issuestorage.TypeTask, Assignee: "alice"})
+	for _, assignee := range []string{"bob", "bob", ""} {
+		if err := s.Modify(ctx, id, func(i *issuestorage.Issue) error {
+			i.Assignee = assignee
+			return nil
+		}); err != nil {
+			t.Fatalf("assign %q: %v", assignee, err)
+		}
+	}
+
+	got, _ := s.Get(ctx, id)
+	var changes []string
+	for _, h := range got.History {
+		if h.Event != issuestorage.EventAssigned {
+			continue
+		}
+		if h.Actor != "lead" || h.Field != "assignee" {
+			t.Errorf("assignment entry = %+v, want field assignee by lead", h)
+		}
+		changes = append(changes, h.Old+"->"+h.New)
+	}
+	if want := []string{"alice->bob", "bob->"}; !slices.Equal(changes, want) {
+		t.Fatalf("assignment changes = %v, want %v", changes, want)
+	}
+}
+
+func TestModifyLabelsRecordsHistory(t *testing.T) {
+	ctx := context.Background()
+	s := newTestIssueService(t)
+	s.SetActor(func() string { return "lead" })
+
+	id, _ := s.Create(ctx, &issuestorage.Issue{Title: "Task", Type: issuestorage.TypeTask, Labels: []string{"web"}})
+	for _, labels := range [][]string{{"web", "needs-info"}, {"needs-info"}, {"needs-info", "web"}} {
+		if err := s.Modify(ctx, id, func(i *issuestorage.Issue) error {
+			i.Labels = labels
+			return nil
+		}); err != nil {
+			t.Fatalf("labels %q: %v", labels, err)
+		}
+	}
+
+	got, _ := s.Get(ctx, id)
+	var added []string
+	for _, h := range got.History {
+		if h.Event == issuestorage.EventLabeled {
+			added = append(added, h.New)
+		}
+	}
+	if want := []string{"needs-info", "web"}; !slices.Equal(added, want) {
+		t.Fatalf("labels added = %v, want %v", added, want)
+	}
+}
+
+func TestCreateKeepTimestamps(t *testing.T) {
+	ctx := context.Background()
+	s := newTestIssueService(t)
+
+	created := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	updated := created.Add(48 * time.Hour)
+	id, err := s.Create(ctx, &issuestorage.Issue{Title: "Backdated", CreatedAt: created, UpdatedAt: updated},
+		issuestorage.CreateOpts{KeepTimestamps: true})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	got, err := s.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !got.CreatedAt.Equal(created) || !got.UpdatedAt.Equal(updated) {
+		t.Errorf("timestamps = %v / %v, want %v / %v", got.CreatedAt, got.UpdatedAt, created, updated)
+	}
+
+	// Without the option, given timestamps are replaced with now.
+	id, err = s.Create(ctx, &issuestorage.Issue{Title: "Stamped", CreatedAt: created, UpdatedAt: updated})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	got, err = s.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !got.CreatedAt.After(updated) {
+		t.Errorf("CreatedAt = %v, want the current time", got.CreatedAt)
+	}
+}
+
+func TestSetClockAndIDSource_Reproducible(t *testing.T) {
+	ctx := context.Background()
+	run := func() ([]issuestorage.Issue, string) {
+		s := newTestIssueService(t)
+		source := deterministic.New(42)
+		s.SetClock(source)
+		s.SetIDSource(source)
+		var out []issuestorage.Issue
+		for _, title := range []string{"First", "Second"} {
+			id, err := s.Create(ctx, &issuestorage.Issue{Title: title})
+			if err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+			if err := s.Modify(ctx, id, func(i *issuestorage.Issue) error {
+				i.Status = issuestorage.StatusClosed
+				return nil
+			}); err != nil {
+				t.Fatalf("Modify: %v", err)
+			}
+			got, err := s.Get(ctx, id)
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			out = append(out, *got)
+		}
+		return out, s.NewUUID()
+	}
+
+	a, uuidA := run()
+	b, uuidB := run()
+	if uuidA != uuidB {
+		t.Errorf("comment UUIDs differ between runs: %s vs %s", uuidA, uuidB)
+	}
+	for i := range a {
+		if a[i].ID != b[i].ID || !a[i].CreatedAt.Equal(b[i].CreatedAt) || !a[i].ClosedAt.Equal(*b[i].ClosedAt) {
+			t.Errorf("issue %d differs between runs: %s %v %v vs %s %v %v", i,
+				a[i].ID, a[i].CreatedAt, a[i].ClosedAt, b[i].ID, b[i].CreatedAt, b[i].ClosedAt)
+		}
+	}
+	if !a[0].CreatedAt.Equal(deterministic.Epoch) {
+		t.Errorf("first CreatedAt = %v, want the clock's epoch", a[0].CreatedAt)
+	}
+	if !a[0].UpdatedAt.Equal(*a[0].ClosedAt) {
+		t.Errorf("close stamped UpdatedAt %v and ClosedAt %v, want one reading", a[0].UpdatedAt, a[0].ClosedAt)
+	}
+}
@@ -0,0 +1,37 @@
+package issueservice
+
+import (
+	"context"
+	"fmt"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// answeredReason is the close reason recorded when a question's answer is accepted.
+const answeredReason = "Answered"
+
+// AcceptAnswer marks the comment with the given ID as the accepted answer
+// to a question and closes the question.
+func (s *IssueStore) AcceptAnswer(ctx context.Context, id string, commentID int) error {
+	return s.Modify(ctx, id, func(issue *issuestorage.Issue) error {
+		if issue.Type != issuestorage.TypeQuestion {
+			return fmt.Errorf("%s is not a question (type %s)", id, issue.Type)
+		}
+		found := false
+		for _, c := range issue.Comments {
+			if c.ID == commentID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("comment %d not found on %s", commentID, id)
+		}
+		issue.AcceptedAnswer = commentID
+		if issue.Status != issuestorage.StatusClosed {
+			issue.Status = issuestorage.StatusClosed
+			issue.CloseReason = answeredReason
+		}
+		return nil
+	})
+}
@@ -0,0 +1,40 @@
+package issueservice
+
+import (
+	"context"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestAcceptAnswer(t *testing.T) {
+	ctx := context.Background()
+	s := newTestIssueService(t)
+
+	qID, _ := s.Create(ctx, &issuestorage.Issue{Title: "How do I reset?", Type: issuestorage.TypeQuestion})
+	if err := s.Modify(ctx, qID, func(i *issuestorage.Issue) error {
+		i.Comments = append(i.Comments, issuestorage.Comment{ID: 1, Text: "Try turning it off"}, issuestorage.Comment{ID: 2, Text: "Run bd doctor --fix"})
+		return nil
+	}); err != nil {
+		t.Fatalf("add comments: %v", err)
+	}
+
+	if err := s.AcceptAnswer(ctx, qID, 9); err == nil {
+		t.Error("expected error for missing comment")
+	}
+	if err := s.AcceptAnswer(ctx, qID, 2); err != nil {
+		t.Fatalf("AcceptAnswer: %v", err)
+	}
+	q, _ := s.Get(ctx, qID)
+	if q.Status != issuestorage.StatusClosed || q.CloseReason != answeredReason || q.ClosedAt == nil {
+		t.Errorf("question not closed as answered: status=%s reason=%q", q.Status, q.CloseReason)
+	}
+	if a := q.Answer(); a == nil || a.ID != 2 {
+		t.Errorf("Answer() = %+v, want comment 2", a)
+	}
+
+	taskID, _ := s.Create(ctx, &issuestorage.Issue{Title: "Task"})
+	if err := s.AcceptAnswer(ctx, taskID, 1); err == nil {
+		t.Error("expected error accepting an answer on a non-question")
+	}
+}
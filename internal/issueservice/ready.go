@@ -0,0 +1,19 @@
+package issueservice
+
+import (
+	"context"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// ListReady returns the issues matching filter that are ready to work on,
+// as found by the local store. cascade is graph.cascade_parent_blocking.
+func (s *IssueStore) ListReady(ctx context.Context, filter *issuestorage.ListFilter, cascade bool) ([]*issuestorage.Issue, error) {
+	return s.local.ListReady(ctx, filter, cascade)
+}
+
+// ListBlocked returns the blocked issues matching filter with their
+// blockers, as found by the local store.
+func (s *IssueStore) ListBlocked(ctx context.Context, filter *issuestorage.ListFilter, cascade bool) ([]issuestorage.BlockedIssue, error) {
+	return s.local.ListBlocked(ctx, filter, cascade)
+}
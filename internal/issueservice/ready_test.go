@@ -0,0 +1,102 @@
+package issueservice
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestListReadyAndBlocked(t *testing.T) {
+	ctx := context.Background()
+	s := newTestIssueService(t)
+
+	create := func(title string, mutate func(*issuestorage.Issue)) string {
+		t.Helper()
+		issue := &issuestorage.Issue{Title: title, Type: issuestorage.TypeTask}
+		if mutate != nil {
+			mutate(issue)
+		}
+		id, err := s.Create(ctx, issue)
+		if err != nil {
+			t.Fatalf("create %s: %v", title, err)
+		}
+		return id
+	}
+	blocker := create("Blocker", nil)
+	epic := create("Epic", func(i *issuestorage.Issue) { i.Type = issuestorage.TypeEpic })
+	step := create("Step", func(i *issuestorage.Issue) { i.Assignee = "alice" })
+	done := create("Done", nil)
+	unblocked := create("Unblocked", func(i *issuestorage.Issue) { i.Assignee = "alice" })
+	wisp := create("Wisp", func(i *issuestorage.Issue) { i.Ephemeral = true })
+	for _, dep := range []struct{ from, to string }{{epic, blocker}, {unblocked, done}} {
+		if err := s.AddDependency(ctx, dep.from, dep.to, issuestorage.DepTypeBlocks); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.AddDependency(ctx, step, epic, issuestorage.DepTypeParentChild); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Modify(ctx, done, func(i *issuestorage.Issue) error {
+		i.Status = issuestorage.StatusClosed
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := func(issues []*issuestorage.Issue) []string {
+		var out []string
+		for _, issue := range issues {
+			out = append(out, issue.ID)
+		}
+		sort.Strings(out)
+		return out
+	}
+	sorted := func(s ...string) []string {
+		sort.Strings(s)
+		return s
+	}
+
+	ready, err := s.ListReady(ctx, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := ids(ready), sorted(blocker, unblocked); !reflect.DeepEqual(got, want) {
+		t.Errorf("ListReady = %v, want %v (epic blocked, step inherits it, %s ephemeral)", got, want, wisp)
+	}
+
+	blocked, err := s.ListBlocked(ctx, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]*issuestorage.Blockers)
+	for _, b := range blocked {
+		got[b.ID] = b.Blockers
+	}
+	want := map[string]*issuestorage.Blockers{
+		epic: {Direct: []string{blocker}},
+		step: {Inherited: []issuestorage.InheritedBlocker{{AncestorID: epic, BlockerID: blocker}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListBlocked = %+v, want %+v", got, want)
+	}
+
+	// The filter narrows the candidates; ancestors outside it still count.
+	ready, err = s.ListReady(ctx, &issuestorage.ListFilter{Assignees: []string{"alice"}}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := ids(ready), []string{unblocked}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListReady(alice) = %v, want %v", got, want)
+	}
+
+	ready, err = s.ListReady(ctx, &issuestorage.ListFilter{Assignees: []string{"alice"}}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := ids(ready), sorted(step, unblocked); !reflect.DeepEqual(got, want) {
+		t.Errorf("ListReady(alice) without cascade = %v, want %v", got, want)
+	}
+}
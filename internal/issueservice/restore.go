@@ -0,0 +1,28 @@
+package issueservice
+
+import (
+	"context"
+	"errors"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// Restore writes issue to the local store exactly as given, creating it or
+// replacing the stored issue with the same ID, and reports whether it was
+// created. Nothing is defaulted, stamped or validated and related issues
+// are left alone, so restoring every issue of an export, each carrying
+// both sides of its dependencies, reproduces the exported store.
+func (s *IssueStore) Restore(ctx context.Context, issue *issuestorage.Issue) (bool, error) {
+	restored := *issue
+	err := s.local.Modify(ctx, issue.ID, func(existing *issuestorage.Issue) error {
+		*existing = restored
+		return nil
+	})
+	if !errors.Is(err, issuestorage.ErrNotFound) {
+		return false, err
+	}
+	if _, err := s.local.Create(ctx, &restored); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,49 @@
+package issueservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestRestore(t *testing.T) {
+	ctx := context.Background()
+	s := newTestIssueService(t)
+	s.SetRequireApproval(true)
+
+	then := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	issue := &issuestorage.Issue{
+		ID:         "bd-restored",
+		Title:      "From another machine",
+		Status:     issuestorage.StatusClosed,
+		Type:       issuestorage.TypeTask,
+		CreatedAt:  then,
+		UpdatedAt:  then,
+		ClosedAt:   &then,
+		Dependents: []issuestorage.Dependency{{ID: "bd-elsewhere", Type: issuestorage.DepTypeBlocks}},
+	}
+	created, err := s.Restore(ctx, issue)
+	if err != nil || !created {
+		t.Fatalf("Restore = %v, %v; want created", created, err)
+	}
+	got, err := s.Get(ctx, issue.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.UpdatedAt.Equal(then) || got.Status != issuestorage.StatusClosed || len(got.Dependents) != 1 {
+		t.Errorf("restored issue changed: %+v", got)
+	}
+
+	issue.Status = issuestorage.StatusOpen
+	issue.ClosedAt = nil
+	created, err = s.Restore(ctx, issue)
+	if err != nil || created {
+		t.Fatalf("Restore over existing = %v, %v; want replaced", created, err)
+	}
+	got, _ = s.Get(ctx, issue.ID)
+	if got.Status != issuestorage.StatusOpen || got.ReopenCount != 0 || len(got.History) != 0 {
+		t.Errorf("replacement was not verbatim: %+v", got)
+	}
+}
@@ -0,0 +1,71 @@
+package issueservice
+
+import (
+	"context"
+	"fmt"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// ApprovalRequiredError is returned when closing an issue that has no
+// approved review while approvals are required.
+type ApprovalRequiredError struct {
+	ID string
+}
+
+func (e *ApprovalRequiredError) Error() string {
+	return fmt.Sprintf("%s cannot be closed without an approved review (review.require_approval is set; see bd review approve)", e.ID)
+}
+
+// SetRequireApproval makes Modify refuse to close issues whose latest
+// review is not an approval.
+func (s *IssueStore) SetRequireApproval(required bool) {
+	s.requireApproval = required
+}
+
+// checkApproval enforces review.require_approval on a transition to closed.
+func (s *IssueStore) checkApproval(oldStatus issuestorage.Status, issue *issuestorage.Issue) error {
+	if !s.requireApproval || oldStatus == issuestorage.StatusClosed || issue.Status != issuestorage.StatusClosed {
+		return nil
+	}
+	if issue.Approved() {
+		return nil
+	}
+	return &ApprovalRequiredError{ID: issue.ID}
+}
+
+// RequestReview moves an issue into review, assigning reviewer if given.
+func (s *IssueStore) RequestReview(ctx context.Context, id, reviewer string) error {
+	return s.Modify(ctx, id, func(issue *issuestorage.Issue) error {
+		if issue.Status == issuestorage.StatusClosed {
+			return fmt.Errorf("%s is closed; reopen it before requesting review", id)
+		}
+		issue.Status = issuestorage.StatusReview
+		if reviewer != "" {
+			issue.Reviewer = reviewer
+		}
+		return nil
+	})
+}
+
+// RecordReview adds a reviewer's verdict to an issue. Requesting changes
+// sends an issue in review back to in_progress; an approval leaves it in
+// review, ready to close.
+func (s *IssueStore) RecordReview(ctx context.Context, id string, review issuestorage.Review) error {
+	if review.Outcome != issuestorage.ReviewApproved && review.Outcome != issuestorage.ReviewChangesRequested {
+		return fmt.Errorf("invalid review outcome %q", review.Outcome)
+	}
+	if review.At.IsZero() {
+		review.At = s.Now()
+	}
+	return s.Modify(ctx, id, func(issue *issuestorage.Issue) error {
+		if issue.Status == issuestorage.StatusClosed {
+			return fmt.Errorf("%s is closed; reopen it before reviewing", id)
+		}
+		issue.Reviews = append(issue.Reviews, review)
+		if review.Outcome == issuestorage.ReviewChangesRequested && issue.Status == issuestorage.StatusReview {
+			issue.Status = issuestorage.StatusInProgress
+		}
+		return nil
+	})
+}
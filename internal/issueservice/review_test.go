@@ -0,0 +1,69 @@
+package issueservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestRecordReview(t *testing.T) {
+	ctx := context.Background()
+	s := newTestIssueService(t)
+	id, _ := s.Create(ctx, &issuestorage.Issue{Title: "Change", Type: issuestorage.TypeTask})
+
+	if err := s.RequestReview(ctx, id, "bob"); err != nil {
+		t.Fatalf("request review: %v", err)
+	}
+	got, _ := s.Get(ctx, id)
+	if got.Status != issuestorage.StatusReview || got.Reviewer != "bob" {
+		t.Fatalf("after request: status %s reviewer %q, want review by bob", got.Status, got.Reviewer)
+	}
+
+	if err := s.RecordReview(ctx, id, issuestorage.Review{Reviewer: "bob", Outcome: issuestorage.ReviewChangesRequested, Comment: "tests"}); err != nil {
+		t.Fatalf("request changes: %v", err)
+	}
+	got, _ = s.Get(ctx, id)
+	if got.Status != issuestorage.StatusInProgress || got.Approved() {
+		t.Fatalf("after changes requested: status %s approved %v, want in_progress, not approved", got.Status, got.Approved())
+	}
+
+	_ = s.RequestReview(ctx, id, "")
+	if err := s.RecordReview(ctx, id, issuestorage.Review{Reviewer: "bob", Outcome: issuestorage.ReviewApproved}); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	got, _ = s.Get(ctx, id)
+	if got.Status != issuestorage.StatusReview || !got.Approved() || got.Reviewer != "bob" || len(got.Reviews) != 2 {
+		t.Fatalf("after approve: %+v", got)
+	}
+
+	if err := s.RecordReview(ctx, id, issuestorage.Review{Reviewer: "bob", Outcome: "maybe"}); err == nil {
+		t.Error("invalid outcome: want error")
+	}
+}
+
+func TestModifyCloseRequiresApproval(t *testing.T) {
+	ctx := context.Background()
+	s := newTestIssueService(t)
+	s.SetRequireApproval(true)
+	id, _ := s.Create(ctx, &issuestorage.Issue{Title: "Change", Type: issuestorage.TypeTask})
+
+	closeIssue := func() error {
+		return s.Modify(ctx, id, func(i *issuestorage.Issue) error {
+			i.Status = issuestorage.StatusClosed
+			return nil
+		})
+	}
+	var approvalErr *ApprovalRequiredError
+	if err := closeIssue(); !errors.As(err, &approvalErr) {
+		t.Fatalf("close without review: err = %v, want ApprovalRequiredError", err)
+	}
+
+	if err := s.RecordReview(ctx, id, issuestorage.Review{Reviewer: "bob", Outcome: issuestorage.ReviewApproved}); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if err := closeIssue(); err != nil {
+		t.Fatalf("close after approval: %v", err)
+	}
+}
@@ -0,0 +1,230 @@
+package issueservice
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// Config key suffixes for per-type rules. Keys take the form
+// "types.<type>.<suffix>", e.g. "types.bug.default_priority".
+const (
+	typeRuleDefaultPriority = "default_priority"
+	typeRuleDefaultSeverity = "default_severity"
+	typeRuleRequired        = "required"
+)
+
+// RequirableFields lists the issue fields that may be named in a
+// "types.<type>.required" rule.
+var RequirableFields = []string{
+	"acceptance_criteria",
+	"assignee",
+	"await",
+	"description",
+	"estimate",
+	"impact",
+	"labels",
+	"likelihood",
+	"review_by",
+	"severity",
+}
+
+// TypeRule holds the defaults and required fields configured for one
+// issue type.
+type TypeRule struct {
+	DefaultPriority *issuestorage.Priority
+	DefaultSeverity issuestorage.Severity
+	Required        []string
+}
+
+// RequirementError is returned when an issue is missing fields its type
+// requires.
+type RequirementError struct {
+	ID      string
+	Type    issuestorage.IssueType
+	Missing []string
+}
+
+func (e *RequirementError) Error() string {
+	subject := string(e.Type) + " issues"
+	if e.ID != "" {
+		subject = e.ID + " (" + string(e.Type) + ")"
+	}
+	return fmt.Sprintf("%s require: %s (configured by types.%s.required)", subject, strings.Join(e.Missing, ", "), e.Type)
+}
+
+// SetTypeRules installs per-type defaults and field requirements. Rules
+// are enforced by Create and Modify.
+func (s *IssueStore) SetTypeRules(rules map[issuestorage.IssueType]TypeRule) {
+	s.typeRules = rules
+}
+
+// ParseTypeRules extracts per-type rules from flat config values. Invalid
+// entries are skipped and reported together in the returned error.
+func ParseTypeRules(all map[string]string) (map[issuestorage.IssueType]TypeRule, error) {
+	rules := make(map[issuestorage.IssueType]TypeRule)
+	var errs []string
+
+	keys := make([]string, 0, len(all))
+	for k := range all {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		rest, ok := strings.CutPrefix(key, "types.")
+		if !ok {
+			continue
+		}
+		dot := strings.LastIndex(rest, ".")
+		if dot <= 0 {
+			continue // e.g. types.custom
+		}
+		typ, suffix := issuestorage.IssueType(rest[:dot]), rest[dot+1:]
+		value := all[key]
+		rule := rules[typ]
+
+		switch suffix {
+		case typeRuleDefaultPriority:
+			p, err := issuestorage.ParsePriority(value)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", key, err))
+				continue
+			}
+			rule.DefaultPriority = &p
+		case typeRuleDefaultSeverity:
+			sev, err := issuestorage.ParseSeverity(value)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", key, err))
+				continue
+			}
+			rule.DefaultSeverity = sev
+		case typeRuleRequired:
+			rule.Required = nil
+			for _, f := range strings.Split(value, ",") {
+				f = strings.TrimSpace(f)
+				if f == "" {
+					continue
+				}
+				if !isRequirableField(f) {
+					errs = append(errs, fmt.Sprintf("%s: unknown field %q (valid: %s)", key, f, strings.Join(RequirableFields, ", ")))
+					continue
+				}
+				rule.Required = append(rule.Required, f)
+			}
+		default:
+			continue
+		}
+		rules[typ] = rule
+	}
+
+	if len(errs) > 0 {
+		return rules, fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return rules, nil
+}
+
+func isRequirableField(f string) bool {
+	for _, rf := range RequirableFields {
+		if rf == f {
+			return true
+		}
+	}
+	return false
+}
+
+// applyTypeDefaults fills zero-valued fields from the issue type's rule,
+// and the priority unless prioritySet: a zero Priority is a valid
+// explicit value, so the caller says whether it chose one.
+func (s *IssueStore) applyTypeDefaults(issue *issuestorage.Issue, prioritySet bool) {
+	rule, ok := s.typeRules[issue.Type]
+	if !ok {
+		return
+	}
+	if !prioritySet && rule.DefaultPriority != nil {
+		issue.Priority = *rule.DefaultPriority
+	}
+	if issue.Severity == "" {
+		issue.Severity = rule.DefaultSeverity
+	}
+}
+
+// checkTypeRequirements returns a RequirementError if issue is missing any
+// field its type requires.
+func (s *IssueStore) checkTypeRequirements(issue *issuestorage.Issue) error {
+	missing := s.missingRequired(issue)
+	if len(missing) == 0 {
+		return nil
+	}
+	return &RequirementError{ID: issue.ID, Type: issue.Type, Missing: missing}
+}
+
+func (s *IssueStore) missingRequired(issue *issuestorage.Issue) []string {
+	rule, ok := s.typeRules[issue.Type]
+	if !ok {
+		return nil
+	}
+	var missing []string
+	for _, f := range rule.Required {
+		if !hasField(issue, f) {
+			missing = append(missing, f)
+		}
+	}
+	return missing
+}
+
+// checkModifyRequirements enforces type requirements on update. An update
+// is rejected only if it leaves a required field unset that was set
+// before, or changes the type to one whose requirements are unmet, so
+// issues created before a rule was configured remain editable.
+func (s *IssueStore) checkModifyRequirements(before, after *issuestorage.Issue) error {
+	missing := s.missingRequired(after)
+	if len(missing) == 0 {
+		return nil
+	}
+	if before.Type != after.Type {
+		return &RequirementError{ID: after.ID, Type: after.Type, Missing: missing}
+	}
+	var cleared []string
+	for _, f := range missing {
+		if hasField(before, f) {
+			cleared = append(cleared, f)
+		}
+	}
+	if len(cleared) == 0 {
+		return nil
+	}
+	return &RequirementError{ID: after.ID, Type: after.Type, Missing: cleared}
+}
+
+func hasField(issue *issuestorage.Issue, field string) bool {
+	switch field {
+	case "acceptance_criteria":
+		return len(issue.AcceptanceCriteria) > 0
+	case "assignee":
+		return issue.Assignee != ""
+	case "close_reason":
+		return strings.TrimSpace(issue.CloseReason) != ""
+	case "await":
+		return issue.AwaitType != ""
+	case "description":
+		return strings.TrimSpace(issue.Description) != ""
+	case "estimate":
+		return issue.Estimate > 0
+	case "impact":
+		return issue.Impact > 0
+	case "labels":
+		return len(issue.Labels) > 0
+	case "likelihood":
+		return issue.Likelihood > 0
+	case "resolution":
+		return issue.Resolution != ""
+	case "review_by":
+		return issue.ReviewBy != nil
+	case "severity":
+		return issue.Severity != ""
+	}
+	return true
+}
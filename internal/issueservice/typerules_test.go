@@ -0,0 +1,123 @@
+package issueservice
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestParseTypeRules(t *testing.T) {
+	rules, err := ParseTypeRules(map[string]string{
+		"types.custom":                "spike",
+		"types.bug.default_priority":  "P1",
+		"types.bug.default_severity":  "major",
+		"types.bug.required":          "description, severity",
+		"types.gate.required":         "await",
+		"types.feature.required":      "estimate-ish",
+		"types.feature.other_setting": "ignored",
+	})
+	if err == nil || !strings.Contains(err.Error(), "types.feature.required") {
+		t.Errorf("expected error for unknown required field, got %v", err)
+	}
+	bug := rules[issuestorage.TypeBug]
+	if bug.DefaultPriority == nil || *bug.DefaultPriority != issuestorage.PriorityHigh {
+		t.Errorf("bug default priority = %v, want P1", bug.DefaultPriority)
+	}
+	if bug.DefaultSeverity != issuestorage.SeverityMajor {
+		t.Errorf("bug default severity = %q, want major", bug.DefaultSeverity)
+	}
+	if len(bug.Required) != 2 {
+		t.Errorf("bug required = %v, want [description severity]", bug.Required)
+	}
+	if got := rules[issuestorage.TypeGate].Required; len(got) != 1 || got[0] != "await" {
+		t.Errorf("gate required = %v, want [await]", got)
+	}
+}
+
+func TestTypeRulesEnforced(t *testing.T) {
+	ctx := context.Background()
+	s := newTestIssueService(t)
+	rules, err := ParseTypeRules(map[string]string{
+		"types.bug.default_severity": "minor",
+		"types.bug.required":         "description,assignee",
+	})
+	if err != nil {
+		t.Fatalf("ParseTypeRules: %v", err)
+	}
+	s.SetTypeRules(rules)
+
+	_, err = s.Create(ctx, &issuestorage.Issue{Title: "Crash", Type: issuestorage.TypeBug})
+	var reqErr *RequirementError
+	if !errors.As(err, &reqErr) {
+		t.Fatalf("expected RequirementError, got %v", err)
+	}
+	if len(reqErr.Missing) != 2 || !strings.Contains(err.Error(), "description, assignee") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	id, err := s.Create(ctx, &issuestorage.Issue{Title: "Crash", Type: issuestorage.TypeBug, Description: "Steps...", Assignee: "alice"})
+	if err != nil {
+		t.Fatalf("Create with required fields: %v", err)
+	}
+	bug, _ := s.Get(ctx, id)
+	if bug.Severity != issuestorage.SeverityMinor {
+		t.Errorf("severity = %q, want default minor", bug.Severity)
+	}
+
+	// Clearing a required field is rejected; other edits are fine.
+	if err := s.Modify(ctx, id, func(i *issuestorage.Issue) error { i.Assignee = ""; return nil }); err == nil {
+		t.Error("expected error clearing required assignee")
+	}
+	if err := s.Modify(ctx, id, func(i *issuestorage.Issue) error { i.Title = "Crash on save"; return nil }); err != nil {
+		t.Errorf("unrelated edit rejected: %v", err)
+	}
+
+	// Changing a task into a bug must satisfy bug requirements.
+	taskID, _ := s.Create(ctx, &issuestorage.Issue{Title: "Task"})
+	if err := s.Modify(ctx, taskID, func(i *issuestorage.Issue) error { i.Type = issuestorage.TypeBug; return nil }); err == nil {
+		t.Error("expected error converting task to bug without required fields")
+	}
+}
+
+func TestTypeDefaultPriority(t *testing.T) {
+	ctx := context.Background()
+	s := newTestIssueService(t)
+	rules, err := ParseTypeRules(map[string]string{
+		"types.bug.default_priority": "P1",
+		"types.feature.required":     "estimate",
+	})
+	if err != nil {
+		t.Fatalf("ParseTypeRules: %v", err)
+	}
+	s.SetTypeRules(rules)
+
+	// Without PrioritySet the type's default replaces the priority.
+	id, err := s.Create(ctx, &issuestorage.Issue{Title: "Crash", Type: issuestorage.TypeBug, Priority: issuestorage.PriorityMedium})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if bug, _ := s.Get(ctx, id); bug.Priority != issuestorage.PriorityHigh {
+		t.Errorf("priority = %v, want default P1", bug.Priority)
+	}
+
+	// A chosen priority is kept, even P0.
+	id, err = s.Create(ctx, &issuestorage.Issue{Title: "Outage", Type: issuestorage.TypeBug, Priority: issuestorage.PriorityCritical},
+		issuestorage.CreateOpts{PrioritySet: true})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if bug, _ := s.Get(ctx, id); bug.Priority != issuestorage.PriorityCritical {
+		t.Errorf("priority = %v, want chosen P0", bug.Priority)
+	}
+
+	// An estimate can be required.
+	if _, err := s.Create(ctx, &issuestorage.Issue{Title: "Export", Type: issuestorage.TypeFeature}); err == nil {
+		t.Error("expected error creating feature without required estimate")
+	}
+	if _, err := s.Create(ctx, &issuestorage.Issue{Title: "Export", Type: issuestorage.TypeFeature, Estimate: 90}); err != nil {
+		t.Errorf("Create with estimate: %v", err)
+	}
+}
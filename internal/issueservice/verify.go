@@ -0,0 +1,178 @@
+package issueservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// ParanoidConfigKey sets the ParanoidMode for writes that touch more than
+// one issue.
+const ParanoidConfigKey = "graph.paranoid"
+
+// ParanoidMode says what to do when a write that touches more than one
+// issue leaves their relationships asymmetric.
+type ParanoidMode string
+
+const (
+	// ParanoidOff skips verification.
+	ParanoidOff ParanoidMode = "off"
+	// ParanoidWarn reports asymmetries to the warning writer.
+	ParanoidWarn ParanoidMode = "warn"
+	// ParanoidFail returns an AsymmetryError from the write.
+	ParanoidFail ParanoidMode = "fail"
+)
+
+// ParseParanoidMode parses a graph.paranoid value.
+func ParseParanoidMode(v string) (ParanoidMode, error) {
+	switch mode := ParanoidMode(v); mode {
+	case ParanoidOff, ParanoidWarn, ParanoidFail:
+		return mode, nil
+	}
+	return "", fmt.Errorf("must be %q, %q or %q, got %q", ParanoidOff, ParanoidWarn, ParanoidFail, v)
+}
+
+// SetParanoid makes AddDependency and RemoveDependency re-read the issues
+// they touch, and their neighbours, after writing and check that every
+// dependency is listed on both sides and every parent lists its children.
+// In ParanoidWarn mode problems are written to warn; in ParanoidFail mode
+// they are returned as an AsymmetryError.
+func (s *IssueStore) SetParanoid(mode ParanoidMode, warn io.Writer) {
+	s.paranoid = mode
+	s.paranoidWarn = warn
+}
+
+// AsymmetryError is returned in ParanoidFail mode when a write leaves the
+// two sides of a relationship disagreeing, such as a dependency the target
+// does not list as a dependent. The write itself is not rolled back.
+type AsymmetryError struct {
+	Op       string
+	Problems []string
+}
+
+func (e *AsymmetryError) Error() string {
+	return fmt.Sprintf("%s left the dependency graph asymmetric: %s", e.Op, strings.Join(e.Problems, "; "))
+}
+
+// verified runs fn, a write described by op touching ids, and, unless
+// paranoid mode is off, reports the asymmetries among ids and their
+// neighbours that fn introduced. Asymmetries present before the write,
+// which bd doctor reports, are ignored. A failed write is verified too,
+// since a write that fails half way is the likeliest to leave one side
+// behind.
+func (s *IssueStore) verified(ctx context.Context, op string, ids []string, fn func() error) error {
+	if s.paranoid == "" || s.paranoid == ParanoidOff {
+		return fn()
+	}
+	affected := s.neighbourhood(ctx, ids)
+	before := s.asymmetries(ctx, affected)
+	err := fn()
+
+	var introduced []string
+	for _, problem := range s.asymmetries(ctx, affected) {
+		if !slices.Contains(before, problem) {
+			introduced = append(introduced, problem)
+		}
+	}
+	if len(introduced) == 0 {
+		return err
+	}
+	asymmetry := &AsymmetryError{Op: op, Problems: introduced}
+	if s.paranoid == ParanoidFail {
+		return errors.Join(err, asymmetry)
+	}
+	if s.paranoidWarn != nil {
+		fmt.Fprintf(s.paranoidWarn, "warning: %v\n", asymmetry)
+	}
+	return err
+}
+
+// neighbourhood returns ids plus every issue they are related to, sorted.
+func (s *IssueStore) neighbourhood(ctx context.Context, ids []string) []string {
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		seen[id] = true
+		issue, err := s.storeFor(id).Get(ctx, id)
+		if err != nil {
+			continue
+		}
+		if issue.Parent != "" {
+			seen[issue.Parent] = true
+		}
+		for _, dep := range issue.Dependencies {
+			seen[dep.ID] = true
+		}
+		for _, dep := range issue.Dependents {
+			seen[dep.ID] = true
+		}
+	}
+	all := make([]string, 0, len(seen))
+	for id := range seen {
+		all = append(all, id)
+	}
+	sort.Strings(all)
+	return all
+}
+
+// asymmetries re-reads ids and describes each relationship that only one
+// side records. Missing issues are skipped; dangling references are
+// bd doctor's concern.
+func (s *IssueStore) asymmetries(ctx context.Context, ids []string) []string {
+	issues := make(map[string]*issuestorage.Issue)
+	get := func(id string) *issuestorage.Issue {
+		if issue, ok := issues[id]; ok {
+			return issue
+		}
+		issue, err := s.storeFor(id).Get(ctx, id)
+		if err != nil {
+			issue = nil
+		}
+		issues[id] = issue
+		return issue
+	}
+
+	var problems []string
+	report := func(problem string) {
+		if !slices.Contains(problems, problem) {
+			problems = append(problems, problem)
+		}
+	}
+	for _, id := range ids {
+		issue := get(id)
+		if issue == nil {
+			continue
+		}
+		for _, dep := range issue.Dependencies {
+			if target := get(dep.ID); target != nil && !hasDep(target.Dependents, id, dep.Type) {
+				report(fmt.Sprintf("%s depends on %s (%s) but %s does not list it as a dependent", id, dep.ID, dep.Type, dep.ID))
+			}
+		}
+		for _, dep := range issue.Dependents {
+			if source := get(dep.ID); source != nil && !hasDep(source.Dependencies, id, dep.Type) {
+				report(fmt.Sprintf("%s lists %s as a dependent (%s) but %s does not depend on it", id, dep.ID, dep.Type, dep.ID))
+			}
+		}
+		if issue.Parent != "" {
+			if parent := get(issue.Parent); parent != nil && !hasDep(parent.Dependents, id, issuestorage.DepTypeParentChild) {
+				report(fmt.Sprintf("%s has parent %s but %s does not list it as a child", id, issue.Parent, issue.Parent))
+			}
+		}
+	}
+	return problems
+}
+
+// hasDep reports whether deps holds a dependency on id of type depType.
+func hasDep(deps []issuestorage.Dependency, id string, depType issuestorage.DependencyType) bool {
+	for _, dep := range deps {
+		if dep.ID == id && dep.Type == depType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package issueservice
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/issuestorage/filesystem"
+)
+
+// failingStore fails every Modify of one issue, so a dependency write
+// updates one side and not the other.
+type failingStore struct {
+	issuestorage.IssueStore
+	failID string
+}
+
+var errWriteFailed = errors.New("write failed")
+
+func (f *failingStore) Modify(ctx context.Context, id string, fn func(*issuestorage.Issue) error) error {
+	if id == f.failID {
+		return errWriteFailed
+	}
+	return f.IssueStore.Modify(ctx, id, fn)
+}
+
+func TestParanoidVerifiesDependencyWrites(t *testing.T) {
+	ctx := context.Background()
+	local := filesystem.New(t.TempDir(), "bd-")
+	if err := local.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+	failing := &failingStore{IssueStore: local}
+	s := New(nil, failing)
+	var warnings bytes.Buffer
+
+	a, _ := s.Create(ctx, &issuestorage.Issue{Title: "A"})
+	b, _ := s.Create(ctx, &issuestorage.Issue{Title: "B"})
+	epic, _ := s.Create(ctx, &issuestorage.Issue{Title: "Epic", Type: issuestorage.TypeEpic})
+	other, _ := s.Create(ctx, &issuestorage.Issue{Title: "Other epic", Type: issuestorage.TypeEpic})
+
+	// Symmetric writes pass, including reparenting.
+	s.SetParanoid(ParanoidFail, &warnings)
+	for _, step := range []func() error{
+		func() error { return s.AddDependency(ctx, a, b, issuestorage.DepTypeBlocks) },
+		func() error { return s.AddDependency(ctx, a, epic, issuestorage.DepTypeParentChild) },
+		func() error { return s.AddDependency(ctx, a, other, issuestorage.DepTypeParentChild) },
+		func() error { return s.RemoveDependency(ctx, a, b) },
+	} {
+		if err := step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Off: a half-done write is only the storage error.
+	failing.failID = b
+	s.SetParanoid(ParanoidOff, &warnings)
+	if err := s.AddDependency(ctx, a, b, issuestorage.DepTypeBlocks); !errors.Is(err, errWriteFailed) {
+		t.Fatalf("AddDependency = %v, want the write error", err)
+	}
+	failing.failID = ""
+	if err := s.RemoveDependency(ctx, a, b); err != nil {
+		t.Fatal(err)
+	}
+
+	// Warn: the asymmetry is reported and the error is unchanged.
+	failing.failID = b
+	s.SetParanoid(ParanoidWarn, &warnings)
+	var asymmetry *AsymmetryError
+	if err := s.AddDependency(ctx, a, b, issuestorage.DepTypeBlocks); !errors.Is(err, errWriteFailed) || errors.As(err, &asymmetry) {
+		t.Fatalf("AddDependency in warn mode = %v", err)
+	}
+	want := a + " depends on " + b + " (blocks) but " + b + " does not list it as a dependent"
+	if !strings.HasPrefix(warnings.String(), "warning: ") || !strings.Contains(warnings.String(), want) {
+		t.Errorf("warnings = %q, want %q", warnings.String(), want)
+	}
+
+	// Fail: an asymmetry already present is not blamed on the next write.
+	s.SetParanoid(ParanoidFail, &warnings)
+	warnings.Reset()
+	failing.failID = ""
+	if err := s.AddDependency(ctx, b, epic, issuestorage.DepTypeRelated); err != nil {
+		t.Errorf("a write next to an existing asymmetry = %v", err)
+	}
+
+	// Reparenting ignores a failure to update the old parent; the
+	// verifier does not.
+	failing.failID = other
+	err := s.AddDependency(ctx, a, epic, issuestorage.DepTypeParentChild)
+	if !errors.As(err, &asymmetry) {
+		t.Fatalf("AddDependency in fail mode = %v", err)
+	}
+	if len(asymmetry.Problems) != 1 || !strings.Contains(asymmetry.Problems[0], other+" lists "+a+" as a dependent (parent-child)") {
+		t.Errorf("problems = %q", asymmetry.Problems)
+	}
+	if warnings.Len() != 0 {
+		t.Errorf("fail mode wrote warnings: %q", warnings.String())
+	}
+}
@@ -0,0 +1,29 @@
+package issueservice
+
+import (
+	"context"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// WatchPollInterval is how often Watch lists the store for changes when
+// the backend cannot report them itself.
+const WatchPollInterval = 2 * time.Second
+
+// SetWatcher sets the watcher Watch uses, usually the backend under any
+// caching or indexing layers. A nil watcher makes Watch poll.
+func (s *IssueStore) SetWatcher(w issuestorage.Watcher) {
+	s.watcher = w
+}
+
+// Watch streams changes to the local issues matching filter until ctx is
+// done; see issuestorage.Watcher. Backends without a watcher are polled
+// every WatchPollInterval.
+func (s *IssueStore) Watch(ctx context.Context, filter *issuestorage.ListFilter) (<-chan issuestorage.Change, error) {
+	w := s.watcher
+	if w == nil {
+		w = &issuestorage.PollWatcher{Store: s.local, Interval: WatchPollInterval}
+	}
+	return w.Watch(ctx, filter)
+}
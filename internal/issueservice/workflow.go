@@ -0,0 +1,230 @@
+package issueservice
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// Config keys for the workflow take the form "workflow.<status>.next",
+// "workflow.<status>.required" and "workflow.<status>.<type>.required".
+const (
+	WorkflowKeyPrefix    = "workflow."
+	workflowRuleNext     = "next"
+	workflowRuleRequired = "required"
+)
+
+// TransitionFields lists the issue fields that may be named in a
+// "workflow.<status>.required" rule: the fields types can require, plus
+// the ones only a transition sets.
+var TransitionFields = append(slices.Clone(RequirableFields), "close_reason", "resolution")
+
+// Workflow is the configured status state machine. Statuses without a
+// Next entry may move to any status.
+type Workflow struct {
+	// Next lists the statuses each status may move to.
+	Next map[issuestorage.Status][]issuestorage.Status
+	// Required lists the fields an issue must have to enter a status, by
+	// issue type; the "" type applies to every type.
+	Required map[issuestorage.Status]map[issuestorage.IssueType][]string
+}
+
+// IsZero reports whether no workflow is configured.
+func (w Workflow) IsZero() bool {
+	return len(w.Next) == 0 && len(w.Required) == 0
+}
+
+// Statuses returns every status the workflow mentions, sorted.
+func (w Workflow) Statuses() []issuestorage.Status {
+	seen := make(map[issuestorage.Status]bool)
+	for from, next := range w.Next {
+		seen[from] = true
+		for _, to := range next {
+			seen[to] = true
+		}
+	}
+	for to := range w.Required {
+		seen[to] = true
+	}
+	statuses := make([]issuestorage.Status, 0, len(seen))
+	for s := range seen {
+		statuses = append(statuses, s)
+	}
+	slices.Sort(statuses)
+	return statuses
+}
+
+// Allowed reports whether an issue may move from one status to another.
+func (w Workflow) Allowed(from, to issuestorage.Status) bool {
+	next, ok := w.Next[from]
+	return from == to || !ok || slices.Contains(next, to)
+}
+
+// RequiredFor returns the fields an issue of type t needs to enter status:
+// those required of every type, then those of t.
+func (w Workflow) RequiredFor(status issuestorage.Status, t issuestorage.IssueType) []string {
+	byType := w.Required[status]
+	fields := slices.Clone(byType[""])
+	for _, f := range byType[t] {
+		if !slices.Contains(fields, f) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// TransitionError is returned when a status change is not allowed by the
+// workflow, or the issue lacks fields the new status requires.
+type TransitionError struct {
+	ID       string
+	Type     issuestorage.IssueType
+	From, To issuestorage.Status
+	Allowed  []issuestorage.Status // when the transition itself is not allowed
+	Missing  []string              // when required fields are missing
+}
+
+func (e *TransitionError) Error() string {
+	if e.Missing == nil {
+		next := make([]string, len(e.Allowed))
+		for i, s := range e.Allowed {
+			next[i] = string(s)
+		}
+		allowed := strings.Join(next, ", ")
+		if allowed == "" {
+			allowed = "none"
+		}
+		return fmt.Sprintf("%s cannot move from %s to %s (allowed: %s; configured by workflow.%s.next)", e.ID, e.From, e.To, allowed, e.From)
+	}
+	return fmt.Sprintf("%s (%s) needs %s to move to %s (configured by workflow.%s.required)", e.ID, e.Type, strings.Join(e.Missing, ", "), e.To, e.To)
+}
+
+// SetWorkflow installs the status state machine enforced by Modify.
+func (s *IssueStore) SetWorkflow(w Workflow) {
+	s.workflow = w
+}
+
+// Workflow returns the installed status state machine.
+func (s *IssueStore) Workflow() Workflow {
+	return s.workflow
+}
+
+// ParseWorkflow extracts the workflow from flat config values. Invalid
+// entries are skipped and reported together in the returned error. Status
+// names must be built-in or listed in status.custom.
+func ParseWorkflow(all map[string]string) (Workflow, error) {
+	w := Workflow{
+		Next:     make(map[issuestorage.Status][]issuestorage.Status),
+		Required: make(map[issuestorage.Status]map[issuestorage.IssueType][]string),
+	}
+	known := make(map[string]bool)
+	for _, st := range issuestorage.BuiltinStatuses {
+		known[string(st)] = true
+	}
+	for _, st := range strings.Split(all["status.custom"], ",") {
+		if st = strings.TrimSpace(st); st != "" {
+			known[st] = true
+		}
+	}
+	var errs []string
+
+	keys := make([]string, 0, len(all))
+	for k := range all {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		rest, ok := strings.CutPrefix(key, WorkflowKeyPrefix)
+		if !ok {
+			continue
+		}
+		parts := strings.Split(rest, ".")
+		rule := parts[len(parts)-1]
+		if len(parts) < 2 || len(parts) > 3 || (len(parts) == 3 && rule != workflowRuleRequired) {
+			errs = append(errs, fmt.Sprintf("%s: unknown workflow key (want workflow.<status>.next, workflow.<status>.required or workflow.<status>.<type>.required)", key))
+			continue
+		}
+		if !known[parts[0]] {
+			errs = append(errs, fmt.Sprintf("%s: unknown status %q", key, parts[0]))
+			continue
+		}
+		status := issuestorage.Status(parts[0])
+		value := all[key]
+
+		switch rule {
+		case workflowRuleNext:
+			next := []issuestorage.Status{}
+			for _, to := range strings.Split(value, ",") {
+				to = strings.TrimSpace(to)
+				if to == "" {
+					continue
+				}
+				if !known[to] {
+					errs = append(errs, fmt.Sprintf("%s: unknown status %q", key, to))
+					continue
+				}
+				next = append(next, issuestorage.Status(to))
+			}
+			// A status whose targets are all invalid stays unrestricted
+			// rather than becoming a dead end.
+			if len(next) > 0 || strings.TrimSpace(value) == "" {
+				w.Next[status] = next
+			}
+		case workflowRuleRequired:
+			var t issuestorage.IssueType
+			if len(parts) == 3 {
+				t = issuestorage.IssueType(parts[1])
+			}
+			var fields []string
+			for _, f := range strings.Split(value, ",") {
+				f = strings.TrimSpace(f)
+				if f == "" {
+					continue
+				}
+				if !slices.Contains(TransitionFields, f) {
+					errs = append(errs, fmt.Sprintf("%s: unknown field %q (valid: %s)", key, f, strings.Join(TransitionFields, ", ")))
+					continue
+				}
+				fields = append(fields, f)
+			}
+			if w.Required[status] == nil {
+				w.Required[status] = make(map[issuestorage.IssueType][]string)
+			}
+			w.Required[status][t] = fields
+		default:
+			errs = append(errs, fmt.Sprintf("%s: unknown workflow rule %q (want next or required)", key, rule))
+		}
+	}
+
+	if len(errs) > 0 {
+		return w, fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return w, nil
+}
+
+// checkTransition returns a TransitionError if moving issue from its old
+// status is not allowed, or issue lacks fields its new status requires.
+// It is called before status side effects, so a close reason counts only
+// if the caller set one. Deleting to a tombstone and back is not a
+// workflow transition.
+func (s *IssueStore) checkTransition(from issuestorage.Status, issue *issuestorage.Issue) error {
+	if from == issue.Status || from == issuestorage.StatusTombstone || issue.Status == issuestorage.StatusTombstone {
+		return nil
+	}
+	if !s.workflow.Allowed(from, issue.Status) {
+		return &TransitionError{ID: issue.ID, Type: issue.Type, From: from, To: issue.Status, Allowed: s.workflow.Next[from]}
+	}
+	var missing []string
+	for _, f := range s.workflow.RequiredFor(issue.Status, issue.Type) {
+		if !hasField(issue, f) {
+			missing = append(missing, f)
+		}
+	}
+	if len(missing) > 0 {
+		return &TransitionError{ID: issue.ID, Type: issue.Type, From: from, To: issue.Status, Missing: missing}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package issueservice
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestParseWorkflow(t *testing.T) {
+	w, err := ParseWorkflow(map[string]string{
+		"status.custom":                   "triage",
+		"workflow.triage.next":            "open, closed",
+		"workflow.open.next":              "in_progress,closed",
+		"workflow.closed.required":        "close_reason",
+		"workflow.closed.bug.required":    "resolution, close_reason",
+		"workflow.review.required":        "estimate-ish",
+		"workflow.shipped.next":           "open",
+		"workflow.open.other":             "x",
+		"workflow.in_progress.next":       "done",
+		"workflow.in_progress.bug.unused": "x",
+	})
+	for _, key := range []string{"workflow.review.required", "workflow.shipped.next", "workflow.open.other", "workflow.in_progress.next", "workflow.in_progress.bug.unused"} {
+		if err == nil || !strings.Contains(err.Error(), key) {
+			t.Errorf("expected an error for %s, got %v", key, err)
+		}
+	}
+	if got := w.Next["triage"]; !reflect.DeepEqual(got, []issuestorage.Status{issuestorage.StatusOpen, issuestorage.StatusClosed}) {
+		t.Errorf("triage next = %v", got)
+	}
+	if got := w.RequiredFor(issuestorage.StatusClosed, issuestorage.TypeBug); !reflect.DeepEqual(got, []string{"close_reason", "resolution"}) {
+		t.Errorf("bug close requires %v", got)
+	}
+	if got := w.RequiredFor(issuestorage.StatusClosed, issuestorage.TypeTask); !reflect.DeepEqual(got, []string{"close_reason"}) {
+		t.Errorf("task close requires %v", got)
+	}
+	if !w.Allowed(issuestorage.StatusInProgress, issuestorage.StatusOpen) || w.Allowed(issuestorage.StatusOpen, issuestorage.StatusBlocked) {
+		t.Error("Allowed should permit unconfigured statuses and refuse unlisted targets")
+	}
+}
+
+func TestWorkflowEnforced(t *testing.T) {
+	ctx := context.Background()
+	s := newTestIssueService(t)
+	w, err := ParseWorkflow(map[string]string{
+		"workflow.open.next":           "in_progress",
+		"workflow.closed.bug.required": "close_reason",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetWorkflow(w)
+	setStatus := func(id string, status issuestorage.Status, reason string) error {
+		return s.Modify(ctx, id, func(i *issuestorage.Issue) error {
+			i.Status = status
+			i.CloseReason = reason
+			return nil
+		})
+	}
+
+	bug, _ := s.Create(ctx, &issuestorage.Issue{Title: "Crash", Type: issuestorage.TypeBug})
+	err = setStatus(bug, issuestorage.StatusClosed, "")
+	var terr *TransitionError
+	if !errors.As(err, &terr) || terr.Missing != nil || !strings.Contains(err.Error(), "allowed: in_progress") {
+		t.Fatalf("open → closed = %v, want a disallowed transition", err)
+	}
+	if err := s.Modify(ctx, bug, func(i *issuestorage.Issue) error { i.Title = "Crash on save"; return nil }); err != nil {
+		t.Errorf("an edit without a status change = %v", err)
+	}
+	if err := setStatus(bug, issuestorage.StatusInProgress, ""); err != nil {
+		t.Fatal(err)
+	}
+	err = setStatus(bug, issuestorage.StatusClosed, "")
+	if !errors.As(err, &terr) || !reflect.DeepEqual(terr.Missing, []string{"close_reason"}) {
+		t.Fatalf("closing a bug without a reason = %v", err)
+	}
+	if err := setStatus(bug, issuestorage.StatusClosed, "fixed"); err != nil {
+		t.Errorf("closing a bug with a reason = %v", err)
+	}
+
+	task, _ := s.Create(ctx, &issuestorage.Issue{Title: "Chore", Status: issuestorage.StatusInProgress})
+	if err := setStatus(task, issuestorage.StatusClosed, ""); err != nil {
+		t.Errorf("closing a task without a reason = %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+package issuestorage
+
+import "fmt"
+
+// BackendConfigKey selects the storage engine for the local project.
+const BackendConfigKey = "storage.backend"
+
+// Storage backends selectable with storage.backend.
+const (
+	BackendFilesystem = "filesystem"
+	BackendS3         = "s3"
+	BackendPostgres   = "postgres"
+)
+
+// Backends lists the valid storage.backend values.
+var Backends = []string{BackendFilesystem, BackendS3, BackendPostgres}
+
+// ParseBackend validates a storage.backend value. Empty selects the
+// filesystem backend.
+func ParseBackend(s string) (string, error) {
+	if s == "" {
+		return BackendFilesystem, nil
+	}
+	for _, b := range Backends {
+		if s == b {
+			return b, nil
+		}
+	}
+	return "", fmt.Errorf("invalid storage backend %q (valid: %s, %s, %s)", s, BackendFilesystem, BackendS3, BackendPostgres)
+}
@@ -0,0 +1,109 @@
+package issuestorage
+
+import (
+	"context"
+	"fmt"
+)
+
+// InheritedBlocker represents a blocking constraint inherited from an ancestor.
+type InheritedBlocker struct {
+	AncestorID string // the parent/grandparent that has the blocking dep
+	BlockerID  string // the issue blocking that ancestor
+}
+
+// Blockers holds both direct and inherited blocking info for an issue.
+type Blockers struct {
+	Direct    []string           // direct DepTypeBlocks dependency IDs that are unclosed
+	Inherited []InheritedBlocker // blocking constraints from ancestors
+}
+
+// HasBlockers returns true if there are any direct or inherited blockers.
+func (b *Blockers) HasBlockers() bool {
+	return len(b.Direct) > 0 || len(b.Inherited) > 0
+}
+
+// AllBlockerIDs returns a deduplicated set of all blocker IDs (direct and inherited).
+func (b *Blockers) AllBlockerIDs() []string {
+	seen := make(map[string]bool, len(b.Direct)+len(b.Inherited))
+	var ids []string
+	for _, id := range b.Direct {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, ib := range b.Inherited {
+		if !seen[ib.BlockerID] {
+			seen[ib.BlockerID] = true
+			ids = append(ids, ib.BlockerID)
+		}
+	}
+	return ids
+}
+
+// ResolveBlockers returns the blocking constraints on each of issues, keyed
+// by issue ID:
+//   - Direct: the issue's own unclosed DepTypeBlocks dependencies
+//   - Inherited: unclosed DepTypeBlocks dependencies from any ancestor in
+//     the parent chain, only when cascade is set
+//
+// A dependency is resolved when its ID is in closed. Ancestors are read
+// through getter, once each however many issues share them. An ancestor
+// that cannot be loaded is an error: treating the chain as unblocked
+// would report blocked work as ready.
+func ResolveBlockers(
+	ctx context.Context,
+	getter IssueGetter,
+	issues []*Issue,
+	closed map[string]bool,
+	cascade bool,
+) (map[string]*Blockers, error) {
+	results := make(map[string]*Blockers, len(issues))
+	ancestors := make(map[string]*Issue)
+	depType := DepTypeBlocks
+
+	for _, issue := range issues {
+		result := &Blockers{}
+		for _, depID := range issue.DependencyIDs(&depType) {
+			if !closed[depID] {
+				result.Direct = append(result.Direct, depID)
+			}
+		}
+		results[issue.ID] = result
+		if !cascade {
+			continue
+		}
+
+		// Walk parent chain for inherited blockers
+		currentID := issue.Parent
+		visited := map[string]bool{issue.ID: true}
+		for currentID != "" {
+			if visited[currentID] {
+				// Cycle guard — stop traversal
+				break
+			}
+			visited[currentID] = true
+
+			ancestor, ok := ancestors[currentID]
+			if !ok {
+				var err error
+				ancestor, err = getter.Get(ctx, currentID)
+				if err != nil {
+					return nil, fmt.Errorf("loading ancestor %s of %s: %w", currentID, issue.ID, err)
+				}
+				ancestors[currentID] = ancestor
+			}
+
+			for _, depID := range ancestor.DependencyIDs(&depType) {
+				if !closed[depID] {
+					result.Inherited = append(result.Inherited, InheritedBlocker{
+						AncestorID: currentID,
+						BlockerID:  depID,
+					})
+				}
+			}
+			currentID = ancestor.Parent
+		}
+	}
+	return results, nil
+}
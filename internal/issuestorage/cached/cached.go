@@ -0,0 +1,221 @@
+// Package cached implements a read-through caching IssueStore decorator.
+// Get and List results are kept in memory and revalidated against cheap
+// version tokens from the wrapped store (for the filesystem backend, file
+// and directory mtimes), so repeated reads skip reading and parsing issue
+// files while changes made by other processes are still seen.
+package cached
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// ConfigKey enables the cache for the filesystem backend.
+const ConfigKey = "storage.cache"
+
+// Versioned is a store that can report cheaply whether its data changed.
+// A token of "" means the state is too new to be versioned reliably and
+// must not be cached.
+type Versioned interface {
+	issuestorage.IssueStore
+
+	// Version returns a token that changes whenever the issue is written.
+	// Returns ErrNotFound if the issue doesn't exist.
+	Version(ctx context.Context, id string) (string, error)
+
+	// Revision returns a token that changes whenever any issue is created,
+	// modified or deleted.
+	Revision(ctx context.Context) (string, error)
+}
+
+// Store wraps a Versioned store with an in-memory cache.
+type Store struct {
+	inner Versioned
+
+	mu       sync.Mutex
+	issues   map[string]entry // issue ID → encoded issue
+	lists    map[string][]byte
+	revision string // revision the lists were computed at
+	stats    Stats
+}
+
+type entry struct {
+	version string
+	data    []byte
+}
+
+// Stats counts cache hits and misses.
+type Stats struct {
+	Hits   int
+	Misses int
+}
+
+// New wraps inner with a cache.
+func New(inner Versioned) *Store {
+	return &Store{
+		inner:  inner,
+		issues: make(map[string]entry),
+		lists:  make(map[string][]byte),
+	}
+}
+
+// Stats returns the cache's hit and miss counts.
+func (s *Store) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+// invalidate drops cached state affected by a write to id, or everything
+// if id is empty. Version tokens would catch our own writes too; dropping
+// eagerly keeps the cache correct even if a store's tokens are coarse.
+func (s *Store) invalidate(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id == "" {
+		s.issues = make(map[string]entry)
+	}
+	delete(s.issues, id)
+	s.lists = make(map[string][]byte)
+	s.revision = ""
+}
+
+// Get returns the cached issue if its version is unchanged, otherwise
+// reads it from the wrapped store. Callers receive their own copy.
+func (s *Store) Get(ctx context.Context, id string) (*issuestorage.Issue, error) {
+	version, err := s.inner.Version(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if version != "" {
+		s.mu.Lock()
+		e, ok := s.issues[id]
+		if ok && e.version == version {
+			s.stats.Hits++
+			s.mu.Unlock()
+			var issue issuestorage.Issue
+			if err := json.Unmarshal(e.data, &issue); err != nil {
+				return nil, err
+			}
+			return &issue, nil
+		}
+		s.stats.Misses++
+		s.mu.Unlock()
+	}
+
+	issue, err := s.inner.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if version != "" {
+		if data, err := json.Marshal(issue); err == nil {
+			s.mu.Lock()
+			s.issues[id] = entry{version: version, data: data}
+			s.mu.Unlock()
+		}
+	}
+	return issue, nil
+}
+
+// List returns cached results for the same filter if no issue has changed
+// since they were computed, otherwise lists from the wrapped store.
+func (s *Store) List(ctx context.Context, filter *issuestorage.ListFilter) ([]*issuestorage.Issue, error) {
+	revision, err := s.inner.Revision(ctx)
+	if err != nil {
+		return nil, err
+	}
+	key, err := json.Marshal(filter)
+	if err != nil {
+		return s.inner.List(ctx, filter)
+	}
+
+	if revision != "" {
+		s.mu.Lock()
+		if s.revision != revision {
+			s.lists = make(map[string][]byte)
+			s.revision = revision
+		}
+		data, ok := s.lists[string(key)]
+		if ok {
+			s.stats.Hits++
+			s.mu.Unlock()
+			var issues []*issuestorage.Issue
+			if err := json.Unmarshal(data, &issues); err != nil {
+				return nil, err
+			}
+			return issues, nil
+		}
+		s.stats.Misses++
+		s.mu.Unlock()
+	}
+
+	issues, err := s.inner.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if revision != "" {
+		if data, err := json.Marshal(issues); err == nil {
+			s.mu.Lock()
+			if s.revision == revision {
+				s.lists[string(key)] = data
+			}
+			s.mu.Unlock()
+		}
+	}
+	return issues, nil
+}
+
+// ListReady implements issuestorage.IssueStore on top of the cached List,
+// so repeated calls between changes are served from memory.
+func (s *Store) ListReady(ctx context.Context, filter *issuestorage.ListFilter, cascade bool) ([]*issuestorage.Issue, error) {
+	return issuestorage.ListReady(ctx, s, filter, cascade)
+}
+
+// ListBlocked implements issuestorage.IssueStore on top of the cached
+// List.
+func (s *Store) ListBlocked(ctx context.Context, filter *issuestorage.ListFilter, cascade bool) ([]issuestorage.BlockedIssue, error) {
+	return issuestorage.ListBlocked(ctx, s, filter, cascade)
+}
+
+// Create creates the issue in the wrapped store.
+func (s *Store) Create(ctx context.Context, issue *issuestorage.Issue, opts ...issuestorage.CreateOpts) (string, error) {
+	id, err := s.inner.Create(ctx, issue, opts...)
+	if err != nil {
+		return "", err
+	}
+	s.invalidate(id)
+	return id, nil
+}
+
+// Modify modifies the issue in the wrapped store.
+func (s *Store) Modify(ctx context.Context, id string, fn func(*issuestorage.Issue) error) error {
+	defer s.invalidate(id)
+	return s.inner.Modify(ctx, id, fn)
+}
+
+// Delete deletes the issue from the wrapped store.
+func (s *Store) Delete(ctx context.Context, id string) error {
+	defer s.invalidate(id)
+	return s.inner.Delete(ctx, id)
+}
+
+// GetNextChildID delegates to the wrapped store.
+func (s *Store) GetNextChildID(ctx context.Context, parentID string) (string, error) {
+	return s.inner.GetNextChildID(ctx, parentID)
+}
+
+// Init delegates to the wrapped store.
+func (s *Store) Init(ctx context.Context) error {
+	return s.inner.Init(ctx)
+}
+
+// Doctor delegates to the wrapped store and drops the cache, since fixes
+// rewrite issues.
+func (s *Store) Doctor(ctx context.Context, fix bool) ([]string, error) {
+	defer s.invalidate("")
+	return s.inner.Doctor(ctx, fix)
+}
@@ -0,0 +1,148 @@
+package cached
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/issuestorage/filesystem"
+)
+
+func TestCachedContract(t *testing.T) {
+	factory := func() issuestorage.IssueStore {
+		return New(filesystem.New(t.TempDir(), "bd-"))
+	}
+	issuestorage.RunContractTests(t, factory)
+}
+
+func TestCachedProperties(t *testing.T) {
+	factory := func() issuestorage.IssueStore {
+		return New(filesystem.New(t.TempDir(), "bd-"))
+	}
+	issuestorage.RunPropertyTests(t, factory)
+}
+
+// ageFiles backdates every file and directory under dir past the racy
+// window so their versions are cacheable.
+func ageFiles(t *testing.T, dir string) {
+	t.Helper()
+	old := time.Now().Add(-time.Hour)
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil {
+			os.Chtimes(path, old, old)
+		}
+		return nil
+	})
+}
+
+func setup(t *testing.T) (*Store, string, string) {
+	t.Helper()
+	ctx := context.Background()
+	dir := t.TempDir()
+	inner := filesystem.New(dir, "bd-")
+	if err := inner.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+	id, err := inner.Create(ctx, &issuestorage.Issue{Title: "Original", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ageFiles(t, dir)
+	return New(inner), dir, id
+}
+
+func TestGetServesFromCache(t *testing.T) {
+	s, _, id := setup(t)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		got, err := s.Get(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got.Title = "mutated by caller"
+	}
+	got, _ := s.Get(ctx, id)
+	if got.Title != "Original" {
+		t.Errorf("Title = %q, cached copy was shared with a caller", got.Title)
+	}
+	if st := s.Stats(); st.Hits != 3 || st.Misses != 1 {
+		t.Errorf("stats = %+v, want 3 hits and 1 miss", st)
+	}
+}
+
+func TestExternalEditInvalidates(t *testing.T) {
+	s, dir, id := setup(t)
+	ctx := context.Background()
+
+	if _, err := s.Get(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.List(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Rewrite the file the way a text editor or git checkout would.
+	path := filepath.Join(dir, filesystem.DataDirName, filesystem.DirOpen, id+".json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := strings.Replace(string(data), "Original", "Edited externally", 1)
+	if err := os.WriteFile(path+".tmp", []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Get(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "Edited externally" {
+		t.Errorf("Get Title = %q, stale cache", got.Title)
+	}
+	issues, err := s.List(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].Title != "Edited externally" {
+		t.Errorf("List returned stale results: %+v", issues)
+	}
+}
+
+func TestWritesInvalidateLists(t *testing.T) {
+	s, dir, id := setup(t)
+	ctx := context.Background()
+
+	if _, err := s.List(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Modify(ctx, id, func(i *issuestorage.Issue) error {
+		i.Status = issuestorage.StatusClosed
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	ageFiles(t, dir)
+
+	open, err := s.List(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(open) != 0 {
+		t.Errorf("List after close = %d issues, want 0", len(open))
+	}
+	closed, err := s.List(ctx, &issuestorage.ListFilter{Statuses: []issuestorage.Status{issuestorage.StatusClosed}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(closed) != 1 {
+		t.Errorf("closed List = %d issues, want 1", len(closed))
+	}
+}
@@ -0,0 +1,46 @@
+package issuestorage
+
+import "fmt"
+
+// Inconsistencies describes each way the issue's fields contradict one
+// another: a closed_at or deleted_at that disagrees with the status, an
+// await_type on an issue that is not a gate, or a parent without the
+// matching parent-child dependency. It returns nil for a consistent issue.
+func (issue *Issue) Inconsistencies() []string {
+	var problems []string
+	switch {
+	case issue.Status == StatusClosed && issue.ClosedAt == nil:
+		problems = append(problems, "status is closed but closed_at is unset")
+	case issue.Status != StatusClosed && issue.ClosedAt != nil:
+		problems = append(problems, fmt.Sprintf("closed_at is set but status is %s, not closed", issue.Status))
+	}
+	switch {
+	case issue.Status == StatusTombstone && issue.DeletedAt == nil:
+		problems = append(problems, "status is tombstone but deleted_at is unset")
+	case issue.Status != StatusTombstone && issue.DeletedAt != nil:
+		problems = append(problems, fmt.Sprintf("deleted_at is set but status is %s, not tombstone", issue.Status))
+	}
+	if issue.AwaitType != "" && issue.Type != TypeGate {
+		problems = append(problems, fmt.Sprintf("await_type %s is set but type is %s; only gates await", issue.AwaitType, issue.Type))
+	}
+
+	hasParentDep := false
+	for _, dep := range issue.Dependencies {
+		if dep.Type != DepTypeParentChild {
+			continue
+		}
+		if dep.ID == issue.Parent {
+			hasParentDep = true
+			continue
+		}
+		if issue.Parent == "" {
+			problems = append(problems, fmt.Sprintf("parent-child dependency on %s but parent is unset", dep.ID))
+		} else {
+			problems = append(problems, fmt.Sprintf("parent-child dependency on %s but parent is %s", dep.ID, issue.Parent))
+		}
+	}
+	if issue.Parent != "" && !hasParentDep {
+		problems = append(problems, fmt.Sprintf("parent is %s but there is no parent-child dependency on it", issue.Parent))
+	}
+	return problems
+}
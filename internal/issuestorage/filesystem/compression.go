@@ -0,0 +1,169 @@
+package filesystem
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression selects how issue files are encoded on disk. Files keep their
+// .json name either way; compressed files are recognised by their magic
+// bytes, so a store reads both formats regardless of its setting.
+type Compression string
+
+const (
+	CompressionNone Compression = "none"
+	CompressionGzip Compression = "gzip"
+	CompressionZstd Compression = "zstd"
+)
+
+// CompressionConfigKey is the config key selecting issue file compression.
+const CompressionConfigKey = "storage.compression"
+
+// gzipMagic is the two-byte header that starts every gzip stream, and
+// zstdMagic the four-byte one that starts every zstd frame.
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// zstdEncoder and zstdDecoder are shared: both are safe for concurrent
+// EncodeAll and DecodeAll calls, and costly to set up.
+var (
+	zstdEncoder = sync.OnceValue(func() *zstd.Encoder {
+		enc, _ := zstd.NewWriter(nil) // fails only on invalid options
+		return enc
+	})
+	zstdDecoder = sync.OnceValue(func() *zstd.Decoder {
+		dec, _ := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+		return dec
+	})
+)
+
+// ParseCompression parses a storage.compression value. An empty value means
+// no compression.
+func ParseCompression(s string) (Compression, error) {
+	switch s {
+	case "", string(CompressionNone):
+		return CompressionNone, nil
+	case string(CompressionGzip):
+		return CompressionGzip, nil
+	case string(CompressionZstd):
+		return CompressionZstd, nil
+	}
+	return "", fmt.Errorf("invalid compression %q (valid: none, gzip, zstd)", s)
+}
+
+// WithCompression sets the encoding used when writing issue files.
+func WithCompression(c Compression) Option {
+	return func(fs *FilesystemStorage) {
+		fs.compression = c
+	}
+}
+
+// isGzip reports whether data is a gzip stream.
+func isGzip(data []byte) bool {
+	return bytes.HasPrefix(data, gzipMagic)
+}
+
+// fileCompression returns the compression an issue file was written with,
+// by its magic bytes.
+func fileCompression(data []byte) Compression {
+	switch {
+	case isGzip(data):
+		return CompressionGzip
+	case bytes.HasPrefix(data, zstdMagic):
+		return CompressionZstd
+	}
+	return CompressionNone
+}
+
+// encodeIssue renders an issue file in the store's configured format.
+func (fs *FilesystemStorage) encodeIssue(issue *issuestorage.Issue) ([]byte, error) {
+	return EncodeIssueFile(issue, fs.compression)
+}
+
+// EncodeIssueFile renders an issue file's contents with compression c.
+func EncodeIssueFile(issue *issuestorage.Issue, c Compression) ([]byte, error) {
+	data, err := json.MarshalIndent(issue, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	data = append(data, '\n')
+	switch c {
+	case CompressionGzip:
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(data); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case CompressionZstd:
+		return zstdEncoder().EncodeAll(data, nil), nil
+	}
+	return data, nil
+}
+
+// DecodeIssueFile parses an issue file's contents, plain, gzip or zstd,
+// and reports the compression it was written with.
+func DecodeIssueFile(data []byte) (*issuestorage.Issue, Compression, error) {
+	c := fileCompression(data)
+	var issue issuestorage.Issue
+	if err := decodeIssue(data, &issue); err != nil {
+		return nil, c, err
+	}
+	return &issue, c, nil
+}
+
+// decodeIssue parses an issue file in plain, gzip or zstd format.
+func decodeIssue(data []byte, issue *issuestorage.Issue) error {
+	switch fileCompression(data) {
+	case CompressionGzip:
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		if data, err = io.ReadAll(zr); err != nil {
+			return err
+		}
+	case CompressionZstd:
+		var err error
+		if data, err = zstdDecoder().DecodeAll(data, nil); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(data, issue)
+}
+
+// writeIssueFile atomically writes an issue file in the configured format.
+func (fs *FilesystemStorage) writeIssueFile(path string, issue *issuestorage.Issue) error {
+	data, err := fs.encodeIssue(issue)
+	if err != nil {
+		return err
+	}
+	if err := fs.prepareDir(path); err != nil {
+		return err
+	}
+	return atomicWriteFile(path, data)
+}
+
+// compressionMismatch reports whether an issue file's encoding differs
+// from the store's configured compression.
+func (fs *FilesystemStorage) compressionMismatch(data []byte) bool {
+	want := fs.compression
+	if want == "" {
+		want = CompressionNone
+	}
+	return fileCompression(data) != want
+}
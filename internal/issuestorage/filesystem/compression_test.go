@@ -0,0 +1,146 @@
+package filesystem
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestGzipCompressionRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	s := New(dir, "bd-", WithCompression(CompressionGzip))
+	if err := s.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	desc := strings.Repeat("a very long description ", 500)
+	id, err := s.Create(ctx, &issuestorage.Issue{Title: "Big", Description: desc, Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	data, err := os.ReadFile(s.issuePathInDir(id, DirOpen))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isGzip(data) || len(data) >= len(desc) {
+		t.Errorf("expected a compressed file smaller than the description, got %d bytes (gzip=%v)", len(data), isGzip(data))
+	}
+
+	// Modify in place and move between directories keep the format.
+	if err := s.Modify(ctx, id, func(i *issuestorage.Issue) error {
+		i.Title = "Bigger"
+		return nil
+	}); err != nil {
+		t.Fatalf("Modify failed: %v", err)
+	}
+	if err := s.Modify(ctx, id, func(i *issuestorage.Issue) error {
+		i.Status = issuestorage.StatusClosed
+		return nil
+	}); err != nil {
+		t.Fatalf("Modify failed: %v", err)
+	}
+	data, err = os.ReadFile(s.issuePathInDir(id, DirClosed))
+	if err != nil || !isGzip(data) {
+		t.Fatalf("expected compressed closed file: %v", err)
+	}
+
+	got, err := s.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.Title != "Bigger" || got.Description != desc {
+		t.Errorf("round trip lost data: title=%q", got.Title)
+	}
+
+	// A store without compression still reads the gzip file.
+	plain := New(dir, "bd-")
+	issues, err := plain.List(ctx, &issuestorage.ListFilter{Statuses: []issuestorage.Status{issuestorage.StatusClosed}})
+	if err != nil || len(issues) != 1 || issues[0].ID != id {
+		t.Fatalf("plain store should list gzip issue, got %v %v", issues, err)
+	}
+}
+
+func TestZstdCompressionRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	s := New(dir, "bd-", WithCompression(CompressionZstd))
+	if err := s.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	desc := strings.Repeat("a very long description ", 500)
+	id, err := s.Create(ctx, &issuestorage.Issue{Title: "Big", Description: desc, Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	data, err := os.ReadFile(s.issuePathInDir(id, DirOpen))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fileCompression(data) != CompressionZstd || len(data) >= len(desc) {
+		t.Errorf("expected a zstd file smaller than the description, got %d bytes (%s)", len(data), fileCompression(data))
+	}
+
+	// A gzip store reads the zstd file, and doctor --fix re-encodes it.
+	gz := New(dir, "bd-", WithCompression(CompressionGzip))
+	got, err := gz.Get(ctx, id)
+	if err != nil || got.Description != desc {
+		t.Fatalf("gzip store should read the zstd issue: %v", err)
+	}
+	problems, err := gz.Doctor(ctx, true)
+	if err != nil {
+		t.Fatalf("Doctor failed: %v", err)
+	}
+	if len(problems) != 1 || !strings.Contains(problems[0], "is zstd but storage.compression is gzip") {
+		t.Fatalf("expected one compression mismatch, got %v", problems)
+	}
+	if data, err := os.ReadFile(gz.issuePathInDir(id, DirOpen)); err != nil || !isGzip(data) {
+		t.Fatalf("expected doctor --fix to gzip the file: %v", err)
+	}
+}
+
+func TestDoctorCompressionMismatch(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	plain := New(dir, "bd-")
+	if err := plain.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	id, err := plain.Create(ctx, &issuestorage.Issue{Title: "Plain", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	gz := New(dir, "bd-", WithCompression(CompressionGzip))
+	problems, err := gz.Doctor(ctx, true)
+	if err != nil {
+		t.Fatalf("Doctor failed: %v", err)
+	}
+	if len(problems) != 1 || !strings.Contains(problems[0], "compression mismatch") {
+		t.Fatalf("expected one compression mismatch, got %v", problems)
+	}
+	data, err := os.ReadFile(gz.issuePathInDir(id, DirOpen))
+	if err != nil || !isGzip(data) {
+		t.Fatalf("expected doctor --fix to compress the file: %v", err)
+	}
+	if problems, _ := gz.Doctor(ctx, false); len(problems) != 0 {
+		t.Errorf("expected no problems after fix, got %v", problems)
+	}
+}
+
+func TestParseCompression(t *testing.T) {
+	for in, want := range map[string]Compression{"": CompressionNone, "none": CompressionNone, "gzip": CompressionGzip, "zstd": CompressionZstd} {
+		if got, err := ParseCompression(in); err != nil || got != want {
+			t.Errorf("ParseCompression(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"lz4", "ZSTD"} {
+		if _, err := ParseCompression(in); err == nil {
+			t.Errorf("ParseCompression(%q): expected error", in)
+		}
+	}
+}
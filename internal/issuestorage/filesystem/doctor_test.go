@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"beads-lite/internal/issuestorage"
 )
@@ -453,7 +454,9 @@ func TestDoctorCleanStorage(t *testing.T) {
 
 	// Close one issue properly
 	fs.Modify(ctx, idB, func(i *issuestorage.Issue) error {
+		now := time.Now()
 		i.Status = issuestorage.StatusClosed
+		i.ClosedAt = &now
 		return nil
 	})
 
@@ -588,3 +591,103 @@ func TestDoctorAsymmetricParentChild(t *testing.T) {
 		t.Errorf("Expected Parent.Dependents to contain child after fix, got: %v", gotParent.Dependents)
 	}
 }
+
+func TestDoctorLegacyChildCounters(t *testing.T) {
+	dir := t.TempDir()
+	fs := New(dir, "bd-")
+	ctx := context.Background()
+	if err := fs.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	parent, err := fs.Create(ctx, &issuestorage.Issue{Title: "Parent"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	childID, err := fs.GetNextChildID(ctx, parent)
+	if err != nil {
+		t.Fatalf("GetNextChildID failed: %v", err)
+	}
+	if _, err := fs.Create(ctx, &issuestorage.Issue{ID: childID, Title: "Child"}); err != nil {
+		t.Fatalf("Create child failed: %v", err)
+	}
+
+	path := filepath.Join(dir, DataDirName, LegacyChildCountersFile)
+	data, _ := json.Marshal(map[string]int{parent: 3})
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("Failed to write counters: %v", err)
+	}
+
+	problems, err := fs.Doctor(ctx, true)
+	if err != nil {
+		t.Fatalf("Doctor failed: %v", err)
+	}
+	if len(problems) != 2 {
+		t.Fatalf("Expected 2 problems, got %d: %v", len(problems), problems)
+	}
+	if !strings.Contains(problems[0], "legacy child counters") {
+		t.Errorf("Expected 'legacy child counters' in problem: %s", problems[0])
+	}
+	if !strings.Contains(problems[1], parent+" was at 3") || !strings.Contains(problems[1], "number is 1") {
+		t.Errorf("Expected counter detail for %s in problem: %s", parent, problems[1])
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Legacy counters file should have been removed")
+	}
+	// The counter outlives the file: children 2 and 3 were handed out
+	// before, so the next child is numbered above them.
+	next, err := fs.GetNextChildID(ctx, parent)
+	if err != nil {
+		t.Fatalf("GetNextChildID after fix failed: %v", err)
+	}
+	if want := parent + ".4"; next != want {
+		t.Errorf("GetNextChildID after fix = %s, want %s", next, want)
+	}
+
+	problems, err = fs.Doctor(ctx, false)
+	if err != nil {
+		t.Fatalf("Doctor after fix failed: %v", err)
+	}
+	if len(problems) != 0 {
+		t.Errorf("Expected 0 problems after fix, got %d: %v", len(problems), problems)
+	}
+}
+
+func TestDoctorInconsistentFields(t *testing.T) {
+	dir := t.TempDir()
+	fs := New(dir, "bd-")
+	ctx := context.Background()
+
+	if err := fs.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	// Written directly, as a hand edit would: open but with closed_at set,
+	// and an await_type on a task.
+	closed := time.Now()
+	issue := issuestorage.Issue{
+		ID:        "bd-odd",
+		Title:     "Half reopened",
+		Status:    issuestorage.StatusOpen,
+		Priority:  issuestorage.PriorityMedium,
+		Type:      issuestorage.TypeTask,
+		ClosedAt:  &closed,
+		AwaitType: "timer",
+	}
+	data, _ := json.Marshal(issue)
+	os.WriteFile(filepath.Join(dir, DataDirName, "open", "bd-odd.json"), data, 0644)
+
+	problems, err := fs.Doctor(ctx, false)
+	if err != nil {
+		t.Fatalf("Doctor failed: %v", err)
+	}
+	var inconsistent []string
+	for _, p := range problems {
+		if strings.HasPrefix(p, "inconsistent fields: bd-odd: ") {
+			inconsistent = append(inconsistent, p)
+		}
+	}
+	if len(inconsistent) != 2 || !strings.Contains(inconsistent[0], "closed_at") || !strings.Contains(inconsistent[1], "await_type") {
+		t.Errorf("Expected closed_at and await_type problems, got: %v", problems)
+	}
+}
@@ -12,9 +12,13 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"syscall"
+	"time"
 
+	"beads-lite/internal/clock"
 	"beads-lite/internal/idgen"
 	"beads-lite/internal/issuestorage"
 )
@@ -34,20 +38,30 @@ const (
 	DirEphemeral = "ephemeral" // ephemeral issues (not exported)
 )
 
-// relocateIfNeeded checks whether an issue file is in the wrong directory
-// (e.g., a closed issue sitting in open/) and moves it to the correct one.
-// This handles the case where external tools edit issue JSON directly without
-// going through storage.Modify(). It is best-effort: errors are silently
-// ignored so reads are never blocked by a failed relocation.
-func (fs *FilesystemStorage) relocateIfNeeded(issue *issuestorage.Issue, currentDir string) {
+// CountCacheFile holds the issue count recorded by the last Create and the
+// time it was last recounted from the directories, used to size random IDs
+// without a directory walk. It lives in the ephemeral directory so it stays
+// local and out of version control.
+const CountCacheFile = ".count"
+
+// CountRefreshInterval is how long a cached issue count is trusted before
+// Create recounts, picking up issues added or removed by other means
+// (git pulls, hand edits). CreateOpts.UseCachedCount trusts it regardless.
+const CountRefreshInterval = 10 * time.Minute
+
+// relocateIfNeeded checks whether an issue file at oldPath is in the wrong
+// directory (e.g., a closed issue sitting in open/) and moves it to the
+// correct one. This handles the case where external tools edit issue JSON
+// directly without going through storage.Modify(). It is best-effort: errors
+// are silently ignored so reads are never blocked by a failed relocation.
+func (fs *FilesystemStorage) relocateIfNeeded(issue *issuestorage.Issue, currentDir, oldPath string) {
 	correctDir := dirForIssue(issue)
 	if currentDir == correctDir {
 		return
 	}
-	oldPath := fs.issuePathInDir(issue.ID, currentDir)
 	newPath := fs.issuePathInDir(issue.ID, correctDir)
 	// Atomic: write to new location, then remove old.
-	if err := atomicWriteJSON(newPath, issue); err != nil {
+	if err := fs.writeIssueFile(newPath, issue); err != nil {
 		return
 	}
 	os.Remove(oldPath)
@@ -82,6 +96,15 @@ type FilesystemStorage struct {
 	root              string // path to data directory (configDir/issues)
 	maxHierarchyDepth int
 	prefix            string // ID prefix (e.g., "bd-", "bl-")
+	compression       Compression
+	shardWidth        int          // see ShardWidthConfigKey
+	filesRead         atomic.Int64 // issue files read, for metrics
+	clock             clock.Clock  // ages the count cache
+}
+
+// FilesRead returns the number of issue files this storage has read.
+func (fs *FilesystemStorage) FilesRead() int64 {
+	return fs.filesRead.Load()
 }
 
 // Option configures a FilesystemStorage instance.
@@ -94,6 +117,14 @@ func WithMaxHierarchyDepth(n int) Option {
 	}
 }
 
+// WithClock sets the clock that ages the issue count cache. Defaults to
+// the system clock.
+func WithClock(c clock.Clock) Option {
+	return func(fs *FilesystemStorage) {
+		fs.clock = clock.OrReal(c)
+	}
+}
+
 // New creates a new FilesystemStorage for the given config directory.
 // The storage creates its data in configDir/issues/.
 // The prefix is prepended to generated IDs (e.g., "bd-", "bl-").
@@ -102,6 +133,8 @@ func New(configDir, prefix string, opts ...Option) *FilesystemStorage {
 		root:              filepath.Join(configDir, DataDirName),
 		maxHierarchyDepth: idgen.DefaultMaxHierarchyDepth,
 		prefix:            prefix,
+		compression:       CompressionNone,
+		clock:             clock.Real,
 	}
 	for _, opt := range opts {
 		opt(fs)
@@ -127,21 +160,13 @@ func (fs *FilesystemStorage) Init(ctx context.Context) error {
 // crashed between creating the backup and completing the write.
 func (fs *FilesystemStorage) recoverBackups() {
 	for _, dir := range []string{DirOpen, DirClosed, DirDeleted, DirEphemeral} {
-		dirPath := filepath.Join(fs.root, dir)
-		entries, err := os.ReadDir(dirPath)
-		if err != nil {
-			continue
-		}
-		for _, entry := range entries {
-			name := entry.Name()
+		fs.walkDir(dir, func(backupPath, name string) {
 			if !strings.HasSuffix(name, ".json.backup") {
-				continue
+				return
 			}
-			backupPath := filepath.Join(dirPath, name)
-			jsonPath := filepath.Join(dirPath, strings.TrimSuffix(name, ".backup"))
 			// Restore the backup over the (potentially corrupt) json file.
-			os.Rename(backupPath, jsonPath)
-		}
+			os.Rename(backupPath, strings.TrimSuffix(backupPath, ".backup"))
+		})
 	}
 }
 
@@ -184,11 +209,13 @@ func (fs *FilesystemStorage) issuePath(id string, closed bool) string {
 	if closed {
 		dir = DirClosed
 	}
-	return filepath.Join(fs.root, dir, id+".json")
+	return fs.issuePathInDir(id, dir)
 }
 
+// issuePathInDir returns where id's file belongs in dir in the configured
+// layout.
 func (fs *FilesystemStorage) issuePathInDir(id string, dir string) string {
-	return filepath.Join(fs.root, dir, id+".json")
+	return filepath.Join(fs.root, dir, shardName(id, fs.shardWidth), id+".json")
 }
 
 func (fs *FilesystemStorage) lockPath(id string) string {
@@ -227,23 +254,62 @@ func (fs *FilesystemStorage) acquireLock(id string) (*issueLock, error) {
 func (fs *FilesystemStorage) countAllIssues() (int, error) {
 	count := 0
 	for _, dir := range []string{DirOpen, DirClosed, DirDeleted, DirEphemeral} {
-		entries, err := os.ReadDir(filepath.Join(fs.root, dir))
-		if os.IsNotExist(err) {
-			continue
-		}
-		if err != nil {
-			return 0, err
-		}
-		for _, entry := range entries {
-			if !entry.IsDir() && filepath.Ext(entry.Name()) == ".json" && !strings.Contains(entry.Name(), ".tmp.") {
+		err := fs.walkDir(dir, func(_, name string) {
+			if isIssueFile(name) {
 				count++
 			}
+		})
+		if err != nil {
+			return 0, err
 		}
 	}
 	return count, nil
 }
 
+// cachedCount returns the issue count recorded in CountCacheFile and when
+// it was last recounted, if any. A count without a refresh time (written by
+// older versions) reports the zero time, so it is refreshed on next use.
+func (fs *FilesystemStorage) cachedCount() (int, time.Time, bool) {
+	data, err := os.ReadFile(filepath.Join(fs.root, DirEphemeral, CountCacheFile))
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, time.Time{}, false
+	}
+	n, err := strconv.Atoi(fields[0])
+	if err != nil || n < 0 {
+		return 0, time.Time{}, false
+	}
+	var refreshed time.Time
+	if len(fields) > 1 {
+		if secs, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+			refreshed = time.Unix(secs, 0)
+		}
+	}
+	return n, refreshed, true
+}
+
+// writeCountCache records n and its refresh time in CountCacheFile. It is
+// best-effort: a failed write leaves the cache cold or stale, which only
+// affects ID length. Concurrent writers each rename their own temp file,
+// so the last one wins and the file is never torn.
+func (fs *FilesystemStorage) writeCountCache(n int, refreshed time.Time) {
+	path := filepath.Join(fs.root, DirEphemeral, CountCacheFile)
+	atomicWriteFile(path, []byte(fmt.Sprintf("%d %d\n", n, refreshed.Unix())))
+}
+
 func atomicWriteJSON(path string, data interface{}) error {
+	encoded, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomicWriteFile(path, append(encoded, '\n'))
+}
+
+// atomicWriteFile writes data to a temporary file and renames it over path.
+func atomicWriteFile(path string, data []byte) error {
 	// Generate a unique temporary filename
 	randBytes := make([]byte, 8)
 	if _, err := rand.Read(randBytes); err != nil {
@@ -256,9 +322,7 @@ func atomicWriteJSON(path string, data interface{}) error {
 		return err
 	}
 
-	enc := json.NewEncoder(f)
-	enc.SetIndent("", "  ")
-	if err := enc.Encode(data); err != nil {
+	if _, err := f.Write(data); err != nil {
 		f.Close()
 		os.Remove(tmp)
 		return err
@@ -296,6 +360,9 @@ func (fs *FilesystemStorage) Create(ctx context.Context, issue *issuestorage.Iss
 
 		dir := dirForIssue(issue)
 		path := fs.issuePathInDir(issue.ID, dir)
+		if err := fs.prepareDir(path); err != nil {
+			return "", err
+		}
 
 		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
 		if os.IsExist(err) {
@@ -306,7 +373,7 @@ func (fs *FilesystemStorage) Create(ctx context.Context, issue *issuestorage.Iss
 		}
 		f.Close()
 
-		if err := atomicWriteJSON(path, issue); err != nil {
+		if err := fs.writeIssueFile(path, issue); err != nil {
 			os.Remove(path)
 			return "", err
 		}
@@ -314,20 +381,29 @@ func (fs *FilesystemStorage) Create(ctx context.Context, issue *issuestorage.Iss
 		return issue.ID, nil
 	}
 
+	var createOpts issuestorage.CreateOpts
+	if len(opts) > 0 {
+		createOpts = opts[0]
+	}
+
 	// Random ID generation with collision retry.
-	// Count existing issues for adaptive length scaling.
-	count, err := fs.countAllIssues()
-	if err != nil {
-		return "", fmt.Errorf("counting issues for adaptive length: %w", err)
+	// Size IDs from the cached issue count, recounting the directories
+	// only when the cache is cold or older than CountRefreshInterval. A
+	// stale count only shifts collision odds, which the retry loop absorbs.
+	count, refreshed, cached := fs.cachedCount()
+	if !cached || (!createOpts.UseCachedCount && fs.clock.Now().Sub(refreshed) > CountRefreshInterval) {
+		var err error
+		count, err = fs.countAllIssues()
+		if err != nil {
+			return "", fmt.Errorf("counting issues for adaptive length: %w", err)
+		}
+		refreshed = fs.clock.Now()
 	}
 
 	length := idgen.AdaptiveLength(count)
 
 	// Compose the effective prefix, incorporating any PrefixAddition.
-	var prefixAddition string
-	if len(opts) > 0 {
-		prefixAddition = opts[0].PrefixAddition
-	}
+	prefixAddition := createOpts.PrefixAddition
 	effectivePrefix := idgen.BuildPrefix(fs.prefix, prefixAddition)
 
 	dir := dirForIssue(issue)
@@ -336,11 +412,14 @@ func (fs *FilesystemStorage) Create(ctx context.Context, issue *issuestorage.Iss
 	// AdaptiveLength ensures ≤25% collision probability,
 	// so P(MaxIDRetries consecutive collisions) ≈ 0.25^20 ≈ 10^-12.
 	for attempt := 0; attempt < MaxIDRetries; attempt++ {
-		id, err := idgen.RandomID(effectivePrefix, length)
+		id, err := idgen.RandomIDFrom(createOpts.IDSource(), effectivePrefix, length)
 		if err != nil {
 			return "", fmt.Errorf("generating random ID: %w", err)
 		}
 		path := fs.issuePathInDir(id, dir)
+		if err := fs.prepareDir(path); err != nil {
+			return "", err
+		}
 
 		// O_EXCL fails if file exists - collision detection
 		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
@@ -354,11 +433,12 @@ func (fs *FilesystemStorage) Create(ctx context.Context, issue *issuestorage.Iss
 
 		issue.ID = id
 
-		if err := atomicWriteJSON(path, issue); err != nil {
+		if err := fs.writeIssueFile(path, issue); err != nil {
 			os.Remove(path)
 			return "", err
 		}
 
+		fs.writeCountCache(count+1, refreshed)
 		return id, nil
 	}
 	return "", fmt.Errorf("failed to generate unique ID: %d retries exhausted at length %d", MaxIDRetries, length)
@@ -386,13 +466,16 @@ func readFileSharedLock(path string) ([]byte, error) {
 func (fs *FilesystemStorage) Get(ctx context.Context, id string) (*issuestorage.Issue, error) {
 	// Search order: open → ephemeral → closed → deleted
 	var data []byte
-	var foundDir string
+	var foundDir, foundPath string
 	var err error
+search:
 	for _, dir := range []string{DirOpen, DirEphemeral, DirClosed, DirDeleted} {
-		data, err = readFileSharedLock(fs.issuePathInDir(id, dir))
-		if !os.IsNotExist(err) {
-			foundDir = dir
-			break
+		for _, path := range fs.candidatePaths(id, dir) {
+			data, err = readFileSharedLock(path)
+			if !os.IsNotExist(err) {
+				foundDir, foundPath = dir, path
+				break search
+			}
 		}
 	}
 	if os.IsNotExist(err) {
@@ -401,14 +484,15 @@ func (fs *FilesystemStorage) Get(ctx context.Context, id string) (*issuestorage.
 	if err != nil {
 		return nil, err
 	}
+	fs.filesRead.Add(1)
 
 	var issue issuestorage.Issue
-	if err := json.Unmarshal(data, &issue); err != nil {
+	if err := decodeIssue(data, &issue); err != nil {
 		return nil, err
 	}
 
 	// Self-heal: relocate if the file is in the wrong directory.
-	fs.relocateIfNeeded(&issue, foundDir)
+	fs.relocateIfNeeded(&issue, foundDir, foundPath)
 
 	return &issue, nil
 }
@@ -426,11 +510,13 @@ func (fs *FilesystemStorage) Get(ctx context.Context, id string) (*issuestorage.
 func (fs *FilesystemStorage) Modify(ctx context.Context, id string, fn func(*issuestorage.Issue) error) error {
 	// Find the issue file: open → ephemeral → closed → deleted
 	var path string
+search:
 	for _, dir := range []string{DirOpen, DirEphemeral, DirClosed, DirDeleted} {
-		candidate := fs.issuePathInDir(id, dir)
-		if _, err := os.Stat(candidate); err == nil {
-			path = candidate
-			break
+		for _, candidate := range fs.candidatePaths(id, dir) {
+			if _, err := os.Stat(candidate); err == nil {
+				path = candidate
+				break search
+			}
 		}
 	}
 	if path == "" {
@@ -455,7 +541,7 @@ func (fs *FilesystemStorage) Modify(ctx context.Context, id string, fn func(*iss
 	}
 
 	var issue issuestorage.Issue
-	if err := json.Unmarshal(data, &issue); err != nil {
+	if err := decodeIssue(data, &issue); err != nil {
 		return fmt.Errorf("parsing issue file: %w", err)
 	}
 
@@ -471,11 +557,10 @@ func (fs *FilesystemStorage) Modify(ctx context.Context, id string, fn func(*iss
 
 	if oldDir == newDir {
 		// Same directory — in-place write with backup (current behavior).
-		newData, err := json.MarshalIndent(&issue, "", "  ")
+		newData, err := fs.encodeIssue(&issue)
 		if err != nil {
 			return fmt.Errorf("encoding issue: %w", err)
 		}
-		newData = append(newData, '\n')
 
 		backupPath := path + ".backup"
 		if err := os.WriteFile(backupPath, data, 0644); err != nil {
@@ -499,7 +584,7 @@ func (fs *FilesystemStorage) Modify(ctx context.Context, id string, fn func(*iss
 	} else {
 		// Different directory — write to new location, remove old.
 		newPath := fs.issuePathInDir(id, newDir)
-		if err := atomicWriteJSON(newPath, &issue); err != nil {
+		if err := fs.writeIssueFile(newPath, &issue); err != nil {
 			return fmt.Errorf("writing issue to %s: %w", newDir, err)
 		}
 		os.Remove(path)
@@ -517,10 +602,13 @@ func (fs *FilesystemStorage) Delete(ctx context.Context, id string) error {
 	defer lock.release()
 
 	// Search order: open → ephemeral → closed → deleted
+search:
 	for _, dir := range []string{DirOpen, DirEphemeral, DirClosed, DirDeleted} {
-		err = os.Remove(fs.issuePathInDir(id, dir))
-		if !os.IsNotExist(err) {
-			break
+		for _, path := range fs.candidatePaths(id, dir) {
+			err = os.Remove(path)
+			if !os.IsNotExist(err) {
+				break search
+			}
 		}
 	}
 	if os.IsNotExist(err) {
@@ -534,7 +622,7 @@ func (fs *FilesystemStorage) Delete(ctx context.Context, id string) error {
 }
 
 // List returns all issues matching the filter.
-// Results are sorted by CreatedAt (oldest first).
+// Results are sorted by CreatedAt (oldest first), then by ID.
 func (fs *FilesystemStorage) List(ctx context.Context, filter *issuestorage.ListFilter) ([]*issuestorage.Issue, error) {
 	var issues []*issuestorage.Issue
 
@@ -559,13 +647,13 @@ func (fs *FilesystemStorage) List(ctx context.Context, filter *issuestorage.List
 	}
 
 	if scanOpen {
-		openIssues, err := fs.listDir(filepath.Join(fs.root, DirOpen), filter)
+		openIssues, err := fs.listDir(DirOpen, filter)
 		if err != nil {
 			return nil, err
 		}
 		issues = append(issues, openIssues...)
 
-		ephemeralIssues, err := fs.listDir(filepath.Join(fs.root, DirEphemeral), filter)
+		ephemeralIssues, err := fs.listDir(DirEphemeral, filter)
 		if err != nil {
 			return nil, err
 		}
@@ -573,7 +661,7 @@ func (fs *FilesystemStorage) List(ctx context.Context, filter *issuestorage.List
 	}
 
 	if scanClosed {
-		closedIssues, err := fs.listDir(filepath.Join(fs.root, DirClosed), filter)
+		closedIssues, err := fs.listDir(DirClosed, filter)
 		if err != nil {
 			return nil, err
 		}
@@ -581,165 +669,71 @@ func (fs *FilesystemStorage) List(ctx context.Context, filter *issuestorage.List
 	}
 
 	if scanDeleted {
-		deletedIssues, err := fs.listDir(filepath.Join(fs.root, DirDeleted), filter)
+		deletedIssues, err := fs.listDir(DirDeleted, filter)
 		if err != nil {
 			return nil, err
 		}
 		issues = append(issues, deletedIssues...)
 	}
 
-	// Sort by CreatedAt (oldest first)
+	// Sort by CreatedAt (oldest first), then ID so ties are deterministic
 	sort.Slice(issues, func(i, j int) bool {
-		return issues[i].CreatedAt.Before(issues[j].CreatedAt)
+		if !issues[i].CreatedAt.Equal(issues[j].CreatedAt) {
+			return issues[i].CreatedAt.Before(issues[j].CreatedAt)
+		}
+		return issues[i].ID < issues[j].ID
 	})
 
 	return issues, nil
 }
 
-func (fs *FilesystemStorage) listDir(dir string, filter *issuestorage.ListFilter) ([]*issuestorage.Issue, error) {
-	entries, err := os.ReadDir(dir)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil
-		}
-		return nil, err
-	}
+// ListReady implements issuestorage.IssueStore.
+func (fs *FilesystemStorage) ListReady(ctx context.Context, filter *issuestorage.ListFilter, cascade bool) ([]*issuestorage.Issue, error) {
+	return issuestorage.ListReady(ctx, fs, filter, cascade)
+}
 
-	currentDir := filepath.Base(dir)
+// ListBlocked implements issuestorage.IssueStore.
+func (fs *FilesystemStorage) ListBlocked(ctx context.Context, filter *issuestorage.ListFilter, cascade bool) ([]issuestorage.BlockedIssue, error) {
+	return issuestorage.ListBlocked(ctx, fs, filter, cascade)
+}
 
+// listDir returns the issues in the status directory dir that match filter.
+func (fs *FilesystemStorage) listDir(dir string, filter *issuestorage.ListFilter) ([]*issuestorage.Issue, error) {
 	var issues []*issuestorage.Issue
-	for _, entry := range entries {
-		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
-			continue
+	err := fs.walkDir(dir, func(path, name string) {
+		if filepath.Ext(name) != ".json" {
+			return
 		}
 
-		path := filepath.Join(dir, entry.Name())
 		data, err := os.ReadFile(path)
 		if err != nil {
-			continue
+			return
 		}
+		fs.filesRead.Add(1)
 
 		var issue issuestorage.Issue
-		if err := json.Unmarshal(data, &issue); err != nil {
-			continue
+		if err := decodeIssue(data, &issue); err != nil {
+			return
 		}
 
 		// Self-heal: if the issue's status doesn't match this directory,
 		// move it to the correct one and skip it from these results.
-		if dirForIssue(&issue) != currentDir {
-			fs.relocateIfNeeded(&issue, currentDir)
-			continue
+		if dirForIssue(&issue) != dir {
+			fs.relocateIfNeeded(&issue, dir, path)
+			return
 		}
 
-		if fs.matchesFilter(&issue, filter) {
+		if filter.Matches(&issue) {
 			issues = append(issues, &issue)
 		}
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return issues, nil
 }
 
-func (fs *FilesystemStorage) matchesFilter(issue *issuestorage.Issue, filter *issuestorage.ListFilter) bool {
-	if filter == nil {
-		return true
-	}
-
-	if len(filter.Statuses) > 0 && !containsStatus(filter.Statuses, issue.Status) {
-		return false
-	}
-	if filter.Priority != nil && issue.Priority != *filter.Priority {
-		return false
-	}
-	if len(filter.Types) > 0 && !containsType(filter.Types, issue.Type) {
-		return false
-	}
-	if filter.MolType != nil {
-		filterMT := *filter.MolType
-		issueMT := issue.MolType
-		// Treat empty and "work" as equivalent
-		if filterMT == "" || filterMT == issuestorage.MolTypeWork {
-			if issueMT != "" && issueMT != issuestorage.MolTypeWork {
-				return false
-			}
-		} else if issueMT != filterMT {
-			return false
-		}
-	}
-	if filter.CreatedAfter != nil && issue.CreatedAt.Before(*filter.CreatedAfter) {
-		return false
-	}
-	if filter.CreatedBefore != nil && issue.CreatedAt.After(*filter.CreatedBefore) {
-		return false
-	}
-	if len(filter.Assignees) > 0 && !containsString(filter.Assignees, issue.Assignee) {
-		return false
-	}
-	if filter.Parent != nil {
-		if *filter.Parent == "" && issue.Parent != "" {
-			return false
-		} else if *filter.Parent != "" && issue.Parent != *filter.Parent {
-			return false
-		}
-	}
-
-	// Labels (OR): issue must have at least one of the specified labels.
-	if len(filter.Labels) > 0 {
-		found := false
-		for _, issueLabel := range issue.Labels {
-			if containsString(filter.Labels, issueLabel) {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return false
-		}
-	}
-
-	// LabelsAll (AND): issue must have all of the specified labels.
-	for _, label := range filter.LabelsAll {
-		found := false
-		for _, issueLabel := range issue.Labels {
-			if issueLabel == label {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return false
-		}
-	}
-
-	return true
-}
-
-func containsStatus(ss []issuestorage.Status, s issuestorage.Status) bool {
-	for _, v := range ss {
-		if v == s {
-			return true
-		}
-	}
-	return false
-}
-
-func containsType(ts []issuestorage.IssueType, t issuestorage.IssueType) bool {
-	for _, v := range ts {
-		if v == t {
-			return true
-		}
-	}
-	return false
-}
-
-func containsString(ss []string, s string) bool {
-	for _, v := range ss {
-		if v == s {
-			return true
-		}
-	}
-	return false
-}
-
 // Doctor checks for and optionally fixes inconsistencies.
 func (fs *FilesystemStorage) Doctor(ctx context.Context, fix bool) ([]string, error) {
 	var problems []string
@@ -748,83 +742,97 @@ func (fs *FilesystemStorage) Doctor(ctx context.Context, fix bool) ([]string, er
 	type locatedIssue struct {
 		issue *issuestorage.Issue
 		dir   string
+		path  string
 	}
 	issuesByID := make(map[string]*locatedIssue)
 	allIssues := make(map[string]*issuestorage.Issue)
+	recompress := make(map[string]bool) // files not in the configured format
 
 	// Scan all directories
 	for _, dir := range []string{DirOpen, DirEphemeral, DirClosed} {
-		entries, err := os.ReadDir(filepath.Join(fs.root, dir))
-		if err != nil && !os.IsNotExist(err) {
-			return nil, err
-		}
-
-		for _, entry := range entries {
-			name := entry.Name()
+		err := fs.walkDir(dir, func(path, name string) {
 			ext := filepath.Ext(name)
+			rel := fs.relPath(path)
 
 			// Check for orphaned temp files
 			if strings.Contains(name, ".tmp.") {
-				problems = append(problems, fmt.Sprintf("orphaned temp file: %s/%s", dir, name))
+				problems = append(problems, fmt.Sprintf("orphaned temp file: %s", rel))
 				if fix {
-					os.Remove(filepath.Join(fs.root, dir, name))
+					os.Remove(path)
 				}
-				continue
+				return
 			}
 
 			// Check for orphaned lock files (only in open/)
 			if ext == ".lock" && dir == DirOpen {
 				id := name[:len(name)-5]
-				jsonPath := filepath.Join(fs.root, dir, id+".json")
-				if _, err := os.Stat(jsonPath); os.IsNotExist(err) {
-					problems = append(problems, fmt.Sprintf("orphaned lock file: %s/%s", dir, name))
-					if fix {
-						os.Remove(filepath.Join(fs.root, dir, name))
+				for _, jsonPath := range fs.candidatePaths(id, dir) {
+					if _, err := os.Stat(jsonPath); err == nil {
+						return
 					}
 				}
-				continue
+				problems = append(problems, fmt.Sprintf("orphaned lock file: %s", rel))
+				if fix {
+					os.Remove(path)
+				}
+				return
 			}
 
 			if ext != ".json" {
-				continue
+				return
 			}
 
 			id := name[:len(name)-5]
-			path := filepath.Join(fs.root, dir, name)
 			data, err := os.ReadFile(path)
 			if err != nil {
-				problems = append(problems, fmt.Sprintf("cannot read file: %s/%s: %v", dir, name, err))
-				continue
+				problems = append(problems, fmt.Sprintf("cannot read file: %s: %v", rel, err))
+				return
 			}
 
 			var issue issuestorage.Issue
-			if err := json.Unmarshal(data, &issue); err != nil {
-				problems = append(problems, fmt.Sprintf("malformed JSON: %s/%s: %v", dir, name, err))
-				continue
+			if err := decodeIssue(data, &issue); err != nil {
+				problems = append(problems, fmt.Sprintf("malformed JSON: %s: %v", rel, err))
+				return
+			}
+
+			if fs.compressionMismatch(data) {
+				problems = append(problems, fmt.Sprintf("compression mismatch: %s is %s but %s is %s", rel, fileCompression(data), CompressionConfigKey, fs.compression))
+				recompress[id] = true
 			}
 
 			if existing, exists := issuesByID[id]; exists {
-				problems = append(problems, fmt.Sprintf("duplicate issue: %s exists in both %s/ and %s/", id, existing.dir, dir))
+				problems = append(problems, fmt.Sprintf("duplicate issue: %s exists in both %s/ and %s/", id, filepath.Dir(fs.relPath(existing.path)), filepath.Dir(rel)))
 				if fix {
 					// Keep the one in the correct directory based on status/ephemeral
 					correctDir := dirForIssue(&issue)
 					if dir == correctDir {
-						os.Remove(filepath.Join(fs.root, existing.dir, id+".json"))
-						issuesByID[id] = &locatedIssue{issue: &issue, dir: dir}
+						os.Remove(existing.path)
+						issuesByID[id] = &locatedIssue{issue: &issue, dir: dir, path: path}
 						allIssues[id] = &issue
 					} else {
-						os.Remove(filepath.Join(fs.root, dir, id+".json"))
+						os.Remove(path)
 					}
 				}
 			} else {
-				issuesByID[id] = &locatedIssue{issue: &issue, dir: dir}
+				issuesByID[id] = &locatedIssue{issue: &issue, dir: dir, path: path}
 				allIssues[id] = &issue
 			}
+		})
+		if err != nil {
+			return nil, err
 		}
 	}
 
+	// Visit issues in ID order so problems are reported deterministically.
+	ids := make([]string, 0, len(allIssues))
+	for id := range allIssues {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
 	// Check for status-location and ephemeral-location mismatches
-	for id, loc := range issuesByID {
+	for _, id := range ids {
+		loc := issuesByID[id]
 		expectedDir := dirForIssue(loc.issue)
 		if loc.dir != expectedDir {
 			if loc.issue.Ephemeral && loc.dir != DirEphemeral {
@@ -833,20 +841,43 @@ func (fs *FilesystemStorage) Doctor(ctx context.Context, fix bool) ([]string, er
 				problems = append(problems, fmt.Sprintf("status mismatch: %s has status=%s but is in %s/", id, loc.issue.Status, loc.dir))
 			}
 			if fix {
-				oldPath := filepath.Join(fs.root, loc.dir, id+".json")
-				newPath := filepath.Join(fs.root, expectedDir, id+".json")
-				if err := atomicWriteJSON(newPath, loc.issue); err == nil {
-					os.Remove(oldPath)
-					loc.dir = expectedDir
+				newPath := fs.issuePathInDir(id, expectedDir)
+				if err := fs.writeIssueFile(newPath, loc.issue); err == nil {
+					os.Remove(loc.path)
+					loc.dir, loc.path = expectedDir, newPath
 				}
 			}
 		}
 	}
 
+	// Check for files outside the configured shard layout
+	for _, id := range ids {
+		loc := issuesByID[id]
+		want := fs.issuePathInDir(id, loc.dir)
+		if loc.path == want {
+			continue
+		}
+		problems = append(problems, fmt.Sprintf("shard layout mismatch: %s should be at %s (%s is %d)", fs.relPath(loc.path), fs.relPath(want), ShardWidthConfigKey, fs.shardWidth))
+		if fix {
+			if err := fs.prepareDir(want); err == nil && os.Rename(loc.path, want) == nil {
+				loc.path = want
+			}
+		}
+	}
+	if fix {
+		for _, dir := range []string{DirOpen, DirEphemeral, DirClosed} {
+			fs.removeEmptyShards(dir)
+		}
+	}
+
 	// Check for broken references and asymmetric relationships
 	issuesNeedingUpdate := make(map[string]bool)
+	for id := range recompress {
+		issuesNeedingUpdate[id] = true
+	}
 
-	for id, issue := range allIssues {
+	for _, id := range ids {
+		issue := allIssues[id]
 		// Check parent reference
 		if issue.Parent != "" {
 			if _, exists := allIssues[issue.Parent]; !exists {
@@ -912,13 +943,32 @@ func (fs *FilesystemStorage) Doctor(ctx context.Context, fix bool) ([]string, er
 		}
 	}
 
+	legacy, err := fs.checkLegacyChildCounters(fix)
+	if err != nil {
+		return nil, err
+	}
+	problems = append(problems, legacy...)
+
+	// Check for fields that contradict each other. The issue service
+	// refuses writes that would introduce these, so they come from hand
+	// edits or merges; they are reported but not fixed, as which field is
+	// wrong takes a human to judge.
+	for _, id := range ids {
+		for _, p := range allIssues[id].Inconsistencies() {
+			problems = append(problems, fmt.Sprintf("inconsistent fields: %s: %s", id, p))
+		}
+	}
+
 	// Write back updated issues
 	if fix {
-		for id := range issuesNeedingUpdate {
+		for _, id := range ids {
+			if !issuesNeedingUpdate[id] {
+				continue
+			}
 			issue := allIssues[id]
 			dir := dirForIssue(issue)
 			path := fs.issuePathInDir(id, dir)
-			atomicWriteJSON(path, issue)
+			fs.writeIssueFile(path, issue)
 		}
 	}
 
@@ -936,6 +986,89 @@ func removeDep(deps []issuestorage.Dependency, id string) []issuestorage.Depende
 	return result
 }
 
+// LegacyChildCountersFile is the global child counter file written by
+// older versions. Child numbers are now derived per parent by scanning for
+// existing children, so concurrent creates under different parents don't
+// contend on one file; Doctor reports and removes any leftover copy.
+const LegacyChildCountersFile = "child_counters.json"
+
+// ChildFloorsDir holds one file per parent, named by its ID, recording a
+// child number that new children must be numbered above. Doctor writes it
+// from a legacy counter that is ahead of the parent's remaining children,
+// so the numbers of children removed outright are not handed out again.
+const ChildFloorsDir = "child_floors"
+
+// checkLegacyChildCounters reports a leftover LegacyChildCountersFile and,
+// with fix, removes it. Parents whose recorded counter is above their
+// highest remaining child are listed; fixing keeps their counters as child
+// floors, so the numbers of children removed outright are not reused.
+func (fs *FilesystemStorage) checkLegacyChildCounters(fix bool) ([]string, error) {
+	path := filepath.Join(fs.root, LegacyChildCountersFile)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	problems := []string{fmt.Sprintf("legacy child counters: %s is no longer used", LegacyChildCountersFile)}
+	var counters map[string]int
+	if err := json.Unmarshal(data, &counters); err != nil {
+		problems[0] += fmt.Sprintf(" (unreadable: %v)", err)
+	}
+	parents := make([]string, 0, len(counters))
+	for parent := range counters {
+		parents = append(parents, parent)
+	}
+	sort.Strings(parents)
+	for _, parent := range parents {
+		maxChild, err := fs.scanMaxChildNumber(parent)
+		if err != nil {
+			return nil, err
+		}
+		if counters[parent] <= maxChild || counters[parent] <= fs.childFloor(parent) {
+			continue
+		}
+		problems = append(problems, fmt.Sprintf("legacy child counter: %s was at %d but its highest remaining child number is %d; numbers above it may be reused",
+			parent, counters[parent], maxChild))
+		if fix {
+			if err := fs.writeChildFloor(parent, counters[parent]); err != nil {
+				return nil, fmt.Errorf("keeping child counter of %s: %w", parent, err)
+			}
+		}
+	}
+	if fix {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	return problems, nil
+}
+
+// childFloor returns the child number recorded for parentID in
+// ChildFloorsDir, or 0 if there is none.
+func (fs *FilesystemStorage) childFloor(parentID string) int {
+	data, err := os.ReadFile(filepath.Join(fs.root, ChildFloorsDir, parentID))
+	if err != nil {
+		return 0
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// writeChildFloor records n as the child floor of parentID.
+func (fs *FilesystemStorage) writeChildFloor(parentID string, n int) error {
+	dir := filepath.Join(fs.root, ChildFloorsDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return atomicWriteFile(filepath.Join(dir, parentID), []byte(strconv.Itoa(n)+"\n"))
+}
+
 // scanMaxChildNumber scans all issue directories for direct children of
 // parentID and returns the highest child number found. Returns 0 if no
 // children exist.
@@ -948,24 +1081,18 @@ func (fs *FilesystemStorage) scanMaxChildNumber(parentID string) (int, error) {
 	maxChild := 0
 
 	for _, dir := range dirs {
-		dirPath := filepath.Join(fs.root, dir)
-		entries, err := os.ReadDir(dirPath)
-		if err != nil {
-			if os.IsNotExist(err) {
-				continue
-			}
-			return 0, fmt.Errorf("reading %s: %w", dir, err)
-		}
-		for _, entry := range entries {
-			name := entry.Name()
+		err := fs.walkDir(dir, func(_, name string) {
 			if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ".json") {
-				continue
+				return
 			}
 			id := strings.TrimSuffix(name, ".json")
 			parent, childNum, ok := idgen.ParseHierarchicalID(id)
 			if ok && parent == parentID && childNum > maxChild {
 				maxChild = childNum
 			}
+		})
+		if err != nil {
+			return 0, fmt.Errorf("reading %s: %w", dir, err)
 		}
 	}
 
@@ -973,7 +1100,8 @@ func (fs *FilesystemStorage) scanMaxChildNumber(parentID string) (int, error) {
 }
 
 // GetNextChildID validates the parent exists, checks hierarchy depth limits,
-// scans the filesystem for existing children, and returns the next child ID.
+// scans the filesystem for existing children, and returns the next child ID,
+// numbered above both those children and the parent's child floor.
 // The returned ID is not reserved — the caller should create the issue with
 // O_EXCL to handle concurrent races, retrying GetNextChildID on collision.
 func (fs *FilesystemStorage) GetNextChildID(ctx context.Context, parentID string) (string, error) {
@@ -995,6 +1123,7 @@ func (fs *FilesystemStorage) GetNextChildID(ctx context.Context, parentID string
 	if err != nil {
 		return "", fmt.Errorf("scanning children of %s: %w", parentID, err)
 	}
+	maxChild = max(maxChild, fs.childFloor(parentID))
 
 	return idgen.ChildID(parentID, maxChild+1), nil
 }
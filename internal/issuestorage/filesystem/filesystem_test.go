@@ -13,6 +13,7 @@ import (
 	"testing"
 	"time"
 
+	"beads-lite/internal/clock"
 	"beads-lite/internal/idgen"
 	"beads-lite/internal/issuestorage"
 )
@@ -74,6 +75,110 @@ func TestCreate_IDCollisionRetry(t *testing.T) {
 	}
 }
 
+// TestCreate_CountCacheAgesByClock verifies that the count cache expires
+// by the store's clock.
+func TestCreate_CountCacheAgesByClock(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC))
+	s := New(t.TempDir(), "bd-", WithClock(fake))
+	ctx := context.Background()
+	if err := s.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	if _, err := s.Create(ctx, &issuestorage.Issue{Title: "first"}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, refreshed, _ := s.cachedCount(); !refreshed.Equal(fake.Now()) {
+		t.Errorf("cache refreshed at %v, want the fake time %v", refreshed, fake.Now())
+	}
+
+	const cached = 1000000
+	s.writeCountCache(cached, fake.Now())
+	fake.Advance(CountRefreshInterval / 2)
+	id, err := s.Create(ctx, &issuestorage.Issue{Title: "second"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if want := idgen.AdaptiveLength(cached); len(id)-len("bd-") != want {
+		t.Errorf("ID %q: want suffix length %d while the cache is fresh", id, want)
+	}
+
+	fake.Advance(CountRefreshInterval)
+	if _, err := s.Create(ctx, &issuestorage.Issue{Title: "third"}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if count, _, _ := s.cachedCount(); count != 3 {
+		t.Errorf("cached count = %d, want 3 recounted once the cache aged out", count)
+	}
+}
+
+// TestCreate_UseCachedCount verifies that Create records the issue count
+// and sizes IDs from it instead of counting files, recounting once the
+// cache is older than CountRefreshInterval unless UseCachedCount is set.
+func TestCreate_UseCachedCount(t *testing.T) {
+	s := setupTestStorage(t)
+	ctx := context.Background()
+	cachePath := filepath.Join(s.root, DirEphemeral, CountCacheFile)
+
+	// Cold cache: falls back to counting.
+	if _, err := s.Create(ctx, &issuestorage.Issue{Title: "first"}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	count, refreshed, ok := s.cachedCount()
+	if !ok {
+		t.Fatal("expected count cache to be written")
+	}
+	if count != 1 || time.Since(refreshed) > time.Minute {
+		t.Errorf("cached count = %d refreshed %v, want 1 refreshed now", count, refreshed)
+	}
+
+	// A fresh cache is trusted even when it disagrees with the directory.
+	const cached = 1000000
+	s.writeCountCache(cached, time.Now())
+	id, err := s.Create(ctx, &issuestorage.Issue{Title: "second"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if want := idgen.AdaptiveLength(cached); len(id)-len("bd-") != want {
+		t.Errorf("ID %q: want suffix length %d from cached count", id, want)
+	}
+
+	// UseCachedCount trusts a stale cache too.
+	stale := time.Now().Add(-2 * CountRefreshInterval)
+	s.writeCountCache(cached, stale)
+	id, err = s.Create(ctx, &issuestorage.Issue{Title: "third"}, issuestorage.CreateOpts{UseCachedCount: true})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if want := idgen.AdaptiveLength(cached); len(id)-len("bd-") != want {
+		t.Errorf("ID %q: want suffix length %d from stale cached count", id, want)
+	}
+
+	// Without it a stale cache is refreshed from the directories.
+	s.writeCountCache(cached, stale)
+	id, err = s.Create(ctx, &issuestorage.Issue{Title: "fourth"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if want := idgen.AdaptiveLength(3); len(id)-len("bd-") != want {
+		t.Errorf("ID %q: want suffix length %d from directory count", id, want)
+	}
+	if count, _, _ := s.cachedCount(); count != 4 {
+		t.Errorf("cached count after refresh = %d, want 4", count)
+	}
+
+	// Counts written by older versions carry no refresh time and are
+	// recounted on next use.
+	if err := os.WriteFile(cachePath, []byte(fmt.Sprintf("%d\n", cached)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Create(ctx, &issuestorage.Issue{Title: "fifth"}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if count, _, _ := s.cachedCount(); count != 5 {
+		t.Errorf("cached count after legacy refresh = %d, want 5", count)
+	}
+}
+
 // TestCreate_ConcurrentIDGeneration verifies concurrent creates don't collide.
 func TestCreate_ConcurrentIDGeneration(t *testing.T) {
 	dir := t.TempDir()
@@ -462,6 +567,13 @@ func TestFilesystemContract(t *testing.T) {
 	issuestorage.RunContractTests(t, factory)
 }
 
+func TestFilesystemProperties(t *testing.T) {
+	factory := func() issuestorage.IssueStore {
+		return New(t.TempDir(), "bd-")
+	}
+	issuestorage.RunPropertyTests(t, factory)
+}
+
 // TestClose verifies that Close updates status and moves the file.
 func TestClose(t *testing.T) {
 	s := setupTestStorage(t)
@@ -2059,3 +2171,66 @@ func TestSelfHealClosedIssueInOpenDir(t *testing.T) {
 		t.Error("after List, file should be in closed/")
 	}
 }
+
+func TestListSeverityFilter(t *testing.T) {
+	s := setupTestStorage(t)
+	ctx := context.Background()
+
+	critID, err := s.Create(ctx, &issuestorage.Issue{Title: "Critical", Severity: issuestorage.SeverityCritical})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	minorID, err := s.Create(ctx, &issuestorage.Issue{Title: "Minor", Severity: issuestorage.SeverityMinor})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := s.Create(ctx, &issuestorage.Issue{Title: "Unset"}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	result, err := s.List(ctx, &issuestorage.ListFilter{Severities: []issuestorage.Severity{issuestorage.SeverityCritical}})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(result) != 1 || result[0].ID != critID {
+		t.Errorf("critical filter: expected [%s], got %v", critID, issueIDs(result))
+	}
+
+	result, err = s.List(ctx, &issuestorage.ListFilter{Severities: []issuestorage.Severity{issuestorage.SeverityCritical, issuestorage.SeverityMinor}})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(result) != 2 {
+		t.Errorf("critical|minor filter: expected [%s %s], got %v", critID, minorID, issueIDs(result))
+	}
+
+	result, err = s.List(ctx, &issuestorage.ListFilter{})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(result) != 3 {
+		t.Errorf("no filter: expected 3 issues, got %v", issueIDs(result))
+	}
+}
+
+// TestListTiesOrderedByID verifies that issues with identical CreatedAt are
+// listed in ID order, so output is deterministic.
+func TestListTiesOrderedByID(t *testing.T) {
+	s := setupTestStorage(t)
+	ctx := context.Background()
+
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, id := range []string{"bd-c", "bd-a", "bd-b"} {
+		if _, err := s.Create(ctx, &issuestorage.Issue{ID: id, Title: id, Status: issuestorage.StatusOpen, CreatedAt: created}); err != nil {
+			t.Fatalf("Create %s failed: %v", id, err)
+		}
+	}
+
+	issues, err := s.List(ctx, nil)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if got := strings.Join(issueIDs(issues), ","); got != "bd-a,bd-b,bd-c" {
+		t.Errorf("List order = %s, want bd-a,bd-b,bd-c", got)
+	}
+}
@@ -0,0 +1,154 @@
+package filesystem
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ShardWidthConfigKey is the config key selecting how many characters of
+// an issue's ID name the subdirectory its file is stored in. With width 2,
+// bd-ab12 is stored as open/ab/bd-ab12.json; 0 (the default) keeps every
+// file directly in its status directory.
+const ShardWidthConfigKey = "storage.shard_width"
+
+// MaxShardWidth is the largest supported shard width.
+const MaxShardWidth = 4
+
+// DefaultShardWidth is the width an unsharded store also checks when
+// looking up an issue, so switching sharding off again keeps issues
+// readable until bd doctor --fix flattens them.
+const DefaultShardWidth = 2
+
+// ParseShardWidth parses a storage.shard_width value. An empty value means
+// no sharding.
+func ParseShardWidth(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > MaxShardWidth {
+		return 0, fmt.Errorf("invalid shard width %q (valid: 0-%d)", s, MaxShardWidth)
+	}
+	return n, nil
+}
+
+// WithShardWidth sets the layout used when writing issue files. Files are
+// found in either layout, so a store keeps working while bd doctor --fix
+// moves existing files after the width changes.
+func WithShardWidth(n int) Option {
+	return func(fs *FilesystemStorage) {
+		fs.shardWidth = n
+	}
+}
+
+// shardName returns the subdirectory for id at the given width, taken from
+// the start of the ID's last hyphen-separated segment so that prefixes
+// don't collapse everything into one shard and children share their
+// root's shard. Returns "" when width is 0.
+func shardName(id string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	base, _, _ := strings.Cut(id, ".")
+	if i := strings.LastIndex(base, "-"); i >= 0 && i < len(base)-1 {
+		base = base[i+1:]
+	}
+	base = strings.ToLower(base)
+	if len(base) > width {
+		base = base[:width]
+	}
+	if base == "" {
+		return "_"
+	}
+	return base
+}
+
+// flatPath returns where id's file lives in dir without sharding.
+func (fs *FilesystemStorage) flatPath(id, dir string) string {
+	return filepath.Join(fs.root, dir, id+".json")
+}
+
+// relPath returns path relative to the data directory, for messages.
+func (fs *FilesystemStorage) relPath(path string) string {
+	if rel, err := filepath.Rel(fs.root, path); err == nil {
+		return rel
+	}
+	return path
+}
+
+// candidatePaths returns where id's file may be in dir, the configured
+// layout first. Files not yet moved to the configured layout are found at
+// the flat path, or, for an unsharded store, at the default-width shard.
+func (fs *FilesystemStorage) candidatePaths(id, dir string) []string {
+	path := fs.issuePathInDir(id, dir)
+	if fs.shardWidth > 0 {
+		return []string{path, fs.flatPath(id, dir)}
+	}
+	return []string{path, filepath.Join(fs.root, dir, shardName(id, DefaultShardWidth), id+".json")}
+}
+
+// prepareDir creates the shard directory for path if needed.
+func (fs *FilesystemStorage) prepareDir(path string) error {
+	if fs.shardWidth == 0 {
+		return nil
+	}
+	return os.MkdirAll(filepath.Dir(path), 0755)
+}
+
+// walkDir calls fn with the path and name of every file in the status
+// directory dir and in its shard subdirectories, in name order. A missing
+// directory is not an error.
+func (fs *FilesystemStorage) walkDir(dir string, fn func(path, name string)) error {
+	dirPath := filepath.Join(fs.root, dir)
+	entries, err := os.ReadDir(dirPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			fn(filepath.Join(dirPath, entry.Name()), entry.Name())
+			continue
+		}
+		shardPath := filepath.Join(dirPath, entry.Name())
+		shardEntries, err := os.ReadDir(shardPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		for _, se := range shardEntries {
+			if !se.IsDir() {
+				fn(filepath.Join(shardPath, se.Name()), se.Name())
+			}
+		}
+	}
+	return nil
+}
+
+// isIssueFile reports whether name is an issue file rather than a temp,
+// lock or backup file.
+func isIssueFile(name string) bool {
+	return filepath.Ext(name) == ".json" && !strings.Contains(name, ".tmp.")
+}
+
+// removeEmptyShards removes shard subdirectories of dir left empty after
+// their files moved.
+func (fs *FilesystemStorage) removeEmptyShards(dir string) {
+	dirPath := filepath.Join(fs.root, dir)
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			os.Remove(filepath.Join(dirPath, entry.Name())) // fails unless empty
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package filesystem
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestShardedContract(t *testing.T) {
+	factory := func() issuestorage.IssueStore {
+		dir := t.TempDir()
+		return New(dir, "bd-", WithShardWidth(2))
+	}
+	issuestorage.RunContractTests(t, factory)
+}
+
+func TestShardedProperties(t *testing.T) {
+	factory := func() issuestorage.IssueStore {
+		dir := t.TempDir()
+		return New(dir, "bd-", WithShardWidth(2))
+	}
+	issuestorage.RunPropertyTests(t, factory)
+}
+
+func TestShardName(t *testing.T) {
+	tests := []struct {
+		id    string
+		width int
+		want  string
+	}{
+		{"bd-ab12", 0, ""},
+		{"bd-ab12", 2, "ab"},
+		{"bd-AB12", 1, "a"},
+		{"bd-ab12.3.1", 2, "ab"},
+		{"bd-mol-x7k2", 2, "x7"},
+		{"bd-a", 3, "a"},
+		{"custom", 2, "cu"},
+		{"bd-", 2, "bd"},
+	}
+	for _, tt := range tests {
+		if got := shardName(tt.id, tt.width); got != tt.want {
+			t.Errorf("shardName(%q, %d) = %q, want %q", tt.id, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestParseShardWidth(t *testing.T) {
+	for _, v := range []string{"", "0", "2", "4"} {
+		if _, err := ParseShardWidth(v); err != nil {
+			t.Errorf("ParseShardWidth(%q): %v", v, err)
+		}
+	}
+	for _, v := range []string{"-1", "5", "two"} {
+		if _, err := ParseShardWidth(v); err == nil {
+			t.Errorf("ParseShardWidth(%q): expected error", v)
+		}
+	}
+}
+
+func TestShardedLayout(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	s := New(dir, "bd-", WithShardWidth(2))
+	if err := s.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	id, err := s.Create(ctx, &issuestorage.Issue{Title: "Sharded"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	want := filepath.Join(dir, DataDirName, DirOpen, strings.TrimPrefix(id, "bd-")[:2], id+".json")
+	if _, err := os.Stat(want); err != nil {
+		t.Fatalf("expected issue file at %s: %v", want, err)
+	}
+
+	child, err := s.GetNextChildID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetNextChildID failed: %v", err)
+	}
+	if _, err := s.Create(ctx, &issuestorage.Issue{ID: child, Title: "Child"}); err != nil {
+		t.Fatalf("Create child failed: %v", err)
+	}
+	if _, err := os.Stat(s.issuePathInDir(child, DirOpen)); err != nil || filepath.Dir(s.issuePathInDir(child, DirOpen)) != filepath.Dir(want) {
+		t.Errorf("child should share its parent's shard: %v", err)
+	}
+	if next, err := s.GetNextChildID(ctx, id); err != nil || next != id+".2" {
+		t.Errorf("GetNextChildID = %q, %v; want %s.2", next, err, id)
+	}
+
+	if err := s.Modify(ctx, id, func(i *issuestorage.Issue) error {
+		i.Status = issuestorage.StatusClosed
+		return nil
+	}); err != nil {
+		t.Fatalf("Modify failed: %v", err)
+	}
+	if _, err := os.Stat(s.issuePathInDir(id, DirClosed)); err != nil {
+		t.Errorf("closed issue should move to its closed shard: %v", err)
+	}
+	if n, err := s.countAllIssues(); err != nil || n != 2 {
+		t.Errorf("countAllIssues = %d, %v; want 2", n, err)
+	}
+}
+
+// TestShardMigration verifies that a store finds files in the other layout
+// and that Doctor moves them to the configured one, in both directions.
+func TestShardMigration(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	flat := New(dir, "bd-")
+	if err := flat.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	var ids []string
+	for _, title := range []string{"one", "two", "three"} {
+		id, err := flat.Create(ctx, &issuestorage.Issue{Title: title})
+		if err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	sharded := New(dir, "bd-", WithShardWidth(2))
+	for _, id := range ids {
+		if _, err := sharded.Get(ctx, id); err != nil {
+			t.Errorf("sharded store should find flat file %s: %v", id, err)
+		}
+	}
+	if err := sharded.Modify(ctx, ids[0], func(i *issuestorage.Issue) error {
+		i.Title = "one (edited)"
+		return nil
+	}); err != nil {
+		t.Fatalf("Modify of flat file failed: %v", err)
+	}
+
+	problems, err := sharded.Doctor(ctx, true)
+	if err != nil {
+		t.Fatalf("Doctor failed: %v", err)
+	}
+	if len(problems) != len(ids) {
+		t.Fatalf("expected %d problems, got %d: %v", len(ids), len(problems), problems)
+	}
+	for _, p := range problems {
+		if !strings.Contains(p, "shard layout mismatch") {
+			t.Errorf("unexpected problem: %s", p)
+		}
+	}
+	for _, id := range ids {
+		if _, err := os.Stat(sharded.issuePathInDir(id, DirOpen)); err != nil {
+			t.Errorf("%s not moved into its shard: %v", id, err)
+		}
+	}
+	if problems, _ := sharded.Doctor(ctx, false); len(problems) != 0 {
+		t.Errorf("expected no problems after migration, got %v", problems)
+	}
+
+	// Switching back: the flat store still reads default-width shards and
+	// Doctor flattens them, removing the emptied shard directories.
+	if got, err := flat.Get(ctx, ids[0]); err != nil || got.Title != "one (edited)" {
+		t.Fatalf("flat store Get of sharded file: %v %v", got, err)
+	}
+	if problems, err := flat.Doctor(ctx, true); err != nil || len(problems) != len(ids) {
+		t.Fatalf("flat Doctor: %v %v", problems, err)
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, DataDirName, DirOpen))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			t.Errorf("shard directory %s should have been removed", e.Name())
+		}
+	}
+	issues, err := flat.List(ctx, nil)
+	if err != nil || len(issues) != len(ids) {
+		t.Errorf("List after flattening: %d issues, %v", len(issues), err)
+	}
+}
+
+func TestShardedRevision(t *testing.T) {
+	s := New(t.TempDir(), "bd-", WithShardWidth(2))
+	ctx := context.Background()
+	if err := s.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	id, err := s.Create(ctx, &issuestorage.Issue{Title: "first"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	past := time.Now().Add(-time.Hour)
+	backdate := func() {
+		filepath.Walk(s.root, func(path string, _ os.FileInfo, _ error) error {
+			return os.Chtimes(path, past, past)
+		})
+	}
+	backdate()
+	before, err := s.Revision(ctx)
+	if err != nil || before == "" {
+		t.Fatalf("Revision = %q, %v", before, err)
+	}
+
+	// A write inside the shard changes only the shard directory's mtime.
+	shard := filepath.Dir(s.issuePathInDir(id, DirOpen))
+	if err := os.WriteFile(filepath.Join(shard, "bd-other.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	backdate()
+	later := past.Add(time.Minute)
+	os.Chtimes(shard, later, later)
+	after, err := s.Revision(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after == before {
+		t.Errorf("Revision unchanged after a write inside a shard: %q", after)
+	}
+}
@@ -0,0 +1,148 @@
+package filesystem
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// RacyWindow is how recently a file or directory may have changed before
+// its modification time is no longer trusted to detect further changes.
+// Filesystems update mtimes from a coarse clock, so two writes within one
+// tick can leave the same mtime; like git's racy-clean check, anything
+// that new is reported as unversioned rather than risk a stale cache.
+const RacyWindow = 2 * time.Second
+
+// statToken formats a file's identity for Version and Revision, or returns
+// "" if its mtime falls within RacyWindow of now.
+func statToken(info os.FileInfo, now time.Time) string {
+	if now.Sub(info.ModTime()) < RacyWindow {
+		return ""
+	}
+	return fmt.Sprintf("%d:%d", info.ModTime().UnixNano(), info.Size())
+}
+
+// Version returns a token that changes whenever the issue's file is
+// written or moved, built from its directory, mtime and size. It returns
+// "" when the file changed too recently to be versioned reliably.
+// Returns ErrNotFound if the issue doesn't exist.
+func (fs *FilesystemStorage) Version(ctx context.Context, id string) (string, error) {
+	now := time.Now()
+	for _, dir := range []string{DirOpen, DirEphemeral, DirClosed, DirDeleted} {
+		for _, path := range fs.candidatePaths(id, dir) {
+			info, err := os.Stat(path)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return "", err
+			}
+			token := statToken(info, now)
+			if token == "" {
+				return "", nil
+			}
+			return fs.relPath(filepath.Dir(path)) + ":" + token, nil
+		}
+	}
+	return "", issuestorage.ErrNotFound
+}
+
+// Revision returns a token that changes whenever an issue is created,
+// modified, moved or deleted through this package or by tools that
+// replace files, built from the issue directories' mtimes, including
+// their shard subdirectories. Modify's backup file touches the directory
+// even for in-place writes. It returns "" when a directory changed too
+// recently to be versioned reliably.
+func (fs *FilesystemStorage) Revision(ctx context.Context) (string, error) {
+	now := time.Now()
+	var parts []string
+	for _, dir := range []string{DirOpen, DirEphemeral, DirClosed, DirDeleted} {
+		dirPath := filepath.Join(fs.root, dir)
+		info, err := os.Stat(dirPath)
+		if os.IsNotExist(err) {
+			parts = append(parts, "-")
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		token := statToken(info, now)
+		if token == "" {
+			return "", nil
+		}
+		parts = append(parts, token)
+
+		if fs.shardWidth == 0 {
+			continue
+		}
+		entries, err := os.ReadDir(dirPath)
+		if err != nil {
+			return "", err
+		}
+		shards := fnv.New64a()
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			info, err := entry.Info()
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return "", err
+			}
+			token := statToken(info, now)
+			if token == "" {
+				return "", nil
+			}
+			fmt.Fprintf(shards, "%s=%s;", entry.Name(), token)
+		}
+		parts = append(parts, fmt.Sprintf("%x", shards.Sum64()))
+	}
+	return strings.Join(parts, "/"), nil
+}
+
+// Versions returns the Version token of every issue, keyed by ID, from one
+// walk of the issue directories, for callers that track many issues at
+// once. An ID found in more than one directory (a move interrupted
+// mid-way) is reported as unversioned.
+func (fs *FilesystemStorage) Versions(ctx context.Context) (map[string]string, error) {
+	now := time.Now()
+	versions := make(map[string]string)
+	for _, dir := range []string{DirOpen, DirEphemeral, DirClosed, DirDeleted} {
+		var walkErr error
+		err := fs.walkDir(dir, func(path, name string) {
+			if !isIssueFile(name) || walkErr != nil {
+				return
+			}
+			info, err := os.Stat(path)
+			if os.IsNotExist(err) {
+				return
+			}
+			if err != nil {
+				walkErr = err
+				return
+			}
+			id := strings.TrimSuffix(name, ".json")
+			token := statToken(info, now)
+			if _, dup := versions[id]; dup || token == "" {
+				versions[id] = ""
+				return
+			}
+			versions[id] = fs.relPath(filepath.Dir(path)) + ":" + token
+		})
+		if err != nil {
+			return nil, err
+		}
+		if walkErr != nil {
+			return nil, walkErr
+		}
+	}
+	return versions, nil
+}
@@ -0,0 +1,109 @@
+package filesystem
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func backdate(t *testing.T, paths ...string) {
+	t.Helper()
+	old := time.Now().Add(-time.Hour)
+	for _, p := range paths {
+		if err := os.Chtimes(p, old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestVersion(t *testing.T) {
+	s := setupTestStorage(t)
+	ctx := context.Background()
+
+	id, err := s.Create(ctx, &issuestorage.Issue{Title: "Versioned", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := s.Version(ctx, id); err != nil || v != "" {
+		t.Errorf("fresh file: Version = %q, %v; want unversioned", v, err)
+	}
+
+	path := filepath.Join(s.root, DirOpen, id+".json")
+	backdate(t, path)
+	v1, err := s.Version(ctx, id)
+	if err != nil || v1 == "" {
+		t.Fatalf("Version = %q, %v", v1, err)
+	}
+
+	if err := s.Modify(ctx, id, func(i *issuestorage.Issue) error {
+		i.Status = issuestorage.StatusClosed
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	backdate(t, filepath.Join(s.root, DirClosed, id+".json"))
+	v2, err := s.Version(ctx, id)
+	if err != nil || v2 == "" || v2 == v1 {
+		t.Errorf("after move: Version = %q (was %q), %v", v2, v1, err)
+	}
+
+	if _, err := s.Version(ctx, "bd-missing"); err != issuestorage.ErrNotFound {
+		t.Errorf("missing issue: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestRevision(t *testing.T) {
+	s := setupTestStorage(t)
+	ctx := context.Background()
+
+	dirs := []string{DirOpen, DirEphemeral, DirClosed, DirDeleted}
+	paths := make([]string, len(dirs))
+	for i, d := range dirs {
+		paths[i] = filepath.Join(s.root, d)
+	}
+	backdate(t, paths...)
+	r1, err := s.Revision(ctx)
+	if err != nil || r1 == "" {
+		t.Fatalf("Revision = %q, %v", r1, err)
+	}
+
+	if _, err := s.Create(ctx, &issuestorage.Issue{Title: "New", Status: issuestorage.StatusOpen}); err != nil {
+		t.Fatal(err)
+	}
+	if r, _ := s.Revision(ctx); r != "" {
+		t.Errorf("Revision right after a create = %q, want unversioned", r)
+	}
+}
+
+func TestVersions(t *testing.T) {
+	s := setupTestStorage(t)
+	ctx := context.Background()
+
+	older, err := s.Create(ctx, &issuestorage.Issue{Title: "Older", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	backdate(t, filepath.Join(s.root, DirOpen, older+".json"))
+	newer, err := s.Create(ctx, &issuestorage.Issue{Title: "Newer", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	versions, err := s.Versions(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("Versions = %v, want 2 issues", versions)
+	}
+	if v, _ := s.Version(ctx, older); versions[older] != v || v == "" {
+		t.Errorf("Versions[%s] = %q, Version = %q", older, versions[older], v)
+	}
+	if v, ok := versions[newer]; !ok || v != "" {
+		t.Errorf("Versions[%s] = %q, %v; want unversioned", newer, v, ok)
+	}
+}
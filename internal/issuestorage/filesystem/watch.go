@@ -0,0 +1,141 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchSettle is how long Watch waits after a file event before reading
+// the issue, so the several events of one write (temp file, rename,
+// directory move) yield one change.
+const watchSettle = 50 * time.Millisecond
+
+// Watch implements issuestorage.Watcher with filesystem notifications on
+// the issue directories and their shards.
+func (fs *FilesystemStorage) Watch(ctx context.Context, filter *issuestorage.ListFilter) (<-chan issuestorage.Change, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range []string{DirOpen, DirClosed, DirDeleted, DirEphemeral} {
+		if err := fs.watchTree(w, filepath.Join(fs.root, dir)); err != nil {
+			w.Close()
+			return nil, err
+		}
+	}
+	initial, err := issuestorage.ListEverything(ctx, fs)
+	if err != nil {
+		w.Close()
+		return nil, err
+	}
+	tracker := issuestorage.NewChangeTracker(filter, initial)
+
+	changes := make(chan issuestorage.Change)
+	go func() {
+		defer close(changes)
+		defer w.Close()
+		pending := make(map[string]bool)
+		settle := time.NewTimer(0)
+		<-settle.C
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-w.Events:
+				if !ok {
+					return
+				}
+				if ev.Has(fsnotify.Create) {
+					if info, err := os.Stat(ev.Name); err == nil && info.IsDir() {
+						// A new shard: watch it, and pick up files written
+						// into it before the watch was added.
+						fs.watchTree(w, ev.Name)
+						fs.walkShard(ev.Name, func(name string) { pending[name] = true })
+						settle.Reset(watchSettle)
+						continue
+					}
+				}
+				if id, ok := issueFileID(filepath.Base(ev.Name)); ok {
+					pending[id] = true
+					settle.Reset(watchSettle)
+				}
+			case <-w.Errors:
+				// Overflows and the like; the next event still resyncs
+				// the issues it names.
+			case <-settle.C:
+				ids := make([]string, 0, len(pending))
+				for id := range pending {
+					ids = append(ids, id)
+				}
+				sort.Strings(ids)
+				clear(pending)
+				for _, id := range ids {
+					issue, err := fs.Get(ctx, id)
+					if errors.Is(err, issuestorage.ErrNotFound) {
+						issue = nil
+					} else if err != nil {
+						continue
+					}
+					c, ok := tracker.Observe(id, issue)
+					if !ok {
+						continue
+					}
+					select {
+					case changes <- c:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+	return changes, nil
+}
+
+// watchTree adds dir and its shard subdirectories to w.
+func (fs *FilesystemStorage) watchTree(w *fsnotify.Watcher, dir string) error {
+	if err := w.Add(dir); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			if err := w.Add(filepath.Join(dir, e.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// walkShard calls fn with the ID of each issue file in dir.
+func (fs *FilesystemStorage) walkShard(dir string, fn func(id string)) {
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if id, ok := issueFileID(e.Name()); ok && !e.IsDir() {
+			fn(id)
+		}
+	}
+}
+
+// issueFileID returns the issue ID an issue file is named for, rejecting
+// lock, backup and temporary files.
+func issueFileID(name string) (string, bool) {
+	id, ok := strings.CutSuffix(name, ".json")
+	if !ok || id == "" || strings.HasPrefix(id, ".") {
+		return "", false
+	}
+	return id, true
+}
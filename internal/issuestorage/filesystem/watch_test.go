@@ -0,0 +1,64 @@
+package filesystem
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// nextChange returns the next change from changes, failing after a second.
+func nextChange(t *testing.T, changes <-chan issuestorage.Change) issuestorage.Change {
+	t.Helper()
+	select {
+	case c := <-changes:
+		return c
+	case <-time.After(time.Second):
+		t.Fatal("no change within a second")
+		return issuestorage.Change{}
+	}
+}
+
+func TestWatch(t *testing.T) {
+	for _, width := range []int{0, 2} {
+		fs := New(t.TempDir(), "bd-", WithShardWidth(width))
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		if err := fs.Init(ctx); err != nil {
+			t.Fatal(err)
+		}
+		existing, _ := fs.Create(ctx, &issuestorage.Issue{Title: "Existing", Status: issuestorage.StatusOpen})
+
+		changes, err := fs.Watch(ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		id, _ := fs.Create(ctx, &issuestorage.Issue{Title: "New", Status: issuestorage.StatusOpen})
+		if c := nextChange(t, changes); c.Kind != issuestorage.ChangeCreated || c.ID != id {
+			t.Errorf("width %d: after create got %s %s", width, c.Kind, c.ID)
+		}
+		fs.Modify(ctx, existing, func(i *issuestorage.Issue) error {
+			i.Title = "Renamed"
+			return nil
+		})
+		if c := nextChange(t, changes); c.Kind != issuestorage.ChangeUpdated || c.Issue.Title != "Renamed" {
+			t.Errorf("width %d: after modify got %s %+v", width, c.Kind, c.Issue)
+		}
+		fs.Modify(ctx, id, func(i *issuestorage.Issue) error {
+			i.Status = issuestorage.StatusClosed
+			return nil
+		})
+		if c := nextChange(t, changes); c.Kind != issuestorage.ChangeClosed || c.ID != id {
+			t.Errorf("width %d: after close got %s %s", width, c.Kind, c.ID)
+		}
+		fs.Delete(ctx, existing)
+		if c := nextChange(t, changes); c.Kind != issuestorage.ChangeDeleted || c.ID != existing {
+			t.Errorf("width %d: after delete got %s %s", width, c.Kind, c.ID)
+		}
+
+		cancel()
+		for range changes {
+		}
+	}
+}
@@ -0,0 +1,331 @@
+package indexed
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// Ranking parameters. Scores follow BM25 over a document made of the
+// issue's fields, each term counted with its field's weight: title, then
+// labels, then body (description and accepted answer), then the other
+// comments. A query word that only prefixes a term ("auth" for
+// "authentication") scores PrefixWeight of an exact match.
+const (
+	TitleBoost    = 3
+	LabelBoost    = 2
+	CommentWeight = 0.5
+	PrefixWeight  = 0.5
+	bm25K1        = 1.2
+	bm25B         = 0.75
+)
+
+// Tokenize splits s into lowercase terms: runs of letters and digits.
+func Tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), isSeparator)
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// Searchable fields, in ranking order.
+const (
+	fieldTitle = iota
+	fieldLabels
+	fieldBody
+	fieldComments
+	numFields
+)
+
+// fieldWeights are the weights of the searchable fields.
+var fieldWeights = [numFields]float64{TitleBoost, LabelBoost, 1, CommentWeight}
+
+// doc is one issue's entry in the index: its term counts in each
+// searchable field, its place in the blocking graph, and the version
+// token the issue had when it was indexed.
+type doc struct {
+	Version  string         `json:"v,omitempty"`
+	Title    map[string]int `json:"t,omitempty"`
+	Labels   map[string]int `json:"l,omitempty"`
+	Body     map[string]int `json:"b,omitempty"`
+	Comments map[string]int `json:"c,omitempty"`
+
+	// Status, Parent and the blocks dependencies are what ListReady and
+	// ListBlocked need of issues outside their listing.
+	Status issuestorage.Status `json:"s,omitempty"`
+	Parent string              `json:"p,omitempty"`
+	Blocks []string            `json:"k,omitempty"`
+
+	lens [numFields]int // term totals, set when the doc is added
+}
+
+// newDoc indexes issue's searchable text and blocking graph.
+func newDoc(issue *issuestorage.Issue, version string) *doc {
+	blocks := issuestorage.DepTypeBlocks
+	d := &doc{
+		Version: version,
+		Title:   countTerms(issue.Title),
+		Labels:  countTerms(strings.Join(issue.Labels, " ")),
+		Status:  issue.Status,
+		Parent:  issue.Parent,
+		Blocks:  issue.DependencyIDs(&blocks),
+	}
+	body := issue.Description
+	var comments []string
+	for _, c := range issue.Comments {
+		if issue.AcceptedAnswer != 0 && c.ID == issue.AcceptedAnswer {
+			body += "\n" + c.Text
+		} else {
+			comments = append(comments, c.Text)
+		}
+	}
+	d.Body = countTerms(body)
+	d.Comments = countTerms(strings.Join(comments, "\n"))
+	return d
+}
+
+// fields returns d's term counts by field.
+func (d *doc) fields() [numFields]map[string]int {
+	return [numFields]map[string]int{d.Title, d.Labels, d.Body, d.Comments}
+}
+
+func countTerms(s string) map[string]int {
+	terms := Tokenize(s)
+	if len(terms) == 0 {
+		return nil
+	}
+	counts := make(map[string]int, len(terms))
+	for _, t := range terms {
+		counts[t]++
+	}
+	return counts
+}
+
+func sum(counts map[string]int) int {
+	n := 0
+	for _, c := range counts {
+		n += c
+	}
+	return n
+}
+
+// same reports whether d and o index the same text and graph.
+func (d *doc) same(o *doc) bool {
+	if d.Status != o.Status || d.Parent != o.Parent || !slices.Equal(d.Blocks, o.Blocks) {
+		return false
+	}
+	df, of := d.fields(), o.fields()
+	for i := range df {
+		if !sameCounts(df[i], of[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameCounts(a, b map[string]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for t, c := range a {
+		if b[t] != c {
+			return false
+		}
+	}
+	return true
+}
+
+// weighted returns the weighted sum of per-field counts; with titleOnly,
+// just the title's.
+func weighted(counts [numFields]int, titleOnly bool) float64 {
+	if titleOnly {
+		return float64(counts[fieldTitle])
+	}
+	total := 0.0
+	for i, n := range counts {
+		total += fieldWeights[i] * float64(n)
+	}
+	return total
+}
+
+// Hit is a matching issue and its relevance score; higher is better.
+type Hit struct {
+	ID    string
+	Score float64
+}
+
+// Index is an in-memory inverted index over issue text.
+type Index struct {
+	docs     map[string]*doc
+	postings map[string]map[string]struct{} // term → IDs of docs containing it
+	terms    []string                       // sorted terms, nil when stale
+	lens     [numFields]int                 // total terms by field, for average lengths
+}
+
+// NewIndex returns an empty index.
+func NewIndex() *Index {
+	return &Index{docs: make(map[string]*doc), postings: make(map[string]map[string]struct{})}
+}
+
+// Len returns the number of indexed issues.
+func (ix *Index) Len() int {
+	return len(ix.docs)
+}
+
+// Add indexes issue, replacing any previous entry for its ID. version is
+// the store's version token for the issue, or "" if unknown.
+func (ix *Index) Add(issue *issuestorage.Issue, version string) {
+	ix.put(issue.ID, newDoc(issue, version))
+}
+
+// put stores d under id, replacing any previous entry.
+func (ix *Index) put(id string, d *doc) {
+	ix.Remove(id)
+	fields := d.fields()
+	for i, counts := range fields {
+		d.lens[i] = sum(counts)
+		ix.lens[i] += d.lens[i]
+	}
+	ix.docs[id] = d
+	for _, counts := range fields {
+		for t := range counts {
+			ids, ok := ix.postings[t]
+			if !ok {
+				ids = make(map[string]struct{})
+				ix.postings[t] = ids
+				ix.terms = nil
+			}
+			ids[id] = struct{}{}
+		}
+	}
+}
+
+// Remove drops id from the index. Removing an unindexed ID does nothing.
+func (ix *Index) Remove(id string) {
+	d, ok := ix.docs[id]
+	if !ok {
+		return
+	}
+	delete(ix.docs, id)
+	for i, counts := range d.fields() {
+		ix.lens[i] -= d.lens[i]
+		for t := range counts {
+			ids := ix.postings[t]
+			delete(ids, id)
+			if len(ids) == 0 {
+				delete(ix.postings, t)
+				ix.terms = nil
+			}
+		}
+	}
+}
+
+// sortedTerms returns every indexed term in order, for prefix lookups.
+func (ix *Index) sortedTerms() []string {
+	if ix.terms == nil {
+		ix.terms = make([]string, 0, len(ix.postings))
+		for t := range ix.postings {
+			ix.terms = append(ix.terms, t)
+		}
+		sort.Strings(ix.terms)
+	}
+	return ix.terms
+}
+
+// Search returns the issues containing every word of query, as a term or
+// a term's prefix, best match first. With titleOnly only titles are
+// searched. A query with no words matches every issue with score 0.
+// Ties are broken by ID.
+func (ix *Index) Search(query string, titleOnly bool) []Hit {
+	words := uniqueWords(query)
+	var hits []Hit
+	if len(words) == 0 {
+		for id := range ix.docs {
+			hits = append(hits, Hit{ID: id})
+		}
+		sortHits(hits)
+		return hits
+	}
+
+	n := float64(len(ix.docs))
+	avgLen := weighted(ix.lens, titleOnly) / n
+
+	var scores map[string]float64
+	for _, word := range words {
+		// Each word scores by its best-matching term in a doc, so a short
+		// prefix matching many terms doesn't outweigh an exact match.
+		best := make(map[string]float64)
+		terms := ix.sortedTerms()
+		for i := sort.SearchStrings(terms, word); i < len(terms) && strings.HasPrefix(terms[i], word); i++ {
+			term := terms[i]
+			weight := 1.0
+			if term != word {
+				weight = PrefixWeight
+			}
+			ids := ix.postings[term]
+			df := float64(len(ids))
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			for id := range ids {
+				d := ix.docs[id]
+				var counts [numFields]int
+				for i, field := range d.fields() {
+					counts[i] = field[term]
+				}
+				tf := weighted(counts, titleOnly)
+				length := weighted(d.lens, titleOnly)
+				if tf == 0 {
+					continue
+				}
+				s := weight * idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*length/avgLen))
+				if s > best[id] {
+					best[id] = s
+				}
+			}
+		}
+
+		if scores == nil {
+			scores = best
+			continue
+		}
+		for id, s := range scores {
+			if b, ok := best[id]; ok {
+				scores[id] = s + b
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+
+	for id, s := range scores {
+		hits = append(hits, Hit{ID: id, Score: s})
+	}
+	sortHits(hits)
+	return hits
+}
+
+// uniqueWords returns query's terms without repeats, in order.
+func uniqueWords(query string) []string {
+	var words []string
+	seen := make(map[string]bool)
+	for _, w := range Tokenize(query) {
+		if !seen[w] {
+			seen[w] = true
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+func sortHits(hits []Hit) {
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ID < hits[j].ID
+	})
+}
@@ -0,0 +1,361 @@
+// Package indexed implements an IssueStore decorator that keeps a
+// persistent full-text index of issue text for bd search, along with each
+// issue's status, parent and blockers for bd ready and bd blocked.
+//
+// The index lives in its own directory as a base file plus an append-only
+// journal. Writes through the decorator append the changed issue to the
+// journal; a search replays it, then catches up with the store by
+// re-indexing only the issues whose version token changed since they were
+// indexed, so issues written by other means (git pulls, hand edits, older
+// bd versions) are found too. The store stays the source of truth: a lost,
+// stale or corrupt index only costs a re-read of the affected issues.
+package indexed
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// DirName is the index directory's name under the .beads directory.
+const DirName = "index"
+
+// Files in the index directory.
+const (
+	baseFile    = "search.json"
+	journalFile = "journal.jsonl"
+	lockFile    = "lock"
+)
+
+// formatVersion is bumped when the on-disk format changes; an index in
+// another format is rebuilt.
+const formatVersion = 3
+
+// Versioner reports a version token for every issue in a store. A token
+// changes whenever its issue is written; "" means the issue changed too
+// recently to be versioned reliably and is re-read on every search.
+type Versioner interface {
+	Versions(ctx context.Context) (map[string]string, error)
+}
+
+// Store wraps an IssueStore, recording writes in the search index.
+type Store struct {
+	inner    issuestorage.IssueStore
+	versions Versioner
+	dir      string
+}
+
+// New wraps inner, keeping its index in dir. versions usually reports on
+// the same underlying store as inner.
+func New(inner issuestorage.IssueStore, versions Versioner, dir string) *Store {
+	return &Store{inner: inner, versions: versions, dir: dir}
+}
+
+// base is the index file's format.
+type base struct {
+	Format int             `json:"format"`
+	Docs   map[string]*doc `json:"docs"`
+}
+
+// journalEntry records one write: the issue's new entry, or its deletion.
+type journalEntry struct {
+	ID      string `json:"id"`
+	Doc     *doc   `json:"doc,omitempty"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+// Search returns the issues matching query, best match first, after
+// bringing the index up to date with the store. See Index.Search.
+func (s *Store) Search(ctx context.Context, query string, titleOnly bool) ([]Hit, error) {
+	ix, err := s.update(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	return ix.Search(query, titleOnly), nil
+}
+
+// Rebuild discards the index and indexes every issue again, returning how
+// many were indexed.
+func (s *Store) Rebuild(ctx context.Context) (int, error) {
+	ix, err := s.update(ctx, true)
+	if err != nil {
+		return 0, err
+	}
+	return ix.Len(), nil
+}
+
+// update loads the index, or starts an empty one if fresh is set, catches
+// it up with the store and saves it if anything changed.
+func (s *Store) update(ctx context.Context, fresh bool) (*Index, error) {
+	ix, journaled := NewIndex(), 0
+	if !fresh {
+		var err error
+		if ix, journaled, err = s.load(); err != nil {
+			return nil, err
+		}
+	}
+	changed, err := s.catchUp(ctx, ix)
+	if err != nil {
+		return nil, err
+	}
+	if fresh || changed || journaled > 0 {
+		if err := s.save(ix); err != nil {
+			return nil, fmt.Errorf("saving search index: %w", err)
+		}
+	}
+	return ix, nil
+}
+
+// catchUp re-indexes every issue whose version differs from the one it
+// was indexed at, or that is unversioned, and drops issues the store no
+// longer has. It reports whether the index changed.
+func (s *Store) catchUp(ctx context.Context, ix *Index) (bool, error) {
+	versions, err := s.versions.Versions(ctx)
+	if err != nil {
+		return false, fmt.Errorf("listing issue versions: %w", err)
+	}
+
+	changed := false
+	for id := range ix.docs {
+		if _, ok := versions[id]; !ok {
+			ix.Remove(id)
+			changed = true
+		}
+	}
+	for id, version := range versions {
+		old, ok := ix.docs[id]
+		if ok && version != "" && old.Version == version {
+			continue
+		}
+		issue, err := s.inner.Get(ctx, id)
+		if errors.Is(err, issuestorage.ErrNotFound) {
+			if ok {
+				ix.Remove(id)
+				changed = true
+			}
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("indexing %s: %w", id, err)
+		}
+		d := newDoc(issue, version)
+		if ok && old.Version == version && old.same(d) {
+			continue
+		}
+		ix.put(id, d)
+		changed = true
+	}
+	return changed, nil
+}
+
+// load reads the base file and replays the journal over it, returning the
+// number of journal entries replayed. A missing index is empty; an index
+// in an unknown format, or unreadable, starts empty and is rebuilt by the
+// catch-up that follows. A torn final journal line is skipped.
+func (s *Store) load() (*Index, int, error) {
+	unlock, err := s.lock(syscall.LOCK_SH)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer unlock()
+
+	ix := NewIndex()
+	data, err := os.ReadFile(filepath.Join(s.dir, baseFile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, 0, err
+	}
+	if err == nil {
+		var b base
+		if json.Unmarshal(data, &b) == nil && b.Format == formatVersion {
+			for id, d := range b.Docs {
+				if d != nil {
+					ix.put(id, d)
+				}
+			}
+		}
+	}
+
+	data, err = os.ReadFile(filepath.Join(s.dir, journalFile))
+	if os.IsNotExist(err) {
+		return ix, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	n := 0
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), len(data)+1)
+	for scanner.Scan() {
+		var e journalEntry
+		if json.Unmarshal(scanner.Bytes(), &e) != nil || e.ID == "" {
+			continue
+		}
+		n++
+		if e.Deleted || e.Doc == nil {
+			ix.Remove(e.ID)
+		} else {
+			ix.put(e.ID, e.Doc)
+		}
+	}
+	return ix, n, scanner.Err()
+}
+
+// save writes ix as the new base file and empties the journal. Entries
+// another process journaled since ix was loaded are dropped with it; the
+// next catch-up re-reads those issues, since their versions changed.
+func (s *Store) save(ix *Index) error {
+	if err := s.prepareDir(); err != nil {
+		return err
+	}
+	unlock, err := s.lock(syscall.LOCK_EX)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	data, err := json.Marshal(base{Format: formatVersion, Docs: ix.docs})
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(s.dir, baseFile+".tmp")
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, filepath.Join(s.dir, baseFile)); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Remove(filepath.Join(s.dir, journalFile)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// record appends a write to the journal. It is best-effort: the write
+// itself already succeeded, and an entry that fails to land is recovered
+// by the next search's catch-up.
+func (s *Store) record(e journalEntry) {
+	if s.prepareDir() != nil {
+		return
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	unlock, err := s.lock(syscall.LOCK_EX)
+	if err != nil {
+		return
+	}
+	defer unlock()
+	f, err := os.OpenFile(filepath.Join(s.dir, journalFile), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	f.Write(append(line, '\n'))
+}
+
+// prepareDir creates the index directory, ignored by git since the index
+// is derived and local.
+func (s *Store) prepareDir() error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return err
+	}
+	ignore := filepath.Join(s.dir, ".gitignore")
+	if _, err := os.Stat(ignore); os.IsNotExist(err) {
+		return os.WriteFile(ignore, []byte("*\n"), 0644)
+	}
+	return nil
+}
+
+// lock takes the index lock in the given flock mode. Readers share it, so
+// they never see a half-written base file paired with a stale journal.
+func (s *Store) lock(how int) (func(), error) {
+	f, err := os.OpenFile(filepath.Join(s.dir, lockFile), os.O_RDWR|os.O_CREATE, 0644)
+	if os.IsNotExist(err) {
+		return func() {}, nil // no index directory yet, so nothing to guard
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), how); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return func() {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
+
+// Create creates the issue in the wrapped store and journals it.
+func (s *Store) Create(ctx context.Context, issue *issuestorage.Issue, opts ...issuestorage.CreateOpts) (string, error) {
+	id, err := s.inner.Create(ctx, issue, opts...)
+	if err != nil {
+		return "", err
+	}
+	s.record(journalEntry{ID: id, Doc: newDoc(issue, "")})
+	return id, nil
+}
+
+// Get delegates to the wrapped store.
+func (s *Store) Get(ctx context.Context, id string) (*issuestorage.Issue, error) {
+	return s.inner.Get(ctx, id)
+}
+
+// Modify modifies the issue in the wrapped store and journals the result.
+func (s *Store) Modify(ctx context.Context, id string, fn func(*issuestorage.Issue) error) error {
+	var modified *issuestorage.Issue
+	err := s.inner.Modify(ctx, id, func(issue *issuestorage.Issue) error {
+		if err := fn(issue); err != nil {
+			return err
+		}
+		modified = issue
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if modified != nil {
+		s.record(journalEntry{ID: id, Doc: newDoc(modified, "")})
+	}
+	return nil
+}
+
+// Delete deletes the issue from the wrapped store and journals it.
+func (s *Store) Delete(ctx context.Context, id string) error {
+	if err := s.inner.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.record(journalEntry{ID: id, Deleted: true})
+	return nil
+}
+
+// List delegates to the wrapped store.
+func (s *Store) List(ctx context.Context, filter *issuestorage.ListFilter) ([]*issuestorage.Issue, error) {
+	return s.inner.List(ctx, filter)
+}
+
+// GetNextChildID delegates to the wrapped store.
+func (s *Store) GetNextChildID(ctx context.Context, parentID string) (string, error) {
+	return s.inner.GetNextChildID(ctx, parentID)
+}
+
+// Init delegates to the wrapped store.
+func (s *Store) Init(ctx context.Context) error {
+	return s.inner.Init(ctx)
+}
+
+// Doctor delegates to the wrapped store. Issues it rewrites are caught up
+// by the next search.
+func (s *Store) Doctor(ctx context.Context, fix bool) ([]string, error) {
+	return s.inner.Doctor(ctx, fix)
+}
@@ -0,0 +1,359 @@
+package indexed
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/issuestorage/filesystem"
+)
+
+func TestIndexedContract(t *testing.T) {
+	factory := func() issuestorage.IssueStore {
+		dir := t.TempDir()
+		fs := filesystem.New(dir, "bd-")
+		return New(fs, fs, filepath.Join(dir, DirName))
+	}
+	issuestorage.RunContractTests(t, factory)
+}
+
+func TestTokenize(t *testing.T) {
+	got := Tokenize("Fix OAuth2 login—crash in auth_flow (bd-a1b2), café")
+	want := []string{"fix", "oauth2", "login", "crash", "in", "auth", "flow", "bd", "a1b2", "café"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize = %q, want %q", got, want)
+	}
+}
+
+func ids(hits []Hit) []string {
+	var out []string
+	for _, h := range hits {
+		out = append(out, h.ID)
+	}
+	return out
+}
+
+func TestIndexSearch(t *testing.T) {
+	ix := NewIndex()
+	ix.Add(&issuestorage.Issue{ID: "bd-1", Title: "Authentication fails", Description: "Login returns 500"}, "")
+	ix.Add(&issuestorage.Issue{ID: "bd-2", Title: "Update docs", Description: "Mention auth tokens and login"}, "")
+	ix.Add(&issuestorage.Issue{ID: "bd-3", Title: "Login page", Description: "Restyle the login form"}, "")
+	answered := &issuestorage.Issue{ID: "bd-4", Title: "Which cache?", Type: issuestorage.TypeQuestion, Comments: []issuestorage.Comment{{ID: 1, Text: "Use redis"}}}
+	answered.AcceptedAnswer = 1
+	ix.Add(answered, "")
+
+	tests := []struct {
+		query     string
+		titleOnly bool
+		want      []string
+	}{
+		{"auth", false, []string{"bd-2", "bd-1"}}, // exact body term beats a title prefix
+		{"authent", false, []string{"bd-1"}},
+		{"login", false, []string{"bd-3", "bd-1", "bd-2"}}, // title match and frequency rank first
+		{"login auth", false, []string{"bd-2", "bd-1"}},    // every word must match
+		{"LOGIN", true, []string{"bd-3"}},
+		{"redis", false, []string{"bd-4"}},
+		{"redis", true, nil},
+		{"nothing", false, nil},
+		{"--", false, []string{"bd-1", "bd-2", "bd-3", "bd-4"}},
+	}
+	for _, tt := range tests {
+		if got := ids(ix.Search(tt.query, tt.titleOnly)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Search(%q, %v) = %v, want %v", tt.query, tt.titleOnly, got, tt.want)
+		}
+	}
+
+	ix.Remove("bd-3")
+	ix.Add(&issuestorage.Issue{ID: "bd-1", Title: "Renamed"}, "")
+	if got := ids(ix.Search("login", false)); !reflect.DeepEqual(got, []string{"bd-2"}) {
+		t.Errorf("after updates: Search = %v, want [bd-2]", got)
+	}
+	if got := len(ix.sortedTerms()); got != len(ix.postings) {
+		t.Errorf("%d sorted terms for %d postings", got, len(ix.postings))
+	}
+}
+
+func TestIndexSearchFieldOrder(t *testing.T) {
+	ix := NewIndex()
+	ix.Add(&issuestorage.Issue{ID: "bd-1", Title: "Flaky test", Comments: []issuestorage.Comment{{ID: 1, Text: "Seen on windows again"}}}, "")
+	ix.Add(&issuestorage.Issue{ID: "bd-2", Title: "Crash on start", Description: "Only on windows"}, "")
+	ix.Add(&issuestorage.Issue{ID: "bd-3", Title: "Installer", Labels: []string{"windows"}}, "")
+	ix.Add(&issuestorage.Issue{ID: "bd-4", Title: "Windows installer"}, "")
+	if got, want := ids(ix.Search("windows", false)), []string{"bd-4", "bd-3", "bd-2", "bd-1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Search = %v, want title, label, description, comment: %v", got, want)
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	tests := []struct {
+		text, query string
+		width       int
+		want        string
+		marked      string
+	}{
+		{"Fix the authentication bug", "auth", 80, "Fix the authentication bug", "Fix the [authentication] bug"},
+		{"Login fails for\n\nOAuth users", "oauth login", 80, "Login fails for OAuth users", "[Login] fails for [OAuth] users"},
+		{
+			"Steps: open the app, go to settings, then press the big sync button and wait for the crash to happen",
+			"sync", 40,
+			"… the big sync button and wait for the …",
+			"… the big [sync] button and wait for the …",
+		},
+		{"Resync everything", "sync", 80, "", ""},
+	}
+	for _, tt := range tests {
+		got, highlights, ok := Snippet(tt.text, tt.query, tt.width)
+		if got != tt.want || ok != (tt.want != "") {
+			t.Errorf("Snippet(%q, %q) = %q, %v; want %q", tt.text, tt.query, got, ok, tt.want)
+			continue
+		}
+		if marked := Highlight(got, highlights, func(s string) string { return "[" + s + "]" }); marked != tt.marked {
+			t.Errorf("Highlight = %q, want %q", marked, tt.marked)
+		}
+	}
+}
+
+// setup returns an indexed store over a fresh filesystem store.
+func setup(t *testing.T) (*Store, *filesystem.FilesystemStorage, string) {
+	t.Helper()
+	dir := t.TempDir()
+	fs := filesystem.New(dir, "bd-")
+	if err := fs.Init(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return New(fs, fs, filepath.Join(dir, DirName)), fs, dir
+}
+
+// ageFiles backdates every file under dir past the racy window so their
+// versions are stable.
+func ageFiles(t *testing.T, dir string) {
+	t.Helper()
+	old := time.Now().Add(-time.Hour)
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil {
+			os.Chtimes(path, old, old)
+		}
+		return nil
+	})
+}
+
+func search(t *testing.T, s *Store, query string) []string {
+	t.Helper()
+	hits, err := s.Search(context.Background(), query, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ids(hits)
+}
+
+func TestStoreTracksWrites(t *testing.T) {
+	s, _, _ := setup(t)
+	ctx := context.Background()
+
+	id, err := s.Create(ctx, &issuestorage.Issue{Title: "Flaky test", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := search(t, s, "flaky"); !reflect.DeepEqual(got, []string{id}) {
+		t.Errorf("after create: %v", got)
+	}
+
+	if err := s.Modify(ctx, id, func(i *issuestorage.Issue) error {
+		i.Title = "Slow build"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got := search(t, s, "flaky"); got != nil {
+		t.Errorf("after rename: flaky = %v", got)
+	}
+	if got := search(t, s, "slow"); !reflect.DeepEqual(got, []string{id}) {
+		t.Errorf("after rename: slow = %v", got)
+	}
+
+	if err := s.Delete(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if got := search(t, s, "slow"); got != nil {
+		t.Errorf("after delete: %v", got)
+	}
+}
+
+func TestStoreCatchesUpWithOutsideWrites(t *testing.T) {
+	s, fs, dir := setup(t)
+	ctx := context.Background()
+
+	kept, err := s.Create(ctx, &issuestorage.Issue{Title: "Kept issue", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gone, err := s.Create(ctx, &issuestorage.Issue{Title: "Doomed issue", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ageFiles(t, dir)
+	if got := search(t, s, "issue"); len(got) != 2 {
+		t.Fatalf("search = %v", got)
+	}
+
+	// Write behind the index's back, as a git pull would.
+	pulled, err := fs.Create(ctx, &issuestorage.Issue{Title: "Pulled issue", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Modify(ctx, kept, func(i *issuestorage.Issue) error {
+		i.Description = "now mentions zebras"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Delete(ctx, gone); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := search(t, s, "pulled"); !reflect.DeepEqual(got, []string{pulled}) {
+		t.Errorf("new issue: %v", got)
+	}
+	if got := search(t, s, "zebra"); !reflect.DeepEqual(got, []string{kept}) {
+		t.Errorf("modified issue: %v", got)
+	}
+	if got := search(t, s, "doomed"); got != nil {
+		t.Errorf("deleted issue: %v", got)
+	}
+}
+
+func TestStorePersistsAndReindexesOnlyChanges(t *testing.T) {
+	s, _, dir := setup(t)
+	ctx := context.Background()
+	for _, title := range []string{"Alpha", "Beta", "Gamma"} {
+		if _, err := s.Create(ctx, &issuestorage.Issue{Title: title, Status: issuestorage.StatusOpen}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ageFiles(t, dir)
+	search(t, s, "alpha")
+	if _, err := os.Stat(filepath.Join(dir, DirName, journalFile)); !os.IsNotExist(err) {
+		t.Errorf("journal not compacted after search: %v", err)
+	}
+
+	// A second store over the same files reads no issues when nothing
+	// changed since the index was saved.
+	counting := filesystem.New(dir, "bd-")
+	s2 := New(counting, counting, filepath.Join(dir, DirName))
+	if got := search(t, s2, "gamma"); len(got) != 1 {
+		t.Errorf("search = %v", got)
+	}
+	if n := counting.FilesRead(); n != 0 {
+		t.Errorf("read %d issue files from an up-to-date index, want 0", n)
+	}
+}
+
+func TestStoreRebuild(t *testing.T) {
+	s, _, dir := setup(t)
+	ctx := context.Background()
+	id, err := s.Create(ctx, &issuestorage.Issue{Title: "Searchable", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	search(t, s, "searchable")
+
+	if err := os.WriteFile(filepath.Join(dir, DirName, baseFile), []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := search(t, s, "searchable"); !reflect.DeepEqual(got, []string{id}) {
+		t.Errorf("corrupt index: search = %v", got)
+	}
+
+	n, err := s.Rebuild(ctx)
+	if err != nil || n != 1 {
+		t.Errorf("Rebuild = %d, %v; want 1", n, err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, DirName, ".gitignore"))
+	if err != nil || string(data) != "*\n" {
+		t.Errorf(".gitignore = %q, %v", data, err)
+	}
+}
+
+func TestStoreListReadyAndBlocked(t *testing.T) {
+	s, fs, dir := setup(t)
+	ctx := context.Background()
+	create := func(issue *issuestorage.Issue) string {
+		t.Helper()
+		issue.Status = issuestorage.StatusOpen
+		id, err := s.Create(ctx, issue)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+	blocker := create(&issuestorage.Issue{Title: "Blocker"})
+	epic := create(&issuestorage.Issue{Title: "Epic", Type: issuestorage.TypeEpic,
+		Dependencies: []issuestorage.Dependency{{ID: blocker, Type: issuestorage.DepTypeBlocks}}})
+	step := create(&issuestorage.Issue{Title: "Step", Parent: epic, Assignee: "alice"})
+	done := create(&issuestorage.Issue{Title: "Done"})
+	create(&issuestorage.Issue{Title: "Unblocked", Assignee: "alice",
+		Dependencies: []issuestorage.Dependency{{ID: done, Type: issuestorage.DepTypeBlocks}}})
+	if err := s.Modify(ctx, done, func(i *issuestorage.Issue) error {
+		i.Status = issuestorage.StatusClosed
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	ageFiles(t, dir)
+
+	// The index answers as the store alone would, with and without
+	// cascading blockers and for a filter that leaves out the ancestors.
+	check := func(when string) {
+		t.Helper()
+		for _, filter := range []*issuestorage.ListFilter{nil, {Assignees: []string{"alice"}}} {
+			for _, cascade := range []bool{true, false} {
+				got, err := s.ListReady(ctx, filter, cascade)
+				if err != nil {
+					t.Fatal(err)
+				}
+				want, err := issuestorage.ListReady(ctx, fs, filter, cascade)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("%s: ListReady(%+v, %v) = %v, want %v", when, filter, cascade, got, want)
+				}
+				gotBlocked, err := s.ListBlocked(ctx, filter, cascade)
+				if err != nil {
+					t.Fatal(err)
+				}
+				wantBlocked, err := issuestorage.ListBlocked(ctx, fs, filter, cascade)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(gotBlocked, wantBlocked) {
+					t.Errorf("%s: ListBlocked(%+v, %v) = %v, want %v", when, filter, cascade, gotBlocked, wantBlocked)
+				}
+			}
+		}
+	}
+	check("initially")
+	blocked, err := s.ListBlocked(ctx, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocked) != 2 || blocked[1].ID != step || blocked[1].Blockers.Inherited[0].BlockerID != blocker {
+		t.Errorf("ListBlocked = %+v, want %s blocked and %s inheriting it", blocked, epic, step)
+	}
+
+	// Closing the blocker behind the index's back unblocks both.
+	if err := fs.Modify(ctx, blocker, func(i *issuestorage.Issue) error {
+		i.Status = issuestorage.StatusClosed
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	check("after an outside close")
+	if blocked, err := s.ListBlocked(ctx, nil, true); err != nil || len(blocked) != 0 {
+		t.Errorf("ListBlocked after close = %+v, %v; want none", blocked, err)
+	}
+}
@@ -0,0 +1,93 @@
+package indexed
+
+import (
+	"context"
+	"fmt"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// ListReady implements issuestorage.IssueStore with a single List of the
+// candidates: which issues are closed, and the ancestors each inherits
+// blockers from, are read from the index rather than from a second List
+// of closed issues and a Get per ancestor.
+func (s *Store) ListReady(ctx context.Context, filter *issuestorage.ListFilter, cascade bool) ([]*issuestorage.Issue, error) {
+	f := issuestorage.ReadyFilter(filter)
+	issues, blockers, err := s.listWithBlockers(ctx, &f, cascade)
+	if err != nil {
+		return nil, err
+	}
+	return issuestorage.ReadyIssues(issues, blockers), nil
+}
+
+// ListBlocked implements issuestorage.IssueStore like ListReady.
+func (s *Store) ListBlocked(ctx context.Context, filter *issuestorage.ListFilter, cascade bool) ([]issuestorage.BlockedIssue, error) {
+	f := issuestorage.BlockedFilter(filter)
+	issues, blockers, err := s.listWithBlockers(ctx, &f, cascade)
+	if err != nil {
+		return nil, err
+	}
+	return issuestorage.BlockedIssues(issues, blockers), nil
+}
+
+// listWithBlockers lists the issues matching filter from the wrapped store
+// and resolves their blockers against the index, brought up to date
+// first. The index is only derived, so if that fails the blockers are
+// resolved from the store instead.
+func (s *Store) listWithBlockers(ctx context.Context, filter *issuestorage.ListFilter, cascade bool) ([]*issuestorage.Issue, map[string]*issuestorage.Blockers, error) {
+	ix, updateErr := s.update(ctx, false)
+	issues, err := s.inner.List(ctx, filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing issues: %w", err)
+	}
+	if updateErr != nil {
+		return s.storeBlockers(ctx, issues, cascade)
+	}
+
+	closed := make(map[string]bool)
+	for id, d := range ix.docs {
+		if d.Status == issuestorage.StatusClosed {
+			closed[id] = true
+		}
+	}
+	blockers, err := issuestorage.ResolveBlockers(ctx, graphGetter{ix}, issues, closed, cascade)
+	if err != nil {
+		return nil, nil, err
+	}
+	return issues, blockers, nil
+}
+
+// storeBlockers resolves the blockers of issues from the wrapped store.
+func (s *Store) storeBlockers(ctx context.Context, issues []*issuestorage.Issue, cascade bool) ([]*issuestorage.Issue, map[string]*issuestorage.Blockers, error) {
+	closedIssues, err := s.inner.List(ctx, &issuestorage.ListFilter{Statuses: []issuestorage.Status{issuestorage.StatusClosed}})
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing closed issues: %w", err)
+	}
+	closed := make(map[string]bool, len(closedIssues))
+	for _, issue := range closedIssues {
+		closed[issue.ID] = true
+	}
+	blockers, err := issuestorage.ResolveBlockers(ctx, s.inner, issues, closed, cascade)
+	if err != nil {
+		return nil, nil, err
+	}
+	return issues, blockers, nil
+}
+
+// graphGetter serves ancestors from the index, as issues holding only the
+// parent and blocks dependencies that ResolveBlockers reads.
+type graphGetter struct {
+	ix *Index
+}
+
+func (g graphGetter) Get(ctx context.Context, id string) (*issuestorage.Issue, error) {
+	d, ok := g.ix.docs[id]
+	if !ok {
+		return nil, issuestorage.ErrNotFound
+	}
+	issue := &issuestorage.Issue{ID: id, Status: d.Status, Parent: d.Parent}
+	for _, dep := range d.Blocks {
+		issue.Dependencies = append(issue.Dependencies, issuestorage.Dependency{ID: dep, Type: issuestorage.DepTypeBlocks})
+	}
+	return issue, nil
+}
@@ -0,0 +1,102 @@
+package indexed
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// ellipsis marks text cut from either end of a snippet.
+const ellipsis = "…"
+
+// Snippet returns an excerpt of text, about width bytes long, around the
+// first word matching one of query's words as Search matches them: whole
+// or as a prefix. Whitespace is collapsed to single spaces. highlights
+// holds the byte ranges of the matching words within the excerpt. ok is
+// false, and the rest empty, if nothing in text matches.
+func Snippet(text, query string, width int) (snippet string, highlights [][2]int, ok bool) {
+	words := uniqueWords(query)
+	if len(words) == 0 {
+		return "", nil, false
+	}
+	text = strings.Join(strings.Fields(text), " ")
+	matches := matchingTerms(text, words)
+	if len(matches) == 0 {
+		return "", nil, false
+	}
+
+	// Start a third of the width before the first match and end width
+	// later, keeping whole words and the whole match.
+	first := matches[0]
+	start, end := 0, len(text)
+	if len(text) > width {
+		start = max(0, first[0]-width/3)
+		if i := strings.IndexByte(text[start:first[0]], ' '); start > 0 && i >= 0 {
+			start += i + 1
+		} else if start > 0 {
+			start = first[0]
+		}
+		end = max(first[1], min(len(text), start+width))
+		if i := strings.LastIndexByte(text[first[1]:end], ' '); end < len(text) && i >= 0 {
+			end = first[1] + i
+		} else if end < len(text) {
+			end = first[1]
+		}
+	}
+
+	prefix := ""
+	if start > 0 {
+		prefix = ellipsis + " "
+	}
+	snippet = prefix + text[start:end]
+	if end < len(text) {
+		snippet += " " + ellipsis
+	}
+	for _, m := range matches {
+		if m[0] >= start && m[1] <= end {
+			highlights = append(highlights, [2]int{m[0] - start + len(prefix), m[1] - start + len(prefix)})
+		}
+	}
+	return snippet, highlights, true
+}
+
+// matchingTerms returns the byte ranges of the terms in text, as Tokenize
+// splits it, that one of words prefixes.
+func matchingTerms(text string, words []string) [][2]int {
+	var matches [][2]int
+	termStart := -1
+	for i := 0; i <= len(text); {
+		r, size := utf8.RuneError, 1
+		if i < len(text) {
+			r, size = utf8.DecodeRuneInString(text[i:])
+		}
+		if i < len(text) && !isSeparator(r) {
+			if termStart < 0 {
+				termStart = i
+			}
+		} else if termStart >= 0 {
+			term := strings.ToLower(text[termStart:i])
+			for _, w := range words {
+				if strings.HasPrefix(term, w) {
+					matches = append(matches, [2]int{termStart, i})
+					break
+				}
+			}
+			termStart = -1
+		}
+		i += size
+	}
+	return matches
+}
+
+// Highlight returns s with each range in highlights passed through mark.
+func Highlight(s string, highlights [][2]int, mark func(string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, h := range highlights {
+		b.WriteString(s[last:h[0]])
+		b.WriteString(mark(s[h[0]:h[1]]))
+		last = h[1]
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
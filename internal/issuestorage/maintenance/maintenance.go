@@ -0,0 +1,375 @@
+// Package maintenance implements a store-wide advisory lock for operations
+// that rewrite many issues at once, such as compaction, and an IssueStore
+// decorator that makes normal writes honour it.
+//
+// The lock is a flock on .beads/maintenance.lock. Each write takes it
+// shared for its duration and maintenance takes it exclusive, so neither
+// starts while the other is in flight. A maintenance window can also be
+// opened explicitly by one command and closed by another, across many
+// processes (bd maintenance begin/end): it is recorded in
+// .beads/maintenance.json, and writes by other actors treat it as held.
+//
+// Only processes sharing the .beads directory coordinate; writers on other
+// machines of a shared backend do not see the lock.
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// File names in the .beads directory.
+const (
+	LockFile  = "maintenance.lock"
+	StateFile = "maintenance.json"
+)
+
+// MinMaintenanceWait is how long maintenance waits for in-flight writes,
+// which hold the lock only briefly, when the timeout is shorter.
+const MinMaintenanceWait = 10 * time.Second
+
+// pollInterval is how often a waiting lock attempt retries.
+const pollInterval = 25 * time.Millisecond
+
+// ErrInProgress is returned, wrapped with the holder, by writes made while
+// maintenance is under way and the timeout runs out.
+var ErrInProgress = errors.New("maintenance in progress")
+
+// Holder describes who holds the lock.
+type Holder struct {
+	Actor     string    `json:"actor"`
+	Reason    string    `json:"reason,omitempty"`
+	PID       int       `json:"pid"`
+	Host      string    `json:"host,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+	Explicit  bool      `json:"explicit"` // opened by bd maintenance begin
+}
+
+func (h *Holder) String() string {
+	s := fmt.Sprintf("%s since %s", h.Actor, h.StartedAt.Format(time.RFC3339))
+	if h.Reason != "" {
+		s += " (" + h.Reason + ")"
+	}
+	return s
+}
+
+// Lock is the maintenance lock of one .beads directory.
+type Lock struct {
+	dir     string
+	timeout time.Duration
+	actor   func() string
+
+	mu   sync.Mutex
+	held *os.File // the exclusive lock, while this process holds it
+}
+
+// New returns the lock for the .beads directory dir. By default writes
+// fail at once while maintenance is under way; see SetTimeout.
+func New(dir string) *Lock {
+	return &Lock{dir: dir, actor: func() string { return "" }}
+}
+
+// SetTimeout sets how long a write waits for maintenance to end before
+// failing with ErrInProgress.
+func (l *Lock) SetTimeout(d time.Duration) { l.timeout = d }
+
+// SetActor sets the function naming the actor writes are made as. Writes
+// by the actor who opened an explicit window go ahead.
+func (l *Lock) SetActor(fn func() string) { l.actor = fn }
+
+// Acquire takes the lock exclusively for an operation in this process,
+// waiting for in-flight writes to finish. Writes through this process's
+// Store go ahead until release is called. It fails if another actor holds
+// the lock.
+func (l *Lock) Acquire(ctx context.Context, reason string) (release func() error, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.held != nil {
+		return nil, fmt.Errorf("%w in this process", ErrInProgress)
+	}
+	f, state, err := l.lock(ctx, syscall.LOCK_EX, max(l.timeout, MinMaintenanceWait))
+	if err != nil {
+		return nil, err
+	}
+	// The window's own actor runs maintenance in it; its record stays.
+	if state == nil || !state.Explicit {
+		if err := l.writeState(l.holder(reason, false)); err != nil {
+			unlock(f)
+			return nil, err
+		}
+	}
+	l.held = f
+	return func() error {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		var err error
+		if state == nil || !state.Explicit {
+			err = os.Remove(l.path(StateFile))
+		}
+		unlock(l.held)
+		l.held = nil
+		return err
+	}, nil
+}
+
+// Begin opens an explicit maintenance window that lasts until End, once
+// in-flight writes have finished. Only the current actor may write while
+// it is open.
+func (l *Lock) Begin(ctx context.Context, reason string) (*Holder, error) {
+	f, _, err := l.lock(ctx, syscall.LOCK_EX, max(l.timeout, MinMaintenanceWait))
+	if err != nil {
+		return nil, err
+	}
+	defer unlock(f)
+	h := l.holder(reason, true)
+	if err := l.writeState(h); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// End closes the explicit window and returns who opened it. Unless force
+// is set, only the actor who opened it may close it.
+func (l *Lock) End(force bool) (*Holder, error) {
+	h, err := l.readState()
+	if err != nil {
+		return nil, err
+	}
+	if h == nil || !h.Explicit {
+		return nil, errors.New("no maintenance window is open")
+	}
+	if actor := l.actor(); !force && h.Actor != actor {
+		return nil, fmt.Errorf("maintenance window was opened by %s, not %s (use --force to close it anyway)", h, actor)
+	}
+	if err := os.Remove(l.path(StateFile)); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Status returns who holds the lock, or nil if no one does.
+func (l *Lock) Status() (*Holder, error) {
+	f, err := l.open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	h, err := l.readState()
+	if err != nil {
+		return nil, err
+	}
+	if h != nil && !h.Explicit {
+		// The record of an operation that is not running is left by a
+		// process that died; its flock went with it.
+		if err := syscall.Flock(int(f.Fd()), syscall.LOCK_SH|syscall.LOCK_NB); err == nil {
+			syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+			return nil, nil
+		}
+	}
+	return h, nil
+}
+
+// guard takes the lock shared for one write and returns the function that
+// releases it.
+func (l *Lock) guard(ctx context.Context) (release func(), err error) {
+	l.mu.Lock()
+	held := l.held != nil
+	l.mu.Unlock()
+	if held {
+		return func() {}, nil
+	}
+	f, _, err := l.lock(ctx, syscall.LOCK_SH, l.timeout)
+	if err != nil {
+		return nil, err
+	}
+	return func() { unlock(f) }, nil
+}
+
+// lock takes the flock as how, waiting up to timeout for it and for an
+// explicit window opened by another actor to close. It returns the state
+// record read under the lock.
+func (l *Lock) lock(ctx context.Context, how int, timeout time.Duration) (*os.File, *Holder, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		f, err := l.open()
+		if err != nil {
+			return nil, nil, err
+		}
+		var holder *Holder
+		err = syscall.Flock(int(f.Fd()), how|syscall.LOCK_NB)
+		if err == nil {
+			state, err := l.readState()
+			if err != nil {
+				unlock(f)
+				return nil, nil, err
+			}
+			if state == nil || !state.Explicit || state.Actor == l.actor() {
+				return f, state, nil
+			}
+			holder = state
+			unlock(f)
+		} else {
+			f.Close()
+			if !errors.Is(err, syscall.EWOULDBLOCK) {
+				return nil, nil, fmt.Errorf("locking %s: %w", l.path(LockFile), err)
+			}
+			holder, _ = l.readState()
+		}
+
+		if time.Now().After(deadline) {
+			if holder == nil {
+				return nil, nil, fmt.Errorf("%w; retry later or wait with --lock-timeout", ErrInProgress)
+			}
+			return nil, nil, fmt.Errorf("%w by %s; retry later or wait with --lock-timeout", ErrInProgress, holder)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func (l *Lock) holder(reason string, explicit bool) *Holder {
+	host, _ := os.Hostname()
+	return &Holder{
+		Actor:     l.actor(),
+		Reason:    reason,
+		PID:       os.Getpid(),
+		Host:      host,
+		StartedAt: time.Now().UTC().Truncate(time.Second),
+		Explicit:  explicit,
+	}
+}
+
+func (l *Lock) path(name string) string { return filepath.Join(l.dir, name) }
+
+func (l *Lock) open() (*os.File, error) {
+	return os.OpenFile(l.path(LockFile), os.O_CREATE|os.O_RDWR, 0644)
+}
+
+func (l *Lock) readState() (*Holder, error) {
+	data, err := os.ReadFile(l.path(StateFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var h Holder
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", l.path(StateFile), err)
+	}
+	return &h, nil
+}
+
+func (l *Lock) writeState(h *Holder) error {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := l.path(StateFile + ".tmp")
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, l.path(StateFile))
+}
+
+func unlock(f *os.File) {
+	syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+	f.Close()
+}
+
+// Store wraps an IssueStore so that writes wait for or fail on maintenance.
+// Reads are never blocked.
+type Store struct {
+	inner issuestorage.IssueStore
+	lock  *Lock
+}
+
+// NewStore wraps inner with lock.
+func NewStore(inner issuestorage.IssueStore, lock *Lock) *Store {
+	return &Store{inner: inner, lock: lock}
+}
+
+// Create implements issuestorage.IssueStore.
+func (s *Store) Create(ctx context.Context, issue *issuestorage.Issue, opts ...issuestorage.CreateOpts) (string, error) {
+	release, err := s.lock.guard(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+	return s.inner.Create(ctx, issue, opts...)
+}
+
+// Get implements issuestorage.IssueStore.
+func (s *Store) Get(ctx context.Context, id string) (*issuestorage.Issue, error) {
+	return s.inner.Get(ctx, id)
+}
+
+// Modify implements issuestorage.IssueStore.
+func (s *Store) Modify(ctx context.Context, id string, fn func(*issuestorage.Issue) error) error {
+	release, err := s.lock.guard(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return s.inner.Modify(ctx, id, fn)
+}
+
+// Delete implements issuestorage.IssueStore.
+func (s *Store) Delete(ctx context.Context, id string) error {
+	release, err := s.lock.guard(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return s.inner.Delete(ctx, id)
+}
+
+// List implements issuestorage.IssueStore.
+func (s *Store) List(ctx context.Context, filter *issuestorage.ListFilter) ([]*issuestorage.Issue, error) {
+	return s.inner.List(ctx, filter)
+}
+
+// ListReady implements issuestorage.IssueStore.
+func (s *Store) ListReady(ctx context.Context, filter *issuestorage.ListFilter, cascade bool) ([]*issuestorage.Issue, error) {
+	return s.inner.ListReady(ctx, filter, cascade)
+}
+
+// ListBlocked implements issuestorage.IssueStore.
+func (s *Store) ListBlocked(ctx context.Context, filter *issuestorage.ListFilter, cascade bool) ([]issuestorage.BlockedIssue, error) {
+	return s.inner.ListBlocked(ctx, filter, cascade)
+}
+
+// GetNextChildID implements issuestorage.IssueStore.
+func (s *Store) GetNextChildID(ctx context.Context, parentID string) (string, error) {
+	return s.inner.GetNextChildID(ctx, parentID)
+}
+
+// Init implements issuestorage.IssueStore.
+func (s *Store) Init(ctx context.Context) error {
+	return s.inner.Init(ctx)
+}
+
+// Doctor implements issuestorage.IssueStore. Fixing takes the lock like
+// any other maintenance.
+func (s *Store) Doctor(ctx context.Context, fix bool) ([]string, error) {
+	if fix {
+		release, err := s.lock.Acquire(ctx, "doctor --fix")
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+	return s.inner.Doctor(ctx, fix)
+}
@@ -0,0 +1,135 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/issuestorage/filesystem"
+)
+
+func TestMaintenanceContract(t *testing.T) {
+	factory := func() issuestorage.IssueStore {
+		dir := t.TempDir()
+		return NewStore(filesystem.New(dir, "bd-"), New(dir))
+	}
+	issuestorage.RunContractTests(t, factory)
+}
+
+// newWriter returns a store and its lock for actor, as another process on
+// dir would open them: each Lock has its own flock.
+func newWriter(t *testing.T, dir, actor string) (*Store, *Lock) {
+	t.Helper()
+	lock := New(dir)
+	lock.SetActor(func() string { return actor })
+	return NewStore(filesystem.New(dir, "bd-"), lock), lock
+}
+
+func TestAcquireHoldsOffOtherWriters(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	ours, ourLock := newWriter(t, dir, "alice")
+	theirs, theirLock := newWriter(t, dir, "bob")
+	if err := ours.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+	id, err := ours.Create(ctx, &issuestorage.Issue{Title: "Old", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	release, err := ourLock.Acquire(ctx, "compact")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ours.Delete(ctx, id); err != nil {
+		t.Errorf("the holder's own writes should go ahead: %v", err)
+	}
+	_, err = theirs.Create(ctx, &issuestorage.Issue{Title: "New", Status: issuestorage.StatusOpen})
+	if !errors.Is(err, ErrInProgress) {
+		t.Errorf("Create during maintenance = %v, want ErrInProgress", err)
+	}
+	if h, err := theirLock.Status(); err != nil || h == nil || h.Actor != "alice" || h.Reason != "compact" {
+		t.Errorf("Status = %+v, %v; want alice compacting", h, err)
+	}
+	if _, err := theirs.List(ctx, nil); err != nil {
+		t.Errorf("reads should not wait: %v", err)
+	}
+
+	// With a timeout, a write waits for the lock to be released.
+	theirLock.SetTimeout(5 * time.Second)
+	time.AfterFunc(50*time.Millisecond, func() { release() })
+	if _, err := theirs.Create(ctx, &issuestorage.Issue{Title: "New", Status: issuestorage.StatusOpen}); err != nil {
+		t.Errorf("Create with a timeout should wait for maintenance: %v", err)
+	}
+	if h, _ := theirLock.Status(); h != nil {
+		t.Errorf("Status after release = %+v, want none", h)
+	}
+}
+
+func TestExplicitWindow(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	ours, ourLock := newWriter(t, dir, "alice")
+	theirs, theirLock := newWriter(t, dir, "bob")
+	if err := ours.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ourLock.Begin(ctx, "cleanup"); err != nil {
+		t.Fatal(err)
+	}
+	// Another process run by the same actor, e.g. a later bd command.
+	again, againLock := newWriter(t, dir, "alice")
+	if _, err := again.Create(ctx, &issuestorage.Issue{Title: "Mine", Status: issuestorage.StatusOpen}); err != nil {
+		t.Errorf("the window's actor should write: %v", err)
+	}
+	release, err := againLock.Acquire(ctx, "compact")
+	if err != nil {
+		t.Fatalf("the window's actor should run maintenance in it: %v", err)
+	}
+	release()
+	if h, _ := theirLock.Status(); h == nil || !h.Explicit {
+		t.Errorf("the window should outlast maintenance run in it, status = %+v", h)
+	}
+
+	if _, err := theirs.Create(ctx, &issuestorage.Issue{Title: "Theirs", Status: issuestorage.StatusOpen}); !errors.Is(err, ErrInProgress) {
+		t.Errorf("Create by another actor = %v, want ErrInProgress", err)
+	}
+	if _, err := theirLock.End(false); err == nil {
+		t.Error("another actor should not end the window without force")
+	}
+	h, err := ourLock.End(false)
+	if err != nil || h.Reason != "cleanup" {
+		t.Fatalf("End = %+v, %v", h, err)
+	}
+	if _, err := theirs.Create(ctx, &issuestorage.Issue{Title: "Theirs", Status: issuestorage.StatusOpen}); err != nil {
+		t.Errorf("Create after the window = %v", err)
+	}
+	if _, err := ourLock.End(false); err == nil {
+		t.Error("End with no window open should fail")
+	}
+}
+
+func TestStaleRecordIgnored(t *testing.T) {
+	dir := t.TempDir()
+	// Left by a maintenance process that died; its flock went with it.
+	stale := `{"actor": "alice", "pid": 1, "started_at": "2026-01-02T03:04:05Z", "explicit": false}`
+	if err := os.WriteFile(filepath.Join(dir, StateFile), []byte(stale), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store, lock := newWriter(t, dir, "bob")
+	if h, err := lock.Status(); err != nil || h != nil {
+		t.Errorf("Status = %+v, %v; want none", h, err)
+	}
+	if err := store.Init(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Create(context.Background(), &issuestorage.Issue{Title: "New", Status: issuestorage.StatusOpen}); err != nil {
+		t.Errorf("Create = %v", err)
+	}
+}
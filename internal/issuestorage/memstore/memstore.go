@@ -0,0 +1,333 @@
+// Package memstore implements the IssueStore interface entirely in memory,
+// for tests and for programs that embed beads-lite without touching disk.
+// Issues are kept JSON-encoded, as the other backends store them, so
+// callers never share memory with the store and round-trip behaviour
+// (omitted empty fields, time precision) matches the filesystem backend.
+//
+// Like objectstore, Modify is optimistic: fn runs without holding the
+// store's lock, and the change is re-applied to a fresh copy if another
+// writer got in first. fn may therefore read from the store itself.
+package memstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"beads-lite/internal/idgen"
+	"beads-lite/internal/issuestorage"
+)
+
+// MaxIDRetries is the maximum number of random ID generation attempts before
+// returning an error. See filesystem.MaxIDRetries.
+const MaxIDRetries = 20
+
+// MaxModifyRetries is the number of times Modify re-reads and re-applies its
+// change after losing a race with another writer.
+const MaxModifyRetries = 10
+
+// record is a stored issue and the revision it was written at.
+type record struct {
+	data     []byte
+	revision uint64
+}
+
+// Store implements issuestorage.IssueStore in memory. It is safe for
+// concurrent use.
+type Store struct {
+	mu                sync.RWMutex
+	issues            map[string]record
+	revision          uint64 // last revision handed out
+	maxHierarchyDepth int
+	prefix            string // ID prefix (e.g., "bd-", "bl-")
+}
+
+// Option configures a Store instance.
+type Option func(*Store)
+
+// WithMaxHierarchyDepth sets the maximum hierarchy depth for child IDs.
+func WithMaxHierarchyDepth(n int) Option {
+	return func(s *Store) {
+		s.maxHierarchyDepth = n
+	}
+}
+
+// New creates an empty Store.
+// The prefix is prepended to generated IDs (e.g., "bd-", "bl-").
+func New(prefix string, opts ...Option) *Store {
+	s := &Store{
+		issues:            make(map[string]record),
+		maxHierarchyDepth: idgen.DefaultMaxHierarchyDepth,
+		prefix:            prefix,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Init does nothing: a Store is ready to use once created.
+func (s *Store) Init(ctx context.Context) error {
+	return nil
+}
+
+func encodeIssue(issue *issuestorage.Issue) ([]byte, error) {
+	data, err := json.Marshal(issue)
+	if err != nil {
+		return nil, fmt.Errorf("encoding issue: %w", err)
+	}
+	return data, nil
+}
+
+func decodeIssue(data []byte) (*issuestorage.Issue, error) {
+	var issue issuestorage.Issue
+	if err := json.Unmarshal(data, &issue); err != nil {
+		return nil, fmt.Errorf("decoding issue: %w", err)
+	}
+	return &issue, nil
+}
+
+// putLocked stores data for id at a new revision. s.mu must be held for
+// writing.
+func (s *Store) putLocked(id string, data []byte) {
+	s.revision++
+	s.issues[id] = record{data: data, revision: s.revision}
+}
+
+// Create creates a new issue and returns its ID.
+// If issue.ID is already set, that ID is used directly (for hierarchical child IDs).
+// Otherwise a random ID is generated, retrying on collision.
+func (s *Store) Create(ctx context.Context, issue *issuestorage.Issue, opts ...issuestorage.CreateOpts) (string, error) {
+	if issue.ID != "" {
+		if parentID, _, ok := idgen.ParseHierarchicalID(issue.ID); ok {
+			if err := idgen.CheckHierarchyDepth(parentID, s.maxHierarchyDepth); err != nil {
+				return "", err
+			}
+		}
+		data, err := encodeIssue(issue)
+		if err != nil {
+			return "", err
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, exists := s.issues[issue.ID]; exists {
+			return "", fmt.Errorf("issue %s already exists", issue.ID)
+		}
+		s.putLocked(issue.ID, data)
+		return issue.ID, nil
+	}
+
+	var createOpts issuestorage.CreateOpts
+	if len(opts) > 0 {
+		createOpts = opts[0]
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The count is always exact, so UseCachedCount has nothing to skip.
+	length := idgen.AdaptiveLength(len(s.issues))
+	effectivePrefix := idgen.BuildPrefix(s.prefix, createOpts.PrefixAddition)
+
+	for attempt := 0; attempt < MaxIDRetries; attempt++ {
+		id, err := idgen.RandomIDFrom(createOpts.IDSource(), effectivePrefix, length)
+		if err != nil {
+			return "", fmt.Errorf("generating random ID: %w", err)
+		}
+		if _, exists := s.issues[id]; exists {
+			continue // Collision, try next random ID
+		}
+		issue.ID = id
+		data, err := encodeIssue(issue)
+		if err != nil {
+			issue.ID = ""
+			return "", err
+		}
+		s.putLocked(id, data)
+		return id, nil
+	}
+	return "", fmt.Errorf("failed to generate unique ID: %d retries exhausted at length %d", MaxIDRetries, length)
+}
+
+// get returns a fresh copy of an issue along with its revision.
+func (s *Store) get(id string) (*issuestorage.Issue, uint64, error) {
+	s.mu.RLock()
+	rec, ok := s.issues[id]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, 0, issuestorage.ErrNotFound
+	}
+	issue, err := decodeIssue(rec.data)
+	if err != nil {
+		return nil, 0, fmt.Errorf("reading issue %s: %w", id, err)
+	}
+	return issue, rec.revision, nil
+}
+
+// Get retrieves an issue by ID.
+func (s *Store) Get(ctx context.Context, id string) (*issuestorage.Issue, error) {
+	issue, _, err := s.get(id)
+	return issue, err
+}
+
+// Modify reads an issue, applies fn to it, and writes it back only if no
+// one else has written it in between. On a lost race the issue is re-read
+// and fn applied again, so fn must not have side effects beyond mutating
+// the issue it is given.
+func (s *Store) Modify(ctx context.Context, id string, fn func(*issuestorage.Issue) error) error {
+	for attempt := 0; attempt < MaxModifyRetries; attempt++ {
+		issue, revision, err := s.get(id)
+		if err != nil {
+			return err
+		}
+		if err := fn(issue); err != nil {
+			return err
+		}
+		data, err := encodeIssue(issue)
+		if err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		rec, ok := s.issues[id]
+		switch {
+		case !ok:
+			s.mu.Unlock()
+			return issuestorage.ErrNotFound
+		case rec.revision != revision:
+			s.mu.Unlock()
+			continue
+		}
+		s.putLocked(id, data)
+		s.mu.Unlock()
+		return nil
+	}
+	return fmt.Errorf("modifying %s: %w (gave up after %d concurrent writes)", id, issuestorage.ErrLockTimeout, MaxModifyRetries)
+}
+
+// Delete permanently removes an issue.
+func (s *Store) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.issues[id]; !ok {
+		return issuestorage.ErrNotFound
+	}
+	delete(s.issues, id)
+	return nil
+}
+
+// location mirrors the filesystem backend's directory layout so List
+// returns the same issues for the same filter.
+func location(issue *issuestorage.Issue) string {
+	switch {
+	case issue.Status == issuestorage.StatusTombstone:
+		return "deleted"
+	case issue.Ephemeral:
+		return "ephemeral"
+	case issue.Status == issuestorage.StatusClosed:
+		return "closed"
+	default:
+		return "open"
+	}
+}
+
+// scannedLocations returns the locations a filter selects: open and
+// ephemeral issues by default, plus closed or deleted ones when those
+// statuses are requested.
+func scannedLocations(filter *issuestorage.ListFilter) map[string]bool {
+	if filter == nil || len(filter.Statuses) == 0 {
+		return map[string]bool{"open": true, "ephemeral": true}
+	}
+	locs := make(map[string]bool)
+	for _, st := range filter.Statuses {
+		switch st {
+		case issuestorage.StatusClosed:
+			locs["closed"] = true
+		case issuestorage.StatusTombstone:
+			locs["deleted"] = true
+		default:
+			locs["open"] = true
+			locs["ephemeral"] = true
+		}
+	}
+	return locs
+}
+
+// List returns all issues matching the filter.
+// Results are sorted by CreatedAt (oldest first), then by ID.
+func (s *Store) List(ctx context.Context, filter *issuestorage.ListFilter) ([]*issuestorage.Issue, error) {
+	s.mu.RLock()
+	snapshot := make([][]byte, 0, len(s.issues))
+	for _, rec := range s.issues {
+		snapshot = append(snapshot, rec.data)
+	}
+	s.mu.RUnlock()
+
+	locs := scannedLocations(filter)
+	var issues []*issuestorage.Issue
+	for _, data := range snapshot {
+		issue, err := decodeIssue(data)
+		if err != nil {
+			return nil, err
+		}
+		if locs[location(issue)] && filter.Matches(issue) {
+			issues = append(issues, issue)
+		}
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		if !issues[i].CreatedAt.Equal(issues[j].CreatedAt) {
+			return issues[i].CreatedAt.Before(issues[j].CreatedAt)
+		}
+		return issues[i].ID < issues[j].ID
+	})
+	return issues, nil
+}
+
+// ListReady implements issuestorage.IssueStore.
+func (s *Store) ListReady(ctx context.Context, filter *issuestorage.ListFilter, cascade bool) ([]*issuestorage.Issue, error) {
+	return issuestorage.ListReady(ctx, s, filter, cascade)
+}
+
+// ListBlocked implements issuestorage.IssueStore.
+func (s *Store) ListBlocked(ctx context.Context, filter *issuestorage.ListFilter, cascade bool) ([]issuestorage.BlockedIssue, error) {
+	return issuestorage.ListBlocked(ctx, s, filter, cascade)
+}
+
+// GetNextChildID validates the parent exists, checks hierarchy depth limits,
+// scans for existing children, and returns the next child ID.
+// The returned ID is not reserved; Create fails if another writer takes it
+// first and the caller should retry.
+func (s *Store) GetNextChildID(ctx context.Context, parentID string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if _, ok := s.issues[parentID]; !ok {
+		return "", fmt.Errorf("parent %s: %w", parentID, issuestorage.ErrNotFound)
+	}
+
+	if err := idgen.CheckHierarchyDepth(parentID, s.maxHierarchyDepth); err != nil {
+		return "", err
+	}
+
+	maxChild := 0
+	for id := range s.issues {
+		if !strings.HasPrefix(id, parentID+".") {
+			continue
+		}
+		parent, childNum, ok := idgen.ParseHierarchicalID(id)
+		if ok && parent == parentID && childNum > maxChild {
+			maxChild = childNum
+		}
+	}
+
+	return idgen.ChildID(parentID, maxChild+1), nil
+}
+
+// Doctor reports nothing: issues held in memory cannot be left half
+// written or misplaced, so there is nothing to check or fix.
+func (s *Store) Doctor(ctx context.Context, fix bool) ([]string, error) {
+	return nil, nil
+}
@@ -0,0 +1,177 @@
+package memstore
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestMemStoreContract(t *testing.T) {
+	factory := func() issuestorage.IssueStore {
+		return New("bd-")
+	}
+	issuestorage.RunContractTests(t, factory)
+}
+
+func TestMemStoreProperties(t *testing.T) {
+	factory := func() issuestorage.IssueStore {
+		return New("bd-")
+	}
+	issuestorage.RunPropertyTests(t, factory)
+}
+
+func TestModifyRetriesOnConcurrentWrites(t *testing.T) {
+	s := New("bd-")
+	ctx := context.Background()
+
+	id, err := s.Create(ctx, &issuestorage.Issue{Title: "Counter", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const writers = 8
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.Modify(ctx, id, func(issue *issuestorage.Issue) error {
+				issue.Labels = append(issue.Labels, "x")
+				return nil
+			}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, err := s.Get(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Labels) != writers {
+		t.Errorf("labels = %d, want %d (a concurrent write was lost)", len(got.Labels), writers)
+	}
+}
+
+func TestModifyCanReadStore(t *testing.T) {
+	s := New("bd-")
+	ctx := context.Background()
+
+	other, err := s.Create(ctx, &issuestorage.Issue{Title: "Other", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := s.Create(ctx, &issuestorage.Issue{Title: "Copy", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// fn runs without the store's lock, so reading inside it must not deadlock.
+	if err := s.Modify(ctx, id, func(issue *issuestorage.Issue) error {
+		src, err := s.Get(ctx, other)
+		if err != nil {
+			return err
+		}
+		issue.Title = "Copy of " + src.Title
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := s.Get(ctx, id)
+	if got.Title != "Copy of Other" {
+		t.Errorf("Title = %q", got.Title)
+	}
+}
+
+func TestGetReturnsCopies(t *testing.T) {
+	s := New("bd-")
+	ctx := context.Background()
+
+	issue := &issuestorage.Issue{Title: "Shared", Status: issuestorage.StatusOpen, Labels: []string{"a"}}
+	id, err := s.Create(ctx, issue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issue.Labels[0] = "changed by caller"
+
+	got, _ := s.Get(ctx, id)
+	got.Labels[0] = "changed by reader"
+
+	again, _ := s.Get(ctx, id)
+	if again.Labels[0] != "a" {
+		t.Errorf("Labels = %v, want the stored value untouched", again.Labels)
+	}
+}
+
+func TestCreateExplicitIDConflict(t *testing.T) {
+	s := New("bd-")
+	ctx := context.Background()
+
+	if _, err := s.Create(ctx, &issuestorage.Issue{ID: "bd-abc", Title: "First", Status: issuestorage.StatusOpen}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Create(ctx, &issuestorage.Issue{ID: "bd-abc", Title: "Second"}); err == nil {
+		t.Fatal("expected error creating duplicate ID")
+	}
+	got, err := s.Get(ctx, "bd-abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "First" {
+		t.Errorf("Title = %q, duplicate create overwrote the issue", got.Title)
+	}
+}
+
+func TestListMatchesFilesystemLayout(t *testing.T) {
+	s := New("bd-")
+	ctx := context.Background()
+	base := time.Now()
+
+	create := func(id string, status issuestorage.Status, ephemeral bool, offset time.Duration) {
+		t.Helper()
+		if _, err := s.Create(ctx, &issuestorage.Issue{
+			ID: id, Title: id, Status: status, Ephemeral: ephemeral,
+			CreatedAt: base.Add(offset), UpdatedAt: base.Add(offset),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	create("bd-open", issuestorage.StatusOpen, false, 0)
+	create("bd-closed", issuestorage.StatusClosed, false, time.Second)
+	create("bd-wisp", issuestorage.StatusOpen, true, 2*time.Second)
+	create("bd-gone", issuestorage.StatusTombstone, false, 3*time.Second)
+
+	tests := []struct {
+		name   string
+		filter *issuestorage.ListFilter
+		want   []string
+	}{
+		{"default", nil, []string{"bd-open", "bd-wisp"}},
+		{"closed", &issuestorage.ListFilter{Statuses: []issuestorage.Status{issuestorage.StatusClosed}}, []string{"bd-closed"}},
+		{"tombstone", &issuestorage.ListFilter{Statuses: []issuestorage.Status{issuestorage.StatusTombstone}}, []string{"bd-gone"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.List(ctx, tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []string
+			for _, issue := range got {
+				ids = append(ids, issue.ID)
+			}
+			if len(ids) != len(tt.want) {
+				t.Fatalf("got %v, want %v", ids, tt.want)
+			}
+			for i := range ids {
+				if ids[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", ids, tt.want)
+				}
+			}
+		})
+	}
+}
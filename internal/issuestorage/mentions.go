@@ -0,0 +1,57 @@
+package issuestorage
+
+import (
+	"regexp"
+	"strings"
+)
+
+// mentionRe matches an @mention. The mention must not follow a word
+// character, so email addresses are not mistaken for mentions.
+var mentionRe = regexp.MustCompile(`(?:^|[^\w@.])@([\w][\w.\-]*)`)
+
+// ParseMentions returns the identities @mentioned in text, in order of
+// first appearance without duplicates. Trailing punctuation ("@alice.")
+// is not part of the mention.
+func ParseMentions(text string) []string {
+	var mentions []string
+	for _, m := range mentionRe.FindAllStringSubmatch(text, -1) {
+		name := strings.TrimRight(m[1], ".-")
+		if name == "" {
+			continue
+		}
+		dup := false
+		for _, seen := range mentions {
+			if strings.EqualFold(seen, name) {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			mentions = append(mentions, name)
+		}
+	}
+	return mentions
+}
+
+// MentionMatches reports whether a mention refers to identity. Matching
+// ignores case, and a mention of an email identity's local part matches
+// it ("@alice" matches "alice@example.com").
+func MentionMatches(mention, identity string) bool {
+	if strings.EqualFold(mention, identity) {
+		return true
+	}
+	local, _, ok := strings.Cut(identity, "@")
+	return ok && strings.EqualFold(mention, local)
+}
+
+// Subscribe adds identity to the issue's subscribers, reporting whether it
+// was added (false if it was already subscribed).
+func (issue *Issue) Subscribe(identity string) bool {
+	for _, s := range issue.Subscribers {
+		if strings.EqualFold(s, identity) {
+			return false
+		}
+	}
+	issue.Subscribers = append(issue.Subscribers, identity)
+	return true
+}
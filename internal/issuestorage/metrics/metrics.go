@@ -0,0 +1,186 @@
+// Package metrics implements an IssueStore decorator that records how many
+// times each storage operation runs and how long it takes, so the CLI can
+// point users at faster setups when storage becomes the bottleneck.
+package metrics
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// Config keys for storage metrics.
+const (
+	// SlowThresholdKey is the duration above which a single storage
+	// operation triggers a hint ("0" disables hints).
+	SlowThresholdKey = "storage.slow_threshold"
+)
+
+// DefaultSlowThreshold is used when storage.slow_threshold is unset.
+const DefaultSlowThreshold = 2 * time.Second
+
+// Operation names as recorded in OpStats.
+const (
+	OpCreate         = "create"
+	OpGet            = "get"
+	OpModify         = "modify"
+	OpDelete         = "delete"
+	OpList           = "list"
+	OpListReady      = "list_ready"
+	OpListBlocked    = "list_blocked"
+	OpGetNextChildID = "next_child_id"
+	OpInit           = "init"
+	OpDoctor         = "doctor"
+)
+
+// FileCounter reports how many issue files a backend has read so far.
+// The filesystem backend implements it.
+type FileCounter interface {
+	FilesRead() int64
+}
+
+// OpStats summarizes the calls of one operation.
+type OpStats struct {
+	Op    string
+	Count int
+	Total time.Duration
+
+	// Slowest is the duration of the slowest single call, and
+	// SlowestFiles the number of files it read (0 if unknown).
+	Slowest      time.Duration
+	SlowestFiles int64
+}
+
+// Store wraps an IssueStore and records per-operation statistics.
+type Store struct {
+	inner issuestorage.IssueStore
+	files FileCounter
+
+	mu  sync.Mutex
+	ops map[string]*OpStats
+}
+
+// New wraps inner. files may be nil if the backend cannot count reads.
+func New(inner issuestorage.IssueStore, files FileCounter) *Store {
+	return &Store{inner: inner, files: files, ops: make(map[string]*OpStats)}
+}
+
+// track starts timing op and returns the function that records it.
+func (s *Store) track(op string) func() {
+	start := time.Now()
+	var filesBefore int64
+	if s.files != nil {
+		filesBefore = s.files.FilesRead()
+	}
+	return func() {
+		d := time.Since(start)
+		var files int64
+		if s.files != nil {
+			files = s.files.FilesRead() - filesBefore
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		st, ok := s.ops[op]
+		if !ok {
+			st = &OpStats{Op: op}
+			s.ops[op] = st
+		}
+		st.Count++
+		st.Total += d
+		if d > st.Slowest {
+			st.Slowest = d
+			st.SlowestFiles = files
+		}
+	}
+}
+
+// Snapshot returns the statistics recorded so far, sorted by operation.
+func (s *Store) Snapshot() []OpStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]OpStats, 0, len(s.ops))
+	for _, st := range s.ops {
+		out = append(out, *st)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Op < out[j].Op })
+	return out
+}
+
+// Slow returns the operations with a single call of at least threshold,
+// slowest first. A zero threshold returns nothing.
+func (s *Store) Slow(threshold time.Duration) []OpStats {
+	if threshold <= 0 {
+		return nil
+	}
+	var slow []OpStats
+	for _, st := range s.Snapshot() {
+		if st.Slowest >= threshold {
+			slow = append(slow, st)
+		}
+	}
+	sort.SliceStable(slow, func(i, j int) bool { return slow[i].Slowest > slow[j].Slowest })
+	return slow
+}
+
+// Create implements issuestorage.IssueStore.
+func (s *Store) Create(ctx context.Context, issue *issuestorage.Issue, opts ...issuestorage.CreateOpts) (string, error) {
+	defer s.track(OpCreate)()
+	return s.inner.Create(ctx, issue, opts...)
+}
+
+// Get implements issuestorage.IssueStore.
+func (s *Store) Get(ctx context.Context, id string) (*issuestorage.Issue, error) {
+	defer s.track(OpGet)()
+	return s.inner.Get(ctx, id)
+}
+
+// Modify implements issuestorage.IssueStore.
+func (s *Store) Modify(ctx context.Context, id string, fn func(*issuestorage.Issue) error) error {
+	defer s.track(OpModify)()
+	return s.inner.Modify(ctx, id, fn)
+}
+
+// Delete implements issuestorage.IssueStore.
+func (s *Store) Delete(ctx context.Context, id string) error {
+	defer s.track(OpDelete)()
+	return s.inner.Delete(ctx, id)
+}
+
+// List implements issuestorage.IssueStore.
+func (s *Store) List(ctx context.Context, filter *issuestorage.ListFilter) ([]*issuestorage.Issue, error) {
+	defer s.track(OpList)()
+	return s.inner.List(ctx, filter)
+}
+
+// ListReady implements issuestorage.IssueStore.
+func (s *Store) ListReady(ctx context.Context, filter *issuestorage.ListFilter, cascade bool) ([]*issuestorage.Issue, error) {
+	defer s.track(OpListReady)()
+	return s.inner.ListReady(ctx, filter, cascade)
+}
+
+// ListBlocked implements issuestorage.IssueStore.
+func (s *Store) ListBlocked(ctx context.Context, filter *issuestorage.ListFilter, cascade bool) ([]issuestorage.BlockedIssue, error) {
+	defer s.track(OpListBlocked)()
+	return s.inner.ListBlocked(ctx, filter, cascade)
+}
+
+// GetNextChildID implements issuestorage.IssueStore.
+func (s *Store) GetNextChildID(ctx context.Context, parentID string) (string, error) {
+	defer s.track(OpGetNextChildID)()
+	return s.inner.GetNextChildID(ctx, parentID)
+}
+
+// Init implements issuestorage.IssueStore.
+func (s *Store) Init(ctx context.Context) error {
+	defer s.track(OpInit)()
+	return s.inner.Init(ctx)
+}
+
+// Doctor implements issuestorage.IssueStore.
+func (s *Store) Doctor(ctx context.Context, fix bool) ([]string, error) {
+	defer s.track(OpDoctor)()
+	return s.inner.Doctor(ctx, fix)
+}
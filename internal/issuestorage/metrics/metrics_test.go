@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/issuestorage/filesystem"
+)
+
+func TestMetricsContract(t *testing.T) {
+	factory := func() issuestorage.IssueStore {
+		return New(filesystem.New(t.TempDir(), "bd-"), nil)
+	}
+	issuestorage.RunContractTests(t, factory)
+}
+
+func TestMetricsProperties(t *testing.T) {
+	factory := func() issuestorage.IssueStore {
+		return New(filesystem.New(t.TempDir(), "bd-"), nil)
+	}
+	issuestorage.RunPropertyTests(t, factory)
+}
+
+func TestRecordsOperations(t *testing.T) {
+	fs := filesystem.New(t.TempDir(), "bd-")
+	s := New(fs, fs)
+	ctx := context.Background()
+	if err := s.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := s.Create(ctx, &issuestorage.Issue{Title: "Issue", Status: issuestorage.StatusOpen}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.List(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	byOp := map[string]OpStats{}
+	for _, st := range s.Snapshot() {
+		byOp[st.Op] = st
+	}
+	if byOp[OpCreate].Count != 3 {
+		t.Errorf("create count = %d, want 3", byOp[OpCreate].Count)
+	}
+	if got := byOp[OpList]; got.Count != 1 || got.SlowestFiles != 3 {
+		t.Errorf("list stats = %+v, want 1 call reading 3 files", got)
+	}
+
+	if slow := s.Slow(time.Hour); len(slow) != 0 {
+		t.Errorf("Slow(1h) = %v, want none", slow)
+	}
+	if slow := s.Slow(time.Nanosecond); len(slow) == 0 {
+		t.Error("Slow(1ns) should report every operation")
+	}
+	if slow := s.Slow(0); slow != nil {
+		t.Errorf("Slow(0) = %v, want disabled", slow)
+	}
+}
@@ -0,0 +1,109 @@
+package objectstore
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Sentinel errors returned by Bucket implementations.
+var (
+	ErrNotExist           = errors.New("object does not exist")
+	ErrPreconditionFailed = errors.New("precondition failed")
+)
+
+// PutCondition makes a Put conditional on the current state of the object,
+// the primitive that lets many clients share a bucket without a server.
+type PutCondition struct {
+	// IfNoneMatch writes only if the object does not exist yet.
+	IfNoneMatch bool
+	// IfMatch writes only if the object's current ETag equals this value.
+	IfMatch string
+}
+
+// Bucket is the subset of an object store the Store needs: whole-object
+// reads and writes, conditional writes, and prefix listing.
+type Bucket interface {
+	// Get returns the object's content and ETag.
+	// Returns ErrNotExist if there is no object at key.
+	Get(ctx context.Context, key string) ([]byte, string, error)
+
+	// Put writes the object and returns its new ETag.
+	// Returns ErrPreconditionFailed if cond is not satisfied.
+	Put(ctx context.Context, key string, data []byte, cond PutCondition) (string, error)
+
+	// Delete removes the object. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+
+	// List returns the keys that start with prefix, sorted.
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// MemoryBucket is an in-process Bucket, used in tests and as a reference
+// for the conditional-write semantics real stores must provide.
+type MemoryBucket struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+// NewMemoryBucket creates an empty MemoryBucket.
+func NewMemoryBucket() *MemoryBucket {
+	return &MemoryBucket{objects: make(map[string][]byte)}
+}
+
+func etagOf(data []byte) string {
+	sum := md5.Sum(data)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// Get implements Bucket.
+func (b *MemoryBucket) Get(ctx context.Context, key string) ([]byte, string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	data, ok := b.objects[key]
+	if !ok {
+		return nil, "", ErrNotExist
+	}
+	return append([]byte(nil), data...), etagOf(data), nil
+}
+
+// Put implements Bucket.
+func (b *MemoryBucket) Put(ctx context.Context, key string, data []byte, cond PutCondition) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	current, exists := b.objects[key]
+	if cond.IfNoneMatch && exists {
+		return "", ErrPreconditionFailed
+	}
+	if cond.IfMatch != "" && (!exists || etagOf(current) != cond.IfMatch) {
+		return "", ErrPreconditionFailed
+	}
+	b.objects[key] = append([]byte(nil), data...)
+	return etagOf(data), nil
+}
+
+// Delete implements Bucket.
+func (b *MemoryBucket) Delete(ctx context.Context, key string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.objects, key)
+	return nil
+}
+
+// List implements Bucket.
+func (b *MemoryBucket) List(ctx context.Context, prefix string) ([]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var keys []string
+	for k := range b.objects {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
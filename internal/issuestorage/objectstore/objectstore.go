@@ -0,0 +1,377 @@
+// Package objectstore implements the IssueStore interface on top of an
+// S3-compatible object store, so a team can share one tracker without
+// running a server. Each issue is stored as a JSON object at
+// issues/<id>.json; concurrent writers are serialized with conditional
+// writes (If-None-Match on create, If-Match on modify) instead of locks.
+package objectstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"beads-lite/internal/idgen"
+	"beads-lite/internal/issuestorage"
+)
+
+// MaxIDRetries is the maximum number of random ID generation attempts before
+// returning an error. See filesystem.MaxIDRetries.
+const MaxIDRetries = 20
+
+// MaxModifyRetries is the number of times Modify re-reads and re-applies its
+// change after losing a race with another writer.
+const MaxModifyRetries = 10
+
+// IssuePrefix is the key prefix under which issue objects are stored.
+const IssuePrefix = "issues/"
+
+// errParse wraps the error for an issue object that exists but does not
+// decode. List skips such objects and Doctor reports them.
+var errParse = errors.New("parsing issue")
+
+// Store implements issuestorage.IssueStore over a Bucket.
+type Store struct {
+	bucket            Bucket
+	maxHierarchyDepth int
+	prefix            string // ID prefix (e.g., "bd-", "bl-")
+}
+
+// Option configures a Store instance.
+type Option func(*Store)
+
+// WithMaxHierarchyDepth sets the maximum hierarchy depth for child IDs.
+func WithMaxHierarchyDepth(n int) Option {
+	return func(s *Store) {
+		s.maxHierarchyDepth = n
+	}
+}
+
+// New creates a Store backed by bucket.
+// The prefix is prepended to generated IDs (e.g., "bd-", "bl-").
+func New(bucket Bucket, prefix string, opts ...Option) *Store {
+	s := &Store{
+		bucket:            bucket,
+		maxHierarchyDepth: idgen.DefaultMaxHierarchyDepth,
+		prefix:            prefix,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func issueKey(id string) string {
+	return IssuePrefix + id + ".json"
+}
+
+func idFromKey(key string) (string, bool) {
+	name, ok := strings.CutPrefix(key, IssuePrefix)
+	if !ok || !strings.HasSuffix(name, ".json") || strings.Contains(name, "/") {
+		return "", false
+	}
+	return strings.TrimSuffix(name, ".json"), true
+}
+
+func encodeIssue(issue *issuestorage.Issue) ([]byte, error) {
+	data, err := json.MarshalIndent(issue, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// Init checks that the bucket is reachable. Object stores have no
+// directories to create.
+func (s *Store) Init(ctx context.Context) error {
+	if _, err := s.bucket.List(ctx, IssuePrefix); err != nil {
+		return fmt.Errorf("accessing bucket: %w", err)
+	}
+	return nil
+}
+
+// listIDs returns the IDs of every stored issue.
+func (s *Store) listIDs(ctx context.Context) ([]string, error) {
+	keys, err := s.bucket.List(ctx, IssuePrefix)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, key := range keys {
+		if id, ok := idFromKey(key); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// Create creates a new issue and returns its ID.
+// If issue.ID is already set, that ID is used directly (for hierarchical child IDs).
+// Otherwise a random ID is generated, retrying on collision.
+func (s *Store) Create(ctx context.Context, issue *issuestorage.Issue, opts ...issuestorage.CreateOpts) (string, error) {
+	if issue.ID != "" {
+		if parentID, _, ok := idgen.ParseHierarchicalID(issue.ID); ok {
+			if err := idgen.CheckHierarchyDepth(parentID, s.maxHierarchyDepth); err != nil {
+				return "", err
+			}
+		}
+		if err := s.putNew(ctx, issue); err != nil {
+			if errors.Is(err, ErrPreconditionFailed) {
+				return "", fmt.Errorf("issue %s already exists", issue.ID)
+			}
+			return "", err
+		}
+		return issue.ID, nil
+	}
+
+	var createOpts issuestorage.CreateOpts
+	if len(opts) > 0 {
+		createOpts = opts[0]
+	}
+
+	// A key listing is cheap compared to reading issues, so the count is
+	// always fresh and UseCachedCount has nothing to skip.
+	ids, err := s.listIDs(ctx)
+	if err != nil {
+		return "", fmt.Errorf("counting issues for adaptive length: %w", err)
+	}
+	length := idgen.AdaptiveLength(len(ids))
+	effectivePrefix := idgen.BuildPrefix(s.prefix, createOpts.PrefixAddition)
+
+	for attempt := 0; attempt < MaxIDRetries; attempt++ {
+		id, err := idgen.RandomIDFrom(createOpts.IDSource(), effectivePrefix, length)
+		if err != nil {
+			return "", fmt.Errorf("generating random ID: %w", err)
+		}
+		issue.ID = id
+		err = s.putNew(ctx, issue)
+		if errors.Is(err, ErrPreconditionFailed) {
+			continue // Collision, try next random ID
+		}
+		if err != nil {
+			issue.ID = ""
+			return "", err
+		}
+		return id, nil
+	}
+	issue.ID = ""
+	return "", fmt.Errorf("failed to generate unique ID: %d retries exhausted at length %d", MaxIDRetries, length)
+}
+
+// putNew writes issue only if no object exists for its ID yet.
+func (s *Store) putNew(ctx context.Context, issue *issuestorage.Issue) error {
+	data, err := encodeIssue(issue)
+	if err != nil {
+		return fmt.Errorf("encoding issue: %w", err)
+	}
+	_, err = s.bucket.Put(ctx, issueKey(issue.ID), data, PutCondition{IfNoneMatch: true})
+	return err
+}
+
+// get reads an issue along with the ETag of the object it came from.
+func (s *Store) get(ctx context.Context, id string) (*issuestorage.Issue, string, error) {
+	data, etag, err := s.bucket.Get(ctx, issueKey(id))
+	if errors.Is(err, ErrNotExist) {
+		return nil, "", issuestorage.ErrNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	var issue issuestorage.Issue
+	if err := json.Unmarshal(data, &issue); err != nil {
+		return nil, "", fmt.Errorf("%w %s: %w", errParse, id, err)
+	}
+	return &issue, etag, nil
+}
+
+// Get retrieves an issue by ID.
+func (s *Store) Get(ctx context.Context, id string) (*issuestorage.Issue, error) {
+	issue, _, err := s.get(ctx, id)
+	return issue, err
+}
+
+// Modify reads an issue, applies fn to it, and writes it back only if no
+// one else has written it in between. On a lost race the issue is re-read
+// and fn applied again, so fn must not have side effects beyond mutating
+// the issue it is given.
+func (s *Store) Modify(ctx context.Context, id string, fn func(*issuestorage.Issue) error) error {
+	for attempt := 0; attempt < MaxModifyRetries; attempt++ {
+		issue, etag, err := s.get(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := fn(issue); err != nil {
+			return err
+		}
+		data, err := encodeIssue(issue)
+		if err != nil {
+			return fmt.Errorf("encoding issue: %w", err)
+		}
+		_, err = s.bucket.Put(ctx, issueKey(id), data, PutCondition{IfMatch: etag})
+		if errors.Is(err, ErrPreconditionFailed) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("writing issue %s: %w", id, err)
+		}
+		return nil
+	}
+	return fmt.Errorf("modifying %s: %w (gave up after %d concurrent writes)", id, issuestorage.ErrLockTimeout, MaxModifyRetries)
+}
+
+// Delete permanently removes an issue.
+func (s *Store) Delete(ctx context.Context, id string) error {
+	if _, _, err := s.bucket.Get(ctx, issueKey(id)); err != nil {
+		if errors.Is(err, ErrNotExist) {
+			return issuestorage.ErrNotFound
+		}
+		return err
+	}
+	return s.bucket.Delete(ctx, issueKey(id))
+}
+
+// location mirrors the filesystem backend's directory layout so List
+// returns the same issues for the same filter.
+func location(issue *issuestorage.Issue) string {
+	switch {
+	case issue.Status == issuestorage.StatusTombstone:
+		return "deleted"
+	case issue.Ephemeral:
+		return "ephemeral"
+	case issue.Status == issuestorage.StatusClosed:
+		return "closed"
+	default:
+		return "open"
+	}
+}
+
+// scannedLocations returns the locations a filter selects: open and
+// ephemeral issues by default, plus closed or deleted ones when those
+// statuses are requested.
+func scannedLocations(filter *issuestorage.ListFilter) map[string]bool {
+	if filter == nil || len(filter.Statuses) == 0 {
+		return map[string]bool{"open": true, "ephemeral": true}
+	}
+	locs := make(map[string]bool)
+	for _, st := range filter.Statuses {
+		switch st {
+		case issuestorage.StatusClosed:
+			locs["closed"] = true
+		case issuestorage.StatusTombstone:
+			locs["deleted"] = true
+		default:
+			locs["open"] = true
+			locs["ephemeral"] = true
+		}
+	}
+	return locs
+}
+
+// List returns all issues matching the filter.
+// Results are sorted by CreatedAt (oldest first), then by ID.
+func (s *Store) List(ctx context.Context, filter *issuestorage.ListFilter) ([]*issuestorage.Issue, error) {
+	ids, err := s.listIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	locs := scannedLocations(filter)
+
+	var issues []*issuestorage.Issue
+	for _, id := range ids {
+		issue, err := s.Get(ctx, id)
+		if errors.Is(err, issuestorage.ErrNotFound) || errors.Is(err, errParse) {
+			// Deleted since the listing, or unreadable; Doctor reports the latter.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if locs[location(issue)] && filter.Matches(issue) {
+			issues = append(issues, issue)
+		}
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		if !issues[i].CreatedAt.Equal(issues[j].CreatedAt) {
+			return issues[i].CreatedAt.Before(issues[j].CreatedAt)
+		}
+		return issues[i].ID < issues[j].ID
+	})
+	return issues, nil
+}
+
+// ListReady implements issuestorage.IssueStore.
+func (s *Store) ListReady(ctx context.Context, filter *issuestorage.ListFilter, cascade bool) ([]*issuestorage.Issue, error) {
+	return issuestorage.ListReady(ctx, s, filter, cascade)
+}
+
+// ListBlocked implements issuestorage.IssueStore.
+func (s *Store) ListBlocked(ctx context.Context, filter *issuestorage.ListFilter, cascade bool) ([]issuestorage.BlockedIssue, error) {
+	return issuestorage.ListBlocked(ctx, s, filter, cascade)
+}
+
+// GetNextChildID validates the parent exists, checks hierarchy depth limits,
+// scans the bucket for existing children, and returns the next child ID.
+// The returned ID is not reserved; Create fails if another writer takes it
+// first and the caller should retry.
+func (s *Store) GetNextChildID(ctx context.Context, parentID string) (string, error) {
+	if _, err := s.Get(ctx, parentID); err != nil {
+		if err == issuestorage.ErrNotFound {
+			return "", fmt.Errorf("parent %s: %w", parentID, issuestorage.ErrNotFound)
+		}
+		return "", fmt.Errorf("checking parent %s: %w", parentID, err)
+	}
+
+	if err := idgen.CheckHierarchyDepth(parentID, s.maxHierarchyDepth); err != nil {
+		return "", err
+	}
+
+	keys, err := s.bucket.List(ctx, IssuePrefix+parentID+".")
+	if err != nil {
+		return "", fmt.Errorf("scanning children of %s: %w", parentID, err)
+	}
+	maxChild := 0
+	for _, key := range keys {
+		id, ok := idFromKey(key)
+		if !ok {
+			continue
+		}
+		parent, childNum, ok := idgen.ParseHierarchicalID(id)
+		if ok && parent == parentID && childNum > maxChild {
+			maxChild = childNum
+		}
+	}
+
+	return idgen.ChildID(parentID, maxChild+1), nil
+}
+
+// Doctor reports objects that cannot be parsed or whose ID does not match
+// their key. These need manual attention, so fix has no effect.
+func (s *Store) Doctor(ctx context.Context, fix bool) ([]string, error) {
+	keys, err := s.bucket.List(ctx, IssuePrefix)
+	if err != nil {
+		return nil, err
+	}
+	var problems []string
+	for _, key := range keys {
+		id, ok := idFromKey(key)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unexpected object %s", key))
+			continue
+		}
+		issue, err := s.Get(ctx, id)
+		if err != nil {
+			if errors.Is(err, issuestorage.ErrNotFound) {
+				continue
+			}
+			problems = append(problems, fmt.Sprintf("%s: %v", key, err))
+			continue
+		}
+		if issue.ID != id {
+			problems = append(problems, fmt.Sprintf("%s: contains issue %s", key, issue.ID))
+		}
+	}
+	return problems, nil
+}
@@ -0,0 +1,189 @@
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestObjectStoreContract(t *testing.T) {
+	factory := func() issuestorage.IssueStore {
+		return New(NewMemoryBucket(), "bd-")
+	}
+	issuestorage.RunContractTests(t, factory)
+}
+
+func TestObjectStoreProperties(t *testing.T) {
+	factory := func() issuestorage.IssueStore {
+		return New(NewMemoryBucket(), "bd-")
+	}
+	issuestorage.RunPropertyTests(t, factory)
+}
+
+func TestModifyRetriesOnConcurrentWrites(t *testing.T) {
+	s := New(NewMemoryBucket(), "bd-")
+	ctx := context.Background()
+
+	id, err := s.Create(ctx, &issuestorage.Issue{Title: "Counter", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const writers = 8
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.Modify(ctx, id, func(issue *issuestorage.Issue) error {
+				issue.Labels = append(issue.Labels, "x")
+				return nil
+			}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, err := s.Get(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Labels) != writers {
+		t.Errorf("labels = %d, want %d (a concurrent write was lost)", len(got.Labels), writers)
+	}
+}
+
+func TestCreateExplicitIDConflict(t *testing.T) {
+	s := New(NewMemoryBucket(), "bd-")
+	ctx := context.Background()
+
+	issue := &issuestorage.Issue{ID: "bd-abc", Title: "First", Status: issuestorage.StatusOpen}
+	if _, err := s.Create(ctx, issue); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Create(ctx, &issuestorage.Issue{ID: "bd-abc", Title: "Second"}); err == nil {
+		t.Fatal("expected error creating duplicate ID")
+	}
+	got, err := s.Get(ctx, "bd-abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "First" {
+		t.Errorf("Title = %q, duplicate create overwrote the issue", got.Title)
+	}
+}
+
+func TestListMatchesFilesystemLayout(t *testing.T) {
+	s := New(NewMemoryBucket(), "bd-")
+	ctx := context.Background()
+	base := time.Now()
+
+	create := func(id string, status issuestorage.Status, ephemeral bool, offset time.Duration) {
+		t.Helper()
+		if _, err := s.Create(ctx, &issuestorage.Issue{
+			ID: id, Title: id, Status: status, Ephemeral: ephemeral,
+			CreatedAt: base.Add(offset), UpdatedAt: base.Add(offset),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	create("bd-open", issuestorage.StatusOpen, false, 0)
+	create("bd-closed", issuestorage.StatusClosed, false, time.Second)
+	create("bd-wisp", issuestorage.StatusOpen, true, 2*time.Second)
+	create("bd-gone", issuestorage.StatusTombstone, false, 3*time.Second)
+
+	tests := []struct {
+		name   string
+		filter *issuestorage.ListFilter
+		want   []string
+	}{
+		{"default", nil, []string{"bd-open", "bd-wisp"}},
+		{"closed", &issuestorage.ListFilter{Statuses: []issuestorage.Status{issuestorage.StatusClosed}}, []string{"bd-closed"}},
+		{"tombstone", &issuestorage.ListFilter{Statuses: []issuestorage.Status{issuestorage.StatusTombstone}}, []string{"bd-gone"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.List(ctx, tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []string
+			for _, issue := range got {
+				ids = append(ids, issue.ID)
+			}
+			if len(ids) != len(tt.want) {
+				t.Fatalf("got %v, want %v", ids, tt.want)
+			}
+			for i := range ids {
+				if ids[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", ids, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDoctorReportsBadObjects(t *testing.T) {
+	bucket := NewMemoryBucket()
+	s := New(bucket, "bd-")
+	ctx := context.Background()
+
+	bucket.Put(ctx, issueKey("bd-bad"), []byte("{not json"), PutCondition{})
+	bucket.Put(ctx, issueKey("bd-moved"), []byte(`{"id":"bd-other"}`), PutCondition{})
+	if _, err := s.Create(ctx, &issuestorage.Issue{Title: "Fine", Status: issuestorage.StatusOpen}); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := s.Doctor(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 2 {
+		t.Errorf("problems = %v, want 2", problems)
+	}
+}
+
+// failingBucket fails every Get of one key, as a bucket would on a network
+// or permission error.
+type failingBucket struct {
+	Bucket
+	key string
+}
+
+func (b *failingBucket) Get(ctx context.Context, key string) ([]byte, string, error) {
+	if key == b.key {
+		return nil, "", errors.New("connection reset")
+	}
+	return b.Bucket.Get(ctx, key)
+}
+
+func TestListReturnsBucketErrors(t *testing.T) {
+	bucket := NewMemoryBucket()
+	ctx := context.Background()
+	fine, err := New(bucket, "bd-").Create(ctx, &issuestorage.Issue{Title: "Fine", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bucket.Put(ctx, issueKey("bd-bad"), []byte("{not json"), PutCondition{})
+
+	// An unreadable object is skipped.
+	issues, err := New(bucket, "bd-").List(ctx, nil)
+	if err != nil {
+		t.Fatalf("List with an unreadable object: %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != fine {
+		t.Errorf("List = %v, want only %s", issues, fine)
+	}
+
+	// A failed read is returned rather than dropping the issue.
+	s := New(&failingBucket{Bucket: bucket, key: issueKey(fine)}, "bd-")
+	if _, err := s.List(ctx, nil); err == nil || !strings.Contains(err.Error(), "connection reset") {
+		t.Errorf("List with a failing Get = %v, want the bucket error", err)
+	}
+}
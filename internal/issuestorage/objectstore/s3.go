@@ -0,0 +1,325 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// Config keys for the object storage backend.
+const (
+	BucketConfigKey   = "storage.s3.bucket"
+	EndpointConfigKey = "storage.s3.endpoint"
+	RegionConfigKey   = "storage.s3.region"
+	PrefixConfigKey   = "storage.s3.prefix"
+)
+
+// DefaultRegion is used when storage.s3.region is unset.
+const DefaultRegion = "us-east-1"
+
+// S3Config describes an S3-compatible bucket. Any store that implements the
+// S3 API with conditional writes (If-Match and If-None-Match on PUT) works,
+// e.g. AWS S3, MinIO, Cloudflare R2.
+type S3Config struct {
+	Endpoint        string // e.g. https://s3.us-east-1.amazonaws.com; defaults from Region
+	Region          string
+	Bucket          string
+	Prefix          string // key prefix inside the bucket, e.g. "team-a/"
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// S3ConfigFromEnv builds an S3Config from config values read with get.
+// Credentials come from the standard AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables so they
+// never end up in a committed config file.
+func S3ConfigFromEnv(get func(key string) (string, bool)) (S3Config, error) {
+	value := func(key string) string {
+		v, _ := get(key)
+		return v
+	}
+	cfg := S3Config{
+		Endpoint:        value(EndpointConfigKey),
+		Region:          value(RegionConfigKey),
+		Bucket:          value(BucketConfigKey),
+		Prefix:          value(PrefixConfigKey),
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if cfg.Bucket == "" {
+		return cfg, fmt.Errorf("%s is required when %s is %s", BucketConfigKey, issuestorage.BackendConfigKey, issuestorage.BackendS3)
+	}
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return cfg, fmt.Errorf("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set to use the %s backend", issuestorage.BackendS3)
+	}
+	return cfg, nil
+}
+
+// S3Bucket is a Bucket backed by the S3 REST API, signed with AWS
+// Signature Version 4. Requests use path-style addressing
+// (<endpoint>/<bucket>/<key>), which all S3-compatible stores accept.
+type S3Bucket struct {
+	cfg    S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewS3Bucket creates an S3Bucket for cfg.
+func NewS3Bucket(cfg S3Config) *S3Bucket {
+	if cfg.Region == "" {
+		cfg.Region = DefaultRegion
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "https://s3." + cfg.Region + ".amazonaws.com"
+	}
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+	return &S3Bucket{cfg: cfg, client: http.DefaultClient, now: time.Now}
+}
+
+// objectURL returns the URL of key, including the configured key prefix.
+func (b *S3Bucket) objectURL(key string) string {
+	return b.cfg.Endpoint + "/" + b.cfg.Bucket + "/" + b.cfg.Prefix + key
+}
+
+func (b *S3Bucket) do(ctx context.Context, method, rawURL string, body []byte, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	signV4(req, body, b.cfg, b.now().UTC())
+	return b.client.Do(req)
+}
+
+// responseError converts a non-2xx response to an error, mapping the
+// statuses the Store relies on to sentinel errors.
+func responseError(resp *http.Response) error {
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return ErrNotExist
+	case http.StatusPreconditionFailed, http.StatusConflict:
+		// 409 is returned when a conditional write races another one.
+		return ErrPreconditionFailed
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("%s %s: %s: %s", resp.Request.Method, resp.Request.URL.Path, resp.Status, strings.TrimSpace(string(msg)))
+}
+
+// Get implements Bucket.
+func (b *S3Bucket) Get(ctx context.Context, key string) ([]byte, string, error) {
+	resp, err := b.do(ctx, http.MethodGet, b.objectURL(key), nil, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", responseError(resp)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, resp.Header.Get("ETag"), nil
+}
+
+// Put implements Bucket.
+func (b *S3Bucket) Put(ctx context.Context, key string, data []byte, cond PutCondition) (string, error) {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	if cond.IfNoneMatch {
+		header.Set("If-None-Match", "*")
+	}
+	if cond.IfMatch != "" {
+		header.Set("If-Match", cond.IfMatch)
+	}
+	resp, err := b.do(ctx, http.MethodPut, b.objectURL(key), data, header)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", responseError(resp)
+	}
+	return resp.Header.Get("ETag"), nil
+}
+
+// Delete implements Bucket.
+func (b *S3Bucket) Delete(ctx context.Context, key string) error {
+	resp, err := b.do(ctx, http.MethodDelete, b.objectURL(key), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if resp.StatusCode/100 != 2 {
+		return responseError(resp)
+	}
+	return nil
+}
+
+// listBucketResult is the subset of a ListObjectsV2 response we read.
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List implements Bucket, following continuation tokens until every key
+// has been returned.
+func (b *S3Bucket) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	token := ""
+	for {
+		q := url.Values{}
+		q.Set("list-type", "2")
+		q.Set("prefix", b.cfg.Prefix+prefix)
+		if token != "" {
+			q.Set("continuation-token", token)
+		}
+		resp, err := b.do(ctx, http.MethodGet, b.cfg.Endpoint+"/"+b.cfg.Bucket+"?"+q.Encode(), nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			err := responseError(resp)
+			resp.Body.Close()
+			if err == ErrNotExist {
+				return nil, fmt.Errorf("bucket %s does not exist", b.cfg.Bucket)
+			}
+			return nil, err
+		}
+		var result listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("parsing bucket listing: %w", err)
+		}
+		for _, c := range result.Contents {
+			keys = append(keys, strings.TrimPrefix(c.Key, b.cfg.Prefix))
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// signV4 adds AWS Signature Version 4 headers to req. Every header already
+// set on req is signed along with Host.
+func signV4(req *http.Request, body []byte, cfg S3Config, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if cfg.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", cfg.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(strings.Join(v, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path, false),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + cfg.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+cfg.SecretAccessKey), date)
+	key = hmacSHA256(key, cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		cfg.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func canonicalQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		vals := append([]string(nil), q[k]...)
+		sort.Strings(vals)
+		for _, v := range vals {
+			parts = append(parts, uriEncode(k, true)+"="+uriEncode(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode percent-encodes s as SigV4 requires: everything except
+// unreserved characters, and '/' too when encodeSlash is set.
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
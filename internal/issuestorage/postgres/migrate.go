@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// migrations are applied in order; migration i brings the schema to
+// version i+1. Append new migrations, never edit applied ones.
+var migrations = []string{
+	`CREATE TABLE issues (
+		id         text PRIMARY KEY,
+		status     text NOT NULL,
+		ephemeral  boolean NOT NULL DEFAULT false,
+		created_at timestamptz NOT NULL,
+		data       jsonb NOT NULL
+	);
+	CREATE INDEX issues_status_created_at ON issues (status, created_at, id);
+	CREATE INDEX issues_data ON issues USING gin (data jsonb_path_ops);`,
+}
+
+// migrationLockID is the advisory lock key that serializes concurrent
+// migrations from several clients starting at once.
+const migrationLockID = 0x62642d6c697465 // "bd-lite"
+
+// migrate applies pending migrations in a single transaction.
+func migrate(ctx context.Context, db *sql.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning migration: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, migrationLockID); err != nil {
+		return fmt.Errorf("acquiring migration lock: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version integer PRIMARY KEY, applied_at timestamptz NOT NULL DEFAULT now())`); err != nil {
+		return fmt.Errorf("creating schema_migrations: %w", err)
+	}
+	var version int
+	if err := tx.QueryRowContext(ctx, `SELECT coalesce(max(version), 0) FROM schema_migrations`).Scan(&version); err != nil {
+		return fmt.Errorf("reading schema version: %w", err)
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("database schema version %d is newer than this bd supports (%d); upgrade bd", version, len(migrations))
+	}
+	for i := version; i < len(migrations); i++ {
+		if _, err := tx.ExecContext(ctx, migrations[i]); err != nil {
+			return fmt.Errorf("applying migration %d: %w", i+1, err)
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version) VALUES ($1)`, i+1); err != nil {
+			return fmt.Errorf("recording migration %d: %w", i+1, err)
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,400 @@
+// Package postgres implements the IssueStore interface on PostgreSQL, for
+// multi-user deployments where flock on a shared disk is not enough.
+//
+// Each issue is one row: the full issue is kept in a JSONB column so new
+// fields need no migration, while status, ephemeral and created_at are
+// duplicated into plain columns for indexed filtering and ordering. Modify
+// takes a row lock (SELECT ... FOR UPDATE) for the duration of its
+// read-modify-write.
+//
+// The package uses database/sql and does not link a driver itself; the bd
+// binary registers github.com/lib/pq under DriverName in cmd/main.go.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"beads-lite/internal/idgen"
+	"beads-lite/internal/issuestorage"
+)
+
+// DriverName is the database/sql driver Open uses.
+const DriverName = "postgres"
+
+// Config keys for the postgres backend.
+const (
+	DSNConfigKey = "storage.postgres.dsn"
+)
+
+// MaxIDRetries is the maximum number of random ID generation attempts before
+// returning an error. See filesystem.MaxIDRetries.
+const MaxIDRetries = 20
+
+// Store implements issuestorage.IssueStore on a PostgreSQL database.
+type Store struct {
+	db                *sql.DB
+	maxHierarchyDepth int
+	prefix            string // ID prefix (e.g., "bd-", "bl-")
+}
+
+// Option configures a Store instance.
+type Option func(*Store)
+
+// WithMaxHierarchyDepth sets the maximum hierarchy depth for child IDs.
+func WithMaxHierarchyDepth(n int) Option {
+	return func(s *Store) {
+		s.maxHierarchyDepth = n
+	}
+}
+
+// New creates a Store using db.
+// The prefix is prepended to generated IDs (e.g., "bd-", "bl-").
+func New(db *sql.DB, prefix string, opts ...Option) *Store {
+	s := &Store{
+		db:                db,
+		maxHierarchyDepth: idgen.DefaultMaxHierarchyDepth,
+		prefix:            prefix,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Open connects to the database at dsn and returns a Store for it.
+func Open(dsn, prefix string, opts ...Option) (*Store, error) {
+	if !driverRegistered() {
+		return nil, fmt.Errorf("this build of bd has no %q database/sql driver; link one in (e.g. import _ \"github.com/lib/pq\")", DriverName)
+	}
+	db, err := sql.Open(DriverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening database: %w", err)
+	}
+	return New(db, prefix, opts...), nil
+}
+
+func driverRegistered() bool {
+	for _, d := range sql.Drivers() {
+		if d == DriverName {
+			return true
+		}
+	}
+	return false
+}
+
+// Init applies any pending schema migrations.
+func (s *Store) Init(ctx context.Context) error {
+	return migrate(ctx, s.db)
+}
+
+func encodeIssue(issue *issuestorage.Issue) ([]byte, error) {
+	return json.Marshal(issue)
+}
+
+func decodeIssue(data []byte) (*issuestorage.Issue, error) {
+	var issue issuestorage.Issue
+	if err := json.Unmarshal(data, &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// insert adds issue, returning false without error if its ID is taken.
+func (s *Store) insert(ctx context.Context, issue *issuestorage.Issue) (bool, error) {
+	data, err := encodeIssue(issue)
+	if err != nil {
+		return false, fmt.Errorf("encoding issue: %w", err)
+	}
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO issues (id, status, ephemeral, created_at, data)
+		 VALUES ($1, $2, $3, $4, $5)
+		 ON CONFLICT (id) DO NOTHING`,
+		issue.ID, string(issue.Status), issue.Ephemeral, issue.CreatedAt, data)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// Create creates a new issue and returns its ID.
+// If issue.ID is already set, that ID is used directly (for hierarchical child IDs).
+// Otherwise a random ID is generated, retrying on collision.
+func (s *Store) Create(ctx context.Context, issue *issuestorage.Issue, opts ...issuestorage.CreateOpts) (string, error) {
+	if issue.ID != "" {
+		if parentID, _, ok := idgen.ParseHierarchicalID(issue.ID); ok {
+			if err := idgen.CheckHierarchyDepth(parentID, s.maxHierarchyDepth); err != nil {
+				return "", err
+			}
+		}
+		ok, err := s.insert(ctx, issue)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return "", fmt.Errorf("issue %s already exists", issue.ID)
+		}
+		return issue.ID, nil
+	}
+
+	var createOpts issuestorage.CreateOpts
+	if len(opts) > 0 {
+		createOpts = opts[0]
+	}
+
+	// count(*) is answered from the primary key index, so UseCachedCount
+	// has nothing to skip.
+	var count int
+	if err := s.db.QueryRowContext(ctx, `SELECT count(*) FROM issues`).Scan(&count); err != nil {
+		return "", fmt.Errorf("counting issues for adaptive length: %w", err)
+	}
+	length := idgen.AdaptiveLength(count)
+	effectivePrefix := idgen.BuildPrefix(s.prefix, createOpts.PrefixAddition)
+
+	for attempt := 0; attempt < MaxIDRetries; attempt++ {
+		id, err := idgen.RandomIDFrom(createOpts.IDSource(), effectivePrefix, length)
+		if err != nil {
+			return "", fmt.Errorf("generating random ID: %w", err)
+		}
+		issue.ID = id
+		ok, err := s.insert(ctx, issue)
+		if err != nil {
+			issue.ID = ""
+			return "", err
+		}
+		if ok {
+			return id, nil
+		}
+	}
+	issue.ID = ""
+	return "", fmt.Errorf("failed to generate unique ID: %d retries exhausted at length %d", MaxIDRetries, length)
+}
+
+// Get retrieves an issue by ID.
+func (s *Store) Get(ctx context.Context, id string) (*issuestorage.Issue, error) {
+	var data []byte
+	err := s.db.QueryRowContext(ctx, `SELECT data FROM issues WHERE id = $1`, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, issuestorage.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	issue, err := decodeIssue(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing issue %s: %w", id, err)
+	}
+	return issue, nil
+}
+
+// Modify reads an issue under a row lock, applies fn to it, and writes it
+// back in the same transaction.
+func (s *Store) Modify(ctx context.Context, id string, fn func(*issuestorage.Issue) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var data []byte
+	err = tx.QueryRowContext(ctx, `SELECT data FROM issues WHERE id = $1 FOR UPDATE`, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return issuestorage.ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("locking issue %s: %w", id, err)
+	}
+	issue, err := decodeIssue(data)
+	if err != nil {
+		return fmt.Errorf("parsing issue %s: %w", id, err)
+	}
+
+	if err := fn(issue); err != nil {
+		return err
+	}
+
+	newData, err := encodeIssue(issue)
+	if err != nil {
+		return fmt.Errorf("encoding issue: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE issues SET status = $2, ephemeral = $3, created_at = $4, data = $5 WHERE id = $1`,
+		id, string(issue.Status), issue.Ephemeral, issue.CreatedAt, newData); err != nil {
+		return fmt.Errorf("writing issue %s: %w", id, err)
+	}
+	return tx.Commit()
+}
+
+// Delete permanently removes an issue.
+func (s *Store) Delete(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM issues WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return issuestorage.ErrNotFound
+	}
+	return nil
+}
+
+// listQuery returns the SQL and arguments selecting the candidate rows for
+// filter. Only the status selection is done in SQL; it matches the
+// filesystem backend, which lists open and ephemeral issues unless
+// statuses are requested. The remaining criteria are applied with
+// ListFilter.Matches.
+func listQuery(filter *issuestorage.ListFilter) (string, []any) {
+	const base = `SELECT data FROM issues`
+	const order = ` ORDER BY created_at, id`
+	if filter == nil || len(filter.Statuses) == 0 {
+		return base + ` WHERE (status NOT IN ('closed', 'tombstone') OR (ephemeral AND status <> 'tombstone'))` + order, nil
+	}
+	args := make([]any, len(filter.Statuses))
+	where := ` WHERE status IN (`
+	for i, st := range filter.Statuses {
+		if i > 0 {
+			where += ", "
+		}
+		where += fmt.Sprintf("$%d", i+1)
+		args[i] = string(st)
+	}
+	return base + where + ")" + order, args
+}
+
+// List returns all issues matching the filter.
+// Results are sorted by CreatedAt (oldest first), then by ID.
+func (s *Store) List(ctx context.Context, filter *issuestorage.ListFilter) ([]*issuestorage.Issue, error) {
+	query, args := listQuery(filter)
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var issues []*issuestorage.Issue
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		issue, err := decodeIssue(data)
+		if err != nil {
+			continue // reported by Doctor
+		}
+		if filter.Matches(issue) {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, rows.Err()
+}
+
+// ListReady implements issuestorage.IssueStore.
+func (s *Store) ListReady(ctx context.Context, filter *issuestorage.ListFilter, cascade bool) ([]*issuestorage.Issue, error) {
+	return issuestorage.ListReady(ctx, s, filter, cascade)
+}
+
+// ListBlocked implements issuestorage.IssueStore.
+func (s *Store) ListBlocked(ctx context.Context, filter *issuestorage.ListFilter, cascade bool) ([]issuestorage.BlockedIssue, error) {
+	return issuestorage.ListBlocked(ctx, s, filter, cascade)
+}
+
+// GetNextChildID validates the parent exists, checks hierarchy depth limits,
+// scans for existing children, and returns the next child ID.
+// The returned ID is not reserved; Create fails if another writer takes it
+// first and the caller should retry.
+func (s *Store) GetNextChildID(ctx context.Context, parentID string) (string, error) {
+	if _, err := s.Get(ctx, parentID); err != nil {
+		if err == issuestorage.ErrNotFound {
+			return "", fmt.Errorf("parent %s: %w", parentID, issuestorage.ErrNotFound)
+		}
+		return "", fmt.Errorf("checking parent %s: %w", parentID, err)
+	}
+
+	if err := idgen.CheckHierarchyDepth(parentID, s.maxHierarchyDepth); err != nil {
+		return "", err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT id FROM issues WHERE starts_with(id, $1)`, parentID+".")
+	if err != nil {
+		return "", fmt.Errorf("scanning children of %s: %w", parentID, err)
+	}
+	defer rows.Close()
+	maxChild := 0
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return "", err
+		}
+		parent, childNum, ok := idgen.ParseHierarchicalID(id)
+		if ok && parent == parentID && childNum > maxChild {
+			maxChild = childNum
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("scanning children of %s: %w", parentID, err)
+	}
+
+	return idgen.ChildID(parentID, maxChild+1), nil
+}
+
+// Doctor reports rows whose indexed columns disagree with the issue data,
+// e.g. after a manual UPDATE of the data column, and rows whose data does
+// not match their ID. With fix, the indexed columns are rewritten from the
+// data.
+func (s *Store) Doctor(ctx context.Context, fix bool) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, status, ephemeral, data FROM issues ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	var problems []string
+	var toFix []*issuestorage.Issue
+	for rows.Next() {
+		var (
+			id, status string
+			ephemeral  bool
+			data       []byte
+		)
+		if err := rows.Scan(&id, &status, &ephemeral, &data); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		issue, err := decodeIssue(data)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: unparseable data: %v", id, err))
+			continue
+		}
+		if issue.ID != id {
+			problems = append(problems, fmt.Sprintf("%s: data contains issue %s", id, issue.ID))
+			continue
+		}
+		if string(issue.Status) != status || issue.Ephemeral != ephemeral {
+			problems = append(problems, fmt.Sprintf("%s: indexed columns out of sync with data", id))
+			toFix = append(toFix, issue)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if fix {
+		for _, issue := range toFix {
+			if _, err := s.db.ExecContext(ctx,
+				`UPDATE issues SET status = $2, ephemeral = $3, created_at = $4 WHERE id = $1`,
+				issue.ID, string(issue.Status), issue.Ephemeral, issue.CreatedAt); err != nil {
+				return problems, fmt.Errorf("fixing %s: %w", issue.ID, err)
+			}
+		}
+	}
+	return problems, nil
+}
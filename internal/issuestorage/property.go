@@ -0,0 +1,599 @@
+package issuestorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"beads-lite/internal/idgen"
+)
+
+// Property test sizing: each run applies propertySteps random operations to
+// a fresh store. testing.Short runs fewer seeds.
+const (
+	propertyRuns      = 20
+	propertyRunsShort = 5
+	propertySteps     = 40
+)
+
+// PropertySeedEnv names the environment variable that replays a single
+// seed of RunPropertyTests, as reported by a failure.
+const PropertySeedEnv = "BD_TEST_PROPERTY_SEED"
+
+// RunPropertyTests runs a generative test suite against an IssueStore
+// implementation. Each run applies a seeded random sequence of operations
+// (create, create child, status changes, dependency edits, tombstone,
+// delete, lookups of missing issues) to both the store and an in-memory
+// model, and after every step checks that:
+//   - every issue reads back as modelled, and deleted issues are gone
+//   - dependencies are symmetric: a depends on b exactly when b lists a as
+//     a dependent, with the same type
+//   - listing by each status returns exactly the issues with that status,
+//     and an unfiltered list returns exactly the non-closed, non-tombstoned
+//     ones, so an issue is never stored in the wrong place for its status
+//   - ClosedAt is set exactly when an issue is closed
+//   - child IDs count up: GetNextChildID returns a number above every
+//     existing child, one more than the last it handed out
+//
+// It complements RunContractTests, which checks fixed examples, and should
+// be called with the same factory. Operations write both sides of each
+// dependency the way issueservice does. A failure reports the seed and the
+// operations leading to it; set BD_TEST_PROPERTY_SEED to replay one seed.
+func RunPropertyTests(t *testing.T, factory func() IssueStore) {
+	seeds := make([]int64, 0, propertyRuns)
+	if s := os.Getenv(PropertySeedEnv); s != "" {
+		seed, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			t.Fatalf("%s: invalid seed %q", PropertySeedEnv, s)
+		}
+		seeds = append(seeds, seed)
+	} else {
+		runs := propertyRuns
+		if testing.Short() {
+			runs = propertyRunsShort
+		}
+		for i := 1; i <= runs; i++ {
+			seeds = append(seeds, int64(i))
+		}
+	}
+
+	for _, seed := range seeds {
+		t.Run(fmt.Sprintf("seed=%d", seed), func(t *testing.T) {
+			runPropertySequence(t, factory(), seed)
+		})
+	}
+}
+
+// propIssue is the model of one stored issue. Dependents are derived from
+// the other issues' deps.
+type propIssue struct {
+	status   Status
+	priority Priority
+	labels   []string
+	parent   string
+	deps     map[string]DependencyType
+}
+
+// propModel is the expected state of the store.
+type propModel struct {
+	issues     map[string]*propIssue
+	deleted    map[string]bool
+	lastChild  map[string]int  // parent ID -> last child number handed out
+	childGone  map[string]bool // parent ID -> a child was deleted since
+	nextTitle  int
+	rng        *rand.Rand
+	operations []string
+}
+
+// propOp is one kind of random operation. It returns an error if the store
+// misbehaves.
+type propOp struct {
+	weight int
+	run    func(ctx context.Context, s IssueStore, m *propModel) error
+}
+
+var propOps = []propOp{
+	{3, propCreate},
+	{2, propCreateChild},
+	{3, propSetStatus},
+	{2, propAddDep},
+	{1, propRemoveDep},
+	{1, propRelabel},
+	{1, propTombstone},
+	{1, propDelete},
+	{1, propMissing},
+}
+
+// runPropertySequence applies propertySteps random operations to s,
+// checking invariants after each.
+func runPropertySequence(t *testing.T, s IssueStore, seed int64) {
+	ctx := context.Background()
+	if err := s.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	m := &propModel{
+		issues:    make(map[string]*propIssue),
+		deleted:   make(map[string]bool),
+		lastChild: make(map[string]int),
+		childGone: make(map[string]bool),
+		rng:       rand.New(rand.NewSource(seed)),
+	}
+	total := 0
+	for _, op := range propOps {
+		total += op.weight
+	}
+
+	fail := func(err error) {
+		t.Helper()
+		t.Fatalf("seed %d: %v\noperations (replay with %s=%d):\n  %s",
+			seed, err, PropertySeedEnv, seed, strings.Join(m.operations, "\n  "))
+	}
+	for step := 0; step < propertySteps; step++ {
+		n := m.rng.Intn(total)
+		for _, op := range propOps {
+			if n < op.weight {
+				if err := op.run(ctx, s, m); err != nil {
+					fail(err)
+				}
+				break
+			}
+			n -= op.weight
+		}
+		if err := checkPropertyInvariants(ctx, s, m); err != nil {
+			fail(err)
+		}
+	}
+}
+
+// record logs an operation for the failure report.
+func (m *propModel) record(format string, args ...any) {
+	m.operations = append(m.operations, fmt.Sprintf(format, args...))
+}
+
+// pick returns a random modelled issue ID, or "" if there are none.
+// Tombstoned issues are skipped unless includeTombstones is set.
+func (m *propModel) pick(includeTombstones bool) string {
+	var ids []string
+	for id, issue := range m.issues {
+		if includeTombstones || issue.status != StatusTombstone {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return ""
+	}
+	sort.Strings(ids)
+	return ids[m.rng.Intn(len(ids))]
+}
+
+// randomLabels returns up to two labels from a small fixed set.
+func (m *propModel) randomLabels() []string {
+	var labels []string
+	for _, l := range []string{"backend", "frontend", "urgent"} {
+		if m.rng.Intn(3) == 0 {
+			labels = append(labels, l)
+		}
+	}
+	return labels
+}
+
+// randomStatus returns a status users can set, excluding tombstone.
+func (m *propModel) randomStatus() Status {
+	return BuiltinStatuses[m.rng.Intn(len(BuiltinStatuses))]
+}
+
+// newIssue builds a stored issue from its model.
+func (m *propModel) newIssue(id string, p *propIssue) *Issue {
+	m.nextTitle++
+	now := time.Now()
+	issue := &Issue{
+		ID:        id,
+		Title:     fmt.Sprintf("Property issue %d", m.nextTitle),
+		Status:    p.status,
+		Priority:  p.priority,
+		Type:      TypeTask,
+		Labels:    p.labels,
+		Parent:    p.parent,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if p.status == StatusClosed {
+		issue.ClosedAt = &now
+	}
+	for dep, typ := range p.deps {
+		issue.Dependencies = append(issue.Dependencies, Dependency{ID: dep, Type: typ})
+	}
+	return issue
+}
+
+func propCreate(ctx context.Context, s IssueStore, m *propModel) error {
+	p := &propIssue{
+		status:   m.randomStatus(),
+		priority: Priority(m.rng.Intn(5)),
+		labels:   m.randomLabels(),
+		deps:     make(map[string]DependencyType),
+	}
+	id, err := s.Create(ctx, m.newIssue("", p))
+	m.record("create %s status=%s", id, p.status)
+	if err != nil {
+		return fmt.Errorf("create: %w", err)
+	}
+	if _, exists := m.issues[id]; exists || id == "" {
+		return fmt.Errorf("create returned ID %q, which is empty or already in use", id)
+	}
+	m.issues[id] = p
+	delete(m.deleted, id)
+	return nil
+}
+
+func propCreateChild(ctx context.Context, s IssueStore, m *propModel) error {
+	parent := m.pick(false)
+	if parent == "" {
+		return nil
+	}
+	childID, err := s.GetNextChildID(ctx, parent)
+	if errors.Is(err, idgen.ErrMaxDepthExceeded) && idgen.HierarchyDepth(parent) > 0 {
+		m.record("next child of %s: max depth", parent)
+		return nil
+	}
+	m.record("next child of %s: %s", parent, childID)
+	if err != nil {
+		return fmt.Errorf("GetNextChildID(%s): %w", parent, err)
+	}
+	gotParent, n, ok := idgen.ParseHierarchicalID(childID)
+	if !ok || gotParent != parent {
+		return fmt.Errorf("GetNextChildID(%s) = %q, not a child of the parent", parent, childID)
+	}
+	for id := range m.issues {
+		if p, num, ok := idgen.ParseHierarchicalID(id); ok && p == parent && num >= n {
+			return fmt.Errorf("GetNextChildID(%s) = %s, but child %s already exists", parent, childID, id)
+		}
+	}
+	if last := m.lastChild[parent]; !m.childGone[parent] && n != last+1 {
+		return fmt.Errorf("GetNextChildID(%s) = %s after handing out .%d with no child deleted since; want .%d", parent, childID, last, last+1)
+	}
+	m.lastChild[parent] = n
+	m.childGone[parent] = false
+
+	p := &propIssue{
+		status:   StatusOpen,
+		priority: Priority(m.rng.Intn(5)),
+		parent:   parent,
+		deps:     map[string]DependencyType{parent: DepTypeParentChild},
+	}
+	if _, err := s.Create(ctx, m.newIssue(childID, p)); err != nil {
+		return fmt.Errorf("create child %s: %w", childID, err)
+	}
+	m.issues[childID] = p
+	delete(m.deleted, childID)
+	if err := s.Modify(ctx, parent, func(issue *Issue) error {
+		issue.Dependents = append(issue.Dependents, Dependency{ID: childID, Type: DepTypeParentChild})
+		return nil
+	}); err != nil {
+		return fmt.Errorf("add child %s to %s: %w", childID, parent, err)
+	}
+	return nil
+}
+
+func propSetStatus(ctx context.Context, s IssueStore, m *propModel) error {
+	id := m.pick(false)
+	if id == "" {
+		return nil
+	}
+	status := m.randomStatus()
+	m.record("set %s status=%s", id, status)
+	if err := s.Modify(ctx, id, func(issue *Issue) error {
+		issue.Status = status
+		if status == StatusClosed {
+			now := time.Now()
+			issue.ClosedAt = &now
+		} else {
+			issue.ClosedAt = nil
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("set status of %s: %w", id, err)
+	}
+	m.issues[id].status = status
+	return nil
+}
+
+func propAddDep(ctx context.Context, s IssueStore, m *propModel) error {
+	from, to := m.pick(true), m.pick(true)
+	if from == "" || from == to {
+		return nil
+	}
+	if _, exists := m.issues[from].deps[to]; exists {
+		return nil
+	}
+	types := []DependencyType{DepTypeBlocks, DepTypeRelated, DepTypeTracks, DepTypeDiscoveredFrom}
+	typ := types[m.rng.Intn(len(types))]
+	m.record("dep add %s -> %s (%s)", from, to, typ)
+	if err := s.Modify(ctx, from, func(issue *Issue) error {
+		issue.Dependencies = append(issue.Dependencies, Dependency{ID: to, Type: typ})
+		return nil
+	}); err != nil {
+		return fmt.Errorf("add dependency to %s: %w", from, err)
+	}
+	if err := s.Modify(ctx, to, func(issue *Issue) error {
+		issue.Dependents = append(issue.Dependents, Dependency{ID: from, Type: typ})
+		return nil
+	}); err != nil {
+		return fmt.Errorf("add dependent to %s: %w", to, err)
+	}
+	m.issues[from].deps[to] = typ
+	return nil
+}
+
+func propRemoveDep(ctx context.Context, s IssueStore, m *propModel) error {
+	from := m.pick(true)
+	if from == "" {
+		return nil
+	}
+	var candidates []string
+	for dep, typ := range m.issues[from].deps {
+		if typ != DepTypeParentChild {
+			candidates = append(candidates, dep)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.Strings(candidates)
+	to := candidates[m.rng.Intn(len(candidates))]
+	m.record("dep remove %s -> %s", from, to)
+	if err := unlinkProp(ctx, s, from, to); err != nil {
+		return err
+	}
+	delete(m.issues[from].deps, to)
+	return nil
+}
+
+func propRelabel(ctx context.Context, s IssueStore, m *propModel) error {
+	id := m.pick(false)
+	if id == "" {
+		return nil
+	}
+	labels, priority := m.randomLabels(), Priority(m.rng.Intn(5))
+	m.record("relabel %s labels=%v priority=%d", id, labels, priority)
+	if err := s.Modify(ctx, id, func(issue *Issue) error {
+		issue.Labels = labels
+		issue.Priority = priority
+		return nil
+	}); err != nil {
+		return fmt.Errorf("relabel %s: %w", id, err)
+	}
+	m.issues[id].labels = labels
+	m.issues[id].priority = priority
+	return nil
+}
+
+func propTombstone(ctx context.Context, s IssueStore, m *propModel) error {
+	id := m.pick(false)
+	if id == "" {
+		return nil
+	}
+	m.record("tombstone %s", id)
+	if err := s.Modify(ctx, id, func(issue *Issue) error {
+		now := time.Now()
+		issue.OriginalType = issue.Type
+		issue.Status = StatusTombstone
+		issue.ClosedAt = nil
+		issue.DeletedAt = &now
+		return nil
+	}); err != nil {
+		return fmt.Errorf("tombstone %s: %w", id, err)
+	}
+	m.issues[id].status = StatusTombstone
+	return nil
+}
+
+// propDelete hard-deletes an issue with no children, first removing it
+// from the other side of each of its dependencies.
+func propDelete(ctx context.Context, s IssueStore, m *propModel) error {
+	id := m.pick(true)
+	if id == "" {
+		return nil
+	}
+	for _, other := range m.issues {
+		if other.parent == id {
+			return nil
+		}
+	}
+	m.record("delete %s", id)
+	for dep := range m.issues[id].deps {
+		if err := unlinkProp(ctx, s, id, dep); err != nil {
+			return err
+		}
+	}
+	for otherID, other := range m.issues {
+		if _, ok := other.deps[id]; ok {
+			if err := unlinkProp(ctx, s, otherID, id); err != nil {
+				return err
+			}
+			delete(other.deps, id)
+		}
+	}
+	if err := s.Delete(ctx, id); err != nil {
+		return fmt.Errorf("delete %s: %w", id, err)
+	}
+	if parent := m.issues[id].parent; parent != "" {
+		m.childGone[parent] = true
+	}
+	delete(m.issues, id)
+	m.deleted[id] = true
+	return nil
+}
+
+// propMissing checks that every operation on an issue that doesn't exist
+// reports ErrNotFound.
+func propMissing(ctx context.Context, s IssueStore, m *propModel) error {
+	id := "bd-missing"
+	var gone []string
+	for d := range m.deleted {
+		gone = append(gone, d)
+	}
+	if len(gone) > 0 {
+		sort.Strings(gone)
+		id = gone[m.rng.Intn(len(gone))]
+	}
+	m.record("lookup missing %s", id)
+	if _, err := s.Get(ctx, id); !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("Get(%s) of missing issue: got %v, want ErrNotFound", id, err)
+	}
+	if err := s.Modify(ctx, id, func(*Issue) error { return nil }); !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("Modify(%s) of missing issue: got %v, want ErrNotFound", id, err)
+	}
+	if err := s.Delete(ctx, id); !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("Delete(%s) of missing issue: got %v, want ErrNotFound", id, err)
+	}
+	if _, err := s.GetNextChildID(ctx, id); !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("GetNextChildID(%s) of missing issue: got %v, want ErrNotFound", id, err)
+	}
+	return nil
+}
+
+// unlinkProp removes the dependency of from on to from both issues.
+func unlinkProp(ctx context.Context, s IssueStore, from, to string) error {
+	drop := func(deps []Dependency, id string) []Dependency {
+		return slices.DeleteFunc(deps, func(d Dependency) bool { return d.ID == id })
+	}
+	if err := s.Modify(ctx, from, func(issue *Issue) error {
+		issue.Dependencies = drop(issue.Dependencies, to)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("remove dependency from %s: %w", from, err)
+	}
+	if err := s.Modify(ctx, to, func(issue *Issue) error {
+		issue.Dependents = drop(issue.Dependents, from)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("remove dependent from %s: %w", to, err)
+	}
+	return nil
+}
+
+// checkPropertyInvariants compares the store against the model.
+func checkPropertyInvariants(ctx context.Context, s IssueStore, m *propModel) error {
+	stored := make(map[string]*Issue, len(m.issues))
+	for id := range m.issues {
+		issue, err := s.Get(ctx, id)
+		if err != nil {
+			return fmt.Errorf("Get(%s): %w", id, err)
+		}
+		stored[id] = issue
+	}
+	for id := range m.deleted {
+		if _, err := s.Get(ctx, id); !errors.Is(err, ErrNotFound) {
+			return fmt.Errorf("deleted issue %s: Get returned %v, want ErrNotFound", id, err)
+		}
+	}
+
+	// Dependency symmetry, checked on what the store returns.
+	for id, issue := range stored {
+		for _, dep := range issue.Dependencies {
+			other, ok := stored[dep.ID]
+			if !ok {
+				return fmt.Errorf("dependency symmetry: %s depends on %s, which does not exist", id, dep.ID)
+			}
+			if !slices.Contains(other.Dependents, Dependency{ID: id, Type: dep.Type}) {
+				return fmt.Errorf("dependency symmetry: %s depends on %s (%s), but %s does not list it as a dependent", id, dep.ID, dep.Type, dep.ID)
+			}
+		}
+		for _, dep := range issue.Dependents {
+			other, ok := stored[dep.ID]
+			if !ok {
+				return fmt.Errorf("dependency symmetry: %s lists dependent %s, which does not exist", id, dep.ID)
+			}
+			if !slices.Contains(other.Dependencies, Dependency{ID: id, Type: dep.Type}) {
+				return fmt.Errorf("dependency symmetry: %s lists dependent %s (%s), but %s does not depend on it", id, dep.ID, dep.Type, dep.ID)
+			}
+		}
+	}
+
+	// Each issue reads back as modelled.
+	for id, want := range m.issues {
+		got := stored[id]
+		if got.Status != want.status {
+			return fmt.Errorf("%s: status %q, want %q", id, got.Status, want.status)
+		}
+		if got.Priority != want.priority {
+			return fmt.Errorf("%s: priority %d, want %d", id, got.Priority, want.priority)
+		}
+		if got.Parent != want.parent {
+			return fmt.Errorf("%s: parent %q, want %q", id, got.Parent, want.parent)
+		}
+		if !slices.Equal(sortedCopy(got.Labels), sortedCopy(want.labels)) {
+			return fmt.Errorf("%s: labels %v, want %v", id, got.Labels, want.labels)
+		}
+		if (got.ClosedAt != nil) != (got.Status == StatusClosed) {
+			return fmt.Errorf("%s: status %q with closed_at %v", id, got.Status, got.ClosedAt)
+		}
+		if len(got.Dependencies) != len(want.deps) {
+			return fmt.Errorf("%s: %d dependencies, want %d", id, len(got.Dependencies), len(want.deps))
+		}
+		for _, dep := range got.Dependencies {
+			if want.deps[dep.ID] != dep.Type {
+				return fmt.Errorf("%s: unexpected dependency %s (%s)", id, dep.ID, dep.Type)
+			}
+		}
+	}
+
+	// Listing agrees with each issue's status.
+	for _, status := range append(slices.Clone(BuiltinStatuses), StatusTombstone) {
+		listed, err := s.List(ctx, &ListFilter{Statuses: []Status{status}})
+		if err != nil {
+			return fmt.Errorf("List(status=%s): %w", status, err)
+		}
+		if err := checkListed(m, listed, fmt.Sprintf("List(status=%s)", status), func(st Status) bool { return st == status }); err != nil {
+			return err
+		}
+	}
+	listed, err := s.List(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("List(nil): %w", err)
+	}
+	return checkListed(m, listed, "List(nil)", func(st Status) bool {
+		return st != StatusClosed && st != StatusTombstone
+	})
+}
+
+// checkListed verifies that listed holds exactly the modelled issues whose
+// status satisfies want, each once, with a matching status.
+func checkListed(m *propModel, listed []*Issue, what string, want func(Status) bool) error {
+	seen := make(map[string]bool, len(listed))
+	for _, issue := range listed {
+		if seen[issue.ID] {
+			return fmt.Errorf("%s returned %s twice", what, issue.ID)
+		}
+		seen[issue.ID] = true
+		modelled, ok := m.issues[issue.ID]
+		if !ok {
+			return fmt.Errorf("%s returned %s, which should not exist", what, issue.ID)
+		}
+		if !want(modelled.status) || issue.Status != modelled.status {
+			return fmt.Errorf("%s returned %s with status %q (modelled %q)", what, issue.ID, issue.Status, modelled.status)
+		}
+	}
+	for id, issue := range m.issues {
+		if want(issue.status) && !seen[id] {
+			return fmt.Errorf("%s is missing %s (status %q)", what, id, issue.status)
+		}
+	}
+	return nil
+}
+
+// sortedCopy returns a sorted copy of ss, treating nil and empty alike.
+func sortedCopy(ss []string) []string {
+	out := append([]string{}, ss...)
+	sort.Strings(out)
+	return out
+}
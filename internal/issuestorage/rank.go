@@ -0,0 +1,65 @@
+package issuestorage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// rankDigits are the digits of a rank key, in ascending order.
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// RankBetween returns a rank key that sorts strictly between a and b,
+// where "" for a means "before everything" and "" for b means "after
+// everything". Keys are base-36 fractions compared as strings; generated
+// keys never end in "0", so there is always room for another key between
+// any two of them.
+func RankBetween(a, b string) (string, error) {
+	if err := validateRank(a); err != nil {
+		return "", err
+	}
+	if err := validateRank(b); err != nil {
+		return "", err
+	}
+	if b != "" && a >= b {
+		return "", fmt.Errorf("no rank between %q and %q", a, b)
+	}
+
+	digit := func(s string, i int) int {
+		if i < len(s) {
+			return strings.IndexByte(rankDigits, s[i])
+		}
+		return 0
+	}
+	var out []byte
+	bounded := b != ""
+	for i := 0; ; i++ {
+		lo, hi := digit(a, i), len(rankDigits)
+		if bounded {
+			hi = digit(b, i)
+		}
+		switch {
+		case lo == hi:
+			out = append(out, rankDigits[lo])
+		case hi-lo > 1:
+			return string(append(out, rankDigits[(lo+hi)/2])), nil
+		default:
+			// No digit fits between lo and hi here: keep lo and look for
+			// a key above the rest of a, with b no longer a constraint.
+			out = append(out, rankDigits[lo])
+			bounded = false
+		}
+	}
+}
+
+// validateRank reports whether s is usable as a rank key.
+func validateRank(s string) error {
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(rankDigits, s[i]) < 0 {
+			return fmt.Errorf("invalid rank %q: keys use only 0-9 and a-z", s)
+		}
+	}
+	if strings.HasSuffix(s, "0") {
+		return fmt.Errorf("invalid rank %q: keys must not end in 0", s)
+	}
+	return nil
+}
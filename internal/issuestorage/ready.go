@@ -0,0 +1,127 @@
+package issuestorage
+
+import (
+	"context"
+	"fmt"
+)
+
+// BlockedIssue is an issue found by ListBlocked along with what blocks it.
+type BlockedIssue struct {
+	*Issue
+	Blockers *Blockers
+}
+
+// ListReady implements IssueStore.ListReady on top of s's List and Get,
+// for backends that keep no index of blocking dependencies. Results are in
+// List order.
+func ListReady(ctx context.Context, s IssueStore, filter *ListFilter, cascade bool) ([]*Issue, error) {
+	f := ReadyFilter(filter)
+	issues, blockers, err := listWithBlockers(ctx, s, &f, cascade)
+	if err != nil {
+		return nil, err
+	}
+	return ReadyIssues(issues, blockers), nil
+}
+
+// ListBlocked implements IssueStore.ListBlocked on top of s's List and
+// Get, for backends that keep no index of blocking dependencies. Results
+// are in List order.
+func ListBlocked(ctx context.Context, s IssueStore, filter *ListFilter, cascade bool) ([]BlockedIssue, error) {
+	f := BlockedFilter(filter)
+	issues, blockers, err := listWithBlockers(ctx, s, &f, cascade)
+	if err != nil {
+		return nil, err
+	}
+	return BlockedIssues(issues, blockers), nil
+}
+
+// ReadyFilter returns a copy of filter selecting open issues, the only
+// ones that can be ready.
+func ReadyFilter(filter *ListFilter) ListFilter {
+	f := ListFilter{}
+	if filter != nil {
+		f = *filter
+	}
+	f.Statuses = []Status{StatusOpen}
+	return f
+}
+
+// BlockedFilter returns a copy of filter, selecting open issues if it
+// names no statuses, as ListBlocked does.
+func BlockedFilter(filter *ListFilter) ListFilter {
+	f := ListFilter{}
+	if filter != nil {
+		f = *filter
+	}
+	if len(f.Statuses) == 0 {
+		f.Statuses = []Status{StatusOpen}
+	}
+	return f
+}
+
+// ReadyIssues returns the issues, listed as open, that are not ephemeral
+// and have no blockers, in order.
+func ReadyIssues(issues []*Issue, blockers map[string]*Blockers) []*Issue {
+	var ready []*Issue
+	for _, issue := range issues {
+		if !issue.Ephemeral && !blockers[issue.ID].HasBlockers() {
+			ready = append(ready, issue)
+		}
+	}
+	return ready
+}
+
+// BlockedIssues returns the issues that have blockers, in order, paired
+// with them.
+func BlockedIssues(issues []*Issue, blockers map[string]*Blockers) []BlockedIssue {
+	var blocked []BlockedIssue
+	for _, issue := range issues {
+		if b := blockers[issue.ID]; b.HasBlockers() {
+			blocked = append(blocked, BlockedIssue{Issue: issue, Blockers: b})
+		}
+	}
+	return blocked
+}
+
+// listWithBlockers lists the issues matching filter and resolves their
+// blockers against one listing of closed issues. Ancestors are taken from
+// the listed issues where possible, so only those outside the filter cost
+// a Get; stores that cache List results serve both listings from memory.
+func listWithBlockers(ctx context.Context, s IssueStore, filter *ListFilter, cascade bool) ([]*Issue, map[string]*Blockers, error) {
+	issues, err := s.List(ctx, filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing issues: %w", err)
+	}
+	closedIssues, err := s.List(ctx, &ListFilter{Statuses: []Status{StatusClosed}})
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing closed issues: %w", err)
+	}
+	closed := make(map[string]bool, len(closedIssues))
+	for _, issue := range closedIssues {
+		closed[issue.ID] = true
+	}
+
+	listed := listedGetter{issues: make(map[string]*Issue, len(issues)), store: s}
+	for _, issue := range issues {
+		listed.issues[issue.ID] = issue
+	}
+	blockers, err := ResolveBlockers(ctx, listed, issues, closed, cascade)
+	if err != nil {
+		return nil, nil, err
+	}
+	return issues, blockers, nil
+}
+
+// listedGetter serves Gets from already listed issues, falling back to the
+// store for the rest.
+type listedGetter struct {
+	issues map[string]*Issue
+	store  IssueGetter
+}
+
+func (g listedGetter) Get(ctx context.Context, id string) (*Issue, error) {
+	if issue, ok := g.issues[id]; ok {
+		return issue, nil
+	}
+	return g.store.Get(ctx, id)
+}
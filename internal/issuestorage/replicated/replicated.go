@@ -0,0 +1,297 @@
+// Package replicated implements an IssueStore that writes to a primary
+// store and mirrors every change to one or more secondaries in the
+// background, e.g. a local filesystem store mirrored to a shared bucket.
+//
+// Reads are served by the primary. Mirroring copies the primary's current
+// state of each changed issue rather than replaying operations, so a
+// mirror write is idempotent and a missed one is repaired by Reconcile.
+package replicated
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"beads-lite/internal/issuestorage"
+)
+
+// MirrorsConfigKey lists the mirror targets, comma-separated.
+const MirrorsConfigKey = "storage.mirrors"
+
+// QueueSize bounds the number of pending mirror writes per secondary.
+// Writers block when a secondary falls this far behind.
+const QueueSize = 256
+
+// Secondary is a mirror target with a name used in reports.
+type Secondary struct {
+	Name  string
+	Store issuestorage.IssueStore
+}
+
+// Store implements issuestorage.IssueStore over a primary and its mirrors.
+type Store struct {
+	primary issuestorage.IssueStore
+	mirrors []*mirror
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+type mirror struct {
+	Secondary
+	queue chan string
+	done  chan struct{}
+
+	mu     sync.Mutex
+	failed map[string]error // issue ID → last mirror error
+}
+
+// New creates a Store and starts one mirroring goroutine per secondary.
+// Call Close to wait for pending mirror writes before exiting.
+func New(primary issuestorage.IssueStore, secondaries ...Secondary) *Store {
+	s := &Store{primary: primary}
+	for _, sec := range secondaries {
+		m := &mirror{
+			Secondary: sec,
+			queue:     make(chan string, QueueSize),
+			done:      make(chan struct{}),
+			failed:    make(map[string]error),
+		}
+		s.mirrors = append(s.mirrors, m)
+		go s.run(m)
+	}
+	return s
+}
+
+func (s *Store) run(m *mirror) {
+	defer close(m.done)
+	ctx := context.Background()
+	for id := range m.queue {
+		err := copyIssue(ctx, s.primary, m.Store, id)
+		m.mu.Lock()
+		if err != nil {
+			m.failed[id] = err
+		} else {
+			delete(m.failed, id)
+		}
+		m.mu.Unlock()
+	}
+}
+
+// enqueue schedules id to be mirrored to every secondary.
+func (s *Store) enqueue(id string) {
+	for _, m := range s.mirrors {
+		m.queue <- id
+	}
+}
+
+// Close waits for pending mirror writes and reports any that failed.
+// The Store must not be written to after Close.
+func (s *Store) Close() error {
+	s.closeOnce.Do(func() { s.closeErr = s.drain() })
+	return s.closeErr
+}
+
+func (s *Store) drain() error {
+	var errs []error
+	for _, m := range s.mirrors {
+		close(m.queue)
+		<-m.done
+		if n := len(m.failed); n > 0 {
+			ids := make([]string, 0, n)
+			for id := range m.failed {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+			errs = append(errs, fmt.Errorf("mirror %s: %d issue(s) not mirrored (%s: %v); run bd reconcile --fix",
+				m.Name, n, ids[0], m.failed[ids[0]]))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// copyIssue makes dst's copy of id match src, deleting it from dst if src
+// no longer has it.
+func copyIssue(ctx context.Context, src, dst issuestorage.IssueStore, id string) error {
+	issue, err := src.Get(ctx, id)
+	if errors.Is(err, issuestorage.ErrNotFound) {
+		if err := dst.Delete(ctx, id); err != nil && !errors.Is(err, issuestorage.ErrNotFound) {
+			return err
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	err = dst.Modify(ctx, id, func(i *issuestorage.Issue) error {
+		*i = *issue
+		return nil
+	})
+	if errors.Is(err, issuestorage.ErrNotFound) {
+		_, err = dst.Create(ctx, issue)
+	}
+	return err
+}
+
+// Create creates the issue in the primary and mirrors it.
+func (s *Store) Create(ctx context.Context, issue *issuestorage.Issue, opts ...issuestorage.CreateOpts) (string, error) {
+	id, err := s.primary.Create(ctx, issue, opts...)
+	if err != nil {
+		return "", err
+	}
+	s.enqueue(id)
+	return id, nil
+}
+
+// Get retrieves an issue from the primary.
+func (s *Store) Get(ctx context.Context, id string) (*issuestorage.Issue, error) {
+	return s.primary.Get(ctx, id)
+}
+
+// Modify modifies the issue in the primary and mirrors the result.
+func (s *Store) Modify(ctx context.Context, id string, fn func(*issuestorage.Issue) error) error {
+	if err := s.primary.Modify(ctx, id, fn); err != nil {
+		return err
+	}
+	s.enqueue(id)
+	return nil
+}
+
+// Delete deletes the issue from the primary and its mirrors.
+func (s *Store) Delete(ctx context.Context, id string) error {
+	if err := s.primary.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.enqueue(id)
+	return nil
+}
+
+// List lists issues from the primary.
+func (s *Store) List(ctx context.Context, filter *issuestorage.ListFilter) ([]*issuestorage.Issue, error) {
+	return s.primary.List(ctx, filter)
+}
+
+// ListReady lists from the primary.
+func (s *Store) ListReady(ctx context.Context, filter *issuestorage.ListFilter, cascade bool) ([]*issuestorage.Issue, error) {
+	return s.primary.ListReady(ctx, filter, cascade)
+}
+
+// ListBlocked lists from the primary.
+func (s *Store) ListBlocked(ctx context.Context, filter *issuestorage.ListFilter, cascade bool) ([]issuestorage.BlockedIssue, error) {
+	return s.primary.ListBlocked(ctx, filter, cascade)
+}
+
+// GetNextChildID allocates child IDs from the primary.
+func (s *Store) GetNextChildID(ctx context.Context, parentID string) (string, error) {
+	return s.primary.GetNextChildID(ctx, parentID)
+}
+
+// Init initializes the primary and every secondary.
+func (s *Store) Init(ctx context.Context) error {
+	if err := s.primary.Init(ctx); err != nil {
+		return err
+	}
+	for _, m := range s.mirrors {
+		if err := m.Store.Init(ctx); err != nil {
+			return fmt.Errorf("initializing mirror %s: %w", m.Name, err)
+		}
+	}
+	return nil
+}
+
+// Doctor checks the primary. Use Reconcile to compare the mirrors.
+func (s *Store) Doctor(ctx context.Context, fix bool) ([]string, error) {
+	return s.primary.Doctor(ctx, fix)
+}
+
+// listEverything returns every issue in store, whatever its status, by ID.
+func listEverything(ctx context.Context, store issuestorage.IssueStore) (map[string]*issuestorage.Issue, error) {
+	all := make(map[string]*issuestorage.Issue)
+	for _, filter := range []*issuestorage.ListFilter{
+		nil,
+		{Statuses: []issuestorage.Status{issuestorage.StatusClosed}},
+		{Statuses: []issuestorage.Status{issuestorage.StatusTombstone}},
+	} {
+		issues, err := store.List(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			all[issue.ID] = issue
+		}
+	}
+	return all, nil
+}
+
+// Difference is one issue that differs between the primary and a mirror.
+type Difference struct {
+	Mirror string
+	ID     string
+	Kind   string // "missing", "extra" or "changed"
+}
+
+func (d Difference) String() string {
+	switch d.Kind {
+	case "missing":
+		return fmt.Sprintf("%s: %s is missing", d.Mirror, d.ID)
+	case "extra":
+		return fmt.Sprintf("%s: %s is not in the primary", d.Mirror, d.ID)
+	default:
+		return fmt.Sprintf("%s: %s differs from the primary", d.Mirror, d.ID)
+	}
+}
+
+// Reconcile compares every mirror with the primary and returns the issues
+// that differ, sorted by mirror then ID. With fix, each difference is
+// repaired by copying the primary's state to the mirror.
+func (s *Store) Reconcile(ctx context.Context, fix bool) ([]Difference, error) {
+	want, err := listEverything(ctx, s.primary)
+	if err != nil {
+		return nil, fmt.Errorf("listing primary: %w", err)
+	}
+
+	var diffs []Difference
+	for _, m := range s.mirrors {
+		have, err := listEverything(ctx, m.Store)
+		if err != nil {
+			return diffs, fmt.Errorf("listing mirror %s: %w", m.Name, err)
+		}
+		var found []Difference
+		for id, issue := range want {
+			other, ok := have[id]
+			switch {
+			case !ok:
+				found = append(found, Difference{m.Name, id, "missing"})
+			case !sameIssue(issue, other):
+				found = append(found, Difference{m.Name, id, "changed"})
+			}
+		}
+		for id := range have {
+			if _, ok := want[id]; !ok {
+				found = append(found, Difference{m.Name, id, "extra"})
+			}
+		}
+		sort.Slice(found, func(i, j int) bool { return found[i].ID < found[j].ID })
+
+		if fix {
+			for _, d := range found {
+				if err := copyIssue(ctx, s.primary, m.Store, d.ID); err != nil {
+					return append(diffs, found...), fmt.Errorf("repairing %s on %s: %w", d.ID, m.Name, err)
+				}
+			}
+		}
+		diffs = append(diffs, found...)
+	}
+	return diffs, nil
+}
+
+// sameIssue compares two issues by their stored representation.
+func sameIssue(a, b *issuestorage.Issue) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(ja, jb)
+}
@@ -0,0 +1,130 @@
+package replicated
+
+import (
+	"context"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/issuestorage/filesystem"
+)
+
+func TestReplicatedContract(t *testing.T) {
+	factory := func() issuestorage.IssueStore {
+		s := New(filesystem.New(t.TempDir(), "bd-"), Secondary{Name: "mirror", Store: filesystem.New(t.TempDir(), "bd-")})
+		t.Cleanup(func() { s.Close() })
+		return s
+	}
+	issuestorage.RunContractTests(t, factory)
+}
+
+func TestReplicatedProperties(t *testing.T) {
+	factory := func() issuestorage.IssueStore {
+		s := New(filesystem.New(t.TempDir(), "bd-"), Secondary{Name: "mirror", Store: filesystem.New(t.TempDir(), "bd-")})
+		t.Cleanup(func() { s.Close() })
+		return s
+	}
+	issuestorage.RunPropertyTests(t, factory)
+}
+
+func setup(t *testing.T) (*Store, issuestorage.IssueStore) {
+	t.Helper()
+	ctx := context.Background()
+	primary := filesystem.New(t.TempDir(), "bd-")
+	secondary := filesystem.New(t.TempDir(), "bd-")
+	s := New(primary, Secondary{Name: "mirror", Store: secondary})
+	if err := s.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+	return s, secondary
+}
+
+func TestWritesAreMirrored(t *testing.T) {
+	s, secondary := setup(t)
+	ctx := context.Background()
+
+	keep, err := s.Create(ctx, &issuestorage.Issue{Title: "Keep", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gone, err := s.Create(ctx, &issuestorage.Issue{Title: "Gone", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Modify(ctx, keep, func(i *issuestorage.Issue) error {
+		i.Status = issuestorage.StatusClosed
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete(ctx, gone); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	got, err := secondary.Get(ctx, keep)
+	if err != nil {
+		t.Fatalf("mirrored issue: %v", err)
+	}
+	if got.Status != issuestorage.StatusClosed {
+		t.Errorf("mirrored status = %q, want closed", got.Status)
+	}
+	if _, err := secondary.Get(ctx, gone); err != issuestorage.ErrNotFound {
+		t.Errorf("deleted issue on mirror: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	s, secondary := setup(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	id, err := s.Create(ctx, &issuestorage.Issue{Title: "Original", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Let the mirror catch up, then diverge it behind the wrapper's back.
+	s.Close()
+	s = New(s.primary, Secondary{Name: "mirror", Store: secondary})
+	defer s.Close()
+	if err := secondary.Modify(ctx, id, func(i *issuestorage.Issue) error {
+		i.Title = "Edited on mirror"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := secondary.Create(ctx, &issuestorage.Issue{ID: "bd-stray", Title: "Stray", Status: issuestorage.StatusOpen}); err != nil {
+		t.Fatal(err)
+	}
+	missing, err := s.primary.Create(ctx, &issuestorage.Issue{Title: "Unmirrored", Status: issuestorage.StatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diffs, err := s.Reconcile(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kinds := map[string]string{}
+	for _, d := range diffs {
+		kinds[d.ID] = d.Kind
+	}
+	want := map[string]string{id: "changed", "bd-stray": "extra", missing: "missing"}
+	for wid, wkind := range want {
+		if kinds[wid] != wkind {
+			t.Errorf("difference for %s = %q, want %q (all: %v)", wid, kinds[wid], wkind, diffs)
+		}
+	}
+
+	if _, err := s.Reconcile(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+	diffs, err = s.Reconcile(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != 0 {
+		t.Errorf("differences after --fix: %v", diffs)
+	}
+}
@@ -4,9 +4,12 @@ package issuestorage
 
 import (
 	"context"
+	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -35,6 +38,7 @@ const (
 	DepTypeValidates      DependencyType = "validates"
 	DepTypeRelatesTo      DependencyType = "relates-to"
 	DepTypeSupersedes     DependencyType = "supersedes"
+	DepTypeDuplicateOf    DependencyType = "duplicate-of"
 )
 
 // ValidDependencyTypes is the set of all valid dependency types.
@@ -49,6 +53,27 @@ var ValidDependencyTypes = map[DependencyType]bool{
 	DepTypeValidates:      true,
 	DepTypeRelatesTo:      true,
 	DepTypeSupersedes:     true,
+	DepTypeDuplicateOf:    true,
+}
+
+// LinkTypes are the dependency types managed by bd link: relations between
+// issues that never block either of them.
+var LinkTypes = []DependencyType{DepTypeRelatesTo, DepTypeDuplicateOf, DepTypeSupersedes}
+
+// IsLink reports whether t is one of LinkTypes.
+func (t DependencyType) IsLink() bool {
+	for _, l := range LinkTypes {
+		if t == l {
+			return true
+		}
+	}
+	return false
+}
+
+// Symmetric reports whether t reads the same in both directions, so that
+// A relating to B is B relating to A and can never form a cycle.
+func (t DependencyType) Symmetric() bool {
+	return t == DepTypeRelatesTo
 }
 
 // Dependency represents a typed dependency between two issues.
@@ -64,9 +89,14 @@ type Issue struct {
 	Description string    `json:"description"`
 	Status      Status    `json:"status"`
 	Priority    Priority  `json:"priority"`
+	Severity    Severity  `json:"severity,omitempty"`
 	Type        IssueType `json:"type"`
 	MolType     MolType   `json:"mol_type,omitempty"`
 
+	// Manual backlog position; lower sorts first, "" means unranked.
+	// Keys are generated by RankBetween.
+	Rank string `json:"rank,omitempty"`
+
 	// Hierarchy convenience field (set automatically with parent-child deps)
 	Parent string `json:"parent,omitempty"`
 
@@ -76,22 +106,67 @@ type Issue struct {
 
 	CreatedBy string `json:"created_by,omitempty"`
 	Owner     string `json:"owner,omitempty"`
-
-	Labels      []string   `json:"labels,omitempty"`
-	Assignee    string     `json:"assignee,omitempty"`
-	Ephemeral   bool       `json:"ephemeral,omitempty"` // If true, not exported to JSONL
-	Comments    []Comment  `json:"comments,omitempty"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
-	ClosedAt    *time.Time `json:"closed_at,omitempty"`
-	CloseReason string     `json:"close_reason,omitempty"`
+	Reporter  string `json:"reporter,omitempty"` // who raised the issue; see ReportedBy
+
+	Labels      []string       `json:"labels,omitempty"`
+	Assignee    string         `json:"assignee,omitempty"`
+	Reviewer    string         `json:"reviewer,omitempty"`
+	Subscribers []string       `json:"subscribers,omitempty"` // identities following the issue, e.g. from @mentions
+	Ephemeral   bool           `json:"ephemeral,omitempty"`   // If true, not exported to JSONL
+	Comments    []Comment      `json:"comments,omitempty"`
+	Attachments []Attachment   `json:"attachments,omitempty"`
+	History     []HistoryEntry `json:"history,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	ClosedAt    *time.Time     `json:"closed_at,omitempty"`
+	CloseReason string         `json:"close_reason,omitempty"`
+	ReopenCount int            `json:"reopen_count,omitempty"` // times the issue left the closed status; see History for who
+	Resolution  Resolution     `json:"resolution,omitempty"`   // structured close reason
+	DuplicateOf string         `json:"duplicate_of,omitempty"` // issue this one duplicates (resolution duplicate)
+
+	// Scheduling fields (imported from org-mode and Taskwarrior)
+	DueAt      *time.Time `json:"due_at,omitempty"`      // deadline
+	DeferUntil *time.Time `json:"defer_until,omitempty"` // not expected to start before this date
+	DueEvent   string     `json:"due_event,omitempty"`   // calendar event DueAt follows (see bd calendar)
+
+	// Effort, in minutes
+	Estimate  int `json:"estimate_minutes,omitempty"`   // expected total effort
+	TimeSpent int `json:"time_spent_minutes,omitempty"` // effort logged so far (see bd log-time)
+
+	Milestone string `json:"milestone,omitempty"` // release the issue is planned for (see bd milestone)
+	Sprint    string `json:"sprint,omitempty"`    // sprint the issue is planned into (see bd sprint)
 
 	// Gate fields (async coordination primitives)
-	AwaitType string   `json:"await_type,omitempty"` // "gh:run", "gh:pr", "timer", "human", "bead"
+	AwaitType string   `json:"await_type,omitempty"` // "gh:run", "gh:pr", "timer", "calendar", "human", "bead"
 	AwaitID   string   `json:"await_id,omitempty"`   // external identifier being waited on
 	TimeoutNS int64    `json:"timeout_ns,omitempty"` // nanoseconds (matches reference impl column name)
 	Waiters   []string `json:"waiters,omitempty"`    // addresses to notify when gate clears
 
+	// Risk fields (used by TypeRisk issues)
+	Likelihood int        `json:"likelihood,omitempty"` // 1 (rare) .. 5 (almost certain)
+	Impact     int        `json:"impact,omitempty"`     // 1 (negligible) .. 5 (severe)
+	ReviewBy   *time.Time `json:"review_by,omitempty"`  // date the risk must next be reassessed
+
+	// Decision record state (used by TypeDecision issues)
+	DecisionState DecisionState `json:"decision_state,omitempty"`
+
+	// Accepted answer comment ID (used by TypeQuestion issues; 0 = none)
+	AcceptedAnswer int `json:"accepted_answer,omitempty"`
+
+	// Checklist of conditions that must hold for the issue to be done
+	AcceptanceCriteria []Criterion `json:"acceptance_criteria,omitempty"`
+
+	// Review verdicts, oldest first; the latest one counts
+	Reviews []Review `json:"reviews,omitempty"`
+
+	// The same issue in other trackers, by tracker: "github" maps to
+	// "owner/repo#12" (see bd bridge)
+	ExternalRefs map[string]string `json:"external_refs,omitempty"`
+
+	// Parameters for automation, e.g. DEPLOY_ENV=staging; keys are
+	// environment variable names (see bd env)
+	Vars map[string]string `json:"vars,omitempty"`
+
 	// Tombstone fields (set when issue is soft-deleted)
 	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
 	DeletedBy    string     `json:"deleted_by,omitempty"`
@@ -130,6 +205,43 @@ func (issue *Issue) HasDependent(id string) bool {
 	return false
 }
 
+// Answer returns the comment marked as the accepted answer, or nil.
+func (issue *Issue) Answer() *Comment {
+	if issue.AcceptedAnswer == 0 {
+		return nil
+	}
+	for i := range issue.Comments {
+		if issue.Comments[i].ID == issue.AcceptedAnswer {
+			return &issue.Comments[i]
+		}
+	}
+	return nil
+}
+
+// ReportedBy returns who raised the issue: Reporter, or CreatedBy for
+// issues created before reporters were recorded.
+func (issue *Issue) ReportedBy() string {
+	if issue.Reporter != "" {
+		return issue.Reporter
+	}
+	return issue.CreatedBy
+}
+
+// Exposure returns the risk exposure score (likelihood × impact).
+// Returns 0 when either factor is unset.
+func (issue *Issue) Exposure() int {
+	return issue.Likelihood * issue.Impact
+}
+
+// RemainingEffort returns the minutes of the estimate not yet logged, or 0
+// once the issue is closed or more time was logged than estimated.
+func (issue *Issue) RemainingEffort() int {
+	if issue.Status == StatusClosed || issue.Status == StatusTombstone {
+		return 0
+	}
+	return max(issue.Estimate-issue.TimeSpent, 0)
+}
+
 // DependencyIDs returns the IDs from the Dependencies list, optionally filtered by type.
 func (issue *Issue) DependencyIDs(filterType *DependencyType) []string {
 	var ids []string
@@ -152,20 +264,133 @@ func (issue *Issue) DependentIDs(filterType *DependencyType) []string {
 	return ids
 }
 
-// Comment represents a comment on an issue.
+// Comment represents a comment on an issue. UUID identifies the comment
+// across clones; ID is the number it is shown and referred to by, unique
+// within the issue but renumbered when comments added offline on two
+// clones merge under the same number. Mentions holds the people the text
+// @mentions, by handle where the people registry knows them; comments
+// written without a registry leave it empty.
 type Comment struct {
 	ID        int       `json:"id"`
+	UUID      string    `json:"uuid,omitempty"`
 	Author    string    `json:"author"`
 	Text      string    `json:"text"`
+	Mentions  []string  `json:"mentions,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// Key returns the identity comments are matched by when merging: the
+// UUID, or for comments written before comments had UUIDs, the author,
+// time and text, which never change.
+func (c Comment) Key() string {
+	if c.UUID != "" {
+		return c.UUID
+	}
+	return c.Author + "\x00" + c.CreatedAt.UTC().Format(time.RFC3339Nano) + "\x00" + c.Text
+}
+
+// NewUUID returns a random (version 4) UUID.
+func NewUUID() string {
+	return NewUUIDFrom(rand.Reader)
+}
+
+// NewUUIDFrom returns a version 4 UUID drawn from r, such as the seeded
+// source of deterministic mode.
+func NewUUIDFrom(r io.Reader) string {
+	var b [16]byte
+	io.ReadFull(r, b[:]) // crypto/rand never fails; seeded sources neither
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// Attachment records a file attached to an issue. The content is stored
+// separately as a blob keyed by SHA256.
+type Attachment struct {
+	Name      string    `json:"name"`
+	SHA256    string    `json:"sha256"`
+	Size      int64     `json:"size"`
+	MediaType string    `json:"media_type,omitempty"`
+	AddedBy   string    `json:"added_by,omitempty"`
+	AddedAt   time.Time `json:"added_at"`
+}
+
+// Criterion is one acceptance criterion of an issue.
+type Criterion struct {
+	Text      string     `json:"text"`
+	Done      bool       `json:"done,omitempty"`
+	CheckedBy string     `json:"checked_by,omitempty"`
+	CheckedAt *time.Time `json:"checked_at,omitempty"`
+}
+
+// UncheckedCriteria returns the number of acceptance criteria not yet done.
+func (issue *Issue) UncheckedCriteria() int {
+	n := 0
+	for _, c := range issue.AcceptanceCriteria {
+		if !c.Done {
+			n++
+		}
+	}
+	return n
+}
+
+// ReviewOutcome is a reviewer's verdict on an issue.
+type ReviewOutcome string
+
+const (
+	ReviewApproved         ReviewOutcome = "approved"
+	ReviewChangesRequested ReviewOutcome = "changes_requested"
+)
+
+// Review is one reviewer's verdict on an issue.
+type Review struct {
+	Reviewer string        `json:"reviewer"`
+	Outcome  ReviewOutcome `json:"outcome"`
+	Comment  string        `json:"comment,omitempty"`
+	At       time.Time     `json:"at"`
+}
+
+// Approved reports whether the issue's latest review approved it.
+func (issue *Issue) Approved() bool {
+	n := len(issue.Reviews)
+	return n > 0 && issue.Reviews[n-1].Outcome == ReviewApproved
+}
+
+// History event kinds.
+const (
+	EventDoctorFix    = "doctor_fix"    // bd doctor --fix corrected a field
+	EventReopened     = "reopened"      // the issue left the closed status
+	EventAssigned     = "assigned"      // the assignee changed (New is "" when unassigned)
+	EventAutoAssigned = "auto_assigned" // an assignment rule picked the assignee (Note names the rule)
+	EventLabeled      = "labeled"       // a label was added (New is the label)
+	EventLabelExpired = "label_expired" // an expiry policy removed a label (Old is the label, Note the action)
+	EventTimeLogged   = "time_logged"   // time was logged (New is the minutes, Note the message)
+	EventStatus       = "status"        // the status changed other than by reopening (Old and New are the statuses)
+	EventUpdated      = "updated"       // a field changed (Old and New are left out for long text)
+	EventUnlabeled    = "unlabeled"     // a label was removed (Old is the label)
+	EventCommented    = "commented"     // a comment was added (New is its ID)
+	EventDepAdded     = "dep_added"     // a dependency was added (Field is its type, New the issue depended on)
+	EventDepRemoved   = "dep_removed"   // a dependency was removed (Field is its type, Old the issue)
+)
+
+// HistoryEntry records a change made to an issue.
+type HistoryEntry struct {
+	At    time.Time `json:"at"`
+	Actor string    `json:"actor,omitempty"`
+	Event string    `json:"event"`
+	Field string    `json:"field,omitempty"`
+	Old   string    `json:"old,omitempty"`
+	New   string    `json:"new,omitempty"`
+	Note  string    `json:"note,omitempty"`
+}
+
 // Status represents the current state of an issue.
 type Status string
 
 const (
 	StatusOpen       Status = "open"
 	StatusInProgress Status = "in_progress"
+	StatusReview     Status = "review"
 	StatusBlocked    Status = "blocked"
 	StatusDeferred   Status = "deferred"
 	StatusHooked     Status = "hooked"
@@ -176,7 +401,7 @@ const (
 
 // BuiltinStatuses lists the statuses users can set directly (excludes tombstone).
 var BuiltinStatuses = []Status{
-	StatusOpen, StatusInProgress, StatusBlocked, StatusDeferred,
+	StatusOpen, StatusInProgress, StatusReview, StatusBlocked, StatusDeferred,
 	StatusHooked, StatusPinned, StatusClosed,
 }
 
@@ -243,6 +468,73 @@ func ParsePriority(s string) (Priority, error) {
 	}
 }
 
+// Severity represents how bad an issue is, independent of Priority (how soon
+// it should be worked on). The zero value means severity has not been assessed.
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityMajor    Severity = "major"
+	SeverityMinor    Severity = "minor"
+	SeverityTrivial  Severity = "trivial"
+)
+
+// Severities lists the valid severities from most to least severe.
+var Severities = []Severity{SeverityCritical, SeverityMajor, SeverityMinor, SeverityTrivial}
+
+// ParseSeverity converts a string to a Severity value.
+// Accepts the level names ("critical", "major", "minor", "trivial") or the
+// S-format shorthand ("S1"-"S4"). Returns an error for unrecognized input.
+func ParseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "critical", "s1":
+		return SeverityCritical, nil
+	case "major", "s2":
+		return SeverityMajor, nil
+	case "minor", "s3":
+		return SeverityMinor, nil
+	case "trivial", "s4":
+		return SeverityTrivial, nil
+	default:
+		return "", fmt.Errorf("invalid severity %q (expected critical, major, minor, trivial or S1-S4)", s)
+	}
+}
+
+// Resolution is the structured reason an issue was closed, kept alongside
+// the free-text CloseReason. The zero value means none was given.
+type Resolution string
+
+const (
+	ResolutionFixed     Resolution = "fixed"
+	ResolutionWontFix   Resolution = "wontfix"
+	ResolutionDuplicate Resolution = "duplicate"
+	ResolutionInvalid   Resolution = "invalid"
+	ResolutionObsolete  Resolution = "obsolete"
+)
+
+// Resolutions lists the valid resolutions.
+var Resolutions = []Resolution{ResolutionFixed, ResolutionWontFix, ResolutionDuplicate, ResolutionInvalid, ResolutionObsolete}
+
+// ParseResolution converts a string to a Resolution, ignoring case and
+// accepting spelling variants such as "won't fix" and "wont-fix".
+func ParseResolution(s string) (Resolution, error) {
+	norm := strings.NewReplacer(" ", "", "-", "", "_", "", "'", "").Replace(strings.ToLower(strings.TrimSpace(s)))
+	switch norm {
+	case "fixed", "fix", "done":
+		return ResolutionFixed, nil
+	case "wontfix":
+		return ResolutionWontFix, nil
+	case "duplicate", "dup":
+		return ResolutionDuplicate, nil
+	case "invalid":
+		return ResolutionInvalid, nil
+	case "obsolete":
+		return ResolutionObsolete, nil
+	default:
+		return "", fmt.Errorf("invalid resolution %q (expected fixed, wontfix, duplicate, invalid or obsolete)", s)
+	}
+}
+
 // IssueType represents the category of an issue.
 type IssueType string
 
@@ -254,8 +546,62 @@ const (
 	TypeChore    IssueType = "chore"
 	TypeGate     IssueType = "gate"
 	TypeMolecule IssueType = "molecule"
+	TypeRisk     IssueType = "risk"
+	TypeDecision IssueType = "decision"
+	TypeQuestion IssueType = "question"
+)
+
+// DecisionState is the lifecycle state of a decision record (ADR),
+// tracked separately from the issue's work Status.
+type DecisionState string
+
+const (
+	DecisionProposed   DecisionState = "proposed"
+	DecisionAccepted   DecisionState = "accepted"
+	DecisionSuperseded DecisionState = "superseded"
+)
+
+// ParseDecisionState converts a string to a DecisionState.
+func ParseDecisionState(s string) (DecisionState, error) {
+	switch DecisionState(strings.ToLower(strings.TrimSpace(s))) {
+	case DecisionProposed:
+		return DecisionProposed, nil
+	case DecisionAccepted:
+		return DecisionAccepted, nil
+	case DecisionSuperseded:
+		return DecisionSuperseded, nil
+	default:
+		return "", fmt.Errorf("invalid decision state %q (expected proposed, accepted or superseded)", s)
+	}
+}
+
+// ValidDecisionTransition reports whether a decision may move from one
+// state to another. Superseded is terminal.
+func ValidDecisionTransition(from, to DecisionState) bool {
+	switch from {
+	case DecisionProposed:
+		return to == DecisionAccepted || to == DecisionSuperseded
+	case DecisionAccepted:
+		return to == DecisionSuperseded
+	}
+	return false
+}
+
+// MinRiskScore and MaxRiskScore bound the likelihood and impact scales.
+const (
+	MinRiskScore = 1
+	MaxRiskScore = 5
 )
 
+// ParseRiskScore parses a likelihood or impact value on the 1-5 scale.
+func ParseRiskScore(s string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || n < MinRiskScore || n > MaxRiskScore {
+		return 0, fmt.Errorf("invalid risk score %q (expected %d-%d)", s, MinRiskScore, MaxRiskScore)
+	}
+	return n, nil
+}
+
 // MolType represents the molecule type of an issue.
 type MolType string
 
@@ -281,6 +627,7 @@ func ValidateMolType(s string) bool {
 type ListFilter struct {
 	Statuses        []Status    // empty means any; OR across values
 	Priority        *Priority   // nil means any
+	Severities      []Severity  // empty means any; OR across values
 	Types           []IssueType // empty means any; OR across values
 	MolType         *MolType    // nil means any
 	CreatedAfter    *time.Time  // nil means any; inclusive lower bound
@@ -289,15 +636,166 @@ type ListFilter struct {
 	Labels          []string    // OR: issue must have at least one of these labels
 	LabelsAll       []string    // AND: issue must have all of these labels
 	Assignees       []string    // empty means any; OR across values
+	Reporters       []string    // empty means any; OR across values of ReportedBy
+	Milestone       *string     // nil means any, empty string means no milestone
 	IncludeChildren bool        // if true, include descendants of matching issues
 }
 
+// Matches reports whether issue satisfies every criterion in the filter.
+// A nil filter matches all issues.
+func (f *ListFilter) Matches(issue *Issue) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.Statuses) > 0 && !containsStatus(f.Statuses, issue.Status) {
+		return false
+	}
+	if f.Priority != nil && issue.Priority != *f.Priority {
+		return false
+	}
+	if len(f.Severities) > 0 && !containsSeverity(f.Severities, issue.Severity) {
+		return false
+	}
+	if len(f.Types) > 0 && !containsType(f.Types, issue.Type) {
+		return false
+	}
+	if f.MolType != nil {
+		filterMT := *f.MolType
+		issueMT := issue.MolType
+		// Treat empty and "work" as equivalent
+		if filterMT == "" || filterMT == MolTypeWork {
+			if issueMT != "" && issueMT != MolTypeWork {
+				return false
+			}
+		} else if issueMT != filterMT {
+			return false
+		}
+	}
+	if f.CreatedAfter != nil && issue.CreatedAt.Before(*f.CreatedAfter) {
+		return false
+	}
+	if f.CreatedBefore != nil && issue.CreatedAt.After(*f.CreatedBefore) {
+		return false
+	}
+	if len(f.Assignees) > 0 && !containsString(f.Assignees, issue.Assignee) {
+		return false
+	}
+	if len(f.Reporters) > 0 && !containsString(f.Reporters, issue.ReportedBy()) {
+		return false
+	}
+	if f.Milestone != nil && issue.Milestone != *f.Milestone {
+		return false
+	}
+	if f.Parent != nil {
+		if *f.Parent == "" && issue.Parent != "" {
+			return false
+		} else if *f.Parent != "" && issue.Parent != *f.Parent {
+			return false
+		}
+	}
+
+	// Labels (OR): issue must have at least one of the specified labels.
+	if len(f.Labels) > 0 {
+		found := false
+		for _, issueLabel := range issue.Labels {
+			if containsString(f.Labels, issueLabel) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	// LabelsAll (AND): issue must have all of the specified labels.
+	for _, label := range f.LabelsAll {
+		found := false
+		for _, issueLabel := range issue.Labels {
+			if issueLabel == label {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsStatus(ss []Status, s Status) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsSeverity(ss []Severity, s Severity) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsType(ts []IssueType, t IssueType) bool {
+	for _, v := range ts {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
 // CreateOpts provides optional parameters for Create.
 type CreateOpts struct {
 	// PrefixAddition is inserted between the store's base prefix and the
 	// random suffix when generating a new ID (e.g. "mol" → "bd-mol-xxxx").
 	// Ignored when issue.ID is already set.
 	PrefixAddition string
+
+	// UseCachedCount sizes the random ID from the issue count cached by a
+	// previous Create however old it is, skipping the periodic recount.
+	// Backends without a cache, or with a cold one, fall back to counting.
+	UseCachedCount bool
+
+	// KeepTimestamps makes issueservice keep a non-zero CreatedAt and
+	// UpdatedAt instead of stamping the current time, for generated or
+	// migrated issues with a history of their own.
+	KeepTimestamps bool
+
+	// PrioritySet marks the issue's Priority as chosen, by the user or
+	// copied from another issue. Otherwise issueservice replaces it with
+	// the type's default priority (types.<type>.default_priority), if one
+	// is configured, since a zero Priority cannot tell "unset" from P0.
+	PrioritySet bool
+
+	// Rand supplies the randomness for a generated ID in place of
+	// crypto/rand. issueservice sets it in deterministic mode.
+	Rand io.Reader
+}
+
+// IDSource returns the reader a backend should draw a generated ID from.
+func (o CreateOpts) IDSource() io.Reader {
+	if o.Rand != nil {
+		return o.Rand
+	}
+	return rand.Reader
 }
 
 // IssueGetter provides read-only access to issues by ID.
@@ -351,4 +849,15 @@ type IssueStore interface {
 
 	// Doctor checks for and optionally fixes inconsistencies.
 	Doctor(ctx context.Context, fix bool) ([]string, error)
+
+	// ListReady returns the issues matching filter that are ready to work
+	// on: open, not ephemeral and not blocked (see ResolveBlockers, which
+	// cascade is passed to). The filter's statuses are ignored. Backends
+	// without a faster way call the package-level ListReady.
+	ListReady(ctx context.Context, filter *ListFilter, cascade bool) ([]*Issue, error)
+
+	// ListBlocked returns the issues matching filter, open ones if it
+	// names no statuses, that are blocked, with their blockers. Backends
+	// without a faster way call the package-level ListBlocked.
+	ListBlocked(ctx context.Context, filter *ListFilter, cascade bool) ([]BlockedIssue, error)
 }
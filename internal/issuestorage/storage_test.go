@@ -281,3 +281,200 @@ func TestParsePriority(t *testing.T) {
 		}
 	}
 }
+
+func TestParseSeverity(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Severity
+		wantErr bool
+	}{
+		{"critical", SeverityCritical, false},
+		{"major", SeverityMajor, false},
+		{"minor", SeverityMinor, false},
+		{"trivial", SeverityTrivial, false},
+		{"Major", SeverityMajor, false}, // case-insensitive
+		{"S1", SeverityCritical, false},
+		{"s4", SeverityTrivial, false},
+		{"", "", true},
+		{"S5", "", true},
+		{"high", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSeverity(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseSeverity(%q) should error", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseSeverity(%q) unexpected error: %v", tt.input, err)
+		}
+		if got != tt.want {
+			t.Errorf("ParseSeverity(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseResolution(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Resolution
+		wantErr bool
+	}{
+		{"fixed", ResolutionFixed, false},
+		{"Won't fix", ResolutionWontFix, false},
+		{"wont-fix", ResolutionWontFix, false},
+		{"DUPLICATE", ResolutionDuplicate, false},
+		{"invalid", ResolutionInvalid, false},
+		{"obsolete", ResolutionObsolete, false},
+		{"", "", true},
+		{"Closed", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseResolution(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseResolution(%q) should error", tt.input)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseResolution(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseRiskScore(t *testing.T) {
+	for _, in := range []string{"1", "3", " 5 "} {
+		if _, err := ParseRiskScore(in); err != nil {
+			t.Errorf("ParseRiskScore(%q) unexpected error: %v", in, err)
+		}
+	}
+	for _, in := range []string{"0", "6", "high", ""} {
+		if _, err := ParseRiskScore(in); err == nil {
+			t.Errorf("ParseRiskScore(%q) should error", in)
+		}
+	}
+}
+
+func TestIssueExposure(t *testing.T) {
+	if got := (&Issue{Likelihood: 3, Impact: 4}).Exposure(); got != 12 {
+		t.Errorf("Exposure() = %d, want 12", got)
+	}
+	if got := (&Issue{Likelihood: 3}).Exposure(); got != 0 {
+		t.Errorf("Exposure() with unset impact = %d, want 0", got)
+	}
+}
+
+func TestParseBackend(t *testing.T) {
+	for _, v := range []string{"", "filesystem", "s3", "postgres"} {
+		if _, err := ParseBackend(v); err != nil {
+			t.Errorf("ParseBackend(%q): %v", v, err)
+		}
+	}
+	if _, err := ParseBackend("dynamodb"); err == nil {
+		t.Error("expected error for unknown backend")
+	}
+}
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"no mentions here", nil},
+		{"@alice can you look?", []string{"alice"}},
+		{"cc @bob, @carol.smith and @Bob again.", []string{"bob", "carol.smith"}},
+		{"mail alice@example.com, not a mention", nil},
+		{"(@dave) thanks @eve.", []string{"dave", "eve"}},
+		{"@@x and @", nil},
+	}
+	for _, tt := range tests {
+		got := ParseMentions(tt.text)
+		if len(got) != len(tt.want) {
+			t.Errorf("ParseMentions(%q) = %v, want %v", tt.text, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("ParseMentions(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestMentionMatches(t *testing.T) {
+	for _, tt := range []struct {
+		mention, identity string
+		want              bool
+	}{
+		{"alice", "alice", true},
+		{"Alice", "alice", true},
+		{"alice", "alice@example.com", true},
+		{"alice", "alicia", false},
+		{"example.com", "alice@example.com", false},
+	} {
+		if got := MentionMatches(tt.mention, tt.identity); got != tt.want {
+			t.Errorf("MentionMatches(%q, %q) = %v, want %v", tt.mention, tt.identity, got, tt.want)
+		}
+	}
+}
+
+func TestIssueSubscribe(t *testing.T) {
+	issue := &Issue{}
+	if !issue.Subscribe("alice") || issue.Subscribe("Alice") || !issue.Subscribe("bob") {
+		t.Errorf("unexpected Subscribe results, subscribers = %v", issue.Subscribers)
+	}
+	if len(issue.Subscribers) != 2 {
+		t.Errorf("subscribers = %v, want [alice bob]", issue.Subscribers)
+	}
+}
+
+func TestRankBetween(t *testing.T) {
+	for _, tt := range []struct{ a, b string }{
+		{"", ""}, {"", "i"}, {"i", ""}, {"z", ""}, {"", "1"}, {"1", "2"},
+		{"ab", "ac"}, {"a", "a01"}, {"i", "ii"}, {"zz", ""}, {"0i", "1"},
+	} {
+		got, err := RankBetween(tt.a, tt.b)
+		if err != nil {
+			t.Errorf("RankBetween(%q, %q): %v", tt.a, tt.b, err)
+			continue
+		}
+		if got <= tt.a || (tt.b != "" && got >= tt.b) || validateRank(got) != nil {
+			t.Errorf("RankBetween(%q, %q) = %q, not strictly between", tt.a, tt.b, got)
+		}
+	}
+
+	// Repeated insertion at the front and between neighbours keeps order.
+	keys := []string{}
+	prev := ""
+	for i := 0; i < 50; i++ {
+		k, err := RankBetween("", prev)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append([]string{k}, keys...)
+		prev = k
+	}
+	for i := 0; i < 50; i++ {
+		k, err := RankBetween(keys[0], keys[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append([]string{keys[0], k}, keys[1:]...)
+	}
+	for i := 1; i < len(keys); i++ {
+		if keys[i-1] >= keys[i] {
+			t.Fatalf("keys out of order at %d: %q >= %q", i, keys[i-1], keys[i])
+		}
+	}
+
+	for _, bad := range [][2]string{{"b", "a"}, {"a", "a"}, {"A", ""}, {"a0", ""}} {
+		if _, err := RankBetween(bad[0], bad[1]); err == nil {
+			t.Errorf("RankBetween(%q, %q): expected error", bad[0], bad[1])
+		}
+	}
+}
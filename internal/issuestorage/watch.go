@@ -0,0 +1,162 @@
+package issuestorage
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// ChangeKind classifies a change seen by a Watcher.
+type ChangeKind string
+
+const (
+	ChangeCreated ChangeKind = "created"
+	ChangeUpdated ChangeKind = "updated"
+	ChangeClosed  ChangeKind = "closed"
+	ChangeDeleted ChangeKind = "deleted" // removed, or tombstoned
+)
+
+// Change is one issue change seen by a Watcher. Issue is the issue as
+// written, or as last seen for a deletion.
+type Change struct {
+	Kind  ChangeKind
+	ID    string
+	Issue *Issue
+}
+
+// Watcher streams changes to the issues in a store.
+type Watcher interface {
+	// Watch sends a Change for each write to an issue matching filter,
+	// before or after the write, until ctx is done, then closes the
+	// channel. Changes made before Watch returns are not sent.
+	Watch(ctx context.Context, filter *ListFilter) (<-chan Change, error)
+}
+
+// ChangeTracker turns the current states of issues into Changes by
+// comparing each with the state it last saw.
+type ChangeTracker struct {
+	filter *ListFilter
+	seen   map[string]*Issue
+}
+
+// NewChangeTracker returns a tracker that reports changes to issues
+// matching filter, starting from the issues in initial.
+func NewChangeTracker(filter *ListFilter, initial []*Issue) *ChangeTracker {
+	t := &ChangeTracker{filter: filter, seen: make(map[string]*Issue, len(initial))}
+	for _, issue := range initial {
+		t.seen[issue.ID] = issue
+	}
+	return t
+}
+
+// Observe records that issue id is now issue, or gone if issue is nil,
+// and returns the resulting change, if any.
+func (t *ChangeTracker) Observe(id string, issue *Issue) (Change, bool) {
+	prev := t.seen[id]
+	if issue == nil || issue.Status == StatusTombstone {
+		delete(t.seen, id)
+		if prev == nil || prev.Status == StatusTombstone || !t.filter.Matches(prev) {
+			return Change{}, false
+		}
+		return Change{Kind: ChangeDeleted, ID: id, Issue: prev}, true
+	}
+	t.seen[id] = issue
+	if prev != nil && reflect.DeepEqual(prev, issue) {
+		return Change{}, false
+	}
+	if !t.filter.Matches(issue) && (prev == nil || !t.filter.Matches(prev)) {
+		return Change{}, false
+	}
+	kind := ChangeUpdated
+	switch {
+	case prev == nil:
+		kind = ChangeCreated
+	case issue.Status == StatusClosed && prev.Status != StatusClosed:
+		kind = ChangeClosed
+	}
+	return Change{Kind: kind, ID: id, Issue: issue}, true
+}
+
+// IDs returns the IDs of the issues the tracker has seen and not seen
+// deleted.
+func (t *ChangeTracker) IDs() []string {
+	ids := make([]string, 0, len(t.seen))
+	for id := range t.seen {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// ListEverything returns every issue in store, open, closed and
+// tombstoned.
+func ListEverything(ctx context.Context, store IssueStore) ([]*Issue, error) {
+	var all []*Issue
+	for _, filter := range []*ListFilter{
+		nil,
+		{Statuses: []Status{StatusClosed}},
+		{Statuses: []Status{StatusTombstone}},
+	} {
+		issues, err := store.List(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, issues...)
+	}
+	return all, nil
+}
+
+// PollWatcher watches a store that cannot report its own changes by
+// listing every issue each Interval and comparing.
+type PollWatcher struct {
+	Store    IssueStore
+	Interval time.Duration
+}
+
+// Watch implements Watcher. A failed listing is retried at the next tick.
+func (w *PollWatcher) Watch(ctx context.Context, filter *ListFilter) (<-chan Change, error) {
+	initial, err := ListEverything(ctx, w.Store)
+	if err != nil {
+		return nil, err
+	}
+	tracker := NewChangeTracker(filter, initial)
+	changes := make(chan Change)
+	go func() {
+		defer close(changes)
+		ticker := time.NewTicker(w.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			issues, err := ListEverything(ctx, w.Store)
+			if err != nil {
+				continue
+			}
+			current := make(map[string]bool, len(issues))
+			var found []Change
+			for _, issue := range issues {
+				current[issue.ID] = true
+				if c, ok := tracker.Observe(issue.ID, issue); ok {
+					found = append(found, c)
+				}
+			}
+			for _, id := range tracker.IDs() {
+				if !current[id] {
+					if c, ok := tracker.Observe(id, nil); ok {
+						found = append(found, c)
+					}
+				}
+			}
+			for _, c := range found {
+				select {
+				case changes <- c:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return changes, nil
+}
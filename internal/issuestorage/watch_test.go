@@ -0,0 +1,34 @@
+package issuestorage
+
+import "testing"
+
+func TestChangeTracker(t *testing.T) {
+	open := &Issue{ID: "bd-1", Title: "A", Status: StatusOpen, Labels: []string{"web"}}
+	tracker := NewChangeTracker(&ListFilter{Labels: []string{"web"}}, []*Issue{open})
+
+	steps := []struct {
+		id    string
+		issue *Issue
+		want  ChangeKind // "" for no change
+	}{
+		{"bd-1", &Issue{ID: "bd-1", Title: "A", Status: StatusOpen, Labels: []string{"web"}}, ""},
+		{"bd-1", &Issue{ID: "bd-1", Title: "B", Status: StatusOpen, Labels: []string{"web"}}, ChangeUpdated},
+		{"bd-1", &Issue{ID: "bd-1", Title: "B", Status: StatusClosed, Labels: []string{"web"}}, ChangeClosed},
+		{"bd-2", &Issue{ID: "bd-2", Title: "Other", Status: StatusOpen}, ""},
+		{"bd-3", &Issue{ID: "bd-3", Title: "New", Status: StatusOpen, Labels: []string{"web"}}, ChangeCreated},
+		// Leaving the filter is still reported; later changes are not.
+		{"bd-3", &Issue{ID: "bd-3", Title: "New", Status: StatusOpen}, ChangeUpdated},
+		{"bd-3", &Issue{ID: "bd-3", Title: "Renamed", Status: StatusOpen}, ""},
+		{"bd-1", &Issue{ID: "bd-1", Title: "B", Status: StatusTombstone, Labels: []string{"web"}}, ChangeDeleted},
+		{"bd-1", nil, ""},
+	}
+	for i, step := range steps {
+		var got ChangeKind
+		if c, ok := tracker.Observe(step.id, step.issue); ok {
+			got = c.Kind
+		}
+		if got != step.want {
+			t.Errorf("step %d: Observe(%s) = %q, want %q", i, step.id, got, step.want)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"beads-lite/internal/clock"
 	"beads-lite/internal/issuestorage"
 )
 
@@ -23,7 +24,7 @@ func GC(ctx context.Context, store issuestorage.IssueStore, opts GCOptions) (*GC
 		olderThan = DefaultGCOlderThan
 	}
 
-	cutoff := time.Now().Add(-olderThan)
+	cutoff := clock.OrReal(opts.Clock).Now().Add(-olderThan)
 
 	// List all issues (nil fields = any).
 	issues, err := store.List(ctx, &issuestorage.ListFilter{})
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"beads-lite/internal/clock"
 	"beads-lite/internal/issuestorage"
 	"beads-lite/internal/issuestorage/filesystem"
 )
@@ -180,3 +181,28 @@ func TestGC_DefaultOlderThan(t *testing.T) {
 		t.Errorf("fresh issue should survive, got: %v", err)
 	}
 }
+
+func TestGC_MeasuresAgeByClock(t *testing.T) {
+	ctx := context.Background()
+	s := newGCStore(t)
+	issue := createGCIssue(t, ctx, s, "Ephemeral", true)
+
+	// Created just now, so only a clock three hours ahead sees it as old.
+	fake := clock.NewFake(issue.CreatedAt.Add(30 * time.Minute))
+	result, err := GC(ctx, s, GCOptions{OlderThan: time.Hour, Clock: fake})
+	if err != nil {
+		t.Fatalf("GC: %v", err)
+	}
+	if result.Count != 0 {
+		t.Fatalf("Count: got %d, want 0 before the threshold", result.Count)
+	}
+
+	fake.Advance(150 * time.Minute)
+	result, err = GC(ctx, s, GCOptions{OlderThan: time.Hour, Clock: fake})
+	if err != nil {
+		t.Fatalf("GC: %v", err)
+	}
+	if result.Count != 1 || result.RemovedIDs[0] != issue.ID {
+		t.Errorf("RemovedIDs: got %v, want [%s]", result.RemovedIDs, issue.ID)
+	}
+}
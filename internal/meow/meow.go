@@ -2,6 +2,8 @@ package meow
 
 import (
 	"time"
+
+	"beads-lite/internal/clock"
 )
 
 // CurrentOptions configures a Current query.
@@ -24,6 +26,8 @@ type StaleStep struct {
 // GCOptions configures a GC (garbage collection) operation.
 type GCOptions struct {
 	OlderThan time.Duration
+	// Clock supplies the time ages are measured from (default: system clock).
+	Clock clock.Clock
 }
 
 // GCResult describes the outcome of a GC run.
@@ -3,6 +3,7 @@ package meow
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 
 	"beads-lite/internal/issueservice"
@@ -29,7 +30,8 @@ type PourResult struct {
 }
 
 // Pour resolves a formula, validates and substitutes variables, then creates
-// a root epic issue and child issues in the storage backend.
+// a root epic issue and child issues in the storage backend. The variables
+// supplied are kept as the root issue's vars.
 // When opts.Ephemeral is true the operation is a "wisp" — all created issues
 // are marked ephemeral.
 func Pour(ctx context.Context, store *issueservice.IssueStore, opts PourOptions) (*PourResult, error) {
@@ -64,7 +66,10 @@ func Pour(ctx context.Context, store *issueservice.IssueStore, opts PourOptions)
 		Ephemeral:   opts.Ephemeral,
 		CreatedBy:   actor,
 	}
-	rootID, err := store.Create(ctx, rootIssue, issuestorage.CreateOpts{PrefixAddition: opts.PrefixAddition})
+	if len(opts.Vars) > 0 {
+		rootIssue.Vars = maps.Clone(opts.Vars)
+	}
+	rootID, err := store.Create(ctx, rootIssue, issuestorage.CreateOpts{PrefixAddition: opts.PrefixAddition, PrioritySet: true})
 	if err != nil {
 		return nil, fmt.Errorf("creating root issue: %w", err)
 	}
@@ -97,7 +102,7 @@ func Pour(ctx context.Context, store *issueservice.IssueStore, opts PourOptions)
 			Ephemeral:   opts.Ephemeral,
 			CreatedBy:   actor,
 		}
-		if _, err := store.Create(ctx, child); err != nil {
+		if _, err := store.Create(ctx, child, issuestorage.CreateOpts{PrioritySet: true}); err != nil {
 			return nil, fmt.Errorf("creating child issue for step %q: %w", step.ID, err)
 		}
 
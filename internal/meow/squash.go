@@ -84,7 +84,7 @@ func Squash(ctx context.Context, store *issueservice.IssueStore, opts SquashOpti
 		CloseReason: fmt.Sprintf("Squashed from %d wisps", len(ephemeral)),
 	}
 
-	if _, err := store.Create(ctx, digest); err != nil {
+	if _, err := store.Create(ctx, digest, issuestorage.CreateOpts{PrioritySet: true}); err != nil {
 		return nil, fmt.Errorf("create digest issue: %w", err)
 	}
 
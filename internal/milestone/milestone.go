@@ -0,0 +1,194 @@
+// Package milestone provides helpers for managing release milestones in a KV table ("milestones").
+package milestone
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/kvstorage"
+)
+
+// Milestone is a release or other target date that issues are grouped under.
+type Milestone struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Status      string     `json:"status"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ClosedAt    *time.Time `json:"closed_at,omitempty"`
+}
+
+// Status constants for the milestone lifecycle.
+const (
+	StatusOpen   = "open"
+	StatusClosed = "closed"
+)
+
+// namePattern matches milestone names such as "v1.2", "2026-Q4" or
+// "beta_2". Names are used as KV keys, so they cannot contain separators.
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ValidateName returns an error if name cannot be used as a milestone name.
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid milestone name %q: use letters, digits, '.', '_' and '-', starting with a letter or digit", name)
+	}
+	return nil
+}
+
+// Get retrieves the milestone with the given name.
+// Returns kvstorage.ErrKeyNotFound if the milestone does not exist.
+func Get(ctx context.Context, store kvstorage.KVStore, name string) (Milestone, error) {
+	data, err := store.Get(ctx, name)
+	if err != nil {
+		if errors.Is(err, kvstorage.ErrKeyNotFound) {
+			return Milestone{}, kvstorage.ErrKeyNotFound
+		}
+		return Milestone{}, fmt.Errorf("getting milestone %s: %w", name, err)
+	}
+	var m Milestone
+	if err := json.Unmarshal(data, &m); err != nil {
+		return Milestone{}, fmt.Errorf("decoding milestone %s: %w", name, err)
+	}
+	return m, nil
+}
+
+// List returns every milestone, those due soonest first and those without
+// a due date last, ordered by name.
+func List(ctx context.Context, store kvstorage.KVStore) ([]Milestone, error) {
+	names, err := store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing milestones: %w", err)
+	}
+	milestones := make([]Milestone, 0, len(names))
+	for _, name := range names {
+		m, err := Get(ctx, store, name)
+		if err != nil {
+			return nil, err
+		}
+		milestones = append(milestones, m)
+	}
+	sort.Slice(milestones, func(i, j int) bool {
+		a, b := milestones[i], milestones[j]
+		if (a.DueAt == nil) != (b.DueAt == nil) {
+			return a.DueAt != nil
+		}
+		if a.DueAt != nil && !a.DueAt.Equal(*b.DueAt) {
+			return a.DueAt.Before(*b.DueAt)
+		}
+		return a.Name < b.Name
+	})
+	return milestones, nil
+}
+
+// Create stores a new open milestone. It fails if one with the same name
+// already exists.
+func Create(ctx context.Context, store kvstorage.KVStore, m Milestone) error {
+	if err := ValidateName(m.Name); err != nil {
+		return err
+	}
+	m.Status = StatusOpen
+	m.CreatedAt = m.CreatedAt.UTC()
+	data, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("encoding milestone %s: %w", m.Name, err)
+	}
+	if err := store.Set(ctx, m.Name, data, kvstorage.SetOptions{FailIfExists: true}); err != nil {
+		if errors.Is(err, kvstorage.ErrAlreadyExists) {
+			return fmt.Errorf("milestone %s already exists", m.Name)
+		}
+		return fmt.Errorf("storing milestone %s: %w", m.Name, err)
+	}
+	return nil
+}
+
+// Close marks the milestone closed as of now and returns it.
+func Close(ctx context.Context, store kvstorage.KVStore, name string, now time.Time) (Milestone, error) {
+	m, err := Get(ctx, store, name)
+	if err != nil {
+		if errors.Is(err, kvstorage.ErrKeyNotFound) {
+			return Milestone{}, fmt.Errorf("milestone %s not found", name)
+		}
+		return Milestone{}, err
+	}
+	if m.Status == StatusClosed {
+		return Milestone{}, fmt.Errorf("milestone %s is already closed", name)
+	}
+	now = now.UTC()
+	m.Status = StatusClosed
+	m.ClosedAt = &now
+	data, err := json.Marshal(m)
+	if err != nil {
+		return Milestone{}, fmt.Errorf("encoding milestone %s: %w", name, err)
+	}
+	if err := store.Update(ctx, name, data); err != nil {
+		return Milestone{}, fmt.Errorf("storing milestone %s: %w", name, err)
+	}
+	return m, nil
+}
+
+// Progress counts the issues planned for a milestone. Tombstoned issues
+// are left out.
+type Progress struct {
+	Open   int
+	Closed int
+}
+
+// Total returns the number of issues counted.
+func (p Progress) Total() int {
+	return p.Open + p.Closed
+}
+
+// Count returns the progress of issues, which should all be planned for
+// the same milestone.
+func Count(issues []*issuestorage.Issue) Progress {
+	var p Progress
+	for _, issue := range issues {
+		switch issue.Status {
+		case issuestorage.StatusTombstone:
+		case issuestorage.StatusClosed:
+			p.Closed++
+		default:
+			p.Open++
+		}
+	}
+	return p
+}
+
+// Point is one day of a burndown: the issues still open at its end.
+type Point struct {
+	Date string `json:"date"` // YYYY-MM-DD
+	Open int    `json:"open"`
+}
+
+// Burndown returns the number of issues open at the end of each day from
+// from through to, in to's location. An issue counts from the day it was
+// created until the day it was closed; reopened issues count as open
+// throughout, since only the last close is recorded.
+func Burndown(issues []*issuestorage.Issue, from, to time.Time) []Point {
+	loc := to.Location()
+	day := time.Date(from.In(loc).Year(), from.In(loc).Month(), from.In(loc).Day(), 0, 0, 0, 0, loc)
+	var points []Point
+	for !day.After(to) {
+		end := day.AddDate(0, 0, 1)
+		open := 0
+		for _, issue := range issues {
+			if issue.Status == issuestorage.StatusTombstone || !issue.CreatedAt.Before(end) {
+				continue
+			}
+			if issue.Status == issuestorage.StatusClosed && issue.ClosedAt != nil && issue.ClosedAt.Before(end) {
+				continue
+			}
+			open++
+		}
+		points = append(points, Point{Date: day.Format("2006-01-02"), Open: open})
+		day = end
+	}
+	return points
+}
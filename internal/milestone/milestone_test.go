@@ -0,0 +1,114 @@
+package milestone
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/kvstorage"
+	kvfs "beads-lite/internal/kvstorage/filesystem"
+)
+
+func newTestStore(t *testing.T) *kvfs.Store {
+	t.Helper()
+	store, err := kvfs.New(t.TempDir(), "milestones")
+	if err != nil {
+		t.Fatalf("failed to create kv store: %v", err)
+	}
+	if err := store.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init kv store: %v", err)
+	}
+	return store
+}
+
+func TestValidateName(t *testing.T) {
+	for _, name := range []string{"v1.2", "2026-Q4", "beta_2"} {
+		if err := ValidateName(name); err != nil {
+			t.Errorf("ValidateName(%q) = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", ".hidden", "v1/2", "next release"} {
+		if err := ValidateName(name); err == nil {
+			t.Errorf("ValidateName(%q) should fail", name)
+		}
+	}
+}
+
+func TestCreateListClose(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	due := now.AddDate(0, 1, 0)
+
+	for _, m := range []Milestone{
+		{Name: "someday", CreatedAt: now},
+		{Name: "v2.0", DueAt: &due, CreatedAt: now},
+		{Name: "v1.9", DueAt: &now, CreatedAt: now},
+	} {
+		if err := Create(ctx, store, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := Create(ctx, store, Milestone{Name: "v2.0"}); err == nil {
+		t.Error("creating a duplicate milestone should fail")
+	}
+
+	list, err := List(ctx, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, m := range list {
+		names = append(names, m.Name)
+		if m.Status != StatusOpen {
+			t.Errorf("%s status = %q", m.Name, m.Status)
+		}
+	}
+	if want := []string{"v1.9", "v2.0", "someday"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("List order = %v, want %v", names, want)
+	}
+
+	closed, err := Close(ctx, store, "v1.9", now.Add(time.Hour))
+	if err != nil || closed.Status != StatusClosed || closed.ClosedAt == nil {
+		t.Fatalf("Close = %+v, %v", closed, err)
+	}
+	if got, _ := Get(ctx, store, "v1.9"); got.Status != StatusClosed {
+		t.Errorf("stored status = %q", got.Status)
+	}
+	if _, err := Close(ctx, store, "v1.9", now); err == nil {
+		t.Error("closing a closed milestone should fail")
+	}
+	if _, err := Get(ctx, store, "v3"); !errors.Is(err, kvstorage.ErrKeyNotFound) {
+		t.Errorf("Get missing = %v, want ErrKeyNotFound", err)
+	}
+}
+
+func TestCountAndBurndown(t *testing.T) {
+	day := func(d, h int) time.Time { return time.Date(2026, 10, d, h, 0, 0, 0, time.UTC) }
+	closedAt := func(d int) *time.Time { t := day(d, 15); return &t }
+	issues := []*issuestorage.Issue{
+		{Status: issuestorage.StatusOpen, CreatedAt: day(1, 9)},
+		{Status: issuestorage.StatusInProgress, CreatedAt: day(2, 9)},
+		{Status: issuestorage.StatusClosed, CreatedAt: day(1, 9), ClosedAt: closedAt(2)},
+		{Status: issuestorage.StatusClosed, CreatedAt: day(1, 9), ClosedAt: closedAt(4)},
+		{Status: issuestorage.StatusTombstone, CreatedAt: day(1, 9)},
+	}
+
+	if got := Count(issues); got != (Progress{Open: 2, Closed: 2}) || got.Total() != 4 {
+		t.Errorf("Count = %+v", got)
+	}
+
+	got := Burndown(issues, day(1, 12), day(4, 18))
+	want := []Point{
+		{Date: "2026-10-01", Open: 3},
+		{Date: "2026-10-02", Open: 3},
+		{Date: "2026-10-03", Open: 3},
+		{Date: "2026-10-04", Open: 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Burndown = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,185 @@
+// Package notify delivers desktop notifications for issue events through
+// the platform's notification center: osascript on macOS, notify-send
+// (libnotify) on Linux and the BSDs, and a PowerShell toast on Windows.
+//
+// Notifications are opt-in via the "notify.enabled" config key; the
+// "notify.events" key narrows which event kinds are delivered.
+package notify
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Config keys.
+const (
+	EnabledKey = "notify.enabled"
+	EventsKey  = "notify.events"
+)
+
+// Kind identifies the type of event being notified.
+type Kind string
+
+const (
+	KindAssigned     Kind = "assigned"      // issue assigned to the watcher
+	KindMentioned    Kind = "mentioned"     // watcher mentioned in a comment
+	KindGateResolved Kind = "gate_resolved" // gate closed
+	KindNeedsInfo    Kind = "needs_info"    // issue the watcher reported needs more information
+)
+
+// Kinds lists every event kind, in display order.
+var Kinds = []Kind{KindAssigned, KindMentioned, KindGateResolved, KindNeedsInfo}
+
+// ParseKind parses an event kind name.
+func ParseKind(s string) (Kind, error) {
+	for _, k := range Kinds {
+		if string(k) == strings.TrimSpace(s) {
+			return k, nil
+		}
+	}
+	names := make([]string, len(Kinds))
+	for i, k := range Kinds {
+		names[i] = string(k)
+	}
+	return "", fmt.Errorf("unknown notification event %q (valid: %s)", s, strings.Join(names, ", "))
+}
+
+// Event is a single notification.
+type Event struct {
+	Kind    Kind
+	IssueID string
+	Title   string // issue title
+	Detail  string // e.g. "assigned by alice"
+}
+
+// Executor runs an external command.
+type Executor func(name string, args ...string) error
+
+func defaultExecutor(name string, args ...string) error {
+	return exec.Command(name, args...).Run()
+}
+
+// Notifier sends events to the desktop.
+type Notifier struct {
+	goos  string
+	exec  Executor
+	kinds map[Kind]bool
+}
+
+// New returns a Notifier for the current platform delivering the given
+// kinds (all kinds if none are given).
+func New(kinds ...Kind) *Notifier {
+	return NewWithExecutor(runtime.GOOS, defaultExecutor, kinds...)
+}
+
+// NewWithExecutor returns a Notifier for goos that runs commands through
+// exec. It exists so tests can observe the commands that would be run.
+func NewWithExecutor(goos string, exec Executor, kinds ...Kind) *Notifier {
+	n := &Notifier{goos: goos, exec: exec, kinds: make(map[Kind]bool)}
+	if len(kinds) == 0 {
+		kinds = Kinds
+	}
+	for _, k := range kinds {
+		n.kinds[k] = true
+	}
+	return n
+}
+
+// FromConfig returns a Notifier configured from notify.* keys, or nil if
+// notifications are not enabled.
+func FromConfig(get func(key string) (string, bool)) (*Notifier, error) {
+	if v, ok := get(EnabledKey); !ok || v != "true" {
+		return nil, nil
+	}
+	var kinds []Kind
+	if v, ok := get(EventsKey); ok {
+		for _, s := range strings.Split(v, ",") {
+			if strings.TrimSpace(s) == "" {
+				continue
+			}
+			k, err := ParseKind(s)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", EventsKey, err)
+			}
+			kinds = append(kinds, k)
+		}
+	}
+	return New(kinds...), nil
+}
+
+// Notify delivers ev if its kind is enabled. A nil Notifier ignores all
+// events, so callers need not check whether notifications are configured.
+func (n *Notifier) Notify(ev Event) error {
+	if n == nil || !n.kinds[ev.Kind] {
+		return nil
+	}
+	title, body := message(ev)
+	name, args, err := command(n.goos, title, body)
+	if err != nil {
+		return err
+	}
+	if err := n.exec(name, args...); err != nil {
+		return fmt.Errorf("sending notification via %s: %w", name, err)
+	}
+	return nil
+}
+
+// message builds the notification title and body for ev.
+func message(ev Event) (string, string) {
+	var title string
+	switch ev.Kind {
+	case KindAssigned:
+		title = "Assigned: " + ev.IssueID
+	case KindMentioned:
+		title = "Mentioned in " + ev.IssueID
+	case KindGateResolved:
+		title = "Gate resolved: " + ev.IssueID
+	case KindNeedsInfo:
+		title = "Needs info: " + ev.IssueID
+	default:
+		title = ev.IssueID
+	}
+	body := ev.Title
+	if ev.Detail != "" {
+		body += "\n" + ev.Detail
+	}
+	return title, body
+}
+
+// command returns the platform command that shows a notification.
+func command(goos, title, body string) (string, []string, error) {
+	switch goos {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(body), appleScriptString(title))
+		return "osascript", []string{"-e", script}, nil
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return "notify-send", []string{"--app-name=bd", title, body}, nil
+	case "windows":
+		return "powershell", []string{"-NoProfile", "-NonInteractive", "-Command", windowsToast(title, body)}, nil
+	}
+	return "", nil, fmt.Errorf("desktop notifications are not supported on %s", goos)
+}
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// powerShellString quotes s as a single-quoted PowerShell string literal.
+func powerShellString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// windowsToast returns a PowerShell script that shows a toast notification.
+func windowsToast(title, body string) string {
+	return `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null;` +
+		`$t = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02);` +
+		`$n = $t.GetElementsByTagName('text');` +
+		`$n.Item(0).AppendChild($t.CreateTextNode(` + powerShellString(title) + `)) | Out-Null;` +
+		`$n.Item(1).AppendChild($t.CreateTextNode(` + powerShellString(body) + `)) | Out-Null;` +
+		`[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('bd').Show([Windows.UI.Notifications.ToastNotification]::new($t))`
+}
@@ -0,0 +1,89 @@
+package notify
+
+import (
+	"strings"
+	"testing"
+)
+
+type call struct {
+	name string
+	args []string
+}
+
+func recorder(calls *[]call) Executor {
+	return func(name string, args ...string) error {
+		*calls = append(*calls, call{name, args})
+		return nil
+	}
+}
+
+func TestNotifyPlatformCommands(t *testing.T) {
+	ev := Event{Kind: KindAssigned, IssueID: "bd-a1b2", Title: `Fix "login" bug`, Detail: "assigned by alice"}
+
+	tests := []struct {
+		goos string
+		name string
+		want string
+	}{
+		{"darwin", "osascript", `display notification "Fix \"login\" bug` + "\n" + `assigned by alice" with title "Assigned: bd-a1b2"`},
+		{"linux", "notify-send", "Assigned: bd-a1b2"},
+		{"windows", "powershell", "CreateTextNode('Assigned: bd-a1b2')"},
+	}
+	for _, tt := range tests {
+		var calls []call
+		n := NewWithExecutor(tt.goos, recorder(&calls))
+		if err := n.Notify(ev); err != nil {
+			t.Fatalf("%s: Notify failed: %v", tt.goos, err)
+		}
+		if len(calls) != 1 || calls[0].name != tt.name {
+			t.Fatalf("%s: expected one %s call, got %+v", tt.goos, tt.name, calls)
+		}
+		if joined := strings.Join(calls[0].args, " "); !strings.Contains(joined, tt.want) {
+			t.Errorf("%s: args %q do not contain %q", tt.goos, joined, tt.want)
+		}
+	}
+
+	n := NewWithExecutor("plan9", recorder(new([]call)))
+	if err := n.Notify(ev); err == nil {
+		t.Error("expected error on unsupported platform")
+	}
+}
+
+func TestNotifyFiltersKinds(t *testing.T) {
+	var calls []call
+	n := NewWithExecutor("linux", recorder(&calls), KindGateResolved)
+	n.Notify(Event{Kind: KindAssigned, IssueID: "bd-1"})
+	n.Notify(Event{Kind: KindGateResolved, IssueID: "bd-2"})
+	if len(calls) != 1 || calls[0].args[1] != "Gate resolved: bd-2" {
+		t.Errorf("expected only the gate notification, got %+v", calls)
+	}
+
+	var nilNotifier *Notifier
+	if err := nilNotifier.Notify(Event{Kind: KindAssigned}); err != nil {
+		t.Errorf("nil notifier should ignore events, got %v", err)
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	cfg := map[string]string{}
+	get := func(k string) (string, bool) { v, ok := cfg[k]; return v, ok }
+
+	if n, err := FromConfig(get); n != nil || err != nil {
+		t.Errorf("expected nil notifier when disabled, got %v %v", n, err)
+	}
+
+	cfg[EnabledKey] = "true"
+	cfg[EventsKey] = "assigned, mentioned"
+	n, err := FromConfig(get)
+	if err != nil || n == nil {
+		t.Fatalf("FromConfig failed: %v", err)
+	}
+	if !n.kinds[KindAssigned] || !n.kinds[KindMentioned] || n.kinds[KindGateResolved] {
+		t.Errorf("unexpected kinds: %v", n.kinds)
+	}
+
+	cfg[EventsKey] = "assigned,closed"
+	if _, err := FromConfig(get); err == nil {
+		t.Error("expected error for unknown event kind")
+	}
+}
@@ -0,0 +1,140 @@
+// Package people provides helpers for managing the people registry in a KV table ("people").
+package people
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/kvstorage"
+)
+
+// Person is someone issues can be assigned to and comments can @mention.
+type Person struct {
+	Handle    string    `json:"handle"`
+	Name      string    `json:"name,omitempty"`
+	Email     string    `json:"email,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// handlePattern matches handles that can be @mentioned, such as "alice"
+// or "bob.smith". Handles are used as KV keys, so they cannot contain
+// separators.
+var handlePattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]*$`)
+
+// ValidateHandle returns an error if handle cannot be used as a handle.
+func ValidateHandle(handle string) error {
+	if !handlePattern.MatchString(handle) || strings.HasSuffix(handle, ".") || strings.HasSuffix(handle, "-") {
+		return fmt.Errorf("invalid handle %q: use letters, digits, '_', '.' and '-', not ending in '.' or '-'", handle)
+	}
+	return nil
+}
+
+// Identities returns the identities p is known by: the handle, then the
+// email and name if set.
+func (p Person) Identities() []string {
+	ids := []string{p.Handle}
+	if p.Email != "" {
+		ids = append(ids, p.Email)
+	}
+	if p.Name != "" {
+		ids = append(ids, p.Name)
+	}
+	return ids
+}
+
+// Matches reports whether identity, such as an assignee, a git user name
+// or an @mention, refers to p. Matching ignores case, and the local part
+// of p's email matches too ("alice" for alice@example.com).
+func (p Person) Matches(identity string) bool {
+	identity = strings.TrimPrefix(identity, "@")
+	for _, id := range p.Identities() {
+		if issuestorage.MentionMatches(identity, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// Find returns the first person identity refers to.
+func Find(people []Person, identity string) (Person, bool) {
+	for _, p := range people {
+		if p.Matches(identity) {
+			return p, true
+		}
+	}
+	return Person{}, false
+}
+
+// Get retrieves the person with the given handle.
+// Returns kvstorage.ErrKeyNotFound if there is no such person.
+func Get(ctx context.Context, store kvstorage.KVStore, handle string) (Person, error) {
+	data, err := store.Get(ctx, handle)
+	if err != nil {
+		if errors.Is(err, kvstorage.ErrKeyNotFound) {
+			return Person{}, kvstorage.ErrKeyNotFound
+		}
+		return Person{}, fmt.Errorf("getting person %s: %w", handle, err)
+	}
+	var p Person
+	if err := json.Unmarshal(data, &p); err != nil {
+		return Person{}, fmt.Errorf("decoding person %s: %w", handle, err)
+	}
+	return p, nil
+}
+
+// List returns everyone in the registry, ordered by handle.
+func List(ctx context.Context, store kvstorage.KVStore) ([]Person, error) {
+	handles, err := store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing people: %w", err)
+	}
+	sort.Strings(handles)
+	people := make([]Person, 0, len(handles))
+	for _, handle := range handles {
+		p, err := Get(ctx, store, handle)
+		if err != nil {
+			return nil, err
+		}
+		people = append(people, p)
+	}
+	return people, nil
+}
+
+// Set stores p, failing if someone with the same handle already exists
+// unless replace is true.
+func Set(ctx context.Context, store kvstorage.KVStore, p Person, replace bool) error {
+	if err := ValidateHandle(p.Handle); err != nil {
+		return err
+	}
+	p.CreatedAt = p.CreatedAt.UTC()
+	data, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("encoding person %s: %w", p.Handle, err)
+	}
+	if err := store.Set(ctx, p.Handle, data, kvstorage.SetOptions{FailIfExists: !replace}); err != nil {
+		if errors.Is(err, kvstorage.ErrAlreadyExists) {
+			return fmt.Errorf("%s is already registered", p.Handle)
+		}
+		return fmt.Errorf("storing person %s: %w", p.Handle, err)
+	}
+	return nil
+}
+
+// Remove deletes the person with the given handle.
+// Returns kvstorage.ErrKeyNotFound if there is no such person.
+func Remove(ctx context.Context, store kvstorage.KVStore, handle string) error {
+	if err := store.Delete(ctx, handle); err != nil {
+		if errors.Is(err, kvstorage.ErrKeyNotFound) {
+			return kvstorage.ErrKeyNotFound
+		}
+		return fmt.Errorf("removing person %s: %w", handle, err)
+	}
+	return nil
+}
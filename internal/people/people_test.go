@@ -0,0 +1,112 @@
+package people
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"beads-lite/internal/kvstorage"
+	kvfs "beads-lite/internal/kvstorage/filesystem"
+)
+
+func newTestStore(t *testing.T) *kvfs.Store {
+	t.Helper()
+	store, err := kvfs.New(t.TempDir(), "people")
+	if err != nil {
+		t.Fatalf("failed to create kv store: %v", err)
+	}
+	if err := store.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init kv store: %v", err)
+	}
+	return store
+}
+
+func TestValidateHandle(t *testing.T) {
+	for _, h := range []string{"alice", "bob.smith", "c_d-e", "42"} {
+		if err := ValidateHandle(h); err != nil {
+			t.Errorf("ValidateHandle(%q) = %v", h, err)
+		}
+	}
+	for _, h := range []string{"", "a b", "a/b", "alice.", "bob-", ".x", "@alice"} {
+		if err := ValidateHandle(h); err == nil {
+			t.Errorf("ValidateHandle(%q) should fail", h)
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	alice := Person{Handle: "alice", Name: "Alice Liddell", Email: "al@example.com"}
+	tests := []struct {
+		identity string
+		want     bool
+	}{
+		{"alice", true},
+		{"@Alice", true},
+		{"al@example.com", true},
+		{"al", true},
+		{"alice liddell", true},
+		{"bob", false},
+		{"ali", false},
+	}
+	for _, tt := range tests {
+		if got := alice.Matches(tt.identity); got != tt.want {
+			t.Errorf("Matches(%q) = %v, want %v", tt.identity, got, tt.want)
+		}
+	}
+
+	if p, ok := Find([]Person{{Handle: "bob"}, alice}, "al@example.com"); !ok || p.Handle != "alice" {
+		t.Errorf("Find = %v, %v", p, ok)
+	}
+	if _, ok := Find(nil, "alice"); ok {
+		t.Error("Find in an empty registry should fail")
+	}
+}
+
+func TestSetGetListRemove(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	created := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	if err := Set(ctx, store, Person{Handle: "bob", CreatedAt: created}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := Set(ctx, store, Person{Handle: "alice", Email: "alice@example.com", CreatedAt: created}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := Set(ctx, store, Person{Handle: "alice"}, false); err == nil {
+		t.Error("adding alice twice should fail without replace")
+	}
+	if err := Set(ctx, store, Person{Handle: "alice", Name: "Alice", CreatedAt: created}, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := Set(ctx, store, Person{Handle: "no good"}, false); err == nil {
+		t.Error("an invalid handle should be rejected")
+	}
+
+	p, err := Get(ctx, store, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "Alice" || p.Email != "" || !p.CreatedAt.Equal(created) {
+		t.Errorf("Get(alice) = %+v", p)
+	}
+
+	all, err := List(ctx, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 || all[0].Handle != "alice" || all[1].Handle != "bob" {
+		t.Errorf("List = %+v", all)
+	}
+
+	if err := Remove(ctx, store, "bob"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Remove(ctx, store, "bob"); !errors.Is(err, kvstorage.ErrKeyNotFound) {
+		t.Errorf("removing bob twice = %v", err)
+	}
+	if _, err := Get(ctx, store, "bob"); !errors.Is(err, kvstorage.ErrKeyNotFound) {
+		t.Errorf("Get(bob) after remove = %v", err)
+	}
+}
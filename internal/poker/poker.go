@@ -0,0 +1,150 @@
+// Package poker runs blind estimation rounds ("planning poker") for issues
+// in a KV table ("estimates"): each voter's estimate is kept hidden, under
+// a key of its own, until the round is revealed and a consensus value
+// chosen.
+package poker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"beads-lite/internal/kvstorage"
+)
+
+// Vote is one voter's estimate, in minutes of work.
+type Vote struct {
+	Voter   string    `json:"voter"`
+	Minutes int       `json:"minutes"`
+	At      time.Time `json:"at"`
+}
+
+// Round is the open estimation round for an issue: the votes cast on it,
+// ordered by voter.
+type Round struct {
+	IssueID string `json:"issue_id"`
+	Votes   []Vote `json:"votes"`
+}
+
+// Voters returns who has voted, ordered by name.
+func (r Round) Voters() []string {
+	voters := make([]string, len(r.Votes))
+	for i, v := range r.Votes {
+		voters[i] = v.Voter
+	}
+	return voters
+}
+
+// ballot is how a vote is stored: one key per issue and voter, so voters
+// on different clones never write the same file and merge cleanly.
+type ballot struct {
+	IssueID string `json:"issue_id"`
+	Vote
+}
+
+// ballotKey returns the key of voter's vote on issueID. The voter is
+// escaped so it is safe as a file name; the issue ID is matched on the
+// stored ballot, since issue IDs may themselves contain dots.
+func ballotKey(issueID, voter string) string {
+	return issueID + "." + url.PathEscape(voter)
+}
+
+// ballots returns the keys and votes cast on issueID.
+func ballots(ctx context.Context, store kvstorage.KVStore, issueID string) ([]string, []Vote, error) {
+	keys, err := store.List(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing estimates: %w", err)
+	}
+	var (
+		found []string
+		votes []Vote
+	)
+	for _, key := range keys {
+		if !strings.HasPrefix(key, issueID+".") {
+			continue
+		}
+		data, err := store.Get(ctx, key)
+		if errors.Is(err, kvstorage.ErrKeyNotFound) {
+			continue // revealed meanwhile
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting estimate %s: %w", key, err)
+		}
+		var b ballot
+		if err := json.Unmarshal(data, &b); err != nil {
+			return nil, nil, fmt.Errorf("decoding estimate %s: %w", key, err)
+		}
+		if b.IssueID != issueID || b.Voter == "" {
+			continue
+		}
+		found = append(found, key)
+		votes = append(votes, b.Vote)
+	}
+	sort.Slice(votes, func(i, j int) bool { return votes[i].Voter < votes[j].Voter })
+	return found, votes, nil
+}
+
+// Get returns the open round for issueID.
+// Returns kvstorage.ErrKeyNotFound if nobody has voted on it.
+func Get(ctx context.Context, store kvstorage.KVStore, issueID string) (Round, error) {
+	_, votes, err := ballots(ctx, store, issueID)
+	if err != nil {
+		return Round{}, err
+	}
+	if len(votes) == 0 {
+		return Round{}, kvstorage.ErrKeyNotFound
+	}
+	return Round{IssueID: issueID, Votes: votes}, nil
+}
+
+// Cast records v in the open round for issueID, opening one if needed,
+// and returns the round. A voter who votes again replaces their vote.
+func Cast(ctx context.Context, store kvstorage.KVStore, issueID string, v Vote) (Round, error) {
+	if v.Minutes <= 0 {
+		return Round{}, fmt.Errorf("an estimate must be more than zero")
+	}
+	if v.Voter == "" {
+		return Round{}, fmt.Errorf("a vote needs a voter")
+	}
+	v.At = v.At.UTC()
+	data, err := json.Marshal(ballot{IssueID: issueID, Vote: v})
+	if err != nil {
+		return Round{}, fmt.Errorf("encoding estimate for %s: %w", issueID, err)
+	}
+	if err := store.Set(ctx, ballotKey(issueID, v.Voter), data, kvstorage.SetOptions{}); err != nil {
+		return Round{}, fmt.Errorf("storing estimate for %s: %w", issueID, err)
+	}
+	return Get(ctx, store, issueID)
+}
+
+// Clear ends the open round for issueID, discarding its votes.
+func Clear(ctx context.Context, store kvstorage.KVStore, issueID string) error {
+	keys, _, err := ballots(ctx, store, issueID)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := store.Delete(ctx, key); err != nil && !errors.Is(err, kvstorage.ErrKeyNotFound) {
+			return fmt.Errorf("clearing estimates for %s: %w", issueID, err)
+		}
+	}
+	return nil
+}
+
+// Consensus returns the median of votes, taking the higher of the two
+// middle votes when there is an even number, so the result is always an
+// estimate someone gave and errs on the side of more work. unanimous
+// reports whether every vote was the same. votes must not be empty.
+func Consensus(votes []Vote) (minutes int, unanimous bool) {
+	sorted := make([]int, len(votes))
+	for i, v := range votes {
+		sorted[i] = v.Minutes
+	}
+	sort.Ints(sorted)
+	return sorted[len(sorted)/2], sorted[0] == sorted[len(sorted)-1]
+}
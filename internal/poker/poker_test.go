@@ -0,0 +1,104 @@
+package poker
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"beads-lite/internal/kvstorage"
+	kvfs "beads-lite/internal/kvstorage/filesystem"
+)
+
+func newTestStore(t *testing.T) *kvfs.Store {
+	t.Helper()
+	store, err := kvfs.New(t.TempDir(), "estimates")
+	if err != nil {
+		t.Fatalf("failed to create kv store: %v", err)
+	}
+	if err := store.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init kv store: %v", err)
+	}
+	return store
+}
+
+func TestCastAndClear(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	if _, err := Get(ctx, store, "bd-a1"); !errors.Is(err, kvstorage.ErrKeyNotFound) {
+		t.Fatalf("Get before voting = %v, want ErrKeyNotFound", err)
+	}
+	for _, v := range []Vote{{"alice", 180, now}, {"bob", 480, now}, {"alice", 240, now.Add(time.Hour)}} {
+		if _, err := Cast(ctx, store, "bd-a1", v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := Cast(ctx, store, "bd-a1", Vote{Voter: "carol"}); err == nil {
+		t.Error("a zero estimate should be refused")
+	}
+
+	r, err := Get(ctx, store, "bd-a1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Vote{{"alice", 240, now.Add(time.Hour)}, {"bob", 480, now}}
+	if r.IssueID != "bd-a1" || !reflect.DeepEqual(r.Votes, want) || !reflect.DeepEqual(r.Voters(), []string{"alice", "bob"}) {
+		t.Errorf("round = %+v", r)
+	}
+
+	// Each vote has a key of its own, so voters on different clones never
+	// touch the same file; a child issue's votes stay its own.
+	if _, err := Cast(ctx, store, "bd-a1.1", Vote{"team/dave", 60, now}); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := store.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(keys)
+	if want := []string{"bd-a1.1.team%2Fdave", "bd-a1.alice", "bd-a1.bob"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("keys = %v, want %v", keys, want)
+	}
+
+	if err := Clear(ctx, store, "bd-a1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Get(ctx, store, "bd-a1"); !errors.Is(err, kvstorage.ErrKeyNotFound) {
+		t.Errorf("Get after Clear = %v, want ErrKeyNotFound", err)
+	}
+	if r, err := Get(ctx, store, "bd-a1.1"); err != nil || len(r.Votes) != 1 {
+		t.Errorf("clearing bd-a1 touched bd-a1.1: %+v, %v", r, err)
+	}
+	if err := Clear(ctx, store, "bd-a1"); err != nil {
+		t.Errorf("clearing an empty round: %v", err)
+	}
+}
+
+func TestConsensus(t *testing.T) {
+	votes := func(minutes ...int) []Vote {
+		var vs []Vote
+		for _, m := range minutes {
+			vs = append(vs, Vote{Minutes: m})
+		}
+		return vs
+	}
+	for _, tc := range []struct {
+		votes     []Vote
+		want      int
+		unanimous bool
+	}{
+		{votes(60), 60, true},
+		{votes(120, 120, 120), 120, true},
+		{votes(480, 60, 120), 120, false},
+		{votes(60, 120, 240, 480), 240, false},
+	} {
+		got, unanimous := Consensus(tc.votes)
+		if got != tc.want || unanimous != tc.unanimous {
+			t.Errorf("Consensus(%v) = %d, %v, want %d, %v", tc.votes, got, unanimous, tc.want, tc.unanimous)
+		}
+	}
+}
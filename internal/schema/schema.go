@@ -0,0 +1,219 @@
+// Package schema generates JSON Schemas for bd's --json output and issue
+// file format, and checks documents against them.
+//
+// Schemas are derived by reflection from the Go types that produce the
+// JSON, so they cannot drift from what bd prints. They are strict: objects
+// reject properties the type does not declare, and a property is required
+// unless its field is omitempty. A nil slice, map or pointer marshals as
+// null, so those schemas admit null unless the field is omitempty.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Version is the version of the published output contract. It is bumped
+// when a change could break a consumer: a property removed, renamed,
+// retyped or made optional. Adding an optional property is not breaking.
+const Version = 1
+
+// Dialect is the JSON Schema draft the schemas are written in.
+const Dialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is the subset of JSON Schema bd's schemas use.
+type Schema struct {
+	Dialect     string `json:"$schema,omitempty"`
+	ID          string `json:"$id,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Ref         string `json:"$ref,omitempty"`
+
+	// Type is a JSON type name, or a list of them for nullable values.
+	Type   any    `json:"type,omitempty"`
+	Format string `json:"format,omitempty"`
+
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+	// AdditionalProperties is false for structs and the value schema for maps.
+	AdditionalProperties any `json:"additionalProperties,omitempty"`
+
+	Items *Schema   `json:"items,omitempty"`
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+
+	Defs map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// ID returns the $id of the named schema at the current Version, e.g.
+// "urn:beads-lite:schema:v1:dep-add" for "dep add".
+func ID(name string) string {
+	return fmt.Sprintf("urn:beads-lite:schema:v%d:%s", Version, FileName(name))
+}
+
+// FileName returns the base name, without extension, the named schema is
+// published under.
+func FileName(name string) string {
+	return strings.ReplaceAll(name, " ", "-")
+}
+
+// Document returns the schema for the JSON encoding of v, as a standalone
+// document named name. Named struct types are placed in $defs.
+func Document(name, description string, v any) *Schema {
+	g := &generator{defs: map[string]*Schema{}, names: map[reflect.Type]string{}}
+	s := g.schema(reflect.TypeOf(v))
+	s.Dialect = Dialect
+	s.ID = ID(name)
+	s.Title = name
+	s.Description = description
+	if len(g.defs) > 0 {
+		s.Defs = g.defs
+	}
+	return s
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+type generator struct {
+	defs  map[string]*Schema
+	names map[reflect.Type]string
+}
+
+func (g *generator) schema(t reflect.Type) *Schema {
+	switch {
+	case t == nil:
+		return &Schema{}
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(g.schema(t.Elem()))
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: []string{"string", "null"}} // base64
+		}
+		return &Schema{Type: []string{"array", "null"}, Items: g.schema(t.Elem())}
+	case reflect.Array:
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: []string{"object", "null"}, AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		return &Schema{Ref: "#/$defs/" + g.define(t)}
+	default:
+		// Interfaces can hold anything.
+		return &Schema{}
+	}
+}
+
+// define adds a named struct type to $defs and returns its key. Types are
+// keyed by name, qualified by package only when two packages clash.
+func (g *generator) define(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if _, taken := g.defs[name]; taken {
+		name = path.Base(t.PkgPath()) + "." + name
+	}
+	g.names[t] = name
+	g.defs[name] = nil // placeholder, so recursive types terminate
+	g.defs[name] = g.object(t)
+	return name
+}
+
+// object returns the closed object schema for a struct type.
+func (g *generator) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}, AdditionalProperties: false}
+	g.addFields(s, t)
+	return s
+}
+
+func (g *generator) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.addFields(s, ft) // embedded fields are promoted
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		omitempty := strings.Contains(","+opts+",", ",omitempty,")
+		fs := g.schema(f.Type)
+		if omitempty {
+			// Nil values are omitted rather than written as null.
+			fs = notNull(fs)
+		} else {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = fs
+	}
+}
+
+// nullable returns s widened to also accept null.
+func nullable(s *Schema) *Schema {
+	switch typ := s.Type.(type) {
+	case string:
+		s.Type = []string{typ, "null"}
+		return s
+	case []string:
+		return s // already nullable
+	}
+	if s.Ref != "" {
+		return &Schema{AnyOf: []*Schema{s, {Type: "null"}}}
+	}
+	return s // the empty schema accepts null already
+}
+
+// notNull returns s narrowed to reject null.
+func notNull(s *Schema) *Schema {
+	if typ, ok := s.Type.([]string); ok && len(typ) == 2 && typ[1] == "null" {
+		s.Type = typ[0]
+	}
+	if len(s.AnyOf) == 2 && s.AnyOf[1].Type == "null" {
+		return s.AnyOf[0]
+	}
+	return s
+}
+
+// MarshalIndent returns s as indented JSON with a trailing newline, the
+// form schemas are published in.
+func MarshalIndent(s *Schema) ([]byte, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
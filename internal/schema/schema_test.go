@@ -0,0 +1,120 @@
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+type node struct {
+	Name     string  `json:"name"`
+	Children []*node `json:"children,omitempty"`
+}
+
+type sample struct {
+	ID       string            `json:"id"`
+	Count    int               `json:"count"`
+	Ratio    float64           `json:"ratio,omitempty"`
+	Labels   []string          `json:"labels"`
+	Tags     []string          `json:"tags,omitempty"`
+	Extra    map[string]int    `json:"extra,omitempty"`
+	Closed   *time.Time        `json:"closed_at,omitempty"`
+	Due      *time.Time        `json:"due_at"`
+	At       time.Time         `json:"at"`
+	Tree     *node             `json:"tree,omitempty"`
+	Parent   *node             `json:"parent"`
+	Skipped  string            `json:"-"`
+	Meta     map[string]string `json:"meta"`
+	internal string
+}
+
+func TestDocument(t *testing.T) {
+	s := Document("dep add", "desc", sample{})
+	if s.ID != "urn:beads-lite:schema:v1:dep-add" || s.Dialect != Dialect || s.Title != "dep add" {
+		t.Errorf("header = %q %q %q", s.ID, s.Dialect, s.Title)
+	}
+	if s.Ref != "#/$defs/sample" {
+		t.Fatalf("root $ref = %q", s.Ref)
+	}
+	def := s.Defs["sample"]
+	want := []string{"id", "count", "labels", "due_at", "at", "parent", "meta"}
+	if strings.Join(def.Required, ",") != strings.Join(want, ",") {
+		t.Errorf("required = %v, want %v", def.Required, want)
+	}
+	if _, ok := def.Properties["Skipped"]; ok {
+		t.Error(`json:"-" field should be skipped`)
+	}
+	if def.AdditionalProperties != false {
+		t.Error("struct schemas should be closed")
+	}
+	if _, err := MarshalIndent(s); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	s := Document("sample", "", []sample{})
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr string
+	}{
+		{"valid", `[{"id":"a","count":1,"labels":null,"due_at":null,"at":"2026-01-02T03:04:05Z","parent":null,"meta":{"k":"v"},"tree":{"name":"r","children":[{"name":"c"}]}}]`, ""},
+		{"null array", `null`, ""},
+		{"missing required", `[{"id":"a","labels":[],"due_at":null,"at":"2026-01-02T03:04:05Z","parent":null,"meta":null}]`, `$[0]: missing required property "count"`},
+		{"wrong type", `[{"id":1,"count":1,"labels":[],"due_at":null,"at":"2026-01-02T03:04:05Z","parent":null,"meta":null}]`, "$[0].id: expected string, got integer"},
+		{"unexpected property", `[{"id":"a","count":1,"labels":[],"due_at":null,"at":"2026-01-02T03:04:05Z","parent":null,"meta":null,"new":1}]`, `$[0]: unexpected property "new"`},
+		{"omitempty is not nullable", `[{"id":"a","count":1,"labels":[],"tags":null,"due_at":null,"at":"2026-01-02T03:04:05Z","parent":null,"meta":null}]`, "$[0].tags: expected array, got null"},
+		{"bad date-time", `[{"id":"a","count":1,"labels":[],"due_at":null,"at":"yesterday","parent":null,"meta":null}]`, `$[0].at: "yesterday" is not a date-time`},
+		{"float for integer", `[{"id":"a","count":1.5,"labels":[],"due_at":null,"at":"2026-01-02T03:04:05Z","parent":null,"meta":null}]`, "$[0].count: expected integer, got number"},
+		{"recursive", `[{"id":"a","count":1,"labels":[],"due_at":null,"at":"2026-01-02T03:04:05Z","parent":{"name":"p","children":[{"nom":"x"}]},"meta":null}]`, "$[0].parent.children[0]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateJSON(s, []byte(tt.doc))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateGeneratedValues(t *testing.T) {
+	now := time.Now()
+	values := []any{
+		sample{ID: "x", At: now},
+		sample{ID: "y", At: now, Closed: &now, Due: &now, Tags: []string{"a"}, Extra: map[string]int{"n": 1}, Tree: &node{Name: "t", Children: []*node{{Name: "c"}}}},
+	}
+	s := Document("sample", "", sample{})
+	for _, v := range values {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ValidateJSON(s, data); err != nil {
+			t.Errorf("%s: %v", data, err)
+		}
+	}
+}
+
+func TestCheckEmojiFree(t *testing.T) {
+	var v any
+	if err := json.Unmarshal([]byte(`{"a":["plain → arrow","└─ tree"],"b":"ok"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckEmojiFree(v); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, s := range []string{"✓ done", "● open", "⚠ warning", "📎 file", "⏳"} {
+		if err := CheckEmojiFree(map[string]any{"k": s}); err == nil {
+			t.Errorf("%q: expected an error", s)
+		}
+	}
+}
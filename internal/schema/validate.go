@@ -0,0 +1,213 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ValidateJSON decodes data and checks it against s. See Validate.
+func ValidateJSON(s *Schema, data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return fmt.Errorf("decoding JSON: %w", err)
+	}
+	return Validate(s, v)
+}
+
+// Validate checks a decoded JSON value against s, a document returned by
+// Document. Numbers must be decoded as json.Number. The error names the
+// path of the first value that does not match, e.g. "$[0].status".
+func Validate(s *Schema, v any) error {
+	return (&validator{root: s}).check(s, v, "$")
+}
+
+type validator struct {
+	root *Schema
+}
+
+func (c *validator) check(s *Schema, v any, at string) error {
+	if s.Ref != "" {
+		name, ok := strings.CutPrefix(s.Ref, "#/$defs/")
+		def := c.root.Defs[name]
+		if !ok || def == nil {
+			return fmt.Errorf("%s: unresolvable $ref %q", at, s.Ref)
+		}
+		s = def
+	}
+
+	if len(s.AnyOf) > 0 {
+		var errs []string
+		for _, alt := range s.AnyOf {
+			err := c.check(alt, v, at)
+			if err == nil {
+				return nil
+			}
+			errs = append(errs, err.Error())
+		}
+		return fmt.Errorf("%s: matches no alternative (%s)", at, strings.Join(errs, "; "))
+	}
+
+	if s.Type != nil {
+		got := typeOf(v)
+		if !typeAllowed(s.Type, got) {
+			return fmt.Errorf("%s: expected %s, got %s", at, typeName(s.Type), got)
+		}
+	}
+
+	switch v := v.(type) {
+	case string:
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, v); err != nil {
+				return fmt.Errorf("%s: %q is not a date-time", at, v)
+			}
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range v {
+				if err := c.check(s.Items, item, fmt.Sprintf("%s[%d]", at, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				return fmt.Errorf("%s: missing required property %q", at, name)
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			sub, ok := s.Properties[k]
+			if !ok {
+				switch extra := s.AdditionalProperties.(type) {
+				case bool:
+					if !extra {
+						return fmt.Errorf("%s: unexpected property %q", at, k)
+					}
+					continue
+				case *Schema:
+					sub = extra
+				default:
+					continue
+				}
+			}
+			if err := c.check(sub, v[k], at+"."+k); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// typeOf returns the JSON Schema type of a decoded value.
+func typeOf(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case float64:
+		if v == float64(int64(v)) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+func typeAllowed(want any, got string) bool {
+	var allowed []string
+	switch want := want.(type) {
+	case string:
+		allowed = []string{want}
+	case []string:
+		allowed = want
+	}
+	for _, t := range allowed {
+		if t == got || (t == "number" && got == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func typeName(t any) string {
+	if types, ok := t.([]string); ok {
+		return strings.Join(types, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+// CheckEmojiFree returns an error naming the first string in a decoded
+// JSON value that contains an emoji or pictographic symbol (✓, ●, ⚠ and
+// the like). Those decorate bd's text output and must never leak into
+// JSON, where consumers compare strings exactly.
+func CheckEmojiFree(v any) error {
+	return checkEmojiFree(v, "$")
+}
+
+func checkEmojiFree(v any, at string) error {
+	switch v := v.(type) {
+	case string:
+		for _, r := range v {
+			if isEmoji(r) {
+				return fmt.Errorf("%s: %q contains %q", at, v, r)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			if err := checkEmojiFree(item, fmt.Sprintf("%s[%d]", at, i)); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if err := checkEmojiFree(v[k], at+"."+k); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isEmoji reports whether r is in a block of emoji or pictographic
+// symbols. Arrows and box-drawing characters are not included.
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x2300 && r <= 0x23FF: // Miscellaneous Technical (⌛, ⏳)
+	case r >= 0x25A0 && r <= 0x27BF: // Geometric Shapes, Misc Symbols, Dingbats
+	case r >= 0x2B00 && r <= 0x2BFF: // Misc Symbols and Arrows (⭐)
+	case r >= 0x1F000 && r <= 0x1FAFF: // emoji planes
+	case r == 0xFE0F: // emoji variation selector
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,172 @@
+// Package sprint provides helpers for managing time-boxed sprints in a KV table ("sprints").
+package sprint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"beads-lite/internal/kvstorage"
+)
+
+// Sprint is a time box that issues are planned into. At most one sprint is
+// active at a time. Completed and CarriedOver are recorded when it closes.
+type Sprint struct {
+	Name        string     `json:"name"`
+	Goal        string     `json:"goal,omitempty"`
+	Status      string     `json:"status"`
+	StartAt     time.Time  `json:"start_at"`
+	EndAt       time.Time  `json:"end_at"` // last day of the sprint
+	ClosedAt    *time.Time `json:"closed_at,omitempty"`
+	Completed   []string   `json:"completed,omitempty"`    // issues closed in the sprint
+	CarriedOver []string   `json:"carried_over,omitempty"` // issues still open when it closed
+}
+
+// Status constants for the sprint lifecycle.
+const (
+	StatusActive = "active"
+	StatusClosed = "closed"
+)
+
+// ErrNoActiveSprint is returned by Active when no sprint is active, and by
+// Latest when there are no sprints at all.
+var ErrNoActiveSprint = errors.New("no active sprint")
+
+// namePattern matches sprint names such as "sprint-14" or "2026.W42".
+// Names are used as KV keys, so they cannot contain separators.
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ValidateName returns an error if name cannot be used as a sprint name.
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid sprint name %q: use letters, digits, '.', '_' and '-', starting with a letter or digit", name)
+	}
+	return nil
+}
+
+// Get retrieves the sprint with the given name.
+// Returns kvstorage.ErrKeyNotFound if the sprint does not exist.
+func Get(ctx context.Context, store kvstorage.KVStore, name string) (Sprint, error) {
+	data, err := store.Get(ctx, name)
+	if err != nil {
+		if errors.Is(err, kvstorage.ErrKeyNotFound) {
+			return Sprint{}, kvstorage.ErrKeyNotFound
+		}
+		return Sprint{}, fmt.Errorf("getting sprint %s: %w", name, err)
+	}
+	var s Sprint
+	if err := json.Unmarshal(data, &s); err != nil {
+		return Sprint{}, fmt.Errorf("decoding sprint %s: %w", name, err)
+	}
+	return s, nil
+}
+
+// List returns every sprint, the earliest started first.
+func List(ctx context.Context, store kvstorage.KVStore) ([]Sprint, error) {
+	names, err := store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing sprints: %w", err)
+	}
+	sprints := make([]Sprint, 0, len(names))
+	for _, name := range names {
+		s, err := Get(ctx, store, name)
+		if err != nil {
+			return nil, err
+		}
+		sprints = append(sprints, s)
+	}
+	sort.Slice(sprints, func(i, j int) bool {
+		if !sprints[i].StartAt.Equal(sprints[j].StartAt) {
+			return sprints[i].StartAt.Before(sprints[j].StartAt)
+		}
+		return sprints[i].Name < sprints[j].Name
+	})
+	return sprints, nil
+}
+
+// Active returns the active sprint, or ErrNoActiveSprint if there is none.
+func Active(ctx context.Context, store kvstorage.KVStore) (Sprint, error) {
+	sprints, err := List(ctx, store)
+	if err != nil {
+		return Sprint{}, err
+	}
+	for _, s := range sprints {
+		if s.Status == StatusActive {
+			return s, nil
+		}
+	}
+	return Sprint{}, ErrNoActiveSprint
+}
+
+// Latest returns the active sprint if there is one, otherwise the one
+// started last. Returns ErrNoActiveSprint if there are no sprints.
+func Latest(ctx context.Context, store kvstorage.KVStore) (Sprint, error) {
+	sprints, err := List(ctx, store)
+	if err != nil {
+		return Sprint{}, err
+	}
+	for _, s := range sprints {
+		if s.Status == StatusActive {
+			return s, nil
+		}
+	}
+	if len(sprints) == 0 {
+		return Sprint{}, ErrNoActiveSprint
+	}
+	return sprints[len(sprints)-1], nil
+}
+
+// Start stores s as the active sprint. It fails if another sprint is
+// active or one with the same name exists.
+func Start(ctx context.Context, store kvstorage.KVStore, s Sprint) error {
+	if err := ValidateName(s.Name); err != nil {
+		return err
+	}
+	if s.EndAt.Before(s.StartAt) {
+		return fmt.Errorf("sprint %s would end before it starts", s.Name)
+	}
+	active, err := Active(ctx, store)
+	if err == nil {
+		return fmt.Errorf("sprint %s is still active: close it with 'bd sprint close' first", active.Name)
+	}
+	if !errors.Is(err, ErrNoActiveSprint) {
+		return err
+	}
+	s.Status = StatusActive
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("encoding sprint %s: %w", s.Name, err)
+	}
+	if err := store.Set(ctx, s.Name, data, kvstorage.SetOptions{FailIfExists: true}); err != nil {
+		if errors.Is(err, kvstorage.ErrAlreadyExists) {
+			return fmt.Errorf("sprint %s already exists", s.Name)
+		}
+		return fmt.Errorf("storing sprint %s: %w", s.Name, err)
+	}
+	return nil
+}
+
+// Close marks the active sprint s closed as of now, recording which of
+// its issues were completed and which are carried over.
+func Close(ctx context.Context, store kvstorage.KVStore, s Sprint, now time.Time, completed, carriedOver []string) (Sprint, error) {
+	if s.Status != StatusActive {
+		return Sprint{}, fmt.Errorf("sprint %s is not active", s.Name)
+	}
+	now = now.UTC()
+	s.Status = StatusClosed
+	s.ClosedAt = &now
+	s.Completed = completed
+	s.CarriedOver = carriedOver
+	data, err := json.Marshal(s)
+	if err != nil {
+		return Sprint{}, fmt.Errorf("encoding sprint %s: %w", s.Name, err)
+	}
+	if err := store.Update(ctx, s.Name, data); err != nil {
+		return Sprint{}, fmt.Errorf("storing sprint %s: %w", s.Name, err)
+	}
+	return s, nil
+}
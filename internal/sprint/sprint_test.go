@@ -0,0 +1,92 @@
+package sprint
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"beads-lite/internal/kvstorage"
+	kvfs "beads-lite/internal/kvstorage/filesystem"
+)
+
+func newTestStore(t *testing.T) *kvfs.Store {
+	t.Helper()
+	store, err := kvfs.New(t.TempDir(), "sprints")
+	if err != nil {
+		t.Fatalf("failed to create kv store: %v", err)
+	}
+	if err := store.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init kv store: %v", err)
+	}
+	return store
+}
+
+func TestValidateName(t *testing.T) {
+	for _, name := range []string{"sprint-14", "2026.W42"} {
+		if err := ValidateName(name); err != nil {
+			t.Errorf("ValidateName(%q) = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "-next", "a/b", "sprint 14"} {
+		if err := ValidateName(name); err == nil {
+			t.Errorf("ValidateName(%q) should fail", name)
+		}
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+
+	if _, err := Active(ctx, store); !errors.Is(err, ErrNoActiveSprint) {
+		t.Fatalf("Active on empty store = %v", err)
+	}
+	if _, err := Latest(ctx, store); !errors.Is(err, ErrNoActiveSprint) {
+		t.Fatalf("Latest on empty store = %v", err)
+	}
+	if err := Start(ctx, store, Sprint{Name: "s1", StartAt: day(5), EndAt: day(1)}); err == nil {
+		t.Error("a sprint ending before it starts should be refused")
+	}
+
+	if err := Start(ctx, store, Sprint{Name: "s1", Goal: "Ship search", StartAt: day(5), EndAt: day(16)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Start(ctx, store, Sprint{Name: "s2", StartAt: day(19), EndAt: day(30)}); err == nil {
+		t.Error("starting a second sprint while s1 is active should fail")
+	}
+	active, err := Active(ctx, store)
+	if err != nil || active.Name != "s1" || active.Status != StatusActive {
+		t.Fatalf("Active = %+v, %v", active, err)
+	}
+
+	closed, err := Close(ctx, store, active, day(16), []string{"bd-1"}, []string{"bd-2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Close(ctx, store, closed, day(17), nil, nil); err == nil {
+		t.Error("closing a closed sprint should fail")
+	}
+	stored, err := Get(ctx, store, "s1")
+	if err != nil || stored.Status != StatusClosed || !reflect.DeepEqual(stored.Completed, []string{"bd-1"}) || !reflect.DeepEqual(stored.CarriedOver, []string{"bd-2"}) {
+		t.Errorf("stored = %+v, %v", stored, err)
+	}
+	if latest, _ := Latest(ctx, store); latest.Name != "s1" {
+		t.Errorf("Latest = %s, want the closed s1", latest.Name)
+	}
+
+	if err := Start(ctx, store, Sprint{Name: "s1", StartAt: day(19), EndAt: day(30)}); err == nil {
+		t.Error("reusing a sprint name should fail")
+	}
+	if err := Start(ctx, store, Sprint{Name: "s2", StartAt: day(19), EndAt: day(30)}); err != nil {
+		t.Fatal(err)
+	}
+	if latest, _ := Latest(ctx, store); latest.Name != "s2" {
+		t.Errorf("Latest = %s, want the active s2", latest.Name)
+	}
+	if _, err := Get(ctx, store, "s9"); !errors.Is(err, kvstorage.ErrKeyNotFound) {
+		t.Errorf("Get missing = %v", err)
+	}
+}
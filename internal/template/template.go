@@ -0,0 +1,122 @@
+// Package template provides helpers for managing issue templates in a KV table ("templates").
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/kvstorage"
+)
+
+// Template describes the issues bd create --template makes: an issue with
+// the template's fields and one child issue per checklist item.
+type Template struct {
+	Name        string                 `json:"name"`
+	Title       string                 `json:"title,omitempty"` // pattern; {{title}} is replaced by the given title
+	Type        issuestorage.IssueType `json:"type,omitempty"`
+	Priority    *issuestorage.Priority `json:"priority,omitempty"`
+	Labels      []string               `json:"labels,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Children    []string               `json:"children,omitempty"` // titles of the child issues
+	CreatedAt   time.Time              `json:"created_at"`
+}
+
+// TitlePlaceholder is replaced in a template's title pattern by the title
+// given when the template is used.
+const TitlePlaceholder = "{{title}}"
+
+// namePattern matches template names such as "bug-report" or "rfc_v2".
+// Names are used as KV keys, so they cannot contain separators.
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ValidateName returns an error if name cannot be used as a template name.
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid template name %q: use letters, digits, '.', '_' and '-', starting with a letter or digit", name)
+	}
+	return nil
+}
+
+// IssueTitle returns the title of an issue made from t with the given title:
+// the pattern with its placeholder replaced, the given title if there is
+// no pattern, or the pattern itself if it has no placeholder and no title
+// is given. Returns an error if the result would be empty.
+func (t Template) IssueTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	switch {
+	case t.Title == "":
+	case strings.Contains(t.Title, TitlePlaceholder):
+		if title == "" {
+			return "", fmt.Errorf("template %s needs a title for %s in %q", t.Name, TitlePlaceholder, t.Title)
+		}
+		title = strings.ReplaceAll(t.Title, TitlePlaceholder, title)
+	case title == "":
+		title = t.Title
+	}
+	if title == "" {
+		return "", fmt.Errorf("title is required (provide as argument or --title)")
+	}
+	return title, nil
+}
+
+// Get retrieves the template with the given name.
+// Returns kvstorage.ErrKeyNotFound if the template does not exist.
+func Get(ctx context.Context, store kvstorage.KVStore, name string) (Template, error) {
+	data, err := store.Get(ctx, name)
+	if err != nil {
+		if errors.Is(err, kvstorage.ErrKeyNotFound) {
+			return Template{}, kvstorage.ErrKeyNotFound
+		}
+		return Template{}, fmt.Errorf("getting template %s: %w", name, err)
+	}
+	var t Template
+	if err := json.Unmarshal(data, &t); err != nil {
+		return Template{}, fmt.Errorf("decoding template %s: %w", name, err)
+	}
+	return t, nil
+}
+
+// List returns every template, ordered by name.
+func List(ctx context.Context, store kvstorage.KVStore) ([]Template, error) {
+	names, err := store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing templates: %w", err)
+	}
+	sort.Strings(names)
+	templates := make([]Template, 0, len(names))
+	for _, name := range names {
+		t, err := Get(ctx, store, name)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+	return templates, nil
+}
+
+// Set stores t, failing if a template with the same name already exists
+// unless replace is true.
+func Set(ctx context.Context, store kvstorage.KVStore, t Template, replace bool) error {
+	if err := ValidateName(t.Name); err != nil {
+		return err
+	}
+	t.CreatedAt = t.CreatedAt.UTC()
+	data, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("encoding template %s: %w", t.Name, err)
+	}
+	if err := store.Set(ctx, t.Name, data, kvstorage.SetOptions{FailIfExists: !replace}); err != nil {
+		if errors.Is(err, kvstorage.ErrAlreadyExists) {
+			return fmt.Errorf("template %s already exists", t.Name)
+		}
+		return fmt.Errorf("storing template %s: %w", t.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/kvstorage"
+	kvfs "beads-lite/internal/kvstorage/filesystem"
+)
+
+func newTestStore(t *testing.T) *kvfs.Store {
+	t.Helper()
+	store, err := kvfs.New(t.TempDir(), "templates")
+	if err != nil {
+		t.Fatalf("failed to create kv store: %v", err)
+	}
+	if err := store.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init kv store: %v", err)
+	}
+	return store
+}
+
+func TestIssueTitle(t *testing.T) {
+	tests := []struct {
+		pattern, title, want string
+		wantErr              bool
+	}{
+		{"Bug: {{title}}", "Login fails", "Bug: Login fails", false},
+		{"Bug: {{title}}", "", "", true},
+		{"Weekly release", "", "Weekly release", false},
+		{"Weekly release", "Release 1.4", "Release 1.4", false},
+		{"", "Plain", "Plain", false},
+		{"", "  ", "", true},
+	}
+	for _, tt := range tests {
+		got, err := Template{Name: "t", Title: tt.pattern}.IssueTitle(tt.title)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("IssueTitle(%q, %q) = %q, %v; want %q", tt.pattern, tt.title, got, err, tt.want)
+		}
+	}
+}
+
+func TestSetGetList(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	high := issuestorage.PriorityHigh
+
+	bug := Template{
+		Name:        "bug-report",
+		Title:       "Bug: {{title}}",
+		Type:        issuestorage.TypeBug,
+		Priority:    &high,
+		Labels:      []string{"triage"},
+		Description: "Steps to reproduce:",
+		Children:    []string{"Reproduce", "Add a regression test"},
+		CreatedAt:   now,
+	}
+	if err := Set(ctx, store, bug, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := Set(ctx, store, Template{Name: "adr", CreatedAt: now}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := Set(ctx, store, Template{Name: "adr"}, false); err == nil {
+		t.Error("adding a duplicate template should fail")
+	}
+	if err := Set(ctx, store, Template{Name: "adr", Type: issuestorage.TypeDecision, CreatedAt: now}, true); err != nil {
+		t.Errorf("replacing a template: %v", err)
+	}
+	if err := Set(ctx, store, Template{Name: "bad/name"}, false); err == nil {
+		t.Error("an invalid name should be rejected")
+	}
+
+	got, err := Get(ctx, store, "bug-report")
+	if err != nil || !reflect.DeepEqual(got, bug) {
+		t.Errorf("Get = %+v, %v; want %+v", got, err, bug)
+	}
+	list, err := List(ctx, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].Name != "adr" || list[0].Type != issuestorage.TypeDecision || list[1].Name != "bug-report" {
+		t.Errorf("List = %+v", list)
+	}
+	if _, err := Get(ctx, store, "missing"); !errors.Is(err, kvstorage.ErrKeyNotFound) {
+		t.Errorf("Get missing = %v, want ErrKeyNotFound", err)
+	}
+}
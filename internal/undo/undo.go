@@ -0,0 +1,118 @@
+// Package undo provides helpers for the stack of undoable commands kept in a KV table ("undo").
+package undo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/kvstorage"
+)
+
+// MaxEntries is how many commands the stack keeps; pushing more drops the
+// oldest.
+const MaxEntries = 20
+
+// Entry is a command that changed issues, with what they were before.
+type Entry struct {
+	Seq     int       `json:"seq"`
+	Command string    `json:"command"`
+	At      time.Time `json:"at"`
+	Actor   string    `json:"actor,omitempty"`
+	Issues  []Image   `json:"issues"`
+}
+
+// Image is an issue a command changed. Before is the issue before the
+// command, or nil if the command created it. UpdatedAt is when the command
+// left it, zero if it deleted it, so later changes can be detected.
+type Image struct {
+	ID        string              `json:"id"`
+	Before    *issuestorage.Issue `json:"before,omitempty"`
+	UpdatedAt time.Time           `json:"updated_at,omitempty"`
+}
+
+// key is the KV key of the entry with sequence number seq, padded so keys
+// sort in push order.
+func key(seq int) string {
+	return fmt.Sprintf("%08d", seq)
+}
+
+// List returns the entries on the stack, the most recent first.
+func List(ctx context.Context, store kvstorage.KVStore) ([]Entry, error) {
+	keys, err := store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing undo stack: %w", err)
+	}
+	entries := make([]Entry, 0, len(keys))
+	for _, k := range keys {
+		if _, err := strconv.Atoi(k); err != nil {
+			continue
+		}
+		data, err := store.Get(ctx, k)
+		if errors.Is(err, kvstorage.ErrKeyNotFound) {
+			continue // dropped by another process's push
+		}
+		if err != nil {
+			return nil, fmt.Errorf("getting undo entry %s: %w", k, err)
+		}
+		var e Entry
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil, fmt.Errorf("decoding undo entry %s: %w", k, err)
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Seq > entries[j].Seq })
+	return entries, nil
+}
+
+// pushRetries is how many times Push renumbers an entry when another
+// process pushed one with the same number first.
+const pushRetries = 10
+
+// Push adds e to the top of the stack, numbering it after the entry
+// below, and drops entries beyond MaxEntries. It returns e as stored.
+func Push(ctx context.Context, store kvstorage.KVStore, e Entry) (Entry, error) {
+	e.At = e.At.UTC()
+	var entries []Entry
+	for attempt := 0; ; attempt++ {
+		var err error
+		entries, err = List(ctx, store)
+		if err != nil {
+			return Entry{}, err
+		}
+		e.Seq = 1
+		if len(entries) > 0 {
+			e.Seq = entries[0].Seq + 1
+		}
+		data, err := json.Marshal(e)
+		if err != nil {
+			return Entry{}, fmt.Errorf("encoding undo entry: %w", err)
+		}
+		err = store.Set(ctx, key(e.Seq), data, kvstorage.SetOptions{FailIfExists: true})
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, kvstorage.ErrAlreadyExists) || attempt == pushRetries {
+			return Entry{}, fmt.Errorf("storing undo entry: %w", err)
+		}
+	}
+	for i := MaxEntries - 1; i < len(entries); i++ {
+		if err := Remove(ctx, store, entries[i].Seq); err != nil {
+			return Entry{}, err
+		}
+	}
+	return e, nil
+}
+
+// Remove deletes the entry with sequence number seq.
+func Remove(ctx context.Context, store kvstorage.KVStore, seq int) error {
+	if err := store.Delete(ctx, key(seq)); err != nil && !errors.Is(err, kvstorage.ErrKeyNotFound) {
+		return fmt.Errorf("removing undo entry %d: %w", seq, err)
+	}
+	return nil
+}
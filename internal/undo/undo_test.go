@@ -0,0 +1,52 @@
+package undo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kvfs "beads-lite/internal/kvstorage/filesystem"
+)
+
+func TestPushListRemove(t *testing.T) {
+	store, err := kvfs.New(t.TempDir(), "undo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Init(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	for i := 0; i < MaxEntries+3; i++ {
+		e, err := Push(ctx, store, Entry{Command: "bd close", At: now, Issues: []Image{{ID: "bd-a1b2"}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Seq != i+1 {
+			t.Fatalf("push %d got seq %d", i, e.Seq)
+		}
+	}
+	entries, err := List(ctx, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != MaxEntries {
+		t.Fatalf("stack should keep %d entries, has %d", MaxEntries, len(entries))
+	}
+	if entries[0].Seq != MaxEntries+3 || entries[len(entries)-1].Seq != 4 {
+		t.Errorf("stack should hold the newest entries, newest first: %d..%d", entries[0].Seq, entries[len(entries)-1].Seq)
+	}
+
+	if err := Remove(ctx, store, entries[0].Seq); err != nil {
+		t.Fatal(err)
+	}
+	e, err := Push(ctx, store, Entry{Command: "bd update"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Seq != MaxEntries+3 {
+		t.Errorf("after removing the top, next seq = %d", e.Seq)
+	}
+}
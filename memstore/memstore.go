@@ -0,0 +1,43 @@
+// Package memstore is the public entry point for running beads-lite
+// entirely in memory from another Go program, with no .beads directory.
+//
+// New returns the raw storage backend; NewService wraps one in the issue
+// service the bd commands use, which adds dependency validation, status
+// side effects and history:
+//
+//	issues := memstore.NewService("app-")
+//	id, err := issues.Create(ctx, &issuestorage.Issue{Title: "Try it"})
+//
+// The types are aliases of beads-lite's internal ones, so values can be
+// passed anywhere the CLI's own code accepts them.
+package memstore
+
+import (
+	"beads-lite/internal/issueservice"
+	mem "beads-lite/internal/issuestorage/memstore"
+)
+
+// Store is an in-memory issuestorage.IssueStore, safe for concurrent use.
+type Store = mem.Store
+
+// Option configures a Store.
+type Option = mem.Option
+
+// Service is the issue service layered over a Store.
+type Service = issueservice.IssueStore
+
+// New returns an empty Store. The prefix is prepended to generated IDs
+// (e.g., "bd-").
+func New(prefix string, opts ...Option) *Store {
+	return mem.New(prefix, opts...)
+}
+
+// WithMaxHierarchyDepth sets the maximum hierarchy depth for child IDs.
+func WithMaxHierarchyDepth(n int) Option {
+	return mem.WithMaxHierarchyDepth(n)
+}
+
+// NewService returns the issue service over a new, empty Store.
+func NewService(prefix string, opts ...Option) *Service {
+	return issueservice.New(nil, New(prefix, opts...))
+}
@@ -0,0 +1,33 @@
+package memstore
+
+import (
+	"context"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestNewService(t *testing.T) {
+	ctx := context.Background()
+	issues := NewService("app-")
+
+	parent, err := issues.Create(ctx, &issuestorage.Issue{Title: "Epic", Type: issuestorage.TypeEpic})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	child, err := issues.Create(ctx, &issuestorage.Issue{Title: "Task"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := issues.AddDependency(ctx, child, parent, issuestorage.DepTypeParentChild); err != nil {
+		t.Fatalf("AddDependency: %v", err)
+	}
+
+	got, err := issues.Get(ctx, parent)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status != issuestorage.StatusOpen || len(got.Dependents) != 1 || got.Dependents[0].ID != child {
+		t.Errorf("parent = %+v, want open with child %s as a dependent", got, child)
+	}
+}
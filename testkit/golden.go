@@ -0,0 +1,217 @@
+package testkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// WriteSection writes a section header and content to the builder. Golden
+// output is a sequence of sections, compared one by one with CompareOutput.
+func WriteSection(out *strings.Builder, label string, content string) {
+	out.WriteString("=== ")
+	out.WriteString(label)
+	out.WriteString(" ===\n")
+	out.WriteString(content)
+	out.WriteString("\n\n")
+}
+
+// WriteExitCodeSection writes a section with just an exit code.
+func WriteExitCodeSection(out *strings.Builder, label string, exitCode int) {
+	WriteSection(out, label, fmt.Sprintf("EXIT_CODE: %d", exitCode))
+}
+
+// UpdateGolden writes actual to the golden file at path, creating its
+// directory if needed. header is written first; it is outside any section,
+// so it is ignored when comparing.
+func UpdateGolden(path, header, actual string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating golden dir: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(header+actual), 0644); err != nil {
+		return fmt.Errorf("writing golden file: %w", err)
+	}
+	return nil
+}
+
+// AssertGolden compares actual against the golden file at path with
+// CompareOutput, or rewrites the file when update is set.
+func AssertGolden(t testing.TB, path, actual string, update bool) {
+	t.Helper()
+	if update {
+		if err := UpdateGolden(path, "", actual); err != nil {
+			t.Fatal(err)
+		}
+		t.Logf("updated %s", path)
+		return
+	}
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("no expected file %q (run with update to generate): %v", path, err)
+	}
+	if diff := CompareOutput(string(expected), actual); diff != "" {
+		t.Errorf("output mismatch for %s:\n%s", path, diff)
+	}
+}
+
+// Section is a named section of test output (e.g., "=== create basic task ===\n{...}").
+type Section struct {
+	Name    string
+	Content string
+}
+
+// SplitSections parses golden output into named sections delimited by "=== name ===" headers.
+func SplitSections(s string) []Section {
+	var sections []Section
+	lines := strings.Split(s, "\n")
+	var current *Section
+	var contentLines []string
+
+	for _, line := range lines {
+		if strings.HasPrefix(line, "=== ") && strings.HasSuffix(line, " ===") {
+			if current != nil {
+				current.Content = strings.TrimSpace(strings.Join(contentLines, "\n"))
+				sections = append(sections, *current)
+			}
+			name := strings.TrimPrefix(line, "=== ")
+			name = strings.TrimSuffix(name, " ===")
+			current = &Section{Name: name}
+			contentLines = nil
+		} else if current != nil {
+			contentLines = append(contentLines, line)
+		}
+	}
+	if current != nil {
+		current.Content = strings.TrimSpace(strings.Join(contentLines, "\n"))
+		sections = append(sections, *current)
+	}
+
+	return sections
+}
+
+// CompareOutput compares expected and actual golden output section by section.
+// For JSON sections, it uses superset matching: the actual output may contain
+// extra fields not in the expected output, but every field in the expected
+// output must be present in the actual output with the same value.
+// For non-JSON sections (e.g., EXIT_CODE), it uses exact string comparison.
+// Returns an empty string if the outputs match, or a description of differences.
+func CompareOutput(expected, actual string) string {
+	expSections := SplitSections(expected)
+	actSections := SplitSections(actual)
+
+	var b strings.Builder
+
+	if len(expSections) != len(actSections) {
+		b.WriteString(fmt.Sprintf("section count mismatch: expected %d, got %d\n", len(expSections), len(actSections)))
+		b.WriteString(fmt.Sprintf("expected sections: %s\n", sectionNames(expSections)))
+		b.WriteString(fmt.Sprintf("actual sections:   %s\n", sectionNames(actSections)))
+	}
+
+	maxSections := len(expSections)
+	if len(actSections) < maxSections {
+		maxSections = len(actSections)
+	}
+
+	for i := 0; i < maxSections; i++ {
+		exp := expSections[i]
+		act := actSections[i]
+
+		if exp.Name != act.Name {
+			b.WriteString(fmt.Sprintf("section %d: expected %q, got %q\n", i, exp.Name, act.Name))
+			continue
+		}
+
+		// Try JSON superset comparison
+		var expJSON, actJSON interface{}
+		expErr := json.Unmarshal([]byte(exp.Content), &expJSON)
+		actErr := json.Unmarshal([]byte(act.Content), &actJSON)
+
+		if expErr == nil && actErr == nil {
+			if err := jsonSupersetMatch(expJSON, actJSON, ""); err != nil {
+				b.WriteString(fmt.Sprintf("section %q: %v\n", exp.Name, err))
+			}
+		} else {
+			// Plain text comparison (e.g., EXIT_CODE sections)
+			if exp.Content != act.Content {
+				b.WriteString(fmt.Sprintf("section %q:\n  expected: %q\n  actual:   %q\n", exp.Name, exp.Content, act.Content))
+			}
+		}
+	}
+
+	// Report any extra expected sections
+	for i := maxSections; i < len(expSections); i++ {
+		b.WriteString(fmt.Sprintf("missing section: %q\n", expSections[i].Name))
+	}
+	// Report any extra actual sections
+	for i := maxSections; i < len(actSections); i++ {
+		b.WriteString(fmt.Sprintf("extra section: %q\n", actSections[i].Name))
+	}
+
+	return b.String()
+}
+
+func sectionNames(sections []Section) string {
+	names := make([]string, len(sections))
+	for i, s := range sections {
+		names[i] = s.Name
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+// jsonSupersetMatch checks that actual is a superset of expected.
+// Every key/value in expected must exist in actual with the same value.
+// Extra keys in actual are allowed. Arrays must have the same length
+// and each element is compared with superset logic.
+func jsonSupersetMatch(expected, actual interface{}, path string) error {
+	switch exp := expected.(type) {
+	case map[string]interface{}:
+		act, ok := actual.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected object, got %T", pathOrRoot(path), actual)
+		}
+		for key, expVal := range exp {
+			childPath := path + "." + key
+			actVal, exists := act[key]
+			if !exists {
+				return fmt.Errorf("%s: missing field %q", pathOrRoot(path), key)
+			}
+			if err := jsonSupersetMatch(expVal, actVal, childPath); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case []interface{}:
+		act, ok := actual.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected array, got %T", pathOrRoot(path), actual)
+		}
+		if len(exp) != len(act) {
+			return fmt.Errorf("%s: array length %d, expected %d", pathOrRoot(path), len(act), len(exp))
+		}
+		for i := range exp {
+			childPath := fmt.Sprintf("%s[%d]", path, i)
+			if err := jsonSupersetMatch(exp[i], act[i], childPath); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		// Primitives: exact match
+		if fmt.Sprintf("%v", expected) != fmt.Sprintf("%v", actual) {
+			return fmt.Errorf("%s: expected %v, got %v", pathOrRoot(path), expected, actual)
+		}
+		return nil
+	}
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
@@ -1,4 +1,4 @@
-package e2etests
+package testkit
 
 import "encoding/json"
 
@@ -1,4 +1,4 @@
-package reference
+package testkit
 
 import (
 	"encoding/json"
@@ -88,7 +88,7 @@ func (n *Normalizer) NormalizeJSON(input []byte) string {
 	var data interface{}
 	if err := json.Unmarshal(input, &data); err != nil {
 		// Not valid JSON, normalize as plain text
-		return n.normalizeText(string(input))
+		return n.NormalizeText(string(input))
 	}
 
 	// Walk and normalize the data
@@ -97,7 +97,7 @@ func (n *Normalizer) NormalizeJSON(input []byte) string {
 	// Re-marshal with sorted keys and pretty-printing
 	output, err := json.MarshalIndent(normalized, "", "  ")
 	if err != nil {
-		return n.normalizeText(string(input))
+		return n.NormalizeText(string(input))
 	}
 
 	return string(output)
@@ -115,7 +115,7 @@ func (n *Normalizer) NormalizeJSONSorted(input []byte) string {
 
 	var data interface{}
 	if err := json.Unmarshal(input, &data); err != nil {
-		return n.normalizeText(string(input))
+		return n.NormalizeText(string(input))
 	}
 
 	// Sort top-level array by title BEFORE normalization so IDs are
@@ -138,7 +138,7 @@ func (n *Normalizer) NormalizeJSONSorted(input []byte) string {
 
 	output, err := json.MarshalIndent(normalized, "", "  ")
 	if err != nil {
-		return n.normalizeText(string(input))
+		return n.NormalizeText(string(input))
 	}
 
 	return string(output)
@@ -303,8 +303,8 @@ func (n *Normalizer) normalizeStringValue(s string) string {
 	return s
 }
 
-// normalizeText normalizes plain text (non-JSON) output.
-func (n *Normalizer) normalizeText(s string) string {
+// NormalizeText normalizes plain text (non-JSON) output.
+func (n *Normalizer) NormalizeText(s string) string {
 	if n.sandboxPath != "" {
 		s = strings.ReplaceAll(s, n.sandboxPath, "SANDBOX_PATH")
 	}
@@ -0,0 +1,214 @@
+package testkit
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// Runner executes bd commands against a sandbox directory.
+type Runner struct {
+	BdCmd       string   // path to bd binary
+	KillDaemons bool     // kill reference binary daemons between sandboxes
+	ExtraArgs   []string // extra args prepended to every command (e.g. --no-daemon)
+	ExtraEnv    []string // extra env vars for every command (e.g. "CLICOLOR_FORCE=1")
+
+	// Config is set with bd config set in every new sandbox, after the
+	// defaults below. Use it to point sandboxes at another storage backend
+	// or to enable an extension under test.
+	Config map[string]string
+}
+
+// sandboxDefaults is the config applied to every new sandbox. Auto-close
+// parent is off because the reference implementation doesn't have it, so
+// reference comparison tests need it off.
+var sandboxDefaults = []string{"graph.auto_close_parent", "false"}
+
+// SetupSandbox creates a fresh beads sandbox: a temporary git repo with
+// bd init run in it. Returns the sandbox path; remove it with
+// TeardownSandbox.
+func (r *Runner) SetupSandbox() (string, error) {
+	sandbox, err := os.MkdirTemp("", "beads-sandbox-")
+	if err != nil {
+		return "", fmt.Errorf("setup sandbox failed: %w", err)
+	}
+
+	// Initialize a git repo so the reference bd binary's daemon can start
+	// (it needs a repo fingerprint). Also needed for beads-lite's cwd discovery.
+	git := exec.Command("git", "init", "-q")
+	git.Dir = sandbox
+	if out, err := git.CombinedOutput(); err != nil {
+		os.RemoveAll(sandbox)
+		return "", fmt.Errorf("setup sandbox failed: git init: %v\n%s", err, out)
+	}
+
+	if res := r.Run(sandbox, "init"); res.ExitCode != 0 {
+		os.RemoveAll(sandbox)
+		return "", fmt.Errorf("setup sandbox failed: bd init (exit %d)\nstderr: %s", res.ExitCode, res.Stderr)
+	}
+
+	// The reference binary stores data at BEADS_DIR root (no .beads/
+	// subdirectory), but its "config set" looks for .beads/config.yaml.
+	// Ensure .beads/ exists with a copy of config.yaml so both the reference
+	// binary and beads-lite can find it.
+	dotBeads := filepath.Join(sandbox, ".beads")
+	if _, err := os.Stat(dotBeads); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Join(dotBeads, "formulas"), 0755); err != nil {
+			os.RemoveAll(sandbox)
+			return "", fmt.Errorf("setup sandbox failed: %w", err)
+		}
+		if data, err := os.ReadFile(filepath.Join(sandbox, "config.yaml")); err == nil {
+			os.WriteFile(filepath.Join(dotBeads, "config.yaml"), data, 0644)
+		}
+	}
+
+	settings := append([]string{}, sandboxDefaults...)
+	for _, key := range sortedKeys(r.Config) {
+		settings = append(settings, key, r.Config[key])
+	}
+	for i := 0; i < len(settings); i += 2 {
+		res := r.Run(sandbox, "config", "set", settings[i], settings[i+1])
+		if res.ExitCode != 0 && i >= len(sandboxDefaults) {
+			os.RemoveAll(sandbox)
+			return "", fmt.Errorf("setup sandbox failed: config set %s (exit %d)\nstderr: %s", settings[i], res.ExitCode, res.Stderr)
+		}
+	}
+
+	if r.KillDaemons {
+		r.KillAllDaemons(sandbox)
+	}
+
+	return sandbox, nil
+}
+
+// TeardownSandbox removes a sandbox directory.
+func (r *Runner) TeardownSandbox(path string) error {
+	if err := os.RemoveAll(path); err != nil {
+		return fmt.Errorf("teardown sandbox failed: %w", err)
+	}
+	return nil
+}
+
+// Sandbox creates a sandbox that is removed when t finishes, failing t if
+// it can't be set up.
+func (r *Runner) Sandbox(t testing.TB) string {
+	t.Helper()
+	sandbox, err := r.SetupSandbox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if r.KillDaemons {
+			r.KillAllDaemons(sandbox)
+		}
+		r.TeardownSandbox(sandbox)
+	})
+	return sandbox
+}
+
+// RunResult holds the output of a command execution.
+type RunResult struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+}
+
+// Run executes a bd command with the given arguments.
+// If sandbox is non-empty, BEADS_DIR is set and the working directory is changed
+// to the sandbox so the command finds the right data directory.
+// Pass an empty sandbox for commands that don't need one (e.g., --help).
+func (r *Runner) Run(sandbox string, args ...string) RunResult {
+	cmd := r.command(args)
+	if sandbox != "" {
+		cmd.Dir = sandbox
+		cmd.Env = append(cmd.Env, "BEADS_DIR="+sandbox)
+	}
+	return r.run(cmd)
+}
+
+// RunInDir executes a bd command in a specific directory without setting BEADS_DIR.
+// This lets bd discover the .beads directory by walking up from cwd.
+func (r *Runner) RunInDir(dir string, args ...string) RunResult {
+	cmd := r.command(args)
+	cmd.Dir = dir
+	return r.run(cmd)
+}
+
+// RunWithBeadsDir executes a bd command with BEADS_DIR set to the specified path.
+func (r *Runner) RunWithBeadsDir(beadsDir string, args ...string) RunResult {
+	cmd := r.command(args)
+	cmd.Env = append(cmd.Env, "BEADS_DIR="+beadsDir)
+	return r.run(cmd)
+}
+
+// command builds a bd invocation with the runner's extra args.
+func (r *Runner) command(args []string) *exec.Cmd {
+	allArgs := append(append([]string{}, r.ExtraArgs...), args...)
+	cmd := exec.Command(r.BdCmd, allArgs...)
+	cmd.Env = os.Environ()
+	return cmd
+}
+
+// run executes cmd with the runner's extra env and collects its output.
+func (r *Runner) run(cmd *exec.Cmd) RunResult {
+	cmd.Env = append(cmd.Env, r.ExtraEnv...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	exitCode := 0
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			exitCode = exitErr.ExitCode()
+		} else {
+			exitCode = -1
+		}
+	}
+
+	return RunResult{
+		Stdout:   stdout.String(),
+		Stderr:   stderr.String(),
+		ExitCode: exitCode,
+	}
+}
+
+// KillAllDaemons kills any running reference binary daemons.
+// Requires a valid sandbox path to provide a .beads/ workspace context.
+func (r *Runner) KillAllDaemons(sandbox string) {
+	cmd := exec.Command(r.BdCmd, "daemon", "killall")
+	cmd.Dir = sandbox
+	cmd.Env = append(os.Environ(), "BEADS_DIR="+sandbox)
+	cmd.Run() // best-effort
+}
+
+// SyncAndKillDaemons runs bd sync and then kills all daemons.
+// Useful for ensuring reference beads has flushed state before a benchmark phase.
+func (r *Runner) SyncAndKillDaemons(sandbox string) {
+	env := append(os.Environ(), "BEADS_DIR="+sandbox)
+
+	syncCmd := exec.Command(r.BdCmd, "sync")
+	syncCmd.Dir = sandbox
+	syncCmd.Env = env
+	syncCmd.Run() // best-effort
+
+	killCmd := exec.Command(r.BdCmd, "daemon", "killall")
+	killCmd.Dir = sandbox
+	killCmd.Env = env
+	killCmd.Run() // best-effort
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
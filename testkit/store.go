@@ -0,0 +1,59 @@
+// Package testkit is the end-to-end test harness used by beads-lite's own
+// e2e suites, packaged for extension authors and storage backend
+// implementers.
+//
+// It provides:
+//   - Runner: runs a bd binary against throwaway sandbox projects
+//   - Normalizer: replaces issue IDs, comment IDs, timestamps and the
+//     sandbox path with stable placeholders so output can be compared
+//   - golden files: WriteSection to build output, AssertGolden or
+//     CompareOutput to check it against an expected file
+//   - NewStore, NewMemStore and NewStoreOn: in-process sandbox stores for
+//     tests that don't need the binary
+//
+// A typical test:
+//
+//	r := &testkit.Runner{BdCmd: os.Getenv("BD_CMD")}
+//	sandbox := r.Sandbox(t)
+//	n := testkit.NewNormalizer()
+//	n.SetSandboxPath(sandbox)
+//	var out strings.Builder
+//	res := r.Run(sandbox, "create", "My task", "--json")
+//	testkit.WriteSection(&out, "create", n.NormalizeJSON([]byte(res.Stdout)))
+//	testkit.AssertGolden(t, "testdata/create.txt", out.String(), *update)
+package testkit
+
+import (
+	"context"
+	"testing"
+
+	"beads-lite/internal/issueservice"
+	"beads-lite/internal/issuestorage"
+	"beads-lite/internal/issuestorage/filesystem"
+	"beads-lite/internal/issuestorage/memstore"
+)
+
+// NewStore returns an issue service backed by filesystem storage in a
+// temporary directory removed when t finishes. IDs use the "bd-" prefix.
+func NewStore(t testing.TB) *issueservice.IssueStore {
+	t.Helper()
+	return NewStoreOn(t, filesystem.New(t.TempDir(), "bd-"))
+}
+
+// NewMemStore returns an issue service backed by in-memory storage, for
+// tests that never need to look at files. IDs use the "bd-" prefix.
+func NewMemStore(t testing.TB) *issueservice.IssueStore {
+	t.Helper()
+	return NewStoreOn(t, memstore.New("bd-"))
+}
+
+// NewStoreOn initializes backend and wraps it in the issue service, so
+// backend implementers can exercise their storage through the same layer
+// the CLI uses (status defaults, history, reopen counts, ...).
+func NewStoreOn(t testing.TB, backend issuestorage.IssueStore) *issueservice.IssueStore {
+	t.Helper()
+	if err := backend.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init storage: %v", err)
+	}
+	return issueservice.New(nil, backend)
+}
@@ -0,0 +1,159 @@
+package testkit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"beads-lite/internal/issuestorage"
+)
+
+func TestNormalizeJSON_StableIDs(t *testing.T) {
+	n := NewNormalizer()
+	first := n.NormalizeJSON([]byte(`{"id":"bd-a1b2","parent":"bd-c3d4","created_at":"2026-01-02T03:04:05Z"}`))
+	want := `{
+  "created_at": "TIMESTAMP",
+  "id": "ISSUE_1",
+  "parent": "ISSUE_2"
+}`
+	if first != want {
+		t.Errorf("NormalizeJSON =\n%s\nwant\n%s", first, want)
+	}
+	// The same ID maps to the same placeholder for the rest of the case.
+	if got := n.NormalizeText("closed bd-c3d4 and bd-e5f6"); got != "closed ISSUE_2 and ISSUE_3" {
+		t.Errorf("NormalizeText = %q", got)
+	}
+}
+
+func TestNormalizeText_SandboxPath(t *testing.T) {
+	dir := t.TempDir()
+	n := NewNormalizer()
+	n.SetSandboxPath(dir)
+	resolved, _ := filepath.EvalSymlinks(dir)
+	if got := n.NormalizeText("wrote " + resolved + "/.beads"); got != "wrote SANDBOX_PATH/.beads" {
+		t.Errorf("NormalizeText = %q", got)
+	}
+}
+
+func TestCompareOutput(t *testing.T) {
+	var expected, actual strings.Builder
+	WriteSection(&expected, "show", `{"id": "ISSUE_1", "title": "A"}`)
+	WriteExitCodeSection(&expected, "show missing", 1)
+	WriteSection(&actual, "show", `{"id": "ISSUE_1", "title": "A", "extra": true}`)
+	WriteExitCodeSection(&actual, "show missing", 1)
+
+	if diff := CompareOutput("# Generated by: test\n"+expected.String(), actual.String()); diff != "" {
+		t.Errorf("extra fields and header should be ignored, got diff:\n%s", diff)
+	}
+
+	var changed strings.Builder
+	WriteSection(&changed, "show", `{"id": "ISSUE_1", "title": "B"}`)
+	WriteExitCodeSection(&changed, "show missing", 0)
+	diff := CompareOutput(expected.String(), changed.String())
+	if !strings.Contains(diff, `.title: expected A, got B`) || !strings.Contains(diff, "EXIT_CODE: 0") {
+		t.Errorf("diff should report title and exit code, got:\n%s", diff)
+	}
+}
+
+func TestAssertGolden_UpdateThenCompare(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "expected", "case.txt")
+	var out strings.Builder
+	WriteSection(&out, "create", `{"id": "ISSUE_1"}`)
+
+	AssertGolden(t, path, out.String(), true)
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("golden file not written: %v", err)
+	}
+	AssertGolden(t, path, out.String(), false)
+}
+
+func TestNewStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore(t)
+	id, err := store.Create(ctx, &issuestorage.Issue{Title: "Sandboxed"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if !strings.HasPrefix(id, "bd-") {
+		t.Errorf("ID = %q, want bd- prefix", id)
+	}
+	got, err := store.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.Status != issuestorage.StatusOpen {
+		t.Errorf("Status = %q, want open", got.Status)
+	}
+}
+
+func TestNewMemStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemStore(t)
+	id, err := store.Create(ctx, &issuestorage.Issue{Title: "In memory"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := store.Modify(ctx, id, func(i *issuestorage.Issue) error {
+		i.Status = issuestorage.StatusClosed
+		return nil
+	}); err != nil {
+		t.Fatalf("Modify failed: %v", err)
+	}
+	got, err := store.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.ClosedAt == nil {
+		t.Error("expected the service to stamp ClosedAt")
+	}
+}
+
+func TestRunnerSandbox(t *testing.T) {
+	bdCmd := os.Getenv("BD_CMD")
+	if bdCmd == "" {
+		t.Skip("BD_CMD environment variable not set")
+	}
+	r := &Runner{BdCmd: bdCmd, Config: map[string]string{"issue_prefix": "kit"}}
+	sandbox := r.Sandbox(t)
+
+	res := r.Run(sandbox, "config", "get", "issue_prefix")
+	if res.ExitCode != 0 || !strings.Contains(res.Stdout, "kit") {
+		t.Fatalf("config get issue_prefix = %q (exit %d): %s", res.Stdout, res.ExitCode, res.Stderr)
+	}
+	res = r.Run(sandbox, "create", "From testkit", "--json")
+	if res.ExitCode != 0 {
+		t.Fatalf("create failed (exit %d): %s", res.ExitCode, res.Stderr)
+	}
+	if id := ExtractID([]byte(res.Stdout)); id == "" {
+		t.Errorf("no ID in create output: %s", res.Stdout)
+	}
+}
+
+func TestRunnerDeterministic(t *testing.T) {
+	bdCmd := os.Getenv("BD_CMD")
+	if bdCmd == "" {
+		t.Skip("BD_CMD environment variable not set")
+	}
+	// With BD_SEED, raw output matches across sandboxes without a Normalizer.
+	transcript := func() string {
+		r := &Runner{BdCmd: bdCmd, ExtraEnv: []string{"BD_SEED=9"}, Config: map[string]string{"issue_prefix": "kit"}}
+		sandbox := r.Sandbox(t)
+		var out strings.Builder
+		res := r.Run(sandbox, "create", "Seeded", "--json")
+		id := ExtractID([]byte(res.Stdout))
+		WriteSection(&out, "create", res.Stdout)
+		r.Run(sandbox, "comments", "add", id, "note")
+		r.Run(sandbox, "close", id)
+		WriteSection(&out, "show", r.Run(sandbox, "show", id, "--json").Stdout)
+		return out.String()
+	}
+	first, second := transcript(), transcript()
+	if first != second {
+		t.Errorf("seeded sandboxes differ:\n%s\n---\n%s", first, second)
+	}
+	if !strings.Contains(first, "2025-01-01T00:00:") {
+		t.Errorf("expected fake-clock timestamps, got:\n%s", first)
+	}
+}